@@ -3,6 +3,7 @@ package cmdargs
 import (
 	"errors"
 	"fmt"
+	"os"
 	"strings"
 
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -13,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -22,6 +24,10 @@ const (
 	ArrayStartSeparator = "["
 	// ArrayEndSeparator marks the end of array cli arg.
 	ArrayEndSeparator = "]"
+	// MapStartSeparator marks the start of map cli arg.
+	MapStartSeparator = "{"
+	// MapEndSeparator marks the end of map cli arg.
+	MapEndSeparator = "}"
 )
 
 const (
@@ -31,9 +37,11 @@ const (
    manually use "type:value" syntax where the type is one of the following:
    'signature', 'bool', 'int', 'hash160', 'hash256', 'bytes', 'key' or 'string'.
    Array types are also supported: use special space-separated '[' and ']'
-   symbols around array values to denote array bounds. Nested arrays are also
-   supported. Null parameter is supported via 'nil' keyword without additional
-   type specification.
+   symbols around array values to denote array bounds. Maps are specified in
+   the same way using space-separated '{' and '}' symbols around interleaved
+   keys and values (keys can't be arrays, maps or nulls). Nested arrays and maps
+   are also supported. Null parameter is supported via 'nil' keyword without
+   additional type specification.
 
    There is ability to provide an argument of 'bytearray' type via file. Use a
    special 'filebytes' argument type for this with a filepath specified after
@@ -95,7 +103,36 @@ const (
     * '[ a b c ]' is an array with strings values 'a', 'b' and 'c'
     * '[ a b [ c d ] e ]' is an array with 4 values: string 'a', string 'b',
       array of two strings 'c' and 'd', string 'e'
-    * '[ ]' is an empty array`
+    * '[ ]' is an empty array
+    * '{ a 1 b [ 2 3 ] }' is a map with two keys: string 'a' with integer 1
+      value and string 'b' with an array of two integers 2 and 3 value
+    * '[ [ NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM 42 ] [ nil 0 ] ]' is an array of
+      two structures (arrays) with hash160 and integer fields
+
+   Parameters that can't be parsed are reported with their path, e.g. '#2[1]'
+   is the second element of the second parameter (which is an array) and
+   '#1{0}.key' is the key of the first pair of the first parameter (which is a
+   map).`
+
+	// ParamsFileDoc is a documentation for parameters file format.
+	ParamsFileDoc = `   Parameters can also be provided via YAML file with --params-file flag. The
+   file must contain a sequence of parameters, every scalar value is parsed
+   the same way as the command-line argument (so 'type:value' syntax can be
+   used), YAML nulls are Any type parameters, sequences are arrays and
+   mappings are maps. Signers can be specified after '--' in this case as
+   usual. Example:
+
+     - NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM
+     - int:42
+     - - [NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM, 1]
+       - [NbTiM6h8r99kpRtb428XcsUk1TzKed2gTc, 2]
+     - {name: string:token, data: null}
+
+   When the target contract's manifest is available from the RPC node, types
+   of implicitly typed top-level parameters (both from arguments and from the
+   file) are taken from the method's parameters description instead of being
+   inferred from values (so '42' is passed as a string to a method accepting
+   a string).`
 
 	// SignersParsingDoc is a documentation for signers parsing.
 	SignersParsingDoc = `   Signers represent a set of Uint160 hashes with witness scopes and are used
@@ -271,6 +308,23 @@ func EnsureNone(ctx *cli.Context) *cli.ExitError {
 // recursively and used to check if CosignersSeparator and ArrayEndSeparator are
 // allowed to be in `args` sequence.
 func ParseParams(args []string, calledFromMain bool) (int, []smartcontract.Parameter, error) {
+	return ParseParamsWithTypes(args, calledFromMain, nil)
+}
+
+// ParseParamsWithTypes is similar to ParseParams, but uses the given types
+// (usually taken from the contract manifest) for implicitly typed top-level
+// parameters instead of inferring them from values. Types of nested array and
+// map elements are always inferred.
+func ParseParamsWithTypes(args []string, calledFromMain bool, types []smartcontract.ParamType) (int, []smartcontract.Parameter, error) {
+	return parseParams(args, calledFromMain, ArrayEndSeparator, types, func(i int) string {
+		return fmt.Sprintf("#%d", i+1)
+	})
+}
+
+// parseParams parses parameters from args up to the given end separator (or
+// up to the end of args/CosignersSeparator if calledFromMain is set). path
+// returns the path of the i-th parsed element to be used in error messages.
+func parseParams(args []string, calledFromMain bool, end string, types []smartcontract.ParamType, path func(int) string) (int, []smartcontract.Parameter, error) {
 	res := []smartcontract.Parameter{}
 	for k := 0; k < len(args); {
 		s := args[k]
@@ -279,36 +333,40 @@ func ParseParams(args []string, calledFromMain bool) (int, []smartcontract.Param
 			if calledFromMain {
 				return k + 1, res, nil // `1` to convert index to numWordsRead
 			}
-			return 0, []smartcontract.Parameter{}, errors.New("invalid array syntax: missing closing bracket")
-		case ArrayStartSeparator:
-			numWordsRead, array, err := ParseParams(args[k+1:], false)
+			return 0, []smartcontract.Parameter{}, syntaxError(end, "missing closing")
+		case ArrayStartSeparator, MapStartSeparator:
+			numWordsRead, param, err := parseCompound(args[k+1:], s, path(len(res)))
 			if err != nil {
-				return 0, nil, fmt.Errorf("failed to parse array: %w", err)
+				return 0, nil, err
 			}
-			res = append(res, smartcontract.Parameter{
-				Type:  smartcontract.ArrayType,
-				Value: array,
-			})
+			res = append(res, param)
 			k += 1 + numWordsRead // `1` for opening bracket
-		case ArrayEndSeparator:
+		case ArrayEndSeparator, MapEndSeparator:
 			if calledFromMain {
-				return 0, nil, errors.New("invalid array syntax: missing opening bracket")
+				return 0, nil, syntaxError(s, "missing opening")
+			}
+			if s != end {
+				return 0, nil, syntaxError(end, fmt.Sprintf("unexpected '%s' instead of closing", s))
 			}
 			return k + 1, res, nil // `1`to convert index to numWordsRead
 		default:
-			param, err := smartcontract.NewParameterFromString(s)
+			typ := smartcontract.AnyType
+			if len(res) < len(types) {
+				typ = types[len(res)]
+			}
+			param, err := smartcontract.NewParameterFromStringWithType(s, typ)
 			if err != nil {
 				// '--' argument is skipped by urfave/cli library, which leads
 				// to [--, addr:scope] being transformed to [addr:scope] and
 				// interpreted as a parameter if other positional arguments are not present.
 				// Here we fallback to parsing cosigners in this specific case to
 				// create a better user experience ('-- addr:scope' vs '-- -- addr:scope').
-				if k == 0 {
+				if k == 0 && calledFromMain {
 					if _, err := parseCosigner(s); err == nil {
 						return 0, nil, nil
 					}
 				}
-				return 0, nil, fmt.Errorf("failed to parse argument #%d: %w", k+1, err)
+				return 0, nil, fmt.Errorf("failed to parse argument %s: %w", path(len(res)), err)
 			}
 			res = append(res, *param)
 			k++
@@ -317,7 +375,183 @@ func ParseParams(args []string, calledFromMain bool) (int, []smartcontract.Param
 	if calledFromMain {
 		return len(args), res, nil
 	}
-	return 0, []smartcontract.Parameter{}, errors.New("invalid array syntax: missing closing bracket")
+	return 0, []smartcontract.Parameter{}, syntaxError(end, "missing closing")
+}
+
+// parseCompound parses array or map (depending on the given opening
+// separator) elements from args and returns the number of handled words
+// (including the closing separator) and the resulting parameter.
+func parseCompound(args []string, start string, path string) (int, smartcontract.Parameter, error) {
+	if start == ArrayStartSeparator {
+		numWordsRead, arr, err := parseParams(args, false, ArrayEndSeparator, nil, func(i int) string {
+			return fmt.Sprintf("%s[%d]", path, i)
+		})
+		if err != nil {
+			return 0, smartcontract.Parameter{}, err
+		}
+		return numWordsRead, smartcontract.Parameter{
+			Type:  smartcontract.ArrayType,
+			Value: arr,
+		}, nil
+	}
+	numWordsRead, elems, err := parseParams(args, false, MapEndSeparator, nil, func(i int) string {
+		return mapElementPath(path, i)
+	})
+	if err != nil {
+		return 0, smartcontract.Parameter{}, err
+	}
+	if len(elems)%2 != 0 {
+		return 0, smartcontract.Parameter{}, fmt.Errorf("invalid map %s: missing value for the last key", path)
+	}
+	pairs := make([]smartcontract.ParameterPair, 0, len(elems)/2)
+	for i := 0; i < len(elems); i += 2 {
+		if err := checkMapKey(elems[i], mapElementPath(path, i)); err != nil {
+			return 0, smartcontract.Parameter{}, err
+		}
+		pairs = append(pairs, smartcontract.ParameterPair{
+			Key:   elems[i],
+			Value: elems[i+1],
+		})
+	}
+	return numWordsRead, smartcontract.Parameter{
+		Type:  smartcontract.MapType,
+		Value: pairs,
+	}, nil
+}
+
+// mapElementPath returns the path of the i-th element of the map with the
+// given path assuming that keys and values are interleaved.
+func mapElementPath(path string, i int) string {
+	if i%2 == 0 {
+		return fmt.Sprintf("%s{%d}.key", path, i/2)
+	}
+	return fmt.Sprintf("%s{%d}.value", path, i/2)
+}
+
+// checkMapKey checks that the given parameter can be used as a map key.
+func checkMapKey(key smartcontract.Parameter, path string) error {
+	switch key.Type {
+	case smartcontract.ArrayType, smartcontract.MapType, smartcontract.AnyType:
+		return fmt.Errorf("invalid map key %s: %s can't be used as a key", path, key.Type)
+	default:
+		return nil
+	}
+}
+
+// syntaxError returns an array or map (depending on the given separator)
+// syntax error with the given description.
+func syntaxError(separator string, desc string) error {
+	if separator == ArrayStartSeparator || separator == ArrayEndSeparator {
+		return fmt.Errorf("invalid array syntax: %s bracket", desc)
+	}
+	return fmt.Errorf("invalid map syntax: %s brace", desc)
+}
+
+// ParseParamsFile reads parameters from the given YAML file, see
+// ParseParamsYAML for details.
+func ParseParamsFile(path string, types []smartcontract.ParamType) ([]smartcontract.Parameter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read parameters file: %w", err)
+	}
+	return ParseParamsYAML(data, types)
+}
+
+// ParseParamsYAML extracts array of smartcontract.Parameter from the given YAML
+// document. The document must be a sequence of parameters where every scalar
+// value is parsed the same way as a command-line argument (so "type:value"
+// syntax can be used), null values are converted to Any type parameters,
+// sequences are converted to arrays and mappings to maps. Types are used for
+// implicitly typed top-level parameters the same way ParseParamsWithTypes does.
+func ParseParamsYAML(data []byte, types []smartcontract.ParamType) ([]smartcontract.Parameter, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("invalid parameters YAML: %w", err)
+	}
+	res := []smartcontract.Parameter{}
+	if len(doc.Content) == 0 {
+		return res, nil // Empty document.
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("parameters must be a YAML sequence (line %d)", root.Line)
+	}
+	for i, n := range root.Content {
+		typ := smartcontract.AnyType
+		if i < len(types) {
+			typ = types[i]
+		}
+		param, err := paramFromYAML(n, typ, fmt.Sprintf("#%d", i+1))
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, param)
+	}
+	return res, nil
+}
+
+// ParseParamYAML converts the given YAML node to a parameter the same way
+// ParseParamsYAML does for every element of the parameters sequence. Name is
+// used in error messages to identify the parameter.
+func ParseParamYAML(n *yaml.Node, name string) (smartcontract.Parameter, error) {
+	return paramFromYAML(n, smartcontract.AnyType, name)
+}
+
+// paramFromYAML converts the given YAML node with the given path to a
+// parameter using typ for implicitly typed scalar values.
+func paramFromYAML(n *yaml.Node, typ smartcontract.ParamType, path string) (smartcontract.Parameter, error) {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Tag == "!!null" {
+			return smartcontract.NewParameter(smartcontract.AnyType), nil
+		}
+		param, err := smartcontract.NewParameterFromStringWithType(n.Value, typ)
+		if err != nil {
+			return smartcontract.Parameter{}, fmt.Errorf("failed to parse argument %s (line %d): %w", path, n.Line, err)
+		}
+		return *param, nil
+	case yaml.SequenceNode:
+		arr := make([]smartcontract.Parameter, 0, len(n.Content))
+		for i, e := range n.Content {
+			param, err := paramFromYAML(e, smartcontract.AnyType, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return smartcontract.Parameter{}, err
+			}
+			arr = append(arr, param)
+		}
+		return smartcontract.Parameter{
+			Type:  smartcontract.ArrayType,
+			Value: arr,
+		}, nil
+	case yaml.MappingNode:
+		pairs := make([]smartcontract.ParameterPair, 0, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			keyPath := mapElementPath(path, i)
+			key, err := paramFromYAML(n.Content[i], smartcontract.AnyType, keyPath)
+			if err != nil {
+				return smartcontract.Parameter{}, err
+			}
+			if err := checkMapKey(key, keyPath); err != nil {
+				return smartcontract.Parameter{}, fmt.Errorf("%w (line %d)", err, n.Content[i].Line)
+			}
+			val, err := paramFromYAML(n.Content[i+1], smartcontract.AnyType, mapElementPath(path, i+1))
+			if err != nil {
+				return smartcontract.Parameter{}, err
+			}
+			pairs = append(pairs, smartcontract.ParameterPair{
+				Key:   key,
+				Value: val,
+			})
+		}
+		return smartcontract.Parameter{
+			Type:  smartcontract.MapType,
+			Value: pairs,
+		}, nil
+	case yaml.AliasNode:
+		return paramFromYAML(n.Alias, typ, path)
+	default:
+		return smartcontract.Parameter{}, fmt.Errorf("unsupported YAML node for argument %s (line %d)", path, n.Line)
+	}
 }
 
 // GetSignersAccounts returns the list of signers combined with the corresponding
@@ -2,12 +2,14 @@ package cmdargs
 
 import (
 	"encoding/hex"
+	"math/big"
 	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
@@ -378,3 +380,173 @@ func TestParseParams_CalledFromOutside(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestParseParams_Maps(t *testing.T) {
+	offset, actual, err := ParseParams(strings.Split("{ a 1 b [ 2 { c 3 } ] } { } -- cosigner1", " "), true)
+	require.NoError(t, err)
+	require.Equal(t, 15, offset)
+	require.Equal(t, []smartcontract.Parameter{
+		{
+			Type: smartcontract.MapType,
+			Value: []smartcontract.ParameterPair{
+				{
+					Key:   smartcontract.Parameter{Type: smartcontract.StringType, Value: "a"},
+					Value: smartcontract.Parameter{Type: smartcontract.IntegerType, Value: big.NewInt(1)},
+				},
+				{
+					Key: smartcontract.Parameter{Type: smartcontract.StringType, Value: "b"},
+					Value: smartcontract.Parameter{
+						Type: smartcontract.ArrayType,
+						Value: []smartcontract.Parameter{
+							{Type: smartcontract.IntegerType, Value: big.NewInt(2)},
+							{
+								Type: smartcontract.MapType,
+								Value: []smartcontract.ParameterPair{
+									{
+										Key:   smartcontract.Parameter{Type: smartcontract.StringType, Value: "c"},
+										Value: smartcontract.Parameter{Type: smartcontract.IntegerType, Value: big.NewInt(3)},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			Type:  smartcontract.MapType,
+			Value: []smartcontract.ParameterPair{},
+		},
+	}, actual)
+
+	errorCases := map[string]string{
+		"{":                   "invalid map syntax: missing closing brace",
+		"}":                   "invalid map syntax: missing opening brace",
+		"{ a }":               "invalid map #1: missing value for the last key",
+		"{ a 1 ]":             "invalid map syntax: unexpected ']' instead of closing brace",
+		"[ a }":               "invalid array syntax: unexpected '}' instead of closing bracket",
+		"{ [ a ] 1 }":         "invalid map key #1{0}.key: Array can't be used as a key",
+		"{ nil 1 }":           "invalid map key #1{0}.key: Any can't be used as a key",
+		"a [ b { c int:d } ]": "failed to parse argument #2[1]{0}.value: invalid integer value",
+		"a [ b [ bool:c ] ]":  "failed to parse argument #2[1][0]: invalid boolean value",
+	}
+	for str, msg := range errorCases {
+		_, _, err := ParseParams(strings.Split(str, " "), true)
+		require.ErrorContains(t, err, msg, str)
+	}
+}
+
+func TestParseParamsWithTypes(t *testing.T) {
+	addr := "NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM"
+	u, err := address.StringToUint160(addr)
+	require.NoError(t, err)
+	types := []smartcontract.ParamType{smartcontract.StringType, smartcontract.Hash160Type, smartcontract.ArrayType, smartcontract.StringType}
+	_, actual, err := ParseParamsWithTypes(strings.Split("42 "+addr+" [ 42 ] int:42 43", " "), true, types)
+	require.NoError(t, err)
+	require.Equal(t, []smartcontract.Parameter{
+		{Type: smartcontract.StringType, Value: "42"},
+		{Type: smartcontract.Hash160Type, Value: u},
+		{
+			Type:  smartcontract.ArrayType,
+			Value: []smartcontract.Parameter{{Type: smartcontract.IntegerType, Value: big.NewInt(42)}},
+		},
+		{Type: smartcontract.IntegerType, Value: big.NewInt(42)}, // Explicit type is preferred.
+		{Type: smartcontract.IntegerType, Value: big.NewInt(43)}, // No type given.
+	}, actual)
+
+	_, _, err = ParseParamsWithTypes([]string{"a", "b"}, true, []smartcontract.ParamType{smartcontract.StringType, smartcontract.Hash160Type})
+	require.ErrorContains(t, err, "failed to parse argument #2")
+}
+
+func TestParseParamsYAML(t *testing.T) {
+	addr := "NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM"
+	u, err := address.StringToUint160(addr)
+	require.NoError(t, err)
+	data := `
+- ` + addr + `
+- int:42
+- - [` + addr + `, 1]
+  - [42, string:x]
+- {name: "string:token", data: null, 7: [true]}
+- 42
+- null
+- []
+`
+	expected := []smartcontract.Parameter{
+		{Type: smartcontract.Hash160Type, Value: u},
+		{Type: smartcontract.IntegerType, Value: big.NewInt(42)},
+		{
+			Type: smartcontract.ArrayType,
+			Value: []smartcontract.Parameter{
+				{
+					Type: smartcontract.ArrayType,
+					Value: []smartcontract.Parameter{
+						{Type: smartcontract.Hash160Type, Value: u},
+						{Type: smartcontract.IntegerType, Value: big.NewInt(1)},
+					},
+				},
+				{
+					Type: smartcontract.ArrayType,
+					Value: []smartcontract.Parameter{
+						{Type: smartcontract.IntegerType, Value: big.NewInt(42)},
+						{Type: smartcontract.StringType, Value: "x"},
+					},
+				},
+			},
+		},
+		{
+			Type: smartcontract.MapType,
+			Value: []smartcontract.ParameterPair{
+				{
+					Key:   smartcontract.Parameter{Type: smartcontract.StringType, Value: "name"},
+					Value: smartcontract.Parameter{Type: smartcontract.StringType, Value: "token"},
+				},
+				{
+					Key:   smartcontract.Parameter{Type: smartcontract.StringType, Value: "data"},
+					Value: smartcontract.Parameter{Type: smartcontract.AnyType},
+				},
+				{
+					Key: smartcontract.Parameter{Type: smartcontract.IntegerType, Value: big.NewInt(7)},
+					Value: smartcontract.Parameter{
+						Type:  smartcontract.ArrayType,
+						Value: []smartcontract.Parameter{{Type: smartcontract.BoolType, Value: true}},
+					},
+				},
+			},
+		},
+		{Type: smartcontract.IntegerType, Value: big.NewInt(42)},
+		{Type: smartcontract.AnyType},
+		{Type: smartcontract.ArrayType, Value: []smartcontract.Parameter{}},
+	}
+	actual, err := ParseParamsYAML([]byte(data), nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	// Types affect top-level parameters only.
+	actual, err = ParseParamsYAML([]byte(data), []smartcontract.ParamType{smartcontract.StringType,
+		smartcontract.IntegerType, smartcontract.ArrayType, smartcontract.MapType, smartcontract.StringType})
+	require.NoError(t, err)
+	expected[0] = smartcontract.Parameter{Type: smartcontract.StringType, Value: addr}
+	expected[4] = smartcontract.Parameter{Type: smartcontract.StringType, Value: "42"}
+	require.Equal(t, expected, actual)
+
+	actual, err = ParseParamsYAML([]byte(""), nil)
+	require.NoError(t, err)
+	require.Equal(t, []smartcontract.Parameter{}, actual)
+
+	errorCases := map[string]string{
+		"a: b":                   "parameters must be a YAML sequence (line 1)",
+		"- [":                    "invalid parameters YAML",
+		"- a\n- [1, bool:x]":     "failed to parse argument #2[1] (line 2): invalid boolean value",
+		"- a\n- {a: int:x}":      "failed to parse argument #2{0}.value (line 2): invalid integer value",
+		"- a\n- {[1]: 2}":        "invalid map key #2{0}.key: Array can't be used as a key (line 2)",
+		"- a\n- - b\n  - {~: 2}": "invalid map key #2[1]{0}.key: Any can't be used as a key (line 3)",
+	}
+	for data, msg := range errorCases {
+		_, err := ParseParamsYAML([]byte(data), nil)
+		require.ErrorContains(t, err, msg, data)
+	}
+
+	_, err = ParseParamsFile("./testdata/missing.yml", nil)
+	require.Error(t, err)
+}
@@ -141,6 +141,15 @@ func TestNEP17Transfer(t *testing.T) {
 		e.In.Reset()
 	})
 
+	t.Run("precision loss", func(t *testing.T) {
+		as := append([]string{}, args[:13]...)
+		as = append(as, "1.5")
+		as = append(as, args[14:]...)
+		e.In.WriteString("one\r")
+		e.RunWithErrorCheck(t, "invalid amount: precision loss", as...)
+		e.In.Reset()
+	})
+
 	t.Run("InvalidPassword", func(t *testing.T) {
 		e.In.WriteString("onetwothree\r")
 		e.RunWithError(t, args...)
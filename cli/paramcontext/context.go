@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
@@ -15,10 +16,9 @@ import (
 // as an input to `multisig sign`. If a wallet.Account is given and can sign,
 // it's signed as well using it.
 func InitAndSave(net netmode.Magic, tx *transaction.Transaction, acc *wallet.Account, filename string) error {
-	scCtx := context.NewParameterContext(context.TransactionType, net, tx)
+	scCtx := context.NewTransactionContext(net, tx)
 	if acc != nil && acc.CanSign() {
-		sign := acc.SignHashable(net, tx)
-		if err := scCtx.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
+		if err := scCtx.Sign(acc); err != nil {
 			return fmt.Errorf("can't add signature: %w", err)
 		}
 	}
@@ -48,3 +48,54 @@ func Save(c *context.ParameterContext, filename string) error {
 	}
 	return nil
 }
+
+// ReadCompact reads the parameter context from the file containing compact
+// container text chunks (one per line, in any order).
+func ReadCompact(filename string) (*context.ParameterContext, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("can't read input file: %w", err)
+	}
+	return ParseCompact(string(data))
+}
+
+// ParseCompact parses the parameter context from compact container text
+// chunks (one per line, in any order).
+func ParseCompact(s string) (*context.ParameterContext, error) {
+	bin, err := context.DecodeCompactChunks(strings.Split(s, "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("can't decode container: %w", err)
+	}
+	c := new(context.ParameterContext)
+	if err := c.UnmarshalCompact(bin); err != nil {
+		return nil, fmt.Errorf("can't parse container: %w", err)
+	}
+	return c, nil
+}
+
+// FormatCompact returns the parameter context as compact container text
+// chunks (one per line) of the given size (unlimited if not positive).
+func FormatCompact(c *context.ParameterContext, chunkSize int) (string, error) {
+	bin, err := c.MarshalCompact()
+	if err != nil {
+		return "", fmt.Errorf("can't marshal transaction: %w", err)
+	}
+	chunks, err := context.EncodeCompactChunks(bin, chunkSize)
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(chunks, "\n") + "\n", nil
+}
+
+// SaveCompact writes the parameter context to the file as compact container
+// text chunks (one per line) of the given size (unlimited if not positive).
+func SaveCompact(c *context.ParameterContext, filename string, chunkSize int) error {
+	s, err := FormatCompact(c, chunkSize)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, []byte(s), 0644); err != nil {
+		return fmt.Errorf("can't write transaction to file: %w", err)
+	}
+	return nil
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"sort"
 	"strconv"
 	"strings"
@@ -18,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/accountinfo"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -34,10 +37,23 @@ func NewCommands() []cli.Command {
 			Usage: "Output full tx info and execution logs",
 		},
 	}, options.RPC...)
+	queryAccountFlags := append([]cli.Flag{
+		cli.BoolFlag{
+			Name:  "json",
+			Usage: "Output account data in JSON format",
+		},
+	}, options.RPC...)
 	return []cli.Command{{
 		Name:  "query",
 		Usage: "Query data from RPC node",
 		Subcommands: []cli.Command{
+			{
+				Name:      "account",
+				Usage:     "Print aggregated account information",
+				UsageText: "neo-go query account <address> -r endpoint [-s timeout] [--json]",
+				Action:    queryAccount,
+				Flags:     queryAccountFlags,
+			},
 			{
 				Name:      "candidates",
 				Usage:     "Get candidates and votes",
@@ -77,6 +93,86 @@ func NewCommands() []cli.Command {
 	}}
 }
 
+func queryAccount(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) == 0 {
+		return cli.NewExitError("No address specified", 1)
+	} else if len(args) > 1 {
+		return cli.NewExitError("this command only accepts one address", 1)
+	}
+
+	addr, err := flags.ParseAddress(args[0])
+	if err != nil {
+		return cli.NewExitError(fmt.Sprintf("wrong address: %s", args[0]), 1)
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+
+	info, err := accountinfo.Get(c, addr)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if ctx.Bool("json") {
+		b, err := json.MarshalIndent(info, "", "  ")
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(b))
+		return nil
+	}
+	return dumpAccountInfo(ctx, info)
+}
+
+func dumpAccountInfo(ctx *cli.Context, info *accountinfo.Info) error {
+	var (
+		res   []byte
+		voted = "null"
+	)
+	if info.NEO.VoteTo != nil {
+		voted = hex.EncodeToString(info.NEO.VoteTo.Bytes())
+	}
+	res = fmt.Appendf(res, "Address:\t%s\n", address.Uint160ToString(info.Account))
+	res = fmt.Appendf(res, "NEO:\t%s (block %d)\n", info.NEO.Balance, info.NEO.BalanceHeight)
+	res = fmt.Appendf(res, "Voted:\t%s\n", voted)
+	res = fmt.Appendf(res, "Unclaimed GAS:\t%s\n", fixedn.ToString(info.NEO.Unclaimed, 8))
+	if info.Notary != nil {
+		res = fmt.Appendf(res, "Notary deposit:\t%s (till %d)\n", fixedn.ToString(info.Notary.Deposit, 8), info.Notary.Till)
+	} else {
+		res = fmt.Appendf(res, "Notary deposit:\tnone\n")
+	}
+	res = fmt.Appendf(res, "NEP-17 balances:\t%d\n", len(info.NEP17))
+	for _, b := range info.NEP17 {
+		amount := b.Amount
+		if bi, ok := new(big.Int).SetString(b.Amount, 10); ok {
+			amount = fixedn.ToString(bi, b.Decimals)
+		}
+		res = fmt.Appendf(res, "\t%s (%s): %s\n", b.Symbol, b.Asset.StringLE(), amount)
+	}
+	res = fmt.Appendf(res, "NEP-11 tokens:\t%d\n", len(info.NEP11))
+	for _, b := range info.NEP11 {
+		res = fmt.Appendf(res, "\t%s (%s): %d\n", b.Symbol, b.Asset.StringLE(), b.Tokens)
+	}
+	res = fmt.Appendf(res, "Deployed contracts:\t%d\n", len(info.Contracts))
+	for _, cs := range info.Contracts {
+		res = fmt.Appendf(res, "\t%d: %s (%s)\n", cs.ID, cs.Name, cs.Hash.StringLE())
+	}
+	res = fmt.Appendf(res, "Pending transactions:\t%d\n", len(info.Pending))
+	for _, p := range info.Pending {
+		res = fmt.Appendf(res, "\t%s: from %t, to %t\n", p.Hash.StringLE(), p.From, p.To)
+	}
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 2, 2, ' ', 0)
+	_, err := tw.Write(res)
+	if err != nil {
+		return err
+	}
+	return tw.Flush()
+}
+
 func queryTx(ctx *cli.Context) error {
 	args := ctx.Args()
 	if len(args) == 0 {
@@ -147,11 +243,10 @@ func DumpApplicationLog(
 		buf = fmt.Appendf(buf, "SystemFee:\t%s GAS\n", fixedn.Fixed8(tx.SystemFee).String())
 		buf = fmt.Appendf(buf, "NetworkFee:\t%s GAS\n", fixedn.Fixed8(tx.NetworkFee).String())
 		buf = fmt.Appendf(buf, "Script:\t%s\n", base64.StdEncoding.EncodeToString(tx.Script))
-		v := vm.New()
-		v.Load(tx.Script)
-		opts := bytes.NewBuffer(nil)
-		v.PrintOps(opts)
-		buf = append(buf, opts.Bytes()...)
+		instrs, err := vm.Disassemble(tx.Script, vm.DisassembleOptions{})
+		ops := bytes.NewBuffer(nil)
+		vm.WriteDisassembly(ops, instrs, err)
+		buf = append(buf, ops.Bytes()...)
 		if res != nil {
 			for _, e := range res.Executions {
 				if e.VMState != vmstate.Halt {
@@ -309,7 +404,7 @@ func queryVoter(ctx *cli.Context) error {
 		voted = fmt.Sprintf("%s (%s)", hex.EncodeToString(st.VoteTo.Bytes()), address.Uint160ToString(st.VoteTo.GetScriptHash()))
 	}
 	fmt.Fprintf(ctx.App.Writer, "\tVoted: %s\n", voted)
-	fmt.Fprintf(ctx.App.Writer, "\tAmount : %s\n", fixedn.ToString(&st.Balance, int(dec)))
+	fmt.Fprintf(ctx.App.Writer, "\tAmount : %s\n", fixedn.NewDecimal(&st.Balance, int(dec)))
 	fmt.Fprintf(ctx.App.Writer, "\tBlock: %d\n", st.BalanceHeight)
 	return nil
 }
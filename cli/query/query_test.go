@@ -2,8 +2,11 @@ package query_test
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"math/big"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
@@ -14,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/accountinfo"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -153,3 +157,46 @@ func TestQueryHeight(t *testing.T) {
 		e.RunWithError(t, append(args, "something")...)
 	})
 }
+
+func TestQueryAccount(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+
+	args := []string{"neo-go", "query", "account", "--rpc-endpoint", "http://" + e.RPC.Addresses()[0]}
+	e.Run(t, append(args, testcli.ValidatorAddr)...)
+	e.CheckNextLine(t, `^Address:\s+`+testcli.ValidatorAddr+`$`)
+	e.CheckNextLine(t, `^NEO:\s+100000000 \(block 0\)$`)
+	e.CheckNextLine(t, `^Voted:\s+null$`)
+	e.CheckNextLine(t, `^Unclaimed GAS:\s+[0-9.]+$`)
+	e.CheckNextLine(t, `^Notary deposit:\s+none$`)
+	e.CheckNextLine(t, `^NEP-17 balances:\s+2$`)
+	// Balances are returned by the server in no particular order.
+	balances := []string{e.GetNextLine(t), e.GetNextLine(t)}
+	slices.Sort(balances)
+	e.CheckLine(t, balances[0], `^\s+GAS \(`+e.Chain.UtilityTokenHash().StringLE()+`\): [0-9.]+$`)
+	e.CheckLine(t, balances[1], `^\s+NEO \(`+e.Chain.GoverningTokenHash().StringLE()+`\): 100000000$`)
+	e.CheckNextLine(t, `^NEP-11 tokens:\s+0$`)
+	e.CheckNextLine(t, `^Deployed contracts:\s+0$`)
+	e.CheckNextLine(t, `^Pending transactions:\s+0$`)
+	e.CheckEOF(t)
+
+	t.Run("json", func(t *testing.T) {
+		e.Run(t, append(args, "--json", testcli.ValidatorAddr)...)
+		var info accountinfo.Info
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &info))
+		require.Equal(t, testcli.ValidatorHash, info.Account)
+		require.Len(t, info.NEP17, 2)
+		require.Equal(t, big.NewInt(100000000), info.NEO.Balance)
+		require.Nil(t, info.Notary)
+		require.Empty(t, info.Contracts)
+		require.Empty(t, info.Pending)
+	})
+	t.Run("no address", func(t *testing.T) {
+		e.RunWithError(t, args...)
+	})
+	t.Run("excessive arguments", func(t *testing.T) {
+		e.RunWithError(t, append(args, testcli.ValidatorAddr, testcli.ValidatorAddr)...)
+	})
+	t.Run("invalid address", func(t *testing.T) {
+		e.RunWithError(t, append(args, "notanaddress")...)
+	})
+}
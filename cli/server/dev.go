@@ -0,0 +1,178 @@
+package server
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/consensus"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/network"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+	"go.uber.org/zap"
+)
+
+const (
+	// devWalletPassword is the password of the developer wallet account.
+	devWalletPassword = "dev"
+	// devTimePerBlock is the maximum time between devnet blocks, blocks
+	// with transactions are produced as soon as transactions arrive.
+	devTimePerBlock = time.Second
+	// devInitialGAS is the amount of GAS generated in the devnet genesis
+	// block.
+	devInitialGAS = 52_000_000
+	// devGenesisSystemFee is the system fee of devnet genesis transaction
+	// funding the developer account.
+	devGenesisSystemFee = 1_0000_0000
+)
+
+// devNet contains devnet node parameters.
+type devNet struct {
+	key        *keys.PrivateKey
+	walletPath string
+}
+
+// newDevNet generates a new key for devnet validator and developer account
+// and saves it into the wallet at the given path (temporary directory is
+// used if it's empty).
+func newDevNet(walletPath string) (*devNet, error) {
+	key, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, fmt.Errorf("can't generate key: %w", err)
+	}
+	if walletPath == "" {
+		walletPath = filepath.Join(os.TempDir(), "neo-go-dev-wallet.json")
+	}
+	if err := os.Remove(walletPath); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("can't remove old wallet: %w", err)
+	}
+	w, err := wallet.NewWallet(walletPath)
+	if err != nil {
+		return nil, fmt.Errorf("can't create wallet: %w", err)
+	}
+	defer w.Close()
+	acc := wallet.NewAccountFromPrivateKey(key)
+	acc.Label = "dev"
+	if err := acc.Encrypt(devWalletPassword, w.Scrypt); err != nil {
+		return nil, fmt.Errorf("can't encrypt account: %w", err)
+	}
+	w.AddAccount(acc)
+	if err := w.Save(); err != nil {
+		return nil, fmt.Errorf("can't save wallet: %w", err)
+	}
+	// Wallet closing destroys the key, so a copy is kept.
+	key, err = keys.NewPrivateKeyFromBytes(key.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return &devNet{key: key, walletPath: walletPath}, nil
+}
+
+// config returns in-memory single-node network configuration with the RPC
+// server listening on the given address. The whole NEO and almost all GAS
+// supply are transferred to the developer account in the genesis block.
+func (d *devNet) config(rpcAddress string) (config.Config, error) {
+	var (
+		pub       = d.key.PublicKey()
+		neoHash   = state.CreateNativeContractHash(nativenames.Neo)
+		gasHash   = state.CreateNativeContractHash(nativenames.Gas)
+		developer = pub.GetScriptHash()
+	)
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(keys.PublicKeys{pub})
+	if err != nil {
+		return config.Config{}, err
+	}
+	validators := hash.Hash160(script)
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, neoHash, "transfer", callflag.All, validators, developer, int64(100_000_000), nil)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	emit.AppCall(w.BinWriter, gasHash, "transfer", callflag.All, validators, developer,
+		int64(fixedn.Fixed8FromInt64(devInitialGAS))-devGenesisSystemFee, nil)
+	emit.Opcodes(w.BinWriter, opcode.ASSERT)
+	if w.Err != nil {
+		return config.Config{}, w.Err
+	}
+
+	cfg := config.Config{
+		ProtocolConfiguration: config.ProtocolConfiguration{
+			Magic:              netmode.PrivNet,
+			InitialGASSupply:   fixedn.Fixed8FromInt64(devInitialGAS),
+			MaxTraceableBlocks: 200000,
+			MemPoolSize:        50000,
+			StandbyCommittee:   []string{hex.EncodeToString(pub.Bytes())},
+			TimePerBlock:       devTimePerBlock,
+			ValidatorsCount:    1,
+			VerifyTransactions: true,
+			Genesis: config.Genesis{
+				Transaction: &config.GenesisTransaction{
+					Script:    w.Bytes(),
+					SystemFee: devGenesisSystemFee,
+				},
+			},
+		},
+		ApplicationConfiguration: config.ApplicationConfiguration{
+			DBConfiguration: dbconfig.DBConfiguration{
+				Type: dbconfig.InMemoryDB,
+			},
+			P2P: config.P2P{
+				Addresses:    []string{"127.0.0.1:0"},
+				PingInterval: 30 * time.Second,
+				PingTimeout:  90 * time.Second,
+			},
+			Relay: true,
+			RPC: config.RPC{
+				BasicService: config.BasicService{
+					Enabled:   true,
+					Addresses: []string{rpcAddress},
+				},
+				MaxGasInvoke:   fixedn.Fixed8FromInt64(100),
+				SessionEnabled: true,
+			},
+		},
+	}
+	return cfg, cfg.ProtocolConfiguration.Validate()
+}
+
+// mkConsensus creates devnet block producer.
+func (d *devNet) mkConsensus(chain *core.Blockchain, serv *network.Server, log *zap.Logger) (consensus.Service, error) {
+	srv, err := consensus.NewDevService(consensus.DevConfig{
+		Logger:       log,
+		Chain:        chain,
+		BlockQueue:   serv.GetBlockQueue(),
+		PrivateKey:   d.key,
+		TimePerBlock: devTimePerBlock,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("can't initialize devnet block producer: %w", err)
+	}
+	serv.AddConsensusService(srv, srv.OnPayload, srv.OnTransaction)
+	return srv, nil
+}
+
+// printInfo prints devnet parameters for the user.
+func (d *devNet) printInfo(ctx *cli.Context, rpcAddress string) {
+	fmt.Fprintln(ctx.App.Writer, "Development network (in-memory, single validator, data is lost on exit)")
+	fmt.Fprintf(ctx.App.Writer, "RPC: http://%s (WebSocket: ws://%s/ws)\n", rpcAddress, rpcAddress)
+	fmt.Fprintf(ctx.App.Writer, "Developer account: %s\n", d.key.Address())
+	fmt.Fprintf(ctx.App.Writer, "Public key: %s\n", hex.EncodeToString(d.key.PublicKey().Bytes()))
+	fmt.Fprintf(ctx.App.Writer, "WIF: %s\n", d.key.WIF())
+	fmt.Fprintf(ctx.App.Writer, "Wallet: %s (password: %s)\n", d.walletPath, devWalletPassword)
+	fmt.Fprintln(ctx.App.Writer)
+}
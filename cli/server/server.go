@@ -27,6 +27,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv"
 	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/services/tracing"
 	"github.com/urfave/cli"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -81,13 +82,37 @@ func NewCommands() []cli.Command {
 		Usage:    "Height of the state to reset DB to",
 		Required: true,
 	}
+	var cfgRollbackFlags = make([]cli.Flag, len(cfgFlags)+1)
+	copy(cfgRollbackFlags, cfgFlags)
+	cfgRollbackFlags[len(cfgRollbackFlags)-1] = cli.UintFlag{
+		Name:     "to-height",
+		Usage:    "Height of the state to rollback DB to",
+		Required: true,
+	}
+	var nodeFlags = make([]cli.Flag, len(cfgFlags))
+	copy(nodeFlags, cfgFlags)
+	nodeFlags = append(nodeFlags,
+		cli.BoolFlag{
+			Name:  "dev",
+			Usage: "run a single-node in-memory development network with a pre-funded developer wallet (configuration options are ignored)",
+		},
+		cli.StringFlag{
+			Name:  "dev-rpc-address",
+			Value: "127.0.0.1:20331",
+			Usage: "RPC server address for the development network",
+		},
+		cli.StringFlag{
+			Name:  "dev-wallet",
+			Usage: "path to save the development network wallet to (temporary directory is used by default)",
+		},
+	)
 	return []cli.Command{
 		{
 			Name:      "node",
 			Usage:     "start a NeoGo node",
-			UsageText: "neo-go node [--config-path path] [-d] [-p/-m/-t] [--config-file file]",
+			UsageText: "neo-go node [--config-path path] [-d] [-p/-m/-t] [--config-file file] [--dev [--dev-rpc-address address] [--dev-wallet path]]",
 			Action:    startServer,
-			Flags:     cfgFlags,
+			Flags:     nodeFlags,
 		},
 		{
 			Name:  "db",
@@ -114,6 +139,20 @@ func NewCommands() []cli.Command {
 					Action:    resetDB,
 					Flags:     cfgHeightFlags,
 				},
+				{
+					Name:      "rollback",
+					Usage:     "rollback database to one of the recent states using the changelog",
+					UsageText: "neo-go db rollback --to-height height [--config-path path] [-p/-m/-t] [--config-file file]",
+					Action:    rollbackDB,
+					Flags:     cfgRollbackFlags,
+				},
+				{
+					Name:      "reindex-balances",
+					Usage:     "rebuild NEP-17 balances index (see TrackNEP17Balances setting)",
+					UsageText: "neo-go db reindex-balances [--config-path path] [-p/-m/-t] [--config-file file]",
+					Action:    reindexBalances,
+					Flags:     cfgFlags,
+				},
 			},
 		},
 	}
@@ -358,6 +397,71 @@ func resetDB(ctx *cli.Context) error {
 	return nil
 }
 
+func rollbackDB(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	h := uint32(ctx.Uint("to-height"))
+
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	chain, store, err := initBlockChain(cfg, log)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create Blockchain instance: %w", err), 1)
+	}
+
+	err = chain.Rollback(h)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to rollback chain state to height %d: %w", h, err), 1)
+	}
+	err = store.Close()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to close the DB: %w", err), 1)
+	}
+	return nil
+}
+
+func reindexBalances(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+	chain, store, err := initBlockChain(cfg, log)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create Blockchain instance: %w", err), 1)
+	}
+
+	err = chain.ReindexNEP17Balances()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to reindex NEP-17 balances: %w", err), 1)
+	}
+	err = store.Close()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to close the DB: %w", err), 1)
+	}
+	return nil
+}
+
 // oracleService is an interface representing Oracle service with network.Service
 // capabilities and ability to submit oracle responses.
 type oracleService interface {
@@ -385,6 +489,19 @@ func mkOracle(config config.OracleConfiguration, magic netmode.Magic, chain *cor
 	return orc, nil
 }
 
+func mkTracer(config config.Tracing, chain *core.Blockchain, log *zap.Logger) error {
+	if !config.Enabled {
+		chain.SetTracer(nil)
+		return nil
+	}
+	tr, err := tracing.New(config, log, nil)
+	if err != nil {
+		return fmt.Errorf("can't initialize tracer: %w", err)
+	}
+	chain.SetTracer(tr)
+	return nil
+}
+
 func mkConsensus(config config.Consensus, tpb time.Duration, chain *core.Blockchain, serv *network.Server, log *zap.Logger) (consensus.Service, error) {
 	if !config.Enabled {
 		return nil, nil
@@ -440,7 +557,19 @@ func startServer(ctx *cli.Context) error {
 		return err
 	}
 
-	cfg, err := options.GetConfigFromContext(ctx)
+	var (
+		cfg config.Config
+		dev *devNet
+		err error
+	)
+	if ctx.Bool("dev") {
+		dev, err = newDevNet(ctx.String("dev-wallet"))
+		if err == nil {
+			cfg, err = dev.config(ctx.String("dev-rpc-address"))
+		}
+	} else {
+		cfg, err = options.GetConfigFromContext(ctx)
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -486,7 +615,16 @@ func startServer(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
-	dbftSrv, err := mkConsensus(cfg.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
+	err = mkTracer(cfg.ApplicationConfiguration.Tracing, chain, log)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	var dbftSrv consensus.Service
+	if dev != nil {
+		dbftSrv, err = dev.mkConsensus(chain, serv, log)
+	} else {
+		dbftSrv, err = mkConsensus(cfg.ApplicationConfiguration.Consensus, serverConfig.TimePerBlock, chain, serv, log)
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -496,7 +634,16 @@ func startServer(ctx *cli.Context) error {
 	}
 	errChan := make(chan error)
 	rpcServer := rpcsrv.New(chain, cfg.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+	if p2pNotary != nil {
+		rpcServer.SetNotaryHandler(p2pNotary)
+	}
 	serv.AddService(&rpcServer)
+	health := mkHealthService(cfg.ApplicationConfiguration, chain, serv, &rpcServer, log)
+	defer func() { health.ShutDown() }()
+	err = health.Start()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to start HealthCheck service: %w", err), 1)
+	}
 
 	serv.Start()
 	if !cfg.ApplicationConfiguration.RPC.StartWhenSynchronized {
@@ -514,6 +661,9 @@ func startServer(ctx *cli.Context) error {
 	fmt.Fprintln(ctx.App.Writer, Logo())
 	fmt.Fprintln(ctx.App.Writer, serv.UserAgent)
 	fmt.Fprintln(ctx.App.Writer)
+	if dev != nil {
+		dev.printInfo(ctx, cfg.ApplicationConfiguration.RPC.Addresses[0])
+	}
 
 	var shutdownErr error
 Main:
@@ -526,6 +676,10 @@ Main:
 			var newLogLevel = zapcore.InvalidLevel
 
 			log.Info("signal received", zap.Stringer("name", sig))
+			if dev != nil {
+				log.Warn("configuration can't be reloaded for development network, signal ignored")
+				break // Continue working.
+			}
 			cfgnew, err := options.GetConfigFromContext(ctx)
 			if err != nil {
 				log.Warn("can't reread the config file, signal ignored", zap.Error(err))
@@ -552,14 +706,24 @@ Main:
 					logLevel.SetLevel(newLogLevel)
 					log.Warn("using new logging level", zap.Stringer("level", newLogLevel))
 				}
+				health.ShutDown()
 				serv.DelService(&rpcServer)
 				rpcServer.Shutdown()
 				rpcServer = rpcsrv.New(chain, cfgnew.ApplicationConfiguration.RPC, serv, oracleSrv, log, errChan)
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+				}
 				serv.AddService(&rpcServer)
 				if !cfgnew.ApplicationConfiguration.RPC.StartWhenSynchronized || serv.IsInSync() {
 					// Here similar to the initial run (see above for-loop), so async.
 					go rpcServer.Start()
 				}
+				health = mkHealthService(cfgnew.ApplicationConfiguration, chain, serv, &rpcServer, log)
+				err = health.Start()
+				if err != nil {
+					shutdownErr = fmt.Errorf("failed to start HealthCheck service: %w", err)
+					cancel() // Fatal error, like for RPC server.
+				}
 				pprof.ShutDown()
 				pprof = metrics.NewPprofService(cfgnew.ApplicationConfiguration.Pprof, log)
 				err = pprof.Start()
@@ -574,6 +738,10 @@ Main:
 					shutdownErr = fmt.Errorf("failed to start Prometheus service: %w", err)
 					cancel() // Fatal error, like for RPC server.
 				}
+				err = mkTracer(cfgnew.ApplicationConfiguration.Tracing, chain, log)
+				if err != nil {
+					log.Error("failed to create tracer", zap.Error(err))
+				}
 			case sigusr1:
 				if oracleSrv != nil {
 					serv.DelService(oracleSrv)
@@ -595,6 +763,7 @@ Main:
 				if p2pNotary != nil {
 					serv.DelService(p2pNotary)
 					chain.SetNotary(nil)
+					rpcServer.SetNotaryHandler(nil)
 					p2pNotary.Shutdown()
 				}
 				p2pNotary, err = mkP2PNotary(cfgnew.ApplicationConfiguration.P2PNotary, chain, serv, log)
@@ -602,8 +771,11 @@ Main:
 					log.Error("failed to create notary service", zap.Error(err))
 					break // Keep going.
 				}
-				if p2pNotary != nil && serv.IsInSync() {
-					p2pNotary.Start()
+				if p2pNotary != nil {
+					rpcServer.SetNotaryHandler(p2pNotary)
+					if serv.IsInSync() {
+						p2pNotary.Start()
+					}
 				}
 				serv.DelExtensibleService(sr, stateroot.Category)
 				srMod.SetUpdateValidatorsCallback(nil)
@@ -646,6 +818,16 @@ Main:
 	return nil
 }
 
+// mkHealthService creates health check service for the given node components.
+// RPC server state is only taken into account if it's enabled.
+func mkHealthService(cfg config.ApplicationConfiguration, chain *core.Blockchain, serv *network.Server, rpcServer *rpcsrv.Server, log *zap.Logger) *metrics.Service {
+	var rpc metrics.HealthRPC
+	if cfg.RPC.Enabled {
+		rpc = rpcServer
+	}
+	return metrics.NewHealthService(cfg.HealthCheck, chain, serv, rpc, log)
+}
+
 // initBlockChain initializes BlockChain with preselected DB.
 func initBlockChain(cfg config.Config, log *zap.Logger) (*core.Blockchain, storage.Store, error) {
 	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
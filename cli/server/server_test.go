@@ -13,9 +13,13 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
+	"go.uber.org/zap/zaptest"
 	"gopkg.in/yaml.v3"
 )
 
@@ -376,6 +380,33 @@ func TestInitBlockChain(t *testing.T) {
 	})
 }
 
+func TestDevNet(t *testing.T) {
+	walletPath := filepath.Join(t.TempDir(), "dev.json")
+	dev, err := newDevNet(walletPath)
+	require.NoError(t, err)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Len(t, w.Accounts, 1)
+	require.NoError(t, w.Accounts[0].Decrypt(devWalletPassword, w.Scrypt))
+	require.Equal(t, dev.key.Address(), w.Accounts[0].Address)
+	w.Close()
+
+	cfg, err := dev.config("127.0.0.1:0")
+	require.NoError(t, err)
+	chain, _, err := initBlockChain(cfg, zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go chain.Run()
+	t.Cleanup(chain.Close)
+
+	developer := dev.key.GetScriptHash()
+	neo, _ := chain.GetGoverningTokenBalance(developer)
+	require.EqualValues(t, 100_000_000, neo.Int64())
+	// Committee rewards are also paid to the developer account.
+	gas := chain.GetUtilityTokenBalance(developer)
+	require.GreaterOrEqual(t, gas.Int64(), int64(fixedn.Fixed8FromInt64(devInitialGAS))-devGenesisSystemFee)
+}
+
 func TestResetDB(t *testing.T) {
 	d := t.TempDir()
 	err := os.Chdir(d)
@@ -390,3 +421,38 @@ func TestResetDB(t *testing.T) {
 	err = resetDB(ctx)
 	require.NoError(t, err)
 }
+
+func TestRollbackDB(t *testing.T) {
+	d := t.TempDir()
+	err := os.Chdir(d)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.Chdir(serverTestWD)) })
+	set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+	set.String("config-path", filepath.Join(serverTestWD, "..", "..", "config"), "")
+	set.Bool("privnet", true, "")
+	set.Bool("debug", true, "")
+	set.Int("to-height", 0, "")
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+	err = rollbackDB(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, set.Set("to-height", "1"))
+	ctx = cli.NewContext(cli.NewApp(), set, nil)
+	err = rollbackDB(ctx)
+	require.Error(t, err)
+}
+
+func TestReindexBalances(t *testing.T) {
+	d := t.TempDir()
+	err := os.Chdir(d)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, os.Chdir(serverTestWD)) })
+	set := flag.NewFlagSet("flagSet", flag.ExitOnError)
+	set.String("config-path", filepath.Join(serverTestWD, "..", "..", "config"), "")
+	set.Bool("privnet", true, "")
+	set.Bool("debug", true, "")
+	ctx := cli.NewContext(cli.NewApp(), set, nil)
+	// Balances tracking is disabled in the default configuration.
+	err = reindexBalances(ctx)
+	require.ErrorContains(t, err, core.ErrNEP17BalancesDisabled.Error())
+}
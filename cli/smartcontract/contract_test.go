@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -12,17 +13,24 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/cli/smartcontract"
+	extended "github.com/nspcc-dev/neo-go/cli/smartcontract/testdata/rpcbindings/extended/rpc"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
 	"github.com/nspcc-dev/neo-go/internal/versionutil"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	sc "github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -102,8 +110,9 @@ func TestCalcHash(t *testing.T) {
 }
 
 func TestContractBindings(t *testing.T) {
-	// For proper contract init. The actual version as it will be replaced.
+	// For proper contract init. The actual versions are replaced below.
 	smartcontract.ModVersion = "v0.0.0"
+	smartcontract.NeoGoModVersion = "v0.0.0"
 
 	tmpDir := t.TempDir()
 	e := testcli.NewExecutor(t, false)
@@ -137,14 +146,24 @@ func Blocks() []*alias.Block {
 }
 `), os.ModePerm))
 
+	// Template safe methods are not present in the replaced contract.
 	cfgPath := filepath.Join(ctrPath, "neo-go.yml")
+	cfgData, err := os.ReadFile(cfgPath)
+	require.NoError(t, err)
+	var cfg smartcontract.ProjectConfig
+	require.NoError(t, yaml.Unmarshal(cfgData, &cfg))
+	cfg.SafeMethods = nil
+	cfgData, err = yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, cfgData, os.ModePerm))
+
 	manifestPath := filepath.Join(tmpDir, "manifest.json")
 	bindingsPath := filepath.Join(tmpDir, "bindings.yml")
 	cmd := []string{"neo-go", "contract", "compile"}
 
 	cmd = append(cmd, "--in", ctrPath, "--bindings", bindingsPath)
 
-	// Replace `pkg/interop` in go.mod to avoid getting an actual module version.
+	// Replace neo-go modules in go.mod to avoid getting actual module versions.
 	goMod := filepath.Join(ctrPath, "go.mod")
 	data, err := os.ReadFile(goMod)
 	require.NoError(t, err)
@@ -154,9 +173,14 @@ func Blocks() []*alias.Block {
 
 	wd, err := os.Getwd()
 	require.NoError(t, err)
+	data = append(data, "\nreplace github.com/nspcc-dev/neo-go => "...)
+	data = append(data, filepath.Join(wd, "../..")...)
 	data = append(data, "\nreplace github.com/nspcc-dev/neo-go/pkg/interop => "...)
 	data = append(data, filepath.Join(wd, "../../pkg/interop")...)
 	require.NoError(t, os.WriteFile(goMod, data, os.ModePerm))
+	sum, err := os.ReadFile(filepath.Join(wd, "../../go.sum"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(ctrPath, "go.sum"), sum, os.ModePerm))
 
 	cmd = append(cmd, "--config", cfgPath,
 		"--out", filepath.Join(tmpDir, "out.nef"),
@@ -214,8 +238,9 @@ func ToMap(a []testcontract.MyPair) map[int]string {
 }
 
 func TestContractInitAndCompile(t *testing.T) {
-	// For proper contract init. The actual version as it will be replaced.
+	// For proper contract init. The actual versions are replaced below.
 	smartcontract.ModVersion = "v0.0.0"
+	smartcontract.NeoGoModVersion = "v0.0.0"
 
 	tmpDir := t.TempDir()
 	e := testcli.NewExecutor(t, false)
@@ -264,16 +289,21 @@ func TestContractInitAndCompile(t *testing.T) {
 		e.RunWithError(t, append(cmd, "--config", badCfg)...)
 	})
 
-	// Replace `pkg/interop` in go.mod to avoid getting an actual module version.
+	// Replace neo-go modules in go.mod to avoid getting actual module versions.
 	goMod := filepath.Join(ctrPath, "go.mod")
 	data, err := os.ReadFile(goMod)
 	require.NoError(t, err)
 
 	wd, err := os.Getwd()
 	require.NoError(t, err)
+	data = append(data, "\nreplace github.com/nspcc-dev/neo-go => "...)
+	data = append(data, filepath.Join(wd, "../..")...)
 	data = append(data, "\nreplace github.com/nspcc-dev/neo-go/pkg/interop => "...)
 	data = append(data, filepath.Join(wd, "../../pkg/interop")...)
 	require.NoError(t, os.WriteFile(goMod, data, os.ModePerm))
+	sum, err := os.ReadFile(filepath.Join(wd, "../../go.sum"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(ctrPath, "go.sum"), sum, os.ModePerm))
 
 	cmd = append(cmd, "--config", cfgPath)
 
@@ -629,6 +659,55 @@ func TestContract_TestInvokeScript(t *testing.T) {
 	})
 }
 
+func TestContract_TestInvokeFunctionParams(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	stdHash, err := e.Chain.GetNativeContractScriptHash(nativenames.StdLib)
+	require.NoError(t, err)
+	cmd := []string{"neo-go", "contract", "testinvokefunction",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0]}
+
+	checkResult := func(t *testing.T, expected stackitem.Item) {
+		res := new(result.Invoke)
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+		require.Equal(t, vmstate.Halt.String(), res.State, res.FaultException)
+		require.Len(t, res.Stack, 1)
+		require.Equal(t, expected, res.Stack[0])
+	}
+
+	t.Run("manifest types", func(t *testing.T) {
+		// atoi accepts a string, so 42 is not an integer here.
+		e.Run(t, append(cmd, stdHash.StringLE(), "atoi", "42")...)
+		checkResult(t, stackitem.Make(42))
+
+		// Explicit type is preferred.
+		e.RunWithErrorCheck(t, "FAULT", append(cmd, stdHash.StringLE(), "atoi", "int:42")...)
+	})
+	t.Run("inline map", func(t *testing.T) {
+		e.Run(t, append(cmd, stdHash.StringLE(), "jsonSerialize", "{", "a", "[", "1", "true", "]", "}")...)
+		checkResult(t, stackitem.Make(`{"a":[1,true]}`))
+	})
+	t.Run("params file", func(t *testing.T) {
+		paramsFile := filepath.Join(t.TempDir(), "params.yml")
+		require.NoError(t, os.WriteFile(paramsFile, []byte("- {a: [1, true], b: null}\n"), os.ModePerm))
+		fileCmd := append(cmd, "--params-file", paramsFile, stdHash.StringLE(), "jsonSerialize")
+		e.Run(t, fileCmd...)
+		checkResult(t, stackitem.Make(`{"a":[1,true],"b":null}`))
+
+		e.Run(t, append(fileCmd, "--", testcli.ValidatorAddr)...)
+		checkResult(t, stackitem.Make(`{"a":[1,true],"b":null}`))
+
+		e.RunWithErrorCheck(t, "parameters can't be specified both via --params-file and as arguments", append(fileCmd, "42")...)
+		e.RunWithErrorCheck(t, "failed to read parameters file", append(cmd, "--params-file", paramsFile+".bad", stdHash.StringLE(), "jsonSerialize")...)
+
+		require.NoError(t, os.WriteFile(paramsFile, []byte("- [1, {a: bool:x}]\n"), os.ModePerm))
+		e.RunWithErrorCheck(t, "failed to parse argument #1[1]{0}.value (line 1): invalid boolean value", fileCmd...)
+	})
+	t.Run("bad element", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "failed to parse argument #1[1][0]: invalid integer value",
+			append(cmd, stdHash.StringLE(), "jsonSerialize", "[", "1", "[", "int:x", "]", "]")...)
+	})
+}
+
 func TestComlileAndInvokeFunction(t *testing.T) {
 	e := testcli.NewExecutor(t, true)
 	tmpDir := t.TempDir()
@@ -1112,3 +1191,164 @@ func TestContractCompile_NEFSizeCheck(t *testing.T) {
 	e.RunWithError(t, "neo-go", "contract", "compile", "--in", in)
 	require.NoFileExists(t, filepath.Join(tmpDir, "main.nef"))
 }
+
+func TestContractCompile_Diagnostics(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	in := filepath.Join(tmpDir, "main.go")
+	cfg := filepath.Join(tmpDir, "main.yml")
+	require.NoError(t, os.WriteFile(cfg, []byte("name: main\nevents:\n  - name: Unused"), os.ModePerm))
+
+	t.Run("unsupported format", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(in, []byte("package main\nfunc Main() int { return 1 }"), os.ModePerm))
+		e.RunWithError(t, "neo-go", "contract", "compile", "--in", in, "--diagnostics", "xml")
+	})
+	t.Run("errors", func(t *testing.T) {
+		src := `package main
+func Main() int {
+	a := make([]int, 1, 2)
+	return len(a)
+}`
+		require.NoError(t, os.WriteFile(in, []byte(src), os.ModePerm))
+		e.RunWithError(t, "neo-go", "contract", "compile", "--in", in, "--diagnostics", "json")
+
+		var diags []compiler.Diagnostic
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &diags))
+		require.Equal(t, 1, len(diags))
+		require.Equal(t, compiler.SeverityError, diags[0].Severity)
+		require.Equal(t, compiler.CodeCodegen, diags[0].Code)
+		require.Equal(t, 3, diags[0].Pos.Line)
+		require.Equal(t, in, diags[0].Pos.Filename)
+		require.NoFileExists(t, filepath.Join(tmpDir, "main.nef"))
+	})
+	t.Run("warnings", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(in, []byte("package main\nfunc Main() int { return 1 }"), os.ModePerm))
+		e.Run(t, "neo-go", "contract", "compile", "--in", in, "--diagnostics", "json", "--no-events")
+
+		var diags []compiler.Diagnostic
+		require.NoError(t, json.Unmarshal(e.Out.Bytes(), &diags))
+		require.Equal(t, 1, len(diags))
+		require.Equal(t, compiler.SeverityWarning, diags[0].Severity)
+		require.Equal(t, compiler.CodeUnusedEvent, diags[0].Code)
+		require.FileExists(t, filepath.Join(tmpDir, "main.nef"))
+	})
+}
+
+func TestContractCompile_InitReport(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	in := filepath.Join(tmpDir, "main.go")
+	report := filepath.Join(tmpDir, "init.json")
+	src := `package main
+var a = []int{1, 2, 3}
+//neo:lazy
+var b = []int{4, 5, 6}
+func Main() int { return a[0] + b[0] }`
+	require.NoError(t, os.WriteFile(in, []byte(src), os.ModePerm))
+	e.Run(t, "neo-go", "contract", "compile", "--in", in, "--out", filepath.Join(tmpDir, "main.nef"), "--init-report", report)
+
+	data, err := os.ReadFile(report)
+	require.NoError(t, err)
+	var r compiler.InitReport
+	require.NoError(t, json.Unmarshal(data, &r))
+	require.Equal(t, 1, len(r.Entries))
+	require.Equal(t, compiler.InitEntryVar, r.Entries[0].Kind)
+	require.Equal(t, []string{"a"}, r.Entries[0].Names)
+	require.Equal(t, in+":2:5", r.Entries[0].Position)
+	require.Equal(t, 1, len(r.Lazy))
+	require.Equal(t, []string{"b"}, r.Lazy[0].Names)
+	require.True(t, r.Size > r.Entries[0].Size)
+}
+
+func TestContractBundle(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	bundleName := filepath.Join(tmpDir, "deploy."+bundle.FileExt)
+	t.Run("no config", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "compile",
+			"--in", "testdata/deploy/main.go",
+			"--out", nefName, "--bundle", bundleName)
+	})
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--bundle", bundleName)
+
+	b, err := bundle.ReadFile(bundleName)
+	require.NoError(t, err)
+	rawNef, err := os.ReadFile(nefName)
+	require.NoError(t, err)
+	nefBytes, err := b.NEF.Bytes()
+	require.NoError(t, err)
+	require.Equal(t, rawNef, nefBytes)
+	require.Equal(t, "Test deploy", b.Manifest.Name)
+	require.NotNil(t, b.DebugInfo)
+
+	t.Run("manifest with bundle", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "deploy",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+			"--in", bundleName, "--manifest", filepath.Join(tmpDir, "deploy.manifest.json"))
+	})
+	t.Run("corrupted bundle", func(t *testing.T) {
+		badName := filepath.Join(tmpDir, "bad."+bundle.FileExt)
+		require.NoError(t, os.WriteFile(badName, []byte("not a bundle"), os.ModePerm))
+		e.RunWithError(t, "neo-go", "contract", "deploy",
+			"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+			"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+			"--in", badName)
+	})
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, "neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--in", bundleName, "--force")
+	e.CheckTxPersisted(t, "Sent invocation transaction ")
+}
+
+// neotestInvoker implements extended.Invoker over neotest executor.
+type neotestInvoker struct {
+	t testing.TB
+	e *neotest.Executor
+}
+
+func (i neotestInvoker) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	script, err := sc.CreateCallScript(contract, operation, params...)
+	if err != nil {
+		return nil, err
+	}
+	stack, err := i.e.NewInvoker(contract, i.e.Committee).TestInvokeScript(i.t, script, []neotest.Signer{i.e.Committee})
+	if err != nil {
+		return &result.Invoke{State: "FAULT", FaultException: err.Error()}, nil
+	}
+	return &result.Invoke{State: "HALT", Stack: stack.ToArray()}, nil
+}
+
+func TestExtendedTypesBindingsInvoke(t *testing.T) {
+	source := filepath.Join("testdata", "rpcbindings", "extended")
+	configFile := filepath.Join(source, "config.yml")
+
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	ctr := neotest.CompileFile(t, e.CommitteeHash, source, configFile)
+	e.DeployContract(t, ctr, nil)
+
+	reader := extended.NewReader(neotestInvoker{t: t, e: e}, ctr.Hash)
+	p, err := reader.GetPoint(big.NewInt(1), big.NewInt(2), "point")
+	require.NoError(t, err)
+	require.Equal(t, &extended.ExtendedPoint{X: big.NewInt(1), Y: big.NewInt(2), Name: "point"}, p)
+
+	owner := util.Uint160{1, 2, 3}
+	s, err := reader.GetSegment(big.NewInt(1), big.NewInt(2), big.NewInt(3), big.NewInt(4), owner)
+	require.NoError(t, err)
+	require.Equal(t, &extended.ExtendedSegment{
+		From:  &extended.ExtendedPoint{X: big.NewInt(1), Y: big.NewInt(2), Name: "from"},
+		To:    &extended.ExtendedPoint{X: big.NewInt(3), Y: big.NewInt(4), Name: "to"},
+		Owner: owner,
+		Tags:  []string{"from", "to"},
+	}, s)
+}
@@ -0,0 +1,515 @@
+package smartcontract
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultDeployRecords is the default deployment record file name (relative
+// to the deployment configuration file).
+const DefaultDeployRecords = "deploy.records.jsonl"
+
+// Deployment record states.
+const (
+	// DeployStateSent means that the deployment transaction is sent, but
+	// its result is not yet known.
+	DeployStateSent = "sent"
+	// DeployStateDeployed means that the contract is deployed.
+	DeployStateDeployed = "deployed"
+	// DeployStateFailed means that the deployment transaction has failed.
+	DeployStateFailed = "failed"
+)
+
+type (
+	// DeployConfig is a multi-network contract deployment configuration
+	// (deploy.yml). Relative paths are resolved against the configuration
+	// file directory.
+	DeployConfig struct {
+		// NEF is the path to the contract NEF file.
+		NEF string `yaml:"nef"`
+		// Manifest is the path to the contract manifest file.
+		Manifest string `yaml:"manifest"`
+		// Records is the path to the deployment record file,
+		// DefaultDeployRecords is used if not set.
+		Records string `yaml:"records"`
+		// Networks contains per-network deployment settings.
+		Networks map[string]DeployNetwork `yaml:"networks"`
+
+		dir string
+	}
+
+	// DeployNetwork is a set of deployment settings for a single network.
+	DeployNetwork struct {
+		// RPC is the RPC node endpoint.
+		RPC string `yaml:"rpc"`
+		// Magic is the expected network magic, deployment is refused if the
+		// node belongs to some other network. It's not checked if zero.
+		Magic netmode.Magic `yaml:"magic"`
+		// Wallet is the path to the wallet with the sender account.
+		Wallet string `yaml:"wallet"`
+		// WalletConfig is the path to the wallet configuration file (with
+		// the wallet path and password), it's an alternative to Wallet.
+		WalletConfig string `yaml:"walletconfig"`
+		// Address is the sender account address (or LE hash), the default
+		// wallet address is used if not set.
+		Address string `yaml:"address"`
+		// Hash is the expected contract hash (address or LE hash), deployment
+		// is refused if the actual one is different. It's not checked if empty.
+		Hash string `yaml:"hash"`
+		// Data is the '_deploy' method data parameter in the same format as
+		// used by parameter files (see ParamsFileDoc).
+		Data yaml.Node `yaml:"data"`
+	}
+
+	// DeployRecord is a deployment record file entry. Every entry is a single
+	// line of JSON, entries are only appended to the file and the last entry
+	// for the network describes its current state.
+	DeployRecord struct {
+		Network string       `json:"network"`
+		State   string       `json:"state"`
+		Hash    util.Uint160 `json:"hash"`
+		TxID    util.Uint256 `json:"txid"`
+		// VUB is the ValidUntilBlock value of the deployment transaction.
+		VUB uint32 `json:"vub"`
+		// Timestamp is the record creation time in milliseconds.
+		Timestamp uint64 `json:"timestamp"`
+	}
+
+	// DeployResult is the result of the deployment to a single network.
+	DeployResult struct {
+		// Record is the final deployment record (it's not saved for dry run).
+		Record DeployRecord
+		// Skipped is set if the contract was already deployed before.
+		Skipped bool
+		// Invocation is the test invocation result for dry run.
+		Invocation *result.Invoke
+	}
+)
+
+// ParseDeployConfig reads deployment configuration from the given file.
+// `${NAME}` (and `$NAME`) references in scalar values are substituted with
+// environment variables (`$$` can be used for a literal `$`), undefined
+// variables are an error.
+func ParseDeployConfig(path string) (*DeployConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeployConfigYAML(data, filepath.Dir(path), os.LookupEnv)
+}
+
+// ParseDeployConfigYAML parses deployment configuration from the given YAML
+// document using the given function for variable substitution (see
+// ParseDeployConfig). Relative paths are resolved against dir.
+func ParseDeployConfigYAML(data []byte, dir string, lookup func(string) (string, bool)) (*DeployConfig, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("bad deployment config: %w", err)
+	}
+	if err := expandYAML(&doc, lookup); err != nil {
+		return nil, err
+	}
+	var cfg = &DeployConfig{dir: dir}
+	if err := doc.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("bad deployment config: %w", err)
+	}
+	if cfg.NEF == "" || cfg.Manifest == "" {
+		return nil, errors.New("bad deployment config: nef and manifest must be specified")
+	}
+	if len(cfg.Networks) == 0 {
+		return nil, errors.New("bad deployment config: no networks specified")
+	}
+	for name, n := range cfg.Networks {
+		if n.RPC == "" {
+			return nil, fmt.Errorf("bad deployment config: no RPC endpoint for %s", name)
+		}
+		if (n.Wallet == "") == (n.WalletConfig == "") {
+			return nil, fmt.Errorf("bad deployment config: either wallet or walletconfig must be specified for %s", name)
+		}
+	}
+	return cfg, nil
+}
+
+// expandYAML substitutes environment variables in all scalar nodes.
+func expandYAML(n *yaml.Node, lookup func(string) (string, bool)) error {
+	if n.Kind == yaml.ScalarNode && strings.Contains(n.Value, "$") {
+		var missing []string
+		v := os.Expand(n.Value, func(name string) string {
+			if name == "$" {
+				return "$"
+			}
+			val, ok := lookup(name)
+			if !ok {
+				missing = append(missing, name)
+			}
+			return val
+		})
+		if len(missing) != 0 {
+			return fmt.Errorf("undefined variable %s (line %d)", strings.Join(missing, ", "), n.Line)
+		}
+		n.Value = v
+		if n.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle|yaml.LiteralStyle|yaml.FoldedStyle) == 0 {
+			n.Tag = "" // Resolve the type of the substituted value.
+			n.Tag = n.ShortTag()
+		}
+	}
+	for _, c := range n.Content {
+		if err := expandYAML(c, lookup); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NetworkNames returns sorted names of all configured networks.
+func (c *DeployConfig) NetworkNames() []string {
+	var names = make([]string, 0, len(c.Networks))
+	for name := range c.Networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RecordsPath returns the deployment record file path.
+func (c *DeployConfig) RecordsPath() string {
+	if c.Records == "" {
+		return c.path(DefaultDeployRecords)
+	}
+	return c.path(c.Records)
+}
+
+// path resolves the given path relative to the configuration file.
+func (c *DeployConfig) path(p string) string {
+	if p == "" || filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(c.dir, p)
+}
+
+// Account opens the wallet configured for the given network and returns the
+// (unlocked) sender account from it. Password is requested interactively if
+// it's not provided via the wallet configuration file.
+func (c *DeployConfig) Account(network string) (*wallet.Account, *wallet.Wallet, error) {
+	n, ok := c.Networks[network]
+	if !ok {
+		return nil, nil, fmt.Errorf("unknown network %s", network)
+	}
+	var (
+		wPath = c.path(n.Wallet)
+		pass  *string
+		addr  util.Uint160
+	)
+	if n.WalletConfig != "" {
+		cfg, err := options.ReadWalletConfig(c.path(n.WalletConfig))
+		if err != nil {
+			return nil, nil, err
+		}
+		wPath = cfg.Path
+		pass = &cfg.Password
+	}
+	wall, err := wallet.NewWalletFromFile(wPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if n.Address != "" {
+		addr, err = flags.ParseAddress(n.Address)
+		if err != nil {
+			wall.Close()
+			return nil, nil, fmt.Errorf("invalid address: %w", err)
+		}
+	} else {
+		addr = wall.GetChangeAddress()
+		if addr.Equals(util.Uint160{}) {
+			wall.Close()
+			return nil, nil, errors.New("can't get default address")
+		}
+	}
+	acc, err := options.GetUnlockedAccount(wall, addr, pass)
+	if err != nil {
+		wall.Close()
+		return nil, nil, err
+	}
+	return acc, wall, nil
+}
+
+// ReadDeployRecords reads the given deployment record file and returns the
+// latest records for every network found. Missing file is not an error.
+func ReadDeployRecords(path string) (map[string]DeployRecord, error) {
+	var res = make(map[string]DeployRecord)
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return res, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for line := 1; s.Scan(); line++ {
+		if len(strings.TrimSpace(s.Text())) == 0 {
+			continue
+		}
+		var r DeployRecord
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			return nil, fmt.Errorf("bad deployment record at line %d: %w", line, err)
+		}
+		res[r.Network] = r
+	}
+	return res, s.Err()
+}
+
+// appendDeployRecord appends the given record to the record file.
+func appendDeployRecord(path string, r DeployRecord) error {
+	r.Timestamp = uint64(time.Now().UnixMilli())
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("can't open deployment record file: %w", err)
+	}
+	_, err = f.Write(append(data, '\n'))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return fmt.Errorf("can't write deployment record: %w", err)
+	}
+	return nil
+}
+
+// readContract reads contract NEF and manifest.
+func (c *DeployConfig) readContract() (*nef.File, *manifest.Manifest, error) {
+	nefFile, _, err := readNEFFile(c.path(c.NEF))
+	if err != nil {
+		return nil, nil, err
+	}
+	m, _, err := readManifest(c.path(c.Manifest), util.Uint160{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest file: %w", err)
+	}
+	return nefFile, m, nil
+}
+
+// DeployToNetwork deploys the contract to the given network using the given
+// RPC client and actor (both must be connected to the network's node, the
+// actor's sender is the contract deployer). Deployment is resumable, every
+// step is recorded to the record file, so if it's interrupted the next call
+// continues from the last recorded state: an already deployed contract is
+// not deployed again and a sent transaction is awaited instead of sending a
+// new one. In dry run mode the deployment is only test-invoked and no
+// records are written.
+func (c *DeployConfig) DeployToNetwork(cl *rpcclient.Client, act *actor.Actor, network string, dryRun bool) (*DeployResult, error) {
+	n, ok := c.Networks[network]
+	if !ok {
+		return nil, fmt.Errorf("unknown network %s", network)
+	}
+	if n.Magic != 0 && act.GetNetwork() != n.Magic {
+		return nil, fmt.Errorf("network magic mismatch: expected %d, node has %d", n.Magic, act.GetNetwork())
+	}
+	nefFile, m, err := c.readContract()
+	if err != nil {
+		return nil, err
+	}
+	hash := state.CreateContractHash(act.Sender(), nefFile.Checksum, m.Name)
+	if n.Hash != "" {
+		expected, err := flags.ParseAddress(n.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expected contract hash: %w", err)
+		}
+		if expected != hash {
+			return nil, fmt.Errorf("contract hash mismatch: expected %s, got %s", expected.StringLE(), hash.StringLE())
+		}
+	}
+	var data any
+	if n.Data.Kind != 0 {
+		p, err := cmdargs.ParseParamYAML(&n.Data, "data")
+		if err != nil {
+			return nil, err
+		}
+		data, err = p.ToStackItem()
+		if err != nil {
+			return nil, fmt.Errorf("invalid data parameter: %w", err)
+		}
+	}
+	var res = &DeployResult{Record: DeployRecord{Network: network, Hash: hash}}
+
+	if dryRun {
+		nefBytes, err := nefFile.Bytes()
+		if err != nil {
+			return nil, err
+		}
+		manifBytes, err := json.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		params := []any{nefBytes, manifBytes}
+		if data != nil {
+			params = append(params, data)
+		}
+		script, err := smartcontract.CreateCallScript(management.Hash, "deploy", params...)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := act.Run(script)
+		if err != nil {
+			return nil, fmt.Errorf("test invocation failed: %w", err)
+		}
+		if inv.State != vmstate.Halt.String() {
+			return nil, fmt.Errorf("test invocation failed: %s", inv.FaultException)
+		}
+		res.Invocation = inv
+		return res, nil
+	}
+
+	records, err := ReadDeployRecords(c.RecordsPath())
+	if err != nil {
+		return nil, err
+	}
+	rec, haveRec := records[network]
+	if haveRec && rec.Hash == hash {
+		res.Record = rec
+	}
+	record := func(st string) error {
+		res.Record.State = st
+		return appendDeployRecord(c.RecordsPath(), res.Record)
+	}
+
+	if _, err := cl.GetContractStateByHash(hash); err == nil {
+		res.Skipped = true
+		if res.Record.State != DeployStateDeployed {
+			return res, record(DeployStateDeployed)
+		}
+		return res, nil
+	}
+	// Transaction was sent previously, check whether it's still alive.
+	if res.Record.State == DeployStateSent {
+		if _, err := cl.GetRawTransaction(res.Record.TxID); err == nil {
+			return res, c.awaitDeploy(act, res, record)
+		}
+	}
+
+	txid, vub, err := management.New(act).Deploy(nefFile, m, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send deployment transaction: %w", err)
+	}
+	res.Record.TxID = txid
+	res.Record.VUB = vub
+	if err := record(DeployStateSent); err != nil {
+		return res, err
+	}
+	return res, c.awaitDeploy(act, res, record)
+}
+
+// awaitDeploy waits for the deployment transaction from the result and
+// records its outcome.
+func (c *DeployConfig) awaitDeploy(act *actor.Actor, res *DeployResult, record func(string) error) error {
+	aer, err := act.Wait(res.Record.TxID, res.Record.VUB, nil)
+	if err != nil {
+		return fmt.Errorf("failed to await deployment transaction %s: %w", res.Record.TxID.StringLE(), err)
+	}
+	if aer.VMState != vmstate.Halt {
+		if err := record(DeployStateFailed); err != nil {
+			return err
+		}
+		return fmt.Errorf("deployment transaction %s failed: %s", res.Record.TxID.StringLE(), aer.FaultException)
+	}
+	return record(DeployStateDeployed)
+}
+
+// contractDeployConfig deploys contract to networks from the deployment
+// configuration file.
+func contractDeployConfig(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	if !ctx.IsSet("config") {
+		return cli.NewExitError("deployment config file is required (--config)", 1)
+	}
+	cfg, err := ParseDeployConfig(ctx.String("config"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	networks := ctx.StringSlice("network")
+	if len(networks) == 0 {
+		networks = cfg.NetworkNames()
+	}
+	for _, name := range networks {
+		if _, ok := cfg.Networks[name]; !ok {
+			return cli.NewExitError(fmt.Errorf("unknown network %s", name), 1)
+		}
+	}
+	for _, name := range networks {
+		res, err := deployConfigNetwork(ctx, cfg, name)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("%s: %w", name, err), 1)
+		}
+		switch {
+		case res.Invocation != nil:
+			fmt.Fprintf(ctx.App.Writer, "%s: contract %s can be deployed (GAS: %s)\n", name,
+				res.Record.Hash.StringLE(), fixedn.Fixed8(res.Invocation.GasConsumed))
+		case res.Skipped:
+			fmt.Fprintf(ctx.App.Writer, "%s: contract %s is already deployed\n", name, res.Record.Hash.StringLE())
+		default:
+			fmt.Fprintf(ctx.App.Writer, "%s: contract %s deployed (tx %s)\n", name,
+				res.Record.Hash.StringLE(), res.Record.TxID.StringLE())
+		}
+	}
+	return nil
+}
+
+// deployConfigNetwork deploys contract to a single network.
+func deployConfigNetwork(ctx *cli.Context, cfg *DeployConfig, name string) (*DeployResult, error) {
+	gctx, cancel := context.WithTimeout(context.Background(), ctx.Duration("timeout"))
+	defer cancel()
+
+	acc, w, err := cfg.Account(name)
+	if err != nil {
+		return nil, fmt.Errorf("can't get sender account: %w", err)
+	}
+	defer w.Close()
+
+	cl, err := rpcclient.New(gctx, cfg.Networks[name].RPC, rpcclient.Options{})
+	if err != nil {
+		return nil, err
+	}
+	defer cl.Close()
+	if err := cl.Init(); err != nil {
+		return nil, err
+	}
+	act, err := actor.NewSimple(cl, acc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create actor: %w", err)
+	}
+	return cfg.DeployToNetwork(cl, act, name, ctx.Bool("dry-run"))
+}
@@ -0,0 +1,210 @@
+package smartcontract_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/smartcontract"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestParseDeployConfig(t *testing.T) {
+	env := map[string]string{
+		"RPC":   "http://localhost:20332",
+		"MAGIC": "860833102",
+		"OWNER": "NfgHwwTi3wHAS8aFAN243C5vGbkYDpqLHP",
+	}
+	lookup := func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	cfg, err := smartcontract.ParseDeployConfigYAML([]byte(`
+nef: contract.nef
+manifest: /abs/contract.manifest.json
+networks:
+  mainnet:
+    rpc: ${RPC}
+    magic: ${MAGIC}
+    wallet: wallet.json
+    data:
+      - hash160:$OWNER
+      - "$$OWNER"
+  testnet:
+    rpc: http://localhost:30333
+    walletconfig: wallet.yml
+`), "dir", lookup)
+	require.NoError(t, err)
+	require.Equal(t, []string{"mainnet", "testnet"}, cfg.NetworkNames())
+	require.Equal(t, filepath.Join("dir", smartcontract.DefaultDeployRecords), cfg.RecordsPath())
+
+	mainnet := cfg.Networks["mainnet"]
+	require.Equal(t, "http://localhost:20332", mainnet.RPC)
+	require.Equal(t, netmode.MainNet, mainnet.Magic)
+	require.Equal(t, yaml.SequenceNode, mainnet.Data.Kind)
+	require.Equal(t, "hash160:NfgHwwTi3wHAS8aFAN243C5vGbkYDpqLHP", mainnet.Data.Content[0].Value)
+	require.Equal(t, "$OWNER", mainnet.Data.Content[1].Value)
+	require.Equal(t, yaml.Kind(0), cfg.Networks["testnet"].Data.Kind)
+
+	for name, cfg := range map[string]string{
+		"undefined variable": "nef: a.nef\nmanifest: a.json\nnetworks: {n: {rpc: $UNDEFINED, wallet: w.json}}",
+		"no nef":             "manifest: a.json\nnetworks: {n: {rpc: ${RPC}, wallet: w.json}}",
+		"no networks":        "nef: a.nef\nmanifest: a.json",
+		"no rpc":             "nef: a.nef\nmanifest: a.json\nnetworks: {n: {wallet: w.json}}",
+		"no wallet":          "nef: a.nef\nmanifest: a.json\nnetworks: {n: {rpc: ${RPC}}}",
+		"both wallets":       "nef: a.nef\nmanifest: a.json\nnetworks: {n: {rpc: ${RPC}, wallet: w.json, walletconfig: w.yml}}",
+		"bad magic":          "nef: a.nef\nmanifest: a.json\nnetworks: {n: {rpc: ${RPC}, wallet: w.json, magic: ${OWNER}}}",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := smartcontract.ParseDeployConfigYAML([]byte(cfg), "", lookup)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestReadDeployRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "records.jsonl")
+	recs, err := smartcontract.ReadDeployRecords(path)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(recs))
+
+	var data []byte
+	for _, r := range []smartcontract.DeployRecord{
+		{Network: "a", State: smartcontract.DeployStateSent, TxID: util.Uint256{1}},
+		{Network: "b", State: smartcontract.DeployStateDeployed, TxID: util.Uint256{2}},
+		{Network: "a", State: smartcontract.DeployStateFailed, TxID: util.Uint256{1}},
+	} {
+		b, err := json.Marshal(r)
+		require.NoError(t, err)
+		data = append(append(data, b...), '\n')
+	}
+	require.NoError(t, os.WriteFile(path, data, 0o644))
+	recs, err = smartcontract.ReadDeployRecords(path)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(recs))
+	require.Equal(t, smartcontract.DeployStateFailed, recs["a"].State)
+	require.Equal(t, smartcontract.DeployStateDeployed, recs["b"].State)
+
+	require.NoError(t, os.WriteFile(path, append(data, "garbage\n"...), 0o644))
+	_, err = smartcontract.ReadDeployRecords(path)
+	require.ErrorContains(t, err, "line 4")
+}
+
+func TestContractDeployConfig(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	e.Run(t, "neo-go", "contract", "compile",
+		"--in", "testdata/deploy/main.go",
+		"--config", "testdata/deploy/neo-go.yml",
+		"--out", nefName, "--manifest", manifestName)
+
+	nefBytes, err := os.ReadFile(nefName)
+	require.NoError(t, err)
+	nefFile, err := nef.FileFromBytes(nefBytes)
+	require.NoError(t, err)
+	manifBytes, err := os.ReadFile(manifestName)
+	require.NoError(t, err)
+	m := new(manifest.Manifest)
+	require.NoError(t, json.Unmarshal(manifBytes, m))
+	h := state.CreateContractHash(testcli.ValidatorHash, nefFile.Checksum, m.Name)
+
+	walletPath, err := filepath.Abs(testcli.ValidatorWallet)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "wallet.yml"),
+		[]byte(fmt.Sprintf("Path: %s\nPassword: %s\n", walletPath, testcli.ValidatorPass)), 0o644))
+
+	t.Setenv("DEPLOY_TEST_RPC", "http://"+e.RPC.Addresses()[0])
+	cfgPath := filepath.Join(tmpDir, "deploy.yml")
+	writeConfig := func(t *testing.T, magic netmode.Magic, hash string) {
+		cfg := fmt.Sprintf(`nef: deploy.nef
+manifest: deploy.manifest.json
+networks:
+  local:
+    rpc: ${DEPLOY_TEST_RPC}
+    magic: %d
+    walletconfig: wallet.yml
+    address: %s
+    hash: "%s"
+    data: [key1, int:12, key2, take_me_to_church]
+`, magic, testcli.ValidatorAddr, hash)
+		require.NoError(t, os.WriteFile(cfgPath, []byte(cfg), 0o644))
+	}
+	recordsPath := filepath.Join(tmpDir, smartcontract.DefaultDeployRecords)
+	magic := e.Chain.GetConfig().Magic
+
+	t.Run("missing config", func(t *testing.T) {
+		e.RunWithErrorCheck(t, "deployment config file is required", "neo-go", "contract", "deploy-config")
+	})
+	t.Run("unknown network", func(t *testing.T) {
+		writeConfig(t, magic, "")
+		e.RunWithErrorCheck(t, "unknown network", "neo-go", "contract", "deploy-config", "-c", cfgPath, "-n", "nonexistent")
+	})
+	t.Run("magic mismatch", func(t *testing.T) {
+		writeConfig(t, magic+1, "")
+		e.RunWithErrorCheck(t, "network magic mismatch", "neo-go", "contract", "deploy-config", "-c", cfgPath)
+	})
+	t.Run("hash mismatch", func(t *testing.T) {
+		writeConfig(t, magic, util.Uint160{1, 2, 3}.StringLE())
+		e.RunWithErrorCheck(t, "contract hash mismatch", "neo-go", "contract", "deploy-config", "-c", cfgPath)
+	})
+
+	writeConfig(t, magic, h.StringLE())
+	t.Run("dry run", func(t *testing.T) {
+		e.Run(t, "neo-go", "contract", "deploy-config", "-c", cfgPath, "--dry-run")
+		e.CheckNextLine(t, "^local: contract "+h.StringLE()+" can be deployed")
+		e.CheckEOF(t)
+		_, err := os.Stat(recordsPath)
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	e.Run(t, "neo-go", "contract", "deploy-config", "-c", cfgPath)
+	line := e.GetNextLine(t)
+	require.True(t, strings.HasPrefix(line, "local: contract "+h.StringLE()+" deployed (tx "), line)
+	e.CheckEOF(t)
+
+	recs, err := smartcontract.ReadDeployRecords(recordsPath)
+	require.NoError(t, err)
+	rec := recs["local"]
+	require.Equal(t, smartcontract.DeployStateDeployed, rec.State)
+	require.Equal(t, h, rec.Hash)
+	aer, err := e.Chain.GetAppExecResults(rec.TxID, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Halt, aer[0].VMState)
+
+	e.Run(t, "neo-go", "contract", "testinvokefunction",
+		"--rpc-endpoint", "http://"+e.RPC.Addresses()[0],
+		h.StringLE(), "getValueWithKey", "key1")
+	res := new(result.Invoke)
+	require.NoError(t, json.Unmarshal(e.Out.Bytes(), res))
+	require.Equal(t, vmstate.Halt.String(), res.State, res.FaultException)
+	require.Equal(t, []byte{12}, res.Stack[0].Value())
+	e.Out.Reset()
+
+	t.Run("resume", func(t *testing.T) {
+		height := e.Chain.BlockHeight()
+		e.Run(t, "neo-go", "contract", "deploy-config", "-c", cfgPath, "-n", "local")
+		e.CheckNextLine(t, "^local: contract "+h.StringLE()+" is already deployed")
+		e.CheckEOF(t)
+		require.Equal(t, height, e.Chain.BlockHeight())
+
+		data, err := os.ReadFile(recordsPath)
+		require.NoError(t, err)
+		require.Equal(t, 2, strings.Count(string(data), "\n")) // Sent and deployed.
+	})
+}
@@ -0,0 +1,16 @@
+package smartcontract
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/artifact"
+)
+
+// SetArtifactStorage replaces NeoFS artifact storage constructor until the
+// end of the test.
+func SetArtifactStorage(t *testing.T, f func(endpoint string, key *keys.PrivateKey) artifact.Storage) {
+	old := newArtifactStorage
+	newArtifactStorage = f
+	t.Cleanup(func() { newArtifactStorage = old })
+}
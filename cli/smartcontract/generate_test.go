@@ -454,6 +454,44 @@ func TestAssistedRPCBindings(t *testing.T) {
 	require.False(t, rewriteExpectedOutputs)
 }
 
+func TestExtendedTypesRPCBindings(t *testing.T) {
+	tmpDir := t.TempDir()
+	app := cli.NewApp()
+	app.Commands = NewCommands()
+
+	source := filepath.Join("testdata", "rpcbindings", "extended")
+	configFile := filepath.Join(source, "config.yml")
+	expectedFile := filepath.Join(source, "rpc", "extended.go")
+	manifestF := filepath.Join(tmpDir, "manifest.json")
+	outFile := filepath.Join(tmpDir, "out.go")
+	require.NoError(t, app.Run([]string{"", "contract", "compile",
+		"--in", source,
+		"--config", configFile,
+		"--manifest", manifestF,
+		"--out", filepath.Join(tmpDir, "out.nef"),
+		"--extended-types",
+	}))
+	// No bindings configuration, everything is taken from the manifest.
+	require.NoError(t, app.Run([]string{"", "contract", "generate-rpcwrapper",
+		"--manifest", manifestF,
+		"--out", outFile,
+	}))
+
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err)
+	data = bytes.ReplaceAll(data, []byte("\r"), []byte{}) // Windows.
+	if rewriteExpectedOutputs {
+		require.NoError(t, os.WriteFile(expectedFile, data, os.ModePerm))
+	} else {
+		expected, err := os.ReadFile(expectedFile)
+		require.NoError(t, err)
+		expected = bytes.ReplaceAll(expected, []byte("\r"), []byte{}) // Windows.
+		require.Equal(t, string(expected), string(data))
+	}
+
+	require.False(t, rewriteExpectedOutputs)
+}
+
 func TestGenerate_Errors(t *testing.T) {
 	app := cli.NewApp()
 	app.Commands = []cli.Command{generateWrapperCmd}
@@ -0,0 +1,898 @@
+package smartcontract
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+)
+
+// defaultContractTemplate is the template used by `init` command if none is
+// specified.
+const defaultContractTemplate = "basic"
+
+// contractTemplate describes a project generated by `init` command.
+type contractTemplate struct {
+	// source is the contract code template.
+	source string
+	// test is the contract test code template.
+	test string
+	// standards are the standards supported by the contract.
+	standards []string
+	// safeMethods are the contract methods marked as safe.
+	safeMethods []string
+	// events are the events emitted by the contract.
+	events []compiler.HybridEvent
+}
+
+// contractTemplateData is used to render contractTemplate files.
+type contractTemplateData struct {
+	// Name is the contract (and Go module) name.
+	Name string
+	// Package is the Go package name.
+	Package string
+}
+
+// contractTemplates contains all templates available for `init` command.
+var contractTemplates = map[string]contractTemplate{
+	"basic": {
+		source:      basicContractTmpl,
+		test:        basicContractTestTmpl,
+		safeMethods: []string{"get", "owner"},
+		events: []compiler.HybridEvent{
+			ownerChangedEvent,
+			newEvent("ValueChanged",
+				manifest.Parameter{Name: "key", Type: smartcontract.StringType},
+				manifest.Parameter{Name: "value", Type: smartcontract.StringType}),
+		},
+	},
+	"nep17": {
+		source:      nep17ContractTmpl,
+		test:        nep17ContractTestTmpl,
+		standards:   []string{manifest.NEP17StandardName},
+		safeMethods: []string{"balanceOf", "decimals", "owner", "symbol", "totalSupply"},
+		events: []compiler.HybridEvent{
+			ownerChangedEvent,
+			newEvent("Transfer",
+				manifest.Parameter{Name: "from", Type: smartcontract.Hash160Type},
+				manifest.Parameter{Name: "to", Type: smartcontract.Hash160Type},
+				manifest.Parameter{Name: "amount", Type: smartcontract.IntegerType}),
+		},
+	},
+	"nft": {
+		source:    nftContractTmpl,
+		test:      nftContractTestTmpl,
+		standards: []string{manifest.NEP11StandardName},
+		safeMethods: []string{"balanceOf", "decimals", "owner", "ownerOf", "properties",
+			"symbol", "tokens", "tokensOf", "totalSupply"},
+		events: []compiler.HybridEvent{
+			ownerChangedEvent,
+			newEvent("Transfer",
+				manifest.Parameter{Name: "from", Type: smartcontract.Hash160Type},
+				manifest.Parameter{Name: "to", Type: smartcontract.Hash160Type},
+				manifest.Parameter{Name: "amount", Type: smartcontract.IntegerType},
+				manifest.Parameter{Name: "tokenId", Type: smartcontract.ByteArrayType}),
+		},
+	},
+	"oracle": {
+		source:      oracleContractTmpl,
+		test:        oracleContractTestTmpl,
+		safeMethods: []string{"getResult", "owner"},
+		events: []compiler.HybridEvent{
+			ownerChangedEvent,
+			newEvent("OracleResponse",
+				manifest.Parameter{Name: "url", Type: smartcontract.StringType},
+				manifest.Parameter{Name: "code", Type: smartcontract.IntegerType},
+				manifest.Parameter{Name: "result", Type: smartcontract.ByteArrayType}),
+		},
+	},
+}
+
+// ownerChangedEvent is emitted by all templates on owner change.
+var ownerChangedEvent = newEvent("OwnerChanged",
+	manifest.Parameter{Name: "newOwner", Type: smartcontract.Hash160Type})
+
+func newEvent(name string, params ...manifest.Parameter) compiler.HybridEvent {
+	ev := compiler.HybridEvent{
+		Name:       name,
+		Parameters: make([]compiler.HybridParameter, 0, len(params)),
+	}
+	for _, p := range params {
+		ev.Parameters = append(ev.Parameters, compiler.HybridParameter{Parameter: p})
+	}
+	return ev
+}
+
+// config returns the contract configuration for the given contract name.
+func (t contractTemplate) config(name string) ProjectConfig {
+	standards := t.standards
+	if standards == nil {
+		standards = []string{}
+	}
+	return ProjectConfig{
+		Name:               name,
+		SourceURL:          "http://example.com/",
+		SupportedStandards: standards,
+		SafeMethods:        t.safeMethods,
+		Events:             t.events,
+		Permissions:        []permission{permission(*manifest.NewPermission(manifest.PermissionWildcard))},
+	}
+}
+
+const (
+	// goModTmpl is written to go.mod file of the project.
+	goModTmpl = `module {{.Name}}
+
+go 1.20
+
+require (
+	github.com/nspcc-dev/neo-go {{.NeoGoVersion}}
+	github.com/nspcc-dev/neo-go/pkg/interop {{.InteropVersion}}
+)
+`
+
+	// makefileTmpl is written to the Makefile of the project, it contains
+	// targets for compiling, testing and deploying the contract.
+	makefileTmpl = `NEOGO ?= neo-go
+NAME = {{.Name}}
+RPC ?= http://localhost:20331
+WALLET ?= wallet.json
+
+.PHONY: all deps build test deploy clean
+
+all: build
+
+deps:
+	go mod tidy
+
+build: deps
+	$(NEOGO) contract compile -i . -c neo-go.yml -o $(NAME).nef -m $(NAME).manifest.json
+
+test: deps
+	go test ./...
+
+deploy: build
+	$(NEOGO) contract deploy -i $(NAME).nef -m $(NAME).manifest.json -r $(RPC) -w $(WALLET) --await
+
+clean:
+	rm -f $(NAME).nef $(NAME).manifest.json
+`
+
+	// ownerMethodsTmpl contains owner-related methods shared by all contract
+	// templates. ownerKey constant must be defined by the contract.
+	ownerMethodsTmpl = `
+// Owner returns the contract owner.
+func Owner() interop.Hash160 {
+	return storage.Get(storage.GetReadOnlyContext(), ownerKey).(interop.Hash160)
+}
+
+// SetOwner changes the contract owner, it can only be called by the current
+// one.
+func SetOwner(newOwner interop.Hash160) {
+	ctx := storage.GetContext()
+	checkOwner(ctx)
+	if len(newOwner) != interop.Hash160Len {
+		panic("invalid owner")
+	}
+	storage.Put(ctx, ownerKey, newOwner)
+	runtime.Notify("OwnerChanged", newOwner)
+}
+
+// Update updates the contract, it can only be called by the owner.
+func Update(nef []byte, manifest string, data any) {
+	checkOwner(storage.GetReadOnlyContext())
+	management.UpdateWithData(nef, []byte(manifest), data)
+}
+
+// initOwner saves the contract owner on deployment. The owner can be passed as
+// deployment data, the transaction sender is used otherwise.
+func initOwner(ctx storage.Context, data any) interop.Hash160 {
+	owner := runtime.GetScriptContainer().Sender
+	if data != nil {
+		owner = data.(interop.Hash160)
+	}
+	if len(owner) != interop.Hash160Len {
+		panic("invalid owner")
+	}
+	storage.Put(ctx, ownerKey, owner)
+	return owner
+}
+
+// checkOwner panics if the transaction is not witnessed by the contract owner.
+func checkOwner(ctx storage.Context) {
+	owner := storage.Get(ctx, ownerKey).(interop.Hash160)
+	if !runtime.CheckWitness(owner) {
+		panic("not witnessed by the owner")
+	}
+}
+`
+
+	// testHelpersTmpl contains helpers shared by all test templates.
+	testHelpersTmpl = `
+// newContract deploys the contract to a new test chain, the committee
+// account becomes the contract owner.
+func newContract(t *testing.T) (*neotest.ContractInvoker, *neotest.Contract) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := neotest.CompileFile(t, e.CommitteeHash, ".", "neo-go.yml")
+	e.DeployContract(t, c, nil)
+	return e.CommitteeInvoker(c.Hash), c
+}
+
+func TestOwner(t *testing.T) {
+	c, _ := newContract(t)
+	c.Invoke(t, stackitem.NewBuffer(c.CommitteeHash.BytesBE()), "owner")
+
+	acc := c.NewAccount(t)
+	c.WithSigners(acc).InvokeFail(t, "not witnessed by the owner", "setOwner", acc.ScriptHash())
+	c.InvokeFail(t, "invalid owner", "setOwner", []byte{1, 2, 3})
+
+	h := c.Invoke(t, stackitem.Null{}, "setOwner", acc.ScriptHash())
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "OwnerChanged",
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(acc.ScriptHash())}),
+	})
+	c.Invoke(t, stackitem.NewBuffer(acc.ScriptHash().BytesBE()), "owner")
+	c.InvokeFail(t, "not witnessed by the owner", "setOwner", c.CommitteeHash)
+}
+
+func TestUpdate(t *testing.T) {
+	c, ctr := newContract(t)
+	nefBytes, err := ctr.NEF.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifestBytes, err := json.Marshal(ctr.Manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c.WithSigners(c.NewAccount(t)).InvokeFail(t, "not witnessed by the owner", "update", nefBytes, string(manifestBytes), nil)
+	c.Invoke(t, stackitem.Null{}, "update", nefBytes, string(manifestBytes), nil)
+	c.Invoke(t, stackitem.NewBuffer(c.CommitteeHash.BytesBE()), "owner")
+}
+`
+)
+
+const (
+	basicContractTmpl = `// Package {{.Package}} contains {{.Name}} smart contract, a simple key-value
+// storage managed by the contract owner.
+package {{.Package}}
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Storage keys and prefixes used by the contract.
+const (
+	ownerKey    = "o"
+	valuePrefix = "v"
+)
+
+// _deploy initializes the contract on deployment.
+func _deploy(data any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	initOwner(storage.GetContext(), data)
+}
+
+// Put stores the value under the given key, it can only be called by the
+// owner.
+func Put(key string, value string) {
+	ctx := storage.GetContext()
+	checkOwner(ctx)
+	storage.Put(ctx, valuePrefix+key, value)
+	runtime.Notify("ValueChanged", key, value)
+}
+
+// Get returns the value stored under the given key (or nothing if there is
+// none).
+func Get(key string) string {
+	return storage.Get(storage.GetReadOnlyContext(), valuePrefix+key).(string)
+}
+` + ownerMethodsTmpl
+
+	basicContractTestTmpl = `package {{.Package}}_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func TestPutGet(t *testing.T) {
+	c, _ := newContract(t)
+	c.Invoke(t, stackitem.Null{}, "get", "key")
+
+	c.WithSigners(c.NewAccount(t)).InvokeFail(t, "not witnessed by the owner", "put", "key", "value")
+
+	h := c.Invoke(t, stackitem.Null{}, "put", "key", "value")
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "ValueChanged",
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make("key"), stackitem.Make("value")}),
+	})
+	c.Invoke(t, "value", "get", "key")
+}
+` + testHelpersTmpl
+
+	nep17ContractTmpl = `// Package {{.Package}} contains {{.Name}} NEP-17 token contract.
+package {{.Package}}
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Token parameters.
+const (
+	symbol   = "TOKEN"
+	decimals = 8
+	// initialSupply is minted to the owner on deployment.
+	initialSupply = 1_000_000_00000000
+)
+
+// Storage keys and prefixes used by the contract.
+const (
+	ownerKey      = "o"
+	supplyKey     = "s"
+	balancePrefix = "b"
+)
+
+// _deploy initializes the contract on deployment and mints the initial supply
+// to the owner.
+func _deploy(data any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	ctx := storage.GetContext()
+	owner := initOwner(ctx, data)
+	mint(ctx, owner, initialSupply)
+}
+
+// Symbol returns the token symbol.
+func Symbol() string {
+	return symbol
+}
+
+// Decimals returns the token decimals.
+func Decimals() int {
+	return decimals
+}
+
+// TotalSupply returns the token total supply.
+func TotalSupply() int {
+	return getInt(storage.GetReadOnlyContext(), supplyKey)
+}
+
+// BalanceOf returns the token balance of the given account.
+func BalanceOf(account interop.Hash160) int {
+	if len(account) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	return getInt(storage.GetReadOnlyContext(), mkBalanceKey(account))
+}
+
+// Transfer transfers the specified amount of tokens from one account to
+// another.
+func Transfer(from, to interop.Hash160, amount int, data any) bool {
+	if len(from) != interop.Hash160Len || len(to) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	if amount < 0 {
+		panic("negative amount")
+	}
+	if !runtime.CheckWitness(from) {
+		return false
+	}
+	ctx := storage.GetContext()
+	fromBalance := getInt(ctx, mkBalanceKey(from))
+	if fromBalance < amount {
+		return false
+	}
+	if amount != 0 && !from.Equals(to) {
+		putBalance(ctx, from, fromBalance-amount)
+		putBalance(ctx, to, getInt(ctx, mkBalanceKey(to))+amount)
+	}
+	postTransfer(from, to, amount, data)
+	return true
+}
+
+// Mint mints the specified amount of tokens to the given account, it can only
+// be called by the owner.
+func Mint(to interop.Hash160, amount int) {
+	ctx := storage.GetContext()
+	checkOwner(ctx)
+	if len(to) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	if amount <= 0 {
+		panic("invalid amount")
+	}
+	mint(ctx, to, amount)
+}
+
+func mint(ctx storage.Context, to interop.Hash160, amount int) {
+	putBalance(ctx, to, getInt(ctx, mkBalanceKey(to))+amount)
+	storage.Put(ctx, supplyKey, getInt(ctx, supplyKey)+amount)
+	postTransfer(nil, to, amount, nil)
+}
+
+// postTransfer emits Transfer event and calls onNEP17Payment if needed.
+func postTransfer(from, to interop.Hash160, amount int, data any) {
+	runtime.Notify("Transfer", from, to, amount)
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP17Payment", contract.All, from, amount, data)
+	}
+}
+
+func putBalance(ctx storage.Context, account interop.Hash160, balance int) {
+	if balance == 0 {
+		storage.Delete(ctx, mkBalanceKey(account))
+		return
+	}
+	storage.Put(ctx, mkBalanceKey(account), balance)
+}
+
+func getInt(ctx storage.Context, key any) int {
+	val := storage.Get(ctx, key)
+	if val == nil {
+		return 0
+	}
+	return val.(int)
+}
+
+func mkBalanceKey(account interop.Hash160) []byte {
+	return append([]byte(balancePrefix), account...)
+}
+` + ownerMethodsTmpl
+
+	nep17ContractTestTmpl = `package {{.Package}}_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+const initialSupply = 1_000_000_00000000
+
+func TestTokenInfo(t *testing.T) {
+	c, _ := newContract(t)
+	c.Invoke(t, "TOKEN", "symbol")
+	c.Invoke(t, 8, "decimals")
+	c.Invoke(t, initialSupply, "totalSupply")
+	c.Invoke(t, initialSupply, "balanceOf", c.CommitteeHash)
+	c.Invoke(t, 0, "balanceOf", c.NewAccount(t).ScriptHash())
+	c.InvokeFail(t, "invalid account", "balanceOf", []byte{1, 2, 3})
+}
+
+func TestTransfer(t *testing.T) {
+	c, _ := newContract(t)
+	acc := c.NewAccount(t)
+
+	h := c.Invoke(t, true, "transfer", c.CommitteeHash, acc.ScriptHash(), 100, nil)
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "Transfer",
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Make(c.CommitteeHash),
+			stackitem.Make(acc.ScriptHash()),
+			stackitem.Make(100),
+		}),
+	})
+	c.Invoke(t, initialSupply-100, "balanceOf", c.CommitteeHash)
+	c.Invoke(t, 100, "balanceOf", acc.ScriptHash())
+
+	// Not witnessed.
+	c.Invoke(t, false, "transfer", acc.ScriptHash(), c.CommitteeHash, 1, nil)
+	// Insufficient funds.
+	c.WithSigners(acc).Invoke(t, false, "transfer", acc.ScriptHash(), c.CommitteeHash, 101, nil)
+	c.WithSigners(acc).InvokeFail(t, "negative amount", "transfer", acc.ScriptHash(), c.CommitteeHash, -1, nil)
+
+	c.WithSigners(acc).Invoke(t, true, "transfer", acc.ScriptHash(), c.CommitteeHash, 100, nil)
+	c.Invoke(t, initialSupply, "balanceOf", c.CommitteeHash)
+	c.Invoke(t, 0, "balanceOf", acc.ScriptHash())
+}
+
+func TestMint(t *testing.T) {
+	c, _ := newContract(t)
+	acc := c.NewAccount(t)
+
+	c.WithSigners(acc).InvokeFail(t, "not witnessed by the owner", "mint", acc.ScriptHash(), 10)
+	c.InvokeFail(t, "invalid amount", "mint", acc.ScriptHash(), 0)
+
+	h := c.Invoke(t, stackitem.Null{}, "mint", acc.ScriptHash(), 10)
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "Transfer",
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Null{},
+			stackitem.Make(acc.ScriptHash()),
+			stackitem.Make(10),
+		}),
+	})
+	c.Invoke(t, 10, "balanceOf", acc.ScriptHash())
+	c.Invoke(t, initialSupply+10, "totalSupply")
+}
+` + testHelpersTmpl
+
+	nftContractTmpl = `// Package {{.Package}} contains {{.Name}} NEP-11 non-divisible token
+// contract.
+package {{.Package}}
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/crypto"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// symbol is the token symbol.
+const symbol = "NFT"
+
+// Storage keys and prefixes used by the contract.
+const (
+	ownerKey      = "o"
+	supplyKey     = "s"
+	balancePrefix = "b"
+	// tokenPrefix maps token ID to its owner.
+	tokenPrefix = "t"
+	// namePrefix maps token ID to its name.
+	namePrefix = "n"
+	// accountPrefix maps account and token ID to token ID.
+	accountPrefix = "a"
+)
+
+// _deploy initializes the contract on deployment.
+func _deploy(data any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	initOwner(storage.GetContext(), data)
+}
+
+// Symbol returns the token symbol.
+func Symbol() string {
+	return symbol
+}
+
+// Decimals returns the token decimals, NFTs are not divisible.
+func Decimals() int {
+	return 0
+}
+
+// TotalSupply returns the number of tokens minted.
+func TotalSupply() int {
+	return getInt(storage.GetReadOnlyContext(), supplyKey)
+}
+
+// BalanceOf returns the number of tokens owned by the given account.
+func BalanceOf(owner interop.Hash160) int {
+	if len(owner) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	return getInt(storage.GetReadOnlyContext(), mkKey(balancePrefix, owner))
+}
+
+// OwnerOf returns the owner of the given token.
+func OwnerOf(tokenId []byte) interop.Hash160 {
+	return getTokenOwner(storage.GetReadOnlyContext(), tokenId)
+}
+
+// Properties returns the properties of the given token.
+func Properties(tokenId []byte) map[string]any {
+	ctx := storage.GetReadOnlyContext()
+	getTokenOwner(ctx, tokenId)
+	return map[string]any{
+		"name": storage.Get(ctx, mkKey(namePrefix, tokenId)),
+	}
+}
+
+// Tokens returns an iterator over all token IDs.
+func Tokens() iterator.Iterator {
+	return storage.Find(storage.GetReadOnlyContext(), []byte(tokenPrefix), storage.KeysOnly|storage.RemovePrefix)
+}
+
+// TokensOf returns an iterator over IDs of tokens owned by the given account.
+func TokensOf(owner interop.Hash160) iterator.Iterator {
+	if len(owner) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	return storage.Find(storage.GetReadOnlyContext(), mkKey(accountPrefix, owner), storage.ValuesOnly)
+}
+
+// Transfer transfers the token to the given account.
+func Transfer(to interop.Hash160, tokenId []byte, data any) bool {
+	if len(to) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	ctx := storage.GetContext()
+	from := getTokenOwner(ctx, tokenId)
+	if !runtime.CheckWitness(from) {
+		return false
+	}
+	if !from.Equals(to) {
+		storage.Put(ctx, mkKey(tokenPrefix, tokenId), to)
+		removeToken(ctx, from, tokenId)
+		addToken(ctx, to, tokenId)
+	}
+	postTransfer(from, to, tokenId, data)
+	return true
+}
+
+// Mint creates a new token with the given name for the specified account and
+// returns its ID, it can only be called by the owner.
+func Mint(to interop.Hash160, name string) []byte {
+	ctx := storage.GetContext()
+	checkOwner(ctx)
+	if len(to) != interop.Hash160Len {
+		panic("invalid account")
+	}
+	if len(name) == 0 {
+		panic("empty name")
+	}
+	tokenID := []byte(crypto.Sha256([]byte(name)))
+	if storage.Get(ctx, mkKey(tokenPrefix, tokenID)) != nil {
+		panic("token already exists")
+	}
+	storage.Put(ctx, mkKey(tokenPrefix, tokenID), to)
+	storage.Put(ctx, mkKey(namePrefix, tokenID), name)
+	storage.Put(ctx, supplyKey, getInt(ctx, supplyKey)+1)
+	addToken(ctx, to, tokenID)
+	postTransfer(nil, to, tokenID, nil)
+	return tokenID
+}
+
+func getTokenOwner(ctx storage.Context, tokenID []byte) interop.Hash160 {
+	owner := storage.Get(ctx, mkKey(tokenPrefix, tokenID))
+	if owner == nil {
+		panic("unknown token")
+	}
+	return owner.(interop.Hash160)
+}
+
+func addToken(ctx storage.Context, owner interop.Hash160, tokenID []byte) {
+	storage.Put(ctx, append(mkKey(accountPrefix, owner), tokenID...), tokenID)
+	storage.Put(ctx, mkKey(balancePrefix, owner), getInt(ctx, mkKey(balancePrefix, owner))+1)
+}
+
+func removeToken(ctx storage.Context, owner interop.Hash160, tokenID []byte) {
+	storage.Delete(ctx, append(mkKey(accountPrefix, owner), tokenID...))
+	balance := getInt(ctx, mkKey(balancePrefix, owner)) - 1
+	if balance == 0 {
+		storage.Delete(ctx, mkKey(balancePrefix, owner))
+		return
+	}
+	storage.Put(ctx, mkKey(balancePrefix, owner), balance)
+}
+
+// postTransfer emits Transfer event and calls onNEP11Payment if needed.
+func postTransfer(from, to interop.Hash160, tokenID []byte, data any) {
+	runtime.Notify("Transfer", from, to, 1, tokenID)
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP11Payment", contract.All, from, 1, tokenID, data)
+	}
+}
+
+func getInt(ctx storage.Context, key any) int {
+	val := storage.Get(ctx, key)
+	if val == nil {
+		return 0
+	}
+	return val.(int)
+}
+
+func mkKey(prefix string, data []byte) []byte {
+	return append([]byte(prefix), data...)
+}
+` + ownerMethodsTmpl
+
+	nftContractTestTmpl = `package {{.Package}}_test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func tokenID(name string) []byte {
+	h := sha256.Sum256([]byte(name))
+	return h[:]
+}
+
+// checkIterator ensures that the method returns an iterator over the expected
+// token IDs.
+func checkIterator(t *testing.T, c *neotest.ContractInvoker, expected [][]byte, method string, args ...any) {
+	s, err := c.TestInvoke(t, method, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iter := s.Pop().Interop().Value().(*storage.Iterator)
+	var actual []stackitem.Item
+	for iter.Next() {
+		actual = append(actual, iter.Value())
+	}
+	exp := make([]stackitem.Item, 0, len(expected))
+	for _, id := range expected {
+		exp = append(exp, stackitem.Make(id))
+	}
+	neotest.CheckStack(t, exp, actual)
+}
+
+func TestTokenInfo(t *testing.T) {
+	c, _ := newContract(t)
+	c.Invoke(t, "NFT", "symbol")
+	c.Invoke(t, 0, "decimals")
+	c.Invoke(t, 0, "totalSupply")
+	c.Invoke(t, 0, "balanceOf", c.CommitteeHash)
+	c.InvokeFail(t, "invalid account", "balanceOf", []byte{1, 2, 3})
+}
+
+func TestMint(t *testing.T) {
+	c, _ := newContract(t)
+	acc := c.NewAccount(t)
+	id := tokenID("first")
+
+	c.WithSigners(acc).InvokeFail(t, "not witnessed by the owner", "mint", acc.ScriptHash(), "first")
+	c.InvokeFail(t, "empty name", "mint", acc.ScriptHash(), "")
+
+	h := c.Invoke(t, stackitem.NewBuffer(id), "mint", acc.ScriptHash(), "first")
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "Transfer",
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Null{},
+			stackitem.Make(acc.ScriptHash()),
+			stackitem.Make(1),
+			stackitem.Make(id),
+		}),
+	})
+	c.InvokeFail(t, "token already exists", "mint", acc.ScriptHash(), "first")
+
+	c.Invoke(t, 1, "totalSupply")
+	c.Invoke(t, 1, "balanceOf", acc.ScriptHash())
+	c.Invoke(t, stackitem.NewBuffer(acc.ScriptHash().BytesBE()), "ownerOf", id)
+	props := stackitem.NewMap()
+	props.Add(stackitem.Make("name"), stackitem.Make("first"))
+	c.Invoke(t, props, "properties", id)
+	c.InvokeFail(t, "unknown token", "ownerOf", tokenID("unknown"))
+	c.InvokeFail(t, "unknown token", "properties", tokenID("unknown"))
+	checkIterator(t, c, [][]byte{id}, "tokens")
+	checkIterator(t, c, [][]byte{id}, "tokensOf", acc.ScriptHash())
+}
+
+func TestTransfer(t *testing.T) {
+	c, _ := newContract(t)
+	acc := c.NewAccount(t)
+	id := tokenID("token")
+	c.Invoke(t, stackitem.NewBuffer(id), "mint", c.CommitteeHash, "token")
+
+	c.WithSigners(acc).Invoke(t, false, "transfer", acc.ScriptHash(), id, nil)
+	c.InvokeFail(t, "unknown token", "transfer", acc.ScriptHash(), tokenID("unknown"), nil)
+
+	h := c.Invoke(t, true, "transfer", acc.ScriptHash(), id, nil)
+	c.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "Transfer",
+		Item: stackitem.NewArray([]stackitem.Item{
+			stackitem.Make(c.CommitteeHash),
+			stackitem.Make(acc.ScriptHash()),
+			stackitem.Make(1),
+			stackitem.Make(id),
+		}),
+	})
+	c.Invoke(t, stackitem.NewBuffer(acc.ScriptHash().BytesBE()), "ownerOf", id)
+	c.Invoke(t, 0, "balanceOf", c.CommitteeHash)
+	c.Invoke(t, 1, "balanceOf", acc.ScriptHash())
+	checkIterator(t, c, nil, "tokensOf", c.CommitteeHash)
+	checkIterator(t, c, [][]byte{id}, "tokensOf", acc.ScriptHash())
+}
+` + testHelpersTmpl
+
+	oracleContractTmpl = `// Package {{.Package}} contains {{.Name}} oracle consumer contract, it
+// requests data from external sources and stores oracle responses.
+package {{.Package}}
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/oracle"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+
+// Storage keys and prefixes used by the contract.
+const (
+	ownerKey     = "o"
+	resultPrefix = "r"
+)
+
+// _deploy initializes the contract on deployment.
+func _deploy(data any, isUpdate bool) {
+	if isUpdate {
+		return
+	}
+	initOwner(storage.GetContext(), data)
+}
+
+// Request creates an oracle request for the given URL with an optional
+// JSONPath filter, it can only be called by the owner.
+func Request(url string, filter string) {
+	checkOwner(storage.GetReadOnlyContext())
+	var f []byte
+	if len(filter) != 0 {
+		f = []byte(filter)
+	}
+	oracle.Request(url, f, "onOracleResponse", nil, oracle.MinimumResponseGas)
+}
+
+// OnOracleResponse is called by the Oracle contract with the response to the
+// request made previously, successful results are saved.
+func OnOracleResponse(url string, userData any, code int, result []byte) {
+	if !runtime.GetCallingScriptHash().Equals(oracle.Hash) {
+		panic("not called by oracle")
+	}
+	if code == oracle.Success {
+		storage.Put(storage.GetContext(), resultPrefix+url, result)
+	}
+	runtime.Notify("OracleResponse", url, code, result)
+}
+
+// GetResult returns the last successful oracle response for the given URL.
+func GetResult(url string) []byte {
+	return storage.Get(storage.GetReadOnlyContext(), resultPrefix+url).([]byte)
+}
+` + ownerMethodsTmpl
+
+	oracleContractTestTmpl = `package {{.Package}}_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+func TestRequest(t *testing.T) {
+	c, _ := newContract(t)
+
+	c.WithSigners(c.NewAccount(t)).InvokeFail(t, "not witnessed by the owner", "request", "https://example.com/", "")
+	c.Invoke(t, stackitem.Null{}, "request", "https://example.com/", "$.value")
+	c.Invoke(t, stackitem.Null{}, "request", "https://example.com/", "")
+	c.Invoke(t, stackitem.Null{}, "getResult", "https://example.com/")
+}
+
+func TestOnOracleResponse(t *testing.T) {
+	c, _ := newContract(t)
+	c.InvokeFail(t, "not called by oracle", "onOracleResponse", "https://example.com/", nil, 0, []byte("result"))
+}
+` + testHelpersTmpl
+)
@@ -0,0 +1,85 @@
+package smartcontract_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/smartcontract"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInitTemplates generates a project for every contract template, then
+// compiles it and runs its tests against the current neo-go code.
+func TestInitTemplates(t *testing.T) {
+	// The actual versions are replaced below.
+	smartcontract.ModVersion = "v0.0.0"
+	smartcontract.NeoGoModVersion = "v0.0.0"
+	// Generated projects are standalone modules.
+	t.Setenv("GOWORK", "off")
+
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go binary is not available")
+	}
+	root, err := filepath.Abs("../..")
+	require.NoError(t, err)
+
+	e := testcli.NewExecutor(t, false)
+	t.Run("unknown template", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "contract", "init", "--name", filepath.Join(t.TempDir(), "ctr"), "--template", "unknown")
+	})
+	t.Run("unknown neo-go version", func(t *testing.T) {
+		smartcontract.NeoGoModVersion = ""
+		defer func() { smartcontract.NeoGoModVersion = "v0.0.0" }()
+		ver := config.Version
+		config.Version = "0.106.4-pre-12-g1234abcd"
+		defer func() { config.Version = ver }()
+
+		ctrPath := filepath.Join(t.TempDir(), "ctr")
+		e.RunWithErrorCheck(t, "can't determine neo-go module version", "neo-go", "contract", "init", "--name", ctrPath)
+		require.NoDirExists(t, ctrPath)
+	})
+
+	for _, name := range []string{"basic", "nep17", "nft", "oracle"} {
+		t.Run(name, func(t *testing.T) {
+			ctrPath := filepath.Join(t.TempDir(), "testcontract")
+			e.Run(t, "neo-go", "contract", "init", "--name", ctrPath, "--template", name)
+			e.CheckNextLine(t, "Successfully initialized smart contract")
+			for _, f := range []string{"go.mod", "main.go", "main_test.go", "neo-go.yml", "Makefile"} {
+				require.FileExists(t, filepath.Join(ctrPath, f))
+			}
+
+			goMod := filepath.Join(ctrPath, "go.mod")
+			data, err := os.ReadFile(goMod)
+			require.NoError(t, err)
+			data = append(data, "\nreplace github.com/nspcc-dev/neo-go => "+root+"\n"...)
+			data = append(data, "\nreplace github.com/nspcc-dev/neo-go/pkg/interop => "+filepath.Join(root, "pkg", "interop")+"\n"...)
+			require.NoError(t, os.WriteFile(goMod, data, os.ModePerm))
+			// Dependencies are the same as for neo-go itself.
+			sum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(ctrPath, "go.sum"), sum, os.ModePerm))
+
+			goCmd := func(args ...string) {
+				cmd := exec.Command(goBin, args...)
+				cmd.Dir = ctrPath
+				cmd.Env = append(os.Environ(), "GOFLAGS=-mod=mod")
+				out, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(out))
+			}
+
+			e.Run(t, "neo-go", "contract", "compile",
+				"--in", ctrPath,
+				"--config", filepath.Join(ctrPath, "neo-go.yml"),
+				"--out", filepath.Join(ctrPath, "testcontract.nef"),
+				"--manifest", filepath.Join(ctrPath, "testcontract.manifest.json"))
+			e.CheckEOF(t)
+
+			goCmd("test", "./...")
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -92,6 +93,24 @@ func readNEFFile(filename string) (*nef.File, []byte, error) {
 	return &nefFile, f, nil
 }
 
+// readBundle reads and verifies contract bundle, it returns NEF and manifest
+// along with their serialized forms.
+func readBundle(filename string) (*nef.File, []byte, *manifest.Manifest, []byte, error) {
+	b, err := bundle.ReadFile(filename)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	nefBytes, err := b.NEF.Bytes()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	mBytes, err := json.Marshal(b.Manifest)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return b.NEF, nefBytes, b.Manifest, mBytes, nil
+}
+
 // readManifest unmarshalls manifest got from the provided filename and checks
 // it for validness against the provided contract hash. If empty hash is specified
 // then no hash-related manifest groups check is performed.
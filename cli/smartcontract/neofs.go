@@ -0,0 +1,123 @@
+package smartcontract
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/flags"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/artifact"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/urfave/cli"
+)
+
+// newArtifactStorage creates NeoFS storage for contract artifacts, it's
+// replaced in tests.
+var newArtifactStorage = artifact.NewNeoFSStorage
+
+var errNoNeoFSEndpoint = errors.New("no NeoFS endpoint was provided, specify it with the '--neofs-endpoint' flag")
+
+var neofsFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "neofs-endpoint",
+		Usage: "NeoFS node address used for neofs:// URIs",
+	},
+	cli.DurationFlag{
+		Name:  "neofs-timeout",
+		Value: artifact.DefaultTimeout,
+		Usage: "timeout for a single NeoFS request",
+	},
+	cli.StringFlag{
+		Name:  "neofs-cache",
+		Usage: "directory to cache artifacts fetched from NeoFS in",
+	},
+}
+
+var publishFlags = append([]cli.Flag{
+	cli.StringFlag{
+		Name:  "publish",
+		Usage: "publish compiled NEF and manifest to the given NeoFS container (neofs://<container-ID>)",
+	},
+	flags.AddressFlag{
+		Name:  addressFlagName,
+		Usage: "account to sign NeoFS objects with (used with --publish)",
+	},
+}, options.Wallet...)
+
+// getArtifactClient returns artifact client configured from the context,
+// requests are signed with the given key.
+func getArtifactClient(ctx *cli.Context, key *keys.PrivateKey) (*artifact.Client, error) {
+	endpoint := ctx.String("neofs-endpoint")
+	if len(endpoint) == 0 {
+		return nil, errNoNeoFSEndpoint
+	}
+	return &artifact.Client{
+		Storage:  newArtifactStorage(endpoint, key),
+		Timeout:  ctx.Duration("neofs-timeout"),
+		CacheDir: ctx.String("neofs-cache"),
+	}, nil
+}
+
+// fetchArtifact downloads contract NEF and manifest from NeoFS and returns
+// them along with their serialized representations.
+func fetchArtifact(ctx *cli.Context, uri string) (*nef.File, []byte, *manifest.Manifest, []byte, error) {
+	// Reading public objects doesn't require any specific key.
+	key, err := keys.NewPrivateKey()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	c, err := getArtifactClient(ctx, key)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	n, m, err := c.Fetch(context.Background(), uri)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	nefBytes, err := n.Bytes()
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	mBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return n, nefBytes, m, mBytes, nil
+}
+
+// publishArtifact uploads NEF and manifest files to the NeoFS container
+// specified with --publish flag and prints the resulting URI.
+func publishArtifact(ctx *cli.Context, nefFile string, manifestFile string) error {
+	n, _, err := readNEFFile(nefFile)
+	if err != nil {
+		return fmt.Errorf("can't read NEF file: %w", err)
+	}
+	m, _, err := readManifest(manifestFile, util.Uint160{})
+	if err != nil {
+		return fmt.Errorf("can't read contract manifest: %w", err)
+	}
+	acc, w, err := options.GetAccFromContext(ctx)
+	if err != nil {
+		return fmt.Errorf("can't get account to sign NeoFS objects with: %w", err)
+	}
+	defer w.Close()
+	key := acc.PrivateKey()
+	if key == nil {
+		return fmt.Errorf("account %s can't sign", acc.Address)
+	}
+	c, err := getArtifactClient(ctx, key)
+	if err != nil {
+		return err
+	}
+	uri, err := c.Publish(context.Background(), ctx.String("publish"), n, m)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.App.Writer, "Published: %s\n", uri)
+	return nil
+}
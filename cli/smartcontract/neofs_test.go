@@ -0,0 +1,192 @@
+package smartcontract_test
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/smartcontract"
+	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/artifact"
+	apistatus "github.com/nspcc-dev/neofs-sdk-go/client/status"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+// neofsMock is an in-memory NeoFS with a single publicly readable container
+// writable by a single key.
+type neofsMock struct {
+	lock    sync.Mutex
+	cnr     cid.ID
+	owner   *keys.PublicKey
+	objects map[oid.Address][]byte
+	hang    bool
+}
+
+type neofsMockClient struct {
+	*neofsMock
+	key *keys.PrivateKey
+}
+
+func newNeoFSMock(t *testing.T, owner *keys.PublicKey) *neofsMock {
+	m := &neofsMock{
+		cnr:     cidtest.ID(),
+		owner:   owner,
+		objects: make(map[oid.Address][]byte),
+	}
+	smartcontract.SetArtifactStorage(t, func(endpoint string, key *keys.PrivateKey) artifact.Storage {
+		require.Equal(t, "localhost:8080", endpoint)
+		return &neofsMockClient{neofsMock: m, key: key}
+	})
+	return m
+}
+
+func (c *neofsMockClient) Get(ctx context.Context, addr oid.Address) ([]byte, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	data, ok := c.objects[addr]
+	if !ok {
+		return nil, apistatus.ErrObjectNotFound
+	}
+	return data, nil
+}
+
+func (c *neofsMockClient) Put(ctx context.Context, cnr cid.ID, payload []byte, attrs map[string]string) (oid.ID, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if cnr != c.cnr {
+		return oid.ID{}, apistatus.ErrContainerNotFound
+	}
+	if !c.key.PublicKey().Equal(c.owner) {
+		return oid.ID{}, apistatus.ErrObjectAccessDenied
+	}
+	var addr oid.Address
+	addr.SetContainer(cnr)
+	addr.SetObject(oidtest.ID())
+	c.objects[addr] = payload
+	return addr.Object(), nil
+}
+
+func TestContractNeoFSPublishDeploy(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	tmpDir := t.TempDir()
+	nefName := filepath.Join(tmpDir, "deploy.nef")
+	manifestName := filepath.Join(tmpDir, "deploy.manifest.json")
+	fs := newNeoFSMock(t, testcli.ValidatorPriv.PublicKey())
+	cnrURI := "neofs://" + fs.cnr.EncodeToString()
+
+	compile := func(args ...string) []string {
+		return append([]string{"neo-go", "contract", "compile",
+			"--in", "testdata/deploy/main.go",
+			"--config", "testdata/deploy/neo-go.yml",
+			"--out", nefName, "--manifest", manifestName,
+		}, args...)
+	}
+	validatorArgs := []string{"--neofs-endpoint", "localhost:8080",
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr}
+	t.Run("publish", func(t *testing.T) {
+		t.Run("no endpoint", func(t *testing.T) {
+			e.In.WriteString(testcli.ValidatorPass + "\r")
+			e.RunWithErrorCheck(t, "no NeoFS endpoint", compile(
+				"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+				"--publish", cnrURI)...)
+		})
+		t.Run("bad URI", func(t *testing.T) {
+			e.In.WriteString(testcli.ValidatorPass + "\r")
+			e.RunWithErrorCheck(t, artifact.ErrInvalidURI.Error(), compile(append(validatorArgs,
+				"--publish", cnrURI+"/"+oidtest.ID().EncodeToString())...)...)
+		})
+		t.Run("unknown container", func(t *testing.T) {
+			e.In.WriteString(testcli.ValidatorPass + "\r")
+			e.RunWithErrorCheck(t, artifact.ErrNotFound.Error(), compile(append(validatorArgs,
+				"--publish", "neofs://"+cidtest.ID().EncodeToString())...)...)
+		})
+		t.Run("access denied", func(t *testing.T) {
+			e.In.WriteString("testpass\r")
+			e.RunWithErrorCheck(t, artifact.ErrAccessDenied.Error(), compile(
+				"--neofs-endpoint", "localhost:8080",
+				"--wallet", testcli.TestWalletPath, "--address", testcli.TestWalletAccount,
+				"--publish", cnrURI)...)
+		})
+	})
+
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, compile(append(validatorArgs, "--publish", cnrURI)...)...)
+	line, err := e.Out.ReadString('\n')
+	require.NoError(t, err)
+	uri := strings.TrimSpace(strings.TrimPrefix(line, "Published: "))
+	require.True(t, strings.HasPrefix(uri, cnrURI+"/"), uri)
+
+	deploy := []string{"neo-go", "contract", "deploy",
+		"--rpc-endpoint", "http://" + e.RPC.Addresses()[0],
+		"--wallet", testcli.ValidatorWallet, "--address", testcli.ValidatorAddr,
+		"--neofs-endpoint", "localhost:8080",
+		"--force",
+	}
+	t.Run("deploy errors", func(t *testing.T) {
+		t.Run("with manifest", func(t *testing.T) {
+			e.RunWithErrorCheck(t, "--manifest can't be used with NeoFS URI", append(deploy, "--in", uri, "--manifest", manifestName)...)
+		})
+		t.Run("no object", func(t *testing.T) {
+			e.RunWithErrorCheck(t, artifact.ErrInvalidURI.Error(), append(deploy, "--in", cnrURI)...)
+		})
+		t.Run("not found", func(t *testing.T) {
+			e.RunWithErrorCheck(t, artifact.ErrNotFound.Error(), append(deploy, "--in", cnrURI+"/"+oidtest.ID().EncodeToString())...)
+		})
+		t.Run("timeout", func(t *testing.T) {
+			fs.hang = true
+			defer func() { fs.hang = false }()
+			e.RunWithErrorCheck(t, artifact.ErrTimeout.Error(), append(deploy, "--in", uri, "--neofs-timeout", "10ms")...)
+		})
+		t.Run("checksum mismatch", func(t *testing.T) {
+			var tampered = "neofs://" + fs.cnr.EncodeToString() + "/" + oidtest.ID().EncodeToString()
+			_, obj, err := artifact.ParseURI(uri)
+			require.NoError(t, err)
+			_, tObj, err := artifact.ParseURI(tampered)
+			require.NoError(t, err)
+			var addr, tAddr oid.Address
+			addr.SetContainer(fs.cnr)
+			addr.SetObject(*obj)
+			tAddr.SetContainer(fs.cnr)
+			tAddr.SetObject(*tObj)
+
+			b := new(artifact.Bundle)
+			require.NoError(t, json.Unmarshal(fs.objects[addr], b))
+			b.Manifest = append(b.Manifest[:len(b.Manifest)-1], []byte(`,"unknown":1}`)...)
+			fs.objects[tAddr], err = json.Marshal(b)
+			require.NoError(t, err)
+			e.RunWithErrorCheck(t, artifact.ErrChecksumMismatch.Error(), append(deploy, "--in", tampered)...)
+		})
+	})
+
+	cacheDir := t.TempDir()
+	e.In.WriteString(testcli.ValidatorPass + "\r")
+	e.Run(t, append(deploy, "--in", uri, "--neofs-cache", cacheDir)...)
+	e.CheckTxPersisted(t)
+	line, err = e.Out.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "Contract: "), line)
+
+	t.Run("cached", func(t *testing.T) {
+		// NeoFS is not available, but the contract is cached and gets to
+		// the chain.
+		fs.hang = true
+		defer func() { fs.hang = false }()
+		e.In.WriteString(testcli.ValidatorPass + "\r")
+		e.Run(t, append(deploy, "--in", uri, "--neofs-cache", cacheDir, "--neofs-timeout", "10ms")...)
+		line, err := e.Out.ReadString('\n')
+		require.NoError(t, err)
+		require.Contains(t, line, "contract already exists")
+	})
+}
@@ -1,27 +1,35 @@
 package smartcontract
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"runtime/debug"
 	"strings"
+	"text/template"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/artifact"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -47,31 +55,23 @@ var (
 		Name:  addressFlagName,
 		Usage: "address to use as transaction signee (and gas source)",
 	}
+	paramsFileFlag = cli.StringFlag{
+		Name:  "params-file",
+		Usage: "YAML file with method parameters (used instead of arguments)",
+	}
 )
 
 // ModVersion contains `pkg/interop` module version
 // suitable to be used in go.mod.
 var ModVersion string
 
-const (
-	// smartContractTmpl is written to a file when used with `init` command.
-	// %s is parsed to be the smartContractName.
-	smartContractTmpl = `package %s
-
-import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
-
-var notificationName string
-
-// init initializes notificationName before calling any other smart-contract method
-func init() {
-	notificationName = "Hello world!"
-}
+// NeoGoModVersion contains neo-go module version suitable to be used in
+// go.mod. If not set, the release version of the running binary is used,
+// `contract init` fails if it's not known.
+var NeoGoModVersion string
 
-// RuntimeNotify sends runtime notification with "Hello world!" name
-func RuntimeNotify(args []any) {
-    runtime.Notify(notificationName, args)
-}`
-)
+// releaseVersion matches release versions of neo-go module.
+var releaseVersion = regexp.MustCompile(`^v[0-9]+\.[0-9]+\.[0-9]+$`)
 
 // NewCommands returns 'contract' command.
 func NewCommands() []cli.Command {
@@ -83,10 +83,11 @@ func NewCommands() []cli.Command {
 		options.Historic,
 	}
 	testInvokeScriptFlags = append(testInvokeScriptFlags, options.RPC...)
-	testInvokeFunctionFlags := []cli.Flag{options.Historic}
+	testInvokeFunctionFlags := []cli.Flag{options.Historic, paramsFileFlag}
 	testInvokeFunctionFlags = append(testInvokeFunctionFlags, options.RPC...)
 	invokeFunctionFlags := []cli.Flag{
 		addressFlag,
+		paramsFileFlag,
 		txctx.GasFlag,
 		txctx.SysGasFlag,
 		txctx.OutFlag,
@@ -98,13 +99,14 @@ func NewCommands() []cli.Command {
 	deployFlags := append(invokeFunctionFlags, []cli.Flag{
 		cli.StringFlag{
 			Name:  "in, i",
-			Usage: "Input file for the smart contract (*.nef)",
+			Usage: "Input file for the smart contract (*.nef or *.nefbundle) or NeoFS URI of the published contract (neofs://<container-ID>/<object-ID>)",
 		},
 		cli.StringFlag{
 			Name:  "manifest, m",
 			Usage: "Manifest input file (*.manifest.json)",
 		},
 	}...)
+	deployFlags = append(deployFlags, neofsFlags...)
 	manifestAddGroupFlags := append([]cli.Flag{
 		cli.StringFlag{
 			Name:  "sender, s",
@@ -130,17 +132,30 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "compile",
 				Usage:     "compile a smart contract to a .nef file",
-				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes]",
+				UsageText: "neo-go contract compile -i path [-o nef] [-v] [-d] [-m manifest] [-c yaml] [--bindings file] [--init-report file] [--bundle file] [--no-standards] [--no-events] [--no-permissions] [--guess-eventtypes] [--extended-types] [--diagnostics json] [--publish neofs://cid --neofs-endpoint addr -w wallet [-a address]]",
 				Description: `Compiles given smart contract to a .nef file and emits other associated
    information (manifest, bindings configuration, debug information files) if
    asked to. If none of --out, --manifest, --config, --bindings flags are specified,
    then the output filenames for these flags will be guessed using the contract
    name or path provided via --in option by trimming/adding corresponding suffixes
    to the common part of the path. In the latter case the configuration filepath
-   will be guessed from the --in option using the same rule.
+   will be guessed from the --in option using the same rule. If --diagnostics
+   json is specified, all compiler errors and warnings are printed to the
+   standard output as a JSON array with their positions in the source code.
+   If --init-report is specified, the list of global variables and init()
+   functions executed by '_initialize' method along with the size of their
+   code is stored into the given file in JSON format.
+   If --bundle is specified, NEF, manifest and debug info are also stored into
+   the given single bundle file (*.nefbundle) along with their checksums, it
+   requires configuration file and can be used instead of NEF and manifest
+   files for deploy command and VM CLI.
+   If --publish is specified, compiled NEF and manifest are uploaded to the
+   given NeoFS container as a single object signed by the wallet account and
+   its neofs://<container-ID>/<object-ID> URI is printed, this URI can then
+   be used as an input for the deploy command.
 `,
 				Action: contractCompile,
-				Flags: []cli.Flag{
+				Flags: append(append([]cli.Flag{
 					cli.StringFlag{
 						Name:  "in, i",
 						Usage: "Input file for the smart contract to be compiled (*.go file or directory)",
@@ -181,11 +196,27 @@ func NewCommands() []cli.Command {
 						Name:  "guess-eventtypes",
 						Usage: "guess event types for smart-contract bindings configuration from the code usages",
 					},
+					cli.BoolFlag{
+						Name:  "extended-types",
+						Usage: "store extended types of methods and events in manifest extra data for RPC bindings generation",
+					},
 					cli.StringFlag{
 						Name:  "bindings",
 						Usage: "output file for smart-contract bindings configuration",
 					},
-				},
+					cli.StringFlag{
+						Name:  "init-report",
+						Usage: "output file for '_initialize' method report (JSON)",
+					},
+					cli.StringFlag{
+						Name:  "bundle",
+						Usage: "output file for NEF, manifest and debug info bundle (*.nefbundle)",
+					},
+					cli.StringFlag{
+						Name:  "diagnostics",
+						Usage: "print all compiler diagnostics (errors and warnings) in the specified format (only 'json' is supported)",
+					},
+				}, publishFlags...), neofsFlags...),
 			},
 			{
 				Name:      "deploy",
@@ -196,16 +227,82 @@ func NewCommands() []cli.Command {
    parameter is an optional parameter to be passed to '_deploy' method. When
    --await flag is specified, it waits for the transaction to be included 
    in a block.
+
+   Instead of a NEF file --in can be a neofs://<container-ID>/<object-ID> URI
+   of the contract published with 'contract compile --publish', in this case
+   NEF and manifest are fetched from the NeoFS node specified with
+   --neofs-endpoint, their checksum and consistency are verified and --manifest
+   must not be given. Fetched artifacts can be cached locally in the
+   --neofs-cache directory.
+
+   --in can also be a contract bundle (*.nefbundle) created with
+   'contract compile --bundle', its checksums and consistency are verified
+   and --manifest must not be given.
 `,
 				Action: contractDeploy,
 				Flags:  deployFlags,
 			},
+			{
+				Name:      "deploy-config",
+				Usage:     "deploy a smart contract to networks from the deployment configuration file",
+				UsageText: "neo-go contract deploy-config --config deploy.yml [--network name] [--dry-run] [--timeout duration]",
+				Description: `Deploys contract to all networks (or to the ones specified with --network,
+   it can be given multiple times) from the deployment configuration file
+   (deploy.yml). Every network section specifies RPC endpoint, sender wallet
+   and account, optional network magic and contract hash to check against
+   and '_deploy' data parameter in the params file format:
+
+     nef: contract.nef
+     manifest: contract.manifest.json
+     records: deploy.records.jsonl
+     networks:
+       testnet:
+         rpc: ${TESTNET_RPC}
+         magic: 894710606
+         walletconfig: testnet-wallet.yml
+         address: NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB
+         hash: 0x1234...
+         data:
+           - hash160:${OWNER}
+           - 42
+
+   ${NAME} references in values are substituted with environment variables
+   (undefined ones are an error, $$ can be used for $). Relative paths are
+   resolved against the configuration file directory.
+
+   Every deployment step is appended to the record file (deploy.records.jsonl
+   by default) along with contract hash and transaction ID, so interrupted
+   deployment can be resumed by running the same command again: contracts
+   that are already deployed are skipped and sent transactions are awaited.
+   With --dry-run the deployment is only test-invoked and nothing is recorded.
+`,
+				Action: contractDeployConfig,
+				Flags: []cli.Flag{
+					cli.StringFlag{
+						Name:  "config, c",
+						Usage: "deployment configuration file (deploy.yml)",
+					},
+					cli.StringSliceFlag{
+						Name:  "network, n",
+						Usage: "network to deploy to (all by default)",
+					},
+					cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "only test-invoke deployment",
+					},
+					cli.DurationFlag{
+						Name:  "timeout, s",
+						Value: options.DefaultAwaitableTimeout,
+						Usage: "timeout for every network deployment",
+					},
+				},
+			},
 			generateWrapperCmd,
 			generateRPCWrapperCmd,
 			{
 				Name:      "invokefunction",
 				Usage:     "invoke deployed contract on the blockchain",
-				UsageText: "neo-go contract invokefunction -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] [--out file] [--force] [--await] scripthash [method] [arguments...] [--] [signers...]",
+				UsageText: "neo-go contract invokefunction -r endpoint -w wallet [-a address] [-g gas] [-e sysgas] [--out file] [--force] [--await] [--params-file file] scripthash [method] [arguments...] [--] [signers...]",
 				Description: `Executes given (as a script hash) deployed script with the given method,
    arguments and signers. Sender is included in the list of signers by default
    with None witness scope. If you'd like to change default sender's scope, 
@@ -220,7 +317,7 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "testinvokefunction",
 				Usage:     "invoke deployed contract on the blockchain (test mode)",
-				UsageText: "neo-go contract testinvokefunction -r endpoint [--historic index/hash] scripthash [method] [arguments...] [--] [signers...]",
+				UsageText: "neo-go contract testinvokefunction -r endpoint [--historic index/hash] [--params-file file] scripthash [method] [arguments...] [--] [signers...]",
 				Description: `Executes given (as a script hash) deployed script with the given method,
    arguments and signers (sender is not included by default). If no method is given
    "" is passed to the script, if no arguments are given, an empty array is 
@@ -232,6 +329,8 @@ func NewCommands() []cli.Command {
 
 ` + cmdargs.ParamsParsingDoc + `
 
+` + cmdargs.ParamsFileDoc + `
+
 ` + cmdargs.SignersParsingDoc + `
 `,
 				Action: testInvokeFunction,
@@ -251,13 +350,30 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "init",
 				Usage:     "initialize a new smart-contract in a directory with boiler plate code",
-				UsageText: "neo-go contract init -n name [--skip-details]",
-				Action:    initSmartContract,
+				UsageText: "neo-go contract init -n name [--template name] [--skip-details]",
+				Description: `Creates a new smart contract project in the directory with the given
+   name. The project contains contract code with storage, events, _deploy and
+   owner check examples, neotest-based tests for all contract methods, the
+   contract configuration (neo-go.yml), go.mod and a Makefile with targets
+   to build, test and deploy the contract. Use 'make test' to run the tests.
+
+   The contract code is based on the template specified with --template flag:
+     basic  - simple key-value storage managed by the owner (default)
+     nep17  - NEP-17 fungible token
+     nft    - NEP-11 non-divisible token
+     oracle - oracle consumer storing oracle responses
+`,
+				Action: initSmartContract,
 				Flags: []cli.Flag{
 					cli.StringFlag{
 						Name:  "name, n",
 						Usage: "name of the smart-contract to be initialized",
 					},
+					cli.StringFlag{
+						Name:  "template, t",
+						Usage: "contract template to use (basic, nep17, nft or oracle)",
+						Value: defaultContractTemplate,
+					},
 					cli.BoolFlag{
 						Name:  "skip-details, skip",
 						Usage: "skip filling in the projects and contract details",
@@ -326,42 +442,33 @@ func initSmartContract(ctx *cli.Context) error {
 	if contractName == "" {
 		return cli.NewExitError(errNoSmartContractName, 1)
 	}
+	tmplName := ctx.String("template")
+	if tmplName == "" {
+		tmplName = defaultContractTemplate
+	}
+	tmpl, ok := contractTemplates[tmplName]
+	if !ok {
+		return cli.NewExitError(fmt.Errorf("unknown contract template: %s", tmplName), 1)
+	}
 
 	// Check if the file already exists, if yes, exit
 	if _, err := os.Stat(contractName); err == nil {
 		return cli.NewExitError(errFileExist, 1)
 	}
+	neoGoVer, err := neoGoModVersion()
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
 
 	basePath := contractName
 	contractName = filepath.Base(contractName)
-	fileName := "main.go"
 
 	// create base directory
 	if err := os.Mkdir(basePath, os.ModePerm); err != nil {
 		return cli.NewExitError(err, 1)
 	}
 
-	m := ProjectConfig{
-		Name:               contractName,
-		SourceURL:          "http://example.com/",
-		SupportedStandards: []string{},
-		SafeMethods:        []string{},
-		Events: []compiler.HybridEvent{
-			{
-				Name: "Hello world!",
-				Parameters: []compiler.HybridParameter{
-					{
-						Parameter: manifest.Parameter{
-							Name: "args",
-							Type: smartcontract.ArrayType,
-						},
-					},
-				},
-			},
-		},
-		Permissions: []permission{permission(*manifest.NewPermission(manifest.PermissionWildcard))},
-	}
-	b, err := yaml.Marshal(m)
+	b, err := yaml.Marshal(tmpl.config(contractName))
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
@@ -369,25 +476,36 @@ func initSmartContract(ctx *cli.Context) error {
 		return cli.NewExitError(err, 1)
 	}
 
-	ver := ModVersion
-	if ver == "" {
-		ver = "latest"
+	interopVer := ModVersion
+	if interopVer == "" {
+		interopVer = "latest"
 	}
-
-	gm := []byte("module " + contractName + `
-
-go 1.20
-
-require (
-	github.com/nspcc-dev/neo-go/pkg/interop ` + ver + `
-)`)
-	if err := os.WriteFile(filepath.Join(basePath, "go.mod"), gm, 0644); err != nil {
-		return cli.NewExitError(err, 1)
+	data := contractTemplateData{
+		Name:    contractName,
+		Package: contractName,
 	}
-
-	data := []byte(fmt.Sprintf(smartContractTmpl, contractName))
-	if err := os.WriteFile(filepath.Join(basePath, fileName), data, 0644); err != nil {
-		return cli.NewExitError(err, 1)
+	files := []struct {
+		name string
+		tmpl string
+		data any
+	}{
+		{"go.mod", goModTmpl, struct{ Name, NeoGoVersion, InteropVersion string }{contractName, neoGoVer, interopVer}},
+		{"main.go", tmpl.source, data},
+		{"main_test.go", tmpl.test, data},
+		{"Makefile", makefileTmpl, data},
+	}
+	for _, f := range files {
+		t, err := template.New(f.name).Parse(f.tmpl)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to parse %s template: %w", f.name, err), 1)
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, f.data); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to generate %s: %w", f.name, err), 1)
+		}
+		if err := os.WriteFile(filepath.Join(basePath, f.name), buf.Bytes(), 0644); err != nil {
+			return cli.NewExitError(err, 1)
+		}
 	}
 
 	fmt.Fprintf(ctx.App.Writer, "Successfully initialized smart contract [%s]\n", contractName)
@@ -395,6 +513,23 @@ require (
 	return nil
 }
 
+// neoGoModVersion returns neo-go module version to be used in go.mod of the
+// new contract project. Only release versions are used since development
+// builds can't be reliably matched with published module versions.
+func neoGoModVersion() (string, error) {
+	if NeoGoModVersion != "" {
+		return NeoGoModVersion, nil
+	}
+	if bi, ok := debug.ReadBuildInfo(); ok && bi.Main.Path == "github.com/nspcc-dev/neo-go" &&
+		releaseVersion.MatchString(bi.Main.Version) {
+		return bi.Main.Version, nil
+	}
+	if releaseVersion.MatchString("v" + config.Version) {
+		return "v" + config.Version, nil
+	}
+	return "", fmt.Errorf("can't determine neo-go module version for go.mod of the project: %q is not a release version, use a release build of neo-go", config.Version)
+}
+
 func contractCompile(ctx *cli.Context) error {
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
@@ -408,7 +543,12 @@ func contractCompile(ctx *cli.Context) error {
 	debugFile := ctx.String("debug")
 	out := ctx.String("out")
 	bindings := ctx.String("bindings")
-	if len(confFile) == 0 && (len(manifestFile) != 0 || len(debugFile) != 0 || len(bindings) != 0) {
+	bundleFile := ctx.String("bundle")
+	diagFormat := ctx.String("diagnostics")
+	if len(diagFormat) != 0 && diagFormat != "json" {
+		return cli.NewExitError(fmt.Errorf("unsupported diagnostics format: %s", diagFormat), 1)
+	}
+	if len(confFile) == 0 && (len(manifestFile) != 0 || len(debugFile) != 0 || len(bindings) != 0 || len(bundleFile) != 0) {
 		return cli.NewExitError(errNoConfFile, 1)
 	}
 	autocomplete := len(manifestFile) == 0 &&
@@ -442,12 +582,15 @@ func contractCompile(ctx *cli.Context) error {
 		DebugInfo:    debugFile,
 		ManifestFile: manifestFile,
 		BindingsFile: bindings,
+		InitReport:   ctx.String("init-report"),
+		BundleFile:   bundleFile,
 
 		NoStandardCheck:    ctx.Bool("no-standards"),
 		NoEventsCheck:      ctx.Bool("no-events"),
 		NoPermissionsCheck: ctx.Bool("no-permissions"),
 
 		GuessEventTypes: ctx.Bool("guess-eventtypes"),
+		ExtendedTypes:   ctx.Bool("extended-types"),
 	}
 
 	if len(confFile) != 0 {
@@ -460,21 +603,38 @@ func contractCompile(ctx *cli.Context) error {
 		o.ContractEvents = conf.Events
 		o.DeclaredNamedTypes = conf.NamedTypes
 		o.ContractSupportedStandards = conf.SupportedStandards
-		o.Permissions = make([]manifest.Permission, len(conf.Permissions))
-		for i := range conf.Permissions {
-			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+		if conf.Permissions != nil { // Inferred by the compiler otherwise.
+			o.Permissions = make([]manifest.Permission, len(conf.Permissions))
+			for i := range conf.Permissions {
+				o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+			}
 		}
 		o.SafeMethods = conf.SafeMethods
 		o.Overloads = conf.Overloads
 	}
 
-	result, err := compiler.CompileAndSave(src, o)
+	result, diags, err := compiler.CompileAndSaveWithDiagnostics(src, o)
+	if len(diagFormat) != 0 {
+		if diags == nil {
+			diags = []compiler.Diagnostic{}
+		}
+		data, jErr := json.Marshal(diags)
+		if jErr != nil {
+			return cli.NewExitError(fmt.Errorf("failed to marshal diagnostics: %w", jErr), 1)
+		}
+		fmt.Fprintln(ctx.App.Writer, string(data))
+	}
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
 	if ctx.Bool("verbose") {
 		fmt.Fprintln(ctx.App.Writer, hex.EncodeToString(result))
 	}
+	if ctx.IsSet("publish") {
+		if err := publishArtifact(ctx, out, manifestFile); err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to publish contract: %w", err), 1)
+		}
+	}
 
 	return nil
 }
@@ -532,6 +692,7 @@ func invokeInternal(ctx *cli.Context, signAndPush bool) error {
 		operation       string
 		params          []any
 		paramsStart     = 1
+		paramsFile      = ctx.String("params-file")
 		scParams        []smartcontract.Parameter
 		cosigners       []transaction.Signer
 		cosignersOffset = 0
@@ -551,16 +712,36 @@ func invokeInternal(ctx *cli.Context, signAndPush bool) error {
 	operation = args[1]
 	paramsStart++
 
-	if len(args) > paramsStart {
-		cosignersOffset, scParams, err = cmdargs.ParseParams(args[paramsStart:], true)
+	// Parameters are parsed here to check them and get their number, they're
+	// parsed once again when the method's parameter types are known.
+	parseParams := func(types []smartcontract.ParamType) ([]smartcontract.Parameter, error) {
+		if len(paramsFile) != 0 {
+			return cmdargs.ParseParamsFile(paramsFile, types)
+		}
+		if len(args) > paramsStart {
+			_, ps, err := cmdargs.ParseParamsWithTypes(args[paramsStart:], true, types)
+			return ps, err
+		}
+		return nil, nil
+	}
+	if len(paramsFile) != 0 {
+		if len(args) > paramsStart {
+			if args[paramsStart] != cmdargs.CosignersSeparator {
+				return cli.NewExitError(errors.New("parameters can't be specified both via --params-file and as arguments"), 1)
+			}
+			cosignersOffset = 1
+		}
+		scParams, err = cmdargs.ParseParamsFile(paramsFile, nil)
 		if err != nil {
 			return cli.NewExitError(err, 1)
 		}
-		params = make([]any, len(scParams))
-		for i := range scParams {
-			params[i] = scParams[i]
+	} else if len(args) > paramsStart {
+		cosignersOffset, scParams, err = cmdargs.ParseParams(args[paramsStart:], true)
+		if err != nil {
+			return cli.NewExitError(err, 1)
 		}
 	}
+	params = paramsToAny(scParams)
 
 	cosignersStart := paramsStart + cosignersOffset
 	cosigners, exitErr = cmdargs.GetSignersFromContext(ctx, cosignersStart)
@@ -580,15 +761,57 @@ func invokeInternal(ctx *cli.Context, signAndPush bool) error {
 		defer w.Close()
 	}
 
-	return invokeWithArgs(ctx, acc, w, script, operation, params, cosigners)
+	return invokeWithArgs(ctx, acc, w, script, operation, params, cosigners, func(types []smartcontract.ParamType) ([]any, error) {
+		ps, err := parseParams(types)
+		if err != nil {
+			return nil, err
+		}
+		return paramsToAny(ps), nil
+	})
+}
+
+// paramsToAny converts the given parameters to a list of invocation arguments.
+func paramsToAny(scParams []smartcontract.Parameter) []any {
+	if scParams == nil {
+		return nil
+	}
+	params := make([]any, len(scParams))
+	for i := range scParams {
+		params[i] = scParams[i]
+	}
+	return params
+}
+
+// getMethodParamTypes returns parameter types of the contract method with the
+// given number of parameters if the contract and the method are known to the
+// RPC node, nil is returned otherwise.
+func getMethodParamTypes(c *rpcclient.Client, script util.Uint160, method string, count int) []smartcontract.ParamType {
+	cs, err := c.GetContractStateByHash(script)
+	if err != nil {
+		return nil
+	}
+	m := cs.Manifest.ABI.GetMethod(method, count)
+	if m == nil {
+		return nil
+	}
+	types := make([]smartcontract.ParamType, len(m.Parameters))
+	for i := range m.Parameters {
+		types[i] = m.Parameters[i].Type
+	}
+	return types
 }
 
-func invokeWithArgs(ctx *cli.Context, acc *wallet.Account, wall *wallet.Wallet, script util.Uint160, operation string, params []any, cosigners []transaction.Signer) error {
+// invokeWithArgs invokes the given contract method with the given parameters.
+// If retype is not nil it's used to get parameters once again when the
+// method's parameter types are fetched from the RPC node.
+func invokeWithArgs(ctx *cli.Context, acc *wallet.Account, wall *wallet.Wallet, script util.Uint160, operation string, params []any, cosigners []transaction.Signer,
+	retype func(types []smartcontract.ParamType) ([]any, error)) error {
 	var (
 		err             error
 		signersAccounts []actor.SignerAccount
 		resp            *result.Invoke
 		signAndPush     = acc != nil
+		c               *rpcclient.Client
 		inv             *invoker.Invoker
 		act             *actor.Actor
 	)
@@ -601,17 +824,25 @@ func invokeWithArgs(ctx *cli.Context, acc *wallet.Account, wall *wallet.Wallet,
 	gctx, cancel := options.GetTimeoutContext(ctx)
 	defer cancel()
 	if signAndPush {
-		_, act, err = options.GetRPCWithActor(gctx, ctx, signersAccounts)
+		c, act, err = options.GetRPCWithActor(gctx, ctx, signersAccounts)
 		if err != nil {
 			return err
 		}
 		inv = &act.Invoker
 	} else {
-		_, inv, err = options.GetRPCWithInvoker(gctx, ctx, cosigners)
+		c, inv, err = options.GetRPCWithInvoker(gctx, ctx, cosigners)
 		if err != nil {
 			return err
 		}
 	}
+	if retype != nil {
+		if types := getMethodParamTypes(c, script, operation, len(params)); types != nil {
+			params, err = retype(types)
+			if err != nil {
+				return cli.NewExitError(err, 1)
+			}
+		}
+	}
 	out := ctx.String("out")
 	resp, err = inv.Call(script, operation, params...)
 	if err != nil {
@@ -737,23 +968,48 @@ func inspect(ctx *cli.Context) error {
 		}
 		b = nefFile.Script
 	}
-	v := vm.New()
-	v.LoadScript(b)
-	v.PrintOps(ctx.App.Writer)
+	instrs, err := vm.Disassemble(b, vm.DisassembleOptions{})
+	vm.WriteDisassembly(ctx.App.Writer, instrs, err)
 
 	return nil
 }
 
 // contractDeploy deploys contract.
 func contractDeploy(ctx *cli.Context) error {
-	nefFile, f, err := readNEFFile(ctx.String("in"))
-	if err != nil {
-		return cli.NewExitError(err, 1)
-	}
+	var (
+		nefFile       *nef.File
+		f             []byte
+		m             *manifest.Manifest
+		manifestBytes []byte
+		err           error
+		in            = ctx.String("in")
+	)
+	if artifact.IsURI(in) {
+		if ctx.IsSet("manifest") {
+			return cli.NewExitError("--manifest can't be used with NeoFS URI", 1)
+		}
+		nefFile, f, m, manifestBytes, err = fetchArtifact(ctx, in)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to fetch contract: %w", err), 1)
+		}
+	} else if bundle.IsBundle(in) {
+		if ctx.IsSet("manifest") {
+			return cli.NewExitError("--manifest can't be used with contract bundle", 1)
+		}
+		nefFile, f, m, manifestBytes, err = readBundle(in)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read contract bundle: %w", err), 1)
+		}
+	} else {
+		nefFile, f, err = readNEFFile(in)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
 
-	m, manifestBytes, err := readManifest(ctx.String("manifest"), util.Uint160{})
-	if err != nil {
-		return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		m, manifestBytes, err = readManifest(ctx.String("manifest"), util.Uint160{})
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("failed to read manifest file: %w", err), 1)
+		}
 	}
 
 	var appCallParams = []any{f, manifestBytes}
@@ -786,7 +1042,7 @@ func contractDeploy(ctx *cli.Context) error {
 		}}
 	}
 
-	extErr := invokeWithArgs(ctx, acc, w, management.Hash, "deploy", appCallParams, cosigners)
+	extErr := invokeWithArgs(ctx, acc, w, management.Hash, "deploy", appCallParams, cosigners, nil)
 	if extErr != nil {
 		return extErr
 	}
@@ -22,6 +22,8 @@ func TestInitSmartContract(t *testing.T) {
 	require.NoError(t, err)
 	t.Cleanup(func() { require.NoError(t, os.Chdir(testWD)) })
 	contractName := "testContract"
+	NeoGoModVersion = "v0.106.3"
+	t.Cleanup(func() { NeoGoModVersion = "" })
 
 	set := flag.NewFlagSet("flagSet", flag.ExitOnError)
 	set.String("name", contractName, "")
@@ -32,40 +34,42 @@ func TestInitSmartContract(t *testing.T) {
 	require.True(t, dirInfo.IsDir())
 	files, err := os.ReadDir(contractName)
 	require.NoError(t, err)
-	require.Equal(t, 3, len(files))
-	require.Equal(t, "go.mod", files[0].Name())
-	require.Equal(t, "main.go", files[1].Name())
-	require.Equal(t, "neo-go.yml", files[2].Name())
-	main, err := os.ReadFile(contractName + "/" + files[1].Name())
+	require.Equal(t, 5, len(files))
+	require.Equal(t, "Makefile", files[0].Name())
+	require.Equal(t, "go.mod", files[1].Name())
+	require.Equal(t, "main.go", files[2].Name())
+	require.Equal(t, "main_test.go", files[3].Name())
+	require.Equal(t, "neo-go.yml", files[4].Name())
+	goMod, err := os.ReadFile(contractName + "/" + files[1].Name())
 	require.NoError(t, err)
-	require.Equal(t,
-		`package `+contractName+`
-
-import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
-
-var notificationName string
-
-// init initializes notificationName before calling any other smart-contract method
-func init() {
-	notificationName = "Hello world!"
-}
-
-// RuntimeNotify sends runtime notification with "Hello world!" name
-func RuntimeNotify(args []any) {
-    runtime.Notify(notificationName, args)
-}`, string(main))
+	require.Contains(t, string(goMod), "\tgithub.com/nspcc-dev/neo-go v0.106.3\n")
+	main, err := os.ReadFile(contractName + "/" + files[2].Name())
+	require.NoError(t, err)
+	require.Contains(t, string(main), "\npackage "+contractName+"\n")
+	require.Contains(t, string(main), "func _deploy(data any, isUpdate bool) {")
+	test, err := os.ReadFile(contractName + "/" + files[3].Name())
+	require.NoError(t, err)
+	require.Contains(t, string(test), "package "+contractName+"_test\n")
 
-	manifest, err := os.ReadFile(contractName + "/" + files[2].Name())
+	manifest, err := os.ReadFile(contractName + "/" + files[4].Name())
 	require.NoError(t, err)
 	expected := `name: testContract
 sourceurl: http://example.com/
-safemethods: []
+safemethods:
+    - get
+    - owner
 supportedstandards: []
 events:
-    - name: Hello world!
+    - name: OwnerChanged
+      parameters:
+        - name: newOwner
+          type: Hash160
+    - name: ValueChanged
       parameters:
-        - name: args
-          type: Array
+        - name: key
+          type: String
+        - name: value
+          type: String
 permissions:
     - methods: '*'
 `
@@ -12,10 +12,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"math/big"
-	"unicode/utf8"
 )
 
 // Hash contains contract hash.
@@ -388,48 +388,19 @@ func (e *SetAdminEvent) FromStackItem(item *stackitem.Array) error {
 		err   error
 	)
 	index++
-	e.Name, err = func(item stackitem.Item) (string, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return "", err
-		}
-		if !utf8.Valid(b) {
-			return "", errors.New("not a UTF-8 string")
-		}
-		return string(b), nil
-	}(arr[index])
+	e.Name, err = eventdecode.String(arr[index])
 	if err != nil {
 		return fmt.Errorf("field Name: %w", err)
 	}
 
 	index++
-	e.OldAdmin, err = func(item stackitem.Item) (util.Uint160, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		u, err := util.Uint160DecodeBytesBE(b)
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		return u, nil
-	}(arr[index])
+	e.OldAdmin, err = eventdecode.Hash160(arr[index])
 	if err != nil {
 		return fmt.Errorf("field OldAdmin: %w", err)
 	}
 
 	index++
-	e.NewAdmin, err = func(item stackitem.Item) (util.Uint160, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		u, err := util.Uint160DecodeBytesBE(b)
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		return u, nil
-	}(arr[index])
+	e.NewAdmin, err = eventdecode.Hash160(arr[index])
 	if err != nil {
 		return fmt.Errorf("field NewAdmin: %w", err)
 	}
@@ -481,28 +452,19 @@ func (e *RenewEvent) FromStackItem(item *stackitem.Array) error {
 		err   error
 	)
 	index++
-	e.Name, err = func(item stackitem.Item) (string, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return "", err
-		}
-		if !utf8.Valid(b) {
-			return "", errors.New("not a UTF-8 string")
-		}
-		return string(b), nil
-	}(arr[index])
+	e.Name, err = eventdecode.String(arr[index])
 	if err != nil {
 		return fmt.Errorf("field Name: %w", err)
 	}
 
 	index++
-	e.OldExpiration, err = arr[index].TryInteger()
+	e.OldExpiration, err = eventdecode.Integer(arr[index])
 	if err != nil {
 		return fmt.Errorf("field OldExpiration: %w", err)
 	}
 
 	index++
-	e.NewExpiration, err = arr[index].TryInteger()
+	e.NewExpiration, err = eventdecode.Integer(arr[index])
 	if err != nil {
 		return fmt.Errorf("field NewExpiration: %w", err)
 	}
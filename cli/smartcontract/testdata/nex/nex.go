@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"math/big"
@@ -292,45 +293,25 @@ func (e *OnMintEvent) FromStackItem(item *stackitem.Array) error {
 		err   error
 	)
 	index++
-	e.From, err = func(item stackitem.Item) (util.Uint160, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		u, err := util.Uint160DecodeBytesBE(b)
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		return u, nil
-	}(arr[index])
+	e.From, err = eventdecode.Hash160(arr[index])
 	if err != nil {
 		return fmt.Errorf("field From: %w", err)
 	}
 
 	index++
-	e.To, err = func(item stackitem.Item) (util.Uint160, error) {
-		b, err := item.TryBytes()
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		u, err := util.Uint160DecodeBytesBE(b)
-		if err != nil {
-			return util.Uint160{}, err
-		}
-		return u, nil
-	}(arr[index])
+	e.To, err = eventdecode.Hash160(arr[index])
 	if err != nil {
 		return fmt.Errorf("field To: %w", err)
 	}
 
 	index++
-	e.Amount, err = arr[index].TryInteger()
+	e.Amount, err = eventdecode.Integer(arr[index])
 	if err != nil {
 		return fmt.Errorf("field Amount: %w", err)
 	}
 
 	index++
-	e.SwapId, err = arr[index].TryInteger()
+	e.SwapId, err = eventdecode.Integer(arr[index])
 	if err != nil {
 		return fmt.Errorf("field SwapId: %w", err)
 	}
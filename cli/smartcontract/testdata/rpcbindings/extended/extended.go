@@ -0,0 +1,33 @@
+package extended
+
+import "github.com/nspcc-dev/neo-go/pkg/interop"
+
+// Point is a named point.
+type Point struct {
+	X    int
+	Y    int
+	Name string
+}
+
+// Segment connects two points.
+type Segment struct {
+	From  *Point
+	To    *Point
+	Owner interop.Hash160
+	Tags  []string
+}
+
+func GetPoint(x, y int, name string) *Point {
+	return &Point{X: x, Y: y, Name: name}
+}
+
+func GetSegment(x1, y1, x2, y2 int, owner interop.Hash160) *Segment {
+	from := GetPoint(x1, y1, "from")
+	to := GetPoint(x2, y2, "to")
+	return &Segment{
+		From:  from,
+		To:    to,
+		Owner: owner,
+		Tags:  []string{from.Name, to.Name},
+	}
+}
@@ -0,0 +1,31 @@
+package util
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/urfave/cli"
+)
+
+func checkConfig(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	// Configuration with errors can't be loaded, the error lists all of them.
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	ps := config.ValidateConfig(cfg)
+	for _, p := range ps {
+		fmt.Fprintln(ctx.App.Writer, p)
+	}
+	if len(ps) == 0 {
+		fmt.Fprintln(ctx.App.Writer, "Configuration is valid")
+	} else {
+		fmt.Fprintf(ctx.App.Writer, "Configuration is valid, %d warning(s)\n", len(ps))
+	}
+	return nil
+}
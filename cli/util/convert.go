@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/options"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	vmcli "github.com/nspcc-dev/neo-go/cli/vm"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/urfave/cli"
 )
@@ -27,6 +28,43 @@ func NewCommands() []cli.Command {
 		txctx.AwaitFlag,
 	}, options.RPC...)
 	txCancelFlags = append(txCancelFlags, options.Wallet...)
+	checkConfigFlags := []cli.Flag{options.Config, options.ConfigFile, options.RelativePath}
+	checkConfigFlags = append(checkConfigFlags, options.Network...)
+	replayFlags := []cli.Flag{options.Config, options.ConfigFile, options.RelativePath}
+	replayFlags = append(replayFlags, options.Network...)
+	replayFlags = append(replayFlags, options.Debug,
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "Output file (stdout if not given)",
+		},
+		cli.BoolFlag{
+			Name:  "no-trace",
+			Usage: "Print only the execution result without the trace",
+		},
+	)
+	oracleResponseFlags := append([]cli.Flag{
+		cli.Uint64Flag{
+			Name:  "id",
+			Usage: "ID of the request",
+		},
+		cli.StringFlag{
+			Name:  "code",
+			Usage: "response code",
+			Value: transaction.Success.String(),
+		},
+		cli.StringFlag{
+			Name:  "result",
+			Usage: "hex-encoded response result",
+		},
+		cli.UintFlag{
+			Name:  "vub",
+			Usage: "ValidUntilBlock value of the transaction",
+		},
+		cli.StringFlag{
+			Name:  "out, o",
+			Usage: "file to save the parameter context to",
+		},
+	}, options.RPC...)
 	return []cli.Command{
 		{
 			Name:  "util",
@@ -74,6 +112,26 @@ func NewCommands() []cli.Command {
 					Action: cancelTx,
 					Flags:  txCancelFlags,
 				},
+				{
+					Name:      "oracle-response",
+					Usage:     "Create oracle response transaction to be signed by oracle nodes",
+					UsageText: "oracle-response -r <endpoint> --id <id> [--code <code>] [--result <hex>] [--vub <height>] --out <file>",
+					Description: `Creates an unsigned oracle response transaction for the pending request with
+   the given ID and saves it into the given parameter context file. It's
+   intended for manual recovery of stuck requests on private networks, normally
+   responses are created by oracle nodes themselves. Response code can be given
+   either by its name (like "Success" or "NotFound") or by its numeric value,
+   the result (allowed for "Success" code only) is hex-encoded. Fees are
+   calculated via the RPC node and are paid from the GAS attached to the request.
+   The transaction is valid until the given block or for MaxValidUntilBlockIncrement
+   blocks by default. Oracle nodes designated at the moment of its acceptance must
+   sign it with 'wallet sign' (having the oracle nodes multisignature account
+   imported into wallets), then it can be sent with 'util sendtx' which checks
+   it against the request before sending.
+`,
+					Action: oracleResponse,
+					Flags:  oracleResponseFlags,
+				},
 				{
 					Name:      "txdump",
 					Usage:     "Dump transaction stored in file",
@@ -103,6 +161,40 @@ func NewCommands() []cli.Command {
 						},
 					},
 				},
+				{
+					Name:      "replay-tx",
+					Usage:     "Re-execute historical transaction with tracing using local node database",
+					UsageText: "replay-tx <hash> [--config-path path] [-p/-m/-t] [--config-file file] [-o file] [--no-trace]",
+					Description: `Re-executes the transaction with the given hash in exactly the same
+   environment it was executed in when its block was persisted (historic
+   state of the previous block with OnPersist and all preceding transactions
+   of the block applied) using the local node database and prints every
+   instruction executed (invocation depth, script hash, instruction offset,
+   opcode, GAS consumed before it), contract storage accesses (STORAGE lines),
+   notifications (NOTIFY lines) and the execution result with the fault point
+   (if any). It requires historic states to be stored, so the node can't use
+   KeepOnlyLatestState setting and the block must be within MaxTraceableBlocks
+   if RemoveUntraceableBlocks is enabled. The database is not changed, but it
+   must not be used by a running node at the same time.
+`,
+					Action: replayTx,
+					Flags:  replayFlags,
+				},
+				{
+					Name:      "checkconfig",
+					Usage:     "Check node configuration for problems",
+					UsageText: "checkconfig [--config-path path] [-p/-m/-t] [--config-file file]",
+					Description: `Performs deep checks of the node configuration (the same that are done
+   on node startup) and prints all problems found: hardfork ordering and gaps,
+   committee and validators count consistency, standby committee keys and seed
+   list validity, transaction validity period sanity, P2PSigExtensions and
+   Notary service coupling, settings depending on hardforks activation, etc.
+   Configuration with errors can't be used by the node, the command fails in
+   this case. Warnings are printed, but don't make the command fail.
+`,
+					Action: checkConfig,
+					Flags:  checkConfigFlags,
+				},
 			},
 		},
 	}
@@ -143,8 +235,7 @@ func handleOps(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError("unknown encoding: base64 or hex are supported", 1)
 	}
-	v := vm.New()
-	v.LoadScript(b)
-	v.PrintOps(ctx.App.Writer)
+	instrs, err := vm.Disassemble(b, vm.DisassembleOptions{})
+	vm.WriteDisassembly(ctx.App.Writer, instrs, err)
 	return nil
 }
@@ -0,0 +1,134 @@
+package util
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/cmdargs"
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/rolemgmt"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/urfave/cli"
+)
+
+func oracleResponse(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	out := ctx.String("out")
+	if len(out) == 0 {
+		return cli.NewExitError("output file is mandatory", 1)
+	}
+	code, err := parseOracleResponseCode(ctx.String("code"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	res, err := hex.DecodeString(strings.TrimPrefix(ctx.String("result"), "0x"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid result: %w", err), 1)
+	}
+	resp := &transaction.OracleResponse{
+		ID:     ctx.Uint64("id"),
+		Code:   code,
+		Result: res,
+	}
+
+	gctx, cancel := options.GetTimeoutContext(ctx)
+	defer cancel()
+
+	c, exitErr := options.GetRPCClient(gctx, ctx)
+	if exitErr != nil {
+		return exitErr
+	}
+	defer c.Close()
+
+	req, err := oracle.GetRequest(c, resp.ID)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	nodes, height, err := getOracleNodes(c)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	version, err := c.GetVersion()
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to get version: %w", err), 1)
+	}
+	vub := uint32(ctx.Uint("vub"))
+	if vub == 0 {
+		vub = height + version.Protocol.MaxValidUntilBlockIncrement
+	}
+	tx, err := oracle.CreateResponseTx(c, req, nodes, resp, vub)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to create response transaction: %w", err), 1)
+	}
+	if err := oracle.CheckResponseTx(tx, req, nodes); err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid response transaction: %w", err), 1)
+	}
+
+	// The native Oracle contract witness is empty, so it's complete from
+	// the beginning. Oracle nodes add their signatures to the multisig one
+	// with `wallet sign` having the multisig account imported.
+	scCtx := context.NewTransactionContext(version.Protocol.Network, tx)
+	scCtx.Items[oracle.Hash] = &context.Item{Signatures: make(map[string][]byte)}
+	if err := paramcontext.Save(scCtx, out); err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	fmt.Fprintf(ctx.App.Writer, "Oracle nodes account: %s\n", address.Uint160ToString(tx.Signers[1].Account))
+	fmt.Fprintln(ctx.App.Writer, tx.Hash().StringLE())
+	return nil
+}
+
+// verifyOracleResponse checks oracle response transaction against the pending
+// request and oracle nodes currently designated.
+func verifyOracleResponse(c *rpcclient.Client, tx *transaction.Transaction) error {
+	nodes, _, err := getOracleNodes(c)
+	if err != nil {
+		return err
+	}
+	return oracle.VerifyResponseTx(c, tx, nodes)
+}
+
+// getOracleNodes returns oracle nodes that are to sign responses included into
+// the next block along with the current chain height.
+func getOracleNodes(c *rpcclient.Client) (keys.PublicKeys, uint32, error) {
+	count, err := c.GetBlockCount()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get block count: %w", err)
+	}
+	nodes, err := rolemgmt.NewReader(invoker.New(c, nil)).GetDesignatedByRole(noderoles.Oracle, count)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get oracle nodes: %w", err)
+	}
+	if len(nodes) == 0 {
+		return nil, 0, errors.New("no oracle nodes designated")
+	}
+	return nodes, count - 1, nil
+}
+
+// parseOracleResponseCode parses response code given either by its name or by
+// its numeric value.
+func parseOracleResponseCode(s string) (transaction.OracleResponseCode, error) {
+	var code transaction.OracleResponseCode
+	if n, err := strconv.ParseUint(s, 0, 8); err == nil {
+		code = transaction.OracleResponseCode(n)
+	} else if err := json.Unmarshal([]byte(strconv.Quote(s)), &code); err != nil {
+		return 0, fmt.Errorf("invalid response code %q", s)
+	}
+	if !code.IsValid() {
+		return 0, fmt.Errorf("invalid response code %q", s)
+	}
+	return code, nil
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/cli/options"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/urfave/cli"
+)
+
+// traceWriter is a core.TraceWriter printing the trace in a human-readable
+// form.
+type traceWriter struct {
+	w io.Writer
+}
+
+// Instruction implements core.TraceWriter interface.
+func (t traceWriter) Instruction(i core.TraceInstruction) {
+	fmt.Fprintf(t.w, "%d\t%s\t%d\t%s\t%d\n", i.Depth, i.ScriptHash.StringLE(), i.IP, i.Opcode, i.GasConsumed)
+}
+
+// StorageAccess implements core.TraceWriter interface.
+func (t traceWriter) StorageAccess(a core.TraceStorageAccess) {
+	fmt.Fprintf(t.w, "STORAGE\t%s\t%s\tid=%d\tkey=%s", a.Type, a.ScriptHash.StringLE(), a.ContractID, hex.EncodeToString(a.Key))
+	if a.Value != nil {
+		fmt.Fprintf(t.w, "\tvalue=%s", hex.EncodeToString(a.Value))
+	}
+	fmt.Fprintln(t.w)
+}
+
+// Notification implements core.TraceWriter interface.
+func (t traceWriter) Notification(e state.NotificationEvent) {
+	fmt.Fprintf(t.w, "NOTIFY\t%s\t%s\t%s\n", e.ScriptHash.StringLE(), e.Name, stackItemString(e.Item))
+}
+
+// stackItemString returns JSON representation of the stack item (if
+// possible) to be used in the trace output.
+func stackItemString(item stackitem.Item) string {
+	b, err := stackitem.ToJSONWithTypes(item)
+	if err != nil {
+		return fmt.Sprintf("<%s>", err)
+	}
+	return string(b)
+}
+
+func replayTx(ctx *cli.Context) error {
+	args := ctx.Args()
+	if len(args) != 1 {
+		return cli.NewExitError("exactly one transaction hash is expected", 1)
+	}
+	h, err := util.Uint256DecodeStringLE(strings.TrimPrefix(args[0], "0x"))
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("invalid transaction hash: %w", err), 1)
+	}
+	cfg, err := options.GetConfigFromContext(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	log, _, logCloser, err := options.HandleLoggingParams(ctx.Bool("debug"), cfg.ApplicationConfiguration)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if logCloser != nil {
+		defer func() { _ = logCloser() }()
+	}
+
+	var out = ctx.App.Writer
+	if o := ctx.String("out"); o != "" {
+		f, err := os.Create(o)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize storage: %w", err), 1)
+	}
+	defer store.Close()
+	chain, err := core.NewBlockchain(store, cfg.Blockchain(), log)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("could not initialize blockchain: %w", err), 1)
+	}
+	// Do not run chain, only historic states are needed.
+
+	var tw core.TraceWriter
+	if !ctx.Bool("no-trace") {
+		tw = traceWriter{w: out}
+	}
+	res, err := chain.ReplayTransaction(h, tw)
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("failed to replay transaction: %w", err), 1)
+	}
+	fmt.Fprintf(out, "Block:\t%d\n", res.BlockIndex)
+	fmt.Fprintf(out, "VM state:\t%s\n", res.Execution.VMState)
+	fmt.Fprintf(out, "GAS consumed:\t%d\n", res.Execution.GasConsumed)
+	for i, item := range res.Execution.Stack {
+		fmt.Fprintf(out, "Stack[%d]:\t%s\n", i, stackItemString(item))
+	}
+	if res.Fault != nil {
+		fmt.Fprintf(out, "Exception:\t%s\n", res.Execution.FaultException)
+		fmt.Fprintf(out, "Fault point:\t%s at %d (%s), depth %d\n", res.Fault.ScriptHash.StringLE(),
+			res.Fault.IP, res.Fault.Opcode, res.Fault.Depth)
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/cli/txctx"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
 	"github.com/urfave/cli"
 )
@@ -36,6 +37,11 @@ func sendTx(ctx *cli.Context) error {
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to create RPC client: %w", err), 1)
 	}
+	if tx.HasAttribute(transaction.OracleResponseT) {
+		if err := verifyOracleResponse(c, tx); err != nil {
+			return cli.NewExitError(fmt.Errorf("invalid oracle response transaction: %w", err), 1)
+		}
+	}
 	res, err := c.SendRawTransaction(tx)
 	if err != nil {
 		return cli.NewExitError(fmt.Errorf("failed to submit transaction to RPC node: %w", err), 1)
@@ -9,11 +9,17 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"gopkg.in/yaml.v3"
 )
 
 func TestUtilConvert(t *testing.T) {
@@ -190,3 +196,101 @@ func TestAwaitUtilCancelTx(t *testing.T) {
 		t.Fatal(fmt.Errorf("unexpected error: %w", err))
 	}
 }
+
+func TestUtilReplayTx(t *testing.T) {
+	tmpDir := t.TempDir()
+	chainPath := filepath.Join(tmpDir, "neogotestchain")
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err, "could not load config")
+	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
+	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions.DataDirectoryPath = chainPath
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "protocol.unit_testnet.yml"), out, os.ModePerm))
+
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "db", "restore", "--unittest", "--config-path", tmpDir,
+		"--in", filepath.Join("..", "server", "testdata", "chain50x2.acc"), "--count", "10")
+
+	// Get some transaction from the restored chain.
+	store, err := storage.NewStore(cfg.ApplicationConfiguration.DBConfiguration)
+	require.NoError(t, err)
+	chain, err := core.NewBlockchain(store, cfg.Blockchain(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	b, err := chain.GetBlock(chain.GetHeaderHash(5))
+	require.NoError(t, err)
+	require.NotEmpty(t, b.Transactions)
+	h := b.Transactions[len(b.Transactions)-1].Hash()
+	require.NoError(t, store.Close())
+
+	t.Run("invalid hash", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "replay-tx", "--unittest", "--config-path", tmpDir, "bad")
+	})
+	t.Run("missing hash", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "replay-tx", "--unittest", "--config-path", tmpDir)
+	})
+	t.Run("unknown transaction", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "util", "replay-tx", "--unittest", "--config-path", tmpDir, util.Uint256{1, 2, 3}.StringLE())
+	})
+	t.Run("no trace", func(t *testing.T) {
+		e.Run(t, "neo-go", "util", "replay-tx", "--unittest", "--config-path", tmpDir, "--no-trace", h.StringLE())
+		e.CheckNextLine(t, "^Block:\t5$")
+		e.CheckNextLine(t, "^VM state:\tHALT$")
+		e.CheckNextLine(t, "^GAS consumed:\t[0-9]+$")
+	})
+	t.Run("trace", func(t *testing.T) {
+		outFile := filepath.Join(tmpDir, "trace.txt")
+		e.Run(t, "neo-go", "util", "replay-tx", "--unittest", "--config-path", tmpDir, "--out", outFile, h.StringLE())
+		e.CheckEOF(t)
+		data, err := os.ReadFile(outFile)
+		require.NoError(t, err)
+		lines := strings.Split(string(data), "\n")
+		require.Regexp(t, "^1\t[0-9a-f]{40}\t0\t[A-Z0-9_]+\t0$", lines[0])
+		require.Contains(t, string(data), "VM state:\tHALT\n")
+	})
+}
+
+func TestUtilOracleResponse(t *testing.T) {
+	e := testcli.NewExecutor(t, true)
+	out := filepath.Join(t.TempDir(), "resp.json")
+	args := []string{"neo-go", "util", "oracle-response", "-r", "http://" + e.RPC.Addresses()[0]}
+
+	e.RunWithErrorCheck(t, "output file is mandatory", args...)
+	args = append(args, "--out", out)
+	e.RunWithErrorCheck(t, "invalid response code", append(args, "--code", "Unknown")...)
+	e.RunWithErrorCheck(t, "invalid response code", append(args, "--code", "2")...)
+	e.RunWithErrorCheck(t, "invalid result", append(args, "--result", "zz")...)
+	e.RunWithErrorCheck(t, "failed to get request 100", append(args, "--id", "100", "--code", "0x14")...)
+	_, err := os.Stat(out)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestUtilCheckConfig(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+	e.Run(t, "neo-go", "util", "checkconfig", "--config-file", filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	e.CheckNextLine(t, "Configuration is valid")
+	e.CheckEOF(t)
+
+	cfg, err := config.LoadFile(filepath.Join("..", "..", "config", "protocol.unit_testnet.yml"))
+	require.NoError(t, err)
+	cfg.ProtocolConfiguration.MaxValidUntilBlockIncrement = 2
+	out, err := yaml.Marshal(cfg)
+	require.NoError(t, err)
+	cfgPath := filepath.Join(t.TempDir(), "protocol.yml")
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+	e.Run(t, "neo-go", "util", "checkconfig", "--config-file", cfgPath)
+	e.CheckNextLine(t, "warning: MaxValidUntilBlockIncrement: .* too short")
+	e.CheckNextLine(t, "Configuration is valid, 1 warning")
+	e.CheckEOF(t)
+
+	cfg.ProtocolConfiguration.SeedList = append(cfg.ProtocolConfiguration.SeedList, "localhost")
+	cfg.ProtocolConfiguration.Hardforks = map[string]uint32{"Aspidochelone": 10, "Cockatrice": 20}
+	out, err = yaml.Marshal(cfg)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(cfgPath, out, os.ModePerm))
+	err = e.RunUnchecked(t, "neo-go", "util", "checkconfig", "--config-file", cfgPath)
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error: Hardforks: Cockatrice is enabled at 20, but previous Basilisk is not")
+	require.ErrorContains(t, err, `error: SeedList: invalid address "localhost"`)
+	require.ErrorContains(t, err, "warning: MaxValidUntilBlockIncrement")
+}
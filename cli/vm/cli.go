@@ -36,6 +36,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
@@ -57,6 +58,7 @@ const (
 	exitFuncKey         = "exitFunc"
 	readlineInstanceKey = "readlineKey"
 	printLogoKey        = "printLogoKey"
+	auditModeKey        = "auditMode"
 )
 
 // Various flag names.
@@ -162,7 +164,8 @@ Example:
 		Flags:     []cli.Flag{historicFlag, gasFlag, hashFlag},
 		Description: `<file> parameter is mandatory, <manifest> parameter (if omitted) will
    be guessed from the <file> parameter by replacing '.nef' suffix with '.manifest.json'
-   suffix.
+   suffix. <file> can also be a contract bundle (*.nefbundle), in this case
+   <manifest> must be omitted.
 
 ` + cmdargs.SignersParsingDoc + `
 
@@ -334,6 +337,21 @@ Example:
 		Description: "Dump events emitted by the current loaded program",
 		Action:      handleEvents,
 	},
+	{
+		Name:      "audit",
+		Usage:     "Set compound stack items ownership audit mode",
+		UsageText: `audit [off|report|fault]`,
+		Description: `Audit mode tracks ownership of compound stack items (Array, Struct, Map and
+Buffer) across contract invocations and detects mutations of items owned by
+another invocation (like an Array passed as an argument to the contract being
+called). 'report' mode prints such mutations after the execution, 'fault' mode
+makes the VM fail on them. Audit mode is disabled by default, if no mode is
+specified the current one is printed.
+
+Example:
+> audit fault`,
+		Action: handleAudit,
+	},
 	{
 		Name:      "env",
 		Usage:     "Dump state of the chain that is used for VM CLI invocations (use -v for verbose node configuration)",
@@ -500,6 +518,7 @@ func NewWithConfig(printLogotype bool, onExit func(int), c *readline.Config, cfg
 		exitFuncKey:         exitF,
 		readlineInstanceKey: l,
 		printLogoKey:        printLogotype,
+		auditModeKey:        vm.AuditOff,
 	}
 	changePrompt(vmcli.shell)
 	return &vmcli, nil
@@ -537,6 +556,10 @@ func getPrintLogoFromContext(app *cli.App) bool {
 	return app.Metadata[printLogoKey].(bool)
 }
 
+func getAuditModeFromContext(app *cli.App) vm.AuditMode {
+	return app.Metadata[auditModeKey].(vm.AuditMode)
+}
+
 func setInteropContextInContext(app *cli.App, ic *interop.Context) {
 	app.Metadata[icKey] = ic
 }
@@ -717,21 +740,25 @@ func handleLoadNEF(c *cli.Context) error {
 			signersStartOffset = 3
 		}
 	}
-	if len(manifestFile) == 0 {
-		manifestFile = strings.TrimSuffix(nefFile, ".nef") + ".manifest.json"
+	var (
+		nef *nef.File
+		m   *manifest.Manifest
+		err error
+	)
+	if bundle.IsBundle(nefFile) {
+		if len(manifestFile) != 0 {
+			return fmt.Errorf("%w: manifest can't be used with contract bundle", ErrInvalidParameter)
+		}
+		nef, m, err = getContractFromBundle(nefFile)
+	} else {
+		if len(manifestFile) == 0 {
+			manifestFile = strings.TrimSuffix(nefFile, ".nef") + ".manifest.json"
+		}
+		nef, m, err = getContractFromFiles(nefFile, manifestFile)
 	}
-	b, err := os.ReadFile(nefFile)
 	if err != nil {
 		return err
 	}
-	nef, err := nef.FileFromBytes(b)
-	if err != nil {
-		return fmt.Errorf("failed to decode NEF file: %w", err)
-	}
-	m, err := getManifestFromFile(manifestFile)
-	if err != nil {
-		return fmt.Errorf("failed to read manifest: %w", err)
-	}
 	var signers []transaction.Signer
 	if signersStartOffset != 0 && len(args) > signersStartOffset {
 		signers, err = cmdargs.ParseSigners(c.Args()[signersStartOffset:])
@@ -749,7 +776,7 @@ func handleLoadNEF(c *cli.Context) error {
 	}
 	cs := &state.ContractBase{
 		Hash:     h,
-		NEF:      nef,
+		NEF:      *nef,
 		Manifest: *m,
 	}
 	setContractStateInContext(c.App, cs)
@@ -760,6 +787,32 @@ func handleLoadNEF(c *cli.Context) error {
 	return nil
 }
 
+// getContractFromFiles reads NEF and manifest files.
+func getContractFromFiles(nefFile string, manifestFile string) (*nef.File, *manifest.Manifest, error) {
+	b, err := os.ReadFile(nefFile)
+	if err != nil {
+		return nil, nil, err
+	}
+	n, err := nef.FileFromBytes(b)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to decode NEF file: %w", err)
+	}
+	m, err := getManifestFromFile(manifestFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	return &n, m, nil
+}
+
+// getContractFromBundle reads and verifies contract bundle.
+func getContractFromBundle(filename string) (*nef.File, *manifest.Manifest, error) {
+	b, err := bundle.ReadFile(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read contract bundle: %w", err)
+	}
+	return b.NEF, b.Manifest, nil
+}
+
 func handleLoadBase64(c *cli.Context) error {
 	args := c.Args()
 	if len(args) < 1 {
@@ -1122,6 +1175,7 @@ func handleRun(c *cli.Context) error {
 // runVMWithHandling runs VM with handling errors and additional state messages.
 func runVMWithHandling(c *cli.Context) {
 	v := getVMFromContext(c.App)
+	v.EnableAudit(getAuditModeFromContext(c.App))
 	err := v.Run()
 	if err != nil {
 		writeErr(c.App.ErrWriter, err)
@@ -1156,6 +1210,15 @@ func runVMWithHandling(c *cli.Context) {
 			}
 			message += "Events:\n" + e
 		}
+		if vs := v.GetAuditViolations(); len(vs) != 0 && !v.HasFailed() {
+			if message != "" {
+				message += "\n"
+			}
+			message += "Audit violations:"
+			for _, viol := range vs {
+				message += "\n" + viol.String()
+			}
+		}
 	}
 	if message != "" {
 		fmt.Fprintln(c.App.Writer, message)
@@ -1211,6 +1274,7 @@ func handleStepType(c *cli.Context, stepType string) error {
 		return nil
 	}
 	v := getVMFromContext(c.App)
+	v.EnableAudit(getAuditModeFromContext(c.App))
 	var err error
 	switch stepType {
 	case "into":
@@ -1259,6 +1323,36 @@ func handleEvents(c *cli.Context) error {
 	return nil
 }
 
+func handleAudit(c *cli.Context) error {
+	args := c.Args()
+	if len(args) == 0 {
+		var mode string
+		switch getAuditModeFromContext(c.App) {
+		case vm.AuditReport:
+			mode = "report"
+		case vm.AuditFault:
+			mode = "fault"
+		default:
+			mode = "off"
+		}
+		fmt.Fprintln(c.App.Writer, "Audit mode: "+mode)
+		return nil
+	}
+	var mode vm.AuditMode
+	switch args[0] {
+	case "off":
+		mode = vm.AuditOff
+	case "report":
+		mode = vm.AuditReport
+	case "fault":
+		mode = vm.AuditFault
+	default:
+		return fmt.Errorf("%w: unknown audit mode %q", ErrInvalidParameter, args[0])
+	}
+	c.App.Metadata[auditModeKey] = mode
+	return nil
+}
+
 func handleEnv(c *cli.Context) error {
 	bc := getChainFromContext(c.App)
 	cfg := getChainConfigFromContext(c.App)
@@ -36,6 +36,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
@@ -146,6 +147,9 @@ func newTestVMClIWithState(t *testing.T) *executor {
 	cfg.ApplicationConfiguration.DBConfiguration.Type = dbconfig.LevelDB
 	cfg.ApplicationConfiguration.DBConfiguration.LevelDBOptions = opts
 	cfg.ProtocolConfiguration.StateRootInHeader = true
+	// The chain is created with all hardforks enabled from genesis, native
+	// contract states depend on them, so the same setting is used here.
+	cfg.ProtocolConfiguration.Hardforks = nil
 	return newTestVMCLIWithLogoAndCustomConfig(t, false, &cfg)
 }
 
@@ -619,6 +623,38 @@ func TestLoad(t *testing.T) {
 		e.checkNextLine(t, "READY: loaded \\d* instructions") // manifest present, signer present, OK
 		e.checkStack(t, 8)
 	})
+	t.Run("loadnef, bundle", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		nefFile, di, err := compiler.CompileWithOptions("test.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		m, err := di.ConvertToManifest(&compiler.Options{Name: "Test"})
+		require.NoError(t, err)
+		rawDI, err := json.Marshal(di)
+		require.NoError(t, err)
+		bundleFile := filepath.Join(tmpDir, "vmtestcontract."+bundle.FileExt)
+		require.NoError(t, bundle.WriteFile(bundleFile, nefFile, m, rawDI))
+		badBundle := filepath.Join(tmpDir, "vmtestcontract_err."+bundle.FileExt)
+		require.NoError(t, os.WriteFile(badBundle, []byte{1, 2, 3, 4}, os.ModePerm))
+		manifestFile, _ := prepareLoadnefSrc(t, tmpDir, src)
+
+		e := newTestVMCLI(t)
+		e.runProg(t,
+			"loadnef '"+badBundle+"'",
+			"loadnef '"+bundleFile+"' "+manifestFile,
+			"loadnef '"+bundleFile+"'",
+			"run main add 3 5",
+			"loadnef '"+bundleFile+"' "+cmdargs.CosignersSeparator+" "+util.Uint160{1, 2, 3}.StringLE(),
+			"run main add 3 5",
+		)
+
+		e.checkNextLine(t, "Error:.*invalid bundle")
+		e.checkError(t, ErrInvalidParameter)
+		e.checkNextLine(t, "READY: loaded \\d* instructions")
+		e.checkStack(t, 8)
+		e.checkNextLine(t, "READY: loaded \\d* instructions")
+		e.checkStack(t, 8)
+	})
 }
 
 func TestLoad_RunWithCALLT(t *testing.T) {
@@ -1129,6 +1165,27 @@ func TestEvents(t *testing.T) {
 	e.checkEvents(t, false, expectedEvent) // printed after `events` command
 }
 
+func TestAudit(t *testing.T) {
+	script := []byte{byte(opcode.NEWARRAY0), byte(opcode.DUP), byte(opcode.PUSH1), byte(opcode.APPEND)}
+	e := newTestVMCLI(t)
+	e.runProg(t,
+		"audit",
+		"audit fault",
+		"audit",
+		"audit unknown",
+		"loadhex "+hex.EncodeToString(script),
+		"run",
+		"audit off",
+		"audit")
+
+	e.checkNextLine(t, "Audit mode: off")
+	e.checkNextLine(t, "Audit mode: fault")
+	e.checkError(t, ErrInvalidParameter)
+	e.checkNextLine(t, "READY: loaded 4 instructions")
+	e.checkStack(t, []stackitem.Item{stackitem.Make(1)})
+	e.checkNextLine(t, "Audit mode: off")
+}
+
 func TestEnv(t *testing.T) {
 	t.Run("default setup", func(t *testing.T) {
 		e := newTestVMCLI(t)
@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"bytes"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
+	"github.com/nspcc-dev/neo-go/internal/fakeledger"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli"
+)
+
+func runSign(t *testing.T, args ...string) error {
+	var sign cli.Command
+	for _, c := range NewCommands()[0].Subcommands {
+		if c.Name == "sign" {
+			sign = c
+		}
+	}
+	set := flag.NewFlagSet("sign", flag.ContinueOnError)
+	for _, f := range sign.Flags {
+		f.Apply(set)
+	}
+	require.NoError(t, set.Parse(args))
+	app := cli.NewApp()
+	app.Writer = new(bytes.Buffer)
+	app.ErrWriter = new(bytes.Buffer)
+	return signStoredTransaction(cli.NewContext(app, set, nil))
+}
+
+func TestSignStoredTransactionLedger(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	app := fakeledger.New(priv)
+	var usedPath string
+	openLedger = func(path string) (*ledger.Device, error) {
+		usedPath = path
+		return ledger.New(app, path)
+	}
+	t.Cleanup(func() { openLedger = ledger.Open })
+
+	tx := &transaction.Transaction{
+		Script:          []byte{1, 2, 3},
+		ValidUntilBlock: 10,
+		Signers: []transaction.Signer{{
+			Account: priv.GetScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}},
+	}
+	tmp := t.TempDir()
+	in := filepath.Join(tmp, "in.json")
+	out := filepath.Join(tmp, "out.json")
+	pc := context.NewParameterContext("Neo.Network.P2P.Payloads.Transaction", netmode.UnitTestNet, tx)
+	require.NoError(t, paramcontext.Save(pc, in))
+
+	t.Run("rejected", func(t *testing.T) {
+		app.Reject = true
+		defer func() { app.Reject = false }()
+		err := runSign(t, "--ledger", "--in", in, "--out", out)
+		require.ErrorContains(t, err, "can't sign with Ledger: request was rejected on the device")
+		require.True(t, app.Closed)
+	})
+	t.Run("locked", func(t *testing.T) {
+		app.Locked = true
+		defer func() { app.Locked = false }()
+		err := runSign(t, "--ledger", "--in", in, "--out", out)
+		require.ErrorContains(t, err, "device is locked, please unlock it")
+	})
+	t.Run("address mismatch", func(t *testing.T) {
+		err := runSign(t, "--ledger", "--in", in, "--out", out, "--address", "NfgHwwTi3wHAS8aFAN243C5vGbkYDpqLHP")
+		require.ErrorContains(t, err, "doesn't match the provided one")
+	})
+	t.Run("not a signer", func(t *testing.T) {
+		err := runSign(t, "--ledger", "--ledger-path", "m/44'/888'/1'/0/0", "--in", in, "--out", out)
+		require.Equal(t, "m/44'/888'/1'/0/0", usedPath)
+		// Fake device uses the same key for all paths.
+		require.NoError(t, err)
+	})
+	t.Run("good", func(t *testing.T) {
+		err := runSign(t, "--ledger", "--in", in, "--out", out, "--address", priv.Address())
+		require.NoError(t, err)
+		require.Equal(t, ledger.DefaultPath, usedPath)
+
+		res, err := paramcontext.Read(out)
+		require.NoError(t, err)
+		signed, err := res.GetCompleteTransaction()
+		require.NoError(t, err)
+		require.Equal(t, 1, len(signed.Scripts))
+		require.True(t, priv.PublicKey().VerifyHashable(signed.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), signed))
+	})
+}
@@ -12,33 +12,68 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
 	"github.com/urfave/cli"
 )
 
+// openLedger opens Ledger device for signing, it's replaced in tests.
+var openLedger = ledger.Open
+
 func signStoredTransaction(ctx *cli.Context) error {
 	var (
 		out      = ctx.String("out")
 		rpcNode  = ctx.String(options.RPCEndpointFlag)
 		addrFlag = ctx.Generic("address").(*flags.Address)
+		offline  = ctx.Bool("offline")
 		aer      *state.AppExecResult
+		pc       *context.ParameterContext
+		err      error
 	)
 	if err := cmdargs.EnsureNone(ctx); err != nil {
 		return err
 	}
-
-	pc, err := paramcontext.Read(ctx.String("in"))
-	if err != nil {
-		return cli.NewExitError(err, 1)
+	if offline && rpcNode != "" {
+		return cli.NewExitError("RPC endpoint can't be used for offline signing", 1)
 	}
 
-	if !addrFlag.IsSet {
-		return cli.NewExitError("address was not provided", 1)
+	if offline {
+		pc, err = paramcontext.ReadCompact(ctx.String("in"))
+	} else {
+		pc, err = paramcontext.Read(ctx.String("in"))
 	}
-	acc, _, err := options.GetAccFromContext(ctx)
 	if err != nil {
 		return cli.NewExitError(err, 1)
 	}
 
+	var (
+		acc *wallet.Account
+		dev *ledger.Device
+	)
+	if ctx.Bool("ledger") {
+		dev, err = openLedger(ctx.String("ledger-path"))
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't use Ledger: %w", err), 1)
+		}
+		defer dev.Close()
+		acc, err = wallet.NewAccountFromExternalSigner(dev)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't use Ledger: %w", err), 1)
+		}
+		if addrFlag.IsSet && !addrFlag.Uint160().Equals(acc.ScriptHash()) {
+			return cli.NewExitError(fmt.Errorf("address of the Ledger key (%s) doesn't match the provided one (wrong --ledger-path?)", acc.Address), 1)
+		}
+	} else {
+		if !addrFlag.IsSet {
+			return cli.NewExitError("address was not provided", 1)
+		}
+		acc, _, err = options.GetAccFromContext(ctx)
+		if err != nil {
+			return cli.NewExitError(err, 1)
+		}
+	}
+
 	tx, ok := pc.Verifiable.(*transaction.Transaction)
 	if !ok {
 		return cli.NewExitError("verifiable item is not a transaction", 1)
@@ -48,14 +83,36 @@ func signStoredTransaction(ctx *cli.Context) error {
 		return cli.NewExitError("tx signers don't contain provided account", 1)
 	}
 
-	if acc.CanSign() {
-		sign := acc.SignHashable(pc.Network, pc.Verifiable)
-		if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, acc.PublicKey(), sign); err != nil {
+	if dev != nil {
+		fmt.Fprintln(ctx.App.ErrWriter, "Please confirm the transaction on the Ledger device")
+		sig, err := dev.SignTx(pc.Network, tx)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't sign with Ledger: %w", err), 1)
+		}
+		pub, _ := dev.GetPublicKey() // Cached by the device, can't fail here.
+		if err := pc.AddSignature(acc.ScriptHash(), acc.Contract, pub, sig); err != nil {
+			return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
+		}
+	} else if acc.CanSign() {
+		if err := pc.Sign(acc); err != nil {
 			return cli.NewExitError(fmt.Errorf("can't add signature: %w", err), 1)
 		}
 	} else if rpcNode == "" {
 		return cli.NewExitError(fmt.Errorf("can't sign transactions with the given account and no RPC endpoing given to send anything signed"), 1)
 	}
+	if offline {
+		if out != "" {
+			err = paramcontext.SaveCompact(pc, out, ctx.Int("chunk-size"))
+		} else {
+			var txt string
+			txt, err = paramcontext.FormatCompact(pc, ctx.Int("chunk-size"))
+			fmt.Fprint(ctx.App.Writer, txt)
+		}
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("can't save resulting container: %w", err), 1)
+		}
+		return nil
+	}
 	// Not saving and not sending, print.
 	if out == "" && rpcNode == "" {
 		txt, err := json.MarshalIndent(pc, " ", "     ")
@@ -100,3 +157,68 @@ func signStoredTransaction(ctx *cli.Context) error {
 	txctx.DumpTransactionInfo(ctx.App.Writer, tx.Hash(), aer)
 	return nil
 }
+
+func exportOffline(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	if out := ctx.String("out"); out != "" {
+		err = paramcontext.SaveCompact(pc, out, ctx.Int("chunk-size"))
+	} else {
+		var txt string
+		txt, err = paramcontext.FormatCompact(pc, ctx.Int("chunk-size"))
+		fmt.Fprint(ctx.App.Writer, txt)
+	}
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't export context: %w", err), 1)
+	}
+	return nil
+}
+
+func importOffline(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	signed := ctx.StringSlice("signed")
+	if len(signed) == 0 {
+		return cli.NewExitError("no signed containers given", 1)
+	}
+	pc, err := paramcontext.Read(ctx.String("in"))
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	for _, f := range signed {
+		other, err := paramcontext.ReadCompact(f)
+		if err != nil {
+			return cli.NewExitError(fmt.Errorf("%s: %w", f, err), 1)
+		}
+		if other.Network != pc.Network {
+			return cli.NewExitError(fmt.Errorf("%s: network mismatch: %d vs %d", f, other.Network, pc.Network), 1)
+		}
+		if h, oh := pc.Verifiable.Hash(), other.Verifiable.Hash(); !h.Equals(oh) {
+			return cli.NewExitError(fmt.Errorf("%s: transaction mismatch: %s vs %s", f, oh.StringLE(), h.StringLE()), 1)
+		}
+		if err := other.VerifySignatures(); err != nil {
+			return cli.NewExitError(fmt.Errorf("%s: %w", f, err), 1)
+		}
+		if err := pc.Merge(other); err != nil {
+			return cli.NewExitError(fmt.Errorf("%s: can't merge: %w", f, err), 1)
+		}
+	}
+	if out := ctx.String("out"); out != "" {
+		if err := paramcontext.Save(pc, out); err != nil {
+			return cli.NewExitError(fmt.Errorf("can't save resulting context: %w", err), 1)
+		}
+		return nil
+	}
+	txt, err := json.MarshalIndent(pc, " ", "     ")
+	if err != nil {
+		return cli.NewExitError(fmt.Errorf("can't display resulting context: %w", err), 1)
+	}
+	fmt.Fprintln(ctx.App.Writer, string(txt))
+	return nil
+}
@@ -7,9 +7,13 @@ import (
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/cli/paramcontext"
 	"github.com/nspcc-dev/neo-go/internal/testcli"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -17,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/context"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/require"
 )
@@ -265,3 +270,103 @@ func TestSignMultisigTx(t *testing.T) {
 func deployVerifyContract(t *testing.T, e *testcli.Executor) util.Uint160 {
 	return testcli.DeployContract(t, e, "../smartcontract/testdata/verify.go", "../smartcontract/testdata/verify.yml", testcli.ValidatorWallet, testcli.ValidatorAddr, testcli.ValidatorPass)
 }
+
+func TestOfflineSigningRoundTrip(t *testing.T) {
+	e := testcli.NewExecutor(t, false)
+
+	privs, pubs := testcli.GenerateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, pubs)
+	require.NoError(t, err)
+	multisigHash := hash.Hash160(script)
+	multisigAddr := address.Uint160ToString(multisigHash)
+
+	// Air-gapped wallets of multisig participants.
+	tmpDir := t.TempDir()
+	walletPaths := make([]string, 2)
+	for i := range walletPaths {
+		walletPaths[i] = filepath.Join(tmpDir, fmt.Sprintf("cold%d.json", i))
+		e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPaths[i])
+		e.In.WriteString("acc\rpass\rpass\r")
+		e.Run(t, "neo-go", "wallet", "import-multisig",
+			"--wallet", walletPaths[i],
+			"--wif", privs[i].WIF(),
+			"--min", "2",
+			hex.EncodeToString(pubs[0].Bytes()),
+			hex.EncodeToString(pubs[1].Bytes()),
+			hex.EncodeToString(pubs[2].Bytes()))
+	}
+
+	// Unsigned transaction created on the online machine.
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = 100
+	tx.Signers = []transaction.Signer{{Account: multisigHash}}
+	ctxPath := filepath.Join(tmpDir, "ctx.json")
+	require.NoError(t, paramcontext.Save(context.NewTransactionContext(netmode.UnitTestNet, tx), ctxPath))
+
+	reqPath := filepath.Join(tmpDir, "request.txt")
+	e.Run(t, "neo-go", "wallet", "offline-export", "--in", ctxPath, "--out", reqPath, "--chunk-size", "100")
+	data, err := os.ReadFile(reqPath)
+	require.NoError(t, err)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		require.LessOrEqual(t, len(line), 100)
+	}
+
+	signedPaths := make([]string, len(walletPaths))
+	for i := range walletPaths {
+		signedPaths[i] = filepath.Join(tmpDir, fmt.Sprintf("signed%d.txt", i))
+		e.In.WriteString("pass\r")
+		e.Run(t, "neo-go", "wallet", "sign", "--offline",
+			"--wallet", walletPaths[i], "--address", multisigAddr,
+			"--in", reqPath, "--out", signedPaths[i], "--chunk-size", "100")
+	}
+
+	t.Run("RPC with offline", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "sign", "--offline",
+			"--rpc-endpoint", "http://localhost:1",
+			"--wallet", walletPaths[0], "--address", multisigAddr,
+			"--in", reqPath)
+	})
+	t.Run("JSON input for offline", func(t *testing.T) {
+		e.In.WriteString("pass\r")
+		e.RunWithError(t, "neo-go", "wallet", "sign", "--offline",
+			"--wallet", walletPaths[0], "--address", multisigAddr,
+			"--in", ctxPath)
+	})
+	t.Run("tampered", func(t *testing.T) {
+		data, err := os.ReadFile(signedPaths[0])
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		last := []byte(lines[len(lines)-1])
+		if last[len(last)-5] == 'A' {
+			last[len(last)-5] = 'B'
+		} else {
+			last[len(last)-5] = 'A'
+		}
+		lines[len(lines)-1] = string(last)
+		tampered := filepath.Join(tmpDir, "tampered.txt")
+		require.NoError(t, os.WriteFile(tampered, []byte(strings.Join(lines, "\n")), 0644))
+		e.RunWithError(t, "neo-go", "wallet", "offline-import", "--in", ctxPath, "--signed", tampered)
+
+		// Missing chunk.
+		require.NoError(t, os.WriteFile(tampered, []byte(strings.Join(lines[1:], "\n")), 0644))
+		e.RunWithError(t, "neo-go", "wallet", "offline-import", "--in", ctxPath, "--signed", tampered)
+	})
+	t.Run("other transaction", func(t *testing.T) {
+		otherTx := transaction.New([]byte{byte(opcode.PUSH2)}, 0)
+		otherTx.Signers = tx.Signers
+		otherPath := filepath.Join(tmpDir, "other.json")
+		require.NoError(t, paramcontext.Save(context.NewTransactionContext(netmode.UnitTestNet, otherTx), otherPath))
+		e.RunWithError(t, "neo-go", "wallet", "offline-import", "--in", otherPath, "--signed", signedPaths[0])
+	})
+	t.Run("no signed", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "offline-import", "--in", ctxPath)
+	})
+
+	e.Run(t, "neo-go", "wallet", "offline-import", "--in", ctxPath, "--out", ctxPath,
+		"--signed", signedPaths[1], "--signed", signedPaths[0])
+	pc, err := paramcontext.Read(ctxPath)
+	require.NoError(t, err)
+	require.True(t, pc.IsComplete())
+	_, err = pc.GetCompleteTransaction()
+	require.NoError(t, err)
+}
@@ -34,7 +34,7 @@ import (
 type transferTarget struct {
 	Token   util.Uint160
 	Address util.Uint160
-	Amount  int64
+	Amount  *big.Int
 	Data    any
 }
 
@@ -306,7 +306,7 @@ func decimalAmount(amount string, decimals int) string {
 	if decimals != 0 {
 		b, ok := new(big.Int).SetString(amount, 10)
 		if ok {
-			amount = fixedn.ToString(b, decimals)
+			amount = fixedn.NewDecimal(b, decimals).String()
 		}
 	}
 	return amount
@@ -583,14 +583,14 @@ func multiTransferNEP17(ctx *cli.Context) error {
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("invalid address: '%s'", ss[1]), 1)
 		}
-		amount, err := fixedn.FromString(ss[2], int(token.Decimals))
+		amount, err := fixedn.DecimalFromString(ss[2], int(token.Decimals))
 		if err != nil {
 			return cli.NewExitError(fmt.Errorf("invalid amount: %w", err), 1)
 		}
 		recipients = append(recipients, transferTarget{
 			Token:   token.Hash,
 			Address: addr,
-			Amount:  amount.Int64(),
+			Amount:  amount.Value(),
 			Data:    nil,
 		})
 	}
@@ -660,7 +660,7 @@ func transferNEP(ctx *cli.Context, standard string) error {
 	}
 
 	amountArg := ctx.String("amount")
-	amount, err := fixedn.FromString(amountArg, int(token.Decimals))
+	amount, err := fixedn.DecimalFromString(amountArg, int(token.Decimals))
 	// It's OK for NEP-11 transfer to not have amount set.
 	if err != nil && (standard == manifest.NEP17StandardName || amountArg != "") {
 		return cli.NewExitError(fmt.Errorf("invalid amount: %w", err), 1)
@@ -668,7 +668,7 @@ func transferNEP(ctx *cli.Context, standard string) error {
 	switch standard {
 	case manifest.NEP17StandardName:
 		n17 := nep17.New(act, token.Hash)
-		tx, err = n17.TransferUnsigned(act.Sender(), to, amount, data)
+		tx, err = n17.TransferUnsigned(act.Sender(), to, amount.Value(), data)
 	case manifest.NEP11StandardName:
 		tokenID := ctx.String("id")
 		if tokenID == "" {
@@ -683,7 +683,7 @@ func transferNEP(ctx *cli.Context, standard string) error {
 			tx, err = n11.TransferUnsigned(to, tokenIDBytes, data)
 		} else {
 			n11 := nep11.NewDivisible(act, token.Hash)
-			tx, err = n11.TransferDUnsigned(act.Sender(), to, amount, tokenIDBytes, data)
+			tx, err = n11.TransferDUnsigned(act.Sender(), to, amount.Value(), tokenIDBytes, data)
 		}
 	default:
 		return cli.NewExitError(fmt.Errorf("unsupported token standard %s", standard), 1)
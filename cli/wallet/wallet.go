@@ -9,6 +9,8 @@ import (
 	"math/big"
 	"os"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/cli/cmdargs"
 	"github.com/nspcc-dev/neo-go/cli/flags"
@@ -24,6 +26,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
 	"github.com/urfave/cli"
 )
 
@@ -63,6 +66,10 @@ var (
 		Name:  "decrypt, d",
 		Usage: "Decrypt encrypted keys.",
 	}
+	chunkSizeFlag = cli.IntFlag{
+		Name:  "chunk-size",
+		Usage: "Split compact container into chunks of the given size (one per line)",
+	}
 	inFlag = cli.StringFlag{
 		Name:  "in",
 		Usage: "file with JSON transaction",
@@ -103,6 +110,20 @@ func NewCommands() []cli.Command {
 			Name:  "address, a",
 			Usage: "Address to use",
 		},
+		cli.BoolFlag{
+			Name:  "ledger",
+			Usage: "Sign with Ledger device (NEO N3 app) instead of wallet account",
+		},
+		cli.StringFlag{
+			Name:  "ledger-path",
+			Usage: "BIP32 derivation path of the Ledger key to use",
+			Value: ledger.DefaultPath,
+		},
+		cli.BoolFlag{
+			Name:  "offline",
+			Usage: "Use compact container format for input and output (offline signing)",
+		},
+		chunkSizeFlag,
 	}
 	signFlags = append(signFlags, options.RPC...)
 	return []cli.Command{{
@@ -279,6 +300,64 @@ func NewCommands() []cli.Command {
 					},
 				}, options.RPC...),
 			},
+			{
+				Name:      "list",
+				Usage:     "list wallet accounts",
+				UsageText: "neo-go wallet list -w wallet [--wallet-config path] [--tag tag ...]",
+				Description: `Prints addresses, labels and tags of the wallet accounts. If --tag
+   flags are given, only accounts having all of the given tags are listed.
+`,
+				Action: listAccounts,
+				Flags: []cli.Flag{
+					walletPathFlag,
+					walletConfigFlag,
+					cli.StringSliceFlag{
+						Name:  "tag",
+						Usage: "list only accounts with the given tag (can be repeated)",
+					},
+				},
+			},
+			{
+				Name:  "account",
+				Usage: "manage wallet accounts",
+				Subcommands: []cli.Command{
+					{
+						Name:      "set-meta",
+						Usage:     "change account label, tags or notes",
+						UsageText: "neo-go wallet account set-meta -w wallet [--wallet-config path] --address <addr> [--label label] [--tag tag ...] [--untag tag ...] [--notes notes]",
+						Description: `Changes metadata of the given account. Tags given with --tag flags are
+   added to the account and the ones given with --untag flags are removed from
+   it. Tags, notes and account creation time are stored in the NEP-6 account
+   extra data, other data stored there (by other applications) is kept intact.
+`,
+						Action: setAccountMeta,
+						Flags: []cli.Flag{
+							walletPathFlag,
+							walletConfigFlag,
+							flags.AddressFlag{
+								Name:  "address, a",
+								Usage: "Account address or hash in LE form",
+							},
+							cli.StringFlag{
+								Name:  "label",
+								Usage: "new account label",
+							},
+							cli.StringSliceFlag{
+								Name:  "tag",
+								Usage: "tag to add (can be repeated)",
+							},
+							cli.StringSliceFlag{
+								Name:  "untag",
+								Usage: "tag to remove (can be repeated)",
+							},
+							cli.StringFlag{
+								Name:  "notes",
+								Usage: "new account notes (empty to remove them)",
+							},
+						},
+					},
+				},
+			},
 			{
 				Name:      "remove",
 				Usage:     "remove an account from the wallet",
@@ -297,7 +376,7 @@ func NewCommands() []cli.Command {
 			{
 				Name:      "sign",
 				Usage:     "cosign transaction with multisig/contract/additional account",
-				UsageText: "sign -w wallet [--wallet-config path] --address <address> --in <file.in> [--out <file.out>] [-r <endpoint>] [--await]",
+				UsageText: "sign {-w wallet [--wallet-config path] --address <address> | --ledger [--ledger-path path] [--address <address>]} --in <file.in> [--out <file.out>] [-r <endpoint>] [--await] [--offline [--chunk-size size]]",
 				Description: `Signs the given (in file.in) context (which must be a transaction
    signing context) for the given address using the given wallet. This command can
    output the resulting JSON (with additional signature added) right to the console
@@ -306,10 +385,64 @@ func NewCommands() []cli.Command {
    complete transaction and send it via RPC (printing its hash if everything is OK). 
    If the --await (with a given RPC endpoint) flag is included, the command waits 
    for the transaction to be included in a block before exiting.
+
+   If --ledger flag is given, the transaction is signed by the Ledger device
+   (which must be connected, unlocked and have NEO N3 app open) using the key
+   with the given BIP32 --ledger-path (m/44'/888'/0'/0/0 by default) instead
+   of a wallet account. The transaction needs to be confirmed on the device.
+   This requires NeoGo to be built with 'ledger' build tag.
+
+   If --offline flag is given, both input and output are compact signing
+   containers (see 'offline-export') and no RPC endpoint can be used, that's
+   intended for air-gapped machines. The resulting container can be split into
+   chunks of the given --chunk-size (one per line), convenient for QR codes.
+   It then can be imported back with 'offline-import'.
 `,
 				Action: signStoredTransaction,
 				Flags:  signFlags,
 			},
+			{
+				Name:      "offline-export",
+				Usage:     "export signing context into a compact container for offline signing",
+				UsageText: "offline-export --in <file.in> [--out <file.out>] [--chunk-size size]",
+				Description: `Converts the given (in file.in) JSON signing context into a compact
+   container containing network magic, transaction and signers' metadata that
+   can be signed on an air-gapped machine with 'sign --offline'. Container is
+   printed as base64 text (or saved into file.out), it can be split into
+   chunks of the given --chunk-size (one per line, "<index>/<total>:<data>"),
+   which is convenient for QR codes. Chunks can be decoded in any order.
+`,
+				Action: exportOffline,
+				Flags: []cli.Flag{
+					inFlag,
+					cli.StringFlag{
+						Name:  "out",
+						Usage: "file to put compact signing container to",
+					},
+					chunkSizeFlag,
+				},
+			},
+			{
+				Name:      "offline-import",
+				Usage:     "import offline signatures into signing context",
+				UsageText: "offline-import --in <file.in> --signed <file> [--signed <file> ...] [--out <file.out>]",
+				Description: `Imports signatures from the compact containers signed offline (--signed
+   files, with chunks in any order) into the given (in file.in) JSON signing
+   context. Every imported container must contain the same transaction for the
+   same network as the context and all of its signatures are verified before
+   merging, so a damaged or tampered container is rejected. Resulting context
+   is printed or saved into file.out (which can be the same as input one).
+`,
+				Action: importOffline,
+				Flags: []cli.Flag{
+					inFlag,
+					txctx.OutFlag,
+					cli.StringSliceFlag{
+						Name:  "signed",
+						Usage: "compact signing container file to import (can be repeated)",
+					},
+				},
+			},
 			{
 				Name:      "strip-keys",
 				Usage:     "remove private keys for all accounts",
@@ -739,6 +872,85 @@ func removeAccount(ctx *cli.Context) error {
 	return nil
 }
 
+func listAccounts(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := readWallet(ctx)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	tags := ctx.StringSlice("tag")
+	accs := wall.FindAccounts(func(a *wallet.Account) bool {
+		for _, t := range tags {
+			if !a.HasTag(t) {
+				return false
+			}
+		}
+		return true
+	})
+	tw := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ADDRESS\tLABEL\tTAGS")
+	for _, a := range accs {
+		var accTags []string
+		if a.Extra != nil {
+			accTags = a.Extra.Tags
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", a.Address, a.Label, strings.Join(accTags, ", "))
+	}
+	return tw.Flush()
+}
+
+func setAccountMeta(ctx *cli.Context) error {
+	if err := cmdargs.EnsureNone(ctx); err != nil {
+		return err
+	}
+	wall, _, err := openWallet(ctx, true)
+	if err != nil {
+		return cli.NewExitError(err, 1)
+	}
+	defer wall.Close()
+
+	addr := ctx.Generic("address").(*flags.Address)
+	if !addr.IsSet {
+		return cli.NewExitError("valid account address must be provided", 1)
+	}
+	acc := wall.GetAccount(addr.Uint160())
+	if acc == nil {
+		return cli.NewExitError("account wasn't found", 1)
+	}
+	var (
+		add    = ctx.StringSlice("tag")
+		remove = ctx.StringSlice("untag")
+	)
+	if !ctx.IsSet("label") && !ctx.IsSet("notes") && len(add) == 0 && len(remove) == 0 {
+		return cli.NewExitError("nothing to change, provide label, tags or notes", 1)
+	}
+	for _, t := range add {
+		if len(t) == 0 {
+			return cli.NewExitError("empty tag", 1)
+		}
+	}
+	if ctx.IsSet("label") {
+		acc.Label = ctx.String("label")
+	}
+	for _, t := range remove {
+		acc.RemoveTag(t)
+	}
+	for _, t := range add {
+		acc.AddTag(t)
+	}
+	if ctx.IsSet("notes") {
+		acc.SetNotes(ctx.String("notes"))
+	}
+	if err := wall.Save(); err != nil {
+		return cli.NewExitError(fmt.Errorf("error while saving wallet: %w", err), 1)
+	}
+	return nil
+}
+
 func askForConsent(w io.Writer) bool {
 	response, err := input.ReadLine("Are you sure? [y/N]: ")
 	if err == nil {
@@ -1078,6 +1290,9 @@ func addAccountAndSave(w *wallet.Wallet, acc *wallet.Account) error {
 		}
 	}
 
+	if acc.Extra == nil || acc.Extra.Created.IsZero() {
+		acc.SetCreated(time.Now())
+	}
 	w.AddAccount(acc)
 	return w.Save()
 }
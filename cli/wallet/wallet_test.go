@@ -70,6 +70,85 @@ func TestWalletAccountRemove(t *testing.T) {
 	require.Equal(t, w.Accounts[1], actual.Accounts[0])
 }
 
+func TestWalletAccountMeta(t *testing.T) {
+	tmpDir := t.TempDir()
+	e := testcli.NewExecutor(t, false)
+
+	walletPath := filepath.Join(tmpDir, "wallet.json")
+	e.In.WriteString("acc1\r")
+	e.In.WriteString("pass\r")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "init", "--wallet", walletPath, "--account")
+	e.In.WriteString("acc2\r")
+	e.In.WriteString("pass\r")
+	e.In.WriteString("pass\r")
+	e.Run(t, "neo-go", "wallet", "create", "--wallet", walletPath)
+
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	addr1, addr2 := w.Accounts[0].Address, w.Accounts[1].Address
+	for _, acc := range w.Accounts {
+		require.NotNil(t, acc.Extra)
+		require.False(t, acc.Extra.Created.IsZero())
+	}
+
+	t.Run("missing address", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--tag", "cold")
+	})
+	t.Run("unknown account", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath,
+			"--address", util.Uint160{}.StringLE(), "--tag", "cold")
+	})
+	t.Run("nothing to change", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--address", addr1)
+	})
+	t.Run("empty tag", func(t *testing.T) {
+		e.RunWithError(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--address", addr1, "--tag", "")
+	})
+
+	e.Run(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--address", addr1,
+		"--tag", "cold", "--tag", "deposit-42", "--notes", "paper backup", "--label", "cold one")
+	e.Run(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--address", addr2,
+		"--tag", "hot", "--tag", "deposit-42")
+
+	actual, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, "cold one", actual.Accounts[0].Label)
+	require.Equal(t, []string{"cold", "deposit-42"}, actual.Accounts[0].Extra.Tags)
+	require.Equal(t, "paper backup", actual.Accounts[0].Extra.Notes)
+	require.Equal(t, w.Accounts[0].Extra.Created, actual.Accounts[0].Extra.Created)
+	require.Equal(t, "acc2", actual.Accounts[1].Label)
+
+	t.Run("list", func(t *testing.T) {
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath)
+		e.CheckNextLine(t, `^ADDRESS\s+LABEL\s+TAGS$`)
+		e.CheckNextLine(t, "^"+addr1+`\s+cold one\s+cold, deposit-42$`)
+		e.CheckNextLine(t, "^"+addr2+`\s+acc2\s+hot, deposit-42$`)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath, "--tag", "cold")
+		e.CheckNextLine(t, `^ADDRESS`)
+		e.CheckNextLine(t, "^"+addr1)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath, "--tag", "deposit-42", "--tag", "hot")
+		e.CheckNextLine(t, `^ADDRESS`)
+		e.CheckNextLine(t, "^"+addr2)
+		e.CheckEOF(t)
+
+		e.Run(t, "neo-go", "wallet", "list", "--wallet", walletPath, "--tag", "unknown")
+		e.CheckNextLine(t, `^ADDRESS`)
+		e.CheckEOF(t)
+	})
+
+	e.Run(t, "neo-go", "wallet", "account", "set-meta", "--wallet", walletPath, "--address", addr1,
+		"--untag", "cold", "--untag", "unknown", "--notes", "")
+	actual, err = wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{"deposit-42"}, actual.Accounts[0].Extra.Tags)
+	require.Equal(t, "", actual.Accounts[0].Extra.Notes)
+}
+
 func TestWalletChangePassword(t *testing.T) {
 	tmpDir := t.TempDir()
 	e := testcli.NewExecutor(t, false)
@@ -3,7 +3,7 @@ Package nft contains non-divisible non-fungible NEP-11-compatible token
 implementation. This token can be minted with GAS transfer to contract address,
 it will hash some data (including data provided in transfer) and produce a
 base64-encoded string that is your NFT. Since it's based on hashing and basically
-you own a hash it's HASHY.
+you own a hash it's HASHY. It also implements NEP-24 royalties.
 */
 package nft
 
@@ -285,3 +285,23 @@ func Properties(id []byte) map[string]string {
 	}
 	return result
 }
+
+// RoyaltyRecipient is a single NEP-24 royalty payment description.
+type RoyaltyRecipient struct {
+	Address interop.Hash160
+	Amount  int
+}
+
+// RoyaltyInfo implements NEP-24 royalties, 10% of the sale price of any token
+// is to be paid to the contract owner.
+func RoyaltyInfo(tokenID []byte, royaltyToken interop.Hash160, salePrice int) []RoyaltyRecipient {
+	if salePrice < 0 {
+		panic("negative sale price")
+	}
+	ctx := storage.GetReadOnlyContext()
+	getOwnerOf(ctx, tokenID) // Panics for unknown tokens.
+	return []RoyaltyRecipient{{
+		Address: contractOwner,
+		Amount:  salePrice / 10,
+	}}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -103,3 +104,12 @@ func GetOracleContractState(t *testing.T, pathToInternalContracts string, sender
 		},
 	}
 }
+
+// DeployExample compiles example contract from the given directory of
+// examples using the given configuration file and deploys it with the
+// committee as a sender. It returns the hash of the deployed contract.
+func DeployExample(t *testing.T, e *neotest.Executor, pathToExamples string, dir string, config string) util.Uint160 {
+	c := neotest.CompileFile(t, e.CommitteeHash, filepath.Join(pathToExamples, dir), filepath.Join(pathToExamples, dir, config))
+	e.DeployContract(t, c, nil)
+	return c.Hash
+}
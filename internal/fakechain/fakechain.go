@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -452,6 +453,11 @@ func (s *FakeStateSync) NeedMPTNodes() bool {
 	panic("TODO")
 }
 
+// Progress implements the StateSync interface.
+func (s *FakeStateSync) Progress() (statesync.Progress, bool) {
+	return statesync.Progress{}, false
+}
+
 // Traverse implements the StateSync interface.
 func (s *FakeStateSync) Traverse(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error {
 	if s.TraverseFunc != nil {
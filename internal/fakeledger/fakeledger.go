@@ -0,0 +1,127 @@
+package fakeledger
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
+)
+
+// App emulates NEO N3 application of a Ledger device, it implements
+// ledger.Transport interface and signs everything with a single key.
+type App struct {
+	Key *keys.PrivateKey
+	// Name is the name of the open application.
+	Name    string
+	Version ledger.AppVersion
+	// Locked makes the device respond with the "locked" status.
+	Locked bool
+	// Reject makes the device reject signing requests.
+	Reject bool
+	// Closed is set when the transport is closed.
+	Closed bool
+	// Signed is the number of transactions signed.
+	Signed int
+
+	path  []byte
+	magic []byte
+	data  []byte
+	next  byte
+}
+
+// New returns an App for the given key.
+func New(key *keys.PrivateKey) *App {
+	return &App{
+		Key:     key,
+		Name:    ledger.AppName,
+		Version: ledger.MinAppVersion,
+	}
+}
+
+// Exchange implements the ledger.Transport interface.
+func (a *App) Exchange(apdu []byte) ([]byte, error) {
+	if a.Locked {
+		return status(nil, ledger.SWLocked), nil
+	}
+	if len(apdu) < 5 || int(apdu[4]) != len(apdu)-5 {
+		return status(nil, ledger.SWWrongDataLength), nil
+	}
+	if apdu[0] != ledger.CLA {
+		return status(nil, ledger.SWClaNotSupported), nil
+	}
+	var (
+		ins, p1, p2 = apdu[1], apdu[2], apdu[3]
+		data        = apdu[5:]
+	)
+	if ins == ledger.InsGetAppName {
+		return status([]byte(a.Name), ledger.SWOk), nil
+	}
+	if a.Name != ledger.AppName {
+		return status(nil, ledger.SWInsNotSupported), nil
+	}
+	switch ins {
+	case ledger.InsGetVersion:
+		return status([]byte{a.Version.Major, a.Version.Minor, a.Version.Patch}, ledger.SWOk), nil
+	case ledger.InsGetPublicKey:
+		a.path = bytes.Clone(data)
+		return status(a.Key.PublicKey().UncompressedBytes(), ledger.SWOk), nil
+	case ledger.InsSignTx:
+		return a.sign(p1, p2, data), nil
+	default:
+		return status(nil, ledger.SWInsNotSupported), nil
+	}
+}
+
+// sign handles SIGN_TX chunks.
+func (a *App) sign(p1, p2 byte, data []byte) []byte {
+	if p1 == ledger.P1SignPath {
+		a.path, a.magic, a.data, a.next = bytes.Clone(data), nil, nil, ledger.P1SignMagic
+		return status(nil, ledger.SWOk)
+	}
+	if p1 != a.next {
+		return status(nil, ledger.SWWrongP1P2)
+	}
+	a.next++
+	if p1 == ledger.P1SignMagic {
+		if len(data) != 4 {
+			return status(nil, ledger.SWWrongDataLength)
+		}
+		a.magic = bytes.Clone(data)
+		return status(nil, ledger.SWOk)
+	}
+	a.data = append(a.data, data...)
+	if p2 == ledger.P2More {
+		return status(nil, ledger.SWOk)
+	}
+	a.next = 0
+	if a.Reject {
+		return status(nil, ledger.SWDenied)
+	}
+	tx := new(transaction.Transaction)
+	if err := tx.DecodeHashableFields(a.data); err != nil {
+		return status(nil, ledger.SWTxParsingFailed)
+	}
+	sig, err := ledger.EncodeSignature(a.Key.SignHashable(binary.LittleEndian.Uint32(a.magic), tx))
+	if err != nil {
+		return status(nil, ledger.SWSignatureFailed)
+	}
+	a.Signed++
+	return status(sig, ledger.SWOk)
+}
+
+// Close implements the ledger.Transport interface.
+func (a *App) Close() error {
+	a.Closed = true
+	return nil
+}
+
+// Path returns the last BIP32 path (in device encoding) used.
+func (a *App) Path() []byte {
+	return a.path
+}
+
+func status(data []byte, sw uint16) []byte {
+	return binary.BigEndian.AppendUint16(data, sw)
+}
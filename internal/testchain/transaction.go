@@ -80,9 +80,11 @@ func NewDeployTx(bc Ledger, name string, sender util.Uint160, r gio.Reader, conf
 		o.ContractEvents = conf.Events
 		o.DeclaredNamedTypes = conf.NamedTypes
 		o.ContractSupportedStandards = conf.SupportedStandards
-		o.Permissions = make([]manifest.Permission, len(conf.Permissions))
-		for i := range conf.Permissions {
-			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+		if conf.Permissions != nil {
+			o.Permissions = make([]manifest.Permission, len(conf.Permissions))
+			for i := range conf.Permissions {
+				o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+			}
 		}
 		o.SafeMethods = conf.SafeMethods
 		o.Overloads = conf.Overloads
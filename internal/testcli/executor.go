@@ -280,6 +280,16 @@ func (e *Executor) RunWithError(t *testing.T, args ...string) {
 	checkExit(t, ch, 1)
 }
 
+// RunWithErrorCheck runs command and checks that it exits with error containing
+// the given message.
+func (e *Executor) RunWithErrorCheck(t *testing.T, msg string, args ...string) {
+	ch := setExitFunc()
+	err := e.run(args...)
+	require.Error(t, err)
+	require.ErrorContains(t, err, msg)
+	checkExit(t, ch, 1)
+}
+
 // Run runs command and checks that there were no errors.
 func (e *Executor) Run(t *testing.T, args ...string) {
 	ch := setExitFunc()
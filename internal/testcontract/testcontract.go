@@ -0,0 +1,23 @@
+// Package testcontract provides a trivial contract to be used as a fixture in
+// tests that don't need a real one.
+package testcontract
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+// New returns NEF and manifest of the "Test" contract with a single "main"
+// method returning 1.
+func New(t testing.TB) (*nef.File, *manifest.Manifest) {
+	n, err := nef.NewFile([]byte{byte(opcode.PUSH1), byte(opcode.RET)})
+	require.NoError(t, err)
+	m := manifest.NewManifest("Test")
+	m.ABI.Methods = []manifest.Method{{Name: "main", ReturnType: smartcontract.IntegerType}}
+	return n, m
+}
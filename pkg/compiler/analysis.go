@@ -6,6 +6,7 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"sort"
 	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -84,6 +85,8 @@ func (c *codegen) traverseGlobals() bool {
 				case *ast.DeferStmt:
 					hasDefer = true
 					return false
+				case *ast.CallExpr:
+					hasDefer = hasDefer || isRecoverCall(n)
 				}
 				return true
 			})
@@ -187,6 +190,9 @@ func countGlobals(f ast.Node, checkUnusedCalls bool) (int, int, bool) {
 						if id.Name != "_" { // If variable has name, then it's treated as used - that's countGlobals' caller responsibility to guarantee that.
 							if isVar {
 								numVar++
+								if len(valueSpec.Names) == 1 && isLazyVar(n, valueSpec) {
+									numVar++ // Initialization flag.
+								}
 							} else {
 								numConst++
 							}
@@ -271,6 +277,15 @@ func lastStmtIsReturn(body *ast.BlockStmt) (b bool) {
 //	initializing the package itself. If multiple packages import a package, the imported package
 //	will be initialized only once. The importing of packages, by construction, guarantees
 //	that there can be no cyclic initialization dependencies.
+//
+// Imports are visited in the order they appear in package files and files
+// are processed in the lexical order of their names, so for every package its
+// dependencies are initialized first and independent packages are
+// initialized in the order of their first import. Within a package all
+// global variables are initialized in the order of their declaration (files
+// are processed in the same lexical order) and then `init()` functions are
+// called. Unlike Go, the compiler doesn't reorder variable declarations of a
+// single package according to their dependencies.
 func (c *codegen) analyzePkgOrder() {
 	seen := make(map[string]bool)
 	info := c.buildInfo.program[0]
@@ -281,7 +296,15 @@ func (c *codegen) visitPkg(pkg *packages.Package, seen map[string]bool) {
 	if seen[pkg.PkgPath] {
 		return
 	}
+	fset := c.buildInfo.config.Fset
+	sort.SliceStable(pkg.Syntax, func(i, j int) bool {
+		return fset.Position(pkg.Syntax[i].Package).Filename < fset.Position(pkg.Syntax[j].Package).Filename
+	})
+	c.checkStdImports(pkg)
 	for _, imp := range pkg.Types.Imports() {
+		if _, ok := stdReplacements[imp.Path()]; ok {
+			continue // Reported by checkStdImports.
+		}
 		var subpkg = pkg.Imports[imp.Path()]
 		if subpkg == nil {
 			if c.prog.Err == nil {
@@ -417,6 +440,10 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 				if n.Tok == token.VAR {
 					for i, s := range n.Specs {
 						valSpec := s.(*ast.ValueSpec)
+						if isLazyVar(n, valSpec) && (len(valSpec.Names) != 1 || len(valSpec.Values) != 1) {
+							c.prog.Err = ErrInvalidLazyVar
+							return false // Program is invalid.
+						}
 						for j, id := range valSpec.Names {
 							if id.Name != "_" {
 								name := c.getIdentName(pkgPath, id.Name)
@@ -432,7 +459,8 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 							// Traverse both named/unnamed global variables, check whether function/method call
 							// is present inside variable value and if so, mark all its children as "used" for
 							// further traversal and evaluation.
-							if len(valSpec.Values) == 0 {
+							// Lazy variable initializer is only evaluated if the variable is used.
+							if len(valSpec.Values) == 0 || isLazyVar(n, valSpec) {
 								continue
 							}
 							multiRet := len(valSpec.Values) != len(valSpec.Names)
@@ -552,6 +580,7 @@ func (c *codegen) analyzeFuncAndGlobalVarUsage() funcUsage {
 	// and the code won't be emitted for them.
 	for name, node := range globalVarsCache {
 		if _, ok := globalVarsUsage[name]; !ok {
+			c.unusedGlobals[node.ident] = node.ident.Name
 			node.ident.Name = "_"
 		}
 	}
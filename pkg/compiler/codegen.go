@@ -34,6 +34,14 @@ type codegen struct {
 	// prog holds the output buffer.
 	prog *io.BufBinWriter
 
+	// errs contains errors accumulated during code generation.
+	errs Errors
+	// errPos is the position of the innermost node being converted when
+	// prog.Err has occurred.
+	errPos token.Pos
+	// warnings contains non-fatal diagnostic messages.
+	warnings Errors
+
 	// Type information.
 	typeInfo *types.Info
 	// pkgInfoInline is a stack of type information for packages containing inline functions.
@@ -68,6 +76,14 @@ type codegen struct {
 	// globalInlineCount contains the amount of auxiliary variables introduced by
 	// function inlining during global variables initialization.
 	globalInlineCount int
+	// lazyGlobals contains globals marked with //neo:lazy directive in the
+	// order of declaration.
+	lazyGlobals []*lazyGlobal
+	// initEntries contains pieces of code emitted into `_initialize` method.
+	initEntries []initEntry
+	// unusedGlobals contains original names of unused global variables
+	// renamed to "_".
+	unusedGlobals map[*ast.Ident]string
 
 	// A label for the for-loop being currently visited.
 	currentFor string
@@ -113,8 +129,14 @@ type codegen struct {
 	// emittedEvents contains all events emitted by the contract.
 	emittedEvents map[string][]EmittedEventInfo
 
-	// invokedContracts contains invoked methods of other contracts.
+	// invokedContracts contains invoked methods of other contracts that
+	// can change states or emit notifications.
 	invokedContracts map[util.Uint160][]string
+	// calledContracts contains all called methods of other contracts.
+	calledContracts map[util.Uint160][]string
+	// dynamicCalls is set if contract performs calls that can't be resolved
+	// at compile time (either contract hash or method is not constant).
+	dynamicCalls bool
 
 	// Label table for recording jump destinations.
 	l []int
@@ -152,6 +174,9 @@ type inlineContextSingle struct {
 	labelOffset int
 	// returnLabel contains label ID pointing to the first instruction right after the call.
 	returnLabel uint16
+	// name is the name of the inlined function and pos is the position of its call.
+	name string
+	pos  token.Pos
 }
 
 type varType int
@@ -168,7 +193,7 @@ var ErrUnsupportedTypeAssertion = errors.New("type assertion with two return val
 // newLabel creates a new label to jump to.
 func (c *codegen) newLabel() (l uint16) {
 	li := len(c.l)
-	if li > math.MaxUint16 {
+	if li >= noLabel {
 		c.prog.Err = errors.New("label number is too big")
 		return
 	}
@@ -294,6 +319,9 @@ func (c *codegen) emitLoadVar(pkg string, name string) {
 	} else if vi.index == unspecifiedVarIndex {
 		emit.Opcodes(c.prog.BinWriter, opcode.PUSHNULL)
 		return
+	} else if g := c.lazyGlobal(vi); g != nil {
+		emit.Call(c.prog.BinWriter, opcode.CALLL, g.label)
+		return
 	}
 	c.emitLoadByIndex(vi.refType, vi.index)
 }
@@ -316,6 +344,11 @@ func (c *codegen) emitStoreVar(pkg string, name string) {
 	}
 	vi := c.getVarIndex(pkg, name)
 	c.emitStoreByIndex(vi.refType, vi.index)
+	if g := c.lazyGlobal(vi); g != nil {
+		// Explicit assignment makes the initializer unnecessary.
+		emit.Opcodes(c.prog.BinWriter, opcode.PUSHT)
+		c.emitStoreByIndex(varGlobal, g.flag)
+	}
 }
 
 // emitLoadByIndex stores top value in the specified variable type with index i.
@@ -349,6 +382,12 @@ func (c *codegen) emitDefault(t types.Type) {
 		}
 		emit.Int(c.prog.BinWriter, int64(num))
 		emit.Opcodes(c.prog.BinWriter, opcode.PACKSTRUCT)
+	case *types.Array:
+		for i := int64(0); i < t.Len(); i++ {
+			c.emitDefault(t.Elem())
+		}
+		emit.Int(c.prog.BinWriter, t.Len())
+		emit.Opcodes(c.prog.BinWriter, opcode.PACK)
 	default:
 		emit.Opcodes(c.prog.BinWriter, opcode.PUSHNULL)
 	}
@@ -396,6 +435,7 @@ func (c *codegen) convertInitFuncs(f *ast.File, pkg *types.Package, lastCount in
 		switch n := node.(type) {
 		case *ast.FuncDecl:
 			if isInitFunc(n) {
+				start := c.prog.Len()
 				if lastCount != -1 {
 					c.clearSlots(lastCount)
 				}
@@ -405,6 +445,7 @@ func (c *codegen) convertInitFuncs(f *ast.File, pkg *types.Package, lastCount in
 				if lastCount > maxCount {
 					maxCount = lastCount
 				}
+				c.addInitEntry(InitEntryFunc, n.Pos(), nil, start)
 			}
 		case *ast.GenDecl:
 			return false
@@ -477,6 +518,14 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 		}
 	}
 
+	if !isLambda {
+		flags, err := parseMethodDirective(decl, pkg == c.mainPkg.Types)
+		if err != nil {
+			c.prog.Err = err
+		}
+		f.callFlags = flags
+	}
+
 	f.rng.Start = uint16(c.prog.Len())
 	c.scope = f
 	ast.Inspect(decl, c.scope.analyzeVoidCalls) // @OPTIMIZE
@@ -535,13 +584,18 @@ func (c *codegen) convertFuncDecl(file ast.Node, decl *ast.FuncDecl, pkg *types.
 		}
 	}
 
+	if !isInit && !isDeploy && hasDefer(decl.Body) {
+		c.startDefers(decl)
+	}
+
 	ast.Walk(c, decl.Body)
 
 	// If we have reached the end of the function without encountering `return` statement,
 	// we should clean alt.stack manually.
 	// This can be the case with void and named-return functions.
-	if !isInit && !isDeploy && !lastStmtIsReturn(decl.Body) {
-		c.processDefers()
+	if f.defers != nil {
+		c.emitDeferredCalls(decl.Body)
+	} else if !isInit && !isDeploy && !lastStmtIsReturn(decl.Body) {
 		c.saveSequencePoint(decl.Body)
 		emit.Opcodes(c.prog.BinWriter, opcode.RET)
 	}
@@ -577,6 +631,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 	if c.prog.Err != nil {
 		return nil
 	}
+	defer c.markErrorPos(node)
 	switch n := node.(type) {
 	// General declarations.
 	// var (
@@ -619,6 +674,18 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 						return nil
 					}
 				}
+				if n.Tok == token.VAR && isLazyVar(n, t) {
+					if c.scope != nil {
+						c.prog.Err = ErrLazyLocalVar
+						return nil
+					}
+					c.newLazyGlobal(t)
+					if c.prog.Err != nil {
+						return nil
+					}
+					continue
+				}
+				initStart := c.prog.Len()
 				multiRet := n.Tok == token.VAR && len(t.Values) != 0 && len(t.Names) != len(t.Values)
 				for _, id := range t.Names {
 					if id.Name != "_" {
@@ -660,6 +727,9 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 						c.emitStoreVar("", "_") // drop unused after walk
 					}
 				}
+				if c.scope == nil {
+					c.addInitEntry(InitEntryVar, t.Pos(), t.Names, initStart)
+				}
 			}
 		}
 		return nil
@@ -769,6 +839,11 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		}
 		c.dropItems(cnt)
 
+		if c.scope.defers != nil && len(c.pkgInfoInline) == 0 {
+			c.emitDeferredReturn(n)
+			return nil
+		}
+
 		if len(n.Results) == 0 {
 			results := c.scope.decl.Type.Results
 			if results.NumFields() != 0 {
@@ -791,8 +866,6 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			}
 		}
 
-		c.processDefers()
-
 		c.saveSequencePoint(n)
 		if len(c.pkgInfoInline) == 0 {
 			emit.Opcodes(c.prog.BinWriter, opcode.RET)
@@ -890,19 +963,18 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		return nil
 
 	case *ast.FuncLit:
-		var found bool
-		var l uint16
+		var lf *funcScope
 		for _, fs := range c.lambda {
 			if fs.decl.Body == n.Body {
-				found = true
-				l = fs.label
+				lf = fs
 				break
 			}
 		}
-		if !found {
-			l = c.newLabel()
-			c.newLambda(l, n)
+		if lf == nil {
+			lf = c.newLambda(c.newLabel(), n)
 		}
+		c.addCall(lf, n.Pos())
+		l := lf.label
 
 		buf := make([]byte, 4)
 		binary.LittleEndian.PutUint16(buf, l)
@@ -940,6 +1012,9 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 			c.convertStruct(n, false)
 		case *types.Map:
 			c.convertMap(n)
+		case *types.Pointer:
+			// Elided &T{} in a composite literal of pointers, like []*T{{...}}.
+			c.convertStruct(n, true)
 		default:
 			if tn, ok := t.(*types.Named); ok && isInteropPath(tn.String()) {
 				st, _, _, _ := scAndVMInteropTypeFromExpr(tn, false)
@@ -955,17 +1030,13 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 					return nil
 				}
 			}
-			ln := len(n.Elts)
+			elems := c.getSliceLitElems(n)
 			// ByteArrays needs a different approach than normal arrays.
 			if isByteSlice(typ) {
-				c.convertByteArray(n.Elts)
+				c.convertByteArray(elems)
 				return nil
 			}
-			for i := ln - 1; i >= 0; i-- {
-				ast.Walk(c, n.Elts[i])
-			}
-			emit.Int(c.prog.BinWriter, int64(ln))
-			emit.Opcodes(c.prog.BinWriter, opcode.PACK)
+			c.convertSliceLit(elems, typ)
 		}
 
 		return nil
@@ -1090,6 +1161,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		case isSyscall(f):
 			c.convertSyscall(f, n)
 		default:
+			c.addCall(f, n.Pos())
 			emit.Call(c.prog.BinWriter, opcode.CALLL, f.label)
 		}
 
@@ -1110,21 +1182,7 @@ func (c *codegen) Visit(node ast.Node) ast.Visitor {
 		return nil
 
 	case *ast.DeferStmt:
-		catch := c.newLabel()
-		finally := c.newLabel()
-		param := make([]byte, 8)
-		binary.LittleEndian.PutUint16(param[0:], catch)
-		binary.LittleEndian.PutUint16(param[4:], finally)
-		emit.Instruction(c.prog.BinWriter, opcode.TRYL, param)
-		index := c.scope.newLocal(fmt.Sprintf("defer@%d", n.Call.Pos()))
-		emit.Opcodes(c.prog.BinWriter, opcode.PUSH1)
-		c.emitStoreByIndex(varLocal, index)
-		c.scope.deferStack = append(c.scope.deferStack, deferInfo{
-			catchLabel:   catch,
-			finallyLabel: finally,
-			expr:         n.Call,
-			localIndex:   index,
-		})
+		c.emitDefer(n)
 		return nil
 
 	case *ast.SelectorExpr:
@@ -1452,66 +1510,6 @@ func (c *codegen) isCallExprSyscall(e ast.Expr) bool {
 	return ok && isSyscall(f)
 }
 
-// processDefers emits code for `defer` statements.
-// TRY-related opcodes handle exception as follows:
-//  1. CATCH block is executed only if exception has occurred.
-//  2. FINALLY block is always executed, but after catch block.
-//
-// Go `defer` statements are a bit different:
-//  1. `defer` is always executed irregardless of whether an exception has occurred.
-//  2. `recover` can or can not handle a possible exception.
-//
-// Thus, we use the following approach:
-//  1. Throwed exception is saved in a static field X, static fields Y and it is set to true.
-//  2. For each defer local there is a dedicated local variable which is set to 1 if `defer` statement
-//     is encountered during an actual execution.
-//  3. CATCH and FINALLY blocks are the same, and both contain the same CALLs.
-//  4. Right before the CATCH block, check a variable from (2). If it is null, jump to the end of CATCH+FINALLY block.
-//  5. In CATCH block we set Y to true and emit default return values if it is the last defer.
-//  6. Execute FINALLY block only if Y is false.
-func (c *codegen) processDefers() {
-	for i := len(c.scope.deferStack) - 1; i >= 0; i-- {
-		stmt := c.scope.deferStack[i]
-		after := c.newLabel()
-
-		c.emitLoadByIndex(varLocal, c.scope.deferStack[i].localIndex)
-		emit.Opcodes(c.prog.BinWriter, opcode.ISNULL)
-		emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, after)
-
-		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, after)
-
-		c.setLabel(stmt.catchLabel)
-		c.emitStoreByIndex(varGlobal, c.exceptionIndex)
-		emit.Int(c.prog.BinWriter, 1)
-
-		finalIndex := c.getVarIndex("", finallyVarName).index
-		c.emitStoreByIndex(varLocal, finalIndex)
-		ast.Walk(c, stmt.expr)
-		if i == 0 {
-			results := c.scope.decl.Type.Results
-			if results.NumFields() != 0 {
-				// After panic, default values must be returns, except for named returns,
-				// which we don't support here for now.
-				for i := len(results.List) - 1; i >= 0; i-- {
-					c.emitDefault(c.typeOf(results.List[i].Type))
-				}
-			}
-		}
-		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, after)
-
-		c.setLabel(stmt.finallyLabel)
-		before := c.newLabel()
-		c.emitLoadByIndex(varLocal, finalIndex)
-		emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, before)
-		ast.Walk(c, stmt.expr)
-		c.setLabel(before)
-		emit.Int(c.prog.BinWriter, 0)
-		c.emitStoreByIndex(varLocal, finalIndex)
-		emit.Opcodes(c.prog.BinWriter, opcode.ENDFINALLY)
-		c.setLabel(after)
-	}
-}
-
 // emitExplicitConvert handles `someType(someValue)` conversions between string/[]byte.
 // Rules for conversion:
 //  1. interop.* types are converted to ByteArray if not already.
@@ -1767,6 +1765,7 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 
 	if strings.HasPrefix(f.name, "Syscall") {
 		c.emitReverse(len(callArgs))
+		c.useFlags(arg0Str, syscallFlags[arg0Str], expr.Pos())
 		emit.Syscall(c.prog.BinWriter, arg0Str)
 	} else if strings.HasPrefix(f.name, "CallWithToken") {
 		var hasRet = !strings.HasSuffix(f.name, "NoRet")
@@ -1798,6 +1797,7 @@ func (c *codegen) convertSyscall(f *funcScope, expr *ast.CallExpr) {
 		}
 
 		c.appendInvokedContract(hash, method, flag)
+		c.useFlags(method, callflag.ReadStates|callflag.AllowCall|callflag.CallFlag(flag), expr.Pos())
 
 		tokNum, err := c.getCallToken(hash, method, len(callArgs), hasRet, callflag.CallFlag(flag))
 		if err != nil {
@@ -1948,7 +1948,13 @@ func (c *codegen) convertBuiltin(expr *ast.CallExpr) {
 		emit.Opcodes(c.prog.BinWriter, opcode.THROW)
 	case "recover":
 		if !c.scope.voidCalls[expr] {
+			// Panic value is wrapped into an array, see emitCatchPanic.
+			after := c.newLabel()
 			c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+			emit.Opcodes(c.prog.BinWriter, opcode.DUP, opcode.ISNULL)
+			emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, after)
+			emit.Opcodes(c.prog.BinWriter, opcode.PUSH0, opcode.PICKITEM)
+			c.setLabel(after)
 		}
 		emit.Opcodes(c.prog.BinWriter, opcode.PUSHNULL)
 		c.emitStoreByIndex(varGlobal, c.exceptionIndex)
@@ -2012,10 +2018,64 @@ func (c *codegen) emitConvert(typ stackitem.Type) {
 	}
 }
 
+// getSliceLitElems returns elements of the slice or array literal placed at
+// their indices, elements that are not specified explicitly (either because of
+// keyed elements like []int{5: 1} or because of array literal being shorter
+// than the array) are nil.
+func (c *codegen) getSliceLitElems(lit *ast.CompositeLit) []ast.Expr {
+	var (
+		elems []ast.Expr
+		index int
+	)
+	for _, e := range lit.Elts {
+		if kv, ok := e.(*ast.KeyValueExpr); ok {
+			val, _ := constant.Int64Val(c.typeAndValueOf(kv.Key).Value)
+			index = int(val)
+			e = kv.Value
+		}
+		for len(elems) <= index {
+			elems = append(elems, nil)
+		}
+		elems[index] = e
+		index++
+	}
+	if arr, ok := c.typeOf(lit).Underlying().(*types.Array); ok {
+		for int64(len(elems)) < arr.Len() {
+			elems = append(elems, nil)
+		}
+	}
+	return elems
+}
+
+// convertSliceLit emits code creating an array from the given elements of the
+// slice or array literal, missing elements are initialized with the default
+// value of the element type.
+func (c *codegen) convertSliceLit(elems []ast.Expr, typ types.Type) {
+	var elemType types.Type
+	switch t := typ.(type) {
+	case *types.Slice:
+		elemType = t.Elem()
+	case *types.Array:
+		elemType = t.Elem()
+	}
+	for i := len(elems) - 1; i >= 0; i-- {
+		if elems[i] == nil {
+			c.emitDefault(elemType)
+			continue
+		}
+		ast.Walk(c, elems[i])
+	}
+	emit.Int(c.prog.BinWriter, int64(len(elems)))
+	emit.Opcodes(c.prog.BinWriter, opcode.PACK)
+}
+
 func (c *codegen) convertByteArray(elems []ast.Expr) {
 	buf := make([]byte, len(elems))
 	varIndices := []int{}
 	for i := 0; i < len(elems); i++ {
+		if elems[i] == nil {
+			continue
+		}
 		t := c.typeAndValueOf(elems[i])
 		if t.Value != nil {
 			val, _ := constant.Int64Val(t.Value)
@@ -2060,7 +2120,7 @@ func (c *codegen) getStruct(typ types.Type) (*types.Struct, bool) {
 func (c *codegen) convertStruct(lit *ast.CompositeLit, ptr bool) {
 	// Create a new structScope to initialize and store
 	// the positions of its variables.
-	strct, ok := c.typeOf(lit).Underlying().(*types.Struct)
+	strct, ok := c.getStruct(c.typeOf(lit))
 	if !ok {
 		c.prog.Err = fmt.Errorf("the given literal is not of type struct: %v", lit)
 		return
@@ -2213,7 +2273,7 @@ func (c *codegen) getFuncNameFromSelector(e *ast.SelectorExpr) (string, bool) {
 	return c.getIdentName(ident.Name, e.Sel.Name), false
 }
 
-func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
+func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) *funcScope {
 	name := fmt.Sprintf("lambda@%d", u)
 	f := c.newFuncScope(&ast.FuncDecl{
 		Name: ast.NewIdent(name),
@@ -2221,18 +2281,57 @@ func (c *codegen) newLambda(u uint16, lit *ast.FuncLit) {
 		Body: lit.Body,
 	}, u)
 	c.lambda[c.getFuncNameFromDecl("", f.decl)] = f
+	return f
+}
+
+// markErrorPos remembers the position of the given node if an error has
+// occurred during its conversion. Nodes are processed recursively, so the
+// innermost one is marked.
+func (c *codegen) markErrorPos(n ast.Node) {
+	if c.prog.Err != nil && !c.errPos.IsValid() && n != nil {
+		c.errPos = n.Pos()
+	}
+}
+
+// takeError moves the current code generation error (if any) into the error
+// list, the given node position is used if there is no more specific one.
+// It returns true if there was an error.
+func (c *codegen) takeError(n ast.Node) bool {
+	if c.prog.Err == nil {
+		return false
+	}
+	pos := c.errPos
+	if !pos.IsValid() && n != nil {
+		pos = n.Pos()
+	}
+	c.errs = append(c.errs, newError(c.position(pos), CodeCodegen, c.prog.Err))
+	c.prog.Err = nil
+	c.errPos = token.NoPos
+	return true
+}
+
+// position converts token.Pos into token.Position.
+func (c *codegen) position(pos token.Pos) token.Position {
+	if !pos.IsValid() {
+		return token.Position{}
+	}
+	return c.buildInfo.config.Fset.Position(pos)
 }
 
 func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 	c.mainPkg = pkg
 	c.analyzePkgOrder()
-	if c.prog.Err != nil {
-		return c.prog.Err
+	if c.takeError(nil) || len(c.errs) != 0 {
+		return joinErrors(c.errs)
 	}
 	c.fillDocumentInfo()
 	funUsage := c.analyzeFuncAndGlobalVarUsage()
-	if c.prog.Err != nil {
-		return c.prog.Err
+	if c.takeError(nil) {
+		return joinErrors(c.errs)
+	}
+	c.checkDefers()
+	if len(c.errs) != 0 {
+		return joinErrors(c.errs)
 	}
 
 	// Bring all imported functions into scope.
@@ -2251,6 +2350,9 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 		c.deployEndOffset = c.prog.Len()
 		emit.Opcodes(c.prog.BinWriter, opcode.RET)
 	}
+	if c.takeError(nil) {
+		return joinErrors(c.errs)
+	}
 
 	// sort map keys to generate code deterministically.
 	keys := make([]*types.Package, 0, len(info.program))
@@ -2271,15 +2373,33 @@ func (c *codegen) compile(info *buildInfo, pkg *packages.Package) error {
 					pkgPath = pkg.Path()
 				}
 				name := c.getFuncNameFromDecl(pkgPath, n)
-				if !isInitFunc(n) && !isDeployFunc(n) && funUsage.funcUsed(name) &&
-					(!isInteropPath(pkg.Path()) && !canInline(pkg.Path(), n.Name.Name, false)) {
+				if isInitFunc(n) || isDeployFunc(n) {
+					continue
+				}
+				if !funUsage.funcUsed(name) {
+					if pkg == c.mainPkg.Types {
+						c.warnings = append(c.warnings, newError(c.position(n.Name.Pos()), CodeUnreachableMethod,
+							fmt.Errorf("function %s is never used", name)))
+					}
+					continue
+				}
+				if !isInteropPath(pkg.Path()) && !canInline(pkg.Path(), n.Name.Name, false) {
 					c.convertFuncDecl(f, n, pkg)
+					if c.takeError(n) {
+						// Continue with the next function to report as many
+						// errors as possible, the code is not emitted anyway.
+						c.labelList = c.labelList[:0]
+						c.currentFor, c.currentSwitch, c.nextLabel = "", "", ""
+					}
 				}
 			}
 		}
 	})
+	c.convertLazyGlobals()
+	c.checkMethodFlags()
+	c.checkStorageKeys()
 
-	return c.prog.Err
+	return joinErrors(c.errs)
 }
 
 func newCodegen(info *buildInfo, pkg *packages.Package) *codegen {
@@ -2296,47 +2416,51 @@ func newCodegen(info *buildInfo, pkg *packages.Package) *codegen {
 		constMap:         map[string]types.TypeAndValue{},
 		docIndex:         map[string]int{},
 		packageCache:     map[string]*packages.Package{},
+		unusedGlobals:    map[*ast.Ident]string{},
 
 		initEndOffset:   -1,
 		deployEndOffset: -1,
 
 		emittedEvents:    make(map[string][]EmittedEventInfo),
 		invokedContracts: make(map[util.Uint160][]string),
+		calledContracts:  make(map[util.Uint160][]string),
 		sequencePoints:   make(map[string][]DebugSeqPoint),
 	}
 }
 
-// codeGen compiles the program to bytecode.
-func codeGen(info *buildInfo) (*nef.File, *DebugInfo, error) {
+// codeGen compiles the program to bytecode. Warnings are returned even if
+// there is an error.
+func codeGen(info *buildInfo) (*nef.File, *DebugInfo, Errors, error) {
 	if len(info.program) == 0 {
-		return nil, nil, errors.New("empty package")
+		return nil, nil, nil, errors.New("empty package")
 	}
 	pkg := info.program[0]
 	c := newCodegen(info, pkg)
 
 	if err := c.compile(info, pkg); err != nil {
-		return nil, nil, err
+		return nil, nil, c.warnings, err
 	}
 
 	buf, err := c.writeJumps(c.prog.Bytes())
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, c.warnings, err
 	}
 
 	methods := bitfield.New(len(buf))
 	di := c.emitDebugInfo(buf)
+	di.InitReport = c.initReport()
 	for i := range di.Methods {
 		methods.Set(int(di.Methods[i].Range.Start))
 	}
 	f, err := nef.NewFile(buf)
 	if err != nil {
-		return nil, nil, fmt.Errorf("error while trying to create .nef file: %w", err)
+		return nil, nil, c.warnings, fmt.Errorf("error while trying to create .nef file: %w", err)
 	}
 	if c.callTokens != nil {
 		f.Tokens = c.callTokens
 	}
 	f.Checksum = f.CalculateChecksum()
-	return f, di, vm.IsScriptCorrect(buf, methods)
+	return f, di, c.warnings, vm.IsScriptCorrect(buf, methods)
 }
 
 func (c *codegen) resolveFuncDecls(f *ast.File, pkg *types.Package) {
@@ -2362,6 +2486,10 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 			if err != nil {
 				return nil, err
 			}
+			if binary.LittleEndian.Uint16(param[4:]) == noLabel {
+				binary.LittleEndian.PutUint32(param[4:], 0)
+				break
+			}
 			_, err = c.replaceLabelWithOffset(ctx.IP(), param[4:])
 			if err != nil {
 				return nil, err
@@ -2413,6 +2541,13 @@ func (c *codegen) writeJumps(b []byte) ([]byte, error) {
 	for _, f := range c.funcs {
 		f.rng.Start, f.rng.End = correctRange(f.rng.Start, f.rng.End, nopOffsets)
 	}
+	for i := range c.initEntries {
+		e := &c.initEntries[i]
+		e.rng.Start, e.rng.End = correctRange(e.rng.Start, e.rng.End, nopOffsets)
+	}
+	for _, g := range c.lazyGlobals {
+		g.rng.Start, g.rng.End = correctRange(g.rng.Start, g.rng.End, nopOffsets)
+	}
 	return removeNOPs(b, nopOffsets), nil
 }
 
@@ -5,8 +5,11 @@ import (
 	"go/types"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConvertToken(t *testing.T) {
@@ -90,3 +93,18 @@ func eval(t *testing.T, token token.Token, opcode opcode.Opcode, typ types.Type)
 	assert.NoError(t, err)
 	assert.Equal(t, opcode, op)
 }
+
+// Checks that syscall flags known to the compiler match the ones required by
+// the core.
+func TestSyscallFlags(t *testing.T) {
+	ic := &interop.Context{}
+	core.SpawnVM(ic) // set Functions field
+	var n int
+	for _, f := range ic.Functions {
+		require.Equal(t, f.RequiredFlags, syscallFlags[f.Name], f.Name)
+		if f.RequiredFlags != 0 {
+			n++
+		}
+	}
+	require.Equal(t, n, len(syscallFlags))
+}
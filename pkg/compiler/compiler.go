@@ -16,6 +16,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
@@ -41,6 +42,10 @@ type Options struct {
 	// The name of the output for contract manifest file.
 	ManifestFile string
 
+	// The name of the output for contract bundle containing NEF, manifest
+	// and debug info (see bundle package).
+	BundleFile string
+
 	// NoEventsCheck specifies if events emitted by contract needs to be present in manifest.
 	// This setting has effect only if manifest is emitted.
 	NoEventsCheck bool
@@ -62,6 +67,14 @@ type Options struct {
 	// occurrence of event call.
 	GuessEventTypes bool
 
+	// ExtendedTypes specifies if extended types of method parameters, return
+	// values and event parameters along with named structure types they use
+	// need to be stored in the manifest extra data (see
+	// binding.ManifestTypes). It allows to generate typed RPC bindings using
+	// the manifest only.
+	// This setting has effect only if manifest is emitted.
+	ExtendedTypes bool
+
 	// Name is a contract's name to be written to manifest.
 	Name string
 
@@ -86,10 +99,17 @@ type Options struct {
 	Overloads map[string]string
 
 	// Permissions is a list of permissions for every contract method.
+	// If nil, the minimal set of permissions is inferred from contract
+	// invocations performed by the contract (wildcard permission is used
+	// if some of them can't be resolved at compile time).
 	Permissions []manifest.Permission
 
 	// BindingsFile contains configuration for smart-contract bindings generator.
 	BindingsFile string
+
+	// InitReport is the name of the output file for `_initialize` method
+	// report (see InitReport).
+	InitReport string
 }
 
 // HybridEvent represents the description of event emitted by the contract squashed
@@ -210,18 +230,22 @@ func getBuildInfo(name string, src any) (*buildInfo, error) {
 		if singleFile && filepath.Dir(filename) == filepath.Dir(absName) && filename != absName {
 			return nil, nil
 		}
-		const mode = parser.AllErrors
+		const mode = parser.AllErrors | parser.ParseComments
 		return parser.ParseFile(fset, filename, src, mode)
 	}
 	prog, err := packages.Load(conf, names...)
 	if err != nil {
 		return nil, err
 	}
+	var errs Errors
 	for _, p := range prog {
-		if len(p.Errors) != 0 {
-			return nil, p.Errors[0]
+		for _, e := range p.Errors {
+			errs = append(errs, packageError(e))
 		}
 	}
+	if len(errs) != 0 {
+		return nil, joinErrors(errs)
+	}
 	return &buildInfo{
 		config:  conf,
 		program: prog,
@@ -241,17 +265,79 @@ func Compile(name string, r io.Reader) ([]byte, error) {
 }
 
 // CompileWithOptions compiles a Go program into bytecode with the provided compiler options.
+// If there is more than one error, Errors is returned, otherwise it's either Error
+// (for errors related to some specific position in the source code) or any other error.
 func CompileWithOptions(name string, r io.Reader, o *Options) (*nef.File, *DebugInfo, error) {
 	ctx, err := getBuildInfo(name, r)
 	if err != nil {
 		return nil, nil, err
 	}
 	ctx.options = o
-	return codeGen(ctx)
+	f, di, _, err := codeGen(ctx)
+	return f, di, err
+}
+
+// CompileWithDiagnostics is similar to CompileWithOptions, but it also returns
+// all diagnostic messages produced by the compiler including warnings (like
+// events declared, but never emitted and functions that are never called).
+// Warnings are returned even if compilation succeeds, errors are returned both
+// as diagnostics and as an error.
+func CompileWithDiagnostics(name string, r io.Reader, o *Options) (*nef.File, *DebugInfo, []Diagnostic, error) {
+	var (
+		diags    []Diagnostic
+		warnings Errors
+		f        *nef.File
+		di       *DebugInfo
+	)
+	ctx, err := getBuildInfo(name, r)
+	if err == nil {
+		ctx.options = o
+		f, di, warnings, err = codeGen(ctx)
+	}
+	if err != nil {
+		for _, e := range toErrors(err, CodeCodegen) {
+			diags = append(diags, Diagnostic{Error: e, Severity: SeverityError})
+		}
+	}
+	if err == nil && o != nil {
+		for _, e := range o.ContractEvents {
+			if _, ok := di.EmittedEvents[e.Name]; !ok {
+				warnings = append(warnings, Error{
+					Code: CodeUnusedEvent,
+					Msg:  fmt.Sprintf("event %q is declared, but never emitted", e.Name),
+				})
+			}
+		}
+	}
+	for _, w := range warnings {
+		diags = append(diags, Diagnostic{Error: w, Severity: SeverityWarning})
+	}
+	return f, di, diags, err
 }
 
 // CompileAndSave will compile and save the file to disk in the NEF format.
 func CompileAndSave(src string, o *Options) ([]byte, error) {
+	script, _, err := CompileAndSaveWithDiagnostics(src, o)
+	return script, err
+}
+
+// CompileAndSaveWithDiagnostics is similar to CompileAndSave, but it also
+// returns all diagnostic messages produced (see CompileWithDiagnostics).
+// Errors related to output files generation are also included into them
+// with CodeOutput code.
+func CompileAndSaveWithDiagnostics(src string, o *Options) ([]byte, []Diagnostic, error) {
+	script, diags, err := compileAndSave(src, o)
+	if err != nil && !hasErrors(diags) {
+		// Compilation itself succeeded, but something else went wrong.
+		diags = append(diags, Diagnostic{
+			Error:    newError(token.Position{}, CodeOutput, err),
+			Severity: SeverityError,
+		})
+	}
+	return script, diags, err
+}
+
+func compileAndSave(src string, o *Options) ([]byte, []Diagnostic, error) {
 	o.Outfile = strings.TrimSuffix(o.Outfile, fmt.Sprintf(".%s", fileExt))
 	if len(o.Outfile) == 0 {
 		if strings.HasSuffix(src, ".go") {
@@ -263,31 +349,42 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 	if len(o.Ext) == 0 {
 		o.Ext = fileExt
 	}
-	f, di, err := CompileWithOptions(src, nil, o)
+	f, di, diags, err := CompileWithDiagnostics(src, nil, o)
 	if err != nil {
-		return nil, fmt.Errorf("error while trying to compile smart contract file: %w", err)
+		return nil, diags, fmt.Errorf("error while trying to compile smart contract file: %w", err)
 	}
 	if o.SourceURL != "" {
 		if len(o.SourceURL) > nef.MaxSourceURLLength {
-			return nil, errors.New("too long source URL")
+			return nil, diags, errors.New("too long source URL")
 		}
 		f.Source = o.SourceURL
 		f.Checksum = f.CalculateChecksum()
 	}
 	bytes, err := f.Bytes()
 	if err != nil {
-		return nil, fmt.Errorf("error while serializing .nef file: %w", err)
+		return nil, diags, fmt.Errorf("error while serializing .nef file: %w", err)
 	}
 	out := fmt.Sprintf("%s.%s", o.Outfile, o.Ext)
 	err = os.WriteFile(out, bytes, os.ModePerm)
 	if err != nil {
-		return f.Script, err
+		return f.Script, diags, err
 	}
-	if o.DebugInfo == "" && o.ManifestFile == "" && o.BindingsFile == "" {
-		return f.Script, nil
+	if o.DebugInfo == "" && o.ManifestFile == "" && o.BindingsFile == "" && o.InitReport == "" && o.BundleFile == "" {
+		return f.Script, diags, nil
 	}
 
-	if o.DebugInfo != "" {
+	if o.InitReport != "" {
+		data, err := json.MarshalIndent(di.InitReport, "", "  ")
+		if err != nil {
+			return f.Script, diags, fmt.Errorf("failed to marshal init report: %w", err)
+		}
+		if err := os.WriteFile(o.InitReport, data, os.ModePerm); err != nil {
+			return f.Script, diags, err
+		}
+	}
+
+	var diData []byte
+	if o.DebugInfo != "" || o.BundleFile != "" {
 		di.Events = make([]EventDebugInfo, len(o.ContractEvents))
 		for i, e := range o.ContractEvents {
 			params := make([]DebugParam, len(e.Parameters))
@@ -305,147 +402,54 @@ func CompileAndSave(src string, o *Options) ([]byte, error) {
 				Parameters: params,
 			}
 		}
-		data, err := json.Marshal(di)
+		diData, err = json.Marshal(di)
 		if err != nil {
-			return f.Script, err
+			return f.Script, diags, err
 		}
-		if err := os.WriteFile(o.DebugInfo, data, os.ModePerm); err != nil {
-			return f.Script, err
+		if o.DebugInfo != "" {
+			if err := os.WriteFile(o.DebugInfo, diData, os.ModePerm); err != nil {
+				return f.Script, diags, err
+			}
 		}
 	}
 
 	if o.BindingsFile != "" {
-		cfg := binding.NewConfig()
-		cfg.Package = di.MainPkg
-		for _, m := range di.Methods {
-			if !m.IsExported {
-				continue
-			}
-			for _, p := range m.Parameters {
-				pname := m.Name.Name + "." + p.Name
-				if p.RealType.TypeName != "" {
-					cfg.Overrides[pname] = p.RealType
-				}
-				if p.ExtendedType != nil {
-					cfg.Types[pname] = *p.ExtendedType
-				}
-			}
-			if m.ReturnTypeReal.TypeName != "" {
-				cfg.Overrides[m.Name.Name] = m.ReturnTypeReal
-			}
-			if m.ReturnTypeExtended != nil {
-				cfg.Types[m.Name.Name] = *m.ReturnTypeExtended
-			}
-		}
-		if len(di.NamedTypes) > 0 {
-			cfg.NamedTypes = di.NamedTypes
-		}
-		for name, et := range o.DeclaredNamedTypes {
-			if _, ok := cfg.NamedTypes[name]; ok {
-				return nil, fmt.Errorf("configured declared named type intersects with the contract's one: `%s`", name)
-			}
-			cfg.NamedTypes[name] = et
-		}
-		for _, e := range o.ContractEvents {
-			eStructName := rpcbinding.ToEventBindingName(e.Name)
-			for _, p := range e.Parameters {
-				pStructName := rpcbinding.ToParameterBindingName(p.Name)
-				if p.ExtendedType != nil {
-					pName := eStructName + "." + pStructName
-					cfg.Types[pName] = *p.ExtendedType
-				}
-			}
-		}
-		if o.GuessEventTypes {
-			if len(di.EmittedEvents) > 0 {
-				var keys = make([]string, 0, len(di.EmittedEvents))
-				for k := range di.EmittedEvents {
-					keys = append(keys, k)
-				}
-				sort.Strings(keys)
-				for _, eventName := range keys {
-					var (
-						eventUsages   = di.EmittedEvents[eventName]
-						manifestEvent HybridEvent
-					)
-					for _, e := range o.ContractEvents {
-						if e.Name == eventName {
-							manifestEvent = e
-							break
-						}
-					}
-					if len(manifestEvent.Name) == 0 {
-						return nil, fmt.Errorf("inconsistent usages of event `%s`: not declared in the contract config", eventName)
-					}
-					exampleUsage := eventUsages[0]
-					for _, usage := range eventUsages {
-						if len(usage.Params) != len(manifestEvent.Parameters) {
-							return nil, fmt.Errorf("inconsistent usages of event `%s` against config: number of params mismatch: %d vs %d", eventName, len(exampleUsage.Params), len(manifestEvent.Parameters))
-						}
-						for i, actual := range usage.Params {
-							mParam := manifestEvent.Parameters[i]
-							// TODO: see the TestCompile_GuessEventTypes, "SC parameter type mismatch" section,
-							// do we want to compare with actual.RealType? The conversion code is emitted by the
-							// compiler for it, so we expect the parameter to be of the proper type.
-							if !(mParam.Type == smartcontract.AnyType || actual.TypeSC == mParam.Type) {
-								return nil, fmt.Errorf("inconsistent usages of event `%s` against config: SC type of param #%d mismatch: %s vs %s", eventName, i, actual.TypeSC, mParam.Type)
-							}
-							expected := exampleUsage.Params[i]
-							if !actual.ExtendedType.Equals(expected.ExtendedType) {
-								return nil, fmt.Errorf("inconsistent usages of event `%s`: extended type of param #%d mismatch", eventName, i)
-							}
-						}
-					}
-					eBindingName := rpcbinding.ToEventBindingName(eventName)
-					for _, p := range exampleUsage.Params {
-						pBindingName := rpcbinding.ToParameterBindingName(p.Name)
-						pname := eBindingName + "." + pBindingName
-						if p.RealType.TypeName != "" {
-							if _, ok := cfg.Overrides[pname]; !ok {
-								cfg.Overrides[pname] = p.RealType
-							}
-						}
-						if p.ExtendedType != nil {
-							typeName := p.ExtendedType.Name
-							if extType, ok := exampleUsage.ExtTypes[typeName]; ok {
-								for _, ok := cfg.NamedTypes[typeName]; ok; _, ok = cfg.NamedTypes[typeName] {
-									typeName = typeName + "X"
-								}
-								extType.Name = typeName
-								p.ExtendedType.Name = typeName
-								cfg.NamedTypes[typeName] = extType
-							}
-							if _, ok := cfg.Types[pname]; !ok {
-								cfg.Types[pname] = *p.ExtendedType
-							}
-						}
-					}
-				}
-			}
+		cfg, err := bindingsConfig(di, o)
+		if err != nil {
+			return nil, diags, err
 		}
 		data, err := yaml.Marshal(&cfg)
 		if err != nil {
-			return nil, fmt.Errorf("can't marshal bindings configuration: %w", err)
+			return nil, diags, fmt.Errorf("can't marshal bindings configuration: %w", err)
 		}
 		err = os.WriteFile(o.BindingsFile, data, os.ModePerm)
 		if err != nil {
-			return nil, fmt.Errorf("can't write bindings configuration: %w", err)
+			return nil, diags, fmt.Errorf("can't write bindings configuration: %w", err)
 		}
 	}
 
+	if o.ManifestFile == "" && o.BundleFile == "" {
+		return f.Script, diags, nil
+	}
+	m, err := CreateManifest(di, o)
+	if err != nil {
+		return f.Script, diags, err
+	}
 	if o.ManifestFile != "" {
-		m, err := CreateManifest(di, o)
-		if err != nil {
-			return f.Script, err
-		}
 		mData, err := json.Marshal(m)
 		if err != nil {
-			return f.Script, fmt.Errorf("failed to marshal manifest to JSON: %w", err)
+			return f.Script, diags, fmt.Errorf("failed to marshal manifest to JSON: %w", err)
+		}
+		if err := os.WriteFile(o.ManifestFile, mData, os.ModePerm); err != nil {
+			return f.Script, diags, err
 		}
-		return f.Script, os.WriteFile(o.ManifestFile, mData, os.ModePerm)
 	}
-
-	return f.Script, nil
+	if o.BundleFile != "" {
+		if err := bundle.WriteFile(o.BundleFile, f, m, diData); err != nil {
+			return f.Script, diags, fmt.Errorf("failed to write bundle: %w", err)
+		}
+	}
+	return f.Script, diags, nil
 }
 
 // CreateManifest creates manifest and checks that is is valid.
@@ -454,6 +458,16 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	if err != nil {
 		return m, fmt.Errorf("failed to convert debug info to manifest: %w", err)
 	}
+	if o.ExtendedTypes {
+		cfg, err := bindingsConfig(di, o)
+		if err != nil {
+			return m, err
+		}
+		mt := binding.ManifestTypes{NamedTypes: cfg.NamedTypes, Types: cfg.Types}
+		if err := binding.PutManifestTypes(m, mt); err != nil {
+			return m, fmt.Errorf("failed to store extended types: %w", err)
+		}
+	}
 	for _, name := range o.SafeMethods {
 		if m.ABI.GetMethod(name, -1) == nil {
 			return m, fmt.Errorf("method %s is marked as safe but missing from manifest", name)
@@ -508,13 +522,14 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 		// 1. Contract hash may not be available at compile time.
 		// 2. Permission may be specified for a group of contracts by public key.
 		// Thus only basic checks are performed.
+		perms := m.Permissions
 
 		for h, methods := range di.InvokedContracts {
 			knownHash := !h.Equals(util.Uint160{})
 
 		methodLoop:
 			for _, m := range methods {
-				for _, p := range o.Permissions {
+				for _, p := range perms {
 					// Group or wildcard permission is ok to try.
 					if knownHash && p.Contract.Type == manifest.PermissionHash && !p.Contract.Hash().Equals(h) {
 						continue
@@ -536,3 +551,118 @@ func CreateManifest(di *DebugInfo, o *Options) (*manifest.Manifest, error) {
 	}
 	return m, nil
 }
+
+// bindingsConfig creates smart-contract bindings configuration with extended
+// types data from the debug info and compiler options.
+func bindingsConfig(di *DebugInfo, o *Options) (binding.Config, error) {
+	cfg := binding.NewConfig()
+	cfg.Package = di.MainPkg
+	for _, m := range di.Methods {
+		if !m.IsExported {
+			continue
+		}
+		for _, p := range m.Parameters {
+			pname := m.Name.Name + "." + p.Name
+			if p.RealType.TypeName != "" {
+				cfg.Overrides[pname] = p.RealType
+			}
+			if p.ExtendedType != nil {
+				cfg.Types[pname] = *p.ExtendedType
+			}
+		}
+		if m.ReturnTypeReal.TypeName != "" {
+			cfg.Overrides[m.Name.Name] = m.ReturnTypeReal
+		}
+		if m.ReturnTypeExtended != nil {
+			cfg.Types[m.Name.Name] = *m.ReturnTypeExtended
+		}
+	}
+	for name, et := range di.NamedTypes {
+		cfg.NamedTypes[name] = et
+	}
+	for name, et := range o.DeclaredNamedTypes {
+		if _, ok := cfg.NamedTypes[name]; ok {
+			return cfg, fmt.Errorf("configured declared named type intersects with the contract's one: `%s`", name)
+		}
+		cfg.NamedTypes[name] = et
+	}
+	for _, e := range o.ContractEvents {
+		eStructName := rpcbinding.ToEventBindingName(e.Name)
+		for _, p := range e.Parameters {
+			pStructName := rpcbinding.ToParameterBindingName(p.Name)
+			if p.ExtendedType != nil {
+				pName := eStructName + "." + pStructName
+				cfg.Types[pName] = *p.ExtendedType
+			}
+		}
+	}
+	if o.GuessEventTypes {
+		if len(di.EmittedEvents) > 0 {
+			var keys = make([]string, 0, len(di.EmittedEvents))
+			for k := range di.EmittedEvents {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, eventName := range keys {
+				var (
+					eventUsages   = di.EmittedEvents[eventName]
+					manifestEvent HybridEvent
+				)
+				for _, e := range o.ContractEvents {
+					if e.Name == eventName {
+						manifestEvent = e
+						break
+					}
+				}
+				if len(manifestEvent.Name) == 0 {
+					return cfg, fmt.Errorf("inconsistent usages of event `%s`: not declared in the contract config", eventName)
+				}
+				exampleUsage := eventUsages[0]
+				for _, usage := range eventUsages {
+					if len(usage.Params) != len(manifestEvent.Parameters) {
+						return cfg, fmt.Errorf("inconsistent usages of event `%s` against config: number of params mismatch: %d vs %d", eventName, len(exampleUsage.Params), len(manifestEvent.Parameters))
+					}
+					for i, actual := range usage.Params {
+						mParam := manifestEvent.Parameters[i]
+						// TODO: see the TestCompile_GuessEventTypes, "SC parameter type mismatch" section,
+						// do we want to compare with actual.RealType? The conversion code is emitted by the
+						// compiler for it, so we expect the parameter to be of the proper type.
+						if !(mParam.Type == smartcontract.AnyType || actual.TypeSC == mParam.Type) {
+							return cfg, fmt.Errorf("inconsistent usages of event `%s` against config: SC type of param #%d mismatch: %s vs %s", eventName, i, actual.TypeSC, mParam.Type)
+						}
+						expected := exampleUsage.Params[i]
+						if !actual.ExtendedType.Equals(expected.ExtendedType) {
+							return cfg, fmt.Errorf("inconsistent usages of event `%s`: extended type of param #%d mismatch", eventName, i)
+						}
+					}
+				}
+				eBindingName := rpcbinding.ToEventBindingName(eventName)
+				for _, p := range exampleUsage.Params {
+					pBindingName := rpcbinding.ToParameterBindingName(p.Name)
+					pname := eBindingName + "." + pBindingName
+					if p.RealType.TypeName != "" {
+						if _, ok := cfg.Overrides[pname]; !ok {
+							cfg.Overrides[pname] = p.RealType
+						}
+					}
+					if p.ExtendedType != nil {
+						typeName := p.ExtendedType.Name
+						if extType, ok := exampleUsage.ExtTypes[typeName]; ok {
+							for _, ok := cfg.NamedTypes[typeName]; ok; _, ok = cfg.NamedTypes[typeName] {
+								typeName = typeName + "X"
+							}
+							extType.Name = typeName
+							cfg.NamedTypes[typeName] = extType
+						}
+						if _, ok := cfg.Types[pname]; !ok {
+							et := *p.ExtendedType
+							et.Name = typeName
+							cfg.Types[pname] = et
+						}
+					}
+				}
+			}
+		}
+	}
+	return cfg, nil
+}
@@ -12,8 +12,11 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/versionutil"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/ledger"
 	"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -169,6 +172,45 @@ func TestSafeMethodWarnings(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestExtendedTypesManifest(t *testing.T) {
+	src := `package pointer
+		type Point struct {
+			X    int
+			Name string
+		}
+		func GetPoint(x int) *Point { return &Point{X: x, Name: "point"} }
+		func Main() int { return 1 }`
+
+	_, di, err := compiler.CompileWithOptions("pointer.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+
+	m, err := compiler.CreateManifest(di, &compiler.Options{Name: "pointer"})
+	require.NoError(t, err)
+	mt, err := binding.GetManifestTypes(m)
+	require.NoError(t, err)
+	require.Nil(t, mt)
+
+	o := &compiler.Options{Name: "pointer", ExtendedTypes: true}
+	m, err = compiler.CreateManifest(di, o)
+	require.NoError(t, err)
+	mt, err = binding.GetManifestTypes(m)
+	require.NoError(t, err)
+	require.NotNil(t, mt)
+	require.Equal(t, binding.ExtendedType{Base: smartcontract.ArrayType, Name: "pointer.Point"}, mt.Types["getPoint"])
+	require.Equal(t, binding.ExtendedType{
+		Base: smartcontract.ArrayType,
+		Name: "pointer.Point",
+		Fields: []binding.FieldExtendedType{
+			{Field: "X", ExtendedType: binding.ExtendedType{Base: smartcontract.IntegerType}},
+			{Field: "Name", ExtendedType: binding.ExtendedType{Base: smartcontract.StringType}},
+		},
+	}, mt.NamedTypes["pointer.Point"])
+
+	// Manifest is created multiple times for the same debug info.
+	_, err = compiler.CreateManifest(di, o)
+	require.NoError(t, err)
+}
+
 func TestEventWarnings(t *testing.T) {
 	src := `package payable
 		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
@@ -408,6 +450,107 @@ func TestInvokedContractsPermissons(t *testing.T) {
 	})
 }
 
+func TestInferredPermissions(t *testing.T) {
+	t.Run("constant hashes", func(t *testing.T) {
+		hashStr := "aaaaaaaaaaaaaaaaaaaa"
+		src := fmt.Sprintf(`package test
+			import "github.com/nspcc-dev/neo-go/pkg/interop"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/native/ledger"
+			const hash = "%s"
+			var flags contract.CallFlag
+			func Main() {
+				neo.Transfer(nil, nil, 10, nil)
+				neo.Vote(nil, nil)
+				gas.Transfer(nil, nil, 10, nil)
+				ledger.CurrentIndex()
+				contract.Call(interop.Hash160(hash), "method", contract.All)
+				contract.Call(interop.Hash160(hash), "get", contract.ReadStates)
+				contract.Call(interop.Hash160(hash), "other", flags)
+			}`, hashStr)
+
+		_, di, diags, err := compiler.CompileWithDiagnostics("permissionTest.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		require.Empty(t, diags)
+
+		m, err := compiler.CreateManifest(di, &compiler.Options{Name: "test"})
+		require.NoError(t, err)
+
+		var nh, gh, lh, h util.Uint160
+		copy(nh[:], neo.Hash)
+		copy(gh[:], gas.Hash)
+		copy(lh[:], ledger.Hash)
+		copy(h[:], hashStr)
+		require.Equal(t, 4, len(m.Permissions))
+		for _, p := range m.Permissions {
+			require.Equal(t, manifest.PermissionHash, p.Contract.Type)
+			switch p.Contract.Hash() {
+			case nh:
+				require.Equal(t, []string{"transfer", "vote"}, p.Methods.Value)
+			case gh:
+				require.Equal(t, []string{"transfer"}, p.Methods.Value)
+			case lh:
+				require.Equal(t, []string{"currentIndex"}, p.Methods.Value)
+			case h:
+				require.Equal(t, []string{"get", "method", "other"}, p.Methods.Value)
+			default:
+				t.Fatalf("unexpected permission for %s", p.Contract.Hash().StringLE())
+			}
+		}
+
+		t.Run("override", func(t *testing.T) {
+			m, err := compiler.CreateManifest(di, &compiler.Options{
+				Name:        "test",
+				Permissions: []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+			})
+			require.NoError(t, err)
+			require.Equal(t, 1, len(m.Permissions))
+			require.Equal(t, manifest.PermissionWildcard, m.Permissions[0].Contract.Type)
+		})
+	})
+
+	t.Run("dynamic hash", func(t *testing.T) {
+		src := `package test
+			import "github.com/nspcc-dev/neo-go/pkg/interop"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+			func Main(h interop.Hash160) {
+				neo.Transfer(nil, nil, 10, nil)
+				contract.Call(h, "method", contract.All)
+			}`
+
+		_, di, diags, err := compiler.CompileWithDiagnostics("permissionTest.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(diags))
+		require.Equal(t, compiler.CodeDynamicCall, diags[0].Code)
+		require.Equal(t, compiler.SeverityWarning, diags[0].Severity)
+
+		m, err := compiler.CreateManifest(di, &compiler.Options{Name: "test"})
+		require.NoError(t, err)
+		require.Equal(t, []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}, m.Permissions)
+	})
+
+	t.Run("read-only dynamic call", func(t *testing.T) {
+		src := `package test
+			import "github.com/nspcc-dev/neo-go/pkg/interop"
+			import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			func Main(method string) {
+				contract.Call(interop.Hash160("aaaaaaaaaaaaaaaaaaaa"), method, contract.ReadOnly)
+			}`
+
+		_, di, diags, err := compiler.CompileWithDiagnostics("permissionTest.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, len(diags))
+		require.Equal(t, compiler.CodeDynamicCall, diags[0].Code)
+
+		m, err := compiler.CreateManifest(di, &compiler.Options{Name: "test"})
+		require.NoError(t, err)
+		require.Equal(t, []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}, m.Permissions)
+	})
+}
+
 func TestUnnamedParameterCheck(t *testing.T) {
 	t.Run("single argument", func(t *testing.T) {
 		src := `
@@ -15,6 +15,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -39,8 +40,17 @@ type DebugInfo struct {
 	EmittedEvents map[string][]EmittedEventInfo `json:"-"`
 	// InvokedContracts contains foreign contract invocations.
 	InvokedContracts map[util.Uint160][]string `json:"-"`
+	// CalledContracts contains all foreign contract calls including the
+	// ones with read-only call flags.
+	CalledContracts map[util.Uint160][]string `json:"-"`
+	// DynamicCalls is set if contract performs calls with hash or method
+	// that can't be determined at compile time.
+	DynamicCalls bool `json:"-"`
 	// StaticVariables contains a list of static variable names and types.
 	StaticVariables []string `json:"static-variables"`
+	// InitReport describes the code emitted into `_initialize` method, it's
+	// not a part of the debug info.
+	InitReport *InitReport `json:"-"`
 }
 
 // MethodDebugInfo represents smart-contract's method debug information.
@@ -67,7 +77,10 @@ type MethodDebugInfo struct {
 	ReturnTypeExtended *binding.ExtendedType `json:"-"`
 	// ReturnTypeSC is a return type to use in manifest.
 	ReturnTypeSC smartcontract.ParamType `json:"-"`
-	Variables    []string                `json:"variables"`
+	// CallFlags are the flags declared for the method with //neo:method
+	// directive, nil if there is no directive.
+	CallFlags *callflag.CallFlag `json:"-"`
+	Variables []string           `json:"variables"`
 	// SeqPoints is a map between source lines and byte-code instruction offsets.
 	SeqPoints []DebugSeqPoint `json:"sequence-points"`
 }
@@ -218,6 +231,11 @@ func (c *codegen) emitDebugInfo(contract []byte) *DebugInfo {
 	}
 	d.EmittedEvents = c.emittedEvents
 	d.InvokedContracts = c.invokedContracts
+	d.CalledContracts = c.calledContracts
+	d.DynamicCalls = c.dynamicCalls
+	if _, ok := c.calledContracts[util.Uint160{}]; ok {
+		d.DynamicCalls = true
+	}
 	return d
 }
 
@@ -266,6 +284,7 @@ func (c *codegen) methodInfoFromScope(name string, scope *funcScope, exts map[st
 		ReturnTypeSC:       st,
 		SeqPoints:          c.sequencePoints[name],
 		Variables:          scope.variables,
+		CallFlags:          scope.callFlags,
 	}
 }
 
@@ -531,6 +550,7 @@ func (m *MethodDebugInfo) ToManifestMethod() manifest.Method {
 	result.Offset = int(m.Range.Start)
 	result.Parameters = parameters
 	result.ReturnType = m.ReturnTypeSC
+	result.Safe = m.CallFlags != nil && isSafeFlags(*m.CallFlags)
 	return result
 }
 
@@ -587,6 +607,10 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 			mMethod := method.ToManifestMethod()
 			for i := range o.SafeMethods {
 				if mMethod.Name == o.SafeMethods[i] {
+					if method.CallFlags != nil && !mMethod.Safe {
+						return nil, fmt.Errorf("method %s is marked as safe but declared with %s flags",
+							mMethod.Name, *method.CallFlags)
+					}
 					mMethod.Safe = true
 					break
 				}
@@ -617,7 +641,11 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 	if result.ABI.Events == nil {
 		result.ABI.Events = make([]manifest.Event, 0)
 	}
-	result.Permissions = o.Permissions
+	if o.Permissions != nil {
+		result.Permissions = o.Permissions
+	} else {
+		result.Permissions = di.inferPermissions()
+	}
 	for name, emitName := range o.Overloads {
 		m := result.ABI.GetMethod(name, -1)
 		if m == nil {
@@ -643,3 +671,31 @@ func (di *DebugInfo) ConvertToManifest(o *Options) (*manifest.Manifest, error) {
 	}
 	return result, nil
 }
+
+// inferPermissions returns the minimal set of permissions required for
+// contract calls performed by the contract. Read-only calls are included
+// since the node checks permissions for any method that is not safe.
+// Wildcard permission is returned if there are calls that can't be resolved
+// at compile time.
+func (di *DebugInfo) inferPermissions() []manifest.Permission {
+	if di.DynamicCalls {
+		return []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}
+	}
+	hashes := make([]util.Uint160, 0, len(di.CalledContracts))
+	for h := range di.CalledContracts {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Less(hashes[j]) })
+	perms := make([]manifest.Permission, 0, len(hashes))
+	for _, h := range hashes {
+		p := manifest.NewPermission(manifest.PermissionHash, h)
+		methods := append([]string(nil), di.CalledContracts[h]...)
+		sort.Strings(methods)
+		p.Methods.Restrict()
+		for _, m := range methods {
+			p.Methods.Add(m)
+		}
+		perms = append(perms, *p)
+	}
+	return perms
+}
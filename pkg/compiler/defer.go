@@ -0,0 +1,423 @@
+package compiler
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"math"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"golang.org/x/tools/go/packages"
+)
+
+// Defer and recover usage errors.
+var (
+	// ErrUnsupportedDefer is returned for `defer` statements that can't be
+	// compiled preserving Go semantics.
+	ErrUnsupportedDefer = errors.New("unsupported defer statement")
+	// ErrInvalidRecover is returned for `recover` calls that are not made
+	// directly by deferred functions.
+	ErrInvalidRecover = errors.New("recover() can only be called directly by a deferred function")
+)
+
+// noLabel is used as a TRYL label to omit the corresponding block.
+const noLabel = math.MaxUint16
+
+// Auxiliary local variables of functions with `defer` statements.
+const (
+	panicVarName = "<panic>"
+	depthVarName = "<depth>"
+	outerVarName = "<outer>"
+	tryVarName   = "<try>"
+)
+
+// deferFrame contains the state of function with `defer` statements.
+//
+// Function body is wrapped into TRY block starting at the first executed
+// `defer` statement (exceptions thrown before it are not to be caught, since
+// catching them reverts the changes made by called contracts). Its CATCH block
+// stores the exception and both `return` statements and CATCH block jump to
+// the code executing deferred calls. Function results are stored in local variables,
+// so that deferred calls can change named results and the same code can
+// return them or rethrow the exception after all deferred calls are done.
+type deferFrame struct {
+	// catchLabel marks the CATCH block of the function body.
+	catchLabel uint16
+	// runLabel marks the code executing deferred calls.
+	runLabel uint16
+	// panicIndex is a local containing current panic value wrapped into
+	// an array or null if the function is not panicking.
+	panicIndex int
+	// depthIndex is a local containing the stack depth at the function
+	// start, it's used to clean up the stack after exception.
+	depthIndex int
+	// outerIndex is a local containing exception slot value to be restored
+	// after deferred calls.
+	outerIndex int
+	// tryIndex is a local that is set when TRY block is entered.
+	tryIndex int
+	// results are locals containing function results.
+	results []int
+	// stack contains encountered `defer` statements.
+	stack []deferInfo
+}
+
+type deferInfo struct {
+	expr       *ast.CallExpr
+	localIndex int
+}
+
+// hasDefer checks whether function body contains `defer` statements, nested
+// function literals are not taken into account.
+func hasDefer(body *ast.BlockStmt) bool {
+	var found bool
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch n.(type) {
+		case *ast.DeferStmt:
+			found = true
+		case *ast.FuncLit:
+			return false
+		}
+		return !found
+	})
+	return found
+}
+
+func isRecoverCall(n ast.Node) bool {
+	call, ok := n.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	id, ok := call.Fun.(*ast.Ident)
+	return ok && id.Name == "recover"
+}
+
+// checkDefers reports `defer` statements and `recover` calls that are not
+// supported. `recover` is only supported in function literals used in
+// `defer` statements and in functions that are only called via `defer`,
+// otherwise it could stop a panic in cases where Go returns nil from it.
+func (c *codegen) checkDefers() {
+	type deferErr struct {
+		pos token.Pos
+		err error
+	}
+	var (
+		errs         []deferErr
+		recoverFuncs = make(map[types.Object]bool)
+		deferred     = make(map[*ast.Ident]bool)
+		uses         = make(map[*ast.Ident]types.Object)
+	)
+	c.ForEachPackage(func(pkg *packages.Package) {
+		for id, obj := range pkg.TypesInfo.Uses {
+			if _, ok := obj.(*types.Func); ok {
+				uses[id] = obj
+			}
+		}
+		for _, f := range pkg.Syntax {
+			var stack []ast.Node
+			ast.Inspect(f, func(node ast.Node) bool {
+				if node == nil {
+					stack = stack[:len(stack)-1]
+					return false
+				}
+				fi := len(stack) - 1
+				for ; fi >= 0; fi-- {
+					switch stack[fi].(type) {
+					case *ast.FuncDecl, *ast.FuncLit:
+					default:
+						continue
+					}
+					break
+				}
+				switch n := node.(type) {
+				case *ast.DeferStmt:
+					switch fun := n.Call.Fun.(type) {
+					case *ast.Ident:
+						deferred[fun] = true
+					case *ast.SelectorExpr:
+						deferred[fun.Sel] = true
+					}
+					if isRecoverCall(n.Call) {
+						errs = append(errs, deferErr{n.Pos(), fmt.Errorf("%w: it can't be deferred itself", ErrInvalidRecover)})
+					}
+					for _, p := range stack[fi+1:] {
+						switch p.(type) {
+						case *ast.ForStmt, *ast.RangeStmt:
+							errs = append(errs, deferErr{n.Pos(), fmt.Errorf("%w: defer in loop", ErrUnsupportedDefer)})
+						}
+					}
+					if fi < 0 {
+						break
+					}
+					if decl, ok := stack[fi].(*ast.FuncDecl); ok {
+						switch {
+						case isInitFunc(decl):
+							errs = append(errs, deferErr{n.Pos(), fmt.Errorf("%w: defer in init function", ErrUnsupportedDefer)})
+						case isDeployFunc(decl):
+							errs = append(errs, deferErr{n.Pos(), fmt.Errorf("%w: defer in _deploy function", ErrUnsupportedDefer)})
+						case canInline(pkg.PkgPath, decl.Name.Name, false):
+							errs = append(errs, deferErr{n.Pos(), fmt.Errorf("%w: defer in inlined function", ErrUnsupportedDefer)})
+						}
+					}
+				case *ast.CallExpr:
+					if !isRecoverCall(n) {
+						break
+					}
+					if fi < 0 {
+						errs = append(errs, deferErr{n.Pos(), ErrInvalidRecover})
+						break
+					}
+					switch fn := stack[fi].(type) {
+					case *ast.FuncDecl:
+						recoverFuncs[pkg.TypesInfo.Defs[fn.Name]] = true
+					case *ast.FuncLit:
+						var ok bool
+						if fi >= 2 {
+							call, isCall := stack[fi-1].(*ast.CallExpr)
+							stmt, isDefer := stack[fi-2].(*ast.DeferStmt)
+							ok = isCall && isDefer && call.Fun == fn && stmt.Call == call
+						}
+						if !ok {
+							errs = append(errs, deferErr{n.Pos(), ErrInvalidRecover})
+						}
+					}
+				}
+				stack = append(stack, node)
+				return true
+			})
+		}
+	})
+	for id, obj := range uses {
+		if recoverFuncs[obj] && !deferred[id] {
+			errs = append(errs, deferErr{id.Pos(), fmt.Errorf("%w: %s calls recover() and can only be used in defer statements", ErrInvalidRecover, obj.Name())})
+		}
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].pos < errs[j].pos })
+	for _, e := range errs {
+		c.errs = append(c.errs, newError(c.position(e.pos), CodeCodegen, e.err))
+	}
+}
+
+// startDefers allocates auxiliary variables for deferred calls execution. It must be called after
+// named results are initialized.
+func (c *codegen) startDefers(decl *ast.FuncDecl) {
+	fr := &deferFrame{
+		catchLabel: c.newLabel(),
+		runLabel:   c.newLabel(),
+		panicIndex: c.scope.newLocal(panicVarName),
+		depthIndex: c.scope.newLocal(depthVarName),
+		outerIndex: c.scope.newLocal(outerVarName),
+		tryIndex:   c.scope.newLocal(tryVarName),
+	}
+	if decl.Type.Results != nil {
+		for _, arg := range decl.Type.Results.List {
+			names := arg.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{nil}
+			}
+			for _, id := range names {
+				if id != nil && id.Name != "_" {
+					fr.results = append(fr.results, c.scope.vars.getVarInfo(id.Name).index)
+					continue
+				}
+				i := c.scope.newLocal(fmt.Sprintf("<result%d>", len(fr.results)))
+				c.emitDefault(c.typeOf(arg.Type))
+				c.emitStoreByIndex(varLocal, i)
+				fr.results = append(fr.results, i)
+			}
+		}
+	}
+	c.scope.defers = fr
+
+	emit.Opcodes(c.prog.BinWriter, opcode.DEPTH)
+	c.emitStoreByIndex(varLocal, fr.depthIndex)
+}
+
+// emitEndBody leaves the function body and jumps to the deferred calls.
+func (c *codegen) emitEndBody() {
+	fr := c.scope.defers
+	c.emitLoadByIndex(varLocal, fr.tryIndex)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, fr.runLabel)
+	emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, fr.runLabel)
+}
+
+// emitTry emits TRYL instruction without FINALLY block.
+func (c *codegen) emitTry(catch uint16) {
+	param := make([]byte, 8)
+	binary.LittleEndian.PutUint16(param[0:], catch)
+	binary.LittleEndian.PutUint16(param[4:], noLabel)
+	emit.Instruction(c.prog.BinWriter, opcode.TRYL, param)
+}
+
+// emitCatchPanic stores the caught exception wrapped into an array (so that
+// panic(nil) can be distinguished from no panic) and drops everything left on
+// the stack by the failed code.
+func (c *codegen) emitCatchPanic() {
+	fr := c.scope.defers
+	emit.Opcodes(c.prog.BinWriter, opcode.PUSH1, opcode.PACK)
+	c.emitStoreByIndex(varLocal, fr.panicIndex)
+	emit.Opcodes(c.prog.BinWriter, opcode.DEPTH)
+	c.emitLoadByIndex(varLocal, fr.depthIndex)
+	emit.Opcodes(c.prog.BinWriter, opcode.SUB, opcode.PACK, opcode.DROP)
+}
+
+// emitDefer remembers the deferred call and marks it as reached, function
+// body TRY block is entered if it's not yet.
+func (c *codegen) emitDefer(n *ast.DeferStmt) {
+	var (
+		fr    = c.scope.defers
+		call  = c.deferredCall(n.Call)
+		index = c.scope.newLocal(fmt.Sprintf("defer@%d", n.Call.Pos()))
+		skip  = c.newLabel()
+	)
+	c.emitLoadByIndex(varLocal, fr.tryIndex)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, skip)
+	emit.Opcodes(c.prog.BinWriter, opcode.PUSHT)
+	c.emitStoreByIndex(varLocal, fr.tryIndex)
+	c.emitTry(fr.catchLabel)
+	c.setLabel(skip)
+	emit.Opcodes(c.prog.BinWriter, opcode.PUSH1)
+	c.emitStoreByIndex(varLocal, index)
+	c.scope.defers.stack = append(c.scope.defers.stack, deferInfo{
+		expr:       call,
+		localIndex: index,
+	})
+}
+
+// deferredCall evaluates function value, method receiver and non-constant
+// arguments of the deferred call and stores them in local variables like Go
+// does at the moment `defer` statement is executed. It returns a copy of the
+// call using these variables.
+func (c *codegen) deferredCall(call *ast.CallExpr) *ast.CallExpr {
+	if id, ok := call.Fun.(*ast.Ident); ok && isGoBuiltin(id.Name) {
+		c.scope.voidCalls[call] = true
+		return call
+	}
+	res := *call
+	switch fun := call.Fun.(type) {
+	case *ast.Ident:
+		if _, ok := c.typeInfo.Uses[fun].(*types.Var); ok {
+			id := c.storeDeferredValue(fun, false)
+			id.Obj = fun.Obj
+			res.Fun = id
+		}
+	case *ast.SelectorExpr:
+		if sel := c.typeInfo.Selections[fun]; sel != nil && sel.Kind() == types.MethodVal {
+			f := *fun
+			f.X = c.storeDeferredValue(fun.X, false)
+			c.typeInfo.Types[&f] = c.typeAndValueOf(fun)
+			c.typeInfo.Selections[&f] = sel
+			res.Fun = &f
+		}
+	}
+	res.Args = make([]ast.Expr, len(call.Args))
+	for i, arg := range call.Args {
+		if c.typeAndValueOf(arg).Value != nil || isExprNil(arg) {
+			res.Args[i] = arg
+			continue
+		}
+		res.Args[i] = c.storeDeferredValue(arg, true)
+	}
+	c.typeInfo.Types[&res] = c.typeAndValueOf(call)
+	c.scope.voidCalls[&res] = true
+	return &res
+}
+
+// storeDeferredValue evaluates an expression and stores it in a new local
+// variable available in the function scope, identifier referring to this
+// variable is returned.
+func (c *codegen) storeDeferredValue(e ast.Expr, clone bool) *ast.Ident {
+	ast.Walk(c, e)
+	typ := c.typeOf(e)
+	if _, ok := typ.Underlying().(*types.Struct); ok && clone && !isInteropPath(typ.String()) {
+		emit.Opcodes(c.prog.BinWriter, opcode.NEWARRAY0,
+			opcode.DUP, opcode.ROT, opcode.APPEND,
+			opcode.POPITEM)
+	}
+	name := fmt.Sprintf("<deferred%d>", c.scope.vars.localsCnt)
+	index := c.scope.newLocal(name)
+	c.scope.vars.locals[0][name] = varInfo{refType: varLocal, index: index}
+	c.emitStoreByIndex(varLocal, index)
+
+	id := ast.NewIdent(name)
+	id.NamePos = e.Pos()
+	c.typeInfo.Types[id] = types.TypeAndValue{Type: typ}
+	return id
+}
+
+// emitDeferredReturn stores `return` statement results and jumps to the
+// deferred calls.
+func (c *codegen) emitDeferredReturn(n *ast.ReturnStmt) {
+	fr := c.scope.defers
+	if len(n.Results) != 0 {
+		// first result should be on top of the stack
+		for i := len(n.Results) - 1; i >= 0; i-- {
+			ast.Walk(c, n.Results[i])
+		}
+		for _, index := range fr.results {
+			c.emitStoreByIndex(varLocal, index)
+		}
+	}
+	c.saveSequencePoint(n)
+	c.emitEndBody()
+}
+
+// emitDeferredCalls finishes the function body and emits its CATCH block
+// and deferred calls. Each deferred call is executed in its own TRY block
+// with the current panic value stored in the exception slot, so that
+// `recover` can return and clear it. A new panic replaces the current one.
+// After all calls, the panic (if any) is rethrown, otherwise the results
+// are returned.
+func (c *codegen) emitDeferredCalls(body *ast.BlockStmt) {
+	fr := c.scope.defers
+	if !lastStmtIsReturn(body) {
+		c.saveSequencePoint(body)
+		c.emitEndBody()
+	}
+	c.setLabel(fr.catchLabel)
+	c.emitCatchPanic()
+	emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, fr.runLabel)
+
+	c.setLabel(fr.runLabel)
+	c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+	c.emitStoreByIndex(varLocal, fr.outerIndex)
+	for i := len(fr.stack) - 1; i >= 0; i-- {
+		var (
+			d     = fr.stack[i]
+			next  = c.newLabel()
+			catch = c.newLabel()
+		)
+		c.emitLoadByIndex(varLocal, d.localIndex)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPIFNOTL, next)
+		c.emitLoadByIndex(varLocal, fr.panicIndex)
+		c.emitStoreByIndex(varGlobal, c.exceptionIndex)
+		c.emitTry(catch)
+		ast.Walk(c, d.expr)
+		c.emitLoadByIndex(varGlobal, c.exceptionIndex)
+		c.emitStoreByIndex(varLocal, fr.panicIndex)
+		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, next)
+		c.setLabel(catch)
+		c.emitCatchPanic()
+		emit.Jmp(c.prog.BinWriter, opcode.ENDTRYL, next)
+		c.setLabel(next)
+	}
+	c.emitLoadByIndex(varLocal, fr.outerIndex)
+	c.emitStoreByIndex(varGlobal, c.exceptionIndex)
+
+	ret := c.newLabel()
+	c.emitLoadByIndex(varLocal, fr.panicIndex)
+	emit.Opcodes(c.prog.BinWriter, opcode.DUP, opcode.ISNULL)
+	emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, ret)
+	emit.Opcodes(c.prog.BinWriter, opcode.PUSH0, opcode.PICKITEM, opcode.THROW)
+	c.setLabel(ret)
+	emit.Opcodes(c.prog.BinWriter, opcode.DROP)
+	for i := len(fr.results) - 1; i >= 0; i-- {
+		c.emitLoadByIndex(varLocal, fr.results[i])
+	}
+	emit.Opcodes(c.prog.BinWriter, opcode.RET)
+}
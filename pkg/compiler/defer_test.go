@@ -5,6 +5,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
@@ -287,6 +288,313 @@ func TestRecover(t *testing.T) {
 		}`
 		eval(t, src, big.NewInt(5))
 	})
+	t.Run("NoRecover", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			defer func() { a = 1 }()
+			defer func() { a = 2 }()
+			panic("not recovered")
+		}`
+		v := vmAndCompile(t, src)
+		err := v.Run()
+		require.Error(t, err)
+		require.ErrorContains(t, err, "not recovered")
+	})
+	t.Run("Value", func(t *testing.T) {
+		src := `package foo
+		var s string
+		func Main() string {
+			h()
+			return s
+		}
+		func h() {
+			defer func() { s = recover().(string) }()
+			panic("msg")
+		}`
+		eval(t, src, []byte("msg"))
+	})
+	t.Run("NilPanic", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			defer func() {
+				if recover() == nil {
+					a = 1
+				}
+			}()
+			panic(nil)
+		}`
+		eval(t, src, big.NewInt(1))
+	})
+	t.Run("NamedResults", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			a, b := h()
+			return a*10 + b
+		}
+		func h() (a, b int) {
+			defer func() { recover() }()
+			a = 3
+			panic("msg")
+		}`
+		eval(t, src, big.NewInt(30))
+	})
+	t.Run("UnnamedResultsAfterRecover", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			a, s := h()
+			if s != "" {
+				return -1
+			}
+			return a
+		}
+		func h() (int, string) {
+			defer func() { recover() }()
+			panic("msg")
+		}`
+		eval(t, src, big.NewInt(0))
+	})
+	t.Run("RecoverInDeferredFunction", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			defer handle()
+			panic("msg")
+		}
+		func handle() {
+			if r := recover(); r != nil {
+				a = 7
+			}
+		}`
+		eval(t, src, big.NewInt(7))
+	})
+	t.Run("RecoverOnlyOnce", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			defer func() {
+				if recover() == nil {
+					a = 1
+				}
+			}()
+			defer func() { recover() }()
+			panic("msg")
+		}`
+		eval(t, src, big.NewInt(1))
+	})
+	t.Run("StackCleanup", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			return 1 + h([]int{1, 2, 3})
+		}
+		func h(arr []int) (res int) {
+			defer func() { recover() }()
+			for _, x := range arr {
+				res += x
+				if x == 2 {
+					panic("in loop")
+				}
+			}
+			return -1
+		}`
+		eval(t, src, big.NewInt(4))
+	})
+}
+
+func TestDeferArguments(t *testing.T) {
+	t.Run("Evaluation", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			x := 1
+			defer add(x)
+			x = 10
+		}
+		func add(x int) { a += x }`
+		eval(t, src, big.NewInt(1))
+	})
+	t.Run("NestedScope", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			h(true)
+			return a
+		}
+		func h(b bool) {
+			if b {
+				x := 2
+				defer add(x)
+			}
+			x := 5
+			_ = x
+		}
+		func add(x int) { a += x }`
+		eval(t, src, big.NewInt(2))
+	})
+	t.Run("Struct", func(t *testing.T) {
+		src := `package foo
+		type S struct{ x int }
+		var a int
+		func Main() int {
+			h()
+			return a
+		}
+		func h() {
+			s := S{x: 1}
+			defer s.add()
+			defer addValue(s)
+			s.x = 10
+		}
+		func (s *S) add() { a += s.x }
+		func addValue(s S) { a += s.x * 100 }`
+		eval(t, src, big.NewInt(110))
+	})
+}
+
+func TestNestedPanicRecover(t *testing.T) {
+	src := `package foo
+	import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	func Main() int {
+		defer runtime.Notify("main")
+		return outer() * 10
+	}
+	func outer() (res int) {
+		res = 5
+		defer func() {
+			r := recover()
+			runtime.Notify("outer", r)
+		}()
+		inner()
+		return 1
+	}
+	func inner() {
+		defer runtime.Notify("inner 1")
+		defer func() {
+			runtime.Notify("inner 2", recover())
+			panic("from defer")
+		}()
+		middle()
+		runtime.Notify("unreachable")
+	}
+	func middle() {
+		defer runtime.Notify("middle")
+		panic("first")
+	}`
+	v, s, _ := vmAndCompileInterop(t, src)
+	require.NoError(t, v.Run())
+	assertResult(t, v, big.NewInt(50))
+	require.Equal(t, 0, v.Estack().Len())
+
+	expected := []struct {
+		name string
+		args []stackitem.Item
+	}{
+		{"middle", []stackitem.Item{}},
+		{"inner 2", []stackitem.Item{stackitem.Make("first")}},
+		{"inner 1", []stackitem.Item{}},
+		{"outer", []stackitem.Item{stackitem.Make("from defer")}},
+		{"main", []stackitem.Item{}},
+	}
+	require.Equal(t, len(expected), len(s.events))
+	for i, e := range expected {
+		require.Equal(t, e.name, s.events[i].Name)
+		require.Equal(t, stackitem.NewArray(e.args), s.events[i].Item)
+	}
+}
+
+func TestDeferRecoverErrors(t *testing.T) {
+	testCases := map[string]struct {
+		src string
+		err error
+	}{
+		"recover in regular function": {`package foo
+		func Main() int {
+			f()
+			return 1
+		}
+		func f() { recover() }`, compiler.ErrInvalidRecover},
+		"recover in nested function literal": {`package foo
+		func Main() int {
+			defer func() {
+				func() { recover() }()
+			}()
+			return 1
+		}`, compiler.ErrInvalidRecover},
+		"recover in non-deferred function literal": {`package foo
+		func Main() int {
+			f := func() { recover() }
+			defer f()
+			return 1
+		}`, compiler.ErrInvalidRecover},
+		"deferred recover": {`package foo
+		func Main() int {
+			defer recover()
+			return 1
+		}`, compiler.ErrInvalidRecover},
+		"defer in loop": {`package foo
+		func Main() int {
+			for i := 0; i < 2; i++ {
+				defer func() {}()
+			}
+			return 1
+		}`, compiler.ErrUnsupportedDefer},
+		"defer in range": {`package foo
+		func Main() int {
+			for range []int{1, 2} {
+				defer func() {}()
+			}
+			return 1
+		}`, compiler.ErrUnsupportedDefer},
+		"defer in init": {`package foo
+		var a int
+		func init() {
+			defer func() { a = 1 }()
+		}
+		func Main() int { return a }`, compiler.ErrUnsupportedDefer},
+		"defer in _deploy": {`package foo
+		var a int
+		func _deploy(_ any, isUpdate bool) {
+			defer func() { a = 1 }()
+		}
+		func Main() int { return a }`, compiler.ErrUnsupportedDefer},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := compiler.CompileWithOptions("foo.go", strings.NewReader(tc.src), nil)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+
+	t.Run("defer in loop literal", func(t *testing.T) {
+		src := `package foo
+		var a int
+		func Main() int {
+			for i := 0; i < 2; i++ {
+				func() {
+					defer func() { a++ }()
+				}()
+			}
+			return a
+		}`
+		eval(t, src, big.NewInt(2))
+	})
 }
 
 func TestDeferNoGlobals(t *testing.T) {
@@ -0,0 +1,260 @@
+package compiler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Diagnostic codes.
+const (
+	// CodeLoad is used for package loading errors.
+	CodeLoad = "load"
+	// CodeParse is used for Go source parsing errors.
+	CodeParse = "parse"
+	// CodeType is used for Go type checking errors.
+	CodeType = "type"
+	// CodeCodegen is used for errors that occur during bytecode generation
+	// (like unsupported language constructions).
+	CodeCodegen = "codegen"
+	// CodeOutput is used for errors related to output files generation (like
+	// manifest, debug info or bindings configuration).
+	CodeOutput = "output"
+	// CodeUnusedEvent is used for warnings about events declared in the
+	// contract configuration, but never emitted by the contract.
+	CodeUnusedEvent = "unused-event"
+	// CodeUnreachableMethod is used for warnings about unexported functions
+	// and methods of the main package that are never called.
+	CodeUnreachableMethod = "unreachable-method"
+	// CodeStorageKeyCollision is used for warnings about constant storage
+	// keys (or key prefixes) used by different functions for values of
+	// different types.
+	CodeStorageKeyCollision = "storage-key-collision"
+	// CodeDynamicCall is used for warnings about contract calls with hash
+	// or method that can't be determined at compile time, they make
+	// compiler use wildcard manifest permission.
+	CodeDynamicCall = "dynamic-call"
+)
+
+// Error is a single compiler diagnostic message with the position in the
+// source code it relates to. Pos can be invalid (zero) if there is no
+// specific position.
+type Error struct {
+	Pos  token.Position
+	Code string
+	Msg  string
+
+	// err is the original error if any.
+	err error
+}
+
+// Errors is a list of compiler errors. It's returned from compilation
+// functions if there is more than one error, use errors.As to get a specific
+// Error from it.
+type Errors []Error
+
+// Severity is a severity level of a Diagnostic.
+type Severity byte
+
+// Severity levels.
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+)
+
+// Diagnostic is an Error or warning produced by CompileWithDiagnostics.
+type Diagnostic struct {
+	Error
+	Severity Severity
+}
+
+// diagnosticAux is an auxiliary struct for Diagnostic JSON marshalling.
+type diagnosticAux struct {
+	Severity string `json:"severity"`
+	File     string `json:"file,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Code     string `json:"code"`
+	Msg      string `json:"message"`
+}
+
+// newError creates an Error from the given error with the given code and
+// position.
+func newError(pos token.Position, code string, err error) Error {
+	return Error{
+		Pos:  pos,
+		Code: code,
+		Msg:  err.Error(),
+		err:  err,
+	}
+}
+
+// Error implements the error interface, it returns the message prefixed with
+// the position (if any) in the usual "file:line:column: message" format.
+func (e Error) Error() string {
+	if !e.Pos.IsValid() && e.Pos.Filename == "" {
+		return e.Msg
+	}
+	return e.Pos.String() + ": " + e.Msg
+}
+
+// Unwrap returns the original error (if any).
+func (e Error) Unwrap() error {
+	return e.err
+}
+
+// Error implements the error interface, it returns all errors one per line.
+func (e Errors) Error() string {
+	var b strings.Builder
+	for i := range e {
+		if i != 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(e[i].Error())
+	}
+	return b.String()
+}
+
+// Unwrap returns the list of errors.
+func (e Errors) Unwrap() []error {
+	var res = make([]error, len(e))
+	for i := range e {
+		res[i] = e[i]
+	}
+	return res
+}
+
+// String implements the fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d Diagnostic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(diagnosticAux{
+		Severity: d.Severity.String(),
+		File:     d.Pos.Filename,
+		Line:     d.Pos.Line,
+		Column:   d.Pos.Column,
+		Code:     d.Code,
+		Msg:      d.Msg,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (d *Diagnostic) UnmarshalJSON(data []byte) error {
+	var aux diagnosticAux
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	switch aux.Severity {
+	case SeverityError.String():
+		d.Severity = SeverityError
+	case SeverityWarning.String():
+		d.Severity = SeverityWarning
+	default:
+		return fmt.Errorf("unknown severity: %q", aux.Severity)
+	}
+	d.Error = Error{
+		Pos: token.Position{
+			Filename: aux.File,
+			Line:     aux.Line,
+			Column:   aux.Column,
+		},
+		Code: aux.Code,
+		Msg:  aux.Msg,
+	}
+	return nil
+}
+
+// toErrors converts the given error into the list of errors, the given code
+// is used for errors without one.
+func toErrors(err error, code string) Errors {
+	var list Errors
+	if errors.As(err, &list) {
+		return list
+	}
+	var single Error
+	if errors.As(err, &single) {
+		return Errors{single}
+	}
+	return Errors{newError(token.Position{}, code, err)}
+}
+
+// hasErrors returns true if there are error-level diagnostics in the list.
+func hasErrors(diags []Diagnostic) bool {
+	for i := range diags {
+		if diags[i].Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// joinErrors returns nil for an empty list, a single Error if there is only
+// one and Errors otherwise.
+func joinErrors(list Errors) error {
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	default:
+		return list
+	}
+}
+
+// packageError converts packages.Error into Error.
+func packageError(e packages.Error) Error {
+	var code string
+	switch e.Kind {
+	case packages.ParseError:
+		code = CodeParse
+	case packages.TypeError:
+		code = CodeType
+	default:
+		code = CodeLoad
+	}
+	return newError(parsePosition(e.Pos), code, errors.New(e.Msg))
+}
+
+// parsePosition parses "file:line:column" or "file:line" position string
+// used by packages.Error.
+func parsePosition(s string) token.Position {
+	var pos token.Position
+	if s == "" || s == "-" {
+		return pos
+	}
+	parts := strings.Split(s, ":")
+	// File name can contain colons (like Windows drive letter), so numbers
+	// are parsed from the end.
+	nums := make([]int, 0, 2)
+	for len(parts) > 1 && len(nums) < 2 {
+		n, err := strconv.Atoi(parts[len(parts)-1])
+		if err != nil {
+			break
+		}
+		nums = append(nums, n)
+		parts = parts[:len(parts)-1]
+	}
+	pos.Filename = strings.Join(parts, ":")
+	switch len(nums) {
+	case 1:
+		pos.Line = nums[0]
+	case 2:
+		pos.Line, pos.Column = nums[1], nums[0]
+	}
+	return pos
+}
@@ -0,0 +1,123 @@
+package compiler_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileWithDiagnostics(t *testing.T) {
+	t.Run("multiple codegen errors", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			a := make([]int, 1, 2)
+			return len(a)
+		}
+		func Other() int {
+			b := make([]int, 2, 3)
+			return len(b)
+		}`
+		_, _, diags, err := compiler.CompileWithDiagnostics("foo.go", strings.NewReader(src), nil)
+		require.Error(t, err)
+		require.Equal(t, 2, len(diags))
+		for i, line := range []int{3, 7} {
+			require.Equal(t, compiler.SeverityError, diags[i].Severity)
+			require.Equal(t, compiler.CodeCodegen, diags[i].Code)
+			require.Equal(t, line, diags[i].Pos.Line)
+			require.Equal(t, 9, diags[i].Pos.Column)
+			require.True(t, strings.HasSuffix(diags[i].Pos.Filename, "foo.go"))
+			require.Contains(t, diags[i].Msg, "capacity argument is not supported")
+		}
+
+		var list compiler.Errors
+		require.ErrorAs(t, err, &list)
+		require.Equal(t, 2, len(list))
+		require.Equal(t, 2, len(strings.Split(err.Error(), "\n")))
+		require.Contains(t, err.Error(), "foo.go:7:9: `make()`")
+
+		var single compiler.Error
+		require.ErrorAs(t, err, &single)
+		require.Equal(t, 3, single.Pos.Line)
+	})
+	t.Run("type errors", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			var a int = "str"
+			_ = a
+			return b
+		}`
+		_, _, diags, err := compiler.CompileWithDiagnostics("foo.go", strings.NewReader(src), nil)
+		require.Error(t, err)
+		require.Equal(t, 2, len(diags))
+		require.Equal(t, compiler.CodeType, diags[0].Code)
+		require.Equal(t, 3, diags[0].Pos.Line)
+		require.Equal(t, compiler.CodeType, diags[1].Code)
+		require.Equal(t, 5, diags[1].Pos.Line)
+	})
+	t.Run("warnings", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		func Main() int {
+			runtime.Notify("Used")
+			return 1
+		}
+		func unused() int {
+			return 2
+		}`
+		f, _, diags, err := compiler.CompileWithDiagnostics("foo.go", strings.NewReader(src), &compiler.Options{
+			Name: "foo",
+			ContractEvents: []compiler.HybridEvent{
+				{Name: "Used"},
+				{Name: "Unused"},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, f)
+		require.Equal(t, 2, len(diags))
+
+		require.Equal(t, compiler.SeverityWarning, diags[0].Severity)
+		require.Equal(t, compiler.CodeUnreachableMethod, diags[0].Code)
+		require.Equal(t, 7, diags[0].Pos.Line)
+		require.Contains(t, diags[0].Msg, "unused")
+
+		require.Equal(t, compiler.SeverityWarning, diags[1].Severity)
+		require.Equal(t, compiler.CodeUnusedEvent, diags[1].Code)
+		require.Contains(t, diags[1].Msg, `"Unused"`)
+	})
+}
+
+func TestDiagnosticJSON(t *testing.T) {
+	src := `package foo
+		func Main() int {
+			a := make([]int, 1, 2)
+			return len(a)
+		}`
+	_, _, diags, err := compiler.CompileWithDiagnostics("foo.go", strings.NewReader(src), nil)
+	require.Error(t, err)
+	require.Equal(t, 1, len(diags))
+
+	data, err := json.Marshal(diags)
+	require.NoError(t, err)
+
+	var raw []map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	require.Equal(t, 1, len(raw))
+	require.Equal(t, "error", raw[0]["severity"])
+	require.Equal(t, "codegen", raw[0]["code"])
+	require.Equal(t, float64(3), raw[0]["line"])
+	require.Equal(t, float64(9), raw[0]["column"])
+
+	var actual []compiler.Diagnostic
+	require.NoError(t, json.Unmarshal(data, &actual))
+	require.Equal(t, diags[0].Pos.Filename, actual[0].Pos.Filename)
+	require.Equal(t, diags[0].Pos.Line, actual[0].Pos.Line)
+	require.Equal(t, diags[0].Pos.Column, actual[0].Pos.Column)
+	require.Equal(t, diags[0].Code, actual[0].Code)
+	require.Equal(t, diags[0].Msg, actual[0].Msg)
+	require.Equal(t, diags[0].Severity, actual[0].Severity)
+
+	require.Error(t, json.Unmarshal([]byte(`[{"severity":"fatal","code":"x","message":"y"}]`), &actual))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"go/ast"
 	"go/types"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 )
 
 // A funcScope represents the scope within the function context.
@@ -32,8 +34,9 @@ type funcScope struct {
 	// Variables together with it's type in neo-vm.
 	variables []string
 
-	// deferStack is a stack containing encountered `defer` statements.
-	deferStack []deferInfo
+	// defers is the state of the function with `defer` statements, it's nil
+	// for functions without them.
+	defers *deferFrame
 
 	// Local variables
 	vars varScope
@@ -48,19 +51,18 @@ type funcScope struct {
 
 	// Local variable counter.
 	i int
-}
 
-type deferInfo struct {
-	catchLabel   uint16
-	finallyLabel uint16
-	expr         *ast.CallExpr
-	localIndex   int
+	// callFlags are the flags declared for the function with //neo:method
+	// directive, nil if there is no directive.
+	callFlags *callflag.CallFlag
+	// flagUses are the calls requiring some call flags made by the function
+	// itself and calls are the functions it calls, both are used to check
+	// callFlags.
+	flagUses []flagUse
+	calls    []funcCall
 }
 
-const (
-	finallyVarName   = "<finally>"
-	exceptionVarName = "<exception>"
-)
+const exceptionVarName = "<exception>"
 
 func (c *codegen) newFuncScope(decl *ast.FuncDecl, label uint16) *funcScope {
 	var name string
@@ -0,0 +1,258 @@
+package compiler
+
+import (
+	"errors"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"golang.org/x/tools/go/packages"
+)
+
+// lazyDirective marks global variables that are to be initialized on first
+// use instead of `_initialize` method.
+const lazyDirective = "//neo:lazy"
+
+// lazyFlagSuffix is appended to the lazy variable name to get the name of the
+// static slot holding its initialization flag.
+const lazyFlagSuffix = "$init"
+
+// Kinds of InitEntry.
+const (
+	// InitEntryVar is a global variable declaration.
+	InitEntryVar = "var"
+	// InitEntryFunc is an `init()` function.
+	InitEntryFunc = "init"
+	// InitEntryLazy is an accessor of the global variable marked with
+	// //neo:lazy directive, it's not a part of `_initialize` method.
+	InitEntryLazy = "lazy"
+)
+
+// Various //neo:lazy directive usage errors.
+var (
+	// ErrLazyLocalVar is returned when //neo:lazy directive is used for a local variable.
+	ErrLazyLocalVar = errors.New("//neo:lazy directive can only be used for global variables")
+	// ErrInvalidLazyVar is returned when //neo:lazy directive is used for a declaration
+	// having multiple variables or no initializer.
+	ErrInvalidLazyVar = errors.New("//neo:lazy directive requires a single variable with an initializer")
+)
+
+// InitReport describes the code the compiler has emitted into `_initialize`
+// method, it allows to find out what makes contract initialization costly.
+type InitReport struct {
+	// Size is the total size of `_initialize` method in bytes.
+	Size int `json:"size"`
+	// Entries are global variable declarations and `init()` functions in
+	// the order they're executed in. Declarations not producing any code
+	// are omitted.
+	Entries []InitEntry `json:"entries"`
+	// Lazy contains accessors generated for //neo:lazy variables, they're
+	// called on the first variable use.
+	Lazy []InitEntry `json:"lazy"`
+}
+
+// InitEntry is a single piece of code emitted for contract initialization.
+type InitEntry struct {
+	// Kind is one of InitEntryVar, InitEntryFunc and InitEntryLazy.
+	Kind string `json:"kind"`
+	// Package is the path of the package the entry belongs to.
+	Package string `json:"package"`
+	// Names contains variable names for InitEntryVar and InitEntryLazy
+	// entries.
+	Names []string `json:"names,omitempty"`
+	// Position is the source code position of the declaration.
+	Position string `json:"position"`
+	// Size is the size of the emitted code in bytes.
+	Size int `json:"size"`
+}
+
+// initEntry is an InitEntry with the range of emitted opcodes.
+type initEntry struct {
+	kind  string
+	pkg   string
+	names []string
+	pos   token.Pos
+	rng   DebugRange
+}
+
+// lazyGlobal is a global variable initialized by the generated accessor.
+type lazyGlobal struct {
+	name      string
+	pos       token.Pos
+	index     int
+	flag      int
+	label     uint16
+	expr      ast.Expr
+	pkg       *packages.Package
+	importMap map[string]string
+	rng       DebugRange
+}
+
+// isLazyVar checks whether variable specification is marked with //neo:lazy
+// directive. The directive can be placed either before the specification
+// itself or before the whole declaration if it's not parenthesized.
+func isLazyVar(decl *ast.GenDecl, spec *ast.ValueSpec) bool {
+	if hasDirective(spec.Doc, lazyDirective) {
+		return true
+	}
+	return !decl.Lparen.IsValid() && hasDirective(decl.Doc, lazyDirective)
+}
+
+func hasDirective(doc *ast.CommentGroup, directive string) bool {
+	if doc == nil {
+		return false
+	}
+	for _, c := range doc.List {
+		if strings.TrimRight(c.Text, " \t") == directive {
+			return true
+		}
+	}
+	return false
+}
+
+// newLazyGlobal allocates static slots for the variable and its
+// initialization flag. No code is emitted at this point, the accessor is
+// emitted after all functions by convertLazyGlobals.
+func (c *codegen) newLazyGlobal(spec *ast.ValueSpec) {
+	if len(spec.Names) != 1 || len(spec.Values) != 1 {
+		c.prog.Err = ErrInvalidLazyVar
+		return
+	}
+	name := spec.Names[0].Name
+	if name == "_" { // Unused variable is never evaluated.
+		return
+	}
+	c.newGlobal("", name)
+	c.registerDebugVariable(name, spec.Type)
+	c.newGlobal("", name+lazyFlagSuffix)
+	c.staticVariables = append(c.staticVariables, name+lazyFlagSuffix+",Boolean")
+	c.lazyGlobals = append(c.lazyGlobals, &lazyGlobal{
+		name:      name,
+		pos:       spec.Pos(),
+		index:     c.globals[c.getIdentName("", name)],
+		flag:      c.globals[c.getIdentName("", name+lazyFlagSuffix)],
+		label:     c.newLabel(),
+		expr:      spec.Values[0],
+		pkg:       c.currPkg,
+		importMap: c.importMap,
+	})
+}
+
+// lazyGlobal returns lazy variable description if vi refers to it.
+func (c *codegen) lazyGlobal(vi *varInfo) *lazyGlobal {
+	if vi.refType != varGlobal {
+		return nil
+	}
+	for _, g := range c.lazyGlobals {
+		if g.index == vi.index {
+			return g
+		}
+	}
+	return nil
+}
+
+// convertLazyGlobals emits accessors for lazy variables. Accessor evaluates
+// the initializer once, stores the result and returns the variable value.
+func (c *codegen) convertLazyGlobals() {
+	for _, g := range c.lazyGlobals {
+		c.currPkg = g.pkg
+		c.typeInfo = g.pkg.TypesInfo
+		c.importMap = g.importMap
+		c.scope = nil
+
+		// Sequence points produced by the initializer would be attributed
+		// to `_initialize` otherwise.
+		seqCount := len(c.sequencePoints["init"])
+		inlineCount := c.globalInlineCount
+		c.globalInlineCount = 0
+
+		c.setLabel(g.label)
+		start := c.prog.Len()
+		g.rng.Start = uint16(start)
+		emit.Instruction(c.prog.BinWriter, opcode.INITSLOT, []byte{0, 0})
+		done := c.newLabel()
+		c.emitLoadByIndex(varGlobal, g.flag)
+		emit.Jmp(c.prog.BinWriter, opcode.JMPIFL, done)
+		ast.Walk(c, g.expr)
+		c.emitStoreByIndex(varGlobal, g.index)
+		emit.Opcodes(c.prog.BinWriter, opcode.PUSHT)
+		c.emitStoreByIndex(varGlobal, g.flag)
+		c.setLabel(done)
+		c.emitLoadByIndex(varGlobal, g.index)
+		emit.Opcodes(c.prog.BinWriter, opcode.RET)
+		g.rng.End = uint16(c.prog.Len() - 1)
+
+		c.reverseOffsetMap[start] = nameWithLocals{
+			name:  c.getIdentName("", g.name),
+			count: c.globalInlineCount,
+		}
+		c.globalInlineCount = inlineCount
+		c.sequencePoints["init"] = c.sequencePoints["init"][:seqCount]
+
+		for _, f := range c.lambda {
+			c.convertFuncDecl(nil, f.decl, g.pkg.Types)
+		}
+		c.lambda = make(map[string]*funcScope)
+		if c.takeError(g.expr) {
+			return
+		}
+	}
+}
+
+// addInitEntry remembers code emitted into `_initialize` method since start
+// offset if there is any.
+func (c *codegen) addInitEntry(kind string, pos token.Pos, ids []*ast.Ident, start int) {
+	if c.prog.Len() == start {
+		return
+	}
+	var names []string
+	for _, id := range ids {
+		name, ok := c.unusedGlobals[id]
+		if !ok {
+			name = id.Name
+		}
+		names = append(names, name)
+	}
+	c.initEntries = append(c.initEntries, initEntry{
+		kind:  kind,
+		pkg:   c.currPkg.PkgPath,
+		names: names,
+		pos:   pos,
+		rng: DebugRange{
+			Start: uint16(start),
+			End:   uint16(c.prog.Len() - 1),
+		},
+	})
+}
+
+// initReport builds InitReport, it must be called after writeJumps.
+func (c *codegen) initReport() *InitReport {
+	r := &InitReport{
+		Entries: make([]InitEntry, 0, len(c.initEntries)),
+		Lazy:    make([]InitEntry, 0, len(c.lazyGlobals)),
+	}
+	if c.initEndOffset > 0 {
+		r.Size = c.initEndOffset + 1
+	}
+	for _, e := range c.initEntries {
+		r.Entries = append(r.Entries, InitEntry{
+			Kind:     e.kind,
+			Package:  e.pkg,
+			Names:    e.names,
+			Position: c.position(e.pos).String(),
+			Size:     int(e.rng.End) - int(e.rng.Start) + 1,
+		})
+	}
+	for _, g := range c.lazyGlobals {
+		r.Lazy = append(r.Lazy, InitEntry{
+			Kind:     InitEntryLazy,
+			Package:  g.pkg.PkgPath,
+			Names:    []string{g.name},
+			Position: c.position(g.pos).String(),
+			Size:     int(g.rng.End) - int(g.rng.Start) + 1,
+		})
+	}
+	return r
+}
@@ -1,9 +1,13 @@
 package compiler_test
 
 import (
+	"fmt"
 	"math/big"
+	"path"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/stretchr/testify/require"
 )
 
@@ -105,3 +109,200 @@ func TestInitWithNoGlobals(t *testing.T) {
 	assertResult(t, v, big.NewInt(42))
 	require.True(t, len(s.events) == 1)
 }
+
+func TestInitOrderAcrossPackages(t *testing.T) {
+	t.Run("c,a", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/c"
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/a"
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+		)
+		var x = trace.Add("main:" + a.A2 + c.C)
+		func init() {
+			trace.Add("main.init")
+		}
+		func Main() string { return trace.Log }`
+		eval(t, src, []byte("c;b;b.init;a1:b;a2;a1.init;a2.init;main:a2c;main.init;"))
+	})
+	t.Run("a,c", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/a"
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/c"
+			"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+		)
+		var x = trace.Add("main:" + a.A2 + c.C)
+		func Main() string { return trace.Log }`
+		eval(t, src, []byte("b;b.init;a1:b;a2;a1.init;a2.init;c;main:a2c;"))
+	})
+}
+
+func TestInitReport(t *testing.T) {
+	src := `package foo
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/a"
+		"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+	)
+	const c = 1
+	var x, y = 1, 2
+	var unused int
+	//neo:lazy
+	var z = a.A1 + trace.Add("z")
+	func init() {
+		x = 3
+	}
+	func Main() string { return z }`
+	_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	r := di.InitReport
+	require.NotNil(t, r)
+
+	var (
+		kinds []string
+		names [][]string
+		size  int
+	)
+	for _, e := range r.Entries {
+		kinds = append(kinds, e.Kind+":"+path.Base(e.Package))
+		names = append(names, e.Names)
+		require.True(t, e.Size > 0)
+		size += e.Size
+	}
+	require.Equal(t, []string{
+		"var:trace", "var:b", "init:b",
+		"var:a", "var:a", "init:a", "init:a",
+		"var:command-line-arguments", "init:command-line-arguments",
+	}, kinds)
+	require.Equal(t, [][]string{
+		{"Log"}, {"B"}, nil,
+		{"A1"}, {"A2"}, nil, nil,
+		{"x", "y"}, nil,
+	}, names)
+	// INITSSLOT and RET are not a part of any entry.
+	require.Equal(t, r.Size, size+3)
+
+	require.Equal(t, 1, len(r.Lazy))
+	require.Equal(t, compiler.InitEntryLazy, r.Lazy[0].Kind)
+	require.Equal(t, []string{"z"}, r.Lazy[0].Names)
+	require.True(t, r.Lazy[0].Size > 0)
+}
+
+func TestLazyGlobal(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		src := `package foo
+		//neo:lazy
+		var a = []int{1, 2, 3}
+		func Main() int { return a[0] + a[2] }`
+		eval(t, src, big.NewInt(4))
+	})
+	t.Run("EvaluatedOnce", func(t *testing.T) {
+		src := `package foo
+		var calls int
+		var (
+			//neo:lazy
+			a = inc()
+		)
+		func inc() int {
+			calls++
+			return calls * 10
+		}
+		func Main() int { return a + a + calls }`
+		eval(t, src, big.NewInt(21))
+	})
+	t.Run("NotEvaluatedIfUnused", func(t *testing.T) {
+		src := `package foo
+		var calls int
+		//neo:lazy
+		var a = inc()
+		func inc() int {
+			calls++
+			return calls
+		}
+		func Main() int { return calls }`
+		eval(t, src, big.NewInt(0))
+	})
+	t.Run("AssignedBeforeUse", func(t *testing.T) {
+		src := `package foo
+		//neo:lazy
+		var a = panicking()
+		func panicking() int { panic("unexpected") }
+		func Main() int {
+			a = 42
+			return a
+		}`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("FromInitializer", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+		//neo:lazy
+		var a = trace.Add("a")
+		var b = trace.Add("b:" + a)
+		func Main() string { return trace.Log }`
+		eval(t, src, []byte("a;b:a;"))
+	})
+	t.Run("WithLambda", func(t *testing.T) {
+		src := `package foo
+		//neo:lazy
+		var a = func(x int) int { return x * 2 }(21)
+		func Main() int { return a }`
+		eval(t, src, big.NewInt(42))
+	})
+	t.Run("WithInlinedCall", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/inline"
+		//neo:lazy
+		var a = inline.Sum(inline.Sum(1, 2), 3)
+		func Main() int { return a }`
+		eval(t, src, big.NewInt(6))
+	})
+	t.Run("LocalVariable", func(t *testing.T) {
+		src := `package foo
+		func Main() int {
+			//neo:lazy
+			var a = 1
+			return a
+		}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorIs(t, err, compiler.ErrLazyLocalVar)
+	})
+	t.Run("MultipleVariables", func(t *testing.T) {
+		src := `package foo
+		//neo:lazy
+		var a, b = 1, 2
+		func Main() int { return a + b }`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorIs(t, err, compiler.ErrInvalidLazyVar)
+	})
+}
+
+// TestLazyGlobalInitSize ensures heavy lazy variables don't contribute to
+// `_initialize` method.
+func TestLazyGlobalInitSize(t *testing.T) {
+	const srcTmpl = `package foo
+	var small = 1
+	%s
+	var big = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "ten"}
+	func Main() int { return len(big) + small }`
+
+	compile := func(t *testing.T, directive string) *compiler.InitReport {
+		src := fmt.Sprintf(srcTmpl, directive)
+		eval(t, src, big.NewInt(11))
+		_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		require.NoError(t, err)
+		return di.InitReport
+	}
+	eager := compile(t, "")
+	lazy := compile(t, "//neo:lazy")
+
+	require.Equal(t, 2, len(eager.Entries))
+	require.Equal(t, 0, len(eager.Lazy))
+	require.Equal(t, 1, len(lazy.Entries))
+	require.Equal(t, 1, len(lazy.Lazy))
+
+	// The only thing left in `_initialize` is the small variable.
+	require.Equal(t, eager.Entries[0], lazy.Entries[0])
+	require.Equal(t, eager.Size-eager.Entries[1].Size, lazy.Size)
+	require.True(t, lazy.Lazy[0].Size > eager.Entries[1].Size)
+}
@@ -28,6 +28,8 @@ func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
 	c.inlineContext = append(c.inlineContext, inlineContextSingle{
 		labelOffset: len(c.labelList),
 		returnLabel: c.newLabel(),
+		name:        f.pkg.Name() + "." + f.name,
+		pos:         n.Pos(),
 	})
 
 	defer func() {
@@ -39,7 +41,7 @@ func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
 	sig := c.typeOf(n.Fun).(*types.Signature)
 
 	hasVarArgs := !n.Ellipsis.IsValid()
-	eventParams := c.processStdlibCall(f, n.Args, !hasVarArgs)
+	eventParams := c.processStdlibCall(f, n, !hasVarArgs)
 
 	// When inlined call is used during global initialization
 	// there is no func scope, thus this if.
@@ -119,8 +121,6 @@ func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
 
 	c.pkgInfoInline = append(c.pkgInfoInline, pkg)
 	oldMap := c.importMap
-	oldDefers := c.scope.deferStack
-	c.scope.deferStack = nil
 	c.fillImportMap(f.file, pkg)
 	ast.Inspect(f.decl, c.scope.analyzeVoidCalls)
 	ast.Walk(c, f.decl.Body)
@@ -130,24 +130,22 @@ func (c *codegen) inlineCall(f *funcScope, n *ast.CallExpr) {
 			emit.Opcodes(c.prog.BinWriter, opcode.DROP)
 		}
 	}
-	c.processDefers()
-	c.scope.deferStack = oldDefers
 	c.importMap = oldMap
 	c.pkgInfoInline = c.pkgInfoInline[:len(c.pkgInfoInline)-1]
 }
 
-func (c *codegen) processStdlibCall(f *funcScope, args []ast.Expr, hasEllipsis bool) []*stackitem.Type {
+func (c *codegen) processStdlibCall(f *funcScope, n *ast.CallExpr, hasEllipsis bool) []*stackitem.Type {
 	if f == nil {
 		return nil
 	}
 
 	var eventParams []*stackitem.Type
 	if f.pkg.Path() == interopPrefix+"/runtime" && (f.name == "Notify" || f.name == "Log") {
-		eventParams = c.processNotify(f, args, hasEllipsis)
+		eventParams = c.processNotify(f, n.Args, hasEllipsis)
 	}
 
 	if f.pkg.Path() == interopPrefix+"/contract" && f.name == "Call" {
-		c.processContractCall(f, args)
+		c.processContractCall(f, n)
 	}
 	return eventParams
 }
@@ -240,8 +238,11 @@ func (c *codegen) processNotify(f *funcScope, args []ast.Expr, hasEllipsis bool)
 	return nil
 }
 
-func (c *codegen) processContractCall(f *funcScope, args []ast.Expr) {
-	var u util.Uint160
+func (c *codegen) processContractCall(f *funcScope, n *ast.CallExpr) {
+	var (
+		u    util.Uint160
+		args = n.Args
+	)
 
 	// For stdlib calls it is `interop.Hash160(constHash)`
 	// so we can determine hash at compile-time.
@@ -261,33 +262,57 @@ func (c *codegen) processContractCall(f *funcScope, args []ast.Expr) {
 		}
 	}
 
-	value := c.typeAndValueOf(args[1]).Value
-	if value == nil {
-		return
+	var (
+		flag      = uint64(callflag.All)
+		flagKnown bool
+	)
+	if value := c.typeAndValueOf(args[2]).Value; value != nil {
+		flag, _ = constant.Uint64Val(value)
+		flagKnown = true
 	}
+	// Unknown flags are assumed to be All for the //neo:method check.
+	c.useFlags(f.pkg.Name()+"."+f.name, callflag.CallFlag(flag), n.Pos())
 
-	method := constant.StringVal(value)
-
-	value = c.typeAndValueOf(args[2]).Value
+	value := c.typeAndValueOf(args[1]).Value
 	if value == nil {
+		c.warnDynamicCall(args[1], "method")
 		return
 	}
+	if u.Equals(util.Uint160{}) {
+		c.warnDynamicCall(args[0], "contract hash")
+	}
+	if !flagKnown {
+		// Such call can't be checked against permissions, but it still
+		// needs one if the method is not safe.
+		flag = uint64(callflag.NoneFlag)
+	}
 
-	flag, _ := constant.Uint64Val(value)
+	method := constant.StringVal(value)
 	c.appendInvokedContract(u, method, flag)
 }
 
+// warnDynamicCall marks contract as performing dynamic calls and adds a
+// warning about it.
+func (c *codegen) warnDynamicCall(expr ast.Expr, what string) {
+	c.dynamicCalls = true
+	c.warnings = append(c.warnings, newError(c.position(expr.Pos()), CodeDynamicCall,
+		fmt.Errorf("contract call with %s unknown at compile time requires wildcard permission", what)))
+}
+
 func (c *codegen) appendInvokedContract(u util.Uint160, method string, flag uint64) {
-	currLst := c.invokedContracts[u]
-	for _, m := range currLst {
-		if m == method {
-			return
-		}
+	c.calledContracts[u] = appendMethod(c.calledContracts[u], method)
+	if flag&uint64(callflag.WriteStates|callflag.AllowNotify) != 0 {
+		c.invokedContracts[u] = appendMethod(c.invokedContracts[u], method)
 	}
+}
 
-	if flag&uint64(callflag.WriteStates|callflag.AllowNotify) != 0 {
-		c.invokedContracts[u] = append(currLst, method)
+func appendMethod(lst []string, method string) []string {
+	for _, m := range lst {
+		if m == method {
+			return lst
+		}
 	}
+	return append(lst, method)
 }
 
 // hasCalls returns true if expression contains any calls.
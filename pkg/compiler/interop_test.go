@@ -622,6 +622,39 @@ func TestCallWithVersion(t *testing.T) {
 	})
 }
 
+func TestInferredPermissionsReadOnlyCall(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		func Get() int {
+			return 42
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Callee"})
+	e.DeployContract(t, ctr, nil)
+
+	src = fmt.Sprintf(`package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		)
+		func Get() any {
+			return contract.Call(interop.Hash160(%q), "get", contract.ReadStates)
+		}`, ctr.Hash.BytesBE())
+	t.Run("inferred", func(t *testing.T) {
+		caller := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Caller"})
+		e.DeployContract(t, caller, nil)
+		e.CommitteeInvoker(caller.Hash).Invoke(t, 42, "get")
+	})
+	t.Run("no permissions", func(t *testing.T) {
+		caller := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+			Name:        "CallerNoPermissions",
+			Permissions: []manifest.Permission{},
+		})
+		e.DeployContract(t, caller, nil)
+		e.CommitteeInvoker(caller.Hash).InvokeFail(t, "disallowed method call", "get")
+	})
+}
+
 func TestForcedNotifyArgumentsConversion(t *testing.T) {
 	const methodWithEllipsis = "withEllipsis"
 	const methodWithoutEllipsis = "withoutEllipsis"
@@ -765,3 +798,104 @@ func TestForcedNotifyArgumentsConversion(t *testing.T) {
 		checkSingleType(t, methodWithoutEllipsis, smartcontract.PublicKeyType, stackitem.IntegerT, true)
 	})
 }
+
+func TestSerializeNestedComposites(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		type Leaf struct {
+			Name string
+			Val  int
+		}
+		type Mid struct {
+			ID     int
+			Leaves []Leaf
+			Ptrs   []*Leaf
+			Pair   [2]Leaf
+		}
+		type Top struct {
+			Title string
+			Mids  map[string]Mid
+			List  []Mid
+		}
+		func Put() {
+			t := Top{
+				Title: "top",
+				Mids: map[string]Mid{
+					"a": {ID: 1, Leaves: []Leaf{{"x", 1}, {Name: "y", Val: 2}}, Ptrs: []*Leaf{{Val: 3}}},
+					"b": {ID: 2, Pair: [2]Leaf{1: {"z", 4}}},
+				},
+				List: []Mid{1: {ID: 3}},
+			}
+			storage.Put(storage.GetContext(), "top", std.Serialize(t))
+		}
+		func Get() Top {
+			return std.Deserialize(storage.Get(storage.GetReadOnlyContext(), "top").([]byte)).(Top)
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Helper"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+	c.Invoke(t, stackitem.Null{}, "put")
+
+	stack, err := c.TestInvoke(t, "get")
+	require.NoError(t, err)
+	top := stack.Pop().Item()
+	fields := func(t *testing.T, item stackitem.Item, n int) []stackitem.Item {
+		require.Equal(t, stackitem.StructT, item.Type())
+		arr := item.Value().([]stackitem.Item)
+		require.Equal(t, n, len(arr))
+		return arr
+	}
+	checkLeaf := func(t *testing.T, item stackitem.Item, name string, val int64) {
+		leaf := fields(t, item, 2)
+		require.Equal(t, []byte(name), leaf[0].Value())
+		require.Equal(t, big.NewInt(val), leaf[1].Value())
+	}
+	checkMid := func(t *testing.T, item stackitem.Item, id int64) []stackitem.Item {
+		mid := fields(t, item, 4)
+		require.Equal(t, big.NewInt(id), mid[0].Value())
+		return mid
+	}
+
+	topFields := fields(t, top, 3)
+	require.Equal(t, []byte("top"), topFields[0].Value())
+
+	require.Equal(t, stackitem.MapT, topFields[1].Type())
+	mids := topFields[1].Value().([]stackitem.MapElement)
+	require.Equal(t, 2, len(mids))
+
+	require.Equal(t, []byte("a"), mids[0].Key.Value())
+	a := checkMid(t, mids[0].Value, 1)
+	leaves := a[1].Value().([]stackitem.Item)
+	require.Equal(t, 2, len(leaves))
+	checkLeaf(t, leaves[0], "x", 1)
+	checkLeaf(t, leaves[1], "y", 2)
+	ptrs := a[2].Value().([]stackitem.Item)
+	require.Equal(t, 1, len(ptrs))
+	require.Equal(t, stackitem.ArrayT, ptrs[0].Type())
+	ptr := ptrs[0].Value().([]stackitem.Item)
+	require.Equal(t, []byte{}, ptr[0].Value())
+	require.Equal(t, big.NewInt(3), ptr[1].Value())
+	pair := a[3].Value().([]stackitem.Item)
+	require.Equal(t, 2, len(pair))
+	checkLeaf(t, pair[0], "", 0)
+	checkLeaf(t, pair[1], "", 0)
+
+	require.Equal(t, []byte("b"), mids[1].Key.Value())
+	b := checkMid(t, mids[1].Value, 2)
+	require.Equal(t, stackitem.Null{}, b[1])
+	require.Equal(t, stackitem.Null{}, b[2])
+	pair = b[3].Value().([]stackitem.Item)
+	require.Equal(t, 2, len(pair))
+	checkLeaf(t, pair[0], "", 0)
+	checkLeaf(t, pair[1], "z", 4)
+
+	list := topFields[2].Value().([]stackitem.Item)
+	require.Equal(t, 2, len(list))
+	checkMid(t, list[0], 0)
+	checkMid(t, list[1], 3)
+}
@@ -0,0 +1,192 @@
+package compiler
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+)
+
+// methodDirective declares call flags an exported contract method needs,
+// it's either `//neo:method safe` or `//neo:method flags=ReadStates|AllowCall`.
+const methodDirective = "//neo:method"
+
+// Various //neo:method directive errors.
+var (
+	// ErrInvalidMethodDirective is returned when //neo:method directive is
+	// malformed or used for something other than an exported function of the
+	// main package.
+	ErrInvalidMethodDirective = errors.New("invalid //neo:method directive")
+	// ErrMethodFlags is returned when a method annotated with //neo:method
+	// directive (or some function it calls) performs a call requiring flags
+	// that are not declared for the method.
+	ErrMethodFlags = errors.New("call flags violation")
+)
+
+// syscallFlags contains call flags required by syscalls, syscalls not listed
+// here don't require any flags. It must match the set of interops in the core
+// package (which is checked by TestSyscallFlags).
+var syscallFlags = map[string]callflag.CallFlag{
+	interopnames.SystemContractCall:              callflag.ReadStates | callflag.AllowCall,
+	interopnames.SystemContractNativeOnPersist:   callflag.States,
+	interopnames.SystemContractNativePostPersist: callflag.States,
+	interopnames.SystemRuntimeGetTime:            callflag.ReadStates,
+	interopnames.SystemRuntimeLoadScript:         callflag.AllowCall,
+	interopnames.SystemRuntimeLog:                callflag.AllowNotify,
+	interopnames.SystemRuntimeNotify:             callflag.AllowNotify,
+	interopnames.SystemStorageDelete:             callflag.WriteStates,
+	interopnames.SystemStorageFind:               callflag.ReadStates,
+	interopnames.SystemStorageFindFrom:           callflag.ReadStates,
+	interopnames.SystemStorageGet:                callflag.ReadStates,
+	interopnames.SystemStorageGetContext:         callflag.ReadStates,
+	interopnames.SystemStorageGetReadOnlyContext: callflag.ReadStates,
+	interopnames.SystemStoragePut:                callflag.WriteStates,
+	interopnames.SystemStorageAsReadOnly:         callflag.ReadStates,
+}
+
+// flagUse is a call requiring some call flags.
+type flagUse struct {
+	name  string
+	flags callflag.CallFlag
+	pos   token.Pos
+}
+
+// funcCall is a call of a function that is not inlined.
+type funcCall struct {
+	f   *funcScope
+	pos token.Pos
+}
+
+// isSafeFlags returns true if a method with the given flags can be marked as
+// safe in the manifest, i.e. it can't change the state or emit notifications.
+func isSafeFlags(f callflag.CallFlag) bool {
+	return f&(callflag.All^callflag.ReadOnly) == 0
+}
+
+// parseMethodDirective returns call flags declared for the function with
+// //neo:method directive, nil is returned if there is no directive.
+func parseMethodDirective(decl *ast.FuncDecl, isMain bool) (*callflag.CallFlag, error) {
+	if decl.Doc == nil {
+		return nil, nil
+	}
+	var (
+		args  string
+		found bool
+	)
+	for _, c := range decl.Doc.List {
+		text := strings.TrimRight(c.Text, " \t")
+		rest, ok := strings.CutPrefix(text, methodDirective)
+		if !ok || (rest != "" && rest[0] != ' ' && rest[0] != '\t') {
+			continue
+		}
+		if found {
+			return nil, fmt.Errorf("%w: multiple directives for %s", ErrInvalidMethodDirective, decl.Name.Name)
+		}
+		args, found = strings.TrimSpace(rest), true
+	}
+	if !found {
+		return nil, nil
+	}
+	if !isMain || decl.Recv != nil || !decl.Name.IsExported() {
+		return nil, fmt.Errorf("%w: it can only be used for exported functions of the main package", ErrInvalidMethodDirective)
+	}
+	var flags callflag.CallFlag
+	switch {
+	case args == "safe":
+		flags = callflag.ReadOnly
+	case strings.HasPrefix(args, "flags="):
+		for _, s := range strings.Split(strings.TrimPrefix(args, "flags="), "|") {
+			f, err := callflag.FromString(strings.TrimSpace(s))
+			if err != nil || strings.Contains(s, ",") {
+				return nil, fmt.Errorf("%w: unknown flag %q", ErrInvalidMethodDirective, s)
+			}
+			flags |= f
+		}
+	default:
+		return nil, fmt.Errorf("%w: expected `safe` or `flags=...`, got %q", ErrInvalidMethodDirective, args)
+	}
+	return &flags, nil
+}
+
+// useFlags remembers a call requiring the given flags made by the current
+// function. Calls made by inlined functions are attributed to the outermost
+// inlined call.
+func (c *codegen) useFlags(name string, flags callflag.CallFlag, pos token.Pos) {
+	if c.scope == nil || flags == callflag.NoneFlag {
+		return
+	}
+	if len(c.inlineContext) != 0 {
+		name, pos = c.inlineContext[0].name, c.inlineContext[0].pos
+	}
+	c.scope.flagUses = append(c.scope.flagUses, flagUse{name: name, flags: flags, pos: pos})
+}
+
+// addCall remembers a call of the given function made by the current function.
+func (c *codegen) addCall(f *funcScope, pos token.Pos) {
+	if c.scope == nil {
+		return
+	}
+	if len(c.inlineContext) != 0 {
+		pos = c.inlineContext[0].pos
+	}
+	c.scope.calls = append(c.scope.calls, funcCall{f: f, pos: pos})
+}
+
+// checkMethodFlags ensures that methods annotated with //neo:method directive
+// and all functions they call don't perform calls requiring undeclared flags.
+func (c *codegen) checkMethodFlags() {
+	var methods []*funcScope
+	for _, f := range c.funcs {
+		if f.callFlags != nil {
+			methods = append(methods, f)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].decl.Pos() < methods[j].decl.Pos() })
+	for _, m := range methods {
+		var (
+			declared = *m.callFlags
+			seen     = map[*funcScope]bool{m: true}
+			reported = map[token.Pos]bool{}
+			check    func(f *funcScope, chain []string)
+		)
+		check = func(f *funcScope, chain []string) {
+			for _, u := range f.flagUses {
+				missing := u.flags &^ declared
+				if missing == 0 || reported[u.pos] {
+					continue
+				}
+				reported[u.pos] = true
+				var desc = "with " + declared.String() + " flags"
+				if declared == callflag.ReadOnly {
+					desc = "safe"
+				}
+				c.errs = append(c.errs, newError(c.position(u.pos), CodeCodegen,
+					fmt.Errorf("%w: method %s is declared %s, but %s requires %s (call chain: %s)",
+						ErrMethodFlags, m.decl.Name.Name, desc, u.name, missing,
+						strings.Join(append(chain, u.name), " -> "))))
+			}
+			for _, call := range f.calls {
+				if seen[call.f] {
+					continue
+				}
+				seen[call.f] = true
+				check(call.f, append(chain, funcName(call.f)))
+			}
+		}
+		check(m, []string{m.decl.Name.Name})
+	}
+}
+
+// funcName returns a human-readable name of the function.
+func funcName(f *funcScope) string {
+	if f.decl.Recv != nil && len(f.decl.Recv.List) != 0 {
+		return types.ExprString(f.decl.Recv.List[0].Type) + "." + f.name
+	}
+	return f.name
+}
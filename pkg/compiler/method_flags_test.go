@@ -0,0 +1,210 @@
+package compiler_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMethodDirective(t *testing.T) {
+	compileManifest := func(t *testing.T, src string, safe ...string) (*compiler.DebugInfo, error) {
+		_, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
+		if err != nil {
+			return nil, err
+		}
+		m, err := compiler.CreateManifest(di, &compiler.Options{Name: "foo", SafeMethods: safe,
+			NoEventsCheck: true, NoPermissionsCheck: true})
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range []string{"get", "getTime", "put", "plain"} {
+			if md := m.ABI.GetMethod(name, -1); md != nil {
+				var expected bool
+				for _, s := range safe {
+					expected = expected || s == name
+				}
+				for _, md := range di.Methods {
+					if md.Name.Name == name && md.CallFlags != nil {
+						expected = expected || *md.CallFlags&(callflag.WriteStates|callflag.AllowNotify) == 0
+					}
+				}
+				require.Equal(t, expected, md.Safe, name)
+			}
+		}
+		return di, nil
+	}
+	checkViolation := func(t *testing.T, src string, line int, substr string) {
+		_, err := compileManifest(t, src)
+		require.ErrorIs(t, err, compiler.ErrMethodFlags)
+		var errs compiler.Errors
+		if !errors.As(err, &errs) {
+			var cerr compiler.Error
+			require.True(t, errors.As(err, &cerr))
+			errs = compiler.Errors{cerr}
+		}
+		for _, e := range errs {
+			if e.Pos.Line == line && strings.Contains(e.Msg, substr) {
+				return
+			}
+		}
+		t.Fatalf("no error at line %d containing %q: %s", line, substr, err)
+	}
+
+	t.Run("safe", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		//neo:method safe
+		func Get(key []byte) any {
+			ctx := storage.GetReadOnlyContext()
+			return storage.Get(ctx, key)
+		}
+		// GetTime returns the balance and the time.
+		//neo:method flags=ReadStates|AllowCall
+		func GetTime() int {
+			return gas.BalanceOf(runtime.GetExecutingScriptHash()) + runtime.GetTime()
+		}
+		//neo:method flags=States
+		func Put(key []byte) {
+			storage.Put(storage.GetContext(), key, key)
+		}
+		func Plain() int { return 1 }`
+		di, err := compileManifest(t, src)
+		require.NoError(t, err)
+		for _, m := range di.Methods {
+			switch m.Name.Name {
+			case "get", "getTime":
+				require.Equal(t, callflag.ReadOnly, *m.CallFlags)
+			case "put":
+				require.Equal(t, callflag.States, *m.CallFlags)
+			default:
+				require.Nil(t, m.CallFlags)
+			}
+		}
+
+		_, err = compileManifest(t, src, "plain", "get")
+		require.NoError(t, err)
+		_, err = compileManifest(t, src, "put")
+		require.Error(t, err)
+	})
+	t.Run("direct storage.Put", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		//neo:method safe
+		func Get(key []byte) any {
+			ctx := storage.GetContext()
+			storage.Put(ctx, key, key)
+			return storage.Get(ctx, key)
+		}`
+		checkViolation(t, src, 6, "method Get is declared safe, but storage.Put requires WriteStates")
+	})
+	t.Run("transitive", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		//neo:method safe
+		func Get(key []byte) any {
+			return cached(key)
+		}
+		func cached(key []byte) any {
+			v := storage.Get(storage.GetReadOnlyContext(), key)
+			if v == nil {
+				v = compute(key)
+			}
+			return v
+		}
+		func compute(key []byte) any {
+			storage.Put(storage.GetContext(), key, key)
+			return key
+		}
+		//neo:method flags=States
+		func Update(key []byte) {
+			compute(key)
+			runtime.Notify("updated", key)
+		}`
+		checkViolation(t, src, 18, "method Get is declared safe, but storage.Put requires WriteStates "+
+			"(call chain: Get -> cached -> compute -> storage.Put)")
+		checkViolation(t, src, 24, "method Update is declared with States flags, but runtime.Notify requires AllowNotify")
+	})
+	t.Run("lambda", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		//neo:method flags=ReadStates
+		func Get() int {
+			f := func() int {
+				runtime.Log("called")
+				return 1
+			}
+			return f()
+		}`
+		checkViolation(t, src, 6, "runtime.Log requires AllowNotify (call chain: Get -> lambda@")
+	})
+	t.Run("native call", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/gas"
+		)
+		//neo:method safe
+		func Pay(to interop.Hash160) bool {
+			return gas.Transfer(to, to, 1, nil)
+		}`
+		checkViolation(t, src, 8, "gas.Transfer requires WriteStates, AllowNotify")
+	})
+	t.Run("contract call", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		)
+		//neo:method safe
+		func Read(h interop.Hash160) any {
+			return contract.Call(h, "get", contract.ReadOnly)
+		}
+		//neo:method safe
+		func Write(h interop.Hash160, f contract.CallFlag) any {
+			return contract.Call(h, "get", f)
+		}`
+		checkViolation(t, src, 12, "method Write is declared safe, but contract.Call requires WriteStates, AllowNotify")
+	})
+	t.Run("invalid", func(t *testing.T) {
+		for name, src := range map[string]string{
+			"unexported": `package foo
+				//neo:method safe
+				func get() int { return 1 }
+				func Main() int { return get() }`,
+			"receiver": `package foo
+				type T struct{}
+				//neo:method safe
+				func (T) Get() int { return 1 }
+				func Main() int { return T{}.Get() }`,
+			"unknown": `package foo
+				//neo:method unsafe
+				func Main() int { return 1 }`,
+			"bad flag": `package foo
+				//neo:method flags=ReadStates|Write
+				func Main() int { return 1 }`,
+			"comma": `package foo
+				//neo:method flags=ReadStates,AllowCall
+				func Main() int { return 1 }`,
+			"multiple": `package foo
+				//neo:method safe
+				//neo:method flags=All
+				func Main() int { return 1 }`,
+		} {
+			t.Run(name, func(t *testing.T) {
+				_, err := compiler.Compile("foo.go", strings.NewReader(src))
+				require.ErrorIs(t, err, compiler.ErrInvalidMethodDirective)
+			})
+		}
+	})
+}
@@ -131,6 +131,7 @@ func TestPolicyAttributeType(t *testing.T) {
 	require.EqualValues(t, policy.NotValidBeforeT, transaction.NotValidBeforeT)
 	require.EqualValues(t, policy.ConflictsT, transaction.ConflictsT)
 	require.EqualValues(t, policy.NotaryAssistedT, transaction.NotaryAssistedT)
+	require.EqualValues(t, policy.SponsorT, transaction.SponsorT)
 }
 
 func TestStorageLimits(t *testing.T) {
@@ -174,9 +175,13 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"unclaimedGas", []string{u160, "123"}},
 		{"unregisterCandidate", []string{pub}},
 		{"getAccountState", []string{u160}},
+		{"unclaimedGasDetailed", []string{u160}},
+		{"getVoterInfo", []string{u160}},
+		{"claimGas", []string{u160}},
 	}, nep17TestCases...))
 	runNativeTestCases(t, cs.GAS.ContractMD, "gas", nep17TestCases)
 	runNativeTestCases(t, cs.Oracle.ContractMD, "oracle", []nativeTestCase{
+		{"cancelRequest", []string{"1"}},
 		{"getPrice", nil},
 		{"request", []string{`"url"`, "nil", `"callback"`, "nil", "123"}},
 		{"setPrice", []string{"10"}},
@@ -223,6 +228,7 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"getContract", []string{u160}},
 		{"getContractById", []string{"1"}},
 		{"getContractHashes", nil},
+		{"getContractsIterator", nil},
 		{"getMinimumDeploymentFee", nil},
 		{"hasMethod", []string{u160, `"method"`, "0"}},
 		{"setMinimumDeploymentFee", []string{"42"}},
@@ -241,12 +247,17 @@ func TestNativeHelpersCompile(t *testing.T) {
 		{"bls12381Mul", []string{"crypto.Bls12381Point{}", "[]byte{1, 2, 3}", "true"}},
 		{"bls12381Pairing", []string{"crypto.Bls12381Point{}", "crypto.Bls12381Point{}"}},
 		{"keccak256", []string{"[]byte{1, 2, 3}"}},
+		{"sha256Init", nil},
+		{"sha256Update", []string{"crypto.Sha256State{}", "[]byte{1, 2, 3}"}},
+		{"sha256Final", []string{"crypto.Sha256State{}"}},
+		{"merkleRoot", []string{"[]interop.Hash256{}"}},
 	})
 	runNativeTestCases(t, cs.Std.ContractMD, "std", []nativeTestCase{
 		{"serialize", []string{"[]byte{1, 2, 3}"}},
 		{"deserialize", []string{"[]byte{1, 2, 3}"}},
 		{"jsonSerialize", []string{"[]byte{1, 2, 3}"}},
 		{"jsonDeserialize", []string{"[]byte{1, 2, 3}"}},
+		{"jsonPath", []string{"[]byte{1, 2, 3}", `"$"`}},
 		{"base64Encode", []string{"[]byte{1, 2, 3}"}},
 		{"base64Decode", []string{"[]byte{1, 2, 3}"}},
 		{"base58Encode", []string{"[]byte{1, 2, 3}"}},
@@ -329,6 +329,39 @@ var sliceTestCases = []testCase{
 		`,
 		[]byte("str"),
 	},
+	{
+		"literal slice with keyed elements",
+		`func F%d() []int {
+			return []int{2: 5, 1, 0: 3}
+		}
+		`,
+		[]stackitem.Item{
+			stackitem.NewBigInteger(big.NewInt(3)),
+			stackitem.NewBigInteger(big.NewInt(0)),
+			stackitem.NewBigInteger(big.NewInt(5)),
+			stackitem.NewBigInteger(big.NewInt(1)),
+		},
+	},
+	{
+		"array literal shorter than array",
+		`type pairC struct { a, b int }
+		func F%d() int {
+			a := [3]pairC{{1, 2}, 2: {b: 3}}
+			return len(a)*100 + a[1].b*10 + a[2].b
+		}
+		`,
+		big.NewInt(303),
+	},
+	{
+		"defaults to zero values for array",
+		`func F%d() int {
+			var a [2]int
+			a[1] = 3
+			return len(a)*10 + a[0] + a[1]
+		}
+		`,
+		big.NewInt(23),
+	},
 }
 
 func TestSliceOperations(t *testing.T) {
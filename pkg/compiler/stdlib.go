@@ -0,0 +1,92 @@
+package compiler
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// stdReplacements contains standard library packages that can't be compiled,
+// but have a subset of their functions provided by interop libraries. Function
+// lists must be kept in sync with respective libraries.
+var stdReplacements = map[string]stdReplacement{
+	"bytes": {
+		lib: interopPrefix + "/lib/bytes",
+		funcs: []string{"Compare", "Contains", "Equal", "HasPrefix", "HasSuffix",
+			"Index", "LastIndex", "TrimPrefix", "TrimSuffix"},
+	},
+	"strings": {
+		lib: interopPrefix + "/lib/strings",
+		funcs: []string{"Compare", "Contains", "HasPrefix", "HasSuffix", "Index",
+			"Join", "LastIndex", "Repeat", "Split", "ToLower", "ToUpper",
+			"TrimPrefix", "TrimSuffix"},
+	},
+}
+
+// stdReplacement is an interop library replacing a standard library package.
+type stdReplacement struct {
+	lib   string
+	funcs []string
+}
+
+// provides returns true if the library has the function with the given name.
+func (r stdReplacement) provides(name string) bool {
+	for _, f := range r.funcs {
+		if f == name {
+			return true
+		}
+	}
+	return false
+}
+
+// checkStdImports reports an error for every usage of standard library
+// packages replaced by interop libraries in pkg.
+func (c *codegen) checkStdImports(pkg *packages.Package) {
+	var found bool
+	for _, f := range pkg.Syntax {
+		for _, spec := range f.Imports {
+			path := strings.Trim(spec.Path.Value, "\"")
+			repl, ok := stdReplacements[path]
+			if !ok {
+				continue
+			}
+			found = true
+			if spec.Name != nil && spec.Name.Name == "_" {
+				c.errs = append(c.errs, newError(c.position(spec.Pos()), CodeCodegen,
+					fmt.Errorf("standard %q package can't be used in contracts, use %q instead", path, repl.lib)))
+			}
+		}
+	}
+	if !found {
+		return
+	}
+	var uses []*ast.Ident
+	for id, obj := range pkg.TypesInfo.Uses {
+		if obj.Pkg() == nil || obj.Parent() != obj.Pkg().Scope() {
+			continue // Package names, locals, fields and methods.
+		}
+		if _, ok := stdReplacements[obj.Pkg().Path()]; ok {
+			uses = append(uses, id)
+		}
+	}
+	sort.Slice(uses, func(i, j int) bool { return uses[i].Pos() < uses[j].Pos() })
+	for _, id := range uses {
+		var (
+			obj  = pkg.TypesInfo.Uses[id]
+			path = obj.Pkg().Path()
+			repl = stdReplacements[path]
+			name = path + "." + obj.Name()
+			err  error
+		)
+		if _, isFunc := obj.(*types.Func); isFunc && repl.provides(obj.Name()) {
+			err = fmt.Errorf("standard %s can't be used in contracts, use the one from %q instead", name, repl.lib)
+		} else {
+			err = fmt.Errorf("standard %s is not supported, %q only provides %s", name, repl.lib, strings.Join(repl.funcs, ", "))
+		}
+		c.errs = append(c.errs, newError(c.position(id.Pos()), CodeCodegen, err))
+	}
+}
@@ -0,0 +1,349 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibStrings(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/lib/strings"
+		func Compare(a, b string) int { return strings.Compare(a, b) }
+		func Contains(s, sub string) bool { return strings.Contains(s, sub) }
+		func HasPrefix(s, p string) bool { return strings.HasPrefix(s, p) }
+		func HasSuffix(s, p string) bool { return strings.HasSuffix(s, p) }
+		func Index(s, sub string) int { return strings.Index(s, sub) }
+		func LastIndex(s, sub string) int { return strings.LastIndex(s, sub) }
+		func Join(elems []string, sep string) string { return strings.Join(elems, sep) }
+		func Repeat(s string, n int) string { return strings.Repeat(s, n) }
+		func Split(s, sep string) []string { return strings.Split(s, sep) }
+		func ToLower(s string) string { return strings.ToLower(s) }
+		func ToUpper(s string) string { return strings.ToUpper(s) }
+		func TrimPrefix(s, p string) string { return strings.TrimPrefix(s, p) }
+		func TrimSuffix(s, p string) string { return strings.TrimSuffix(s, p) }
+		func TrimEq(s, p, exp string) bool { return strings.TrimPrefix(s, p) == exp }
+		func JoinEq(exp string) bool { return strings.Join([]string{"a", "b"}, ",") == exp }`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Helper"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	long := strings.Repeat("ab", 100) + "needle" + strings.Repeat("ba", 100)
+	tooLong := strings.Repeat("ab", 600) + "needle"
+	cases := map[string][][3]any{
+		"compare": {
+			{"abc", "abc", 0}, {"abc", "abd", -1}, {"abd", "abc", 1},
+			{"ab", "abc", -1}, {"abc", "ab", 1}, {"", "", 0}, {"", "a", -1},
+		},
+		"contains": {
+			{"abc", "bc", true}, {"abc", "", true}, {"abc", "cd", false},
+			{long, "needle", true}, {long, "needles", false},
+		},
+		"hasPrefix": {{"abc", "ab", true}, {"abc", "", true}, {"abc", "abcd", false}, {"abc", "b", false}},
+		"hasSuffix": {{"abc", "bc", true}, {"abc", "", true}, {"abc", "zabc", false}, {"abc", "b", false}},
+		"index": {
+			{"chicken", "ken", 4}, {"chicken", "dmr", -1}, {"aaa", "a", 0}, {"abc", "", 0},
+			{"ab", "abc", -1}, {long, "needle", 200}, {tooLong, "needle", 1200},
+		},
+		"lastIndex": {
+			{"go gopher", "go", 3}, {"go gopher", "rodent", -1}, {"abc", "", 3},
+			{long, "ba", 404}, {long, "ab", 403}, {tooLong, "ab", 1198},
+		},
+		"repeat":     {{"ab", 0, ""}, {"ab", 1, "ab"}, {"ab", 3, "ababab"}, {"", 5, ""}, {"abc", 7, strings.Repeat("abc", 7)}},
+		"split":      {{"a,b,c", ",", []any{"a", "b", "c"}}, {"abc", ",", []any{"abc"}}, {"a,,b", ",", []any{"a", "", "b"}}},
+		"toLower":    {{"HeLLo, 123", "", "hello, 123"}, {"hello", "", "hello"}},
+		"toUpper":    {{"HeLLo, 123", "", "HELLO, 123"}, {"HELLO", "", "HELLO"}},
+		"trimPrefix": {{"prefix-body", "prefix-", "body"}, {"body", "prefix-", "body"}, {"abc", "abc", ""}},
+		"trimSuffix": {{"body.go", ".go", "body"}, {"body", ".go", "body"}},
+	}
+	for method, tcs := range cases {
+		t.Run(method, func(t *testing.T) {
+			for _, tc := range tcs {
+				var args = []any{tc[0]}
+				if tc[1] != "" || (method != "toLower" && method != "toUpper") {
+					args = append(args, tc[1])
+				}
+				c.Invoke(t, stackitem.Make(tc[2]), method, args...)
+			}
+		})
+	}
+	t.Run("join", func(t *testing.T) {
+		c.Invoke(t, "", "join", []any{}, ",")
+		c.Invoke(t, "a", "join", []any{"a"}, ",")
+		c.Invoke(t, "a, b, c", "join", []any{"a", "b", "c"}, ", ")
+	})
+	t.Run("ByteString results", func(t *testing.T) {
+		c.Invoke(t, true, "trimEq", "prefix-body", "prefix-", "body")
+		c.Invoke(t, true, "joinEq", "a,b")
+		res, err := c.TestInvoke(t, "trimPrefix", "prefix-body", "prefix-")
+		require.NoError(t, err)
+		require.Equal(t, stackitem.ByteArrayT, res.Pop().Item().Type())
+		res, err = c.TestInvoke(t, "repeat", "ab", 3)
+		require.NoError(t, err)
+		require.Equal(t, stackitem.ByteArrayT, res.Pop().Item().Type())
+	})
+	t.Run("repeat negative", func(t *testing.T) {
+		c.InvokeFail(t, "negative Repeat count", "repeat", "ab", -1)
+	})
+	// Behavior deliberately different from Go standard library.
+	t.Run("UTF-8", func(t *testing.T) {
+		// Only ASCII letters are mapped.
+		c.Invoke(t, "éCOLE", "toUpper", "école")
+		c.Invoke(t, "straße", "toLower", "STRAßE")
+		c.Invoke(t, "STRAßE", "toUpper", "straße")
+		c.Invoke(t, "ǅ", "toLower", "ǅ")
+		// Indexes are byte offsets just like in Go.
+		c.Invoke(t, 1, "index", "€€€", "\x82")
+		c.Invoke(t, 0, "index", "€€€", "€")
+		c.Invoke(t, 6, "lastIndex", "€€€", "€")
+		// Split can only handle valid UTF-8 strings up to 1024 bytes.
+		c.Invoke(t, stackitem.Make([]any{"a", "b"}), "split", "a€b", "€")
+		c.InvokeFail(t, "not UTF-8", "split", "a\xffb", ",")
+		c.InvokeFail(t, "too big", "split", strings.Repeat("a", 1025), ",")
+		// Empty separator splits after each UTF-8 sequence.
+		c.Invoke(t, stackitem.Make([]any{"a", "€", "b"}), "split", "a€b", "")
+	})
+}
+
+func TestLibBytes(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/lib/bytes"
+		func Compare(a, b []byte) int { return bytes.Compare(a, b) }
+		func Contains(s, sub []byte) bool { return bytes.Contains(s, sub) }
+		func Equal(a, b []byte) bool { return bytes.Equal(a, b) }
+		func HasPrefix(s, p []byte) bool { return bytes.HasPrefix(s, p) }
+		func HasSuffix(s, p []byte) bool { return bytes.HasSuffix(s, p) }
+		func Index(s, sub []byte) int { return bytes.Index(s, sub) }
+		func LastIndex(s, sub []byte) int { return bytes.LastIndex(s, sub) }
+		func TrimPrefix(s, p []byte) []byte { return bytes.TrimPrefix(s, p) }
+		func TrimSuffix(s, p []byte) []byte { return bytes.TrimSuffix(s, p) }
+		func TrimCopy(trim bool) []byte {
+			s := []byte{9, 1, 2}
+			var r []byte
+			if trim {
+				r = bytes.TrimPrefix(s, []byte{9})
+			} else {
+				r = bytes.TrimPrefix(s, []byte{1})
+			}
+			r[0] = 42
+			return s
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Helper"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	b := func(s string) []byte { return []byte(s) }
+	buf := func(s string) *stackitem.Buffer { return stackitem.NewBuffer([]byte(s)) }
+	long := b(strings.Repeat("ab", 100) + "needle" + strings.Repeat("ba", 100))
+	cases := map[string][][3]any{
+		"compare": {
+			{b("abc"), b("abc"), 0}, {b("abc"), b("abd"), -1}, {b("abd"), b("abc"), 1},
+			{b("ab"), b("abc"), -1}, {[]byte{}, []byte{}, 0}, {[]byte{0xff}, []byte{0x01}, 1},
+		},
+		"contains":  {{b("abc"), b("bc"), true}, {b("abc"), b("cd"), false}, {long, b("needle"), true}},
+		"equal":     {{b("abc"), b("abc"), true}, {b("abc"), b("abd"), false}, {b("ab"), b("abc"), false}, {[]byte{}, []byte{}, true}},
+		"hasPrefix": {{b("abc"), b("ab"), true}, {b("abc"), []byte{}, true}, {b("ab"), b("abc"), false}},
+		"hasSuffix": {{b("abc"), b("bc"), true}, {b("abc"), []byte{}, true}, {b("ab"), b("zab"), false}},
+		"index": {
+			{b("chicken"), b("ken"), 4}, {b("chicken"), b("dmr"), -1}, {[]byte{1, 2, 0xff}, []byte{0xff}, 2},
+			{long, b("needle"), 200},
+		},
+		"lastIndex":  {{b("go gopher"), b("go"), 3}, {b("abc"), []byte{}, 3}, {long, b("ab"), 403}},
+		"trimPrefix": {{b("prefix-body"), b("prefix-"), buf("body")}, {b("body"), b("prefix-"), buf("body")}},
+		"trimSuffix": {{b("body.go"), b(".go"), buf("body")}, {b("body"), b(".go"), buf("body")}},
+	}
+	for method, tcs := range cases {
+		t.Run(method, func(t *testing.T) {
+			for _, tc := range tcs {
+				c.Invoke(t, stackitem.Make(tc[2]), method, tc[0], tc[1])
+			}
+		})
+	}
+	t.Run("trim copies", func(t *testing.T) {
+		c.Invoke(t, stackitem.NewBuffer([]byte{9, 1, 2}), "trimCopy", true)
+		c.Invoke(t, stackitem.NewBuffer([]byte{9, 1, 2}), "trimCopy", false)
+	})
+}
+
+// TestLibGas checks that library functions are no more expensive than the
+// loops contract authors usually write to do the same thing.
+func TestLibGas(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/lib/bytes"
+			"github.com/nspcc-dev/neo-go/pkg/interop/lib/strings"
+		)
+		func LibIndex(s, sub string) int { return strings.Index(s, sub) }
+		func LoopIndex(s, sub string) int {
+			for i := 0; i <= len(s)-len(sub); i++ {
+				if string(s[i:i+len(sub)]) == sub {
+					return i
+				}
+			}
+			return -1
+		}
+		func LibHasPrefix(s, p string) bool { return strings.HasPrefix(s, p) }
+		func LoopHasPrefix(s, p string) bool {
+			if len(s) < len(p) {
+				return false
+			}
+			for i := 0; i < len(p); i++ {
+				if s[i] != p[i] {
+					return false
+				}
+			}
+			return true
+		}
+		func LibToUpper(s string) string { return strings.ToUpper(s) }
+		func LoopToUpper(s string) string {
+			var res []byte
+			for i := 0; i < len(s); i++ {
+				c := s[i]
+				if c >= 'a' && c <= 'z' {
+					c -= 'a' - 'A'
+				}
+				res = append(res, c)
+			}
+			return string(res)
+		}
+		func LibSplit(s, sep string) []string { return strings.Split(s, sep) }
+		func LoopSplit(s, sep string) []string {
+			var res []string
+			var start int
+			for i := 0; i+len(sep) <= len(s); i++ {
+				if string(s[i:i+len(sep)]) == sep {
+					res = append(res, string(s[start:i]))
+					start = i + len(sep)
+					i += len(sep) - 1
+				}
+			}
+			return append(res, string(s[start:]))
+		}
+		func LibTrimPrefix(s, p string) string { return strings.TrimPrefix(s, p) }
+		func LoopTrimPrefix(s, p string) string {
+			if len(s) < len(p) {
+				return s
+			}
+			for i := 0; i < len(p); i++ {
+				if s[i] != p[i] {
+					return s
+				}
+			}
+			return string(s[len(p):])
+		}
+		func LibBytesIndex(s, sub []byte) int { return bytes.Index(s, sub) }
+		func LoopBytesIndex(s, sub []byte) int {
+			for i := 0; i <= len(s)-len(sub); i++ {
+				var j int
+				for j < len(sub) && s[i+j] == sub[j] {
+					j++
+				}
+				if j == len(sub) {
+					return i
+				}
+			}
+			return -1
+		}
+		func LibBytesEqual(a, b []byte) bool { return bytes.Equal(a, b) }
+		func LoopBytesEqual(a, b []byte) bool {
+			if len(a) != len(b) {
+				return false
+			}
+			for i := 0; i < len(a); i++ {
+				if a[i] != b[i] {
+					return false
+				}
+			}
+			return true
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "Helper"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+
+	for _, tc := range []struct {
+		name string
+		args []any
+	}{
+		{"Index", []any{"hello, world", "world"}},
+		{"Index", []any{strings.Repeat("ab", 40) + "needle", "needle"}},
+		{"Index", []any{strings.Repeat("ab", 300) + "needle", "needle"}},
+		{"HasPrefix", []any{"prefix-body", "prefix-"}},
+		{"HasPrefix", []any{strings.Repeat("prefix", 20) + "body", strings.Repeat("prefix", 20)}},
+		{"ToUpper", []any{"hello, world"}},
+		{"ToUpper", []any{strings.Repeat("Hello, World! ", 10)}},
+		{"Split", []any{"a,b,c,d,e", ","}},
+		{"Split", []any{strings.Repeat("abcdef,", 20), ","}},
+		{"TrimPrefix", []any{"prefix-body", "prefix-"}},
+		{"TrimPrefix", []any{strings.Repeat("prefix", 20) + "body", strings.Repeat("prefix", 20)}},
+		{"BytesIndex", []any{[]byte(strings.Repeat("ab", 40) + "needle"), []byte("needle")}},
+		{"BytesIndex", []any{[]byte(strings.Repeat("ab", 300) + "needle"), []byte("needle")}},
+		{"BytesEqual", []any{[]byte("abcdef"), []byte("abcdef")}},
+		{"BytesEqual", []any{[]byte(strings.Repeat("ab", 80)), []byte(strings.Repeat("ab", 80))}},
+	} {
+		libGas, libRes := invokeGas(t, c, "lib"+tc.name, tc.args...)
+		loopGas, loopRes := invokeGas(t, c, "loop"+tc.name, tc.args...)
+		require.Equal(t, loopRes, libRes, tc.name)
+		require.LessOrEqual(t, libGas, loopGas, tc.name)
+	}
+}
+
+// invokeGas invokes the method and returns the GAS consumed by the invocation
+// with its result.
+func invokeGas(t *testing.T, c *neotest.ContractInvoker, method string, args ...any) (int64, stackitem.Item) {
+	tx := c.PrepareInvoke(t, method, args...)
+	c.AddNewBlock(t, tx)
+	res := c.CheckHalt(t, tx.Hash())
+	return res.GasConsumed, res.Stack[0]
+}
+
+func TestStdPackages(t *testing.T) {
+	t.Run("supported", func(t *testing.T) {
+		src := `package foo
+			import "strings"
+			func Main(s string) bool {
+				return strings.HasPrefix(s, "a") && strings.Index(s, "b") > 0
+			}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorContains(t, err, `standard strings.HasPrefix can't be used in contracts, use the one from "github.com/nspcc-dev/neo-go/pkg/interop/lib/strings" instead`)
+		require.ErrorContains(t, err, "standard strings.Index can't be used")
+	})
+	t.Run("unsupported", func(t *testing.T) {
+		src := `package foo
+			import "bytes"
+			func Main(b []byte) [][]byte {
+				return bytes.Fields(b)
+			}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorContains(t, err, `standard bytes.Fields is not supported, "github.com/nspcc-dev/neo-go/pkg/interop/lib/bytes" only provides Compare, Contains, Equal`)
+	})
+	t.Run("types", func(t *testing.T) {
+		src := `package foo
+			import "strings"
+			func Main(s string) int {
+				var b strings.Builder
+				return b.Len()
+			}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorContains(t, err, "standard strings.Builder is not supported")
+	})
+	t.Run("blank", func(t *testing.T) {
+		src := `package foo
+			import _ "bytes"
+			func Main() int {
+				return 1
+			}`
+		_, err := compiler.Compile("foo.go", strings.NewReader(src))
+		require.ErrorContains(t, err, `foo.go:2:11: standard "bytes" package can't be used in contracts`)
+	})
+}
@@ -0,0 +1,424 @@
+package compiler
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+)
+
+// maxKeyResolveDepth limits the number of variables and helper functions
+// followed when storage key value is resolved.
+const maxKeyResolveDepth = 8
+
+// storageKeyArgs contains key and value argument indexes of storage functions
+// (-1 if there is no value argument).
+var storageKeyArgs = map[string][2]int{
+	"Put":      {1, 2},
+	"Get":      {1, -1},
+	"Delete":   {1, -1},
+	"Find":     {1, -1},
+	"FindFrom": {1, -1},
+}
+
+// storageKeyUsage is a storage key used by some function. Only the constant
+// part of the key is known, exact is set if it's the whole key, otherwise
+// it's a prefix followed by some variable data.
+type storageKeyUsage struct {
+	fn    string
+	pos   token.Pos
+	key   []byte
+	exact bool
+	// shape is the type of values stored under the key (empty if unknown).
+	shape string
+}
+
+// storageKeyAnalyzer collects storage keys used by the main package
+// functions.
+type storageKeyAnalyzer struct {
+	info *types.Info
+	// defs contains the only value assigned to variables and the only
+	// expression returned from functions.
+	defs map[types.Object]ast.Expr
+	// multi contains variables assigned more than once (and functions with
+	// more than one return).
+	multi map[types.Object]bool
+	used  []storageKeyUsage
+}
+
+// checkStorageKeys emits warnings for constant storage keys (or prefixes)
+// used by different functions that can collide while values of different
+// types are stored under them. Like "user" + id and "users" keys, if id is
+// "s" both refer to the same storage item.
+func (c *codegen) checkStorageKeys() {
+	a := &storageKeyAnalyzer{
+		info:  c.mainPkg.TypesInfo,
+		defs:  make(map[types.Object]ast.Expr),
+		multi: make(map[types.Object]bool),
+	}
+	for _, f := range c.mainPkg.Syntax {
+		a.collectDefs(f)
+	}
+	for _, f := range c.mainPkg.Syntax {
+		for _, decl := range f.Decls {
+			if fd, ok := decl.(*ast.FuncDecl); ok && fd.Body != nil {
+				a.collectUsages(fd)
+			}
+		}
+	}
+	for i := range a.used {
+		for j := 0; j < i; j++ {
+			u, v := a.used[i], a.used[j]
+			if u.fn == v.fn || u.shape == "" || v.shape == "" || u.shape == v.shape {
+				continue
+			}
+			var msg string
+			switch {
+			case u.exact && v.exact && bytes.Equal(u.key, v.key):
+				msg = fmt.Sprintf("storage key %s is used for %s values in %s and for %s values in %s at %s",
+					formatStorageKey(u.key), u.shape, u.fn, v.shape, v.fn, c.position(v.pos))
+			case !v.exact && bytes.HasPrefix(u.key, v.key), !u.exact && bytes.HasPrefix(v.key, u.key):
+				msg = fmt.Sprintf("storage key %s in %s (%s values) overlaps with %s in %s (%s values) at %s",
+					describeStorageKey(u), u.fn, u.shape, describeStorageKey(v), v.fn, v.shape, c.position(v.pos))
+			default:
+				continue
+			}
+			c.warnings = append(c.warnings, newError(c.position(u.pos), CodeStorageKeyCollision, fmt.Errorf("%s", msg)))
+		}
+	}
+}
+
+// collectDefs remembers values of variables assigned once and the results of
+// functions with a single return statement.
+func (a *storageKeyAnalyzer) collectDefs(f *ast.File) {
+	def := func(id *ast.Ident, e ast.Expr) {
+		obj := a.info.ObjectOf(id)
+		if obj == nil {
+			return
+		}
+		if _, ok := a.defs[obj]; ok || e == nil {
+			a.multi[obj] = true
+		}
+		a.defs[obj] = e
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.ValueSpec:
+			for i, id := range n.Names {
+				var e ast.Expr
+				if len(n.Values) == len(n.Names) {
+					e = n.Values[i]
+				}
+				def(id, e)
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				id, ok := lhs.(*ast.Ident)
+				if !ok {
+					continue
+				}
+				var e ast.Expr
+				if (n.Tok == token.DEFINE || n.Tok == token.ASSIGN) && len(n.Rhs) == len(n.Lhs) {
+					e = n.Rhs[i]
+				}
+				def(id, e)
+			}
+		case *ast.IncDecStmt:
+			if id, ok := n.X.(*ast.Ident); ok {
+				def(id, nil)
+			}
+		case *ast.UnaryExpr:
+			// Address is taken, so it can be changed indirectly.
+			if id, ok := n.X.(*ast.Ident); ok && n.Op == token.AND {
+				def(id, nil)
+			}
+		case *ast.FuncDecl:
+			if n.Body == nil || n.Recv != nil {
+				return true
+			}
+			obj := a.info.ObjectOf(n.Name)
+			ast.Inspect(n.Body, func(n ast.Node) bool {
+				switch r := n.(type) {
+				case *ast.FuncLit:
+					return false
+				case *ast.ReturnStmt:
+					var e ast.Expr
+					if len(r.Results) == 1 {
+						e = r.Results[0]
+					}
+					if _, ok := a.defs[obj]; ok || e == nil {
+						a.multi[obj] = true
+					}
+					a.defs[obj] = e
+				}
+				return true
+			})
+		}
+		return true
+	})
+}
+
+// collectUsages collects storage keys used by the given function.
+func (a *storageKeyAnalyzer) collectUsages(fd *ast.FuncDecl) {
+	var (
+		name  = fd.Name.Name
+		stack []ast.Node
+	)
+	if fd.Recv != nil && len(fd.Recv.List) != 0 {
+		name = types.ExprString(fd.Recv.List[0].Type) + "." + name
+	}
+	ast.Inspect(fd.Body, func(n ast.Node) bool {
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return false
+		}
+		stack = append(stack, n)
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		args, ok := storageKeyArgs[a.interopFunc(call, "storage")]
+		if !ok || len(call.Args) <= args[0] {
+			return true
+		}
+		key, exact, ok := a.constKey(call.Args[args[0]], 0)
+		if !ok || len(key) == 0 {
+			return true
+		}
+		u := storageKeyUsage{
+			fn:    name,
+			pos:   call.Pos(),
+			key:   key,
+			exact: exact,
+		}
+		if args[1] >= 0 && len(call.Args) > args[1] {
+			u.shape = a.valueShape(call.Args[args[1]])
+		} else if a.interopFunc(call, "storage") == "Get" {
+			u.shape = a.resultShape(stack)
+		}
+		a.used = append(a.used, u)
+		return true
+	})
+}
+
+// interopFunc returns the name of the function from the given interop package
+// called by the expression (if it's such a call).
+func (a *storageKeyAnalyzer) interopFunc(call *ast.CallExpr, pkg string) string {
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	fn, ok := a.info.Uses[sel.Sel].(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != interopPrefix+"/"+pkg {
+		return ""
+	}
+	return fn.Name()
+}
+
+// constKey returns the constant part of the key expression, exact is false
+// if it's only a prefix of the key.
+func (a *storageKeyAnalyzer) constKey(e ast.Expr, depth int) (key []byte, exact bool, ok bool) {
+	if depth > maxKeyResolveDepth {
+		return nil, false, false
+	}
+	if tv, ok := a.info.Types[e]; ok && tv.Value != nil {
+		b, ok := constBytes(tv.Value)
+		return b, ok, ok
+	}
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return a.constKey(e.X, depth)
+	case *ast.Ident:
+		obj, isVar := a.info.Uses[e].(*types.Var)
+		if !isVar || a.multi[obj] || a.defs[obj] == nil {
+			return nil, false, false
+		}
+		return a.constKey(a.defs[obj], depth+1)
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return nil, false, false
+		}
+		return a.concatKeys(depth, e.X, e.Y)
+	case *ast.CompositeLit:
+		var key []byte
+		for _, elt := range e.Elts {
+			if _, ok := elt.(*ast.KeyValueExpr); ok {
+				return key, false, len(key) != 0
+			}
+			tv := a.info.Types[elt]
+			if tv.Value == nil {
+				return key, false, len(key) != 0
+			}
+			v, ok := constant.Int64Val(constant.ToInt(tv.Value))
+			if !ok {
+				return key, false, len(key) != 0
+			}
+			key = append(key, byte(v))
+		}
+		return key, true, true
+	case *ast.CallExpr:
+		if tv, ok := a.info.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return a.constKey(e.Args[0], depth)
+		}
+		if id, ok := e.Fun.(*ast.Ident); ok {
+			if _, isBuiltin := a.info.Uses[id].(*types.Builtin); isBuiltin {
+				if id.Name != "append" || len(e.Args) == 0 {
+					return nil, false, false
+				}
+				return a.appendKey(depth, e)
+			}
+			obj, isFunc := a.info.Uses[id].(*types.Func)
+			if !isFunc || a.multi[obj] || a.defs[obj] == nil {
+				return nil, false, false
+			}
+			return a.constKey(a.defs[obj], depth+1)
+		}
+	}
+	return nil, false, false
+}
+
+// concatKeys returns the constant part of the concatenation of the given
+// expressions.
+func (a *storageKeyAnalyzer) concatKeys(depth int, es ...ast.Expr) ([]byte, bool, bool) {
+	var res []byte
+	for i, e := range es {
+		key, exact, ok := a.constKey(e, depth)
+		if !ok {
+			return res, false, i != 0 && len(res) != 0
+		}
+		res = append(res, key...)
+		if !exact {
+			return res, false, true
+		}
+	}
+	return res, true, true
+}
+
+// appendKey returns the constant part of the key built with append.
+func (a *storageKeyAnalyzer) appendKey(depth int, e *ast.CallExpr) ([]byte, bool, bool) {
+	if e.Ellipsis.IsValid() {
+		return a.concatKeys(depth, e.Args...)
+	}
+	key, exact, ok := a.constKey(e.Args[0], depth)
+	if !ok || !exact {
+		return key, false, ok
+	}
+	for _, arg := range e.Args[1:] {
+		tv := a.info.Types[arg]
+		if tv.Value == nil {
+			return key, false, len(key) != 0
+		}
+		v, ok := constant.Int64Val(constant.ToInt(tv.Value))
+		if !ok {
+			return key, false, len(key) != 0
+		}
+		key = append(key, byte(v))
+	}
+	return key, true, true
+}
+
+// constBytes converts a constant into the storage key bytes.
+func constBytes(v constant.Value) ([]byte, bool) {
+	switch v.Kind() {
+	case constant.String:
+		return []byte(constant.StringVal(v)), true
+	case constant.Int:
+		i, ok := new(big.Int).SetString(v.ExactString(), 10)
+		if !ok {
+			return nil, false
+		}
+		return bigint.ToBytes(i), true
+	default:
+		return nil, false
+	}
+}
+
+// valueShape returns the type of value stored, values serialized with
+// std.Serialize are distinguished by their original type.
+func (a *storageKeyAnalyzer) valueShape(e ast.Expr) string {
+	if call, ok := e.(*ast.CallExpr); ok && a.interopFunc(call, "native/std") == "Serialize" && len(call.Args) == 1 {
+		return "serialized " + typeShape(a.info.TypeOf(call.Args[0]))
+	}
+	return typeShape(a.info.TypeOf(e))
+}
+
+// resultShape returns the type of the value returned from storage.Get call
+// (the last node of the stack) based on its usage: type assertion or
+// std.Deserialize call followed by type assertion.
+func (a *storageKeyAnalyzer) resultShape(stack []ast.Node) string {
+	var serialized bool
+	for i := len(stack) - 2; i >= 0; i-- {
+		switch n := stack[i].(type) {
+		case *ast.ParenExpr:
+		case *ast.TypeAssertExpr:
+			if n.Type == nil {
+				return ""
+			}
+			t := typeShape(a.info.TypeOf(n.Type))
+			if !serialized && t == "[]byte" && i > 0 {
+				if call, ok := stack[i-1].(*ast.CallExpr); ok && a.interopFunc(call, "native/std") == "Deserialize" {
+					serialized = true
+					i--
+					continue
+				}
+			}
+			if serialized {
+				return "serialized " + t
+			}
+			return t
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// typeShape returns the storage-related type of value, strings and byte
+// slices are stored the same way as well as all integers.
+func typeShape(t types.Type) string {
+	if t == nil {
+		return ""
+	}
+	t = types.Default(t)
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsInteger != 0:
+			return "int"
+		case u.Info()&types.IsBoolean != 0:
+			return "bool"
+		case u.Info()&types.IsString != 0:
+			return "[]byte"
+		}
+	case *types.Slice:
+		if isByte(u.Elem()) {
+			return "[]byte"
+		}
+	case *types.Interface:
+		return ""
+	}
+	return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+}
+
+// formatStorageKey returns printable keys as quoted strings and all other
+// ones as hex.
+func formatStorageKey(key []byte) string {
+	for _, b := range key {
+		if b < 0x20 || b > 0x7e {
+			return fmt.Sprintf("0x%x", key)
+		}
+	}
+	return fmt.Sprintf("%q", key)
+}
+
+func describeStorageKey(u storageKeyUsage) string {
+	if u.exact {
+		return formatStorageKey(u.key)
+	}
+	return "prefix " + formatStorageKey(u.key)
+}
@@ -0,0 +1,110 @@
+package compiler_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/stretchr/testify/require"
+)
+
+func storageKeyWarnings(t *testing.T, src string) []compiler.Diagnostic {
+	_, _, diags, err := compiler.CompileWithDiagnostics("foo.go", strings.NewReader(src), nil)
+	require.NoError(t, err)
+	var res []compiler.Diagnostic
+	for _, d := range diags {
+		if d.Code == compiler.CodeStorageKeyCollision {
+			require.Equal(t, compiler.SeverityWarning, d.Severity)
+			res = append(res, d)
+		}
+	}
+	return res
+}
+
+func TestStorageKeyCollisions(t *testing.T) {
+	t.Run("collisions", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		type User struct {
+			Name string
+		}
+		const balancePrefix = 0x01
+		func PutUser(id string, u User) {
+			ctx := storage.GetContext()
+			storage.Put(ctx, "user"+id, std.Serialize(u))
+		}
+		func CountUsers() int {
+			return storage.Get(storage.GetReadOnlyContext(), "users").(int)
+		}
+		func SetBalance(id []byte, amount int) {
+			storage.Put(storage.GetContext(), append([]byte{balancePrefix}, id...), amount)
+		}
+		func GetOwner() []byte {
+			key := []byte{balancePrefix}
+			return storage.Get(storage.GetReadOnlyContext(), key).([]byte)
+		}`
+		ws := storageKeyWarnings(t, src)
+		require.Equal(t, 2, len(ws), ws)
+
+		require.Equal(t, 15, ws[0].Pos.Line)
+		require.Contains(t, ws[0].Msg, `"users" in CountUsers (int values)`)
+		require.Contains(t, ws[0].Msg, `prefix "user" in PutUser (serialized foo.User values)`)
+		require.Contains(t, ws[0].Msg, "foo.go:12:")
+
+		require.Equal(t, 22, ws[1].Pos.Line)
+		require.Contains(t, ws[1].Msg, `0x01 in GetOwner ([]byte values)`)
+		require.Contains(t, ws[1].Msg, `prefix 0x01 in SetBalance (int values)`)
+		require.Contains(t, ws[1].Msg, "foo.go:18:")
+	})
+	t.Run("same key", func(t *testing.T) {
+		src := `package foo
+		import "github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		var totalKey = []byte("total")
+		func totalSupplyKey() []byte {
+			return totalKey
+		}
+		func SetTotal(v int) {
+			storage.Put(storage.GetContext(), totalSupplyKey(), v)
+		}
+		func SetName(n string) {
+			storage.Put(storage.GetContext(), "total", n)
+		}`
+		ws := storageKeyWarnings(t, src)
+		require.Equal(t, 1, len(ws), ws)
+		require.Equal(t, 11, ws[0].Pos.Line)
+		require.Contains(t, ws[0].Msg, `storage key "total" is used for []byte values in SetName and for int values in SetTotal`)
+	})
+	t.Run("clean", func(t *testing.T) {
+		src := `package foo
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		type User struct {
+			Name string
+		}
+		const (
+			userPrefix  = 0x01
+			countPrefix = 0x02
+		)
+		func PutUser(id []byte, u User) {
+			ctx := storage.GetContext()
+			storage.Put(ctx, append([]byte{userPrefix}, id...), std.Serialize(u))
+			storage.Put(ctx, []byte{countPrefix}, CountUsers()+1)
+		}
+		func GetUser(id []byte) User {
+			ctx := storage.GetReadOnlyContext()
+			return std.Deserialize(storage.Get(ctx, append([]byte{userPrefix}, id...)).([]byte)).(User)
+		}
+		func CountUsers() int {
+			return storage.Get(storage.GetReadOnlyContext(), []byte{countPrefix}).(int)
+		}
+		func Name(id []byte) string {
+			return GetUser(id).Name
+		}`
+		require.Equal(t, 0, len(storageKeyWarnings(t, src)))
+	})
+}
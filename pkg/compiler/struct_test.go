@@ -431,6 +431,41 @@ var structTestCases = []testCase{
 		`,
 		big.NewInt(2),
 	},
+	{
+		"elided pointer literals in slice",
+		`type pointedB struct { a, b int }
+		func F%d() int {
+			s := []*pointedB{{b: 2}, {1, 3}}
+			p := s[0]
+			p.a = 4
+			return s[0].a*100 + s[0].b*10 + s[1].b
+		}
+		`,
+		big.NewInt(423),
+	},
+	{
+		"map with struct values",
+		`type inner struct { a int; s []inner }
+		func F%d() int {
+			m := map[string]inner{
+				"x": {a: 1, s: []inner{{a: 2}, {3, nil}}},
+				"y": {},
+			}
+			return m["x"].a*100 + m["x"].s[1].a*10 + len(m["y"].s)
+		}
+		`,
+		big.NewInt(130),
+	},
+	{
+		"uninitialized array field",
+		`type withArray struct { arr [2]struct{ a int } }
+		func F%d() int {
+			w := withArray{}
+			return len(w.arr)*10 + w.arr[1].a
+		}
+		`,
+		big.NewInt(20),
+	},
 }
 
 func TestStructs(t *testing.T) {
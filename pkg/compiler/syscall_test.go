@@ -9,7 +9,9 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	istorage "github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
@@ -74,6 +76,7 @@ func TestSyscallExecution(t *testing.T) {
 		"runtime.BurnGas":                  {interopnames.SystemRuntimeBurnGas, []string{"1"}, true},
 		"runtime.CheckWitness":             {interopnames.SystemRuntimeCheckWitness, []string{b}, false},
 		"runtime.CurrentSigners":           {interopnames.SystemRuntimeCurrentSigners, nil, false},
+		"runtime.EnterNonReentrant":        {interopnames.SystemRuntimeEnterNonReentrant, []string{b}, true},
 		"runtime.GasLeft":                  {interopnames.SystemRuntimeGasLeft, nil, false},
 		"runtime.GetAddressVersion":        {interopnames.SystemRuntimeGetAddressVersion, nil, false},
 		"runtime.GetCallingScriptHash":     {interopnames.SystemRuntimeGetCallingScriptHash, nil, false},
@@ -82,16 +85,19 @@ func TestSyscallExecution(t *testing.T) {
 		"runtime.GetInvocationCounter":     {interopnames.SystemRuntimeGetInvocationCounter, nil, false},
 		"runtime.GetNetwork":               {interopnames.SystemRuntimeGetNetwork, nil, false},
 		"runtime.GetNotifications":         {interopnames.SystemRuntimeGetNotifications, []string{u160}, false},
+		"runtime.GetNotificationsByName":   {interopnames.SystemRuntimeGetNotificationsByName, []string{u160, `"ev"`}, false},
 		"runtime.GetRandom":                {interopnames.SystemRuntimeGetRandom, nil, false},
 		"runtime.GetScriptContainer":       {interopnames.SystemRuntimeGetScriptContainer, nil, false},
 		"runtime.GetTime":                  {interopnames.SystemRuntimeGetTime, nil, false},
 		"runtime.GetTrigger":               {interopnames.SystemRuntimeGetTrigger, nil, false},
+		"runtime.LeaveNonReentrant":        {interopnames.SystemRuntimeLeaveNonReentrant, []string{b}, true},
 		"runtime.LoadScript":               {interopnames.SystemRuntimeLoadScript, []string{b, "0", b}, false},
 		"runtime.Log":                      {interopnames.SystemRuntimeLog, []string{`"msg"`}, true},
 		"runtime.Notify":                   {interopnames.SystemRuntimeNotify, []string{`"ev"`, "1"}, true},
 		"runtime.Platform":                 {interopnames.SystemRuntimePlatform, nil, false},
 		"storage.Delete":                   {interopnames.SystemStorageDelete, []string{sctx, b}, true},
 		"storage.Find":                     {interopnames.SystemStorageFind, []string{sctx, b, "storage.None"}, false},
+		"storage.FindFrom":                 {interopnames.SystemStorageFindFrom, []string{sctx, b, b, "storage.None"}, false},
 		"storage.Get":                      {interopnames.SystemStorageGet, []string{sctx, b}, false},
 		"storage.GetContext":               {interopnames.SystemStorageGetContext, nil, false},
 		"storage.GetReadOnlyContext":       {interopnames.SystemStorageGetReadOnlyContext, nil, false},
@@ -100,7 +106,10 @@ func TestSyscallExecution(t *testing.T) {
 		"crypto.CheckMultisig":             {interopnames.SystemCryptoCheckMultisig, []string{pubs, sigs}, false},
 		"crypto.CheckSig":                  {interopnames.SystemCryptoCheckSig, []string{pub, sig}, false},
 	}
-	ic := &interop.Context{}
+	ic := &interop.Context{
+		Block:     &block.Block{Header: block.Header{Index: 1}},
+		Hardforks: map[string]uint32{config.HFNeoGoExtensions.String(): 0}, // Enable all syscalls.
+	}
 	core.SpawnVM(ic) // set Functions field
 	for _, fs := range ic.Functions {
 		// It will be set in test and we want to fail if calling invalid syscall.
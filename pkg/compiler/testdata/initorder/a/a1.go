@@ -0,0 +1,12 @@
+package a
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/b"
+	"github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+)
+
+var A1 = trace.Add("a1:" + b.B)
+
+func init() {
+	trace.Add("a1.init")
+}
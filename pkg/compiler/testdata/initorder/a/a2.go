@@ -0,0 +1,9 @@
+package a
+
+import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+
+var A2 = trace.Add("a2")
+
+func init() {
+	trace.Add("a2.init")
+}
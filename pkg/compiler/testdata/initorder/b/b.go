@@ -0,0 +1,9 @@
+package b
+
+import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+
+var B = trace.Add("b")
+
+func init() {
+	trace.Add("b.init")
+}
@@ -0,0 +1,5 @@
+package c
+
+import "github.com/nspcc-dev/neo-go/pkg/compiler/testdata/initorder/trace"
+
+var C = trace.Add("c")
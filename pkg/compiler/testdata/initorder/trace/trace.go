@@ -0,0 +1,10 @@
+package trace
+
+// Log contains initialization steps separated by ';'.
+var Log string
+
+// Add appends s to the log and returns it.
+func Add(s string) string {
+	Log = Log + s + ";"
+	return s
+}
@@ -93,18 +93,20 @@ func vmAndCompile(t *testing.T, src string) *vm.VM {
 }
 
 func vmAndCompileInterop(t *testing.T, src string) (*vm.VM, *storagePlugin, []byte) {
-	vm := vm.New()
+	v := vm.New()
 
 	storePlugin := newStoragePlugin()
-	vm.GasLimit = -1
-	vm.SyscallHandler = storePlugin.syscallHandler
+	v.GasLimit = -1
+	v.SyscallHandler = storePlugin.syscallHandler
+	// Compiled code must never mutate items owned by other contexts.
+	v.EnableAudit(vm.AuditFault)
 
 	b, di, err := compiler.CompileWithOptions("foo.go", strings.NewReader(src), nil)
 	require.NoError(t, err)
 
 	storePlugin.info = di
-	invokeMethod(t, testMainIdent, b.Script, vm, di)
-	return vm, storePlugin, b.Script
+	invokeMethod(t, testMainIdent, b.Script, v, di)
+	return v, storePlugin, b.Script
 }
 
 func invokeMethod(t *testing.T, method string, script []byte, v *vm.VM, di *compiler.DebugInfo) {
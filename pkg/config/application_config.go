@@ -20,8 +20,9 @@ type ApplicationConfiguration struct {
 
 	P2P P2P `yaml:"P2P"`
 
-	Pprof      BasicService `yaml:"Pprof"`
-	Prometheus BasicService `yaml:"Prometheus"`
+	HealthCheck HealthCheck  `yaml:"HealthCheck"`
+	Pprof       BasicService `yaml:"Pprof"`
+	Prometheus  BasicService `yaml:"Prometheus"`
 
 	Relay     bool                `yaml:"Relay"`
 	Consensus Consensus           `yaml:"Consensus"`
@@ -29,10 +30,12 @@ type ApplicationConfiguration struct {
 	Oracle    OracleConfiguration `yaml:"Oracle"`
 	P2PNotary P2PNotary           `yaml:"P2PNotary"`
 	StateRoot StateRoot           `yaml:"StateRoot"`
+	Tracing   Tracing             `yaml:"Tracing"`
 }
 
 // EqualsButServices returns true when the o is the same as a except for services
-// (Oracle, P2PNotary, Pprof, Prometheus, RPC and StateRoot sections)
+// (HealthCheck, Oracle, P2PNotary, Pprof, Prometheus, RPC, StateRoot and Tracing
+// sections)
 // and LogLevel field.
 func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration) bool {
 	if len(a.P2P.Addresses) != len(o.P2P.Addresses) {
@@ -50,9 +53,15 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 		}
 	}
 	if a.P2P.AttemptConnPeers != o.P2P.AttemptConnPeers ||
+		a.P2P.BlockFetchMaxInFlight != o.P2P.BlockFetchMaxInFlight ||
+		a.P2P.BlockFetchTimeout != o.P2P.BlockFetchTimeout ||
+		a.P2P.BlockFetchWindow != o.P2P.BlockFetchWindow ||
 		a.P2P.BroadcastFactor != o.P2P.BroadcastFactor ||
 		a.DBConfiguration != o.DBConfiguration ||
 		a.P2P.DialTimeout != o.P2P.DialTimeout ||
+		a.P2P.DNSSeedRefreshInterval != o.P2P.DNSSeedRefreshInterval ||
+		!slicesEqual(a.P2P.DNSSeeds, o.P2P.DNSSeeds) ||
+		!slicesEqual(a.P2P.PinnedPeers, o.P2P.PinnedPeers) ||
 		a.P2P.ExtensiblePoolSize != o.P2P.ExtensiblePoolSize ||
 		a.LogPath != o.LogPath ||
 		a.P2P.MaxPeers != o.P2P.MaxPeers ||
@@ -60,9 +69,34 @@ func (a *ApplicationConfiguration) EqualsButServices(o *ApplicationConfiguration
 		a.P2P.PingInterval != o.P2P.PingInterval ||
 		a.P2P.PingTimeout != o.P2P.PingTimeout ||
 		a.P2P.ProtoTickInterval != o.P2P.ProtoTickInterval ||
-		a.Relay != o.Relay {
+		!a.P2P.TLS.Equals(&o.P2P.TLS) ||
+		a.Relay != o.Relay ||
+		len(a.P2P.ExtensibleCategories) != len(o.P2P.ExtensibleCategories) {
 		return false
 	}
+	for i := range a.P2P.ExtensibleCategories {
+		ac, oc := a.P2P.ExtensibleCategories[i], o.P2P.ExtensibleCategories[i]
+		if ac.Category != oc.Category || len(ac.AllowedSenders) != len(oc.AllowedSenders) {
+			return false
+		}
+		for j := range ac.AllowedSenders {
+			if ac.AllowedSenders[j] != oc.AllowedSenders[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func slicesEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
 	return true
 }
 
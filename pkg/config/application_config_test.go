@@ -19,6 +19,21 @@ func TestApplicationConfigurationEquals(t *testing.T) {
 	cfg2, err := LoadFile(filepath.Join("..", "..", "config", "protocol.testnet.yml"))
 	require.NoError(t, err)
 	require.False(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+
+	cfg2 = cfg1
+	cfg2.ApplicationConfiguration.P2P.ExtensibleCategories = []ExtensibleCategory{{Category: "app:a"}}
+	require.False(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+	cfg1.ApplicationConfiguration.P2P.ExtensibleCategories = []ExtensibleCategory{{Category: "app:a", AllowedSenders: []string{"NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB"}}}
+	require.False(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+	cfg2.ApplicationConfiguration.P2P.ExtensibleCategories = []ExtensibleCategory{{Category: "app:a", AllowedSenders: []string{"NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB"}}}
+	require.True(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+
+	cfg1.ApplicationConfiguration.P2P.TLS = P2PTLS{Enabled: true, PinnedKeys: map[string]string{"node1": "00"}}
+	require.False(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+	cfg2.ApplicationConfiguration.P2P.TLS = P2PTLS{Enabled: true, PinnedKeys: map[string]string{"node1": "01"}}
+	require.False(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
+	cfg2.ApplicationConfiguration.P2P.TLS.PinnedKeys["node1"] = "00"
+	require.True(t, cfg1.ApplicationConfiguration.EqualsButServices(&cfg2.ApplicationConfiguration))
 }
 
 func TestGetAddresses(t *testing.T) {
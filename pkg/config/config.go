@@ -35,9 +35,27 @@ const (
 	// DefaultMaxRequestBodyBytes is the default maximum allowed size of HTTP
 	// request body in bytes.
 	DefaultMaxRequestBodyBytes = 5 * 1024 * 1024
+	// DefaultCORSMaxAge is the default time in seconds CORS preflight request
+	// results can be cached for.
+	DefaultCORSMaxAge = 6 * 60 * 60
+	// DefaultCompressionThreshold is the default minimum size of HTTP response
+	// body in bytes to be compressed.
+	DefaultCompressionThreshold = 1024
+	// DefaultHealthCheckMaxBlockLag is the default maximum number of blocks
+	// the node can lag behind the known header height while being ready.
+	DefaultHealthCheckMaxBlockLag = 3
 	// DefaultMaxRequestHeaderBytes is the maximum permitted size of the headers
 	// in an HTTP request.
 	DefaultMaxRequestHeaderBytes = http.DefaultMaxHeaderBytes
+	// DefaultAdminRequestTTL is the default maximum difference between the
+	// signed admin RPC request timestamp and the node time.
+	DefaultAdminRequestTTL = time.Minute
+	// DefaultRateLimitRate is the default number of RPC request weight units
+	// refilled per second for every client when rate limiting is enabled.
+	DefaultRateLimitRate = 20
+	// DefaultRateLimitBurst is the default RPC rate limiting bucket capacity
+	// (the maximum burst of request weight units a client can spend at once).
+	DefaultRateLimitBurst = 100
 )
 
 // Version is the version of the node, set at the build time.
@@ -103,9 +121,8 @@ func LoadFile(configPath string, relativePath ...string) (Config, error) {
 		updateRelativePaths(relativePath[0], &config)
 	}
 
-	err = config.ProtocolConfiguration.Validate()
-	if err != nil {
-		return Config{}, err
+	if ps := ValidateConfig(config); HasErrors(ps) {
+		return Config{}, ProblemsError(ps)
 	}
 
 	return config, nil
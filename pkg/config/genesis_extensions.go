@@ -8,6 +8,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 )
 
+// MaxTransferBurnRate is the maximum allowed value of Genesis.TransferBurnRate
+// (100% in basis points).
+const MaxTransferBurnRate = 10000
+
 // Genesis represents a set of genesis block settings including the extensions
 // enabled in the genesis block or during native contracts initialization.
 type Genesis struct {
@@ -19,6 +23,12 @@ type Genesis struct {
 	// genesis block. It is NeoGo extension and must be disabled on the public
 	// Neo N3 networks.
 	Transaction *GenesisTransaction
+	// TransferBurnRate is the part of every GAS transfer amount (in basis
+	// points, 1/10000) that is burnt instead of being credited to the
+	// recipient. It can only be set in the genesis configuration and can't
+	// be changed at runtime. It is NeoGo extension and must be disabled on
+	// the public Neo N3 networks.
+	TransferBurnRate uint32
 }
 
 // GenesisTransaction is a placeholder for script that should be included into genesis
@@ -33,8 +43,9 @@ type GenesisTransaction struct {
 type (
 	// genesisAux is an auxiliary structure for Genesis YAML marshalling.
 	genesisAux struct {
-		Roles       map[string]keys.PublicKeys `yaml:"Roles"`
-		Transaction *genesisTransactionAux     `yaml:"Transaction"`
+		Roles            map[string]keys.PublicKeys `yaml:"Roles"`
+		Transaction      *genesisTransactionAux     `yaml:"Transaction"`
+		TransferBurnRate uint32                     `yaml:"TransferBurnRate"`
 	}
 	// genesisTransactionAux is an auxiliary structure for GenesisTransaction YAML
 	// marshalling.
@@ -47,6 +58,7 @@ type (
 // MarshalYAML implements the YAML marshaler interface.
 func (e Genesis) MarshalYAML() (any, error) {
 	var aux genesisAux
+	aux.TransferBurnRate = e.TransferBurnRate
 	aux.Roles = make(map[string]keys.PublicKeys, len(e.Roles))
 	for r, ks := range e.Roles {
 		aux.Roles[r.String()] = ks
@@ -67,6 +79,7 @@ func (e *Genesis) UnmarshalYAML(unmarshal func(any) error) error {
 		return err
 	}
 
+	e.TransferBurnRate = aux.TransferBurnRate
 	e.Roles = make(map[noderoles.Role]keys.PublicKeys)
 	for s, ks := range aux.Roles {
 		r, ok := noderoles.FromString(s)
@@ -15,6 +15,20 @@ const (
 	// https://github.com/neo-project/neo/pull/2883) and #3085 (ported from
 	// https://github.com/neo-project/neo/pull/2810).
 	HFBasilisk // Basilisk
+	// HFCockatrice represents the Cockatrice hard-fork of the reference
+	// implementation. No protocol changes are bound to it in NeoGo yet, it's
+	// only recognized to keep the configuration compatible with the C# node.
+	HFCockatrice // Cockatrice
+	// HFNeoGoExtensions represents NeoGo-specific hard-fork enabling protocol
+	// extensions that are not supported by the C# node: additional syscalls,
+	// native contract methods and events, Sponsor transaction attribute,
+	// configurable contract call and dynamic script limits, System.Contract.Call
+	// return value check, strict System.Runtime.LoadScript call flags check and
+	// caching of safe method call results within a single execution. It must
+	// never be enabled for networks shared with C# nodes, so unlike other
+	// hard-forks it's never enabled by default and needs an explicit height
+	// in the configuration.
+	HFNeoGoExtensions // NeoGoExtensions
 	// hfLast denotes the end of hardforks enum. Consider adding new hardforks
 	// before hfLast.
 	hfLast
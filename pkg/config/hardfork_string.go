@@ -10,12 +10,16 @@ func _() {
 	var x [1]struct{}
 	_ = x[HFAspidochelone-1]
 	_ = x[HFBasilisk-2]
-	_ = x[hfLast-4]
+	_ = x[HFCockatrice-4]
+	_ = x[HFNeoGoExtensions-8]
+	_ = x[hfLast-16]
 }
 
 const (
 	_Hardfork_name_0 = "AspidocheloneBasilisk"
-	_Hardfork_name_1 = "hfLast"
+	_Hardfork_name_1 = "Cockatrice"
+	_Hardfork_name_2 = "NeoGoExtensions"
+	_Hardfork_name_3 = "hfLast"
 )
 
 var (
@@ -29,6 +33,10 @@ func (i Hardfork) String() string {
 		return _Hardfork_name_0[_Hardfork_index_0[i]:_Hardfork_index_0[i+1]]
 	case i == 4:
 		return _Hardfork_name_1
+	case i == 8:
+		return _Hardfork_name_2
+	case i == 16:
+		return _Hardfork_name_3
 	default:
 		return "Hardfork(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -0,0 +1,10 @@
+package config
+
+// HealthCheck is the configuration of the node health and readiness HTTP
+// service.
+type HealthCheck struct {
+	BasicService `yaml:",inline"`
+	// MaxBlockLag is the maximum difference between the known header height
+	// and the current block height for the node to be considered ready.
+	MaxBlockLag uint32 `yaml:"MaxBlockLag"`
+}
@@ -4,6 +4,18 @@ package config
 // a part of the ProtocolConfiguration (which is common for every node on the
 // network).
 type Ledger struct {
+	// AERArchive configures external storage for application execution
+	// results of blocks removed because of RemoveUntraceableBlocks.
+	AERArchive AERArchive `yaml:"AERArchive"`
+	// BlockProfilesCount is the number of the latest block execution
+	// profiles kept in memory if TrackBlockProfiles is enabled.
+	BlockProfilesCount uint32 `yaml:"BlockProfilesCount"`
+	// ChangelogDepth is the number of the latest blocks reverse state
+	// changes are stored for, it allows to quickly roll the node back to
+	// some recent height. Changelog is disabled if it's 0.
+	ChangelogDepth uint32 `yaml:"ChangelogDepth"`
+	// ColdStorage configures moving of old blocks into a separate store.
+	ColdStorage ColdStorage `yaml:"ColdStorage"`
 	// GarbageCollectionPeriod sets the number of blocks to wait before
 	// starting the next MPT garbage collection cycle when RemoveUntraceableBlocks
 	// option is used.
@@ -12,13 +24,96 @@ type Ledger struct {
 	// If true, DB size will be smaller, but older roots won't be accessible.
 	// This value should remain the same for the same database.
 	KeepOnlyLatestState bool `yaml:"KeepOnlyLatestState"`
+	// MemPoolParking configures the memory pool parking area for
+	// transactions that are not yet valid.
+	MemPoolParking MemPoolParking `yaml:"MemPoolParking"`
 	// RemoveUntraceableBlocks specifies if old data should be removed.
 	RemoveUntraceableBlocks bool `yaml:"RemoveUntraceableBlocks"`
+	// SaveInvocationTrees enables node-local storage of contract invocation
+	// trees for all executions, they're returned along with application
+	// logs.
+	SaveInvocationTrees bool `yaml:"SaveInvocationTrees"`
 	// SaveStorageBatch enables storage batch saving before every persist.
 	SaveStorageBatch bool `yaml:"SaveStorageBatch"`
 	// SkipBlockVerification allows to disable verification of received
 	// blocks (including cryptographic checks).
 	SkipBlockVerification bool `yaml:"SkipBlockVerification"`
+	// StateRootCheck configures local state root consistency check.
+	StateRootCheck StateRootCheck `yaml:"StateRootCheck"`
+	// TrackBlockProfiles enables node-local block execution profiling.
+	TrackBlockProfiles bool `yaml:"TrackBlockProfiles"`
+	// TrackNEP17Balances enables node-local index of current NEP-17 balances
+	// maintained using Transfer events, it's used by getnep17balances RPC
+	// call. The index for an existing database is to be built with
+	// `db reindex-balances` command.
+	TrackNEP17Balances bool `yaml:"TrackNEP17Balances"`
+	// TrackNativeCallStats enables node-local native contract method
+	// invocation statistics gathering.
+	TrackNativeCallStats bool `yaml:"TrackNativeCallStats"`
+	// TrackStorageUsage enables node-local per-contract storage usage
+	// accounting. If it's enabled for an existing database, counters are
+	// rebuilt in background.
+	TrackStorageUsage bool `yaml:"TrackStorageUsage"`
+}
+
+// AERArchive contains application execution results archive settings.
+type AERArchive struct {
+	// Enabled turns the archive on, it requires RemoveUntraceableBlocks.
+	Enabled bool `yaml:"Enabled"`
+	// Backend is the archive storage type, "filesystem" is the only one
+	// supported at the moment (and it's the default).
+	Backend string `yaml:"Backend"`
+	// Path is the archive directory for the filesystem backend.
+	Path string `yaml:"Path"`
+	// Retention is the number of the latest archived blocks to keep
+	// results for, 0 means no limit.
+	Retention uint32 `yaml:"Retention"`
+}
+
+// ColdStorage contains settings of the cold storage tier. Old blocks along
+// with their transactions and execution results are moved there from the main
+// DB, but they're still available to the node.
+type ColdStorage struct {
+	// Enabled turns cold storage on, it can't be used with
+	// RemoveUntraceableBlocks.
+	Enabled bool `yaml:"Enabled"`
+	// Backend is the cold storage type, either "leveldb" (the default) or
+	// "filesystem".
+	Backend string `yaml:"Backend"`
+	// Path is the cold storage database or directory path.
+	Path string `yaml:"Path"`
+	// Depth is the number of the latest blocks kept in the main DB, older
+	// ones are moved to the cold storage. MaxTraceableBlocks is used if it's
+	// not set.
+	Depth uint32 `yaml:"Depth"`
+}
+
+// MemPoolParking contains settings of the memory pool parking area. It keeps
+// transactions that are not yet valid because of their NotValidBefore
+// attribute until they become valid or expire.
+type MemPoolParking struct {
+	// Enabled turns parking on.
+	Enabled bool `yaml:"Enabled"`
+	// Capacity is the maximum number of parked transactions.
+	Capacity int `yaml:"Capacity"`
+	// PerSender is the maximum number of parked transactions of a single
+	// sender.
+	PerSender int `yaml:"PerSender"`
+	// MaxDistance is the maximum difference between NotValidBefore value
+	// and the current height for the transaction to be parked.
+	MaxDistance uint32 `yaml:"MaxDistance"`
+}
+
+// StateRootCheck contains local state root consistency check settings. Local
+// state roots are compared to the ones from block headers (if
+// StateRootInHeader is enabled) or to the ones signed by state validators
+// (received by the state root service).
+type StateRootCheck struct {
+	// Enabled turns the check on.
+	Enabled bool `yaml:"Enabled"`
+	// HaltOnMismatch stops block processing once state divergence is
+	// detected.
+	HaltOnMismatch bool `yaml:"HaltOnMismatch"`
 }
 
 // Blockchain is a set of settings for core.Blockchain to use, it includes protocol
@@ -15,6 +15,15 @@ type OracleConfiguration struct {
 	RequestTimeout        time.Duration      `yaml:"RequestTimeout"`
 	ResponseTimeout       time.Duration      `yaml:"ResponseTimeout"`
 	UnlockWallet          Wallet             `yaml:"UnlockWallet"`
+	Cache                 OracleCache        `yaml:"Cache"`
+}
+
+// OracleCache is a config for the oracle response cache.
+type OracleCache struct {
+	Enabled     bool          `yaml:"Enabled"`
+	Size        int           `yaml:"Size"`
+	TTL         time.Duration `yaml:"TTL"`
+	CacheErrors bool          `yaml:"CacheErrors"`
 }
 
 // NeoFSConfiguration is a config for the NeoFS service.
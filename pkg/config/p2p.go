@@ -7,13 +7,112 @@ type P2P struct {
 	// Addresses stores the node address list in the form of "[host]:[port][:announcedPort]".
 	Addresses        []string `yaml:"Addresses"`
 	AttemptConnPeers int      `yaml:"AttemptConnPeers"`
+	// BlockFetchMaxInFlight is the maximum number of block ranges requested
+	// from a single peer at the same time.
+	BlockFetchMaxInFlight int `yaml:"BlockFetchMaxInFlight"`
+	// BlockFetchTimeout is the time a peer has to deliver requested blocks.
+	BlockFetchTimeout time.Duration `yaml:"BlockFetchTimeout"`
+	// BlockFetchWindow is the maximum number of blocks above the current
+	// height that are requested and buffered.
+	BlockFetchWindow int `yaml:"BlockFetchWindow"`
 	// BroadcastFactor is the factor (0-100) controlling gossip fan-out number optimization.
-	BroadcastFactor    int           `yaml:"BroadcastFactor"`
-	DialTimeout        time.Duration `yaml:"DialTimeout"`
-	ExtensiblePoolSize int           `yaml:"ExtensiblePoolSize"`
-	MaxPeers           int           `yaml:"MaxPeers"`
-	MinPeers           int           `yaml:"MinPeers"`
-	PingInterval       time.Duration `yaml:"PingInterval"`
-	PingTimeout        time.Duration `yaml:"PingTimeout"`
-	ProtoTickInterval  time.Duration `yaml:"ProtoTickInterval"`
+	BroadcastFactor int           `yaml:"BroadcastFactor"`
+	DialTimeout     time.Duration `yaml:"DialTimeout"`
+	// DNSSeeds is a list of "host:port" seeds resolved periodically, all
+	// resolved addresses are added to the address pool.
+	DNSSeeds []string `yaml:"DNSSeeds"`
+	// DNSSeedRefreshInterval is the interval between DNS seed resolutions
+	// used when resolver doesn't provide TTL.
+	DNSSeedRefreshInterval time.Duration `yaml:"DNSSeedRefreshInterval"`
+	// ExtensibleCategories is a list of application-level extensible payload
+	// categories that the node accepts and relays.
+	ExtensibleCategories []ExtensibleCategory `yaml:"ExtensibleCategories"`
+	ExtensiblePoolSize   int                  `yaml:"ExtensiblePoolSize"`
+	// ExternalAddress is the "IP:port" address advertised by the node to
+	// other peers, it disables external address election.
+	ExternalAddress string `yaml:"ExternalAddress"`
+	// ExternalAddressVotes is the minimum number of distinct peers that must
+	// report an address for it to be elected as the node's external one.
+	ExternalAddressVotes int           `yaml:"ExternalAddressVotes"`
+	MaxPeers             int           `yaml:"MaxPeers"`
+	MinPeers             int           `yaml:"MinPeers"`
+	PingInterval         time.Duration `yaml:"PingInterval"`
+	PingTimeout          time.Duration `yaml:"PingTimeout"`
+	// PinnedPeers is a list of "host:port" peers the node always keeps
+	// connection to, they're never considered bad.
+	PinnedPeers       []string      `yaml:"PinnedPeers"`
+	ProtoTickInterval time.Duration `yaml:"ProtoTickInterval"`
+	// RelayPolicy is either "full" (the default), "blocks-only" or
+	// "listen-only", see respective constants.
+	RelayPolicy string `yaml:"RelayPolicy"`
+	// TLS is the P2P connection encryption and authentication configuration,
+	// plaintext connections are used by default.
+	TLS P2PTLS `yaml:"TLS"`
+}
+
+// P2PTLS describes P2P connection TLS configuration.
+type P2PTLS struct {
+	Enabled bool `yaml:"Enabled"`
+	// CertFile and KeyFile are the node certificate and key files, the
+	// certificate is presented to other nodes for both incoming and outgoing
+	// connections.
+	CertFile string `yaml:"CertFile"`
+	KeyFile  string `yaml:"KeyFile"`
+	// CAFile is the file with CA certificates used to verify peers.
+	CAFile string `yaml:"CAFile"`
+	// Mode is either "required" (the default) or "opportunistic".
+	Mode string `yaml:"Mode"`
+	// PinnedKeys maps peer certificate common names to hex-encoded SHA256
+	// hashes of their expected public keys (SubjectPublicKeyInfo).
+	PinnedKeys map[string]string `yaml:"PinnedKeys"`
+}
+
+// P2P TLS modes.
+const (
+	// P2PTLSRequired mode refuses plaintext peers.
+	P2PTLSRequired = "required"
+	// P2PTLSOpportunistic mode uses TLS where possible and falls back to
+	// plaintext for peers not supporting it.
+	P2PTLSOpportunistic = "opportunistic"
+)
+
+// P2P relay policies.
+const (
+	// RelayFull policy makes the node take part in transaction and block
+	// gossip.
+	RelayFull = "full"
+	// RelayBlocksOnly policy makes the node accept and relay blocks only,
+	// transactions and P2P notary requests from peers are ignored.
+	RelayBlocksOnly = "blocks-only"
+	// RelayListenOnly policy is the same as RelayBlocksOnly, but the node
+	// doesn't relay anything received from peers at all, it only requests
+	// the data it needs.
+	RelayListenOnly = "listen-only"
+)
+
+// Equals checks whether two P2PTLS configurations are equal.
+func (t *P2PTLS) Equals(o *P2PTLS) bool {
+	if t.Enabled != o.Enabled || t.CertFile != o.CertFile || t.KeyFile != o.KeyFile ||
+		t.CAFile != o.CAFile || t.Mode != o.Mode || len(t.PinnedKeys) != len(o.PinnedKeys) {
+		return false
+	}
+	for k, v := range t.PinnedKeys {
+		if ov, ok := o.PinnedKeys[k]; !ok || ov != v {
+			return false
+		}
+	}
+	return true
+}
+
+// ExtensibleCategory describes an application-level extensible payload
+// category.
+type ExtensibleCategory struct {
+	// Category is the category name, it must be namespace-prefixed (like
+	// "myapp:orders").
+	Category string `yaml:"Category"`
+	// AllowedSenders is a list of addresses allowed to send payloads of this
+	// category. If empty, the standard extensible payload sender policy is
+	// used (committee members, validators, state validators and notary
+	// nodes).
+	AllowedSenders []string `yaml:"AllowedSenders"`
 }
@@ -35,6 +35,26 @@ type (
 		MaxBlockSize uint32 `yaml:"MaxBlockSize"`
 		// MaxBlockSystemFee is the maximum overall system fee per block.
 		MaxBlockSystemFee int64 `yaml:"MaxBlockSystemFee"`
+		// MaxContractCalls is the maximum number of contract calls allowed
+		// within a single script execution, zero means no limit. It can only
+		// be set for private networks and is effective since NeoGoExtensions
+		// hardfork.
+		MaxContractCalls uint32 `yaml:"MaxContractCalls"`
+		// MaxDynamicScriptSize is the maximum size of a script that can be
+		// loaded with System.Runtime.LoadScript, zero means no limit. It can
+		// only be set for private networks and is effective since
+		// NeoGoExtensions hardfork.
+		MaxDynamicScriptSize uint32 `yaml:"MaxDynamicScriptSize"`
+		// MaxDynamicScripts is the maximum number of scripts that can be
+		// loaded with System.Runtime.LoadScript within a single script
+		// execution, zero means no limit. It can only be set for private
+		// networks and is effective since NeoGoExtensions hardfork.
+		MaxDynamicScripts uint32 `yaml:"MaxDynamicScripts"`
+		// MaxInvocationStackSize is the maximum invocation stack depth allowed
+		// for contract calls, it can't exceed DefaultMaxInvocationStackSize
+		// which is used if it's not set. It can only be set for private
+		// networks and is effective since NeoGoExtensions hardfork.
+		MaxInvocationStackSize uint32 `yaml:"MaxInvocationStackSize"`
 		// MaxTraceableBlocks is the length of the chain accessible to smart contracts.
 		MaxTraceableBlocks uint32 `yaml:"MaxTraceableBlocks"`
 		// MaxTransactionsPerBlock is the maximum amount of transactions per block.
@@ -59,8 +79,10 @@ type (
 		StateSyncInterval int `yaml:"StateSyncInterval"`
 		// TimePerBlock is the time interval between blocks that consensus nodes work with.
 		// It must be an integer number of milliseconds.
-		TimePerBlock    time.Duration `yaml:"TimePerBlock"`
-		ValidatorsCount uint32        `yaml:"ValidatorsCount"`
+		TimePerBlock time.Duration `yaml:"TimePerBlock"`
+		// TimestampValidation contains block timestamp validation settings.
+		TimestampValidation TimestampValidation `yaml:"TimestampValidation"`
+		ValidatorsCount     uint32              `yaml:"ValidatorsCount"`
 		// Validators stores history of changes to consensus node number (height: number).
 		ValidatorsHistory map[uint32]uint32 `yaml:"ValidatorsHistory"`
 		// Whether to verify transactions in the received blocks.
@@ -68,6 +90,10 @@ type (
 	}
 )
 
+// DefaultMaxInvocationStackSize is the default (and the maximum allowed) value
+// of MaxInvocationStackSize, it's the same as the VM invocation stack limit.
+const DefaultMaxInvocationStackSize = 1024
+
 // heightNumber is an auxiliary structure for configuration checks.
 type heightNumber struct {
 	h uint32
@@ -88,6 +114,9 @@ func (p *ProtocolConfiguration) Validate() error {
 			return fmt.Errorf("Hardforks configuration section contains unexpected hardfork: %s", name)
 		}
 	}
+	if _, ok := p.Hardforks[HFNeoGoExtensions.String()]; ok && (p.Magic == netmode.MainNet || p.Magic == netmode.TestNet) {
+		return fmt.Errorf("%s hardfork can't be enabled on %s", HFNeoGoExtensions, p.Magic)
+	}
 	var (
 		prev             uint32
 		shouldBeDisabled bool
@@ -106,6 +135,36 @@ func (p *ProtocolConfiguration) Validate() error {
 			shouldBeDisabled = true
 		}
 	}
+	if p.Genesis.TransferBurnRate > MaxTransferBurnRate {
+		return fmt.Errorf("Genesis.TransferBurnRate must not exceed %d basis points", MaxTransferBurnRate)
+	}
+	if p.Genesis.TransferBurnRate != 0 && (p.Magic == netmode.MainNet || p.Magic == netmode.TestNet) {
+		return fmt.Errorf("Genesis.TransferBurnRate can't be enabled on %s", p.Magic)
+	}
+	if p.MaxInvocationStackSize > DefaultMaxInvocationStackSize {
+		return fmt.Errorf("MaxInvocationStackSize must not exceed %d", DefaultMaxInvocationStackSize)
+	}
+	if (p.MaxContractCalls != 0 || p.MaxInvocationStackSize != 0) && (p.Magic == netmode.MainNet || p.Magic == netmode.TestNet) {
+		return fmt.Errorf("MaxContractCalls and MaxInvocationStackSize can't be changed on %s", p.Magic)
+	}
+	if (p.MaxDynamicScriptSize != 0 || p.MaxDynamicScripts != 0) && (p.Magic == netmode.MainNet || p.Magic == netmode.TestNet) {
+		return fmt.Errorf("MaxDynamicScriptSize and MaxDynamicScripts can't be changed on %s", p.Magic)
+	}
+	switch p.TimestampValidation.Mode {
+	case "", TimestampModeStrict:
+	case TimestampModeMedian:
+		if p.Magic == netmode.MainNet || p.Magic == netmode.TestNet {
+			return fmt.Errorf("TimestampValidation.Mode can't be %q on %s", TimestampModeMedian, p.Magic)
+		}
+		if p.TimestampValidation.MaxFutureDrift <= 0 {
+			return errors.New("TimestampValidation.MaxFutureDrift must be positive in median mode")
+		}
+		if p.TimestampValidation.MaxFutureDrift%time.Millisecond != 0 {
+			return errors.New("TimestampValidation.MaxFutureDrift must be an integer number of milliseconds")
+		}
+	default:
+		return fmt.Errorf("unknown TimestampValidation.Mode: %s", p.TimestampValidation.Mode)
+	}
 	if p.ValidatorsCount != 0 && len(p.ValidatorsHistory) != 0 || p.ValidatorsCount == 0 && len(p.ValidatorsHistory) == 0 {
 		return errors.New("configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
 	}
@@ -218,6 +277,10 @@ func (p *ProtocolConfiguration) Equals(o *ProtocolConfiguration) bool {
 		p.Magic != o.Magic ||
 		p.MaxBlockSize != o.MaxBlockSize ||
 		p.MaxBlockSystemFee != o.MaxBlockSystemFee ||
+		p.MaxContractCalls != o.MaxContractCalls ||
+		p.MaxDynamicScriptSize != o.MaxDynamicScriptSize ||
+		p.MaxDynamicScripts != o.MaxDynamicScripts ||
+		p.MaxInvocationStackSize != o.MaxInvocationStackSize ||
 		p.MaxTraceableBlocks != o.MaxTraceableBlocks ||
 		p.MaxTransactionsPerBlock != o.MaxTransactionsPerBlock ||
 		p.MaxValidUntilBlockIncrement != o.MaxValidUntilBlockIncrement ||
@@ -229,6 +292,7 @@ func (p *ProtocolConfiguration) Equals(o *ProtocolConfiguration) bool {
 		p.StateRootInHeader != o.StateRootInHeader ||
 		p.StateSyncInterval != o.StateSyncInterval ||
 		p.TimePerBlock != o.TimePerBlock ||
+		p.TimestampValidation != o.TimestampValidation ||
 		p.ValidatorsCount != o.ValidatorsCount ||
 		p.VerifyTransactions != o.VerifyTransactions ||
 		len(p.CommitteeHistory) != len(o.CommitteeHistory) ||
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/stretchr/testify/require"
@@ -151,6 +152,122 @@ func TestProtocolConfigurationValidation(t *testing.T) {
 	require.Contains(t, err.Error(), "configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
 }
 
+func TestProtocolConfigurationValidation_TransferBurnRate(t *testing.T) {
+	p := &ProtocolConfiguration{
+		Magic: netmode.PrivNet,
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		},
+		ValidatorsCount: 1,
+		Genesis: Genesis{
+			TransferBurnRate: 100,
+		},
+	}
+	require.NoError(t, p.Validate())
+
+	p.Genesis.TransferBurnRate = MaxTransferBurnRate + 1
+	err := p.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Genesis.TransferBurnRate must not exceed")
+
+	p.Genesis.TransferBurnRate = 100
+	for _, m := range []netmode.Magic{netmode.MainNet, netmode.TestNet} {
+		p.Magic = m
+		err = p.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "Genesis.TransferBurnRate can't be enabled")
+	}
+
+	p.Genesis.TransferBurnRate = 0
+	require.NoError(t, p.Validate())
+}
+
+func TestProtocolConfigurationValidation_CallLimits(t *testing.T) {
+	p := &ProtocolConfiguration{
+		Magic: netmode.PrivNet,
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		},
+		ValidatorsCount:        1,
+		MaxContractCalls:       10,
+		MaxInvocationStackSize: 16,
+	}
+	require.NoError(t, p.Validate())
+
+	p.MaxInvocationStackSize = DefaultMaxInvocationStackSize + 1
+	err := p.Validate()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "MaxInvocationStackSize must not exceed")
+
+	p.MaxInvocationStackSize = 0
+	for _, m := range []netmode.Magic{netmode.MainNet, netmode.TestNet} {
+		p.Magic = m
+		err = p.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't be changed")
+	}
+
+	p.MaxContractCalls = 0
+	require.NoError(t, p.Validate())
+}
+
+func TestProtocolConfigurationValidation_DynamicScriptLimits(t *testing.T) {
+	p := &ProtocolConfiguration{
+		Magic: netmode.PrivNet,
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		},
+		ValidatorsCount:      1,
+		MaxDynamicScriptSize: 1024,
+		MaxDynamicScripts:    4,
+	}
+	require.NoError(t, p.Validate())
+
+	for _, m := range []netmode.Magic{netmode.MainNet, netmode.TestNet} {
+		p.Magic = m
+		err := p.Validate()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "MaxDynamicScriptSize and MaxDynamicScripts can't be changed")
+	}
+
+	p.MaxDynamicScriptSize = 0
+	p.MaxDynamicScripts = 0
+	require.NoError(t, p.Validate())
+}
+
+func TestProtocolConfigurationValidation_TimestampValidation(t *testing.T) {
+	p := &ProtocolConfiguration{
+		Magic: netmode.PrivNet,
+		StandbyCommittee: []string{
+			"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+		},
+		ValidatorsCount: 1,
+		TimestampValidation: TimestampValidation{
+			Mode: TimestampModeStrict,
+		},
+	}
+	require.NoError(t, p.Validate())
+
+	p.TimestampValidation.Mode = "random"
+	require.ErrorContains(t, p.Validate(), "unknown TimestampValidation.Mode")
+
+	p.TimestampValidation.Mode = TimestampModeMedian
+	require.ErrorContains(t, p.Validate(), "MaxFutureDrift must be positive")
+	p.TimestampValidation.MaxFutureDrift = time.Second + time.Microsecond
+	require.ErrorContains(t, p.Validate(), "integer number of milliseconds")
+	p.TimestampValidation.MaxFutureDrift = time.Second
+	require.NoError(t, p.Validate())
+	require.True(t, p.TimestampValidation.IsMedian())
+	require.EqualValues(t, DefaultTimestampMedianWindow, p.TimestampValidation.GetMedianWindow())
+	p.TimestampValidation.MedianWindow = 5
+	require.EqualValues(t, 5, p.TimestampValidation.GetMedianWindow())
+
+	for _, m := range []netmode.Magic{netmode.MainNet, netmode.TestNet} {
+		p.Magic = m
+		require.ErrorContains(t, p.Validate(), "TimestampValidation.Mode can't be")
+	}
+}
+
 func TestProtocolConfigurationValidation_Hardforks(t *testing.T) {
 	p := &ProtocolConfiguration{
 		Hardforks: map[string]uint32{
@@ -210,6 +327,14 @@ func TestProtocolConfigurationValidation_Hardforks(t *testing.T) {
 		ValidatorsCount: 1,
 	}
 	require.NoError(t, p.Validate())
+	p.Hardforks = map[string]uint32{
+		HFNeoGoExtensions.String(): 0,
+	}
+	require.NoError(t, p.Validate())
+	for _, m := range []netmode.Magic{netmode.MainNet, netmode.TestNet} {
+		p.Magic = m
+		require.ErrorContains(t, p.Validate(), "NeoGoExtensions hardfork can't be enabled")
+	}
 }
 
 func TestGetCommitteeAndCNs(t *testing.T) {
@@ -314,6 +439,7 @@ func TestGenesisExtensionsMarshalYAML(t *testing.T) {
 				Script:    []byte{1, 2, 3, 4},
 				SystemFee: 123,
 			},
+			TransferBurnRate: 15,
 		}
 		testserdes.MarshalUnmarshalYAML(t, g, new(Genesis))
 	})
@@ -1,14 +1,35 @@
 package config
 
 import (
+	"time"
+
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 )
 
 type (
 	// RPC is an RPC service configuration information.
 	RPC struct {
-		BasicService         `yaml:",inline"`
+		BasicService `yaml:",inline"`
+		// AdminPublicKeys is a list of hex-encoded public keys
+		// one of which must be used to sign admin method calls,
+		// admin methods don't require signed requests if it's
+		// empty.
+		AdminPublicKeys []string `yaml:"AdminPublicKeys"`
+		// AdminRequestTTL is the maximum difference between the
+		// signed admin request timestamp and the node time.
+		AdminRequestTTL time.Duration `yaml:"AdminRequestTTL"`
+		// CORSAllowedOrigins is a list of origins allowed to make
+		// cross-origin requests, "*" matches any origin and
+		// "https://*.example.com" matches any subdomain of example.com.
+		CORSAllowedOrigins   []string `yaml:"CORSAllowedOrigins"`
+		CORSMaxAge           int      `yaml:"CORSMaxAge"`
+		CompressionThreshold int      `yaml:"CompressionThreshold"`
+		// EnableAdminMethods enables methods changing node-local
+		// state (like resetnativestats), they shouldn't be available
+		// to untrusted clients.
+		EnableAdminMethods   bool `yaml:"EnableAdminMethods"`
 		EnableCORSWorkaround bool `yaml:"EnableCORSWorkaround"`
+		EnableCompression    bool `yaml:"EnableCompression"`
 		// MaxGasInvoke is the maximum amount of GAS which
 		// can be spent during an RPC call.
 		MaxGasInvoke              fixedn.Fixed8 `yaml:"MaxGasInvoke"`
@@ -19,12 +40,33 @@ type (
 		MaxRequestBodyBytes       int           `yaml:"MaxRequestBodyBytes"`
 		MaxRequestHeaderBytes     int           `yaml:"MaxRequestHeaderBytes"`
 		MaxWebSocketClients       int           `yaml:"MaxWebSocketClients"`
+		MaxWebSocketReplayBlocks  int           `yaml:"MaxWebSocketReplayBlocks"`
 		SessionEnabled            bool          `yaml:"SessionEnabled"`
 		SessionExpirationTime     int           `yaml:"SessionExpirationTime"`
+		SessionMaxLifetime        int           `yaml:"SessionMaxLifetime"`
 		SessionBackedByMPT        bool          `yaml:"SessionBackedByMPT"`
 		SessionPoolSize           int           `yaml:"SessionPoolSize"`
-		StartWhenSynchronized     bool          `yaml:"StartWhenSynchronized"`
-		TLSConfig                 TLS           `yaml:"TLSConfig"`
+		// RateLimit configures per-client request rate limiting.
+		RateLimit             RateLimit `yaml:"RateLimit"`
+		StartWhenSynchronized bool      `yaml:"StartWhenSynchronized"`
+		TLSConfig             TLS       `yaml:"TLSConfig"`
+	}
+
+	// RateLimit is the RPC request rate limiting configuration. Every client
+	// IP address has a token bucket refilled with Rate units per second up to
+	// Burst units, every request spends the number of units specified by its
+	// method weight.
+	RateLimit struct {
+		Enabled bool    `yaml:"Enabled"`
+		Rate    float64 `yaml:"Rate"`
+		Burst   int     `yaml:"Burst"`
+		// MethodWeights overrides default method weights, zero weight
+		// makes the method exempt from limiting.
+		MethodWeights map[string]int `yaml:"MethodWeights"`
+		// TrustedProxies is a list of IP addresses or CIDR networks of
+		// reverse proxies allowed to pass the real client address via
+		// X-Forwarded-For header.
+		TrustedProxies []string `yaml:"TrustedProxies"`
 	}
 
 	// TLS describes SSL/TLS configuration.
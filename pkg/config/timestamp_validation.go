@@ -0,0 +1,45 @@
+package config
+
+import "time"
+
+// Block timestamp validation modes.
+const (
+	// TimestampModeStrict requires every block timestamp to be greater than
+	// the previous block timestamp. It's the default mode.
+	TimestampModeStrict = "strict"
+	// TimestampModeMedian requires every block timestamp to be greater than
+	// the median timestamp of the last MedianWindow blocks and to be no more
+	// than MaxFutureDrift ahead of the local clock.
+	TimestampModeMedian = "median"
+)
+
+// DefaultTimestampMedianWindow is the default number of headers used to
+// calculate the median timestamp.
+const DefaultTimestampMedianWindow = 11
+
+// TimestampValidation contains block timestamp validation settings.
+type TimestampValidation struct {
+	// Mode is the validation mode, TimestampModeStrict is used if empty.
+	Mode string `yaml:"Mode"`
+	// MedianWindow is the number of the last headers used to calculate the
+	// median timestamp in TimestampModeMedian, DefaultTimestampMedianWindow
+	// is used if zero.
+	MedianWindow uint32 `yaml:"MedianWindow"`
+	// MaxFutureDrift is the maximum difference between the block timestamp
+	// and the local clock in TimestampModeMedian.
+	MaxFutureDrift time.Duration `yaml:"MaxFutureDrift"`
+}
+
+// IsMedian returns true if TimestampModeMedian is used.
+func (t TimestampValidation) IsMedian() bool {
+	return t.Mode == TimestampModeMedian
+}
+
+// GetMedianWindow returns the number of headers used to calculate the
+// median timestamp.
+func (t TimestampValidation) GetMedianWindow() uint32 {
+	if t.MedianWindow == 0 {
+		return DefaultTimestampMedianWindow
+	}
+	return t.MedianWindow
+}
@@ -0,0 +1,13 @@
+package config
+
+// Tracing is the configuration of node-local block processing tracing.
+type Tracing struct {
+	Enabled bool `yaml:"Enabled"`
+	// Exporter is the name of exporter the completed traces are passed to.
+	// "log" (the default) is the only one supported now, it writes spans to
+	// the node log at debug level.
+	Exporter string `yaml:"Exporter"`
+	// SampleRatio is the ratio of script runs to trace (from 0 to 1), all of
+	// them are traced if it's not set.
+	SampleRatio float64 `yaml:"SampleRatio"`
+}
@@ -0,0 +1,421 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// Severity is the severity of configuration Problem.
+type Severity byte
+
+const (
+	// SeverityError is used for configuration problems that make node
+	// behaviour incorrect, such configuration can't be used.
+	SeverityError Severity = iota
+	// SeverityWarning is used for suspicious configuration settings that
+	// don't prevent node from working, but most likely are not intended.
+	SeverityWarning
+)
+
+// Values used by the blockchain for unset protocol settings.
+const (
+	defaultTimePerBlock       = 15 * time.Second
+	defaultMaxTraceableBlocks = 2102400
+)
+
+// Limits of the transaction validity period (MaxValidUntilBlockIncrement
+// blocks) not producing warnings.
+const (
+	minValidityPeriod = time.Minute
+	maxValidityPeriod = 7 * 24 * time.Hour
+)
+
+// Problem is a single configuration problem found by Validate.
+type Problem struct {
+	Severity Severity
+	// Field is the name of configuration setting (or a set of settings)
+	// the problem is related to.
+	Field string
+	// Message describes the problem.
+	Message string
+}
+
+// String implements fmt.Stringer interface.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+// String implements fmt.Stringer interface.
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s: %s", p.Severity, p.Field, p.Message)
+}
+
+// HasErrors returns true if there is at least one SeverityError problem in
+// the list.
+func HasErrors(ps []Problem) bool {
+	for _, p := range ps {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// ProblemsError returns an error listing all the given problems (including
+// warnings) or nil if there are no problems.
+func ProblemsError(ps []Problem) error {
+	if len(ps) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	sb.WriteString("invalid configuration:")
+	for _, p := range ps {
+		sb.WriteString("\n\t")
+		sb.WriteString(p.String())
+	}
+	return errors.New(sb.String())
+}
+
+// problems is an auxiliary problem collector.
+type problems []Problem
+
+func (ps *problems) errorf(field string, format string, args ...any) {
+	*ps = append(*ps, Problem{Severity: SeverityError, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+func (ps *problems) warnf(field string, format string, args ...any) {
+	*ps = append(*ps, Problem{Severity: SeverityWarning, Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate performs deep checks of the protocol configuration and returns all
+// problems found (nil if there are none). Contrary to
+// ProtocolConfiguration.Validate it doesn't stop on the first error and
+// additionally checks hardfork ordering, committee/validators count
+// consistency, standby committee keys and seed list validity, transaction
+// validity period sanity, P2PSigExtensions/P2PStateExchangeExtensions
+// dependent settings and settings that depend on hardfork activation. Any
+// configuration without SeverityError problems passes
+// ProtocolConfiguration.Validate.
+func Validate(p ProtocolConfiguration) []Problem {
+	var ps problems
+
+	validateHardforks(&ps, &p)
+	validateCommittee(&ps, &p)
+	validateSeedList(&ps, &p)
+	validateTiming(&ps, &p)
+	validateExtensions(&ps, &p)
+	validateNativeDependencies(&ps, &p)
+
+	if p.Genesis.TransferBurnRate > MaxTransferBurnRate {
+		ps.errorf("Genesis.TransferBurnRate", "must not exceed %d basis points", MaxTransferBurnRate)
+	}
+	if p.Genesis.TransferBurnRate != 0 && isPublicNet(p.Magic) {
+		ps.errorf("Genesis.TransferBurnRate", "can't be enabled on %s", p.Magic)
+	}
+	if p.MaxInvocationStackSize > DefaultMaxInvocationStackSize {
+		ps.errorf("MaxInvocationStackSize", "must not exceed %d", DefaultMaxInvocationStackSize)
+	}
+	if (p.MaxContractCalls != 0 || p.MaxInvocationStackSize != 0) && isPublicNet(p.Magic) {
+		ps.errorf("MaxContractCalls", "MaxContractCalls and MaxInvocationStackSize can't be changed on %s", p.Magic)
+	}
+	if (p.MaxDynamicScriptSize != 0 || p.MaxDynamicScripts != 0) && isPublicNet(p.Magic) {
+		ps.errorf("MaxDynamicScriptSize", "MaxDynamicScriptSize and MaxDynamicScripts can't be changed on %s", p.Magic)
+	}
+	if len(ps) == 0 {
+		return nil
+	}
+	return ps
+}
+
+// ValidateConfig performs deep checks of the node configuration. It returns
+// all problems found by Validate for the protocol configuration along with
+// the problems of application settings dependent on the protocol ones.
+func ValidateConfig(c Config) []Problem {
+	ps := problems(Validate(c.ProtocolConfiguration))
+	if c.ApplicationConfiguration.P2PNotary.Enabled && !c.ProtocolConfiguration.P2PSigExtensions {
+		ps.errorf("P2PNotary", "Notary service is enabled, but P2PSigExtensions are disabled")
+	}
+	validateRPC(&ps, &c.ApplicationConfiguration.RPC)
+	validateTracing(&ps, &c.ApplicationConfiguration.Tracing)
+	if len(ps) == 0 {
+		return nil
+	}
+	return ps
+}
+
+func validateRPC(ps *problems, r *RPC) {
+	for i, k := range r.AdminPublicKeys {
+		if _, err := keys.NewPublicKeyFromString(k); err != nil {
+			ps.errorf("RPC.AdminPublicKeys", "invalid key #%d: %s", i, err)
+		}
+	}
+	if len(r.AdminPublicKeys) != 0 && !r.EnableAdminMethods {
+		ps.warnf("RPC.AdminPublicKeys", "admin keys are set, but admin methods are disabled")
+	}
+	if r.AdminRequestTTL < 0 {
+		ps.errorf("RPC.AdminRequestTTL", "must not be negative")
+	}
+}
+
+func validateTracing(ps *problems, t *Tracing) {
+	if t.Exporter != "" && t.Exporter != "log" {
+		ps.errorf("Tracing.Exporter", "unknown exporter %s", t.Exporter)
+	}
+	if t.SampleRatio < 0 || t.SampleRatio > 1 {
+		ps.errorf("Tracing.SampleRatio", "must be from 0 to 1")
+	}
+}
+
+func isPublicNet(m netmode.Magic) bool {
+	return m == netmode.MainNet || m == netmode.TestNet
+}
+
+func validateHardforks(ps *problems, p *ProtocolConfiguration) {
+	names := make([]string, 0, len(p.Hardforks))
+	for name := range p.Hardforks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !IsHardforkValid(name) {
+			ps.errorf("Hardforks", "unknown hardfork %s", name)
+		}
+	}
+	if _, ok := p.Hardforks[HFNeoGoExtensions.String()]; ok && isPublicNet(p.Magic) {
+		ps.errorf("Hardforks", "%s can't be enabled on %s", HFNeoGoExtensions, p.Magic)
+	}
+	var (
+		prev     uint32
+		prevName string
+		missing  string
+	)
+	for _, hf := range Hardforks {
+		h := p.Hardforks[hf.String()]
+		if h == 0 {
+			if prev != 0 && missing == "" {
+				missing = hf.String()
+			}
+			continue
+		}
+		if missing != "" {
+			ps.errorf("Hardforks", "%s is enabled at %d, but previous %s is not", hf, h, missing)
+		}
+		if h < prev {
+			ps.errorf("Hardforks", "%s is enabled at %d which is lower than %d of the previous %s", hf, h, prev, prevName)
+		} else {
+			prev, prevName = h, hf.String()
+		}
+	}
+}
+
+func validateCommittee(ps *problems, p *ProtocolConfiguration) {
+	if len(p.StandbyCommittee) == 0 {
+		ps.errorf("StandbyCommittee", "configuration should include StandbyCommittee")
+	}
+	seen := make(map[string]int, len(p.StandbyCommittee))
+	for i, s := range p.StandbyCommittee {
+		pub, err := keys.NewPublicKeyFromString(s)
+		if err != nil {
+			ps.errorf("StandbyCommittee", "invalid key #%d: %s", i, err)
+			continue
+		}
+		k := string(pub.Bytes())
+		if j, ok := seen[k]; ok {
+			ps.errorf("StandbyCommittee", "key #%d is the same as #%d", i, j)
+			continue
+		}
+		seen[k] = i
+	}
+
+	if p.ValidatorsCount != 0 && len(p.ValidatorsHistory) != 0 || p.ValidatorsCount == 0 && len(p.ValidatorsHistory) == 0 {
+		ps.errorf("ValidatorsCount", "configuration should either have one of ValidatorsCount or ValidatorsHistory, not both")
+	}
+	if int(p.ValidatorsCount) > len(p.StandbyCommittee) {
+		ps.errorf("ValidatorsCount", "validators count (%d) can't exceed the size of StandbyCommittee (%d)", p.ValidatorsCount, len(p.StandbyCommittee))
+	}
+
+	committee := validateHistory(ps, "CommitteeHistory", p.CommitteeHistory, len(p.StandbyCommittee))
+	for i := 1; i < len(committee); i++ {
+		hn, prevN := committee[i], committee[i-1].n
+		if hn.h%hn.n != 0 || hn.h%prevN != 0 {
+			ps.errorf("CommitteeHistory", "bad %d height for %d and %d committee", hn.h, hn.n, prevN)
+		}
+	}
+	validators := validateHistory(ps, "ValidatorsHistory", p.ValidatorsHistory, len(p.StandbyCommittee))
+	committeeSize := func(h uint32) uint32 {
+		var n = uint32(len(p.StandbyCommittee))
+		for _, hn := range committee {
+			if hn.h <= h {
+				n = hn.n
+			}
+		}
+		return n
+	}
+	for _, hn := range validators {
+		cs := committeeSize(hn.h)
+		if hn.n > cs {
+			ps.errorf("ValidatorsHistory", "requested number of validators is too big: %d at %d (committee size is %d)", hn.n, hn.h, cs)
+		} else if cs != 0 && hn.h%cs != 0 {
+			ps.errorf("ValidatorsHistory", "validators number change is not aligned with committee change at %d", hn.h)
+		}
+	}
+}
+
+// validateHistory checks the height-number history map and returns its
+// correct elements sorted by height.
+func validateHistory(ps *problems, field string, history map[uint32]uint32, committeeSize int) []heightNumber {
+	arr := make([]heightNumber, 0, len(history))
+	for h, n := range history {
+		arr = append(arr, heightNumber{h, n})
+	}
+	sort.Slice(arr, func(i, j int) bool {
+		return arr[i].h < arr[j].h
+	})
+	if len(arr) != 0 && arr[0].h != 0 {
+		ps.errorf(field, "no height 0 specified")
+	}
+	var res = arr[:0]
+	for _, hn := range arr {
+		if hn.n == 0 {
+			ps.errorf(field, "bad members count (%d) for height %d", hn.n, hn.h)
+			continue
+		}
+		if int(hn.n) > committeeSize {
+			ps.errorf(field, "too small StandbyCommittee for %d members at %d", hn.n, hn.h)
+			continue
+		}
+		res = append(res, hn)
+	}
+	return res
+}
+
+func validateSeedList(ps *problems, p *ProtocolConfiguration) {
+	seen := make(map[string]bool, len(p.SeedList))
+	for _, s := range p.SeedList {
+		host, port, err := net.SplitHostPort(s)
+		if err != nil {
+			ps.errorf("SeedList", "invalid address %q: %s", s, err)
+			continue
+		}
+		if host == "" {
+			ps.errorf("SeedList", "invalid address %q: no host", s)
+			continue
+		}
+		if n, err := strconv.ParseUint(port, 10, 16); err != nil || n == 0 {
+			ps.errorf("SeedList", "invalid address %q: bad port", s)
+			continue
+		}
+		if seen[strings.ToLower(s)] {
+			ps.warnf("SeedList", "duplicate address %q", s)
+		}
+		seen[strings.ToLower(s)] = true
+	}
+}
+
+func validateTiming(ps *problems, p *ProtocolConfiguration) {
+	if p.TimePerBlock < 0 {
+		ps.errorf("TimePerBlock", "must not be negative")
+		return
+	}
+	if p.TimePerBlock%time.Millisecond != 0 {
+		ps.errorf("TimePerBlock", "TimePerBlock must be an integer number of milliseconds")
+		return
+	}
+	var (
+		tpb       = p.TimePerBlock
+		mtb       = p.MaxTraceableBlocks
+		increment = p.MaxValidUntilBlockIncrement
+	)
+	if tpb == 0 {
+		tpb = defaultTimePerBlock
+	}
+	if mtb == 0 {
+		mtb = defaultMaxTraceableBlocks
+	}
+	if increment == 0 {
+		return // Default value is one day of blocks, which is OK.
+	}
+	if increment >= mtb {
+		ps.warnf("MaxValidUntilBlockIncrement", "%d is not lower than MaxTraceableBlocks (%d)", increment, mtb)
+	}
+	period := time.Duration(increment) * tpb
+	if period < minValidityPeriod {
+		ps.warnf("MaxValidUntilBlockIncrement", "transaction validity period of %d blocks (%s) is too short for %s blocks", increment, period, tpb)
+	} else if period > maxValidityPeriod {
+		ps.warnf("MaxValidUntilBlockIncrement", "transaction validity period of %d blocks (%s) is too long for %s blocks", increment, period, tpb)
+	}
+}
+
+func validateExtensions(ps *problems, p *ProtocolConfiguration) {
+	if !p.P2PSigExtensions {
+		if p.P2PNotaryRequestPayloadPoolSize != 0 {
+			ps.warnf("P2PNotaryRequestPayloadPoolSize", "is ignored with P2PSigExtensions disabled")
+		}
+		if len(p.Genesis.Roles[noderoles.P2PNotary]) != 0 {
+			ps.errorf("Genesis.Roles", "%s role can't be designated with P2PSigExtensions disabled", noderoles.P2PNotary)
+		}
+	} else if p.P2PNotaryRequestPayloadPoolSize < 0 {
+		ps.errorf("P2PNotaryRequestPayloadPoolSize", "must not be negative")
+	}
+	if p.P2PStateExchangeExtensions {
+		if !p.StateRootInHeader {
+			ps.errorf("P2PStateExchangeExtensions", "P2PStateExchangeExtensions are enabled, but StateRootInHeader is off")
+		}
+	} else if p.StateSyncInterval != 0 {
+		ps.warnf("StateSyncInterval", "is ignored with P2PStateExchangeExtensions disabled")
+	}
+}
+
+// validateNativeDependencies checks settings that are only effective after
+// some hardfork (and native contract functionality it enables) activation.
+func validateNativeDependencies(ps *problems, p *ProtocolConfiguration) {
+	// All hardforks are enabled from genesis if the section is missing.
+	enabled := func(hf Hardfork) (uint32, bool) {
+		if p.Hardforks == nil {
+			return 0, hf != HFNeoGoExtensions
+		}
+		h, ok := p.Hardforks[hf.String()]
+		return h, ok
+	}
+	if p.MaxContractCalls != 0 || p.MaxInvocationStackSize != 0 {
+		if _, ok := enabled(HFNeoGoExtensions); !ok {
+			ps.warnf("MaxContractCalls", "MaxContractCalls and MaxInvocationStackSize have no effect with %s hardfork disabled", HFNeoGoExtensions)
+		}
+	}
+	if p.MaxDynamicScriptSize != 0 || p.MaxDynamicScripts != 0 {
+		if _, ok := enabled(HFNeoGoExtensions); !ok {
+			ps.warnf("MaxDynamicScriptSize", "MaxDynamicScriptSize and MaxDynamicScripts have no effect with %s hardfork disabled", HFNeoGoExtensions)
+		}
+	}
+	if p.Genesis.Transaction != nil {
+		var late []string
+		for _, hf := range Hardforks {
+			h, ok := enabled(hf)
+			if !ok && hf == HFNeoGoExtensions {
+				continue // Not enabled by default, so it's not late.
+			}
+			if !ok || h != 0 {
+				late = append(late, hf.String())
+			}
+		}
+		if len(late) != 0 {
+			ps.warnf("Genesis.Transaction", "genesis transaction can't use native functionality of hardforks not enabled at genesis: %s", strings.Join(late, ", "))
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+var validateTestKeys = []string{
+	"02b3622bf4017bdfe317c58aed5f4c753f206b7db896046fa7d774bbc4bf7f8dc2",
+	"02103a7f7dd016558597f7960d27c516a4394fd968b9e65155eb4b013e4040406e",
+	"03d90c07df63e690ce77912e10ab51acc944b66860237b608c4f8f8309e71ee699",
+	"02a7bc55fe8684e0119768d104ba30795bdcc86619e864add26156723ed185cd62",
+}
+
+func newValidateTestConfig() ProtocolConfiguration {
+	return ProtocolConfiguration{
+		Magic:            netmode.UnitTestNet,
+		StandbyCommittee: append([]string{}, validateTestKeys...),
+		ValidatorsCount:  4,
+		SeedList:         []string{"localhost:20333", "127.0.0.1:20334"},
+		TimePerBlock:     time.Second,
+		Hardforks: map[string]uint32{
+			HFAspidochelone.String():   0,
+			HFBasilisk.String():        10,
+			HFCockatrice.String():      20,
+			HFNeoGoExtensions.String(): 20,
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	require.Nil(t, Validate(newValidateTestConfig()))
+
+	pub, err := keys.NewPublicKeyFromString(validateTestKeys[0])
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		modify   func(p *ProtocolConfiguration)
+		expected []Problem
+	}{
+		{"unknown hardfork", func(p *ProtocolConfiguration) {
+			p.Hardforks["Unknown"] = 5
+		}, []Problem{{SeverityError, "Hardforks", "unknown hardfork Unknown"}}},
+		{"hardfork order", func(p *ProtocolConfiguration) {
+			p.Hardforks[HFBasilisk.String()] = 30
+		}, []Problem{
+			{SeverityError, "Hardforks", "Cockatrice is enabled at 20 which is lower than 30 of the previous Basilisk"},
+			{SeverityError, "Hardforks", "NeoGoExtensions is enabled at 20 which is lower than 30 of the previous Basilisk"},
+		}},
+		{"hardfork gap", func(p *ProtocolConfiguration) {
+			p.Hardforks[HFAspidochelone.String()] = 5
+			delete(p.Hardforks, HFBasilisk.String())
+		}, []Problem{
+			{SeverityError, "Hardforks", "Cockatrice is enabled at 20, but previous Basilisk is not"},
+			{SeverityError, "Hardforks", "NeoGoExtensions is enabled at 20, but previous Basilisk is not"},
+		}},
+		{"no committee", func(p *ProtocolConfiguration) {
+			p.StandbyCommittee = nil
+		}, []Problem{
+			{SeverityError, "StandbyCommittee", "configuration should include StandbyCommittee"},
+			{SeverityError, "ValidatorsCount", "validators count (4) can't exceed the size of StandbyCommittee (0)"},
+		}},
+		{"bad and duplicate keys", func(p *ProtocolConfiguration) {
+			p.StandbyCommittee[1] = "bad"
+			p.StandbyCommittee[3] = strings.ToUpper(validateTestKeys[0])
+			p.ValidatorsCount = 2
+		}, []Problem{
+			{SeverityError, "StandbyCommittee", "invalid key #1: encoding/hex: odd length hex string"},
+			{SeverityError, "StandbyCommittee", "key #3 is the same as #0"},
+		}},
+		{"validators count and history", func(p *ProtocolConfiguration) {
+			p.ValidatorsHistory = map[uint32]uint32{0: 4}
+		}, []Problem{{SeverityError, "ValidatorsCount", "configuration should either have one of ValidatorsCount or ValidatorsHistory, not both"}}},
+		{"committee history", func(p *ProtocolConfiguration) {
+			p.CommitteeHistory = map[uint32]uint32{1: 1, 4: 0, 999: 4}
+			p.ValidatorsCount = 0
+			p.ValidatorsHistory = map[uint32]uint32{0: 1, 1000: 5}
+		}, []Problem{
+			{SeverityError, "CommitteeHistory", "no height 0 specified"},
+			{SeverityError, "CommitteeHistory", "bad members count (0) for height 4"},
+			{SeverityError, "CommitteeHistory", "bad 999 height for 4 and 1 committee"},
+			{SeverityError, "ValidatorsHistory", "too small StandbyCommittee for 5 members at 1000"},
+		}},
+		{"validators history", func(p *ProtocolConfiguration) {
+			p.CommitteeHistory = map[uint32]uint32{0: 1, 100: 4}
+			p.ValidatorsCount = 0
+			p.ValidatorsHistory = map[uint32]uint32{0: 4, 102: 2}
+		}, []Problem{
+			{SeverityError, "ValidatorsHistory", "requested number of validators is too big: 4 at 0 (committee size is 1)"},
+			{SeverityError, "ValidatorsHistory", "validators number change is not aligned with committee change at 102"},
+		}},
+		{"seed list", func(p *ProtocolConfiguration) {
+			p.SeedList = []string{"localhost", ":20333", "localhost:port", "LocalHost:20333", "localhost:20333"}
+		}, []Problem{
+			{SeverityError, "SeedList", `invalid address "localhost": address localhost: missing port in address`},
+			{SeverityError, "SeedList", `invalid address ":20333": no host`},
+			{SeverityError, "SeedList", `invalid address "localhost:port": bad port`},
+			{SeverityWarning, "SeedList", `duplicate address "localhost:20333"`},
+		}},
+		{"time per block", func(p *ProtocolConfiguration) {
+			p.TimePerBlock = time.Microsecond
+		}, []Problem{{SeverityError, "TimePerBlock", "TimePerBlock must be an integer number of milliseconds"}}},
+		{"short validity period", func(p *ProtocolConfiguration) {
+			p.MaxValidUntilBlockIncrement = 10
+		}, []Problem{{SeverityWarning, "MaxValidUntilBlockIncrement", "transaction validity period of 10 blocks (10s) is too short for 1s blocks"}}},
+		{"long validity period", func(p *ProtocolConfiguration) {
+			p.TimePerBlock = 0
+			p.MaxTraceableBlocks = 100000
+			p.MaxValidUntilBlockIncrement = 100000
+		}, []Problem{
+			{SeverityWarning, "MaxValidUntilBlockIncrement", "100000 is not lower than MaxTraceableBlocks (100000)"},
+			{SeverityWarning, "MaxValidUntilBlockIncrement", "transaction validity period of 100000 blocks (416h40m0s) is too long for 15s blocks"},
+		}},
+		{"notary without P2PSigExtensions", func(p *ProtocolConfiguration) {
+			p.P2PNotaryRequestPayloadPoolSize = 100
+			p.Genesis.Roles = map[noderoles.Role]keys.PublicKeys{noderoles.P2PNotary: {pub}}
+		}, []Problem{
+			{SeverityWarning, "P2PNotaryRequestPayloadPoolSize", "is ignored with P2PSigExtensions disabled"},
+			{SeverityError, "Genesis.Roles", "P2PNotary role can't be designated with P2PSigExtensions disabled"},
+		}},
+		{"state exchange", func(p *ProtocolConfiguration) {
+			p.P2PStateExchangeExtensions = true
+		}, []Problem{{SeverityError, "P2PStateExchangeExtensions", "P2PStateExchangeExtensions are enabled, but StateRootInHeader is off"}}},
+		{"state sync interval", func(p *ProtocolConfiguration) {
+			p.StateSyncInterval = 100
+		}, []Problem{{SeverityWarning, "StateSyncInterval", "is ignored with P2PStateExchangeExtensions disabled"}}},
+		{"call limits before NeoGoExtensions", func(p *ProtocolConfiguration) {
+			delete(p.Hardforks, HFNeoGoExtensions.String())
+			p.MaxContractCalls = 10
+		}, []Problem{{SeverityWarning, "MaxContractCalls", "MaxContractCalls and MaxInvocationStackSize have no effect with NeoGoExtensions hardfork disabled"}}},
+		{"dynamic script limits before NeoGoExtensions", func(p *ProtocolConfiguration) {
+			delete(p.Hardforks, HFNeoGoExtensions.String())
+			p.MaxDynamicScripts = 10
+		}, []Problem{{SeverityWarning, "MaxDynamicScriptSize", "MaxDynamicScriptSize and MaxDynamicScripts have no effect with NeoGoExtensions hardfork disabled"}}},
+		{"call limits with default hardforks", func(p *ProtocolConfiguration) {
+			p.Hardforks = nil
+			p.MaxContractCalls = 10
+			p.Genesis.Transaction = &GenesisTransaction{Script: []byte{1}}
+		}, []Problem{{SeverityWarning, "MaxContractCalls", "MaxContractCalls and MaxInvocationStackSize have no effect with NeoGoExtensions hardfork disabled"}}},
+		{"genesis transaction before hardforks", func(p *ProtocolConfiguration) {
+			p.Genesis.Transaction = &GenesisTransaction{Script: []byte{1}}
+		}, []Problem{{SeverityWarning, "Genesis.Transaction", "genesis transaction can't use native functionality of hardforks not enabled at genesis: Basilisk, Cockatrice, NeoGoExtensions"}}},
+		{"public network limits", func(p *ProtocolConfiguration) {
+			p.Magic = netmode.MainNet
+			p.Genesis.TransferBurnRate = MaxTransferBurnRate + 1
+			p.MaxInvocationStackSize = DefaultMaxInvocationStackSize + 1
+			p.MaxDynamicScriptSize = 1024
+		}, []Problem{
+			{SeverityError, "Hardforks", "NeoGoExtensions can't be enabled on mainnet"},
+			{SeverityError, "Genesis.TransferBurnRate", "must not exceed 10000 basis points"},
+			{SeverityError, "Genesis.TransferBurnRate", "can't be enabled on mainnet"},
+			{SeverityError, "MaxInvocationStackSize", "must not exceed 1024"},
+			{SeverityError, "MaxContractCalls", "MaxContractCalls and MaxInvocationStackSize can't be changed on mainnet"},
+			{SeverityError, "MaxDynamicScriptSize", "MaxDynamicScriptSize and MaxDynamicScripts can't be changed on mainnet"},
+		}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := newValidateTestConfig()
+			tc.modify(&p)
+			ps := Validate(p)
+			require.Equal(t, tc.expected, ps)
+			// Deep validation is stricter than the basic one.
+			if p.Validate() != nil {
+				require.True(t, HasErrors(ps))
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	c := Config{ProtocolConfiguration: newValidateTestConfig()}
+	require.Nil(t, ValidateConfig(c))
+
+	c.ApplicationConfiguration.P2PNotary.Enabled = true
+	require.Equal(t, []Problem{{SeverityError, "P2PNotary", "Notary service is enabled, but P2PSigExtensions are disabled"}}, ValidateConfig(c))
+
+	c.ProtocolConfiguration.P2PSigExtensions = true
+	require.Nil(t, ValidateConfig(c))
+
+	c.ApplicationConfiguration.RPC.AdminPublicKeys = []string{validateTestKeys[0], "bad"}
+	c.ApplicationConfiguration.RPC.AdminRequestTTL = -time.Second
+	require.Equal(t, []Problem{
+		{SeverityError, "RPC.AdminPublicKeys", "invalid key #1: encoding/hex: odd length hex string"},
+		{SeverityWarning, "RPC.AdminPublicKeys", "admin keys are set, but admin methods are disabled"},
+		{SeverityError, "RPC.AdminRequestTTL", "must not be negative"},
+	}, ValidateConfig(c))
+
+	c.ApplicationConfiguration.RPC.AdminPublicKeys = validateTestKeys[:1]
+	c.ApplicationConfiguration.RPC.AdminRequestTTL = time.Second
+	c.ApplicationConfiguration.RPC.EnableAdminMethods = true
+	require.Nil(t, ValidateConfig(c))
+
+	c.ApplicationConfiguration.Tracing = Tracing{Enabled: true, Exporter: "otlp", SampleRatio: 2}
+	require.Equal(t, []Problem{
+		{SeverityError, "Tracing.Exporter", "unknown exporter otlp"},
+		{SeverityError, "Tracing.SampleRatio", "must be from 0 to 1"},
+	}, ValidateConfig(c))
+
+	c.ApplicationConfiguration.Tracing = Tracing{Enabled: true, Exporter: "log", SampleRatio: 0.5}
+	require.Nil(t, ValidateConfig(c))
+}
+
+func TestProblemsError(t *testing.T) {
+	require.NoError(t, ProblemsError(nil))
+	err := ProblemsError([]Problem{
+		{SeverityError, "A", "bad"},
+		{SeverityWarning, "B", "suspicious"},
+	})
+	require.EqualError(t, err, "invalid configuration:\n\terror: A: bad\n\twarning: B: suspicious")
+	require.False(t, HasErrors([]Problem{{SeverityWarning, "B", "suspicious"}}))
+}
+
+func TestValidateRepoConfigs(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "config", "protocol.*.yml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files)
+	for _, f := range files {
+		t.Run(filepath.Base(f), func(t *testing.T) {
+			c, err := LoadFile(f)
+			require.NoError(t, err)
+			require.False(t, HasErrors(ValidateConfig(c)))
+		})
+	}
+}
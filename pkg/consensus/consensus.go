@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"sort"
@@ -18,7 +19,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -42,7 +42,10 @@ const nsInMs = 1000000
 type Ledger interface {
 	ApplyPolicyToTxSet([]*transaction.Transaction) []*transaction.Transaction
 	GetConfig() config.Blockchain
+	GetHeader(hash util.Uint256) (*coreb.Header, error)
 	GetMemPool() *mempool.Pool
+	GetMillisecondsPerBlock() uint32
+	GetMinNextBlockTimestamp(prev *coreb.Header) (uint64, error)
 	GetNextBlockValidators() ([]*keys.PublicKey, error)
 	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
@@ -97,6 +100,12 @@ type service struct {
 	blockEvents  chan *coreb.Block
 	lastProposal []util.Uint256
 	wallet       *wallet.Wallet
+	// accounts contains all unlocked wallet accounts that can be used for
+	// consensus, they're indexed by the script hash of their public key.
+	accounts map[util.Uint160]*wallet.Account
+	// currKey is the public key used by the node in the current dBFT round,
+	// it's nil if the node is not a validator.
+	currKey *keys.PublicKey
 	// started is a flag set with Start method that runs an event handling
 	// goroutine.
 	started  atomic.Bool
@@ -128,11 +137,28 @@ type Config struct {
 	// StopTxFlow is a callback that is called after the consensus
 	// process stops accepting incoming transactions.
 	StopTxFlow func()
-	// TimePerBlock is minimal time that should pass before the next block is accepted.
+	// TimePerBlock is minimal time that should pass before the next block is
+	// accepted. It's only used until the service is started, the value
+	// returned by the chain is used since then (it can be changed by the
+	// committee).
 	TimePerBlock time.Duration
 	// Wallet is a local-node wallet configuration. If the path is empty, then
 	// no wallet will be initialized and the service will be in watch-only mode.
 	Wallet config.Wallet
+	// TimeSource is a clock used for block timestamps and their validation,
+	// time.Now is used if not set.
+	TimeSource func() time.Time
+}
+
+// clockTimer is a dBFT timer using the configured time source.
+type clockTimer struct {
+	*timer.Timer
+	now func() time.Time
+}
+
+// Now implements the dbft.Timer interface.
+func (t *clockTimer) Now() time.Time {
+	return t.now()
 }
 
 // NewService returns a new consensus.Service instance.
@@ -144,6 +170,9 @@ func NewService(cfg Config) (Service, error) {
 	if cfg.Logger == nil {
 		return nil, errors.New("empty logger")
 	}
+	if cfg.TimeSource == nil {
+		cfg.TimeSource = time.Now
+	}
 
 	srv := &service{
 		Config: cfg,
@@ -165,22 +194,30 @@ func NewService(cfg Config) (Service, error) {
 			return nil, err
 		}
 
-		// Check that the wallet password is correct for at least one account.
-		var ok bool
+		// Unlock all accounts the password is correct for, any of them can
+		// be used for consensus if its key is in the validators list.
+		srv.accounts = make(map[util.Uint160]*wallet.Account)
 		for _, acc := range srv.wallet.Accounts {
 			err := acc.Decrypt(srv.Config.Wallet.Password, srv.wallet.Scrypt)
-			if err == nil {
-				ok = true
-				break
+			if err != nil {
+				srv.log.Debug("can't unlock account, skipping it",
+					zap.String("address", acc.Address),
+					zap.Error(err))
+				continue
+			}
+			h := acc.PublicKey().GetScriptHash()
+			if _, ok := srv.accounts[h]; !ok {
+				srv.accounts[h] = acc
 			}
 		}
-		if !ok {
+		if len(srv.accounts) == 0 {
 			return nil, errors.New("no account with provided password was found")
 		}
+		srv.log.Info("consensus accounts unlocked", zap.Int("count", len(srv.accounts)))
 	}
 
 	srv.dbft, err = dbft.New[util.Uint256](
-		dbft.WithTimer[util.Uint256](timer.New()),
+		dbft.WithTimer[util.Uint256](&clockTimer{Timer: timer.New(), now: cfg.TimeSource}),
 		dbft.WithLogger[util.Uint256](srv.log),
 		dbft.WithSecondsPerBlock[util.Uint256](cfg.TimePerBlock),
 		dbft.WithGetKeyPair[util.Uint256](srv.getKeyPair),
@@ -312,7 +349,8 @@ func (s *service) Start() {
 		s.log.Info("starting consensus service")
 		b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
 		s.lastTimestamp = b.Timestamp
-		s.dbft.Start(s.lastTimestamp * nsInMs)
+		s.updateTimePerBlock()
+		s.dbft.Start(s.baseTimestamp(b))
 		go s.eventLoop()
 	}
 }
@@ -336,7 +374,8 @@ func (s *service) eventLoop() {
 	// Manually sync up with potentially missed fresh blocks that may be added by blockchain
 	// before the subscription.
 	b, _ := s.Chain.GetBlock(s.Chain.CurrentBlockHash()) // Can't fail, we have some current block!
-	if b.Timestamp >= s.lastTimestamp {
+	// Timestamps can decrease in median validation mode.
+	if b.Timestamp >= s.lastTimestamp || s.ProtocolConfiguration.TimestampValidation.IsMedian() {
 		s.handleChainBlock(b)
 	}
 events:
@@ -421,10 +460,36 @@ func (s *service) handleChainBlock(b *coreb.Block) {
 			zap.Uint32("dbft index", s.dbft.BlockIndex),
 			zap.Uint32("chain index", s.Chain.BlockHeight()))
 		s.postBlock(b)
-		s.dbft.Reset(b.Timestamp * nsInMs)
+		s.updateTimePerBlock()
+		s.dbft.Reset(s.baseTimestamp(b))
+	}
+}
+
+// updateTimePerBlock sets dBFT block time to the one currently used by the
+// chain, so that committee changes are effective since the next block.
+func (s *service) updateTimePerBlock() {
+	if ms := s.Chain.GetMillisecondsPerBlock(); ms != 0 {
+		s.dbft.SecondsPerBlock = time.Duration(ms) * time.Millisecond
 	}
 }
 
+// baseTimestamp returns the timestamp (in nanoseconds) dBFT uses to pick the
+// timestamp of the block following b, it's incremented by a millisecond (or
+// current time is used if it's bigger). In median validation mode the median
+// of the last blocks is used, so skewed clocks of the previous primaries
+// don't affect the next block timestamp.
+func (s *service) baseTimestamp(b *coreb.Block) uint64 {
+	if !s.ProtocolConfiguration.TimestampValidation.IsMedian() {
+		return b.Timestamp * nsInMs
+	}
+	minTS, err := s.Chain.GetMinNextBlockTimestamp(&b.Header)
+	if err != nil {
+		s.log.Warn("can't get median timestamp", zap.Uint32("index", b.Index), zap.Error(err))
+		return b.Timestamp * nsInMs
+	}
+	return (minTS - 1) * nsInMs
+}
+
 func (s *service) validatePayload(p *Payload) bool {
 	validators := s.getValidators()
 	if int(p.message.ValidatorIndex) >= len(validators) {
@@ -436,29 +501,50 @@ func (s *service) validatePayload(p *Payload) bool {
 	return p.Sender == h
 }
 
+// getKeyPair selects the first validator key the node has an account for.
+// Validators list can change every block, so the key used by the node can
+// change too (e.g. when the node's key is rotated via the committee).
 func (s *service) getKeyPair(pubs []dbft.PublicKey) (int, dbft.PrivateKey, dbft.PublicKey) {
-	if s.wallet != nil {
-		for i := range pubs {
-			sh := pubs[i].(*publicKey).GetScriptHash()
-			acc := s.wallet.GetAccount(sh)
-			if acc == nil {
-				continue
-			}
-
-			if !acc.CanSign() {
-				err := acc.Decrypt(s.Config.Wallet.Password, s.wallet.Scrypt)
-				if err != nil {
-					s.log.Fatal("can't unlock account", zap.String("address", address.Uint160ToString(sh)))
-					break
-				}
-			}
-
-			return i, &privateKey{PrivateKey: acc.PrivateKey()}, &publicKey{PublicKey: acc.PublicKey()}
+	for i := range pubs {
+		acc := s.accounts[pubs[i].(*publicKey).GetScriptHash()]
+		if acc == nil {
+			continue
 		}
+		s.setCurrentKey(acc.PublicKey())
+		return i, &privateKey{PrivateKey: acc.PrivateKey()}, &publicKey{PublicKey: acc.PublicKey()}
 	}
+	s.setCurrentKey(nil)
 	return -1, nil, nil
 }
 
+// setCurrentKey remembers the key used by the node in the current dBFT round
+// and logs its changes.
+func (s *service) setCurrentKey(pub *keys.PublicKey) {
+	if pub == nil && s.currKey == nil || pub != nil && s.currKey != nil && pub.Equal(s.currKey) {
+		return
+	}
+	var height uint32
+	if s.dbft != nil {
+		height = s.dbft.BlockIndex
+	}
+	switch {
+	case pub == nil:
+		s.log.Info("node is not a validator anymore",
+			zap.Uint32("height", height),
+			zap.String("key", hex.EncodeToString(s.currKey.Bytes())))
+	case s.currKey == nil:
+		s.log.Info("node is a validator, using consensus key",
+			zap.Uint32("height", height),
+			zap.String("key", hex.EncodeToString(pub.Bytes())))
+	default:
+		s.log.Info("consensus key changed",
+			zap.Uint32("height", height),
+			zap.String("old", hex.EncodeToString(s.currKey.Bytes())),
+			zap.String("new", hex.EncodeToString(pub.Bytes())))
+	}
+	s.currKey = pub
+}
+
 func (s *service) payloadFromExtensible(ep *npayload.Extensible) *Payload {
 	return &Payload{
 		Extensible: *ep,
@@ -530,7 +616,11 @@ func (s *service) verifyBlock(b dbft.Block[util.Uint256]) bool {
 		s.log.Warn("proposed block has already outdated")
 		return false
 	}
-	if s.lastTimestamp >= coreb.Timestamp {
+	if tv := s.ProtocolConfiguration.TimestampValidation; tv.IsMedian() {
+		if !s.verifyMedianTimestamp(coreb, tv) {
+			return false
+		}
+	} else if s.lastTimestamp >= coreb.Timestamp {
 		s.log.Warn("proposed block has small timestamp",
 			zap.Uint64("ts", coreb.Timestamp),
 			zap.Uint64("last", s.lastTimestamp))
@@ -752,6 +842,34 @@ func convertKeys(validators []dbft.PublicKey) (pubs []*keys.PublicKey) {
 	return
 }
 
+// verifyMedianTimestamp checks the proposed block timestamp against the
+// median of the previous blocks and the local clock.
+func (s *service) verifyMedianTimestamp(b *coreb.Block, tv config.TimestampValidation) bool {
+	prev, err := s.Chain.GetHeader(b.PrevHash)
+	if err != nil {
+		s.log.Warn("can't get previous block header", zap.Error(err))
+		return false
+	}
+	minTS, err := s.Chain.GetMinNextBlockTimestamp(prev)
+	if err != nil {
+		s.log.Warn("can't get median timestamp", zap.Uint32("index", prev.Index), zap.Error(err))
+		return false
+	}
+	if b.Timestamp < minTS {
+		s.log.Warn("proposed block has small timestamp",
+			zap.Uint64("ts", b.Timestamp),
+			zap.Uint64("min", minTS))
+		return false
+	}
+	if maxTS := uint64(s.TimeSource().Add(tv.MaxFutureDrift).UnixMilli()); b.Timestamp > maxTS {
+		s.log.Warn("proposed block has timestamp too far in the future",
+			zap.Uint64("ts", b.Timestamp),
+			zap.Uint64("max", maxTS))
+		return false
+	}
+	return true
+}
+
 func (s *service) newBlockFromContext(ctx *dbft.Context[util.Uint256]) dbft.Block[util.Uint256] {
 	block := &neoBlock{network: s.ProtocolConfiguration.Magic}
 
@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -13,19 +14,26 @@ import (
 	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewService(t *testing.T) {
@@ -191,6 +199,117 @@ func TestService_NextConsensus(t *testing.T) {
 	*/
 }
 
+func TestService_KeyRotation(t *testing.T) {
+	bc, validator := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, validator, validator)
+	oldKey := validator.(neotest.MultiSigner).Single(0).Account().PrivateKey()
+	newKey, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	newSigner := neotest.NewSingleSigner(wallet.NewAccountFromPrivateKey(newKey))
+
+	// Wallet contains both keys and an account that can't be unlocked.
+	const pass = "pass"
+	w, err := wallet.NewWallet(filepath.Join(t.TempDir(), "wallet.json"))
+	require.NoError(t, err)
+	for _, k := range []*keys.PrivateKey{oldKey, newKey} {
+		acc, err := wallet.NewAccountFromWIF(k.WIF()) // Wallet closing destroys the key.
+		require.NoError(t, err)
+		require.NoError(t, acc.Encrypt(pass, w.Scrypt))
+		w.AddAccount(acc)
+	}
+	other, err := wallet.NewAccount()
+	require.NoError(t, err)
+	require.NoError(t, other.Encrypt("other", w.Scrypt))
+	w.AddAccount(other)
+	require.NoError(t, w.Save())
+	w.Close()
+
+	neoValidator := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+	gasValidator := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+	neoValidator.Invoke(t, true, "transfer", validator.ScriptHash(), newSigner.ScriptHash(), native.NEOTotalSupply, nil)
+	gasValidator.Invoke(t, true, "transfer", validator.ScriptHash(), newSigner.ScriptHash(), 10000_0000_0000, nil)
+	neoNew := neoValidator.WithSigners(newSigner)
+	neoNew.Invoke(t, true, "registerCandidate", newKey.PublicKey().Bytes())
+
+	logCore, logs := observer.New(zapcore.InfoLevel)
+	srv := newTestServiceWithWallet(t, bc, zap.New(logCore), config.Wallet{
+		Path:     w.Path(),
+		Password: pass,
+	})
+	require.Len(t, srv.accounts, 2)
+	srv.dbft.Start(0)
+	h := bc.BlockHeight()
+	header, err := bc.GetHeader(bc.GetHeaderHash(h))
+	require.NoError(t, err)
+	srv.dbft.Reset(header.Timestamp * nsInMs)
+
+	checkKey := func(k *keys.PrivateKey) {
+		require.Equal(t, 0, srv.dbft.MyIndex)
+		require.True(t, k.PublicKey().Equal(srv.dbft.Pub.(*publicKey).PublicKey))
+		require.True(t, k.PublicKey().Equal(srv.currKey))
+	}
+	checkKey(oldKey)
+	require.Equal(t, 1, logs.FilterMessage("node is a validator, using consensus key").Len())
+
+	// Committee is updated every block for the single-node chain, so the
+	// vote changes NextConsensus of the block following the vote one. Both
+	// of them are still signed by the old key.
+	require.NoError(t, bc.PoolTx(neoNew.PrepareInvoke(t, "vote", newSigner.ScriptHash(), newKey.PublicKey().Bytes())))
+	collectBlock(t, bc, srv)
+	require.Equal(t, h+1, bc.BlockHeight())
+	checkKey(oldKey)
+	collectBlock(t, bc, srv)
+	require.Equal(t, h+2, bc.BlockHeight())
+	b, err := bc.GetBlock(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	require.Equal(t, validator.Script(), b.Script.VerificationScript)
+	newScript, err := smartcontract.CreateDefaultMultiSigRedeemScript(keys.PublicKeys{newKey.PublicKey()})
+	require.NoError(t, err)
+	require.Equal(t, hash.Hash160(newScript), b.NextConsensus)
+	checkKey(newKey)
+	require.Equal(t, 1, logs.FilterMessage("consensus key changed").Len())
+
+	// Blocks are signed by the new key from now on.
+	for i := uint32(3); i < 5; i++ {
+		collectBlock(t, bc, srv)
+		require.Equal(t, h+i, bc.BlockHeight())
+		checkKey(newKey)
+	}
+	b, err = bc.GetBlock(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	require.Equal(t, newScript, b.Script.VerificationScript)
+	require.Equal(t, 1, logs.FilterMessage("consensus key changed").Len())
+}
+
+func TestService_TimePerBlockChange(t *testing.T) {
+	bc, validator, _ := chain.New(t, chain.NewConfig().WithHardfork(config.HFNeoGoExtensions.String(), 0))
+	e := neotest.NewExecutor(t, bc, validator, validator)
+
+	const pass = "pass"
+	w, err := wallet.NewWallet(filepath.Join(t.TempDir(), "wallet.json"))
+	require.NoError(t, err)
+	acc, err := wallet.NewAccountFromWIF(validator.(neotest.MultiSigner).Single(0).Account().PrivateKey().WIF())
+	require.NoError(t, err)
+	require.NoError(t, acc.Encrypt(pass, w.Scrypt))
+	w.AddAccount(acc)
+	require.NoError(t, w.Save())
+	w.Close()
+
+	srv := newTestServiceWithWallet(t, bc, zaptest.NewLogger(t), config.Wallet{
+		Path:     w.Path(),
+		Password: pass,
+	})
+	srv.dbft.Start(0)
+	require.Equal(t, chain.TimePerBlock, srv.dbft.SecondsPerBlock)
+
+	policy := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+	policy.Invoke(t, stackitem.Null{}, "setMillisecondsPerBlock", 300)
+	b, err := bc.GetBlock(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	srv.handleChainBlock(b)
+	require.Equal(t, 300*time.Millisecond, srv.dbft.SecondsPerBlock)
+}
+
 func TestService_GetVerified(t *testing.T) {
 	srv := newTestService(t)
 	srv.dbft.Start(0)
@@ -375,6 +494,7 @@ func TestService_OnPayload(t *testing.T) {
 
 	priv, _ := getTestValidator(1)
 	p := new(Payload)
+	p.message.Type = prepareRequestType
 	p.message.ValidatorIndex = 1
 	p.payload = &prepareRequest{}
 	p.encodeData()
@@ -384,6 +504,7 @@ func TestService_OnPayload(t *testing.T) {
 	shouldNotReceive(t, srv.messages)
 
 	p = new(Payload)
+	p.message.Type = prepareRequestType
 	p.message.ValidatorIndex = 1
 	p.Sender = priv.GetScriptHash()
 	p.payload = &prepareRequest{}
@@ -477,6 +598,66 @@ func TestVerifyBlock(t *testing.T) {
 	})
 }
 
+func TestVerifyBlock_MedianTimestamp(t *testing.T) {
+	cfg, err := config.Load("../../config", netmode.UnitTestNet)
+	require.NoError(t, err)
+	cfg.ProtocolConfiguration.TimestampValidation = config.TimestampValidation{
+		Mode:           config.TimestampModeMedian,
+		MedianWindow:   3,
+		MaxFutureDrift: time.Second,
+	}
+	bc, err := core.NewBlockchain(storage.NewMemoryStore(), cfg.Blockchain(), zaptest.NewLogger(t))
+	require.NoError(t, err)
+	go bc.Run()
+	t.Cleanup(bc.Close)
+
+	var (
+		now    = time.Now()
+		offset = func(d time.Duration) uint64 { return uint64(now.Add(d).UnixMilli()) }
+	)
+	newBlock := func(ts uint64) *coreb.Block {
+		b := testchain.NewBlock(t, bc, 1, 0)
+		// Header is recreated to drop the cached hash.
+		b.Header = coreb.Header{
+			Version:       b.Version,
+			PrevHash:      b.PrevHash,
+			Timestamp:     ts,
+			Index:         b.Index,
+			PrimaryIndex:  b.PrimaryIndex,
+			NextConsensus: b.NextConsensus,
+			Script:        b.Script,
+		}
+		b.RebuildMerkleRoot()
+		b.Script.InvocationScript = testchain.Sign(b)
+		return b
+	}
+	// The last block is made by a node with its clock 500ms ahead.
+	for _, d := range []time.Duration{-3 * time.Second, -2 * time.Second, 500 * time.Millisecond} {
+		require.NoError(t, bc.AddBlock(newBlock(offset(d))))
+	}
+
+	srv := newTestServiceWithChain(t, bc)
+	srv.TimeSource = func() time.Time { return now }
+	top, err := bc.GetBlock(bc.CurrentBlockHash())
+	require.NoError(t, err)
+	srv.postBlock(top)
+	// The median is used as a base, not the last block timestamp.
+	require.Equal(t, (offset(-2*time.Second))*nsInMs, srv.baseTimestamp(top))
+
+	// The node with its clock 1s behind proposes a block older than the
+	// last one, but it's still valid.
+	b := newBlock(offset(-time.Second))
+	require.True(t, srv.verifyBlock(&neoBlock{Block: *b}))
+	require.NoError(t, bc.AddBlock(b))
+
+	// The node with its clock 5s ahead proposes a block from the future.
+	b = newBlock(offset(5 * time.Second))
+	require.False(t, srv.verifyBlock(&neoBlock{Block: *b}))
+	// Older than the median.
+	b = newBlock(offset(-2 * time.Second))
+	require.False(t, srv.verifyBlock(&neoBlock{Block: *b}))
+}
+
 func shouldReceive(t *testing.T, ch chan Payload) {
 	select {
 	case <-ch:
@@ -502,8 +683,15 @@ func newTestService(t *testing.T) *service {
 }
 
 func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
+	return newTestServiceWithWallet(t, bc, zaptest.NewLogger(t), config.Wallet{
+		Path:     "./testdata/wallet1.json",
+		Password: "one",
+	})
+}
+
+func newTestServiceWithWallet(t *testing.T, bc *core.Blockchain, log *zap.Logger, w config.Wallet) *service {
 	srv, err := NewService(Config{
-		Logger:                zaptest.NewLogger(t),
+		Logger:                log,
 		Broadcast:             func(*npayload.Extensible) {},
 		Chain:                 bc,
 		BlockQueue:            testBlockQueuer{bc: bc},
@@ -511,10 +699,7 @@ func newTestServiceWithChain(t *testing.T, bc *core.Blockchain) *service {
 		RequestTx:             func(...util.Uint256) {},
 		StopTxFlow:            func() {},
 		TimePerBlock:          bc.GetConfig().TimePerBlock,
-		Wallet: config.Wallet{
-			Path:     "./testdata/wallet1.json",
-			Password: "one",
-		},
+		Wallet:                w,
 	})
 	require.NoError(t, err)
 
@@ -0,0 +1,210 @@
+package consensus
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	coreb "github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	npayload "github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"go.uber.org/zap"
+)
+
+// devPollInterval is the period of memory pool checks made by the devnet
+// block producer.
+const devPollInterval = 50 * time.Millisecond
+
+type (
+	// DevConfig is a configuration for the devnet block producer.
+	DevConfig struct {
+		// Logger is a logger instance.
+		Logger *zap.Logger
+		// Chain is a Ledger instance.
+		Chain Ledger
+		// BlockQueue is a BlockQueuer instance.
+		BlockQueue BlockQueuer
+		// PrivateKey is the key of the single network validator.
+		PrivateKey *keys.PrivateKey
+		// TimePerBlock is the maximum time between blocks, new block is
+		// produced earlier if there are transactions in the memory pool.
+		TimePerBlock time.Duration
+	}
+
+	// devService is a consensus.Service implementation for single-validator
+	// development networks, it produces blocks without dBFT message exchange.
+	devService struct {
+		DevConfig
+
+		started  atomic.Bool
+		quit     chan struct{}
+		finished chan struct{}
+	}
+)
+
+// NewDevService returns a devnet block producer. It only works for networks
+// with a single validator owning the given key, a new block is created as
+// soon as there are transactions in the memory pool or after TimePerBlock
+// since the last block.
+func NewDevService(cfg DevConfig) (Service, error) {
+	if cfg.Logger == nil {
+		return nil, errors.New("empty logger")
+	}
+	if cfg.PrivateKey == nil {
+		return nil, errors.New("no validator key")
+	}
+	if cfg.TimePerBlock <= 0 {
+		cfg.TimePerBlock = defaultTimePerBlock
+	}
+	vals, err := cfg.Chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, fmt.Errorf("can't get validators: %w", err)
+	}
+	if len(vals) != 1 || !vals[0].Equal(cfg.PrivateKey.PublicKey()) {
+		return nil, errors.New("the key is not the only validator of the network")
+	}
+	return &devService{
+		DevConfig: cfg,
+		quit:      make(chan struct{}),
+		finished:  make(chan struct{}),
+	}, nil
+}
+
+// Name implements the Service interface.
+func (s *devService) Name() string {
+	return "consensus"
+}
+
+// Start implements the Service interface.
+func (s *devService) Start() {
+	if s.started.CompareAndSwap(false, true) {
+		s.Logger.Info("starting devnet block producer")
+		go s.eventLoop()
+	}
+}
+
+// Shutdown implements the Service interface.
+func (s *devService) Shutdown() {
+	if s.started.CompareAndSwap(true, false) {
+		s.Logger.Info("stopping devnet block producer")
+		close(s.quit)
+		<-s.finished
+	}
+	_ = s.Logger.Sync()
+}
+
+// OnPayload implements the Service interface, devnet block producer doesn't
+// accept any payloads.
+func (s *devService) OnPayload(*npayload.Extensible) error {
+	return nil
+}
+
+// OnTransaction implements the Service interface, transactions are taken
+// from the memory pool, so it's a no-op.
+func (s *devService) OnTransaction(*transaction.Transaction) {}
+
+func (s *devService) eventLoop() {
+	var (
+		ticker    = time.NewTicker(devPollInterval)
+		lastBlock = time.Now()
+		expected  uint32
+	)
+	defer func() {
+		ticker.Stop()
+		close(s.finished)
+	}()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			var (
+				height  = s.Chain.BlockHeight()
+				timeout = time.Since(lastBlock) >= s.TimePerBlock
+			)
+			if height < expected && !timeout { // Previous block is still being processed.
+				continue
+			}
+			b, err := s.newBlock(timeout)
+			if err == nil && b != nil {
+				err = s.BlockQueue.PutBlock(b)
+			}
+			if err != nil {
+				s.Logger.Error("can't produce block", zap.Uint32("index", height+1), zap.Error(err))
+				continue
+			}
+			if b == nil {
+				continue
+			}
+			s.Logger.Debug("block produced", zap.Uint32("index", b.Index), zap.Int("tx", len(b.Transactions)))
+			expected = b.Index
+			lastBlock = time.Now()
+		}
+	}
+}
+
+// newBlock creates the next signed block with memory pool transactions. If
+// there are no transactions to include and empty block is not requested nil
+// is returned.
+func (s *devService) newBlock(allowEmpty bool) (*coreb.Block, error) {
+	cfg := s.Chain.GetConfig()
+	prev, err := s.Chain.GetBlock(s.Chain.CurrentBlockHash())
+	if err != nil {
+		return nil, fmt.Errorf("can't get current block: %w", err)
+	}
+	txs := s.Chain.ApplyPolicyToTxSet(s.Chain.GetMemPool().GetVerifiedTransactions())
+	if lim := int(cfg.MaxTransactionsPerBlock); lim != 0 && len(txs) > lim {
+		txs = txs[:lim]
+	}
+	if len(txs) == 0 && !allowEmpty {
+		return nil, nil
+	}
+	var nonce [8]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, fmt.Errorf("can't generate nonce: %w", err)
+	}
+
+	b := &coreb.Block{Transactions: txs}
+	b.Version = coreb.VersionInitial
+	b.Index = prev.Index + 1
+	b.PrevHash = prev.Hash()
+	b.Nonce = binary.LittleEndian.Uint64(nonce[:])
+	b.Timestamp = uint64(time.Now().UnixMilli())
+	if b.Timestamp <= prev.Timestamp {
+		b.Timestamp = prev.Timestamp + 1
+	}
+	if cfg.StateRootInHeader {
+		sr, err := s.Chain.GetStateRoot(prev.Index)
+		if err != nil {
+			return nil, fmt.Errorf("can't get state root: %w", err)
+		}
+		b.StateRootEnabled = true
+		b.PrevStateRoot = sr.Root
+	}
+	next, err := smartcontract.CreateDefaultMultiSigRedeemScript(s.Chain.ComputeNextBlockValidators())
+	if err != nil {
+		return nil, fmt.Errorf("can't create next consensus script: %w", err)
+	}
+	b.NextConsensus = hash.Hash160(next)
+	b.RebuildMerkleRoot()
+
+	vals, err := s.Chain.GetNextBlockValidators()
+	if err != nil {
+		return nil, fmt.Errorf("can't get validators: %w", err)
+	}
+	verif, err := smartcontract.CreateDefaultMultiSigRedeemScript(vals)
+	if err != nil {
+		return nil, fmt.Errorf("can't create verification script: %w", err)
+	}
+	sig := s.PrivateKey.SignHashable(uint32(cfg.Magic), b)
+	b.Script.InvocationScript = append([]byte{byte(opcode.PUSHDATA1), byte(len(sig))}, sig...)
+	b.Script.VerificationScript = verif
+	return b, nil
+}
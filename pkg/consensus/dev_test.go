@@ -0,0 +1,76 @@
+package consensus
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestDevService(t *testing.T) {
+	bc, validator := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, validator, validator)
+	key := validator.(neotest.MultiSigner).Single(0).Account().PrivateKey()
+
+	t.Run("invalid key", func(t *testing.T) {
+		k, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		_, err = NewDevService(DevConfig{
+			Logger:     zaptest.NewLogger(t),
+			Chain:      bc,
+			BlockQueue: testBlockQueuer{bc: bc},
+			PrivateKey: k,
+		})
+		require.Error(t, err)
+	})
+
+	srv, err := NewDevService(DevConfig{
+		Logger:       zaptest.NewLogger(t),
+		Chain:        bc,
+		BlockQueue:   testBlockQueuer{bc: bc},
+		PrivateKey:   key,
+		TimePerBlock: time.Hour,
+	})
+	require.NoError(t, err)
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+
+	height := bc.BlockHeight()
+	gasInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+	tx := gasInvoker.PrepareInvoke(t, "transfer", validator.ScriptHash(), util.Uint160{1, 2, 3}, 1000, nil)
+	require.NoError(t, bc.PoolTx(tx))
+
+	require.Eventually(t, func() bool { return bc.BlockHeight() == height+1 }, time.Second, 10*time.Millisecond)
+	e.CheckHalt(t, tx.Hash())
+	e.CheckGASBalance(t, util.Uint160{1, 2, 3}, big.NewInt(1000))
+
+	// No new blocks without transactions.
+	time.Sleep(3 * devPollInterval)
+	require.Equal(t, height+1, bc.BlockHeight())
+}
+
+func TestDevService_Empty(t *testing.T) {
+	bc, validator := chain.NewSingle(t)
+	key := validator.(neotest.MultiSigner).Single(0).Account().PrivateKey()
+
+	srv, err := NewDevService(DevConfig{
+		Logger:       zaptest.NewLogger(t),
+		Chain:        bc,
+		BlockQueue:   testBlockQueuer{bc: bc},
+		PrivateKey:   key,
+		TimePerBlock: 100 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	srv.Start()
+	t.Cleanup(srv.Shutdown)
+
+	height := bc.BlockHeight()
+	require.Eventually(t, func() bool { return bc.BlockHeight() >= height+2 }, time.Second, 10*time.Millisecond)
+}
@@ -222,10 +222,9 @@ func (p *Payload) encodeData() {
 
 // decode data of payload into its message.
 func (p *Payload) decodeData() error {
-	br := io.NewBinReaderFromBuf(p.Extensible.Data)
-	p.message.DecodeBinary(br)
-	if br.Err != nil {
-		return fmt.Errorf("can't decode message: %w", br.Err)
+	err := io.DecodeStrict(p.Extensible.Data, &p.message)
+	if err != nil {
+		return fmt.Errorf("can't decode message: %w", err)
 	}
 	return nil
 }
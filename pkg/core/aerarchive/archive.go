@@ -0,0 +1,218 @@
+/*
+Package aerarchive implements an external archive for application execution
+results of blocks removed from the node's database.
+
+Nodes with RemoveUntraceableBlocks setting enabled drop old blocks along with
+their execution results. If the archive is enabled, these results are saved
+into it before removal, so they can still be served by RPC. Archive data is
+kept in a pluggable Store (a simple filesystem backend is provided) and every
+record contains enough data to check it against the block header that is kept
+by the node even for removed blocks.
+*/
+package aerarchive
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// BackendFilesystem is the name of the filesystem archive backend.
+const BackendFilesystem = "filesystem"
+
+// ErrNotFound is returned when the requested data is missing from the store
+// or from the archive.
+var ErrNotFound = errors.New("not found")
+
+// Store is an archive storage backend, it's a simple object store that can be
+// implemented on top of filesystem, S3-compatible services, etc.
+type Store interface {
+	// Put saves the value under the given key replacing the old one.
+	Put(key string, value []byte) error
+	// Get returns the value stored under the given key or ErrNotFound.
+	Get(key string) ([]byte, error)
+	// Delete removes the given key, it's not an error if there is no such
+	// key.
+	Delete(key string) error
+}
+
+// Record is a set of execution results for a single block.
+type Record struct {
+	// Index is the block index.
+	Index uint32
+	// Hash is the block hash.
+	Hash util.Uint256
+	// TxHashes are the hashes of all block transactions (in block order).
+	TxHashes []util.Uint256
+	// Results contains execution results of the block and of its
+	// transactions.
+	Results []state.AppExecResult
+}
+
+// Archive is an execution results archive. It's not safe for concurrent
+// modification, but reads can be performed concurrently with writes.
+type Archive struct {
+	store     Store
+	retention uint32
+}
+
+// New creates an archive using the given configuration.
+func New(cfg config.AERArchive) (*Archive, error) {
+	switch cfg.Backend {
+	case "", BackendFilesystem:
+		s, err := NewFSStore(cfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		return NewWithStore(s, cfg.Retention), nil
+	default:
+		return nil, fmt.Errorf("unknown AER archive backend: %s", cfg.Backend)
+	}
+}
+
+// NewWithStore creates an archive over the given store keeping data for the
+// given number of blocks (0 means no limit).
+func NewWithStore(s Store, retention uint32) *Archive {
+	return &Archive{
+		store:     s,
+		retention: retention,
+	}
+}
+
+func blockKey(h util.Uint256) string {
+	return "blocks/" + h.StringLE()
+}
+
+func txKey(h util.Uint256) string {
+	return "tx/" + h.StringLE()
+}
+
+func heightKey(index uint32) string {
+	return "heights/" + strconv.FormatUint(uint64(index), 10)
+}
+
+// Put saves the record into the archive. Records older than the retention
+// period are removed.
+func (a *Archive) Put(r *Record) error {
+	w := io.NewBufBinWriter()
+	r.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return w.Err
+	}
+	err := a.store.Put(blockKey(r.Hash), w.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to store block %d: %w", r.Index, err)
+	}
+	for _, h := range r.TxHashes {
+		err = a.store.Put(txKey(h), r.Hash.BytesBE())
+		if err != nil {
+			return fmt.Errorf("failed to store transaction %s index: %w", h.StringLE(), err)
+		}
+	}
+	err = a.store.Put(heightKey(r.Index), r.Hash.BytesBE())
+	if err != nil {
+		return fmt.Errorf("failed to store block %d index: %w", r.Index, err)
+	}
+	if a.retention != 0 && r.Index >= a.retention {
+		return a.remove(r.Index - a.retention)
+	}
+	return nil
+}
+
+// remove deletes the record for the block with the given index.
+func (a *Archive) remove(index uint32) error {
+	hb, err := a.store.Get(heightKey(index))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	h, err := util.Uint256DecodeBytesBE(hb)
+	if err != nil {
+		return fmt.Errorf("invalid block %d index: %w", index, err)
+	}
+	r, err := a.getRecord(h)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	if r != nil {
+		for _, txh := range r.TxHashes {
+			if err := a.store.Delete(txKey(txh)); err != nil {
+				return err
+			}
+		}
+	}
+	if err := a.store.Delete(blockKey(h)); err != nil {
+		return err
+	}
+	return a.store.Delete(heightKey(index))
+}
+
+// Get returns the record containing execution results for the given block or
+// transaction hash. It returns ErrNotFound if there is no such record.
+func (a *Archive) Get(h util.Uint256) (*Record, error) {
+	r, err := a.getRecord(h)
+	if !errors.Is(err, ErrNotFound) {
+		return r, err
+	}
+	hb, err := a.store.Get(txKey(h))
+	if err != nil {
+		return nil, err
+	}
+	bh, err := util.Uint256DecodeBytesBE(hb)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transaction %s index: %w", h.StringLE(), err)
+	}
+	return a.getRecord(bh)
+}
+
+func (a *Archive) getRecord(h util.Uint256) (*Record, error) {
+	b, err := a.store.Get(blockKey(h))
+	if err != nil {
+		return nil, err
+	}
+	r := new(Record)
+	br := io.NewBinReaderFromBuf(b)
+	r.DecodeBinary(br)
+	if br.Err != nil {
+		return nil, fmt.Errorf("invalid block %s record: %w", h.StringLE(), br.Err)
+	}
+	return r, nil
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (r *Record) EncodeBinary(w *io.BinWriter) {
+	w.WriteU32LE(r.Index)
+	r.Hash.EncodeBinary(w)
+	w.WriteArray(r.TxHashes)
+	w.WriteVarUint(uint64(len(r.Results)))
+	for i := range r.Results {
+		r.Results[i].EncodeBinary(w)
+	}
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (r *Record) DecodeBinary(br *io.BinReader) {
+	r.Index = br.ReadU32LE()
+	r.Hash.DecodeBinary(br)
+	br.ReadArray(&r.TxHashes, block.MaxTransactionsPerBlock)
+	n := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
+	if n > uint64(len(r.TxHashes))+2 {
+		br.Err = errors.New("too many execution results")
+		return
+	}
+	r.Results = make([]state.AppExecResult, n)
+	for i := range r.Results {
+		r.Results[i].DecodeBinary(br)
+	}
+}
@@ -0,0 +1,114 @@
+package aerarchive
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+func newRecord(index uint32, txs int) *Record {
+	r := &Record{
+		Index: index,
+		Hash:  util.Uint256{byte(index), 0xff},
+	}
+	r.Results = append(r.Results, state.AppExecResult{
+		Container: r.Hash,
+		Execution: state.Execution{Trigger: trigger.OnPersist, VMState: vmstate.Halt, Stack: []stackitem.Item{}, Events: []state.NotificationEvent{}},
+	})
+	for i := 0; i < txs; i++ {
+		h := util.Uint256{byte(index), byte(i)}
+		r.TxHashes = append(r.TxHashes, h)
+		r.Results = append(r.Results, state.AppExecResult{
+			Container: h,
+			Execution: state.Execution{
+				Trigger:     trigger.Application,
+				VMState:     vmstate.Halt,
+				GasConsumed: int64(i),
+				Stack:       []stackitem.Item{stackitem.Make(i)},
+				Events:      []state.NotificationEvent{},
+			},
+		})
+	}
+	return r
+}
+
+func TestFSStore(t *testing.T) {
+	_, err := NewFSStore("")
+	require.Error(t, err)
+
+	s, err := NewFSStore(t.TempDir())
+	require.NoError(t, err)
+
+	_, err = s.Get("some/key")
+	require.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, s.Delete("some/key"))
+
+	require.NoError(t, s.Put("some/key", []byte{1, 2, 3}))
+	v, err := s.Get("some/key")
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, v)
+
+	require.NoError(t, s.Put("some/key", []byte{4}))
+	v, err = s.Get("some/key")
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, v)
+
+	require.NoError(t, s.Delete("some/key"))
+	_, err = s.Get("some/key")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Error(t, s.Put("", []byte{1}))
+	require.Error(t, s.Put("../key", []byte{1}))
+	_, err = s.Get("../key")
+	require.Error(t, err)
+	require.Error(t, s.Delete("../key"))
+}
+
+func TestArchive(t *testing.T) {
+	_, err := New(config.AERArchive{Backend: "unknown", Path: t.TempDir()})
+	require.Error(t, err)
+
+	dir := t.TempDir()
+	a, err := New(config.AERArchive{Backend: BackendFilesystem, Path: dir, Retention: 2})
+	require.NoError(t, err)
+
+	r1, r2, r3 := newRecord(1, 2), newRecord(2, 0), newRecord(3, 1)
+	for _, r := range []*Record{r1, r2} {
+		require.NoError(t, a.Put(r))
+	}
+	for _, h := range []util.Uint256{r1.Hash, r1.TxHashes[0], r1.TxHashes[1]} {
+		actual, err := a.Get(h)
+		require.NoError(t, err)
+		require.Equal(t, r1, actual)
+	}
+	actual, err := a.Get(r2.Hash)
+	require.NoError(t, err)
+	require.Equal(t, r2.Results, actual.Results)
+	_, err = a.Get(util.Uint256{1, 2, 3})
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// Block 1 is out of retention window now.
+	require.NoError(t, a.Put(r3))
+	for _, h := range []util.Uint256{r1.Hash, r1.TxHashes[0], r1.TxHashes[1]} {
+		_, err = a.Get(h)
+		require.ErrorIs(t, err, ErrNotFound)
+	}
+	for _, h := range []util.Uint256{r2.Hash, r3.Hash, r3.TxHashes[0]} {
+		_, err = a.Get(h)
+		require.NoError(t, err)
+	}
+
+	// Corrupted record.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blocks", r3.Hash.StringLE()), []byte{1, 2, 3}, 0o644))
+	_, err = a.Get(r3.TxHashes[0])
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrNotFound)
+}
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -14,9 +15,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
 	"github.com/stretchr/testify/require"
 )
@@ -77,6 +83,81 @@ func BenchmarkNEO_GetGASPerVote(t *testing.B) {
 	}
 }
 
+func BenchmarkNativeCall(t *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		t.Run(fmt.Sprintf("CallStats=%t", enabled), func(t *testing.B) {
+			benchmarkNativeCall(t, enabled)
+		})
+	}
+}
+
+func benchmarkNativeCall(t *testing.B, statsEnabled bool) {
+	const callsPerScript = 100
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.TrackNativeCallStats = statsEnabled
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	policyHash := e.NativeHash(t, nativenames.Policy)
+
+	w := io.NewBufBinWriter()
+	for i := 0; i < callsPerScript; i++ {
+		emit.AppCall(w.BinWriter, policyHash, "getFeePerByte", callflag.ReadStates)
+		emit.Opcodes(w.BinWriter, opcode.DROP)
+	}
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.ResetTimer()
+	t.ReportAllocs()
+	t.StartTimer()
+	for i := 0; i < t.N; i++ {
+		ic, err := bc.GetTestVM(trigger.Application, nil, nil)
+		require.NoError(t, err)
+		ic.VM.GasLimit = -1
+		ic.VM.LoadScriptWithFlags(script, callflag.All)
+		require.NoError(t, ic.VM.Run())
+		ic.Finalize()
+	}
+	t.StopTimer()
+}
+
+func BenchmarkBlockchain_ParallelTestInvocations(t *testing.B) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	for i := 0; i < 10; i++ {
+		e.AddNewBlock(t)
+	}
+
+	w := io.NewBufBinWriter()
+	for i := 0; i < 10; i++ {
+		emit.AppCall(w.BinWriter, gasHash, "balanceOf", callflag.ReadStates, acc.ScriptHash())
+		emit.Opcodes(w.BinWriter, opcode.DROP)
+	}
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	t.ReportAllocs()
+	t.ResetTimer()
+	t.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ic, err := bc.GetTestVM(trigger.Application, nil, nil)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			ic.VM.GasLimit = -1
+			ic.VM.LoadScriptWithFlags(script, callflag.All)
+			if err := ic.VM.Run(); err != nil {
+				t.Error(err)
+				return
+			}
+			ic.Finalize()
+		}
+	})
+}
+
 func benchmarkForEachNEP17Transfer(t *testing.B, ps storage.Store, startFromBlock, nBlocksToTake int) {
 	var (
 		chainHeight       = 2_100                            // constant chain height to be able to compare paging results
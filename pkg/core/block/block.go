@@ -3,6 +3,7 @@ package block
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"math"
 	"math/big"
 
@@ -59,6 +60,28 @@ func (b *Block) ComputeMerkleRoot() util.Uint256 {
 	return hash.CalcMerkleRoot(hashes)
 }
 
+// MerkleProof returns the Merkle audit path and the index of the transaction
+// with the given hash in the block. Together with the block's MerkleRoot they
+// can be used to prove transaction inclusion via hash.VerifyMerkleProof.
+func (b *Block) MerkleProof(txHash util.Uint256) ([]util.Uint256, uint32, error) {
+	var index = -1
+	hashes := make([]util.Uint256, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hashes[i] = tx.Hash()
+		if hashes[i] == txHash {
+			index = i
+		}
+	}
+	if index < 0 {
+		return nil, 0, fmt.Errorf("transaction %s is not found in block %d", txHash.StringLE(), b.Index)
+	}
+	path, err := hash.CalcMerkleProof(hashes, index)
+	if err != nil {
+		return nil, 0, err
+	}
+	return path, uint32(index), nil
+}
+
 // RebuildMerkleRoot rebuilds the merkleroot of the block.
 func (b *Block) RebuildMerkleRoot() {
 	b.MerkleRoot = b.ComputeMerkleRoot()
@@ -0,0 +1,45 @@
+package block
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func FuzzBlockStrictRoundTrip(f *testing.F) {
+	data, err := getBlockData(1)
+	require.NoError(f, err)
+	b, err := hex.DecodeString(data["raw"].(string))
+	require.NoError(f, err)
+	f.Add(b)
+	blk := newDumbBlock()
+	blk.Transactions[0].Signers = []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}
+	blk.Transactions[0].Scripts = []transaction.Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+	w := io.NewBufBinWriter()
+	blk.EncodeBinary(w.BinWriter)
+	require.NoError(f, w.Err)
+	f.Add(w.Bytes())
+	f.Fuzz(func(t *testing.T, b []byte) {
+		blk := New(false)
+		if io.DecodeStrict(b, blk) != nil {
+			return
+		}
+		w := io.NewBufBinWriter()
+		blk.EncodeBinary(w.BinWriter)
+		require.NoError(t, w.Err)
+		data := w.Bytes()
+		require.Equal(t, b, data)
+
+		actual := New(false)
+		require.NoError(t, io.DecodeStrict(data, actual))
+		require.Equal(t, blk.Hash(), actual.Hash())
+		require.Equal(t, len(blk.Transactions), len(actual.Transactions))
+		for i := range blk.Transactions {
+			require.Equal(t, blk.Transactions[i].Hash(), actual.Transactions[i].Hash())
+		}
+	})
+}
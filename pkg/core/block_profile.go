@@ -0,0 +1,180 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// defaultBlockProfilesCount is the default number of the latest block
+// profiles kept in memory.
+const defaultBlockProfilesCount = 100
+
+var (
+	// ErrBlockProfilingDisabled is returned from block profile requests if
+	// block profiling is disabled in the node configuration.
+	ErrBlockProfilingDisabled = errors.New("block profiling is disabled")
+	// ErrBlockProfileNotFound is returned from block profile requests if
+	// there is no profile for the requested block (it's either not yet
+	// persisted or too old).
+	ErrBlockProfileNotFound = errors.New("block profile not found")
+)
+
+// BlockProfile is a node-local execution profile of a single block. It
+// contains data collected while block transactions are executed during
+// persist, so it's not a part of the chain state.
+type BlockProfile struct {
+	Index uint32
+	Hash  util.Uint256
+	// Time is the wall time spent processing the block (including
+	// OnPersist/PostPersist and storing data).
+	Time time.Duration
+	// GAS is the amount of GAS consumed by all block transactions.
+	GAS          int64
+	Transactions []TxProfile
+	// Contracts is an aggregated per-contract profile of all block
+	// transactions sorted by the amount of GAS spent in descending order.
+	Contracts []ContractProfile
+}
+
+// TxProfile is an execution profile of a single transaction.
+type TxProfile struct {
+	Hash util.Uint256
+	// Time is the wall time of transaction script execution.
+	Time     time.Duration
+	GAS      int64
+	VMState  vmstate.State
+	Syscalls map[string]int
+}
+
+// ContractProfile is an aggregated execution profile of a single contract.
+type ContractProfile struct {
+	Hash util.Uint160
+	// Calls is the number of times the contract was called.
+	Calls int
+	// GAS is the amount of GAS spent by the contract's own code (including
+	// syscall and native method prices, but excluding other contracts
+	// called by it).
+	GAS int64
+}
+
+// blockProfiles is a bounded ring of the latest block profiles.
+type blockProfiles struct {
+	lock sync.RWMutex
+	ring []*BlockProfile
+}
+
+// newBlockProfiles creates a ring for n block profiles.
+func newBlockProfiles(n uint32) *blockProfiles {
+	return &blockProfiles{ring: make([]*BlockProfile, n)}
+}
+
+// add stores the profile replacing the oldest one.
+func (p *blockProfiles) add(bp *BlockProfile) {
+	p.lock.Lock()
+	p.ring[bp.Index%uint32(len(p.ring))] = bp
+	p.lock.Unlock()
+}
+
+// get returns the profile of the block with the given index or nil if it's
+// not in the ring.
+func (p *blockProfiles) get(index uint32) *BlockProfile {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	bp := p.ring[index%uint32(len(p.ring))]
+	if bp == nil || bp.Index != index {
+		return nil
+	}
+	return bp
+}
+
+// blockProfileBuilder accumulates execution profiles of block transactions.
+type blockProfileBuilder struct {
+	start     time.Time
+	txStart   time.Time
+	profile   *BlockProfile
+	contracts map[util.Uint160]*ContractProfile
+}
+
+// newBlockProfileBuilder starts profiling of the given block.
+func newBlockProfileBuilder(index uint32, hash util.Uint256, txCount int) *blockProfileBuilder {
+	return &blockProfileBuilder{
+		start: time.Now(),
+		profile: &BlockProfile{
+			Index:        index,
+			Hash:         hash,
+			Transactions: make([]TxProfile, 0, txCount),
+		},
+		contracts: make(map[util.Uint160]*ContractProfile),
+	}
+}
+
+// startTx attaches a new execution profile to the given transaction interop
+// context, it must be called right before the transaction script is executed.
+func (b *blockProfileBuilder) startTx(ic *interop.Context) {
+	ic.Profile = interop.NewExecProfile()
+	b.txStart = time.Now()
+}
+
+// finishTx adds transaction execution profile to the block profile, it must
+// be called right after the transaction script is executed.
+func (b *blockProfileBuilder) finishTx(ic *interop.Context, aer *state.AppExecResult) {
+	elapsed := time.Since(b.txStart)
+	ic.Profile.Finish(aer.GasConsumed)
+	b.profile.GAS += aer.GasConsumed
+	b.profile.Transactions = append(b.profile.Transactions, TxProfile{
+		Hash:     aer.Container,
+		Time:     elapsed,
+		GAS:      aer.GasConsumed,
+		VMState:  aer.VMState,
+		Syscalls: ic.Profile.Syscalls,
+	})
+	for h, calls := range ic.Invocations {
+		cp := b.contracts[h]
+		if cp == nil {
+			cp = &ContractProfile{Hash: h}
+			b.contracts[h] = cp
+		}
+		cp.Calls += calls
+		// Entry scripts are not contracts, so only called ones are counted.
+		cp.GAS += ic.Profile.ContractGAS[h]
+	}
+}
+
+// finish completes block profile.
+func (b *blockProfileBuilder) finish() *BlockProfile {
+	b.profile.Time = time.Since(b.start)
+	b.profile.Contracts = make([]ContractProfile, 0, len(b.contracts))
+	for _, cp := range b.contracts {
+		b.profile.Contracts = append(b.profile.Contracts, *cp)
+	}
+	sort.Slice(b.profile.Contracts, func(i, j int) bool {
+		ci, cj := b.profile.Contracts[i], b.profile.Contracts[j]
+		if ci.GAS != cj.GAS {
+			return ci.GAS > cj.GAS
+		}
+		return ci.Hash.Less(cj.Hash)
+	})
+	return b.profile
+}
+
+// GetBlockProfile returns node-local execution profile of the block with the
+// given index. Profiles are available only for the latest BlockProfilesCount
+// blocks persisted since the node start.
+func (bc *Blockchain) GetBlockProfile(index uint32) (*BlockProfile, error) {
+	if bc.profiles == nil {
+		return nil, ErrBlockProfilingDisabled
+	}
+	bp := bc.profiles.get(index)
+	// Profiles are not dropped on state reset, so it can be a stale one.
+	if bp == nil || index > bc.BlockHeight() || bp.Hash != bc.GetHeaderHash(index) {
+		return nil, ErrBlockProfileNotFound
+	}
+	return bp, nil
+}
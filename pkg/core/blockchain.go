@@ -14,7 +14,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
+	"github.com/nspcc-dev/neo-go/pkg/core/aerarchive"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/coldstore"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -33,6 +35,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -137,6 +140,9 @@ type Blockchain struct {
 	// Data access object for CRUD operations around storage. It's write-cached.
 	dao *dao.Simple
 
+	// timeNow is the local clock used for block timestamp validation.
+	timeNow func() time.Time
+
 	// persistent is the same DB as dao, but we never write to it, so all reads
 	// are directly from underlying persistent store.
 	persistent *dao.Simple
@@ -160,8 +166,49 @@ type Blockchain struct {
 	runToExitCh chan struct{}
 	// isRunning denotes whether blockchain routines are currently running.
 	isRunning atomic.Value
+	// persistFailed is set if the latest attempt to persist changes to the
+	// DB has failed.
+	persistFailed atomic.Bool
+
+	// storageUsageReady is set when storage usage counters are complete.
+	storageUsageReady atomic.Bool
+	// storageUsageRebuilding is set while storage usage rebuild routine is
+	// running.
+	storageUsageRebuilding atomic.Bool
+	// storageUsageEpoch is incremented every time storage usage counters are
+	// dropped, it's used to restart an ongoing rebuild.
+	storageUsageEpoch atomic.Uint32
+	// nep17BalancesReady is set when NEP-17 balances index is complete.
+	nep17BalancesReady atomic.Bool
+	// stateDiverged is set when local state divergence is detected and
+	// block processing is to be halted because of it.
+	stateDiverged atomic.Bool
+
+	// profiles keeps the latest block execution profiles, it's nil unless
+	// block profiling is enabled.
+	profiles *blockProfiles
+
+	// aerArchive keeps execution results of removed blocks, it's nil
+	// unless enabled.
+	aerArchive *aerarchive.Archive
+
+	// coldStore keeps old blocks moved out of the main DB, it's nil unless
+	// enabled.
+	coldStore coldstore.Store
+	// coldHeight is the index of the first block not yet moved to the cold
+	// storage.
+	coldHeight atomic.Uint32
+	// coldBytes is the total size of data moved to the cold storage, it's
+	// only accessed by the main loop.
+	coldBytes uint64
 
 	memPool *mempool.Pool
+	// admissionFilter is an additional check for transactions entering
+	// memPool, see TxAdmissionFilter.
+	admissionFilter atomic.Pointer[TxAdmissionFilter]
+
+	// tracer receives execution events of block processing, see SetTracer.
+	tracer atomic.Pointer[interop.Tracer]
 
 	// postBlock is a set of callback methods which should be run under the Blockchain lock after new block is persisted.
 	// Block's transactions are passed via mempool.
@@ -198,6 +245,7 @@ type StateRoot interface {
 	FindStates(root util.Uint256, prefix, start []byte, max int) ([]storage.KeyValue, error)
 	SeekStates(root util.Uint256, prefix []byte, f func(k, v []byte) bool)
 	GetState(root util.Uint256, key []byte) ([]byte, error)
+	HasState(root util.Uint256) bool
 	GetStateProof(root util.Uint256, key []byte) ([][]byte, error)
 	GetStateRoot(height uint32) (*state.MPTRoot, error)
 	GetLatestStateHeight(root util.Uint256) (uint32, error)
@@ -285,6 +333,10 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 	if cfg.Hardforks == nil {
 		cfg.Hardforks = map[string]uint32{}
 		for _, hf := range config.Hardforks {
+			if hf == config.HFNeoGoExtensions {
+				// Never enabled implicitly, see HFNeoGoExtensions.
+				continue
+			}
 			cfg.Hardforks[hf.String()] = 0
 		}
 		log.Info("Hardforks are not set, using default value")
@@ -292,6 +344,9 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		// Explicitly set the height of all old omitted hardforks to 0 for proper
 		// IsHardforkEnabled behaviour.
 		for _, hf := range config.Hardforks {
+			if hf == config.HFNeoGoExtensions {
+				break
+			}
 			if _, ok := cfg.Hardforks[hf.String()]; !ok {
 				cfg.Hardforks[hf.String()] = 0
 				continue
@@ -305,6 +360,30 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		cfg.Ledger.GarbageCollectionPeriod = defaultGCPeriod
 		log.Info("GarbageCollectionPeriod is not set or wrong, using default value", zap.Uint32("GarbageCollectionPeriod", cfg.Ledger.GarbageCollectionPeriod))
 	}
+	if cfg.Ledger.TrackBlockProfiles && cfg.Ledger.BlockProfilesCount == 0 {
+		cfg.Ledger.BlockProfilesCount = defaultBlockProfilesCount
+		log.Info("BlockProfilesCount is not set or wrong, using default value", zap.Uint32("BlockProfilesCount", cfg.Ledger.BlockProfilesCount))
+	}
+	if cfg.Ledger.RemoveUntraceableBlocks && cfg.Ledger.ChangelogDepth >= cfg.MaxTraceableBlocks {
+		return nil, fmt.Errorf("ChangelogDepth (%d) should be less than MaxTraceableBlocks (%d) if RemoveUntraceableBlocks is enabled",
+			cfg.Ledger.ChangelogDepth, cfg.MaxTraceableBlocks)
+	}
+	if cfg.Ledger.AERArchive.Enabled && !cfg.Ledger.RemoveUntraceableBlocks {
+		return nil, errors.New("AERArchive can only be enabled with RemoveUntraceableBlocks")
+	}
+	if cfg.Ledger.ColdStorage.Enabled {
+		if cfg.Ledger.RemoveUntraceableBlocks {
+			return nil, errors.New("ColdStorage can't be enabled with RemoveUntraceableBlocks")
+		}
+		if cfg.Ledger.ColdStorage.Depth == 0 {
+			cfg.Ledger.ColdStorage.Depth = cfg.MaxTraceableBlocks
+			log.Info("ColdStorage.Depth is not set or wrong, using default value", zap.Uint32("Depth", cfg.Ledger.ColdStorage.Depth))
+		}
+		if cfg.Ledger.ChangelogDepth >= cfg.Ledger.ColdStorage.Depth {
+			return nil, fmt.Errorf("ChangelogDepth (%d) should be less than ColdStorage.Depth (%d)",
+				cfg.Ledger.ChangelogDepth, cfg.Ledger.ColdStorage.Depth)
+		}
+	}
 	bc := &Blockchain{
 		config:      cfg,
 		dao:         dao.NewSimple(s, cfg.StateRootInHeader),
@@ -318,12 +397,40 @@ func NewBlockchain(s storage.Store, cfg config.Blockchain, log *zap.Logger) (*Bl
 		subCh:       make(chan any),
 		unsubCh:     make(chan any),
 		contracts:   *native.NewContracts(cfg.ProtocolConfiguration),
+		timeNow:     time.Now,
 	}
 
+	bc.dao.SetStorageUsageTracking(cfg.Ledger.TrackStorageUsage)
+	if cfg.Ledger.TrackNativeCallStats {
+		native.EnableCallStats(bc.contracts.Contracts)
+		setNativeCallStatsMetric(bc.contracts.Contracts)
+	}
+	if cfg.Ledger.TrackBlockProfiles {
+		bc.profiles = newBlockProfiles(cfg.Ledger.BlockProfilesCount)
+	}
+	bc.initParking()
+	if cfg.Ledger.AERArchive.Enabled {
+		a, err := aerarchive.New(cfg.Ledger.AERArchive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open AER archive: %w", err)
+		}
+		bc.aerArchive = a
+	}
+	if err := bc.initColdStorage(); err != nil {
+		return nil, err
+	}
 	bc.stateRoot = stateroot.NewModule(cfg, bc.VerifyWitness, bc.log, bc.dao.Store)
 	bc.contracts.Designate.StateRootService = bc.stateRoot
+	if cfg.Ledger.StateRootCheck.Enabled {
+		bc.stateRoot.SetMismatchCallback(func(local, validated *state.MPTRoot) {
+			bc.reportStateDivergence(validated.Index, local.Root, validated.Root, "state validators")
+		})
+	}
 
 	if err := bc.init(); err != nil {
+		if bc.coldStore != nil {
+			_ = bc.coldStore.Close()
+		}
 		return nil, err
 	}
 
@@ -346,18 +453,13 @@ func (bc *Blockchain) GetDesignatedByRole(r noderoles.Role) (keys.PublicKeys, ui
 func (bc *Blockchain) SetOracle(mod native.OracleService) {
 	orc := bc.contracts.Oracle
 	if mod != nil {
-		md, ok := orc.GetMethod(manifest.MethodVerify, -1)
-		if !ok {
-			panic(fmt.Errorf("%s method not found", manifest.MethodVerify))
-		}
-		mod.UpdateNativeContract(orc.NEF.Script, orc.GetOracleResponseScript(),
-			orc.Hash, md.MD.Offset)
-		keys, _, err := bc.GetDesignatedByRole(noderoles.Oracle)
+		mod.UpdateNativeContract(orc.GetOracleResponseScript(), orc.Hash)
+		keys, h, err := bc.GetDesignatedByRole(noderoles.Oracle)
 		if err != nil {
 			bc.log.Error("failed to get oracle key list")
 			return
 		}
-		mod.UpdateOracleNodes(keys)
+		mod.UpdateOracleNodes(h, keys)
 		reqs, err := bc.contracts.Oracle.GetRequests(bc.dao)
 		if err != nil {
 			bc.log.Error("failed to get current oracle request list")
@@ -369,6 +471,27 @@ func (bc *Blockchain) SetOracle(mod native.OracleService) {
 	bc.contracts.Designate.OracleService.Store(&mod)
 }
 
+// SetTracer sets the tracer receiving execution events of block processing
+// (transactions and OnPersist/PostPersist scripts are traced, test invocations
+// and verification scripts are not). It can safely be called on the running
+// blockchain. To disable tracing use SetTracer(nil).
+func (bc *Blockchain) SetTracer(t interop.Tracer) {
+	if t == nil {
+		bc.tracer.Store(nil)
+		return
+	}
+	bc.tracer.Store(&t)
+}
+
+// getTracer returns the current execution tracer, nil if tracing is disabled.
+func (bc *Blockchain) getTracer() interop.Tracer {
+	t := bc.tracer.Load()
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
 // SetNotary sets notary module. It may safely be called on the running blockchain.
 // To unregister Notary service use SetNotary(nil).
 func (bc *Blockchain) SetNotary(mod native.NotaryService) {
@@ -400,6 +523,14 @@ func (bc *Blockchain) init() error {
 		bc.dao.PutVersion(ver)
 		bc.dao.Version = ver
 		bc.persistent.Version = ver
+		if bc.config.Ledger.TrackStorageUsage {
+			bc.dao.Store.Put(storageUsageStateKey, []byte{1})
+			bc.storageUsageReady.Store(true)
+		}
+		if bc.config.Ledger.TrackNEP17Balances {
+			putNEP17BalancesState(bc.dao, 0)
+			bc.nep17BalancesReady.Store(true)
+		}
 		genesisBlock, err := CreateGenesisBlock(bc.config.ProtocolConfiguration)
 		if err != nil {
 			return err
@@ -435,6 +566,8 @@ func (bc *Blockchain) init() error {
 	}
 	bc.dao.Version = ver
 	bc.persistent.Version = ver
+	bc.initStorageUsage()
+	bc.initNEP17Balances()
 
 	// At this point there was no version found in the storage which
 	// implies a creating fresh storage with the version specified
@@ -505,7 +638,7 @@ func (bc *Blockchain) init() error {
 			return fmt.Errorf("failed to check native %s state against autogenerated one: %w", md.Name, err)
 		}
 		autogenCS := &state.Contract{
-			ContractBase:  md.ContractBase,
+			ContractBase:  md.HFSpecificContractMD(bc.hardforkEnabledAt(bHeight)).ContractBase,
 			UpdateCounter: storedCS.UpdateCounter, // it can be restored only from the DB, so use the stored value.
 		}
 		autogenCSBytes, err := stackitem.SerializeConvertible(autogenCS)
@@ -617,6 +750,9 @@ func (bc *Blockchain) jumpToStateInternal(p uint32, stage stateChangeStage) erro
 	})
 
 	bc.dao.Store.Delete(jumpStageKey)
+	bc.dao.Store.Delete([]byte{byte(storage.SYSStateSyncRoot)})
+	bc.invalidateStorageUsage()
+	bc.invalidateNEP17Balances()
 
 	err = bc.resetRAMState(p, false)
 	if err != nil {
@@ -666,7 +802,7 @@ func (bc *Blockchain) Reset(height uint32) error {
 	return bc.resetStateInternal(height, none)
 }
 
-func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage) error {
+func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage) (err error) {
 	// Cache isn't yet initialized, so retrieve block height right from DAO.
 	currHeight, err := bc.dao.GetCurrentBlockHeight()
 	if err != nil {
@@ -689,6 +825,9 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		if bc.config.Ledger.RemoveUntraceableBlocks && currHeight >= bc.config.MaxTraceableBlocks {
 			return fmt.Errorf("RemoveUntraceableBlocks is enabled, a necessary batch of traceable blocks has already been removed")
 		}
+		if err := bc.checkColdStorageBoundary(height); err != nil {
+			return err
+		}
 	}
 
 	// Retrieve necessary state before the DB modification.
@@ -717,29 +856,45 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 	p := start
 
 	// Start batch persisting routine, it will be used for blocks/txs/AERs/storage items batches persist.
+	// Once some batch fails to be persisted, subsequent ones are skipped, so that
+	// the DB keeps the last successfully persisted stage and the reset can be
+	// continued from it on the next start.
 	type postPersist func(persistedKeys int, err error) error
 	var (
 		persistCh       = make(chan postPersist)
 		persistToExitCh = make(chan struct{})
+		persistErr      error
+		persistStopped  bool
 	)
 	go func() {
-		for {
-			f, ok := <-persistCh
-			if !ok {
-				break
+		for f := range persistCh {
+			if persistErr != nil {
+				continue
 			}
-			persistErr := f(cache.Persist())
+			persistErr = f(cache.Persist())
 			if persistErr != nil {
-				bc.log.Fatal("persist failed", zap.Error(persistErr))
-				panic(persistErr)
+				bc.log.Error("persist failed", zap.Error(persistErr))
 			}
 		}
 		close(persistToExitCh)
 	}()
+	// stopPersist waits for all batches to be persisted and returns persisting
+	// error if any.
+	stopPersist := func() error {
+		if !persistStopped {
+			persistStopped = true
+			close(persistCh)
+			<-persistToExitCh
+		}
+		return persistErr
+	}
 	defer func() {
-		close(persistCh)
-		<-persistToExitCh
-		bc.log.Info("reset finished successfully", zap.Duration("took", time.Since(start)))
+		if perr := stopPersist(); perr != nil && err == nil {
+			err = fmt.Errorf("state reset failed: %w", perr)
+		}
+		if err == nil {
+			bc.log.Info("reset finished successfully", zap.Duration("took", time.Since(start)))
+		}
 	}()
 
 	resetStageKey := []byte{byte(storage.SYSStateChangeStage)}
@@ -750,7 +905,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		// MemCached storage, but we'd better use the sync version in case of some further code changes.
 		_, uerr := upperCache.PersistSync()
 		if uerr != nil {
-			panic(uerr)
+			return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 		}
 		upperCache = cache.GetPrivate()
 		persistCh <- func(persistedKeys int, err error) error {
@@ -788,7 +943,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 				persistBatch := batchCnt
 				_, uerr := upperCache.PersistSync()
 				if uerr != nil {
-					panic(uerr)
+					return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 				}
 				upperCache = cache.GetPrivate()
 				persistCh <- func(persistedKeys int, err error) error {
@@ -816,7 +971,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		persistBatch := batchCnt
 		_, uerr := upperCache.PersistSync()
 		if uerr != nil {
-			panic(uerr)
+			return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 		}
 		upperCache = cache.GetPrivate()
 		persistCh <- func(persistedKeys int, err error) error {
@@ -847,7 +1002,10 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		newStoragePrefix := statesync.TemporaryPrefix(oldStoragePrefix)
 
 		const persistBatchSize = 200000
-		var cnt, storageItmsCnt, batchCnt int
+		var (
+			cnt, storageItmsCnt, batchCnt int
+			seekErr                       error
+		)
 		trieStore.Seek(storage.SeekRange{Prefix: []byte{byte(oldStoragePrefix)}}, func(k, v []byte) bool {
 			if cnt >= persistBatchSize {
 				cnt = 0
@@ -858,9 +1016,9 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 
 				persistStart := time.Now()
 				persistBatch := batchCnt
-				_, uerr := upperCache.PersistSync()
-				if uerr != nil {
-					panic(uerr)
+				_, seekErr = upperCache.PersistSync()
+				if seekErr != nil {
+					return false
 				}
 				upperCache = cache.GetPrivate()
 				persistCh <- func(persistedKeys int, err error) error {
@@ -884,6 +1042,9 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 			return true
 		})
 		trieStore.Close()
+		if seekErr != nil {
+			return fmt.Errorf("failed to persist changes to the cache: %w", seekErr)
+		}
 
 		upperCache.Store.Put(resetStageKey, []byte{stateResetBit | byte(newStorageItemsAdded)})
 		batchCnt++
@@ -895,7 +1056,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		lastStart := time.Now()
 		_, uerr := upperCache.PersistSync()
 		if uerr != nil {
-			panic(uerr)
+			return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 		}
 		upperCache = cache.GetPrivate()
 		persistCh <- func(persistedKeys int, err error) error {
@@ -931,7 +1092,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		persistStart := time.Now()
 		_, uerr := upperCache.PersistSync()
 		if uerr != nil {
-			panic(uerr)
+			return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 		}
 		upperCache = cache.GetPrivate()
 		persistCh <- func(persistedKeys int, err error) error {
@@ -963,7 +1124,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 		persistStart := time.Now()
 		_, uerr := upperCache.PersistSync()
 		if uerr != nil {
-			panic(uerr)
+			return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 		}
 		upperCache = cache.GetPrivate()
 		persistCh <- func(persistedKeys int, err error) error {
@@ -999,6 +1160,10 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 	p = time.Now()
 
 	bc.log.Debug("trying to remove state reset point")
+	// Storage usage counters (if any) are outdated now, they're rebuilt
+	// on the next start. NEP-17 balances index is to be rebuilt manually.
+	upperCache.Store.Delete(storageUsageStateKey)
+	upperCache.Store.Delete(nep17BalancesStateKey)
 	upperCache.Store.Delete(resetStageKey)
 	// Unlike the state jump, state sync point must be removed as we have complete state for this height.
 	upperCache.Store.Delete([]byte{byte(storage.SYSStateSyncPoint)})
@@ -1007,7 +1172,7 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 	persistStart := time.Now()
 	_, uerr := upperCache.PersistSync()
 	if uerr != nil {
-		panic(uerr)
+		return fmt.Errorf("failed to persist changes to the cache: %w", uerr)
 	}
 	persistCh <- func(persistedKeys int, err error) error {
 		if err != nil {
@@ -1018,6 +1183,12 @@ func (bc *Blockchain) resetStateInternal(height uint32, stage stateChangeStage)
 	}
 	p = time.Now()
 
+	// In-memory state can only be updated after the DB is.
+	if err := stopPersist(); err != nil {
+		return fmt.Errorf("state reset failed: %w", err)
+	}
+	bc.invalidateStorageUsage()
+	bc.invalidateNEP17Balances()
 	err = bc.resetRAMState(height, true)
 	if err != nil {
 		return fmt.Errorf("failed to update in-memory blockchain data: %w", err)
@@ -1052,6 +1223,15 @@ func (bc *Blockchain) isHardforkEnabled(hf *config.Hardfork, blockHeight uint32)
 	return true
 }
 
+// hardforkEnabledAt returns a function checking whether the hardfork is
+// enabled at the given height.
+func (bc *Blockchain) hardforkEnabledAt(height uint32) func(config.Hardfork) bool {
+	return func(hf config.Hardfork) bool {
+		start, ok := bc.config.Hardforks[hf.String()]
+		return ok && start <= height
+	}
+}
+
 // Run runs chain loop, it needs to be run as goroutine and executing it is
 // critical for correct Blockchain operation.
 func (bc *Blockchain) Run() {
@@ -1065,10 +1245,18 @@ func (bc *Blockchain) Run() {
 		if err := bc.dao.Store.Close(); err != nil {
 			bc.log.Warn("failed to close db", zap.Error(err))
 		}
+		if bc.coldStore != nil {
+			if err := bc.coldStore.Close(); err != nil {
+				bc.log.Warn("failed to close cold storage", zap.Error(err))
+			}
+		}
 		bc.isRunning.Store(false)
 		close(bc.runToExitCh)
 	}()
 	go bc.notificationDispatcher()
+	if bc.config.Ledger.TrackStorageUsage && !bc.storageUsageReady.Load() {
+		bc.startStorageUsageRebuild()
+	}
 	var nextSync bool
 	for {
 		select {
@@ -1085,9 +1273,13 @@ func (bc *Blockchain) Run() {
 			if err != nil {
 				bc.log.Warn("failed to persist blockchain", zap.Error(err))
 			}
+			bc.persistFailed.Store(err != nil)
 			if bc.config.Ledger.RemoveUntraceableBlocks {
 				gcDur = bc.tryRunGC(oldPersisted)
 			}
+			if bc.coldStore != nil {
+				gcDur += bc.migrateToColdStorage()
+			}
 			nextSync = dur > persistInterval*2
 			interval := persistInterval - dur - gcDur
 			if interval <= 0 {
@@ -1455,6 +1647,9 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 	bc.addLock.Lock()
 	defer bc.addLock.Unlock()
 
+	if bc.stateDiverged.Load() {
+		return ErrStateDivergence
+	}
 	var mp *mempool.Pool
 	expectedHeight := bc.BlockHeight() + 1
 	if expectedHeight != block.Index {
@@ -1501,6 +1696,9 @@ func (bc *Blockchain) AddBlock(block *block.Block) error {
 // AddHeaders processes the given headers and add them to the
 // HeaderHashList. It expects headers to be sorted by index.
 func (bc *Blockchain) AddHeaders(headers ...*block.Header) error {
+	if bc.stateDiverged.Load() {
+		return ErrStateDivergence
+	}
 	return bc.addHeaders(!bc.config.SkipBlockVerification, headers...)
 }
 
@@ -1531,8 +1729,8 @@ func (bc *Blockchain) addHeaders(verify bool, headers ...*block.Header) error {
 		if lastHeader, err = bc.GetHeader(headers[0].PrevHash); err != nil {
 			return fmt.Errorf("previous header was not found: %w", err)
 		}
-		for _, h := range headers {
-			if err = bc.verifyHeader(h, lastHeader); err != nil {
+		for i, h := range headers {
+			if err = bc.verifyHeaderInBatch(h, lastHeader, headers[:i]); err != nil {
 				return err
 			}
 			lastHeader = h
@@ -1573,7 +1771,11 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 		appExecResults = make([]*state.AppExecResult, 0, 2+len(block.Transactions))
 		aerchan        = make(chan *state.AppExecResult, len(block.Transactions)/8) // Tested 8 and 4 with no practical difference, but feel free to test more and tune.
 		aerdone        = make(chan error)
+		profile        *blockProfileBuilder
 	)
+	if bc.profiles != nil {
+		profile = newBlockProfileBuilder(block.Index, block.Hash(), len(block.Transactions))
+	}
 	go func() {
 		var (
 			kvcache      = aerCache
@@ -1600,6 +1802,14 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				stop = start + 1
 			}
 			for index := start; index < stop; index++ {
+				if bc.aerArchive != nil {
+					err := bc.archiveBlock(kvcache, index)
+					if err != nil {
+						bc.log.Warn("failed to archive execution results of old block",
+							zap.Uint32("index", index),
+							zap.Error(err))
+					}
+				}
 				err := kvcache.DeleteBlock(bc.GetHeaderHash(index))
 				if err != nil {
 					bc.log.Warn("error while removing old block",
@@ -1619,6 +1829,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				err = kvcache.StoreAsTransaction(block.Transactions[txCnt], block.Index, aer)
 				txCnt++
 			}
+			if err == nil && aer.InvocationTree != nil {
+				err = kvcache.PutInvocationTree(aer.Container, aer.Trigger, aer.InvocationTree)
+			}
 			if err != nil {
 				err = fmt.Errorf("failed to store exec result: %w", err)
 				break
@@ -1663,12 +1876,20 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	appExecResults = append(appExecResults, aer)
 	aerchan <- aer
 
+	tracer := bc.getTracer()
 	for _, tx := range block.Transactions {
 		systemInterop := bc.newInteropContext(trigger.Application, cache, block, tx)
 		systemInterop.ReuseVM(v)
 		v.LoadScriptWithFlags(tx.Script, callflag.All)
 		v.GasLimit = tx.SystemFee
 
+		if profile != nil {
+			profile.startTx(systemInterop)
+		}
+		if bc.config.Ledger.SaveInvocationTrees {
+			systemInterop.InvocationTracker = interop.NewInvocationTracker(v.GetCurrentScriptHash())
+		}
+		systemInterop.Tracer = tracer
 		err := systemInterop.Exec()
 		var faultException string
 		if !v.HasFailed() {
@@ -1697,6 +1918,12 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 				FaultException: faultException,
 			},
 		}
+		if systemInterop.InvocationTracker != nil {
+			aer.InvocationTree = systemInterop.InvocationTracker.Finish(aer.GasConsumed)
+		}
+		if profile != nil {
+			profile.finishTx(systemInterop, aer)
+		}
 		appExecResults = append(appExecResults, aer)
 		aerchan <- aer
 	}
@@ -1727,6 +1954,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 			err = fmt.Errorf("failed to get next header: %w", err)
 		} else if h.PrevStateRoot != sr.Root {
 			err = fmt.Errorf("local stateroot and next header's PrevStateRoot mismatch: %s vs %s", sr.Root.StringBE(), h.PrevStateRoot.StringBE())
+			if bc.config.StateRootCheck.Enabled {
+				bc.reportStateDivergence(sr.Index, sr.Root, h.PrevStateRoot, "block header")
+			}
 		}
 		if err != nil {
 			// Release goroutines, don't care about errors, we already have one.
@@ -1750,6 +1980,12 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	if aererr != nil {
 		return aererr
 	}
+	if bc.config.Ledger.ChangelogDepth != 0 {
+		err = bc.storeChangelog(block.Index, cache, aerCache)
+		if err != nil {
+			return fmt.Errorf("failed to store changelog: %w", err)
+		}
+	}
 
 	bc.lock.Lock()
 	_, err = aerCache.Persist()
@@ -1768,6 +2004,7 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.topBlock.Store(block)
 	atomic.StoreUint32(&bc.blockHeight, block.Index)
 	bc.memPool.RemoveStale(func(tx *transaction.Transaction) bool { return bc.IsTxStillRelevant(tx, txpool, false) }, bc)
+	bc.promoteParkedTxs(block.Index)
 	for _, f := range bc.postBlock {
 		f(bc.IsTxStillRelevant, txpool, block)
 	}
@@ -1778,6 +2015,9 @@ func (bc *Blockchain) storeBlock(block *block.Block, txpool *mempool.Pool) error
 	bc.lock.Unlock()
 
 	updateBlockHeightMetric(block.Index)
+	if profile != nil {
+		bc.profiles.add(profile.finish())
+	}
 	// Genesis block is stored when Blockchain is not yet running, so there
 	// is no one to read this event. And it doesn't make much sense as event
 	// anyway.
@@ -1844,12 +2084,16 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.S
 		systemInterop.ReuseVM(v)
 	}
 	v.LoadScriptWithFlags(script, callflag.All)
+	if bc.config.Ledger.SaveInvocationTrees {
+		systemInterop.InvocationTracker = interop.NewInvocationTracker(v.GetCurrentScriptHash())
+	}
+	systemInterop.Tracer = bc.getTracer()
 	if err := systemInterop.Exec(); err != nil {
 		return nil, v, fmt.Errorf("VM has failed: %w", err)
 	} else if _, err := systemInterop.DAO.Persist(); err != nil {
 		return nil, v, fmt.Errorf("can't save changes: %w", err)
 	}
-	return &state.AppExecResult{
+	aer := &state.AppExecResult{
 		Container: block.Hash(), // application logs can be retrieved by block hash
 		Execution: state.Execution{
 			Trigger:     trig,
@@ -1858,7 +2102,11 @@ func (bc *Blockchain) runPersist(script []byte, block *block.Block, cache *dao.S
 			Stack:       v.Estack().ToArray(),
 			Events:      systemInterop.Notifications,
 		},
-	}, v, nil
+	}
+	if systemInterop.InvocationTracker != nil {
+		aer.InvocationTree = systemInterop.InvocationTracker.Finish(aer.GasConsumed)
+	}
+	return aer, v, nil
 }
 
 func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.Simple,
@@ -1868,18 +2116,22 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.S
 	}
 	arr, ok := note.Item.Value().([]stackitem.Item)
 	if !ok || !(len(arr) == 3 || len(arr) == 4) {
+		bc.markNEP17Unreliable(d, note.ScriptHash)
 		return
 	}
-	from, err := parseUint160(arr[0])
+	from, err := eventdecode.Hash160(arr[0])
 	if err != nil {
+		bc.markNEP17Unreliable(d, note.ScriptHash)
 		return
 	}
-	to, err := parseUint160(arr[1])
+	to, err := eventdecode.Hash160(arr[1])
 	if err != nil {
+		bc.markNEP17Unreliable(d, note.ScriptHash)
 		return
 	}
 	amount, err := arr[2].TryInteger()
 	if err != nil {
+		bc.markNEP17Unreliable(d, note.ScriptHash)
 		return
 	}
 	var id []byte
@@ -1892,18 +2144,6 @@ func (bc *Blockchain) handleNotification(note *state.NotificationEvent, d *dao.S
 	bc.processTokenTransfer(d, transCache, h, b, note.ScriptHash, from, to, amount, id)
 }
 
-func parseUint160(itm stackitem.Item) (util.Uint160, error) {
-	_, ok := itm.(stackitem.Null) // Minting or burning.
-	if ok {
-		return util.Uint160{}, nil
-	}
-	bytes, err := itm.TryBytes()
-	if err != nil {
-		return util.Uint160{}, err
-	}
-	return util.Uint160DecodeBytesBE(bytes)
-}
-
 func (bc *Blockchain) processTokenTransfer(cache *dao.Simple, transCache map[util.Uint160]transferData,
 	h util.Uint256, b *block.Block, sc util.Uint160, from util.Uint160, to util.Uint160,
 	amount *big.Int, tokenID []byte) {
@@ -1931,6 +2171,7 @@ func (bc *Blockchain) processTokenTransfer(cache *dao.Simple, transCache map[uti
 			Tx:           h,
 		}
 		transfer = nep17xfer
+		bc.updateNEP17Balances(cache, sc, from, to, amount)
 	} else {
 		nep11xfer := &state.NEP11Transfer{
 			NEP17Transfer: state.NEP17Transfer{
@@ -2143,6 +2384,9 @@ func (bc *Blockchain) persist(isSync bool) (time.Duration, error) {
 
 		// update monitoring metrics.
 		updatePersistedHeightMetric(bHeight)
+		if bc.storageUsageReady.Load() {
+			bc.updateStorageUsageMetrics(bc.persistent)
+		}
 	}
 
 	return duration, nil
@@ -2159,7 +2403,99 @@ func (bc *Blockchain) GetTransaction(hash util.Uint256) (*transaction.Transactio
 // GetAppExecResults returns application execution results with the specified trigger by the given
 // tx hash or block hash.
 func (bc *Blockchain) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
-	return bc.dao.GetAppExecResults(hash, trig)
+	aers, err := bc.dao.GetAppExecResults(hash, trig)
+	if err != nil || !bc.config.Ledger.SaveInvocationTrees {
+		return aers, err
+	}
+	for i := range aers {
+		// Trees may be missing for executions done before the setting was
+		// enabled.
+		t, err := bc.dao.GetInvocationTree(hash, aers[i].Trigger)
+		if err == nil {
+			aers[i].InvocationTree = t
+		} else if !errors.Is(err, storage.ErrKeyNotFound) {
+			return nil, err
+		}
+	}
+	return aers, nil
+}
+
+// archiveBlock saves execution results of the block with the given index into
+// the AER archive.
+func (bc *Blockchain) archiveBlock(d *dao.Simple, index uint32) error {
+	h := bc.GetHeaderHash(index)
+	b, err := d.GetBlock(h)
+	if err != nil {
+		return err
+	}
+	rec := &aerarchive.Record{
+		Index:    index,
+		Hash:     h,
+		TxHashes: make([]util.Uint256, len(b.Transactions)),
+	}
+	rec.Results, err = d.GetAppExecResults(h, trigger.All)
+	if err != nil {
+		return err
+	}
+	for i, tx := range b.Transactions {
+		rec.TxHashes[i] = tx.Hash()
+		aers, err := d.GetAppExecResults(rec.TxHashes[i], trigger.All)
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", rec.TxHashes[i].StringLE(), err)
+		}
+		rec.Results = append(rec.Results, aers...)
+	}
+	return bc.aerArchive.Put(rec)
+}
+
+// GetArchivedAppExecResults returns application execution results with the
+// specified trigger by the given tx hash or block hash from the AER archive.
+// Archived data is checked against the block header stored in the chain. It
+// returns storage.ErrKeyNotFound if the archive is disabled or has no data for
+// the hash.
+func (bc *Blockchain) GetArchivedAppExecResults(h util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
+	if bc.aerArchive == nil {
+		return nil, storage.ErrKeyNotFound
+	}
+	rec, err := bc.aerArchive.Get(h)
+	if err != nil {
+		if errors.Is(err, aerarchive.ErrNotFound) {
+			return nil, storage.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	if rec.Index > bc.HeaderHeight() || !bc.GetHeaderHash(rec.Index).Equals(rec.Hash) {
+		return nil, fmt.Errorf("archived block %d is not a part of the chain", rec.Index)
+	}
+	hdr, err := bc.GetHeader(rec.Hash)
+	if err != nil {
+		return nil, err
+	}
+	if !hash.CalcMerkleRoot(rec.TxHashes).Equals(hdr.MerkleRoot) {
+		return nil, fmt.Errorf("archived block %d transactions don't match the header", rec.Index)
+	}
+	var (
+		found  = h.Equals(rec.Hash)
+		res    []state.AppExecResult
+		hashes = make(map[util.Uint256]bool, len(rec.TxHashes)+1)
+	)
+	hashes[rec.Hash] = true
+	for _, txh := range rec.TxHashes {
+		hashes[txh] = true
+		found = found || txh.Equals(h)
+	}
+	if !found {
+		return nil, fmt.Errorf("archived block %d doesn't contain %s", rec.Index, h.StringLE())
+	}
+	for i := range rec.Results {
+		if !hashes[rec.Results[i].Container] {
+			return nil, fmt.Errorf("archived block %d contains unrelated execution result", rec.Index)
+		}
+		if rec.Results[i].Container.Equals(h) && rec.Results[i].Trigger&trig != 0 {
+			res = append(res, rec.Results[i])
+		}
+	}
+	return res, nil
 }
 
 // GetStorageItem returns an item from storage.
@@ -2169,7 +2505,15 @@ func (bc *Blockchain) GetStorageItem(id int32, key []byte) state.StorageItem {
 
 // SeekStorage performs seek operation over contract storage. Prefix is trimmed in the resulting pair's key.
 func (bc *Blockchain) SeekStorage(id int32, prefix []byte, cont func(k, v []byte) bool) {
-	bc.dao.Seek(id, storage.SeekRange{Prefix: prefix}, cont)
+	// Use a snapshot if possible for the results to reflect a single
+	// state even if some block is being stored or persisted concurrently.
+	d, err := bc.dao.GetSnapshot()
+	if err != nil {
+		d = bc.dao
+	} else {
+		defer d.Store.Close()
+	}
+	d.Seek(id, storage.SeekRange{Prefix: prefix}, cont)
 }
 
 // GetBlock returns a Block by the given hash.
@@ -2270,11 +2614,30 @@ func (bc *Blockchain) GetNativeContractScriptHash(name string) (util.Uint160, er
 func (bc *Blockchain) GetNatives() []state.NativeContract {
 	res := make([]state.NativeContract, 0, len(bc.contracts.Contracts))
 	for _, c := range bc.contracts.Contracts {
-		res = append(res, c.Metadata().NativeContract)
+		md := c.Metadata().HFSpecificContractMD(bc.hardforkEnabledAt(bc.BlockHeight()))
+		res = append(res, state.NativeContract{ContractBase: md.ContractBase})
 	}
 	return res
 }
 
+// GetNativeActivationHeight returns the height native contract with the given
+// hash is active from. False is returned for unknown contracts and for the
+// ones activated by hardforks that are not enabled on the network.
+func (bc *Blockchain) GetNativeActivationHeight(h util.Uint160) (uint32, bool) {
+	for _, c := range bc.contracts.Contracts {
+		if !c.Metadata().Hash.Equals(h) {
+			continue
+		}
+		hf := c.ActiveIn()
+		if hf == nil {
+			return 0, true
+		}
+		height, ok := bc.config.Hardforks[hf.String()]
+		return height, ok
+	}
+	return 0, false
+}
+
 // GetConfig returns the config stored in the blockchain.
 func (bc *Blockchain) GetConfig() config.Blockchain {
 	return bc.config
@@ -2419,6 +2782,18 @@ func (bc *Blockchain) FeePerByte() int64 {
 	return bc.contracts.Policy.GetFeePerByteInternal(bc.dao)
 }
 
+// IsRunning returns true if blockchain routines are currently running (see
+// Run).
+func (bc *Blockchain) IsRunning() bool {
+	return bc.isRunning.Load().(bool)
+}
+
+// IsPersistFailing returns true if the latest attempt to persist blockchain
+// changes to the DB has failed. It's reset after the next successful attempt.
+func (bc *Blockchain) IsPersistFailing() bool {
+	return bc.persistFailed.Load()
+}
+
 // GetMemPool returns the memory pool of the blockchain.
 func (bc *Blockchain) GetMemPool() *mempool.Pool {
 	return bc.memPool
@@ -2467,14 +2842,26 @@ var (
 	ErrHdrHashMismatch     = errors.New("previous header hash doesn't match")
 	ErrHdrIndexMismatch    = errors.New("previous header index doesn't match")
 	ErrHdrInvalidTimestamp = errors.New("block is not newer than the previous one")
+	ErrHdrFutureTimestamp  = errors.New("block timestamp is too far in the future")
 	ErrHdrStateRootSetting = errors.New("state root setting mismatch")
 	ErrHdrInvalidStateRoot = errors.New("state root for previous block is invalid")
 )
 
 func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
+	return bc.verifyHeaderInBatch(currHeader, prevHeader, nil)
+}
+
+// verifyHeaderInBatch verifies the header following prevHeader, batch
+// contains preceding headers that are not yet stored (if any).
+func (bc *Blockchain) verifyHeaderInBatch(currHeader, prevHeader *block.Header, batch []*block.Header) error {
 	if bc.config.StateRootInHeader {
 		if bc.stateRoot.CurrentLocalHeight() == prevHeader.Index {
 			if sr := bc.stateRoot.CurrentLocalStateRoot(); currHeader.PrevStateRoot != sr {
+				// Unlike the stored ones, this header is not yet
+				// verified, so it can be a forged one.
+				if bc.config.StateRootCheck.Enabled && bc.verifyHeaderWitnesses(currHeader, prevHeader) == nil {
+					bc.reportStateDivergence(prevHeader.Index, sr, currHeader.PrevStateRoot, "block header")
+				}
 				return fmt.Errorf("%w: %s != %s",
 					ErrHdrInvalidStateRoot, currHeader.PrevStateRoot.StringLE(), sr.StringLE())
 			}
@@ -2486,12 +2873,81 @@ func (bc *Blockchain) verifyHeader(currHeader, prevHeader *block.Header) error {
 	if prevHeader.Index+1 != currHeader.Index {
 		return ErrHdrIndexMismatch
 	}
-	if prevHeader.Timestamp >= currHeader.Timestamp {
-		return ErrHdrInvalidTimestamp
+	if err := bc.verifyHeaderTimestamp(currHeader, prevHeader, batch); err != nil {
+		return err
 	}
 	return bc.verifyHeaderWitnesses(currHeader, prevHeader)
 }
 
+// verifyHeaderTimestamp checks the header timestamp according to the
+// TimestampValidation protocol setting.
+func (bc *Blockchain) verifyHeaderTimestamp(currHeader, prevHeader *block.Header, batch []*block.Header) error {
+	tv := bc.config.TimestampValidation
+	if !tv.IsMedian() {
+		if prevHeader.Timestamp >= currHeader.Timestamp {
+			return ErrHdrInvalidTimestamp
+		}
+		return nil
+	}
+	minTS, err := bc.minNextTimestamp(prevHeader, batch)
+	if err != nil {
+		return err
+	}
+	if currHeader.Timestamp < minTS {
+		return fmt.Errorf("%w: %d is not greater than the median timestamp of the last %d blocks",
+			ErrHdrInvalidTimestamp, currHeader.Timestamp, tv.GetMedianWindow())
+	}
+	maxTS := uint64(bc.timeNow().Add(tv.MaxFutureDrift).UnixMilli())
+	if currHeader.Timestamp > maxTS {
+		return fmt.Errorf("%w: %d > %d", ErrHdrFutureTimestamp, currHeader.Timestamp, maxTS)
+	}
+	return nil
+}
+
+// GetMinNextBlockTimestamp returns the minimum timestamp (in milliseconds)
+// allowed for the block following the given header according to the
+// TimestampValidation protocol setting.
+func (bc *Blockchain) GetMinNextBlockTimestamp(prev *block.Header) (uint64, error) {
+	return bc.minNextTimestamp(prev, nil)
+}
+
+// minNextTimestamp returns the minimum timestamp of the block following prev,
+// batch contains preceding headers that are not yet stored (if any).
+func (bc *Blockchain) minNextTimestamp(prev *block.Header, batch []*block.Header) (uint64, error) {
+	tv := bc.config.TimestampValidation
+	if !tv.IsMedian() {
+		return prev.Timestamp + 1, nil
+	}
+	var (
+		window = int(tv.GetMedianWindow())
+		ts     = make([]uint64, 0, window)
+		h      = prev
+	)
+	for {
+		ts = append(ts, h.Timestamp)
+		if len(ts) == window || h.Index == 0 {
+			break
+		}
+		var parent *block.Header
+		for i := len(batch) - 1; i >= 0; i-- {
+			if batch[i].Hash() == h.PrevHash {
+				parent = batch[i]
+				break
+			}
+		}
+		if parent == nil {
+			var err error
+			parent, err = bc.GetHeader(h.PrevHash)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get header %d: %w", h.Index-1, err)
+			}
+		}
+		h = parent
+	}
+	sort.Slice(ts, func(i, j int) bool { return ts[i] < ts[j] })
+	return ts[(len(ts)-1)/2] + 1, nil
+}
+
 // Various errors that could be returned upon verification.
 var (
 	ErrTxExpired         = errors.New("transaction has expired")
@@ -2536,7 +2992,7 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 		return fmt.Errorf("%w: net fee is %v, need %v", ErrTxSmallNetworkFee, t.NetworkFee, needNetworkFee)
 	}
 	// check that current tx wasn't included in the conflicts attributes of some other transaction which is already in the chain
-	if err := bc.dao.HasTransaction(t.Hash(), t.Signers, height, bc.config.MaxTraceableBlocks); err != nil {
+	if err := bc.dao.HasTransaction(t.Hash(), t.Signers, height, bc.GetMaxTraceableBlocks()); err != nil {
 		switch {
 		case errors.Is(err, dao.ErrAlreadyExists):
 			return ErrAlreadyExists
@@ -2551,8 +3007,18 @@ func (bc *Blockchain) verifyAndPoolTx(t *transaction.Transaction, pool *mempool.
 		return err
 	}
 	if err := bc.verifyTxAttributes(bc.dao, t, isPartialTx); err != nil {
+		if pool == bc.memPool && errors.Is(err, ErrTxNotYetValid) {
+			return bc.parkTx(t, err)
+		}
 		return err
 	}
+	// Node-local filter is only applied to the main pool, block and
+	// consensus transactions are verified using other pools.
+	if pool == bc.memPool {
+		if err := bc.checkTxAdmission(t); err != nil {
+			return err
+		}
+	}
 	err = pool.Add(t, feer, data...)
 	if err != nil {
 		switch {
@@ -2596,6 +3062,7 @@ func (bc *Blockchain) CalculateAttributesFee(tx *transaction.Transaction) int64
 }
 
 func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transaction, isPartialTx bool) error {
+	var notYetValid error
 	for i := range tx.Attributes {
 		switch attrType := tx.Attributes[i].Type; attrType {
 		case transaction.HighPriority:
@@ -2647,7 +3114,9 @@ func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transact
 				}
 			} else {
 				if curHeight < nvb {
-					return fmt.Errorf("%w: transaction is not yet valid: NotValidBefore = %d, current height = %d", ErrInvalidAttribute, nvb, curHeight)
+					// Other attributes are still checked, the transaction
+					// can be parked if it's the only problem.
+					notYetValid = fmt.Errorf("%w: %w: NotValidBefore = %d, current height = %d", ErrInvalidAttribute, ErrTxNotYetValid, nvb, curHeight)
 				}
 			}
 		case transaction.ConflictsT:
@@ -2664,13 +3133,19 @@ func (bc *Blockchain) verifyTxAttributes(d *dao.Simple, tx *transaction.Transact
 			if !tx.HasSigner(bc.contracts.Notary.Hash) {
 				return fmt.Errorf("%w: NotaryAssisted attribute was found, but transaction is not signed by the Notary native contract", ErrInvalidAttribute)
 			}
+		case transaction.SponsorT:
+			if !bc.hardforkEnabledAt(bc.BlockHeight() + 1)(config.HFNeoGoExtensions) {
+				return fmt.Errorf("%w: Sponsor attribute was found, but %s hardfork is not enabled", ErrInvalidAttribute, config.HFNeoGoExtensions)
+			}
+			// Transaction structure check ensures that the sponsor is one of
+			// the signers, so its witness is always verified.
 		default:
 			if !bc.config.ReservedAttributes && attrType >= transaction.ReservedLowerBound && attrType <= transaction.ReservedUpperBound {
 				return fmt.Errorf("%w: attribute of reserved type was found, but ReservedAttributes are disabled", ErrInvalidAttribute)
 			}
 		}
 	}
-	return nil
+	return notYetValid
 }
 
 // IsTxStillRelevant is a callback for mempool transaction filtering after the
@@ -2689,7 +3164,7 @@ func (bc *Blockchain) IsTxStillRelevant(t *transaction.Transaction, txpool *memp
 		return false
 	}
 	if txpool == nil {
-		if bc.dao.HasTransaction(t.Hash(), t.Signers, curheight, bc.config.MaxTraceableBlocks) != nil {
+		if bc.dao.HasTransaction(t.Hash(), t.Signers, curheight, bc.GetMaxTraceableBlocks()) != nil {
 			return false
 		}
 	} else if txpool.HasConflicts(t, bc) {
@@ -2794,7 +3269,18 @@ func (bc *Blockchain) GetTestVM(t trigger.Type, tx *transaction.Transaction, b *
 			return nil, fmt.Errorf("failed to create fake block for height %d: %w", h, err)
 		}
 	}
-	systemInterop := bc.newInteropContext(t, bc.dao, b, tx)
+	// Test invocations never change the chain state, so they use an
+	// immutable snapshot of it if possible to avoid blocking (and being
+	// blocked by) the persisting process. It also keeps iterators consistent
+	// for their whole lifetime, the snapshot is released on Finalize.
+	d, err := bc.dao.GetSnapshot()
+	if err != nil {
+		d = bc.dao
+	}
+	systemInterop := bc.newInteropContext(t, d, b, tx)
+	if d != bc.dao {
+		systemInterop.RegisterCancelFunc(func() { _ = d.Store.Close() })
+	}
 	_ = systemInterop.SpawnVM() // All the other code suppose that the VM is ready.
 	return systemInterop, nil
 }
@@ -2845,7 +3331,7 @@ func (bc *Blockchain) getFakeNextBlock(nextBlockHeight uint32) (*block.Block, er
 	if err != nil {
 		return nil, err
 	}
-	b.Timestamp = hdr.Timestamp + uint64(bc.config.TimePerBlock/time.Millisecond)
+	b.Timestamp = hdr.Timestamp + uint64(bc.GetMillisecondsPerBlock())
 	return b, nil
 }
 
@@ -3049,6 +3535,32 @@ func (bc *Blockchain) GetBaseExecFee() int64 {
 	return bc.contracts.Policy.GetExecFeeFactorInternal(bc.dao)
 }
 
+// GetMillisecondsPerBlock returns the current block time. It's the one from
+// the protocol configuration unless changed by the committee via the Policy
+// contract. The value is taken from the latest persisted state, so changes
+// made in some block are effective since the next one.
+func (bc *Blockchain) GetMillisecondsPerBlock() uint32 {
+	if bc.BlockHeight() != 0 {
+		if v := bc.contracts.Policy.GetMillisecondsPerBlockInternal(bc.dao); v != 0 {
+			return v
+		}
+	}
+	return uint32(bc.config.TimePerBlock / time.Millisecond)
+}
+
+// GetMaxTraceableBlocks returns the current MaxTraceableBlocks value. It's the
+// one from the protocol configuration unless changed by the committee via the
+// Policy contract (it can only be decreased this way, so the configuration
+// value is still used for old data removal).
+func (bc *Blockchain) GetMaxTraceableBlocks() uint32 {
+	if bc.BlockHeight() != 0 {
+		if v := bc.contracts.Policy.GetMaxTraceableBlocksInternal(bc.dao); v != 0 {
+			return v
+		}
+	}
+	return bc.config.MaxTraceableBlocks
+}
+
 // GetMaxVerificationGAS returns maximum verification GAS Policy limit.
 func (bc *Blockchain) GetMaxVerificationGAS() int64 {
 	return bc.contracts.Policy.GetMaxVerificationGas(bc.dao)
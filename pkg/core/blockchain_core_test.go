@@ -3,8 +3,10 @@ package core
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -14,10 +16,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -50,6 +56,88 @@ func TestVerifyHeader(t *testing.T) {
 	})
 }
 
+func TestVerifyHeader_MedianTimestamp(t *testing.T) {
+	const interval = 500 // Sub-second blocks.
+	var (
+		// Clock skews (in ms) of consensus nodes producing subsequent blocks.
+		skews = []int64{300, -300, 200, -250, 0, 300, -300, 100}
+		// Blocks are produced a minute ago, so the drift doesn't matter.
+		base   = time.Now().Add(-time.Minute).UnixMilli()
+		median = func(c *config.Config) {
+			c.ProtocolConfiguration.TimestampValidation = config.TimestampValidation{
+				Mode:           config.TimestampModeMedian,
+				MedianWindow:   3,
+				MaxFutureDrift: time.Second,
+			}
+		}
+	)
+	genBlocks := func(bc *Blockchain) []*block.Block {
+		var (
+			res  = make([]*block.Block, 0, len(skews))
+			prev = bc.topBlock.Load().(*block.Block).Hash()
+		)
+		for i, skew := range skews {
+			b := newBlockCustom(bc.config.ProtocolConfiguration, func(b *block.Block) {
+				b.PrevHash = prev
+				b.Index = uint32(i) + 1
+				b.Timestamp = uint64(base + int64(i+1)*interval + skew)
+			})
+			res = append(res, b)
+			prev = b.Hash()
+		}
+		return res
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		bc := newTestChain(t)
+		blocks := genBlocks(bc)
+		require.NoError(t, bc.AddBlock(blocks[0]))
+		require.ErrorIs(t, bc.AddBlock(blocks[1]), ErrHdrInvalidTimestamp)
+	})
+	t.Run("median", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, median)
+		for _, b := range genBlocks(bc) {
+			require.NoError(t, bc.AddBlock(b))
+		}
+		top := bc.topBlock.Load().(*block.Block)
+		minTS, err := bc.GetMinNextBlockTimestamp(&top.Header)
+		require.NoError(t, err)
+		// Median of the last three blocks is the sixth one (with 300ms skew)
+		// even though the seventh one is older.
+		require.Equal(t, uint64(base+6*interval+300+1), minTS)
+
+		next := func(ts uint64) *block.Block {
+			return newBlockCustom(bc.config.ProtocolConfiguration, func(b *block.Block) {
+				b.PrevHash = top.Hash()
+				b.Index = top.Index + 1
+				b.Timestamp = ts
+			})
+		}
+		require.ErrorIs(t, bc.AddBlock(next(minTS-1)), ErrHdrInvalidTimestamp)
+
+		future := uint64(time.Now().Add(5 * time.Second).UnixMilli())
+		require.ErrorIs(t, bc.AddBlock(next(future)), ErrHdrFutureTimestamp)
+		bc.timeNow = func() time.Time { return time.Now().Add(5 * time.Second) }
+		require.NoError(t, bc.AddBlock(next(minTS)))
+	})
+	t.Run("median, headers batch", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, median)
+		blocks := genBlocks(bc)
+		hdrs := make([]*block.Header, 0, len(blocks))
+		for _, b := range blocks {
+			hdrs = append(hdrs, &b.Header)
+		}
+		bad := newBlockCustom(bc.config.ProtocolConfiguration, func(b *block.Block) {
+			b.PrevHash = blocks[len(blocks)-1].Hash()
+			b.Index = uint32(len(blocks)) + 1
+			b.Timestamp = uint64(base + 5*interval)
+		})
+		require.ErrorIs(t, bc.AddHeaders(append(hdrs, &bad.Header)...), ErrHdrInvalidTimestamp)
+		require.NoError(t, bc.AddHeaders(hdrs...))
+		require.Equal(t, uint32(len(blocks)), bc.HeaderHeight())
+	})
+}
+
 func TestAddBlock(t *testing.T) {
 	const size = 3
 	bc := newTestChain(t)
@@ -360,6 +448,86 @@ func TestBlockchain_IsRunning(t *testing.T) {
 	require.False(t, chain.isRunning.Load().(bool))
 }
 
+func TestBlockchain_PersistFault(t *testing.T) {
+	var (
+		errFull = errors.New("disk full")
+		ms      = storage.NewMemoryStore()
+		fs      = storage.NewFaultStore(ms)
+		bc      = newTestChainWithCustomCfgAndStore(t, fs, nil)
+		inDB    = func(b *block.Block) bool {
+			_, err := ms.Get(append([]byte{byte(storage.DataExecutable)}, b.Hash().BytesBE()...))
+			return err == nil
+		}
+	)
+	_, err := bc.persist(true)
+	require.NoError(t, err)
+	vals, err := bc.GetNextBlockValidators()
+	require.NoError(t, err)
+
+	// Fault is set before adding blocks, so the persisting routine can't
+	// flush them to the DB either.
+	fs.FailPutChangeSet(1, errFull)
+	blocks, err := bc.genBlocks(3)
+	require.NoError(t, err)
+	_, err = bc.persist(false)
+	require.ErrorIs(t, err, errFull)
+
+	// Nothing is in the DB, but everything is still available from the cache.
+	require.Equal(t, uint32(0), atomic.LoadUint32(&bc.persistedHeight))
+	require.Equal(t, uint32(3), bc.BlockHeight())
+	for _, b := range blocks {
+		require.False(t, inDB(b))
+		actual, err := bc.GetBlock(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), actual.Hash())
+	}
+	actualVals, err := bc.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Equal(t, vals, actualVals)
+
+	// The chain keeps working while the DB is failing.
+	more, err := bc.genBlocks(2)
+	require.NoError(t, err)
+	blocks = append(blocks, more...)
+	_, err = bc.persist(true)
+	require.ErrorIs(t, err, errFull)
+
+	// Retry succeeds once the fault is cleared.
+	fs.Heal()
+	_, err = bc.persist(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(5), atomic.LoadUint32(&bc.persistedHeight))
+	for _, b := range blocks {
+		require.True(t, inDB(b))
+	}
+
+	// Another instance sees the same chain.
+	restored := initTestChain(t, ms, nil)
+	require.Equal(t, uint32(5), restored.BlockHeight())
+	require.Equal(t, bc.CurrentBlockHash(), restored.CurrentBlockHash())
+	actualVals, err = restored.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Equal(t, vals, actualVals)
+}
+
+func TestBlockchain_PersistFaultRunning(t *testing.T) {
+	var (
+		errIO = errors.New("IO error")
+		fs    = storage.NewFaultStore(storage.NewMemoryStore())
+		bc    = newTestChainWithCustomCfgAndStore(t, fs, nil)
+	)
+	fs.FailPutChangeSet(1, errIO)
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	require.Eventually(t, bc.IsPersistFailing, 2*persistInterval, 100*time.Millisecond)
+	require.Equal(t, uint32(0), atomic.LoadUint32(&bc.persistedHeight))
+
+	fs.Heal()
+	require.NoError(t, bc.AddBlock(bc.newBlock()))
+	require.Eventually(t, func() bool {
+		return !bc.IsPersistFailing() && atomic.LoadUint32(&bc.persistedHeight) == 2
+	}, 2*persistInterval, 100*time.Millisecond)
+}
+
 func TestNewBlockchain_InitHardforks(t *testing.T) {
 	t.Run("empty set", func(t *testing.T) {
 		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
@@ -369,6 +537,30 @@ func TestNewBlockchain_InitHardforks(t *testing.T) {
 		require.Equal(t, map[string]uint32{
 			config.HFAspidochelone.String(): 0,
 			config.HFBasilisk.String():      0,
+			config.HFCockatrice.String():    0,
+		}, bc.GetConfig().Hardforks)
+	})
+	t.Run("not set", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.Hardforks = nil
+			require.NoError(t, c.ProtocolConfiguration.Validate())
+		})
+		require.Equal(t, map[string]uint32{
+			config.HFAspidochelone.String(): 0,
+			config.HFBasilisk.String():      0,
+			config.HFCockatrice.String():    0,
+		}, bc.GetConfig().Hardforks)
+	})
+	t.Run("extensions only", func(t *testing.T) {
+		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
+			c.ProtocolConfiguration.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 5}
+			require.NoError(t, c.ProtocolConfiguration.Validate())
+		})
+		require.Equal(t, map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): 5,
 		}, bc.GetConfig().Hardforks)
 	})
 	t.Run("missing old", func(t *testing.T) {
@@ -392,12 +584,204 @@ func TestNewBlockchain_InitHardforks(t *testing.T) {
 	})
 	t.Run("all present", func(t *testing.T) {
 		bc := newTestChainWithCustomCfg(t, func(c *config.Config) {
-			c.ProtocolConfiguration.Hardforks = map[string]uint32{config.HFAspidochelone.String(): 5, config.HFBasilisk.String(): 10}
+			c.ProtocolConfiguration.Hardforks = map[string]uint32{config.HFAspidochelone.String(): 5, config.HFBasilisk.String(): 10, config.HFCockatrice.String(): 15, config.HFNeoGoExtensions.String(): 15}
 			require.NoError(t, c.ProtocolConfiguration.Validate())
 		})
 		require.Equal(t, map[string]uint32{
-			config.HFAspidochelone.String(): 5,
-			config.HFBasilisk.String():      10,
+			config.HFAspidochelone.String():   5,
+			config.HFBasilisk.String():        10,
+			config.HFCockatrice.String():      15,
+			config.HFNeoGoExtensions.String(): 15,
 		}, bc.GetConfig().Hardforks)
 	})
 }
+
+func TestBlockchain_GetTestVMConcurrentPersist(t *testing.T) {
+	const (
+		invokers = 100
+		blocks   = 20
+	)
+	var (
+		bc   = newTestChain(t)
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+		w    = io.NewBufBinWriter()
+	)
+	emit.AppCall(w.BinWriter, bc.contracts.GAS.Hash, "totalSupply", callflag.ReadStates)
+	emit.AppCall(w.BinWriter, bc.contracts.Policy.Hash, "getFeePerByte", callflag.ReadStates)
+	require.NoError(t, w.Err)
+	script := w.Bytes()
+
+	for i := 0; i < invokers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				ic, err := bc.GetTestVM(trigger.Application, nil, nil)
+				if !assert.NoError(t, err) {
+					return
+				}
+				ic.VM.GasLimit = -1
+				ic.VM.LoadScriptWithFlags(script, callflag.All)
+				err = ic.VM.Run()
+				ic.Finalize()
+				if !assert.NoError(t, err) || !assert.Equal(t, 2, ic.VM.Estack().Len()) {
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < blocks; i++ {
+		_, err := bc.genBlocks(1)
+		require.NoError(t, err)
+		_, err = bc.persist(i%2 == 0)
+		require.NoError(t, err)
+	}
+	close(done)
+	wg.Wait()
+}
+
+func TestBlockchain_SeekStorageConsistency(t *testing.T) {
+	const (
+		id      = 1
+		keys    = 1000
+		stride  = 10 // Every batch changes every stride-th key.
+		batches = 300
+		page    = 50
+		pagers  = 4
+		iters   = 2
+	)
+	for name, newStore := range map[string]func(testing.TB) storage.Store{
+		"memory": func(testing.TB) storage.Store { return storage.NewMemoryStore() },
+		"LevelDB": func(t testing.TB) storage.Store {
+			st, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: t.TempDir()})
+			require.NoError(t, err)
+			return st
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				bc      = newTestChainWithCustomCfgAndStore(t, newStore(t), nil)
+				written atomic.Uint32
+				done    = make(chan struct{})
+				wg      sync.WaitGroup
+			)
+			// write imitates block processing, every batch is an atomic
+			// change of some keys to the batch number.
+			write := func(n uint32, all bool) {
+				cache := bc.dao.GetPrivate()
+				for i := 0; i < keys; i++ {
+					if all || uint32(i)%stride == n%stride {
+						cache.PutStorageItem(id, binary.BigEndian.AppendUint16(nil, uint16(i)),
+							binary.LittleEndian.AppendUint32(nil, n))
+					}
+				}
+				_, err := cache.Persist()
+				require.NoError(t, err)
+				written.Store(n)
+			}
+			// collect adds key-value pair to the set.
+			collect := func(kvs map[uint16]uint32, k, v []byte) {
+				kvs[binary.BigEndian.Uint16(k)] = binary.LittleEndian.Uint32(v)
+			}
+			// check ensures that all values are the same as after some
+			// single batch (every set contains all key residues, so the
+			// latest value is the batch number).
+			check := func(kvs map[uint16]uint32) bool {
+				var last uint32
+				for _, v := range kvs {
+					if v > last {
+						last = v
+					}
+				}
+				for k, v := range kvs {
+					var (
+						r        = uint32(k) % stride
+						expected uint32
+					)
+					if last >= r {
+						expected = last - (last-r)%stride
+					}
+					if !assert.Equal(t, expected, v, "key %d, batch %d", k, last) {
+						return false
+					}
+				}
+				return true
+			}
+			isDone := func() bool {
+				select {
+				case <-done:
+					return true
+				default:
+					return false
+				}
+			}
+			write(0, true)
+
+			// findstorage-like paging, every page is a separate seek.
+			for i := 0; i < pagers; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for start := 0; !isDone(); start = (start + page) % keys {
+						var (
+							kvs = make(map[uint16]uint32)
+							j   int
+						)
+						bc.SeekStorage(id, nil, func(k, v []byte) bool {
+							if j >= start {
+								collect(kvs, k, v)
+							}
+							j++
+							return len(kvs) < page
+						})
+						if !assert.Len(t, kvs, page) || !check(kvs) {
+							return
+						}
+					}
+				}()
+			}
+			// Long-living iterators waiting for changes between pages.
+			for i := 0; i < iters; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for !isDone() {
+						ic, err := bc.GetTestVM(trigger.Application, nil, nil)
+						if !assert.NoError(t, err) {
+							return
+						}
+						kvs := make(map[uint16]uint32)
+						ic.DAO.Seek(id, storage.SeekRange{}, func(k, v []byte) bool {
+							collect(kvs, k, v)
+							if len(kvs)%page == 0 {
+								for w := written.Load(); w == written.Load() && !isDone(); {
+									time.Sleep(time.Millisecond)
+								}
+							}
+							return true
+						})
+						ic.Finalize()
+						if !assert.Len(t, kvs, keys) || !check(kvs) {
+							return
+						}
+					}
+				}()
+			}
+			for n := uint32(1); n <= batches; n++ {
+				write(n, false)
+				if n%3 == 0 {
+					_, err := bc.persist(n%2 == 0)
+					require.NoError(t, err)
+				}
+			}
+			close(done)
+			wg.Wait()
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"math/rand"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -18,7 +19,9 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/aerarchive"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/coldstore"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
@@ -39,6 +42,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -315,6 +319,112 @@ func TestBlockchain_InitializeNeoCache_Bug3181(t *testing.T) {
 	})
 }
 
+func TestBlockchain_NativeMethodActivation(t *testing.T) {
+	const extensionsHeight = 3
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	customConfig := func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): extensionsHeight,
+		}
+	}
+	bc, validators, committee, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, customConfig, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	stdHash := e.NativeHash(t, nativenames.StdLib)
+	stdInvoker := e.ValidatorInvoker(stdHash)
+
+	oldState := bc.GetContractState(stdHash)
+	require.NotNil(t, oldState)
+	require.Nil(t, oldState.Manifest.ABI.GetMethod("jsonPath", 2))
+	stdInvoker.InvokeFail(t, "method not found: jsonPath/2", "jsonPath", []byte(`{"a":1}`), "$.a")
+	oracleHash := e.NativeHash(t, nativenames.Oracle)
+	oldOracleState := bc.GetContractState(oracleHash)
+	require.NotNil(t, oldOracleState)
+	require.Nil(t, oldOracleState.Manifest.ABI.GetMethod("cancelRequest", 1))
+	require.Nil(t, oldOracleState.Manifest.ABI.GetEvent("OracleCancel"))
+	cryptoHash := e.NativeHash(t, nativenames.CryptoLib)
+	cryptoMethods := []struct {
+		name   string
+		params int
+	}{{"sha256Init", 0}, {"sha256Update", 2}, {"sha256Final", 1}, {"merkleRoot", 1}}
+	oldCryptoState := bc.GetContractState(cryptoHash)
+	require.NotNil(t, oldCryptoState)
+	for _, m := range cryptoMethods {
+		require.Nil(t, oldCryptoState.Manifest.ABI.GetMethod(m.name, m.params), m.name)
+	}
+	neoHash := e.NativeHash(t, nativenames.Neo)
+	oldNeoState := bc.GetContractState(neoHash)
+	require.NotNil(t, oldNeoState)
+	require.Nil(t, oldNeoState.Manifest.ABI.GetMethod("unclaimedGasDetailed", 1))
+	require.Nil(t, oldNeoState.Manifest.ABI.GetMethod("getVoterInfo", 1))
+	require.Nil(t, oldNeoState.Manifest.ABI.GetMethod("claimGas", 1))
+	mgmtHash := e.NativeHash(t, nativenames.Management)
+	oldMgmtState := bc.GetContractState(mgmtHash)
+	require.NotNil(t, oldMgmtState)
+	require.Nil(t, oldMgmtState.Manifest.ABI.GetMethod("getContractsIterator", 0))
+
+	// Stored native state must match the hardfork-specific one on restart.
+	bc.Close()
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	bc, _, _, err = chain.NewMultiWithCustomConfigAndStoreNoCheck(t, customConfig, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	e = neotest.NewExecutor(t, bc, validators, committee)
+	stdInvoker = e.ValidatorInvoker(stdHash)
+
+	for bc.BlockHeight() < extensionsHeight {
+		e.AddNewBlock(t)
+	}
+	newState := bc.GetContractState(stdHash)
+	require.NotNil(t, newState)
+	require.NotNil(t, newState.Manifest.ABI.GetMethod("jsonPath", 2))
+	require.NotEqual(t, oldState.NEF.Checksum, newState.NEF.Checksum)
+	require.Equal(t, oldState.UpdateCounter, newState.UpdateCounter)
+	newOracleState := bc.GetContractState(oracleHash)
+	require.NotNil(t, newOracleState)
+	require.NotNil(t, newOracleState.Manifest.ABI.GetMethod("cancelRequest", 1))
+	require.NotNil(t, newOracleState.Manifest.ABI.GetEvent("OracleCancel"))
+	newCryptoState := bc.GetContractState(cryptoHash)
+	require.NotNil(t, newCryptoState)
+	for _, m := range cryptoMethods {
+		require.NotNil(t, newCryptoState.Manifest.ABI.GetMethod(m.name, m.params), m.name)
+	}
+	require.NotEqual(t, oldCryptoState.NEF.Checksum, newCryptoState.NEF.Checksum)
+	newNeoState := bc.GetContractState(neoHash)
+	require.NotNil(t, newNeoState)
+	require.NotNil(t, newNeoState.Manifest.ABI.GetMethod("unclaimedGasDetailed", 1))
+	require.NotNil(t, newNeoState.Manifest.ABI.GetMethod("getVoterInfo", 1))
+	require.NotNil(t, newNeoState.Manifest.ABI.GetMethod("claimGas", 1))
+	require.NotEqual(t, oldNeoState.NEF.Checksum, newNeoState.NEF.Checksum)
+	newMgmtState := bc.GetContractState(mgmtHash)
+	require.NotNil(t, newMgmtState)
+	require.NotNil(t, newMgmtState.Manifest.ABI.GetMethod("getContractsIterator", 0))
+	require.NotEqual(t, oldMgmtState.NEF.Checksum, newMgmtState.NEF.Checksum)
+	e.ValidatorInvoker(neoHash).Invoke(t, stackitem.Null{}, "getVoterInfo", util.Uint160{})
+	e.ValidatorInvoker(cryptoHash).Invoke(t, stackitem.Make(make([]byte, 32)), "merkleRoot", []any{make([]byte, 32)})
+	h1 := stdInvoker.Invoke(t, stackitem.Make([]byte("[1]")), "jsonPath", []byte(`{"a":1}`), "$.a")
+	h2 := stdInvoker.Invoke(t, stackitem.Make([]byte("[1]")), "jsonPath", []byte(`{"a":1,"bcdefgh":2}`), "$.a")
+	// The price depends on the input size.
+	aer1, err := bc.GetAppExecResults(h1, trigger.Application)
+	require.NoError(t, err)
+	aer2, err := bc.GetAppExecResults(h2, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, int64(12*16)*bc.GetBaseExecFee(), aer2[0].GasConsumed-aer1[0].GasConsumed)
+	// Methods that were available before are still callable with new offsets.
+	stdInvoker.Invoke(t, stackitem.Make("42"), "itoa", 42)
+
+	bc.Close()
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	bc, _, _, err = chain.NewMultiWithCustomConfigAndStoreNoCheck(t, customConfig, ps)
+	require.NoError(t, err)
+	go bc.Run()
+	bc.Close()
+}
+
 // This test enables Notary native contract at non-zero height and checks that no
 // Notary cache initialization is performed before that height on node restart.
 /*
@@ -490,6 +600,61 @@ func TestBlockchain_AddHeadersStateRoot(t *testing.T) {
 	require.NoError(t, bc.AddHeaders(&b.Header))
 }
 
+func TestBlockchain_StateRootCheck(t *testing.T) {
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	customConfig := func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+	}
+	bc, acc := chain.NewSingleWithCustomConfig(t, customConfig)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Create the node DB in sync with the network.
+	b1 := e.AddNewBlock(t)
+	node, _ := chain.NewSingleWithCustomConfigAndStore(t, customConfig, ps, false)
+	go node.Run()
+	require.NoError(t, node.AddBlock(b1))
+	node.Close()
+
+	// Corrupt sender's GAS balance, it's not reflected in MPT.
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	cache := storage.NewMemCachedStore(ps)
+	d := dao.NewSimple(cache, true)
+	gasID := e.NativeID(t, nativenames.Gas)
+	key := append([]byte{20}, acc.ScriptHash().BytesBE()...)
+	si := d.GetStorageItem(gasID, key)
+	require.NotNil(t, si)
+	bal, err := state.NEP17BalanceFromBytes(si)
+	require.NoError(t, err)
+	bal.Balance.Add(&bal.Balance, big.NewInt(1))
+	d.PutStorageItem(gasID, key, bal.Bytes(nil))
+	_, err = d.Persist()
+	require.NoError(t, err)
+
+	node, _ = chain.NewSingleWithCustomConfigAndStore(t, func(c *config.Blockchain) {
+		customConfig(c)
+		c.StateRootCheck.Enabled = true
+		c.StateRootCheck.HaltOnMismatch = true
+	}, cache, true)
+
+	gasInvoker := e.NewInvoker(e.NativeHash(t, nativenames.Gas), acc)
+	gasInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	b3 := e.AddNewBlock(t)
+	b2, err := bc.GetBlock(b3.PrevHash)
+	require.NoError(t, err)
+
+	// Block execution results in different state, but it's only noticed
+	// with the next block.
+	require.NoError(t, node.AddBlock(b2))
+	local, err := node.GetStateRoot(2)
+	require.NoError(t, err)
+	require.NotEqual(t, b3.PrevStateRoot, local.Root)
+
+	require.ErrorIs(t, node.AddBlock(b3), core.ErrHdrInvalidStateRoot)
+	require.ErrorIs(t, node.AddBlock(b3), core.ErrStateDivergence)
+	require.ErrorIs(t, node.AddHeaders(&b3.Header), core.ErrStateDivergence)
+	require.EqualValues(t, 2, node.BlockHeight())
+}
+
 func TestBlockchain_AddBadBlock(t *testing.T) {
 	check := func(t *testing.T, b *block.Block, cfg func(c *config.Blockchain)) {
 		bc, _ := chain.NewSingleWithCustomConfig(t, cfg)
@@ -761,6 +926,103 @@ func TestBlockchain_IsTxStillRelevant(t *testing.T) {
 	})
 }
 
+func TestBlockchain_TxAdmissionFilter(t *testing.T) {
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.VerifyTransactions = true
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	denied := e.NewAccount(t)
+	allowed := e.NewAccount(t)
+
+	newTx := func(acc neotest.Signer) *transaction.Transaction {
+		return e.NewTx(t, []neotest.Signer{acc}, gasHash, "transfer",
+			acc.ScriptHash(), validator.ScriptHash(), 1, nil)
+	}
+
+	bc.SetTxAdmissionFilter(core.NewSignerDenyList(denied.ScriptHash()))
+
+	tx := newTx(denied)
+	require.ErrorIs(t, bc.PoolTx(tx), core.ErrTxAdmission)
+	require.False(t, bc.GetMemPool().ContainsKey(tx.Hash()))
+	require.NoError(t, bc.PoolTx(newTx(allowed)))
+
+	t.Run("other pool", func(t *testing.T) {
+		// Consensus verifies proposed transactions using its own pool.
+		mp := mempool.New(10, 0, false, nil)
+		require.NoError(t, bc.PoolTx(tx, mp))
+		require.NoError(t, bc.VerifyTx(tx))
+	})
+
+	t.Run("block from other node", func(t *testing.T) {
+		b := e.AddNewBlock(t, tx)
+		require.Equal(t, 1, len(b.Transactions))
+		e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+	})
+
+	t.Run("filter removed", func(t *testing.T) {
+		bc.SetTxAdmissionFilter(nil)
+		require.NoError(t, bc.PoolTx(newTx(denied)))
+	})
+}
+
+func TestBlockchain_MemPoolParking(t *testing.T) {
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.MemPoolParking = config.MemPoolParking{
+			Enabled:     true,
+			PerSender:   2,
+			MaxDistance: 3,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	acc := e.NewAccount(t)
+	mp := bc.GetMemPool()
+
+	newTx := func(nvb uint32, vub uint32) *transaction.Transaction {
+		tx := e.NewUnsignedTx(t, gasHash, "transfer", acc.ScriptHash(), validator.ScriptHash(), 1, nil)
+		tx.ValidUntilBlock = vub
+		tx.Attributes = append(tx.Attributes, transaction.Attribute{
+			Type:  transaction.NotValidBeforeT,
+			Value: &transaction.NotValidBefore{Height: nvb},
+		})
+		return e.SignTx(t, tx, -1, acc)
+	}
+
+	h := bc.BlockHeight()
+	tx1 := newTx(h+1, h+10)
+	require.ErrorIs(t, bc.PoolTx(tx1), core.ErrTxParked)
+	require.True(t, mp.IsParked(tx1.Hash()))
+	require.False(t, mp.ContainsKey(tx1.Hash()))
+	require.ErrorIs(t, bc.PoolTx(tx1), core.ErrAlreadyInPool)
+
+	tooFar := newTx(h+4, h+10)
+	require.ErrorIs(t, bc.PoolTx(tooFar), core.ErrTxNotYetValid)
+	require.False(t, mp.IsParked(tooFar.Hash()))
+
+	tx2 := newTx(h+2, h+2)
+	require.ErrorIs(t, bc.PoolTx(tx2), core.ErrTxParked)
+	tx3 := newTx(h+1, h+10) // Per-sender limit is reached.
+	err := bc.PoolTx(tx3)
+	require.ErrorIs(t, err, core.ErrTxNotYetValid)
+	require.ErrorIs(t, err, mempool.ErrParkingFull)
+	require.Equal(t, 2, mp.ParkedCount())
+
+	// Other pools don't park transactions.
+	require.ErrorIs(t, bc.PoolTx(tx3, mempool.New(10, 0, false, nil)), core.ErrTxNotYetValid)
+
+	e.AddNewBlock(t)
+	require.True(t, mp.ContainsKey(tx1.Hash()))
+	require.False(t, mp.IsParked(tx1.Hash()))
+	require.True(t, mp.IsParked(tx2.Hash()))
+
+	e.AddNewBlock(t, tx1)
+	e.CheckHalt(t, tx1.Hash(), stackitem.Make(true))
+	require.False(t, mp.IsParked(tx2.Hash())) // Expired.
+	require.False(t, mp.ContainsKey(tx2.Hash()))
+	require.Equal(t, 0, mp.ParkedCount())
+}
+
 func TestBlockchain_MemPoolRemoval(t *testing.T) {
 	const added = 16
 	const notAdded = 32
@@ -1061,6 +1323,82 @@ func TestBlockchain_RemoveUntraceable(t *testing.T) {
 	})
 }
 
+func TestBlockchain_AERArchive(t *testing.T) {
+	t.Run("no RemoveUntraceableBlocks", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.AERArchive = config.AERArchive{Enabled: true, Path: t.TempDir()}
+		}, nil)
+		require.Error(t, err)
+	})
+	t.Run("unknown backend", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.MaxTraceableBlocks = 2
+			c.Ledger.RemoveUntraceableBlocks = true
+			c.Ledger.AERArchive = config.AERArchive{Enabled: true, Backend: "s3", Path: t.TempDir()}
+		}, nil)
+		require.Error(t, err)
+	})
+
+	archivePath := t.TempDir()
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.MaxTraceableBlocks = 2
+		c.Ledger.GarbageCollectionPeriod = 2
+		c.Ledger.RemoveUntraceableBlocks = true
+		c.Ledger.AERArchive = config.AERArchive{Enabled: true, Path: archivePath}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoValidatorInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+
+	txHash := neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	b := e.TopBlock(t)
+	txAERs, err := bc.GetAppExecResults(txHash, trigger.All)
+	require.NoError(t, err)
+	blockAERs, err := bc.GetAppExecResults(b.Hash(), trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blockAERs))
+
+	// Not yet archived.
+	_, err = bc.GetArchivedAppExecResults(txHash, trigger.All)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	e.GenerateNewBlocks(t, 4)
+
+	// Pruned from the DB, but available from the archive.
+	_, err = bc.GetAppExecResults(txHash, trigger.All)
+	require.Error(t, err)
+	actual, err := bc.GetArchivedAppExecResults(txHash, trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, txAERs, actual)
+	actual, err = bc.GetArchivedAppExecResults(b.Hash(), trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, blockAERs, actual)
+	actual, err = bc.GetArchivedAppExecResults(b.Hash(), trigger.OnPersist)
+	require.NoError(t, err)
+	require.Equal(t, blockAERs[:1], actual)
+
+	_, err = bc.GetArchivedAppExecResults(util.Uint256{1, 2, 3}, trigger.All)
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	// Archived data that doesn't match the chain is rejected.
+	a, err := aerarchive.New(config.AERArchive{Path: archivePath})
+	require.NoError(t, err)
+	require.NoError(t, a.Put(&aerarchive.Record{
+		Index:    b.Index,
+		Hash:     b.Hash(),
+		TxHashes: []util.Uint256{txHash, {1, 2, 3}},
+		Results:  append(blockAERs, txAERs...),
+	}))
+	_, err = bc.GetArchivedAppExecResults(txHash, trigger.All)
+	require.ErrorContains(t, err, "don't match the header")
+	require.NoError(t, a.Put(&aerarchive.Record{
+		Index:    b.Index + 1,
+		Hash:     b.Hash(),
+		TxHashes: []util.Uint256{txHash},
+		Results:  append(blockAERs, txAERs...),
+	}))
+	_, err = bc.GetArchivedAppExecResults(txHash, trigger.All)
+	require.ErrorContains(t, err, "not a part of the chain")
+}
 func TestBlockchain_InvalidNotification(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -2215,6 +2553,131 @@ func TestBlockchain_VerifyTx(t *testing.T) {
 	})
 }
 
+func TestBlockchain_Sponsor(t *testing.T) {
+	const extensionsHeight = 6
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): extensionsHeight,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	policyInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+
+	sponsor := e.NewAccount(t)
+	sender := e.NewAccount(t)
+	poorAcc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	poor := neotest.NewSingleSigner(poorAcc)
+
+	newTx := func(t *testing.T, sponsor util.Uint160, sysFee int64, signers ...neotest.Signer) *transaction.Transaction {
+		tx := e.NewUnsignedTx(t, gasHash, "symbol")
+		tx.ValidUntilBlock = bc.BlockHeight() + 3
+		if !sponsor.Equals(util.Uint160{}) {
+			tx.Attributes = append(tx.Attributes, transaction.Attribute{
+				Type:  transaction.SponsorT,
+				Value: &transaction.Sponsor{Account: sponsor},
+			})
+		}
+		return e.SignTx(t, tx, sysFee, signers...)
+	}
+	balance := func(acc util.Uint160) *big.Int {
+		return bc.GetUtilityTokenBalance(acc)
+	}
+	// checkPaid adds a block with the transaction and checks that fees are
+	// paid by the payer only.
+	checkPaid := func(t *testing.T, tx *transaction.Transaction, payer util.Uint160, others ...util.Uint160) {
+		payerBefore := balance(payer)
+		othersBefore := make([]*big.Int, len(others))
+		for i := range others {
+			othersBefore[i] = balance(others[i])
+		}
+		b := e.AddNewBlock(t, tx)
+		e.CheckHalt(t, tx.Hash(), stackitem.Make("GAS"))
+		e.CheckGASBalance(t, payer, new(big.Int).Sub(payerBefore, big.NewInt(tx.SystemFee+tx.NetworkFee)))
+		for i := range others {
+			e.CheckGASBalance(t, others[i], othersBefore[i])
+		}
+
+		aers, err := bc.GetAppExecResults(b.Hash(), trigger.OnPersist)
+		require.NoError(t, err)
+		require.Equal(t, state.NotificationEvent{
+			ScriptHash: gasHash,
+			Name:       "Transfer",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.Make(payer),
+				stackitem.Null{},
+				stackitem.Make(tx.SystemFee + tx.NetworkFee),
+			}),
+		}, aers[0].Events[0])
+	}
+
+	t.Run("before hardfork", func(t *testing.T) {
+		require.True(t, bc.BlockHeight()+1 < extensionsHeight)
+		tx := newTx(t, sponsor.ScriptHash(), -1, sender, sponsor)
+		require.ErrorIs(t, bc.PoolTx(tx), core.ErrInvalidAttribute)
+
+		policyInvoker.InvokeFail(t, "invalid attribute type", "getAttributeFee", int64(transaction.SponsorT))
+		policyInvoker.InvokeFail(t, "invalid attribute type", "setAttributeFee", int64(transaction.SponsorT), 1)
+
+		checkPaid(t, newTx(t, util.Uint160{}, -1, sender, sponsor), sender.ScriptHash(), sponsor.ScriptHash())
+	})
+
+	for bc.BlockHeight()+1 < extensionsHeight {
+		e.AddNewBlock(t)
+	}
+
+	t.Run("not a signer", func(t *testing.T) {
+		tx := newTx(t, sponsor.ScriptHash(), -1, sender)
+		_, err := transaction.NewTransactionFromBytes(tx.Bytes())
+		require.ErrorIs(t, err, transaction.ErrInvalidAttribute)
+	})
+	t.Run("sponsor pays", func(t *testing.T) {
+		require.Equal(t, 0, balance(poor.ScriptHash()).Sign())
+		tx := newTx(t, sponsor.ScriptHash(), -1, poor, sponsor)
+		require.Equal(t, sponsor.ScriptHash(), tx.FeePayer())
+		checkPaid(t, tx, sponsor.ScriptHash(), poor.ScriptHash())
+	})
+	t.Run("sponsor is not the first signer", func(t *testing.T) {
+		tx := newTx(t, sponsor.ScriptHash(), -1, sponsor, sender)
+		checkPaid(t, tx, sponsor.ScriptHash(), sender.ScriptHash())
+	})
+	t.Run("sponsor without balance", func(t *testing.T) {
+		tx := newTx(t, poor.ScriptHash(), -1, sender, poor)
+		require.ErrorIs(t, bc.PoolTx(tx), core.ErrInsufficientFunds)
+	})
+	t.Run("sponsor equals sender", func(t *testing.T) {
+		tx := newTx(t, sender.ScriptHash(), -1, sender)
+		plain := newTx(t, util.Uint160{}, -1, sender)
+		require.Equal(t, plain.NetworkFee+int64(1+util.Uint160Size)*bc.FeePerByte(), tx.NetworkFee) // Attribute size only.
+		checkPaid(t, tx, sender.ScriptHash(), sponsor.ScriptHash())
+
+		tx = newTx(t, poor.ScriptHash(), -1, poor)
+		require.ErrorIs(t, bc.PoolTx(tx), core.ErrInsufficientFunds)
+	})
+	t.Run("no sponsor", func(t *testing.T) {
+		checkPaid(t, newTx(t, util.Uint160{}, -1, sender, sponsor), sender.ScriptHash(), sponsor.ScriptHash())
+		policyInvoker.Invoke(t, 0, "getAttributeFee", int64(transaction.SponsorT))
+	})
+	t.Run("mempool accounts sponsored fees", func(t *testing.T) {
+		half := balance(sponsor.ScriptHash()).Int64() / 2
+		tx1 := newTx(t, sponsor.ScriptHash(), half, poor, sponsor)
+		tx2 := newTx(t, sponsor.ScriptHash(), half, sender, sponsor)
+		require.NoError(t, bc.PoolTx(tx1))
+		require.ErrorIs(t, bc.PoolTx(tx2), core.ErrMemPoolConflict)
+
+		// The sender can still send its own transactions.
+		tx3 := newTx(t, util.Uint160{}, half, sender, sponsor)
+		require.NoError(t, bc.PoolTx(tx3))
+		e.AddNewBlock(t, tx1, tx3)
+		e.CheckHalt(t, tx1.Hash())
+		e.CheckHalt(t, tx3.Hash())
+	})
+}
+
 func TestBlockchain_Bug1728(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -2272,6 +2735,45 @@ func TestBlockchain_ResetStateErrors(t *testing.T) {
 	})
 }
 
+func TestBlockchain_ResetStateFault(t *testing.T) {
+	const (
+		chainHeight = 5
+		resetHeight = 2
+	)
+	db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+	bc, validators, committee := chain.NewMultiWithCustomConfigAndStore(t, nil, db, false)
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	go bc.Run()
+	for i := 0; i < chainHeight; i++ {
+		e.AddNewBlock(t)
+	}
+	resetHash := bc.GetHeaderHash(resetHeight)
+	bc.Close()
+
+	db, _ = newLevelDBForTestingWithPath(t, path)
+	defer db.Close()
+	var (
+		errFull = errors.New("disk full")
+		fs      = storage.NewFaultStore(db)
+	)
+	bc, _, _ = chain.NewMultiWithCustomConfigAndStore(t, nil, fs, false)
+	require.Equal(t, uint32(chainHeight), bc.BlockHeight())
+
+	// The first batch (reset start marker) is persisted, the second one fails.
+	fs.FailPutChangeSet(2, errFull)
+	err := bc.Reset(resetHeight)
+	require.ErrorIs(t, err, errFull)
+	// In-memory state is not changed if the DB is not updated.
+	require.Equal(t, uint32(chainHeight), bc.BlockHeight())
+
+	// Reset is continued on the next start once the fault is cleared.
+	fs.Heal()
+	bc, _, _ = chain.NewMultiWithCustomConfigAndStore(t, nil, fs, false)
+	require.Equal(t, uint32(resetHeight), bc.BlockHeight())
+	require.Equal(t, uint32(resetHeight), bc.HeaderHeight())
+	require.Equal(t, resetHash, bc.CurrentBlockHash())
+}
+
 // TestBlockchain_ResetState is based on knowledge about basic chain transactions,
 // it performs basic chain reset and checks that reset chain has proper state.
 func TestBlockchain_ResetState(t *testing.T) {
@@ -2447,6 +2949,86 @@ func TestBlockchain_ResetState(t *testing.T) {
 	require.Equal(t, expectedLUB, lub)
 }
 
+func TestBlockchain_Rollback(t *testing.T) {
+	const (
+		depth     = 5
+		blocksCnt = 10
+		rollback  = 3
+	)
+	check := func(t *testing.T, keepOnlyLatest bool) {
+		cfg := func(c *config.Blockchain) {
+			c.Ledger.ChangelogDepth = depth
+			c.Ledger.KeepOnlyLatestState = keepOnlyLatest
+		}
+		db, path := newLevelDBForTestingWithPath(t, t.TempDir())
+		bc, validators, committee := chain.NewMultiWithCustomConfigAndStore(t, cfg, db, false)
+		go bc.Run()
+		e := neotest.NewExecutor(t, bc, validators, committee)
+		gas := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+		acc := random.Uint160()
+
+		for i := 0; i < blocksCnt; i++ {
+			gas.Invoke(t, true, "transfer", e.Validator.ScriptHash(), acc, 1_0000_0000, nil)
+		}
+		var (
+			top         = bc.BlockHeight()
+			target      = top - rollback
+			staleHash   = bc.GetHeaderHash(target + 1)
+			expectedBal = bc.GetUtilityTokenBalance(acc).Int64() - rollback*1_0000_0000
+		)
+		sr, err := bc.GetStateModule().GetStateRoot(target)
+		require.NoError(t, err)
+		staleBlock, err := bc.GetBlock(staleHash)
+		require.NoError(t, err)
+		require.Error(t, bc.Rollback(target), "running chain")
+		bc.Close()
+
+		db, _ = newLevelDBForTestingWithPath(t, path)
+		defer db.Close()
+		bc, _, _ = chain.NewMultiWithCustomConfigAndStore(t, cfg, db, false)
+
+		// Changelog is kept for the last depth blocks only, nothing is changed.
+		require.ErrorContains(t, bc.Rollback(top-depth-1), fmt.Sprintf("no changelog for block %d", top-depth))
+		require.Equal(t, top, bc.BlockHeight())
+		require.Equal(t, top, bc.HeaderHeight())
+
+		require.NoError(t, bc.Rollback(target))
+		require.Equal(t, target, bc.BlockHeight())
+		require.Equal(t, target, bc.HeaderHeight())
+		require.Equal(t, sr.Root, bc.GetStateModule().CurrentLocalStateRoot())
+		require.Equal(t, target, bc.GetStateModule().CurrentLocalHeight())
+		_, err = bc.GetStateModule().GetStateRoot(target + 1)
+		require.Error(t, err)
+		_, err = bc.GetBlock(staleHash)
+		require.Error(t, err)
+		_, err = bc.GetHeader(staleHash)
+		require.Error(t, err)
+		_, _, err = bc.GetTransaction(staleBlock.Transactions[0].Hash())
+		require.Error(t, err)
+		require.Equal(t, expectedBal, bc.GetUtilityTokenBalance(acc).Int64())
+
+		// The chain is consistent and can be continued.
+		go bc.Run()
+		defer bc.Close()
+		e = neotest.NewExecutor(t, bc, validators, committee)
+		gas = e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+		gas.Invoke(t, true, "transfer", e.Validator.ScriptHash(), acc, 1_0000_0000, nil)
+		require.Equal(t, target+1, bc.BlockHeight())
+		require.Equal(t, expectedBal+1_0000_0000, bc.GetUtilityTokenBalance(acc).Int64())
+	}
+	t.Run("full state", func(t *testing.T) { check(t, false) })
+	t.Run("KeepOnlyLatestState", func(t *testing.T) { check(t, true) })
+
+	t.Run("changelog depth with RemoveUntraceableBlocks", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.ChangelogDepth = 10
+			c.Ledger.RemoveUntraceableBlocks = true
+			c.MaxTraceableBlocks = 10
+		}, nil)
+		require.ErrorContains(t, err, "ChangelogDepth (10) should be less than MaxTraceableBlocks (10)")
+	})
+}
+
 func TestBlockchain_GenesisTransactionExtension(t *testing.T) {
 	priv0 := testchain.PrivateKeyByID(0)
 	acc0 := wallet.NewAccountFromPrivateKey(priv0)
@@ -2479,3 +3061,550 @@ func TestBlockchain_GenesisTransactionExtension(t *testing.T) {
 	require.Equal(t, int64(amount), actualNeo.Int64())
 	require.Equal(t, 0, int(lub))
 }
+
+func TestBlockchain_StorageUsage(t *testing.T) {
+	const src = `package storageusage
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Put(k, v []byte) { storage.Put(storage.GetContext(), k, v) }
+	func Delete(k []byte) { storage.Delete(storage.GetContext(), k) }
+	func Destroy() { management.Destroy() }`
+
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, nil, ps, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	_, err := bc.GetContractStorageUsage(1)
+	require.ErrorIs(t, err, core.ErrStorageUsageDisabled)
+
+	c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+		Name:        "StorageUsage",
+		Permissions: []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	e.DeployContract(t, c, nil)
+	ctr := e.CommitteeInvoker(c.Hash)
+	ctr.Invoke(t, stackitem.Null{}, "put", []byte{1}, []byte{1, 2, 3})
+	ctr.Invoke(t, stackitem.Null{}, "put", []byte{2}, []byte{1})
+	bc.Close()
+
+	// checkCounters compares counters with the actual storage state.
+	checkCounters := func(t *testing.T, bc *core.Blockchain) {
+		top, err := bc.GetTopContractStorageUsage(0)
+		require.NoError(t, err)
+		require.NotEmpty(t, top)
+		for i, u := range top {
+			if i > 0 {
+				require.LessOrEqual(t, u.Size, top[i-1].Size)
+			}
+			var expected state.StorageUsage
+			bc.SeekStorage(u.ID, nil, func(k, v []byte) bool {
+				expected.Items++
+				expected.Size += int64(len(k) + len(v))
+				return true
+			})
+			require.Equal(t, expected, u.StorageUsage, u.ID)
+		}
+	}
+	checkContract := func(t *testing.T, bc *core.Blockchain, items, size int64) {
+		u, err := bc.GetContractStorageUsage(1)
+		require.NoError(t, err)
+		require.Equal(t, state.StorageUsage{Items: items, Size: size}, u)
+	}
+
+	// Reopen the DB with tracking enabled, counters are to be rebuilt.
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	bc, acc = chain.NewSingleWithCustomConfigAndStore(t, func(c *config.Blockchain) {
+		c.Ledger.TrackStorageUsage = true
+	}, ps, false)
+	_, err = bc.GetContractStorageUsage(1)
+	require.ErrorIs(t, err, core.ErrStorageUsageRebuilding)
+	go bc.Run()
+	t.Cleanup(bc.Close)
+	require.Eventually(t, func() bool {
+		_, err := bc.GetContractStorageUsage(1)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	checkContract(t, bc, 2, 4+2)
+	checkCounters(t, bc)
+
+	e = neotest.NewExecutor(t, bc, acc, acc)
+	ctr = e.CommitteeInvoker(c.Hash)
+	t.Run("overwrite", func(t *testing.T) {
+		ctr.Invoke(t, stackitem.Null{}, "put", []byte{1}, []byte{1, 2, 3, 4, 5})
+		checkContract(t, bc, 2, 6+2)
+		checkCounters(t, bc)
+	})
+	t.Run("delete", func(t *testing.T) {
+		ctr.Invoke(t, stackitem.Null{}, "delete", []byte{2})
+		ctr.Invoke(t, stackitem.Null{}, "delete", []byte{3}) // Missing key.
+		checkContract(t, bc, 1, 6)
+		checkCounters(t, bc)
+	})
+	t.Run("destroy", func(t *testing.T) {
+		ctr.Invoke(t, stackitem.Null{}, "put", []byte{3}, []byte{3})
+		ctr.Invoke(t, stackitem.Null{}, "destroy")
+		checkContract(t, bc, 0, 0)
+		checkCounters(t, bc)
+		top, err := bc.GetTopContractStorageUsage(0)
+		require.NoError(t, err)
+		for _, u := range top {
+			require.NotEqual(t, int32(1), u.ID)
+		}
+	})
+	t.Run("top N", func(t *testing.T) {
+		top, err := bc.GetTopContractStorageUsage(2)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(top))
+	})
+}
+
+func TestBlockchain_NEP17Balances(t *testing.T) {
+	const src = `package tracked
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Symbol() string { return "TRK" }
+	func Decimals() int { return 0 }
+	func TotalSupply() int { return 0 }
+	func BalanceOf(h interop.Hash160) int {
+		v := storage.Get(storage.GetReadOnlyContext(), h)
+		if v == nil {
+			return 0
+		}
+		return v.(int)
+	}
+	func Mint(to interop.Hash160, amount int) {
+		storage.Put(storage.GetContext(), to, BalanceOf(to)+amount)
+		var zero interop.Hash160
+		runtime.Notify("Transfer", zero, to, amount)
+	}
+	func Transfer(from, to interop.Hash160, amount int, data any) bool {
+		b := BalanceOf(from)
+		if amount < 0 || b < amount || !runtime.CheckWitness(from) {
+			return false
+		}
+		storage.Put(storage.GetContext(), from, b-amount)
+		storage.Put(storage.GetContext(), to, BalanceOf(to)+amount)
+		runtime.Notify("Transfer", from, to, amount)
+		return true
+	}
+	func Negative(h interop.Hash160) { runtime.Notify("Transfer", h, h, -1) }`
+
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, _, err := bc.GetNEP17Balances(util.Uint160{})
+		require.ErrorIs(t, err, core.ErrNEP17BalancesDisabled)
+		require.ErrorIs(t, bc.ReindexNEP17Balances(), core.ErrNEP17BalancesDisabled)
+	})
+
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, nil, ps, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+		Name:                       "Tracked",
+		NoEventsCheck:              true,
+		SafeMethods:                []string{"balanceOf", "decimals", "symbol", "totalSupply"},
+		ContractSupportedStandards: []string{manifest.NEP17StandardName},
+		ContractEvents: []compiler.HybridEvent{{
+			Name: "Transfer",
+			Parameters: []compiler.HybridParameter{
+				{Parameter: manifest.NewParameter("from", smartcontract.Hash160Type)},
+				{Parameter: manifest.NewParameter("to", smartcontract.Hash160Type)},
+				{Parameter: manifest.NewParameter("amount", smartcontract.IntegerType)},
+			},
+		}},
+	})
+	e.DeployContract(t, c, nil)
+
+	var (
+		tokens = []util.Uint160{e.NativeHash(t, nativenames.Neo), e.NativeHash(t, nativenames.Gas), c.Hash}
+		accs   = make([]neotest.Signer, 4)
+	)
+	for i := range accs {
+		accs[i] = e.NewAccount(t)
+		e.ValidatorInvoker(tokens[0]).Invoke(t, true, "transfer", e.Validator.ScriptHash(), accs[i].ScriptHash(), 1000, nil)
+		e.CommitteeInvoker(c.Hash).Invoke(t, stackitem.Null{}, "mint", accs[i].ScriptHash(), 1000)
+	}
+	// transferRandomly makes a number of random transfers between accounts.
+	transferRandomly := func(t *testing.T, e *neotest.Executor) {
+		for i := 0; i < 20; i++ {
+			from, to := accs[rand.Intn(len(accs))], accs[rand.Intn(len(accs))]
+			e.NewInvoker(tokens[rand.Intn(len(tokens))], from).Invoke(t, true, "transfer",
+				from.ScriptHash(), to.ScriptHash(), rand.Intn(100), nil)
+		}
+	}
+	// checkBalances compares indexed balances with balanceOf results.
+	checkBalances := func(t *testing.T, e *neotest.Executor, bc *core.Blockchain) {
+		for _, a := range append(accs, e.Validator) {
+			balances, unreliable, err := bc.GetNEP17Balances(a.ScriptHash())
+			require.NoError(t, err)
+			for _, h := range tokens {
+				id := bc.GetContractState(h).ID
+				if unreliable[id] {
+					continue
+				}
+				st, err := e.NewInvoker(h).TestInvoke(t, "balanceOf", a.ScriptHash())
+				require.NoError(t, err)
+				expected := st.Pop().BigInt()
+				actual, ok := balances[id]
+				if !ok {
+					actual = new(big.Int)
+				}
+				require.Equal(t, expected, actual, "token %d, account %s", id, a.ScriptHash().StringLE())
+			}
+		}
+	}
+	transferRandomly(t, e)
+	bc.Close()
+
+	// Reopen the DB with tracking enabled, the index is to be built.
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	bc, acc = chain.NewSingleWithCustomConfigAndStore(t, func(c *config.Blockchain) {
+		c.Ledger.TrackNEP17Balances = true
+	}, ps, false)
+	_, _, err := bc.GetNEP17Balances(acc.ScriptHash())
+	require.ErrorIs(t, err, core.ErrNEP17BalancesNotReady)
+	require.NoError(t, bc.ReindexNEP17Balances())
+	go bc.Run()
+	t.Cleanup(bc.Close)
+	e = neotest.NewExecutor(t, bc, acc, acc)
+	checkBalances(t, e, bc)
+
+	for i := 0; i < 5; i++ {
+		transferRandomly(t, e)
+		checkBalances(t, e, bc)
+	}
+
+	t.Run("unreliable", func(t *testing.T) {
+		e.CommitteeInvoker(c.Hash).Invoke(t, stackitem.Null{}, "negative", acc.ScriptHash())
+		_, unreliable, err := bc.GetNEP17Balances(acc.ScriptHash())
+		require.NoError(t, err)
+		require.Equal(t, map[int32]bool{bc.GetContractState(c.Hash).ID: true}, unreliable)
+		checkBalances(t, e, bc)
+	})
+}
+
+func TestBlockchain_NativeCallStats(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, err := bc.GetNativeCallStats()
+		require.ErrorIs(t, err, core.ErrNativeCallStatsDisabled)
+		require.ErrorIs(t, bc.ResetNativeCallStats(), core.ErrNativeCallStatsDisabled)
+	})
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.TrackNativeCallStats = true
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	getStats := func(t *testing.T, method string) (int64, int64) {
+		stats, err := bc.GetNativeCallStats()
+		require.NoError(t, err)
+		for _, st := range stats {
+			if st.Hash == gasHash && st.Method == method {
+				require.Equal(t, nativenames.Gas, st.Contract)
+				return st.Calls, st.GAS
+			}
+		}
+		t.Fatalf("no stats for %s", method)
+		return 0, 0
+	}
+
+	calls, gas := getStats(t, "transfer")
+	require.Zero(t, calls)
+	require.Zero(t, gas)
+
+	gasInv := e.ValidatorInvoker(gasHash)
+	to := random.Uint160()
+	gasInv.Invoke(t, true, "transfer", e.Validator.ScriptHash(), to, 1, nil)
+	gasInv.Invoke(t, true, "transfer", e.Validator.ScriptHash(), to, 1, nil)
+	calls, gas = getStats(t, "transfer")
+	// Every Invoke is preceded by a test invocation.
+	require.Equal(t, int64(4), calls)
+	require.Greater(t, gas, 4*(1<<17)*bc.GetBaseExecFee())
+
+	calls, _ = getStats(t, "symbol")
+	require.Zero(t, calls)
+	gasInv.Invoke(t, "GAS", "symbol")
+	calls, gas = getStats(t, "symbol")
+	require.Equal(t, int64(2), calls)
+	require.Zero(t, gas) // symbol has zero price.
+
+	require.NoError(t, bc.ResetNativeCallStats())
+	calls, gas = getStats(t, "transfer")
+	require.Zero(t, calls)
+	require.Zero(t, gas)
+}
+
+func TestBlockchain_BlockProfile(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		bc, _ := chain.NewSingle(t)
+		_, err := bc.GetBlockProfile(0)
+		require.ErrorIs(t, err, core.ErrBlockProfilingDisabled)
+	})
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.TrackBlockProfiles = true
+		c.Ledger.BlockProfilesCount = 2
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	gasInv := e.ValidatorInvoker(gasHash)
+
+	h := gasInv.Invoke(t, true, "transfer", e.Validator.ScriptHash(), random.Uint160(), 1, nil)
+	aer := e.GetTxExecResult(t, h)
+	index := bc.BlockHeight()
+
+	bp, err := bc.GetBlockProfile(index)
+	require.NoError(t, err)
+	require.Equal(t, index, bp.Index)
+	require.Equal(t, bc.GetHeaderHash(index), bp.Hash)
+	require.Positive(t, bp.Time)
+	require.Equal(t, aer.GasConsumed, bp.GAS)
+	require.Equal(t, 1, len(bp.Transactions))
+	tp := bp.Transactions[0]
+	require.Equal(t, h, tp.Hash)
+	require.Equal(t, aer.GasConsumed, tp.GAS)
+	require.Equal(t, vmstate.Halt, tp.VMState)
+	require.Positive(t, tp.Time)
+	require.Equal(t, 1, tp.Syscalls[interopnames.SystemContractCall])
+	require.Equal(t, 1, tp.Syscalls[interopnames.SystemContractCallNative])
+	require.Equal(t, 1, len(bp.Contracts))
+	require.Equal(t, gasHash, bp.Contracts[0].Hash)
+	require.Equal(t, 1, bp.Contracts[0].Calls)
+	require.Positive(t, bp.Contracts[0].GAS)
+	require.Less(t, bp.Contracts[0].GAS, tp.GAS) // Entry script is not counted.
+
+	// Empty blocks are profiled too.
+	e.AddNewBlock(t)
+	bp, err = bc.GetBlockProfile(index + 1)
+	require.NoError(t, err)
+	require.Zero(t, bp.GAS)
+	require.Empty(t, bp.Transactions)
+	require.Empty(t, bp.Contracts)
+
+	e.AddNewBlock(t)
+	_, err = bc.GetBlockProfile(index)
+	require.ErrorIs(t, err, core.ErrBlockProfileNotFound)
+	_, err = bc.GetBlockProfile(index + 3)
+	require.ErrorIs(t, err, core.ErrBlockProfileNotFound)
+}
+
+func TestBlockchain_InvocationTrees(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		bc, acc := chain.NewSingle(t)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		gasInv := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+		h := gasInv.Invoke(t, true, "transfer", e.Validator.ScriptHash(), random.Uint160(), 1, nil)
+		require.Nil(t, e.GetTxExecResult(t, h).InvocationTree)
+		aers, err := bc.GetAppExecResults(h, trigger.All)
+		require.NoError(t, err)
+		require.Nil(t, aers[0].InvocationTree)
+	})
+
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Ledger.SaveInvocationTrees = true
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	deploy := func(name string, src string) util.Uint160 {
+		c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+			Name:        name,
+			Permissions: []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		})
+		e.DeployContract(t, c, nil)
+		return c.Hash
+	}
+	leaf := deploy("Leaf", `package leaf
+	func Leaf() int { return 1 }`)
+	middle := deploy("Middle", `package middle
+	import "github.com/nspcc-dev/neo-go/pkg/interop"
+	import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	func Middle(h interop.Hash160) int {
+		return contract.Call(h, "leaf", contract.All).(int) + 1
+	}`)
+	top := deploy("Top", `package top
+	import "github.com/nspcc-dev/neo-go/pkg/interop"
+	import "github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	func Top(m, l interop.Hash160) int {
+		return contract.Call(m, "middle", contract.All, l).(int) + contract.Call(l, "leaf", contract.All).(int)
+	}
+	func Fail(m, l interop.Hash160) {
+		contract.Call(m, "middle", contract.All, l)
+		panic("fail")
+	}`)
+	inv := e.CommitteeInvoker(top)
+
+	// checkTree checks the tree of top contract method invocation and returns
+	// the middle contract call.
+	checkTree := func(t *testing.T, aer *state.AppExecResult, method string) *state.Invocation {
+		tree := aer.InvocationTree
+		require.NotNil(t, tree)
+		require.False(t, tree.Truncated)
+		require.Empty(t, tree.Method)
+		require.Equal(t, aer.GasConsumed, tree.GAS)
+		require.Equal(t, 1, len(tree.Calls))
+
+		topCall := tree.Calls[0]
+		require.Equal(t, top, topCall.Hash)
+		require.Equal(t, method, topCall.Method)
+		require.Positive(t, topCall.GAS)
+		require.Less(t, topCall.GAS, tree.GAS)
+
+		midCall := topCall.Calls[0]
+		require.Equal(t, middle, midCall.Hash)
+		require.Equal(t, "middle", midCall.Method)
+		require.Equal(t, 1, len(midCall.Calls))
+		require.Less(t, midCall.GAS, topCall.GAS)
+
+		leafCall := midCall.Calls[0]
+		require.Equal(t, leaf, leafCall.Hash)
+		require.Equal(t, "leaf", leafCall.Method)
+		require.Empty(t, leafCall.Calls)
+		require.Positive(t, leafCall.GAS)
+		require.Less(t, leafCall.GAS, midCall.GAS)
+		return midCall
+	}
+
+	h := inv.Invoke(t, 3, "top", middle, leaf)
+	aers, err := bc.GetAppExecResults(h, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(aers))
+	require.Equal(t, e.GetTxExecResult(t, h).InvocationTree, aers[0].InvocationTree)
+	midCall := checkTree(t, &aers[0], "top")
+	topCall := aers[0].InvocationTree.Calls[0]
+	require.Equal(t, 2, len(topCall.Calls))
+	require.Equal(t, leaf, topCall.Calls[1].Hash)
+	require.Equal(t, midCall.Calls[0].GAS, topCall.Calls[1].GAS)
+	require.Less(t, midCall.GAS+topCall.Calls[1].GAS, topCall.GAS)
+
+	// Calls are recorded for failed transactions as well.
+	h = inv.InvokeFail(t, "fail", "fail", middle, leaf)
+	aers, err = bc.GetAppExecResults(h, trigger.Application)
+	require.NoError(t, err)
+	require.Equal(t, vmstate.Fault, aers[0].VMState)
+	checkTree(t, &aers[0], "fail")
+	require.Equal(t, 1, len(aers[0].InvocationTree.Calls[0].Calls))
+
+	// Block executions have trees too.
+	aers, err = bc.GetAppExecResults(bc.GetHeaderHash(bc.BlockHeight()), trigger.All)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(aers))
+	for _, aer := range aers {
+		require.NotNil(t, aer.InvocationTree)
+		require.Equal(t, aer.GasConsumed, aer.InvocationTree.GAS)
+	}
+}
+
+func TestBlockchain_ColdStorage(t *testing.T) {
+	t.Run("RemoveUntraceableBlocks", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.RemoveUntraceableBlocks = true
+			c.Ledger.ColdStorage = config.ColdStorage{Enabled: true, Path: t.TempDir()}
+		}, nil)
+		require.Error(t, err)
+	})
+	t.Run("ChangelogDepth", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.ChangelogDepth = 5
+			c.Ledger.ColdStorage = config.ColdStorage{Enabled: true, Path: t.TempDir(), Depth: 5}
+		}, nil)
+		require.Error(t, err)
+	})
+	t.Run("unknown backend", func(t *testing.T) {
+		_, _, _, err := chain.NewMultiWithCustomConfigAndStoreNoCheck(t, func(c *config.Blockchain) {
+			c.Ledger.ColdStorage = config.ColdStorage{Enabled: true, Backend: "s3", Path: t.TempDir()}
+		}, nil)
+		require.Error(t, err)
+	})
+	for _, backend := range []string{coldstore.BackendLevelDB, coldstore.BackendFilesystem} {
+		t.Run(backend, func(t *testing.T) {
+			testColdStorage(t, backend)
+		})
+	}
+}
+
+func testColdStorage(t *testing.T, backend string) {
+	coldCfg := config.ColdStorage{Enabled: true, Backend: backend, Path: t.TempDir(), Depth: 3}
+	setCold := func(c *config.Blockchain) {
+		c.Ledger.ColdStorage = coldCfg
+	}
+	ps, path := newLevelDBForTestingWithPath(t, "")
+	bc, acc := chain.NewSingleWithCustomConfigAndStore(t, setCold, ps, false)
+	go bc.Run()
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	neoValidatorInvoker := e.ValidatorInvoker(e.NativeHash(t, nativenames.Neo))
+
+	txHash := neoValidatorInvoker.Invoke(t, true, "transfer", acc.ScriptHash(), util.Uint160{1, 2, 3}, 1, nil)
+	b := e.TopBlock(t)
+	txAERs, err := bc.GetAppExecResults(txHash, trigger.All)
+	require.NoError(t, err)
+	blockAERs, err := bc.GetAppExecResults(b.Hash(), trigger.All)
+	require.NoError(t, err)
+
+	isHot := func(ps storage.Store, h util.Uint256) bool {
+		_, err := ps.Get(append([]byte{byte(storage.DataExecutable)}, h.BytesBE()...))
+		return err == nil
+	}
+	check := func(t *testing.T, bc *core.Blockchain) {
+		actual, err := bc.GetBlock(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), actual.Hash())
+		require.Equal(t, 1, len(actual.Transactions))
+		require.Equal(t, txHash, actual.Transactions[0].Hash())
+		h, err := bc.GetHeader(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Index, h.Index)
+
+		tx, height, err := bc.GetTransaction(txHash)
+		require.NoError(t, err)
+		require.Equal(t, b.Index, height)
+		require.Equal(t, txHash, tx.Hash())
+
+		aers, err := bc.GetAppExecResults(txHash, trigger.All)
+		require.NoError(t, err)
+		require.Equal(t, txAERs, aers)
+		aers, err = bc.GetAppExecResults(b.Hash(), trigger.All)
+		require.NoError(t, err)
+		require.Equal(t, blockAERs, aers)
+
+		// The latest blocks are still available.
+		top := bc.GetHeaderHash(bc.BlockHeight())
+		actual, err = bc.GetBlock(top)
+		require.NoError(t, err)
+		require.Equal(t, bc.BlockHeight(), actual.Index)
+	}
+
+	e.GenerateNewBlocks(t, 5)
+	top := e.TopBlock(t).Hash()
+	// Blocks are moved after they're persisted.
+	require.Eventually(t, func() bool { return isHot(ps, top) }, 5*time.Second, 100*time.Millisecond)
+	require.Eventually(t, func() bool { return !isHot(ps, b.Hash()) && !isHot(ps, txHash) }, 5*time.Second, 100*time.Millisecond)
+	require.False(t, isHot(ps, bc.GetHeaderHash(0)))
+	check(t, bc)
+
+	// Migration is resumed after restart.
+	bc.Close()
+	ps, _ = newLevelDBForTestingWithPath(t, path)
+	_, _, _, err = chain.NewMultiWithCustomConfigAndStoreNoCheck(t, nil, ps)
+	require.ErrorContains(t, err, "cold storage")
+
+	bc, acc = chain.NewSingleWithCustomConfigAndStore(t, setCold, ps, false)
+	check(t, bc)
+	require.ErrorContains(t, bc.Rollback(b.Index), "cold storage")
+	go bc.Run()
+	t.Cleanup(bc.Close)
+	e = neotest.NewExecutor(t, bc, acc, acc)
+	e.GenerateNewBlocks(t, 5)
+	top = e.TopBlock(t).Hash()
+	last := bc.GetHeaderHash(bc.BlockHeight() - 3)
+	require.Eventually(t, func() bool { return isHot(ps, top) }, 5*time.Second, 100*time.Millisecond)
+	require.Eventually(t, func() bool { return !isHot(ps, last) }, 5*time.Second, 100*time.Millisecond)
+	require.True(t, isHot(ps, bc.GetHeaderHash(bc.BlockHeight()-2)))
+	check(t, bc)
+}
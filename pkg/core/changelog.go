@@ -0,0 +1,141 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"go.uber.org/zap"
+)
+
+// storeChangelog saves reverse changes of the block with the given index made
+// in the given caches (that are not yet persisted) and removes changelog
+// entries that are older than Ledger.ChangelogDepth blocks. The changelog is
+// stored in the first cache.
+func (bc *Blockchain) storeChangelog(index uint32, caches ...*dao.Simple) error {
+	if index == 0 {
+		// There is nothing to roll back to.
+		return nil
+	}
+	var (
+		seen    = make(map[string]bool)
+		changes []storage.KeyValueExists
+	)
+	for _, c := range caches {
+		b := c.Store.GetBatch()
+		for _, kvs := range [][]storage.KeyValueExists{b.Put, b.Deleted} {
+			for _, kv := range kvs {
+				if seen[string(kv.Key)] {
+					continue
+				}
+				seen[string(kv.Key)] = true
+				old, err := bc.dao.Store.Get(kv.Key)
+				switch {
+				case err == nil:
+					changes = append(changes, storage.KeyValueExists{KeyValue: storage.KeyValue{Key: kv.Key, Value: old}, Exists: true})
+				case errors.Is(err, storage.ErrKeyNotFound):
+					changes = append(changes, storage.KeyValueExists{KeyValue: storage.KeyValue{Key: kv.Key}})
+				default:
+					return fmt.Errorf("failed to get previous value: %w", err)
+				}
+			}
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return bytes.Compare(changes[i].Key, changes[j].Key) < 0
+	})
+	d := caches[0]
+	err := d.PutChangelog(index, changes)
+	if err != nil {
+		return err
+	}
+	if depth := bc.config.Ledger.ChangelogDepth; index >= depth {
+		d.DeleteChangelog(index - depth)
+	}
+	return nil
+}
+
+// Rollback rolls chain state back to the specified height using reverse state
+// changes stored for the latest blocks (see Ledger.ChangelogDepth setting).
+// Unlike Reset it doesn't require historical MPT data, but the height can't
+// be lower than the changelog allows. Headers above the height are removed as
+// well. This method performs direct DB changes and can be called on
+// non-running Blockchain only.
+func (bc *Blockchain) Rollback(height uint32) error {
+	if bc.isRunning.Load().(bool) {
+		return errors.New("can't rollback the running blockchain")
+	}
+	currHeight := bc.BlockHeight()
+	hHeight := bc.HeaderHeight()
+	if height > currHeight {
+		return fmt.Errorf("current block height is %d, can't rollback to height %d", currHeight, height)
+	}
+	if height == currHeight && hHeight == currHeight {
+		bc.log.Info("chain is at the proper state", zap.Uint32("height", height))
+		return nil
+	}
+	if err := bc.checkColdStorageBoundary(height); err != nil {
+		return err
+	}
+
+	bc.log.Info("rolling back chain state", zap.Uint32("from", currHeight), zap.Uint32("to", height))
+	start := time.Now()
+	cache := bc.dao.GetPrivate()
+	// Changes are applied to the private cache, so nothing is changed if
+	// some changelog entry is missing.
+	for i := currHeight; i > height; i-- {
+		changes, err := cache.GetChangelog(i)
+		if err != nil {
+			return fmt.Errorf("no changelog for block %d: %w", i, err)
+		}
+		for _, c := range changes {
+			if c.Exists {
+				cache.Store.Put(c.Key, c.Value)
+			} else {
+				cache.Store.Delete(c.Key)
+			}
+		}
+		cache.DeleteChangelog(i)
+	}
+
+	b, err := cache.GetBlock(bc.GetHeaderHash(height))
+	if err != nil {
+		return fmt.Errorf("failed to retrieve block %d: %w", height, err)
+	}
+	for i := height + 1; i <= hHeight; i++ {
+		cache.PurgeHeader(bc.GetHeaderHash(i))
+	}
+	cache.DeleteHeaderHashes(height+1, headerBatchCount)
+	cache.PutCurrentHeader(b.Hash(), height)
+	err = bc.stateRoot.ResetState(height, cache.Store)
+	if err != nil {
+		return fmt.Errorf("failed to rollback MPT state: %w", err)
+	}
+	// Storage usage counters can be rebuilt in background concurrently with
+	// blocks processing, so they're not covered by the changelog.
+	cache.Store.Delete(storageUsageStateKey)
+	rollbackNEP17BalancesState(cache, height)
+
+	_, err = cache.PersistSync()
+	if err != nil {
+		return fmt.Errorf("failed to persist rolled back state: %w", err)
+	}
+	_, err = bc.dao.PersistSync()
+	if err != nil {
+		return fmt.Errorf("failed to persist rolled back state to the DB: %w", err)
+	}
+	bc.invalidateStorageUsage()
+	if _, err := bc.dao.Store.Get(nep17BalancesStateKey); err != nil {
+		bc.invalidateNEP17Balances()
+	}
+	err = bc.resetRAMState(height, true)
+	if err != nil {
+		return fmt.Errorf("failed to update in-memory blockchain data: %w", err)
+	}
+	bc.log.Info("chain state is rolled back", zap.Uint32("height", height), zap.Duration("took", time.Since(start)))
+	return nil
+}
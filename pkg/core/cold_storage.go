@@ -0,0 +1,148 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/coldstore"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"go.uber.org/zap"
+)
+
+// coldStorageBatch is the maximum number of blocks moved to the cold storage
+// in a single migration step.
+const coldStorageBatch = 1000
+
+// coldStorageStateKey is the key of the cold storage migration state. Its
+// value is the index of the first block not yet moved to the cold storage
+// followed by the total size of moved data.
+var coldStorageStateKey = []byte{byte(storage.SYSColdStorageState)}
+
+// coldReader is a dao.ColdStore measuring read-through latency.
+type coldReader struct {
+	store coldstore.Store
+}
+
+// Get implements the dao.ColdStore interface.
+func (r coldReader) Get(key []byte) ([]byte, error) {
+	start := time.Now()
+	v, err := r.store.Get(key)
+	updateColdStorageReadMetric(time.Since(start))
+	return v, err
+}
+
+// initColdStorage opens the cold storage if it's enabled and loads the
+// migration state. It's an error to disable the cold storage once some blocks
+// are moved there.
+func (bc *Blockchain) initColdStorage() error {
+	b, err := bc.store.Get(coldStorageStateKey)
+	if err != nil && !errors.Is(err, storage.ErrKeyNotFound) {
+		return fmt.Errorf("failed to get cold storage state: %w", err)
+	}
+	if err == nil {
+		if len(b) != 12 {
+			return errors.New("invalid cold storage state")
+		}
+		bc.coldHeight.Store(binary.LittleEndian.Uint32(b))
+		bc.coldBytes = binary.LittleEndian.Uint64(b[4:])
+	}
+	if !bc.config.Ledger.ColdStorage.Enabled {
+		if bc.coldHeight.Load() != 0 {
+			return fmt.Errorf("blocks up to %d are moved to the cold storage, it can't be disabled", bc.coldHeight.Load()-1)
+		}
+		return nil
+	}
+	s, err := coldstore.New(bc.config.Ledger.ColdStorage)
+	if err != nil {
+		return fmt.Errorf("failed to open cold storage: %w", err)
+	}
+	bc.coldStore = s
+	bc.dao.SetColdStore(coldReader{store: s})
+	bc.persistent.SetColdStore(coldReader{store: s})
+	return nil
+}
+
+// checkColdStorageBoundary returns an error if some blocks above the given
+// height are already moved to the cold storage, they can't be removed then.
+func (bc *Blockchain) checkColdStorageBoundary(height uint32) error {
+	if ch := bc.coldHeight.Load(); ch > height+1 {
+		return fmt.Errorf("blocks up to %d are moved to the cold storage, can't go back to height %d", ch-1, height)
+	}
+	return nil
+}
+
+// migrateToColdStorage moves a batch of blocks (with their transactions and
+// execution results) that are deeper than the configured depth from the main
+// DB to the cold storage. Every block is copied and verified first, then
+// its records are deleted from the main DB along with the migration state
+// update, so migration can be safely interrupted at any moment.
+func (bc *Blockchain) migrateToColdStorage() time.Duration {
+	start := time.Now()
+	persisted := atomic.LoadUint32(&bc.persistedHeight)
+	next := bc.coldHeight.Load()
+	stop := int64(persisted) + 1 - int64(bc.config.Ledger.ColdStorage.Depth)
+	if stop > int64(next)+coldStorageBatch {
+		stop = int64(next) + coldStorageBatch
+	}
+	for index := next; int64(index) < stop; index++ {
+		err := bc.moveBlockToColdStorage(index)
+		if err != nil {
+			bc.log.Warn("failed to move block to the cold storage",
+				zap.Uint32("index", index),
+				zap.Error(err))
+			break
+		}
+	}
+	moved := bc.coldHeight.Load()
+	updateStorageTierMetrics(persisted+1-moved, moved, bc.coldBytes)
+	dur := time.Since(start)
+	if moved != next {
+		bc.log.Debug("blocks moved to the cold storage",
+			zap.Uint32("from", next),
+			zap.Uint32("to", moved-1),
+			zap.Duration("time", dur))
+	}
+	return dur
+}
+
+// moveBlockToColdStorage moves records of the block with the given index to
+// the cold storage.
+func (bc *Blockchain) moveBlockToColdStorage(index uint32) error {
+	kvs, err := bc.persistent.GetBlockRecords(bc.GetHeaderHash(index))
+	if err != nil {
+		return fmt.Errorf("failed to get block records: %w", err)
+	}
+	err = bc.coldStore.PutBlock(index, kvs)
+	if err != nil {
+		return fmt.Errorf("failed to store block records: %w", err)
+	}
+	var (
+		size uint64
+		puts = make(map[string][]byte, len(kvs)+1)
+	)
+	for _, kv := range kvs {
+		v, err := bc.coldStore.Get(kv.Key)
+		if err != nil {
+			return fmt.Errorf("failed to verify record %x: %w", kv.Key, err)
+		}
+		if !bytes.Equal(v, kv.Value) {
+			return fmt.Errorf("stored record %x doesn't match the original one", kv.Key)
+		}
+		size += uint64(len(kv.Value))
+		puts[string(kv.Key)] = nil
+	}
+	state := binary.LittleEndian.AppendUint32(nil, index+1)
+	state = binary.LittleEndian.AppendUint64(state, bc.coldBytes+size)
+	puts[string(coldStorageStateKey)] = state
+	err = bc.store.PutChangeSet(puts, nil)
+	if err != nil {
+		return fmt.Errorf("failed to remove moved records: %w", err)
+	}
+	bc.coldHeight.Store(index + 1)
+	bc.coldBytes += size
+	return nil
+}
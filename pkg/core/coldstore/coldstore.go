@@ -0,0 +1,88 @@
+/*
+Package coldstore implements the cold storage tier for old blocks.
+
+Full nodes keep all blocks along with their transactions and execution results,
+but old blocks are rarely requested. If the cold storage is enabled, such data
+is moved from the main DB into a separate store that can be placed onto a
+cheaper (and slower) disk. Two backends are provided: a LevelDB database and a
+filesystem one keeping a dump file for every block with a per-key index.
+*/
+package coldstore
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
+)
+
+const (
+	// BackendLevelDB is the name of the LevelDB cold storage backend.
+	BackendLevelDB = "leveldb"
+	// BackendFilesystem is the name of the filesystem cold storage backend.
+	BackendFilesystem = "filesystem"
+)
+
+// Store is a cold storage backend. It keeps raw DB records of old blocks, so
+// they can be read the same way they're read from the main DB.
+type Store interface {
+	// PutBlock saves records of the block with the given index. It can be
+	// called for the same block multiple times.
+	PutBlock(index uint32, kvs []storage.KeyValue) error
+	// Get returns the value stored under the given key or
+	// storage.ErrKeyNotFound.
+	Get(key []byte) ([]byte, error)
+	// Close releases resources used by the store.
+	Close() error
+}
+
+// New opens a cold storage using the given configuration.
+func New(cfg config.ColdStorage) (Store, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("empty cold storage path")
+	}
+	switch cfg.Backend {
+	case "", BackendLevelDB:
+		db, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: cfg.Path})
+		if err != nil {
+			return nil, err
+		}
+		return NewDBStore(db), nil
+	case BackendFilesystem:
+		return NewFSStore(cfg.Path)
+	default:
+		return nil, fmt.Errorf("unknown cold storage backend: %s", cfg.Backend)
+	}
+}
+
+// DBStore is a Store using a regular node database (LevelDB, BoltDB, etc.).
+type DBStore struct {
+	db storage.Store
+}
+
+// NewDBStore creates a Store over the given database, it's closed along with
+// the store.
+func NewDBStore(db storage.Store) *DBStore {
+	return &DBStore{db: db}
+}
+
+// PutBlock implements the Store interface.
+func (s *DBStore) PutBlock(_ uint32, kvs []storage.KeyValue) error {
+	puts := make(map[string][]byte, len(kvs))
+	for _, kv := range kvs {
+		puts[string(kv.Key)] = kv.Value
+	}
+	return s.db.PutChangeSet(puts, nil)
+}
+
+// Get implements the Store interface.
+func (s *DBStore) Get(key []byte) ([]byte, error) {
+	return s.db.Get(key)
+}
+
+// Close implements the Store interface.
+func (s *DBStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,68 @@
+package coldstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/stretchr/testify/require"
+)
+
+func testStore(t *testing.T, s Store) {
+	_, err := s.Get([]byte{1, 2, 3})
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	kvs := []storage.KeyValue{
+		{Key: []byte{1, 0xaa, 0x01}, Value: []byte{1, 2, 3}},
+		{Key: []byte{1, 0xbb, 0x01}, Value: []byte{4}},
+	}
+	require.NoError(t, s.PutBlock(5, kvs))
+	// Repeated puts are OK.
+	require.NoError(t, s.PutBlock(5, kvs))
+	require.NoError(t, s.PutBlock(6, []storage.KeyValue{{Key: []byte{1, 0xcc, 0x02}, Value: []byte{5, 6}}}))
+
+	for _, kv := range append(kvs, storage.KeyValue{Key: []byte{1, 0xcc, 0x02}, Value: []byte{5, 6}}) {
+		v, err := s.Get(kv.Key)
+		require.NoError(t, err)
+		require.Equal(t, kv.Value, v)
+	}
+	_, err = s.Get([]byte{1, 0xdd, 0x01})
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+	require.NoError(t, s.Close())
+}
+
+func TestDBStore(t *testing.T) {
+	testStore(t, NewDBStore(storage.NewMemoryStore()))
+}
+
+func TestFSStore(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFSStore(dir)
+	require.NoError(t, err)
+	testStore(t, s)
+
+	// Broken dumps are reported.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "blocks", "0", "6"), []byte{1, 3, 1}, 0o644))
+	_, err = s.Get([]byte{1, 0xcc, 0x02})
+	require.Error(t, err)
+	require.NotErrorIs(t, err, storage.ErrKeyNotFound)
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(config.ColdStorage{Backend: BackendFilesystem})
+	require.Error(t, err)
+	_, err = New(config.ColdStorage{Backend: "s3", Path: t.TempDir()})
+	require.Error(t, err)
+
+	s, err := New(config.ColdStorage{Backend: BackendFilesystem, Path: t.TempDir()})
+	require.NoError(t, err)
+	require.IsType(t, &FSStore{}, s)
+	testStore(t, s)
+
+	s, err = New(config.ColdStorage{Path: t.TempDir()})
+	require.NoError(t, err)
+	require.IsType(t, &DBStore{}, s)
+	testStore(t, s)
+}
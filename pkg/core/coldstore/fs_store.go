@@ -0,0 +1,120 @@
+package coldstore
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/aerarchive"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+)
+
+const (
+	// blocksPerDir is the number of block dump files kept in a single
+	// directory.
+	blocksPerDir = 10000
+	// maxRecordSize is the maximum size of a single dumped value.
+	maxRecordSize = 64 << 20
+)
+
+// FSStore is a Store keeping records of every block in a separate dump file.
+// The index file is created for every record key, it contains the index of
+// the block the record belongs to.
+type FSStore struct {
+	files *aerarchive.FSStore
+}
+
+// NewFSStore creates a filesystem store in the given directory creating it if
+// needed.
+func NewFSStore(dir string) (*FSStore, error) {
+	files, err := aerarchive.NewFSStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	return &FSStore{files: files}, nil
+}
+
+func dumpKey(index uint32) string {
+	return "blocks/" + strconv.FormatUint(uint64(index/blocksPerDir), 10) +
+		"/" + strconv.FormatUint(uint64(index), 10)
+}
+
+func indexKey(key []byte) string {
+	h := hex.EncodeToString(key)
+	// Keys are hashes (with some prefix), so the last byte is good enough
+	// for sharding.
+	return "index/" + h[len(h)-2:] + "/" + h
+}
+
+// PutBlock implements the Store interface. The dump is written before the
+// index, so records are only visible when the dump is complete.
+func (s *FSStore) PutBlock(index uint32, kvs []storage.KeyValue) error {
+	w := io.NewBufBinWriter()
+	w.WriteVarUint(uint64(len(kvs)))
+	for _, kv := range kvs {
+		if len(kv.Key) == 0 {
+			return errors.New("empty key")
+		}
+		w.WriteVarBytes(kv.Key)
+		w.WriteVarBytes(kv.Value)
+	}
+	if w.Err != nil {
+		return w.Err
+	}
+	err := s.files.Put(dumpKey(index), w.Bytes())
+	if err != nil {
+		return fmt.Errorf("failed to store block %d dump: %w", index, err)
+	}
+	ib := binary.LittleEndian.AppendUint32(nil, index)
+	for _, kv := range kvs {
+		err = s.files.Put(indexKey(kv.Key), ib)
+		if err != nil {
+			return fmt.Errorf("failed to store block %d index: %w", index, err)
+		}
+	}
+	return nil
+}
+
+// Get implements the Store interface.
+func (s *FSStore) Get(key []byte) ([]byte, error) {
+	if len(key) == 0 {
+		return nil, storage.ErrKeyNotFound
+	}
+	ib, err := s.files.Get(indexKey(key))
+	if err != nil {
+		if errors.Is(err, aerarchive.ErrNotFound) {
+			return nil, storage.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	if len(ib) != 4 {
+		return nil, fmt.Errorf("invalid index for key %x", key)
+	}
+	index := binary.LittleEndian.Uint32(ib)
+	dump, err := s.files.Get(dumpKey(index))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block %d dump: %w", index, err)
+	}
+	r := io.NewBinReaderFromBuf(dump)
+	n := r.ReadVarUint()
+	for i := uint64(0); i < n && r.Err == nil; i++ {
+		k := r.ReadVarBytes(maxRecordSize)
+		v := r.ReadVarBytes(maxRecordSize)
+		if r.Err == nil && bytes.Equal(k, key) {
+			return v, nil
+		}
+	}
+	if r.Err != nil {
+		return nil, fmt.Errorf("invalid block %d dump: %w", index, r.Err)
+	}
+	return nil, fmt.Errorf("key %x is missing from block %d dump", key, index)
+}
+
+// Close implements the Store interface.
+func (s *FSStore) Close() error {
+	return nil
+}
@@ -47,6 +47,13 @@ type Simple struct {
 	// nativeCachePS set to nil.
 	nativeCachePS *Simple
 
+	// trackStorageUsage enables per-contract storage usage accounting, it's
+	// inherited by all derived DAOs.
+	trackStorageUsage bool
+	// cold is the cold storage tier executable records are read from if
+	// they're missing from the Store, it's inherited by all derived DAOs.
+	cold ColdStore
+
 	private bool
 	serCtx  *stackitem.SerializationContext
 	keyBuf  []byte
@@ -62,6 +69,14 @@ type NativeContractCache interface {
 	Copy() NativeContractCache
 }
 
+// ColdStore is a read-only cold storage tier containing executable records of
+// old blocks moved out of the main DB.
+type ColdStore interface {
+	// Get returns the value stored under the given key or
+	// storage.ErrKeyNotFound.
+	Get(key []byte) ([]byte, error)
+}
+
 // NewSimple creates a new simple dao using the provided backend store.
 func NewSimple(backend storage.Store, stateRootInHeader bool) *Simple {
 	st := storage.NewMemCachedStore(backend)
@@ -90,6 +105,8 @@ func (dao *Simple) GetWrapped() *Simple {
 	d := NewSimple(dao.Store, dao.Version.StateRootInHeader)
 	d.Version = dao.Version
 	d.nativeCachePS = dao
+	d.trackStorageUsage = dao.trackStorageUsage
+	d.cold = dao.cold
 	return d
 }
 
@@ -97,10 +114,12 @@ func (dao *Simple) GetWrapped() *Simple {
 // MemCachedStore around the current DAO Store.
 func (dao *Simple) GetPrivate() *Simple {
 	d := &Simple{
-		Version: dao.Version,
-		keyBuf:  dao.keyBuf,
-		dataBuf: dao.dataBuf,
-		serCtx:  dao.serCtx,
+		Version:           dao.Version,
+		keyBuf:            dao.keyBuf,
+		dataBuf:           dao.dataBuf,
+		serCtx:            dao.serCtx,
+		trackStorageUsage: dao.trackStorageUsage,
+		cold:              dao.cold,
 	} // Inherit everything...
 	d.Store = storage.NewPrivateMemCachedStore(dao.Store) // except storage, wrap another layer.
 	d.private = true
@@ -114,6 +133,22 @@ func (dao *Simple) GetPrivate() *Simple {
 	return d
 }
 
+// GetSnapshot returns a new private DAO instance similar to the one returned
+// from GetPrivate, but based on an immutable snapshot of the current DAO Store
+// (see storage.Snapshotter). It's not affected by any subsequent changes made
+// to the DAO and it never blocks them. The snapshot must be released by
+// closing its Store when it's no longer needed. storage.ErrSnapshotUnsupported
+// is returned if the underlying Store doesn't support snapshots.
+func (dao *Simple) GetSnapshot() (*Simple, error) {
+	snap, err := dao.Store.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	d := dao.GetPrivate()
+	d.Store = storage.NewPrivateMemCachedStore(snap)
+	return d, nil
+}
+
 // GetAndDecode performs get operation and decoding with serializable structures.
 func (dao *Simple) GetAndDecode(entity io.Serializable, key []byte) error {
 	entityBytes, err := dao.Store.Get(key)
@@ -255,11 +290,59 @@ func (dao *Simple) makeExecutableKey(hash util.Uint256) []byte {
 	return key
 }
 
+// SetColdStore sets the cold storage tier for this DAO and all DAOs derived
+// from it. Executable records (blocks, transactions and their execution
+// results) missing from the Store are read from it.
+func (dao *Simple) SetColdStore(cold ColdStore) {
+	dao.cold = cold
+}
+
+// getExecutable returns the executable record stored under the given key
+// reading through to the cold storage if it's missing from the Store.
+func (dao *Simple) getExecutable(key []byte) ([]byte, error) {
+	b, err := dao.Store.Get(key)
+	if errors.Is(err, storage.ErrKeyNotFound) && dao.cold != nil {
+		return dao.cold.Get(key)
+	}
+	return b, err
+}
+
+// GetBlockRecords returns raw executable records of the block with the given
+// hash and of all of its transactions (including their execution results) as
+// they're stored in the Store, the cold storage is not used. Conflict records
+// are not included.
+func (dao *Simple) GetBlockRecords(hash util.Uint256) ([]storage.KeyValue, error) {
+	key := dao.makeExecutableKey(hash)
+	b, err := dao.Store.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	r := io.NewBinReaderFromBuf(b)
+	if r.ReadB() != storage.ExecBlock {
+		return nil, storage.ErrKeyNotFound
+	}
+	blk, err := block.NewTrimmedFromReader(dao.Version.StateRootInHeader, r)
+	if err != nil {
+		return nil, err
+	}
+	kvs := make([]storage.KeyValue, 0, 1+len(blk.Transactions))
+	kvs = append(kvs, storage.KeyValue{Key: bytes.Clone(key), Value: b})
+	for _, tx := range blk.Transactions {
+		copy(key[1:], tx.Hash().BytesBE())
+		v, err := dao.Store.Get(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get transaction %s: %w", tx.Hash().StringLE(), err)
+		}
+		kvs = append(kvs, storage.KeyValue{Key: bytes.Clone(key), Value: v})
+	}
+	return kvs, nil
+}
+
 // GetAppExecResults gets application execution results with the specified trigger from the
 // given store.
 func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
 	key := dao.makeExecutableKey(hash)
-	bs, err := dao.Store.Get(key)
+	bs, err := dao.getExecutable(key)
 	if err != nil {
 		return nil, err
 	}
@@ -307,7 +390,7 @@ func (dao *Simple) GetAppExecResults(hash util.Uint256, trig trigger.Type) ([]st
 // and returns the transaction itself, its height and its AppExecResult.
 func (dao *Simple) GetTxExecResult(hash util.Uint256) (uint32, *transaction.Transaction, *state.AppExecResult, error) {
 	key := dao.makeExecutableKey(hash)
-	bs, err := dao.Store.Get(key)
+	bs, err := dao.getExecutable(key)
 	if err != nil {
 		return 0, nil, nil, err
 	}
@@ -320,6 +403,43 @@ func (dao *Simple) GetTxExecResult(hash util.Uint256) (uint32, *transaction.Tran
 	return decodeTxAndExecResult(bs)
 }
 
+func (dao *Simple) makeInvocationTreeKey(hash util.Uint256, trig trigger.Type) []byte {
+	key := dao.getKeyBuf(1 + util.Uint256Size + 1)
+	key[0] = byte(storage.DataInvocationTrees)
+	copy(key[1:], hash.BytesBE())
+	key[len(key)-1] = byte(trig)
+	return key
+}
+
+// PutInvocationTree stores the invocation tree of the execution with the
+// specified trigger of the given script container (either a block or a
+// transaction).
+func (dao *Simple) PutInvocationTree(hash util.Uint256, trig trigger.Type, t *state.InvocationTree) error {
+	buf := dao.getDataBuf()
+	t.EncodeBinary(buf.BinWriter)
+	if buf.Err != nil {
+		return buf.Err
+	}
+	dao.Store.Put(dao.makeInvocationTreeKey(hash, trig), buf.Bytes())
+	return nil
+}
+
+// GetInvocationTree returns the invocation tree of the execution with the
+// specified trigger of the given script container.
+func (dao *Simple) GetInvocationTree(hash util.Uint256, trig trigger.Type) (*state.InvocationTree, error) {
+	b, err := dao.Store.Get(dao.makeInvocationTreeKey(hash, trig))
+	if err != nil {
+		return nil, err
+	}
+	t := new(state.InvocationTree)
+	r := io.NewBinReaderFromBuf(b)
+	t.DecodeBinary(r)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return t, nil
+}
+
 // decodeTxAndExecResult decodes transaction, its height and execution result from
 // the given executable bytes. It performs no executable prefix check.
 func decodeTxAndExecResult(buf []byte) (uint32, *transaction.Transaction, *state.AppExecResult, error) {
@@ -360,6 +480,14 @@ func (dao *Simple) GetStorageItem(id int32, key []byte) state.StorageItem {
 // key into the given store.
 func (dao *Simple) PutStorageItem(id int32, key []byte, si state.StorageItem) {
 	stKey := dao.makeStorageItemKey(id, key)
+	if dao.trackStorageUsage {
+		var delta = state.StorageUsage{Items: 1, Size: int64(len(key) + len(si))}
+		if old, err := dao.Store.Get(stKey); err == nil {
+			delta.Items = 0
+			delta.Size -= int64(len(key) + len(old))
+		}
+		dao.updateStorageUsage(id, delta)
+	}
 	dao.Store.Put(stKey, si)
 }
 
@@ -375,6 +503,12 @@ func (dao *Simple) PutBigInt(id int32, key []byte, n *big.Int) {
 // given key from the store.
 func (dao *Simple) DeleteStorageItem(id int32, key []byte) {
 	stKey := dao.makeStorageItemKey(id, key)
+	if dao.trackStorageUsage {
+		old, err := dao.Store.Get(stKey)
+		if err == nil {
+			dao.updateStorageUsage(id, state.StorageUsage{Items: -1, Size: -int64(len(key) + len(old))})
+		}
+	}
 	dao.Store.Delete(stKey)
 }
 
@@ -409,6 +543,171 @@ func (dao *Simple) makeStorageItemKey(id int32, key []byte) []byte {
 
 // -- end storage item.
 
+// -- start storage usage.
+
+// SetStorageUsageTracking enables or disables per-contract storage usage
+// accounting for this DAO and all DAOs derived from it. This accounting is
+// node-local, it's not a part of the contract state.
+func (dao *Simple) SetStorageUsageTracking(enabled bool) {
+	dao.trackStorageUsage = enabled
+}
+
+// GetStorageUsage returns storage usage statistics for the contract with the
+// given ID. Empty statistics is returned if there is no data for it.
+func (dao *Simple) GetStorageUsage(id int32) (state.StorageUsage, error) {
+	b, err := dao.Store.Get(makeStorageUsageKey(id))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return state.StorageUsage{}, nil
+		}
+		return state.StorageUsage{}, err
+	}
+	return state.StorageUsageFromBytes(b)
+}
+
+// PutStorageUsage stores storage usage statistics for the contract with the
+// given ID, empty statistics is deleted from the store.
+func (dao *Simple) PutStorageUsage(id int32, u state.StorageUsage) {
+	key := makeStorageUsageKey(id)
+	if u.Items <= 0 {
+		dao.Store.Delete(key)
+		return
+	}
+	dao.Store.Put(key, u.Bytes())
+}
+
+// SeekStorageUsage executes f for storage usage statistics of every contract
+// that has any. Iteration stops if f returns false.
+func (dao *Simple) SeekStorageUsage(f func(id int32, u state.StorageUsage) bool) {
+	dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageUsage)}}, func(k, v []byte) bool {
+		u, err := state.StorageUsageFromBytes(v)
+		if err != nil || len(k) != 5 {
+			return true // Skip corrupted entries, they're not consensus-critical.
+		}
+		return f(int32(binary.LittleEndian.Uint32(k[1:])), u)
+	})
+}
+
+// DeleteAllStorageUsage removes storage usage statistics of all contracts.
+func (dao *Simple) DeleteAllStorageUsage() {
+	var keys [][]byte
+	dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STStorageUsage)}}, func(k, _ []byte) bool {
+		keys = append(keys, bytes.Clone(k))
+		return true
+	})
+	for _, k := range keys {
+		dao.Store.Delete(k)
+	}
+}
+
+func (dao *Simple) updateStorageUsage(id int32, delta state.StorageUsage) {
+	u, err := dao.GetStorageUsage(id)
+	if err != nil {
+		u = state.StorageUsage{} // It'll be rebuilt anyway.
+	}
+	u.Items += delta.Items
+	u.Size += delta.Size
+	dao.PutStorageUsage(id, u)
+}
+
+func makeStorageUsageKey(id int32) []byte {
+	key := make([]byte, 5)
+	key[0] = byte(storage.STStorageUsage)
+	binary.LittleEndian.PutUint32(key[1:], uint32(id))
+	return key
+}
+
+// -- end storage usage.
+
+// -- start NEP-17 balances.
+
+// GetNEP17Balance returns indexed balance of the account for the NEP-17 token
+// with the given ID, zero is returned if there is no data for it.
+func (dao *Simple) GetNEP17Balance(acc util.Uint160, id int32) (*big.Int, error) {
+	b, err := dao.Store.Get(makeNEP17BalanceKey(acc, id))
+	if err != nil {
+		if errors.Is(err, storage.ErrKeyNotFound) {
+			return new(big.Int), nil
+		}
+		return nil, err
+	}
+	return bigint.FromBytes(b), nil
+}
+
+// PutNEP17Balance stores indexed balance of the account for the NEP-17 token
+// with the given ID, zero balance is deleted from the store.
+func (dao *Simple) PutNEP17Balance(acc util.Uint160, id int32, balance *big.Int) {
+	key := makeNEP17BalanceKey(acc, id)
+	if balance.Sign() == 0 {
+		dao.Store.Delete(key)
+		return
+	}
+	dao.Store.Put(key, bigint.ToBytes(balance))
+}
+
+// SeekNEP17Balances executes f for every non-zero indexed NEP-17 balance of
+// the account. Iteration stops if f returns false.
+func (dao *Simple) SeekNEP17Balances(acc util.Uint160, f func(id int32, balance *big.Int) bool) {
+	prefix := makeNEP17BalanceKey(acc, 0)[:1+util.Uint160Size]
+	dao.Store.Seek(storage.SeekRange{Prefix: prefix}, func(k, v []byte) bool {
+		if len(k) != 1+util.Uint160Size+4 {
+			return true
+		}
+		return f(int32(binary.LittleEndian.Uint32(k[1+util.Uint160Size:])), bigint.FromBytes(v))
+	})
+}
+
+// MarkNEP17Unreliable marks NEP-17 token with the given ID as the one which
+// balances can't be tracked using Transfer events.
+func (dao *Simple) MarkNEP17Unreliable(id int32) {
+	dao.Store.Put(makeNEP17UnreliableKey(id), []byte{1})
+}
+
+// GetNEP17UnreliableTokens returns the set of IDs of NEP-17 tokens marked as
+// unreliable.
+func (dao *Simple) GetNEP17UnreliableTokens() map[int32]bool {
+	var res = make(map[int32]bool)
+	dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STNEP17UnreliableTokens)}}, func(k, _ []byte) bool {
+		if len(k) == 5 {
+			res[int32(binary.LittleEndian.Uint32(k[1:]))] = true
+		}
+		return true
+	})
+	return res
+}
+
+// DeleteAllNEP17Balances removes all indexed NEP-17 balances along with
+// unreliable token marks.
+func (dao *Simple) DeleteAllNEP17Balances() {
+	var keys [][]byte
+	for _, p := range []storage.KeyPrefix{storage.STNEP17Balances, storage.STNEP17UnreliableTokens} {
+		dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(p)}}, func(k, _ []byte) bool {
+			keys = append(keys, bytes.Clone(k))
+			return true
+		})
+	}
+	for _, k := range keys {
+		dao.Store.Delete(k)
+	}
+}
+
+func makeNEP17BalanceKey(acc util.Uint160, id int32) []byte {
+	key := make([]byte, 1+util.Uint160Size+4)
+	key[0] = byte(storage.STNEP17Balances)
+	copy(key[1:], acc.BytesBE())
+	binary.LittleEndian.PutUint32(key[1+util.Uint160Size:], uint32(id))
+	return key
+}
+
+func makeNEP17UnreliableKey(id int32) []byte {
+	key := make([]byte, 5)
+	key[0] = byte(storage.STNEP17UnreliableTokens)
+	binary.LittleEndian.PutUint32(key[1:], uint32(id))
+	return key
+}
+
+// -- end NEP-17 balances.
+
 // -- other.
 
 // GetBlock returns Block by the given hash if it exists in the store.
@@ -417,7 +716,7 @@ func (dao *Simple) GetBlock(hash util.Uint256) (*block.Block, error) {
 }
 
 func (dao *Simple) getBlock(key []byte) (*block.Block, error) {
-	b, err := dao.Store.Get(key)
+	b, err := dao.getExecutable(key)
 	if err != nil {
 		return nil, err
 	}
@@ -565,6 +864,16 @@ func (dao *Simple) GetStateSyncCurrentBlockHeight() (uint32, error) {
 	return binary.LittleEndian.Uint32(b), nil
 }
 
+// GetStateSyncRoot returns the state root MPT nodes are being fetched for
+// during state synchronization process.
+func (dao *Simple) GetStateSyncRoot() (util.Uint256, error) {
+	b, err := dao.Store.Get(dao.mkKeyPrefix(storage.SYSStateSyncRoot))
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesLE(b)
+}
+
 // GetHeaderHashes returns a page of header hashes retrieved from
 // the given underlying store.
 func (dao *Simple) GetHeaderHashes(height uint32) ([]util.Uint256, error) {
@@ -608,7 +917,7 @@ func (dao *Simple) DeleteHeaderHashes(since uint32, batchSize int) {
 // if it exists in the store. It does not return dummy transactions.
 func (dao *Simple) GetTransaction(hash util.Uint256) (*transaction.Transaction, uint32, error) {
 	key := dao.makeExecutableKey(hash)
-	b, err := dao.Store.Get(key)
+	b, err := dao.getExecutable(key)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -665,6 +974,70 @@ func (dao *Simple) PutStateSyncCurrentBlockHeight(h uint32) {
 	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateSyncCurrentBlockHeight), buf.Bytes())
 }
 
+// PutStateSyncRoot stores the state root MPT nodes are being fetched for during
+// state synchronization process.
+func (dao *Simple) PutStateSyncRoot(root util.Uint256) {
+	buf := dao.getDataBuf()
+	buf.WriteBytes(root.BytesLE())
+	dao.Store.Put(dao.mkKeyPrefix(storage.SYSStateSyncRoot), buf.Bytes())
+}
+
+func (dao *Simple) mkChangelogKey(index uint32) []byte {
+	b := dao.getKeyBuf(1 + 4)
+	b[0] = byte(storage.DataChangelog)
+	binary.BigEndian.PutUint32(b[1:], index)
+	return b
+}
+
+// PutChangelog stores reverse state changes of the block with the given index,
+// every change contains the previous value of the key (if it existed).
+func (dao *Simple) PutChangelog(index uint32, changes []storage.KeyValueExists) error {
+	buf := dao.getDataBuf()
+	buf.WriteVarUint(uint64(len(changes)))
+	for i := range changes {
+		buf.WriteVarBytes(changes[i].Key)
+		buf.WriteBool(changes[i].Exists)
+		if changes[i].Exists {
+			buf.WriteVarBytes(changes[i].Value)
+		}
+	}
+	if buf.Err != nil {
+		return buf.Err
+	}
+	dao.Store.Put(dao.mkChangelogKey(index), buf.Bytes())
+	return nil
+}
+
+// GetChangelog returns reverse state changes of the block with the given index.
+func (dao *Simple) GetChangelog(index uint32) ([]storage.KeyValueExists, error) {
+	b, err := dao.Store.Get(dao.mkChangelogKey(index))
+	if err != nil {
+		return nil, err
+	}
+	r := io.NewBinReaderFromBuf(b)
+	n := r.ReadVarUint()
+	var changes []storage.KeyValueExists
+	for i := uint64(0); i < n && r.Err == nil; i++ {
+		var c storage.KeyValueExists
+		c.Key = r.ReadVarBytes()
+		c.Exists = r.ReadBool()
+		if c.Exists {
+			c.Value = r.ReadVarBytes()
+		}
+		changes = append(changes, c)
+	}
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	return changes, nil
+}
+
+// DeleteChangelog removes reverse state changes of the block with the given
+// index.
+func (dao *Simple) DeleteChangelog(index uint32) {
+	dao.Store.Delete(dao.mkChangelogKey(index))
+}
+
 func (dao *Simple) mkHeaderHashKey(h uint32) []byte {
 	b := dao.getKeyBuf(1 + 4)
 	b[0] = byte(storage.IXHeaderHashList)
@@ -694,7 +1067,7 @@ func (dao *Simple) StoreHeaderHashes(hashes []util.Uint256, height uint32) error
 // HasTransaction does not consider the case of block executable.
 func (dao *Simple) HasTransaction(hash util.Uint256, signers []transaction.Signer, currentIndex uint32, maxTraceableBlocks uint32) error {
 	key := dao.makeExecutableKey(hash)
-	bytes, err := dao.Store.Get(key)
+	bytes, err := dao.getExecutable(key)
 	if err != nil {
 		return nil
 	}
@@ -799,6 +1172,11 @@ func (dao *Simple) DeleteBlock(h util.Uint256) error {
 			}
 		}
 	}
+	dao.Store.Delete(dao.makeInvocationTreeKey(h, trigger.OnPersist))
+	dao.Store.Delete(dao.makeInvocationTreeKey(h, trigger.PostPersist))
+	for _, tx := range b.Transactions {
+		dao.Store.Delete(dao.makeInvocationTreeKey(tx.Hash(), trigger.Application))
+	}
 
 	return nil
 }
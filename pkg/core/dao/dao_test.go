@@ -62,6 +62,62 @@ func TestDeleteStorageItem(t *testing.T) {
 	require.Nil(t, gotStorageItem)
 }
 
+func TestStorageUsage(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), false)
+	dao.SetStorageUsageTracking(true)
+	check := func(t *testing.T, d *Simple, id int32, items, size int64) {
+		u, err := d.GetStorageUsage(id)
+		require.NoError(t, err)
+		require.Equal(t, state.StorageUsage{Items: items, Size: size}, u)
+	}
+
+	dao.PutStorageItem(1, []byte{1}, state.StorageItem{1, 2, 3})
+	dao.PutStorageItem(1, []byte{2, 2}, state.StorageItem{1})
+	dao.PutStorageItem(2, []byte{1}, state.StorageItem{})
+	check(t, dao, 1, 2, 4+3)
+	check(t, dao, 2, 1, 1)
+	check(t, dao, 3, 0, 0)
+
+	t.Run("overwrite", func(t *testing.T) {
+		d := dao.GetWrapped()
+		d.PutStorageItem(1, []byte{1}, state.StorageItem{1, 2, 3, 4, 5})
+		check(t, d, 1, 2, 6+3)
+		d.PutStorageItem(1, []byte{1}, state.StorageItem{1})
+		check(t, d, 1, 2, 2+3)
+		check(t, dao, 1, 2, 4+3)
+	})
+	t.Run("delete", func(t *testing.T) {
+		d := dao.GetPrivate()
+		d.DeleteStorageItem(1, []byte{1})
+		check(t, d, 1, 1, 3)
+		d.DeleteStorageItem(1, []byte{1}) // Missing item.
+		check(t, d, 1, 1, 3)
+		d.DeleteStorageItem(1, []byte{2, 2})
+		check(t, d, 1, 0, 0)
+		_, err := d.Store.Get(makeStorageUsageKey(1))
+		require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+		_, err = d.Persist()
+		require.NoError(t, err)
+		check(t, dao, 1, 0, 0)
+	})
+	t.Run("seek and drop", func(t *testing.T) {
+		var ids []int32
+		dao.SeekStorageUsage(func(id int32, _ state.StorageUsage) bool {
+			ids = append(ids, id)
+			return true
+		})
+		require.Equal(t, []int32{2}, ids)
+		dao.DeleteAllStorageUsage()
+		check(t, dao, 2, 0, 0)
+	})
+	t.Run("disabled", func(t *testing.T) {
+		d := NewSimple(storage.NewMemoryStore(), false)
+		d.PutStorageItem(1, []byte{1}, state.StorageItem{1, 2, 3})
+		check(t, d, 1, 0, 0)
+	})
+}
+
 func TestGetBlock_NotExists(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	hash := random.Uint256()
@@ -109,6 +165,73 @@ func TestPutGetBlock(t *testing.T) {
 	require.Equal(t, *appExecResult2, gotAppExecResult[1])
 }
 
+func TestColdStore(t *testing.T) {
+	hot := NewSimple(storage.NewMemoryStore(), false)
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 1)
+	tx.Signers = append(tx.Signers, transaction.Signer{})
+	tx.Scripts = append(tx.Scripts, transaction.Witness{})
+	b := &block.Block{
+		Header: block.Header{
+			Index: 5,
+			Script: transaction.Witness{
+				VerificationScript: []byte{byte(opcode.PUSH1)},
+				InvocationScript:   []byte{byte(opcode.NOP)},
+			},
+		},
+		Transactions: []*transaction.Transaction{tx},
+	}
+	aer := &state.AppExecResult{
+		Container: tx.Hash(),
+		Execution: state.Execution{
+			Trigger: trigger.Application,
+			Events:  []state.NotificationEvent{},
+			Stack:   []stackitem.Item{},
+		},
+	}
+	require.NoError(t, hot.StoreAsBlock(b, nil, nil))
+	require.NoError(t, hot.StoreAsTransaction(tx, b.Index, aer))
+
+	_, err := hot.GetBlockRecords(tx.Hash())
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+	kvs, err := hot.GetBlockRecords(b.Hash())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(kvs))
+
+	cold := storage.NewMemoryStore()
+	d := NewSimple(storage.NewMemoryStore(), false)
+	_, err = d.GetBlock(b.Hash())
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+
+	d.SetColdStore(cold)
+	_, err = d.GetBlock(b.Hash())
+	require.ErrorIs(t, err, storage.ErrKeyNotFound)
+	puts := make(map[string][]byte)
+	for _, kv := range kvs {
+		puts[string(kv.Key)] = kv.Value
+	}
+	require.NoError(t, cold.PutChangeSet(puts, nil))
+	for _, d := range []*Simple{d, d.GetWrapped(), d.GetPrivate()} {
+		actual, err := d.GetBlock(b.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), actual.Hash())
+		require.Equal(t, 1, len(actual.Transactions))
+
+		actualTx, h, err := d.GetTransaction(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Index, h)
+		require.Equal(t, tx.Hash(), actualTx.Hash())
+		require.ErrorIs(t, d.HasTransaction(tx.Hash(), nil, 0, 0), ErrAlreadyExists)
+
+		aers, err := d.GetAppExecResults(tx.Hash(), trigger.All)
+		require.NoError(t, err)
+		require.Equal(t, []state.AppExecResult{*aer}, aers)
+
+		// Raw records are never read from the cold storage.
+		_, err = d.GetBlockRecords(b.Hash())
+		require.ErrorIs(t, err, storage.ErrKeyNotFound)
+	}
+}
+
 func TestGetVersion_NoVersion(t *testing.T) {
 	dao := NewSimple(storage.NewMemoryStore(), false)
 	version, err := dao.GetVersion()
@@ -376,3 +499,18 @@ func TestPutGetStateSyncCurrentBlockHeight(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, actual)
 }
+
+func TestPutGetStateSyncRoot(t *testing.T) {
+	dao := NewSimple(storage.NewMemoryStore(), true)
+
+	// empty store
+	_, err := dao.GetStateSyncRoot()
+	require.Error(t, err)
+
+	// non-empty store
+	expected := util.Uint256{1, 2, 3}
+	dao.PutStateSyncRoot(expected)
+	actual, err := dao.GetStateSyncRoot()
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+}
@@ -0,0 +1,112 @@
+package interop
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// callCache memoizes results of safe method calls made within a single
+// execution. Safe methods can't change the state, so the same method called
+// with the same arguments returns the same result unless the state is changed
+// by some other (writing) call in between, every such call invalidates the
+// cache. Calls using volatile syscalls (see Function.Volatile) are not cached.
+type callCache struct {
+	results map[string]stackitem.Item
+	// epoch is incremented on every cache invalidation.
+	epoch uint64
+	// volatile is the number of volatile syscalls made.
+	volatile uint64
+}
+
+// GetCachedCall returns a copy of the result cached for the call with the
+// given key (see CacheCall) if there is any.
+func (ic *Context) GetCachedCall(key string) (stackitem.Item, bool) {
+	res, ok := ic.calls.results[key]
+	if !ok {
+		return nil, false
+	}
+	res, _ = copyCacheable(res, make(map[stackitem.Item]stackitem.Item))
+	return res, true
+}
+
+// CacheCall starts a cacheable call with the given key, key is expected to
+// identify the method, its arguments, the caller and call flags. It returns a
+// function to be called with the call result when the call is successfully
+// finished, the result is then cached unless the cache was invalidated or
+// volatile syscalls were used during the call. Results that can't be copied
+// (containing Interop or Pointer items) are not cached.
+func (ic *Context) CacheCall(key string) func(res stackitem.Item) {
+	var (
+		epoch    = ic.calls.epoch
+		volatile = ic.calls.volatile
+	)
+	return func(res stackitem.Item) {
+		if epoch != ic.calls.epoch || volatile != ic.calls.volatile {
+			return
+		}
+		res, ok := copyCacheable(res, make(map[stackitem.Item]stackitem.Item))
+		if !ok {
+			return
+		}
+		if ic.calls.results == nil {
+			ic.calls.results = make(map[string]stackitem.Item)
+		}
+		ic.calls.results[key] = res
+	}
+}
+
+// InvalidateCallCache drops all cached call results, it's to be used whenever
+// the state can be changed.
+func (ic *Context) InvalidateCallCache() {
+	ic.calls.epoch++
+	ic.calls.results = nil
+}
+
+// copyCacheable returns a deep mutable copy of the item preserving references
+// between its elements. It returns false if the item contains Interop or
+// Pointer items which can't be cached.
+func copyCacheable(item stackitem.Item, seen map[stackitem.Item]stackitem.Item) (stackitem.Item, bool) {
+	if it, ok := seen[item]; ok {
+		return it, true
+	}
+	switch it := item.(type) {
+	case stackitem.Null, stackitem.Bool, *stackitem.BigInteger, *stackitem.ByteArray:
+		return it, true // Immutable.
+	case *stackitem.Buffer:
+		res := stackitem.NewBuffer(append([]byte{}, it.Value().([]byte)...))
+		seen[item] = res
+		return res, true
+	case *stackitem.Array:
+		res := stackitem.NewArray(make([]stackitem.Item, it.Len()))
+		seen[item] = res
+		return res, copyElements(res.Value().([]stackitem.Item), it.Value().([]stackitem.Item), seen)
+	case *stackitem.Struct:
+		res := stackitem.NewStruct(make([]stackitem.Item, it.Len()))
+		seen[item] = res
+		return res, copyElements(res.Value().([]stackitem.Item), it.Value().([]stackitem.Item), seen)
+	case *stackitem.Map:
+		res := stackitem.NewMap()
+		seen[item] = res
+		for _, e := range it.Value().([]stackitem.MapElement) {
+			v, ok := copyCacheable(e.Value, seen)
+			if !ok {
+				return nil, false
+			}
+			res.Add(e.Key, v) // Keys are immutable.
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
+
+// copyElements copies src items into dst with copyCacheable.
+func copyElements(dst, src []stackitem.Item, seen map[stackitem.Item]stackitem.Item) bool {
+	for i := range src {
+		var ok bool
+		dst[i], ok = copyCacheable(src[i], seen)
+		if !ok {
+			return false
+		}
+	}
+	return true
+}
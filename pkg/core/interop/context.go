@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync/atomic"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -67,7 +68,51 @@ type Context struct {
 	loadToken        func(ic *Context, id int32) error
 	GetRandomCounter uint32
 	signers          []transaction.Signer
-}
+	// nonReentrant contains re-entrancy guards held during the current
+	// execution, see EnterNonReentrant.
+	nonReentrant map[string]struct{}
+	// Profile collects node-local execution profiling data, it's nil unless
+	// profiling is requested.
+	Profile *ExecProfile
+	// InvocationTracker builds the tree of contract calls, it's nil unless
+	// invocation trees are requested.
+	InvocationTracker *InvocationTracker
+	// Tracer receives execution events, it's nil unless tracing is
+	// enabled.
+	Tracer Tracer
+	// contractCalls is the number of contract calls made in this context,
+	// maxContractCalls and maxInvocationStackSize are the limits for them
+	// set by the protocol configuration, see AddContractCall.
+	contractCalls          uint32
+	maxContractCalls       uint32
+	maxInvocationStackSize uint32
+	// dynamicScripts is the number of scripts loaded with
+	// System.Runtime.LoadScript in this context, maxDynamicScripts and
+	// maxDynamicScriptSize are the limits for them set by the protocol
+	// configuration, see AddDynamicScript.
+	dynamicScripts       uint32
+	maxDynamicScripts    uint32
+	maxDynamicScriptSize uint32
+	// calls contains results of safe method calls made in this context,
+	// see CacheCall.
+	calls callCache
+}
+
+var (
+	// ErrInvocationStackLimit is returned from contract call when the
+	// invocation stack depth limit is reached.
+	ErrInvocationStackLimit = errors.New("invocation stack limit reached")
+	// ErrTooManyContractCalls is returned from contract call when the number
+	// of contract calls allowed within a single execution is exceeded.
+	ErrTooManyContractCalls = errors.New("too many contract calls")
+	// ErrDynamicScriptTooBig is returned from System.Runtime.LoadScript when
+	// the script loaded exceeds the size limit.
+	ErrDynamicScriptTooBig = errors.New("dynamic script is too big")
+	// ErrTooManyDynamicScripts is returned from System.Runtime.LoadScript
+	// when the number of scripts loaded within a single execution is
+	// exceeded.
+	ErrTooManyDynamicScripts = errors.New("too many dynamic scripts")
+)
 
 // NewContext returns new interop context.
 func NewContext(trigger trigger.Type, bc Ledger, d *dao.Simple, baseExecFee, baseStorageFee int64,
@@ -77,20 +122,24 @@ func NewContext(trigger trigger.Type, bc Ledger, d *dao.Simple, baseExecFee, bas
 	dao := d.GetPrivate()
 	cfg := bc.GetConfig().ProtocolConfiguration
 	return &Context{
-		Chain:          bc,
-		Network:        uint32(cfg.Magic),
-		Hardforks:      cfg.Hardforks,
-		Natives:        natives,
-		Trigger:        trigger,
-		Block:          block,
-		Tx:             tx,
-		DAO:            dao,
-		Log:            log,
-		Invocations:    make(map[util.Uint160]int),
-		getContract:    getContract,
-		baseExecFee:    baseExecFee,
-		baseStorageFee: baseStorageFee,
-		loadToken:      loadTokenFunc,
+		Chain:                  bc,
+		Network:                uint32(cfg.Magic),
+		Hardforks:              cfg.Hardforks,
+		Natives:                natives,
+		Trigger:                trigger,
+		Block:                  block,
+		Tx:                     tx,
+		DAO:                    dao,
+		Log:                    log,
+		Invocations:            make(map[util.Uint160]int),
+		getContract:            getContract,
+		baseExecFee:            baseExecFee,
+		baseStorageFee:         baseStorageFee,
+		loadToken:              loadTokenFunc,
+		maxContractCalls:       cfg.MaxContractCalls,
+		maxInvocationStackSize: cfg.MaxInvocationStackSize,
+		maxDynamicScripts:      cfg.MaxDynamicScripts,
+		maxDynamicScriptSize:   cfg.MaxDynamicScriptSize,
 	}
 }
 
@@ -135,6 +184,14 @@ type Function struct {
 	// RequiredFlags is a set of flags which must be set during script invocations.
 	// Default value is NoneFlag i.e. no flags are required.
 	RequiredFlags callflag.CallFlag
+	// ActiveFrom is the hardfork the function is available from, nil means
+	// it's always available.
+	ActiveFrom *config.Hardfork
+	// Volatile is true for functions that can return different results
+	// within a single execution for the same state (like GetRandom) or that
+	// are to be executed every time (like BurnGas), calls using them are
+	// never cached.
+	Volatile bool
 }
 
 // Method is a signature for a native method.
@@ -148,6 +205,23 @@ type MethodAndPrice struct {
 	StorageFee    int64
 	SyscallOffset int
 	RequiredFlags callflag.CallFlag
+	// ActiveFrom is the hardfork the method is available from, nil means
+	// it's always available.
+	ActiveFrom *config.Hardfork
+	// Stats accumulates node-local method invocation statistics, it's nil
+	// unless statistics gathering is enabled.
+	Stats *MethodStats
+}
+
+// MethodStats contains node-local native method invocation statistics. It's
+// not a part of the chain state and it's updated concurrently, so atomic
+// operations are used.
+type MethodStats struct {
+	// Calls is the number of method invocations.
+	Calls atomic.Int64
+	// GAS is the amount of GAS spent by method invocations (including the
+	// method price and everything charged by the method itself).
+	GAS atomic.Int64
 }
 
 // Contract is an interface for all native contracts.
@@ -171,6 +245,27 @@ type ContractMD struct {
 	state.NativeContract
 	Name    string
 	Methods []MethodAndPrice
+
+	// mds contains hardfork-specific contract metadata ordered by hardfork,
+	// the first element is the metadata valid before all of them.
+	mds []hfSpecificMD
+	// eventsActiveFrom contains hardforks enabling events that are not
+	// always available.
+	eventsActiveFrom map[string]config.Hardfork
+}
+
+// HFSpecificContractMD is a native contract metadata (NEF, manifest and
+// methods) valid for some specific set of enabled hardforks. Methods that
+// are not active yet are not included into it.
+type HFSpecificContractMD struct {
+	state.ContractBase
+	Methods []MethodAndPrice
+}
+
+// hfSpecificMD is a contract metadata valid since the specified hardfork.
+type hfSpecificMD struct {
+	hf config.Hardfork
+	md *HFSpecificContractMD
 }
 
 // NewContractMD returns Contract with the specified list of methods.
@@ -190,24 +285,110 @@ func NewContractMD(name string, id int32) *ContractMD {
 	return c
 }
 
-// UpdateHash creates a native contract script and updates hash.
+// UpdateHash creates a native contract script and updates hash. It also
+// builds hardfork-specific contract metadata if some methods or events are
+// available only from some hardfork.
 func (c *ContractMD) UpdateHash() {
+	c.NEF.Script = buildNativeScript(c.Methods, c.Manifest.ABI.Methods)
+	c.NEF.Checksum = c.NEF.CalculateChecksum()
+
+	var hfs []config.Hardfork
+	for _, m := range c.Methods {
+		if m.ActiveFrom != nil && !containsHardfork(hfs, *m.ActiveFrom) {
+			hfs = append(hfs, *m.ActiveFrom)
+		}
+	}
+	for _, hf := range c.eventsActiveFrom {
+		if !containsHardfork(hfs, hf) {
+			hfs = append(hfs, hf)
+		}
+	}
+	sort.Slice(hfs, func(i, j int) bool { return hfs[i] < hfs[j] })
+	c.mds = make([]hfSpecificMD, 0, len(hfs)+1)
+	c.mds = append(c.mds, hfSpecificMD{md: c.buildHFSpecificMD(0)})
+	for _, hf := range hfs {
+		c.mds = append(c.mds, hfSpecificMD{hf: hf, md: c.buildHFSpecificMD(hf)})
+	}
+}
+
+// buildHFSpecificMD creates a contract metadata containing only methods and
+// events that are active with the given hardfork (and all preceding ones)
+// enabled, zero hardfork means that only always-active ones are included.
+func (c *ContractMD) buildHFSpecificMD(hf config.Hardfork) *HFSpecificContractMD {
+	md := &HFSpecificContractMD{
+		ContractBase: c.ContractBase,
+		Methods:      make([]MethodAndPrice, 0, len(c.Methods)),
+	}
+	md.Manifest.ABI.Methods = make([]manifest.Method, 0, len(c.Methods))
+	for _, m := range c.Methods {
+		if m.ActiveFrom != nil && (hf == 0 || *m.ActiveFrom > hf) {
+			continue
+		}
+		desc := *m.MD // Offsets are different for different hardforks.
+		m.MD = &desc
+		md.Methods = append(md.Methods, m)
+		md.Manifest.ABI.Methods = append(md.Manifest.ABI.Methods, desc)
+	}
+	md.Manifest.ABI.Events = make([]manifest.Event, 0, len(c.Manifest.ABI.Events))
+	for _, e := range c.Manifest.ABI.Events {
+		if from, ok := c.eventsActiveFrom[e.Name]; ok && (hf == 0 || from > hf) {
+			continue
+		}
+		md.Manifest.ABI.Events = append(md.Manifest.ABI.Events, e)
+	}
+	md.NEF.Script = buildNativeScript(md.Methods, md.Manifest.ABI.Methods)
+	md.NEF.Checksum = md.NEF.CalculateChecksum()
+	return md
+}
+
+// buildNativeScript creates a native contract script for the given methods,
+// updates their offsets and returns the script.
+func buildNativeScript(ms []MethodAndPrice, descs []manifest.Method) []byte {
 	w := io.NewBufBinWriter()
-	for i := range c.Methods {
+	for i := range ms {
 		offset := w.Len()
-		c.Methods[i].MD.Offset = offset
-		c.Manifest.ABI.Methods[i].Offset = offset
+		ms[i].MD.Offset = offset
+		descs[i].Offset = offset
 		emit.Int(w.BinWriter, 0)
-		c.Methods[i].SyscallOffset = w.Len()
+		ms[i].SyscallOffset = w.Len()
 		emit.Syscall(w.BinWriter, interopnames.SystemContractCallNative)
 		emit.Opcodes(w.BinWriter, opcode.RET)
 	}
 	if w.Err != nil {
 		panic(fmt.Errorf("can't create native contract script: %w", w.Err))
 	}
+	return w.Bytes()
+}
 
-	c.NEF.Script = w.Bytes()
-	c.NEF.Checksum = c.NEF.CalculateChecksum()
+func containsHardfork(hfs []config.Hardfork, hf config.Hardfork) bool {
+	for _, h := range hfs {
+		if h == hf {
+			return true
+		}
+	}
+	return false
+}
+
+// HFSpecificContractMD returns the contract metadata valid for the set of
+// enabled hardforks, isEnabled is only called for the hardforks that change
+// the set of contract methods.
+func (c *ContractMD) HFSpecificContractMD(isEnabled func(config.Hardfork) bool) *HFSpecificContractMD {
+	for i := len(c.mds) - 1; i > 0; i-- {
+		if isEnabled(c.mds[i].hf) {
+			return c.mds[i].md
+		}
+	}
+	return c.mds[0].md
+}
+
+// MethodHardforks returns an ordered list of hardforks that enable some of
+// the contract methods or events.
+func (c *ContractMD) MethodHardforks() []config.Hardfork {
+	res := make([]config.Hardfork, 0, len(c.mds)-1)
+	for _, md := range c.mds[1:] {
+		res = append(res, md.hf)
+	}
+	return res
 }
 
 // AddMethod adds a new method to a native contract.
@@ -234,7 +415,7 @@ func (c *ContractMD) AddMethod(md *MethodAndPrice, desc *manifest.Method) {
 
 // GetMethodByOffset returns method with the provided offset.
 // Offset is offset of `System.Contract.CallNative` syscall.
-func (c *ContractMD) GetMethodByOffset(offset int) (MethodAndPrice, bool) {
+func (c *HFSpecificContractMD) GetMethodByOffset(offset int) (MethodAndPrice, bool) {
 	for k := range c.Methods {
 		if c.Methods[k].SyscallOffset == offset {
 			return c.Methods[k], true
@@ -272,6 +453,16 @@ func (c *ContractMD) AddEvent(name string, ps ...manifest.Parameter) {
 	})
 }
 
+// AddEventFrom adds a new event to the native contract that is available
+// only since the specified hardfork.
+func (c *ContractMD) AddEventFrom(hf config.Hardfork, name string, ps ...manifest.Parameter) {
+	if c.eventsActiveFrom == nil {
+		c.eventsActiveFrom = make(map[string]config.Hardfork)
+	}
+	c.eventsActiveFrom[name] = hf
+	c.AddEvent(name, ps...)
+}
+
 // Sort sorts interop functions by id.
 func Sort(fs []Function) {
 	sort.Slice(fs, func(i, j int) bool { return fs[i].ID < fs[j].ID })
@@ -312,7 +503,7 @@ func (ic *Context) LoadToken(id int32) error {
 // SyscallHandler handles syscall with id.
 func (ic *Context) SyscallHandler(_ *vm.VM, id uint32) error {
 	f := ic.GetFunction(id)
-	if f == nil {
+	if f == nil || (f.ActiveFrom != nil && !ic.IsHardforkEnabled(*f.ActiveFrom)) {
 		return errors.New("syscall not found")
 	}
 	cf := ic.VM.Context().GetCallFlags()
@@ -322,6 +513,15 @@ func (ic *Context) SyscallHandler(_ *vm.VM, id uint32) error {
 	if !ic.VM.AddGas(f.Price * ic.BaseExecFee()) {
 		return errors.New("insufficient amount of gas")
 	}
+	if ic.Profile != nil {
+		ic.Profile.Syscalls[f.Name]++
+	}
+	if f.Volatile {
+		ic.calls.volatile++
+	}
+	if f.RequiredFlags&callflag.WriteStates != 0 {
+		ic.InvalidateCallCache()
+	}
 	return f.Func(ic)
 }
 
@@ -340,6 +540,115 @@ func (ic *Context) initVM(v *vm.VM) {
 	ic.VM = v
 }
 
+// AddContractCall accounts a call of the contract h checking it against the
+// invocation stack depth and contract call number limits. Limits set by the
+// protocol configuration are only effective since NeoGoExtensions hardfork, VM
+// invocation stack limit is used before it and the number of calls is not
+// limited.
+func (ic *Context) AddContractCall(h util.Uint160) error {
+	var (
+		depth    = len(ic.VM.Istack())
+		maxDepth = vm.MaxInvocationStackSize
+		maxCalls uint32
+	)
+	if (ic.maxInvocationStackSize != 0 || ic.maxContractCalls != 0) && ic.IsHardforkEnabled(config.HFNeoGoExtensions) {
+		if ic.maxInvocationStackSize != 0 {
+			maxDepth = int(ic.maxInvocationStackSize)
+		}
+		maxCalls = ic.maxContractCalls
+	}
+	if depth >= maxDepth {
+		return fmt.Errorf("%w: calling %s at depth %d (max %d)", ErrInvocationStackLimit, h.StringLE(), depth, maxDepth)
+	}
+	if maxCalls != 0 && ic.contractCalls >= maxCalls {
+		return fmt.Errorf("%w: calling %s at depth %d (max %d calls)", ErrTooManyContractCalls, h.StringLE(), depth, maxCalls)
+	}
+	ic.contractCalls++
+	return nil
+}
+
+// AddDynamicScript accounts a script of the given size loaded with
+// System.Runtime.LoadScript checking it against the script size and the
+// number of dynamic scripts limits. These limits are only effective since
+// NeoGoExtensions hardfork.
+func (ic *Context) AddDynamicScript(size int) error {
+	if !ic.IsHardforkEnabled(config.HFNeoGoExtensions) {
+		return nil
+	}
+	if ic.maxDynamicScriptSize != 0 && size > int(ic.maxDynamicScriptSize) {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrDynamicScriptTooBig, size, ic.maxDynamicScriptSize)
+	}
+	if ic.maxDynamicScripts != 0 && ic.dynamicScripts >= ic.maxDynamicScripts {
+		return fmt.Errorf("%w: %d scripts already loaded (max %d)", ErrTooManyDynamicScripts, ic.dynamicScripts, ic.maxDynamicScripts)
+	}
+	ic.dynamicScripts++
+	return nil
+}
+
+// TrackCall adds a call of the contract method to the invocation tree (if
+// it's being built), reports it to the Tracer (if any) and returns a function
+// to be called once the called context is unloaded, ok is false for contexts
+// unloaded because of exception. It returns nil if there is nothing to track.
+func (ic *Context) TrackCall(h util.Uint160, method string) func(ok bool) {
+	if ic.InvocationTracker == nil && ic.Tracer == nil {
+		return nil
+	}
+	var (
+		t     = ic.InvocationTracker
+		tr    = ic.Tracer
+		start = ic.VM.GasConsumed()
+	)
+	if t != nil {
+		t.enter(h, method, start)
+	}
+	if tr != nil {
+		tr.OnCallStart(h, method, start)
+	}
+	return func(ok bool) {
+		var consumed = ic.VM.GasConsumed()
+		if t != nil {
+			t.leave(consumed)
+		}
+		if tr != nil {
+			var err error
+			if !ok {
+				err = ErrUnhandledException
+			}
+			tr.OnCallEnd(h, method, start, consumed, err)
+		}
+	}
+}
+
+// EnterNonReentrant takes re-entrancy guard with the given key for the contract
+// h, it returns an error if the guard is already held. Guards are not stored
+// anywhere, so they're valid for the current execution only.
+func (ic *Context) EnterNonReentrant(h util.Uint160, key []byte) error {
+	k := nonReentrantKey(h, key)
+	if _, ok := ic.nonReentrant[k]; ok {
+		return errors.New("re-entrancy detected")
+	}
+	if ic.nonReentrant == nil {
+		ic.nonReentrant = make(map[string]struct{})
+	}
+	ic.nonReentrant[k] = struct{}{}
+	return nil
+}
+
+// LeaveNonReentrant releases re-entrancy guard with the given key for the
+// contract h, it returns an error if the guard is not held.
+func (ic *Context) LeaveNonReentrant(h util.Uint160, key []byte) error {
+	k := nonReentrantKey(h, key)
+	if _, ok := ic.nonReentrant[k]; !ok {
+		return errors.New("re-entrancy guard is not held")
+	}
+	delete(ic.nonReentrant, k)
+	return nil
+}
+
+func nonReentrantKey(h util.Uint160, key []byte) string {
+	return string(h.BytesBE()) + string(key)
+}
+
 // ReuseVM resets given VM and allows to reuse it in the current context.
 func (ic *Context) ReuseVM(v *vm.VM) {
 	v.Reset(ic.Trigger)
@@ -365,7 +674,13 @@ func (ic *Context) Finalize() {
 // Exec executes loaded VM script and calls registered finalizers to release the occupied resources.
 func (ic *Context) Exec() error {
 	defer ic.Finalize()
-	return ic.VM.Run()
+	if ic.Tracer == nil {
+		return ic.VM.Run()
+	}
+	ic.Tracer.OnExecStart(ic)
+	err := ic.VM.Run()
+	ic.Tracer.OnExecEnd(ic, err)
+	return err
 }
 
 // BlockHeight returns the latest persisted and stored block height/index.
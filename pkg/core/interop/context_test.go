@@ -5,6 +5,9 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,3 +36,52 @@ func TestIsHardforkEnabled(t *testing.T) {
 		require.True(t, ic.IsHardforkEnabled(config.HFAspidochelone))
 	})
 }
+
+func TestAddContractCall(t *testing.T) {
+	h := util.Uint160{1, 2, 3}
+	newIC := func(depth int, hf uint32, maxDepth, maxCalls uint32) *Context {
+		ic := &Context{
+			Hardforks:              map[string]uint32{config.HFNeoGoExtensions.String(): hf},
+			Block:                  &block.Block{Header: block.Header{Index: 10}},
+			maxInvocationStackSize: maxDepth,
+			maxContractCalls:       maxCalls,
+		}
+		ic.VM = vm.New()
+		for i := 0; i < depth; i++ {
+			ic.VM.LoadScript([]byte{byte(opcode.RET)})
+		}
+		return ic
+	}
+
+	t.Run("default", func(t *testing.T) {
+		ic := newIC(vm.MaxInvocationStackSize-1, 0, 0, 0)
+		for i := 0; i < 100; i++ {
+			require.NoError(t, ic.AddContractCall(h))
+		}
+		ic.VM.LoadScript([]byte{byte(opcode.RET)})
+		err := ic.AddContractCall(h)
+		require.ErrorIs(t, err, ErrInvocationStackLimit)
+		require.ErrorContains(t, err, h.StringLE())
+		require.ErrorContains(t, err, "depth 1024")
+	})
+	t.Run("configured", func(t *testing.T) {
+		ic := newIC(3, 0, 4, 0)
+		require.NoError(t, ic.AddContractCall(h))
+		ic.VM.LoadScript([]byte{byte(opcode.RET)})
+		require.ErrorIs(t, ic.AddContractCall(h), ErrInvocationStackLimit)
+
+		ic = newIC(1, 0, 0, 2)
+		require.NoError(t, ic.AddContractCall(h))
+		require.NoError(t, ic.AddContractCall(h))
+		err := ic.AddContractCall(h)
+		require.ErrorIs(t, err, ErrTooManyContractCalls)
+		require.ErrorContains(t, err, h.StringLE())
+		require.ErrorContains(t, err, "depth 1")
+	})
+	t.Run("before hardfork", func(t *testing.T) {
+		ic := newIC(10, 20, 4, 2)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, ic.AddContractCall(h))
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
@@ -76,6 +77,9 @@ func callInternal(ic *interop.Context, cs *state.Contract, name string, f callfl
 	md := cs.Manifest.ABI.GetMethod(name, len(args))
 	if md.Safe {
 		f &^= (callflag.WriteStates | callflag.AllowNotify)
+		if hasReturn && ic.IsHardforkEnabled(config.HFNeoGoExtensions) {
+			return callSafe(ic, cs, name, f, args, isDynamic)
+		}
 	} else if ctx := ic.VM.Context(); ctx != nil && ctx.IsDeployed() {
 		curr, err := ic.GetContract(ic.VM.GetCurrentScriptHash())
 		if err == nil {
@@ -87,9 +91,54 @@ func callInternal(ic *interop.Context, cs *state.Contract, name string, f callfl
 	return callExFromNative(ic, ic.VM.GetCurrentScriptHash(), cs, name, args, f, hasReturn, isDynamic, false)
 }
 
+// callSafe calls a safe method returning a value, results of such calls are
+// cached within the execution (see interop.Context.CacheCall) if arguments
+// are primitive (so they can't be changed by the method) and the method has
+// not used any volatile syscalls. Cached calls are not executed at all, so
+// they're cheaper, they're not counted as contract calls and invocations.
+func callSafe(ic *interop.Context, cs *state.Contract, name string, f callflag.CallFlag,
+	args []stackitem.Item, isDynamic bool) error {
+	var (
+		caller = ic.VM.GetCurrentScriptHash()
+		key    = safeCallKey(caller, cs.Hash, name, ic.VM.Context().GetCallFlags()&f, args)
+	)
+	if key == "" {
+		return callExFromNative(ic, caller, cs, name, args, f, true, isDynamic, false)
+	}
+	if res, ok := ic.GetCachedCall(key); ok {
+		ic.VM.Estack().PushItem(res)
+		return nil
+	}
+	return callEx(ic, caller, cs, name, args, f, true, isDynamic, false, ic.CacheCall(key))
+}
+
+// safeCallKey returns a cache key for the call or an empty string if the call
+// can't be cached.
+func safeCallKey(caller, h util.Uint160, name string, f callflag.CallFlag, args []stackitem.Item) string {
+	for _, arg := range args {
+		switch arg.(type) {
+		case stackitem.Null, stackitem.Bool, *stackitem.BigInteger, *stackitem.ByteArray:
+		default:
+			return "" // Mutable or can't be serialized.
+		}
+	}
+	a, err := stackitem.Serialize(stackitem.NewArray(args))
+	if err != nil {
+		return ""
+	}
+	return string(caller.BytesBE()) + string(h.BytesBE()) + string([]byte{byte(f)}) + name + "\x00" + string(a)
+}
+
 // callExFromNative calls a contract with flags using the provided calling hash.
 func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contract,
 	name string, args []stackitem.Item, f callflag.CallFlag, hasReturn bool, isDynamic bool, callFromNative bool) error {
+	return callEx(ic, caller, cs, name, args, f, hasReturn, isDynamic, callFromNative, nil)
+}
+
+// callEx is callExFromNative with an optional cacheResult callback called
+// with the value returned by the method when it's successfully finished.
+func callEx(ic *interop.Context, caller util.Uint160, cs *state.Contract, name string, args []stackitem.Item,
+	f callflag.CallFlag, hasReturn bool, isDynamic bool, callFromNative bool, cacheResult func(stackitem.Item)) error {
 	for _, nc := range ic.Natives {
 		if nc.Metadata().Name == nativenames.Policy {
 			var pch = nc.(policyChecker)
@@ -109,13 +158,21 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 	}
 
 	methodOff := md.Offset
+	retType := md.ReturnType
+	checkRet := isDynamic && ic.IsHardforkEnabled(config.HFNeoGoExtensions)
 	initOff := -1
 	md = cs.Manifest.ABI.GetMethod(manifest.MethodInit, 0)
 	if md != nil {
 		initOff = md.Offset
 	}
+	if err := ic.AddContractCall(cs.Hash); err != nil {
+		return err
+	}
 	ic.Invocations[cs.Hash]++
 	f = ic.VM.Context().GetCallFlags() & f
+	if f&callflag.WriteStates != 0 {
+		ic.InvalidateCallCache() // State can be changed by this call.
+	}
 
 	wrapped := ic.VM.ContractHasTryBlock() && // If the method is not wrapped into try-catch block, then changes should be discarded anyway if exception occurs.
 		f&(callflag.All^callflag.ReadOnly) != 0 // If the method is safe, then it's read-only and doesn't perform storage changes or emit notifications.
@@ -124,7 +181,11 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 	if wrapped {
 		ic.DAO = ic.DAO.GetPrivate()
 	}
+	callDone := ic.TrackCall(cs.Hash, name)
 	onUnload := func(v *vm.VM, ctx *vm.Context, commit bool) error {
+		if callDone != nil {
+			callDone(commit)
+		}
 		if wrapped {
 			if commit {
 				_, err := ic.DAO.Persist()
@@ -136,9 +197,21 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 			}
 			ic.DAO = baseDAO
 		}
+		if f&callflag.WriteStates != 0 {
+			ic.InvalidateCallCache()
+		}
 		if callFromNative && !commit {
 			return fmt.Errorf("unhandled exception")
 		}
+		if isDynamic && commit && checkRet {
+			err := checkReturnValue(ctx, cs, name, retType)
+			if err != nil {
+				return err
+			}
+		}
+		if cacheResult != nil && commit && ctx.Estack().Len() == 1 {
+			cacheResult(ctx.Estack().Top().Item())
+		}
 		if isDynamic {
 			return vm.DynamicOnUnload(v, ctx, commit)
 		}
@@ -153,6 +226,57 @@ func callExFromNative(ic *interop.Context, caller util.Uint160, cs *state.Contra
 	return nil
 }
 
+// checkReturnValue checks the value returned by the method against its
+// declared return type converting it when possible. Anything returned from Void
+// methods is dropped.
+func checkReturnValue(ctx *vm.Context, cs *state.Contract, name string, typ smartcontract.ParamType) error {
+	estack := ctx.Estack()
+	if typ == smartcontract.VoidType {
+		for estack.Len() > 0 {
+			estack.Pop()
+		}
+		return nil
+	}
+	if estack.Len() != 1 {
+		return fmt.Errorf("contract %s (%s) method %s returned %d values instead of 1",
+			cs.Manifest.Name, cs.Hash.StringLE(), name, estack.Len())
+	}
+	item := estack.Top().Item()
+	if item.Type() == stackitem.AnyT || typ.Match(item) {
+		return nil
+	}
+	if isPrimitive(item) && isPrimitiveType(typ) {
+		converted, err := item.Convert(typ.ConvertToStackitemType())
+		if err == nil && typ.Match(converted) {
+			estack.Pop()
+			estack.PushItem(converted)
+			return nil
+		}
+	}
+	return fmt.Errorf("contract %s (%s) method %s returned %s instead of %s",
+		cs.Manifest.Name, cs.Hash.StringLE(), name, item.Type(), typ)
+}
+
+func isPrimitive(item stackitem.Item) bool {
+	switch item.Type() {
+	case stackitem.BooleanT, stackitem.IntegerT, stackitem.ByteArrayT, stackitem.BufferT:
+		return true
+	default:
+		return false
+	}
+}
+
+func isPrimitiveType(typ smartcontract.ParamType) bool {
+	switch typ {
+	case smartcontract.BoolType, smartcontract.IntegerType, smartcontract.ByteArrayType,
+		smartcontract.StringType, smartcontract.Hash160Type, smartcontract.Hash256Type,
+		smartcontract.PublicKeyType, smartcontract.SignatureType:
+		return true
+	default:
+		return false
+	}
+}
+
 // ErrNativeCall is returned for failed calls from native.
 var ErrNativeCall = errors.New("failed native call")
 
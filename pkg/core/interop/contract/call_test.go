@@ -11,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/contracts"
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -612,6 +613,160 @@ func TestCALLL_from_VoidContext(t *testing.T) {
 	ctrInvoker.Invoke(t, stackitem.Null{}, "callHasRet")
 }
 
+// TestCall_FlagsNoEscalation checks that call flags dropped by the caller
+// are unavailable to the whole call tree, including calls back into the
+// caller itself.
+func TestCall_FlagsNoEscalation(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Contract A is the privileged one, it holds all flags and calls the
+	// untrusted code with reduced flags.
+	srcA := `package contractA
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Sandbox(h interop.Hash160, method string, args []any) any {
+			return contract.Call(h, method, contract.ReadOnly, args...)
+		}
+		func Direct(h interop.Hash160, method string, args []any) any {
+			return contract.Call(h, method, contract.All, args...)
+		}
+		func Put() {
+			storage.Put(storage.GetContext(), "key", "value")
+		}`
+	// Contract B is a child proxying calls with all flags requested.
+	srcB := `package contractB
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		)
+		func Proxy(h interop.Hash160, method string, args []any) any {
+			return contract.Call(h, method, contract.All, args...)
+		}`
+	// Contract C is a grandchild trying to change the state.
+	srcC := `package contractC
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Flags() int {
+			return int(contract.GetCallFlags())
+		}
+		func Put() {
+			storage.Put(storage.GetContext(), "key", "value")
+		}
+		func CallBack(h interop.Hash160) {
+			contract.Call(h, "put", contract.All)
+		}`
+	var ctrs = make([]*neotest.Contract, 3)
+	for i, src := range []string{srcA, srcB, srcC} {
+		name := fmt.Sprintf("contract%c", 'A'+i)
+		ctrs[i] = neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+			NoEventsCheck:      true,
+			NoPermissionsCheck: true,
+			Name:               name,
+			Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		})
+		e.DeployContract(t, ctrs[i], nil)
+	}
+	var (
+		ctrA = e.NewInvoker(ctrs[0].Hash, e.Committee)
+		hA   = ctrs[0].Hash
+		hB   = ctrs[1].Hash
+		hC   = ctrs[2].Hash
+	)
+
+	t.Run("direct", func(t *testing.T) {
+		ctrA.Invoke(t, int64(callflag.All), "direct", hB, "proxy", []any{hC, "flags", []any{}})
+		ctrA.Invoke(t, stackitem.Null{}, "direct", hB, "proxy", []any{hC, "put", []any{}})
+		ctrA.Invoke(t, stackitem.Null{}, "direct", hB, "proxy", []any{hC, "callBack", []any{hA}})
+	})
+	t.Run("sandboxed", func(t *testing.T) {
+		ctrA.Invoke(t, int64(callflag.ReadOnly), "sandbox", hC, "flags", []any{})
+		ctrA.Invoke(t, int64(callflag.ReadOnly), "sandbox", hB, "proxy", []any{hC, "flags", []any{}})
+		ctrA.InvokeFail(t, "missing call flags", "sandbox", hC, "put", []any{})
+		ctrA.InvokeFail(t, "missing call flags", "sandbox", hB, "proxy", []any{hC, "put", []any{}})
+		ctrA.InvokeFail(t, "missing call flags", "sandbox", hB, "proxy", []any{hC, "callBack", []any{hA}})
+	})
+}
+
+// callLimitsSrc is a contract making recursive (recurse) and sequential (loop)
+// calls to itself.
+const callLimitsSrc = `package recursive
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	)
+	func Recurse(n int) int {
+		if n == 0 {
+			return 0
+		}
+		return contract.Call(runtime.GetExecutingScriptHash(), "recurse", contract.All, n-1).(int) + 1
+	}
+	func Loop(n int) {
+		for i := 0; i < n; i++ {
+			contract.Call(runtime.GetExecutingScriptHash(), "recurse", contract.All, 0)
+		}
+	}`
+
+func TestCall_Limits(t *testing.T) {
+	deploy := func(t *testing.T, f func(*config.Blockchain)) (*neotest.ContractInvoker, util.Uint160) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, f)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		ctr := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(callLimitsSrc), &compiler.Options{
+			NoEventsCheck:      true,
+			NoPermissionsCheck: true,
+			Name:               "recursive",
+			Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		})
+		e.DeployContract(t, ctr, nil)
+		return e.NewInvoker(ctr.Hash, acc), ctr.Hash
+	}
+
+	t.Run("default", func(t *testing.T) {
+		c, _ := deploy(t, nil)
+		c.Invoke(t, 100, "recurse", 100)
+		c.Invoke(t, stackitem.Null{}, "loop", 100)
+		// VM stack size limit is reached before the invocation stack one
+		// for this contract.
+		c.InvokeFail(t, "stack is too big", "recurse", -1)
+	})
+	t.Run("configured", func(t *testing.T) {
+		c, h := deploy(t, func(cfg *config.Blockchain) {
+			cfg.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+			cfg.MaxInvocationStackSize = 16
+			cfg.MaxContractCalls = 20
+		})
+		// The entry script is the first one in the invocation stack.
+		c.Invoke(t, 14, "recurse", 14)
+		c.InvokeFail(t, fmt.Sprintf("%s: calling %s at depth 16 (max 16)",
+			interop.ErrInvocationStackLimit, h.StringLE()), "recurse", 15)
+
+		// Loop call itself is the first one.
+		c.Invoke(t, stackitem.Null{}, "loop", 19)
+		c.InvokeFail(t, fmt.Sprintf("%s: calling %s at depth 2 (max 20 calls)",
+			interop.ErrTooManyContractCalls, h.StringLE()), "loop", 20)
+	})
+	t.Run("before hardfork", func(t *testing.T) {
+		c, _ := deploy(t, func(cfg *config.Blockchain) {
+			cfg.Hardforks = map[string]uint32{
+				config.HFAspidochelone.String():   0,
+				config.HFBasilisk.String():        0,
+				config.HFCockatrice.String():      0,
+				config.HFNeoGoExtensions.String(): 100,
+			}
+			cfg.MaxInvocationStackSize = 16
+			cfg.MaxContractCalls = 20
+		})
+		c.Invoke(t, 100, "recurse", 100)
+		c.Invoke(t, stackitem.Null{}, "loop", 100)
+	})
+}
+
 func loadScript(ic *interop.Context, script []byte, args ...any) {
 	ic.SpawnVM()
 	ic.VM.LoadScriptWithFlags(script, callflag.AllowCall)
@@ -629,3 +784,200 @@ func loadScriptWithHashAndFlags(ic *interop.Context, script []byte, hash util.Ui
 	}
 	ic.VM.GasLimit = -1
 }
+
+func TestCall_ReturnTypeCheck(t *testing.T) {
+	src := `package callee
+		func GetArray() any { return []any{1, 2} }
+		func GetFlag() any { return 1 }
+		func GetBytes() any { return []byte{1, 2, 3} }
+		func GetInt() any { return 5 }
+		func GetNil() any { return nil }
+		func GetNothing() { GetInt() }`
+	deploy := func(t *testing.T, f func(*config.Blockchain)) (*neotest.ContractInvoker, util.Uint160) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, f)
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		ctr := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+			NoEventsCheck:      true,
+			NoPermissionsCheck: true,
+			Name:               "callee",
+		})
+		// The manifest lies about return values.
+		for name, typ := range map[string]smartcontract.ParamType{
+			"getArray":   smartcontract.IntegerType,
+			"getFlag":    smartcontract.BoolType,
+			"getBytes":   smartcontract.Hash160Type,
+			"getInt":     smartcontract.VoidType,
+			"getNil":     smartcontract.Hash160Type,
+			"getNothing": smartcontract.IntegerType,
+		} {
+			ctr.Manifest.ABI.GetMethod(name, 0).ReturnType = typ
+		}
+		e.DeployContract(t, ctr, nil)
+		return e.NewInvoker(ctr.Hash, acc), ctr.Hash
+	}
+
+	t.Run("enforced", func(t *testing.T) {
+		c, h := deploy(t, func(cfg *config.Blockchain) {
+			cfg.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+		})
+		c.InvokeFail(t, fmt.Sprintf("contract callee (%s) method getArray returned Array instead of Integer",
+			h.StringLE()), "getArray")
+		c.Invoke(t, true, "getFlag")
+		c.InvokeFail(t, fmt.Sprintf("contract callee (%s) method getBytes returned Buffer instead of Hash160",
+			h.StringLE()), "getBytes")
+		c.Invoke(t, stackitem.Null{}, "getInt")
+		c.Invoke(t, stackitem.Null{}, "getNil")
+		c.InvokeFail(t, fmt.Sprintf("contract callee (%s) method getNothing returned 0 values instead of 1",
+			h.StringLE()), "getNothing")
+	})
+	t.Run("before hardfork", func(t *testing.T) {
+		c, _ := deploy(t, func(cfg *config.Blockchain) {
+			cfg.Hardforks = map[string]uint32{
+				config.HFAspidochelone.String():   0,
+				config.HFBasilisk.String():        0,
+				config.HFCockatrice.String():      0,
+				config.HFNeoGoExtensions.String(): 100,
+			}
+		})
+		c.Invoke(t, stackitem.NewArray([]stackitem.Item{stackitem.Make(1), stackitem.Make(2)}), "getArray")
+		c.Invoke(t, 1, "getFlag")
+		c.Invoke(t, stackitem.NewBuffer([]byte{1, 2, 3}), "getBytes")
+		c.InvokeFail(t, "invalid return values count", "getInt")
+		c.InvokeFail(t, "invalid return values count", "getNothing")
+	})
+	t.Run("not configured", func(t *testing.T) {
+		c, _ := deploy(t, nil)
+		c.Invoke(t, stackitem.NewArray([]stackitem.Item{stackitem.Make(1), stackitem.Make(2)}), "getArray")
+		c.Invoke(t, 1, "getFlag")
+		c.Invoke(t, stackitem.NewBuffer([]byte{1, 2, 3}), "getBytes")
+		c.InvokeFail(t, "invalid return values count", "getInt")
+	})
+}
+
+func TestCall_SafeCallCache(t *testing.T) {
+	const hfHeight = 11
+	srcCallee := `package callee
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Get() int {
+			v := storage.Get(storage.GetReadOnlyContext(), "k")
+			if v == nil {
+				return 0
+			}
+			return v.(int)
+		}
+		func Set(v int) {
+			storage.Put(storage.GetContext(), "k", v)
+		}
+		func SelfGetSetGet(v int) []any {
+			h := runtime.GetExecutingScriptHash()
+			a := contract.Call(h, "get", contract.ReadOnly)
+			storage.Put(storage.GetContext(), "k", v)
+			return []any{a, contract.Call(h, "get", contract.ReadOnly)}
+		}
+		func List() []any { return []any{1, 2} }
+		func Counter() int { return runtime.GetInvocationCounter() }
+		func Guarded() int {
+			runtime.EnterNonReentrant([]byte("g"))
+			runtime.LeaveNonReentrant([]byte("g"))
+			return 1
+		}
+		func SelfGuarded() int {
+			h := runtime.GetExecutingScriptHash()
+			contract.Call(h, "guarded", contract.ReadOnly)
+			runtime.EnterNonReentrant([]byte("g"))
+			return contract.Call(h, "guarded", contract.ReadOnly).(int)
+		}
+		func Loop(n int) int {
+			var s int
+			for i := 0; i < n; i++ {
+				s += i
+			}
+			return s
+		}`
+	srcCaller := `package caller
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		)
+		func GetSetGet(h interop.Hash160, v int) []any {
+			a := contract.Call(h, "get", contract.ReadOnly)
+			b := contract.Call(h, "get", contract.ReadOnly)
+			contract.Call(h, "set", contract.All, v)
+			return []any{a, b, contract.Call(h, "get", contract.ReadOnly)}
+		}
+		func ListAppend(h interop.Hash160) []any {
+			l := contract.Call(h, "list", contract.ReadOnly).([]any)
+			l = append(l, 3)
+			l = contract.Call(h, "list", contract.ReadOnly).([]any)
+			return append(l, 4)
+		}
+		func Counters(h interop.Hash160) []any {
+			a := contract.Call(h, "counter", contract.ReadOnly)
+			b := contract.Call(h, "counter", contract.ReadOnly)
+			return []any{a, b}
+		}
+		func Loop(h interop.Hash160, times, n int) int {
+			var s int
+			for i := 0; i < times; i++ {
+				s += contract.Call(h, "loop", contract.ReadOnly, n).(int)
+			}
+			return s
+		}`
+
+	bc, acc, _ := chain.New(t, chain.NewConfig().WithHardfork(config.HFNeoGoExtensions.String(), hfHeight))
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	callee := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcCallee), &compiler.Options{
+		Name:               "callee",
+		SafeMethods:        []string{"get", "list", "counter", "guarded", "loop"},
+		NoPermissionsCheck: true,
+		Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	caller := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcCaller), &compiler.Options{
+		Name:               "caller",
+		NoPermissionsCheck: true,
+		Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	// Blocks 1 and 2.
+	e.DeployContract(t, callee, nil)
+	e.DeployContract(t, caller, nil)
+	calleeInv := e.NewInvoker(callee.Hash, acc)
+	inv := e.NewInvoker(caller.Hash, acc)
+
+	// loopGas returns the GAS consumed by `times` calls of callee.loop.
+	loopGas := func(times int) int64 {
+		h := inv.Invoke(t, times*(1000*999/2), "loop", callee.Hash, times, 1000)
+		return e.GetTxExecResult(t, h).GasConsumed
+	}
+	check := func(t *testing.T, v int) {
+		inv.Invoke(t, []stackitem.Item{stackitem.Make(v - 2), stackitem.Make(v - 2), stackitem.Make(v)},
+			"getSetGet", callee.Hash, v)
+		calleeInv.Invoke(t, []stackitem.Item{stackitem.Make(v), stackitem.Make(v + 1)}, "selfGetSetGet", v+1)
+		inv.Invoke(t, []stackitem.Item{stackitem.Make(1), stackitem.Make(2), stackitem.Make(4)}, "listAppend", callee.Hash)
+		inv.Invoke(t, []stackitem.Item{stackitem.Make(1), stackitem.Make(2)}, "counters", callee.Hash)
+	}
+
+	// Blocks 3-9.
+	calleeInv.Invoke(t, stackitem.Null{}, "set", 1)
+	check(t, 3)
+	oneBefore, tenBefore := loopGas(1), loopGas(10)
+
+	e.EnableHardforkAt(t, config.HFNeoGoExtensions.String(), hfHeight)
+	check(t, 6)
+	oneAfter, tenAfter := loopGas(1), loopGas(10)
+
+	// Guarded method result is not cached, so the guard taken by the
+	// caller is detected.
+	calleeInv.InvokeFail(t, "re-entrancy detected", "selfGuarded")
+
+	// The first call costs the same, subsequent ones are cached and only
+	// System.Contract.Call price is paid for them.
+	callPrice := int64(1<<15) * int64(bc.GetBaseExecFee())
+	require.Equal(t, oneBefore, oneAfter)
+	require.Less(t, tenAfter, tenBefore)
+	require.Greater(t, tenAfter-oneAfter, 9*callPrice)
+	require.Less(t, tenAfter-oneAfter, 9*callPrice+(tenBefore-oneBefore-9*callPrice)/9)
+}
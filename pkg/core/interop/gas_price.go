@@ -7,5 +7,8 @@ import (
 
 // GetPrice returns a price for executing op with the provided parameter.
 func (ic *Context) GetPrice(op opcode.Opcode, parameter []byte) int64 {
+	if ic.Profile != nil {
+		ic.Profile.trackGAS(ic.VM.GetCurrentScriptHash(), ic.VM.GasConsumed())
+	}
 	return fee.Opcode(ic.baseExecFee, op)
 }
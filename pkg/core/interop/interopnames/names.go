@@ -16,6 +16,7 @@ const (
 	SystemRuntimeBurnGas                = "System.Runtime.BurnGas"
 	SystemRuntimeCheckWitness           = "System.Runtime.CheckWitness"
 	SystemRuntimeCurrentSigners         = "System.Runtime.CurrentSigners"
+	SystemRuntimeEnterNonReentrant      = "System.Runtime.EnterNonReentrant"
 	SystemRuntimeGasLeft                = "System.Runtime.GasLeft"
 	SystemRuntimeGetAddressVersion      = "System.Runtime.GetAddressVersion"
 	SystemRuntimeGetCallingScriptHash   = "System.Runtime.GetCallingScriptHash"
@@ -24,16 +25,19 @@ const (
 	SystemRuntimeGetInvocationCounter   = "System.Runtime.GetInvocationCounter"
 	SystemRuntimeGetNetwork             = "System.Runtime.GetNetwork"
 	SystemRuntimeGetNotifications       = "System.Runtime.GetNotifications"
+	SystemRuntimeGetNotificationsByName = "System.Runtime.GetNotificationsByName"
 	SystemRuntimeGetRandom              = "System.Runtime.GetRandom"
 	SystemRuntimeGetScriptContainer     = "System.Runtime.GetScriptContainer"
 	SystemRuntimeGetTime                = "System.Runtime.GetTime"
 	SystemRuntimeGetTrigger             = "System.Runtime.GetTrigger"
+	SystemRuntimeLeaveNonReentrant      = "System.Runtime.LeaveNonReentrant"
 	SystemRuntimeLoadScript             = "System.Runtime.LoadScript"
 	SystemRuntimeLog                    = "System.Runtime.Log"
 	SystemRuntimeNotify                 = "System.Runtime.Notify"
 	SystemRuntimePlatform               = "System.Runtime.Platform"
 	SystemStorageDelete                 = "System.Storage.Delete"
 	SystemStorageFind                   = "System.Storage.Find"
+	SystemStorageFindFrom               = "System.Storage.FindFrom"
 	SystemStorageGet                    = "System.Storage.Get"
 	SystemStorageGetContext             = "System.Storage.GetContext"
 	SystemStorageGetReadOnlyContext     = "System.Storage.GetReadOnlyContext"
@@ -54,6 +58,7 @@ var names = []string{
 	SystemRuntimeBurnGas,
 	SystemRuntimeCheckWitness,
 	SystemRuntimeCurrentSigners,
+	SystemRuntimeEnterNonReentrant,
 	SystemRuntimeGasLeft,
 	SystemRuntimeGetAddressVersion,
 	SystemRuntimeGetCallingScriptHash,
@@ -62,15 +67,18 @@ var names = []string{
 	SystemRuntimeGetInvocationCounter,
 	SystemRuntimeGetNetwork,
 	SystemRuntimeGetNotifications,
+	SystemRuntimeGetNotificationsByName,
 	SystemRuntimeGetRandom,
 	SystemRuntimeGetScriptContainer,
 	SystemRuntimeGetTime,
 	SystemRuntimeGetTrigger,
+	SystemRuntimeLeaveNonReentrant,
 	SystemRuntimeLog,
 	SystemRuntimeNotify,
 	SystemRuntimePlatform,
 	SystemStorageDelete,
 	SystemStorageFind,
+	SystemStorageFindFrom,
 	SystemStorageGet,
 	SystemStorageGetContext,
 	SystemStorageGetReadOnlyContext,
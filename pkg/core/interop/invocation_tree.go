@@ -0,0 +1,70 @@
+package interop
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// InvocationTracker builds an invocation tree of a single script run. Calls
+// are added to it by the Context it's attached to (see TrackCall), so it's
+// not safe for concurrent use.
+type InvocationTracker struct {
+	tree *state.InvocationTree
+	size int
+	// stack contains calls that are being executed, nil invocations are
+	// the ones not recorded because of limits.
+	stack []trackedCall
+}
+
+type trackedCall struct {
+	inv   *state.Invocation
+	start int64
+}
+
+// NewInvocationTracker returns a new InvocationTracker for the entry script
+// with the given hash.
+func NewInvocationTracker(entry util.Uint160) *InvocationTracker {
+	t := &InvocationTracker{
+		tree: &state.InvocationTree{Invocation: state.Invocation{Hash: entry}},
+	}
+	t.stack = []trackedCall{{inv: &t.tree.Invocation}}
+	return t
+}
+
+// enter starts a new call of the given contract method.
+func (t *InvocationTracker) enter(h util.Uint160, method string, consumed int64) {
+	var (
+		parent = t.stack[len(t.stack)-1].inv
+		inv    *state.Invocation
+	)
+	if parent != nil && len(t.stack) <= state.MaxInvocationTreeDepth && t.size < state.MaxInvocationTreeSize {
+		inv = &state.Invocation{Hash: h, Method: method}
+		parent.Calls = append(parent.Calls, inv)
+		t.size++
+	} else {
+		t.tree.Truncated = true
+	}
+	t.stack = append(t.stack, trackedCall{inv: inv, start: consumed})
+}
+
+// leave completes the current call.
+func (t *InvocationTracker) leave(consumed int64) {
+	if len(t.stack) == 0 {
+		return
+	}
+	var c = t.stack[len(t.stack)-1]
+	if c.inv != nil {
+		c.inv.GAS = consumed - c.start
+	}
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// Finish completes all calls that are still being executed (if the script
+// has failed) and returns the tree. It must be called once the script run is
+// completed, consumed is the total amount of GAS consumed by this run.
+func (t *InvocationTracker) Finish(consumed int64) *state.InvocationTree {
+	for len(t.stack) > 0 {
+		t.leave(consumed)
+	}
+	return t.tree
+}
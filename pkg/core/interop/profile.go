@@ -0,0 +1,48 @@
+package interop
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ExecProfile collects node-local execution profiling data of a single
+// script run. It's filled in by the Context this profile is attached to
+// (syscalls are counted by SyscallHandler and GAS is attributed to contracts
+// by GetPrice), so it's not safe for concurrent use.
+type ExecProfile struct {
+	// Syscalls contains the number of invocations of each syscall by its
+	// name.
+	Syscalls map[string]int
+	// ContractGAS contains the amount of GAS spent by each contract's code
+	// including syscall and native method prices (but not the code of other
+	// contracts called).
+	ContractGAS map[util.Uint160]int64
+
+	lastHash util.Uint160
+	lastGAS  int64
+}
+
+// NewExecProfile returns a new empty ExecProfile.
+func NewExecProfile() *ExecProfile {
+	return &ExecProfile{
+		Syscalls:    make(map[string]int),
+		ContractGAS: make(map[util.Uint160]int64),
+	}
+}
+
+// trackGAS attributes GAS spent since the previous call to the contract that
+// was executing at that moment and remembers the current one.
+func (p *ExecProfile) trackGAS(curr util.Uint160, consumed int64) {
+	if d := consumed - p.lastGAS; d != 0 {
+		p.ContractGAS[p.lastHash] += d
+	}
+	p.lastHash = curr
+	p.lastGAS = consumed
+}
+
+// Finish attributes the remaining GAS (the price of the last instruction
+// executed and everything charged by it) to the contract that executed it.
+// It must be called once the script run is completed, consumed is the total
+// amount of GAS consumed by this run.
+func (p *ExecProfile) Finish(consumed int64) {
+	p.trackGAS(util.Uint160{}, consumed)
+}
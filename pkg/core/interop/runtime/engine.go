@@ -14,6 +14,11 @@ import (
 	"go.uber.org/zap"
 )
 
+// ErrLoadScriptFlags is returned from System.Runtime.LoadScript since
+// NeoGoExtensions hardfork if the requested call flags are not a subset of the
+// allowed ones (read-only flags of the calling context).
+var ErrLoadScriptFlags = errors.New("call flags denied")
+
 type itemable interface {
 	ToStackItem() stackitem.Item
 }
@@ -26,6 +31,8 @@ const (
 	// SystemRuntimeLogMessage represents log entry message used for output
 	// of the System.Runtime.Log syscall.
 	SystemRuntimeLogMessage = "runtime log"
+	// MaxNonReentrantKeyLen is the maximum length of a re-entrancy guard key.
+	MaxNonReentrantKeyLen = 64
 )
 
 // GetExecutingScriptHash returns executing script hash.
@@ -126,11 +133,18 @@ func LoadScript(ic *interop.Context) error {
 		return errors.New("call flags out of range")
 	}
 	args := ic.VM.Estack().Pop().Array()
+	if err := ic.AddDynamicScript(len(script)); err != nil {
+		return err
+	}
 	err := vm.IsScriptCorrect(script, nil)
 	if err != nil {
 		return fmt.Errorf("invalid script: %w", err)
 	}
-	fs = ic.VM.Context().GetCallFlags() & callflag.ReadOnly & fs
+	allowed := ic.VM.Context().GetCallFlags() & callflag.ReadOnly
+	if fs&^allowed != 0 && ic.IsHardforkEnabled(config.HFNeoGoExtensions) {
+		return fmt.Errorf("%w: requested %s, allowed %s", ErrLoadScriptFlags, fs, allowed)
+	}
+	fs &= allowed
 	ic.VM.LoadDynamicScript(script, fs)
 
 	for e, i := ic.VM.Estack(), len(args)-1; i >= 0; i-- {
@@ -193,3 +207,32 @@ func CurrentSigners(ic *interop.Context) error {
 
 	return nil
 }
+
+// EnterNonReentrant takes re-entrancy guard with the key popped from the stack
+// for the executing contract. It fails if the guard is already held by this
+// contract in the current execution.
+func EnterNonReentrant(ic *interop.Context) error {
+	key, err := popNonReentrantKey(ic)
+	if err != nil {
+		return err
+	}
+	return ic.EnterNonReentrant(ic.VM.GetCurrentScriptHash(), key)
+}
+
+// LeaveNonReentrant releases re-entrancy guard with the key popped from the
+// stack for the executing contract. It fails if the guard is not held.
+func LeaveNonReentrant(ic *interop.Context) error {
+	key, err := popNonReentrantKey(ic)
+	if err != nil {
+		return err
+	}
+	return ic.LeaveNonReentrant(ic.VM.GetCurrentScriptHash(), key)
+}
+
+func popNonReentrantKey(ic *interop.Context) ([]byte, error) {
+	key := ic.VM.Estack().Pop().Bytes()
+	if len(key) > MaxNonReentrantKeyLen {
+		return nil, fmt.Errorf("key must be less than %d", MaxNonReentrantKeyLen)
+	}
+	return key, nil
+}
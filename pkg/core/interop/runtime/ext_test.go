@@ -10,6 +10,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/contracts"
 	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
@@ -103,6 +104,18 @@ func wrapDynamicScript(t *testing.T, script []byte, flags callflag.CallFlag, arg
 	return b.Bytes()
 }
 
+// loadDynamicScript returns a script loading the given one with
+// System.Runtime.LoadScript without any exception handling.
+func loadDynamicScript(t *testing.T, script []byte, flags callflag.CallFlag, args ...any) []byte {
+	b := io.NewBufBinWriter()
+	emit.Array(b.BinWriter, args...)
+	emit.Int(b.BinWriter, int64(flags))
+	emit.Bytes(b.BinWriter, script)
+	emit.Syscall(b.BinWriter, interopnames.SystemRuntimeLoadScript)
+	require.NoError(t, b.Err)
+	return b.Bytes()
+}
+
 func getDeployedInternal(t *testing.T) (*neotest.Executor, neotest.Signer, *core.Blockchain, *state.Contract) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -401,31 +414,33 @@ func TestCheckWitness(t *testing.T) {
 }
 
 func TestLoadScript(t *testing.T) {
-	bc, acc := chain.NewSingle(t)
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+	})
 	e := neotest.NewExecutor(t, bc, acc, acc)
 
 	t.Run("no ret val", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{byte(opcode.RET)}, callflag.All)
+		script := wrapDynamicScript(t, []byte{byte(opcode.RET)}, callflag.ReadOnly)
 		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Null{})
 	})
 	t.Run("empty script", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{}, callflag.All)
+		script := wrapDynamicScript(t, []byte{}, callflag.ReadOnly)
 		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Null{})
 	})
 	t.Run("bad script", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{0xff}, callflag.All)
+		script := wrapDynamicScript(t, []byte{0xff}, callflag.ReadOnly)
 		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "invalid script")
 	})
 	t.Run("ret val, no params", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1)}, callflag.All)
+		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1)}, callflag.ReadOnly)
 		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(1))
 	})
 	t.Run("ret val with params", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{byte(opcode.MUL)}, callflag.All, 2, 2)
+		script := wrapDynamicScript(t, []byte{byte(opcode.MUL)}, callflag.ReadOnly, 2, 2)
 		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(4))
 	})
 	t.Run("two retrun values", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1), byte(opcode.PUSH1)}, callflag.All, 2, 2)
+		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1), byte(opcode.PUSH1)}, callflag.ReadOnly, 2, 2)
 		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "multiple return values in a cross-contract call")
 	})
 	t.Run("invalid flags", func(t *testing.T) {
@@ -433,7 +448,7 @@ func TestLoadScript(t *testing.T) {
 		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "call flags out of range")
 	})
 	t.Run("abort", func(t *testing.T) {
-		script := wrapDynamicScript(t, []byte{byte(opcode.ABORT)}, callflag.All)
+		script := wrapDynamicScript(t, []byte{byte(opcode.ABORT)}, callflag.ReadOnly)
 		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "ABORT")
 	})
 	t.Run("internal call", func(t *testing.T) {
@@ -451,13 +466,91 @@ func TestLoadScript(t *testing.T) {
 	t.Run("internal state-changing call", func(t *testing.T) {
 		script, err := smartcontract.CreateCallScript(e.NativeHash(t, nativenames.Neo), "transfer", acc.ScriptHash(), acc.ScriptHash(), 1, nil)
 		require.NoError(t, err)
-		script = wrapDynamicScript(t, script, callflag.All)
+		script = wrapDynamicScript(t, script, callflag.ReadOnly)
 		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "missing call flags")
 	})
 	t.Run("exception", func(t *testing.T) {
 		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1), byte(opcode.THROW)}, callflag.ReadOnly)
 		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make("exception"))
 	})
+	t.Run("denied flags", func(t *testing.T) {
+		script := wrapDynamicScript(t, []byte{byte(opcode.PUSH1)}, callflag.All)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "call flags denied: requested All, allowed ReadOnly")
+		script = wrapDynamicScript(t, []byte{byte(opcode.PUSH1)}, callflag.States|callflag.AllowNotify)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "call flags denied: requested States, AllowNotify, allowed ReadOnly")
+	})
+}
+
+func TestLoadScript_BeforeNeoGoExtensions(t *testing.T) {
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFNeoGoExtensions.String(): 100,
+		}
+		c.MaxDynamicScriptSize = 1
+		c.MaxDynamicScripts = 1
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Flags are masked and limits are not applied.
+	script := loadDynamicScript(t, []byte{byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.ADD)}, callflag.All)
+	script = append(script, loadDynamicScript(t, []byte{byte(opcode.PUSH1)}, callflag.All)...)
+	e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(3), stackitem.Make(1))
+
+	script, err := smartcontract.CreateCallScript(e.NativeHash(t, nativenames.Neo), "transfer", acc.ScriptHash(), acc.ScriptHash(), 1, nil)
+	require.NoError(t, err)
+	script = wrapDynamicScript(t, script, callflag.All)
+	e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "missing call flags")
+}
+
+func TestLoadScript_Limits(t *testing.T) {
+	const (
+		maxSize    = 32
+		maxScripts = 3
+	)
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+		c.MaxDynamicScriptSize = maxSize
+		c.MaxDynamicScripts = maxScripts
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// sized returns a script of the given size returning 1.
+	sized := func(n int) []byte {
+		script := make([]byte, n)
+		for i := range script {
+			script[i] = byte(opcode.NOP)
+		}
+		script[n-1] = byte(opcode.PUSH1)
+		return script
+	}
+	t.Run("size", func(t *testing.T) {
+		script := wrapDynamicScript(t, sized(maxSize), callflag.ReadOnly)
+		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(1))
+
+		script = wrapDynamicScript(t, sized(maxSize+1), callflag.ReadOnly)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "dynamic script is too big: 33 bytes (max 32)")
+	})
+	t.Run("count", func(t *testing.T) {
+		var script []byte
+		for i := 0; i < maxScripts; i++ {
+			script = append(script, loadDynamicScript(t, sized(1), callflag.ReadOnly)...)
+		}
+		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(1), stackitem.Make(1), stackitem.Make(1))
+
+		script = append(script, loadDynamicScript(t, sized(1), callflag.ReadOnly)...)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "too many dynamic scripts: 3 scripts already loaded (max 3)")
+	})
+	t.Run("nested", func(t *testing.T) {
+		// Scripts loaded by dynamic scripts are counted as well.
+		script := sized(1)
+		for i := 0; i < maxScripts; i++ {
+			script = loadDynamicScript(t, script, callflag.ReadOnly)
+		}
+		e.InvokeScriptCheckHALT(t, script, []neotest.Signer{acc}, stackitem.Make(1))
+
+		script = loadDynamicScript(t, script, callflag.ReadOnly)
+		e.InvokeScriptCheckFAULT(t, script, []neotest.Signer{acc}, "too many dynamic scripts")
+	})
 }
 
 func TestGasLeft(t *testing.T) {
@@ -485,6 +578,71 @@ func TestGasLeft(t *testing.T) {
 	require.Equal(t, int64(gasLimit-2*runtimeGasLeftPrice*interop.DefaultBaseExecFee), l2.Int64())
 }
 
+func TestGasLeft_Batch(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Process as many items as GAS allows and save the cursor, the result is
+	// the number of items processed, GAS left after the loop and the maximum
+	// cost of a single iteration.
+	src := `package batch
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Process(threshold int) []int {
+			ctx := storage.GetContext()
+			cursor := storage.Get(ctx, "cursor")
+			var i int
+			if cursor != nil {
+				i = cursor.(int)
+			}
+			var n, step int
+			left := runtime.GasLeft()
+			for left > threshold {
+				storage.Put(ctx, i, i)
+				i++
+				n++
+				prev := left
+				left = runtime.GasLeft()
+				if prev-left > step {
+					step = prev - left
+				}
+			}
+			storage.Put(ctx, "cursor", i)
+			return []int{n, left, step}
+		}
+		func Cursor() int {
+			return storage.Get(storage.GetReadOnlyContext(), "cursor").(int)
+		}`
+	c := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{Name: "batch"})
+	e.DeployContract(t, c, nil)
+	inv := e.NewInvoker(c.Hash, acc)
+
+	const (
+		sysFee    = 5_0000_0000
+		threshold = 1_000_0000
+	)
+	var total int64
+	for i := 0; i < 2; i++ {
+		tx := inv.PrepareInvokeNoSign(t, "process", threshold)
+		e.SignTx(t, tx, sysFee, acc)
+		e.AddNewBlock(t, tx)
+		aer := e.CheckHalt(t, tx.Hash())
+		require.True(t, aer.GasConsumed <= sysFee)
+
+		res := aer.Stack[0].Value().([]stackitem.Item)
+		n := res[0].Value().(*big.Int).Int64()
+		left := res[1].Value().(*big.Int).Int64()
+		step := res[2].Value().(*big.Int).Int64()
+		require.True(t, n > 0)
+		require.True(t, left <= threshold, left)
+		require.True(t, left > threshold-step, "overshoot: left %d, step %d", left, step)
+		total += n
+	}
+	inv.Invoke(t, total, "cursor")
+}
+
 func TestGetAddressVersion(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
@@ -575,6 +733,296 @@ func TestGetNotifications(t *testing.T) {
 	})
 }
 
+func TestGetNotificationsByName_Filter(t *testing.T) {
+	v, ic, _ := createVM(t)
+
+	ic.Notifications = []state.NotificationEvent{
+		{ScriptHash: util.Uint160{1}, Name: "Event1", Item: stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray([]byte{11})})},
+		{ScriptHash: util.Uint160{2}, Name: "Event1", Item: stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray([]byte{22})})},
+		{ScriptHash: util.Uint160{1}, Name: "Event2", Item: stackitem.NewArray([]stackitem.Item{stackitem.NewByteArray([]byte{33})})},
+	}
+	check := func(t *testing.T, h any, name any, expected ...int) {
+		v.GasLimit = -1
+		gas := v.GasConsumed()
+		v.Estack().PushVal(name)
+		v.Estack().PushVal(h)
+		require.NoError(t, runtime.GetNotificationsByName(ic))
+		require.Equal(t, ic.BaseExecFee()*int64(runtime.NotificationScanPrice*len(ic.Notifications)+runtime.NotificationMatchPrice*len(expected)),
+			v.GasConsumed()-gas)
+
+		arr := v.Estack().Pop().Array()
+		require.Equal(t, len(expected), len(arr))
+		for i, j := range expected {
+			elem := arr[i].Value().([]stackitem.Item)
+			require.Equal(t, ic.Notifications[j].ScriptHash.BytesBE(), elem[0].Value())
+			name, err := stackitem.ToString(elem[1])
+			require.NoError(t, err)
+			require.Equal(t, ic.Notifications[j].Name, name)
+			require.Equal(t, ic.Notifications[j].Item, elem[2])
+		}
+	}
+	t.Run("no filter", func(t *testing.T) {
+		check(t, stackitem.Null{}, stackitem.Null{}, 0, 1, 2)
+	})
+	t.Run("hash", func(t *testing.T) {
+		check(t, util.Uint160{1}.BytesBE(), stackitem.Null{}, 0, 2)
+	})
+	t.Run("name", func(t *testing.T) {
+		check(t, stackitem.Null{}, "Event1", 0, 1)
+	})
+	t.Run("hash and name", func(t *testing.T) {
+		check(t, util.Uint160{1}.BytesBE(), "Event1", 0)
+		check(t, util.Uint160{2}.BytesBE(), "Event2")
+	})
+	t.Run("bad name", func(t *testing.T) {
+		v.Estack().PushVal(stackitem.NewInterop("Event1"))
+		v.Estack().PushVal(stackitem.Null{})
+		require.Error(t, runtime.GetNotificationsByName(ic))
+
+		v.Estack().PushVal(strings.Repeat("e", runtime.MaxEventNameLen+1))
+		v.Estack().PushVal(stackitem.Null{})
+		require.Error(t, runtime.GetNotificationsByName(ic))
+	})
+	t.Run("bad hash", func(t *testing.T) {
+		v.Estack().PushVal("Event1")
+		v.Estack().PushVal([]byte{1, 2, 3})
+		require.Error(t, runtime.GetNotificationsByName(ic))
+	})
+	t.Run("not enough gas", func(t *testing.T) {
+		v.GasLimit = v.GasConsumed() + ic.BaseExecFee()*int64(runtime.NotificationScanPrice*len(ic.Notifications))
+		v.Estack().PushVal("Event1")
+		v.Estack().PushVal(stackitem.Null{})
+		require.Error(t, runtime.GetNotificationsByName(ic))
+	})
+}
+
+func TestGetNotificationsByName(t *testing.T) {
+	const (
+		enabledHeight = 4
+		eventsCount   = 50
+	)
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFNeoGoExtensions.String(): enabledHeight,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	srcEmitter := `package emitter
+		import "github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		func Emit(n int) {
+			for i := 0; i < n; i++ {
+				runtime.Notify("Ping", i)
+				runtime.Notify("Pong", i)
+			}
+			runtime.Notify("Done", n)
+		}`
+	srcCaller := `package caller
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func Filtered(h interop.Hash160, n int, name string) any {
+			contract.Call(h, "emit", contract.All, n)
+			return runtime.GetNotificationsByName(h, name)
+		}
+		func Manual(h interop.Hash160, n int, name string) []any {
+			contract.Call(h, "emit", contract.All, n)
+			var res []any
+			for _, ntf := range runtime.GetNotifications(h) {
+				if string(ntf[1].([]byte)) == name {
+					res = append(res, ntf)
+				}
+			}
+			return res
+		}`
+	intParam := func(name string) compiler.HybridParameter {
+		return compiler.HybridParameter{Parameter: manifest.NewParameter(name, smartcontract.IntegerType)}
+	}
+	emitter := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcEmitter), &compiler.Options{
+		Name: "emitter",
+		ContractEvents: []compiler.HybridEvent{
+			{Name: "Ping", Parameters: []compiler.HybridParameter{intParam("i")}},
+			{Name: "Pong", Parameters: []compiler.HybridParameter{intParam("i")}},
+			{Name: "Done", Parameters: []compiler.HybridParameter{intParam("n")}},
+		},
+	})
+	caller := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcCaller), &compiler.Options{
+		Name:               "caller",
+		NoPermissionsCheck: true,
+		Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+	})
+	// Blocks 1 and 2: deploy contracts.
+	e.DeployContract(t, emitter, nil)
+	e.DeployContract(t, caller, nil)
+	inv := e.NewInvoker(caller.Hash, acc)
+
+	// Block 3: the syscall is not yet available.
+	require.Equal(t, uint32(enabledHeight-2), bc.BlockHeight())
+	inv.InvokeFail(t, "syscall not found", "filtered", emitter.Hash, 1, "Done")
+
+	// Block 4 and later: the syscall is available.
+	checkDone := func(t testing.TB, stack []stackitem.Item) {
+		require.Equal(t, 1, len(stack))
+		arr, ok := stack[0].Value().([]stackitem.Item)
+		require.True(t, ok)
+		require.Equal(t, 1, len(arr))
+		ntf := arr[0].Value().([]stackitem.Item)
+		require.Equal(t, emitter.Hash.BytesBE(), ntf[0].Value())
+		require.Equal(t, []byte("Done"), ntf[1].Value())
+		require.Equal(t, []stackitem.Item{stackitem.Make(eventsCount)}, ntf[2].Value())
+	}
+	filteredH := inv.InvokeAndCheck(t, checkDone, "filtered", emitter.Hash, eventsCount, "Done")
+	manualH := inv.InvokeAndCheck(t, checkDone, "manual", emitter.Hash, eventsCount, "Done")
+	filteredGas := e.CheckHalt(t, filteredH).GasConsumed
+	manualGas := e.CheckHalt(t, manualH).GasConsumed
+	require.Less(t, filteredGas, manualGas)
+
+	inv.InvokeAndCheck(t, func(t testing.TB, stack []stackitem.Item) {
+		require.Equal(t, eventsCount, len(stack[0].Value().([]stackitem.Item)))
+	}, "filtered", emitter.Hash, eventsCount, "Ping")
+	inv.Invoke(t, stackitem.NewArray([]stackitem.Item{}), "filtered", emitter.Hash, eventsCount, "Unknown")
+}
+
+func TestNonReentrant_Guard(t *testing.T) {
+	_, ic, _ := createVM(t)
+	var (
+		h1  = util.Uint160{1}
+		h2  = util.Uint160{2}
+		key = []byte("key")
+	)
+	enter := func(h util.Uint160, key []byte) error {
+		loadScriptWithHashAndFlags(ic, []byte{byte(opcode.RET)}, h, callflag.NoneFlag, key)
+		return runtime.EnterNonReentrant(ic)
+	}
+	leave := func(h util.Uint160, key []byte) error {
+		loadScriptWithHashAndFlags(ic, []byte{byte(opcode.RET)}, h, callflag.NoneFlag, key)
+		return runtime.LeaveNonReentrant(ic)
+	}
+
+	require.NoError(t, enter(h1, key))
+	require.ErrorContains(t, enter(h1, key), "re-entrancy detected")
+	// Other keys and other contracts are not affected.
+	require.NoError(t, enter(h1, []byte("other")))
+	require.NoError(t, enter(h2, key))
+
+	require.NoError(t, leave(h1, key))
+	require.ErrorContains(t, leave(h1, key), "not held")
+	require.NoError(t, enter(h1, key))
+
+	require.Error(t, enter(h1, make([]byte, runtime.MaxNonReentrantKeyLen+1)))
+	require.NoError(t, enter(h1, make([]byte, runtime.MaxNonReentrantKeyLen)))
+}
+
+func TestNonReentrant(t *testing.T) {
+	const enabledHeight = 4
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFNeoGoExtensions.String(): enabledHeight,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	// Vault pays before updating the balance, so it's vulnerable to
+	// re-entrancy unless the guard is used.
+	srcVault := `package vault
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Deposit(owner interop.Hash160, amount int) {
+			ctx := storage.GetContext()
+			storage.Put(ctx, owner, balanceOf(ctx, owner)+amount)
+		}
+		func balanceOf(ctx storage.Context, owner interop.Hash160) int {
+			b := storage.Get(ctx, owner)
+			if b == nil {
+				return 0
+			}
+			return b.(int)
+		}
+		func Withdraw(owner interop.Hash160) {
+			ctx := storage.GetContext()
+			amount := balanceOf(ctx, owner)
+			if amount > 0 {
+				contract.Call(owner, "onWithdraw", contract.All, amount)
+			}
+			storage.Put(ctx, owner, 0)
+		}
+		func SafeWithdraw(owner interop.Hash160) {
+			runtime.EnterNonReentrant([]byte("withdraw"))
+			Withdraw(owner)
+			runtime.LeaveNonReentrant([]byte("withdraw"))
+		}`
+	srcAttacker := `package attacker
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+			"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+		)
+		func Attack(vault interop.Hash160, method string, reenter int) int {
+			ctx := storage.GetContext()
+			storage.Put(ctx, "vault", vault)
+			storage.Put(ctx, "method", method)
+			storage.Put(ctx, "reenter", reenter)
+			storage.Put(ctx, "received", 0)
+			contract.Call(vault, method, contract.All, runtime.GetExecutingScriptHash())
+			return storage.Get(ctx, "received").(int)
+		}
+		func Twice(vault interop.Hash160) {
+			contract.Call(vault, "safeWithdraw", contract.All, runtime.GetExecutingScriptHash())
+			contract.Call(vault, "safeWithdraw", contract.All, runtime.GetExecutingScriptHash())
+		}
+		func OnWithdraw(amount int) {
+			ctx := storage.GetContext()
+			storage.Put(ctx, "received", storage.Get(ctx, "received").(int)+amount)
+			reenter := storage.Get(ctx, "reenter").(int)
+			if reenter > 0 {
+				storage.Put(ctx, "reenter", reenter-1)
+				contract.Call(storage.Get(ctx, "vault").(interop.Hash160), storage.Get(ctx, "method").(string),
+					contract.All, runtime.GetExecutingScriptHash())
+			}
+		}`
+	opts := func(name string) *compiler.Options {
+		return &compiler.Options{
+			Name:               name,
+			NoPermissionsCheck: true,
+			Permissions:        []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+		}
+	}
+	vault := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcVault), opts("vault"))
+	attacker := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(srcAttacker), opts("attacker"))
+	// Blocks 1 and 2: deploy contracts.
+	e.DeployContract(t, vault, nil)
+	e.DeployContract(t, attacker, nil)
+	vaultInv := e.NewInvoker(vault.Hash, acc)
+	attackerInv := e.NewInvoker(attacker.Hash, acc)
+
+	// Block 3: the syscall is not yet available.
+	require.Equal(t, uint32(enabledHeight-2), bc.BlockHeight())
+	vaultInv.InvokeFail(t, "syscall not found", "safeWithdraw", attacker.Hash)
+
+	// Unguarded withdrawal can be drained by re-entering.
+	vaultInv.Invoke(t, stackitem.Null{}, "deposit", attacker.Hash, 10)
+	attackerInv.Invoke(t, 30, "attack", vault.Hash, "withdraw", 2)
+
+	// The guard blocks the same attack.
+	vaultInv.Invoke(t, stackitem.Null{}, "deposit", attacker.Hash, 10)
+	attackerInv.InvokeFail(t, "re-entrancy detected", "attack", vault.Hash, "safeWithdraw", 2)
+
+	// Honest withdrawals work in subsequent transactions and within a
+	// single transaction.
+	attackerInv.Invoke(t, 10, "attack", vault.Hash, "safeWithdraw", 0)
+	vaultInv.Invoke(t, stackitem.Null{}, "deposit", attacker.Hash, 5)
+	attackerInv.Invoke(t, 5, "attack", vault.Hash, "safeWithdraw", 0)
+	vaultInv.Invoke(t, stackitem.Null{}, "deposit", attacker.Hash, 5)
+	attackerInv.Invoke(t, stackitem.Null{}, "twice", vault.Hash)
+}
+
 func TestGetRandom_DifferentTransactions(t *testing.T) {
 	bc, acc := chain.NewSingle(t)
 	e := neotest.NewExecutor(t, bc, acc, acc)
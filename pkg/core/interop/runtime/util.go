@@ -3,6 +3,7 @@ package runtime
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -26,26 +27,91 @@ func GasLeft(ic *interop.Context) error {
 	return nil
 }
 
+// Prices of System.Runtime.GetNotificationsByName dynamic parts.
+const (
+	// NotificationScanPrice is the price of checking a single notification
+	// against the filter.
+	NotificationScanPrice = 1 << 4
+	// NotificationMatchPrice is the price of a single notification returned.
+	NotificationMatchPrice = 1 << 8
+)
+
 // GetNotifications returns notifications emitted in the current execution context.
 func GetNotifications(ic *interop.Context) error {
-	item := ic.VM.Estack().Pop().Item()
+	h, err := popNotificationsHash(ic)
+	if err != nil {
+		return err
+	}
 	notifications := ic.Notifications
+	if h != nil {
+		notifications = []state.NotificationEvent{}
+		for i := range ic.Notifications {
+			if ic.Notifications[i].ScriptHash.Equals(*h) {
+				notifications = append(notifications, ic.Notifications[i])
+			}
+		}
+	}
+	return pushNotifications(ic, notifications)
+}
+
+// GetNotificationsByName returns notifications emitted in the current execution
+// context filtered by contract hash and event name (both are optional). Unlike
+// GetNotifications it charges for every notification checked and for every
+// notification returned.
+func GetNotificationsByName(ic *interop.Context) error {
+	h, err := popNotificationsHash(ic)
+	if err != nil {
+		return err
+	}
+	var name *string
+	item := ic.VM.Estack().Pop().Item()
 	if _, ok := item.(stackitem.Null); !ok {
 		b, err := item.TryBytes()
 		if err != nil {
 			return err
 		}
-		u, err := util.Uint160DecodeBytesBE(b)
-		if err != nil {
-			return err
+		if len(b) > MaxEventNameLen {
+			return fmt.Errorf("event name must be less than %d", MaxEventNameLen)
 		}
-		notifications = []state.NotificationEvent{}
-		for i := range ic.Notifications {
-			if ic.Notifications[i].ScriptHash.Equals(u) {
-				notifications = append(notifications, ic.Notifications[i])
-			}
+		s := string(b)
+		name = &s
+	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * NotificationScanPrice * int64(len(ic.Notifications))) {
+		return errors.New("insufficient amount of gas")
+	}
+	notifications := []state.NotificationEvent{}
+	for i := range ic.Notifications {
+		if (h == nil || ic.Notifications[i].ScriptHash.Equals(*h)) &&
+			(name == nil || ic.Notifications[i].Name == *name) {
+			notifications = append(notifications, ic.Notifications[i])
 		}
 	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * NotificationMatchPrice * int64(len(notifications))) {
+		return errors.New("insufficient amount of gas")
+	}
+	return pushNotifications(ic, notifications)
+}
+
+// popNotificationsHash pops an optional contract hash used to filter
+// notifications from the stack.
+func popNotificationsHash(ic *interop.Context) (*util.Uint160, error) {
+	item := ic.VM.Estack().Pop().Item()
+	if _, ok := item.(stackitem.Null); ok {
+		return nil, nil
+	}
+	b, err := item.TryBytes()
+	if err != nil {
+		return nil, err
+	}
+	u, err := util.Uint160DecodeBytesBE(b)
+	if err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// pushNotifications pushes an array of the given notifications to the stack.
+func pushNotifications(ic *interop.Context, notifications []state.NotificationEvent) error {
 	if len(notifications) > vm.MaxStackSize {
 		return errors.New("too many notifications")
 	}
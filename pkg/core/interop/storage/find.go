@@ -3,6 +3,7 @@ package storage
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
@@ -35,6 +36,9 @@ type Iterator struct {
 	// copied if no FindRemovePrefix option specified since it's shared between all
 	// iterator items.
 	prefix []byte
+	// skip is the key (without prefix) that is skipped if it's the first one
+	// returned by seek (used by FindFrom to exclude the cursor key).
+	skip []byte
 }
 
 // NewIterator creates a new Iterator with the given options for the given channel of store.Seek results.
@@ -50,6 +54,12 @@ func NewIterator(seekCh chan storage.KeyValue, prefix []byte, opts int64) *Itera
 // current position.
 func (s *Iterator) Next() bool {
 	s.curr, s.next = <-s.seekCh
+	if s.skip != nil {
+		if s.next && bytes.Equal(s.curr.Key, s.skip) {
+			s.curr, s.next = <-s.seekCh
+		}
+		s.skip = nil
+	}
 	return s.next
 }
 
@@ -91,13 +101,50 @@ func (s *Iterator) Value() stackitem.Item {
 
 // Find finds stored key-value pair.
 func Find(ic *interop.Context) error {
+	stc, err := popContext(ic)
+	if err != nil {
+		return err
+	}
+	prefix := ic.VM.Estack().Pop().Bytes()
+	opts := ic.VM.Estack().Pop().BigInt().Int64()
+	if err := checkFindOptions(opts); err != nil {
+		return err
+	}
+	find(ic, stc, prefix, nil, opts)
+	return nil
+}
+
+// FindFrom finds stored key-value pairs with the given prefix starting strictly
+// after (or before for FindBackwards) the given key which must have the same
+// prefix. It allows to continue iteration from the last key seen by contract.
+func FindFrom(ic *interop.Context) error {
+	stc, err := popContext(ic)
+	if err != nil {
+		return err
+	}
+	prefix := ic.VM.Estack().Pop().Bytes()
+	lastKey := ic.VM.Estack().Pop().Bytes()
+	opts := ic.VM.Estack().Pop().BigInt().Int64()
+	if err := checkFindOptions(opts); err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(lastKey, prefix) {
+		return errors.New("last key is outside of the prefix")
+	}
+	find(ic, stc, prefix, lastKey[len(prefix):], opts)
+	return nil
+}
+
+func popContext(ic *interop.Context) (*Context, error) {
 	stcInterface := ic.VM.Estack().Pop().Value()
 	stc, ok := stcInterface.(*Context)
 	if !ok {
-		return fmt.Errorf("%T is not a storage,Context", stcInterface)
+		return nil, fmt.Errorf("%T is not a storage,Context", stcInterface)
 	}
-	prefix := ic.VM.Estack().Pop().Bytes()
-	opts := ic.VM.Estack().Pop().BigInt().Int64()
+	return stc, nil
+}
+
+func checkFindOptions(opts int64) error {
 	if opts&^FindAll != 0 {
 		return fmt.Errorf("%w: unknown flag", errFindInvalidOptions)
 	}
@@ -115,10 +162,31 @@ func Find(ic *interop.Context) error {
 	if opts&FindDeserialize == 0 && (opts&FindPick0 != 0 || opts&FindPick1 != 0) {
 		return fmt.Errorf("%w: PickN is specified without Deserialize", errFindInvalidOptions)
 	}
-	bkwrds := opts&FindBackwards != 0
-	ctx, cancel := context.WithCancel(context.Background())
-	seekres := ic.DAO.SeekAsync(ctx, stc.ID, storage.SeekRange{Prefix: prefix, Backwards: bkwrds})
+	return nil
+}
+
+// find pushes an iterator over the items with the given prefix to the stack.
+// If after is not nil, iteration starts from the key following it (it's
+// relative to the prefix).
+func find(ic *interop.Context, stc *Context, prefix []byte, after []byte, opts int64) {
+	var (
+		bkwrds  = opts&FindBackwards != 0
+		rng     = storage.SeekRange{Prefix: prefix, Start: after, Backwards: bkwrds}
+		seekres chan storage.KeyValue
+		cancel  = func() {}
+	)
+	if bkwrds && after != nil && len(after) == 0 {
+		// Nothing can precede the prefix itself, while empty Start means
+		// seeking from the very end.
+		seekres = make(chan storage.KeyValue)
+		close(seekres)
+	} else {
+		var ctx context.Context
+		ctx, cancel = context.WithCancel(context.Background())
+		seekres = ic.DAO.SeekAsync(ctx, stc.ID, rng)
+	}
 	item := NewIterator(seekres, prefix, opts)
+	item.skip = after
 	ic.VM.Estack().PushItem(stackitem.NewInterop(item))
 	ic.RegisterCancelFunc(func() {
 		cancel()
@@ -128,6 +196,4 @@ func Find(ic *interop.Context) error {
 		for range seekres { //nolint:revive //empty-block
 		}
 	})
-
-	return nil
 }
@@ -2,8 +2,11 @@ package storage_test
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/limits"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -14,6 +17,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -306,6 +310,171 @@ func TestFind(t *testing.T) {
 	})
 }
 
+func TestFindFrom(t *testing.T) {
+	v, contractState, ic, _ := createVMAndContractState(t)
+	require.NoError(t, native.PutContractState(ic.DAO, contractState))
+	id := contractState.ID
+
+	// Some items are stored in the lower layer and some are in the upper
+	// cached layer only.
+	for _, k := range [][]byte{{0x01}, {0x01, 0x01}, {0x01, 0x03}, {0x01, 0x05}, {0x02, 0x00}} {
+		ic.DAO.PutStorageItem(id, k, k)
+	}
+	ic.DAO = ic.DAO.GetWrapped()
+	for _, k := range [][]byte{{0x01, 0x02}, {0x01, 0x04}, {0x01, 0x04, 0x00}, {0x00, 0xff}} {
+		ic.DAO.PutStorageItem(id, k, k)
+	}
+
+	findFrom := func(t *testing.T, prefix, lastKey []byte, opts int64) ([][]byte, error) {
+		v.Estack().PushVal(opts | istorage.FindKeysOnly)
+		v.Estack().PushVal(lastKey)
+		v.Estack().PushVal(prefix)
+		v.Estack().PushVal(stackitem.NewInterop(&istorage.Context{ID: id}))
+		if err := istorage.FindFrom(ic); err != nil {
+			return nil, err
+		}
+		iter := v.Estack().Pop().Interop()
+		var res [][]byte
+		for {
+			v.Estack().PushVal(iter)
+			require.NoError(t, iterator.Next(ic))
+			if !v.Estack().Pop().Bool() {
+				return res, nil
+			}
+			v.Estack().PushVal(iter)
+			require.NoError(t, iterator.Value(ic))
+			res = append(res, v.Estack().Pop().Bytes())
+		}
+	}
+	check := func(t *testing.T, prefix, lastKey []byte, opts int64, expected ...[]byte) {
+		res, err := findFrom(t, prefix, lastKey, opts)
+		require.NoError(t, err)
+		require.Equal(t, expected, res)
+	}
+
+	t.Run("forward", func(t *testing.T) {
+		check(t, []byte{0x01}, []byte{0x01}, 0, []byte{0x01, 0x01}, []byte{0x01, 0x02},
+			[]byte{0x01, 0x03}, []byte{0x01, 0x04}, []byte{0x01, 0x04, 0x00}, []byte{0x01, 0x05})
+		check(t, []byte{0x01}, []byte{0x01, 0x02}, 0, []byte{0x01, 0x03}, []byte{0x01, 0x04},
+			[]byte{0x01, 0x04, 0x00}, []byte{0x01, 0x05})
+		check(t, []byte{0x01}, []byte{0x01, 0x04}, 0, []byte{0x01, 0x04, 0x00}, []byte{0x01, 0x05})
+		check(t, []byte{0x01}, []byte{0x01, 0x05}, 0)
+		check(t, []byte{}, []byte{0x01, 0x05}, 0, []byte{0x02, 0x00})
+	})
+	t.Run("backwards", func(t *testing.T) {
+		check(t, []byte{0x01}, []byte{0x01, 0x04}, istorage.FindBackwards,
+			[]byte{0x01, 0x03}, []byte{0x01, 0x02}, []byte{0x01, 0x01}, []byte{0x01})
+		check(t, []byte{0x01}, []byte{0x01, 0x01}, istorage.FindBackwards, []byte{0x01})
+		check(t, []byte{0x01}, []byte{0x01}, istorage.FindBackwards)
+		check(t, []byte{0x01}, []byte{0x01, 0xff}, istorage.FindBackwards, []byte{0x01, 0x05},
+			[]byte{0x01, 0x04, 0x00}, []byte{0x01, 0x04}, []byte{0x01, 0x03}, []byte{0x01, 0x02},
+			[]byte{0x01, 0x01}, []byte{0x01})
+	})
+	t.Run("deleted last key", func(t *testing.T) {
+		// Both persisted and cached keys can be deleted between calls.
+		ic.DAO.DeleteStorageItem(id, []byte{0x01, 0x03})
+		ic.DAO.DeleteStorageItem(id, []byte{0x01, 0x04})
+		check(t, []byte{0x01}, []byte{0x01, 0x03}, 0, []byte{0x01, 0x04, 0x00}, []byte{0x01, 0x05})
+		check(t, []byte{0x01}, []byte{0x01, 0x04}, 0, []byte{0x01, 0x04, 0x00}, []byte{0x01, 0x05})
+		check(t, []byte{0x01}, []byte{0x01, 0x04}, istorage.FindBackwards, []byte{0x01, 0x02},
+			[]byte{0x01, 0x01}, []byte{0x01})
+	})
+	t.Run("options", func(t *testing.T) {
+		v.Estack().PushVal(istorage.FindRemovePrefix)
+		v.Estack().PushVal([]byte{0x01, 0x02})
+		v.Estack().PushVal([]byte{0x01})
+		v.Estack().PushVal(stackitem.NewInterop(&istorage.Context{ID: id}))
+		require.NoError(t, istorage.FindFrom(ic))
+		iter := v.Estack().Pop().Interop()
+		v.Estack().PushVal(iter)
+		require.NoError(t, iterator.Next(ic))
+		require.True(t, v.Estack().Pop().Bool())
+		v.Estack().PushVal(iter)
+		require.NoError(t, iterator.Value(ic))
+		require.Equal(t, stackitem.NewStruct([]stackitem.Item{
+			stackitem.NewByteArray([]byte{0x04, 0x00}),
+			stackitem.NewByteArray([]byte{0x01, 0x04, 0x00}),
+		}), v.Estack().Pop().Item())
+
+		_, err := findFrom(t, []byte{0x01}, []byte{0x01}, istorage.FindValuesOnly)
+		require.Error(t, err)
+	})
+	t.Run("last key outside of prefix", func(t *testing.T) {
+		for _, k := range [][]byte{{}, {0x00, 0xff}, {0x02}, {0x02, 0x00}} {
+			_, err := findFrom(t, []byte{0x01}, k, 0)
+			require.Error(t, err)
+		}
+	})
+}
+
+func TestFindFromPagination(t *testing.T) {
+	const enabledHeight = 5
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Hardforks = map[string]uint32{
+			config.HFNeoGoExtensions.String(): enabledHeight,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	src := `package pager
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/iterator"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Fill(n int) {
+		ctx := storage.GetContext()
+		for i := 0; i < n; i++ {
+			storage.Put(ctx, []byte{'i', byte(i)}, []byte{byte(i)})
+		}
+	}
+	func Remove(k []byte) {
+		storage.Delete(storage.GetContext(), k)
+	}
+	// Next returns up to n items starting after the stored cursor.
+	func Next(n int) [][]byte {
+		ctx := storage.GetContext()
+		var it iterator.Iterator
+		cursor := storage.Get(ctx, "cursor")
+		if cursor == nil {
+			it = storage.Find(ctx, "i", storage.None)
+		} else {
+			it = storage.FindFrom(ctx, "i", cursor.([]byte), storage.None)
+		}
+		var res [][]byte
+		for len(res) < n && iterator.Next(it) {
+			kv := iterator.Value(it).(struct {
+				key   []byte
+				value []byte
+			})
+			res = append(res, kv.value)
+			storage.Put(ctx, "cursor", kv.key)
+		}
+		return res
+	}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{Name: "pager"})
+	e.DeployContract(t, ctr, nil)
+	c := e.CommitteeInvoker(ctr.Hash)
+	c.Invoke(t, stackitem.Null{}, "fill", 10)
+	page := func(vals ...byte) stackitem.Item {
+		res := make([]stackitem.Item, len(vals))
+		for i := range vals {
+			res[i] = stackitem.NewByteArray([]byte{vals[i]})
+		}
+		return stackitem.NewArray(res)
+	}
+
+	// Cursor is not set yet, so Find is used.
+	c.Invoke(t, page(0, 1, 2), "next", 3)
+	require.Less(t, bc.BlockHeight()+1, uint32(enabledHeight))
+	c.InvokeFail(t, "System.Storage.FindFrom", "next", 3)
+
+	c.Invoke(t, page(3, 4, 5), "next", 3)
+	// Cursor key is deleted between calls.
+	c.Invoke(t, stackitem.Null{}, "remove", []byte{'i', 5})
+	c.Invoke(t, page(6, 7, 8), "next", 3)
+	c.Invoke(t, page(9), "next", 3)
+	c.Invoke(t, stackitem.Null{}, "next", 3)
+}
+
 // Helper functions to create VM, InteropContext, TX, Account, Contract.
 
 func createVM(t testing.TB) (*vm.VM, *interop.Context, *core.Blockchain) {
@@ -0,0 +1,36 @@
+package interop
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrUnhandledException is passed to Tracer.OnCallEnd for contract calls
+// ended with an exception that was not caught by the called contract.
+var ErrUnhandledException = errors.New("unhandled exception")
+
+// Tracer receives structured execution events of the Context it's attached
+// to, it can be used to build traces of script runs and contract calls made
+// by them. Tracing is disabled when Context has no Tracer (which is the
+// default), so no events are produced then. Methods are called synchronously
+// from the execution goroutine, so they should be fast. Calls are properly
+// nested within a single script run, but OnCallEnd is not called for calls
+// that are still executing when the VM faults (OnExecEnd is called anyway).
+type Tracer interface {
+	// OnExecStart is called when the script run starts, the script is
+	// already loaded into the VM.
+	OnExecStart(ic *Context)
+	// OnExecEnd is called when the script run ends with the error returned
+	// from the VM (if any).
+	OnExecEnd(ic *Context, err error)
+	// OnCallStart is called when the contract method call starts,
+	// gasBefore is the amount of GAS consumed by the script run up to
+	// this moment.
+	OnCallStart(contract util.Uint160, method string, gasBefore int64)
+	// OnCallEnd is called when the contract method call started with the
+	// same parameters ends, gasAfter is the amount of GAS consumed by the
+	// script run at this moment. err is ErrUnhandledException if the call
+	// has ended with an exception.
+	OnCallEnd(contract util.Uint160, method string, gasBefore, gasAfter int64, err error)
+}
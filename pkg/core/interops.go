@@ -8,6 +8,7 @@ package core
 */
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -29,6 +30,10 @@ func SpawnVM(ic *interop.Context) *vm.VM {
 	return vm
 }
 
+// hfNeoGoExtensions is used as ActiveFrom value for interops added by the
+// NeoGoExtensions hardfork.
+var hfNeoGoExtensions = config.HFNeoGoExtensions
+
 // All lists are sorted, keep 'em this way, please.
 var systemInterops = []interop.Function{
 	{Name: interopnames.SystemContractCall, Func: contract.Call, Price: 1 << 15,
@@ -43,23 +48,29 @@ var systemInterops = []interop.Function{
 	{Name: interopnames.SystemCryptoCheckSig, Func: crypto.ECDSASecp256r1CheckSig, Price: fee.ECDSAVerifyPrice, ParamCount: 2},
 	{Name: interopnames.SystemIteratorNext, Func: iterator.Next, Price: 1 << 15, ParamCount: 1},
 	{Name: interopnames.SystemIteratorValue, Func: iterator.Value, Price: 1 << 4, ParamCount: 1},
-	{Name: interopnames.SystemRuntimeBurnGas, Func: runtime.BurnGas, Price: 1 << 4, ParamCount: 1},
+	{Name: interopnames.SystemRuntimeBurnGas, Func: runtime.BurnGas, Price: 1 << 4, ParamCount: 1, Volatile: true},
 	{Name: interopnames.SystemRuntimeCheckWitness, Func: runtime.CheckWitness, Price: 1 << 10,
 		RequiredFlags: callflag.NoneFlag, ParamCount: 1},
 	{Name: interopnames.SystemRuntimeCurrentSigners, Func: runtime.CurrentSigners, Price: 1 << 4,
 		RequiredFlags: callflag.NoneFlag},
-	{Name: interopnames.SystemRuntimeGasLeft, Func: runtime.GasLeft, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeEnterNonReentrant, Func: runtime.EnterNonReentrant, Price: 1 << 4,
+		ParamCount: 1, ActiveFrom: &hfNeoGoExtensions, Volatile: true},
+	{Name: interopnames.SystemRuntimeGasLeft, Func: runtime.GasLeft, Price: 1 << 4, Volatile: true},
 	{Name: interopnames.SystemRuntimeGetAddressVersion, Func: runtime.GetAddressVersion, Price: 1 << 3},
 	{Name: interopnames.SystemRuntimeGetCallingScriptHash, Func: runtime.GetCallingScriptHash, Price: 1 << 4},
 	{Name: interopnames.SystemRuntimeGetEntryScriptHash, Func: runtime.GetEntryScriptHash, Price: 1 << 4},
 	{Name: interopnames.SystemRuntimeGetExecutingScriptHash, Func: runtime.GetExecutingScriptHash, Price: 1 << 4},
-	{Name: interopnames.SystemRuntimeGetInvocationCounter, Func: runtime.GetInvocationCounter, Price: 1 << 4},
+	{Name: interopnames.SystemRuntimeGetInvocationCounter, Func: runtime.GetInvocationCounter, Price: 1 << 4, Volatile: true},
 	{Name: interopnames.SystemRuntimeGetNetwork, Func: runtime.GetNetwork, Price: 1 << 3},
-	{Name: interopnames.SystemRuntimeGetNotifications, Func: runtime.GetNotifications, Price: 1 << 12, ParamCount: 1},
-	{Name: interopnames.SystemRuntimeGetRandom, Func: runtime.GetRandom, Price: 0},
+	{Name: interopnames.SystemRuntimeGetNotifications, Func: runtime.GetNotifications, Price: 1 << 12, ParamCount: 1, Volatile: true},
+	{Name: interopnames.SystemRuntimeGetNotificationsByName, Func: runtime.GetNotificationsByName, Price: 1 << 10,
+		ParamCount: 2, ActiveFrom: &hfNeoGoExtensions, Volatile: true},
+	{Name: interopnames.SystemRuntimeGetRandom, Func: runtime.GetRandom, Price: 0, Volatile: true},
 	{Name: interopnames.SystemRuntimeGetScriptContainer, Func: runtime.GetScriptContainer, Price: 1 << 3},
 	{Name: interopnames.SystemRuntimeGetTime, Func: runtime.GetTime, Price: 1 << 3, RequiredFlags: callflag.ReadStates},
 	{Name: interopnames.SystemRuntimeGetTrigger, Func: runtime.GetTrigger, Price: 1 << 3},
+	{Name: interopnames.SystemRuntimeLeaveNonReentrant, Func: runtime.LeaveNonReentrant, Price: 1 << 4,
+		ParamCount: 1, ActiveFrom: &hfNeoGoExtensions, Volatile: true},
 	{Name: interopnames.SystemRuntimeLoadScript, Func: runtime.LoadScript, Price: 1 << 15, RequiredFlags: callflag.AllowCall,
 		ParamCount: 3},
 	{Name: interopnames.SystemRuntimeLog, Func: runtime.Log, Price: 1 << 15, RequiredFlags: callflag.AllowNotify,
@@ -71,6 +82,8 @@ var systemInterops = []interop.Function{
 		RequiredFlags: callflag.WriteStates, ParamCount: 2},
 	{Name: interopnames.SystemStorageFind, Func: storage.Find, Price: 1 << 15, RequiredFlags: callflag.ReadStates,
 		ParamCount: 3},
+	{Name: interopnames.SystemStorageFindFrom, Func: storage.FindFrom, Price: 1 << 15, RequiredFlags: callflag.ReadStates,
+		ParamCount: 4, ActiveFrom: &hfNeoGoExtensions},
 	{Name: interopnames.SystemStorageGet, Func: storage.Get, Price: 1 << 15, RequiredFlags: callflag.ReadStates,
 		ParamCount: 2},
 	{Name: interopnames.SystemStorageGetContext, Func: storage.GetContext, Price: 1 << 4,
@@ -73,6 +73,10 @@ type Pool struct {
 	resendThreshold uint32
 	resendFunc      func(*transaction.Transaction, any)
 
+	// parking contains transactions that are not yet valid, it's nil if
+	// parking is disabled.
+	parking *parking
+
 	// subscriptions for mempool events
 	subscriptionsEnabled bool
 	subscriptionsOn      atomic.Bool
@@ -157,10 +161,20 @@ func (mp *Pool) HasConflicts(t *transaction.Transaction, fee Feer) bool {
 	return false
 }
 
+// payer returns the account paying fees for the given transaction. It's the
+// signer with payerIndex, but the main pool (payerIndex 0) also takes Sponsor
+// attribute into account.
+func (mp *Pool) payer(tx *transaction.Transaction) util.Uint160 {
+	if mp.payerIndex == 0 {
+		return tx.FeePayer()
+	}
+	return tx.Signers[mp.payerIndex].Account
+}
+
 // tryAddSendersFee tries to add system fee and network fee to the total sender`s fee in the mempool
 // and returns false if both balance check is required and the sender does not have enough GAS to pay.
 func (mp *Pool) tryAddSendersFee(tx *transaction.Transaction, feer Feer, needCheck bool) bool {
-	payer := tx.Signers[mp.payerIndex].Account
+	payer := mp.payer(tx)
 	senderFee, ok := mp.fees[payer]
 	if !ok {
 		_ = senderFee.balance.SetFromBig(feer.GetUtilityTokenBalance(payer))
@@ -318,7 +332,7 @@ func (mp *Pool) removeInternal(hash util.Uint256, feer Feer) {
 		} else if num == len(mp.verifiedTxes)-1 {
 			mp.verifiedTxes = mp.verifiedTxes[:num]
 		}
-		payer := itm.txn.Signers[mp.payerIndex].Account
+		payer := mp.payer(itm.txn)
 		senderFee := mp.fees[payer]
 		senderFee.feeSum.SubUint64(&senderFee.feeSum, uint64(tx.SystemFee+tx.NetworkFee))
 		mp.fees[payer] = senderFee
@@ -498,7 +512,7 @@ func (mp *Pool) GetVerifiedTransactions() []*transaction.Transaction {
 // checkTxConflicts is an internal unprotected version of Verify. It takes into
 // consideration conflicting transactions which are about to be removed from mempool.
 func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*transaction.Transaction, error) {
-	payer := tx.Signers[mp.payerIndex].Account
+	payer := mp.payer(tx)
 	actualSenderFee, ok := mp.fees[payer]
 	if !ok {
 		actualSenderFee.balance.SetFromBig(fee.GetUtilityTokenBalance(payer))
@@ -553,7 +567,7 @@ func (mp *Pool) checkTxConflicts(tx *transaction.Transaction, fee Feer) ([]*tran
 	// Step 3: take into account sender's conflicting transactions before balance check.
 	expectedSenderFee = actualSenderFee
 	for _, conflictingTx := range conflictsToBeRemoved {
-		if conflictingTx.Signers[mp.payerIndex].Account.Equals(payer) {
+		if mp.payer(conflictingTx).Equals(payer) {
 			expectedSenderFee.feeSum.SubUint64(&expectedSenderFee.feeSum, uint64(conflictingTx.SystemFee+conflictingTx.NetworkFee))
 		}
 	}
@@ -310,6 +310,46 @@ func TestMemPoolFees(t *testing.T) {
 	require.Equal(t, 0, len(mp.fees))
 }
 
+func TestMemPoolSponsoredFees(t *testing.T) {
+	mp := New(10, 0, false, nil)
+	fs := &FeerStub{balance: 10000000}
+	sender, sponsor := util.Uint160{1, 2, 3}, util.Uint160{3, 2, 1}
+	newTx := func(netFee int64, sponsored bool) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = uint32(len(mp.verifiedTxes))
+		tx.NetworkFee = netFee
+		tx.Signers = []transaction.Signer{{Account: sender}, {Account: sponsor}}
+		if sponsored {
+			tx.Attributes = []transaction.Attribute{{Type: transaction.SponsorT, Value: &transaction.Sponsor{Account: sponsor}}}
+		}
+		return tx
+	}
+
+	tx1 := newTx(fs.balance, true)
+	require.NoError(t, mp.Add(tx1, fs))
+	require.Equal(t, 1, len(mp.fees))
+	require.Equal(t, utilityBalanceAndFees{
+		balance: *uint256.NewInt(uint64(fs.balance)),
+		feeSum:  *uint256.NewInt(uint64(fs.balance)),
+	}, mp.fees[sponsor])
+
+	// Sponsor can't pay more, but sender can.
+	require.ErrorIs(t, mp.Add(newTx(1, true), fs), ErrConflict)
+	tx2 := newTx(fs.balance, false)
+	require.NoError(t, mp.Add(tx2, fs))
+	require.Equal(t, 2, len(mp.fees))
+
+	// Only the sponsored transaction is left.
+	mp.RemoveStale(func(t *transaction.Transaction) bool {
+		return t == tx1
+	}, fs)
+	require.Equal(t, 1, len(mp.fees))
+	require.Equal(t, utilityBalanceAndFees{
+		balance: *uint256.NewInt(uint64(fs.balance)),
+		feeSum:  *uint256.NewInt(uint64(fs.balance)),
+	}, mp.fees[sponsor])
+}
+
 func TestMempoolItemsOrder(t *testing.T) {
 	sender0 := util.Uint160{1, 2, 3}
 	balance := big.NewInt(10000000)
@@ -821,3 +861,68 @@ func TestMempoolIterateVerifiedTransactions(t *testing.T) {
 	}
 	checkPooledRequest(t, r5, false)
 }
+
+func TestMempoolParking(t *testing.T) {
+	fs := &FeerStub{balance: 100}
+	mp := New(10, 0, false, nil)
+	newTx := func(sender util.Uint160, nvb uint32, vub uint32) *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.Nonce = nvb*100 + vub
+		tx.ValidUntilBlock = vub
+		tx.Signers = []transaction.Signer{{Account: sender}}
+		return tx
+	}
+	sender1 := util.Uint160{1, 2, 3}
+	sender2 := util.Uint160{3, 2, 1}
+
+	tx := newTx(sender1, 1, 10)
+	require.ErrorIs(t, mp.Park(tx, 1), ErrParkingFull) // Disabled.
+
+	mp.SetParking(3, 2)
+	require.NoError(t, mp.Park(tx, 1))
+	require.ErrorIs(t, mp.Park(tx, 1), ErrDup)
+	require.True(t, mp.IsParked(tx.Hash()))
+	require.False(t, mp.ContainsKey(tx.Hash()))
+
+	pooled := newTx(sender1, 0, 10)
+	require.NoError(t, mp.Add(pooled, fs))
+	require.ErrorIs(t, mp.Park(pooled, 1), ErrDup)
+
+	expiring := newTx(sender1, 3, 2)
+	require.NoError(t, mp.Park(expiring, 3))
+	require.ErrorIs(t, mp.Park(newTx(sender1, 2, 10), 2), ErrParkingFull) // Per sender.
+	later := newTx(sender2, 3, 10)
+	require.NoError(t, mp.Park(later, 3))
+	require.ErrorIs(t, mp.Park(newTx(sender2, 2, 10), 2), ErrParkingFull) // Total.
+	require.Equal(t, 3, mp.ParkedCount())
+
+	ready, expired := mp.TakeParked(1)
+	require.Equal(t, []*transaction.Transaction{tx}, ready)
+	require.Equal(t, 0, expired)
+	require.False(t, mp.IsParked(tx.Hash()))
+
+	ready, expired = mp.TakeParked(2)
+	require.Equal(t, 0, len(ready))
+	require.Equal(t, 1, expired)
+
+	ready, expired = mp.TakeParked(3)
+	require.Equal(t, []*transaction.Transaction{later}, ready)
+	require.Equal(t, 0, expired)
+	require.Equal(t, 0, mp.ParkedCount())
+
+	t.Run("relay", func(t *testing.T) {
+		ch := make(chan *transaction.Transaction, 1)
+		mp.SetResendThreshold(1, func(tx *transaction.Transaction, _ any) { ch <- tx })
+		mp.Relay([]*transaction.Transaction{later})
+		select {
+		case tx := <-ch:
+			require.Equal(t, later, tx)
+		case <-time.After(time.Second):
+			t.Fatal("transaction wasn't relayed")
+		}
+	})
+
+	mp.SetParking(0, 0)
+	require.False(t, mp.IsParked(tx.Hash()))
+	require.Equal(t, 0, mp.ParkedCount())
+}
@@ -0,0 +1,148 @@
+package mempool
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrParkingFull is returned when the transaction can't be parked because of
+// the parking area capacity constraints (total or per-sender ones).
+var ErrParkingFull = errors.New("parking area is full")
+
+// parkedItem is a transaction waiting for its NotValidBefore height.
+type parkedItem struct {
+	txn *transaction.Transaction
+	nvb uint32
+}
+
+// parking is a bounded buffer of transactions that are not yet valid, they're
+// grouped by sender.
+type parking struct {
+	capacity  int
+	perSender int
+	count     int
+	senders   map[util.Uint160][]parkedItem
+	hashes    map[util.Uint256]util.Uint160
+}
+
+// SetParking enables the parking area for transactions that are not yet valid
+// because of their NotValidBefore attribute. The capacity limits the total
+// number of parked transactions and perSender limits it for a single sender.
+// Parking is disabled if any of the limits is zero.
+func (mp *Pool) SetParking(capacity int, perSender int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	if capacity <= 0 || perSender <= 0 {
+		mp.parking = nil
+		return
+	}
+	mp.parking = &parking{
+		capacity:  capacity,
+		perSender: perSender,
+		senders:   make(map[util.Uint160][]parkedItem),
+		hashes:    make(map[util.Uint256]util.Uint160),
+	}
+}
+
+// Park puts the transaction that becomes valid at the nvb height into the
+// parking area. Parked transactions are not a part of the pool, they're to be
+// taken with TakeParked and added to the pool after re-verification.
+func (mp *Pool) Park(t *transaction.Transaction, nvb uint32) error {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	p := mp.parking
+	if p == nil {
+		return ErrParkingFull
+	}
+	h := t.Hash()
+	if _, ok := p.hashes[h]; ok || mp.containsKey(h) {
+		return ErrDup
+	}
+	sender := t.Sender()
+	if p.count >= p.capacity || len(p.senders[sender]) >= p.perSender {
+		return ErrParkingFull
+	}
+	p.senders[sender] = append(p.senders[sender], parkedItem{txn: t, nvb: nvb})
+	p.hashes[h] = sender
+	p.count++
+	return nil
+}
+
+// IsParked checks whether the transaction with the given hash is in the
+// parking area.
+func (mp *Pool) IsParked(hash util.Uint256) bool {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	if mp.parking == nil {
+		return false
+	}
+	_, ok := mp.parking.hashes[hash]
+	return ok
+}
+
+// ParkedCount returns the number of transactions in the parking area.
+func (mp *Pool) ParkedCount() int {
+	mp.lock.RLock()
+	defer mp.lock.RUnlock()
+	if mp.parking == nil {
+		return 0
+	}
+	return mp.parking.count
+}
+
+// TakeParked removes transactions that can be valid after the block with the
+// given height (the ones with NotValidBefore not exceeding it) from the parking
+// area and returns them. Transactions that have expired at this height are
+// dropped, their number is returned as the second value.
+func (mp *Pool) TakeParked(height uint32) ([]*transaction.Transaction, int) {
+	mp.lock.Lock()
+	defer mp.lock.Unlock()
+	p := mp.parking
+	if p == nil || p.count == 0 {
+		return nil, 0
+	}
+	var (
+		ready   []*transaction.Transaction
+		expired int
+	)
+	for sender, items := range p.senders {
+		var left = items[:0]
+		for _, itm := range items {
+			switch {
+			case itm.txn.ValidUntilBlock <= height:
+				expired++
+			case itm.nvb <= height:
+				ready = append(ready, itm.txn)
+			default:
+				left = append(left, itm)
+				continue
+			}
+			delete(p.hashes, itm.txn.Hash())
+			p.count--
+		}
+		if len(left) == 0 {
+			delete(p.senders, sender)
+		} else {
+			p.senders[sender] = left
+		}
+	}
+	return ready, expired
+}
+
+// Relay passes the given transactions to the function set with
+// SetResendThreshold (if any) in a separate goroutine.
+func (mp *Pool) Relay(txes []*transaction.Transaction) {
+	mp.lock.RLock()
+	f := mp.resendFunc
+	mp.lock.RUnlock()
+	if f == nil || len(txes) == 0 {
+		return
+	}
+	go func() {
+		for _, t := range txes {
+			f(t, nil)
+		}
+	}()
+}
@@ -3,10 +3,12 @@ package mpt
 import (
 	"bytes"
 	"encoding/binary"
+	"encoding/hex"
 	"errors"
 	"fmt"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
@@ -15,7 +17,10 @@ var (
 	// ErrRestoreFailed is returned when replacing HashNode by its "unhashed"
 	// candidate fails.
 	ErrRestoreFailed = errors.New("failed to restore MPT node")
-	errStop          = errors.New("stop condition is met")
+	// ErrVerificationFailed is returned when restored MPT doesn't match its
+	// root hash or contract storage items or when stored node can't be decoded.
+	ErrVerificationFailed = errors.New("MPT verification failed")
+	errStop               = errors.New("stop condition is met")
 )
 
 // Billet is a part of an MPT trie with missing hash nodes that need to be restored.
@@ -212,6 +217,52 @@ func (b *Billet) Traverse(process func(pathToNode []byte, node Node, nodeBytes [
 	return nil
 }
 
+// Verify checks MPT restored into the storage starting from the billet root
+// hash. All nodes must be present in the storage and match their hashes, every
+// leaf must have its value stored with the billet TempStoragePrefix and there
+// must be no other items with this prefix. It returns the number of checked
+// nodes. The billet itself is not changed.
+func (b *Billet) Verify() (int, error) {
+	var (
+		nodes  int
+		leaves int
+		err    error
+	)
+	_, tErr := b.traverse(NewHashNode(b.root.Hash()), []byte{}, []byte{}, func(pathToNode []byte, n Node, nodeBytes []byte) bool {
+		nodes++
+		if h := hash.DoubleSha256(nodeBytes); h != n.Hash() {
+			err = fmt.Errorf("%w: node %s has hash %s", ErrVerificationFailed, n.Hash().StringBE(), h.StringBE())
+			return true
+		}
+		leaf, ok := n.(*LeafNode)
+		if !ok {
+			return false
+		}
+		leaves++
+		v, gErr := b.Store.Get(append([]byte{byte(b.TempStoragePrefix)}, pathToNode...))
+		if gErr != nil || !bytes.Equal(v, leaf.value) {
+			err = fmt.Errorf("%w: storage item with key %s doesn't match leaf %s", ErrVerificationFailed, hex.EncodeToString(pathToNode), n.Hash().StringBE())
+			return true
+		}
+		return false
+	}, false, false)
+	if tErr != nil && !errors.Is(tErr, errStop) {
+		return nodes, fmt.Errorf("%w: %w", ErrVerificationFailed, tErr)
+	}
+	if err != nil {
+		return nodes, err
+	}
+	var items int
+	b.Store.Seek(storage.SeekRange{Prefix: []byte{byte(b.TempStoragePrefix)}}, func(_, _ []byte) bool {
+		items++
+		return true
+	})
+	if items != leaves {
+		return nodes, fmt.Errorf("%w: %d storage items for %d leaves", ErrVerificationFailed, items, leaves)
+	}
+	return nodes, nil
+}
+
 func (b *Billet) traverse(curr Node, path, from []byte, process func(pathToNode []byte, node Node, nodeBytes []byte) bool, ignoreStorageErr bool, backwards bool) (Node, error) {
 	if _, ok := curr.(EmptyNode); ok {
 		// We're not interested in EmptyNodes, and they do not affect the
@@ -347,7 +398,7 @@ func (b *Billet) GetFromStore(h util.Uint256) (Node, error) {
 	r := io.NewBinReaderFromBuf(data)
 	n.DecodeBinary(r)
 	if r.Err != nil {
-		return nil, r.Err
+		return nil, fmt.Errorf("%w: can't decode node %s: %w", ErrVerificationFailed, h.StringBE(), r.Err)
 	}
 
 	if b.mode.RC() {
@@ -1,6 +1,7 @@
 package mpt
 
 import (
+	"bytes"
 	"encoding/binary"
 	"testing"
 
@@ -208,3 +209,60 @@ func TestBillet_RestoreHashNode(t *testing.T) {
 		require.Error(t, tr.RestoreHashNode([]byte{0x03}, l))
 	})
 }
+
+func TestBillet_Verify(t *testing.T) {
+	newRestored := func(t *testing.T) (*Billet, *storage.MemCachedStore) {
+		store := newTestStore()
+		tr := NewTrie(nil, ModeLatest, store)
+		for i := 0; i < 20; i++ {
+			k := []byte{0xAB, byte(i % 4), byte(i)}
+			v := []byte{byte(i % 3)} // Some leaves are the same.
+			require.NoError(t, tr.Put(k, v))
+			store.Put(append([]byte{byte(storage.STTempStorage)}, k...), v)
+		}
+		tr.Flush(0)
+		return NewBillet(tr.StateRoot(), ModeLatest, storage.STTempStorage, store), store
+	}
+
+	t.Run("good", func(t *testing.T) {
+		b, _ := newRestored(t)
+		n, err := b.Verify()
+		require.NoError(t, err)
+		require.Positive(t, n)
+	})
+	t.Run("missing node", func(t *testing.T) {
+		b, store := newRestored(t)
+		store.Delete(makeStorageKey(b.root.Hash()))
+		_, err := b.Verify()
+		require.ErrorIs(t, err, ErrVerificationFailed)
+	})
+	t.Run("corrupted node", func(t *testing.T) {
+		b, store := newRestored(t)
+		key := makeStorageKey(b.root.Hash())
+		data, err := store.Get(key)
+		require.NoError(t, err)
+		data = bytes.Clone(data)
+		data[len(data)-6]++ // Last byte of the serialized node.
+		store.Put(key, data)
+		_, err = b.Verify()
+		require.ErrorIs(t, err, ErrVerificationFailed)
+	})
+	t.Run("missing storage item", func(t *testing.T) {
+		b, store := newRestored(t)
+		store.Delete([]byte{byte(storage.STTempStorage), 0xAB, 1, 5})
+		_, err := b.Verify()
+		require.ErrorIs(t, err, ErrVerificationFailed)
+	})
+	t.Run("wrong storage item", func(t *testing.T) {
+		b, store := newRestored(t)
+		store.Put([]byte{byte(storage.STTempStorage), 0xAB, 1, 5}, []byte{42})
+		_, err := b.Verify()
+		require.ErrorIs(t, err, ErrVerificationFailed)
+	})
+	t.Run("extra storage item", func(t *testing.T) {
+		b, store := newRestored(t)
+		store.Put([]byte{byte(storage.STTempStorage), 0xAB, 1, 42}, []byte{42})
+		_, err := b.Verify()
+		require.ErrorIs(t, err, ErrVerificationFailed)
+	})
+}
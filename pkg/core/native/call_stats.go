@@ -0,0 +1,68 @@
+package native
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// MethodCallStats is a snapshot of node-local native method invocation
+// statistics.
+type MethodCallStats struct {
+	// Contract is the name of the native contract.
+	Contract string
+	Hash     util.Uint160
+	Method   string
+	// ParamCount is the number of method parameters (to distinguish
+	// overloaded methods).
+	ParamCount int
+	Calls      int64
+	GAS        int64
+}
+
+// EnableCallStats enables invocation statistics gathering for all methods of
+// the given native contracts. It must be called before contracts are used.
+func EnableCallStats(cs []interop.Contract) {
+	for _, c := range cs {
+		md := c.Metadata()
+		for i := range md.Methods {
+			md.Methods[i].Stats = new(interop.MethodStats)
+		}
+		md.UpdateHash() // Rebuild hardfork-specific method lists.
+	}
+}
+
+// GetCallStats returns invocation statistics of all methods of the given
+// native contracts (methods without statistics are skipped).
+func GetCallStats(cs []interop.Contract) []MethodCallStats {
+	var res []MethodCallStats
+	for _, c := range cs {
+		md := c.Metadata()
+		for _, m := range md.Methods {
+			if m.Stats == nil {
+				continue
+			}
+			res = append(res, MethodCallStats{
+				Contract:   md.Name,
+				Hash:       md.Hash,
+				Method:     m.MD.Name,
+				ParamCount: len(m.MD.Parameters),
+				Calls:      m.Stats.Calls.Load(),
+				GAS:        m.Stats.GAS.Load(),
+			})
+		}
+	}
+	return res
+}
+
+// ResetCallStats resets invocation statistics of all methods of the given
+// native contracts.
+func ResetCallStats(cs []interop.Contract) {
+	for _, c := range cs {
+		for _, m := range c.Metadata().Methods {
+			if m.Stats != nil {
+				m.Stats.Calls.Store(0)
+				m.Stats.GAS.Store(0)
+			}
+		}
+	}
+}
@@ -72,7 +72,7 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	cs.Ledger = ledger
 	cs.Contracts = append(cs.Contracts, ledger)
 
-	gas := newGAS(int64(cfg.InitialGASSupply), cfg.P2PSigExtensions)
+	gas := newGAS(int64(cfg.InitialGASSupply), cfg.P2PSigExtensions, cfg.Genesis.TransferBurnRate)
 	neo := newNEO(cfg)
 	policy := newPolicy(cfg.P2PSigExtensions)
 	neo.GAS = gas
@@ -82,6 +82,7 @@ func NewContracts(cfg config.ProtocolConfiguration) *Contracts {
 	mgmt.NEO = neo
 	mgmt.Policy = policy
 	policy.NEO = neo
+	ledger.Policy = policy
 
 	cs.GAS = gas
 	cs.NEO = neo
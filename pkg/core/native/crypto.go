@@ -2,9 +2,11 @@ package native
 
 import (
 	"crypto/elliptic"
+	"crypto/sha256"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	gohash "hash"
 	"math/big"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
@@ -18,6 +20,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/twmb/murmur3"
 	"golang.org/x/crypto/sha3"
@@ -37,7 +40,16 @@ const (
 	Secp256r1 NamedCurve = 23
 )
 
-const cryptoContractID = -3
+const (
+	cryptoContractID = -3
+
+	// cryptoSHA256FeePerByte is the sha256Update price (in execution fee
+	// factor units) charged for every byte of hashed data.
+	cryptoSHA256FeePerByte = 1 << 2
+	// cryptoMerkleRootFeePerHash is the merkleRoot price (in execution fee
+	// factor units) charged for every hash of the input.
+	cryptoMerkleRootFeePerHash = 1 << 8
+)
 
 func newCrypto() *Crypto {
 	c := &Crypto{ContractMD: *interop.NewContractMD(nativenames.CryptoLib, cryptoContractID)}
@@ -106,6 +118,26 @@ func newCrypto() *Crypto {
 		manifest.NewParameter("data", smartcontract.ByteArrayType))
 	md = newMethodAndPrice(c.keccak256, 1<<15, callflag.NoneFlag)
 	c.AddMethod(md, desc)
+
+	desc = newDescriptor("sha256Init", smartcontract.InteropInterfaceType)
+	md = newMethodAndPrice(c.sha256Init, 1<<4, callflag.NoneFlag, config.HFNeoGoExtensions)
+	c.AddMethod(md, desc)
+
+	desc = newDescriptor("sha256Update", smartcontract.VoidType,
+		manifest.NewParameter("state", smartcontract.InteropInterfaceType),
+		manifest.NewParameter("data", smartcontract.ByteArrayType))
+	md = newMethodAndPrice(c.sha256Update, 1<<10, callflag.NoneFlag, config.HFNeoGoExtensions)
+	c.AddMethod(md, desc)
+
+	desc = newDescriptor("sha256Final", smartcontract.ByteArrayType,
+		manifest.NewParameter("state", smartcontract.InteropInterfaceType))
+	md = newMethodAndPrice(c.sha256Final, 1<<10, callflag.NoneFlag, config.HFNeoGoExtensions)
+	c.AddMethod(md, desc)
+
+	desc = newDescriptor("merkleRoot", smartcontract.ByteArrayType,
+		manifest.NewParameter("hashes", smartcontract.ArrayType))
+	md = newMethodAndPrice(c.merkleRoot, 1<<10, callflag.NoneFlag, config.HFNeoGoExtensions)
+	c.AddMethod(md, desc)
 	return c
 }
 
@@ -304,6 +336,68 @@ func (c *Crypto) keccak256(_ *interop.Context, args []stackitem.Item) stackitem.
 	return stackitem.NewByteArray(digest.Sum(nil))
 }
 
+// sha256State is an incremental SHA256 hashing state. It's only kept on the VM
+// stack as an InteropInterface item and never stored.
+type sha256State struct {
+	h    gohash.Hash
+	done bool
+}
+
+func toSHA256State(item stackitem.Item) *sha256State {
+	st, ok := item.Value().(*sha256State)
+	if !ok {
+		panic(errors.New("not a sha256 state"))
+	}
+	if st.done {
+		panic(errors.New("sha256 state is already finalized"))
+	}
+	return st
+}
+
+func (c *Crypto) sha256Init(_ *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewInterop(&sha256State{h: sha256.New()})
+}
+
+func (c *Crypto) sha256Update(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	st := toSHA256State(args[0])
+	bs, err := args[1].TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * cryptoSHA256FeePerByte * int64(len(bs))) {
+		panic(errGasLimitExceeded)
+	}
+	_, _ = st.h.Write(bs)
+	return stackitem.Null{}
+}
+
+func (c *Crypto) sha256Final(_ *interop.Context, args []stackitem.Item) stackitem.Item {
+	st := toSHA256State(args[0])
+	st.done = true
+	return stackitem.NewByteArray(st.h.Sum(nil))
+}
+
+func (c *Crypto) merkleRoot(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	arr, ok := args[0].Value().([]stackitem.Item)
+	if !ok {
+		panic(errors.New("not an array"))
+	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * cryptoMerkleRootFeePerHash * int64(len(arr))) {
+		panic(errGasLimitExceeded)
+	}
+	hashes := make([]util.Uint256, len(arr))
+	for i := range arr {
+		bs, err := arr[i].TryBytes()
+		if err == nil {
+			hashes[i], err = util.Uint256DecodeBytesBE(bs)
+		}
+		if err != nil {
+			panic(fmt.Errorf("invalid hash #%d: %w", i, err))
+		}
+	}
+	return stackitem.NewByteArray(hash.CalcMerkleRoot(hashes).BytesBE())
+}
+
 // Metadata implements the Contract interface.
 func (c *Crypto) Metadata() *interop.ContractMD {
 	return &c.ContractMD
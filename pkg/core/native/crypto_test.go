@@ -8,8 +8,11 @@ import (
 	"testing"
 
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -30,6 +33,80 @@ func TestSha256(t *testing.T) {
 	})
 }
 
+func TestSha256Streaming(t *testing.T) {
+	c := newCrypto()
+	ic := &interop.Context{VM: vm.New()}
+
+	data := random.Bytes(3*stackitem.MaxSize + 123)
+	st := c.sha256Init(ic, nil)
+	for i := 0; i < len(data); i += stackitem.MaxSize {
+		end := i + stackitem.MaxSize
+		if end > len(data) {
+			end = len(data)
+		}
+		c.sha256Update(ic, []stackitem.Item{st, stackitem.NewByteArray(data[i:end])})
+	}
+	actual := c.sha256Final(ic, []stackitem.Item{st})
+	require.Equal(t, hash.Sha256(data).BytesBE(), actual.Value())
+
+	t.Run("empty", func(t *testing.T) {
+		st := c.sha256Init(ic, nil)
+		require.Equal(t, hash.Sha256(nil).BytesBE(), c.sha256Final(ic, []stackitem.Item{st}).Value())
+	})
+	t.Run("finalized state", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.sha256Update(ic, []stackitem.Item{st, stackitem.NewByteArray([]byte{1})})
+		})
+		require.Panics(t, func() {
+			c.sha256Final(ic, []stackitem.Item{st})
+		})
+	})
+	t.Run("bad state", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.sha256Update(ic, []stackitem.Item{stackitem.NewInterop(nil), stackitem.NewByteArray([]byte{1})})
+		})
+		require.Panics(t, func() {
+			c.sha256Final(ic, []stackitem.Item{stackitem.NewByteArray([]byte{1})})
+		})
+	})
+	t.Run("bad data", func(t *testing.T) {
+		st := c.sha256Init(ic, nil)
+		require.Panics(t, func() {
+			c.sha256Update(ic, []stackitem.Item{st, stackitem.NewInterop(nil)})
+		})
+	})
+}
+
+func TestMerkleRoot(t *testing.T) {
+	c := newCrypto()
+	ic := &interop.Context{VM: vm.New()}
+
+	for _, n := range []int{0, 1, 2, 3, 10} {
+		hashes := make([]util.Uint256, n)
+		items := make([]stackitem.Item, n)
+		for i := range hashes {
+			hashes[i] = random.Uint256()
+			items[i] = stackitem.NewByteArray(hashes[i].BytesBE())
+		}
+		actual := c.merkleRoot(ic, []stackitem.Item{stackitem.NewArray(items)})
+		require.Equal(t, hash.CalcMerkleRoot(hashes).BytesBE(), actual.Value(), n)
+	}
+
+	t.Run("not an array", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.merkleRoot(ic, []stackitem.Item{stackitem.NewByteArray([]byte{1})})
+		})
+	})
+	t.Run("bad hash", func(t *testing.T) {
+		require.Panics(t, func() {
+			c.merkleRoot(ic, []stackitem.Item{stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(random.Bytes(util.Uint256Size)),
+				stackitem.NewByteArray([]byte{1, 2, 3}),
+			})})
+		})
+	})
+}
+
 // TestKeccak256_Compat is a C# node compatibility test with data taken from https://github.com/Jim8y/neo/blob/560d35783e428d31e3681eaa7ee9ed00a8a50d09/tests/Neo.UnitTests/SmartContract/Native/UT_CryptoLib.cs#L340
 func TestKeccak256_Compat(t *testing.T) {
 	c := newCrypto()
@@ -263,7 +263,7 @@ func (s *Designate) notifyRoleChanged(v *roleData, r noderoles.Role) {
 	switch r {
 	case noderoles.Oracle:
 		if orc, _ := s.OracleService.Load().(*OracleService); orc != nil && *orc != nil {
-			(*orc).UpdateOracleNodes(v.nodes.Copy())
+			(*orc).UpdateOracleNodes(v.height, v.nodes.Copy())
 		}
 	case noderoles.P2PNotary:
 		if ntr, _ := s.NotaryService.Load().(*NotaryService); ntr != nil && *ntr != nil {
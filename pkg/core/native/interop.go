@@ -43,7 +43,7 @@ func Call(ic *interop.Context) error {
 			return fmt.Errorf("native contract %s is active after hardfork %s", meta.Name, activeIn.String())
 		}
 	}
-	m, ok := meta.GetMethodByOffset(ic.VM.Context().IP())
+	m, ok := meta.HFSpecificContractMD(ic.IsHardforkEnabled).GetMethodByOffset(ic.VM.Context().IP())
 	if !ok {
 		return fmt.Errorf("method not found")
 	}
@@ -58,6 +58,7 @@ func Call(ic *interop.Context) error {
 	}
 	invokeFee := m.CPUFee*ic.BaseExecFee() +
 		m.StorageFee*ic.BaseStorageFee()
+	gasBefore := ic.VM.GasConsumed()
 	if !ic.VM.AddGas(invokeFee) {
 		return errors.New("gas limit exceeded")
 	}
@@ -67,6 +68,10 @@ func Call(ic *interop.Context) error {
 		args[i] = ic.VM.Estack().Peek(i).Item()
 	}
 	result := m.Func(ic, args)
+	if m.Stats != nil {
+		m.Stats.Calls.Add(1)
+		m.Stats.GAS.Add(ic.VM.GasConsumed() - gasBefore)
+	}
 	for range m.MD.Parameters {
 		ic.VM.Estack().Pop()
 	}
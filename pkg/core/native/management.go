@@ -163,6 +163,10 @@ func newManagement() *Management {
 	md = newMethodAndPrice(m.getContractHashes, 1<<15, callflag.ReadStates)
 	m.AddMethod(md, desc)
 
+	desc = newDescriptor("getContractsIterator", smartcontract.InteropInterfaceType)
+	md = newMethodAndPrice(m.getContractsIterator, 1<<15, callflag.ReadStates, config.HFNeoGoExtensions)
+	m.AddMethod(md, desc)
+
 	hashParam := manifest.NewParameter("Hash", smartcontract.Hash160Type)
 	m.AddEvent(contractDeployNotificationName, hashParam)
 	m.AddEvent(contractUpdateNotificationName, hashParam)
@@ -289,6 +293,22 @@ func (m *Management) getContractHashes(ic *interop.Context, _ []stackitem.Item)
 	return stackitem.NewInterop(item)
 }
 
+// getContractsIterator returns an iterator over states of all contracts
+// (including native ones) ordered by their hashes.
+func (m *Management) getContractsIterator(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	ctx, cancel := context.WithCancel(context.Background())
+	prefix := []byte{PrefixContract}
+	seekres := ic.DAO.SeekAsync(ctx, ManagementContractID, storage.SeekRange{Prefix: prefix})
+	opts := istorage.FindValuesOnly | istorage.FindDeserialize
+	item := istorage.NewIterator(seekres, prefix, int64(opts))
+	ic.RegisterCancelFunc(func() {
+		cancel()
+		for range seekres { //nolint:revive //empty-block
+		}
+	})
+	return stackitem.NewInterop(item)
+}
+
 // getNefAndManifestFromItems converts input arguments into NEF and manifest
 // adding an appropriate deployment GAS price and sanitizing inputs.
 func (m *Management) getNefAndManifestFromItems(ic *interop.Context, args []stackitem.Item, isDeploy bool) (*nef.File, *manifest.Manifest, error) {
@@ -583,18 +603,39 @@ func updateContractCache(cache *ManagementCache, cs *state.Contract) {
 func (m *Management) OnPersist(ic *interop.Context) error {
 	var cache *ManagementCache
 	for _, native := range ic.Natives {
-		activeIn := native.ActiveIn()
-		if !(activeIn == nil && ic.Block.Index == 0 ||
-			activeIn != nil && ic.IsHardforkActivation(*activeIn)) {
+		var (
+			md       = native.Metadata()
+			activeIn = native.ActiveIn()
+			isDeploy = activeIn == nil && ic.Block.Index == 0 ||
+				activeIn != nil && ic.IsHardforkActivation(*activeIn)
+			isUpdate bool
+		)
+		if !isDeploy && (activeIn == nil || ic.IsHardforkEnabled(*activeIn)) {
+			for _, hf := range md.MethodHardforks() {
+				if ic.IsHardforkActivation(hf) {
+					isUpdate = true
+					break
+				}
+			}
+		}
+		if !isDeploy && !isUpdate {
 			continue
 		}
 
-		md := native.Metadata()
 		cs := &state.Contract{
-			ContractBase: md.ContractBase,
+			ContractBase: md.HFSpecificContractMD(ic.IsHardforkEnabled).ContractBase,
 		}
-		if err := native.Initialize(ic); err != nil {
-			return fmt.Errorf("initializing %s native contract: %w", md.Name, err)
+		if isDeploy {
+			if err := native.Initialize(ic); err != nil {
+				return fmt.Errorf("initializing %s native contract: %w", md.Name, err)
+			}
+		} else {
+			// New methods are available, so NEF and manifest are updated.
+			old, err := GetContract(ic.DAO, md.Hash)
+			if err != nil {
+				return fmt.Errorf("updating %s native contract: %w", md.Name, err)
+			}
+			cs.UpdateCounter = old.UpdateCounter
 		}
 		err := putContractState(ic.DAO, cs, false) // Perform cache update manually.
 		if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
@@ -24,6 +25,9 @@ type GAS struct {
 
 	initialSupply           int64
 	p2pSigExtensionsEnabled bool
+	// transferBurnRate is the part of every transfer amount (in basis points)
+	// that is burnt, see config.Genesis.TransferBurnRate.
+	transferBurnRate int64
 }
 
 const gasContractID = -6
@@ -32,10 +36,11 @@ const gasContractID = -6
 const GASFactor = NEOTotalSupply
 
 // newGAS returns GAS native contract.
-func newGAS(init int64, p2pSigExtensionsEnabled bool) *GAS {
+func newGAS(init int64, p2pSigExtensionsEnabled bool, transferBurnRate uint32) *GAS {
 	g := &GAS{
 		initialSupply:           init,
 		p2pSigExtensionsEnabled: p2pSigExtensionsEnabled,
+		transferBurnRate:        int64(transferBurnRate),
 	}
 	defer g.UpdateHash()
 
@@ -45,6 +50,12 @@ func newGAS(init int64, p2pSigExtensionsEnabled bool) *GAS {
 	nep17.factor = GASFactor
 	nep17.incBalance = g.increaseBalance
 	nep17.balFromBytes = g.balanceFromBytes
+	if g.transferBurnRate != 0 {
+		nep17.transferFee = g.transferFee
+		nep17.AddEvent("Burn",
+			manifest.NewParameter("from", smartcontract.Hash160Type),
+			manifest.NewParameter("amount", smartcontract.IntegerType))
+	}
 
 	g.nep17TokenNative = *nep17
 
@@ -74,6 +85,13 @@ func (g *GAS) increaseBalance(_ *interop.Context, _ util.Uint160, si *state.Stor
 	return nil, nil
 }
 
+// transferFee returns the amount of GAS that is burnt from the given transfer
+// amount according to the configured transfer burn rate.
+func (g *GAS) transferFee(amount *big.Int) *big.Int {
+	fee := new(big.Int).Mul(amount, big.NewInt(g.transferBurnRate))
+	return fee.Quo(fee, big.NewInt(config.MaxTransferBurnRate))
+}
+
 func (g *GAS) balanceFromBytes(si *state.StorageItem) (*big.Int, error) {
 	acc, err := state.NEP17BalanceFromBytes(*si)
 	if err != nil {
@@ -109,9 +127,14 @@ func (g *GAS) OnPersist(ic *interop.Context) error {
 	if len(ic.Block.Transactions) == 0 {
 		return nil
 	}
+	sponsorship := ic.IsHardforkEnabled(config.HFNeoGoExtensions)
 	for _, tx := range ic.Block.Transactions {
 		absAmount := big.NewInt(tx.SystemFee + tx.NetworkFee)
-		g.burn(ic, tx.Sender(), absAmount)
+		payer := tx.Sender()
+		if sponsorship {
+			payer = tx.FeePayer()
+		}
+		g.burn(ic, payer, absAmount)
 	}
 	validators := g.NEO.GetNextBlockValidatorsInternal(ic.DAO)
 	primary := validators[ic.Block.PrimaryIndex].GetScriptHash()
@@ -194,6 +194,21 @@ func newNEO(cfg config.ProtocolConfiguration) *NEO {
 	md := newMethodAndPrice(n.unclaimedGas, 1<<17, callflag.ReadStates)
 	n.AddMethod(md, desc)
 
+	desc = newDescriptor("unclaimedGasDetailed", smartcontract.ArrayType,
+		manifest.NewParameter("account", smartcontract.Hash160Type))
+	md = newMethodAndPrice(n.unclaimedGasDetailed, 1<<17, callflag.ReadStates, config.HFNeoGoExtensions)
+	n.AddMethod(md, desc)
+
+	desc = newDescriptor("getVoterInfo", smartcontract.ArrayType,
+		manifest.NewParameter("account", smartcontract.Hash160Type))
+	md = newMethodAndPrice(n.getVoterInfo, 1<<15, callflag.ReadStates, config.HFNeoGoExtensions)
+	n.AddMethod(md, desc)
+
+	desc = newDescriptor("claimGas", smartcontract.IntegerType,
+		manifest.NewParameter("account", smartcontract.Hash160Type))
+	md = newMethodAndPrice(n.claimGas, 1<<17, callflag.States|callflag.AllowCall|callflag.AllowNotify, config.HFNeoGoExtensions)
+	n.AddMethod(md, desc)
+
 	desc = newDescriptor("registerCandidate", smartcontract.BoolType,
 		manifest.NewParameter("pubkey", smartcontract.PublicKeyType))
 	md = newMethodAndPrice(n.registerCandidate, 0, callflag.States)
@@ -621,6 +636,96 @@ func (n *NEO) unclaimedGas(ic *interop.Context, args []stackitem.Item) stackitem
 	return stackitem.NewBigInteger(gen)
 }
 
+func (n *NEO) unclaimedGasDetailed(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	acc := n.getNEOBalance(ic.DAO, toUint160(args[0]))
+	if acc == nil {
+		return stackitem.Null{}
+	}
+	r, v, err := n.calculateBonusParts(ic.DAO, acc, ic.Block.Index)
+	if err != nil {
+		panic(err)
+	}
+	item, _ := (&state.NEOUnclaimedGAS{
+		HolderReward:    *r,
+		VoterReward:     *v,
+		LastClaimHeight: acc.BalanceHeight,
+	}).ToStackItem()
+	return item
+}
+
+func (n *NEO) claimGas(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	gen, err := n.ClaimGasInternal(ic, toUint160(args[0]))
+	if err != nil {
+		panic(err)
+	}
+	return stackitem.NewBigInteger(gen)
+}
+
+// ClaimGasInternal distributes GAS generated by the account's NEO the same way
+// a self-transfer of 0 NEO does, but without NEO Transfer notification and
+// onNEP17Payment call. It returns the amount of GAS minted to the account.
+func (n *NEO) ClaimGasInternal(ic *interop.Context, h util.Uint160) (*big.Int, error) {
+	caller := ic.VM.GetCallingScriptHash()
+	if caller.Equals(util.Uint160{}) || !h.Equals(caller) {
+		ok, err := runtime.CheckHashedWitness(ic, h)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, errors.New("invalid signature")
+		}
+	}
+	key := makeAccountKey(h)
+	si := ic.DAO.GetStorageItem(n.ID, key)
+	if si == nil {
+		return big.NewInt(0), nil
+	}
+	acc, err := state.NEOBalanceFromBytes(si)
+	if err != nil {
+		return nil, err
+	}
+	newGas, err := n.distributeGas(ic, acc)
+	if err != nil {
+		return nil, err
+	}
+	ic.DAO.PutStorageItem(n.ID, key, acc.Bytes(ic.DAO.GetItemCtx()))
+	if newGas == nil { // Can be if it was already distributed in the same block.
+		return big.NewInt(0), nil
+	}
+	n.GAS.mint(ic, h, newGas, true)
+	return newGas, nil
+}
+
+func (n *NEO) getVoterInfo(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	acc := n.getNEOBalance(ic.DAO, toUint160(args[0]))
+	if acc == nil {
+		return stackitem.Null{}
+	}
+	info := &state.NEOVoterInfo{
+		VoteTo:         acc.VoteTo,
+		BalanceHeight:  acc.BalanceHeight,
+		LastGasPerVote: acc.LastGasPerVote,
+	}
+	if acc.VoteTo != nil {
+		info.LatestGasPerVote = n.getLatestGASPerVote(ic.DAO, makeVoterKey(acc.VoteTo.Bytes()))
+	}
+	item, _ := info.ToStackItem()
+	return item
+}
+
+// getNEOBalance returns NEO balance state of the account or nil if it has no
+// NEO.
+func (n *NEO) getNEOBalance(d *dao.Simple, h util.Uint160) *state.NEOBalance {
+	si := d.GetStorageItem(n.ID, makeAccountKey(h))
+	if len(si) == 0 {
+		return nil
+	}
+	acc, err := state.NEOBalanceFromBytes(si)
+	if err != nil {
+		panic(err) // no errors are expected but we better be sure
+	}
+	return acc
+}
+
 func (n *NEO) getGASPerBlock(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
 	gas := n.GetGASPerBlock(ic.DAO, ic.Block.Index)
 	return stackitem.NewBigInteger(gas)
@@ -759,9 +864,19 @@ func (n *NEO) CalculateBonus(ic *interop.Context, acc util.Uint160, end uint32)
 }
 
 func (n *NEO) calculateBonus(d *dao.Simple, acc *state.NEOBalance, end uint32) (*big.Int, error) {
+	r, v, err := n.calculateBonusParts(d, acc, end)
+	if err != nil {
+		return nil, err
+	}
+	return r.Add(r, v), nil
+}
+
+// calculateBonusParts returns GAS generated for holding NEO and GAS generated
+// for voting separately.
+func (n *NEO) calculateBonusParts(d *dao.Simple, acc *state.NEOBalance, end uint32) (*big.Int, *big.Int, error) {
 	r, err := n.CalculateNEOHolderReward(d, &acc.Balance, acc.BalanceHeight, end)
 	if err != nil || acc.VoteTo == nil {
-		return r, err
+		return r, big.NewInt(0), err
 	}
 
 	var key = makeVoterKey(acc.VoteTo.Bytes())
@@ -769,8 +884,7 @@ func (n *NEO) calculateBonus(d *dao.Simple, acc *state.NEOBalance, end uint32) (
 	var tmp = big.NewInt(0).Sub(&reward, &acc.LastGasPerVote)
 	tmp.Mul(tmp, &acc.Balance)
 	tmp.Div(tmp, bigVoterRewardFactor)
-	tmp.Add(tmp, r)
-	return tmp, nil
+	return r, tmp, nil
 }
 
 // CalculateNEOHolderReward return GAS reward for holding `value` of NEO from start to end block.
@@ -6,6 +6,7 @@ import (
 	"math"
 	"math/big"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/contract"
@@ -35,6 +36,10 @@ type nep17TokenNative struct {
 	factor       int64
 	incBalance   func(*interop.Context, util.Uint160, *state.StorageItem, *big.Int, *big.Int) (func(), error)
 	balFromBytes func(item *state.StorageItem) (*big.Int, error)
+	// transferFee, if set, returns the part of the transferred amount that
+	// should be burnt instead of being credited to the recipient. Contracts
+	// setting it must also declare Burn event.
+	transferFee func(amount *big.Int) *big.Int
 }
 
 // totalSupplyKey is the key used to store totalSupply value.
@@ -171,6 +176,14 @@ func (c *nep17TokenNative) emitTransfer(ic *interop.Context, from, to *util.Uint
 	}))
 }
 
+// emitBurn emits Burn event for the transfer fee burnt from the given account.
+func (c *nep17TokenNative) emitBurn(ic *interop.Context, from util.Uint160, amount *big.Int) {
+	ic.AddNotification(c.Hash, "Burn", stackitem.NewArray([]stackitem.Item{
+		stackitem.NewByteArray(from.BytesBE()),
+		stackitem.NewBigInteger(amount),
+	}))
+}
+
 // updateAccBalance adds the specified amount to the acc's balance. If requiredBalance
 // is set and amount is 0, the acc's balance is checked against requiredBalance.
 func (c *nep17TokenNative) updateAccBalance(ic *interop.Context, acc util.Uint160, amount *big.Int, requiredBalance *big.Int) (func(), error) {
@@ -235,6 +248,14 @@ func (c *nep17TokenNative) TransferInternal(ic *interop.Context, from, to util.U
 		return err
 	}
 
+	var fee *big.Int
+	if !isEmpty && c.transferFee != nil {
+		fee = c.transferFee(amount)
+		if fee.Sign() != 0 {
+			amount = new(big.Int).Sub(amount, fee)
+		}
+	}
+
 	if !isEmpty {
 		postF2, err = c.updateAccBalance(ic, to, amount, nil)
 		if err != nil {
@@ -242,6 +263,14 @@ func (c *nep17TokenNative) TransferInternal(ic *interop.Context, from, to util.U
 		}
 	}
 
+	if fee != nil && fee.Sign() != 0 {
+		buf, supply := c.getTotalSupply(ic.DAO)
+		supply.Sub(supply, fee)
+		c.saveTotalSupply(ic.DAO, buf, supply)
+		c.emitTransfer(ic, &from, nil, fee)
+		c.emitBurn(ic, from, fee)
+	}
+
 	c.postTransfer(ic, &from, &to, amount, data, true, postF1, postF2)
 	return nil
 }
@@ -319,12 +348,18 @@ func newDescriptor(name string, ret smartcontract.ParamType, ps ...manifest.Para
 	}
 }
 
-func newMethodAndPrice(f interop.Method, cpuFee int64, flags callflag.CallFlag) *interop.MethodAndPrice {
-	return &interop.MethodAndPrice{
+// newMethodAndPrice creates a native method descriptor, the method is only
+// available since the hardfork specified as the last argument (if any).
+func newMethodAndPrice(f interop.Method, cpuFee int64, flags callflag.CallFlag, activeFrom ...config.Hardfork) *interop.MethodAndPrice {
+	md := &interop.MethodAndPrice{
 		Func:          f,
 		CPUFee:        cpuFee,
 		RequiredFlags: flags,
 	}
+	if len(activeFrom) != 0 {
+		md.ActiveFrom = &activeFrom[0]
+	}
+	return md
 }
 
 func toBigInt(s stackitem.Item) *big.Int {
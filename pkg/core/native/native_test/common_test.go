@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -18,8 +19,14 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// withExtensions enables NeoGoExtensions hardfork from genesis, native
+// contracts are tested with all of their methods available.
+func withExtensions(cfg *config.Blockchain) {
+	cfg.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+}
+
 func newNativeClient(t *testing.T, name string) *neotest.ContractInvoker {
-	bc, acc := chain.NewSingle(t)
+	bc, acc := chain.NewSingleWithCustomConfig(t, withExtensions)
 	e := neotest.NewExecutor(t, bc, acc, acc)
 
 	return e.CommitteeInvoker(e.NativeHash(t, name))
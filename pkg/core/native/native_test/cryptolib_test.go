@@ -1,6 +1,7 @@
 package native_test
 
 import (
+	"bytes"
 	"encoding/hex"
 	"strconv"
 	"strings"
@@ -8,11 +9,16 @@ import (
 
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -470,3 +476,66 @@ func TestVerifyGroth16Proof(t *testing.T) {
 	// Verify.
 	validatorInvoker.Invoke(t, true, "verifyProof", argA, argB, argC, []interface{}{publicWitness})
 }
+
+func TestCryptolib_Sha256Streaming(t *testing.T) {
+	c := newCryptolibClient(t)
+	e := c.Executor
+
+	src := `package hasher
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/crypto"
+		)
+		func HashRepeated(chunk []byte, n int) interop.Hash256 {
+			s := crypto.Sha256Init()
+			for i := 0; i < n; i++ {
+				crypto.Sha256Update(s, chunk)
+			}
+			return crypto.Sha256Final(s)
+		}
+		func UpdateFinalized() {
+			s := crypto.Sha256Init()
+			crypto.Sha256Final(s)
+			crypto.Sha256Update(s, []byte{1})
+		}`
+	ctr := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+		Name: "hasher",
+	})
+	e.DeployContract(t, ctr, nil)
+	inv := e.CommitteeInvoker(ctr.Hash)
+
+	// Chunks fit into a transaction script, while the total hashed data size
+	// is bigger than any single stack item can be.
+	chunk := random.Bytes(1 << 15)
+	n := stackitem.MaxSize/len(chunk) + 1
+	require.Greater(t, n*len(chunk), stackitem.MaxSize)
+	h1 := inv.Invoke(t, hash.Sha256(bytes.Repeat(chunk, n)).BytesBE(), "hashRepeated", chunk, n)
+	h2 := inv.Invoke(t, hash.Sha256(bytes.Repeat(chunk, n+1)).BytesBE(), "hashRepeated", chunk, n+1)
+
+	// The price depends on the hashed data size.
+	aer1 := e.GetTxExecResult(t, h1)
+	aer2 := e.GetTxExecResult(t, h2)
+	require.Greater(t, aer2.GasConsumed-aer1.GasConsumed, int64(len(chunk))*e.Chain.GetBaseExecFee())
+
+	inv.InvokeFail(t, "sha256 state is already finalized", "updateFinalized")
+}
+
+func TestCryptolib_MerkleRoot(t *testing.T) {
+	c := newCryptolibClient(t)
+	e := c.Executor
+
+	txs := make([]*transaction.Transaction, 3)
+	for i := range txs {
+		txs[i] = e.NewUnsignedTx(t, e.NativeHash(t, nativenames.Neo), "symbol")
+		e.SignTx(t, txs[i], -1, e.Committee)
+	}
+	b := e.AddNewBlock(t, txs...)
+
+	hashes := make([]any, len(b.Transactions))
+	for i, tx := range b.Transactions {
+		hashes[i] = tx.Hash().BytesBE()
+	}
+	c.Invoke(t, b.MerkleRoot.BytesBE(), "merkleRoot", hashes)
+	c.Invoke(t, make([]byte, util.Uint256Size), "merkleRoot", []any{})
+	c.InvokeFail(t, "invalid hash #1", "merkleRoot", []any{hashes[0], []byte{1, 2, 3}})
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
@@ -56,6 +57,35 @@ func TestDesignate_DesignateAsRole(t *testing.T) {
 	})
 }
 
+func TestDesignate_DesignateAsRoleLargeCommittee(t *testing.T) {
+	bc, validators, committee := chain.NewMultiWithCount(t, 7, 7)
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	c := e.CommitteeInvoker(e.NativeHash(t, nativenames.Designation))
+	e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas)).Invoke(t, true, "transfer",
+		validators.ScriptHash(), committee.ScriptHash(), 1000_0000_0000, nil)
+
+	// Committee majority of 7 is 4, validators need 5 signatures and have a
+	// different multisignature account.
+	require.NotEqual(t, validators.ScriptHash(), committee.ScriptHash())
+	c.WithSigners(validators).InvokeFail(t, native.ErrInvalidWitness.Error(), "designateAsRole",
+		int64(noderoles.Oracle), []any{validators.(neotest.MultiSigner).Single(0).Account().PublicKey().Bytes()})
+
+	for _, subset := range [][]int{{0, 1, 2, 3}, {3, 4, 5, 6}, {0, 2, 4, 6}} {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		pubs := keys.PublicKeys{priv.PublicKey()}
+		setNodesByRole(t, c.WithSigners(e.CommitteeSubset(subset...)), true, noderoles.Oracle, pubs)
+		checkNodeRoles(t, c, true, noderoles.Oracle, e.Chain.BlockHeight()+1, pubs)
+	}
+	require.Panics(t, func() { e.CommitteeSubset(0, 1, 2) })
+
+	// Policy changes use the same committee check.
+	p := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+	p.WithSigners(e.CommitteeSubset(6, 5, 4, 3)).Invoke(t, stackitem.Null{}, "setFeePerByte", 100500)
+	p.Invoke(t, 100500, "getFeePerByte")
+	p.WithSigners(validators).InvokeFail(t, "invalid committee signature", "setFeePerByte", 1)
+}
+
 type dummyOracle struct {
 	updateNodes func(k keys.PublicKeys)
 }
@@ -69,8 +99,13 @@ func (o *dummyOracle) RemoveRequests([]uint64) {
 	panic("TODO")
 }
 
+// CancelRequests removes cancelled requests.
+func (o *dummyOracle) CancelRequests([]uint64) {
+	panic("TODO")
+}
+
 // UpdateOracleNodes updates oracle nodes.
-func (o *dummyOracle) UpdateOracleNodes(k keys.PublicKeys) {
+func (o *dummyOracle) UpdateOracleNodes(_ uint32, k keys.PublicKeys) {
 	if o.updateNodes != nil {
 		o.updateNodes(k)
 		return
@@ -78,8 +113,9 @@ func (o *dummyOracle) UpdateOracleNodes(k keys.PublicKeys) {
 	panic("TODO")
 }
 
-// UpdateNativeContract updates oracle contract native script and hash.
-func (o *dummyOracle) UpdateNativeContract([]byte, []byte, util.Uint160, int) {
+// UpdateNativeContract updates oracle response script and native oracle
+// contract hash.
+func (o *dummyOracle) UpdateNativeContract([]byte, util.Uint160) {
 }
 
 // Start runs oracle module.
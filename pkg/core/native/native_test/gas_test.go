@@ -7,6 +7,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
@@ -138,3 +139,102 @@ func TestGAS_RewardWithP2PSigExtensionsEnabled(t *testing.T) {
 	tsExpected := tsInitial + 5000_0000 - tx.SystemFee
 	require.Equal(t, tsExpected, tsUpdated)
 }
+
+func TestGAS_TransferBurnRate(t *testing.T) {
+	const (
+		burnRate = 150 // 1.5%
+		// committeeReward is the amount of GAS minted to the committee
+		// member every block with the default GasPerBlock setting.
+		committeeReward = 5000_0000
+	)
+
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(cfg *config.Blockchain) {
+		cfg.Genesis.TransferBurnRate = burnRate
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	gasHash := e.NativeHash(t, nativenames.Gas)
+	gasValidatorInvoker := e.ValidatorInvoker(gasHash)
+
+	getGASTS := func(t *testing.T) int64 {
+		stack, err := gasValidatorInvoker.TestInvoke(t, "totalSupply")
+		require.NoError(t, err)
+		return stack.Pop().Value().(*big.Int).Int64()
+	}
+
+	acc := e.NewAccount(t, 0).ScriptHash()
+	from := e.Validator.ScriptHash()
+
+	t.Run("manifest", func(t *testing.T) {
+		require.NotNil(t, e.Chain.GetContractState(gasHash).Manifest.ABI.GetEvent("Burn"))
+
+		bc, _ := chain.NewSingle(t)
+		require.Nil(t, bc.GetContractState(gasHash).Manifest.ABI.GetEvent("Burn"))
+	})
+
+	t.Run("regular transfer", func(t *testing.T) {
+		const amount = 1000_0000_0000
+		burnt := int64(amount * burnRate / config.MaxTransferBurnRate)
+
+		tsInitial := getGASTS(t)
+		accInitial := e.Chain.GetUtilityTokenBalance(acc).Int64()
+		h := gasValidatorInvoker.Invoke(t, true, "transfer", from, acc, amount, nil)
+		tx, _ := e.GetTransaction(t, h)
+
+		e.CheckGASBalance(t, acc, big.NewInt(accInitial+amount-burnt))
+		// Fees are burnt and the network fee is minted back to the primary,
+		// so the only remaining supply changes are system fee, committee
+		// reward and transfer burn.
+		require.Equal(t, tsInitial+committeeReward-tx.SystemFee-burnt, getGASTS(t))
+
+		aer := e.GetTxExecResult(t, h)
+		require.Equal(t, 3, len(aer.Events))
+		e.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+			ScriptHash: gasHash,
+			Name:       "Transfer",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(from.BytesBE()),
+				stackitem.Null{},
+				stackitem.Make(burnt),
+			}),
+		})
+		e.CheckTxNotificationEvent(t, h, 1, state.NotificationEvent{
+			ScriptHash: gasHash,
+			Name:       "Burn",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(from.BytesBE()),
+				stackitem.Make(burnt),
+			}),
+		})
+		e.CheckTxNotificationEvent(t, h, 2, state.NotificationEvent{
+			ScriptHash: gasHash,
+			Name:       "Transfer",
+			Item: stackitem.NewArray([]stackitem.Item{
+				stackitem.NewByteArray(from.BytesBE()),
+				stackitem.NewByteArray(acc.BytesBE()),
+				stackitem.Make(amount - burnt),
+			}),
+		})
+	})
+
+	t.Run("amount below burn precision", func(t *testing.T) {
+		const amount = 66 // 66 * 150 / 10000 == 0
+
+		tsInitial := getGASTS(t)
+		accInitial := e.Chain.GetUtilityTokenBalance(acc).Int64()
+		h := gasValidatorInvoker.Invoke(t, true, "transfer", from, acc, amount, nil)
+		tx, _ := e.GetTransaction(t, h)
+
+		e.CheckGASBalance(t, acc, big.NewInt(accInitial+amount))
+		require.Equal(t, tsInitial+committeeReward-tx.SystemFee, getGASTS(t))
+		require.Equal(t, 1, len(e.GetTxExecResult(t, h).Events))
+	})
+
+	t.Run("self-transfer", func(t *testing.T) {
+		tsInitial := getGASTS(t)
+		h := gasValidatorInvoker.Invoke(t, true, "transfer", from, from, 1000_0000_0000, nil)
+		tx, _ := e.GetTransaction(t, h)
+
+		require.Equal(t, tsInitial+committeeReward-tx.SystemFee, getGASTS(t))
+		require.Equal(t, 1, len(e.GetTxExecResult(t, h).Events))
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/contracts"
 	"github.com/nspcc-dev/neo-go/pkg/core/chaindump"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	istorage "github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
@@ -23,6 +24,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -592,6 +594,26 @@ func TestManagement_GetContract(t *testing.T) {
 		h := managementInvoker.InvokeScript(t, w.Bytes(), managementInvoker.Signers)
 		managementInvoker.Executor.CheckHalt(t, h, stackitem.NewStruct([]stackitem.Item{stackitem.Make([]byte{0, 0, 0, 1}), stackitem.Make(cs1.Hash.BytesBE())}))
 	})
+	t.Run("contracts iterator", func(t *testing.T) {
+		stack, err := managementInvoker.TestInvoke(t, "getContractsIterator")
+		require.NoError(t, err)
+		iter := stack.Pop().Interop().Value().(*istorage.Iterator)
+		var hashes []util.Uint160
+		for iter.Next() {
+			cs := new(state.Contract)
+			require.NoError(t, cs.FromStackItem(iter.Value()))
+			expected, err := managementInvoker.Chain.GetContractState(cs.Hash).ToStackItem()
+			require.NoError(t, err)
+			require.Equal(t, expected, iter.Value())
+			hashes = append(hashes, cs.Hash)
+		}
+		// All native contracts and the deployed one, ordered by hash.
+		require.Equal(t, len(managementInvoker.Chain.GetNatives())+1, len(hashes))
+		require.Contains(t, hashes, cs1.Hash)
+		for i := 1; i < len(hashes); i++ {
+			require.Equal(t, -1, bytes.Compare(hashes[i-1].BytesBE(), hashes[i].BytesBE()))
+		}
+	})
 }
 
 func TestManagement_ContractDestroy(t *testing.T) {
@@ -35,7 +35,7 @@ import (
 )
 
 func newNeoCommitteeClient(t *testing.T, expectedGASBalance int) *neotest.ContractInvoker {
-	bc, validators, committee := chain.NewMulti(t)
+	bc, validators, committee := chain.NewMultiWithCustomConfig(t, withExtensions)
 	e := neotest.NewExecutor(t, bc, validators, committee)
 
 	if expectedGASBalance > 0 {
@@ -533,6 +533,286 @@ func TestNEO_GetAccountStateInteropAPI(t *testing.T) {
 	ctrInvoker.Invoke(t, stackitem.Make(expect), "getLastGasPerVote")
 }
 
+func TestNEO_UnclaimedGasDetailedAndVoterInfo(t *testing.T) {
+	neoCommitteeInvoker := newNeoCommitteeClient(t, 100_0000_0000)
+	neoValidatorsInvoker := neoCommitteeInvoker.WithSigners(neoCommitteeInvoker.Validator)
+	e := neoCommitteeInvoker.Executor
+
+	cfg := e.Chain.GetConfig()
+	committeeSize := cfg.GetCommitteeSize(0)
+	advanceChain := func(t *testing.T) {
+		for i := 0; i < committeeSize+cfg.GetNumOfCNs(0); i++ {
+			neoCommitteeInvoker.AddNewBlock(t)
+		}
+	}
+	getDetails := func(t *testing.T, acc util.Uint160) *state.NEOUnclaimedGAS {
+		stack, err := neoCommitteeInvoker.TestInvoke(t, "unclaimedGasDetailed", acc)
+		require.NoError(t, err)
+		res := new(state.NEOUnclaimedGAS)
+		require.NoError(t, res.FromStackItem(stack.Pop().Item()))
+
+		// The sum must match the regular unclaimedGas.
+		stack, err = neoCommitteeInvoker.TestInvoke(t, "unclaimedGas", acc, e.Chain.BlockHeight()+1)
+		require.NoError(t, err)
+		total := new(big.Int).Add(&res.HolderReward, &res.VoterReward)
+		require.Equal(t, stack.Pop().BigInt(), total)
+		return res
+	}
+	getVoterInfo := func(t *testing.T, acc util.Uint160) *state.NEOVoterInfo {
+		stack, err := neoCommitteeInvoker.TestInvoke(t, "getVoterInfo", acc)
+		require.NoError(t, err)
+		res := new(state.NEOVoterInfo)
+		require.NoError(t, res.FromStackItem(stack.Pop().Item()))
+		return res
+	}
+
+	t.Run("no NEO", func(t *testing.T) {
+		neoCommitteeInvoker.Invoke(t, stackitem.Null{}, "unclaimedGasDetailed", util.Uint160{})
+		neoCommitteeInvoker.Invoke(t, stackitem.Null{}, "getVoterInfo", util.Uint160{})
+	})
+
+	// Vote candidates into the committee, the last one has the least
+	// number of votes.
+	voters := make([]neotest.Signer, committeeSize)
+	candidates := make([]neotest.Signer, committeeSize+1)
+	for i := range candidates {
+		candidates[i] = e.NewAccount(t, 2000_0000_0000) // enough for one registration
+	}
+	for i := range voters {
+		voters[i] = e.NewAccount(t, 10_0000_0000)
+	}
+	acc := e.NewAccount(t, 10_0000_0000)
+	var txes []*transaction.Transaction
+	for i := range voters {
+		txes = append(txes,
+			neoValidatorsInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), voters[i].ScriptHash(), int64(committeeSize+1-i)*1000000, nil),
+			neoValidatorsInvoker.WithSigners(candidates[i]).PrepareInvoke(t, "registerCandidate", candidates[i].(neotest.SingleSigner).Account().PublicKey().Bytes()),
+			neoValidatorsInvoker.WithSigners(voters[i]).PrepareInvoke(t, "vote", voters[i].ScriptHash(), candidates[i].(neotest.SingleSigner).Account().PublicKey().Bytes()))
+	}
+	last := candidates[committeeSize-1].(neotest.SingleSigner).Account().PublicKey()
+	txes = append(txes,
+		neoValidatorsInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), acc.ScriptHash(), 1000, nil),
+		neoValidatorsInvoker.WithSigners(acc).PrepareInvoke(t, "vote", acc.ScriptHash(), last.Bytes()))
+	neoValidatorsInvoker.AddNewBlock(t, txes...)
+	for _, tx := range txes {
+		e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+	}
+	voteHeight := e.Chain.BlockHeight()
+
+	info := getVoterInfo(t, acc.ScriptHash())
+	require.Equal(t, last, info.VoteTo)
+	require.Equal(t, voteHeight, info.BalanceHeight)
+	require.Equal(t, int64(0), info.LastGasPerVote.Int64())
+	require.Equal(t, int64(0), info.LatestGasPerVote.Int64())
+
+	advanceChain(t)
+	committee, err := e.Chain.GetCommittee()
+	require.NoError(t, err)
+	require.Contains(t, committee, last)
+
+	details := getDetails(t, acc.ScriptHash())
+	require.Equal(t, voteHeight, details.LastClaimHeight)
+	require.Equal(t, 1, details.HolderReward.Sign())
+	require.Equal(t, 1, details.VoterReward.Sign())
+	info = getVoterInfo(t, acc.ScriptHash())
+	require.Equal(t, 1, info.LatestGasPerVote.Cmp(&info.LastGasPerVote))
+
+	// Outvote the last committee member.
+	outvoter := e.NewAccount(t, 10_0000_0000)
+	outsider := candidates[committeeSize].(neotest.SingleSigner).Account().PublicKey()
+	txes = []*transaction.Transaction{
+		neoValidatorsInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), outvoter.ScriptHash(), 3000000, nil),
+		neoValidatorsInvoker.WithSigners(candidates[committeeSize]).PrepareInvoke(t, "registerCandidate", outsider.Bytes()),
+		neoValidatorsInvoker.WithSigners(outvoter).PrepareInvoke(t, "vote", outvoter.ScriptHash(), outsider.Bytes()),
+	}
+	neoValidatorsInvoker.AddNewBlock(t, txes...)
+	for _, tx := range txes {
+		e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+	}
+	advanceChain(t)
+	committee, err = e.Chain.GetCommittee()
+	require.NoError(t, err)
+	require.NotContains(t, committee, last)
+	require.Contains(t, committee, outsider)
+
+	// Voter reward doesn't grow after the candidate leaves the committee
+	// while NEO holder reward does.
+	before := getDetails(t, acc.ScriptHash())
+	infoBefore := getVoterInfo(t, acc.ScriptHash())
+	advanceChain(t)
+	after := getDetails(t, acc.ScriptHash())
+	require.Equal(t, before.VoterReward, after.VoterReward)
+	require.Equal(t, 1, after.HolderReward.Cmp(&before.HolderReward))
+	require.Equal(t, infoBefore, getVoterInfo(t, acc.ScriptHash()))
+	require.Equal(t, 1, getDetails(t, outvoter.ScriptHash()).VoterReward.Sign())
+
+	// Claim resets the reward and moves the checkpoint.
+	neoValidatorsInvoker.WithSigners(acc).Invoke(t, true, "transfer", acc.ScriptHash(), acc.ScriptHash(), 0, nil)
+	details = getDetails(t, acc.ScriptHash())
+	require.Equal(t, e.Chain.BlockHeight(), details.LastClaimHeight)
+	require.Equal(t, int64(0), details.VoterReward.Int64())
+	info = getVoterInfo(t, acc.ScriptHash())
+	require.Equal(t, e.Chain.BlockHeight(), info.BalanceHeight)
+	require.Equal(t, infoBefore.LatestGasPerVote, info.LastGasPerVote)
+	require.Equal(t, info.LastGasPerVote, info.LatestGasPerVote)
+
+	// Unvoting resets the candidate.
+	neoValidatorsInvoker.WithSigners(acc).Invoke(t, true, "vote", acc.ScriptHash(), nil)
+	info = getVoterInfo(t, acc.ScriptHash())
+	require.Nil(t, info.VoteTo)
+	require.Equal(t, int64(0), info.LatestGasPerVote.Int64())
+}
+
+func TestNEO_ClaimGas(t *testing.T) {
+	neoCommitteeInvoker := newNeoCommitteeClient(t, 100_0000_0000)
+	neoValidatorsInvoker := neoCommitteeInvoker.WithSigners(neoCommitteeInvoker.Validator)
+	e := neoCommitteeInvoker.Executor
+	gasHash := e.NativeHash(t, nativenames.Gas)
+
+	cfg := e.Chain.GetConfig()
+	committeeSize := cfg.GetCommitteeSize(0)
+	// gasMinted returns the amount of GAS minted to acc by the transaction.
+	gasMinted := func(t *testing.T, aer *state.AppExecResult, acc util.Uint160) *big.Int {
+		res := big.NewInt(0)
+		for _, ev := range aer.Events {
+			if ev.ScriptHash != gasHash || ev.Name != "Transfer" {
+				continue
+			}
+			arr := ev.Item.Value().([]stackitem.Item)
+			if _, ok := arr[0].(stackitem.Null); !ok {
+				continue
+			}
+			to, err := arr[1].TryBytes()
+			require.NoError(t, err)
+			if bytes.Equal(to, acc.BytesBE()) {
+				res.Add(res, arr[2].Value().(*big.Int))
+			}
+		}
+		return res
+	}
+	checkNoNEOTransfer := func(t *testing.T, aer *state.AppExecResult) {
+		for _, ev := range aer.Events {
+			require.False(t, ev.ScriptHash == neoCommitteeInvoker.Hash && ev.Name == "Transfer")
+		}
+	}
+
+	t.Run("no NEO", func(t *testing.T) {
+		acc := e.NewAccount(t)
+		h := neoValidatorsInvoker.WithSigners(acc).Invoke(t, 0, "claimGas", acc.ScriptHash())
+		require.Equal(t, 0, gasMinted(t, e.GetTxExecResult(t, h), acc.ScriptHash()).Sign())
+	})
+	t.Run("no witness", func(t *testing.T) {
+		acc := e.NewAccount(t)
+		neoValidatorsInvoker.WithSigners(acc).InvokeFail(t, "invalid signature", "claimGas", e.Validator.ScriptHash())
+	})
+
+	// Transferred and voting in the same block, a and b have exactly the
+	// same NEO state, so they must get the same GAS no matter whether it's
+	// claimed via claimGas or via self-transfer.
+	a, b := e.NewAccount(t), e.NewAccount(t)
+	committee, err := e.Chain.GetCommittee()
+	require.NoError(t, err)
+	neoValidatorsInvoker.WithSigners(e.Validator, e.Validator.(neotest.MultiSigner).Single(0)).Invoke(t, true, "registerCandidate", committee[0].Bytes())
+	var txes []*transaction.Transaction
+	for _, acc := range []neotest.Signer{a, b} {
+		txes = append(txes,
+			neoValidatorsInvoker.PrepareInvoke(t, "transfer", e.Validator.ScriptHash(), acc.ScriptHash(), 1000, nil),
+			neoValidatorsInvoker.WithSigners(acc).PrepareInvoke(t, "vote", acc.ScriptHash(), committee[0].Bytes()))
+	}
+	neoValidatorsInvoker.AddNewBlock(t, txes...)
+	for _, tx := range txes {
+		e.CheckHalt(t, tx.Hash(), stackitem.Make(true))
+	}
+
+	for i, period := range []struct {
+		blocks      int
+		gasPerBlock int64
+	}{
+		{committeeSize, 0},
+		{2*committeeSize + 1, 0},
+		{committeeSize, 3 * native.GASFactor},
+		{1, 0},
+	} {
+		if period.gasPerBlock != 0 {
+			neoCommitteeInvoker.Invoke(t, stackitem.Null{}, "setGasPerBlock", period.gasPerBlock)
+		}
+		for j := 0; j < period.blocks; j++ {
+			neoCommitteeInvoker.AddNewBlock(t)
+		}
+		stack, err := neoCommitteeInvoker.TestInvoke(t, "unclaimedGas", b.ScriptHash(), e.Chain.BlockHeight()+1)
+		require.NoError(t, err)
+		expected := stack.Pop().BigInt()
+		require.Equal(t, 1, expected.Sign(), i)
+
+		txTransfer := neoValidatorsInvoker.WithSigners(a).PrepareInvoke(t, "transfer", a.ScriptHash(), a.ScriptHash(), 0, nil)
+		txClaim := neoValidatorsInvoker.WithSigners(b).PrepareInvoke(t, "claimGas", b.ScriptHash())
+		neoValidatorsInvoker.AddNewBlock(t, txTransfer, txClaim)
+		aerTransfer := e.CheckHalt(t, txTransfer.Hash(), stackitem.Make(true))
+		aerClaim := e.CheckHalt(t, txClaim.Hash(), stackitem.Make(expected))
+
+		require.Equal(t, expected, gasMinted(t, aerTransfer, a.ScriptHash()), i)
+		require.Equal(t, expected, gasMinted(t, aerClaim, b.ScriptHash()), i)
+		require.Equal(t, 1, len(aerClaim.Events), i)
+		checkNoNEOTransfer(t, aerClaim)
+
+		// Both accounts are in the same state after the claim.
+		stateA, err := neoCommitteeInvoker.TestInvoke(t, "getAccountState", a.ScriptHash())
+		require.NoError(t, err)
+		stateB, err := neoCommitteeInvoker.TestInvoke(t, "getAccountState", b.ScriptHash())
+		require.NoError(t, err)
+		require.Equal(t, stateA.Pop().Item(), stateB.Pop().Item(), i)
+	}
+
+	// GAS is only distributed once per block.
+	neoCommitteeInvoker.AddNewBlock(t)
+	tx1 := neoValidatorsInvoker.WithSigners(b).PrepareInvoke(t, "claimGas", b.ScriptHash())
+	tx2 := neoValidatorsInvoker.WithSigners(b).PrepareInvoke(t, "claimGas", b.ScriptHash())
+	neoValidatorsInvoker.AddNewBlock(t, tx1, tx2)
+	aer := e.CheckHalt(t, tx1.Hash())
+	require.Equal(t, 1, aer.Stack[0].Value().(*big.Int).Sign())
+	e.CheckHalt(t, tx2.Hash(), stackitem.Make(0))
+
+	t.Run("contract", func(t *testing.T) {
+		src := `package claimer
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop"
+			"github.com/nspcc-dev/neo-go/pkg/interop/native/neo"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func OnNEP17Payment(from interop.Hash160, amount int, data any) {}
+		func Claim() int {
+			return neo.ClaimGAS(runtime.GetExecutingScriptHash())
+		}
+		func ClaimFor(acc interop.Hash160) int {
+			return neo.ClaimGAS(acc)
+		}`
+		ctr := neotest.CompileSource(t, e.Validator.ScriptHash(), strings.NewReader(src), &compiler.Options{
+			Name: "claimer_contract",
+		})
+		e.DeployContract(t, ctr, nil)
+		neoValidatorsInvoker.Invoke(t, true, "transfer", e.Validator.ScriptHash(), ctr.Hash, 1000, nil)
+		for i := 0; i < committeeSize; i++ {
+			neoCommitteeInvoker.AddNewBlock(t)
+		}
+		stack, err := neoCommitteeInvoker.TestInvoke(t, "unclaimedGas", ctr.Hash, e.Chain.BlockHeight()+1)
+		require.NoError(t, err)
+		expected := stack.Pop().BigInt()
+		require.Equal(t, 1, expected.Sign())
+
+		ctrInvoker := e.NewInvoker(ctr.Hash, a)
+		h := ctrInvoker.Invoke(t, expected, "claim")
+		aer := e.GetTxExecResult(t, h)
+		require.Equal(t, expected, gasMinted(t, aer, ctr.Hash))
+		checkNoNEOTransfer(t, aer)
+
+		// Contract can't claim GAS for accounts not witnessing the call.
+		ctrInvoker.InvokeFail(t, "invalid signature", "claimFor", b.ScriptHash())
+		ctrInvoker.WithSigners(b).InvokeAndCheck(t, func(t testing.TB, stack []stackitem.Item) {
+			require.Equal(t, 1, stack[0].Value().(*big.Int).Sign())
+		}, "claimFor", b.ScriptHash())
+	})
+}
+
 func TestNEO_CommitteeBountyOnPersist(t *testing.T) {
 	neoCommitteeInvoker := newNeoCommitteeClient(t, 0)
 	e := neoCommitteeInvoker.Executor
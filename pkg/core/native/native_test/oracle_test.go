@@ -2,6 +2,7 @@ package native_test
 
 import (
 	"encoding/json"
+	"errors"
 	"math"
 	"math/big"
 	"path/filepath"
@@ -12,10 +13,20 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
@@ -47,12 +58,65 @@ func putOracleRequest(t *testing.T, oracleInvoker *neotest.ContractInvoker,
 	oracleInvoker.InvokeFail(t, errStr[0], "requestURL", url, filtItem, cb, userData, gas)
 }
 
+// designateOracleNode designates a new single Oracle node and returns its
+// multisignature signer.
+func designateOracleNode(t *testing.T, e *neotest.Executor) neotest.MultiSigner {
+	designationCommitteeInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Designation))
+	gasCommitteeInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Gas))
+
+	oracleNode := e.NewAccount(t)
+	designationCommitteeInvoker.Invoke(t, stackitem.Null{}, "designateAsRole", int(noderoles.Oracle), []any{oracleNode.(neotest.SingleSigner).Account().PublicKey().Bytes()})
+	err := oracleNode.(neotest.SingleSigner).Account().ConvertMultisig(1, []*keys.PublicKey{oracleNode.(neotest.SingleSigner).Account().PublicKey()})
+	require.NoError(t, err)
+	oracleNodeMulti := neotest.NewMultiSigner(oracleNode.(neotest.SingleSigner).Account())
+	gasCommitteeInvoker.Invoke(t, true, "transfer", gasCommitteeInvoker.CommitteeHash, oracleNodeMulti.ScriptHash(), 100_0000_0000, nil)
+	return oracleNodeMulti
+}
+
+// newOracleResponseTx creates a successful response transaction for the
+// specified request signed by the given oracle node.
+func newOracleResponseTx(t *testing.T, e *neotest.Executor, oracleHash util.Uint160, oracleNode neotest.MultiSigner, requestID uint64) *transaction.Transaction {
+	script := native.CreateOracleResponseScript(oracleHash)
+
+	tx := transaction.New(script, 1000_0000)
+	tx.Nonce = neotest.Nonce()
+	tx.ValidUntilBlock = e.Chain.BlockHeight() + 10
+	tx.Attributes = []transaction.Attribute{{
+		Type: transaction.OracleResponseT,
+		Value: &transaction.OracleResponse{
+			ID:     requestID,
+			Code:   transaction.Success,
+			Result: []byte{4, 8, 15, 16, 23, 42},
+		},
+	}}
+	tx.Signers = []transaction.Signer{
+		{
+			Account: oracleNode.ScriptHash(),
+			Scopes:  transaction.None,
+		},
+		{
+			Account: oracleHash,
+			Scopes:  transaction.None,
+		},
+	}
+	tx.NetworkFee = 1000_1234
+	tx.Scripts = []transaction.Witness{
+		{
+			InvocationScript:   oracleNode.SignHashable(uint32(e.Chain.GetConfig().Magic), tx),
+			VerificationScript: oracleNode.Script(),
+		},
+		{
+			InvocationScript:   []byte{},
+			VerificationScript: []byte{},
+		},
+	}
+	return tx
+}
+
 func TestOracle_Request(t *testing.T) {
 	oracleCommitteeInvoker := newOracleClient(t)
 	e := oracleCommitteeInvoker.Executor
 	managementCommitteeInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Management))
-	designationCommitteeInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Designation))
-	gasCommitteeInvoker := e.CommitteeInvoker(e.NativeHash(t, nativenames.Gas))
 
 	cs := contracts.GetOracleContractState(t, pathToInternalContracts, e.Validator.ScriptHash(), 1)
 	nBytes, err := cs.NEF.Bytes()
@@ -70,50 +134,11 @@ func TestOracle_Request(t *testing.T) {
 	putOracleRequest(t, helperValidatorInvoker, "url", &filter, "handle", userData, gasForResponse)
 
 	// Designate single Oracle node.
-	oracleNode := e.NewAccount(t)
-	designationCommitteeInvoker.Invoke(t, stackitem.Null{}, "designateAsRole", int(noderoles.Oracle), []any{oracleNode.(neotest.SingleSigner).Account().PublicKey().Bytes()})
-	err = oracleNode.(neotest.SingleSigner).Account().ConvertMultisig(1, []*keys.PublicKey{oracleNode.(neotest.SingleSigner).Account().PublicKey()})
-	require.NoError(t, err)
-	oracleNodeMulti := neotest.NewMultiSigner(oracleNode.(neotest.SingleSigner).Account())
-	gasCommitteeInvoker.Invoke(t, true, "transfer", gasCommitteeInvoker.CommitteeHash, oracleNodeMulti.ScriptHash(), 100_0000_0000, nil)
+	oracleNodeMulti := designateOracleNode(t, e)
 
 	// Finish.
 	prepareResponseTx := func(t *testing.T, requestID uint64) *transaction.Transaction {
-		script := native.CreateOracleResponseScript(oracleCommitteeInvoker.Hash)
-
-		tx := transaction.New(script, 1000_0000)
-		tx.Nonce = neotest.Nonce()
-		tx.ValidUntilBlock = e.Chain.BlockHeight() + 1
-		tx.Attributes = []transaction.Attribute{{
-			Type: transaction.OracleResponseT,
-			Value: &transaction.OracleResponse{
-				ID:     requestID,
-				Code:   transaction.Success,
-				Result: []byte{4, 8, 15, 16, 23, 42},
-			},
-		}}
-		tx.Signers = []transaction.Signer{
-			{
-				Account: oracleNodeMulti.ScriptHash(),
-				Scopes:  transaction.None,
-			},
-			{
-				Account: oracleCommitteeInvoker.Hash,
-				Scopes:  transaction.None,
-			},
-		}
-		tx.NetworkFee = 1000_1234
-		tx.Scripts = []transaction.Witness{
-			{
-				InvocationScript:   oracleNodeMulti.SignHashable(uint32(e.Chain.GetConfig().Magic), tx),
-				VerificationScript: oracleNodeMulti.Script(),
-			},
-			{
-				InvocationScript:   []byte{},
-				VerificationScript: []byte{},
-			},
-		}
-		return tx
+		return newOracleResponseTx(t, e, oracleCommitteeInvoker.Hash, oracleNodeMulti, requestID)
 	}
 	tx := prepareResponseTx(t, 0)
 	e.AddNewBlock(t, tx)
@@ -177,3 +202,180 @@ func TestOracle_Request(t *testing.T) {
 		})
 	})
 }
+
+// newOracleRequesterContract creates a contract that is able to make and
+// cancel oracle requests and has a callback method doing nothing.
+func newOracleRequesterContract(t *testing.T, e *neotest.Executor, oracleHash util.Uint160) *neotest.Contract {
+	w := io.NewBufBinWriter()
+	requestOff := w.Len()
+	emit.Int(w.BinWriter, 5)
+	emit.Opcodes(w.BinWriter, opcode.PACK)
+	emit.AppCallNoArgs(w.BinWriter, oracleHash, "request", callflag.All)
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.RET)
+	cancelOff := w.Len()
+	emit.Int(w.BinWriter, 1)
+	emit.Opcodes(w.BinWriter, opcode.PACK)
+	emit.AppCallNoArgs(w.BinWriter, oracleHash, "cancelRequest", callflag.All)
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.RET)
+	handleOff := w.Len()
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.DROP, opcode.DROP, opcode.DROP, opcode.RET)
+	require.NoError(t, w.Err)
+
+	m := manifest.NewManifest("OracleRequester")
+	m.ABI.Methods = []manifest.Method{
+		{
+			Name:   "request",
+			Offset: requestOff,
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("url", smartcontract.StringType),
+				manifest.NewParameter("filter", smartcontract.StringType),
+				manifest.NewParameter("callback", smartcontract.StringType),
+				manifest.NewParameter("userData", smartcontract.AnyType),
+				manifest.NewParameter("gasForResponse", smartcontract.IntegerType),
+			},
+			ReturnType: smartcontract.VoidType,
+		},
+		{
+			Name:       "cancel",
+			Offset:     cancelOff,
+			Parameters: []manifest.Parameter{manifest.NewParameter("id", smartcontract.IntegerType)},
+			ReturnType: smartcontract.VoidType,
+		},
+		{
+			Name:   "handle",
+			Offset: handleOff,
+			Parameters: []manifest.Parameter{
+				manifest.NewParameter("url", smartcontract.StringType),
+				manifest.NewParameter("userData", smartcontract.AnyType),
+				manifest.NewParameter("code", smartcontract.IntegerType),
+				manifest.NewParameter("result", smartcontract.ByteArrayType),
+			},
+			ReturnType: smartcontract.VoidType,
+		},
+	}
+	m.Permissions = []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)}
+	ne, err := nef.NewFile(w.Bytes())
+	require.NoError(t, err)
+	return &neotest.Contract{
+		Hash:     state.CreateContractHash(e.Validator.ScriptHash(), ne.Checksum, m.Name),
+		NEF:      ne,
+		Manifest: m,
+	}
+}
+
+func TestOracle_CancelRequest(t *testing.T) {
+	oracleInvoker := newOracleClient(t)
+	e := oracleInvoker.Executor
+
+	ctr := newOracleRequesterContract(t, e, oracleInvoker.Hash)
+	e.DeployContract(t, ctr, nil)
+	requester := e.ValidatorInvoker(ctr.Hash)
+	oracleNode := designateOracleNode(t, e)
+
+	const gasForResponse = 2000_0000
+	requester.Invoke(t, stackitem.Null{}, "request", "url", nil, "handle", []byte{}, gasForResponse)
+	oracleBalance := e.Chain.GetUtilityTokenBalance(oracleInvoker.Hash).Int64()
+	require.Equal(t, int64(0), e.Chain.GetUtilityTokenBalance(ctr.Hash).Int64())
+
+	t.Run("not a requester", func(t *testing.T) {
+		oracleInvoker.WithSigners(e.Validator).InvokeFail(t, native.ErrNotRequester.Error(), "cancelRequest", 0)
+	})
+	t.Run("unknown request", func(t *testing.T) {
+		requester.InvokeFail(t, native.ErrRequestNotFound.Error(), "cancel", 100)
+	})
+
+	h := requester.Invoke(t, stackitem.Null{}, "cancel", 0)
+	aer := e.GetTxExecResult(t, h)
+	var cancelEvent *state.NotificationEvent
+	for i := range aer.Events {
+		if aer.Events[i].ScriptHash == oracleInvoker.Hash && aer.Events[i].Name == "OracleCancel" {
+			cancelEvent = &aer.Events[i]
+		}
+	}
+	require.NotNil(t, cancelEvent)
+	require.Equal(t, stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(0),
+		stackitem.Make(ctr.Hash.BytesBE()),
+		stackitem.Make(gasForResponse),
+	}), cancelEvent.Item)
+	require.Equal(t, int64(gasForResponse), e.Chain.GetUtilityTokenBalance(ctr.Hash).Int64())
+	require.Equal(t, oracleBalance-gasForResponse, e.Chain.GetUtilityTokenBalance(oracleInvoker.Hash).Int64())
+
+	// Cancelled request can't be responded to or cancelled again.
+	err := e.Chain.VerifyTx(newOracleResponseTx(t, e, oracleInvoker.Hash, oracleNode, 0))
+	require.ErrorContains(t, err, "oracle tx points to invalid request")
+	requester.InvokeFail(t, native.ErrRequestNotFound.Error(), "cancel", 0)
+
+	t.Run("response in the same block", func(t *testing.T) {
+		requester.Invoke(t, stackitem.Null{}, "request", "url", nil, "handle", []byte{}, gasForResponse)
+		respTx := newOracleResponseTx(t, e, oracleInvoker.Hash, oracleNode, 1)
+		cancelTx := requester.PrepareInvoke(t, "cancel", 1)
+		e.AddNewBlock(t, respTx, cancelTx)
+		e.CheckHalt(t, respTx.Hash(), stackitem.Null{})
+		e.CheckFault(t, cancelTx.Hash(), native.ErrResponseInBlock.Error())
+		require.Equal(t, int64(gasForResponse), e.Chain.GetUtilityTokenBalance(ctr.Hash).Int64())
+	})
+	t.Run("response after cancellation", func(t *testing.T) {
+		requester.Invoke(t, stackitem.Null{}, "request", "url", nil, "handle", []byte{}, gasForResponse)
+		respTx := newOracleResponseTx(t, e, oracleInvoker.Hash, oracleNode, 2)
+		require.NoError(t, e.Chain.PoolTx(respTx))
+		requester.Invoke(t, stackitem.Null{}, "cancel", 2)
+		require.False(t, e.Chain.GetMemPool().ContainsKey(respTx.Hash()))
+		require.Equal(t, int64(2*gasForResponse), e.Chain.GetUtilityTokenBalance(ctr.Hash).Int64())
+	})
+}
+
+// chainRequestReader implements oracle.RequestReader reading the storage of
+// the chain directly.
+type chainRequestReader struct {
+	e *neotest.Executor
+}
+
+func (r chainRequestReader) GetStorageByHash(h util.Uint160, key []byte) ([]byte, error) {
+	cs := r.e.Chain.GetContractState(h)
+	if cs == nil {
+		return nil, errors.New("unknown contract")
+	}
+	si := r.e.Chain.GetStorageItem(cs.ID, key)
+	if si == nil {
+		return nil, errors.New("not found")
+	}
+	return si, nil
+}
+
+func TestOracle_ResponseTxHelpers(t *testing.T) {
+	oracleInvoker := newOracleClient(t)
+	e := oracleInvoker.Executor
+
+	ctr := newOracleRequesterContract(t, e, oracleInvoker.Hash)
+	e.DeployContract(t, ctr, nil)
+	requester := e.ValidatorInvoker(ctr.Hash)
+	oracleNode := designateOracleNode(t, e)
+	nodes := keys.PublicKeys{oracleNode.Single(0).Account().PublicKey()}
+
+	const gasForResponse = 2000_0000
+	requester.Invoke(t, stackitem.Null{}, "request", "url", nil, "handle", []byte{}, gasForResponse)
+	req, err := oracle.GetRequest(chainRequestReader{e}, 0)
+	require.NoError(t, err)
+	require.Equal(t, uint64(gasForResponse), req.GasForResponse)
+	_, err = oracle.GetRequest(chainRequestReader{e}, 1)
+	require.Error(t, err)
+
+	tx, err := oracle.NewResponseTx(nodes, &transaction.OracleResponse{
+		ID:     0,
+		Code:   transaction.Success,
+		Result: []byte{1, 2, 3},
+	}, e.Chain.BlockHeight()+10)
+	require.NoError(t, err)
+	require.Error(t, oracle.CheckResponseTx(tx, req, nodes)) // No fees.
+	tx.NetworkFee = 1000_0000
+	tx.SystemFee = gasForResponse - tx.NetworkFee
+	require.NoError(t, oracle.CheckResponseTx(tx, req, nodes))
+	require.NoError(t, oracle.VerifyResponseTx(chainRequestReader{e}, tx, nodes))
+	require.Error(t, oracle.CheckResponseTx(tx, req, keys.PublicKeys{e.Validator.(neotest.MultiSigner).Single(0).Account().PublicKey()}))
+
+	tx.Scripts[1].InvocationScript = oracleNode.SignHashable(uint32(e.Chain.GetConfig().Magic), tx)
+	e.AddNewBlock(t, tx)
+	e.CheckHalt(t, tx.Hash(), stackitem.Null{})
+	require.Error(t, oracle.VerifyResponseTx(chainRequestReader{e}, tx, nodes))
+}
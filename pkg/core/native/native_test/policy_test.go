@@ -3,18 +3,24 @@ package native_test
 import (
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
 )
 
 func newPolicyClient(t *testing.T) *neotest.ContractInvoker {
@@ -158,3 +164,95 @@ func TestPolicy_BlockedAccounts(t *testing.T) {
 		helperInvoker.Invoke(t, true, "do")
 	})
 }
+
+func TestPolicy_MillisecondsPerBlock(t *testing.T) {
+	testGetSet(t, newPolicyClient(t), "MillisecondsPerBlock", int64(chain.TimePerBlock/time.Millisecond), 1, 30_000)
+}
+
+func TestPolicy_MillisecondsPerBlockChange(t *testing.T) {
+	c := newPolicyClient(t)
+	e := c.Executor
+	committeeInvoker := c.WithSigners(c.Committee)
+	defaultMs := uint32(chain.TimePerBlock / time.Millisecond)
+
+	checkNextTimestamp := func(t *testing.T, ms uint32) {
+		ic, err := e.Chain.GetTestVM(trigger.Application, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, e.TopBlock(t).Timestamp+uint64(ms), ic.Block.Timestamp)
+	}
+	require.Equal(t, defaultMs, e.Chain.GetMillisecondsPerBlock())
+	checkNextTimestamp(t, defaultMs)
+
+	tx := committeeInvoker.PrepareInvoke(t, "setMillisecondsPerBlock", 500)
+	// The value is unchanged for the block being created.
+	require.Equal(t, defaultMs, e.Chain.GetMillisecondsPerBlock())
+	e.AddNewBlock(t, tx)
+	e.CheckHalt(t, tx.Hash(), stackitem.Null{})
+	e.CheckTxNotificationEvent(t, tx.Hash(), 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "MillisecondsPerBlockChanged",
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(defaultMs), stackitem.Make(500)}),
+	})
+
+	// It's effective since the next block.
+	require.Equal(t, uint32(500), e.Chain.GetMillisecondsPerBlock())
+	checkNextTimestamp(t, 500)
+	committeeInvoker.Invoke(t, 500, "getMillisecondsPerBlock")
+}
+
+func TestPolicy_MaxTraceableBlocks(t *testing.T) {
+	const maxVUBIncrement = 5
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(cfg *config.Blockchain) {
+		withExtensions(cfg)
+		cfg.MaxValidUntilBlockIncrement = maxVUBIncrement
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+	randomInvoker := c.WithSigners(c.NewAccount(t))
+	ledgerInvoker := c.CommitteeInvoker(e.NativeHash(t, nativenames.Ledger))
+
+	randomInvoker.Invoke(t, chain.MaxTraceableBlocks, "getMaxTraceableBlocks")
+	randomInvoker.InvokeFail(t, "invalid committee signature", "setMaxTraceableBlocks", 10)
+	c.InvokeFail(t, "MaxTraceableBlocks must be between 1", "setMaxTraceableBlocks", 0)
+	c.InvokeFail(t, "can't be increased", "setMaxTraceableBlocks", chain.MaxTraceableBlocks+1)
+	c.InvokeFail(t, "must be greater than MaxValidUntilBlockIncrement", "setMaxTraceableBlocks", maxVUBIncrement)
+
+	h := c.Invoke(t, stackitem.Null{}, "setMaxTraceableBlocks", 10)
+	e.CheckTxNotificationEvent(t, h, 0, state.NotificationEvent{
+		ScriptHash: c.Hash,
+		Name:       "MaxTraceableBlocksChanged",
+		Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(chain.MaxTraceableBlocks), stackitem.Make(10)}),
+	})
+	require.Equal(t, uint32(10), bc.GetMaxTraceableBlocks())
+	c.InvokeFail(t, "can't be increased", "setMaxTraceableBlocks", 11)
+
+	// Ledger doesn't return blocks beyond the new limit.
+	b := e.TopBlock(t)
+	e.GenerateNewBlocks(t, 8)
+	ledgerInvoker.Invoke(t, b.Index, "getTransactionHeight", b.Transactions[0].Hash())
+	e.GenerateNewBlocks(t, 1)
+	ledgerInvoker.Invoke(t, -1, "getTransactionHeight", b.Transactions[0].Hash())
+}
+
+func TestPolicy_BlockTimeBeforeHardfork(t *testing.T) {
+	const hfHeight = 3
+	bc, acc := chain.NewSingleWithCustomConfig(t, func(cfg *config.Blockchain) {
+		cfg.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): hfHeight,
+		}
+	})
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := e.CommitteeInvoker(e.NativeHash(t, nativenames.Policy))
+
+	c.InvokeFail(t, "method not found: getMillisecondsPerBlock/0", "getMillisecondsPerBlock")
+	c.InvokeFail(t, "method not found: setMaxTraceableBlocks/1", "setMaxTraceableBlocks", 10)
+	for bc.BlockHeight() < hfHeight {
+		e.AddNewBlock(t)
+	}
+	// Configuration values are used until changed by the committee.
+	c.Invoke(t, int64(chain.TimePerBlock/time.Millisecond), "getMillisecondsPerBlock")
+	c.Invoke(t, chain.MaxTraceableBlocks, "getMaxTraceableBlocks")
+}
@@ -42,6 +42,8 @@ type Oracle struct {
 	Module atomic.Value
 	// newRequests contains new requests created during the current block.
 	newRequests map[uint64]*state.OracleRequest
+	// cancelledRequests contains requests cancelled during the current block.
+	cancelledRequests []uint64
 }
 
 type OracleCache struct {
@@ -54,10 +56,14 @@ type OracleService interface {
 	AddRequests(map[uint64]*state.OracleRequest)
 	// RemoveRequests removes already processed requests.
 	RemoveRequests([]uint64)
-	// UpdateOracleNodes updates oracle nodes.
-	UpdateOracleNodes(keys.PublicKeys)
-	// UpdateNativeContract updates oracle contract native script and hash.
-	UpdateNativeContract([]byte, []byte, util.Uint160, int)
+	// CancelRequests removes cancelled requests, they must not be processed
+	// even if they're in progress already.
+	CancelRequests([]uint64)
+	// UpdateOracleNodes updates oracle nodes designated at the given height.
+	UpdateOracleNodes(uint32, keys.PublicKeys)
+	// UpdateNativeContract updates oracle response script and native oracle
+	// contract hash.
+	UpdateNativeContract([]byte, util.Uint160)
 	// Start runs oracle module.
 	Start()
 	// Shutdown shutdowns oracle module.
@@ -95,6 +101,8 @@ var (
 	ErrNotEnoughGas     = errors.New("gas limit exceeded")
 	ErrRequestNotFound  = errors.New("oracle request not found")
 	ErrResponseNotFound = errors.New("oracle response not found")
+	ErrNotRequester     = errors.New("request can only be cancelled by the requesting contract")
+	ErrResponseInBlock  = errors.New("response to the request is already included into the current block")
 )
 
 var (
@@ -146,6 +154,15 @@ func newOracle() *Oracle {
 	o.AddEvent("OracleResponse", manifest.NewParameter("Id", smartcontract.IntegerType),
 		manifest.NewParameter("OriginalTx", smartcontract.Hash256Type))
 
+	desc = newDescriptor("cancelRequest", smartcontract.VoidType,
+		manifest.NewParameter("id", smartcontract.IntegerType))
+	md = newMethodAndPrice(o.cancelRequest, 1<<15, callflag.States|callflag.AllowNotify, config.HFNeoGoExtensions)
+	o.AddMethod(md, desc)
+
+	o.AddEventFrom(config.HFNeoGoExtensions, "OracleCancel", manifest.NewParameter("Id", smartcontract.IntegerType),
+		manifest.NewParameter("RequestContract", smartcontract.Hash160Type),
+		manifest.NewParameter("Refund", smartcontract.IntegerType))
+
 	desc = newDescriptor("getPrice", smartcontract.IntegerType)
 	md = newMethodAndPrice(o.getPrice, 1<<15, callflag.ReadStates)
 	o.AddMethod(md, desc)
@@ -421,6 +438,66 @@ func (o *Oracle) RequestInternal(ic *interop.Context, url string, filter *string
 	return o.PutRequestInternal(id, req, ic.DAO)
 }
 
+func (o *Oracle) cancelRequest(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	id := toUint64(args[0])
+	if err := o.CancelRequestInternal(ic, id); err != nil {
+		panic(err)
+	}
+	return stackitem.Null{}
+}
+
+// CancelRequestInternal cancels the oracle request with the specified id. It
+// can only be done by the contract that has made the request, GAS reserved for
+// the response is returned to this contract (without onNEP17Payment call).
+func (o *Oracle) CancelRequestInternal(ic *interop.Context, id uint64) error {
+	req, err := o.GetRequestInternal(ic.DAO, id)
+	if err != nil {
+		return ErrRequestNotFound
+	}
+	if !ic.VM.GetCallingScriptHash().Equals(req.CallbackContract) {
+		return ErrNotRequester
+	}
+	// Response fees are burnt in GAS.OnPersist, so the request can't be
+	// cancelled if the response is in the same block.
+	if ic.Block != nil {
+		for _, tx := range ic.Block.Transactions {
+			if resp := getResponse(tx); resp != nil && resp.ID == id {
+				return ErrResponseInBlock
+			}
+		}
+	}
+	ic.DAO.DeleteStorageItem(o.ID, makeRequestKey(id))
+
+	idKey := makeIDListKey(req.URL)
+	idList := new(IDList)
+	if err := o.getConvertibleFromDAO(ic.DAO, idKey, idList); err != nil {
+		return err
+	}
+	if !idList.Remove(id) {
+		return errors.New("request ID wasn't found")
+	}
+	if len(*idList) == 0 {
+		ic.DAO.DeleteStorageItem(o.ID, idKey)
+	} else if err := putConvertibleToDAO(o.ID, ic.DAO, idKey, idList); err != nil {
+		return err
+	}
+
+	refund := new(big.Int).SetUint64(req.GasForResponse)
+	o.GAS.burn(ic, o.Hash, refund)
+	o.GAS.mint(ic, req.CallbackContract, refund, false)
+
+	orc, _ := o.Module.Load().(*OracleService)
+	if orc != nil && *orc != nil {
+		o.cancelledRequests = append(o.cancelledRequests, id)
+	}
+	ic.AddNotification(o.Hash, "OracleCancel", stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(id),
+		stackitem.Make(req.CallbackContract.BytesBE()),
+		stackitem.Make(refund),
+	}))
+	return nil
+}
+
 // PutRequestInternal puts the oracle request with the specified id to d.
 func (o *Oracle) PutRequestInternal(id uint64, req *state.OracleRequest, d *dao.Simple) error {
 	reqKey := makeRequestKey(id)
@@ -563,6 +640,17 @@ func (o *Oracle) updateCache(d *dao.Simple) error {
 		}
 	}
 	(*orc).AddRequests(reqs)
+
+	var cancelled []uint64
+	for _, id := range o.cancelledRequests {
+		if si := d.GetStorageItem(o.ID, makeRequestKey(id)); si == nil { // tx hasn't failed
+			cancelled = append(cancelled, id)
+		}
+	}
+	o.cancelledRequests = nil
+	if len(cancelled) != 0 {
+		(*orc).CancelRequests(cancelled)
+	}
 	return nil
 }
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"sort"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
@@ -42,6 +43,11 @@ const (
 	maxStoragePrice = 10000000
 	// maxAttributeFee is the maximum allowed value for a transaction attribute fee.
 	maxAttributeFee = 10_00000000
+	// maxMillisecondsPerBlock is the maximum allowed block time.
+	maxMillisecondsPerBlock = 30_000
+	// maxMaxTraceableBlocks is the maximum allowed MaxTraceableBlocks value
+	// (1 year of 15s blocks).
+	maxMaxTraceableBlocks = 2_102_400
 
 	// blockedAccountPrefix is a prefix used to store blocked account.
 	blockedAccountPrefix = 15
@@ -57,6 +63,12 @@ var (
 	feePerByteKey = []byte{10}
 	// storagePriceKey is a key used to store storage price.
 	storagePriceKey = []byte{19}
+	// millisecondsPerBlockKey is a key used to store block time set by the
+	// committee.
+	millisecondsPerBlockKey = []byte{21}
+	// maxTraceableBlocksKey is a key used to store MaxTraceableBlocks value
+	// set by the committee.
+	maxTraceableBlocksKey = []byte{22}
 )
 
 // Policy represents Policy native contract.
@@ -75,6 +87,10 @@ type PolicyCache struct {
 	storagePrice       uint32
 	attributeFee       map[transaction.AttrType]uint32
 	blockedAccounts    []util.Uint160
+	// millisecondsPerBlock and maxTraceableBlocks are zero unless set by the
+	// committee, protocol configuration values are used then.
+	millisecondsPerBlock uint32
+	maxTraceableBlocks   uint32
 }
 
 var (
@@ -160,6 +176,31 @@ func newPolicy(p2pSigExtensionsEnabled bool) *Policy {
 	md = newMethodAndPrice(p.unblockAccount, 1<<15, callflag.States)
 	p.AddMethod(md, desc)
 
+	desc = newDescriptor("getMillisecondsPerBlock", smartcontract.IntegerType)
+	md = newMethodAndPrice(p.getMillisecondsPerBlock, 1<<15, callflag.ReadStates, config.HFNeoGoExtensions)
+	p.AddMethod(md, desc)
+
+	desc = newDescriptor("setMillisecondsPerBlock", smartcontract.VoidType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(p.setMillisecondsPerBlock, 1<<15, callflag.States|callflag.AllowNotify, config.HFNeoGoExtensions)
+	p.AddMethod(md, desc)
+
+	desc = newDescriptor("getMaxTraceableBlocks", smartcontract.IntegerType)
+	md = newMethodAndPrice(p.getMaxTraceableBlocks, 1<<15, callflag.ReadStates, config.HFNeoGoExtensions)
+	p.AddMethod(md, desc)
+
+	desc = newDescriptor("setMaxTraceableBlocks", smartcontract.VoidType,
+		manifest.NewParameter("value", smartcontract.IntegerType))
+	md = newMethodAndPrice(p.setMaxTraceableBlocks, 1<<15, callflag.States|callflag.AllowNotify, config.HFNeoGoExtensions)
+	p.AddMethod(md, desc)
+
+	p.AddEventFrom(config.HFNeoGoExtensions, "MillisecondsPerBlockChanged",
+		manifest.NewParameter("old", smartcontract.IntegerType),
+		manifest.NewParameter("new", smartcontract.IntegerType))
+	p.AddEventFrom(config.HFNeoGoExtensions, "MaxTraceableBlocksChanged",
+		manifest.NewParameter("old", smartcontract.IntegerType),
+		manifest.NewParameter("new", smartcontract.IntegerType))
+
 	return p
 }
 
@@ -206,6 +247,12 @@ func (p *Policy) fillCacheFromDAO(cache *PolicyCache, d *dao.Simple) error {
 	cache.feePerByte = getIntWithKey(p.ID, d, feePerByteKey)
 	cache.maxVerificationGas = defaultMaxVerificationGas
 	cache.storagePrice = uint32(getIntWithKey(p.ID, d, storagePriceKey))
+	if si := d.GetStorageItem(p.ID, millisecondsPerBlockKey); si != nil {
+		cache.millisecondsPerBlock = uint32(bigint.FromBytes(si).Int64())
+	}
+	if si := d.GetStorageItem(p.ID, maxTraceableBlocksKey); si != nil {
+		cache.maxTraceableBlocks = uint32(bigint.FromBytes(si).Int64())
+	}
 
 	cache.blockedAccounts = make([]util.Uint160, 0)
 	var fErr error
@@ -359,9 +406,110 @@ func (p *Policy) setStoragePrice(ic *interop.Context, args []stackitem.Item) sta
 	return stackitem.Null{}
 }
 
+func (p *Policy) getMillisecondsPerBlock(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.millisecondsPerBlock(ic))))
+}
+
+// millisecondsPerBlock returns the current block time, it's the one from the
+// protocol configuration unless changed by the committee.
+func (p *Policy) millisecondsPerBlock(ic *interop.Context) uint32 {
+	if v := p.GetMillisecondsPerBlockInternal(ic.DAO); v != 0 {
+		return v
+	}
+	return uint32(ic.Chain.GetConfig().TimePerBlock / time.Millisecond)
+}
+
+// GetMillisecondsPerBlockInternal returns block time set by the committee or
+// zero if it was never changed (protocol configuration value is effective
+// then).
+func (p *Policy) GetMillisecondsPerBlockInternal(d *dao.Simple) uint32 {
+	cache := d.GetROCache(p.ID).(*PolicyCache)
+	return cache.millisecondsPerBlock
+}
+
+// setMillisecondsPerBlock is a Policy contract method that sets block time,
+// it's effective since the next block.
+func (p *Policy) setMillisecondsPerBlock(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value == 0 || value > maxMillisecondsPerBlock {
+		panic(fmt.Errorf("MillisecondsPerBlock must be between 1 and %d", maxMillisecondsPerBlock))
+	}
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	old := p.millisecondsPerBlock(ic)
+	setIntWithKey(p.ID, ic.DAO, millisecondsPerBlockKey, int64(value))
+	cache := ic.DAO.GetRWCache(p.ID).(*PolicyCache)
+	cache.millisecondsPerBlock = value
+	ic.AddNotification(p.Hash, "MillisecondsPerBlockChanged", stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(old),
+		stackitem.Make(value),
+	}))
+	return stackitem.Null{}
+}
+
+func (p *Policy) getMaxTraceableBlocks(ic *interop.Context, _ []stackitem.Item) stackitem.Item {
+	return stackitem.NewBigInteger(big.NewInt(int64(p.maxTraceableBlocks(ic))))
+}
+
+// maxTraceableBlocks returns the current MaxTraceableBlocks value, it's the
+// one from the protocol configuration unless changed by the committee.
+func (p *Policy) maxTraceableBlocks(ic *interop.Context) uint32 {
+	if v := p.GetMaxTraceableBlocksInternal(ic.DAO); v != 0 {
+		return v
+	}
+	return ic.Chain.GetConfig().MaxTraceableBlocks
+}
+
+// GetMaxTraceableBlocksInternal returns MaxTraceableBlocks value set by the
+// committee or zero if it was never changed (protocol configuration value is
+// effective then).
+func (p *Policy) GetMaxTraceableBlocksInternal(d *dao.Simple) uint32 {
+	cache := d.GetROCache(p.ID).(*PolicyCache)
+	return cache.maxTraceableBlocks
+}
+
+// setMaxTraceableBlocks is a Policy contract method that sets MaxTraceableBlocks
+// value. It can't be increased since older blocks may already be removed by
+// nodes and it must exceed MaxValidUntilBlockIncrement for transaction
+// duplication checks to cover the whole transaction lifetime.
+func (p *Policy) setMaxTraceableBlocks(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	value := toUint32(args[0])
+	if value == 0 || value > maxMaxTraceableBlocks {
+		panic(fmt.Errorf("MaxTraceableBlocks must be between 1 and %d", maxMaxTraceableBlocks))
+	}
+	old := p.maxTraceableBlocks(ic)
+	if value > old {
+		panic(fmt.Errorf("MaxTraceableBlocks can't be increased (old %d, new %d)", old, value))
+	}
+	if vub := ic.Chain.GetConfig().MaxValidUntilBlockIncrement; value <= vub {
+		panic(fmt.Errorf("MaxTraceableBlocks must be greater than MaxValidUntilBlockIncrement (%d)", vub))
+	}
+	if !p.NEO.checkCommittee(ic) {
+		panic("invalid committee signature")
+	}
+	setIntWithKey(p.ID, ic.DAO, maxTraceableBlocksKey, int64(value))
+	cache := ic.DAO.GetRWCache(p.ID).(*PolicyCache)
+	cache.maxTraceableBlocks = value
+	ic.AddNotification(p.Hash, "MaxTraceableBlocksChanged", stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(old),
+		stackitem.Make(value),
+	}))
+	return stackitem.Null{}
+}
+
+// isValidAttrType checks whether the attribute type is valid at the current
+// context height.
+func isValidAttrType(ic *interop.Context, t transaction.AttrType) bool {
+	if t == transaction.SponsorT && !ic.IsHardforkEnabled(config.HFNeoGoExtensions) {
+		return false
+	}
+	return transaction.IsValidAttrType(ic.Chain.GetConfig().ReservedAttributes, t)
+}
+
 func (p *Policy) getAttributeFee(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	t := transaction.AttrType(toUint8(args[0]))
-	if !transaction.IsValidAttrType(ic.Chain.GetConfig().ReservedAttributes, t) {
+	if !isValidAttrType(ic, t) {
 		panic(fmt.Errorf("invalid attribute type: %d", t))
 	}
 	return stackitem.NewBigInteger(big.NewInt(p.GetAttributeFeeInternal(ic.DAO, t)))
@@ -381,7 +529,7 @@ func (p *Policy) GetAttributeFeeInternal(d *dao.Simple, t transaction.AttrType)
 func (p *Policy) setAttributeFee(ic *interop.Context, args []stackitem.Item) stackitem.Item {
 	t := transaction.AttrType(toUint8(args[0]))
 	value := toUint32(args[1])
-	if !transaction.IsValidAttrType(ic.Chain.GetConfig().ReservedAttributes, t) {
+	if !isValidAttrType(ic, t) {
 		panic(fmt.Errorf("invalid attribute type: %d", t))
 	}
 	if value > maxAttributeFee {
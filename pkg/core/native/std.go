@@ -14,8 +14,10 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	base58neogo "github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/jsonpath"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -33,6 +35,13 @@ const (
 
 	// stdMaxInputLength is the maximum input length for string-related methods.
 	stdMaxInputLength = 1024
+
+	// stdMaxJSONPathLength is the maximum jsonPath path length, it's the
+	// same as the maximum oracle filter length.
+	stdMaxJSONPathLength = maxFilterLength
+	// stdJSONPathFeePerByte is the jsonPath price (in execution fee factor
+	// units) charged for every byte of JSON input.
+	stdJSONPathFeePerByte = 1 << 4
 )
 
 var (
@@ -68,6 +77,12 @@ func newStd() *Std {
 	md = newMethodAndPrice(s.jsonDeserialize, 1<<14, callflag.NoneFlag)
 	s.AddMethod(md, desc)
 
+	desc = newDescriptor("jsonPath", smartcontract.ByteArrayType,
+		manifest.NewParameter("json", smartcontract.ByteArrayType),
+		manifest.NewParameter("path", smartcontract.ByteArrayType))
+	md = newMethodAndPrice(s.jsonPath, 1<<12, callflag.NoneFlag, config.HFNeoGoExtensions)
+	s.AddMethod(md, desc)
+
 	desc = newDescriptor("itoa", smartcontract.StringType,
 		manifest.NewParameter("value", smartcontract.IntegerType),
 		manifest.NewParameter("base", smartcontract.IntegerType))
@@ -212,7 +227,7 @@ func (s *Std) jsonDeserialize(ic *interop.Context, args []stackitem.Item) stacki
 		panic(err)
 	}
 
-	item, err := stackitem.FromJSON(data, stackitem.MaxDeserialized, ic.IsHardforkEnabled(config.HFBasilisk))
+	item, err := stackitem.FromJSONWithLimits(data, stackitem.DefaultJSONLimits, ic.IsHardforkEnabled(config.HFBasilisk))
 	if err != nil {
 		panic(err)
 	}
@@ -220,6 +235,38 @@ func (s *Std) jsonDeserialize(ic *interop.Context, args []stackitem.Item) stacki
 	return item
 }
 
+// jsonPath applies oracle filter to the JSON input, so it has the same input
+// size and result size limits as oracle requests and responses.
+func (s *Std) jsonPath(ic *interop.Context, args []stackitem.Item) stackitem.Item {
+	data, err := args[0].TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if len(data) > transaction.MaxOracleResultSize {
+		panic(ErrTooBigInput)
+	}
+	path, err := args[1].TryBytes()
+	if err != nil {
+		panic(err)
+	}
+	if len(path) > stdMaxJSONPathLength {
+		panic(ErrTooBigInput)
+	}
+	if !ic.VM.AddGas(ic.BaseExecFee() * stdJSONPathFeePerByte * int64(len(data))) {
+		panic(errGasLimitExceeded)
+	}
+
+	res, err := jsonpath.Filter(data, string(path))
+	if err != nil {
+		panic(err)
+	}
+	if len(res) > stackitem.MaxSize {
+		panic(errors.New("too big item"))
+	}
+
+	return stackitem.NewByteArray(res)
+}
+
 func (s *Std) itoa10(_ *interop.Context, args []stackitem.Item) stackitem.Item {
 	num := toBigInt(args[0])
 	return stackitem.NewByteArray([]byte(num.Text(10)))
@@ -3,14 +3,18 @@ package native
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"math"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/mr-tron/base58"
 	"github.com/nspcc-dev/neo-go/pkg/core/dao"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	base58neogo "github.com/nspcc-dev/neo-go/pkg/encoding/base58"
 	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -167,6 +171,54 @@ func TestStdLibJSON(t *testing.T) {
 	})
 }
 
+func TestStdLibJSONPath(t *testing.T) {
+	s := newStd()
+	ic := &interop.Context{VM: vm.New()}
+
+	// Vectors are shared with oracle filter tests to ensure identical semantics.
+	raw, err := os.ReadFile(filepath.Join("..", "..", "services", "oracle", "testdata", "filter.json"))
+	require.NoError(t, err)
+	var vs struct {
+		Value json.RawMessage `json:"value"`
+		Valid []struct {
+			Path   string `json:"path"`
+			Result string `json:"result"`
+		} `json:"valid"`
+		Invalid []string `json:"invalid"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &vs))
+
+	for _, tc := range vs.Valid {
+		t.Run(tc.Path, func(t *testing.T) {
+			var actual stackitem.Item
+			require.NotPanics(t, func() {
+				actual = s.jsonPath(ic, []stackitem.Item{stackitem.Make([]byte(vs.Value)), stackitem.Make(tc.Path)})
+			})
+			require.Equal(t, stackitem.Make([]byte(tc.Result)), actual)
+		})
+	}
+	for _, path := range vs.Invalid {
+		t.Run(path, func(t *testing.T) {
+			require.Panics(t, func() {
+				_ = s.jsonPath(ic, []stackitem.Item{stackitem.Make([]byte(vs.Value)), stackitem.Make(path)})
+			})
+		})
+	}
+	t.Run("not an UTF-8", func(t *testing.T) {
+		require.Panics(t, func() {
+			_ = s.jsonPath(ic, []stackitem.Item{stackitem.Make([]byte{0xFF}), stackitem.Make("$")})
+		})
+	})
+	t.Run("too big input", func(t *testing.T) {
+		require.PanicsWithValue(t, ErrTooBigInput, func() {
+			_ = s.jsonPath(ic, []stackitem.Item{stackitem.Make(make([]byte, transaction.MaxOracleResultSize+1)), stackitem.Make("$")})
+		})
+		require.PanicsWithValue(t, ErrTooBigInput, func() {
+			_ = s.jsonPath(ic, []stackitem.Item{stackitem.Make("{}"), stackitem.Make(strings.Repeat("$", stdMaxJSONPathLength+1))})
+		})
+	})
+}
+
 func TestStdLibEncodeDecode(t *testing.T) {
 	s := newStd()
 	original := []byte("my pretty string")
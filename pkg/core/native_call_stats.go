@@ -0,0 +1,32 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+)
+
+// ErrNativeCallStatsDisabled is returned from native call statistics requests
+// if statistics gathering is disabled in the node configuration.
+var ErrNativeCallStatsDisabled = errors.New("native call statistics gathering is disabled")
+
+// GetNativeCallStats returns node-local invocation statistics of all native
+// contract methods accumulated since the node start (or the last reset). All
+// invocations are counted including the ones made by test invocations and
+// witness checks.
+func (bc *Blockchain) GetNativeCallStats() ([]native.MethodCallStats, error) {
+	if !bc.config.Ledger.TrackNativeCallStats {
+		return nil, ErrNativeCallStatsDisabled
+	}
+	return native.GetCallStats(bc.contracts.Contracts), nil
+}
+
+// ResetNativeCallStats resets invocation statistics of all native contract
+// methods.
+func (bc *Blockchain) ResetNativeCallStats() error {
+	if !bc.config.Ledger.TrackNativeCallStats {
+		return ErrNativeCallStatsDisabled
+	}
+	native.ResetCallStats(bc.contracts.Contracts)
+	return nil
+}
@@ -0,0 +1,265 @@
+package core
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"go.uber.org/zap"
+)
+
+// nep17ReindexBatch is the number of accounts processed by
+// ReindexNEP17Balances between intermediate DB flushes.
+const nep17ReindexBatch = 10000
+
+var (
+	// ErrNEP17BalancesDisabled is returned from NEP-17 balances requests if
+	// balances tracking is disabled in the node configuration.
+	ErrNEP17BalancesDisabled = errors.New("NEP-17 balances tracking is disabled")
+	// ErrNEP17BalancesNotReady is returned from NEP-17 balances requests if
+	// balances index is not yet built.
+	ErrNEP17BalancesNotReady = errors.New("NEP-17 balances index is not built")
+)
+
+// nep17BalancesStateKey is the key of the NEP-17 balances index completeness
+// marker. Its value is the height the index was built at.
+var nep17BalancesStateKey = []byte{byte(storage.SYSNEP17BalancesState)}
+
+// initNEP17Balances checks NEP-17 balances index state on startup against the
+// node configuration. Unlike storage usage counters the index is not rebuilt
+// automatically since it requires contract invocations for every account.
+func (bc *Blockchain) initNEP17Balances() {
+	_, err := bc.dao.Store.Get(nep17BalancesStateKey)
+	complete := err == nil
+	switch {
+	case bc.config.Ledger.TrackNEP17Balances && complete:
+		bc.nep17BalancesReady.Store(true)
+	case bc.config.Ledger.TrackNEP17Balances:
+		bc.log.Warn("NEP-17 balances tracking is enabled, but the index is not built, use `db reindex-balances` command to build it")
+	case complete:
+		bc.dao.Store.Delete(nep17BalancesStateKey)
+		bc.dao.DeleteAllNEP17Balances()
+	}
+}
+
+// invalidateNEP17Balances marks NEP-17 balances index as incomplete after
+// direct contract storage changes (like state jump or reset). It must be
+// called with addLock held.
+func (bc *Blockchain) invalidateNEP17Balances() {
+	if !bc.config.Ledger.TrackNEP17Balances {
+		return
+	}
+	if bc.nep17BalancesReady.Swap(false) {
+		bc.log.Warn("NEP-17 balances index is invalidated, use `db reindex-balances` command to rebuild it")
+	}
+	bc.dao.Store.Delete(nep17BalancesStateKey)
+}
+
+// putNEP17BalancesState marks NEP-17 balances index as complete starting from
+// the given height.
+func putNEP17BalancesState(d *dao.Simple, height uint32) {
+	d.Store.Put(nep17BalancesStateKey, binary.LittleEndian.AppendUint32(nil, height))
+}
+
+// rollbackNEP17BalancesState drops NEP-17 balances index completeness marker
+// if the index was built after the given height, changelog doesn't contain
+// index changes for blocks processed before that.
+func rollbackNEP17BalancesState(d *dao.Simple, height uint32) {
+	b, err := d.Store.Get(nep17BalancesStateKey)
+	if err == nil && (len(b) != 4 || binary.LittleEndian.Uint32(b) > height) {
+		d.Store.Delete(nep17BalancesStateKey)
+	}
+}
+
+// nep17ContractID returns the ID of the contract with the given hash if it
+// supports NEP-17 standard.
+func (bc *Blockchain) nep17ContractID(d *dao.Simple, sc util.Uint160) (int32, bool) {
+	if nativeContract := bc.contracts.ByHash(sc); nativeContract != nil {
+		md := nativeContract.Metadata()
+		if !md.Manifest.IsStandardSupported(manifest.NEP17StandardName) {
+			return 0, false
+		}
+		return md.ID, true
+	}
+	cs, err := native.GetContract(d, sc)
+	if err != nil {
+		return 0, false
+	}
+	return cs.ID, cs.Manifest.IsStandardSupported(manifest.NEP17StandardName)
+}
+
+// markNEP17Unreliable marks the token as the one that can't be indexed if it's
+// a NEP-17 contract emitting a malformed Transfer event.
+func (bc *Blockchain) markNEP17Unreliable(d *dao.Simple, sc util.Uint160) {
+	if !bc.nep17BalancesReady.Load() {
+		return
+	}
+	if id, ok := bc.nep17ContractID(d, sc); ok {
+		d.MarkNEP17Unreliable(id)
+	}
+}
+
+// updateNEP17Balances applies NEP-17 transfer to the balances index. Tokens
+// with transfers leading to negative balances are marked as unreliable.
+func (bc *Blockchain) updateNEP17Balances(d *dao.Simple, sc util.Uint160, from, to util.Uint160, amount *big.Int) {
+	if !bc.nep17BalancesReady.Load() {
+		return
+	}
+	id, ok := bc.nep17ContractID(d, sc)
+	if !ok {
+		return
+	}
+	if amount.Sign() < 0 {
+		d.MarkNEP17Unreliable(id)
+		return
+	}
+	if !from.Equals(util.Uint160{}) {
+		bal, err := d.GetNEP17Balance(from, id)
+		if err != nil {
+			d.MarkNEP17Unreliable(id)
+			return
+		}
+		bal.Sub(bal, amount)
+		if bal.Sign() < 0 {
+			d.MarkNEP17Unreliable(id)
+			bal.SetInt64(0)
+		}
+		d.PutNEP17Balance(from, id, bal)
+	}
+	if !to.Equals(util.Uint160{}) {
+		bal, err := d.GetNEP17Balance(to, id)
+		if err != nil {
+			d.MarkNEP17Unreliable(id)
+			return
+		}
+		d.PutNEP17Balance(to, id, bal.Add(bal, amount))
+	}
+}
+
+// GetNEP17Balances returns non-zero indexed NEP-17 balances of the account
+// by token IDs along with the set of tokens that can't be indexed. Balances
+// of these tokens are not valid and should be retrieved with balanceOf
+// invocation.
+func (bc *Blockchain) GetNEP17Balances(acc util.Uint160) (map[int32]*big.Int, map[int32]bool, error) {
+	if !bc.config.Ledger.TrackNEP17Balances {
+		return nil, nil, ErrNEP17BalancesDisabled
+	}
+	if !bc.nep17BalancesReady.Load() {
+		return nil, nil, ErrNEP17BalancesNotReady
+	}
+	var balances = make(map[int32]*big.Int)
+	bc.dao.SeekNEP17Balances(acc, func(id int32, balance *big.Int) bool {
+		balances[id] = balance
+		return true
+	})
+	return balances, bc.dao.GetNEP17UnreliableTokens(), nil
+}
+
+// ReindexNEP17Balances rebuilds NEP-17 balances index using balanceOf
+// invocations for every account that has NEP-17 transfers. It can only be
+// used on a non-running blockchain.
+func (bc *Blockchain) ReindexNEP17Balances() error {
+	if !bc.config.Ledger.TrackNEP17Balances {
+		return ErrNEP17BalancesDisabled
+	}
+	if bc.isRunning.Load().(bool) {
+		return errors.New("can't reindex balances of the running blockchain")
+	}
+	bc.log.Info("rebuilding NEP-17 balances index")
+	start := time.Now()
+	bc.nep17BalancesReady.Store(false)
+	bc.dao.Store.Delete(nep17BalancesStateKey)
+	bc.dao.DeleteAllNEP17Balances()
+
+	var tokens = make(map[int32]util.Uint160)
+	for _, h := range bc.GetNEP17Contracts() {
+		if id, ok := bc.nep17ContractID(bc.dao, h); ok {
+			tokens[id] = h
+		}
+	}
+	var accounts []util.Uint160
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{byte(storage.STTokenTransferInfo)}}, func(k, _ []byte) bool {
+		acc, err := util.Uint160DecodeBytesBE(k[1:])
+		if err == nil {
+			accounts = append(accounts, acc)
+		}
+		return true
+	})
+	var (
+		bw    = io.NewBufBinWriter()
+		cache = bc.dao.GetPrivate()
+	)
+	for i, acc := range accounts {
+		lastUpdated, err := bc.GetTokenLastUpdated(acc)
+		if err != nil {
+			return fmt.Errorf("failed to get transfer info for %s: %w", acc.StringLE(), err)
+		}
+		for id := range lastUpdated {
+			h, ok := tokens[id]
+			if !ok {
+				continue
+			}
+			bal, err := bc.invokeNEP17BalanceOf(bw, h, acc)
+			if err != nil {
+				cache.MarkNEP17Unreliable(id)
+				continue
+			}
+			cache.PutNEP17Balance(acc, id, bal)
+		}
+		if (i+1)%nep17ReindexBatch == 0 {
+			if _, err := cache.Persist(); err != nil {
+				return fmt.Errorf("failed to persist balances: %w", err)
+			}
+			bc.log.Info("NEP-17 balances reindexing", zap.Int("accounts", i+1), zap.Int("total", len(accounts)))
+		}
+	}
+	putNEP17BalancesState(cache, bc.BlockHeight())
+	if _, err := cache.Persist(); err != nil {
+		return fmt.Errorf("failed to persist balances: %w", err)
+	}
+	if _, err := bc.dao.PersistSync(); err != nil {
+		return fmt.Errorf("failed to persist balances to the DB: %w", err)
+	}
+	bc.nep17BalancesReady.Store(true)
+	bc.log.Info("NEP-17 balances index is rebuilt",
+		zap.Int("accounts", len(accounts)),
+		zap.Duration("took", time.Since(start)))
+	return nil
+}
+
+// invokeNEP17BalanceOf returns the result of balanceOf invocation for the
+// given token and account.
+func (bc *Blockchain) invokeNEP17BalanceOf(bw *io.BufBinWriter, h util.Uint160, acc util.Uint160) (*big.Int, error) {
+	bw.Reset()
+	emit.AppCall(bw.BinWriter, h, "balanceOf", callflag.ReadStates, acc)
+	if bw.Err != nil {
+		return nil, bw.Err
+	}
+	script := bw.Bytes()
+	ic, err := bc.GetTestVM(trigger.Application, &transaction.Transaction{Script: script}, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer ic.Finalize()
+	ic.VM.GasLimit = HeaderVerificationGasLimit
+	ic.VM.LoadScriptWithFlags(script, callflag.All)
+	if err := ic.VM.Run(); err != nil {
+		return nil, err
+	}
+	if ic.VM.Estack().Len() != 1 {
+		return nil, errors.New("invalid return values count")
+	}
+	return ic.VM.Estack().Pop().Item().TryInteger()
+}
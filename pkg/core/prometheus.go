@@ -1,6 +1,13 @@
 package core
 
 import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/native"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/prometheus/client_golang/prometheus"
 )
 
@@ -38,14 +45,137 @@ var (
 			Namespace: "neogo",
 		},
 	)
+	// txAdmissionRejected prometheus metric.
+	txAdmissionRejected = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Help:      "Number of transactions rejected by the node's transaction admission filter",
+			Name:      "mempool_admission_rejected_total",
+			Namespace: "neogo",
+		},
+	)
+	// mempoolParkedTx prometheus metric.
+	mempoolParkedTx = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of transactions in the mempool parking area",
+			Name:      "mempool_parked_tx",
+			Namespace: "neogo",
+		},
+	)
+	// mempoolParkingEvents prometheus metric.
+	mempoolParkingEvents = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of transactions parked, promoted from the parking area to the mempool and expired there",
+			Name:      "mempool_parking_total",
+			Namespace: "neogo",
+		},
+		[]string{"event"},
+	)
+	// storageUsageItems prometheus metric.
+	storageUsageItems = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Total number of persisted contract storage items (if storage usage tracking is enabled)",
+			Name:      "storage_usage_items",
+			Namespace: "neogo",
+		},
+	)
+	// storageUsageBytes prometheus metric.
+	storageUsageBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Total size of persisted contract storage items (if storage usage tracking is enabled)",
+			Name:      "storage_usage_bytes",
+			Namespace: "neogo",
+		},
+	)
+	// storageTierBlocks prometheus metric.
+	storageTierBlocks = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Number of blocks kept in the main DB (hot) and in the cold storage (if cold storage is enabled)",
+			Name:      "storage_tier_blocks",
+			Namespace: "neogo",
+		},
+		[]string{"tier"},
+	)
+	// coldStorageBytes prometheus metric.
+	coldStorageBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Total size of block data moved to the cold storage (if cold storage is enabled)",
+			Name:      "cold_storage_bytes",
+			Namespace: "neogo",
+		},
+	)
+	// coldStorageReadTime prometheus metric.
+	coldStorageReadTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Cold storage read-through latency (if cold storage is enabled)",
+			Name:      "cold_storage_read_seconds",
+			Namespace: "neogo",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 8),
+		},
+	)
+	// stateDivergence prometheus metric.
+	stateDivergence = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Set to 1 once local state root mismatch with the network one is detected (if state root check is enabled)",
+			Name:      "state_divergence",
+			Namespace: "neogo",
+		},
+	)
+	// nativeCallStats is a collector of native contract method invocation
+	// statistics.
+	nativeCallStats = &nativeCallStatsCollector{
+		calls: prometheus.NewDesc("neogo_native_calls_total",
+			"Number of native contract method invocations (if native call statistics gathering is enabled)",
+			[]string{"contract", "method", "params"}, nil),
+		gas: prometheus.NewDesc("neogo_native_gas_total",
+			"GAS spent by native contract method invocations (if native call statistics gathering is enabled)",
+			[]string{"contract", "method", "params"}, nil),
+	}
 )
 
+// nativeCallStatsCollector exposes native contract method invocation
+// statistics, they're collected from the contracts directly since they're
+// updated too often to be duplicated into metrics.
+type nativeCallStatsCollector struct {
+	calls     *prometheus.Desc
+	gas       *prometheus.Desc
+	contracts atomic.Pointer[[]interop.Contract]
+}
+
+// Describe implements prometheus.Collector interface.
+func (c *nativeCallStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.calls
+	ch <- c.gas
+}
+
+// Collect implements prometheus.Collector interface.
+func (c *nativeCallStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	cs := c.contracts.Load()
+	if cs == nil {
+		return
+	}
+	for _, st := range native.GetCallStats(*cs) {
+		params := strconv.Itoa(st.ParamCount)
+		ch <- prometheus.MustNewConstMetric(c.calls, prometheus.CounterValue, float64(st.Calls), st.Contract, st.Method, params)
+		ch <- prometheus.MustNewConstMetric(c.gas, prometheus.CounterValue, float64(st.GAS), st.Contract, st.Method, params)
+	}
+}
+
 func init() {
 	prometheus.MustRegister(
 		blockHeight,
 		persistedHeight,
 		headerHeight,
 		mempoolUnsortedTx,
+		txAdmissionRejected,
+		mempoolParkedTx,
+		mempoolParkingEvents,
+		storageUsageItems,
+		storageUsageBytes,
+		storageTierBlocks,
+		coldStorageBytes,
+		coldStorageReadTime,
+		stateDivergence,
+		nativeCallStats,
 	)
 }
 
@@ -61,7 +191,47 @@ func updateBlockHeightMetric(bHeight uint32) {
 	blockHeight.Set(float64(bHeight))
 }
 
+// updateStorageUsageMetric updates total contract storage usage metrics.
+func updateStorageUsageMetric(u state.StorageUsage) {
+	storageUsageItems.Set(float64(u.Items))
+	storageUsageBytes.Set(float64(u.Size))
+}
+
+// updateStorageTierMetrics updates the number of blocks in the main DB and
+// in the cold storage along with the size of cold storage data.
+func updateStorageTierMetrics(hot uint32, cold uint32, coldBytes uint64) {
+	storageTierBlocks.WithLabelValues("hot").Set(float64(hot))
+	storageTierBlocks.WithLabelValues("cold").Set(float64(cold))
+	coldStorageBytes.Set(float64(coldBytes))
+}
+
+// updateColdStorageReadMetric adds cold storage read duration.
+func updateColdStorageReadMetric(d time.Duration) {
+	coldStorageReadTime.Observe(d.Seconds())
+}
+
+// setNativeCallStatsMetric sets native contracts to collect invocation
+// statistics metrics from.
+func setNativeCallStatsMetric(cs []interop.Contract) {
+	nativeCallStats.contracts.Store(&cs)
+}
+
 // updateMempoolMetrics updates metric of the number of unsorted txs inside the mempool.
 func updateMempoolMetrics(unsortedTxnLen int) {
 	mempoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+// updateTxAdmissionRejectedMetric increments the number of transactions
+// rejected by the admission filter.
+func updateTxAdmissionRejectedMetric() {
+	txAdmissionRejected.Inc()
+}
+
+// updateParkingMetrics updates the number of parked transactions and adds
+// parked, promoted and expired transaction counts.
+func updateParkingMetrics(count int, parked int, promoted int, expired int) {
+	mempoolParkedTx.Set(float64(count))
+	mempoolParkingEvents.WithLabelValues("parked").Add(float64(parked))
+	mempoolParkingEvents.WithLabelValues("promoted").Add(float64(promoted))
+	mempoolParkingEvents.WithLabelValues("expired").Add(float64(expired))
+}
@@ -0,0 +1,283 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	istorage "github.com/nspcc-dev/neo-go/pkg/core/interop/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// ErrHistoricStateUnavailable is returned from ReplayTransaction if the state
+// required to re-execute the transaction is not stored by the node (it's
+// either pruned or the node keeps only the latest state).
+var ErrHistoricStateUnavailable = errors.New("historic state is not available")
+
+// Storage access types used in TraceStorageAccess.
+const (
+	StorageAccessGet    = "get"
+	StorageAccessPut    = "put"
+	StorageAccessDelete = "delete"
+	StorageAccessFind   = "find"
+)
+
+type (
+	// TraceWriter receives transaction execution details from
+	// ReplayTransaction. Its methods are called synchronously in the
+	// execution order.
+	TraceWriter interface {
+		// Instruction is called before every instruction executed.
+		Instruction(TraceInstruction)
+		// StorageAccess is called for every contract storage syscall
+		// (after its execution).
+		StorageAccess(TraceStorageAccess)
+		// Notification is called for every notification emitted.
+		Notification(state.NotificationEvent)
+	}
+
+	// TraceInstruction is a single VM instruction.
+	TraceInstruction struct {
+		// Depth is the invocation stack depth (1 for the entry script).
+		Depth int
+		// ScriptHash is the hash of the script being executed.
+		ScriptHash util.Uint160
+		// IP is the instruction offset in the script.
+		IP int
+		// Opcode is the instruction opcode.
+		Opcode opcode.Opcode
+		// GasConsumed is the amount of GAS consumed before this instruction.
+		GasConsumed int64
+	}
+
+	// TraceStorageAccess is a single contract storage syscall invocation.
+	TraceStorageAccess struct {
+		// Type is one of StorageAccess* constants.
+		Type string
+		// ScriptHash is the hash of the script performing the access.
+		ScriptHash util.Uint160
+		// ContractID is the ID of the contract whose storage is accessed.
+		ContractID int32
+		// Key is the key (or prefix for StorageAccessFind).
+		Key []byte
+		// Value is the value read (nil if there is no such item) or written.
+		Value []byte
+	}
+
+	// ReplayResult is the result of ReplayTransaction.
+	ReplayResult struct {
+		// Transaction is the transaction re-executed.
+		Transaction *transaction.Transaction
+		// BlockIndex is the index of the block containing the transaction.
+		BlockIndex uint32
+		// Execution contains execution results.
+		Execution state.Execution
+		// Fault is the instruction that caused the fault, it's nil if the
+		// transaction is executed successfully.
+		Fault *TraceInstruction
+	}
+)
+
+// ReplayTransaction re-executes the historical transaction with the given hash
+// in exactly the same environment it was executed in when its block was
+// persisted (the state at the previous block with OnPersist and all preceding
+// transactions of the block applied) and reports every instruction executed,
+// contract storage accesses and notifications to the given TraceWriter (which
+// can be nil). It requires historic states to be available (the node can't be
+// configured with KeepOnlyLatestState and the block must be within
+// MaxTraceableBlocks if RemoveUntraceableBlocks is enabled), otherwise
+// ErrHistoricStateUnavailable is returned. Nothing is changed in the chain
+// storage.
+func (bc *Blockchain) ReplayTransaction(h util.Uint256, tw TraceWriter) (*ReplayResult, error) {
+	tx, height, err := bc.GetTransaction(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction: %w", err)
+	}
+	if height == 0 {
+		return nil, errors.New("genesis block transactions can't be replayed")
+	}
+	b, err := bc.GetBlock(bc.GetHeaderHash(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", height, err)
+	}
+	d, err := bc.getHistoricDAO(height - 1)
+	if err != nil {
+		return nil, err
+	}
+	err = bc.initializeNativeCache(b.Index, d)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize native cache backed by historic DAO: %w", err)
+	}
+	_, v, err := bc.runPersist(bc.contracts.GetPersistScript(), b, d, trigger.OnPersist, nil)
+	if err != nil {
+		return nil, fmt.Errorf("onPersist failed: %w", err)
+	}
+	for _, t := range b.Transactions {
+		if t.Hash() == h {
+			break
+		}
+		ic := bc.newInteropContext(trigger.Application, d, b, t)
+		ic.ReuseVM(v)
+		v.LoadScriptWithFlags(t.Script, callflag.All)
+		v.GasLimit = t.SystemFee
+		_ = ic.Exec()
+		if !v.HasFailed() {
+			if _, err := ic.DAO.Persist(); err != nil {
+				return nil, fmt.Errorf("failed to persist %s invocation results: %w", t.Hash().StringLE(), err)
+			}
+		}
+	}
+	return bc.replayTx(d, b, tx, v, tw), nil
+}
+
+// getHistoricDAO returns a DAO backed by the MPT state at the given height,
+// all changes made to it are kept in memory.
+func (bc *Blockchain) getHistoricDAO(height uint32) (*dao.Simple, error) {
+	if bc.config.Ledger.KeepOnlyLatestState {
+		return nil, fmt.Errorf("%w: node keeps only the latest state", ErrHistoricStateUnavailable)
+	}
+	var mode = mpt.ModeAll
+	if bc.config.Ledger.RemoveUntraceableBlocks {
+		if height+bc.config.MaxTraceableBlocks < bc.BlockHeight() {
+			return nil, fmt.Errorf("%w: state for height %d is pruned", ErrHistoricStateUnavailable, height)
+		}
+		mode |= mpt.ModeGCFlag
+	}
+	sr, err := bc.stateRoot.GetStateRoot(height)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to retrieve state root for height %d: %w", ErrHistoricStateUnavailable, height, err)
+	}
+	s := mpt.NewTrieStore(sr.Root, mode, storage.NewPrivateMemCachedStore(bc.dao.Store))
+	d := dao.NewSimple(s, bc.config.StateRootInHeader)
+	d.Version = bc.dao.Version
+	return d, nil
+}
+
+// replayTx executes the transaction step by step reporting execution details
+// to the given TraceWriter.
+func (bc *Blockchain) replayTx(d *dao.Simple, b *block.Block, tx *transaction.Transaction, v *vm.VM, tw TraceWriter) *ReplayResult {
+	ic := bc.newInteropContext(trigger.Application, d, b, tx)
+	if tw != nil {
+		ic.Functions = traceStorageInterops(ic.Functions, tw)
+	}
+	ic.ReuseVM(v)
+	v.LoadScriptWithFlags(tx.Script, callflag.All)
+	v.GasLimit = tx.SystemFee
+
+	var (
+		res = &ReplayResult{
+			Transaction: tx,
+			BlockIndex:  b.Index,
+		}
+		last     TraceInstruction
+		notified int
+		err      error
+	)
+	for v.State() == vmstate.None {
+		ctx := v.Context()
+		ip, op := ctx.NextInstr()
+		last = TraceInstruction{
+			Depth:       len(v.Istack()),
+			ScriptHash:  ctx.ScriptHash(),
+			IP:          ip,
+			Opcode:      op,
+			GasConsumed: v.GasConsumed(),
+		}
+		if tw != nil {
+			tw.Instruction(last)
+		}
+		err = v.Step()
+		if tw != nil {
+			for ; notified < len(ic.Notifications); notified++ {
+				tw.Notification(ic.Notifications[notified])
+			}
+		}
+	}
+	ic.Finalize()
+
+	res.Execution = state.Execution{
+		Trigger:     trigger.Application,
+		VMState:     v.State(),
+		GasConsumed: v.GasConsumed(),
+		Stack:       v.Estack().ToArray(),
+		Events:      ic.Notifications,
+	}
+	if v.HasFailed() {
+		if err != nil {
+			res.Execution.FaultException = err.Error()
+		}
+		res.Fault = &last
+	}
+	return res
+}
+
+// traceStorageInterops returns a copy of the given interop functions list with
+// storage functions wrapped to report storage accesses.
+func traceStorageInterops(fs []interop.Function, tw TraceWriter) []interop.Function {
+	res := make([]interop.Function, len(fs))
+	copy(res, fs)
+	for i := range res {
+		var typ string
+		switch res[i].Name {
+		case interopnames.SystemStorageGet:
+			typ = StorageAccessGet
+		case interopnames.SystemStoragePut:
+			typ = StorageAccessPut
+		case interopnames.SystemStorageDelete:
+			typ = StorageAccessDelete
+		case interopnames.SystemStorageFind, interopnames.SystemStorageFindFrom:
+			typ = StorageAccessFind
+		default:
+			continue
+		}
+		res[i].Func = traceStorageFunc(typ, res[i].Func, tw)
+	}
+	return res
+}
+
+// traceStorageFunc wraps the given storage interop function to report
+// storage accesses.
+func traceStorageFunc(typ string, f func(*interop.Context) error, tw TraceWriter) func(*interop.Context) error {
+	return func(ic *interop.Context) error {
+		var (
+			estack = ic.VM.Estack()
+			acc    = TraceStorageAccess{
+				Type:       typ,
+				ScriptHash: ic.VM.GetCurrentScriptHash(),
+			}
+		)
+		if estack.Len() >= 2 {
+			if stc, ok := estack.Peek(0).Value().(*istorage.Context); ok {
+				acc.ContractID = stc.ID
+			}
+			acc.Key, _ = estack.Peek(1).Item().TryBytes()
+		}
+		if typ == StorageAccessPut && estack.Len() >= 3 {
+			acc.Value, _ = estack.Peek(2).Item().TryBytes()
+		}
+		err := f(ic)
+		if err != nil {
+			return err
+		}
+		if typ == StorageAccessGet && estack.Len() != 0 {
+			if item := estack.Peek(0).Item(); item.Type() != stackitem.AnyT {
+				acc.Value, _ = item.TryBytes()
+			}
+		}
+		tw.StorageAccess(acc)
+		return nil
+	}
+}
@@ -0,0 +1,144 @@
+package core_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+type testTraceWriter struct {
+	instructions []core.TraceInstruction
+	storage      []core.TraceStorageAccess
+	events       []state.NotificationEvent
+}
+
+func (w *testTraceWriter) Instruction(i core.TraceInstruction) {
+	w.instructions = append(w.instructions, i)
+}
+
+func (w *testTraceWriter) StorageAccess(a core.TraceStorageAccess) {
+	w.storage = append(w.storage, a)
+}
+
+func (w *testTraceWriter) Notification(e state.NotificationEvent) {
+	w.events = append(w.events, e)
+}
+
+func TestBlockchain_ReplayTransaction(t *testing.T) {
+	const src = `package replay
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+	)
+	func Inc(k []byte) int {
+		ctx := storage.GetContext()
+		v := storage.Get(ctx, k)
+		var n int
+		if v != nil {
+			n = v.(int)
+		}
+		n++
+		storage.Put(ctx, k, n)
+		runtime.Notify("Inc", n)
+		return n
+	}
+	func Fail(k []byte) {
+		n := Inc(k)
+		if n > 0 {
+			panic("boom")
+		}
+	}`
+
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(src), &compiler.Options{
+		Name: "Replay",
+		ContractEvents: []compiler.HybridEvent{{
+			Name:       "Inc",
+			Parameters: []compiler.HybridParameter{{Parameter: manifest.NewParameter("n", smartcontract.IntegerType)}},
+		}},
+	})
+	e.DeployContract(t, c, nil)
+	ctr := e.CommitteeInvoker(c.Hash)
+
+	key := []byte{1}
+	ctr.Invoke(t, 1, "inc", key) // Make fee estimations correct.
+	tx1 := ctr.PrepareInvoke(t, "inc", key)
+	tx2 := ctr.PrepareInvoke(t, "inc", key)
+	tx3 := ctr.PrepareInvoke(t, "fail", key)
+	e.AddNewBlock(t, tx1, tx2, tx3)
+	aer2 := e.CheckHalt(t, tx2.Hash())
+	e.CheckFault(t, tx3.Hash(), "boom")
+	ctr.Invoke(t, 4, "inc", key) // Change the state after the block.
+
+	t.Run("unknown transaction", func(t *testing.T) {
+		_, err := bc.ReplayTransaction(util.Uint256{1, 2, 3}, nil)
+		require.Error(t, err)
+	})
+	t.Run("halt", func(t *testing.T) {
+		tw := new(testTraceWriter)
+		res, err := bc.ReplayTransaction(tx2.Hash(), tw)
+		require.NoError(t, err)
+		require.Equal(t, tx2, res.Transaction)
+		require.Equal(t, bc.BlockHeight()-1, res.BlockIndex)
+		require.Nil(t, res.Fault)
+		expected, err := json.Marshal(aer2.Execution)
+		require.NoError(t, err)
+		actual, err := json.Marshal(res.Execution)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(actual))
+
+		require.NotEmpty(t, tw.instructions)
+		require.Equal(t, 1, tw.instructions[0].Depth)
+		require.Equal(t, tx2.Script[0], byte(tw.instructions[0].Opcode))
+		require.Equal(t, opcode.RET, tw.instructions[len(tw.instructions)-1].Opcode)
+		require.Equal(t, []core.TraceStorageAccess{
+			{Type: core.StorageAccessGet, ScriptHash: c.Hash, ContractID: 1, Key: key, Value: []byte{2}},
+			{Type: core.StorageAccessPut, ScriptHash: c.Hash, ContractID: 1, Key: key, Value: []byte{3}},
+		}, tw.storage)
+		require.Equal(t, len(aer2.Events), len(tw.events))
+		require.Equal(t, aer2.Events[0].Name, tw.events[0].Name)
+	})
+	t.Run("fault", func(t *testing.T) {
+		tw := new(testTraceWriter)
+		res, err := bc.ReplayTransaction(tx3.Hash(), tw)
+		require.NoError(t, err)
+		require.Equal(t, vmstate.Fault, res.Execution.VMState)
+		require.Contains(t, res.Execution.FaultException, "boom")
+		require.NotNil(t, res.Fault)
+		require.Equal(t, opcode.THROW, res.Fault.Opcode)
+		require.Equal(t, c.Hash, res.Fault.ScriptHash)
+		require.Equal(t, tw.instructions[len(tw.instructions)-1], *res.Fault)
+		require.Equal(t, 1, len(tw.events))
+		require.Equal(t, 2, len(tw.storage))
+		require.Equal(t, []byte{4}, tw.storage[1].Value)
+	})
+	t.Run("no trace writer", func(t *testing.T) {
+		res, err := bc.ReplayTransaction(tx1.Hash(), nil)
+		require.NoError(t, err)
+		require.Equal(t, vmstate.Halt, res.Execution.VMState)
+		require.Equal(t, 1, len(res.Execution.Events))
+	})
+	t.Run("latest state only", func(t *testing.T) {
+		bc, acc := chain.NewSingleWithCustomConfig(t, func(c *config.Blockchain) {
+			c.Ledger.KeepOnlyLatestState = true
+		})
+		e := neotest.NewExecutor(t, bc, acc, acc)
+		h := e.InvokeScript(t, []byte{byte(opcode.PUSH1)}, []neotest.Signer{acc})
+		_, err := bc.ReplayTransaction(h, nil)
+		require.ErrorIs(t, err, core.ErrHistoricStateUnavailable)
+	})
+}
@@ -0,0 +1,182 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Invocation tree limits. Calls that are nested deeper than
+// MaxInvocationTreeDepth or exceed MaxInvocationTreeSize are not recorded.
+const (
+	// MaxInvocationTreeDepth is the maximum depth of recorded calls (entry
+	// script has depth 0).
+	MaxInvocationTreeDepth = 64
+	// MaxInvocationTreeSize is the maximum number of recorded calls (not
+	// including the entry script).
+	MaxInvocationTreeSize = 2048
+)
+
+// Invocation is a single contract call made during script execution along
+// with all calls made by it.
+type Invocation struct {
+	// Hash is the script hash of the called contract (or of the entry
+	// script for the tree root).
+	Hash util.Uint160
+	// Method is the name of the called method, it's empty for the entry
+	// script.
+	Method string
+	// GAS is the amount of GAS consumed by this call including all nested
+	// calls.
+	GAS   int64
+	Calls []*Invocation
+}
+
+// InvocationTree is a tree of contract calls made during a single script
+// execution. It's node-local data not included into the AppExecResult
+// serialized form and stored only if the corresponding setting is enabled.
+type InvocationTree struct {
+	// Invocation is the entry script invocation.
+	Invocation
+	// Truncated is true if some calls were not recorded because of depth or
+	// size limits.
+	Truncated bool
+}
+
+// invocationAux is an auxiliary struct for Invocation JSON marshalling.
+type invocationAux struct {
+	Hash   util.Uint160     `json:"hash"`
+	Method string           `json:"method,omitempty"`
+	GAS    int64            `json:"gasconsumed,string"`
+	Depth  int              `json:"depth"`
+	Calls  []*invocationAux `json:"calls,omitempty"`
+}
+
+// invocationTreeAux is an auxiliary struct for InvocationTree JSON marshalling.
+type invocationTreeAux struct {
+	*invocationAux
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// EncodeBinary implements the Serializable interface.
+func (inv *Invocation) EncodeBinary(w *io.BinWriter) {
+	inv.Hash.EncodeBinary(w)
+	w.WriteString(inv.Method)
+	w.WriteU64LE(uint64(inv.GAS))
+	w.WriteVarUint(uint64(len(inv.Calls)))
+	for _, c := range inv.Calls {
+		c.EncodeBinary(w)
+	}
+}
+
+// DecodeBinary implements the Serializable interface.
+func (inv *Invocation) DecodeBinary(r *io.BinReader) {
+	var size int
+	inv.decodeBinary(r, 0, &size)
+}
+
+func (inv *Invocation) decodeBinary(r *io.BinReader, depth int, size *int) {
+	inv.Hash.DecodeBinary(r)
+	inv.Method = r.ReadString()
+	inv.GAS = int64(r.ReadU64LE())
+	n := r.ReadVarUint()
+	if r.Err != nil {
+		return
+	}
+	if n == 0 {
+		inv.Calls = nil
+		return
+	}
+	if depth >= MaxInvocationTreeDepth || uint64(*size)+n > MaxInvocationTreeSize {
+		r.Err = errors.New("invocation tree is too big")
+		return
+	}
+	*size += int(n)
+	inv.Calls = make([]*Invocation, n)
+	for i := range inv.Calls {
+		inv.Calls[i] = new(Invocation)
+		inv.Calls[i].decodeBinary(r, depth+1, size)
+		if r.Err != nil {
+			return
+		}
+	}
+}
+
+// EncodeBinary implements the Serializable interface.
+func (t *InvocationTree) EncodeBinary(w *io.BinWriter) {
+	w.WriteBool(t.Truncated)
+	t.Invocation.EncodeBinary(w)
+}
+
+// DecodeBinary implements the Serializable interface.
+func (t *InvocationTree) DecodeBinary(r *io.BinReader) {
+	t.Truncated = r.ReadBool()
+	t.Invocation.DecodeBinary(r)
+}
+
+func (inv *Invocation) toAux(depth int) *invocationAux {
+	aux := &invocationAux{
+		Hash:   inv.Hash,
+		Method: inv.Method,
+		GAS:    inv.GAS,
+		Depth:  depth,
+	}
+	if len(inv.Calls) != 0 {
+		aux.Calls = make([]*invocationAux, len(inv.Calls))
+		for i, c := range inv.Calls {
+			aux.Calls[i] = c.toAux(depth + 1)
+		}
+	}
+	return aux
+}
+
+func (aux *invocationAux) toInvocation(inv *Invocation) {
+	inv.Hash = aux.Hash
+	inv.Method = aux.Method
+	inv.GAS = aux.GAS
+	inv.Calls = nil
+	if len(aux.Calls) != 0 {
+		inv.Calls = make([]*Invocation, len(aux.Calls))
+		for i, c := range aux.Calls {
+			inv.Calls[i] = new(Invocation)
+			c.toInvocation(inv.Calls[i])
+		}
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface. Every call is
+// marshaled along with its depth in the tree.
+func (inv Invocation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(inv.toAux(0))
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (inv *Invocation) UnmarshalJSON(data []byte) error {
+	aux := new(invocationAux)
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	aux.toInvocation(inv)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t InvocationTree) MarshalJSON() ([]byte, error) {
+	return json.Marshal(&invocationTreeAux{
+		invocationAux: t.Invocation.toAux(0),
+		Truncated:     t.Truncated,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *InvocationTree) UnmarshalJSON(data []byte) error {
+	aux := &invocationTreeAux{invocationAux: new(invocationAux)}
+	if err := json.Unmarshal(data, aux); err != nil {
+		return err
+	}
+	aux.invocationAux.toInvocation(&t.Invocation)
+	t.Truncated = aux.Truncated
+	return nil
+}
@@ -0,0 +1,56 @@
+package state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/internal/testserdes"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvocationTreeSerialization(t *testing.T) {
+	leaf := &Invocation{Hash: random.Uint160(), Method: "leaf", GAS: 10}
+	tree := &InvocationTree{
+		Invocation: Invocation{
+			Hash: random.Uint160(),
+			GAS:  100,
+			Calls: []*Invocation{{
+				Hash:   random.Uint160(),
+				Method: "top",
+				GAS:    90,
+				Calls:  []*Invocation{leaf, leaf},
+			}},
+		},
+		Truncated: true,
+	}
+	testserdes.EncodeDecodeBinary(t, tree, new(InvocationTree))
+	testserdes.MarshalUnmarshalJSON(t, tree, new(InvocationTree))
+
+	data, err := json.Marshal(tree)
+	require.NoError(t, err)
+	var aux map[string]any
+	require.NoError(t, json.Unmarshal(data, &aux))
+	require.Equal(t, float64(0), aux["depth"])
+	require.Equal(t, "100", aux["gasconsumed"])
+	require.Equal(t, true, aux["truncated"])
+	require.NotContains(t, aux, "method")
+	top := aux["calls"].([]any)[0].(map[string]any)
+	require.Equal(t, float64(1), top["depth"])
+	require.Equal(t, "top", top["method"])
+	require.Equal(t, float64(2), top["calls"].([]any)[1].(map[string]any)["depth"])
+
+	t.Run("too deep", func(t *testing.T) {
+		deep := &InvocationTree{}
+		for inv, i := &deep.Invocation, 0; i <= MaxInvocationTreeDepth; i++ {
+			inv.Calls = []*Invocation{{Hash: random.Uint160()}}
+			inv = inv.Calls[0]
+		}
+		data, err := testserdes.EncodeBinary(deep)
+		require.NoError(t, err)
+		r := io.NewBinReaderFromBuf(data)
+		new(InvocationTree).DecodeBinary(r)
+		require.Error(t, r.Err)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"crypto/elliptic"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -168,3 +169,113 @@ func (s *NEOBalance) FromStackItem(item stackitem.Item) error {
 	}
 	return nil
 }
+
+// NEOUnclaimedGAS represents the breakdown of GAS generated for a NEO holder
+// since the last claim.
+type NEOUnclaimedGAS struct {
+	// HolderReward is the GAS generated for holding NEO.
+	HolderReward big.Int
+	// VoterReward is the GAS generated for voting for a committee member.
+	VoterReward big.Int
+	// LastClaimHeight is the height of the last NEO balance change (and
+	// GAS claim) of the account.
+	LastClaimHeight uint32
+}
+
+// ToStackItem implements stackitem.Convertible interface. It never returns an error.
+func (s *NEOUnclaimedGAS) ToStackItem() (stackitem.Item, error) {
+	return stackitem.NewStruct([]stackitem.Item{
+		stackitem.NewBigInteger(&s.HolderReward),
+		stackitem.NewBigInteger(&s.VoterReward),
+		stackitem.NewBigInteger(big.NewInt(int64(s.LastClaimHeight))),
+	}), nil
+}
+
+// FromStackItem converts stackitem.Item to NEOUnclaimedGAS.
+func (s *NEOUnclaimedGAS) FromStackItem(item stackitem.Item) error {
+	structItem, ok := item.Value().([]stackitem.Item)
+	if !ok || len(structItem) != 3 {
+		return errors.New("invalid stackitem length")
+	}
+	holder, err := structItem[0].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid holder reward stackitem: %w", err)
+	}
+	voter, err := structItem[1].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid voter reward stackitem: %w", err)
+	}
+	h, err := structItem[2].TryInteger()
+	if err != nil || !h.IsUint64() || h.Uint64() > math.MaxUint32 {
+		return errors.New("invalid last claim height stackitem")
+	}
+	s.HolderReward = *holder
+	s.VoterReward = *voter
+	s.LastClaimHeight = uint32(h.Uint64())
+	return nil
+}
+
+// NEOVoterInfo represents voting data of a NEO holder.
+type NEOVoterInfo struct {
+	// VoteTo is the candidate the account votes for, nil if it doesn't vote.
+	VoteTo *keys.PublicKey
+	// BalanceHeight is the height of the last NEO balance change of the
+	// account.
+	BalanceHeight uint32
+	// LastGasPerVote is the candidate's accumulated GAS per vote value
+	// at BalanceHeight, voter reward is calculated starting from it.
+	LastGasPerVote big.Int
+	// LatestGasPerVote is the current candidate's accumulated GAS per vote
+	// value.
+	LatestGasPerVote big.Int
+}
+
+// ToStackItem implements stackitem.Convertible interface. It never returns an error.
+func (s *NEOVoterInfo) ToStackItem() (stackitem.Item, error) {
+	var voteItem stackitem.Item = stackitem.Null{}
+	if s.VoteTo != nil {
+		voteItem = stackitem.NewByteArray(s.VoteTo.Bytes())
+	}
+	return stackitem.NewStruct([]stackitem.Item{
+		voteItem,
+		stackitem.NewBigInteger(big.NewInt(int64(s.BalanceHeight))),
+		stackitem.NewBigInteger(&s.LastGasPerVote),
+		stackitem.NewBigInteger(&s.LatestGasPerVote),
+	}), nil
+}
+
+// FromStackItem converts stackitem.Item to NEOVoterInfo.
+func (s *NEOVoterInfo) FromStackItem(item stackitem.Item) error {
+	structItem, ok := item.Value().([]stackitem.Item)
+	if !ok || len(structItem) != 4 {
+		return errors.New("invalid stackitem length")
+	}
+	var pub *keys.PublicKey
+	if _, ok := structItem[0].(stackitem.Null); !ok {
+		bs, err := structItem[0].TryBytes()
+		if err != nil {
+			return fmt.Errorf("invalid public key stackitem: %w", err)
+		}
+		pub, err = keys.NewPublicKeyFromBytes(bs, elliptic.P256())
+		if err != nil {
+			return fmt.Errorf("invalid public key bytes: %w", err)
+		}
+	}
+	h, err := structItem[1].TryInteger()
+	if err != nil || !h.IsUint64() || h.Uint64() > math.MaxUint32 {
+		return errors.New("invalid balance height stackitem")
+	}
+	last, err := structItem[2].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid last GAS per vote stackitem: %w", err)
+	}
+	latest, err := structItem[3].TryInteger()
+	if err != nil {
+		return fmt.Errorf("invalid latest GAS per vote stackitem: %w", err)
+	}
+	s.VoteTo = pub
+	s.BalanceHeight = uint32(h.Uint64())
+	s.LastGasPerVote = *last
+	s.LatestGasPerVote = *latest
+	return nil
+}
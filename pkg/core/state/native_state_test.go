@@ -79,6 +79,49 @@ func TestNEOBalanceSerialization(t *testing.T) {
 	require.Equal(t, b, bb)
 }
 
+func TestNEOUnclaimedGASSerialization(t *testing.T) {
+	var u = NEOUnclaimedGAS{
+		HolderReward:    *big.NewInt(100500),
+		VoterReward:     *big.NewInt(42),
+		LastClaimHeight: 7,
+	}
+	si, err := u.ToStackItem()
+	require.NoError(t, err)
+
+	var uu NEOUnclaimedGAS
+	require.NoError(t, uu.FromStackItem(si))
+	require.Equal(t, u, uu)
+
+	require.Error(t, uu.FromStackItem(stackitem.Make(1)))
+	require.Error(t, uu.FromStackItem(stackitem.Make([]stackitem.Item{stackitem.Make(1), stackitem.Make(2)})))
+	require.Error(t, uu.FromStackItem(stackitem.Make([]stackitem.Item{stackitem.Make(1), stackitem.Make(2), stackitem.Make(-1)})))
+}
+
+func TestNEOVoterInfoSerialization(t *testing.T) {
+	var v = NEOVoterInfo{
+		BalanceHeight:    42,
+		LastGasPerVote:   *big.NewInt(100),
+		LatestGasPerVote: *big.NewInt(500),
+	}
+	si, err := v.ToStackItem()
+	require.NoError(t, err)
+
+	var vv NEOVoterInfo
+	require.NoError(t, vv.FromStackItem(si))
+	require.Equal(t, v, vv)
+
+	v.VoteTo, err = keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+	require.NoError(t, err)
+	si, err = v.ToStackItem()
+	require.NoError(t, err)
+	vv = NEOVoterInfo{}
+	require.NoError(t, vv.FromStackItem(si))
+	require.Equal(t, v, vv)
+
+	require.Error(t, vv.FromStackItem(stackitem.Make(1)))
+	require.Error(t, vv.FromStackItem(stackitem.Make([]stackitem.Item{stackitem.Make([]byte{1}), stackitem.Make(1), stackitem.Make(2), stackitem.Make(3)})))
+}
+
 func BenchmarkNEP17BalanceBytes(b *testing.B) {
 	var bl NEP17Balance
 	bl.Balance.SetInt64(0x12345678910)
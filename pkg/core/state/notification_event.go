@@ -209,6 +209,10 @@ type Execution struct {
 	Stack          []stackitem.Item
 	Events         []NotificationEvent
 	FaultException string
+	// InvocationTree is the tree of contract calls made during execution.
+	// It's node-local data that is not a part of the Execution serialized
+	// form, it's nil unless invocation trees are saved by the node.
+	InvocationTree *InvocationTree
 }
 
 // executionAux represents an auxiliary struct for Execution JSON marshalling.
@@ -219,6 +223,7 @@ type executionAux struct {
 	Stack          json.RawMessage     `json:"stack"`
 	Events         []NotificationEvent `json:"notifications"`
 	FaultException *string             `json:"exception"`
+	InvocationTree *InvocationTree     `json:"invocationtree,omitempty"`
 }
 
 // MarshalJSON implements the json.Marshaler interface.
@@ -246,6 +251,7 @@ func (e Execution) MarshalJSON() ([]byte, error) {
 		Stack:          st,
 		Events:         e.Events,
 		FaultException: exception,
+		InvocationTree: e.InvocationTree,
 	})
 }
 
@@ -284,6 +290,7 @@ func (e *Execution) UnmarshalJSON(data []byte) error {
 	e.VMState = state
 	e.Events = aux.Events
 	e.GasConsumed = aux.GasConsumed
+	e.InvocationTree = aux.InvocationTree
 	if aux.FaultException != nil {
 		e.FaultException = *aux.FaultException
 	}
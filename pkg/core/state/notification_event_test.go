@@ -187,6 +187,24 @@ func TestMarshalUnmarshalJSONAppExecResult(t *testing.T) {
 		}
 		testserdes.MarshalUnmarshalJSON(t, appExecResult, new(AppExecResult))
 	})
+	t.Run("positive, invocation tree", func(t *testing.T) {
+		appExecResult := &AppExecResult{
+			Container: random.Uint256(),
+			Execution: Execution{
+				Trigger:     trigger.Application,
+				VMState:     vmstate.Halt,
+				GasConsumed: 10,
+				Stack:       []stackitem.Item{},
+				Events:      []NotificationEvent{},
+				InvocationTree: &InvocationTree{Invocation: Invocation{
+					Hash:  random.Uint160(),
+					GAS:   10,
+					Calls: []*Invocation{{Hash: random.Uint160(), Method: "transfer", GAS: 7}},
+				}},
+			},
+		}
+		testserdes.MarshalUnmarshalJSON(t, appExecResult, new(AppExecResult))
+	})
 
 	t.Run("MarshalJSON recursive reference", func(t *testing.T) {
 		arr := stackitem.NewArray(nil)
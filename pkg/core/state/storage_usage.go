@@ -0,0 +1,44 @@
+package state
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// storageUsageSize is the size of serialized StorageUsage.
+const storageUsageSize = 16
+
+// StorageUsage contains contract storage usage statistics. It's not a part
+// of the contract state, it's node-local data maintained only if the
+// corresponding setting is enabled.
+type StorageUsage struct {
+	// Items is the number of contract storage items.
+	Items int64
+	// Size is the total size of contract storage keys and values in bytes.
+	Size int64
+}
+
+// Bytes returns serialized StorageUsage.
+func (u StorageUsage) Bytes() []byte {
+	var buf = make([]byte, storageUsageSize)
+	binary.LittleEndian.PutUint64(buf, uint64(u.Items))
+	binary.LittleEndian.PutUint64(buf[8:], uint64(u.Size))
+	return buf
+}
+
+// StorageUsageFromBytes deserializes StorageUsage.
+func StorageUsageFromBytes(b []byte) (StorageUsage, error) {
+	if len(b) != storageUsageSize {
+		return StorageUsage{}, errors.New("invalid storage usage length")
+	}
+	return StorageUsage{
+		Items: int64(binary.LittleEndian.Uint64(b)),
+		Size:  int64(binary.LittleEndian.Uint64(b[8:])),
+	}, nil
+}
+
+// ContractStorageUsage is a StorageUsage of the contract with the given ID.
+type ContractStorageUsage struct {
+	ID int32
+	StorageUsage
+}
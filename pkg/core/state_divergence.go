@@ -0,0 +1,29 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// ErrStateDivergence is returned from AddBlock and AddHeaders once local state
+// divergence is detected if StateRootCheck.HaltOnMismatch is enabled.
+var ErrStateDivergence = errors.New("local state diverged from the network one, block processing is halted")
+
+// reportStateDivergence handles local state root mismatch with the reference
+// one (taken from the given source) for the given height. Block processing is
+// halted after that if configured, the node needs to be resynchronized then.
+func (bc *Blockchain) reportStateDivergence(index uint32, local, reference util.Uint256, source string) {
+	halt := bc.config.StateRootCheck.HaltOnMismatch
+	bc.log.Error("LOCAL STATE DIVERGENCE DETECTED, local state root doesn't match the network one",
+		zap.Uint32("height", index),
+		zap.String("local", local.StringLE()),
+		zap.String("reference", reference.StringLE()),
+		zap.String("source", source),
+		zap.Bool("halt", halt))
+	stateDivergence.Set(1)
+	if halt {
+		bc.stateDiverged.Store(true)
+	}
+}
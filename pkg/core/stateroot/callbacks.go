@@ -1,6 +1,7 @@
 package stateroot
 
 import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 )
 
@@ -10,3 +11,11 @@ func (s *Module) SetUpdateValidatorsCallback(f func(uint32, keys.PublicKeys)) {
 	defer s.mtx.Unlock()
 	s.updateValidatorsCb = f
 }
+
+// SetMismatchCallback sets callback for state roots signed by state validators
+// that don't match the local ones.
+func (s *Module) SetMismatchCallback(f func(local, validated *state.MPTRoot)) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.mismatchCb = f
+}
@@ -44,6 +44,7 @@ type (
 		keys []keyCache
 
 		updateValidatorsCb func(height uint32, publicKeys keys.PublicKeys)
+		mismatchCb         func(local, validated *state.MPTRoot)
 	}
 
 	keyCache struct {
@@ -80,6 +81,17 @@ func (s *Module) GetState(root util.Uint256, key []byte) ([]byte, error) {
 	return tr.Get(key)
 }
 
+// HasState returns true if the MPT with the specified root is available in the
+// storage, i.e. it's either the current state or an old state that is not yet
+// removed by the garbage collector.
+func (s *Module) HasState(root util.Uint256) bool {
+	if root.Equals(util.Uint256{}) {
+		return true // Empty MPT.
+	}
+	_, err := s.Store.Get(append([]byte{byte(storage.DataMPT)}, root[:]...))
+	return err == nil
+}
+
 // FindStates returns a set of key-value pairs with keys matching the prefix starting
 // from the `prefix`+`start` path from MPT with the specified root. `max` is
 // the maximum number of elements to be returned. If nil `start` is specified, then the
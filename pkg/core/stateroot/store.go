@@ -66,6 +66,12 @@ func (s *Module) AddStateRoot(sr *state.MPTRoot) error {
 		return err
 	}
 	if !local.Root.Equals(sr.Root) {
+		s.mtx.RLock()
+		cb := s.mismatchCb
+		s.mtx.RUnlock()
+		if cb != nil {
+			cb(local, sr)
+		}
 		return fmt.Errorf("%w at block %d: %v vs %v", ErrStateMismatch, sr.Index, local.Root, sr.Root)
 	}
 	if len(local.Witness) != 0 {
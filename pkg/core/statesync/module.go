@@ -11,9 +11,14 @@ includes the following stages:
 3. Fetching blocks starting from height P-MaxTraceableBlocks (or 0) up to P.
 
 Steps 2 and 3 are being performed in parallel. Once all the data are collected
-and stored in the db, an atomic state jump is occurred to the state sync point P.
-Further node operation process is performed using standard sync mechanism until
-the node reaches synchronised state.
+and stored in the db, the restored MPT is verified against the state root and
+an atomic state jump is occurred to the state sync point P. Further node
+operation process is performed using standard sync mechanism until the node
+reaches synchronised state.
+
+The process can be interrupted at any moment, all fetched data are kept in the
+db and the process is resumed on the next node start. MPT nodes stored before
+the restart are checked against their hashes, so corrupted data is refetched.
 */
 package statesync
 
@@ -22,6 +27,7 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
@@ -29,6 +35,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
 	"github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/zap"
@@ -91,6 +98,10 @@ type Module struct {
 	mptpool  *Pool
 
 	billet *mpt.Billet
+	// mptRoot is the state root MPT nodes are fetched for.
+	mptRoot util.Uint256
+	// mptProgress is the MPT restoring progress.
+	mptProgress *mptProgress
 
 	jumpCallback func(p uint32) error
 }
@@ -218,39 +229,10 @@ func (s *Module) defineSyncStage() error {
 		if err != nil {
 			return fmt.Errorf("failed to get header to initialize MPT billet: %w", err)
 		}
-		var mode mpt.TrieMode
-		// No need to enable GC here, it only has latest things.
-		if s.bc.GetConfig().Ledger.KeepOnlyLatestState || s.bc.GetConfig().Ledger.RemoveUntraceableBlocks {
-			mode |= mpt.ModeLatest
-		}
-		s.billet = mpt.NewBillet(header.PrevStateRoot, mode,
-			TemporaryPrefix(s.dao.Version.StoragePrefix), s.dao.Store)
-		s.log.Info("MPT billet initialized",
-			zap.Uint32("height", s.syncPoint),
-			zap.String("state root", header.PrevStateRoot.StringBE()))
-		pool := NewPool()
-		pool.Add(header.PrevStateRoot, []byte{})
-		err = s.billet.Traverse(func(_ []byte, n mpt.Node, _ []byte) bool {
-			nPaths, ok := pool.TryGet(n.Hash())
-			if !ok {
-				// if this situation occurs, then it's a bug in MPT pool or Traverse.
-				panic("failed to get MPT node from the pool")
-			}
-			pool.Remove(n.Hash())
-			childrenPaths := make(map[util.Uint256][][]byte)
-			for _, path := range nPaths {
-				nChildrenPaths := mpt.GetChildrenPaths(path, n)
-				for hash, paths := range nChildrenPaths {
-					childrenPaths[hash] = append(childrenPaths[hash], paths...) // it's OK to have duplicates, they'll be handled by mempool
-				}
-			}
-			pool.Update(nil, childrenPaths)
-			return false
-		}, true)
+		err = s.initMPT(header.PrevStateRoot)
 		if err != nil {
-			return fmt.Errorf("failed to traverse MPT during initialization: %w", err)
+			return err
 		}
-		s.mptpool.Update(nil, pool.GetAll())
 		if s.mptpool.Count() == 0 {
 			s.syncStage |= mptSynced
 			s.log.Info("MPT is in sync",
@@ -258,6 +240,12 @@ func (s *Module) defineSyncStage() error {
 		}
 	}
 
+	if s.syncStage == headersSynced|blocksSynced|mptSynced && s.bc.BlockHeight() < s.syncPoint {
+		// All the data were collected, but the node was stopped before the
+		// state jump.
+		s.checkSyncIsCompleted()
+		return nil
+	}
 	if s.syncStage == headersSynced|blocksSynced|mptSynced {
 		s.log.Info("state is in sync, starting regular blocks processing")
 		s.syncStage = inactive
@@ -265,6 +253,137 @@ func (s *Module) defineSyncStage() error {
 	return nil
 }
 
+// initMPT initializes MPT billet and pool for the given state root. MPT nodes
+// that are already stored are checked against their hashes. If any of them is
+// corrupted or the nodes were collected for a different root, all of them are
+// removed and MPT synchronisation starts from scratch.
+func (s *Module) initMPT(root util.Uint256) error {
+	storedRoot, err := s.dao.GetStateSyncRoot()
+	if err == nil && storedRoot != root {
+		s.log.Warn("stored MPT nodes belong to a different state root, dropping them",
+			zap.String("stored", storedRoot.StringBE()),
+			zap.String("expected", root.StringBE()))
+		err = s.resetMPT()
+		if err != nil {
+			return err
+		}
+	}
+	s.dao.PutStateSyncRoot(root)
+	err = s.restoreMPT(root)
+	if errors.Is(err, mpt.ErrVerificationFailed) {
+		s.log.Warn("corrupted MPT node found in the storage, dropping stored nodes", zap.Error(err))
+		err = s.resetMPT()
+		if err == nil {
+			err = s.restoreMPT(root)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to traverse MPT during initialization: %w", err)
+	}
+	return nil
+}
+
+// restoreMPT creates MPT billet for the given state root and fills MPT pool
+// with nodes that are missing from the storage. mpt.ErrVerificationFailed is
+// returned if a stored node doesn't match its hash.
+func (s *Module) restoreMPT(root util.Uint256) error {
+	s.mptRoot = root
+	s.mptProgress = newMPTProgress()
+	s.mptpool = NewPool()
+	s.billet = mpt.NewBillet(root, s.billetMode(),
+		TemporaryPrefix(s.dao.Version.StoragePrefix), s.dao.Store)
+	s.log.Info("MPT billet initialized",
+		zap.Uint32("height", s.syncPoint),
+		zap.String("state root", root.StringBE()))
+	var corrupted error
+	pool := NewPool()
+	pool.Add(root, []byte{})
+	err := s.billet.Traverse(func(_ []byte, n mpt.Node, nodeBytes []byte) bool {
+		if h := hash.DoubleSha256(nodeBytes); h != n.Hash() {
+			corrupted = fmt.Errorf("%w: node %s has hash %s", mpt.ErrVerificationFailed, n.Hash().StringBE(), h.StringBE())
+			return true
+		}
+		nPaths, ok := pool.TryGet(n.Hash())
+		if !ok {
+			// if this situation occurs, then it's a bug in MPT pool or Traverse.
+			panic("failed to get MPT node from the pool")
+		}
+		pool.Remove(n.Hash())
+		s.mptProgress.nodes++
+		childrenPaths := make(map[util.Uint256][][]byte)
+		for _, path := range nPaths {
+			nChildrenPaths := mpt.GetChildrenPaths(path, n)
+			s.mptProgress.restore(path, nChildrenPaths)
+			for hash, paths := range nChildrenPaths {
+				childrenPaths[hash] = append(childrenPaths[hash], paths...) // it's OK to have duplicates, they'll be handled by mempool
+			}
+		}
+		pool.Update(nil, childrenPaths)
+		return false
+	}, true)
+	if err != nil {
+		return err
+	}
+	if corrupted != nil {
+		return corrupted
+	}
+	s.mptpool.Update(nil, pool.GetAll())
+	if s.mptProgress.nodes != 0 {
+		s.mptProgress.reported = int(s.mptProgress.percent())
+		s.log.Info("MPT sync resumed",
+			zap.Uint64("restored nodes", s.mptProgress.nodes),
+			zap.Float64("percent", s.mptProgress.percent()))
+	}
+	return nil
+}
+
+// billetMode returns the mode of MPT being restored.
+func (s *Module) billetMode() mpt.TrieMode {
+	var mode mpt.TrieMode
+	// No need to enable GC here, it only has latest things.
+	if s.bc.GetConfig().Ledger.KeepOnlyLatestState || s.bc.GetConfig().Ledger.RemoveUntraceableBlocks {
+		mode |= mpt.ModeLatest
+	}
+	return mode
+}
+
+// resetMPT removes all MPT nodes and contract storage items fetched during state
+// synchronisation. It must not be used after the state jump.
+func (s *Module) resetMPT() error {
+	if s.bc.BlockHeight() >= s.syncPoint {
+		return fmt.Errorf("can't drop MPT data: chain is already at %d", s.bc.BlockHeight())
+	}
+	b := storage.NewMemCachedStore(s.dao.Store)
+	prefixes := []byte{byte(storage.DataMPT), byte(TemporaryPrefix(s.dao.Version.StoragePrefix))}
+	for i := range prefixes {
+		s.dao.Store.Seek(storage.SeekRange{Prefix: prefixes[i : i+1]}, func(k, _ []byte) bool {
+			// #1468, but don't need to copy here, because it is done by Store.
+			b.Delete(k)
+			return true
+		})
+	}
+	_, err := b.Persist()
+	if err != nil {
+		return fmt.Errorf("failed to remove fetched MPT data: %w", err)
+	}
+	return nil
+}
+
+// verifyMPT checks the restored MPT against the state root.
+func (s *Module) verifyMPT() error {
+	start := time.Now()
+	b := mpt.NewBillet(s.mptRoot, s.billetMode(), TemporaryPrefix(s.dao.Version.StoragePrefix), s.dao.Store)
+	n, err := b.Verify()
+	if err != nil {
+		return err
+	}
+	s.log.Info("MPT is verified",
+		zap.String("state root", s.mptRoot.StringBE()),
+		zap.Int("nodes", n),
+		zap.Duration("took", time.Since(start)))
+	return nil
+}
+
 // getLatestSavedBlock returns either current block index (if it's still relevant
 // to continue state sync process) or H-1 where H is the index of the earliest
 // block that should be saved next.
@@ -378,6 +497,12 @@ func (s *Module) AddMPTNodes(nodes [][]byte) error {
 			return err
 		}
 	}
+	if pct := int(s.mptProgress.percent()); pct > s.mptProgress.reported {
+		s.mptProgress.reported = pct
+		s.log.Info("MPT sync progress",
+			zap.Uint64("restored nodes", s.mptProgress.nodes),
+			zap.Int("percent", pct))
+	}
 	if s.mptpool.Count() == 0 {
 		s.syncStage |= mptSynced
 		s.log.Info("MPT is in sync",
@@ -394,6 +519,7 @@ func (s *Module) restoreNode(n mpt.Node) error {
 		return nil
 	}
 	var childrenPaths = make(map[util.Uint256][][]byte)
+	s.mptProgress.nodes++
 	for _, path := range nPaths {
 		// Must clone here in order to avoid future collapse collisions. If the node's refcount>1 then MPT pool
 		// will manage all paths for this node and call RestoreHashNode separately for each of the paths.
@@ -401,7 +527,9 @@ func (s *Module) restoreNode(n mpt.Node) error {
 		if err != nil {
 			return fmt.Errorf("failed to restore MPT node with hash %s and path %s: %w", n.Hash().StringBE(), hex.EncodeToString(path), err)
 		}
-		for h, paths := range mpt.GetChildrenPaths(path, n) {
+		nChildrenPaths := mpt.GetChildrenPaths(path, n)
+		s.mptProgress.restore(path, nChildrenPaths)
+		for h, paths := range nChildrenPaths {
 			childrenPaths[h] = append(childrenPaths[h], paths...) // it's OK to have duplicates, they'll be handled by mempool
 		}
 	}
@@ -428,9 +556,22 @@ func (s *Module) checkSyncIsCompleted() {
 	if s.syncStage != headersSynced|mptSynced|blocksSynced {
 		return
 	}
+	err := s.verifyMPT()
+	if err != nil {
+		s.log.Error("restored MPT is invalid, fetching it again", zap.Error(err))
+		err = s.resetMPT()
+		if err == nil {
+			err = s.restoreMPT(s.mptRoot)
+		}
+		if err != nil {
+			s.log.Fatal("failed to restart MPT synchronisation", zap.Error(err))
+		}
+		s.syncStage &^= mptSynced
+		return
+	}
 	s.log.Info("state is in sync",
 		zap.Uint32("state sync point", s.syncPoint))
-	err := s.jumpCallback(s.syncPoint)
+	err = s.jumpCallback(s.syncPoint)
 	if err != nil {
 		s.log.Fatal("failed to jump to the latest state sync point", zap.Error(err))
 	}
@@ -440,6 +581,7 @@ func (s *Module) checkSyncIsCompleted() {
 
 func (s *Module) dispose() {
 	s.billet = nil
+	s.mptProgress = nil
 }
 
 // BlockHeight returns index of the last stored block.
@@ -450,6 +592,43 @@ func (s *Module) BlockHeight() uint32 {
 	return s.blockHeight
 }
 
+// Progress returns the state synchronisation progress. False is returned if the
+// module is not active.
+func (s *Module) Progress() (Progress, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if s.syncStage == inactive || s.syncStage == none {
+		return Progress{}, false
+	}
+	res := Progress{
+		Point:       s.syncPoint,
+		Headers:     100,
+		BlockHeight: s.blockHeight,
+		Blocks:      100,
+		MPT:         100,
+	}
+	if s.syncStage == initialized {
+		res.Headers = 100 * float64(s.bc.HeaderHeight()) / float64(s.syncPoint+1)
+		res.Blocks, res.MPT = 0, 0
+		return res, true
+	}
+	if s.syncStage&blocksSynced == 0 {
+		var start uint32
+		if mtb := s.bc.GetConfig().MaxTraceableBlocks; s.syncPoint > mtb {
+			start = s.syncPoint - mtb
+		}
+		res.Blocks = 100 * float64(s.blockHeight-start) / float64(s.syncPoint-start)
+	}
+	if s.mptProgress != nil {
+		res.MPTNodes = s.mptProgress.nodes
+		if s.syncStage&mptSynced == 0 {
+			res.MPT = s.mptProgress.percent()
+		}
+	}
+	return res, true
+}
+
 // IsActive tells whether state sync module is on and still gathering state
 // synchronisation data (headers, blocks or MPT nodes).
 func (s *Module) IsActive() bool {
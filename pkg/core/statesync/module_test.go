@@ -56,6 +56,7 @@ func TestModule_PR2019_discussion_r689629704(t *testing.T) {
 		syncInterval: 100500,
 		dao:          dao.NewSimple(actualStorage, true),
 		mptpool:      NewPool(),
+		mptProgress:  newMPTProgress(),
 	}
 	stateSync.billet = mpt.NewBillet(sr, mpt.ModeLatest,
 		TemporaryPrefix(stateSync.dao.Version.StoragePrefix), actualStorage)
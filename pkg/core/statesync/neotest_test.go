@@ -6,9 +6,12 @@ import (
 
 	"github.com/nspcc-dev/neo-go/internal/basicchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -471,3 +474,169 @@ func TestStateSyncModule_RestoreBasicChain(t *testing.T) {
 		check(t, true)
 	})
 }
+
+func TestStateSyncModule_Resume(t *testing.T) {
+	const (
+		stateSyncInterval = 2
+		maxTraceable      = 3
+	)
+	spoutCfg := func(c *config.Blockchain) {
+		c.StateRootInHeader = true
+		c.P2PStateExchangeExtensions = true
+		c.StateSyncInterval = stateSyncInterval
+		c.MaxTraceableBlocks = maxTraceable
+	}
+	bcSpout, validators, committee := chain.NewMultiWithCustomConfig(t, spoutCfg)
+	e := neotest.NewExecutor(t, bcSpout, validators, committee)
+	for i := 0; i <= 2*stateSyncInterval+int(maxTraceable)+1; i++ {
+		e.AddNewBlock(t)
+	}
+	stateSyncPoint := (bcSpout.BlockHeight() / stateSyncInterval) * stateSyncInterval
+	h, err := bcSpout.GetHeader(bcSpout.GetHeaderHash(stateSyncPoint + 1))
+	require.NoError(t, err)
+	nodesMap := make(map[util.Uint256][]byte)
+	require.NoError(t, bcSpout.GetStateSyncModule().Traverse(h.PrevStateRoot, func(n mpt.Node, nodeBytes []byte) bool {
+		nodesMap[n.Hash()] = bytes.Clone(nodeBytes)
+		return false
+	}))
+
+	boltCfg := func(c *config.Blockchain) {
+		spoutCfg(c)
+		c.Ledger.KeepOnlyLatestState = true
+		c.Ledger.RemoveUntraceableBlocks = true
+	}
+	// start emulates node start with the DB at the given path.
+	start := func(t *testing.T, path string) (*core.Blockchain, *statesync.Module) {
+		st, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: path})
+		require.NoError(t, err)
+		bc, _, _ := chain.NewMultiWithCustomConfigAndStore(t, boltCfg, st, false)
+		go bc.Run()
+		module := bc.GetStateSyncModule()
+		require.NoError(t, module.Init(bcSpout.BlockHeight()))
+		return bc, module
+	}
+	// addNodes adds at most n requested MPT nodes one-by-one and returns the
+	// number of added nodes. It stops if MPT synchronisation is restarted.
+	addNodes := func(t *testing.T, module *statesync.Module, n int) int {
+		var added int
+		for ; added < n; added++ {
+			need := module.GetUnknownMPTNodesBatch(1)
+			if len(need) == 0 || (added > 0 && need[0] == h.PrevStateRoot) {
+				break
+			}
+			nodeBytes, ok := nodesMap[need[0]]
+			require.True(t, ok, "unknown node requested")
+			require.NoError(t, module.AddMPTNodes([][]byte{nodeBytes}))
+		}
+		return added
+	}
+	// syncHeadersAndBlocks syncs everything except MPT and restores n MPT nodes.
+	syncPartially := func(t *testing.T, path string, n int) {
+		bc, module := start(t, path)
+		for i := uint32(1); i <= bcSpout.HeaderHeight(); i++ {
+			h, err := bcSpout.GetHeader(bcSpout.GetHeaderHash(i))
+			require.NoError(t, err)
+			require.NoError(t, module.AddHeaders(h))
+		}
+		for i := stateSyncPoint - maxTraceable + 1; i <= stateSyncPoint; i++ {
+			b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+			require.NoError(t, err)
+			require.NoError(t, module.AddBlock(b))
+		}
+		require.Equal(t, n, addNodes(t, module, n))
+		p, ok := module.Progress()
+		require.True(t, ok)
+		require.Equal(t, stateSyncPoint, p.Point)
+		require.EqualValues(t, 100, p.Headers)
+		require.EqualValues(t, 100, p.Blocks)
+		require.GreaterOrEqual(t, p.MPTNodes, uint64(n)) // Includes nodes restored from the storage.
+		require.Greater(t, p.MPT, float64(0))
+		require.Less(t, p.MPT, float64(100))
+		bc.Close()
+	}
+	// finish completes MPT sync and checks the resulting state.
+	finish := func(t *testing.T, bc *core.Blockchain, module *statesync.Module) {
+		addNodes(t, module, len(nodesMap))
+		require.False(t, module.IsActive())
+		_, ok := module.Progress()
+		require.False(t, ok)
+		require.Equal(t, stateSyncPoint, bc.BlockHeight())
+		for i := stateSyncPoint + 1; i <= bcSpout.BlockHeight(); i++ {
+			b, err := bcSpout.GetBlock(bcSpout.GetHeaderHash(i))
+			require.NoError(t, err)
+			require.NoError(t, bc.AddBlock(b))
+		}
+		sr, err := bc.GetStateModule().GetStateRoot(bc.BlockHeight())
+		require.NoError(t, err)
+		srSpout, err := bcSpout.GetStateModule().GetStateRoot(bcSpout.BlockHeight())
+		require.NoError(t, err)
+		require.Equal(t, srSpout.Root, sr.Root)
+		bc.Close()
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		path := t.TempDir()
+		syncPartially(t, path, len(nodesMap)/2)
+
+		bc, module := start(t, path)
+		require.True(t, module.NeedMPTNodes())
+		p, ok := module.Progress()
+		require.True(t, ok)
+		require.GreaterOrEqual(t, p.MPTNodes, uint64(len(nodesMap)/2))
+		require.Greater(t, p.MPT, float64(0))
+		unknown := module.GetUnknownMPTNodesBatch(len(nodesMap))
+		require.NotContains(t, unknown, h.PrevStateRoot)
+		// Only the other half is requested.
+		require.Equal(t, len(nodesMap)-len(nodesMap)/2, addNodes(t, module, len(nodesMap)))
+		require.False(t, module.IsActive())
+		bc.Close()
+	})
+	t.Run("corrupted node", func(t *testing.T) {
+		path := t.TempDir()
+		syncPartially(t, path, len(nodesMap)/2)
+
+		// Damage some stored node.
+		st, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: path})
+		require.NoError(t, err)
+		var (
+			key  []byte
+			data []byte
+		)
+		st.Seek(storage.SeekRange{Prefix: []byte{byte(storage.DataMPT)}}, func(k, v []byte) bool {
+			key, data = bytes.Clone(k), bytes.Clone(v)
+			return false
+		})
+		require.NotNil(t, key)
+		data[len(data)-6]++ // The last byte of the node itself, reference counter follows it.
+		require.NoError(t, st.PutChangeSet(map[string][]byte{string(key): data}, nil))
+		require.NoError(t, st.Close())
+
+		bc, module := start(t, path)
+		require.True(t, module.NeedMPTNodes())
+		p, ok := module.Progress()
+		require.True(t, ok)
+		require.EqualValues(t, 0, p.MPTNodes)
+		require.Equal(t, []util.Uint256{h.PrevStateRoot}, module.GetUnknownMPTNodesBatch(10))
+		finish(t, bc, module)
+	})
+	t.Run("invalid storage", func(t *testing.T) {
+		path := t.TempDir()
+		syncPartially(t, path, len(nodesMap)/2)
+
+		// Add unexpected contract storage item, it's only detected by the
+		// final verification.
+		st, err := storage.NewLevelDBStore(dbconfig.LevelDBOptions{DataDirectoryPath: path})
+		require.NoError(t, err)
+		require.NoError(t, st.PutChangeSet(map[string][]byte{string([]byte{byte(storage.STTempStorage), 0xff, 0xff, 0xff, 0xff, 1}): {1}}, nil))
+		require.NoError(t, st.Close())
+
+		bc, module := start(t, path)
+		require.True(t, module.NeedMPTNodes())
+		addNodes(t, module, len(nodesMap))
+		// Verification failed, so MPT is requested again.
+		require.True(t, module.IsActive())
+		require.Equal(t, []util.Uint256{h.PrevStateRoot}, module.GetUnknownMPTNodesBatch(10))
+		require.Equal(t, uint32(0), bc.BlockHeight())
+		finish(t, bc, module)
+	})
+}
@@ -0,0 +1,77 @@
+package statesync
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Progress describes the state synchronisation process status.
+type Progress struct {
+	// Point is the state synchronisation point P.
+	Point uint32
+	// Headers is the percentage of headers fetched (up to P+1).
+	Headers float64
+	// BlockHeight is the index of the latest stored block.
+	BlockHeight uint32
+	// Blocks is the percentage of blocks stored (up to P).
+	Blocks float64
+	// MPTNodes is the number of MPT nodes restored, nodes having several
+	// paths are counted for each of them.
+	MPTNodes uint64
+	// MPT is the estimated percentage of MPT restored.
+	MPT float64
+}
+
+// mptProgress estimates the MPT restoring progress. The root node has weight 1
+// and every restored node splits its weight equally between its children, so
+// the sum of restored leaves weights approaches 1 as the MPT is restored. It's
+// not precise for unbalanced tries, but allows to track the progress without
+// knowing the number of nodes in advance.
+type mptProgress struct {
+	// pending contains weights of nodes that are not yet restored, it's
+	// indexed by MPT node path.
+	pending map[string]float64
+	// done is the sum of restored leaves weights.
+	done float64
+	// nodes is the number of restored nodes.
+	nodes uint64
+	// reported is the latest percentage reported to the log.
+	reported int
+}
+
+func newMPTProgress() *mptProgress {
+	return &mptProgress{
+		pending: map[string]float64{"": 1}, // The root.
+	}
+}
+
+// restore accounts the node with the given path and children paths as
+// restored.
+func (p *mptProgress) restore(path []byte, children map[util.Uint256][][]byte) {
+	w, ok := p.pending[string(path)]
+	if !ok {
+		return
+	}
+	delete(p.pending, string(path))
+	var n int
+	for _, paths := range children {
+		n += len(paths)
+	}
+	if n == 0 {
+		p.done += w
+		return
+	}
+	for _, paths := range children {
+		for _, cp := range paths {
+			p.pending[string(cp)] = w / float64(n)
+		}
+	}
+}
+
+// percent returns the estimated percentage of MPT restored.
+func (p *mptProgress) percent() float64 {
+	res := 100 * p.done
+	if res > 100 {
+		res = 100
+	}
+	return res
+}
@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"bytes"
+	"sync"
+	"time"
+)
+
+// FaultStore is a Store wrapper that allows to inject failures and delays
+// into the operations of the underlying Store. It's intended to be used in
+// tests only, to check the behavior of the code in case of storage errors
+// (full disk, transient IO errors, slow disk). Faults can be set and cleared
+// at any time, FaultStore is safe for concurrent use.
+type FaultStore struct {
+	Store
+
+	lock sync.Mutex
+	// putCalls is the number of PutChangeSet calls made so far.
+	putCalls int
+	// failPutFrom is the number of PutChangeSet call to fail starting from,
+	// zero if there is no PutChangeSet fault.
+	failPutFrom int
+	putErr      error
+	seekFaults  []seekFault
+	latency     time.Duration
+}
+
+type seekFault struct {
+	prefix []byte
+	err    error
+}
+
+// NewFaultStore creates a new FaultStore wrapping the given Store without any
+// faults set.
+func NewFaultStore(s Store) *FaultStore {
+	return &FaultStore{Store: s}
+}
+
+// FailPutChangeSet makes the n-th (starting from 1) subsequent PutChangeSet
+// call and all of the following ones fail with the given error without
+// changing the underlying Store. Faults stay active until Heal is called,
+// like a disk that is full.
+func (s *FaultStore) FailPutChangeSet(n int, err error) {
+	if n < 1 {
+		n = 1
+	}
+	s.lock.Lock()
+	s.failPutFrom = s.putCalls + n
+	s.putErr = err
+	s.lock.Unlock()
+}
+
+// FailSeek makes Seek and SeekGC fail for any range overlapping with the
+// given prefix. SeekGC returns the given error while Seek (that can't return
+// an error) panics with it, the same way BoltDB Store does.
+func (s *FaultStore) FailSeek(prefix []byte, err error) {
+	s.lock.Lock()
+	s.seekFaults = append(s.seekFaults, seekFault{prefix: bytes.Clone(prefix), err: err})
+	s.lock.Unlock()
+}
+
+// SetLatency adds the given delay to every Get, PutChangeSet, Seek and SeekGC
+// call. Zero value removes the delay.
+func (s *FaultStore) SetLatency(d time.Duration) {
+	s.lock.Lock()
+	s.latency = d
+	s.lock.Unlock()
+}
+
+// Heal removes all faults and latency set previously.
+func (s *FaultStore) Heal() {
+	s.lock.Lock()
+	s.failPutFrom = 0
+	s.putErr = nil
+	s.seekFaults = nil
+	s.latency = 0
+	s.lock.Unlock()
+}
+
+// PutChangeSetCalls returns the number of PutChangeSet calls made so far
+// (including failed ones).
+func (s *FaultStore) PutChangeSetCalls() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.putCalls
+}
+
+// Get implements the Store interface.
+func (s *FaultStore) Get(key []byte) ([]byte, error) {
+	s.delay()
+	return s.Store.Get(key)
+}
+
+// PutChangeSet implements the Store interface.
+func (s *FaultStore) PutChangeSet(puts map[string][]byte, stor map[string][]byte) error {
+	s.lock.Lock()
+	s.putCalls++
+	var (
+		latency = s.latency
+		err     error
+	)
+	if s.failPutFrom != 0 && s.putCalls >= s.failPutFrom {
+		err = s.putErr
+	}
+	s.lock.Unlock()
+
+	time.Sleep(latency)
+	if err != nil {
+		return err
+	}
+	return s.Store.PutChangeSet(puts, stor)
+}
+
+// Seek implements the Store interface.
+func (s *FaultStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	if err := s.seekFault(rng); err != nil {
+		panic(err)
+	}
+	s.Store.Seek(rng, f)
+}
+
+// SeekGC implements the Store interface.
+func (s *FaultStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	if err := s.seekFault(rng); err != nil {
+		return err
+	}
+	return s.Store.SeekGC(rng, keep)
+}
+
+// seekFault applies latency and returns the error to fail Seek with for the
+// given range (if any).
+func (s *FaultStore) seekFault(rng SeekRange) error {
+	s.delay()
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, f := range s.seekFaults {
+		if bytes.HasPrefix(rng.Prefix, f.prefix) || bytes.HasPrefix(f.prefix, rng.Prefix) {
+			return f.err
+		}
+	}
+	return nil
+}
+
+func (s *FaultStore) delay() {
+	s.lock.Lock()
+	latency := s.latency
+	s.lock.Unlock()
+	time.Sleep(latency)
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newFaultStoreForTesting(t testing.TB) Store {
+	return NewFaultStore(NewMemoryStore())
+}
+
+func TestFaultStore_PutChangeSet(t *testing.T) {
+	var (
+		errFull = errors.New("disk full")
+		ms      = NewMemoryStore()
+		s       = NewFaultStore(ms)
+		put     = func(k string) error {
+			return s.PutChangeSet(map[string][]byte{k: {1}}, nil)
+		}
+	)
+	require.NoError(t, put("\x01a"))
+
+	s.FailPutChangeSet(2, errFull)
+	require.NoError(t, put("\x01b"))
+	require.ErrorIs(t, put("\x01c"), errFull)
+	require.ErrorIs(t, put("\x01d"), errFull)
+	require.Equal(t, 4, s.PutChangeSetCalls())
+	for k, ok := range map[string]bool{"\x01a": true, "\x01b": true, "\x01c": false, "\x01d": false} {
+		_, err := ms.Get([]byte(k))
+		require.Equal(t, ok, err == nil, k)
+	}
+
+	s.Heal()
+	require.NoError(t, put("\x01c"))
+	_, err := s.Get([]byte("\x01c"))
+	require.NoError(t, err)
+
+	// Zero and negative values fail the next call.
+	s.FailPutChangeSet(0, errFull)
+	require.ErrorIs(t, put("\x01e"), errFull)
+}
+
+func TestFaultStore_Seek(t *testing.T) {
+	var (
+		errIO = errors.New("IO error")
+		s     = NewFaultStore(NewMemoryStore())
+	)
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"\x01a": {1}, "\x02a": {2}}, nil))
+
+	s.FailSeek([]byte{2}, errIO)
+	var keys int
+	s.Seek(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool {
+		keys++
+		return true
+	})
+	require.Equal(t, 1, keys)
+	require.PanicsWithValue(t, errIO, func() {
+		s.Seek(SeekRange{Prefix: []byte{2}}, func(k, v []byte) bool { return true })
+	})
+	require.PanicsWithValue(t, errIO, func() {
+		s.Seek(SeekRange{Prefix: []byte{2, 1}}, func(k, v []byte) bool { return true })
+	})
+	require.ErrorIs(t, s.SeekGC(SeekRange{Prefix: []byte{2}}, func(k, v []byte) bool { return false }), errIO)
+	require.NoError(t, s.SeekGC(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool { return true }))
+
+	s.Heal()
+	require.NoError(t, s.SeekGC(SeekRange{Prefix: []byte{2}}, func(k, v []byte) bool { return false }))
+	_, err := s.Get([]byte("\x02a"))
+	require.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestFaultStore_Latency(t *testing.T) {
+	s := NewFaultStore(NewMemoryStore())
+	s.SetLatency(50 * time.Millisecond)
+	start := time.Now()
+	_, _ = s.Get([]byte{1})
+	require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+	s.SetLatency(0)
+	start = time.Now()
+	require.NoError(t, s.PutChangeSet(map[string][]byte{"\x01": {1}}, nil))
+	require.Less(t, time.Since(start), 50*time.Millisecond)
+}
@@ -72,7 +72,19 @@ func (s *LevelDBStore) PutChangeSet(puts map[string][]byte, stores map[string][]
 // Seek implements the Store interface.
 func (s *LevelDBStore) Seek(rng SeekRange, f func(k, v []byte) bool) {
 	iter := s.db.NewIterator(seekRangeToPrefixes(rng), nil)
-	s.seek(iter, rng.Backwards, f)
+	seekLevelDB(iter, rng.Backwards, f)
+}
+
+// Snapshot implements the Snapshotter interface. It returns a read-only Store
+// backed by LevelDB snapshot that must be closed to release it (it doesn't
+// affect the original Store in any way). Keeping a snapshot for long prevents
+// LevelDB from dropping stale data during compactions.
+func (s *LevelDBStore) Snapshot() (Store, error) {
+	snap, err := s.db.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &levelDBSnapshot{snap: snap}, nil
 }
 
 // SeekGC implements the Store interface.
@@ -82,7 +94,7 @@ func (s *LevelDBStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error
 		return err
 	}
 	iter := tx.NewIterator(seekRangeToPrefixes(rng), nil)
-	s.seek(iter, rng.Backwards, func(k, v []byte) bool {
+	seekLevelDB(iter, rng.Backwards, func(k, v []byte) bool {
 		if !keep(k, v) {
 			err = tx.Delete(k, nil)
 			if err != nil {
@@ -97,7 +109,7 @@ func (s *LevelDBStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error
 	return tx.Commit()
 }
 
-func (s *LevelDBStore) seek(iter iterator.Iterator, backwards bool, f func(k, v []byte) bool) {
+func seekLevelDB(iter iterator.Iterator, backwards bool, f func(k, v []byte) bool) {
 	var (
 		next func() bool
 		ok   bool
@@ -123,3 +135,41 @@ func (s *LevelDBStore) seek(iter iterator.Iterator, backwards bool, f func(k, v
 func (s *LevelDBStore) Close() error {
 	return s.db.Close()
 }
+
+// levelDBSnapshot is a read-only Store backed by LevelDB snapshot.
+type levelDBSnapshot struct {
+	snap *leveldb.Snapshot
+}
+
+// Get implements the Store interface.
+func (s *levelDBSnapshot) Get(key []byte) ([]byte, error) {
+	value, err := s.snap.Get(key, nil)
+	if errors.Is(err, leveldb.ErrNotFound) {
+		err = ErrKeyNotFound
+	}
+	return value, err
+}
+
+// PutChangeSet implements the Store interface, snapshot can't be changed, so
+// it always returns an error.
+func (s *levelDBSnapshot) PutChangeSet(puts map[string][]byte, stores map[string][]byte) error {
+	return errReadOnlySnapshot
+}
+
+// Seek implements the Store interface.
+func (s *levelDBSnapshot) Seek(rng SeekRange, f func(k, v []byte) bool) {
+	iter := s.snap.NewIterator(seekRangeToPrefixes(rng), nil)
+	seekLevelDB(iter, rng.Backwards, f)
+}
+
+// SeekGC implements the Store interface, snapshot can't be changed, so it
+// always returns an error.
+func (s *levelDBSnapshot) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
+	return errReadOnlySnapshot
+}
+
+// Close implements the Store interface, it releases the snapshot.
+func (s *levelDBSnapshot) Close() error {
+	s.snap.Release()
+	return nil
+}
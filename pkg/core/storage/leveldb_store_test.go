@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/storage/dbconfig"
@@ -43,3 +44,47 @@ func TestROLevelDB(t *testing.T) {
 	putErr := store.PutChangeSet(map[string][]byte{"one": []byte("one")}, nil)
 	require.ErrorIs(t, putErr, leveldb.ErrReadOnly)
 }
+
+func TestLevelDBSnapshot(t *testing.T) {
+	var (
+		k1 = []byte{1, 1}
+		k2 = []byte{1, 2}
+		k3 = []byte{1, 3}
+	)
+	s := newLevelDBForTesting(t).(*LevelDBStore)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+	require.NoError(t, s.PutChangeSet(map[string][]byte{string(k1): {1}, string(k2): {2}}, nil))
+
+	snap, err := s.Snapshot()
+	require.NoError(t, err)
+
+	require.NoError(t, s.PutChangeSet(map[string][]byte{string(k1): {4}, string(k2): nil, string(k3): {5}}, nil))
+
+	v, err := snap.Get(k1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1}, v)
+	_, err = snap.Get(k3)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+
+	var kvs []KeyValue
+	snap.Seek(SeekRange{Prefix: []byte{1}, Backwards: true}, func(k, v []byte) bool {
+		kvs = append(kvs, KeyValue{Key: bytes.Clone(k), Value: bytes.Clone(v)})
+		return true
+	})
+	require.Equal(t, []KeyValue{{Key: k2, Value: []byte{2}}, {Key: k1, Value: []byte{1}}}, kvs)
+
+	require.Error(t, snap.PutChangeSet(map[string][]byte{string(k1): {7}}, nil))
+	require.Error(t, snap.SeekGC(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool { return true }))
+
+	// Snapshot can be wrapped to make changes.
+	mc := NewMemCachedStore(snap)
+	mc.Put(k3, []byte{6})
+	v, err = mc.Get(k3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{6}, v)
+
+	require.NoError(t, snap.Close())
+	v, err = s.Get(k1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{4}, v)
+}
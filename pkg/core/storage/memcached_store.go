@@ -107,6 +107,7 @@ func (s *MemCachedStore) Put(key, value []byte) {
 	newKey := string(key)
 	vcopy := bytes.Clone(value)
 	s.lock()
+	s.cow()
 	put(s.chooseMap(key), newKey, vcopy)
 	s.unlock()
 }
@@ -115,6 +116,7 @@ func (s *MemCachedStore) Put(key, value []byte) {
 func (s *MemCachedStore) Delete(key []byte) {
 	newKey := string(key)
 	s.lock()
+	s.cow()
 	put(s.chooseMap(key), newKey, nil)
 	s.unlock()
 }
@@ -374,9 +376,11 @@ func (s *MemCachedStore) persist(isSync bool) (int, error) {
 	// nothing ever changes it, therefore accesses to it (reads) can go
 	// unprotected while writes are handled by s proper.
 	var tempstore = &MemCachedStore{MemoryStore: MemoryStore{mem: s.mem, stor: s.stor}, ps: s.ps}
+	tempstore.shared.Store(s.shared.Load())
 	s.ps = tempstore
 	s.mem = make(map[string][]byte, len(s.mem))
 	s.stor = make(map[string][]byte, len(s.stor))
+	s.shared.Store(false)
 	if !isSync {
 		s.mut.Unlock()
 	}
@@ -393,6 +397,7 @@ func (s *MemCachedStore) persist(isSync bool) (int, error) {
 	} else {
 		// We're toast. We'll try to still keep proper state, but OOM
 		// killer will get to us eventually.
+		tempstore.cow()
 		for k := range s.mem {
 			put(tempstore.mem, k, s.mem[k])
 		}
@@ -402,11 +407,38 @@ func (s *MemCachedStore) persist(isSync bool) (int, error) {
 		s.ps = tempstore.ps
 		s.mem = tempstore.mem
 		s.stor = tempstore.stor
+		s.shared.Store(tempstore.shared.Load())
 	}
 	s.mut.Unlock()
 	return keys, err
 }
 
+// Snapshot implements the Snapshotter interface, it requires the lower Store
+// to implement it as well (otherwise ErrSnapshotUnsupported is returned). The
+// snapshot is taken in O(1) time for MemCachedStore layers and doesn't block
+// any subsequent changes and Persist (cached contents are copied on the first
+// change made after the snapshot). The snapshot is a private MemCachedStore,
+// so it can be safely read from concurrently, but it must not be changed
+// concurrently (wrap it into another private MemCachedStore for changes).
+func (s *MemCachedStore) Snapshot() (Store, error) {
+	s.rlock()
+	defer s.runlock()
+	lower, ok := s.ps.(Snapshotter)
+	if !ok {
+		return nil, ErrSnapshotUnsupported
+	}
+	ps, err := lower.Snapshot()
+	if err != nil {
+		return nil, err
+	}
+	snap := &MemCachedStore{private: true, ps: ps}
+	snap.mem = s.mem
+	snap.stor = s.stor
+	snap.shared.Store(true)
+	s.shared.Store(true)
+	return snap, nil
+}
+
 // Close implements Store interface, clears up memory and closes the lower layer
 // Store.
 func (s *MemCachedStore) Close() error {
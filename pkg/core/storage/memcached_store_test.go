@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"sort"
+	"sync"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -430,3 +431,184 @@ func TestCachedSeekSorting(t *testing.T) {
 		require.Equal(t, expected, foundKVs)
 	}
 }
+
+func TestMemCachedSnapshot(t *testing.T) {
+	var (
+		k1 = []byte{1, 1}
+		k2 = []byte{1, 2}
+		k3 = []byte{1, 3}
+	)
+	ps := NewMemoryStore()
+	ts := NewMemCachedStore(ps)
+	ts.Put(k1, []byte{1})
+	_, err := ts.Persist()
+	require.NoError(t, err)
+	ts.Put(k2, []byte{2})
+
+	snap, err := ts.Snapshot()
+	require.NoError(t, err)
+
+	ts.Put(k1, []byte{3})
+	ts.Delete(k2)
+	ts.Put(k3, []byte{4})
+	_, err = ts.Persist()
+	require.NoError(t, err)
+	ts.Put(k3, []byte{5})
+
+	for k, v := range map[string][]byte{string(k1): {1}, string(k2): {2}, string(k3): nil} {
+		res, err := snap.Get([]byte(k))
+		if v == nil {
+			require.ErrorIs(t, err, ErrKeyNotFound)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, v, res)
+	}
+	var kvs []KeyValue
+	snap.Seek(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool {
+		kvs = append(kvs, KeyValue{Key: bytes.Clone(k), Value: bytes.Clone(v)})
+		return true
+	})
+	require.Equal(t, []KeyValue{{Key: k1, Value: []byte{1}}, {Key: k2, Value: []byte{2}}}, kvs)
+
+	res, err := ts.Get(k1)
+	require.NoError(t, err)
+	require.Equal(t, []byte{3}, res)
+	_, err = ts.Get(k2)
+	require.ErrorIs(t, err, ErrKeyNotFound)
+	res, err = ts.Get(k3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{5}, res)
+
+	// Snapshot can be wrapped to make changes.
+	priv := NewPrivateMemCachedStore(snap)
+	priv.Put(k3, []byte{6})
+	_, err = priv.Persist()
+	require.NoError(t, err)
+	res, err = snap.Get(k3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{6}, res)
+	res, err = ts.Get(k3)
+	require.NoError(t, err)
+	require.Equal(t, []byte{5}, res)
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := NewMemCachedStore(&BadStore{}).Snapshot()
+		require.ErrorIs(t, err, ErrSnapshotUnsupported)
+	})
+}
+
+func TestMemCachedSnapshotConcurrentPersist(t *testing.T) {
+	const (
+		readers = 100
+		blocks  = 200
+	)
+	var (
+		k1   = []byte{1, 1}
+		k2   = []byte{byte(STStorage), 2}
+		ts   = NewMemCachedStore(NewMemoryStore())
+		done = make(chan struct{})
+		wg   sync.WaitGroup
+	)
+	ts.Put(k1, []byte{0})
+	ts.Put(k2, []byte{0})
+
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var last byte
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				snap, err := ts.Snapshot()
+				if !assert.NoError(t, err) {
+					return
+				}
+				v1, err1 := snap.Get(k1)
+				v2, err2 := snap.Get(k2)
+				if !assert.NoError(t, err1) || !assert.NoError(t, err2) ||
+					!assert.Equal(t, v1, v2) || !assert.LessOrEqual(t, last, v1[0]) {
+					return
+				}
+				last = v1[0]
+			}
+		}()
+	}
+	for i := 1; i <= blocks; i++ {
+		// Changes are applied the same way Blockchain does it.
+		priv := NewPrivateMemCachedStore(ts)
+		priv.Put(k1, []byte{byte(i)})
+		priv.Put(k2, []byte{byte(i)})
+		_, err := priv.Persist()
+		require.NoError(t, err)
+		if i%10 == 0 {
+			_, err = ts.Persist()
+			require.NoError(t, err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
+
+func BenchmarkMemCachedSnapshotGet(b *testing.B) {
+	ps := NewMemoryStore()
+	ts := NewMemCachedStore(ps)
+	keys := make([][]byte, 1000)
+	for i := range keys {
+		keys[i] = append([]byte{1}, random.Bytes(10)...)
+		ts.Put(keys[i], random.Bytes(10))
+	}
+	_, err := ts.PersistSync()
+	require.NoError(b, err)
+	for i := range keys[:100] {
+		ts.Put(keys[i], random.Bytes(10))
+	}
+
+	for _, withWriter := range []bool{false, true} {
+		var stop = make(chan struct{})
+		if withWriter {
+			go func() {
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					priv := NewPrivateMemCachedStore(ts)
+					priv.Put(keys[0], random.Bytes(10))
+					_, _ = priv.Persist()
+				}
+			}()
+		}
+		b.Run(fmt.Sprintf("shared, writer=%t", withWriter), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					priv := NewPrivateMemCachedStore(ts)
+					for j := 0; j < 10; j++ {
+						_, _ = priv.Get(keys[(i+j)%len(keys)])
+					}
+					i++
+				}
+			})
+		})
+		b.Run(fmt.Sprintf("snapshot, writer=%t", withWriter), func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				var i int
+				for pb.Next() {
+					snap, _ := ts.Snapshot()
+					priv := NewPrivateMemCachedStore(snap)
+					for j := 0; j < 10; j++ {
+						_, _ = priv.Get(keys[(i+j)%len(keys)])
+					}
+					i++
+				}
+			})
+		})
+		close(stop)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // MemoryStore is an in-memory implementation of a Store, mainly
@@ -13,6 +14,9 @@ type MemoryStore struct {
 	mut  sync.RWMutex
 	mem  map[string][]byte
 	stor map[string][]byte
+	// shared is set when mem and stor maps are referenced by some snapshot,
+	// they're copied before any modification then (see cow).
+	shared atomic.Bool
 }
 
 // NewMemoryStore creates a new MemoryStore object.
@@ -43,6 +47,38 @@ func (s *MemoryStore) chooseMap(key []byte) map[string][]byte {
 	}
 }
 
+// cow makes a private copy of mem and stor maps if they're shared with some
+// snapshot, it's supposed to be called with write lock held before any
+// modification of the maps.
+func (s *MemoryStore) cow() {
+	if !s.shared.Load() {
+		return
+	}
+	s.mem = cloneMap(s.mem)
+	s.stor = cloneMap(s.stor)
+	s.shared.Store(false)
+}
+
+func cloneMap(m map[string][]byte) map[string][]byte {
+	res := make(map[string][]byte, len(m))
+	for k, v := range m {
+		res[k] = v
+	}
+	return res
+}
+
+// Snapshot implements the Snapshotter interface. It takes O(1) time, the
+// contents of the MemoryStore are copied on the first subsequent change made
+// to it instead.
+func (s *MemoryStore) Snapshot() (Store, error) {
+	s.mut.RLock()
+	snap := &MemoryStore{mem: s.mem, stor: s.stor}
+	snap.shared.Store(true)
+	s.shared.Store(true)
+	s.mut.RUnlock()
+	return snap, nil
+}
+
 // put puts a key-value pair into the store, it's supposed to be called
 // with mutex locked.
 func put(m map[string][]byte, key string, value []byte) {
@@ -58,6 +94,7 @@ func (s *MemoryStore) PutChangeSet(puts map[string][]byte, stores map[string][]b
 }
 
 func (s *MemoryStore) putChangeSet(puts map[string][]byte, stores map[string][]byte) {
+	s.cow()
 	for k := range puts {
 		put(s.mem, k, puts[k])
 	}
@@ -77,6 +114,7 @@ func (s *MemoryStore) SeekGC(rng SeekRange, keep func(k, v []byte) bool) error {
 	// Keep RW lock for the whole Seek time, state must be consistent across whole
 	// operation and we call delete in the handler.
 	s.mut.Lock()
+	s.cow()
 	// We still need to perform normal seek, some GC operations can be
 	// sensitive to the order of KV pairs.
 	s.seek(rng, func(k, v []byte) bool {
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"bytes"
 	"fmt"
 	"testing"
 
@@ -36,3 +37,52 @@ func BenchmarkMemorySeek(t *testing.B) {
 		})
 	}
 }
+
+func TestMemorySnapshot(t *testing.T) {
+	var (
+		k1 = []byte{1, 1}
+		k2 = []byte{1, 2}
+		k3 = []byte{1, 3}
+		ks = []byte{byte(STStorage), 1}
+	)
+	s := NewMemoryStore()
+	require.NoError(t, s.PutChangeSet(map[string][]byte{string(k1): {1}, string(k2): {2}}, map[string][]byte{string(ks): {3}}))
+
+	snap, err := s.Snapshot()
+	require.NoError(t, err)
+
+	require.NoError(t, s.PutChangeSet(map[string][]byte{string(k1): {4}, string(k2): nil, string(k3): {5}}, map[string][]byte{string(ks): {6}}))
+	require.NoError(t, s.SeekGC(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool { return !bytes.Equal(k, k1) }))
+
+	check := func(st Store, key []byte, expected []byte) {
+		v, err := st.Get(key)
+		if expected == nil {
+			require.ErrorIs(t, err, ErrKeyNotFound)
+			return
+		}
+		require.NoError(t, err)
+		require.Equal(t, expected, v)
+	}
+	check(snap, k1, []byte{1})
+	check(snap, k2, []byte{2})
+	check(snap, k3, nil)
+	check(snap, ks, []byte{3})
+	check(s, k1, nil)
+	check(s, k2, nil)
+	check(s, k3, []byte{5})
+	check(s, ks, []byte{6})
+
+	var keys [][]byte
+	snap.Seek(SeekRange{Prefix: []byte{1}}, func(k, v []byte) bool {
+		keys = append(keys, bytes.Clone(k))
+		return true
+	})
+	require.Equal(t, [][]byte{k1, k2}, keys)
+
+	// Changing the snapshot doesn't affect the original store.
+	require.NoError(t, snap.PutChangeSet(map[string][]byte{string(k1): {7}}, nil))
+	check(snap, k1, []byte{7})
+	check(s, k1, nil)
+	require.NoError(t, snap.Close())
+	check(s, k3, []byte{5})
+}
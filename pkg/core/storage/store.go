@@ -17,15 +17,30 @@ const (
 	// DataMPTAux is used to store additional MPT data like height-root
 	// mappings and local/validated heights.
 	DataMPTAux KeyPrefix = 0x04
-	STStorage  KeyPrefix = 0x70
+	// DataChangelog is used to store reverse state changes of the latest
+	// blocks (see Ledger.ChangelogDepth setting).
+	DataChangelog KeyPrefix = 0x05
+	// DataInvocationTrees is used to store node-local contract invocation
+	// trees of executions (see Ledger.SaveInvocationTrees setting).
+	DataInvocationTrees KeyPrefix = 0x06
+	STStorage           KeyPrefix = 0x70
 	// STTempStorage is used to store contract storage items during state sync process
 	// in order not to mess up the previous state which has its own items stored by
 	// STStorage prefix. Once state exchange process is completed, all items with
 	// STStorage prefix will be replaced with STTempStorage-prefixed ones.
-	STTempStorage                  KeyPrefix = 0x71
-	STNEP11Transfers               KeyPrefix = 0x72
-	STNEP17Transfers               KeyPrefix = 0x73
-	STTokenTransferInfo            KeyPrefix = 0x74
+	STTempStorage       KeyPrefix = 0x71
+	STNEP11Transfers    KeyPrefix = 0x72
+	STNEP17Transfers    KeyPrefix = 0x73
+	STTokenTransferInfo KeyPrefix = 0x74
+	// STStorageUsage is used to store node-local per-contract storage usage
+	// counters (see Ledger.TrackStorageUsage setting).
+	STStorageUsage KeyPrefix = 0x75
+	// STNEP17Balances is used to store node-local index of current NEP-17
+	// balances (see Ledger.TrackNEP17Balances setting).
+	STNEP17Balances KeyPrefix = 0x76
+	// STNEP17UnreliableTokens is used to mark NEP-17 tokens which balances
+	// can't be tracked using their Transfer events.
+	STNEP17UnreliableTokens        KeyPrefix = 0x77
 	IXHeaderHashList               KeyPrefix = 0x80
 	SYSCurrentBlock                KeyPrefix = 0xc0
 	SYSCurrentHeader               KeyPrefix = 0xc1
@@ -37,6 +52,20 @@ const (
 	// and the last bit reserved for the state reset process marker (set to 1 on
 	// unfinished state reset and to 0 on unfinished state jump).
 	SYSStateChangeStage KeyPrefix = 0xc4
+	// SYSStorageUsageState is used to mark storage usage counters as
+	// complete, it's missing if counters are not maintained or not yet
+	// rebuilt.
+	SYSStorageUsageState KeyPrefix = 0xc5
+	// SYSStateSyncRoot is used to store the state root MPT nodes are being
+	// fetched for during state sync process.
+	SYSStateSyncRoot KeyPrefix = 0xc6
+	// SYSNEP17BalancesState is used to mark NEP-17 balances index as
+	// complete, it's missing if the index is not maintained or not yet
+	// built.
+	SYSNEP17BalancesState KeyPrefix = 0xc7
+	// SYSColdStorageState is used to store the index of the first block
+	// not yet moved to the cold storage along with the moved data size.
+	SYSColdStorageState KeyPrefix = 0xc8
 	SYSVersion          KeyPrefix = 0xf0
 )
 
@@ -72,9 +101,19 @@ type SeekRange struct {
 	SearchDepth int
 }
 
-// ErrKeyNotFound is an error returned by Store implementations
-// when a certain key is not found.
-var ErrKeyNotFound = errors.New("key not found")
+var (
+	// ErrKeyNotFound is an error returned by Store implementations
+	// when a certain key is not found.
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrSnapshotUnsupported is returned by Snapshotter implementations
+	// when the snapshot can't be taken because some of the underlying
+	// Store layers doesn't support it.
+	ErrSnapshotUnsupported = errors.New("snapshots are not supported by the underlying store")
+
+	// errReadOnlySnapshot is returned on attempts to change snapshots that
+	// are read-only.
+	errReadOnlySnapshot = errors.New("snapshot is read-only")
+)
 
 type (
 	// Store is the underlying KV backend for the blockchain data, it's
@@ -97,6 +136,18 @@ type (
 		Close() error
 	}
 
+	// Snapshotter is an optional Store extension implemented by stores that
+	// can provide cheap immutable point-in-time views of their contents.
+	// Snapshot is not affected by subsequent changes made to the original
+	// Store and doesn't block them. Snapshots are intended to be read from,
+	// changes can be made via an upper MemCachedStore layer. Snapshots may
+	// hold resources of the original Store, so they must be closed when
+	// they're no longer needed (closing a snapshot never affects the
+	// original Store).
+	Snapshotter interface {
+		Snapshot() (Store, error)
+	}
+
 	// KeyPrefix is a constant byte added as a prefix for each key
 	// stored.
 	KeyPrefix uint8
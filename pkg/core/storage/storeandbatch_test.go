@@ -234,6 +234,7 @@ func TestAllDBs(t *testing.T) {
 		{"LevelDB", newLevelDBForTesting},
 		{"MemCached", newMemCachedStoreForTesting},
 		{"Memory", newMemoryStoreForTesting},
+		{"Fault", newFaultStoreForTesting},
 	}
 	var tests = []dbTestFunction{testStoreGetNonExistent, testStoreSeek,
 		testStoreSeekGC}
@@ -0,0 +1,253 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"go.uber.org/zap"
+)
+
+// storageUsageLogInterval is the interval between storage usage rebuild
+// progress messages.
+const storageUsageLogInterval = 10 * time.Second
+
+var (
+	// ErrStorageUsageDisabled is returned from storage usage requests if
+	// storage usage tracking is disabled in the node configuration.
+	ErrStorageUsageDisabled = errors.New("storage usage tracking is disabled")
+	// ErrStorageUsageRebuilding is returned from storage usage requests if
+	// storage usage counters are not yet rebuilt.
+	ErrStorageUsageRebuilding = errors.New("storage usage data is being rebuilt")
+)
+
+// storageUsageStateKey is the key of the storage usage completeness marker.
+var storageUsageStateKey = []byte{byte(storage.SYSStorageUsageState)}
+
+// initStorageUsage checks storage usage counters state on startup against
+// the node configuration. It drops stale counters if they can't be trusted,
+// rebuilding is started later by Run.
+func (bc *Blockchain) initStorageUsage() {
+	_, err := bc.dao.Store.Get(storageUsageStateKey)
+	complete := err == nil
+	switch {
+	case bc.config.Ledger.TrackStorageUsage && complete:
+		bc.storageUsageReady.Store(true)
+	case bc.config.Ledger.TrackStorageUsage:
+		// Counters may be left from some previous tracking session and
+		// they're outdated.
+		bc.log.Info("storage usage tracking is enabled, counters will be rebuilt")
+		bc.dao.DeleteAllStorageUsage()
+	case complete:
+		bc.dao.Store.Delete(storageUsageStateKey)
+		bc.dao.DeleteAllStorageUsage()
+	}
+}
+
+// invalidateStorageUsage drops all storage usage counters after direct
+// contract storage changes (like state jump or reset). It must be called with
+// addLock held.
+func (bc *Blockchain) invalidateStorageUsage() {
+	if !bc.config.Ledger.TrackStorageUsage {
+		return
+	}
+	bc.storageUsageReady.Store(false)
+	bc.storageUsageEpoch.Add(1)
+	bc.dao.Store.Delete(storageUsageStateKey)
+	bc.dao.DeleteAllStorageUsage()
+	// It can be called from init when isRunning is not yet initialized.
+	if running, _ := bc.isRunning.Load().(bool); running {
+		bc.startStorageUsageRebuild()
+	}
+}
+
+// startStorageUsageRebuild starts storage usage counters rebuilding routine
+// if it's not yet running.
+func (bc *Blockchain) startStorageUsageRebuild() {
+	if bc.storageUsageRebuilding.CompareAndSwap(false, true) {
+		go bc.rebuildStorageUsage()
+	}
+}
+
+// rebuildStorageUsage recalculates storage usage counters contract by
+// contract. Every contract is processed with addLock held, so no new blocks
+// are being added at the same time. Contracts that are not yet processed can
+// have invalid counters since their incremental updates are based on
+// incomplete data, but they're overwritten when processed. Contracts that
+// are already processed (or created after the rebuild start) have their
+// counters maintained by DAO.
+func (bc *Blockchain) rebuildStorageUsage() {
+	defer bc.storageUsageRebuilding.Store(false)
+
+	var (
+		start     = time.Now()
+		lastLog   = start
+		epoch     uint32
+		next      []byte
+		contracts int
+	)
+	bc.addLock.Lock()
+	epoch = bc.storageUsageEpoch.Load()
+	bc.addLock.Unlock()
+	bc.log.Info("starting storage usage rebuild")
+	for {
+		bc.addLock.Lock()
+		select {
+		case <-bc.stopCh:
+			bc.addLock.Unlock()
+			return
+		default:
+		}
+		if e := bc.storageUsageEpoch.Load(); e != epoch {
+			// Counters were dropped while rebuilding, start from scratch.
+			epoch, next, contracts = e, nil, 0
+		}
+		var (
+			id   int32
+			done bool
+		)
+		id, next, done = bc.rebuildContractStorageUsage(next)
+		if done {
+			bc.finishStorageUsageRebuild()
+			bc.addLock.Unlock()
+			break
+		}
+		bc.addLock.Unlock()
+		contracts++
+		if time.Since(lastLog) >= storageUsageLogInterval {
+			lastLog = time.Now()
+			bc.log.Info("storage usage rebuild is in progress",
+				zap.Int("contracts", contracts),
+				zap.Int32("last id", id))
+		}
+	}
+	bc.log.Info("storage usage rebuild is completed",
+		zap.Int("contracts", contracts),
+		zap.Duration("took", time.Since(start)))
+}
+
+// rebuildContractStorageUsage recalculates counters for the first contract
+// having any storage items with the LE-encoded ID not less than the given
+// one. It returns the contract ID, the LE-encoded ID to start the next step
+// from and a flag denoting that there are no contracts left.
+func (bc *Blockchain) rebuildContractStorageUsage(from []byte) (int32, []byte, bool) {
+	var (
+		prefix = byte(bc.dao.Version.StoragePrefix)
+		idKey  []byte
+	)
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: []byte{prefix}, Start: from}, func(k, _ []byte) bool {
+		if len(k) >= 5 {
+			idKey = bytes.Clone(k[1:5])
+		}
+		return false
+	})
+	if idKey == nil {
+		return 0, nil, true
+	}
+	var (
+		id = int32(binary.LittleEndian.Uint32(idKey))
+		u  state.StorageUsage
+	)
+	bc.dao.Store.Seek(storage.SeekRange{Prefix: append([]byte{prefix}, idKey...)}, func(k, v []byte) bool {
+		u.Items++
+		u.Size += int64(len(k) - 5 + len(v))
+		return true
+	})
+	bc.dao.PutStorageUsage(id, u)
+
+	// Lexicographical successor of idKey.
+	for i := len(idKey) - 1; i >= 0; i-- {
+		idKey[i]++
+		if idKey[i] != 0 {
+			return id, idKey, false
+		}
+	}
+	return id, nil, true
+}
+
+// finishStorageUsageRebuild removes counters for contracts that have no
+// storage items anymore (they could be destroyed during rebuild) and marks
+// counters as complete. It must be called with addLock held.
+func (bc *Blockchain) finishStorageUsageRebuild() {
+	var stale []int32
+	bc.dao.SeekStorageUsage(func(id int32, _ state.StorageUsage) bool {
+		var found bool
+		bc.dao.Seek(id, storage.SeekRange{}, func(_, _ []byte) bool {
+			found = true
+			return false
+		})
+		if !found {
+			stale = append(stale, id)
+		}
+		return true
+	})
+	for _, id := range stale {
+		bc.dao.PutStorageUsage(id, state.StorageUsage{})
+	}
+	bc.dao.Store.Put(storageUsageStateKey, []byte{1})
+	bc.storageUsageReady.Store(true)
+	bc.updateStorageUsageMetrics(bc.dao)
+}
+
+// checkStorageUsage returns an error if storage usage data can't be
+// provided.
+func (bc *Blockchain) checkStorageUsage() error {
+	if !bc.config.Ledger.TrackStorageUsage {
+		return ErrStorageUsageDisabled
+	}
+	if !bc.storageUsageReady.Load() {
+		return ErrStorageUsageRebuilding
+	}
+	return nil
+}
+
+// GetContractStorageUsage returns storage usage statistics of the contract
+// with the given ID. It requires Ledger.TrackStorageUsage setting to be
+// enabled and returns ErrStorageUsageRebuilding until counters are ready.
+func (bc *Blockchain) GetContractStorageUsage(id int32) (state.StorageUsage, error) {
+	if err := bc.checkStorageUsage(); err != nil {
+		return state.StorageUsage{}, err
+	}
+	return bc.dao.GetStorageUsage(id)
+}
+
+// GetTopContractStorageUsage returns storage usage statistics of (at most)
+// n contracts that use the most storage space sorted by size in descending
+// order. Non-positive n means no limit.
+func (bc *Blockchain) GetTopContractStorageUsage(n int) ([]state.ContractStorageUsage, error) {
+	if err := bc.checkStorageUsage(); err != nil {
+		return nil, err
+	}
+	var res []state.ContractStorageUsage
+	bc.dao.SeekStorageUsage(func(id int32, u state.StorageUsage) bool {
+		res = append(res, state.ContractStorageUsage{ID: id, StorageUsage: u})
+		return true
+	})
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Size != res[j].Size {
+			return res[i].Size > res[j].Size
+		}
+		return res[i].ID < res[j].ID
+	})
+	if n > 0 && len(res) > n {
+		res = res[:n]
+	}
+	return res, nil
+}
+
+// updateStorageUsageMetrics updates total storage usage metrics using
+// counters from the given DAO.
+func (bc *Blockchain) updateStorageUsageMetrics(d *dao.Simple) {
+	var total state.StorageUsage
+	d.SeekStorageUsage(func(_ int32, u state.StorageUsage) bool {
+		total.Items += u.Items
+		total.Size += u.Size
+		return true
+	})
+	updateStorageUsageMetric(total)
+}
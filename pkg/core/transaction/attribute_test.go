@@ -113,6 +113,22 @@ func TestAttribute_EncodeBinary(t *testing.T) {
 			require.Error(t, testserdes.DecodeBinary(bw.Bytes(), new(NotaryAssisted)))
 		})
 	})
+	t.Run("Sponsor", func(t *testing.T) {
+		t.Run("positive", func(t *testing.T) {
+			attr := &Attribute{
+				Type: SponsorT,
+				Value: &Sponsor{
+					Account: random.Uint160(),
+				},
+			}
+			testserdes.EncodeDecodeBinary(t, attr, new(Attribute))
+		})
+		t.Run("negative: bad uint160", func(t *testing.T) {
+			bw := io.NewBufBinWriter()
+			bw.WriteBytes(make([]byte, util.Uint160Size-1))
+			require.Error(t, testserdes.DecodeBinary(bw.Bytes(), new(Sponsor)))
+		})
+	})
 }
 
 func TestAttribute_MarshalJSON(t *testing.T) {
@@ -176,4 +192,13 @@ func TestAttribute_MarshalJSON(t *testing.T) {
 		}
 		testserdes.MarshalUnmarshalJSON(t, attr, new(Attribute))
 	})
+	t.Run("Sponsor", func(t *testing.T) {
+		attr := &Attribute{
+			Type: SponsorT,
+			Value: &Sponsor{
+				Account: random.Uint160(),
+			},
+		}
+		testserdes.MarshalUnmarshalJSON(t, attr, new(Attribute))
+	})
 }
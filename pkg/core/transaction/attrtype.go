@@ -19,6 +19,7 @@ const (
 	NotValidBeforeT AttrType = 0x20 // NotValidBefore
 	ConflictsT      AttrType = 0x21 // Conflicts
 	NotaryAssistedT AttrType = 0x22 // NotaryAssisted
+	SponsorT        AttrType = 0x23 // Sponsor
 )
 
 // attrTypes contains a set of valid attribute types (does not include reserved attributes).
@@ -28,6 +29,7 @@ var attrTypes = map[AttrType]struct{}{
 	NotValidBeforeT: {},
 	ConflictsT:      {},
 	NotaryAssistedT: {},
+	SponsorT:        {},
 }
 
 func (a AttrType) allowMultiple() bool {
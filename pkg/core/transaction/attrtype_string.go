@@ -13,16 +13,17 @@ func _() {
 	_ = x[NotValidBeforeT-32]
 	_ = x[ConflictsT-33]
 	_ = x[NotaryAssistedT-34]
+	_ = x[SponsorT-35]
 }
 
 const (
 	_AttrType_name_0 = "HighPriority"
 	_AttrType_name_1 = "OracleResponse"
-	_AttrType_name_2 = "NotValidBeforeConflictsNotaryAssisted"
+	_AttrType_name_2 = "NotValidBeforeConflictsNotaryAssistedSponsor"
 )
 
 var (
-	_AttrType_index_2 = [...]uint8{0, 14, 23, 37}
+	_AttrType_index_2 = [...]uint8{0, 14, 23, 37, 44}
 )
 
 func (i AttrType) String() string {
@@ -31,7 +32,7 @@ func (i AttrType) String() string {
 		return _AttrType_name_0
 	case i == 17:
 		return _AttrType_name_1
-	case 32 <= i && i <= 34:
+	case 32 <= i && i <= 35:
 		i -= 32
 		return _AttrType_name_2[_AttrType_index_2[i]:_AttrType_index_2[i+1]]
 	default:
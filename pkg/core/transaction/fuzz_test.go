@@ -4,6 +4,7 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
@@ -22,3 +23,107 @@ func FuzzNewTransactionFromBytes(f *testing.F) {
 		})
 	})
 }
+
+// checkStrictFixpoint ensures that if b can be decoded strictly then it's
+// encoded back into the same bytes and decoded into the same value.
+func checkStrictFixpoint[T any, PT interface {
+	*T
+	io.Serializable
+}](t *testing.T, b []byte) {
+	var v PT = new(T)
+	if io.DecodeStrict(b, v) != nil {
+		return
+	}
+	data, err := encodeStrict(v)
+	require.NoError(t, err)
+	require.Equal(t, b, data)
+
+	var actual PT = new(T)
+	require.NoError(t, io.DecodeStrict(data, actual))
+	require.Equal(t, v, actual)
+}
+
+func encodeStrict(v io.Serializable) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	v.EncodeBinary(w.BinWriter)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+func FuzzTransactionStrictRoundTrip(f *testing.F) {
+	b, err := base64.StdEncoding.DecodeString(rawInvocationTX)
+	require.NoError(f, err)
+	f.Add(b)
+	tx := New([]byte{0x51}, 1)
+	tx.Signers = []Signer{{
+		Account:          util.Uint160{1, 2, 3},
+		Scopes:           Rules | CustomContracts,
+		AllowedContracts: []util.Uint160{{4, 5, 6}},
+		Rules: []WitnessRule{{
+			Action:    WitnessAllow,
+			Condition: &ConditionAnd{(*ConditionBoolean)(new(bool)), &ConditionCalledByEntry{}},
+		}},
+	}}
+	tx.Attributes = []Attribute{{Type: HighPriority}, {Type: NotValidBeforeT, Value: &NotValidBefore{Height: 123}}}
+	tx.Scripts = []Witness{{InvocationScript: []byte{1, 2, 3}, VerificationScript: []byte{4, 5}}}
+	f.Add(tx.Bytes())
+	f.Fuzz(func(t *testing.T, b []byte) {
+		tx, err := NewTransactionFromBytesStrict(b)
+		if err != nil {
+			return
+		}
+		require.Equal(t, b, tx.Bytes())
+		actual, err := NewTransactionFromBytesStrict(tx.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, tx.Hash(), actual.Hash())
+		require.NoError(t, actual.createHash())
+		require.Equal(t, tx.Hash(), actual.Hash())
+		checkStrictFixpoint[Transaction](t, b)
+	})
+}
+
+func FuzzWitnessStrictRoundTrip(f *testing.F) {
+	f.Add([]byte{0x01, 0x01, 0x02, 0x02, 0x03})
+	f.Add([]byte{0x00, 0x00})
+	f.Fuzz(func(t *testing.T, b []byte) {
+		checkStrictFixpoint[Witness](t, b)
+	})
+}
+
+func FuzzSignerStrictRoundTrip(f *testing.F) {
+	s := Signer{
+		Account:          util.Uint160{1, 2, 3},
+		Scopes:           CalledByEntry | CustomContracts | CustomGroups | Rules,
+		AllowedContracts: []util.Uint160{{4, 5, 6}},
+		AllowedGroups:    nil,
+		Rules: []WitnessRule{{
+			Action:    WitnessDeny,
+			Condition: &ConditionNot{Condition: &ConditionScriptHash{1, 2}},
+		}},
+	}
+	b, err := encodeStrict(&s)
+	require.NoError(f, err)
+	f.Add(b)
+	f.Fuzz(func(t *testing.T, b []byte) {
+		checkStrictFixpoint[Signer](t, b)
+	})
+}
+
+func FuzzAttributeStrictRoundTrip(f *testing.F) {
+	for _, a := range []Attribute{
+		{Type: HighPriority},
+		{Type: OracleResponseT, Value: &OracleResponse{ID: 1, Code: Success, Result: []byte{1, 2}}},
+		{Type: NotValidBeforeT, Value: &NotValidBefore{Height: 1}},
+		{Type: ConflictsT, Value: &Conflicts{Hash: util.Uint256{1}}},
+		{Type: NotaryAssistedT, Value: &NotaryAssisted{NKeys: 3}},
+	} {
+		b, err := encodeStrict(&a)
+		require.NoError(f, err)
+		f.Add(b)
+	}
+	f.Fuzz(func(t *testing.T, b []byte) {
+		checkStrictFixpoint[Attribute](t, b)
+	})
+}
@@ -27,13 +27,13 @@ func (c *Signer) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteBytes(c.Account[:])
 	bw.WriteB(byte(c.Scopes))
 	if c.Scopes&CustomContracts != 0 {
-		bw.WriteArray(c.AllowedContracts)
+		io.WriteSliceOf(bw, c.AllowedContracts)
 	}
 	if c.Scopes&CustomGroups != 0 {
 		bw.WriteArray(c.AllowedGroups)
 	}
 	if c.Scopes&Rules != 0 {
-		bw.WriteArray(c.Rules)
+		io.WriteSliceOf(bw, c.Rules)
 	}
 }
 
@@ -50,13 +50,13 @@ func (c *Signer) DecodeBinary(br *io.BinReader) {
 		return
 	}
 	if c.Scopes&CustomContracts != 0 {
-		br.ReadArray(&c.AllowedContracts, maxSubitems)
+		c.AllowedContracts = io.ReadSliceOf[util.Uint160](br, "allowed contracts", maxSubitems)
 	}
 	if c.Scopes&CustomGroups != 0 {
 		br.ReadArray(&c.AllowedGroups, maxSubitems)
 	}
 	if c.Scopes&Rules != 0 {
-		br.ReadArray(&c.Rules, maxSubitems)
+		c.Rules = io.ReadSliceOf[WitnessRule](br, "witness rules", maxSubitems)
 	}
 }
 
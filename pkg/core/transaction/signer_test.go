@@ -1,6 +1,7 @@
 package transaction
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
@@ -32,3 +33,59 @@ func TestCosignerMarshallUnmarshallJSON(t *testing.T) {
 	actual := &Signer{}
 	testserdes.MarshalUnmarshalJSON(t, expected, actual)
 }
+
+func TestSignerBinaryFixtures(t *testing.T) {
+	pub, err := keys.NewPublicKeyFromString("03b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c")
+	require.NoError(t, err)
+	for name, tc := range map[string]struct {
+		signer Signer
+		hex    string
+	}{
+		"called by entry": {
+			signer: Signer{Account: util.Uint160{1, 2, 3}, Scopes: CalledByEntry},
+			hex:    "010203000000000000000000000000000000000001",
+		},
+		"empty lists": {
+			signer: Signer{
+				Account:          util.Uint160{1, 2, 3},
+				Scopes:           CustomContracts | CustomGroups | Rules,
+				AllowedContracts: []util.Uint160{},
+				AllowedGroups:    []*keys.PublicKey{},
+				Rules:            []WitnessRule{},
+			},
+			hex: "010203000000000000000000000000000000000070000000",
+		},
+		"all scopes": {
+			signer: Signer{
+				Account:          util.Uint160{1, 2, 3},
+				Scopes:           CalledByEntry | CustomContracts | CustomGroups | Rules,
+				AllowedContracts: []util.Uint160{{4, 5, 6}, {7, 8, 9}},
+				AllowedGroups:    []*keys.PublicKey{pub},
+				Rules: []WitnessRule{
+					{Action: WitnessAllow, Condition: ConditionCalledByEntry{}},
+					{Action: WitnessDeny, Condition: &ConditionAnd{
+						(*ConditionScriptHash)(&util.Uint160{10}),
+						ConditionCalledByEntry{},
+					}},
+					{Action: WitnessDeny, Condition: &ConditionNot{Condition: (*ConditionGroup)(pub)}},
+					{Action: WitnessAllow, Condition: &ConditionOr{
+						(*ConditionCalledByContract)(&util.Uint160{11}),
+						(*ConditionCalledByGroup)(pub),
+						(*ConditionBoolean)(new(bool)),
+					}},
+				},
+			},
+			hex: "01020300000000000000000000000000000000007102040506000000000000000000000000000000000007080900000000000000000000000000000000000103b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c040120000202180a000000000000000000000000000000000000002000011903b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c010303280b000000000000000000000000000000000000002903b209fd4f53a7170ea4444e0cb0a6bb6a53c2bd016926989cf85f9b0fba17a70c0000",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := testserdes.EncodeBinary(&tc.signer)
+			require.NoError(t, err)
+			require.Equal(t, tc.hex, hex.EncodeToString(data))
+
+			actual := new(Signer)
+			require.NoError(t, testserdes.DecodeBinary(data, actual))
+			require.Equal(t, tc.signer, *actual)
+		})
+	}
+}
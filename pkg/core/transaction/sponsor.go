@@ -0,0 +1,26 @@
+package transaction
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Sponsor represents attribute designating an account paying transaction
+// fees instead of the sender. The account must be one of transaction signers.
+type Sponsor struct {
+	Account util.Uint160 `json:"account"`
+}
+
+// DecodeBinary implements the io.Serializable interface.
+func (s *Sponsor) DecodeBinary(br *io.BinReader) {
+	s.Account.DecodeBinary(br)
+}
+
+// EncodeBinary implements the io.Serializable interface.
+func (s *Sponsor) EncodeBinary(w *io.BinWriter) {
+	s.Account.EncodeBinary(w)
+}
+
+func (s *Sponsor) toJSONMap(m map[string]any) {
+	m["account"] = s.Account
+}
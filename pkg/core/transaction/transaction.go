@@ -148,30 +148,15 @@ func (t *Transaction) decodeHashableFields(br *io.BinReader, buf []byte) {
 	t.SystemFee = int64(br.ReadU64LE())
 	t.NetworkFee = int64(br.ReadU64LE())
 	t.ValidUntilBlock = br.ReadU32LE()
-	nsigners := br.ReadVarUint()
+	t.Signers = io.ReadSliceOf[Signer](br, "signers", MaxAttributes)
 	if br.Err != nil {
 		return
 	}
-	if nsigners > MaxAttributes {
-		br.Err = errors.New("too many signers")
-		return
-	} else if nsigners == 0 {
+	if len(t.Signers) == 0 {
 		br.Err = errors.New("missing signers")
 		return
 	}
-	t.Signers = make([]Signer, nsigners)
-	for i := 0; i < int(nsigners); i++ {
-		t.Signers[i].DecodeBinary(br)
-	}
-	nattrs := br.ReadVarUint()
-	if nattrs > MaxAttributes-nsigners {
-		br.Err = errors.New("too many attributes")
-		return
-	}
-	t.Attributes = make([]Attribute, nattrs)
-	for i := 0; i < int(nattrs); i++ {
-		t.Attributes[i].DecodeBinary(br)
-	}
+	t.Attributes = io.ReadSliceOf[Attribute](br, "attributes", MaxAttributes-len(t.Signers))
 	t.Script = br.ReadVarBytes(MaxScriptLength)
 	if br.Err == nil {
 		br.Err = t.isValid()
@@ -189,6 +174,9 @@ func (t *Transaction) decodeBinaryNoSize(br *io.BinReader, buf []byte) {
 		return
 	}
 	nscripts := br.ReadVarUint()
+	if br.Err != nil {
+		return
+	}
 	if nscripts > MaxAttributes {
 		br.Err = errors.New("too many witnesses")
 		return
@@ -220,10 +208,7 @@ func (t *Transaction) DecodeBinary(br *io.BinReader) {
 // EncodeBinary implements the Serializable interface.
 func (t *Transaction) EncodeBinary(bw *io.BinWriter) {
 	t.encodeHashableFields(bw)
-	bw.WriteVarUint(uint64(len(t.Scripts)))
-	for i := range t.Scripts {
-		t.Scripts[i].EncodeBinary(bw)
-	}
+	io.WriteSliceOf(bw, t.Scripts)
 }
 
 // encodeHashableFields encodes the fields that are not used for
@@ -234,14 +219,8 @@ func (t *Transaction) encodeHashableFields(bw *io.BinWriter) {
 	bw.WriteU64LE(uint64(t.SystemFee))
 	bw.WriteU64LE(uint64(t.NetworkFee))
 	bw.WriteU32LE(t.ValidUntilBlock)
-	bw.WriteVarUint(uint64(len(t.Signers)))
-	for i := range t.Signers {
-		t.Signers[i].EncodeBinary(bw)
-	}
-	bw.WriteVarUint(uint64(len(t.Attributes)))
-	for i := range t.Attributes {
-		t.Attributes[i].EncodeBinary(bw)
-	}
+	io.WriteSliceOf(bw, t.Signers)
+	io.WriteSliceOf(bw, t.Attributes)
 	bw.WriteVarBytes(t.Script)
 }
 
@@ -296,8 +275,20 @@ func (t *Transaction) Bytes() []byte {
 
 // NewTransactionFromBytes decodes byte array into *Transaction.
 func NewTransactionFromBytes(b []byte) (*Transaction, error) {
+	return newTransactionFromBytes(io.NewBinReaderFromBuf(b), b)
+}
+
+// NewTransactionFromBytesStrict is similar to NewTransactionFromBytes, but it
+// only accepts canonical transaction encoding (see
+// io.NewStrictBinReaderFromBuf), so that the hash of the decoded transaction
+// matches the hash of its re-encoded form. It's used for transactions
+// received from P2P network peers.
+func NewTransactionFromBytesStrict(b []byte) (*Transaction, error) {
+	return newTransactionFromBytes(io.NewStrictBinReaderFromBuf(b), b)
+}
+
+func newTransactionFromBytes(r *io.BinReader, b []byte) (*Transaction, error) {
 	tx := &Transaction{}
-	r := io.NewBinReaderFromBuf(b)
 	tx.decodeBinaryNoSize(r, b)
 	if r.Err != nil {
 		return nil, r.Err
@@ -332,6 +323,19 @@ func (t *Transaction) Sender() util.Uint160 {
 	return t.Signers[0].Account
 }
 
+// FeePayer returns the account paying transaction fees. It's the account
+// specified in the Sponsor attribute if there is any and the sender otherwise.
+// Sponsor attribute is only valid since NeoGoExtensions hardfork, so this method
+// shouldn't be used for fee accounting before it.
+func (t *Transaction) FeePayer() util.Uint160 {
+	for i := range t.Attributes {
+		if t.Attributes[i].Type == SponsorT {
+			return t.Attributes[i].Value.(*Sponsor).Account
+		}
+	}
+	return t.Sender()
+}
+
 // transactionJSON is a wrapper for Transaction and
 // used for correct marhalling of transaction.Data.
 type transactionJSON struct {
@@ -439,6 +443,9 @@ func (t *Transaction) isValid() error {
 			attrs[typ] = true
 		}
 	}
+	if attrs[SponsorT] && !t.HasSigner(t.FeePayer()) {
+		return fmt.Errorf("%w: sponsor is not a signer", ErrInvalidAttribute)
+	}
 	if len(t.Script) == 0 {
 		return ErrEmptyScript
 	}
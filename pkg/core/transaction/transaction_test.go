@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/random"
 	"github.com/nspcc-dev/neo-go/internal/testserdes"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/stretchr/testify/assert"
@@ -106,6 +107,54 @@ func TestNewTransactionFromBytes(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestNewTransactionFromBytesStrict(t *testing.T) {
+	const canonical = "000000000001000000000000000000000000000000010000000101020300000000000000000000000000000000004001010000000151010000"
+
+	b, err := hex.DecodeString(canonical)
+	require.NoError(t, err)
+	tx, err := NewTransactionFromBytesStrict(b)
+	require.NoError(t, err)
+	require.Equal(t, b, tx.Bytes())
+
+	for name, raw := range map[string]string{
+		"script length":     "00000000000100000000000000000000000000000001000000010102030000000000000000000000000000000000400101000000fd010051010000",
+		"witness count":     "000000000001000000000000000000000000000000010000000101020300000000000000000000000000000000004001010000000151fd01000000",
+		"boolean condition": "000000000001000000000000000000000000000000010000000101020300000000000000000000000000000000004001010002000151010000",
+	} {
+		t.Run(name, func(t *testing.T) {
+			b, err := hex.DecodeString(raw)
+			require.NoError(t, err)
+
+			// Non-canonical encoding is accepted by regular decoder, but
+			// the hash differs from the one of the re-encoded transaction.
+			tx, err := NewTransactionFromBytes(b)
+			require.NoError(t, err)
+			require.NotEqual(t, b, tx.Bytes())
+			tx1, err := NewTransactionFromBytes(tx.Bytes())
+			require.NoError(t, err)
+			if name != "witness count" {
+				require.NotEqual(t, tx.Hash(), tx1.Hash())
+			}
+
+			_, err = NewTransactionFromBytesStrict(b)
+			require.ErrorIs(t, err, io.ErrNonCanonical)
+		})
+	}
+
+	_, err = NewTransactionFromBytesStrict(append(b, 0))
+	require.Error(t, err)
+
+	// Boundary varint values are encoded canonically.
+	tx = New(make([]byte, MaxScriptLength), 1)
+	tx.Signers = []Signer{{Account: util.Uint160{1, 2, 3}}}
+	tx.Scripts = []Witness{{InvocationScript: []byte{}, VerificationScript: []byte{}}}
+	b = tx.Bytes()
+	require.Equal(t, tx.Size(), len(b))
+	tx1, err := NewTransactionFromBytesStrict(b)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), tx1.Hash())
+}
+
 func TestEncodingTXWithNoScript(t *testing.T) {
 	_, err := testserdes.EncodeBinary(new(Transaction))
 	require.NoError(t, err) // Garbage in -> garbage out.
@@ -211,6 +260,11 @@ func TestTransaction_isValid(t *testing.T) {
 			tx.Attributes = []Attribute{{Type: HighPriority}}
 			require.NoError(t, tx.isValid())
 		})
+		t.Run("Sponsor", func(t *testing.T) {
+			tx := newTx()
+			tx.Attributes = []Attribute{{Type: SponsorT, Value: &Sponsor{Account: tx.Signers[1].Account}}}
+			require.NoError(t, tx.isValid())
+		})
 	})
 	t.Run("InvalidVersion", func(t *testing.T) {
 		tx := newTx()
@@ -258,6 +312,19 @@ func TestTransaction_isValid(t *testing.T) {
 		}
 		require.ErrorIs(t, tx.isValid(), ErrInvalidAttribute)
 	})
+	t.Run("MultipleSponsor", func(t *testing.T) {
+		tx := newTx()
+		tx.Attributes = []Attribute{
+			{Type: SponsorT, Value: &Sponsor{Account: tx.Signers[0].Account}},
+			{Type: SponsorT, Value: &Sponsor{Account: tx.Signers[1].Account}},
+		}
+		require.ErrorIs(t, tx.isValid(), ErrInvalidAttribute)
+	})
+	t.Run("SponsorIsNotSigner", func(t *testing.T) {
+		tx := newTx()
+		tx.Attributes = []Attribute{{Type: SponsorT, Value: &Sponsor{Account: util.Uint160{7, 8, 9}}}}
+		require.ErrorIs(t, tx.isValid(), ErrInvalidAttribute)
+	})
 	t.Run("NoScript", func(t *testing.T) {
 		tx := newTx()
 		tx.Script = []byte{}
@@ -308,6 +375,20 @@ func TestTransaction_HasSigner(t *testing.T) {
 	require.False(t, tx.HasSigner(util.Uint160{}))
 }
 
+func TestTransaction_FeePayer(t *testing.T) {
+	u1, u2 := random.Uint160(), random.Uint160()
+	tx := Transaction{
+		Signers: []Signer{
+			{Account: u1}, {Account: u2},
+		},
+		Attributes: []Attribute{{Type: HighPriority}},
+	}
+	require.Equal(t, u1, tx.FeePayer())
+	tx.Attributes = append(tx.Attributes, Attribute{Type: SponsorT, Value: &Sponsor{Account: u2}})
+	require.Equal(t, u2, tx.FeePayer())
+	require.Equal(t, u1, tx.Sender())
+}
+
 func BenchmarkTxHash(b *testing.B) {
 	script := []byte{0x51}
 	tx := New(script, 1)
@@ -321,3 +402,67 @@ func BenchmarkTxHash(b *testing.B) {
 		_ = tx.Hash()
 	}
 }
+
+func TestTransactionBinaryFixture(t *testing.T) {
+	const expected = "002a0000009488010000000000e8030000000000007b000000020102030000000000000000000000000000000000010405060000000000000000000000000000000000500107080900000000000000000000000000000000000100200201210102030000000000000000000000000000000000000000000000000000000000021140020301020303040506000107"
+	tx := New([]byte{byte(opcode.PUSH1), byte(opcode.RET)}, 100500)
+	tx.Nonce = 42
+	tx.NetworkFee = 1000
+	tx.ValidUntilBlock = 123
+	tx.Signers = []Signer{
+		{Account: util.Uint160{1, 2, 3}, Scopes: CalledByEntry},
+		{
+			Account:          util.Uint160{4, 5, 6},
+			Scopes:           CustomContracts | Rules,
+			AllowedContracts: []util.Uint160{{7, 8, 9}},
+			Rules:            []WitnessRule{{Action: WitnessDeny, Condition: ConditionCalledByEntry{}}},
+		},
+	}
+	tx.Attributes = []Attribute{
+		{Type: HighPriority},
+		{Type: ConflictsT, Value: &Conflicts{Hash: util.Uint256{1, 2, 3}}},
+	}
+	tx.Scripts = []Witness{
+		{InvocationScript: []byte{1, 2, 3}, VerificationScript: []byte{4, 5, 6}},
+		{InvocationScript: []byte{}, VerificationScript: []byte{7}},
+	}
+	data, err := testserdes.EncodeBinary(tx)
+	require.NoError(t, err)
+	require.Equal(t, expected, hex.EncodeToString(data))
+
+	actual, err := NewTransactionFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), actual.Hash())
+	require.Equal(t, tx.Signers, actual.Signers)
+	require.Equal(t, tx.Attributes, actual.Attributes)
+	require.Equal(t, tx.Scripts, actual.Scripts)
+}
+
+func TestTransactionDecodeLimits(t *testing.T) {
+	newTx := func(nsigners, nattrs int) []byte {
+		tx := New([]byte{byte(opcode.RET)}, 0)
+		tx.Signers = make([]Signer, nsigners)
+		tx.Scripts = make([]Witness, nsigners)
+		for i := range tx.Signers {
+			tx.Signers[i].Account = util.Uint160{byte(i)}
+			tx.Scripts[i] = Witness{InvocationScript: []byte{}, VerificationScript: []byte{}}
+		}
+		tx.Attributes = make([]Attribute, nattrs)
+		for i := range tx.Attributes {
+			tx.Attributes[i] = Attribute{Type: ConflictsT, Value: &Conflicts{Hash: util.Uint256{byte(i)}}}
+		}
+		data, err := testserdes.EncodeBinary(tx)
+		require.NoError(t, err)
+		return data
+	}
+	_, err := NewTransactionFromBytes(newTx(MaxAttributes, 0))
+	require.NoError(t, err)
+	_, err = NewTransactionFromBytes(newTx(MaxAttributes/2, MaxAttributes/2))
+	require.NoError(t, err)
+	_, err = NewTransactionFromBytes(newTx(0, 1))
+	require.Error(t, err)
+	_, err = NewTransactionFromBytes(newTx(MaxAttributes+1, 0))
+	require.ErrorContains(t, err, "too many signers")
+	_, err = NewTransactionFromBytes(newTx(MaxAttributes/2, MaxAttributes/2+1))
+	require.ErrorContains(t, err, "too many attributes")
+}
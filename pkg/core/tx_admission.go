@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/dao"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ErrTxAdmission is returned when transaction is rejected by the node's
+// TxAdmissionFilter.
+var ErrTxAdmission = errors.New("rejected by admission filter")
+
+// TxAdmissionFilter is a node-local check performed for transactions entering
+// the node's memory pool after the standard verification. It can be used to
+// implement custom node policies (like address deny-lists or amount limits).
+// Filter is never applied to transactions of blocks being processed or
+// verified by consensus, so it doesn't affect the chain state and nodes can
+// have different filters.
+type TxAdmissionFilter interface {
+	// Check returns an error if the transaction is not to be accepted.
+	// The DAO given is a private view of the current chain state, changes
+	// made to it are discarded. Check is called under the Blockchain lock,
+	// so it must not call Blockchain methods.
+	Check(tx *transaction.Transaction, d *dao.Simple) error
+}
+
+// SignerDenyList is a sample TxAdmissionFilter rejecting transactions signed
+// by any of the given accounts.
+type SignerDenyList map[util.Uint160]struct{}
+
+// NewSignerDenyList creates a SignerDenyList for the given accounts.
+func NewSignerDenyList(accounts ...util.Uint160) SignerDenyList {
+	var l = make(SignerDenyList, len(accounts))
+	for _, acc := range accounts {
+		l[acc] = struct{}{}
+	}
+	return l
+}
+
+// Check implements the TxAdmissionFilter interface.
+func (l SignerDenyList) Check(tx *transaction.Transaction, _ *dao.Simple) error {
+	for _, s := range tx.Signers {
+		if _, ok := l[s.Account]; ok {
+			return fmt.Errorf("signer %s is denied", s.Account.StringLE())
+		}
+	}
+	return nil
+}
+
+// SetTxAdmissionFilter sets the filter for transactions entering the memory
+// pool. It can safely be called on the running blockchain. To remove the
+// filter use SetTxAdmissionFilter(nil).
+func (bc *Blockchain) SetTxAdmissionFilter(f TxAdmissionFilter) {
+	if f == nil {
+		bc.admissionFilter.Store(nil)
+		return
+	}
+	bc.admissionFilter.Store(&f)
+}
+
+// checkTxAdmission applies the admission filter (if any) to the transaction.
+// It must be called under the Blockchain lock.
+func (bc *Blockchain) checkTxAdmission(t *transaction.Transaction) error {
+	f := bc.admissionFilter.Load()
+	if f == nil {
+		return nil
+	}
+	if err := (*f).Check(t, bc.dao.GetPrivate()); err != nil {
+		updateTxAdmissionRejectedMetric()
+		return fmt.Errorf("%w: %w", ErrTxAdmission, err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"go.uber.org/zap"
+)
+
+// Default mempool parking area settings.
+const (
+	defaultParkingCapacity    = 1000
+	defaultParkingPerSender   = 16
+	defaultParkingMaxDistance = 20
+)
+
+var (
+	// ErrTxNotYetValid is returned when transaction's NotValidBefore height
+	// is not yet reached.
+	ErrTxNotYetValid = errors.New("transaction is not yet valid")
+	// ErrTxParked is returned when transaction is not yet valid, but it's
+	// kept in the mempool parking area and will be added to the mempool
+	// once it becomes valid. It's not a verification failure.
+	ErrTxParked = errors.New("transaction is parked until it becomes valid")
+)
+
+// initParking applies default mempool parking area settings and enables it.
+func (bc *Blockchain) initParking() {
+	cfg := &bc.config.Ledger.MemPoolParking
+	if !cfg.Enabled {
+		return
+	}
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = defaultParkingCapacity
+		bc.log.Info("MemPoolParking.Capacity is not set or wrong, using default value", zap.Int("Capacity", cfg.Capacity))
+	}
+	if cfg.PerSender <= 0 {
+		cfg.PerSender = defaultParkingPerSender
+		bc.log.Info("MemPoolParking.PerSender is not set or wrong, using default value", zap.Int("PerSender", cfg.PerSender))
+	}
+	if cfg.MaxDistance == 0 {
+		cfg.MaxDistance = defaultParkingMaxDistance
+		bc.log.Info("MemPoolParking.MaxDistance is not set or wrong, using default value", zap.Uint32("MaxDistance", cfg.MaxDistance))
+	}
+	bc.memPool.SetParking(cfg.Capacity, cfg.PerSender)
+}
+
+// parkTx tries to put the transaction that failed verification with verr
+// because of its NotValidBefore attribute into the mempool parking area. It
+// returns ErrTxParked on success and verr if the transaction can't be parked.
+// It must be called under the Blockchain lock after all other verification
+// checks.
+func (bc *Blockchain) parkTx(t *transaction.Transaction, verr error) error {
+	cfg := bc.config.Ledger.MemPoolParking
+	if !cfg.Enabled {
+		return verr
+	}
+	nvb := t.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height
+	if nvb > bc.BlockHeight()+cfg.MaxDistance {
+		return verr
+	}
+	if err := bc.checkTxAdmission(t); err != nil {
+		return err
+	}
+	err := bc.memPool.Park(t, nvb)
+	if err != nil {
+		switch {
+		case errors.Is(err, mempool.ErrDup):
+			return ErrAlreadyInPool
+		default:
+			return fmt.Errorf("%w (%w)", verr, err)
+		}
+	}
+	updateParkingMetrics(bc.memPool.ParkedCount(), 1, 0, 0)
+	return ErrTxParked
+}
+
+// promoteParkedTxs re-verifies parked transactions that can be valid at the
+// given height and adds them to the mempool, promoted transactions are
+// relayed. It must be called under the Blockchain lock after the block
+// with the given index is stored.
+func (bc *Blockchain) promoteParkedTxs(height uint32) {
+	ready, expired := bc.memPool.TakeParked(height)
+	if len(ready) == 0 && expired == 0 {
+		return
+	}
+	var promoted = make([]*transaction.Transaction, 0, len(ready))
+	for _, t := range ready {
+		err := bc.verifyAndPoolTx(t, bc.memPool, bc)
+		if err != nil {
+			bc.log.Debug("parked transaction dropped",
+				zap.String("hash", t.Hash().StringLE()),
+				zap.Error(err))
+			expired++
+			continue
+		}
+		promoted = append(promoted, t)
+	}
+	updateParkingMetrics(bc.memPool.ParkedCount(), 0, len(promoted), expired)
+	bc.memPool.Relay(promoted)
+}
@@ -96,6 +96,69 @@ func CalcMerkleRoot(hashes []util.Uint256) util.Uint256 {
 	return CalcMerkleRoot(parents)
 }
 
+// CalcMerkleProof calculates the Merkle audit path for the hash with the given
+// index in the given slice of hashes. The path contains sibling hashes from the
+// leaf level up to the root (excluding it), it's empty for a single-element
+// tree. The tree is the same as the one used by CalcMerkleRoot, so if a node
+// has no sibling on some level (the last one for an odd number of nodes), the
+// node itself is used as a sibling. The given slice is not modified.
+func CalcMerkleProof(hashes []util.Uint256, index int) ([]util.Uint256, error) {
+	if index < 0 || index >= len(hashes) {
+		return nil, errors.New("index is out of range")
+	}
+	var (
+		path    []util.Uint256
+		level   = hashes
+		scratch = make([]byte, 64)
+	)
+	for len(level) > 1 {
+		sibling := index ^ 1
+		if sibling == len(level) {
+			sibling = index
+		}
+		path = append(path, level[sibling])
+
+		parents := make([]util.Uint256, (len(level)+1)/2)
+		for i := range parents {
+			copy(scratch, level[i*2].BytesBE())
+			if i*2+1 == len(level) {
+				copy(scratch[32:], level[i*2].BytesBE())
+			} else {
+				copy(scratch[32:], level[i*2+1].BytesBE())
+			}
+			parents[i] = DoubleSha256(scratch)
+		}
+		level = parents
+		index /= 2
+	}
+	return path, nil
+}
+
+// VerifyMerkleProof checks that the given hash with the given index is a part of
+// the Merkle tree with the given root using the audit path returned by
+// CalcMerkleProof. Notice that since the last node of an odd-sized level is
+// paired with itself, a proof for the last hash is also valid for the
+// (non-existent) index following it, so callers that need to check the index
+// against the number of hashes should do it separately.
+func VerifyMerkleProof(root util.Uint256, h util.Uint256, path []util.Uint256, index uint32) bool {
+	if len(path) < 32 && index>>len(path) != 0 {
+		return false
+	}
+	scratch := make([]byte, 64)
+	for _, sibling := range path {
+		if index&1 == 0 {
+			copy(scratch, h.BytesBE())
+			copy(scratch[32:], sibling.BytesBE())
+		} else {
+			copy(scratch, sibling.BytesBE())
+			copy(scratch[32:], h.BytesBE())
+		}
+		h = DoubleSha256(scratch)
+		index >>= 1
+	}
+	return h == root
+}
+
 // MerkleTreeNode represents a node in the MerkleTree.
 type MerkleTreeNode struct {
 	hash       util.Uint256
@@ -18,7 +18,7 @@ func testComputeMerkleTree(t *testing.T, hexHashes []string, result string) {
 
 	merkle, err := NewMerkleTree(hashes)
 	require.NoError(t, err)
-	optimized := CalcMerkleRoot(hashes)
+	optimized := CalcMerkleRoot(append([]util.Uint256{}, hashes...))
 	assert.Equal(t, result, optimized.StringLE())
 	assert.Equal(t, result, merkle.Root().StringLE())
 	assert.Equal(t, true, merkle.root.IsRoot())
@@ -33,6 +33,12 @@ func testComputeMerkleTree(t *testing.T, hexHashes []string, result string) {
 	}
 	assert.Equal(t, true, leaf.IsLeaf())
 	assert.Equal(t, false, leaf.IsRoot())
+
+	for i := range hashes {
+		path, err := CalcMerkleProof(hashes, i)
+		require.NoError(t, err)
+		require.True(t, VerifyMerkleProof(optimized, hashes[i], path, uint32(i)), i)
+	}
 }
 
 func TestComputeMerkleTree1(t *testing.T) {
@@ -101,3 +107,43 @@ func TestBuildMerkleTreeWithoutNodes(t *testing.T) {
 	leaves = make([]*MerkleTreeNode, 0)
 	require.Panics(t, func() { buildMerkleTree(leaves) })
 }
+
+func TestMerkleProof(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		hashes := make([]util.Uint256, n)
+		for i := range hashes {
+			hashes[i] = Sha256([]byte{byte(n), byte(i)})
+		}
+		root := CalcMerkleRoot(append([]util.Uint256{}, hashes...))
+		for i := range hashes {
+			path, err := CalcMerkleProof(hashes, i)
+			require.NoError(t, err)
+			require.True(t, VerifyMerkleProof(root, hashes[i], path, uint32(i)), "n=%d, i=%d", n, i)
+
+			require.False(t, VerifyMerkleProof(root, Sha256([]byte{0xff}), path, uint32(i)), "n=%d, i=%d", n, i)
+			require.False(t, VerifyMerkleProof(root, hashes[i], path, uint32(i)+1<<len(path)), "n=%d, i=%d", n, i)
+			if len(path) != 0 {
+				require.False(t, VerifyMerkleProof(root, hashes[i], path[1:], uint32(i)), "n=%d, i=%d", n, i)
+				badPath := append([]util.Uint256{}, path...)
+				badPath[0] = Sha256([]byte{0xff})
+				require.False(t, VerifyMerkleProof(root, hashes[i], badPath, uint32(i)), "n=%d, i=%d", n, i)
+			}
+			if i != n-1 || i%2 == 1 {
+				// The last odd leaf is paired with itself, so swapping it
+				// with the sibling gives the same result.
+				if len(path) != 0 {
+					require.False(t, VerifyMerkleProof(root, hashes[i], path, uint32(i^1)), "n=%d, i=%d", n, i)
+				}
+			}
+		}
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		_, err := CalcMerkleProof(nil, 0)
+		require.Error(t, err)
+		_, err = CalcMerkleProof([]util.Uint256{{1}}, 1)
+		require.Error(t, err)
+		_, err = CalcMerkleProof([]util.Uint256{{1}}, -1)
+		require.Error(t, err)
+	})
+}
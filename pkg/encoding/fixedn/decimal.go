@@ -1,17 +1,29 @@
 package fixedn
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
 	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
 
 const maxAllowedPrecision = 16
 
-// ErrInvalidFormat is returned when decimal format is invalid.
-var ErrInvalidFormat = errors.New("invalid decimal format")
+var (
+	// ErrInvalidFormat is returned when decimal format is invalid.
+	ErrInvalidFormat = errors.New("invalid decimal format")
+	// ErrPrecisionLoss is returned when a decimal can't be represented with
+	// the requested number of decimals without rounding.
+	ErrPrecisionLoss = errors.New("precision loss")
+	// ErrOverflow is returned when the result of an operation doesn't fit
+	// into the VM integer.
+	ErrOverflow = errors.New("decimal overflow")
+	// ErrInvalidDecimals is returned for negative number of decimals.
+	ErrInvalidDecimals = errors.New("invalid number of decimals")
+)
 
 var _pow10 []*big.Int
 
@@ -36,45 +48,311 @@ func pow10(n int) *big.Int {
 	return p
 }
 
-// ToString converts a big decimal with the specified precision to a string.
-func ToString(bi *big.Int, precision int) string {
-	var dp, fp big.Int
-	dp.QuoRem(bi, pow10(precision), &fp)
+// Decimal is a fixed-point number with an arbitrary number of decimals, it's
+// an integer value scaled by 10^decimals (like NEP-17 token amounts are).
+// Its raw value is limited by the VM integer size, operations exceeding it
+// return ErrOverflow. Zero value is a valid 0 with no decimals. Decimal is
+// immutable, all operations return a new one.
+type Decimal struct {
+	value    *big.Int
+	decimals int
+}
 
-	var s = dp.String()
-	if fp.Sign() == 0 {
-		return s
+// NewDecimal creates a Decimal from the raw value with the given number of
+// decimals (so that NewDecimal(big.NewInt(15), 1) is 1.5). The value is
+// copied. It panics if decimals is negative.
+func NewDecimal(value *big.Int, decimals int) Decimal {
+	if decimals < 0 {
+		panic(ErrInvalidDecimals)
 	}
-	frac := fp.Uint64()
-	trimmed := 0
-	for ; frac%10 == 0; frac /= 10 {
-		trimmed++
+	var v = new(big.Int)
+	if value != nil {
+		v.Set(value)
 	}
-	return s + "." + fmt.Sprintf("%0"+strconv.FormatUint(uint64(precision-trimmed), 10)+"d", frac)
+	return Decimal{value: v, decimals: decimals}
 }
 
-// FromString converts a string to a big decimal with the specified precision.
-func FromString(s string, precision int) (*big.Int, error) {
-	parts := strings.SplitN(s, ".", 2)
-	bi, ok := new(big.Int).SetString(parts[0], 10)
+// ParseDecimal parses a human-readable decimal string like "-1.5". The
+// number of decimals of the result is the number of fractional digits in the
+// string (including trailing zeroes).
+func ParseDecimal(s string) (Decimal, error) {
+	var neg bool
+	if len(s) != 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	ip, fp, hasDot := strings.Cut(s, ".")
+	if !isDigits(ip) || (hasDot && !isDigits(fp)) {
+		return Decimal{}, ErrInvalidFormat
+	}
+	v, ok := new(big.Int).SetString(ip+fp, 10)
 	if !ok {
-		return nil, ErrInvalidFormat
+		return Decimal{}, ErrInvalidFormat
+	}
+	if neg {
+		v.Neg(v)
+	}
+	if err := stackitem.CheckIntegerSize(v); err != nil {
+		return Decimal{}, ErrOverflow
 	}
-	bi.Mul(bi, pow10(precision))
-	if len(parts) == 1 {
-		return bi, nil
+	return Decimal{value: v, decimals: len(fp)}, nil
+}
+
+// DecimalFromString parses a human-readable decimal string and converts it to
+// the given number of decimals. It returns ErrPrecisionLoss if the string has
+// more significant fractional digits.
+func DecimalFromString(s string, decimals int) (Decimal, error) {
+	d, err := ParseDecimal(s)
+	if err != nil {
+		return Decimal{}, err
 	}
+	return d.Rescale(decimals)
+}
 
-	if len(parts[1]) > precision {
-		return nil, ErrInvalidFormat
+// DecimalFromStackItem converts an integer stack item (like a token balance)
+// into a Decimal with the given number of decimals.
+func DecimalFromStackItem(item stackitem.Item, decimals int) (Decimal, error) {
+	if decimals < 0 {
+		return Decimal{}, ErrInvalidDecimals
 	}
-	fp, ok := new(big.Int).SetString(parts[1], 10)
-	if !ok {
-		return nil, ErrInvalidFormat
+	v, err := item.TryInteger()
+	if err != nil {
+		return Decimal{}, err
+	}
+	return NewDecimal(v, decimals), nil
+}
+
+func isDigits(s string) bool {
+	if len(s) == 0 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// val returns the raw value treating nil as zero.
+func (d Decimal) val() *big.Int {
+	if d.value == nil {
+		return new(big.Int)
+	}
+	return d.value
+}
+
+// Value returns a copy of the raw (scaled) value of d.
+func (d Decimal) Value() *big.Int {
+	return new(big.Int).Set(d.val())
+}
+
+// Decimals returns the number of decimals of d.
+func (d Decimal) Decimals() int {
+	return d.decimals
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.val().Sign()
+}
+
+// Cmp compares d and x (that can have a different number of decimals) and
+// returns -1, 0 or 1 if d is less than, equal to or greater than x.
+func (d Decimal) Cmp(x Decimal) int {
+	a, b := d.val(), x.val()
+	switch {
+	case d.decimals < x.decimals:
+		a = new(big.Int).Mul(a, pow10(x.decimals-d.decimals))
+	case d.decimals > x.decimals:
+		b = new(big.Int).Mul(b, pow10(d.decimals-x.decimals))
+	}
+	return a.Cmp(b)
+}
+
+// Rescale converts d to the given number of decimals. It returns
+// ErrPrecisionLoss if d can't be represented exactly with this number of
+// decimals and ErrOverflow if the resulting raw value is too big.
+func (d Decimal) Rescale(decimals int) (Decimal, error) {
+	if decimals < 0 {
+		return Decimal{}, ErrInvalidDecimals
+	}
+	var v = new(big.Int)
+	if decimals >= d.decimals {
+		v.Mul(d.val(), pow10(decimals-d.decimals))
+		return checked(v, decimals)
+	}
+	var r big.Int
+	v.QuoRem(d.val(), pow10(d.decimals-decimals), &r)
+	if r.Sign() != 0 {
+		return Decimal{}, ErrPrecisionLoss
+	}
+	return Decimal{value: v, decimals: decimals}, nil
+}
+
+// Round converts d to the given number of decimals rounding half away from
+// zero if it has more of them.
+func (d Decimal) Round(decimals int) (Decimal, error) {
+	return d.reduce(decimals, true)
+}
+
+// Truncate converts d to the given number of decimals dropping excessive
+// fractional digits (rounding towards zero).
+func (d Decimal) Truncate(decimals int) (Decimal, error) {
+	return d.reduce(decimals, false)
+}
+
+func (d Decimal) reduce(decimals int, round bool) (Decimal, error) {
+	if decimals < 0 {
+		return Decimal{}, ErrInvalidDecimals
+	}
+	if decimals >= d.decimals {
+		return d.Rescale(decimals)
 	}
-	fp.Mul(fp, pow10(precision-len(parts[1])))
-	if bi.Sign() == -1 {
-		return bi.Sub(bi, fp), nil
+	var (
+		p = pow10(d.decimals - decimals)
+		v = new(big.Int)
+		r big.Int
+	)
+	v.QuoRem(d.val(), p, &r)
+	if round && r.Sign() != 0 {
+		r.Abs(&r)
+		if r.Lsh(&r, 1).Cmp(p) >= 0 {
+			v.Add(v, big.NewInt(int64(d.val().Sign())))
+		}
+	}
+	return Decimal{value: v, decimals: decimals}, nil
+}
+
+// Add returns d+x, the result has the biggest number of decimals of the two.
+func (d Decimal) Add(x Decimal) (Decimal, error) {
+	a, b, err := align(d, x)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return checked(a.value.Add(a.value, b.value), a.decimals)
+}
+
+// Sub returns d-x, the result has the biggest number of decimals of the two.
+func (d Decimal) Sub(x Decimal) (Decimal, error) {
+	a, b, err := align(d, x)
+	if err != nil {
+		return Decimal{}, err
+	}
+	return checked(a.value.Sub(a.value, b.value), a.decimals)
+}
+
+// Mul returns d*x, the number of decimals of the result is the sum of the
+// operand ones, so it's exact. Use Round or Truncate to reduce it.
+func (d Decimal) Mul(x Decimal) (Decimal, error) {
+	return checked(new(big.Int).Mul(d.val(), x.val()), d.decimals+x.decimals)
+}
+
+// align converts both decimals to the biggest number of decimals of the two,
+// values of the results are always new ones.
+func align(a, b Decimal) (Decimal, Decimal, error) {
+	var dec = a.decimals
+	if b.decimals > dec {
+		dec = b.decimals
+	}
+	a, err := a.Rescale(dec)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	b, err = b.Rescale(dec)
+	if err != nil {
+		return Decimal{}, Decimal{}, err
+	}
+	return a, b, nil
+}
+
+func checked(v *big.Int, decimals int) (Decimal, error) {
+	if err := stackitem.CheckIntegerSize(v); err != nil {
+		return Decimal{}, ErrOverflow
+	}
+	return Decimal{value: v, decimals: decimals}, nil
+}
+
+// Integer returns the raw value of d converted to the given number of
+// decimals, it's the integer to be passed to a token contract with this
+// number of decimals. The conversion is exact, see Rescale.
+func (d Decimal) Integer(decimals int) (*big.Int, error) {
+	r, err := d.Rescale(decimals)
+	if err != nil {
+		return nil, err
+	}
+	return r.value, nil
+}
+
+// ToStackItem converts d into an integer stack item for a token with the given
+// number of decimals, see Integer.
+func (d Decimal) ToStackItem(decimals int) (stackitem.Item, error) {
+	v, err := d.Integer(decimals)
+	if err != nil {
+		return nil, err
+	}
+	return stackitem.NewBigInteger(v), nil
+}
+
+// String implements the fmt.Stringer interface. It returns a human-readable
+// representation of d without trailing fractional zeroes.
+func (d Decimal) String() string {
+	v := d.val()
+	if d.decimals == 0 {
+		return v.String()
+	}
+	s := new(big.Int).Abs(v).String()
+	if len(s) <= d.decimals {
+		s = strings.Repeat("0", d.decimals-len(s)+1) + s
+	}
+	var (
+		ip = s[:len(s)-d.decimals]
+		fp = strings.TrimRight(s[len(s)-d.decimals:], "0")
+	)
+	if fp != "" {
+		ip += "." + fp
+	}
+	if v.Sign() < 0 {
+		ip = "-" + ip
+	}
+	return ip
+}
+
+// MarshalJSON implements the json.Marshaler interface, Decimal is marshaled
+// as a string.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface, both strings and
+// numbers are accepted.
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	var s string
+	if len(data) != 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+	} else {
+		s = string(data)
+	}
+	r, err := ParseDecimal(s)
+	if err != nil {
+		return fmt.Errorf("%w: %q", err, s)
+	}
+	*d = r
+	return nil
+}
+
+// ToString converts a big decimal with the specified precision to a string.
+func ToString(bi *big.Int, precision int) string {
+	return NewDecimal(bi, precision).String()
+}
+
+// FromString converts a string to a big decimal with the specified precision.
+func FromString(s string, precision int) (*big.Int, error) {
+	d, err := DecimalFromString(s, precision)
+	if err != nil {
+		return nil, err
 	}
-	return bi.Add(bi, fp), nil
+	return d.value, nil
 }
@@ -1,9 +1,11 @@
 package fixedn
 
 import (
+	"encoding/json"
 	"math/big"
 	"testing"
 
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +53,273 @@ func TestDecimalFromStringBad(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDecimal(t *testing.T) {
+	var testCases = []struct {
+		s   string
+		v   int64
+		dec int
+		str string
+	}{
+		{"0", 0, 0, "0"},
+		{"-0", 0, 0, "0"},
+		{"+1", 1, 0, "1"},
+		{"1.5", 15, 1, "1.5"},
+		{"1.50", 150, 2, "1.5"},
+		{"-1.50", -150, 2, "-1.5"},
+		{"-0.5", -5, 1, "-0.5"},
+		{"0.000", 0, 3, "0"},
+		{"007.010", 7010, 3, "7.01"},
+		{"-0.00000001", -1, 8, "-0.00000001"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			d, err := ParseDecimal(tc.s)
+			require.NoError(t, err)
+			require.Equal(t, big.NewInt(tc.v), d.Value())
+			require.Equal(t, tc.dec, d.Decimals())
+			require.Equal(t, tc.str, d.String())
+		})
+	}
+	for _, s := range []string{"", "-", "+", ".", "1.", ".5", "-.5", "1..5", "1.5.", "--1", "1-", "1.-5", "1.+5", "1e5", " 1", "1 ", "0x10", "1_000", "١"} {
+		t.Run("bad "+s, func(t *testing.T) {
+			_, err := ParseDecimal(s)
+			require.ErrorIs(t, err, ErrInvalidFormat)
+		})
+	}
+	t.Run("overflow", func(t *testing.T) {
+		max := new(big.Int).Lsh(big.NewInt(1), 255)
+		_, err := ParseDecimal(max.String())
+		require.ErrorIs(t, err, ErrOverflow)
+		d, err := ParseDecimal("-" + max.String())
+		require.NoError(t, err)
+		require.Equal(t, new(big.Int).Neg(max), d.Value())
+		max.Sub(max, big.NewInt(1))
+		_, err = ParseDecimal(max.String()[:10] + "." + max.String()[10:])
+		require.NoError(t, err)
+	})
+}
+
+func TestDecimalFromString(t *testing.T) {
+	d, err := DecimalFromString("1.5", 8)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(150000000), d.Value())
+	require.Equal(t, 8, d.Decimals())
+
+	d, err = DecimalFromString("1.500", 1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(15), d.Value())
+
+	_, err = DecimalFromString("1.55", 1)
+	require.ErrorIs(t, err, ErrPrecisionLoss)
+	_, err = DecimalFromString("1", -1)
+	require.ErrorIs(t, err, ErrInvalidDecimals)
+	_, err = DecimalFromString("1", 77)
+	require.ErrorIs(t, err, ErrOverflow)
+	_, err = DecimalFromString("0", 1000)
+	require.NoError(t, err)
+}
+
+func TestDecimalZero(t *testing.T) {
+	var d Decimal
+	require.Equal(t, "0", d.String())
+	require.Equal(t, 0, d.Sign())
+	require.Equal(t, big.NewInt(0), d.Value())
+	s, err := d.Add(NewDecimal(big.NewInt(5), 1))
+	require.NoError(t, err)
+	require.Equal(t, "0.5", s.String())
+	require.Equal(t, "0", NewDecimal(nil, 3).String())
+	require.Panics(t, func() { NewDecimal(big.NewInt(1), -1) })
+}
+
+func TestDecimalRounding(t *testing.T) {
+	var testCases = []struct {
+		s        string
+		dec      int
+		round    string
+		truncate string
+	}{
+		{"1.25", 1, "1.3", "1.2"},
+		{"1.24", 1, "1.2", "1.2"},
+		{"1.26", 1, "1.3", "1.2"},
+		{"-1.25", 1, "-1.3", "-1.2"},
+		{"-1.24", 1, "-1.2", "-1.2"},
+		{"-1.26", 1, "-1.3", "-1.2"},
+		{"0.5", 0, "1", "0"},
+		{"-0.5", 0, "-1", "0"},
+		{"0.4999999", 0, "0", "0"},
+		{"-0.4999999", 0, "0", "0"},
+		{"9.99", 1, "10", "9.9"},
+		{"-9.99", 1, "-10", "-9.9"},
+		{"0.05", 1, "0.1", "0"},
+		{"0.0049", 2, "0", "0"},
+		{"123", 2, "123", "123"},
+		{"1.5", 1, "1.5", "1.5"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.s, func(t *testing.T) {
+			d, err := ParseDecimal(tc.s)
+			require.NoError(t, err)
+
+			r, err := d.Round(tc.dec)
+			require.NoError(t, err)
+			require.Equal(t, tc.dec, r.Decimals())
+			require.Equal(t, tc.round, r.String())
+
+			r, err = d.Truncate(tc.dec)
+			require.NoError(t, err)
+			require.Equal(t, tc.dec, r.Decimals())
+			require.Equal(t, tc.truncate, r.String())
+
+			_, err = d.Rescale(tc.dec)
+			if tc.s == tc.truncate {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrPrecisionLoss)
+			}
+		})
+	}
+	d := NewDecimal(big.NewInt(1), 0)
+	_, err := d.Round(-1)
+	require.ErrorIs(t, err, ErrInvalidDecimals)
+	_, err = d.Truncate(77)
+	require.ErrorIs(t, err, ErrOverflow)
+}
+
+func TestDecimalArithmetic(t *testing.T) {
+	parse := func(s string) Decimal {
+		d, err := ParseDecimal(s)
+		require.NoError(t, err)
+		return d
+	}
+	var testCases = []struct {
+		a, b          string
+		sum, diff, pr string
+		prDec         int
+	}{
+		{"1.5", "2", "3.5", "-0.5", "3", 1},
+		{"0.1", "0.2", "0.3", "-0.1", "0.02", 2},
+		{"-1.25", "0.005", "-1.245", "-1.255", "-0.00625", 5},
+		{"100", "-100", "0", "200", "-10000", 0},
+		{"0.00000001", "100000000", "100000000.00000001", "-99999999.99999999", "1", 8},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			a, b := parse(tc.a), parse(tc.b)
+			dec := a.Decimals()
+			if b.Decimals() > dec {
+				dec = b.Decimals()
+			}
+
+			r, err := a.Add(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.sum, r.String())
+			require.Equal(t, dec, r.Decimals())
+
+			r, err = a.Sub(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.diff, r.String())
+			require.Equal(t, dec, r.Decimals())
+
+			r, err = a.Mul(b)
+			require.NoError(t, err)
+			require.Equal(t, tc.pr, r.String())
+			require.Equal(t, tc.prDec, r.Decimals())
+
+			// Operands are not changed.
+			require.Equal(t, tc.a, a.String())
+			require.Equal(t, tc.b, b.String())
+		})
+	}
+	t.Run("overflow", func(t *testing.T) {
+		max := NewDecimal(new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 255), big.NewInt(1)), 0)
+		min := NewDecimal(new(big.Int).Neg(new(big.Int).Lsh(big.NewInt(1), 255)), 0)
+		one := parse("1")
+
+		_, err := max.Add(one)
+		require.ErrorIs(t, err, ErrOverflow)
+		_, err = min.Sub(one)
+		require.ErrorIs(t, err, ErrOverflow)
+		_, err = max.Mul(parse("2"))
+		require.ErrorIs(t, err, ErrOverflow)
+		// Alignment overflows too.
+		_, err = max.Add(parse("0.1"))
+		require.ErrorIs(t, err, ErrOverflow)
+
+		r, err := min.Add(one)
+		require.NoError(t, err)
+		r, err = r.Sub(one)
+		require.NoError(t, err)
+		require.Equal(t, min, r)
+		_, err = max.Mul(parse("-1"))
+		require.NoError(t, err)
+		_, err = min.Mul(parse("-1"))
+		require.ErrorIs(t, err, ErrOverflow)
+	})
+}
+
+func TestDecimalCmp(t *testing.T) {
+	a := NewDecimal(big.NewInt(15), 1)
+	require.Equal(t, 0, a.Cmp(NewDecimal(big.NewInt(1500), 3)))
+	require.Equal(t, -1, a.Cmp(NewDecimal(big.NewInt(1501), 3)))
+	require.Equal(t, 1, a.Cmp(NewDecimal(big.NewInt(1), 0)))
+	require.Equal(t, 1, NewDecimal(big.NewInt(2), 0).Cmp(a))
+	require.Equal(t, -1, NewDecimal(big.NewInt(-2), 0).Cmp(a))
+}
+
+func TestDecimalStackItem(t *testing.T) {
+	d, err := DecimalFromStackItem(stackitem.Make(150000000), 8)
+	require.NoError(t, err)
+	require.Equal(t, "1.5", d.String())
+
+	_, err = DecimalFromStackItem(stackitem.Make([]int{1}), 8)
+	require.Error(t, err)
+	_, err = DecimalFromStackItem(stackitem.Make(1), -1)
+	require.ErrorIs(t, err, ErrInvalidDecimals)
+
+	item, err := d.ToStackItem(2)
+	require.NoError(t, err)
+	require.Equal(t, stackitem.Make(150), item)
+	_, err = d.ToStackItem(0)
+	require.ErrorIs(t, err, ErrPrecisionLoss)
+
+	v, err := d.Integer(10)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(15000000000), v)
+}
+
+func TestDecimalJSON(t *testing.T) {
+	d, err := ParseDecimal("-12.340")
+	require.NoError(t, err)
+	data, err := json.Marshal(d)
+	require.NoError(t, err)
+	require.Equal(t, `"-12.34"`, string(data))
+
+	var actual Decimal
+	require.NoError(t, json.Unmarshal(data, &actual))
+	require.Equal(t, 0, d.Cmp(actual))
+	require.Equal(t, 2, actual.Decimals())
+
+	require.NoError(t, json.Unmarshal([]byte(`1.5`), &actual))
+	require.Equal(t, "1.5", actual.String())
+
+	var s struct {
+		Amount Decimal `json:"amount"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":"0.00000001"}`), &s))
+	require.Equal(t, NewDecimal(big.NewInt(1), 8), s.Amount)
+
+	for _, bad := range []string{`"1.x"`, `true`, `"1e5"`, `null`, `"`} {
+		require.Error(t, json.Unmarshal([]byte(bad), &actual), bad)
+	}
+}
+
+func TestToStringSign(t *testing.T) {
+	require.Equal(t, "-0.5", ToString(big.NewInt(-5), 1))
+	bi, err := FromString("-0.5", 1)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(-5), bi)
+	// Doesn't fit into uint64.
+	v, _ := new(big.Int).SetString("123456789012345678901", 10)
+	require.Equal(t, "0.123456789012345678901", ToString(v, 21))
+}
@@ -45,7 +45,12 @@ func GetCallFlags() CallFlag {
 
 // Call executes the previously deployed blockchain contract with the specified hash
 // (20 bytes in BE form) using the provided arguments and call flags.
-// It returns whatever this contract returns. This function uses
+// The callee gets the intersection of the given flags and the flags of the
+// current context, neither it nor any contract it calls later (even if it
+// calls back into the current one) can regain the flags dropped, so
+// Call(h, "method", ReadOnly) can be used to call untrusted contracts
+// guaranteeing that no state changes and notifications are possible down the
+// call tree. It returns whatever this contract returns. This function uses
 // `System.Contract.Call` syscall.
 func Call(scriptHash interop.Hash160, method string, f CallFlag, args ...any) any {
 	return neogointernal.Syscall4("System.Contract.Call", scriptHash, method, f, args)
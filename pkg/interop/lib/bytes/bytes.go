@@ -0,0 +1,192 @@
+/*
+Package bytes provides a subset of Go standard library bytes package functions
+for smart contracts. The standard package itself can't be used by contracts,
+so this one is to be imported instead, it has the same function signatures and
+semantics.
+
+Implementations are GAS-aware, StdLib native contract methods are only used
+where they're cheaper than NeoVM code (for long inputs usually, since any
+contract call has a significant fixed price). Byte slices returned never share
+memory with the arguments.
+*/
+package bytes
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+	"github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+)
+
+const (
+	// searchThreshold is the slice length starting from which StdLib
+	// memorySearch method is cheaper than the search loop.
+	searchThreshold = 128
+	// maxNativeInput is the maximum input length of StdLib methods.
+	maxNativeInput = 1024
+	// cmpThreshold is the length starting from which conversions and EQUAL
+	// are cheaper than the comparison loop.
+	cmpThreshold = 64
+)
+
+// Compare returns an integer comparing two byte slices lexicographically. The
+// result is 0 if a == b, -1 if a < b and +1 if a > b. A nil argument is
+// equivalent to an empty slice.
+func Compare(a, b []byte) int {
+	var n = len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if len(a) < len(b) {
+		return -1
+	}
+	if len(a) > len(b) {
+		return 1
+	}
+	return 0
+}
+
+// Contains reports whether subslice is within b.
+func Contains(b, subslice []byte) bool {
+	return Index(b, subslice) >= 0
+}
+
+// Equal reports whether a and b are the same length and contain the same
+// bytes. A nil argument is equivalent to an empty slice.
+func Equal(a, b []byte) bool {
+	var n = len(a)
+	if n != len(b) {
+		return false
+	}
+	if n > cmpThreshold {
+		return string(a) == string(b)
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasPrefix reports whether the byte slice s begins with prefix.
+func HasPrefix(s, prefix []byte) bool {
+	var n = len(prefix)
+	if len(s) < n {
+		return false
+	}
+	if n > cmpThreshold {
+		return string(s[:n]) == string(prefix)
+	}
+	for i := 0; i < n; i++ {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSuffix reports whether the byte slice s ends with suffix.
+func HasSuffix(s, suffix []byte) bool {
+	var (
+		n   = len(suffix)
+		off = len(s) - n
+	)
+	if off < 0 {
+		return false
+	}
+	if n > cmpThreshold {
+		return string(s[off:]) == string(suffix)
+	}
+	for i := 0; i < n; i++ {
+		if s[off+i] != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Index returns the index of the first instance of sep in s, or -1 if sep is
+// not present in s. It uses `memorySearch` method of StdLib native contract for
+// slices longer than 128 (but not longer than 1024) bytes.
+func Index(s, sep []byte) int {
+	var (
+		n    = len(sep)
+		last = len(s) - n
+	)
+	if n == 0 {
+		return 0
+	}
+	if last < 0 {
+		return -1
+	}
+	if len(s) > searchThreshold && len(s) <= maxNativeInput {
+		return neogointernal.CallWithToken(std.Hash, "memorySearch", int(contract.NoneFlag),
+			s, sep).(int)
+	}
+	for i := 0; i <= last; i++ {
+		var j int
+		for j < n && s[i+j] == sep[j] {
+			j++
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndex returns the index of the last instance of sep in s, or -1 if sep
+// is not present in s. It uses `memorySearch` method of StdLib native contract
+// for slices longer than 128 (but not longer than 1024) bytes.
+func LastIndex(s, sep []byte) int {
+	var (
+		n    = len(sep)
+		last = len(s) - n
+	)
+	if n == 0 {
+		return len(s)
+	}
+	if last < 0 {
+		return -1
+	}
+	if len(s) > searchThreshold && len(s) <= maxNativeInput {
+		return neogointernal.CallWithToken(std.Hash, "memorySearch", int(contract.NoneFlag),
+			s, sep, len(s), true).(int)
+	}
+	for i := last; i >= 0; i-- {
+		var j int
+		for j < n && s[i+j] == sep[j] {
+			j++
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// TrimPrefix returns a copy of s without the provided leading prefix. If s
+// doesn't start with prefix, a copy of s is returned.
+func TrimPrefix(s, prefix []byte) []byte {
+	if HasPrefix(s, prefix) {
+		return s[len(prefix):] // Slicing copies in NeoVM.
+	}
+	return s[:]
+}
+
+// TrimSuffix returns a copy of s without the provided trailing suffix. If s
+// doesn't end with suffix, a copy of s is returned.
+func TrimSuffix(s, suffix []byte) []byte {
+	if HasSuffix(s, suffix) {
+		return s[:len(s)-len(suffix)] // Slicing copies in NeoVM.
+	}
+	return s[:]
+}
@@ -0,0 +1,258 @@
+/*
+Package strings provides a subset of Go standard library strings package
+functions for smart contracts. The standard package itself can't be used by
+contracts, so this one is to be imported instead, it has the same function
+signatures and semantics with a few deliberate differences documented for
+respective functions.
+
+Strings are byte sequences here just like in Go, lengths and indexes are byte
+offsets. Implementations are GAS-aware, StdLib native contract methods are only
+used where they're cheaper than NeoVM code (for long inputs usually, since any
+contract call has a significant fixed price). Strings returned are always
+proper NeoVM ByteStrings, so they can be compared to other strings with ==.
+*/
+package strings
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+	"github.com/nspcc-dev/neo-go/pkg/interop/neogointernal"
+)
+
+const (
+	// searchThreshold is the string length starting from which StdLib
+	// memorySearch method is cheaper than the search loop.
+	searchThreshold = 128
+	// maxNativeInput is the maximum input length of StdLib methods.
+	maxNativeInput = 1024
+	// cmpThreshold is the length starting from which conversion and EQUAL
+	// are cheaper than the comparison loop.
+	cmpThreshold = 64
+)
+
+// Compare returns an integer comparing two strings lexicographically. The
+// result is 0 if a == b, -1 if a < b and +1 if a > b.
+func Compare(a, b string) int {
+	if a == b {
+		return 0
+	}
+	var n = len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	if len(a) < len(b) {
+		return -1
+	}
+	if len(a) > len(b) {
+		return 1
+	}
+	return 0
+}
+
+// Contains reports whether substr is within s.
+func Contains(s, substr string) bool {
+	return Index(s, substr) >= 0
+}
+
+// HasPrefix reports whether the string s begins with prefix.
+func HasPrefix(s, prefix string) bool {
+	var n = len(prefix)
+	if len(s) < n {
+		return false
+	}
+	if n > cmpThreshold {
+		return string(s[:n]) == prefix
+	}
+	for i := 0; i < n; i++ {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasSuffix reports whether the string s ends with suffix.
+func HasSuffix(s, suffix string) bool {
+	var (
+		n   = len(suffix)
+		off = len(s) - n
+	)
+	if off < 0 {
+		return false
+	}
+	if n > cmpThreshold {
+		return string(s[off:]) == suffix
+	}
+	for i := 0; i < n; i++ {
+		if s[off+i] != suffix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Index returns the index of the first instance of substr in s, or -1 if
+// substr is not present in s. It uses `memorySearch` method of StdLib native
+// contract for strings longer than 128 (but not longer than 1024) bytes.
+func Index(s, substr string) int {
+	var (
+		n    = len(substr)
+		last = len(s) - n
+	)
+	if n == 0 {
+		return 0
+	}
+	if last < 0 {
+		return -1
+	}
+	if len(s) > searchThreshold && len(s) <= maxNativeInput {
+		return neogointernal.CallWithToken(std.Hash, "memorySearch", int(contract.NoneFlag),
+			s, substr).(int)
+	}
+	for i := 0; i <= last; i++ {
+		var j int
+		for j < n && s[i+j] == substr[j] {
+			j++
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndex returns the index of the last instance of substr in s, or -1 if
+// substr is not present in s. It uses `memorySearch` method of StdLib native
+// contract for strings longer than 128 (but not longer than 1024) bytes.
+func LastIndex(s, substr string) int {
+	var (
+		n    = len(substr)
+		last = len(s) - n
+	)
+	if n == 0 {
+		return len(s)
+	}
+	if last < 0 {
+		return -1
+	}
+	if len(s) > searchThreshold && len(s) <= maxNativeInput {
+		return neogointernal.CallWithToken(std.Hash, "memorySearch", int(contract.NoneFlag),
+			s, substr, len(s), true).(int)
+	}
+	for i := last; i >= 0; i-- {
+		var j int
+		for j < n && s[i+j] == substr[j] {
+			j++
+		}
+		if j == n {
+			return i
+		}
+	}
+	return -1
+}
+
+// Join concatenates the elements of elems to create a single string. The
+// separator string sep is placed between elements in the resulting string.
+func Join(elems []string, sep string) string {
+	if len(elems) == 0 {
+		return ""
+	}
+	var res = elems[0]
+	for i := 1; i < len(elems); i++ {
+		res += sep + elems[i]
+	}
+	return string(res) // Concatenation produces Buffer.
+}
+
+// Repeat returns a new string consisting of count copies of the string s. It
+// panics if count is negative.
+func Repeat(s string, count int) string {
+	if count < 0 {
+		panic("negative Repeat count")
+	}
+	if count == 0 || len(s) == 0 {
+		return ""
+	}
+	var (
+		res = s
+		n   = 1
+	)
+	for n*2 <= count {
+		res += res
+		n *= 2
+	}
+	if n < count {
+		res += res[:len(s)*(count-n)]
+	}
+	return string(res) // Concatenation produces Buffer.
+}
+
+// Split slices s into all substrings separated by sep and returns a slice of
+// the substrings between those separators. If sep is empty, Split splits after
+// each UTF-8 sequence. It uses `stringSplit` method of StdLib native contract,
+// so unlike the standard function it panics if s is longer than 1024 bytes or
+// it's not a valid UTF-8 string.
+func Split(s, sep string) []string {
+	return neogointernal.CallWithToken(std.Hash, "stringSplit", int(contract.NoneFlag),
+		s, sep).([]string)
+}
+
+// ToLower returns s with all ASCII letters mapped to their lower case. Unlike
+// the standard function it doesn't change any non-ASCII characters.
+func ToLower(s string) string {
+	return mapASCII(s, 'A', 'Z', 'a'-'A')
+}
+
+// ToUpper returns s with all ASCII letters mapped to their upper case. Unlike
+// the standard function it doesn't change any non-ASCII characters.
+func ToUpper(s string) string {
+	return mapASCII(s, 'a', 'z', 'A'-'a')
+}
+
+// TrimPrefix returns s without the provided leading prefix string. If s
+// doesn't start with prefix, s is returned unchanged.
+func TrimPrefix(s, prefix string) string {
+	if HasPrefix(s, prefix) {
+		return string(s[len(prefix):]) // Substring is a Buffer.
+	}
+	return s
+}
+
+// TrimSuffix returns s without the provided trailing suffix string. If s
+// doesn't end with suffix, s is returned unchanged.
+func TrimSuffix(s, suffix string) string {
+	if HasSuffix(s, suffix) {
+		return string(s[:len(s)-len(suffix)]) // Substring is a Buffer.
+	}
+	return s
+}
+
+// mapASCII adds delta to all bytes of s in the [from, to] range. The string is
+// only copied if there is something to change.
+func mapASCII(s string, from, to byte, delta int) string {
+	var (
+		b []byte
+		n = len(s)
+	)
+	for i := 0; i < n; i++ {
+		var c = s[i]
+		if from <= c && c <= to {
+			if b == nil {
+				b = []byte(s)
+			}
+			b[i] = byte(int(c) + delta)
+		}
+	}
+	if b == nil {
+		return s
+	}
+	return string(b)
+}
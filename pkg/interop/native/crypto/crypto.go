@@ -98,3 +98,38 @@ func Bls12381Pairing(g1, g2 Bls12381Point) Bls12381Point {
 func Keccak256(b []byte) interop.Hash256 {
 	return neogointernal.CallWithToken(Hash, "keccak256", int(contract.NoneFlag), b).(interop.Hash256)
 }
+
+// Sha256State represents an incremental SHA256 hashing state. It's an opaque
+// type that can only be created properly by Sha256Init, it's updated with
+// Sha256Update and finalized with Sha256Final. The state exists only during
+// script execution and can't be stored.
+type Sha256State struct{}
+
+// Sha256Init calls `sha256Init` method of native CryptoLib contract and creates
+// a new incremental SHA256 hashing state. It's available since NeoGoExtensions
+// hardfork.
+func Sha256Init() Sha256State {
+	return neogointernal.CallWithToken(Hash, "sha256Init", int(contract.NoneFlag)).(Sha256State)
+}
+
+// Sha256Update calls `sha256Update` method of native CryptoLib contract and
+// appends b to the data hashed by the given state. It's priced per byte of b
+// and available since NeoGoExtensions hardfork.
+func Sha256Update(s Sha256State, b []byte) {
+	neogointernal.CallWithTokenNoRet(Hash, "sha256Update", int(contract.NoneFlag), s, b)
+}
+
+// Sha256Final calls `sha256Final` method of native CryptoLib contract and
+// returns SHA256 hash of all the data passed to Sha256Update for the given
+// state. The state can't be used after this call. It's available since
+// NeoGoExtensions hardfork.
+func Sha256Final(s Sha256State) interop.Hash256 {
+	return neogointernal.CallWithToken(Hash, "sha256Final", int(contract.NoneFlag), s).(interop.Hash256)
+}
+
+// MerkleRoot calls `merkleRoot` method of native CryptoLib contract and
+// computes Merkle tree root of the given hashes the same way it's done for
+// block transactions. It's available since NeoGoExtensions hardfork.
+func MerkleRoot(hashes []interop.Hash256) interop.Hash256 {
+	return neogointernal.CallWithToken(Hash, "merkleRoot", int(contract.NoneFlag), hashes).(interop.Hash256)
+}
@@ -57,6 +57,16 @@ func GetContractHashes() iterator.Iterator {
 	return neogointernal.CallWithToken(Hash, "getContractHashes", int(contract.ReadStates)).(iterator.Iterator)
 }
 
+// GetContractsIterator represents `getContractsIterator` method of the
+// Management native contract. It returns an Iterator over states of all
+// contracts (including native ones) ordered by their hashes. Each iterator
+// value can be cast to *Contract. Use [iterator] interop package to work with
+// the returned Iterator. This method is available since NeoGoExtensions
+// hard-fork.
+func GetContractsIterator() iterator.Iterator {
+	return neogointernal.CallWithToken(Hash, "getContractsIterator", int(contract.ReadStates)).(iterator.Iterator)
+}
+
 // GetMinimumDeploymentFee represents `getMinimumDeploymentFee` method of Management native contract.
 func GetMinimumDeploymentFee() int {
 	return neogointernal.CallWithToken(Hash, "getMinimumDeploymentFee", int(contract.ReadStates)).(int)
@@ -21,6 +21,23 @@ type AccountState struct {
 	LastGasPerVote int
 }
 
+// UnclaimedGASDetails contains the breakdown of GAS generated for a NEO holder
+// since the last claim.
+type UnclaimedGASDetails struct {
+	HolderReward    int
+	VoterReward     int
+	LastClaimHeight int
+}
+
+// VoterInfo contains voting data of a NEO holder. GAS per vote values are
+// used to calculate voter reward for the period since BalanceHeight.
+type VoterInfo struct {
+	VoteTo           interop.PublicKey
+	BalanceHeight    int
+	LastGasPerVote   int
+	LatestGasPerVote int
+}
+
 // Hash represents NEO contract hash.
 const Hash = "\xf5\x63\xea\x40\xbc\x28\x3d\x4d\x0e\x05\xc4\x8e\xa3\x05\xb3\xf2\xa0\x73\x40\xef"
 
@@ -127,6 +144,28 @@ func GetAccountState(addr interop.Hash160) *AccountState {
 	return neogointernal.CallWithToken(Hash, "getAccountState", int(contract.ReadStates), addr).(*AccountState)
 }
 
+// UnclaimedGASDetailed represents `unclaimedGasDetailed` method of NEO native
+// contract. It returns nil if the account has no NEO. This method is available
+// since NeoGoExtensions hard-fork.
+func UnclaimedGASDetailed(addr interop.Hash160) *UnclaimedGASDetails {
+	return neogointernal.CallWithToken(Hash, "unclaimedGasDetailed", int(contract.ReadStates), addr).(*UnclaimedGASDetails)
+}
+
+// GetVoterInfo represents `getVoterInfo` method of NEO native contract. It
+// returns nil if the account has no NEO. This method is available since
+// NeoGoExtensions hard-fork.
+func GetVoterInfo(addr interop.Hash160) *VoterInfo {
+	return neogointernal.CallWithToken(Hash, "getVoterInfo", int(contract.ReadStates), addr).(*VoterInfo)
+}
+
+// ClaimGAS represents `claimGas` method of NEO native contract. It mints GAS
+// generated by the account's NEO (the same way NEO transfer does, but without
+// NEO Transfer notification) and returns the amount minted. The account must
+// witness the call. This method is available since NeoGoExtensions hard-fork.
+func ClaimGAS(addr interop.Hash160) int {
+	return neogointernal.CallWithToken(Hash, "claimGas", int(contract.States|contract.AllowCall|contract.AllowNotify), addr).(int)
+}
+
 // GetCommitteeAddress represents `getCommitteeAddress` method of NEO native contract.
 func GetCommitteeAddress() interop.Hash160 {
 	return neogointernal.CallWithToken(Hash, "getCommitteeAddress", int(contract.ReadStates)).(interop.Hash160)
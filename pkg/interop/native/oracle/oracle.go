@@ -70,6 +70,17 @@ func Request(url string, filter []byte, cb string, userData any, gasForResponse
 		url, filter, cb, userData, gasForResponse)
 }
 
+// CancelRequest cancels the oracle request with the given ID. It can only be
+// successfully invoked by the contract that has made the request, GAS attached
+// to the request for response processing (gasForResponse) is returned to this
+// contract (without onNEP17Payment invocation) and OracleCancel event is
+// emitted. The request can't be cancelled if the response to it is included
+// into the same block. This method is available since NeoGoExtensions hard-fork.
+func CancelRequest(id int) {
+	neogointernal.CallWithTokenNoRet(Hash, "cancelRequest",
+		int(contract.States|contract.AllowNotify), id)
+}
+
 // GetPrice returns the current oracle request price.
 func GetPrice() int {
 	return neogointernal.CallWithToken(Hash, "getPrice", int(contract.ReadStates)).(int)
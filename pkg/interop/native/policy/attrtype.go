@@ -11,4 +11,6 @@ const (
 	ConflictsT      AttributeType = 0x21
 	// NotaryAssistedT is an extension of Neo protocol available on specifically configured NeoGo networks.
 	NotaryAssistedT AttributeType = 0x22
+	// SponsorT is available since NeoGoExtensions hardfork.
+	SponsorT AttributeType = 0x23
 )
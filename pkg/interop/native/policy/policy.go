@@ -67,3 +67,32 @@ func BlockAccount(addr interop.Hash160) bool {
 func UnblockAccount(addr interop.Hash160) bool {
 	return neogointernal.CallWithToken(Hash, "unblockAccount", int(contract.States), addr).(bool)
 }
+
+// GetMillisecondsPerBlock represents `getMillisecondsPerBlock` method of Policy
+// native contract. This method is available since NeoGoExtensions hard-fork.
+func GetMillisecondsPerBlock() int {
+	return neogointernal.CallWithToken(Hash, "getMillisecondsPerBlock", int(contract.ReadStates)).(int)
+}
+
+// SetMillisecondsPerBlock represents `setMillisecondsPerBlock` method of Policy
+// native contract. It emits MillisecondsPerBlockChanged event, the new value
+// is effective since the next block. This method is available since
+// NeoGoExtensions hard-fork.
+func SetMillisecondsPerBlock(value int) {
+	neogointernal.CallWithTokenNoRet(Hash, "setMillisecondsPerBlock",
+		int(contract.States|contract.AllowNotify), value)
+}
+
+// GetMaxTraceableBlocks represents `getMaxTraceableBlocks` method of Policy
+// native contract. This method is available since NeoGoExtensions hard-fork.
+func GetMaxTraceableBlocks() int {
+	return neogointernal.CallWithToken(Hash, "getMaxTraceableBlocks", int(contract.ReadStates)).(int)
+}
+
+// SetMaxTraceableBlocks represents `setMaxTraceableBlocks` method of Policy
+// native contract. It emits MaxTraceableBlocksChanged event, the value can't
+// be increased. This method is available since NeoGoExtensions hard-fork.
+func SetMaxTraceableBlocks(value int) {
+	neogointernal.CallWithTokenNoRet(Hash, "setMaxTraceableBlocks",
+		int(contract.States|contract.AllowNotify), value)
+}
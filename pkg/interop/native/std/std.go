@@ -58,6 +58,19 @@ func JSONDeserialize(data []byte) any {
 		data)
 }
 
+// JSONPath applies the given JSONPath-like path to the JSON data and returns a
+// JSON array of selected items. It uses `jsonPath` method of StdLib native
+// contract that implements the same restricted JSONPath subset (with the same
+// limits) as Oracle request filters, so data can be processed like
+//
+//	std.JSONPath(data, "$.Manufacturers[0].Products[*].Price")
+//
+// It's available since NeoGoExtensions hardfork.
+func JSONPath(data []byte, path string) []byte {
+	return neogointernal.CallWithToken(Hash, "jsonPath", int(contract.NoneFlag),
+		data, path).([]byte)
+}
+
 // Base64Encode calls `base64Encode` method of StdLib native contract and encodes
 // the given byte slice into a base64 string and returns byte representation of this
 // string.
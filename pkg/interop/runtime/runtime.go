@@ -42,10 +42,11 @@ func CurrentSigners() []ledger.TransactionSigner {
 // given call flags and arguments. This bytecode is executed as is from byte 0,
 // it's not a deployed contract that can have methods. The execution context is
 // limited to read only actions ([contract.ReadOnly]) irrespective of provided
-// call flags (you can only restrict them further with this option). An item is
-// always returned from this call, either it's the one returned from the script
-// (and it can only return one) or it's a Null stack item if the script returns
-// nothing. Note that this is somewhat similar to [contract.Call], so the
+// call flags (you can only restrict them further with this option, since
+// NeoGoExtensions hardfork requesting more flags than allowed fails). An item
+// is always returned from this call, either it's the one returned from the
+// script (and it can only return one) or it's a Null stack item if the script
+// returns nothing. Note that this is somewhat similar to [contract.Call], so the
 // script can ABORT the transaction or THROW an exception, make sure you
 // appropriately handle exceptions if bytecode comes from untrusted source.
 // This function uses `System.Runtime.LoadScript` syscall.
@@ -103,8 +104,12 @@ func GetTrigger() byte {
 	return neogointernal.Syscall0("System.Runtime.GetTrigger").(byte)
 }
 
-// GasLeft returns the amount of gas available for the current execution.
-// This function uses `System.Runtime.GasLeft` syscall.
+// GasLeft returns the amount of gas available for the current execution
+// (execution GAS limit minus GAS already consumed) or -1 if execution is not
+// limited (which is only possible for test invocations). It can be used to
+// process as many items as GAS allows and save the cursor for the next
+// invocation, each call costs 16 * ExecFeeFactor. This function uses
+// `System.Runtime.GasLeft` syscall.
 func GasLeft() int {
 	return neogointernal.Syscall0("System.Runtime.GasLeft").(int)
 }
@@ -117,6 +122,42 @@ func GetNotifications(h interop.Hash160) [][]any {
 	return neogointernal.Syscall1("System.Runtime.GetNotifications", h).([][]any)
 }
 
+// GetNotificationsByName returns notifications with the given event name
+// emitted by contract h ('nil' literal means any contract). Filtering is done
+// by the system, so it's cheaper than filtering results of GetNotifications
+// in the contract. It returns slice of the same elements as GetNotifications
+// does. This function uses `System.Runtime.GetNotificationsByName` syscall
+// available since NeoGoExtensions hardfork.
+func GetNotificationsByName(h interop.Hash160, name string) [][]any {
+	return neogointernal.Syscall2("System.Runtime.GetNotificationsByName", h, name).([][]any)
+}
+
+// EnterNonReentrant takes re-entrancy guard with the given key (up to 64
+// bytes) for the executing contract and fails (panics) if this guard is
+// already taken by this contract in the current execution. Guards are not
+// stored, so they're reset after every transaction (and each contract has its
+// own set of them). Use LeaveNonReentrant to release the guard, usually it's
+// done via defer, like this:
+//
+//	runtime.EnterNonReentrant([]byte("withdraw"))
+//	defer runtime.LeaveNonReentrant([]byte("withdraw"))
+//
+// Note that guards are not released if the contract fails while holding them,
+// so if the caller catches this exception subsequent guarded calls within the
+// same execution will fail. This function uses `System.Runtime.EnterNonReentrant`
+// syscall available since NeoGoExtensions hardfork.
+func EnterNonReentrant(key []byte) {
+	neogointernal.Syscall1NoReturn("System.Runtime.EnterNonReentrant", key)
+}
+
+// LeaveNonReentrant releases re-entrancy guard with the given key taken by
+// EnterNonReentrant, it fails (panics) if the guard is not taken. This
+// function uses `System.Runtime.LeaveNonReentrant` syscall available since
+// NeoGoExtensions hardfork.
+func LeaveNonReentrant(key []byte) {
+	neogointernal.Syscall1NoReturn("System.Runtime.LeaveNonReentrant", key)
+}
+
 // GetInvocationCounter returns how many times current contract was invoked during current tx execution.
 // This function uses `System.Runtime.GetInvocationCounter` syscall.
 func GetInvocationCounter() int {
@@ -92,3 +92,15 @@ func Delete(ctx Context, key any) {
 func Find(ctx Context, key any, options FindFlags) iterator.Iterator {
 	return neogointernal.Syscall3("System.Storage.Find", ctx, key, options).(iterator.Iterator)
 }
+
+// FindFrom is similar to Find, but the iteration starts strictly after the
+// given lastKey (or strictly before it if Backwards option is used). lastKey
+// is a complete key (including the prefix given as key) that must have key as
+// a prefix, it doesn't need to exist in the storage (it can be deleted after
+// being returned by the previous iteration). This allows to store the last
+// processed key and to continue iteration from it in subsequent invocations
+// without rescanning. This function uses `System.Storage.FindFrom` syscall
+// available since NeoGoExtensions hardfork.
+func FindFrom(ctx Context, key any, lastKey any, options FindFlags) iterator.Iterator {
+	return neogointernal.Syscall4("System.Storage.FindFrom", ctx, key, lastKey, options).(iterator.Iterator)
+}
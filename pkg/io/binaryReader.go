@@ -3,6 +3,7 @@ package io
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"reflect"
@@ -12,12 +13,22 @@ import (
 // It is taken from https://github.com/neo-project/neo/blob/master/neo/IO/Helper.cs#L130
 const MaxArraySize = 0x1000000
 
+// ErrNonCanonical is returned by strict BinReader when the data being read
+// is not encoded canonically (i.e. it would be encoded differently by
+// BinWriter).
+var ErrNonCanonical = errors.New("non-canonical encoding")
+
+// ErrTrailingData is returned by DecodeStrict when some data is left in the
+// buffer after decoding.
+var ErrTrailingData = errors.New("additional data after the decoded value")
+
 // BinReader is a convenient wrapper around an io.Reader and err object.
 // Used to simplify error handling when reading into a struct with many fields.
 type BinReader struct {
-	r   io.Reader
-	uv  [8]byte
-	Err error
+	r      io.Reader
+	uv     [8]byte
+	strict bool
+	Err    error
 }
 
 // NewBinReaderFromIO makes a BinReader from io.Reader.
@@ -31,6 +42,39 @@ func NewBinReaderFromBuf(b []byte) *BinReader {
 	return NewBinReaderFromIO(r)
 }
 
+// NewStrictBinReaderFromBuf makes a strict BinReader from a byte buffer.
+// Strict reader only accepts canonical encodings of variable-length integers
+// (using the minimal number of bytes) and booleans (0 and 1), so that
+// decoded values are guaranteed to be encoded back into the same bytes
+// (which is important for hashes calculated from the original data). It
+// should be used for data received from untrusted sources like network
+// peers.
+func NewStrictBinReaderFromBuf(b []byte) *BinReader {
+	r := NewBinReaderFromBuf(b)
+	r.strict = true
+	return r
+}
+
+// IsStrict returns true if BinReader is strict (see NewStrictBinReaderFromBuf).
+func (r *BinReader) IsStrict() bool {
+	return r.strict
+}
+
+// DecodeStrict decodes the given buffer into v using strict BinReader (see
+// NewStrictBinReaderFromBuf) and ensures that the whole buffer is consumed
+// (returning ErrTrailingData otherwise).
+func DecodeStrict(b []byte, v decodable) error {
+	r := NewStrictBinReaderFromBuf(b)
+	v.DecodeBinary(r)
+	if r.Err != nil {
+		return r.Err
+	}
+	if r.Len() != 0 {
+		return ErrTrailingData
+	}
+	return nil
+}
+
 // Len returns the number of bytes of the unread portion of the buffer if
 // reading from bytes.Reader or -1 otherwise.
 func (r *BinReader) Len() int {
@@ -93,9 +137,15 @@ func (r *BinReader) ReadB() byte {
 }
 
 // ReadBool reads a boolean value encoded in a zero/non-zero byte from the
-// underlying io.Reader. On read failures it returns false.
+// underlying io.Reader. Strict reader only accepts 0 and 1 values. On read
+// failures it returns false.
 func (r *BinReader) ReadBool() bool {
-	return r.ReadB() != 0
+	b := r.ReadB()
+	if r.strict && b > 1 {
+		r.Err = fmt.Errorf("%w: boolean value %d", ErrNonCanonical, b)
+		return false
+	}
+	return b != 0
 }
 
 // ReadArray reads an array into a value which must be
@@ -148,26 +198,85 @@ func (r *BinReader) ReadArray(t any, maxSize ...int) {
 	value.Elem().Set(arr)
 }
 
+// ReadSliceOf reads a slice of Serializable elements encoded by WriteSliceOf
+// (or WriteArray). The number of elements is limited by maxSize (MaxArraySize
+// by default), name describes elements for the error returned if it's
+// exceeded. Decoded slice is never nil unless there is an error, so empty
+// slices are returned for zero-length arrays the same way ReadArray does.
+func ReadSliceOf[T any, PT interface {
+	*T
+	decodable
+}](r *BinReader, name string, maxSize ...int) []T {
+	if r.Err != nil {
+		return nil
+	}
+	ms := MaxArraySize
+	if len(maxSize) != 0 {
+		ms = maxSize[0]
+	}
+	l := r.ReadVarUint()
+	if r.Err != nil {
+		return nil
+	}
+	if l > uint64(ms) {
+		r.Err = fmt.Errorf("too many %s (%d)", name, l)
+		return nil
+	}
+	arr := make([]T, l)
+	for i := range arr {
+		PT(&arr[i]).DecodeBinary(r)
+	}
+	if r.Err != nil {
+		return nil
+	}
+	return arr
+}
+
+// ReadOptional reads an optional Serializable value encoded by WriteOptional,
+// nil is returned if the value is not present (or in case of error).
+func ReadOptional[T any, PT interface {
+	*T
+	decodable
+}](r *BinReader) *T {
+	if !r.ReadBool() || r.Err != nil {
+		return nil
+	}
+	var v = new(T)
+	PT(v).DecodeBinary(r)
+	if r.Err != nil {
+		return nil
+	}
+	return v
+}
+
 // ReadVarUint reads a variable-length-encoded integer from the
-// underlying reader.
+// underlying reader. Strict reader only accepts minimal-length encodings.
 func (r *BinReader) ReadVarUint() uint64 {
 	if r.Err != nil {
 		return 0
 	}
 
-	var b = r.ReadB()
+	var (
+		b      = r.ReadB()
+		res    uint64
+		minVal uint64
+	)
 
-	if b == 0xfd {
-		return uint64(r.ReadU16LE())
-	}
-	if b == 0xfe {
-		return uint64(r.ReadU32LE())
+	switch b {
+	case 0xfd:
+		res, minVal = uint64(r.ReadU16LE()), 0xfd
+	case 0xfe:
+		res, minVal = uint64(r.ReadU32LE()), 0x10000
+	case 0xff:
+		res, minVal = r.ReadU64LE(), 0x100000000
+	default:
+		return uint64(b)
 	}
-	if b == 0xff {
-		return r.ReadU64LE()
+	if r.strict && r.Err == nil && res < minVal {
+		r.Err = fmt.Errorf("%w: %d encoded with 0x%x prefix", ErrNonCanonical, res, b)
+		return 0
 	}
-
-	return uint64(b)
+	return res
 }
 
 // ReadVarBytes reads the next set of bytes from the underlying reader.
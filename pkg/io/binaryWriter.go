@@ -94,6 +94,34 @@ func (w *BinWriter) WriteArray(arr any) {
 	}
 }
 
+// WriteSliceOf writes a slice of Serializable elements into w, it's a
+// type-safe equivalent of WriteArray producing the same encoding (nil and
+// empty slices are encoded the same way).
+func WriteSliceOf[T any, PT interface {
+	*T
+	encodable
+}](w *BinWriter, arr []T) {
+	if w.Err != nil {
+		return
+	}
+	w.WriteVarUint(uint64(len(arr)))
+	for i := range arr {
+		PT(&arr[i]).EncodeBinary(w)
+	}
+}
+
+// WriteOptional writes an optional Serializable value into w. It's encoded as
+// a boolean presence byte followed by the value itself if it's not nil.
+func WriteOptional[T any, PT interface {
+	*T
+	encodable
+}](w *BinWriter, v *T) {
+	w.WriteBool(v != nil)
+	if v != nil {
+		PT(v).EncodeBinary(w)
+	}
+}
+
 // WriteVarUint writes a uint64 into the underlying writer using variable-length encoding.
 func (w *BinWriter) WriteVarUint(val uint64) {
 	if w.Err != nil {
@@ -111,12 +139,12 @@ func PutVarUint(data []byte, val uint64) int {
 		data[0] = byte(val)
 		return 1
 	}
-	if val < 0xFFFF {
+	if val <= 0xFFFF {
 		data[0] = byte(0xfd)
 		binary.LittleEndian.PutUint16(data[1:], uint16(val))
 		return 3
 	}
-	if val < 0xFFFFFFFF {
+	if val <= 0xFFFFFFFF {
 		data[0] = byte(0xfe)
 		binary.LittleEndian.PutUint32(data[1:], uint32(val))
 		return 5
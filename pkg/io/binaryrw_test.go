@@ -1,7 +1,9 @@
 package io
 
 import (
+	"encoding/hex"
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -307,6 +309,33 @@ func TestWriteVarUint100000000000(t *testing.T) {
 	assert.Equal(t, val, res)
 }
 
+func TestWriteVarUintBoundaries(t *testing.T) {
+	// Fixtures match the output of WriteVarInt from the C# node.
+	for _, tc := range []struct {
+		val uint64
+		enc string
+	}{
+		{0xfc, "fc"},
+		{0xfd, "fdfd00"},
+		{0xfffe, "fdfeff"},
+		{0xffff, "fdffff"},
+		{0x10000, "fe00000100"},
+		{0xfffffffe, "fefeffffff"},
+		{0xffffffff, "feffffffff"},
+		{0x100000000, "ff0000000001000000"},
+	} {
+		bw := NewBufBinWriter()
+		bw.WriteVarUint(tc.val)
+		require.NoError(t, bw.Err)
+		buf := bw.Bytes()
+		require.Equal(t, tc.enc, hex.EncodeToString(buf), tc.val)
+
+		br := NewBinReaderFromBuf(buf)
+		require.Equal(t, tc.val, br.ReadVarUint())
+		require.NoError(t, br.Err)
+	}
+}
+
 func TestWriteBytes(t *testing.T) {
 	var (
 		bin = []byte{0xde, 0xad, 0xbe, 0xef}
@@ -462,6 +491,108 @@ func TestBinReader_ReadArray(t *testing.T) {
 	require.Panics(t, func() { r.ReadArray(1) })
 }
 
+func TestSliceOf(t *testing.T) {
+	data := []byte{3, 0, 0, 1, 0, 2, 0}
+	elems := []testSerializable{0, 1, 2}
+	ptrElems := []testPtrSerializable{0, 1, 2}
+
+	t.Run("write", func(t *testing.T) {
+		w := NewBufBinWriter()
+		WriteSliceOf(w.BinWriter, elems)
+		require.NoError(t, w.Err)
+		require.Equal(t, data, w.Bytes())
+
+		w.Reset()
+		WriteSliceOf(w.BinWriter, ptrElems)
+		require.NoError(t, w.Err)
+		require.Equal(t, data, w.Bytes())
+
+		// Same encoding as WriteArray.
+		w.Reset()
+		w.WriteArray(elems)
+		require.Equal(t, data, w.Bytes())
+
+		for _, arr := range [][]testSerializable{nil, {}} {
+			w.Reset()
+			WriteSliceOf(w.BinWriter, arr)
+			require.NoError(t, w.Err)
+			require.Equal(t, []byte{0}, w.Bytes())
+		}
+
+		w.Reset()
+		w.Err = errors.New("error")
+		WriteSliceOf(w.BinWriter, elems)
+		require.Error(t, w.Err)
+		require.Equal(t, []byte(nil), w.Bytes())
+	})
+	t.Run("read", func(t *testing.T) {
+		r := NewBinReaderFromBuf(data)
+		require.Equal(t, elems, ReadSliceOf[testSerializable](r, "elements"))
+		require.NoError(t, r.Err)
+
+		r = NewBinReaderFromBuf(data)
+		require.Equal(t, ptrElems, ReadSliceOf[testPtrSerializable](r, "elements", 3))
+		require.NoError(t, r.Err)
+
+		r = NewBinReaderFromBuf(data)
+		require.Nil(t, ReadSliceOf[testSerializable](r, "elements", 2))
+		require.EqualError(t, r.Err, "too many elements (3)")
+
+		r = NewBinReaderFromBuf([]byte{0})
+		arr := ReadSliceOf[testSerializable](r, "elements")
+		require.NoError(t, r.Err)
+		require.NotNil(t, arr)
+		require.Empty(t, arr)
+
+		r = NewBinReaderFromBuf(data[:4])
+		require.Nil(t, ReadSliceOf[testSerializable](r, "elements"))
+		require.Error(t, r.Err)
+
+		r = NewBinReaderFromBuf(data)
+		r.Err = errors.New("error")
+		require.Nil(t, ReadSliceOf[testSerializable](r, "elements"))
+		require.Equal(t, len(data), r.Len())
+	})
+}
+
+func TestOptional(t *testing.T) {
+	v := testPtrSerializable(0x0102)
+
+	w := NewBufBinWriter()
+	WriteOptional(w.BinWriter, &v)
+	require.NoError(t, w.Err)
+	b := w.Bytes()
+	require.Equal(t, []byte{1, 2, 1}, b)
+
+	r := NewBinReaderFromBuf(b)
+	require.Equal(t, &v, ReadOptional[testPtrSerializable](r))
+	require.NoError(t, r.Err)
+
+	w.Reset()
+	WriteOptional(w.BinWriter, (*testSerializable)(nil))
+	require.NoError(t, w.Err)
+	b = w.Bytes()
+	require.Equal(t, []byte{0}, b)
+
+	r = NewBinReaderFromBuf(b)
+	require.Nil(t, ReadOptional[testSerializable](r))
+	require.NoError(t, r.Err)
+
+	r = NewBinReaderFromBuf([]byte{1, 2})
+	require.Nil(t, ReadOptional[testSerializable](r))
+	require.Error(t, r.Err)
+
+	r = NewStrictBinReaderFromBuf([]byte{2, 2, 1})
+	require.Nil(t, ReadOptional[testSerializable](r))
+	require.ErrorIs(t, r.Err, ErrNonCanonical)
+
+	w.Reset()
+	w.Err = errors.New("error")
+	WriteOptional(w.BinWriter, &v)
+	require.Error(t, w.Err)
+	require.Equal(t, []byte(nil), w.Bytes())
+}
+
 func TestBinReader_ReadBytes(t *testing.T) {
 	data := []byte{0, 1, 2, 3, 4, 5, 6, 7}
 	r := NewBinReaderFromBuf(data)
@@ -486,3 +617,83 @@ func TestBinReader_ReadBytes(t *testing.T) {
 	r.ReadBytes([]byte{})
 	require.Error(t, r.Err)
 }
+
+func TestBinReader_Strict(t *testing.T) {
+	t.Run("varuint", func(t *testing.T) {
+		for _, tc := range []struct {
+			data      []byte
+			val       uint64
+			canonical bool
+		}{
+			{[]byte{0xfc}, 0xfc, true},
+			{[]byte{0xfd, 0xfc, 0x00}, 0xfc, false},
+			{[]byte{0xfd, 0xfd, 0x00}, 0xfd, true},
+			{[]byte{0xfe, 0xff, 0xff, 0x00, 0x00}, 0xffff, false},
+			{[]byte{0xfe, 0x00, 0x00, 0x01, 0x00}, 0x10000, true},
+			{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0x00, 0x00, 0x00, 0x00}, 0xffffffff, false},
+			{[]byte{0xff, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00}, 0x100000000, true},
+		} {
+			r := NewBinReaderFromBuf(tc.data)
+			require.False(t, r.IsStrict())
+			require.Equal(t, tc.val, r.ReadVarUint())
+			require.NoError(t, r.Err)
+
+			r = NewStrictBinReaderFromBuf(tc.data)
+			require.True(t, r.IsStrict())
+			res := r.ReadVarUint()
+			if tc.canonical {
+				require.NoError(t, r.Err)
+				require.Equal(t, tc.val, res)
+			} else {
+				require.ErrorIs(t, r.Err, ErrNonCanonical)
+				require.Equal(t, uint64(0), res)
+			}
+		}
+	})
+	t.Run("bool", func(t *testing.T) {
+		r := NewStrictBinReaderFromBuf([]byte{0, 1, 2})
+		require.False(t, r.ReadBool())
+		require.True(t, r.ReadBool())
+		require.NoError(t, r.Err)
+		require.False(t, r.ReadBool())
+		require.ErrorIs(t, r.Err, ErrNonCanonical)
+
+		r = NewBinReaderFromBuf([]byte{2})
+		require.True(t, r.ReadBool())
+		require.NoError(t, r.Err)
+	})
+	t.Run("varbytes", func(t *testing.T) {
+		r := NewStrictBinReaderFromBuf([]byte{0xfd, 0x01, 0x00, 0x42})
+		r.ReadVarBytes()
+		require.ErrorIs(t, r.Err, ErrNonCanonical)
+	})
+}
+
+func TestWriteVarUintCanonical(t *testing.T) {
+	for _, val := range []uint64{0, 0xfc, 0xfd, 0xfffe, 0xffff, 0x10000, 0xfffffffe, 0xffffffff, 0x100000000, math.MaxUint64} {
+		bw := NewBufBinWriter()
+		bw.WriteVarUint(val)
+		require.NoError(t, bw.Err)
+		buf := bw.Bytes()
+		if val <= math.MaxInt32 {
+			require.Equal(t, GetVarSize(int(val)), len(buf), val)
+		}
+
+		br := NewStrictBinReaderFromBuf(buf)
+		require.Equal(t, val, br.ReadVarUint())
+		require.NoError(t, br.Err, val)
+	}
+}
+
+func TestDecodeStrict(t *testing.T) {
+	var v testSerializable
+	require.NoError(t, DecodeStrict([]byte{1, 2}, &v))
+	require.Equal(t, testSerializable(0x0201), v)
+	require.ErrorIs(t, DecodeStrict([]byte{1, 2, 3}, &v), ErrTrailingData)
+	require.Error(t, DecodeStrict([]byte{1}, &v))
+
+	var arr []testSerializable
+	r := NewStrictBinReaderFromBuf([]byte{0xfd, 0x01, 0x00, 0x01, 0x02})
+	r.ReadArray(&arr)
+	require.ErrorIs(t, r.Err, ErrNonCanonical)
+}
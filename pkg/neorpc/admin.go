@@ -0,0 +1,47 @@
+package neorpc
+
+import (
+	"crypto/sha256"
+	"strconv"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// HTTP headers used to sign admin requests. Admin methods can be restricted
+// by the server to requests signed by one of the configured keys, such
+// requests contain the public key (hex-encoded compressed), the time of
+// signing (Unix milliseconds), a unique nonce and the base64-encoded
+// signature of the AdminRequestHash.
+const (
+	AdminKeyHeader       = "X-Neo-Admin-Key"
+	AdminTimestampHeader = "X-Neo-Admin-Timestamp"
+	AdminNonceHeader     = "X-Neo-Admin-Nonce"
+	AdminSignatureHeader = "X-Neo-Admin-Signature"
+)
+
+// adminMethods is a set of methods changing node-local state or exposing
+// node-local service internals.
+var adminMethods = map[string]bool{
+	"getnotarypoolinfo": true,
+	"resetnativestats":  true,
+}
+
+// IsAdminMethod returns true if the method is an admin one (it can be disabled
+// or require signed requests on the server side).
+func IsAdminMethod(method string) bool {
+	return adminMethods[method]
+}
+
+// AdminRequestHash returns the hash of admin request data that is signed by
+// the client. It covers the timestamp, the nonce and the whole request body.
+func AdminRequestHash(timestamp int64, nonce string, body []byte) util.Uint256 {
+	h := sha256.New()
+	h.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	h.Write([]byte{'\n'})
+	h.Write([]byte(nonce))
+	h.Write([]byte{'\n'})
+	h.Write(body)
+	var res util.Uint256
+	copy(res[:], h.Sum(nil))
+	return res
+}
@@ -0,0 +1,44 @@
+package neorpc
+
+import (
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractFilter is a filter for contracts listed by the `getcontracts` RPC
+// method. Contracts can be filtered by a substring of their manifest name, by
+// a supported standard and/or by the deployer. Empty fields are treated as
+// missing filters.
+type ContractFilter struct {
+	Name     string        `json:"name,omitempty"`
+	Standard string        `json:"standard,omitempty"`
+	Deployer *util.Uint160 `json:"deployer,omitempty"`
+}
+
+// Matches checks whether the contract matches the filter. Deployer is not
+// stored in the contract state, so it's checked by the contract hash derived
+// from the deployer, NEF checksum and manifest name. Contracts updated with
+// a different NEF (and native contracts) never match this filter.
+func (f ContractFilter) Matches(cs *state.Contract) bool {
+	if f.Name != "" && !strings.Contains(cs.Manifest.Name, f.Name) {
+		return false
+	}
+	if f.Standard != "" {
+		var found bool
+		for _, st := range cs.Manifest.SupportedStandards {
+			if st == f.Standard {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.Deployer != nil && !state.CreateContractHash(*f.Deployer, cs.NEF.Checksum, cs.Manifest.Name).Equals(cs.Hash) {
+		return false
+	}
+	return true
+}
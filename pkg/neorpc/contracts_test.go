@@ -0,0 +1,40 @@
+package neorpc
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContractFilter_Matches(t *testing.T) {
+	deployer := util.Uint160{1, 2, 3}
+	m := manifest.NewManifest("SomeToken")
+	m.SupportedStandards = []string{manifest.NEP17StandardName}
+	cs := &state.Contract{ContractBase: state.ContractBase{
+		NEF:      nef.File{Checksum: 42},
+		Manifest: *m,
+	}}
+	cs.Hash = state.CreateContractHash(deployer, cs.NEF.Checksum, m.Name)
+	other := util.Uint160{3, 2, 1}
+
+	for _, tc := range []struct {
+		filter  ContractFilter
+		matches bool
+	}{
+		{ContractFilter{}, true},
+		{ContractFilter{Name: "Token"}, true},
+		{ContractFilter{Name: "token"}, false},
+		{ContractFilter{Standard: manifest.NEP17StandardName}, true},
+		{ContractFilter{Standard: manifest.NEP11StandardName}, false},
+		{ContractFilter{Deployer: &deployer}, true},
+		{ContractFilter{Deployer: &other}, false},
+		{ContractFilter{Name: "Some", Standard: manifest.NEP17StandardName, Deployer: &deployer}, true},
+		{ContractFilter{Name: "Some", Standard: manifest.NEP17StandardName, Deployer: &other}, false},
+	} {
+		require.Equal(t, tc.matches, tc.filter.Matches(cs), tc.filter)
+	}
+}
@@ -112,6 +112,9 @@ const (
 	// ErrInvalidVerificationFunctionCode is returned if contract doesn't have a verify method or
 	// this method doesn't return proper value.
 	ErrInvalidVerificationFunctionCode = -512
+	// ErrTxAdmissionRejectedCode is returned if transaction is rejected by the node-local admission
+	// filter. Can be returned only by the NeoGo RPC server.
+	ErrTxAdmissionRejectedCode = -513
 )
 
 // Errors related to node configuration and various services.
@@ -136,6 +139,29 @@ const (
 	ErrInvalidProofCode = -607
 	// ErrExecutionFailedCode is returned from a call made a VM execution, but it has failed.
 	ErrExecutionFailedCode = -608
+	// ErrStorageUsageUnavailableCode is returned if contract storage usage data can't be provided because
+	// storage usage tracking is disabled in the node configuration or counters are not yet rebuilt.
+	// Can be returned only by the NeoGo RPC server.
+	ErrStorageUsageUnavailableCode = -609
+	// ErrNativeCallStatsDisabledCode is returned if native call statistics can't be provided because
+	// statistics gathering is disabled in the node configuration. Can be returned only by the NeoGo RPC server.
+	ErrNativeCallStatsDisabledCode = -610
+	// ErrBlockProfilingDisabledCode is returned if block execution profile can't be provided because
+	// block profiling is disabled in the node configuration. Can be returned only by the NeoGo RPC server.
+	ErrBlockProfilingDisabledCode = -611
+	// ErrUnknownBlockProfileCode is returned if there is no execution profile for the requested block.
+	// Can be returned only by the NeoGo RPC server.
+	ErrUnknownBlockProfileCode = -612
+	// ErrUnauthorizedCode is returned if an admin method is called without a
+	// valid signature when the node requires admin requests to be signed. Can
+	// be returned only by the NeoGo RPC server.
+	ErrUnauthorizedCode = -613
+	// ErrNotaryDisabledCode is returned if P2PNotary service is not enabled in the configuration (service
+	// is not running). Can be returned only by the NeoGo RPC server.
+	ErrNotaryDisabledCode = -614
+	// ErrRateLimitExceededCode is returned if the client has exceeded its request rate limit, error
+	// data contains a hint on when the request can be retried. Can be returned only by the NeoGo RPC server.
+	ErrRateLimitExceededCode = -615
 )
 
 var (
@@ -243,6 +269,9 @@ var (
 	// ErrInvalidVerificationFunction represents an error with code [ErrInvalidVerificationFunctionCode].
 	// Contract doesn't have a verify method or this method doesn't return proper value.
 	ErrInvalidVerificationFunction = NewErrorWithCode(ErrInvalidVerificationFunctionCode, "Invalid verification function")
+	// ErrTxAdmissionRejected represents an error with code [ErrTxAdmissionRejectedCode].
+	// Transaction is rejected by the node-local admission filter.
+	ErrTxAdmissionRejected = NewErrorWithCode(ErrTxAdmissionRejectedCode, "Rejected by admission filter")
 
 	// ErrSessionsDisabled represents an error with code [ErrSessionsDisabledCode].
 	// Iterator session support is not enabled on the server.
@@ -268,6 +297,27 @@ var (
 	// ErrExecutionFailed represents an error with code [ErrExecutionFailedCode].
 	// Call made a VM execution, but it has failed.
 	ErrExecutionFailed = NewErrorWithCode(ErrExecutionFailedCode, "Execution failed")
+	// ErrStorageUsageUnavailable represents an error with code [ErrStorageUsageUnavailableCode].
+	// Storage usage tracking is disabled or counters are not yet rebuilt.
+	ErrStorageUsageUnavailable = NewErrorWithCode(ErrStorageUsageUnavailableCode, "Storage usage data is unavailable")
+	// ErrNativeCallStatsDisabled represents an error with code [ErrNativeCallStatsDisabledCode].
+	// Native call statistics gathering is disabled.
+	ErrNativeCallStatsDisabled = NewErrorWithCode(ErrNativeCallStatsDisabledCode, "Native call statistics are disabled")
+	// ErrBlockProfilingDisabled represents an error with code [ErrBlockProfilingDisabledCode].
+	// Block profiling is disabled.
+	ErrBlockProfilingDisabled = NewErrorWithCode(ErrBlockProfilingDisabledCode, "Block profiling is disabled")
+	// ErrUnknownBlockProfile represents an error with code [ErrUnknownBlockProfileCode].
+	// There is no execution profile for the requested block.
+	ErrUnknownBlockProfile = NewErrorWithCode(ErrUnknownBlockProfileCode, "Unknown block profile")
+	// ErrUnauthorized represents an error with code [ErrUnauthorizedCode].
+	// Admin request is not signed or its signature is invalid.
+	ErrUnauthorized = NewErrorWithCode(ErrUnauthorizedCode, "Unauthorized")
+	// ErrNotaryDisabled represents an error with code [ErrNotaryDisabledCode].
+	// Service is not enabled in the configuration.
+	ErrNotaryDisabled = NewErrorWithCode(ErrNotaryDisabledCode, "Notary service is not running")
+	// ErrRateLimitExceeded represents an error with code [ErrRateLimitExceededCode].
+	// Client has exceeded its request rate limit.
+	ErrRateLimitExceeded = NewErrorWithCode(ErrRateLimitExceededCode, "Rate limit exceeded")
 )
 
 // NewError is an Error constructor that takes Error contents from its parameters.
@@ -24,6 +24,9 @@ const (
 	NotaryRequestEventID
 	// HeaderOfAddedBlockEventID is used for the `header_of_added_block` event.
 	HeaderOfAddedBlockEventID
+	// CaughtUpEventID notifies user of historical events delivery completion
+	// for subscriptions made with a starting block.
+	CaughtUpEventID EventID = 254
 	// MissedEventID notifies user of missed events.
 	MissedEventID EventID = 255
 )
@@ -43,6 +46,8 @@ func (e EventID) String() string {
 		return "notary_request_event"
 	case HeaderOfAddedBlockEventID:
 		return "header_of_added_block"
+	case CaughtUpEventID:
+		return "subscription_caught_up"
 	case MissedEventID:
 		return "event_missed"
 	default:
@@ -65,6 +70,8 @@ func GetEventIDFromString(s string) (EventID, error) {
 		return NotaryRequestEventID, nil
 	case "header_of_added_block":
 		return HeaderOfAddedBlockEventID, nil
+	case "subscription_caught_up":
+		return CaughtUpEventID, nil
 	case "event_missed":
 		return MissedEventID, nil
 	default:
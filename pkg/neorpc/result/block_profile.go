@@ -0,0 +1,45 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+type (
+	// BlockProfile represents node-local execution profile of a single block
+	// returned by `getblockprofile` RPC handler. Profiles are available only
+	// if the node collects them.
+	BlockProfile struct {
+		Index uint32       `json:"index"`
+		Hash  util.Uint256 `json:"hash"`
+		// Time is the wall time (in nanoseconds) spent processing the block.
+		Time int64 `json:"time"`
+		// GAS is the amount of GAS (in fractional units) consumed by all
+		// block transactions.
+		GAS          int64             `json:"gas"`
+		Transactions []TxProfile       `json:"transactions"`
+		Contracts    []ContractProfile `json:"contracts"`
+	}
+
+	// TxProfile represents execution profile of a single transaction.
+	TxProfile struct {
+		Hash util.Uint256 `json:"hash"`
+		// Time is the wall time (in nanoseconds) of transaction script
+		// execution.
+		Time     int64          `json:"time"`
+		GAS      int64          `json:"gas"`
+		VMState  vmstate.State  `json:"vmstate"`
+		Syscalls map[string]int `json:"syscalls"`
+	}
+
+	// ContractProfile represents aggregated execution profile of a single
+	// contract for all block transactions.
+	ContractProfile struct {
+		Hash util.Uint160 `json:"hash"`
+		// Calls is the number of times the contract was called.
+		Calls int `json:"calls"`
+		// GAS is the amount of GAS spent by the contract's own code
+		// (excluding other contracts called by it).
+		GAS int64 `json:"gas"`
+	}
+)
@@ -0,0 +1,12 @@
+package result
+
+// CaughtUpEvent is sent to the subscriber after all historical events
+// requested for the subscription (see `fromBlock` parameter of `subscribe`
+// call) are delivered, all subsequent events of this subscription are live
+// ones.
+type CaughtUpEvent struct {
+	// ID is the subscription ID.
+	ID string `json:"id"`
+	// Index is the last replayed block index.
+	Index uint32 `json:"index"`
+}
@@ -0,0 +1,18 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Contracts represents the result of `getcontracts` RPC handler. It's a page
+// of contract states ordered by their hashes.
+type Contracts struct {
+	Contracts []state.Contract `json:"contracts"`
+	// Truncated is set if there are more matching contracts, they can be
+	// retrieved by passing Next as a start parameter.
+	Truncated bool `json:"truncated"`
+	// Next is the hash of the last returned contract, it's set only for
+	// truncated results.
+	Next *util.Uint160 `json:"next,omitempty"`
+}
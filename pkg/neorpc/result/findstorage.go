@@ -7,4 +7,10 @@ type FindStorage struct {
 	// that can be retrieved during the next iteration.
 	Next      int  `json:"next"`
 	Truncated bool `json:"truncated"`
+	// Continuation is an opaque token that can be passed instead of start
+	// index to retrieve the items following the last returned one. Unlike
+	// Next, it doesn't depend on items added or removed before the last
+	// returned one. It's set only for truncated results and is a NeoGo
+	// extension.
+	Continuation string `json:"continuation,omitempty"`
 }
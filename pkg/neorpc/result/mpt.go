@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"encoding/json"
 
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 )
 
@@ -12,6 +13,20 @@ import (
 type StateHeight struct {
 	Local     uint32 `json:"localrootindex"`
 	Validated uint32 `json:"validatedrootindex"`
+	// StateSync is only present while P2P state synchronisation is in
+	// progress (NeoGo extension).
+	StateSync *StateSync `json:"statesync,omitempty"`
+}
+
+// StateSync is a P2P state synchronisation progress, percentages of MPT
+// restored is an estimation.
+type StateSync struct {
+	Point       uint32  `json:"point"`
+	Headers     float64 `json:"headers"`
+	BlockHeight uint32  `json:"blockheight"`
+	Blocks      float64 `json:"blocks"`
+	MPTNodes    uint64  `json:"mptnodes"`
+	MPT         float64 `json:"mpt"`
 }
 
 // ProofWithKey represens a key-proof pair.
@@ -26,6 +41,15 @@ type VerifyProof struct {
 	Value []byte
 }
 
+// VerifiableState is a result of getverifiablestate RPC (NeoGo extension). It
+// contains contract storage item value with its MPT proof and the state root
+// signed by state validators this proof is made for.
+type VerifiableState struct {
+	Value []byte         `json:"value"`
+	Proof *ProofWithKey  `json:"proof"`
+	Root  *state.MPTRoot `json:"stateroot"`
+}
+
 // MarshalJSON implements the json.Marshaler.
 func (p *ProofWithKey) MarshalJSON() ([]byte, error) {
 	w := io.NewBufBinWriter()
@@ -0,0 +1,22 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NativeCallStats represents node-local invocation statistics of a single
+// native contract method returned by `getnativestats` RPC handler. These
+// statistics are available only if the node gathers them.
+type NativeCallStats struct {
+	Contract string       `json:"contract"`
+	Hash     util.Uint160 `json:"hash"`
+	Method   string       `json:"method"`
+	// ParamCount is the number of method parameters (to distinguish
+	// overloaded methods).
+	ParamCount int `json:"paramcount"`
+	// Calls is the number of method invocations.
+	Calls int64 `json:"calls"`
+	// GAS is the amount of GAS (in fractional units) spent by method
+	// invocations.
+	GAS int64 `json:"gas"`
+}
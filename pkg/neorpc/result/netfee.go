@@ -1,6 +1,60 @@
 package result
 
+import "github.com/nspcc-dev/neo-go/pkg/util"
+
 // NetworkFee represents a result of calculatenetworkfee RPC call.
 type NetworkFee struct {
 	Value int64 `json:"networkfee,string"`
 }
+
+// Witness types reported in WitnessFee.
+const (
+	// WitnessSignature is a standard single signature witness.
+	WitnessSignature = "signature"
+	// WitnessMultisig is a standard multisignature witness.
+	WitnessMultisig = "multisig"
+	// WitnessContract is a deployed contract witness (with "verify" method
+	// called).
+	WitnessContract = "contract"
+	// WitnessScript is a witness with non-standard verification script.
+	WitnessScript = "script"
+)
+
+// FeeEstimation represents a result of estimatefees RPC call (NeoGo
+// extension).
+type FeeEstimation struct {
+	// Invocation is the result of the script test invocation (the same
+	// one invokescript returns), its GasConsumed is the system fee.
+	Invocation *Invoke `json:"invocation"`
+	SystemFee  int64   `json:"systemfee,string"`
+	NetworkFee int64   `json:"networkfee,string"`
+	// TotalFee is the sum of SystemFee and NetworkFee.
+	TotalFee int64 `json:"totalfee,string"`
+	// Size is the size of the transaction with all witnesses.
+	Size int `json:"size"`
+	// BaseSize is the size of the transaction without witnesses (including
+	// the witness count prefix).
+	BaseSize int `json:"basesize"`
+	// SizeFee is the part of the network fee paid for the transaction size.
+	SizeFee int64 `json:"sizefee,string"`
+	// AttributesFee is the part of the network fee paid for the transaction
+	// attributes.
+	AttributesFee int64 `json:"attributesfee,string"`
+	// Witnesses contains size and verification cost details of every
+	// witness in the same order as transaction signers.
+	Witnesses []WitnessFee `json:"witnesses"`
+}
+
+// WitnessFee contains size and verification cost details of a single witness.
+type WitnessFee struct {
+	Account util.Uint160 `json:"account"`
+	// Type is one of Witness* constants.
+	Type string `json:"type"`
+	// Signatures is the number of signatures required by the standard
+	// verification script, it's zero for other witness types.
+	Signatures       int `json:"signatures,omitempty"`
+	InvocationSize   int `json:"invocationsize"`
+	VerificationSize int `json:"verificationsize"`
+	// VerificationFee is the GAS spent for the witness verification.
+	VerificationFee int64 `json:"verificationfee,string"`
+}
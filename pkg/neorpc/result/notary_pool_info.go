@@ -0,0 +1,40 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// NotaryPoolInfo represents a result of `getnotarypoolinfo` RPC call. It
+// describes main transactions pending completion by the node's P2PNotary
+// service, this data is node-local.
+type NotaryPoolInfo struct {
+	// Height is the current chain height, NotValidBefore and ValidUntilBlock
+	// values of the requests are to be compared with it.
+	Height   uint32              `json:"height"`
+	Requests []NotaryRequestInfo `json:"requests"`
+}
+
+// NotaryRequestInfo is a single main transaction pending completion by the
+// P2PNotary service.
+type NotaryRequestInfo struct {
+	Hash            util.Uint256 `json:"hash"`
+	ValidUntilBlock uint32       `json:"validuntilblock"`
+	// Fallbacks contains hashes of the pending fallback transactions.
+	Fallbacks []util.Uint256 `json:"fallbacks"`
+	// FallbackNotValidBefore is the height since which the main transaction
+	// is no longer sent and fallbacks are sent instead.
+	FallbackNotValidBefore uint32 `json:"fallbacknotvalidbefore"`
+	// SigsCollected is the number of signatures collected for the main
+	// transaction.
+	SigsCollected int `json:"collected"`
+	// SigsRequired is the number of signatures needed to complete the main
+	// transaction, it's 0 if the main transaction is invalid.
+	SigsRequired int  `json:"required"`
+	Completed    bool `json:"completed"`
+	Sent         bool `json:"sent"`
+	// Received is the time the first request for this main transaction was
+	// received at (Unix milliseconds).
+	Received int64 `json:"received"`
+	// Age is the time passed since Received in milliseconds.
+	Age int64 `json:"age"`
+}
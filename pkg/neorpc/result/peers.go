@@ -21,6 +21,31 @@ type (
 	Peer struct {
 		Address string `json:"address"`
 		Port    uint16 `json:"port"`
+		// Stats is only present for connected peers in verbose mode.
+		Stats *PeerStats `json:"stats,omitempty"`
+	}
+
+	// PeerStats contains connection statistics of a connected peer.
+	PeerStats struct {
+		UserAgent string `json:"useragent"`
+		// Age is the connection age in milliseconds.
+		Age uint64 `json:"age"`
+		// LastSent and LastReceived are the timestamps (in milliseconds)
+		// of the last messages sent to and received from the peer, zero if
+		// there were none.
+		LastSent     uint64 `json:"lastsent"`
+		LastReceived uint64 `json:"lastreceived"`
+		// Commands contains traffic counters per command name.
+		Commands map[string]PeerCommandStats `json:"commands"`
+	}
+
+	// PeerCommandStats contains byte and message counters for a single
+	// command.
+	PeerCommandStats struct {
+		SentBytes        uint64 `json:"sentbytes"`
+		SentMessages     uint64 `json:"sentmessages"`
+		ReceivedBytes    uint64 `json:"receivedbytes"`
+		ReceivedMessages uint64 `json:"receivedmessages"`
 	}
 )
 
@@ -43,6 +68,17 @@ func (g *GetPeers) AddConnected(addrs []string) {
 	g.Connected.addPeers(addrs)
 }
 
+// AddConnectedWithStats adds a connected peer with its statistics. Peers with
+// invalid addresses are ignored.
+func (g *GetPeers) AddConnectedWithStats(addr string, stats *PeerStats) {
+	peer, err := newPeer(addr)
+	if err != nil {
+		return
+	}
+	peer.Stats = stats
+	g.Connected = append(g.Connected, peer)
+}
+
 // AddBad adds a set of peers to the bad peers slice.
 func (g *GetPeers) AddBad(addrs []string) {
 	g.Bad.addPeers(addrs)
@@ -51,23 +87,31 @@ func (g *GetPeers) AddBad(addrs []string) {
 // addPeers adds a set of peers to the given peer slice.
 func (p *Peers) addPeers(addrs []string) {
 	for i := range addrs {
-		host, portStr, err := net.SplitHostPort(addrs[i])
+		peer, err := newPeer(addrs[i])
 		if err != nil {
 			continue
 		}
-		port, err := strconv.ParseUint(portStr, 10, 16)
-		if err != nil {
-			port = 0
-		}
-		peer := Peer{
-			Address: host,
-			Port:    uint16(port),
-		}
 
 		*p = append(*p, peer)
 	}
 }
 
+// newPeer creates a Peer from the given host:port address.
+func newPeer(addr string) (Peer, error) {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return Peer{}, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		port = 0
+	}
+	return Peer{
+		Address: host,
+		Port:    uint16(port),
+	}, nil
+}
+
 func (p *Peer) UnmarshalJSON(data []byte) error {
 	type NewPeer Peer
 	var np NewPeer
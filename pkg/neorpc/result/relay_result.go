@@ -5,4 +5,8 @@ import "github.com/nspcc-dev/neo-go/pkg/util"
 // RelayResult ia a result of `sendrawtransaction` or `submitblock` RPC calls.
 type RelayResult struct {
 	Hash util.Uint256 `json:"hash"`
+	// Parked is set by NeoGo nodes if the transaction is not yet valid and
+	// it's kept by the node until it becomes valid (it's not relayed until
+	// then).
+	Parked bool `json:"parked,omitempty"`
 }
@@ -0,0 +1,18 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// ContractStorageUsage represents storage usage statistics of a single
+// contract returned by `getcontractstorageusage` and `listcontractstorageusage`
+// RPC handlers. This data is node-local and is only available if the node
+// tracks it.
+type ContractStorageUsage struct {
+	ID   int32        `json:"id"`
+	Hash util.Uint160 `json:"hash"`
+	// Items is the number of contract storage items.
+	Items int64 `json:"items"`
+	// Size is the total size of contract storage keys and values in bytes.
+	Size int64 `json:"size"`
+}
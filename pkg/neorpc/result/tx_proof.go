@@ -0,0 +1,18 @@
+package result
+
+import (
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// TransactionProof represents a Merkle proof of transaction inclusion into
+// a block returned by `gettxproof` RPC handler. It can be checked against the
+// block header's Merkle root with hash.VerifyMerkleProof.
+type TransactionProof struct {
+	BlockHash  util.Uint256 `json:"blockhash"`
+	BlockIndex uint32       `json:"blockindex"`
+	MerkleRoot util.Uint256 `json:"merkleroot"`
+	// Index is the index of the transaction in the block.
+	Index uint32 `json:"index"`
+	// Path contains sibling hashes from the transaction level up to the root.
+	Path []util.Uint256 `json:"path"`
+}
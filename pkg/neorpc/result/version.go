@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 )
 
 type (
@@ -26,6 +27,10 @@ type (
 	RPC struct {
 		MaxIteratorResultItems int  `json:"maxiteratorresultitems"`
 		SessionEnabled         bool `json:"sessionenabled"`
+		// Capabilities is a NeoGo-specific list of RPC server capabilities
+		// (see RPCCapability* constants), it's nil for servers that don't
+		// report them (C# and older NeoGo nodes).
+		Capabilities []string `json:"capabilities,omitempty"`
 	}
 
 	// Protocol represents network-dependent parameters.
@@ -53,6 +58,18 @@ type (
 		StateRootInHeader bool
 		// ValidatorsHistory stores height:size map of the validators count.
 		ValidatorsHistory map[uint32]uint32
+		// NativeContracts is the list of native contracts enabled on the
+		// network (including the ones activated by future hardforks).
+		NativeContracts []NativeContract
+	}
+
+	// NativeContract describes native contract activation.
+	NativeContract struct {
+		ID   int32        `json:"id"`
+		Name string       `json:"name"`
+		Hash util.Uint160 `json:"hash"`
+		// ActivationHeight is the height native contract is active from.
+		ActivationHeight uint32 `json:"activationheight"`
 	}
 
 	// protocolMarshallerAux is an auxiliary struct used for Protocol JSON marshalling.
@@ -72,6 +89,7 @@ type (
 		P2PSigExtensions  bool              `json:"p2psigextensions,omitempty"`
 		StateRootInHeader bool              `json:"staterootinheader,omitempty"`
 		ValidatorsHistory map[uint32]uint32 `json:"validatorshistory,omitempty"`
+		NativeContracts   []NativeContract  `json:"nativecontracts,omitempty"`
 	}
 
 	// hardforkAux is an auxiliary struct used for Hardfork JSON marshalling.
@@ -84,6 +102,42 @@ type (
 // prefixHardfork is a prefix used for hardfork names in C# node.
 const prefixHardfork = "HF_"
 
+// RPC server capabilities reported in RPC.Capabilities.
+const (
+	// RPCCapabilitySessions means that iterator sessions are enabled.
+	RPCCapabilitySessions = "sessions"
+	// RPCCapabilityHistoric means that historic invocations and state-based
+	// methods (getproof, getstate, findstates, etc) are available for old
+	// states.
+	RPCCapabilityHistoric = "historic"
+	// RPCCapabilitySubscriptions means that WebSocket event subscriptions are
+	// supported.
+	RPCCapabilitySubscriptions = "subscriptions"
+	// RPCCapabilityNotary means that notary-related extensions
+	// (submitnotaryrequest, getrawnotarypool, etc) are available.
+	RPCCapabilityNotary = "notary"
+	// RPCCapabilityAdmin means that admin methods are enabled.
+	RPCCapabilityAdmin = "admin"
+	// RPCCapabilityFeeEstimation means that estimatefees method is
+	// available.
+	RPCCapabilityFeeEstimation = "feeestimation"
+)
+
+// HasCapability returns true if the server reports the given capability (see
+// RPCCapability* constants). For servers not reporting capabilities only
+// RPCCapabilitySessions can be detected (from SessionEnabled).
+func (r RPC) HasCapability(c string) bool {
+	if r.Capabilities == nil {
+		return c == RPCCapabilitySessions && r.SessionEnabled
+	}
+	for _, rc := range r.Capabilities {
+		if rc == c {
+			return true
+		}
+	}
+	return false
+}
+
 // MarshalJSON implements the JSON marshaler interface.
 func (p Protocol) MarshalJSON() ([]byte, error) {
 	// Keep hardforks sorted by name in the result.
@@ -112,6 +166,7 @@ func (p Protocol) MarshalJSON() ([]byte, error) {
 		P2PSigExtensions:  p.P2PSigExtensions,
 		StateRootInHeader: p.StateRootInHeader,
 		ValidatorsHistory: p.ValidatorsHistory,
+		NativeContracts:   p.NativeContracts,
 	}
 	return json.Marshal(aux)
 }
@@ -135,6 +190,7 @@ func (p *Protocol) UnmarshalJSON(data []byte) error {
 	p.P2PSigExtensions = aux.P2PSigExtensions
 	p.StateRootInHeader = aux.StateRootInHeader
 	p.ValidatorsHistory = aux.ValidatorsHistory
+	p.NativeContracts = aux.NativeContracts
 	p.InitialGasDistribution = fixedn.Fixed8(aux.InitialGasDistribution)
 
 	// Filter out unknown hardforks.
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/stretchr/testify/require"
 )
 
@@ -123,3 +124,54 @@ func TestVersion_MarshalUnmarshalJSON(t *testing.T) {
 		})
 	})
 }
+
+func TestVersion_Capabilities(t *testing.T) {
+	responseFromGoExt := `{
+        "nonce": 1677922561,
+        "protocol": {
+            "addressversion": 53,
+            "initialgasdistribution": 5200000000000000,
+            "maxtraceableblocks": 2102400,
+            "maxtransactionsperblock": 512,
+            "maxvaliduntilblockincrement": 5760,
+            "memorypoolmaxtransactions": 50000,
+            "msperblock": 15000,
+            "network": 860833102,
+            "validatorscount": 7,
+            "hardforks": [{"name": "Aspidochelone", "blockheight": 0}],
+            "nativecontracts": [
+                {"id": -1, "name": "ContractManagement", "hash": "0xfffdc93764dbaddd97c48f252a53ea4643faa3fd", "activationheight": 0},
+                {"id": -10, "name": "Notary", "hash": "0xc1e14f19c3e60d0b9244d06dd7ba9b113135ec3b", "activationheight": 100}
+            ]
+        },
+        "rpc": {
+            "maxiteratorresultitems": 100,
+            "sessionenabled": false,
+            "capabilities": ["historic", "subscriptions"]
+        },
+        "tcpport": 10333,
+        "useragent": "/NEO-GO:0.106.0/"
+    }`
+	v := new(Version)
+	require.NoError(t, json.Unmarshal([]byte(responseFromGoExt), v))
+	require.Equal(t, []NativeContract{
+		{ID: -1, Name: "ContractManagement", Hash: util.Uint160{0xfd, 0xa3, 0xfa, 0x43, 0x46, 0xea, 0x53, 0x2a, 0x25, 0x8f, 0xc4, 0x97, 0xdd, 0xad, 0xdb, 0x64, 0x37, 0xc9, 0xfd, 0xff}},
+		{ID: -10, Name: "Notary", Hash: util.Uint160{0x3b, 0xec, 0x35, 0x31, 0x11, 0x9b, 0xba, 0xd7, 0x6d, 0xd0, 0x44, 0x92, 0x0b, 0x0d, 0xe6, 0xc3, 0x19, 0x4f, 0xe1, 0xc1}, ActivationHeight: 100},
+	}, v.Protocol.NativeContracts)
+	require.True(t, v.RPC.HasCapability(RPCCapabilityHistoric))
+	require.True(t, v.RPC.HasCapability(RPCCapabilitySubscriptions))
+	require.False(t, v.RPC.HasCapability(RPCCapabilitySessions))
+	require.False(t, v.RPC.HasCapability(RPCCapabilityNotary))
+
+	actual, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, responseFromGoExt, string(actual))
+
+	t.Run("older server", func(t *testing.T) {
+		r := RPC{SessionEnabled: true}
+		require.True(t, r.HasCapability(RPCCapabilitySessions))
+		require.False(t, r.HasCapability(RPCCapabilityHistoric))
+		r.SessionEnabled = false
+		require.False(t, r.HasCapability(RPCCapabilitySessions))
+	})
+}
@@ -50,6 +50,13 @@ func NewExecutor(t testing.TB, bc *core.Blockchain, validator, committee Signer)
 	}
 }
 
+// CommitteeSubset returns a committee signer that signs using only the
+// committee members with the given indexes (see [MultiSigner.Subset]). It
+// allows to check committee witness for arbitrary quorum subsets.
+func (e *Executor) CommitteeSubset(indexes ...int) Signer {
+	return e.Committee.(MultiSigner).Subset(indexes...)
+}
+
 // TopBlock returns the block with the highest index.
 func (e *Executor) TopBlock(t testing.TB) *block.Block {
 	b, err := e.Chain.GetBlock(e.Chain.GetHeaderHash(e.Chain.BlockHeight()))
@@ -215,13 +222,73 @@ func (e *Executor) InvokeScriptCheckFAULT(t testing.TB, script []byte, signers [
 func (e *Executor) CheckHalt(t testing.TB, h util.Uint256, stack ...stackitem.Item) *state.AppExecResult {
 	aer, err := e.Chain.GetAppExecResults(h, trigger.Application)
 	require.NoError(t, err)
-	require.Equal(t, vmstate.Halt, aer[0].VMState, aer[0].FaultException)
+	if aer[0].VMState != vmstate.Halt {
+		require.Equal(t, vmstate.Halt, aer[0].VMState, aer[0].FaultException+e.limitsReport(h))
+	}
 	if len(stack) != 0 {
-		require.Equal(t, stack, aer[0].Stack)
+		CheckStack(t, stack, aer[0].Stack)
 	}
 	return &aer[0]
 }
 
+// limitWarningPercent is the percentage of VM reference limit reported by
+// limitsReport.
+const limitWarningPercent = 90
+
+// limitsReport re-executes the transaction with the given hash on top of the
+// state preceding its block and returns VM stack limits usage description.
+// Empty string is returned if the transaction can't be re-executed.
+func (e *Executor) limitsReport(h util.Uint256) string {
+	tx, height, err := e.Chain.GetTransaction(h)
+	if err != nil {
+		return ""
+	}
+	ic, err := e.Chain.GetTestHistoricVM(trigger.Application, tx, height)
+	if err != nil {
+		return ""
+	}
+	var crossed string
+	ic.VM.SetLimitWarning(limitWarningPercent, func(ctx *vm.Context, ip int, u vm.LimitUsage) {
+		if crossed == "" {
+			crossed = fmt.Sprintf(", %d%% of the limit is reached in %s at IP %d",
+				limitWarningPercent, ctx.ScriptHash().StringLE(), ip)
+		}
+	})
+	ic.VM.LoadScriptWithFlags(tx.Script, callflag.All)
+	ic.VM.GasLimit = tx.SystemFee
+	_ = ic.Exec()
+	u := ic.VM.LimitUsage()
+	return fmt.Sprintf(" (VM limits: peak references %d of %d, peak stack items %d%s)",
+		u.PeakReferences, u.MaxReferences, u.PeakStackItems, crossed)
+}
+
+// CheckStack checks that the actual stack deeply equals to the expected one
+// (see stackitem.DeepEquals).
+func CheckStack(t testing.TB, expected, actual []stackitem.Item) {
+	require.Equal(t, len(expected), len(actual), "unexpected stack length")
+	for i := range expected {
+		ok, err := stackitem.DeepEquals(expected[i], actual[i], 0)
+		require.NoError(t, err)
+		if !ok {
+			require.FailNow(t, "unexpected stack item",
+				"item %d: expected %s, got %s", i, itemString(expected[i]), itemString(actual[i]))
+		}
+	}
+}
+
+// itemString returns a human-readable item representation for test failure
+// messages.
+func itemString(item stackitem.Item) string {
+	if item == nil {
+		return "<nil>"
+	}
+	data, err := stackitem.ToJSONWithTypes(item)
+	if err != nil {
+		return fmt.Sprintf("%s (%v)", item.Type(), err)
+	}
+	return string(data)
+}
+
 // CheckFault checks that the transaction is persisted with FAULT state.
 // The raised exception is also checked to contain the s as a substring.
 func (e *Executor) CheckFault(t testing.TB, h util.Uint256, s string) {
@@ -315,6 +382,7 @@ func AddNetworkFee(t testing.TB, bc *core.Blockchain, tx *transaction.Transactio
 
 			require.NoError(t, bc.InitVerificationContext(ic, csgr.ScriptHash(), &transaction.Witness{InvocationScript: sc, VerificationScript: csgr.Script()}))
 			require.NoError(t, ic.VM.Run())
+			ic.Finalize()
 
 			tx.NetworkFee += ic.VM.GasConsumed()
 			size += io.GetVarSize(sc) + io.GetVarSize(csgr.Script())
@@ -367,6 +435,21 @@ func (e *Executor) GenerateNewBlocks(t testing.TB, count int) []*block.Block {
 	return blocks
 }
 
+// EnableHardforkAt adds empty blocks to the chain until the next block is
+// the one with the given index, so that the named hardfork scheduled for this
+// height is enabled for all subsequent invocations and transactions. Hardforks
+// can only be configured at genesis (see chain.Config), this method fails the
+// test if the hardfork is not scheduled for the height given or if the chain
+// is already at (or above) it.
+func (e *Executor) EnableHardforkAt(t testing.TB, name string, height uint32) {
+	h, ok := e.Chain.GetConfig().Hardforks[name]
+	require.True(t, ok && h == height, "hardfork %s is not scheduled for height %d", name, height)
+	require.True(t, e.Chain.BlockHeight() < height, "chain height %d is not below hardfork %s height %d", e.Chain.BlockHeight(), name, height)
+	for e.Chain.BlockHeight()+1 < height {
+		e.AddNewBlock(t)
+	}
+}
+
 // SignBlock add validators signature to b.
 func (e *Executor) SignBlock(b *block.Block) *block.Block {
 	invoc := e.Validator.SignHashable(uint32(e.Chain.GetConfig().Magic), b)
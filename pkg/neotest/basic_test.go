@@ -0,0 +1,87 @@
+package neotest_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// fillerSrc is a contract that creates an array of the given size and faults.
+const fillerSrc = `package filler
+func Fill(n int) int {
+	arr := make([]int, n)
+	if len(arr) == n {
+		panic("filled")
+	}
+	return 0
+}`
+
+func TestCheckHaltLimitsReport(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(fillerSrc), &compiler.Options{Name: "filler"})
+	e.DeployContract(t, c, nil)
+	inv := e.CommitteeInvoker(c.Hash)
+
+	const n = 2000
+	failures := runFailing(t, func(t testing.TB) {
+		inv.Invoke(t, 0, "fill", n)
+	})
+	require.Equal(t, 1, len(failures))
+	require.Contains(t, failures[0], "filled")
+	// Array with its elements plus a few references from slots and the stack.
+	require.Contains(t, failures[0], fmt.Sprintf("peak references %d of %d", n+4, vm.MaxStackSize))
+	require.Contains(t, failures[0], "90% of the limit is reached in "+c.Hash.StringLE())
+}
+
+// jsonPathSrc is a contract using Cockatrice-only StdLib method.
+const jsonPathSrc = `package jsonpath
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/std"
+)
+func Select() any {
+	return contract.Call(interop.Hash160(std.Hash), "jsonPath", contract.NoneFlag, []byte("{\"a\":1}"), "$.a")
+}`
+
+func TestEnableHardforkAt(t *testing.T) {
+	const hfHeight = 5
+	bc, acc, _ := chain.New(t, chain.NewConfig().WithHardfork(config.HFNeoGoExtensions.String(), hfHeight))
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(jsonPathSrc), &compiler.Options{Name: "jsonpath"})
+	e.DeployContract(t, c, nil)
+	inv := e.CommitteeInvoker(c.Hash)
+
+	// Not scheduled hardfork or wrong height.
+	require.Equal(t, 1, len(runFailing(t, func(t testing.TB) {
+		e.EnableHardforkAt(t, config.HFNeoGoExtensions.String(), hfHeight+1)
+	})))
+	require.Equal(t, 1, len(runFailing(t, func(t testing.TB) {
+		e.EnableHardforkAt(t, config.HFBasilisk.String(), hfHeight)
+	})))
+
+	// The same method faults before the hardfork and works after it.
+	inv.InvokeFail(t, "method not found: jsonPath/2", "select")
+	e.EnableHardforkAt(t, config.HFNeoGoExtensions.String(), hfHeight)
+	require.Equal(t, uint32(hfHeight-1), bc.BlockHeight())
+	// StdLib is updated when the hardfork block is persisted, so the fee
+	// can't be estimated via test invocation before it.
+	tx := e.SignTx(t, e.NewUnsignedTx(t, c.Hash, "select"), 1_0000_0000, inv.Signers...)
+	e.AddNewBlock(t, tx)
+	e.CheckHalt(t, tx.Hash(), stackitem.Make([]byte("[1]")))
+	require.Equal(t, uint32(hfHeight), bc.BlockHeight())
+
+	// The chain is already there.
+	require.Equal(t, 1, len(runFailing(t, func(t testing.TB) {
+		e.EnableHardforkAt(t, config.HFNeoGoExtensions.String(), hfHeight)
+	})))
+}
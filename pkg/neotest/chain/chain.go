@@ -3,6 +3,7 @@ package chain
 import (
 	"encoding/hex"
 	"sort"
+	"strconv"
 	"testing"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -289,3 +291,81 @@ func NewMultiWithOptionsNoCheck(t testing.TB, options *Options) (*core.Blockchai
 	}
 	return bc, neotest.NewMultiSigner(multiValidatorAcc...), neotest.NewMultiSigner(multiCommitteeAcc...), err
 }
+
+// NewMultiWithCount creates a new blockchain instance with the given number of
+// validators and committee members. Keys used are generated deterministically
+// (the same counts always produce the same keys, the first validators keys are
+// shared by any chains created this way), validators are the first members of
+// the StandbyCommittee. Otherwise, it does not differ much from NewMulti. The
+// second value returned contains the validators Signer, the third -- the
+// committee one, use [neotest.MultiSigner.Subset] (or
+// [neotest.Executor.CommitteeSubset]) to sign with a specific set of members.
+func NewMultiWithCount(t testing.TB, validators, committee int) (*core.Blockchain, neotest.Signer, neotest.Signer) {
+	return NewMultiWithCountAndOptions(t, validators, committee, nil)
+}
+
+// NewMultiWithCountAndOptions is similar to NewMultiWithCount, but allows to
+// customize the chain with options.
+func NewMultiWithCountAndOptions(t testing.TB, validators, committee int, options *Options) (*core.Blockchain, neotest.Signer, neotest.Signer) {
+	require.True(t, validators > 0, "at least one validator is needed")
+	require.True(t, validators <= committee, "validators count can't exceed committee size")
+	if options == nil {
+		options = &Options{}
+	}
+
+	privs := make([]*keys.PrivateKey, committee)
+	pubs := make(keys.PublicKeys, committee)
+	standby := make([]string, committee)
+	for i := range privs {
+		seed := hash.Sha256([]byte("neotest committee member #" + strconv.Itoa(i)))
+		priv, err := keys.NewPrivateKeyFromBytes(seed.BytesBE())
+		require.NoError(t, err)
+		privs[i] = priv
+		pubs[i] = priv.PublicKey()
+		standby[i] = hex.EncodeToString(pubs[i].Bytes())
+	}
+	newSigner := func(m int, pubs keys.PublicKeys, privs []*keys.PrivateKey) neotest.Signer {
+		pubs = pubs.Copy()
+		sort.Sort(pubs)
+		accs := make([]*wallet.Account, len(privs))
+		for i := range privs {
+			accs[i] = wallet.NewAccountFromPrivateKey(privs[i])
+			require.NoError(t, accs[i].ConvertMultisig(m, pubs))
+		}
+		return neotest.NewMultiSigner(accs...)
+	}
+
+	cfg := config.Blockchain{
+		ProtocolConfiguration: config.ProtocolConfiguration{
+			Magic:              netmode.UnitTestNet,
+			MaxTraceableBlocks: MaxTraceableBlocks,
+			TimePerBlock:       TimePerBlock,
+			StandbyCommittee:   standby,
+			ValidatorsCount:    uint32(validators),
+			VerifyTransactions: true,
+		},
+	}
+	if options.BlockchainConfigHook != nil {
+		options.BlockchainConfigHook(&cfg)
+	}
+
+	store := options.Store
+	if store == nil {
+		store = storage.NewMemoryStore()
+	}
+
+	logger := options.Logger
+	if logger == nil {
+		logger = zaptest.NewLogger(t)
+	}
+
+	bc, err := core.NewBlockchain(store, cfg, logger)
+	require.NoError(t, err)
+	if !options.SkipRun {
+		go bc.Run()
+		t.Cleanup(bc.Close)
+	}
+	return bc,
+		newSigner(smartcontract.GetDefaultHonestNodeCount(validators), pubs[:validators], privs[:validators]),
+		newSigner(smartcontract.GetMajorityHonestNodeCount(committee), pubs, privs)
+}
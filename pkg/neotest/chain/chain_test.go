@@ -2,7 +2,9 @@ package chain
 
 import (
 	"testing"
+	"time"
 
+	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/stretchr/testify/require"
 )
@@ -19,3 +21,79 @@ func TestNewMulti(t *testing.T) {
 	c := e.CommitteeInvoker(bc.UtilityTokenHash()).WithSigners(vAcc)
 	c.Invoke(t, true, "transfer", e.Validator.ScriptHash(), e.Committee.ScriptHash(), amount, nil)
 }
+
+func TestNewMultiWithCount(t *testing.T) {
+	bc, vAcc, cAcc := NewMultiWithCount(t, 7, 10)
+	e := neotest.NewExecutor(t, bc, vAcc, cAcc)
+
+	cfg := bc.GetConfig()
+	require.Equal(t, 7, cfg.GetNumOfCNs(0))
+	require.Equal(t, 10, len(cfg.StandbyCommittee))
+	require.NotEqual(t, vAcc.ScriptHash(), cAcc.ScriptHash())
+	vals, err := bc.GetNextBlockValidators()
+	require.NoError(t, err)
+	require.Equal(t, 7, len(vals))
+
+	// Keys are deterministic.
+	bc2, vAcc2, cAcc2 := NewMultiWithCount(t, 7, 10)
+	require.Equal(t, vAcc.ScriptHash(), vAcc2.ScriptHash())
+	require.Equal(t, cAcc.ScriptHash(), cAcc2.ScriptHash())
+	require.Equal(t, bc.GetHeaderHash(0), bc2.GetHeaderHash(0))
+
+	const amount = int64(10_0000_0000)
+
+	c := e.ValidatorInvoker(bc.UtilityTokenHash())
+	c.Invoke(t, true, "transfer", e.Validator.ScriptHash(), e.Committee.ScriptHash(), 2*amount, nil)
+	c = e.CommitteeInvoker(bc.UtilityTokenHash())
+	c.Invoke(t, true, "transfer", e.Committee.ScriptHash(), e.Validator.ScriptHash(), amount, nil)
+
+	// Majority of 10 is 6, any 6 members are enough.
+	c.WithSigners(e.CommitteeSubset(4, 5, 6, 7, 8, 9)).Invoke(t, true, "transfer",
+		e.Committee.ScriptHash(), e.Validator.ScriptHash(), amount/2, nil)
+	require.Panics(t, func() { e.CommitteeSubset(0, 1, 2, 3, 4) })
+}
+
+func TestConfig(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		for name, c := range map[string]*Config{
+			"hardfork":           NewConfig().WithHardfork("Unknown", 1),
+			"validators":         NewConfig().WithValidators(0),
+			"time per block":     NewConfig().WithTimePerBlock(0),
+			"time per block ms":  NewConfig().WithTimePerBlock(time.Microsecond),
+			"traceable blocks":   NewConfig().WithMaxTraceableBlocks(0),
+			"hardforks order":    NewConfig().WithHardfork(config.HFBasilisk.String(), 10).WithHardfork(config.HFCockatrice.String(), 5),
+			"missing hardfork":   NewConfig().WithHardfork(config.HFAspidochelone.String(), 5).WithHardfork(config.HFNeoGoExtensions.String(), 10),
+			"committee exceeded": NewConfig().WithValidators(2),
+		} {
+			t.Run(name, func(t *testing.T) {
+				cfg := config.Blockchain{ProtocolConfiguration: config.ProtocolConfiguration{
+					StandbyCommittee: standByCommittee[:1],
+					ValidatorsCount:  1,
+				}}
+				require.Error(t, c.Apply(&cfg))
+			})
+		}
+	})
+	t.Run("single", func(t *testing.T) {
+		bc, _ := NewSingleWithCustomConfig(t, NewConfig().
+			WithHardfork(config.HFBasilisk.String(), 5).
+			WithTimePerBlock(100*time.Millisecond).
+			WithMaxTraceableBlocks(500).
+			Hook(t))
+		cfg := bc.GetConfig()
+		require.Equal(t, 100*time.Millisecond, cfg.TimePerBlock)
+		require.Equal(t, uint32(500), cfg.MaxTraceableBlocks)
+		require.Equal(t, map[string]uint32{
+			config.HFAspidochelone.String(): 0,
+			config.HFBasilisk.String():      5,
+		}, cfg.Hardforks)
+	})
+	t.Run("multi", func(t *testing.T) {
+		bc, vAcc, cAcc := New(t, NewConfig().WithValidators(4).WithHardfork(config.HFNeoGoExtensions.String(), 3))
+		e := neotest.NewExecutor(t, bc, vAcc, cAcc)
+		cfg := bc.GetConfig()
+		require.Equal(t, 4, cfg.GetNumOfCNs(0))
+		e.EnableHardforkAt(t, config.HFNeoGoExtensions.String(), 3)
+		require.Equal(t, uint32(2), bc.BlockHeight())
+	})
+}
@@ -0,0 +1,150 @@
+package chain
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/stretchr/testify/require"
+)
+
+// Config is a builder of protocol settings for test chains. It's created with
+// NewConfig, adjusted with With* methods that can be chained and then used as
+// a configuration hook (see Hook and Apply) with any of the constructors
+// accepting one (like NewSingleWithCustomConfig) or passed to New that
+// creates a chain with validators matching the configuration.
+//
+// All of these settings are fixed at chain creation (genesis) time and can't
+// be changed for an existing chain, so a separate chain is to be created for
+// every configuration needed. TimePerBlock and MaxTraceableBlocks are only
+// initial values, they can be changed by the committee via Policy contract
+// after NeoGoExtensions hardfork, but the others can't be changed at all. Use
+// [neotest.Executor.EnableHardforkAt] to move the chain to the hardfork
+// scheduled with WithHardfork.
+type Config struct {
+	err                error // The first invalid setting error.
+	hardforks          map[string]uint32
+	maxTraceableBlocks uint32
+	timePerBlock       time.Duration
+	validators         int
+}
+
+// NewConfig returns a new empty configuration builder, it doesn't change
+// anything in the default configuration used by chain constructors.
+func NewConfig() *Config {
+	return &Config{}
+}
+
+// WithHardfork schedules the named hardfork for the given height (0 enables
+// it from genesis). Configuring any hardfork follows the node configuration
+// rules, preceding hardforks that are not configured explicitly are enabled
+// from genesis, while subsequent ones are disabled, so the chain always has
+// all of the hardforks enabled by default, but only those up to the latest
+// configured one if WithHardfork is used. The only exception is NeoGoExtensions
+// which is never enabled unless it's configured explicitly.
+func (c *Config) WithHardfork(name string, height uint32) *Config {
+	if !config.IsHardforkValid(name) {
+		c.setErr(fmt.Errorf("unknown hardfork: %s", name))
+		return c
+	}
+	if c.hardforks == nil {
+		c.hardforks = make(map[string]uint32)
+	}
+	c.hardforks[name] = height
+	return c
+}
+
+// WithValidators sets the number of validators. It must not exceed the
+// StandbyCommittee size of the chain and it must match the validators Signer
+// used for the chain, so it's mostly useful with New which takes care of that.
+func (c *Config) WithValidators(n int) *Config {
+	if n <= 0 {
+		c.setErr(fmt.Errorf("invalid number of validators: %d", n))
+		return c
+	}
+	c.validators = n
+	return c
+}
+
+// WithTimePerBlock sets TimePerBlock protocol setting, it must be an integer
+// number of milliseconds.
+func (c *Config) WithTimePerBlock(d time.Duration) *Config {
+	if d <= 0 {
+		c.setErr(fmt.Errorf("invalid TimePerBlock: %s", d))
+		return c
+	}
+	c.timePerBlock = d
+	return c
+}
+
+// WithMaxTraceableBlocks sets MaxTraceableBlocks protocol setting.
+func (c *Config) WithMaxTraceableBlocks(n uint32) *Config {
+	if n == 0 {
+		c.setErr(errors.New("MaxTraceableBlocks can't be zero"))
+		return c
+	}
+	c.maxTraceableBlocks = n
+	return c
+}
+
+// setErr remembers the first error of invalid setting.
+func (c *Config) setErr(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
+
+// Apply applies the settings to the given configuration and validates the
+// result. It returns an error if any of the settings is invalid or if the
+// resulting protocol configuration is not consistent.
+func (c *Config) Apply(cfg *config.Blockchain) error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.hardforks != nil {
+		cfg.Hardforks = make(map[string]uint32, len(c.hardforks))
+		for name, h := range c.hardforks {
+			cfg.Hardforks[name] = h
+		}
+	}
+	if c.maxTraceableBlocks != 0 {
+		cfg.MaxTraceableBlocks = c.maxTraceableBlocks
+	}
+	if c.timePerBlock != 0 {
+		cfg.TimePerBlock = c.timePerBlock
+	}
+	if c.validators != 0 {
+		cfg.ValidatorsCount = uint32(c.validators)
+		cfg.ValidatorsHistory = nil
+	}
+	return cfg.ProtocolConfiguration.Validate()
+}
+
+// Hook returns a configuration hook applying the settings that can be passed
+// to any of chain constructors accepting one, it fails the test if Apply
+// returns an error.
+func (c *Config) Hook(t testing.TB) func(*config.Blockchain) {
+	return func(cfg *config.Blockchain) {
+		require.NoError(t, c.Apply(cfg))
+	}
+}
+
+// New creates a new blockchain instance with the given configuration. A
+// single-validator chain (the same as NewSingle returns) is created if the
+// number of validators is not specified (or if it's 1), otherwise the chain
+// is the same as NewMultiWithCount would create for this number of
+// validators and the same committee size. The second value returned
+// contains the validators Signer, the third -- the committee one.
+func New(t testing.TB, c *Config) (*core.Blockchain, neotest.Signer, neotest.Signer) {
+	if c.validators <= 1 {
+		bc, acc := NewSingleWithCustomConfig(t, c.Hook(t))
+		return bc, acc, acc
+	}
+	return NewMultiWithCountAndOptions(t, c.validators, c.validators, &Options{
+		BlockchainConfigHook: c.Hook(t),
+	})
+}
@@ -3,5 +3,16 @@ Package chain contains functions creating new test blockchain instances.
 Different configurations can be used, but all chains created here use
 well-known keys. Most of the time, a single-node chain is the best choice to use
 unless you specifically need multiple validators and a large committee.
+
+Protocol settings can be customized with a configuration hook or with Config
+builder which is convenient for hardfork-dependent tests:
+
+	bc, validators, committee := chain.New(t, chain.NewConfig().
+		WithHardfork("NeoGoExtensions", 5).
+		WithValidators(4))
+	e := neotest.NewExecutor(t, bc, validators, committee)
+	// Check the behavior before the hardfork here.
+	e.EnableHardforkAt(t, "NeoGoExtensions", 5)
+	// And after it here.
 */
 package chain
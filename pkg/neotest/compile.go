@@ -8,6 +8,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/compiler"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/bundle"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -62,9 +63,11 @@ func CompileFile(t testing.TB, sender util.Uint160, srcPath string, configPath s
 	o.ContractEvents = conf.Events
 	o.DeclaredNamedTypes = conf.NamedTypes
 	o.ContractSupportedStandards = conf.SupportedStandards
-	o.Permissions = make([]manifest.Permission, len(conf.Permissions))
-	for i := range conf.Permissions {
-		o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+	if conf.Permissions != nil {
+		o.Permissions = make([]manifest.Permission, len(conf.Permissions))
+		for i := range conf.Permissions {
+			o.Permissions[i] = manifest.Permission(conf.Permissions[i])
+		}
 	}
 	o.SafeMethods = conf.SafeMethods
 	o.Overloads = conf.Overloads
@@ -80,3 +83,16 @@ func CompileFile(t testing.TB, sender util.Uint160, srcPath string, configPath s
 	contracts[srcPath] = c
 	return c
 }
+
+// ReadBundle reads a contract bundle (see bundle package) from the file and
+// returns its NEF, manifest and hash.
+func ReadBundle(t testing.TB, sender util.Uint160, bundlePath string) *Contract {
+	b, err := bundle.ReadFile(bundlePath)
+	require.NoError(t, err)
+
+	return &Contract{
+		Hash:     state.CreateContractHash(sender, b.NEF.Checksum, b.Manifest.Name),
+		NEF:      b.NEF,
+		Manifest: b.Manifest,
+	}
+}
@@ -0,0 +1,234 @@
+package neotest
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// NEP11SuiteOptions contains RunNEP11Suite parameters.
+type NEP11SuiteOptions struct {
+	// Mint is a mandatory hook that must create a new token owned by the
+	// specified account and return its ID. For divisible tokens the whole
+	// token must be owned by the account and its amount must be at least 3.
+	Mint func(t testing.TB, to Signer) []byte
+}
+
+// nep11UnknownToken is a token ID that is not expected to exist.
+var nep11UnknownToken = []byte("neotest unknown token")
+
+// RunNEP11Suite checks that the NEP-11 token contract deployed with the
+// specified hash complies with the standard. Divisible and non-divisible
+// tokens are distinguished by their decimals. It checks the manifest, symbol,
+// decimals, totalSupply, balanceOf, tokensOf and ownerOf consistency and
+// transfers: transfers to self, transfers without a proper witness, invalid
+// parameters, Transfer event shape and onNEP11Payment callback invocation
+// (including the rejecting one). Optional tokens and properties methods are
+// checked if they're present in the manifest. New accounts created with
+// Executor.NewAccount are used in the test and options.Mint hook is used to
+// give them some tokens. Failures are reported via t with messages describing
+// the specific requirement violated.
+func RunNEP11Suite(t testing.TB, e *Executor, h util.Uint160, opts NEP11SuiteOptions) {
+	require.NotNil(t, opts.Mint, "NEP-11 suite requires Mint hook")
+	s := newSuite(t, e, h, manifest.NEP11StandardName)
+
+	cs := s.checkManifest(manifest.NEP11StandardName, standard.Nep11Base)
+	divisible := s.checkSymbolDecimals() != 0
+	if divisible {
+		require.NoError(t, standard.ComplyABI(&cs.Manifest, standard.Nep11Divisible),
+			s.msg("manifest is not compliant with divisible NEP-11 (decimals is not 0)"))
+	} else {
+		require.NoError(t, standard.ComplyABI(&cs.Manifest, standard.Nep11NonDivisible),
+			s.msg("manifest is not compliant with non-divisible NEP-11 (decimals is 0)"))
+	}
+	supply := s.callInt("totalSupply")
+	require.True(t, supply.Sign() >= 0, s.msg("totalSupply must be non-negative, got %s", supply))
+
+	var (
+		accA = e.NewAccount(t)
+		accB = e.NewAccount(t)
+		a    = accA.ScriptHash()
+	)
+	require.Equal(t, int64(0), s.callInt("balanceOf", a).Int64(), s.msg("balance of a new account must be 0"))
+	require.Empty(t, s.callIterator("tokensOf", a), s.msg("tokensOf of a new account must be empty"))
+
+	id := opts.Mint(t, accA)
+	require.NotEmpty(t, id, s.msg("Mint hook must return token ID"))
+	require.True(t, s.callInt("totalSupply").Cmp(supply) > 0, s.msg("totalSupply must increase after minting"))
+	require.True(t, s.callInt("balanceOf", a).Sign() > 0, s.msg("balance must be positive after minting"))
+	require.True(t, containsBytes(s.callIterator("tokensOf", a), id), s.msg("tokensOf must contain token owned"))
+	if cs.Manifest.ABI.GetMethod("tokens", 0) != nil {
+		require.True(t, containsBytes(s.callIterator("tokens"), id), s.msg("tokens must contain token minted"))
+	}
+	if cs.Manifest.ABI.GetMethod("properties", 1) != nil {
+		props := s.call("properties", id)
+		require.Equal(t, stackitem.MapT, props.Type(), s.msg("properties must return Map, got %s", props.Type()))
+		require.True(t, props.(*stackitem.Map).Index(stackitem.Make("name")) >= 0,
+			s.msg("properties must contain 'name'"))
+	}
+
+	r := s.receiver()
+	if divisible {
+		s.checkNEP11Divisible(accA, accB, r, id)
+	} else {
+		s.checkNEP11NonDivisible(accA, accB, r, id)
+	}
+
+	// Payment rejected by contract.
+	id = opts.Mint(t, accA)
+	aer := s.transferNEP11(divisible, accA, r, id, suiteRejectData)
+	require.Equal(t, vmstate.Fault, aer.VMState, s.msg("transfer must fail if onNEP11Payment fails"))
+	require.True(t, containsBytes(s.callIterator("tokensOf", a), id),
+		s.msg("transfer rejected by contract: tokensOf sender must contain the token"))
+	require.False(t, containsBytes(s.callIterator("tokensOf", r), id),
+		s.msg("transfer rejected by contract: tokensOf receiver must not contain the token"))
+}
+
+// checkNEP11NonDivisible checks non-divisible NEP-11 transfers of the token
+// owned by accA.
+func (s *suite) checkNEP11NonDivisible(accA, accB Signer, r util.Uint160, id []byte) {
+	var (
+		t    = s.t
+		a    = accA.ScriptHash()
+		b    = accB.ScriptHash()
+		one  = big.NewInt(1)
+		balA = s.callInt("balanceOf", a)
+	)
+	checkOwner := func(what string, expected util.Uint160) {
+		s.checkHash160(s.call("ownerOf", id), expected, what+": ownerOf")
+	}
+	checkOwner("minted token", a)
+
+	// Transfer without owner witness.
+	ok, aer := s.invokeBool(accB, "transfer", b, id, nil)
+	require.False(t, ok, s.msg("transfer without owner witness must return false"))
+	require.Empty(t, s.transferEvents(aer), s.msg("failed transfer must not emit Transfer event"))
+	checkOwner("transfer without owner witness", a)
+
+	// Transfer to self.
+	ok, aer = s.invokeBool(accA, "transfer", a, id, nil)
+	require.True(t, ok, s.msg("transfer to self must succeed"))
+	s.checkTransfer(aer, a, a, one, id)
+	checkOwner("transfer to self", a)
+	s.checkInt(balA, s.callInt("balanceOf", a), "transfer to self: balance must not change")
+
+	// Invalid parameters.
+	require.False(t, succeeded(s.invoke(accA, "transfer", []byte{1, 2, 3}, id, nil)),
+		s.msg("transfer to invalid 'to' address must not succeed"))
+	require.False(t, succeeded(s.invoke(accA, "transfer", b, nep11UnknownToken, nil)),
+		s.msg("transfer of unknown token must not succeed"))
+	checkOwner("transfer with invalid parameters", a)
+
+	// Regular transfer.
+	ok, aer = s.invokeBool(accA, "transfer", b, id, nil)
+	require.True(t, ok, s.msg("transfer must succeed"))
+	s.checkTransfer(aer, a, b, one, id)
+	checkOwner("transfer", b)
+	s.checkInt(new(big.Int).Sub(balA, one), s.callInt("balanceOf", a), "transfer: unexpected sender balance")
+	s.checkInt(one, s.callInt("balanceOf", b), "transfer: unexpected receiver balance")
+	require.False(t, containsBytes(s.callIterator("tokensOf", a), id), s.msg("transfer: tokensOf sender must not contain the token"))
+	require.True(t, containsBytes(s.callIterator("tokensOf", b), id), s.msg("transfer: tokensOf receiver must contain the token"))
+
+	// Payment to contract.
+	ok, aer = s.invokeBool(accB, "transfer", r, id, suiteAcceptData)
+	require.True(t, ok, s.msg("transfer to contract must succeed"))
+	idx := s.checkTransfer(aer, b, r, one, id)
+	s.checkPayment(aer, r, idx, b, one, id)
+	checkOwner("transfer to contract", r)
+}
+
+// checkNEP11Divisible checks divisible NEP-11 transfers of the token owned by
+// accA.
+func (s *suite) checkNEP11Divisible(accA, accB Signer, r util.Uint160, id []byte) {
+	var (
+		t     = s.t
+		a     = accA.ScriptHash()
+		b     = accB.ScriptHash()
+		one   = big.NewInt(1)
+		total = s.callInt("balanceOf", a, id)
+	)
+	require.True(t, total.Cmp(big.NewInt(3)) >= 0, s.msg("minted token amount must be at least 3 for the test, got %s", total))
+	require.True(t, containsHash(s.callIterator("ownerOf", id), a), s.msg("ownerOf must contain the owner of minted token"))
+	checkBalances := func(what string, expA, expB *big.Int) {
+		s.checkInt(expA, s.callInt("balanceOf", a, id), "%s: unexpected sender balance", what)
+		s.checkInt(expB, s.callInt("balanceOf", b, id), "%s: unexpected receiver balance", what)
+	}
+
+	// Transfer without owner witness.
+	ok, aer := s.invokeBool(accB, "transfer", a, b, 1, id, nil)
+	require.False(t, ok, s.msg("transfer without owner witness must return false"))
+	require.Empty(t, s.transferEvents(aer), s.msg("failed transfer must not emit Transfer event"))
+	checkBalances("transfer without owner witness", total, big.NewInt(0))
+
+	// Invalid parameters.
+	aer = s.invoke(accA, "transfer", a, b, -1, id, nil)
+	require.Equal(t, vmstate.Fault, aer.VMState, s.msg("transfer of negative amount must fail"))
+	require.False(t, succeeded(s.invoke(accA, "transfer", a, []byte{1, 2, 3}, 1, id, nil)),
+		s.msg("transfer to invalid 'to' address must not succeed"))
+	require.False(t, succeeded(s.invoke(accA, "transfer", a, b, 1, nep11UnknownToken, nil)),
+		s.msg("transfer of unknown token must not succeed"))
+	checkBalances("transfer with invalid parameters", total, big.NewInt(0))
+
+	// Transfer to self.
+	ok, aer = s.invokeBool(accA, "transfer", a, a, 1, id, nil)
+	require.True(t, ok, s.msg("transfer to self must succeed"))
+	s.checkTransfer(aer, a, a, one, id)
+	checkBalances("transfer to self", total, big.NewInt(0))
+
+	// Regular transfer.
+	ok, aer = s.invokeBool(accA, "transfer", a, b, 1, id, nil)
+	require.True(t, ok, s.msg("transfer must succeed"))
+	s.checkTransfer(aer, a, b, one, id)
+	total = new(big.Int).Sub(total, one)
+	checkBalances("transfer", total, one)
+	owners := s.callIterator("ownerOf", id)
+	require.True(t, containsHash(owners, a) && containsHash(owners, b), s.msg("transfer: ownerOf must contain both owners"))
+	require.True(t, containsBytes(s.callIterator("tokensOf", b), id), s.msg("transfer: tokensOf receiver must contain the token"))
+
+	// Insufficient balance.
+	ok, aer = s.invokeBool(accB, "transfer", b, a, 2, id, nil)
+	require.False(t, ok, s.msg("transfer of amount exceeding the balance must return false"))
+	require.Empty(t, s.transferEvents(aer), s.msg("failed transfer must not emit Transfer event"))
+	checkBalances("transfer of amount exceeding the balance", total, one)
+
+	// Payment to contract.
+	ok, aer = s.invokeBool(accA, "transfer", a, r, 1, id, suiteAcceptData)
+	require.True(t, ok, s.msg("transfer to contract must succeed"))
+	idx := s.checkTransfer(aer, a, r, one, id)
+	s.checkPayment(aer, r, idx, a, one, id)
+	s.checkInt(one, s.callInt("balanceOf", r, id), "transfer to contract: unexpected receiver balance")
+}
+
+// transferNEP11 transfers the whole token owned by the signer to the given
+// account.
+func (s *suite) transferNEP11(divisible bool, from Signer, to util.Uint160, id []byte, data any) *state.AppExecResult {
+	if divisible {
+		amount := s.callInt("balanceOf", from.ScriptHash(), id)
+		return s.invoke(from, "transfer", from.ScriptHash(), to, amount, id, data)
+	}
+	return s.invoke(from, "transfer", to, id, data)
+}
+
+// containsBytes checks whether the list contains an item with the given
+// byte representation.
+func containsBytes(items []stackitem.Item, b []byte) bool {
+	for _, item := range items {
+		if v, err := item.TryBytes(); err == nil && bytes.Equal(v, b) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsHash checks whether the list contains the given Hash160.
+func containsHash(items []stackitem.Item, h util.Uint160) bool {
+	return containsBytes(items, h.BytesBE())
+}
@@ -0,0 +1,124 @@
+package neotest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// NEP17SuiteOptions contains RunNEP17Suite parameters.
+type NEP17SuiteOptions struct {
+	// Mint is a mandatory hook that must give at least the specified amount
+	// of tokens to the specified account (it can be done via minting or a
+	// transfer from some pre-funded account, for example).
+	Mint func(t testing.TB, to Signer, amount int64)
+}
+
+// nep17MintAmount is the amount of tokens minted to the test account by
+// RunNEP17Suite.
+const nep17MintAmount = 1000
+
+// RunNEP17Suite checks that the NEP-17 token contract deployed with the
+// specified hash complies with the standard. It checks the manifest, symbol,
+// decimals and totalSupply invariants and transfers: zero amount transfers,
+// transfers to self, transfers without a proper witness, transfers exceeding
+// the balance, negative and invalid parameters, Transfer event shape and
+// onNEP17Payment callback invocation (including the rejecting one). New
+// accounts created with Executor.NewAccount are used in the test and
+// options.Mint hook is used to give them some tokens. Failures are reported
+// via t with messages describing the specific requirement violated.
+func RunNEP17Suite(t testing.TB, e *Executor, h util.Uint160, opts NEP17SuiteOptions) {
+	require.NotNil(t, opts.Mint, "NEP-17 suite requires Mint hook")
+	s := newSuite(t, e, h, manifest.NEP17StandardName)
+
+	s.checkManifest(manifest.NEP17StandardName, standard.Nep17)
+	s.checkSymbolDecimals()
+	supply := s.callInt("totalSupply")
+	require.True(t, supply.Sign() >= 0, s.msg("totalSupply must be non-negative, got %s", supply))
+
+	var (
+		accA = e.NewAccount(t)
+		accB = e.NewAccount(t)
+		a    = accA.ScriptHash()
+		b    = accB.ScriptHash()
+		one  = big.NewInt(1)
+	)
+	require.Equal(t, int64(0), s.callInt("balanceOf", a).Int64(), s.msg("balance of a new account must be 0"))
+
+	opts.Mint(t, accA, nep17MintAmount)
+	balA := s.callInt("balanceOf", a)
+	require.True(t, balA.Cmp(big.NewInt(nep17MintAmount)) >= 0,
+		s.msg("balance after minting must be at least %d, got %s", nep17MintAmount, balA))
+	supply = s.callInt("totalSupply")
+	require.True(t, supply.Cmp(balA) >= 0, s.msg("totalSupply (%s) must not be less than any balance (%s)", supply, balA))
+
+	checkBalances := func(what string, expA, expB *big.Int) {
+		s.checkInt(expA, s.callInt("balanceOf", a), "%s: unexpected sender balance", what)
+		s.checkInt(expB, s.callInt("balanceOf", b), "%s: unexpected receiver balance", what)
+		s.checkInt(supply, s.callInt("totalSupply"), "%s: totalSupply must not change", what)
+	}
+
+	// Zero amount transfer.
+	ok, aer := s.invokeBool(accA, "transfer", a, b, 0, nil)
+	require.True(t, ok, s.msg("transfer of zero amount must succeed"))
+	s.checkTransfer(aer, a, b, big.NewInt(0), nil)
+	checkBalances("transfer of zero amount", balA, big.NewInt(0))
+
+	// Transfer to self.
+	ok, aer = s.invokeBool(accA, "transfer", a, a, 1, nil)
+	require.True(t, ok, s.msg("transfer to self must succeed"))
+	s.checkTransfer(aer, a, a, one, nil)
+	checkBalances("transfer to self", balA, big.NewInt(0))
+
+	// Regular transfer.
+	ok, aer = s.invokeBool(accA, "transfer", a, b, 1, nil)
+	require.True(t, ok, s.msg("transfer must succeed"))
+	s.checkTransfer(aer, a, b, one, nil)
+	balA = new(big.Int).Sub(balA, one)
+	checkBalances("transfer", balA, one)
+
+	// Transfer without sender witness.
+	ok, aer = s.invokeBool(accB, "transfer", a, b, 1, nil)
+	require.False(t, ok, s.msg("transfer without sender witness must return false"))
+	require.Empty(t, s.transferEvents(aer), s.msg("failed transfer must not emit Transfer event"))
+	checkBalances("transfer without sender witness", balA, one)
+
+	// Insufficient balance.
+	ok, aer = s.invokeBool(accA, "transfer", a, b, new(big.Int).Add(balA, one), nil)
+	require.False(t, ok, s.msg("transfer of amount exceeding the balance must return false"))
+	require.Empty(t, s.transferEvents(aer), s.msg("failed transfer must not emit Transfer event"))
+	checkBalances("transfer of amount exceeding the balance", balA, one)
+
+	// Negative amount.
+	aer = s.invoke(accA, "transfer", a, b, -1, nil)
+	require.Equal(t, vmstate.Fault, aer.VMState, s.msg("transfer of negative amount must fail"))
+	checkBalances("transfer of negative amount", balA, one)
+
+	// Invalid addresses.
+	aer = s.invoke(accA, "transfer", a, []byte{1, 2, 3}, 1, nil)
+	require.False(t, succeeded(aer), s.msg("transfer to invalid 'to' address must not succeed"))
+	aer = s.invoke(accA, "transfer", []byte{1, 2, 3}, b, 1, nil)
+	require.False(t, succeeded(aer), s.msg("transfer from invalid 'from' address must not succeed"))
+	checkBalances("transfer with invalid address", balA, one)
+
+	// Payment to contract.
+	r := s.receiver()
+	ok, aer = s.invokeBool(accA, "transfer", a, r, 1, suiteAcceptData)
+	require.True(t, ok, s.msg("transfer to contract must succeed"))
+	idx := s.checkTransfer(aer, a, r, one, nil)
+	s.checkPayment(aer, r, idx, a, one, nil)
+	balA = new(big.Int).Sub(balA, one)
+	s.checkInt(balA, s.callInt("balanceOf", a), "transfer to contract: unexpected sender balance")
+	s.checkInt(one, s.callInt("balanceOf", r), "transfer to contract: unexpected receiver balance")
+
+	// Payment rejected by contract.
+	aer = s.invoke(accA, "transfer", a, r, 1, suiteRejectData)
+	require.Equal(t, vmstate.Fault, aer.VMState, s.msg("transfer must fail if onNEP17Payment fails"))
+	s.checkInt(balA, s.callInt("balanceOf", a), "transfer rejected by contract: unexpected sender balance")
+	s.checkInt(one, s.callInt("balanceOf", r), "transfer rejected by contract: unexpected receiver balance")
+}
@@ -0,0 +1,92 @@
+package neotest
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// NEP24SuiteOptions contains RunNEP24Suite parameters.
+type NEP24SuiteOptions struct {
+	// Mint is a mandatory hook that must create a new token owned by the
+	// specified account and return its ID.
+	Mint func(t testing.TB, to Signer) []byte
+	// RoyaltyToken is the hash of the token used for royaltyInfo requests,
+	// GAS is used if not set.
+	RoyaltyToken util.Uint160
+}
+
+// nep24SalePrices are the prices royaltyInfo is checked with.
+var nep24SalePrices = []int64{0, 1, 100, 1_0000_0000, 1_000_000_0000_0000}
+
+// RunNEP24Suite checks that the NFT contract deployed with the specified hash
+// complies with NEP-24 (NFT royalties) standard. It checks the manifest
+// (including the optional RoyaltiesTransferred event if it's present) and
+// royaltyInfo results for a token created with options.Mint hook and a set
+// of sale prices: the result must be an array of (recipient, amount) pairs
+// with valid Hash160 recipients and non-negative amounts not exceeding the
+// sale price in total, the same for repeated calls. NEP-11 compliance is not
+// checked, use RunNEP11Suite for that. Failures are reported via t with
+// messages describing the specific requirement violated.
+func RunNEP24Suite(t testing.TB, e *Executor, h util.Uint160, opts NEP24SuiteOptions) {
+	require.NotNil(t, opts.Mint, "NEP-24 suite requires Mint hook")
+	s := newSuite(t, e, h, manifest.NEP24StandardName)
+
+	cs := s.checkManifest(manifest.NEP24StandardName, standard.Nep24)
+	require.True(t, cs.Manifest.IsStandardSupported(manifest.NEP11StandardName),
+		s.msg("%s must be in the list of supported standards", manifest.NEP11StandardName))
+	if ev := cs.Manifest.ABI.GetEvent("RoyaltiesTransferred"); ev != nil {
+		expected := []smartcontract.ParamType{
+			smartcontract.Hash160Type,   // royaltyToken
+			smartcontract.Hash160Type,   // royaltyRecipient
+			smartcontract.Hash160Type,   // buyer
+			smartcontract.ByteArrayType, // tokenId
+			smartcontract.IntegerType,   // amount
+		}
+		require.Equal(t, len(expected), len(ev.Parameters), s.msg("RoyaltiesTransferred event must have %d parameters", len(expected)))
+		for i := range expected {
+			require.Equal(t, expected[i], ev.Parameters[i].Type, s.msg("RoyaltiesTransferred event parameter %d has wrong type", i))
+		}
+	}
+
+	royaltyToken := opts.RoyaltyToken
+	if royaltyToken.Equals(util.Uint160{}) {
+		royaltyToken = e.NativeHash(t, nativenames.Gas)
+	}
+	id := opts.Mint(t, e.NewAccount(t))
+	require.NotEmpty(t, id, s.msg("Mint hook must return token ID"))
+
+	for _, price := range nep24SalePrices {
+		res := s.call("royaltyInfo", id, royaltyToken, price)
+		require.Equal(t, stackitem.ArrayT, res.Type(), s.msg("royaltyInfo must return Array, got %s", res.Type()))
+		var total = new(big.Int)
+		for i, r := range res.Value().([]stackitem.Item) {
+			require.True(t, r.Type() == stackitem.StructT || r.Type() == stackitem.ArrayT,
+				s.msg("royaltyInfo element %d must be a structure, got %s", i, r.Type()))
+			fields := r.Value().([]stackitem.Item)
+			require.Equal(t, 2, len(fields), s.msg("royaltyInfo element %d must have 2 fields (royaltyRecipient, royaltyAmount)", i))
+			recipient, err := fields[0].TryBytes()
+			require.NoError(t, err, s.msg("royaltyInfo element %d royaltyRecipient must be Hash160", i))
+			_, err = util.Uint160DecodeBytesBE(recipient)
+			require.NoError(t, err, s.msg("royaltyInfo element %d royaltyRecipient must be Hash160", i))
+			require.Equal(t, stackitem.IntegerT, fields[1].Type(), s.msg("royaltyInfo element %d royaltyAmount must be Integer", i))
+			amount := fields[1].Value().(*big.Int)
+			require.True(t, amount.Sign() >= 0, s.msg("royaltyInfo element %d royaltyAmount must be non-negative, got %s", i, amount))
+			total.Add(total, amount)
+		}
+		require.True(t, total.Cmp(big.NewInt(price)) <= 0,
+			s.msg("total royalty amount %s must not exceed sale price %d", total, price))
+		_, err := stackitem.Serialize(res)
+		require.NoError(t, err, s.msg("royaltyInfo result must be serializable"))
+		same, err := stackitem.DeepEquals(res, s.call("royaltyInfo", id, royaltyToken, price), 0)
+		require.NoError(t, err)
+		require.True(t, same, s.msg("royaltyInfo must be deterministic"))
+	}
+}
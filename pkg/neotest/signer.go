@@ -45,6 +45,11 @@ type MultiSigner interface {
 	Signer
 	// Single returns a simple-signature signer for the n-th account in a list.
 	Single(n int) SingleSigner
+	// Subset returns a multi-signature signer with the same verification
+	// script that signs using only the accounts with the given indexes (in
+	// the same order as for Single). At least as many accounts as needed
+	// to sign the script must be provided.
+	Subset(indexes ...int) MultiSigner
 }
 
 // signer represents a simple-signature signer.
@@ -166,6 +171,23 @@ func (m multiSigner) Single(n int) SingleSigner {
 	return NewSingleSigner(wallet.NewAccountFromPrivateKey(m.accounts[n].PrivateKey()))
 }
 
+// Subset implements MultiSigner interface.
+func (m multiSigner) Subset(indexes ...int) MultiSigner {
+	accs := make([]*wallet.Account, len(indexes))
+	for i, n := range indexes {
+		if n < 0 || len(m.accounts) <= n {
+			panic("invalid index")
+		}
+		for j := 0; j < i; j++ {
+			if indexes[j] == n {
+				panic(fmt.Sprintf("duplicate index %d", n))
+			}
+		}
+		accs[i] = m.accounts[n]
+	}
+	return NewMultiSigner(accs...)
+}
+
 func checkMultiSigner(t testing.TB, s Signer) {
 	ms, ok := s.(multiSigner)
 	require.True(t, ok, "expected to be a multi-signer")
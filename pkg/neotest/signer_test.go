@@ -46,3 +46,35 @@ func TestMultiSigner(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiSignerSubset(t *testing.T) {
+	const size = 4
+
+	pubs := make(keys.PublicKeys, size)
+	accs := make([]*wallet.Account, size)
+	for i := range accs {
+		a, err := wallet.NewAccount()
+		require.NoError(t, err)
+
+		accs[i] = a
+		pubs[i] = a.PublicKey()
+	}
+
+	sort.Sort(pubs)
+	m := smartcontract.GetDefaultHonestNodeCount(size)
+	for i := range accs {
+		require.NoError(t, accs[i].ConvertMultisig(m, pubs))
+	}
+
+	s := NewMultiSigner(accs...)
+	sub := s.Subset(3, 1, 2)
+	require.Equal(t, s.ScriptHash(), sub.ScriptHash())
+	require.Equal(t, s.Script(), sub.Script())
+	for i, n := range []int{1, 2, 3} {
+		require.Equal(t, s.Single(n).Account().PublicKey(), sub.Single(i).Account().PublicKey())
+	}
+
+	require.Panics(t, func() { s.Subset(0, 1) })
+	require.Panics(t, func() { s.Subset(0, 1, 1) })
+	require.Panics(t, func() { s.Subset(0, 1, size) })
+}
@@ -0,0 +1,266 @@
+package neotest
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// receiverSrc is a source of a contract accepting any NEP-17 and NEP-11
+// payments except the ones with "reject" data. It emits Payment
+// notification for every payment accepted.
+const receiverSrc = `package receiver
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+)
+func OnNEP17Payment(from interop.Hash160, amount int, data any) {
+	onPayment(from, amount, nil, data)
+}
+func OnNEP11Payment(from interop.Hash160, amount int, tokenID []byte, data any) {
+	onPayment(from, amount, tokenID, data)
+}
+func onPayment(from interop.Hash160, amount int, tokenID []byte, data any) {
+	if data == "reject" {
+		panic("payment rejected")
+	}
+	runtime.Notify("Payment", runtime.GetCallingScriptHash(), from, amount, tokenID, data)
+}`
+
+// Data passed to receiver contract in standard compliance test suites.
+const (
+	suiteAcceptData = "neotest payment"
+	suiteRejectData = "reject"
+)
+
+// maxSuiteIteratorItems is the maximum number of iterator items checked by
+// standard compliance test suites.
+const maxSuiteIteratorItems = 1000
+
+// suite contains helpers common for all standard compliance test suites.
+type suite struct {
+	t testing.TB
+	e *Executor
+	c *ContractInvoker
+	// std is the standard name used as a prefix for failure messages.
+	std string
+}
+
+func newSuite(t testing.TB, e *Executor, h util.Uint160, std string) *suite {
+	return &suite{
+		t:   t,
+		e:   e,
+		c:   e.NewInvoker(h),
+		std: std,
+	}
+}
+
+// msg returns failure message prefixed by the standard name.
+func (s *suite) msg(format string, args ...any) string {
+	return s.std + ": " + fmt.Sprintf(format, args...)
+}
+
+// checkManifest ensures that the contract is deployed, claims support for
+// the given standard and complies with the given ABI definition.
+func (s *suite) checkManifest(name string, st *standard.Standard) *state.Contract {
+	cs := s.e.Chain.GetContractState(s.c.Hash)
+	require.NotNil(s.t, cs, s.msg("contract %s is not deployed", s.c.Hash.StringLE()))
+	require.True(s.t, cs.Manifest.IsStandardSupported(name),
+		s.msg("%s is not in the list of supported standards", name))
+	require.NoError(s.t, standard.ComplyABI(&cs.Manifest, st), s.msg("manifest is not compliant"))
+	return cs
+}
+
+// call performs test invocation of the contract method and returns its result.
+func (s *suite) call(method string, args ...any) stackitem.Item {
+	stack, err := s.c.TestInvoke(s.t, method, args...)
+	require.NoError(s.t, err, s.msg("%s invocation failed", method))
+	require.Equal(s.t, 1, stack.Len(), s.msg("%s must return exactly one value", method))
+	return stack.Pop().Item()
+}
+
+// callFails checks whether test invocation of the contract method fails.
+func (s *suite) callFails(method string, args ...any) bool {
+	_, err := s.c.TestInvoke(s.t, method, args...)
+	return err != nil
+}
+
+// callInt performs test invocation of the contract method that must return
+// an integer.
+func (s *suite) callInt(method string, args ...any) *big.Int {
+	item := s.call(method, args...)
+	require.Equal(s.t, stackitem.IntegerT, item.Type(), s.msg("%s must return Integer, got %s", method, item.Type()))
+	return item.Value().(*big.Int)
+}
+
+// callIterator performs test invocation of the contract method that must
+// return an iterator and returns all the iterator values.
+func (s *suite) callIterator(method string, args ...any) []stackitem.Item {
+	script, err := smartcontract.CreateCallAndUnwrapIteratorScript(s.c.Hash, method, maxSuiteIteratorItems, args...)
+	require.NoError(s.t, err)
+	stack, err := s.c.TestInvokeScript(s.t, script, nil)
+	require.NoError(s.t, err, s.msg("%s invocation failed", method))
+	require.Equal(s.t, 1, stack.Len(), s.msg("%s must return exactly one value", method))
+	item := stack.Pop().Item()
+	require.Equal(s.t, stackitem.ArrayT, item.Type(), s.msg("%s must return an iterator", method))
+	return item.Value().([]stackitem.Item)
+}
+
+// checkSymbolDecimals checks symbol and decimals methods common for NEP-11
+// and NEP-17 and returns decimals.
+func (s *suite) checkSymbolDecimals() int64 {
+	item := s.call("symbol")
+	require.Equal(s.t, stackitem.ByteArrayT, item.Type(), s.msg("symbol must return String, got %s", item.Type()))
+	sym, err := stackitem.ToString(item)
+	require.NoError(s.t, err, s.msg("symbol must be a valid string"))
+	require.NotEmpty(s.t, sym, s.msg("symbol must not be empty"))
+	require.Equal(s.t, -1, strings.IndexFunc(sym, func(r rune) bool { return r <= ' ' || r > '~' }),
+		s.msg("symbol %q must contain only printable ASCII characters without whitespace", sym))
+
+	dec := s.callInt("decimals")
+	require.True(s.t, dec.Sign() >= 0 && dec.IsInt64(), s.msg("decimals must be non-negative, got %s", dec))
+	s.checkInt(dec, s.callInt("decimals"), "decimals must be constant")
+	return dec.Int64()
+}
+
+// checkInt checks that the actual integer value is equal to the expected one.
+func (s *suite) checkInt(expected, actual *big.Int, format string, args ...any) {
+	require.True(s.t, expected.Cmp(actual) == 0, s.msg(format, args...)+fmt.Sprintf(": expected %s, got %s", expected, actual))
+}
+
+// invoke creates a transaction invoking the contract method signed by the
+// given signer, persists it and returns its execution result.
+func (s *suite) invoke(signer Signer, method string, args ...any) *state.AppExecResult {
+	tx := s.c.WithSigners(signer).PrepareInvoke(s.t, method, args...)
+	s.e.AddNewBlock(s.t, tx)
+	return s.e.GetTxExecResult(s.t, tx.Hash())
+}
+
+// invokeBool is similar to invoke but also checks that the transaction is
+// executed successfully and returns its boolean result.
+func (s *suite) invokeBool(signer Signer, method string, args ...any) (bool, *state.AppExecResult) {
+	aer := s.invoke(signer, method, args...)
+	require.Equal(s.t, vmstate.Halt, aer.VMState, s.msg("%s invocation failed: %s", method, aer.FaultException))
+	require.Equal(s.t, 1, len(aer.Stack), s.msg("%s must return exactly one value", method))
+	require.Equal(s.t, stackitem.BooleanT, aer.Stack[0].Type(), s.msg("%s must return Boolean, got %s", method, aer.Stack[0].Type()))
+	return aer.Stack[0].Value().(bool), aer
+}
+
+// succeeded checks whether the transaction is successful, i.e. it's executed
+// with HALT state and returns true.
+func succeeded(aer *state.AppExecResult) bool {
+	if aer.VMState != vmstate.Halt || len(aer.Stack) != 1 {
+		return false
+	}
+	ok, err := aer.Stack[0].TryBool()
+	return err == nil && ok
+}
+
+// transferEvents returns all Transfer notifications emitted by the contract.
+func (s *suite) transferEvents(aer *state.AppExecResult) []state.NotificationEvent {
+	var res []state.NotificationEvent
+	for _, ev := range aer.Events {
+		if ev.ScriptHash.Equals(s.c.Hash) && ev.Name == "Transfer" {
+			res = append(res, ev)
+		}
+	}
+	return res
+}
+
+// checkTransfer checks that the transaction emitted exactly one Transfer
+// event with the given parameters (tokenID is nil for NEP-17) and returns its
+// index in the list of transaction notifications.
+func (s *suite) checkTransfer(aer *state.AppExecResult, from, to util.Uint160, amount *big.Int, tokenID []byte) int {
+	evs := s.transferEvents(aer)
+	require.Equal(s.t, 1, len(evs), s.msg("exactly one Transfer event is expected, got %d", len(evs)))
+	params := evs[0].Item.Value().([]stackitem.Item)
+	expectedLen := 3
+	if tokenID != nil {
+		expectedLen = 4
+	}
+	require.Equal(s.t, expectedLen, len(params), s.msg("Transfer event must have %d parameters", expectedLen))
+	s.checkHash160(params[0], from, "Transfer event 'from'")
+	s.checkHash160(params[1], to, "Transfer event 'to'")
+	require.Equal(s.t, stackitem.IntegerT, params[2].Type(), s.msg("Transfer event 'amount' must be Integer, got %s", params[2].Type()))
+	s.checkInt(amount, params[2].Value().(*big.Int), "Transfer event has wrong 'amount'")
+	if tokenID != nil {
+		id, err := params[3].TryBytes()
+		require.NoError(s.t, err, s.msg("Transfer event 'tokenId' must be ByteString"))
+		require.Equal(s.t, tokenID, id, s.msg("Transfer event has wrong 'tokenId'"))
+	}
+	for i := range aer.Events {
+		if aer.Events[i].ScriptHash.Equals(s.c.Hash) && aer.Events[i].Name == "Transfer" {
+			return i
+		}
+	}
+	return -1
+}
+
+// checkHash160 checks that the item is a 20-byte ByteString with the
+// given hash.
+func (s *suite) checkHash160(item stackitem.Item, expected util.Uint160, what string) {
+	b, err := item.TryBytes()
+	require.NoError(s.t, err, s.msg("%s must be Hash160", what))
+	u, err := util.Uint160DecodeBytesBE(b)
+	require.NoError(s.t, err, s.msg("%s must be Hash160", what))
+	require.Equal(s.t, expected, u, s.msg("%s has wrong value", what))
+}
+
+// checkPayment checks that the receiver contract got the payment with the
+// given parameters after the Transfer event with the given index.
+func (s *suite) checkPayment(aer *state.AppExecResult, receiver util.Uint160, transferIndex int, from util.Uint160, amount *big.Int, tokenID []byte) {
+	var payment *state.NotificationEvent
+	for i := transferIndex + 1; i < len(aer.Events); i++ {
+		if aer.Events[i].ScriptHash.Equals(receiver) {
+			payment = &aer.Events[i]
+			break
+		}
+	}
+	require.NotNil(s.t, payment, s.msg("payment callback of the receiver contract must be called after Transfer event"))
+	params := payment.Item.Value().([]stackitem.Item)
+	s.checkHash160(params[0], s.c.Hash, "payment callback caller")
+	s.checkHash160(params[1], from, "payment callback 'from'")
+	require.Equal(s.t, stackitem.IntegerT, params[2].Type(), s.msg("payment callback 'amount' must be Integer"))
+	s.checkInt(amount, params[2].Value().(*big.Int), "payment callback has wrong 'amount'")
+	if tokenID != nil {
+		id, err := params[3].TryBytes()
+		require.NoError(s.t, err)
+		require.Equal(s.t, tokenID, id, s.msg("payment callback has wrong 'tokenId'"))
+	}
+	data, err := stackitem.ToString(params[4])
+	require.NoError(s.t, err, s.msg("payment callback must receive 'data' passed to transfer"))
+	require.Equal(s.t, suiteAcceptData, data, s.msg("payment callback must receive 'data' passed to transfer"))
+}
+
+// receiver returns the hash of the payment receiver contract deploying it if
+// needed.
+func (s *suite) receiver() util.Uint160 {
+	anyParam := func(name string) compiler.HybridParameter {
+		return compiler.HybridParameter{Parameter: manifest.NewParameter(name, smartcontract.AnyType)}
+	}
+	c := CompileSource(s.t, s.e.CommitteeHash, strings.NewReader(receiverSrc), &compiler.Options{
+		Name:          "neotest payment receiver",
+		NoEventsCheck: true,
+		ContractEvents: []compiler.HybridEvent{{
+			Name: "Payment",
+			Parameters: []compiler.HybridParameter{
+				anyParam("token"), anyParam("from"), anyParam("amount"), anyParam("tokenId"), anyParam("data"),
+			},
+		}},
+	})
+	if s.e.Chain.GetContractState(c.Hash) == nil {
+		s.e.DeployContract(s.t, c, nil)
+	}
+	return c.Hash
+}
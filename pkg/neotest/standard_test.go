@@ -0,0 +1,250 @@
+package neotest_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/contracts"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+const examplesPath = "../../examples"
+
+// exampleOwner returns the owner of example contracts funded with some GAS.
+func exampleOwner(t *testing.T, e *neotest.Executor) neotest.SingleSigner {
+	w, err := wallet.NewWalletFromFile(filepath.Join(examplesPath, "my_wallet.json"))
+	require.NoError(t, err)
+	require.NoError(t, w.Accounts[0].Decrypt("qwerty", w.Scrypt))
+	owner := neotest.NewSingleSigner(w.Accounts[0])
+
+	gas := e.ValidatorInvoker(e.NativeHash(t, nativenames.Gas))
+	gas.Invoke(t, true, "transfer", e.Validator.ScriptHash(), owner.ScriptHash(), 1000_0000_0000, nil)
+	return owner
+}
+
+// mintNFT returns Mint hook for example NFT contracts minting tokens with GAS
+// payments.
+func mintNFT(e *neotest.Executor, h util.Uint160, data func() any) func(t testing.TB, to neotest.Signer) []byte {
+	return func(t testing.TB, to neotest.Signer) []byte {
+		gas := e.NewInvoker(e.NativeHash(t, nativenames.Gas), to)
+		txH := gas.Invoke(t, true, "transfer", to.ScriptHash(), h, 10_0000_0000, data())
+		aer := e.GetTxExecResult(t, txH)
+		for _, ev := range aer.Events {
+			if ev.ScriptHash.Equals(h) && ev.Name == "Transfer" {
+				id, err := ev.Item.Value().([]stackitem.Item)[3].TryBytes()
+				require.NoError(t, err)
+				return id
+			}
+		}
+		t.Fatal("no Transfer event")
+		return nil
+	}
+}
+
+func TestRunNEP17Suite(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	owner := exampleOwner(t, e)
+	h := contracts.DeployExample(t, e, examplesPath, "token", "token.yml")
+	token := e.NewInvoker(h, owner)
+	token.Invoke(t, true, "mint", owner.ScriptHash())
+
+	neotest.RunNEP17Suite(t, e, h, neotest.NEP17SuiteOptions{
+		Mint: func(t testing.TB, to neotest.Signer, amount int64) {
+			token.Invoke(t, true, "transfer", owner.ScriptHash(), to.ScriptHash(), amount, nil)
+		},
+	})
+}
+
+func TestRunNEP11Suite(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	t.Run("non-divisible", func(t *testing.T) {
+		h := contracts.DeployExample(t, e, examplesPath, "nft-nd", "nft.yml")
+		neotest.RunNEP11Suite(t, e, h, neotest.NEP11SuiteOptions{
+			Mint: mintNFT(e, h, func() any { return nil }),
+		})
+	})
+	t.Run("divisible", func(t *testing.T) {
+		h := contracts.DeployExample(t, e, examplesPath, "nft-d", "nft.yml")
+		neotest.RunNEP11Suite(t, e, h, neotest.NEP11SuiteOptions{
+			Mint: mintNFT(e, h, func() any {
+				return []any{random.Bytes(util.Uint256Size), random.Bytes(util.Uint256Size)}
+			}),
+		})
+	})
+}
+
+func TestRunNEP24Suite(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	h := contracts.DeployExample(t, e, examplesPath, "nft-nd", "nft.yml")
+	neotest.RunNEP24Suite(t, e, h, neotest.NEP24SuiteOptions{
+		Mint: mintNFT(e, h, func() any { return nil }),
+	})
+}
+
+// failRecorder is a testing.TB implementation recording failures.
+type failRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failRecorder) Helper() {}
+
+func (r *failRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *failRecorder) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.FailNow()
+}
+
+func (r *failRecorder) Fatal(args ...any) {
+	r.failures = append(r.failures, fmt.Sprint(args...))
+	r.FailNow()
+}
+
+func (r *failRecorder) Fail() {}
+
+func (r *failRecorder) FailNow() {
+	// Stop the suite goroutine.
+	runtime.Goexit()
+}
+
+func (r *failRecorder) Failed() bool { return len(r.failures) != 0 }
+
+// runFailing runs the suite in a separate goroutine recording failures.
+func runFailing(t *testing.T, suite func(t testing.TB)) []string {
+	var (
+		r  = &failRecorder{TB: t}
+		wg sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		suite(r)
+	}()
+	wg.Wait()
+	return r.failures
+}
+
+// brokenTokenSrc is a NEP-17 token source with the code for the negative
+// amount check and the code emitting Transfer event to be substituted.
+const brokenTokenSrc = `package broken
+import (
+	"github.com/nspcc-dev/neo-go/pkg/interop"
+	"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+	"github.com/nspcc-dev/neo-go/pkg/interop/native/management"
+	"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	"github.com/nspcc-dev/neo-go/pkg/interop/storage"
+)
+var supplyKey = []byte("s")
+func get(key []byte) int {
+	v := storage.Get(storage.GetReadOnlyContext(), key)
+	if v == nil {
+		return 0
+	}
+	return v.(int)
+}
+func put(key []byte, n int) {
+	storage.Put(storage.GetContext(), key, n)
+}
+func Symbol() string { return "BRK" }
+func Decimals() int { return 0 }
+func TotalSupply() int { return get(supplyKey) }
+func BalanceOf(h interop.Hash160) int { return get(h) }
+func Mint(to interop.Hash160, amount int) {
+	put(to, get(to)+amount)
+	put(supplyKey, get(supplyKey)+amount)
+	var zero interop.Hash160
+	runtime.Notify("Transfer", zero, to, amount)
+}
+func Transfer(from, to interop.Hash160, amount int, data any) bool {
+	%s
+	if len(from) != 20 || len(to) != 20 || !runtime.CheckWitness(from) {
+		return false
+	}
+	b := get(from)
+	if b < amount {
+		return false
+	}
+	put(from, b-amount)
+	put(to, get(to)+amount)
+	%s
+	if management.GetContract(to) != nil {
+		contract.Call(to, "onNEP17Payment", contract.All, from, amount, data)
+	}
+	return true
+}`
+
+func TestRunNEP17Suite_Broken(t *testing.T) {
+	const (
+		negativeCheck = `if amount < 0 { panic("negative amount") }`
+		notify        = `runtime.Notify("Transfer", from, to, amount)`
+		notifyNonZero = `if amount != 0 { runtime.Notify("Transfer", from, to, amount) }`
+	)
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	hash160 := func(name string) compiler.HybridParameter {
+		return compiler.HybridParameter{Parameter: manifest.NewParameter(name, smartcontract.Hash160Type)}
+	}
+
+	for name, tc := range map[string]struct {
+		negativeCheck string
+		notify        string
+		expected      string
+	}{
+		"valid":                 {negativeCheck, notify, ""},
+		"negative amount":       {"", notify, "NEP-17: transfer of negative amount must fail"},
+		"no zero amount events": {negativeCheck, notifyNonZero, "NEP-17: exactly one Transfer event is expected, got 0"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := neotest.CompileSource(t, e.CommitteeHash, strings.NewReader(fmt.Sprintf(brokenTokenSrc, tc.negativeCheck, tc.notify)), &compiler.Options{
+				Name:                       "Broken token " + name,
+				NoEventsCheck:              true,
+				NoPermissionsCheck:         true,
+				SafeMethods:                []string{"balanceOf", "decimals", "symbol", "totalSupply"},
+				ContractSupportedStandards: []string{manifest.NEP17StandardName},
+				ContractEvents: []compiler.HybridEvent{{
+					Name: "Transfer",
+					Parameters: []compiler.HybridParameter{
+						hash160("from"), hash160("to"),
+						{Parameter: manifest.NewParameter("amount", smartcontract.IntegerType)},
+					},
+				}},
+				Permissions: []manifest.Permission{*manifest.NewPermission(manifest.PermissionWildcard)},
+			})
+			e.DeployContract(t, c, nil)
+			inv := e.CommitteeInvoker(c.Hash)
+			opts := neotest.NEP17SuiteOptions{
+				Mint: func(t testing.TB, to neotest.Signer, amount int64) {
+					inv.Invoke(t, nil, "mint", to.ScriptHash(), amount)
+				},
+			}
+			failures := runFailing(t, func(t testing.TB) { neotest.RunNEP17Suite(t, e, c.Hash, opts) })
+			if tc.expected == "" {
+				require.Empty(t, failures)
+				return
+			}
+			require.NotEmpty(t, failures)
+			require.Contains(t, failures[0], tc.expected)
+		})
+	}
+}
@@ -0,0 +1,324 @@
+package bqueue
+
+import (
+	"sync"
+	"time"
+)
+
+// Default Fetcher settings.
+const (
+	// DefaultFetchRangeSize is the number of blocks requested from a peer
+	// at once.
+	DefaultFetchRangeSize = 500
+	// DefaultFetchMaxInFlight is the number of ranges a single peer can be
+	// assigned at the same time.
+	DefaultFetchMaxInFlight = 2
+	// DefaultFetchTimeout is the time given to a peer to deliver the next
+	// block of an assigned range before the range is handed over to some
+	// other peer.
+	DefaultFetchTimeout = 20 * time.Second
+)
+
+// FetchPeer is a peer blocks can be fetched from.
+type FetchPeer interface {
+	LastBlockIndex() uint32
+}
+
+// FetcherConfig contains Fetcher settings, zero values are replaced with
+// defaults.
+type FetcherConfig struct {
+	// Window is the maximum number of blocks above the current height that
+	// can be requested, it must not exceed the size of the queue blocks are
+	// put into.
+	Window int
+	// RangeSize is the maximum number of blocks in a single request.
+	RangeSize int
+	// MaxInFlight is the maximum number of ranges assigned to a single peer.
+	MaxInFlight int
+	// Timeout is the time a peer has to deliver some block of the range
+	// assigned to it.
+	Timeout time.Duration
+}
+
+// Range is a block range to be requested from a peer.
+type Range struct {
+	Start uint32
+	Count uint32
+}
+
+// fetchRange is a range of blocks that is being downloaded.
+type fetchRange struct {
+	start uint32
+	got   []bool
+	left  int
+	// peer is the peer the range is assigned to, nil if the range waits for
+	// (re)assignment.
+	peer FetchPeer
+	// stalled is the last peer that failed to deliver the range, it can't
+	// take the range again until the deadline passes.
+	stalled  FetchPeer
+	deadline time.Time
+}
+
+// Fetcher schedules parallel block downloads from multiple peers. The block
+// range above the current height limited by the window is split into
+// sub-ranges that are assigned to different peers, every peer can have at most
+// MaxInFlight of them at a time. Ranges not delivered in time are reassigned
+// to other peers, the range the chain is waiting for is also duplicated to
+// idle peers. Blocks arrive in any order and are expected to be put into the
+// Queue that persists them sequentially.
+type Fetcher struct {
+	lock     sync.Mutex
+	cfg      FetcherConfig
+	now      func() time.Time
+	ranges   []*fetchRange // Sorted by start.
+	inFlight map[FetchPeer]int
+	// next is the first index not covered by ranges yet.
+	next uint32
+	// base is the last known index blocks are available up to and baseTime
+	// is the time it was last changed at.
+	base     uint32
+	baseTime time.Time
+}
+
+// NewFetcher creates a new Fetcher with the given configuration.
+func NewFetcher(cfg FetcherConfig) *Fetcher {
+	if cfg.Window <= 0 {
+		cfg.Window = CacheSize
+	}
+	if cfg.RangeSize <= 0 {
+		cfg.RangeSize = DefaultFetchRangeSize
+	}
+	if cfg.RangeSize > cfg.Window {
+		cfg.RangeSize = cfg.Window
+	}
+	if cfg.MaxInFlight <= 0 {
+		cfg.MaxInFlight = DefaultFetchMaxInFlight
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultFetchTimeout
+	}
+	return &Fetcher{
+		cfg:      cfg,
+		now:      time.Now,
+		inFlight: make(map[FetchPeer]int),
+	}
+}
+
+func (r *fetchRange) end() uint32 {
+	return r.start + uint32(len(r.got)) - 1
+}
+
+// Request returns the next range to be requested from the given peer. height
+// is the current chain height and queued is the index of the last block
+// available in the queue (everything up to it is not requested). It returns
+// false if there is nothing to request from this peer at the moment.
+func (f *Fetcher) Request(p FetchPeer, height uint32, queued uint32) (Range, bool) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var now = f.now()
+	if queued < height {
+		queued = height
+	}
+	f.advance(queued, now)
+	f.expire(now)
+
+	var peerHeight = p.LastBlockIndex()
+	if peerHeight <= queued || f.inFlight[p] >= f.cfg.MaxInFlight {
+		return Range{}, false
+	}
+	// Reassign ranges that were not delivered first.
+	for i, r := range f.ranges {
+		if r.peer != nil || r.start > peerHeight || (r.stalled == p && now.Before(r.deadline)) {
+			continue
+		}
+		return f.assign(i, p, now), true
+	}
+	var limit = height + uint32(f.cfg.Window)
+	if f.next <= limit && f.next <= peerHeight {
+		var end = f.next + uint32(f.cfg.RangeSize) - 1
+		if end > limit {
+			end = limit
+		}
+		r := &fetchRange{start: f.next, got: make([]bool, end-f.next+1)}
+		r.left = len(r.got)
+		f.ranges = append(f.ranges, r)
+		f.next = end + 1
+		return f.assign(len(f.ranges)-1, p, now), true
+	}
+	// Nothing new to fetch, help with the range the chain is waiting for.
+	if len(f.ranges) != 0 {
+		r := f.ranges[0]
+		if r.start == queued+1 && r.peer != p && r.start <= peerHeight {
+			var end = r.end()
+			if end > peerHeight {
+				end = peerHeight
+			}
+			return Range{Start: r.start, Count: end - r.start + 1}, true
+		}
+	}
+	return Range{}, false
+}
+
+// Received marks the block with the given index as received from the peer.
+// It returns true if this block completes some range.
+func (f *Fetcher) Received(p FetchPeer, index uint32) bool {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for i, r := range f.ranges {
+		if index < r.start {
+			break
+		}
+		if index > r.end() {
+			continue
+		}
+		if !r.got[index-r.start] {
+			r.got[index-r.start] = true
+			r.left--
+		}
+		if r.peer == p {
+			r.deadline = f.now().Add(f.cfg.Timeout)
+		}
+		if r.left == 0 {
+			f.remove(i)
+			return true
+		}
+		return false
+	}
+	return false
+}
+
+// RemovePeer releases all ranges assigned to the peer, they're reassigned
+// to other peers.
+func (f *Fetcher) RemovePeer(p FetchPeer) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	for _, r := range f.ranges {
+		if r.peer == p {
+			r.peer = nil
+		}
+		if r.stalled == p {
+			r.stalled = nil
+		}
+	}
+	delete(f.inFlight, p)
+}
+
+// assign assigns the i-th range to the peer, the part of the range that is
+// above the peer height is split into a separate range.
+func (f *Fetcher) assign(i int, p FetchPeer, now time.Time) Range {
+	var (
+		r          = f.ranges[i]
+		peerHeight = p.LastBlockIndex()
+	)
+	if r.end() > peerHeight {
+		tail := &fetchRange{start: peerHeight + 1, got: r.got[peerHeight+1-r.start:]}
+		r.got = r.got[:peerHeight+1-r.start]
+		r.left = countMissing(r.got)
+		tail.left = countMissing(tail.got)
+		if tail.left != 0 {
+			f.ranges = append(f.ranges, nil)
+			copy(f.ranges[i+2:], f.ranges[i+1:])
+			f.ranges[i+1] = tail
+		}
+	}
+	r.peer = p
+	r.stalled = nil
+	r.deadline = now.Add(f.cfg.Timeout)
+	f.inFlight[p]++
+
+	// There is no need to request blocks we already have.
+	var start, end = r.start, r.end()
+	for start < end && r.got[start-r.start] {
+		start++
+	}
+	for end > start && r.got[end-r.start] {
+		end--
+	}
+	return Range{Start: start, Count: end - start + 1}
+}
+
+// remove drops the i-th range releasing its peer.
+func (f *Fetcher) remove(i int) {
+	f.release(f.ranges[i])
+	copy(f.ranges[i:], f.ranges[i+1:])
+	f.ranges[len(f.ranges)-1] = nil
+	f.ranges = f.ranges[:len(f.ranges)-1]
+}
+
+func (f *Fetcher) release(r *fetchRange) {
+	if r.peer == nil {
+		return
+	}
+	f.inFlight[r.peer]--
+	if f.inFlight[r.peer] <= 0 {
+		delete(f.inFlight, r.peer)
+	}
+	r.peer = nil
+}
+
+// advance drops everything up to the given index which is already available
+// and re-requests blocks that are lost for some reason (like invalid blocks
+// received from some peer).
+func (f *Fetcher) advance(base uint32, now time.Time) {
+	if base != f.base || f.baseTime.IsZero() {
+		f.base = base
+		f.baseTime = now
+	} else if now.Sub(f.baseTime) > f.cfg.Timeout && base+1 < f.next &&
+		(len(f.ranges) == 0 || f.ranges[0].start > base+1) {
+		var end = base + uint32(f.cfg.RangeSize)
+		if end >= f.next {
+			end = f.next - 1
+		}
+		if len(f.ranges) != 0 && end >= f.ranges[0].start {
+			end = f.ranges[0].start - 1
+		}
+		r := &fetchRange{start: base + 1, got: make([]bool, end-base)}
+		r.left = len(r.got)
+		f.ranges = append([]*fetchRange{r}, f.ranges...)
+		f.baseTime = now
+	}
+	for len(f.ranges) != 0 && f.ranges[0].start <= base {
+		r := f.ranges[0]
+		if r.end() <= base {
+			f.remove(0)
+			continue
+		}
+		r.got = r.got[base+1-r.start:]
+		r.start = base + 1
+		r.left = countMissing(r.got)
+		if r.left == 0 {
+			f.remove(0)
+			continue
+		}
+		break
+	}
+	if f.next <= base {
+		f.next = base + 1
+	}
+}
+
+// expire takes ranges away from peers that failed to deliver them in time.
+func (f *Fetcher) expire(now time.Time) {
+	for _, r := range f.ranges {
+		if r.peer != nil && now.After(r.deadline) {
+			var p = r.peer
+			f.release(r)
+			r.stalled = p
+			r.deadline = now.Add(f.cfg.Timeout)
+		}
+	}
+}
+
+func countMissing(got []bool) int {
+	var n int
+	for _, ok := range got {
+		if !ok {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,303 @@
+package bqueue
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/core/block"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testPeer struct {
+	height uint32
+}
+
+func (p *testPeer) LastBlockIndex() uint32 {
+	return p.height
+}
+
+type testClock struct {
+	t time.Time
+}
+
+func (c *testClock) now() time.Time {
+	return c.t
+}
+
+func newTestFetcher(cfg FetcherConfig) (*Fetcher, *testClock) {
+	f := NewFetcher(cfg)
+	c := &testClock{t: time.Unix(1000, 0)}
+	f.now = c.now
+	return f, c
+}
+
+func checkRequest(t *testing.T, f *Fetcher, p FetchPeer, height, queued, start, count uint32) {
+	r, ok := f.Request(p, height, queued)
+	require.True(t, ok)
+	require.Equal(t, Range{Start: start, Count: count}, r)
+}
+
+func checkNoRequest(t *testing.T, f *Fetcher, p FetchPeer, height, queued uint32) {
+	_, ok := f.Request(p, height, queued)
+	require.False(t, ok)
+}
+
+func receive(f *Fetcher, p FetchPeer, start, end uint32) bool {
+	var done bool
+	for i := start; i <= end; i++ {
+		done = f.Received(p, i)
+	}
+	return done
+}
+
+func TestFetcherParallel(t *testing.T) {
+	f, _ := newTestFetcher(FetcherConfig{})
+	p1, p2, p3 := &testPeer{5000}, &testPeer{5000}, &testPeer{700}
+
+	checkRequest(t, f, p1, 0, 0, 1, 500)
+	checkRequest(t, f, p2, 0, 0, 501, 500)
+	checkRequest(t, f, p1, 0, 0, 1001, 500)
+	// Per-peer limit.
+	checkNoRequest(t, f, p1, 0, 0)
+	// Peer p3 has nothing new, so it helps with the first range.
+	checkRequest(t, f, p3, 0, 0, 1, 500)
+	checkRequest(t, f, p2, 0, 0, 1501, 500)
+	// The window is full.
+	checkRequest(t, f, &testPeer{5000}, 0, 0, 1, 500)
+
+	// Blocks received from some other peer still count.
+	require.False(t, receive(f, p3, 1, 499))
+	require.True(t, receive(f, p3, 500, 500))
+	// But they're not queued yet and there is nothing to request.
+	checkNoRequest(t, f, p1, 0, 0)
+	checkNoRequest(t, f, p3, 0, 0)
+
+	// Chain moves, window moves along with it.
+	checkRequest(t, f, p3, 500, 500, 501, 200)
+	checkRequest(t, f, p1, 500, 500, 2001, 500)
+	checkNoRequest(t, f, p1, 500, 500)
+}
+
+func TestFetcherPeerHeight(t *testing.T) {
+	f, _ := newTestFetcher(FetcherConfig{})
+	low, high := &testPeer{150}, &testPeer{5000}
+
+	checkNoRequest(t, f, &testPeer{0}, 0, 0)
+	checkRequest(t, f, low, 0, 0, 1, 150)
+	// The rest of the range goes to the peer that has it.
+	checkRequest(t, f, high, 0, 0, 151, 350)
+	checkNoRequest(t, f, low, 0, 0)
+	checkRequest(t, f, high, 0, 0, 501, 500)
+}
+
+func TestFetcherWindow(t *testing.T) {
+	f, _ := newTestFetcher(FetcherConfig{Window: 300, RangeSize: 100, MaxInFlight: 10})
+	p := &testPeer{5000}
+
+	checkRequest(t, f, p, 0, 0, 1, 100)
+	checkRequest(t, f, p, 0, 0, 101, 100)
+	checkRequest(t, f, p, 0, 0, 201, 100)
+	checkNoRequest(t, f, p, 0, 0)
+	// Blocks are queued, but not persisted yet.
+	require.True(t, receive(f, p, 1, 100))
+	checkNoRequest(t, f, p, 0, 100)
+	checkRequest(t, f, p, 50, 100, 301, 50)
+	checkNoRequest(t, f, p, 50, 100)
+}
+
+func TestFetcherStallReassign(t *testing.T) {
+	f, c := newTestFetcher(FetcherConfig{Timeout: time.Second})
+	slow, good := &testPeer{5000}, &testPeer{5000}
+
+	checkRequest(t, f, slow, 0, 0, 1, 500)
+	checkRequest(t, f, good, 0, 0, 501, 500)
+	require.True(t, receive(f, good, 501, 1000))
+	require.False(t, receive(f, slow, 1, 100))
+
+	// Progress extends the deadline.
+	c.t = c.t.Add(800 * time.Millisecond)
+	require.False(t, receive(f, slow, 101, 101))
+	c.t = c.t.Add(800 * time.Millisecond)
+	checkRequest(t, f, good, 0, 0, 1001, 500)
+	require.True(t, receive(f, good, 1001, 1500))
+
+	// The slow peer stalls and the rest of its range goes to the good peer.
+	c.t = c.t.Add(1100 * time.Millisecond)
+	checkRequest(t, f, good, 0, 0, 102, 399)
+	// The stalled peer doesn't get it back, but gets new blocks.
+	checkRequest(t, f, slow, 0, 0, 1501, 500)
+	require.True(t, receive(f, good, 102, 500))
+
+	t.Run("only peer", func(t *testing.T) {
+		f, c := newTestFetcher(FetcherConfig{Timeout: time.Second, MaxInFlight: 1})
+		p := &testPeer{5000}
+
+		checkRequest(t, f, p, 0, 0, 1, 500)
+		c.t = c.t.Add(1100 * time.Millisecond)
+		// Stalled range can't be taken back immediately.
+		checkRequest(t, f, p, 0, 0, 501, 500)
+		c.t = c.t.Add(1100 * time.Millisecond)
+		// Now both ranges are stalled, the first one is retried.
+		checkRequest(t, f, p, 0, 0, 1, 500)
+	})
+}
+
+func TestFetcherRemovePeer(t *testing.T) {
+	f, _ := newTestFetcher(FetcherConfig{})
+	p1, p2 := &testPeer{5000}, &testPeer{5000}
+
+	checkRequest(t, f, p1, 0, 0, 1, 500)
+	checkRequest(t, f, p1, 0, 0, 501, 500)
+	checkNoRequest(t, f, p1, 0, 0)
+	require.False(t, receive(f, p1, 501, 600))
+
+	f.RemovePeer(p1)
+	checkRequest(t, f, p2, 0, 0, 1, 500)
+	checkRequest(t, f, p2, 0, 0, 601, 400)
+}
+
+func TestFetcherLostBlocks(t *testing.T) {
+	f, c := newTestFetcher(FetcherConfig{Timeout: time.Second})
+	p := &testPeer{5000}
+
+	checkRequest(t, f, p, 0, 0, 1, 500)
+	// Blocks are received, but the first one is invalid and never gets
+	// into the chain.
+	require.True(t, receive(f, p, 1, 500))
+	checkRequest(t, f, p, 0, 0, 501, 500)
+	require.True(t, receive(f, p, 501, 1000))
+	checkRequest(t, f, p, 0, 0, 1001, 500)
+	c.t = c.t.Add(1100 * time.Millisecond)
+	checkRequest(t, f, &testPeer{5000}, 0, 0, 1, 500)
+}
+
+// syncHarness is a set of local peers serving blocks to the node.
+type syncHarness struct {
+	chain   *fakechain.FakeChain
+	queue   *Queue
+	fetcher *Fetcher
+	blocks  []*block.Block
+	peers   []*harnessPeer
+}
+
+type harnessPeer struct {
+	testPeer
+	// latency is the delay before serving each request.
+	latency time.Duration
+	// stalled peers never reply.
+	stalled bool
+	// truncate makes the peer reply with a part of the requested range
+	// sometimes.
+	truncate bool
+}
+
+func newSyncHarness(height uint32, cfg FetcherConfig, peers ...*harnessPeer) *syncHarness {
+	h := &syncHarness{
+		chain:   fakechain.NewFakeChain(),
+		blocks:  make([]*block.Block, height+1),
+		fetcher: NewFetcher(cfg),
+		peers:   peers,
+	}
+	h.queue = New(h.chain, zap.NewNop(), nil, cfg.Window, nil)
+	for i := range h.blocks {
+		h.blocks[i] = &block.Block{Header: block.Header{Index: uint32(i)}}
+	}
+	for _, p := range peers {
+		p.height = height
+	}
+	return h
+}
+
+// run synchronizes the chain up to the height of peers.
+func (h *syncHarness) run(tb testing.TB, timeout time.Duration) {
+	var (
+		target = uint32(len(h.blocks) - 1)
+		wg     sync.WaitGroup
+		done   = make(chan struct{})
+	)
+	go h.queue.Run()
+	defer h.queue.Discard()
+	for i, p := range h.peers {
+		wg.Add(1)
+		go func(p *harnessPeer, seed int64) {
+			defer wg.Done()
+			var rnd = rand.New(rand.NewSource(seed))
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				lq, capLeft := h.queue.LastQueued()
+				var (
+					r  Range
+					ok bool
+				)
+				if capLeft != 0 {
+					r, ok = h.fetcher.Request(p, h.chain.BlockHeight(), lq)
+				}
+				if !ok || p.stalled {
+					time.Sleep(100 * time.Microsecond)
+					continue
+				}
+				time.Sleep(p.latency)
+				var count = r.Count
+				if p.truncate && rnd.Intn(4) == 0 {
+					count = uint32(rnd.Intn(int(count))) + 1
+				}
+				for i := r.Start; i < r.Start+count; i++ {
+					if err := h.queue.PutBlock(h.blocks[i]); err != nil {
+						tb.Error(err)
+						return
+					}
+					h.fetcher.Received(p, i)
+				}
+			}
+		}(p, int64(i))
+	}
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+	var deadline = time.Now().Add(timeout)
+	for h.chain.BlockHeight() < target {
+		if time.Now().After(deadline) {
+			tb.Fatalf("sync timeout: height %d, target %d", h.chain.BlockHeight(), target)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFetcherSyncStalledPeer(t *testing.T) {
+	cfg := FetcherConfig{Window: 400, RangeSize: 50, Timeout: 50 * time.Millisecond}
+	h := newSyncHarness(2000, cfg,
+		&harnessPeer{stalled: true},
+		&harnessPeer{latency: time.Millisecond, truncate: true},
+		&harnessPeer{stalled: true},
+		&harnessPeer{latency: 2 * time.Millisecond},
+	)
+	h.run(t, 20*time.Second)
+}
+
+func BenchmarkFetcherSync(b *testing.B) {
+	const height = 10000
+	for _, n := range []int{1, 2, 4, 8} {
+		b.Run(fmt.Sprintf("peers=%d", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				peers := make([]*harnessPeer, n)
+				for j := range peers {
+					peers[j] = &harnessPeer{latency: 5 * time.Millisecond}
+				}
+				h := newSyncHarness(height, FetcherConfig{RangeSize: 100, Timeout: time.Second}, peers...)
+				b.StartTimer()
+				h.run(b, time.Minute)
+			}
+		})
+	}
+}
@@ -26,26 +26,32 @@ type Queue struct {
 	relayF      func(*block.Block)
 	discarded   atomic.Bool
 	len         int
+	size        int
 	lenUpdateF  func(int)
 }
 
-// CacheSize is the amount of blocks above the current height
+// CacheSize is the default amount of blocks above the current height
 // which are stored in the queue.
 const CacheSize = 2000
 
-func indexToPosition(i uint32) int {
-	return int(i) % CacheSize
+func (bq *Queue) indexToPosition(i uint32) int {
+	return int(i) % bq.size
 }
 
-// New creates an instance of BlockQueue.
-func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), lenMetricsUpdater func(l int)) *Queue {
+// New creates an instance of BlockQueue storing up to size blocks above the
+// current height (CacheSize is used if it's not positive).
+func New(bc Blockqueuer, log *zap.Logger, relayer func(*block.Block), size int, lenMetricsUpdater func(l int)) *Queue {
 	if log == nil {
 		return nil
 	}
+	if size <= 0 {
+		size = CacheSize
+	}
 
 	return &Queue{
 		log:         log,
-		queue:       make([]*block.Block, CacheSize),
+		queue:       make([]*block.Block, size),
+		size:        size,
 		checkBlocks: make(chan struct{}, 1),
 		chain:       bc,
 		relayF:      relayer,
@@ -63,12 +69,12 @@ func (bq *Queue) Run() {
 		}
 		for {
 			h := bq.chain.BlockHeight()
-			pos := indexToPosition(h + 1)
+			pos := bq.indexToPosition(h + 1)
 			bq.queueLock.Lock()
 			b := bq.queue[pos]
 			// The chain moved forward using blocks from other sources (consensus).
 			for i := lastHeight; i < h; i++ {
-				old := indexToPosition(i + 1)
+				old := bq.indexToPosition(i + 1)
 				if bq.queue[old] != nil && bq.queue[old].Index == i {
 					bq.len--
 					bq.queue[old] = nil
@@ -114,17 +120,17 @@ func (bq *Queue) PutBlock(block *block.Block) error {
 	if bq.discarded.Load() {
 		return nil
 	}
-	if block.Index <= h || h+CacheSize < block.Index {
+	if block.Index <= h || h+uint32(bq.size) < block.Index {
 		// can easily happen when fetching the same blocks from
 		// different peers, thus not considered as error
 		return nil
 	}
-	pos := indexToPosition(block.Index)
+	pos := bq.indexToPosition(block.Index)
 	// If we already have it, keep the old block, throw away the new one.
 	if bq.queue[pos] == nil || bq.queue[pos].Index < block.Index {
 		bq.len++
 		bq.queue[pos] = block
-		for pos < CacheSize && bq.queue[pos] != nil && bq.lastQ+1 == bq.queue[pos].Index {
+		for pos < bq.size && bq.queue[pos] != nil && bq.lastQ+1 == bq.queue[pos].Index {
 			bq.lastQ = bq.queue[pos].Index
 			pos++
 		}
@@ -147,7 +153,7 @@ func (bq *Queue) PutBlock(block *block.Block) error {
 func (bq *Queue) LastQueued() (uint32, int) {
 	bq.queueLock.RLock()
 	defer bq.queueLock.RUnlock()
-	return bq.lastQ, CacheSize - bq.len
+	return bq.lastQ, bq.size - bq.len
 }
 
 // Discard stops the queue and prevents it from accepting more blocks to enqueue.
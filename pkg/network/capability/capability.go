@@ -9,6 +9,9 @@ import (
 // MaxCapabilities is the maximum number of capabilities per payload.
 const MaxCapabilities = 32
 
+// MaxUnknownDataSize is the maximum size of unknown capability data.
+const MaxUnknownDataSize = 1024
+
 // Capabilities is a list of Capability.
 type Capabilities []Capability
 
@@ -26,7 +29,7 @@ func (cs *Capabilities) EncodeBinary(br *io.BinWriter) {
 // checkUniqueCapabilities checks whether payload capabilities have a unique type.
 func (cs Capabilities) checkUniqueCapabilities() error {
 	err := errors.New("capabilities with the same type are not allowed")
-	var isFullNode, isTCP, isWS bool
+	var isFullNode, isTCP, isWS, isNoTxRelay bool
 	for _, cap := range cs {
 		switch cap.Type {
 		case FullNode:
@@ -44,6 +47,11 @@ func (cs Capabilities) checkUniqueCapabilities() error {
 				return err
 			}
 			isWS = true
+		case NoTxRelay:
+			if isNoTxRelay {
+				return err
+			}
+			isNoTxRelay = true
 		}
 	}
 	return nil
@@ -64,8 +72,9 @@ func (c *Capability) DecodeBinary(br *io.BinReader) {
 	case TCPServer, WSServer:
 		c.Data = &Server{}
 	default:
-		br.Err = errors.New("unknown node capability type")
-		return
+		// NoTxRelay and types unknown to us (that are probably
+		// supported by newer nodes).
+		c.Data = &Unknown{}
 	}
 	c.Data.DecodeBinary(br)
 }
@@ -110,3 +119,17 @@ func (s *Server) DecodeBinary(br *io.BinReader) {
 func (s *Server) EncodeBinary(bw *io.BinWriter) {
 	bw.WriteU16LE(s.Port)
 }
+
+// Unknown represents data of a capability unknown to the node or a capability
+// without any data (it's empty then).
+type Unknown []byte
+
+// DecodeBinary implements io.Serializable.
+func (u *Unknown) DecodeBinary(br *io.BinReader) {
+	*u = br.ReadVarBytes(MaxUnknownDataSize)
+}
+
+// EncodeBinary implements io.Serializable.
+func (u *Unknown) EncodeBinary(bw *io.BinWriter) {
+	bw.WriteVarBytes(*u)
+}
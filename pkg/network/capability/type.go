@@ -10,4 +10,9 @@ const (
 	WSServer Type = 0x02
 	// FullNode represents full node capability type.
 	FullNode Type = 0x10
+	// NoTxRelay represents the capability of a node that doesn't relay
+	// transactions and doesn't want to receive their inventories. It's a
+	// NeoGo extension using the range reserved for them (0xf0-0xff), its
+	// data is always empty.
+	NoTxRelay Type = 0xf0
 )
@@ -1,8 +1,10 @@
 package network
 
 import (
+	"context"
 	"math"
 	"math/rand"
+	"net"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -37,6 +39,51 @@ type Discoverer interface {
 	GoodPeers() []AddressWithCapabilities
 }
 
+// DiscoveryConfig is a DefaultDiscovery configuration.
+type DiscoveryConfig struct {
+	// Seeds is a list of seed node addresses used if the address pool is
+	// empty.
+	Seeds []string
+	// DNSSeeds is a list of "host:port" seeds, host names are resolved
+	// periodically and resolved addresses are added to the pool.
+	DNSSeeds []string
+	// DNSSeedRefreshInterval is the DNS seed resolution interval used if
+	// resolver doesn't provide TTL.
+	DNSSeedRefreshInterval time.Duration
+	// PinnedPeers is a list of peers the node always keeps connection to,
+	// they're never considered bad.
+	PinnedPeers []string
+	// DialTimeout is the maximum duration a single dial may take.
+	DialTimeout time.Duration
+	// Resolver is used for DNS seeds, the system one is used if it's nil.
+	Resolver Resolver
+}
+
+// addrSource is the source an address was obtained from.
+type addrSource byte
+
+const (
+	// sourcePeers is used for addresses received from other peers.
+	sourcePeers addrSource = iota
+	sourceSeeds
+	sourceDNS
+	sourcePinned
+)
+
+// String implements the fmt.Stringer interface.
+func (s addrSource) String() string {
+	switch s {
+	case sourceSeeds:
+		return "seeds"
+	case sourceDNS:
+		return "dns"
+	case sourcePinned:
+		return "pinned"
+	default:
+		return "peers"
+	}
+}
+
 // AddressWithCapabilities represents a node address with its capabilities.
 type AddressWithCapabilities struct {
 	Address      string
@@ -45,7 +92,14 @@ type AddressWithCapabilities struct {
 
 // DefaultDiscovery default implementation of the Discoverer interface.
 type DefaultDiscovery struct {
+	// seeds and pinned map static addresses to the addresses of connected
+	// peers (empty if not connected).
 	seeds            map[string]string
+	pinned           map[string]string
+	dnsSeeds         []*dnsSeed
+	dnsRefresh       time.Duration
+	resolver         Resolver
+	sources          map[string]addrSource
 	transport        Transporter
 	lock             sync.RWMutex
 	dialTimeout      time.Duration
@@ -61,16 +115,38 @@ type DefaultDiscovery struct {
 	requestCh        chan int
 }
 
-// NewDefaultDiscovery returns a new DefaultDiscovery.
+// NewDefaultDiscovery returns a new DefaultDiscovery using the given seeds.
 func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *DefaultDiscovery {
-	var seeds = make(map[string]string)
-	for i := range addrs {
-		seeds[addrs[i]] = ""
+	return NewDiscovery(DiscoveryConfig{Seeds: addrs, DialTimeout: dt}, ts)
+}
+
+// NewDiscovery returns a new DefaultDiscovery with the given configuration.
+func NewDiscovery(cfg DiscoveryConfig, ts Transporter) *DefaultDiscovery {
+	var (
+		seeds  = make(map[string]string)
+		pinned = make(map[string]string)
+	)
+	for i := range cfg.Seeds {
+		seeds[cfg.Seeds[i]] = ""
+	}
+	for i := range cfg.PinnedPeers {
+		pinned[cfg.PinnedPeers[i]] = ""
+	}
+	if cfg.DNSSeedRefreshInterval <= 0 {
+		cfg.DNSSeedRefreshInterval = defaultDNSSeedRefreshInterval
+	}
+	if cfg.Resolver == nil {
+		cfg.Resolver = netResolver{}
 	}
 	d := &DefaultDiscovery{
 		seeds:            seeds,
+		pinned:           pinned,
+		dnsSeeds:         newDNSSeeds(cfg.DNSSeeds),
+		dnsRefresh:       cfg.DNSSeedRefreshInterval,
+		resolver:         cfg.Resolver,
+		sources:          make(map[string]addrSource),
 		transport:        ts,
-		dialTimeout:      dt,
+		dialTimeout:      cfg.DialTimeout,
 		badAddrs:         make(map[string]bool),
 		connectedAddrs:   make(map[string]bool),
 		handshakedAddrs:  make(map[string]bool),
@@ -82,8 +158,8 @@ func NewDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) *Defa
 	return d
 }
 
-func newDefaultDiscovery(addrs []string, dt time.Duration, ts Transporter) Discoverer {
-	return NewDefaultDiscovery(addrs, dt, ts)
+func newDefaultDiscovery(cfg DiscoveryConfig, ts Transporter) Discoverer {
+	return NewDiscovery(cfg, ts)
 }
 
 // BackFill implements the Discoverer interface and will backfill
@@ -95,16 +171,41 @@ func (d *DefaultDiscovery) BackFill(addrs ...string) {
 }
 
 func (d *DefaultDiscovery) backfill(addrs ...string) {
+	d.backfillFrom(sourcePeers, addrs...)
+}
+
+// backfillFrom adds the addresses obtained from the given source to the pool.
+func (d *DefaultDiscovery) backfillFrom(src addrSource, addrs ...string) {
 	for _, addr := range addrs {
 		if d.badAddrs[addr] || d.connectedAddrs[addr] || d.handshakedAddrs[addr] ||
-			d.unconnectedAddrs[addr] > 0 {
+			d.unconnectedAddrs[addr] > 0 || d.isPinned(addr) {
 			continue
 		}
-		d.pushToPoolOrDrop(addr)
+		if d.pushToPoolOrDrop(addr) && src != sourcePeers {
+			d.sources[addr] = src
+		}
 	}
 	d.updateNetSize()
 }
 
+// isPinned returns true if the address is a pinned one, such addresses are
+// dialed directly and not stored in the pool.
+func (d *DefaultDiscovery) isPinned(addr string) bool {
+	_, ok := d.pinned[addr]
+	return ok
+}
+
+// source returns the source of the address.
+func (d *DefaultDiscovery) source(addr string) addrSource {
+	if _, ok := d.pinned[addr]; ok {
+		return sourcePinned
+	}
+	if _, ok := d.seeds[addr]; ok {
+		return sourceSeeds
+	}
+	return d.sources[addr]
+}
+
 // PoolCount returns the number of the available node addresses.
 func (d *DefaultDiscovery) PoolCount() int {
 	d.lock.RLock()
@@ -117,15 +218,30 @@ func (d *DefaultDiscovery) poolCount() int {
 }
 
 // pushToPoolOrDrop tries to push the address given into the pool, but if the pool
-// is already full, it just drops it.
-func (d *DefaultDiscovery) pushToPoolOrDrop(addr string) {
+// is already full, it just drops it. It returns true if the address is added.
+func (d *DefaultDiscovery) pushToPoolOrDrop(addr string) bool {
 	if len(d.unconnectedAddrs) < maxPoolSize {
 		d.unconnectedAddrs[addr] = connRetries
+		return true
 	}
+	return false
 }
 
-// RequestRemote tries to establish a connection with n nodes.
+// RequestRemote tries to establish a connection with n nodes. Pinned peers
+// are always connected to (if they're not yet) irrespective of n and DNS
+// seeds are resolved if their refresh time has come.
 func (d *DefaultDiscovery) RequestRemote(requested int) {
+	d.lock.Lock()
+	d.refreshDNSSeeds()
+	for addr, peer := range d.pinned {
+		if peer == "" && !d.attempted[addr] {
+			d.attempted[addr] = true
+			atomic.AddInt32(&d.outstanding, 1)
+			go d.tryAddress(addr)
+		}
+	}
+	d.lock.Unlock()
+
 	outstanding := int(atomic.LoadInt32(&d.outstanding))
 	requested -= outstanding
 	for ; requested > 0; requested-- {
@@ -172,7 +288,14 @@ func (d *DefaultDiscovery) RegisterSelf(p AddressablePeer) {
 
 func (d *DefaultDiscovery) registerBad(addr string, force bool) {
 	_, isSeed := d.seeds[addr]
-	if isSeed {
+	_, isPinned := d.pinned[addr]
+	if isPinned {
+		if !force {
+			d.pinned[addr] = "" // Pinned peers are never banned.
+		} else {
+			d.pinned[addr] = "forever"
+		}
+	} else if isSeed {
 		if !force {
 			d.seeds[addr] = ""
 		} else {
@@ -184,6 +307,7 @@ func (d *DefaultDiscovery) registerBad(addr string, force bool) {
 			d.badAddrs[addr] = true
 			delete(d.unconnectedAddrs, addr)
 			delete(d.goodAddrs, addr)
+			delete(d.sources, addr)
 		}
 	}
 	d.updateNetSize()
@@ -244,6 +368,7 @@ func (d *DefaultDiscovery) UnregisterConnected(p AddressablePeer, duplicate bool
 		peeraddr = p.PeerAddr().String()
 		connaddr = p.ConnectionAddr()
 	)
+	var pinnedLost bool
 	d.lock.Lock()
 	delete(d.connectedAddrs, connaddr)
 	if !duplicate {
@@ -253,12 +378,22 @@ func (d *DefaultDiscovery) UnregisterConnected(p AddressablePeer, duplicate bool
 				break
 			}
 		}
+		for addr, ip := range d.pinned {
+			if ip == peeraddr {
+				d.pinned[addr] = ""
+				pinnedLost = true
+				break
+			}
+		}
 		delete(d.handshakedAddrs, peeraddr)
 		if _, ok := d.goodAddrs[peeraddr]; ok {
 			d.backfill(peeraddr)
 		}
 	}
 	d.lock.Unlock()
+	if pinnedLost {
+		d.RequestRemote(0) // Reconnect to it.
+	}
 }
 
 // RegisterConnected tells discoverer that the given peer is now connected.
@@ -271,6 +406,7 @@ func (d *DefaultDiscovery) RegisterConnected(p AddressablePeer) {
 
 func (d *DefaultDiscovery) registerConnected(addr string) {
 	delete(d.unconnectedAddrs, addr)
+	delete(d.sources, addr)
 	d.connectedAddrs[addr] = true
 	d.updateNetSize()
 }
@@ -306,10 +442,14 @@ func (d *DefaultDiscovery) tryAddress(addr string) {
 	atomic.AddInt32(&d.outstanding, -1)
 	d.lock.Lock()
 	delete(d.attempted, addr)
+	updateDialMetric(d.source(addr).String(), err == nil)
 	if err == nil {
 		if _, ok := d.seeds[addr]; ok {
 			d.seeds[addr] = p.PeerAddr().String()
 		}
+		if _, ok := d.pinned[addr]; ok {
+			d.pinned[addr] = p.PeerAddr().String()
+		}
 		d.registerConnected(addr)
 	} else {
 		d.registerBad(addr, false)
@@ -320,3 +460,38 @@ func (d *DefaultDiscovery) tryAddress(addr string) {
 		d.RequestRemote(1)
 	}
 }
+
+// refreshDNSSeeds starts resolution of DNS seeds that need to be refreshed,
+// it must be called under write lock.
+func (d *DefaultDiscovery) refreshDNSSeeds() {
+	var now = time.Now()
+	for _, s := range d.dnsSeeds {
+		if !s.resolving && !now.Before(s.next) {
+			s.resolving = true
+			go d.resolveDNSSeed(s)
+		}
+	}
+}
+
+// resolveDNSSeed resolves the DNS seed and adds resolved addresses to the
+// pool. The next resolution is scheduled according to the records TTL.
+func (d *DefaultDiscovery) resolveDNSSeed(s *dnsSeed) {
+	ctx, cancel := context.WithTimeout(context.Background(), dnsSeedResolveTimeout)
+	ips, ttl, err := d.resolver.LookupHost(ctx, s.host)
+	cancel()
+	var delay time.Duration
+	if err != nil {
+		delay = dnsSeedRefreshDelay(minDNSSeedRefreshInterval, d.dnsRefresh)
+	} else {
+		delay = dnsSeedRefreshDelay(ttl, d.dnsRefresh)
+	}
+	addrs := make([]string, 0, len(ips))
+	for _, ip := range ips {
+		addrs = append(addrs, net.JoinHostPort(ip, s.port))
+	}
+	d.lock.Lock()
+	s.resolving = false
+	s.next = time.Now().Add(delay)
+	d.backfillFrom(sourceDNS, addrs...)
+	d.lock.Unlock()
+}
@@ -1,9 +1,11 @@
 package network
 
 import (
+	"context"
 	"errors"
 	"net"
 	"sort"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -19,6 +21,7 @@ type fakeTransp struct {
 	started  atomic.Bool
 	closed   atomic.Bool
 	dialCh   chan string
+	lock     sync.RWMutex
 	host     string
 	port     string
 }
@@ -68,14 +71,18 @@ func (ft *fakeTransp) Accept() {
 	if ft.started.Load() {
 		panic("started twice")
 	}
+	ft.lock.Lock()
 	ft.host = "0.0.0.0"
 	ft.port = "42"
+	ft.lock.Unlock()
 	ft.started.Store(true)
 }
 func (ft *fakeTransp) Proto() string {
 	return ""
 }
 func (ft *fakeTransp) HostPort() (string, string) {
+	ft.lock.RLock()
+	defer ft.lock.RUnlock()
 	return ft.host, ft.port
 }
 func (ft *fakeTransp) Close() {
@@ -211,6 +218,132 @@ func TestDefaultDiscoverer(t *testing.T) {
 	require.Equal(t, 0, d.PoolCount())
 }
 
+type stubResolver struct {
+	lookups atomic.Int32
+	addrs   []string
+	ttl     time.Duration
+	err     error
+}
+
+func (r *stubResolver) LookupHost(_ context.Context, _ string) ([]string, time.Duration, error) {
+	r.lookups.Add(1)
+	return r.addrs, r.ttl, r.err
+}
+
+func TestDNSSeedDiscovery(t *testing.T) {
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	r := &stubResolver{addrs: []string{"3.3.3.3", "4.4.4.4"}, ttl: time.Hour}
+	d := NewDiscovery(DiscoveryConfig{
+		DNSSeeds:    []string{"seed.example.com:10333", "invalid"},
+		DialTimeout: time.Second / 16,
+		Resolver:    r,
+	}, ts)
+	require.Equal(t, 1, len(d.dnsSeeds))
+
+	d.RequestRemote(0)
+	require.Eventually(t, func() bool { return d.PoolCount() == 2 }, 2*time.Second, 10*time.Millisecond)
+	set := d.UnconnectedPeers()
+	sort.Strings(set)
+	require.Equal(t, []string{"3.3.3.3:10333", "4.4.4.4:10333"}, set)
+	d.lock.RLock()
+	require.Equal(t, sourceDNS, d.source("3.3.3.3:10333"))
+	next := d.dnsSeeds[0].next
+	d.lock.RUnlock()
+	require.True(t, time.Until(next) > 59*time.Minute)
+
+	// TTL has not passed yet, so no new lookup is made.
+	d.RequestRemote(0)
+	require.Equal(t, int32(1), r.lookups.Load())
+
+	// Lookup failure is retried after minimal interval.
+	r.err = errors.New("no such host")
+	d.lock.Lock()
+	d.dnsSeeds[0].next = time.Now()
+	d.lock.Unlock()
+	d.RequestRemote(0)
+	require.Eventually(t, func() bool {
+		d.lock.RLock()
+		defer d.lock.RUnlock()
+		return !d.dnsSeeds[0].resolving && r.lookups.Load() == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	d.lock.RLock()
+	next = d.dnsSeeds[0].next
+	d.lock.RUnlock()
+	require.True(t, time.Until(next) <= minDNSSeedRefreshInterval*11/10)
+	require.Equal(t, 2, d.PoolCount())
+}
+
+func TestDNSSeedRefreshDelay(t *testing.T) {
+	for _, tc := range []struct {
+		ttl, dflt, min time.Duration
+	}{
+		{0, time.Hour, time.Hour},
+		{2 * time.Hour, time.Hour, 2 * time.Hour},
+		{time.Second, time.Hour, minDNSSeedRefreshInterval},
+	} {
+		delay := dnsSeedRefreshDelay(tc.ttl, tc.dflt)
+		require.True(t, delay >= tc.min && delay <= tc.min*11/10, "ttl %s: %s", tc.ttl, delay)
+	}
+}
+
+func TestPinnedPeers(t *testing.T) {
+	const pinned = "5.5.5.5:10333"
+	ts := &fakeTransp{}
+	ts.dialCh = make(chan string)
+	d := NewDiscovery(DiscoveryConfig{
+		PinnedPeers: []string{pinned},
+		DialTimeout: time.Second / 16,
+	}, ts)
+	tryMaxWait = 1 // Don't waste time.
+
+	expectDial := func() {
+		select {
+		case a := <-ts.dialCh:
+			require.Equal(t, pinned, a)
+		case <-time.After(time.Second):
+			t.Fatalf("timeout expecting for pinned peer dial")
+		}
+	}
+
+	// Pinned peer is dialed even if no peers are requested.
+	d.RequestRemote(0)
+	expectDial()
+	require.Eventually(t, func() bool {
+		d.lock.RLock()
+		defer d.lock.RUnlock()
+		return d.pinned[pinned] == pinned
+	}, 2*time.Second, 10*time.Millisecond)
+	require.Equal(t, 0, d.PoolCount())
+
+	// Disconnection leads to immediate reconnection.
+	d.UnregisterConnected(&fakeAPeer{addr: pinned, peer: pinned}, false)
+	expectDial()
+
+	// And it's never banned even if it can't be connected to.
+	ts.retFalse.Store(1)
+	d.UnregisterConnected(&fakeAPeer{addr: pinned, peer: pinned}, false)
+	for i := 0; i < connRetries+1; i++ {
+		expectDial()
+	}
+	require.Equal(t, 0, len(d.BadPeers()))
+	d.BackFill(pinned)
+	require.Equal(t, 0, d.PoolCount())
+
+	// Let it connect to stop reconnection attempts.
+	ts.retFalse.Store(0)
+	for done := false; !done; {
+		select {
+		case <-ts.dialCh:
+		case <-time.After(time.Second / 2):
+			done = true
+		}
+	}
+	d.lock.RLock()
+	require.Equal(t, pinned, d.pinned[pinned])
+	d.lock.RUnlock()
+}
+
 func TestSeedDiscovery(t *testing.T) {
 	var seeds = []string{"1.1.1.1:10333", "2.2.2.2:10333"}
 	ts := &fakeTransp{}
@@ -0,0 +1,75 @@
+package network
+
+import (
+	"context"
+	"math/rand"
+	"net"
+	"time"
+)
+
+const (
+	// defaultDNSSeedRefreshInterval is the DNS seed refresh interval used
+	// when resolver doesn't provide TTL and it's not configured.
+	defaultDNSSeedRefreshInterval = 30 * time.Minute
+	// dnsSeedResolveTimeout is the maximum time a single DNS seed resolution
+	// may take.
+	dnsSeedResolveTimeout = 10 * time.Second
+)
+
+var (
+	// minDNSSeedRefreshInterval is the minimum time between DNS seed
+	// resolutions, it protects from too frequent lookups if records have
+	// very small TTL and it's also used for retries after failures.
+	minDNSSeedRefreshInterval = time.Minute
+)
+
+// Resolver resolves DNS seed host names.
+type Resolver interface {
+	// LookupHost returns IP addresses of the given host and the time they
+	// can be cached for (TTL), zero TTL means that it's unknown.
+	LookupHost(ctx context.Context, host string) ([]string, time.Duration, error)
+}
+
+// netResolver is a Resolver using the system resolver, it doesn't provide
+// TTLs.
+type netResolver struct{}
+
+// dnsSeed is a DNS seed with its resolution schedule.
+type dnsSeed struct {
+	host      string
+	port      string
+	next      time.Time
+	resolving bool
+}
+
+// LookupHost implements the Resolver interface.
+func (netResolver) LookupHost(ctx context.Context, host string) ([]string, time.Duration, error) {
+	addrs, err := net.DefaultResolver.LookupHost(ctx, host)
+	return addrs, 0, err
+}
+
+// newDNSSeeds parses "host:port" DNS seeds, invalid ones are skipped.
+func newDNSSeeds(addrs []string) []*dnsSeed {
+	var seeds = make([]*dnsSeed, 0, len(addrs))
+	for _, addr := range addrs {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			continue
+		}
+		seeds = append(seeds, &dnsSeed{host: host, port: port})
+	}
+	return seeds
+}
+
+// dnsSeedRefreshDelay returns the time to wait before the next seed
+// resolution for the given TTL (zero means unknown one) with a random jitter
+// up to 10% added to avoid synchronized lookups.
+func dnsSeedRefreshDelay(ttl, dflt time.Duration) time.Duration {
+	if ttl <= 0 {
+		ttl = dflt
+	}
+	if ttl < minDNSSeedRefreshInterval {
+		ttl = minDNSSeedRefreshInterval
+	}
+	return ttl + time.Duration(rand.Int63n(int64(ttl/10)+1))
+}
@@ -0,0 +1,144 @@
+package network
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// defaultExtensibleSubscriptionSize is the default size of application-level
+// extensible payload subscription channel.
+const defaultExtensibleSubscriptionSize = 64
+
+var (
+	// ErrInvalidExtensibleCategory is returned from RegisterExtensibleCategory
+	// for categories that are not namespace-prefixed.
+	ErrInvalidExtensibleCategory = errors.New("extensible category is not namespace-prefixed")
+	// ErrExtensibleCategoryNotAllowed is returned from
+	// RegisterExtensibleCategory on public networks for categories that are
+	// not opened by the node configuration.
+	ErrExtensibleCategoryNotAllowed = errors.New("extensible category is not allowed by the configuration")
+	// ErrExtensibleCategoryRegistered is returned from
+	// RegisterExtensibleCategory for categories that already have a handler.
+	ErrExtensibleCategoryRegistered = errors.New("extensible category is already registered")
+)
+
+// extensibleCategory is an application-level extensible payload category
+// subscription.
+type extensibleCategory struct {
+	name      string
+	validator func(*payload.Extensible) error
+	log       *zap.Logger
+
+	lock   sync.RWMutex
+	closed bool
+	ch     chan *payload.Extensible
+}
+
+// initExtensibleCategories sets extensible pool sender filters for the
+// categories opened by the configuration.
+func (s *Server) initExtensibleCategories() {
+	for category, senders := range s.ExtensibleCategories {
+		if len(senders) == 0 {
+			continue
+		}
+		allowed := make(map[util.Uint160]struct{}, len(senders))
+		for _, u := range senders {
+			allowed[u] = struct{}{}
+		}
+		s.extensiblePool.SetSenderFilter(category, func(u util.Uint160) bool {
+			_, ok := allowed[u]
+			return ok
+		})
+	}
+}
+
+// RegisterExtensibleCategory registers a handler for application-level
+// extensible payloads of the given category. Category must be
+// namespace-prefixed (see payload.IsNamespacedCategory) and on public
+// networks (MainNet and TestNet) it also must be opened by the
+// P2P.ExtensibleCategories configuration section that also defines the list
+// of senders allowed for this category. Payloads are accepted into the
+// extensible pool (sharing its limits with other payloads) and relayed only
+// if the validator (if not nil) returns no error for them. Accepted payloads
+// are sent to the returned channel of the given size (non-positive size
+// means the default one), payloads are dropped if the channel is full, so
+// it must be read from without delays. The channel is closed by
+// UnregisterExtensibleCategory.
+func (s *Server) RegisterExtensibleCategory(category string, validator func(*payload.Extensible) error, size int) (<-chan *payload.Extensible, error) {
+	if !payload.IsNamespacedCategory(category) {
+		return nil, ErrInvalidExtensibleCategory
+	}
+	if _, ok := s.ExtensibleCategories[category]; !ok && (s.Net == netmode.MainNet || s.Net == netmode.TestNet) {
+		return nil, ErrExtensibleCategoryNotAllowed
+	}
+	if size <= 0 {
+		size = defaultExtensibleSubscriptionSize
+	}
+
+	s.serviceLock.Lock()
+	defer s.serviceLock.Unlock()
+	if _, ok := s.extensHandlers[category]; ok {
+		return nil, ErrExtensibleCategoryRegistered
+	}
+	c := &extensibleCategory{
+		name:      category,
+		validator: validator,
+		log:       s.log,
+		ch:        make(chan *payload.Extensible, size),
+	}
+	s.extensHandlers[category] = c.handle
+	s.extensCategories[category] = c
+	return c.ch, nil
+}
+
+// UnregisterExtensibleCategory drops the handler registered for the given
+// category with RegisterExtensibleCategory and closes its channel. Payloads
+// of this category are still relayed if they're allowed by the
+// configuration (or by the default sender policy on private networks).
+func (s *Server) UnregisterExtensibleCategory(category string) {
+	s.serviceLock.Lock()
+	c, ok := s.extensCategories[category]
+	if ok {
+		delete(s.extensCategories, category)
+		delete(s.extensHandlers, category)
+	}
+	s.serviceLock.Unlock()
+	if ok {
+		c.close()
+	}
+}
+
+// handle validates the payload and sends it to the subscriber.
+func (c *extensibleCategory) handle(e *payload.Extensible) error {
+	if c.validator != nil {
+		if err := c.validator(e); err != nil {
+			return err
+		}
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if c.closed {
+		return nil
+	}
+	select {
+	case c.ch <- e:
+	default:
+		c.log.Warn("extensible payload subscriber is too slow, payload dropped",
+			zap.String("category", c.name),
+			zap.Stringer("hash", e.Hash()))
+	}
+	return nil
+}
+
+// close closes subscription channel.
+func (c *extensibleCategory) close() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.closed = true
+	close(c.ch)
+}
@@ -0,0 +1,147 @@
+package network
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/fakechain"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewServerConfig_ExtensibleCategories(t *testing.T) {
+	sender := util.Uint160{1, 2, 3}
+	newCfg := func(cats ...config.ExtensibleCategory) config.Config {
+		var cfg config.Config
+		cfg.ApplicationConfiguration.P2P.ExtensibleCategories = cats
+		return cfg
+	}
+
+	c, err := NewServerConfig(newCfg())
+	require.NoError(t, err)
+	require.Nil(t, c.ExtensibleCategories)
+
+	c, err = NewServerConfig(newCfg(
+		config.ExtensibleCategory{Category: "app:a"},
+		config.ExtensibleCategory{Category: "app:b", AllowedSenders: []string{address.Uint160ToString(sender)}},
+	))
+	require.NoError(t, err)
+	require.Equal(t, map[string][]util.Uint160{
+		"app:a": nil,
+		"app:b": {sender},
+	}, c.ExtensibleCategories)
+
+	for name, cat := range map[string]config.ExtensibleCategory{
+		"no namespace":   {Category: "orders"},
+		"empty name":     {Category: "app:"},
+		"standard":       {Category: payload.ConsensusCategory},
+		"invalid sender": {Category: "app:a", AllowedSenders: []string{"bad"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewServerConfig(newCfg(cat))
+			require.Error(t, err)
+		})
+	}
+	t.Run("duplicate", func(t *testing.T) {
+		_, err := NewServerConfig(newCfg(config.ExtensibleCategory{Category: "app:a"}, config.ExtensibleCategory{Category: "app:a"}))
+		require.Error(t, err)
+	})
+}
+
+func TestRegisterExtensibleCategory(t *testing.T) {
+	t.Run("public network", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{
+			Net:                  netmode.MainNet,
+			ExtensibleCategories: map[string][]util.Uint160{"app:open": nil},
+		})
+		_, err := s.RegisterExtensibleCategory("app:closed", nil, 0)
+		require.ErrorIs(t, err, ErrExtensibleCategoryNotAllowed)
+		_, err = s.RegisterExtensibleCategory("app:open", nil, 0)
+		require.NoError(t, err)
+	})
+	t.Run("private network", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{Net: netmode.PrivNet})
+		_, err := s.RegisterExtensibleCategory("orders", nil, 0)
+		require.ErrorIs(t, err, ErrInvalidExtensibleCategory)
+		_, err = s.RegisterExtensibleCategory(payload.ConsensusCategory, nil, 0)
+		require.ErrorIs(t, err, ErrInvalidExtensibleCategory)
+		_, err = s.RegisterExtensibleCategory("app:orders", nil, 0)
+		require.NoError(t, err)
+		_, err = s.RegisterExtensibleCategory("app:orders", nil, 0)
+		require.ErrorIs(t, err, ErrExtensibleCategoryRegistered)
+
+		s.UnregisterExtensibleCategory("app:orders")
+		s.UnregisterExtensibleCategory("app:orders")
+		_, err = s.RegisterExtensibleCategory("app:orders", nil, 0)
+		require.NoError(t, err)
+	})
+}
+
+func TestExtensibleCategoryPayloads(t *testing.T) {
+	allowed := util.Uint160{1, 2, 3}
+	s := newTestServer(t, ServerConfig{
+		Net:                  netmode.MainNet,
+		ExtensibleCategories: map[string][]util.Uint160{"app:orders": {allowed}},
+	})
+	errInvalid := errors.New("invalid")
+	ch, err := s.RegisterExtensibleCategory("app:orders", func(e *payload.Extensible) error {
+		if len(e.Data) == 0 {
+			return errInvalid
+		}
+		return nil
+	}, 1)
+	require.NoError(t, err)
+	startWithCleanup(t, s)
+
+	s.chain.(*fakechain.FakeChain).Blockheight.Store(4)
+	s.chain.(*fakechain.FakeChain).VerifyWitnessF = func() (int64, error) { return 0, nil }
+	p := newLocalPeer(t, s)
+	p.handshaked = 1
+	s.register <- p
+	require.Eventually(t, func() bool { return 1 == s.PeerCount() }, time.Second, time.Millisecond*10)
+
+	newMessage := func(sender util.Uint160, data []byte) *Message {
+		pl := payload.NewExtensible()
+		pl.Category = "app:orders"
+		pl.ValidBlockEnd = 10
+		pl.Sender = sender
+		pl.Data = data
+		return NewMessage(CMDExtensible, pl)
+	}
+
+	// Sender is not in the allow-list (even though it's allowed by the chain).
+	msg := newMessage(util.Uint160{4, 5, 6}, []byte{1})
+	require.Error(t, s.handleMessage(p, msg))
+	require.Nil(t, s.extensiblePool.Get(msg.Payload.(*payload.Extensible).Hash()))
+
+	// Rejected by the validator.
+	require.ErrorIs(t, s.handleMessage(p, newMessage(allowed, nil)), errInvalid)
+
+	msg = newMessage(allowed, []byte{1})
+	require.NoError(t, s.handleMessage(p, msg))
+	require.Equal(t, msg.Payload, <-ch)
+	require.NotNil(t, s.extensiblePool.Get(msg.Payload.(*payload.Extensible).Hash()))
+
+	// The same payload is not delivered twice.
+	require.NoError(t, s.handleMessage(p, msg))
+	require.Equal(t, 0, len(ch))
+
+	// Subscriber is too slow, payload is dropped, but still accepted.
+	require.NoError(t, s.handleMessage(p, newMessage(allowed, []byte{2})))
+	require.NoError(t, s.handleMessage(p, newMessage(allowed, []byte{3})))
+	require.Equal(t, 1, len(ch))
+	require.Equal(t, []byte{2}, (<-ch).Data)
+
+	s.UnregisterExtensibleCategory("app:orders")
+	_, ok := <-ch
+	require.False(t, ok)
+	// Still relayed after unregistration.
+	msg = newMessage(allowed, []byte{4})
+	require.NoError(t, s.handleMessage(p, msg))
+	require.NotNil(t, s.extensiblePool.Get(msg.Payload.(*payload.Extensible).Hash()))
+}
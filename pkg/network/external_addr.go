@@ -0,0 +1,145 @@
+package network
+
+import (
+	"sync"
+)
+
+const (
+	// defaultExternalAddrVotes is the default minimum number of distinct
+	// peers that must report an address for it to be elected.
+	defaultExternalAddrVotes = 3
+	// maxExternalAddrCandidates limits the number of unconfirmed addresses
+	// tracked, the one with the least number of reporters is evicted when
+	// it's exceeded.
+	maxExternalAddrCandidates = 1024
+	// maxExternalAddrReporters limits the number of reporters tracked for a
+	// single unconfirmed address and the total number of votes.
+	maxExternalAddrReporters = 64
+	maxExternalAddrVotes     = 1024
+)
+
+// extAddrVoter elects the external address of the node from observations
+// made by other peers. Neo P2P protocol doesn't have any field for the
+// address the remote side sees, but peers put the address they observe for
+// us (remote IP with our TCPServer port) into their addr messages. Which of
+// these addresses belong to us is only known after we try connecting to them
+// and get our own nonce in the version handshake, so reports are kept for
+// candidate addresses until they're confirmed (or evicted). Each reporter
+// has a single vote for the latest confirmed address it has reported and the
+// address having most votes (but not less than minVotes) wins.
+type extAddrVoter struct {
+	lock     sync.Mutex
+	minVotes int
+	// self is a set of addresses confirmed to be ours.
+	self map[string]bool
+	// candidates maps unconfirmed addresses to their reporters.
+	candidates map[string]map[string]bool
+	// votes maps reporters to confirmed addresses.
+	votes   map[string]string
+	elected string
+}
+
+func newExtAddrVoter(minVotes int) *extAddrVoter {
+	if minVotes <= 0 {
+		minVotes = defaultExternalAddrVotes
+	}
+	return &extAddrVoter{
+		minVotes:   minVotes,
+		self:       make(map[string]bool),
+		candidates: make(map[string]map[string]bool),
+		votes:      make(map[string]string),
+	}
+}
+
+// Observe registers addresses reported by the given peer. It returns the
+// elected address and whether it has changed.
+func (v *extAddrVoter) Observe(reporter string, addrs []string) (string, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for _, addr := range addrs {
+		if v.self[addr] {
+			v.vote(reporter, addr)
+			continue
+		}
+		rs, ok := v.candidates[addr]
+		if !ok {
+			if len(v.candidates) >= maxExternalAddrCandidates {
+				v.evictCandidate()
+			}
+			rs = make(map[string]bool)
+			v.candidates[addr] = rs
+		}
+		if len(rs) < maxExternalAddrReporters {
+			rs[reporter] = true
+		}
+	}
+	return v.elect()
+}
+
+// Confirm marks the address as belonging to the node (the one we've connected
+// to ourselves via). It returns the elected address and whether it has
+// changed.
+func (v *extAddrVoter) Confirm(addr string) (string, bool) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.self[addr] = true
+	for r := range v.candidates[addr] {
+		v.vote(r, addr)
+	}
+	delete(v.candidates, addr)
+	return v.elect()
+}
+
+// Elected returns the currently elected address (empty if there is none).
+func (v *extAddrVoter) Elected() string {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	return v.elected
+}
+
+func (v *extAddrVoter) evictCandidate() {
+	var (
+		victim string
+		least  = maxExternalAddrReporters + 1
+	)
+	for addr, rs := range v.candidates {
+		if len(rs) < least {
+			victim, least = addr, len(rs)
+		}
+	}
+	delete(v.candidates, victim)
+}
+
+func (v *extAddrVoter) vote(reporter string, addr string) {
+	if _, ok := v.votes[reporter]; !ok && len(v.votes) >= maxExternalAddrVotes {
+		return
+	}
+	v.votes[reporter] = addr
+}
+
+// elect picks the address with the most votes, the current one is kept in
+// case of a tie (otherwise the lowest one is picked to be deterministic).
+func (v *extAddrVoter) elect() (string, bool) {
+	counts := make(map[string]int, len(v.self))
+	for _, addr := range v.votes {
+		counts[addr]++
+	}
+	var (
+		best      string
+		bestVotes = v.minVotes - 1
+	)
+	for addr, n := range counts {
+		switch {
+		case n > bestVotes:
+		case n == bestVotes && best != "" && best != v.elected && (addr == v.elected || addr < best):
+		default:
+			continue
+		}
+		best, bestVotes = addr, n
+	}
+	if best == v.elected {
+		return best, false
+	}
+	v.elected = best
+	return best, true
+}
@@ -0,0 +1,195 @@
+package network
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/network/capability"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtAddrVoter(t *testing.T) {
+	const (
+		ext   = "1.2.3.4:10333"
+		ext2  = "5.6.7.8:10333"
+		local = "192.168.0.2:10333"
+		other = "9.9.9.9:10333"
+	)
+	check := func(t *testing.T, expected string, changed bool) func(string, bool) {
+		return func(actual string, actualChanged bool) {
+			require.Equal(t, expected, actual)
+			require.Equal(t, changed, actualChanged)
+		}
+	}
+
+	t.Run("default votes", func(t *testing.T) {
+		require.Equal(t, defaultExternalAddrVotes, newExtAddrVoter(0).minVotes)
+		require.Equal(t, defaultExternalAddrVotes, newExtAddrVoter(-1).minVotes)
+	})
+	t.Run("unconfirmed", func(t *testing.T) {
+		v := newExtAddrVoter(2)
+		for i := 0; i < 5; i++ {
+			check(t, "", false)(v.Observe("peer"+strconv.Itoa(i), []string{ext, other}))
+		}
+		require.Equal(t, "", v.Elected())
+	})
+	t.Run("conflicting observations", func(t *testing.T) {
+		v := newExtAddrVoter(2)
+		check(t, "", false)(v.Observe("p1", []string{ext, other}))
+		check(t, "", false)(v.Observe("p2", []string{local}))
+		check(t, "", false)(v.Observe("p3", []string{ext}))
+		check(t, "", false)(v.Observe("p4", []string{local}))
+		check(t, "", false)(v.Observe("p5", []string{ext}))
+
+		// Confirmation brings all previous reports for the address.
+		check(t, ext, true)(v.Confirm(ext))
+		check(t, ext, false)(v.Confirm(local))
+		require.Equal(t, ext, v.Elected())
+
+		// Reporters change their opinion, the majority changes.
+		check(t, ext, false)(v.Observe("p6", []string{local}))
+		check(t, local, true)(v.Observe("p1", []string{local}))
+		check(t, local, false)(v.Observe("p1", []string{local}))
+		check(t, local, false)(v.Observe("p2", []string{ext}))
+		check(t, ext, true)(v.Observe("p4", []string{ext}))
+	})
+	t.Run("min votes", func(t *testing.T) {
+		v := newExtAddrVoter(3)
+		check(t, "", false)(v.Confirm(ext))
+		check(t, "", false)(v.Observe("p1", []string{ext}))
+		check(t, "", false)(v.Observe("p2", []string{ext}))
+		check(t, ext, true)(v.Observe("p3", []string{ext}))
+
+		// Losing votes below the threshold resets the election.
+		check(t, ext, false)(v.Confirm(ext2))
+		check(t, "", true)(v.Observe("p3", []string{ext2}))
+	})
+	t.Run("tie", func(t *testing.T) {
+		v := newExtAddrVoter(1)
+		v.Confirm(ext)
+		v.Confirm(ext2)
+		check(t, ext2, true)(v.Observe("p1", []string{ext2}))
+		// The current address is kept in case of a tie.
+		check(t, ext2, false)(v.Observe("p2", []string{ext}))
+		check(t, ext, true)(v.Observe("p3", []string{ext}))
+	})
+	t.Run("candidate eviction", func(t *testing.T) {
+		v := newExtAddrVoter(2)
+		v.Observe("p1", []string{ext})
+		v.Observe("p2", []string{ext})
+		for i := 0; i < maxExternalAddrCandidates; i++ {
+			v.Observe("p3", []string{"10.0.0." + strconv.Itoa(i) + ":10333"})
+		}
+		require.Equal(t, maxExternalAddrCandidates, len(v.candidates))
+		check(t, ext, true)(v.Confirm(ext))
+	})
+}
+
+func TestNewServerConfig_ExternalAddress(t *testing.T) {
+	newCfg := func(addr string) config.Config {
+		var cfg config.Config
+		cfg.ApplicationConfiguration.P2P.ExternalAddress = addr
+		return cfg
+	}
+	for _, addr := range []string{"1.2.3.4:10333", "[::1]:10333"} {
+		c, err := NewServerConfig(newCfg(addr))
+		require.NoError(t, err)
+		require.Equal(t, addr, c.ExternalAddress)
+	}
+	for _, addr := range []string{"1.2.3.4", "1.2.3.4:100000", "example.com:10333"} {
+		_, err := NewServerConfig(newCfg(addr))
+		require.Error(t, err, addr)
+	}
+}
+
+func TestServerExternalAddress(t *testing.T) {
+	getAddr := func(t *testing.T, s *Server) []*payload.AddressAndTime {
+		var res []*payload.AddressAndTime
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			require.Equal(t, CMDAddr, msg.Command)
+			res = msg.Payload.(*payload.AddressList).Addrs
+		}
+		s.testHandleMessage(t, p, CMDGetAddr, payload.NewNullPayload())
+		return res
+	}
+	newAddr := func(ip string, port uint16) *payload.AddressAndTime {
+		a := &payload.AddressAndTime{
+			Capabilities: capability.Capabilities{{
+				Type: capability.TCPServer,
+				Data: &capability.Server{Port: port},
+			}},
+		}
+		copy(a.IP[:], net.ParseIP(ip).To16())
+		return a
+	}
+
+	t.Run("elected", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{
+			UserAgent:            "/test/",
+			Addresses:            []config.AnnounceableAddress{{Address: ":0", AnnouncedPort: 20333}},
+			ExternalAddressVotes: 2,
+		})
+		startWithCleanup(t, s)
+
+		// Simulated peers report conflicting observations (and other
+		// nodes' addresses).
+		reports := [][]*payload.AddressAndTime{
+			{newAddr("1.2.3.4", 20333), newAddr("8.8.8.8", 10333)},
+			{newAddr("192.168.0.2", 20333)},
+			{newAddr("1.2.3.4", 20333)},
+			{newAddr("8.8.8.8", 20333)},
+		}
+		for i, addrs := range reports {
+			p := newLocalPeer(t, s)
+			p.netaddr.IP = net.IPv4(10, 0, 0, byte(i+1))
+			p.netaddr.Port = 10333
+			p.handshaked = 1
+			p.getAddrSent = 1
+			s.testHandleMessage(t, p, CMDAddr, &payload.AddressList{Addrs: addrs})
+		}
+		require.Equal(t, "", s.ExternalAddress())
+		require.Equal(t, 0, len(getAddr(t, s)))
+
+		// Connections to ourselves confirm addresses, only the one
+		// reported by enough peers is elected.
+		for _, addr := range []string{"192.168.0.2:20333", "1.2.3.4:20333"} {
+			self := newLocalPeer(t, s)
+			tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+			require.NoError(t, err)
+			self.netaddr = *tcpAddr
+			s.register <- self
+			require.Eventually(t, func() bool { return s.HandshakedPeersCount() == 0 && s.PeerCount() == 1 }, time.Second, 10*time.Millisecond)
+			s.unregister <- peerDrop{self, errIdenticalID}
+			require.Eventually(t, func() bool { return s.PeerCount() == 0 }, time.Second, 10*time.Millisecond)
+		}
+		require.Eventually(t, func() bool { return s.ExternalAddress() == "1.2.3.4:20333" }, time.Second, 10*time.Millisecond)
+
+		addrs := getAddr(t, s)
+		require.Equal(t, 1, len(addrs))
+		tcpAddr, err := addrs[0].GetTCPAddress()
+		require.NoError(t, err)
+		require.Equal(t, "1.2.3.4:20333", tcpAddr)
+	})
+	t.Run("configured", func(t *testing.T) {
+		s := newTestServer(t, ServerConfig{
+			UserAgent:       "/test/",
+			Relay:           true,
+			ExternalAddress: "5.6.7.8:10333",
+		})
+		require.Nil(t, s.extAddr)
+		require.Equal(t, "5.6.7.8:10333", s.ExternalAddress())
+
+		addrs := getAddr(t, s)
+		require.Equal(t, 1, len(addrs))
+		tcpAddr, err := addrs[0].GetTCPAddress()
+		require.NoError(t, err)
+		require.Equal(t, "5.6.7.8:10333", tcpAddr)
+		require.Equal(t, 2, len(addrs[0].Capabilities))
+	})
+}
@@ -26,6 +26,9 @@ type Pool struct {
 	// singleCap represents the maximum number of payloads from a single sender.
 	singleCap int
 	chain     Ledger
+	// filters contains category-specific sender filters used instead of
+	// the Ledger-based one.
+	filters map[string]func(util.Uint160) bool
 }
 
 // New returns a new payload pool using the provided chain.
@@ -39,9 +42,34 @@ func New(bc Ledger, capacity int) *Pool {
 		senders:   make(map[util.Uint160]*list.List),
 		singleCap: capacity,
 		chain:     bc,
+		filters:   make(map[string]func(util.Uint160) bool),
 	}
 }
 
+// SetSenderFilter sets a function that decides whether the sender is allowed
+// to send payloads of the given category. It's used instead of the default
+// Ledger.IsExtensibleAllowed check for this category. Passing nil filter
+// restores the default behavior.
+func (p *Pool) SetSenderFilter(category string, filter func(util.Uint160) bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if filter == nil {
+		delete(p.filters, category)
+		return
+	}
+	p.filters[category] = filter
+}
+
+// isAllowed checks whether the payload sender is allowed to send payloads of
+// the payload category. It must be called with at least read lock held.
+func (p *Pool) isAllowed(e *payload.Extensible) bool {
+	if f, ok := p.filters[e.Category]; ok {
+		return f(e.Sender)
+	}
+	return p.chain.IsExtensibleAllowed(e.Sender)
+}
+
 var (
 	errDisallowedSender = errors.New("disallowed sender")
 	errInvalidHeight    = errors.New("invalid height")
@@ -89,7 +117,10 @@ func (p *Pool) verify(e *payload.Extensible) (bool, error) {
 		}
 		return false, errInvalidHeight
 	}
-	if !p.chain.IsExtensibleAllowed(e.Sender) {
+	p.lock.RLock()
+	allowed := p.isAllowed(e)
+	p.lock.RUnlock()
+	if !allowed {
 		return false, errDisallowedSender
 	}
 	return true, nil
@@ -121,7 +152,7 @@ func (p *Pool) RemoveStale(index uint32) {
 			old := elem
 			elem = elem.Next()
 
-			if e.ValidBlockEnd <= index || !p.chain.IsExtensibleAllowed(e.Sender) {
+			if e.ValidBlockEnd <= index || !p.isAllowed(e) {
 				delete(p.verified, h)
 				lst.Remove(old)
 				continue
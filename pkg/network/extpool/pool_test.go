@@ -104,6 +104,31 @@ func TestRemoveStale(t *testing.T) {
 	require.Nil(t, p.Get(eps[3].Hash()))
 }
 
+func TestSenderFilter(t *testing.T) {
+	bc := newTestChain()
+	bc.height = 10
+
+	p := New(bc, 100)
+	p.SetSenderFilter("app:test", func(u util.Uint160) bool { return u[0] == 0x41 })
+
+	// Disallowed by the chain, but allowed by the filter.
+	ep := &payload.Extensible{Category: "app:test", Sender: util.Uint160{0x41}, ValidBlockEnd: 12}
+	p.testAdd(t, true, nil, ep)
+	// Allowed by the chain, but disallowed by the filter.
+	p.testAdd(t, false, errDisallowedSender, &payload.Extensible{Category: "app:test", ValidBlockEnd: 12})
+	// Other categories are not affected.
+	p.testAdd(t, false, errDisallowedSender, &payload.Extensible{Sender: util.Uint160{0x41}, ValidBlockEnd: 12})
+	p.testAdd(t, true, nil, &payload.Extensible{ValidBlockEnd: 12})
+
+	p.RemoveStale(11)
+	require.Equal(t, ep, p.Get(ep.Hash()))
+
+	p.SetSenderFilter("app:test", nil)
+	p.RemoveStale(11)
+	require.Nil(t, p.Get(ep.Hash()))
+	p.testAdd(t, true, nil, &payload.Extensible{Category: "app:test", ValidBlockEnd: 12})
+}
+
 func (p *Pool) testAdd(t *testing.T, expectedOk bool, expectedErr error, ep *payload.Extensible) {
 	ok, err := p.Add(ep)
 	if expectedErr != nil {
@@ -8,7 +8,6 @@ import (
 	"sync"
 	"sync/atomic"
 	"testing"
-	"time"
 
 	"github.com/nspcc-dev/neo-go/internal/fakechain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
@@ -26,7 +25,7 @@ type testDiscovery struct {
 	backfill     []string
 }
 
-func newTestDiscovery([]string, time.Duration, Transporter) Discoverer { return new(testDiscovery) }
+func newTestDiscovery(DiscoveryConfig, Transporter) Discoverer { return new(testDiscovery) }
 
 func (d *testDiscovery) BackFill(addrs ...string) {
 	d.Lock()
@@ -195,6 +194,10 @@ func (p *localPeer) IsFullNode() bool {
 	return p.isFullNode
 }
 
+func (p *localPeer) Stats() PeerStats {
+	return PeerStats{Address: p.netaddr.String(), Commands: make(map[CommandType]CommandStats)}
+}
+
 func (p *localPeer) AddGetAddrSent() {
 	p.getAddrSent++
 }
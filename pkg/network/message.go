@@ -159,7 +159,7 @@ func (m *Message) decodePayload() error {
 	case CMDHeaders:
 		p = &payload.Headers{StateRootInHeader: m.StateRootInHeader}
 	case CMDTX:
-		p, err := transaction.NewTransactionFromBytes(buf)
+		p, err := transaction.NewTransactionFromBytesStrict(buf)
 		if err != nil {
 			return err
 		}
@@ -174,8 +174,19 @@ func (m *Message) decodePayload() error {
 	default:
 		return fmt.Errorf("can't decode command %s", m.Command.String())
 	}
-	r := io.NewBinReaderFromBuf(buf)
+	var r *io.BinReader
+	switch m.Command {
+	case CMDBlock, CMDHeaders, CMDMerkleBlock:
+		// Blocks are decoded the same way the C# node does it, otherwise
+		// a block accepted by it could be rejected here.
+		r = io.NewBinReaderFromBuf(buf)
+	default:
+		r = io.NewStrictBinReaderFromBuf(buf)
+	}
 	p.DecodeBinary(r)
+	if r.Err == nil && r.IsStrict() && r.Len() != 0 {
+		return fmt.Errorf("%s: %w", m.Command, io.ErrTrailingData)
+	}
 	if r.Err == nil || errors.Is(r.Err, payload.ErrTooManyHeaders) {
 		m.Payload = p
 	}
@@ -203,6 +214,12 @@ func (m *Message) Encode(br *io.BinWriter) error {
 	return br.Err
 }
 
+// size returns the size of the serialized message, it's only valid after
+// Encode or Decode.
+func (m *Message) size() int {
+	return 2 + io.GetVarSize(len(m.compressedPayload)) + len(m.compressedPayload)
+}
+
 // Bytes serializes a Message into the new allocated buffer and returns it.
 func (m *Message) Bytes() ([]byte, error) {
 	w := io.NewBufBinWriter()
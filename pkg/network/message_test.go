@@ -321,6 +321,38 @@ func TestInvalidMessages(t *testing.T) {
 		data = data[:len(data)-1]
 		require.Error(t, testserdes.Decode(data, &Message{}))
 	})
+	encodeRaw := func(cmd CommandType, p []byte) []byte {
+		w := io.NewBufBinWriter()
+		w.WriteB(byte(None))
+		w.WriteB(byte(cmd))
+		w.WriteVarBytes(p)
+		require.NoError(t, w.Err)
+		return w.Bytes()
+	}
+	t.Run("trailing data", func(t *testing.T) {
+		for cmd, p := range map[CommandType]io.Serializable{
+			CMDTX:   newDummyTx(),
+			CMDPing: payload.NewPing(1, 2),
+		} {
+			data, err := testserdes.EncodeBinary(p)
+			require.NoError(t, err)
+			require.NoError(t, testserdes.Decode(encodeRaw(cmd, data), &Message{}))
+			require.Error(t, testserdes.Decode(encodeRaw(cmd, append(data, 0)), &Message{}), cmd.String())
+		}
+		// Blocks are decoded leniently.
+		data, err := testserdes.EncodeBinary(newDummyBlock(1, 1))
+		require.NoError(t, err)
+		require.NoError(t, testserdes.Decode(encodeRaw(CMDBlock, append(data, 0)), &Message{}))
+	})
+	t.Run("non-canonical payload", func(t *testing.T) {
+		h := random.Uint256()
+		data := append([]byte{byte(payload.TXType), 0xfd, 0x01, 0x00}, h[:]...)
+		err := testserdes.Decode(encodeRaw(CMDInv, data), &Message{})
+		require.ErrorIs(t, err, io.ErrNonCanonical)
+
+		data = append([]byte{byte(payload.TXType), 0x01}, h[:]...)
+		require.NoError(t, testserdes.Decode(encodeRaw(CMDInv, data), &Message{}))
+	})
 }
 
 type failSer bool
@@ -2,6 +2,7 @@ package payload
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
@@ -15,6 +16,10 @@ const maxExtensibleCategorySize = 32
 // payloads.
 const ConsensusCategory = "dBFT"
 
+// CategoryNamespaceSeparator separates namespace from the name in
+// application-level extensible payload categories (like "myapp:orders").
+const CategoryNamespaceSeparator = ":"
+
 // Extensible represents a payload containing arbitrary data.
 type Extensible struct {
 	// Category is the payload type.
@@ -35,6 +40,18 @@ type Extensible struct {
 
 var errInvalidPadding = errors.New("invalid padding")
 
+// IsNamespacedCategory checks whether the given category is a valid
+// application-level category consisting of non-empty namespace and name
+// separated by CategoryNamespaceSeparator. Such categories can't clash with
+// the standard ones.
+func IsNamespacedCategory(category string) bool {
+	if len(category) > maxExtensibleCategorySize {
+		return false
+	}
+	ns, name, ok := strings.Cut(category, CategoryNamespaceSeparator)
+	return ok && len(ns) != 0 && len(name) != 0
+}
+
 // NewExtensible creates a new extensible payload.
 func NewExtensible() *Extensible {
 	return &Extensible{}
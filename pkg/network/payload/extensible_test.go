@@ -2,6 +2,7 @@ package payload
 
 import (
 	gio "io"
+	"strings"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/internal/random"
@@ -65,3 +66,18 @@ func TestExtensible_Hashes(t *testing.T) {
 		require.NotEqual(t, p1.Hash(), p2.Hash())
 	})
 }
+
+func TestIsNamespacedCategory(t *testing.T) {
+	for c, ok := range map[string]bool{
+		"app:orders":      true,
+		"a:b:c":           true,
+		ConsensusCategory: false,
+		"StateService":    false,
+		"":                false,
+		":orders":         false,
+		"app:":            false,
+		"app:" + strings.Repeat("a", maxExtensibleCategorySize-3): false,
+	} {
+		require.Equal(t, ok, IsNamespacedCategory(c), c)
+	}
+}
@@ -46,3 +46,32 @@ func TestVersionEncodeDecode(t *testing.T) {
 	assert.Equal(t, versionDecoded.UserAgent, []byte(useragent))
 	assert.Equal(t, version, versionDecoded)
 }
+
+func TestVersionUnknownCapability(t *testing.T) {
+	var capabilities = []capability.Capability{
+		{
+			Type: capability.TCPServer,
+			Data: &capability.Server{Port: 3000},
+		},
+		{
+			Type: capability.NoTxRelay,
+			Data: &capability.Unknown{},
+		},
+		{
+			Type: 0xfe,
+			Data: &capability.Unknown{1, 2, 3},
+		},
+	}
+	version := NewVersion(netmode.UnitTestNet, 1, "/NEO:0.0.1/", capabilities)
+	versionDecoded := &Version{}
+	testserdes.EncodeDecodeBinary(t, version, versionDecoded)
+	assert.Equal(t, version, versionDecoded)
+
+	version.Capabilities = append(version.Capabilities, capability.Capability{
+		Type: capability.NoTxRelay,
+		Data: &capability.Unknown{},
+	})
+	data, err := testserdes.EncodeBinary(version)
+	assert.NoError(t, err)
+	assert.Error(t, testserdes.DecodeBinary(data, new(Version)))
+}
@@ -92,4 +92,7 @@ type Peer interface {
 	// CanProcessAddr checks whether an addr command is expected to come from
 	// this peer and can be processed.
 	CanProcessAddr() bool
+
+	// Stats returns traffic statistics of the current connection to the peer.
+	Stats() PeerStats
 }
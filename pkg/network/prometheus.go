@@ -1,12 +1,16 @@
 package network
 
 import (
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 )
 
+// topTalkersCount is the number of peers exposed via top talkers metric.
+const topTalkersCount = 10
+
 // Metric used in monitoring service.
 var (
 	estimatedNetworkSize = prometheus.NewGauge(
@@ -68,6 +72,44 @@ var (
 	)
 	p2pCmds = make(map[CommandType]prometheus.Histogram)
 
+	p2pBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "P2P traffic in bytes per command and direction",
+			Name:      "p2p_bytes_total",
+			Namespace: "neogo",
+		},
+		[]string{"command", "direction"},
+	)
+	p2pMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of P2P messages per command and direction",
+			Name:      "p2p_messages_total",
+			Namespace: "neogo",
+		},
+		[]string{"command", "direction"},
+	)
+	// p2pTraffic contains resolved p2pBytes and p2pMessages counters for
+	// every known command, it's read-only after initialization.
+	p2pTraffic = make(map[CommandType]*cmdTrafficMetrics)
+
+	topTalkers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Help:      "Bytes transferred over the current connection for the top peers",
+			Name:      "p2p_top_peer_bytes",
+			Namespace: "neogo",
+		},
+		[]string{"peer", "direction"},
+	)
+
+	discoveryDials = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of outgoing connection attempts per address source and result",
+			Name:      "discovery_dials_total",
+			Namespace: "neogo",
+		},
+		[]string{"source", "result"},
+	)
+
 	// notarypoolUnsortedTx prometheus metric.
 	notarypoolUnsortedTx = prometheus.NewGauge(
 		prometheus.GaugeOpts{
@@ -88,6 +130,10 @@ func init() {
 		poolCount,
 		blockQueueLength,
 		notarypoolUnsortedTx,
+		discoveryDials,
+		p2pBytes,
+		p2pMessages,
+		topTalkers,
 	)
 	for _, cmd := range []CommandType{CMDVersion, CMDVerack, CMDGetAddr,
 		CMDAddr, CMDPing, CMDPong, CMDGetHeaders, CMDHeaders, CMDGetBlocks,
@@ -103,9 +149,24 @@ func init() {
 			},
 		)
 		prometheus.MustRegister(p2pCmds[cmd])
+		var name = CommandName(cmd)
+		p2pTraffic[cmd] = &cmdTrafficMetrics{
+			sentBytes:        p2pBytes.WithLabelValues(name, "sent"),
+			sentMessages:     p2pMessages.WithLabelValues(name, "sent"),
+			receivedBytes:    p2pBytes.WithLabelValues(name, "received"),
+			receivedMessages: p2pMessages.WithLabelValues(name, "received"),
+		}
 	}
 }
 
+// cmdTrafficMetrics is a set of traffic counters for a single command.
+type cmdTrafficMetrics struct {
+	sentBytes        prometheus.Counter
+	sentMessages     prometheus.Counter
+	receivedBytes    prometheus.Counter
+	receivedMessages prometheus.Counter
+}
+
 func updateNetworkSizeMetric(sz int) {
 	estimatedNetworkSize.Set(float64(sz))
 }
@@ -149,3 +210,64 @@ func addCmdTimeMetric(cmd CommandType, t time.Duration) {
 func updateNotarypoolMetrics(unsortedTxnLen int) {
 	notarypoolUnsortedTx.Set(float64(unsortedTxnLen))
 }
+
+// updateDialMetric counts an outgoing connection attempt to the address
+// obtained from the given source.
+func updateDialMetric(source string, success bool) {
+	var result = "failure"
+	if success {
+		result = "success"
+	}
+	discoveryDials.WithLabelValues(source, result).Inc()
+}
+
+// addTrafficMetric accounts for a message of the given size sent or received.
+func addTrafficMetric(cmd CommandType, sent bool, size int) {
+	m := p2pTraffic[cmd]
+	if m == nil {
+		return
+	}
+	if sent {
+		m.sentBytes.Add(float64(size))
+		m.sentMessages.Inc()
+	} else {
+		m.receivedBytes.Add(float64(size))
+		m.receivedMessages.Inc()
+	}
+}
+
+// updateTopTalkersMetric sets traffic gauges for topTalkersCount peers with the
+// highest number of bytes transferred. Gauges are reset every time, so
+// disconnected peers disappear and reconnected ones start from zero.
+func updateTopTalkersMetric(stats []PeerStats) {
+	type talker struct {
+		addr           string
+		sent, received uint64
+	}
+	var (
+		byAddr  = make(map[string]*talker, len(stats))
+		talkers = make([]*talker, 0, len(stats))
+	)
+	for i := range stats {
+		sent, received := stats[i].Total()
+		t := byAddr[stats[i].Address]
+		if t == nil {
+			t = &talker{addr: stats[i].Address}
+			byAddr[t.addr] = t
+			talkers = append(talkers, t)
+		}
+		t.sent += sent
+		t.received += received
+	}
+	sort.Slice(talkers, func(i, j int) bool {
+		return talkers[i].sent+talkers[i].received > talkers[j].sent+talkers[j].received
+	})
+	if len(talkers) > topTalkersCount {
+		talkers = talkers[:topTalkersCount]
+	}
+	topTalkers.Reset()
+	for _, t := range talkers {
+		topTalkers.WithLabelValues(t.addr, "sent").Set(float64(t.sent))
+		topTalkers.WithLabelValues(t.addr, "received").Set(float64(t.received))
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
 	"github.com/nspcc-dev/neo-go/pkg/core/mempoolevent"
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
@@ -98,20 +99,29 @@ type (
 		config config.ProtocolConfiguration
 
 		transports        []Transporter
+		tls               *p2pTLS
 		discovery         Discoverer
 		chain             Ledger
 		bQueue            *bqueue.Queue
 		bSyncQueue        *bqueue.Queue
+		bFetcher          *bqueue.Fetcher
+		bSyncFetcher      *bqueue.Fetcher
 		mempool           *mempool.Pool
 		notaryRequestPool *mempool.Pool
 		extensiblePool    *extpool.Pool
 		notaryFeer        NotaryFeer
+		// extAddr elects the external address of the node, it's nil if
+		// the address is configured explicitly.
+		extAddr *extAddrVoter
 
 		serviceLock    sync.RWMutex
 		services       map[string]Service
 		extensHandlers map[string]func(*payload.Extensible) error
-		txCallback     func(*transaction.Transaction)
-		txCbList       atomic.Value
+		// extensCategories contains application-level extensible payload
+		// categories registered with RegisterExtensibleCategory.
+		extensCategories map[string]*extensibleCategory
+		txCallback       func(*transaction.Transaction)
+		txCbList         atomic.Value
 
 		txInLock sync.RWMutex
 		txin     chan *transaction.Transaction
@@ -119,9 +129,10 @@ type (
 
 		lock  sync.RWMutex
 		peers map[Peer]bool
+		// pinned contains addresses of pinned peers, connections to them
+		// are never dropped because of MaxPeers.
+		pinned map[string]bool
 
-		// lastRequestedBlock contains a height of the last requested block.
-		lastRequestedBlock atomic.Uint32
 		// lastRequestedHeader contains a height of the last requested header.
 		lastRequestedHeader atomic.Uint32
 		register            chan Peer
@@ -168,7 +179,7 @@ func NewServer(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Log
 
 func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSync, log *zap.Logger,
 	newTransport func(*Server, string) Transporter,
-	newDiscovery func([]string, time.Duration, Transporter) Discoverer,
+	newDiscovery func(DiscoveryConfig, Transporter) Discoverer,
 ) (*Server, error) {
 	if log == nil {
 		return nil, errors.New("logger is a required parameter")
@@ -195,6 +206,7 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		handshake:      make(chan Peer),
 		txInMap:        make(map[util.Uint256]struct{}),
 		peers:          make(map[Peer]bool),
+		pinned:         make(map[string]bool, len(config.PinnedPeers)),
 		mempool:        chain.GetMemPool(),
 		extensiblePool: extpool.New(chain, config.ExtensiblePoolSize),
 		log:            log,
@@ -203,7 +215,16 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 		services:       make(map[string]Service),
 		extensHandlers: make(map[string]func(*payload.Extensible) error),
 		stateSync:      stSync,
+
+		extensCategories: make(map[string]*extensibleCategory),
+	}
+	for _, addr := range config.PinnedPeers {
+		s.pinned[addr] = true
+	}
+	if len(config.ExternalAddress) == 0 {
+		s.extAddr = newExtAddrVoter(config.ExternalAddressVotes)
 	}
+	s.initExtensibleCategories()
 	if chain.P2PSigExtensionsEnabled() {
 		s.notaryFeer = NewNotaryFeer(chain)
 		s.notaryRequestPool = mempool.New(s.config.P2PNotaryRequestPayloadPoolSize, 1, true, updateNotarypoolMetrics)
@@ -213,11 +234,23 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 			}, s.notaryFeer)
 		})
 	}
+	if s.BlockFetchWindow <= 0 {
+		s.BlockFetchWindow = bqueue.CacheSize
+	}
 	s.bQueue = bqueue.New(chain, log, func(b *block.Block) {
 		s.tryStartServices()
-	}, updateBlockQueueLenMetric)
+	}, s.BlockFetchWindow, updateBlockQueueLenMetric)
+
+	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, s.BlockFetchWindow, updateBlockQueueLenMetric)
 
-	s.bSyncQueue = bqueue.New(s.stateSync, log, nil, updateBlockQueueLenMetric)
+	fetcherCfg := bqueue.FetcherConfig{
+		Window:      s.BlockFetchWindow,
+		RangeSize:   payload.MaxHashesCount,
+		MaxInFlight: s.BlockFetchMaxInFlight,
+		Timeout:     s.BlockFetchTimeout,
+	}
+	s.bFetcher = bqueue.NewFetcher(fetcherCfg)
+	s.bSyncFetcher = bqueue.NewFetcher(fetcherCfg)
 
 	if s.MinPeers < 0 {
 		s.log.Info("bad MinPeers configured, using the default value",
@@ -250,14 +283,26 @@ func newServerFromConstructors(config ServerConfig, chain Ledger, stSync StateSy
 	if len(s.ServerConfig.Addresses) == 0 {
 		return nil, errors.New("no bind addresses configured")
 	}
+	if s.TLS.Enabled {
+		var err error
+		s.tls, err = newP2PTLS(s.TLS)
+		if err != nil {
+			return nil, fmt.Errorf("P2P TLS: %w", err)
+		}
+	}
 	transports := make([]Transporter, len(s.ServerConfig.Addresses))
 	for i, addr := range s.ServerConfig.Addresses {
 		transports[i] = newTransport(s, addr.Address)
 	}
 	s.transports = transports
 	s.discovery = newDiscovery(
-		s.Seeds,
-		s.DialTimeout,
+		DiscoveryConfig{
+			Seeds:                  s.Seeds,
+			DNSSeeds:               s.DNSSeeds,
+			DNSSeedRefreshInterval: s.DNSSeedRefreshInterval,
+			PinnedPeers:            s.PinnedPeers,
+			DialTimeout:            s.DialTimeout,
+		},
 		// Here we need to pick up a single transporter, it will be used to
 		// dial, and it doesn't matter which one.
 		s.transports[0],
@@ -438,6 +483,19 @@ func (s *Server) ConnectedPeers() []string {
 	return peers
 }
 
+// PeersStats returns traffic statistics for all currently connected peers.
+func (s *Server) PeersStats() []PeerStats {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	stats := make([]PeerStats, 0, len(s.peers))
+	for k := range s.peers {
+		stats = append(stats, k.Stats())
+	}
+
+	return stats
+}
+
 // run is a goroutine that starts another goroutine to manage protocol specifics
 // while itself dealing with peers management (handling connects/disconnects).
 func (s *Server) run() {
@@ -488,6 +546,7 @@ func (s *Server) run() {
 			addrCheckTimeout = true
 			addrTimer.Reset(peerCheckTime)
 		case <-peerTimer.C:
+			updateTopTalkersMetric(s.PeersStats())
 			peerTimer.Reset(peerT)
 		case p := <-s.register:
 			s.lock.Lock()
@@ -499,6 +558,9 @@ func (s *Server) run() {
 				s.lock.RLock()
 				// Pick a random peer and drop connection to it.
 				for peer := range s.peers {
+					if s.pinned[peer.ConnectionAddr()] {
+						continue
+					}
 					// It will send us unregister signal.
 					go peer.Disconnect(errMaxPeers)
 					break
@@ -518,10 +580,14 @@ func (s *Server) run() {
 					zap.Int("peerCount", s.PeerCount()))
 				if errors.Is(drop.reason, errIdenticalID) {
 					s.discovery.RegisterSelf(drop.peer)
+					s.confirmExternalAddress(drop.peer.ConnectionAddr())
 				} else {
 					s.discovery.UnregisterConnected(drop.peer, errors.Is(drop.reason, errAlreadyConnected))
 				}
 				updatePeersConnectedMetric(s.PeerCount())
+				updateTopTalkersMetric(s.PeersStats())
+				s.bFetcher.RemovePeer(drop.peer)
+				s.bSyncFetcher.RemovePeer(drop.peer)
 			} else {
 				// else the peer is already gone, which can happen
 				// because we have two goroutines sending signals here
@@ -629,6 +695,12 @@ func (s *Server) PeerCount() int {
 	return len(s.peers)
 }
 
+// StateSyncProgress returns P2P state synchronisation progress, false is
+// returned if state synchronisation is not active.
+func (s *Server) StateSyncProgress() (statesync.Progress, bool) {
+	return s.stateSync.Progress()
+}
+
 // HandshakedPeersCount returns the number of the connected peers
 // which have already performed handshake.
 func (s *Server) HandshakedPeersCount() int {
@@ -662,7 +734,7 @@ func (s *Server) getVersionMsg(localAddr net.Addr) (*Message, error) {
 			},
 		},
 	}
-	if s.Relay {
+	if s.Relay && s.RelayPolicy != config.RelayListenOnly {
 		capabilities = append(capabilities, capability.Capability{
 			Type: capability.FullNode,
 			Data: &capability.Node{
@@ -670,6 +742,12 @@ func (s *Server) getVersionMsg(localAddr net.Addr) (*Message, error) {
 			},
 		})
 	}
+	if !s.relayTxs() {
+		capabilities = append(capabilities, capability.Capability{
+			Type: capability.NoTxRelay,
+			Data: &capability.Unknown{},
+		})
+	}
 	payload := payload.NewVersion(
 		s.Net,
 		s.id,
@@ -754,10 +832,24 @@ func (s *Server) handleVersionCmd(p Peer, version *payload.Version) error {
 
 // handleBlockCmd processes the block received from its peer.
 func (s *Server) handleBlockCmd(p Peer, block *block.Block) error {
+	q, f := s.blockQueue()
+	err := q.PutBlock(block)
+	if err != nil {
+		return err
+	}
+	if f.Received(p, block.Index) {
+		// The peer has finished its range, it can get the next one.
+		return s.requestBlocksOrHeaders(p)
+	}
+	return nil
+}
+
+// blockQueue returns the block queue and fetcher that are currently in use.
+func (s *Server) blockQueue() (*bqueue.Queue, *bqueue.Fetcher) {
 	if s.stateSync.IsActive() {
-		return s.bSyncQueue.PutBlock(block)
+		return s.bSyncQueue, s.bSyncFetcher
 	}
-	return s.bQueue.PutBlock(block)
+	return s.bQueue, s.bFetcher
 }
 
 // handlePing processes a ping request.
@@ -818,6 +910,9 @@ func (s *Server) handlePong(p Peer, pong *payload.Ping) error {
 
 // handleInvCmd processes the received inventory.
 func (s *Server) handleInvCmd(p Peer, inv *payload.Inventory) error {
+	if !s.relayTxs() && (inv.Type == payload.TXType || inv.Type == payload.P2PNotaryRequestType) {
+		return nil
+	}
 	var reqHashes = inv.Hashes[:0]
 	var typExists = map[payload.InventoryType]func(util.Uint256) bool{
 		payload.TXType: func(h util.Uint256) bool {
@@ -1116,7 +1211,9 @@ func (s *Server) handleExtensibleCmd(e *payload.Extensible) error {
 			return err
 		}
 	}
-	s.advertiseExtensible(e)
+	if s.RelayPolicy != config.RelayListenOnly {
+		s.advertiseExtensible(e)
+	}
 	return nil
 }
 
@@ -1134,6 +1231,9 @@ func (s *Server) advertiseExtensible(e *payload.Extensible) {
 // handleTxCmd processes the received transaction.
 // It never returns an error.
 func (s *Server) handleTxCmd(tx *transaction.Transaction) error {
+	if !s.relayTxs() {
+		return nil // Not requested, so the peer is misbehaving, but it's harmless.
+	}
 	// It's OK for it to fail for various reasons like tx already existing
 	// in the pool.
 	s.txInLock.Lock()
@@ -1197,6 +1297,9 @@ func (s *Server) handleP2PNotaryRequestCmd(r *payload.P2PNotaryRequest) error {
 	if !s.chain.P2PSigExtensionsEnabled() {
 		return errors.New("P2PNotaryRequestCMD was received, but P2PSignatureExtensions are disabled")
 	}
+	if !s.relayTxs() {
+		return nil
+	}
 	// It's OK for it to fail for various reasons like request already existing
 	// in the pool.
 	err := s.RelayP2PNotaryRequest(r)
@@ -1253,18 +1356,29 @@ func (s *Server) handleAddrCmd(p Peer, addrs *payload.AddressList) error {
 	if !p.CanProcessAddr() {
 		return errors.New("unexpected addr received")
 	}
+	var own []string
 	for _, a := range addrs.Addrs {
 		addr, err := a.GetTCPAddress()
 		if err == nil {
 			s.discovery.BackFill(addr)
+			if s.extAddr != nil && s.ownAddress(addr) {
+				own = append(own, addr)
+			}
 		}
 	}
+	if len(own) != 0 {
+		s.logExternalAddress(s.extAddr.Observe(p.PeerAddr().String(), own))
+	}
 	return nil
 }
 
-// handleGetAddrCmd sends to the peer some good addresses that we know of.
+// handleGetAddrCmd sends to the peer some good addresses that we know of
+// along with our own external address (if it's known).
 func (s *Server) handleGetAddrCmd(p Peer) error {
 	addrs := s.discovery.GoodPeers()
+	if self := s.selfAddress(); self != nil {
+		addrs = append([]AddressWithCapabilities{*self}, addrs...)
+	}
 	if len(addrs) > payload.MaxAddrsCount {
 		addrs = addrs[:payload.MaxAddrsCount]
 	}
@@ -1278,30 +1392,107 @@ func (s *Server) handleGetAddrCmd(p Peer) error {
 	return p.EnqueueP2PMessage(NewMessage(CMDAddr, alist))
 }
 
+// ExternalAddress returns the address advertised to other peers, it's either
+// the configured one or the one elected from addresses reported by peers. An
+// empty string is returned if it's not known.
+func (s *Server) ExternalAddress() string {
+	if s.extAddr == nil {
+		return s.ServerConfig.ExternalAddress
+	}
+	return s.extAddr.Elected()
+}
+
+// selfAddress returns the external address of the node with its capabilities
+// for addr messages or nil if it's not known.
+func (s *Server) selfAddress() *AddressWithCapabilities {
+	addr := s.ExternalAddress()
+	if len(addr) == 0 {
+		return nil
+	}
+	_, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return nil
+	}
+	self := &AddressWithCapabilities{
+		Address: addr,
+		Capabilities: capability.Capabilities{{
+			Type: capability.TCPServer,
+			Data: &capability.Server{Port: uint16(port)},
+		}},
+	}
+	if s.Relay && s.RelayPolicy != config.RelayListenOnly {
+		self.Capabilities = append(self.Capabilities, capability.Capability{
+			Type: capability.FullNode,
+			Data: &capability.Node{StartHeight: s.chain.BlockHeight()},
+		})
+	}
+	return self
+}
+
+// ownAddress checks whether the given address has the port announced by the
+// node for any of its bind addresses (peers report our address with this
+// port, so it can be ours).
+func (s *Server) ownAddress(addr string) bool {
+	_, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return false
+	}
+	for i, tr := range s.transports {
+		var p string
+		if s.ServerConfig.Addresses[i].AnnouncedPort != 0 {
+			p = strconv.FormatUint(uint64(s.ServerConfig.Addresses[i].AnnouncedPort), 10)
+		} else {
+			_, p = tr.HostPort()
+		}
+		if p == port {
+			return true
+		}
+	}
+	return false
+}
+
+// confirmExternalAddress marks the address we've connected to ourselves via
+// as the one belonging to the node.
+func (s *Server) confirmExternalAddress(addr string) {
+	if s.extAddr == nil || !s.ownAddress(addr) {
+		return
+	}
+	s.logExternalAddress(s.extAddr.Confirm(addr))
+}
+
+func (s *Server) logExternalAddress(addr string, changed bool) {
+	if !changed {
+		return
+	}
+	if len(addr) == 0 {
+		s.log.Info("external address is no longer elected")
+		return
+	}
+	s.log.Info("external address elected", zap.String("addr", addr))
+}
+
 // requestBlocks sends a CMDGetBlockByIndex message to the peer
-// to sync up in blocks. A maximum of maxBlockBatch will be
-// sent at once. There are two things we need to take care of:
-//  1. If possible, blocks should be fetched in parallel.
-//     height..+500 to one peer, height+500..+1000 to another etc.
-//  2. Every block must eventually be fetched even if the peer sends no answer.
-//
-// Thus, the following algorithm is used:
-// 1. Block range is divided into chunks of payload.MaxHashesCount.
-// 2. Send requests for chunk in increasing order.
-// 3. After all requests have been sent, request random height.
+// to sync up in blocks. Blocks are fetched in parallel from different peers
+// with every peer getting its own range of blocks (see bqueue.Fetcher for
+// details), ranges are limited by the block queue window and ranges not
+// delivered in time are requested from other peers. Blocks are then persisted
+// sequentially by the block queue.
 func (s *Server) requestBlocks(bq bqueue.Blockqueuer, p Peer) error {
-	pl := getRequestBlocksPayload(p, bq.BlockHeight(), &s.lastRequestedBlock)
-	lq, capLeft := s.bQueue.LastQueued()
+	q, f := s.blockQueue()
+	lq, capLeft := q.LastQueued()
 	if capLeft == 0 {
 		// No more blocks will fit into the queue.
 		return nil
 	}
-	if lq >= pl.IndexStart {
-		if capLeft < payload.MaxHashesCount {
-			pl.Count = int16(capLeft)
-		}
-		pl.IndexStart = lq + 1
+	r, ok := f.Request(p, bq.BlockHeight(), lq)
+	if !ok {
+		return nil
 	}
+	pl := payload.NewGetBlockByIndex(r.Start, int16(r.Count))
 	return p.EnqueueP2PMessage(NewMessage(CMDGetBlockByIndex, pl))
 }
 
@@ -1591,12 +1782,14 @@ mainloop:
 			s.chain.UnsubscribeFromBlocks(ch)
 			break mainloop
 		case b := <-ch:
-			msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
-			// Filter out nodes that are more current (avoid spamming the network
-			// during initial sync).
-			s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
-				return p.Handshaked() && p.LastBlockIndex() < b.Index
-			})
+			if s.RelayPolicy != config.RelayListenOnly {
+				msg := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{b.Hash()}))
+				// Filter out nodes that are more current (avoid spamming the network
+				// during initial sync).
+				s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, func(p Peer) bool {
+					return p.Handshaked() && p.LastBlockIndex() < b.Index
+				})
+			}
 			s.extensiblePool.RemoveStale(b.Index)
 		}
 	}
@@ -1640,7 +1833,29 @@ func (s *Server) broadcastTxHashes(hs []util.Uint256) {
 
 	// We need to filter out non-relaying nodes, so plain broadcast
 	// functions don't fit here.
-	s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, Peer.IsFullNode)
+	s.iteratePeersWithSendMsg(msg, Peer.BroadcastPacket, acceptsTxs)
+}
+
+// acceptsTxs returns true if the peer is a full node that wants to receive
+// transaction inventories (it doesn't have NoTxRelay capability).
+func acceptsTxs(p Peer) bool {
+	if !p.IsFullNode() {
+		return false
+	}
+	if v := p.Version(); v != nil {
+		for _, c := range v.Capabilities {
+			if c.Type == capability.NoTxRelay {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// relayTxs returns true if the server takes part in transaction gossip
+// according to its relay policy.
+func (s *Server) relayTxs() bool {
+	return s.RelayPolicy == "" || s.RelayPolicy == config.RelayFull
 }
 
 // initStaleMemPools initializes mempools for stale tx/payload processing.
@@ -1,11 +1,17 @@
 package network
 
 import (
+	"errors"
 	"fmt"
+	"net"
+	"strconv"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/util"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -41,9 +47,25 @@ type (
 		// Relay determines whether the server is forwarding its inventory.
 		Relay bool
 
+		// RelayPolicy is one of config.RelayFull, config.RelayBlocksOnly
+		// or config.RelayListenOnly.
+		RelayPolicy string
+
 		// Seeds is a list of initial nodes used to establish connectivity.
 		Seeds []string
 
+		// DNSSeeds is a list of "host:port" seeds resolved periodically to
+		// get node addresses.
+		DNSSeeds []string
+
+		// DNSSeedRefreshInterval is the DNS seed resolution interval used
+		// when TTL is not known.
+		DNSSeedRefreshInterval time.Duration
+
+		// PinnedPeers is a list of nodes the server always keeps connection
+		// to, they're never banned.
+		PinnedPeers []string
+
 		// Maximum duration a single dial may take.
 		DialTimeout time.Duration
 
@@ -74,8 +96,36 @@ type (
 		// ExtensiblePoolSize is the size of the pool for extensible payloads from a single sender.
 		ExtensiblePoolSize int
 
+		// ExtensibleCategories maps application-level extensible payload
+		// categories opened by the configuration to the lists of senders
+		// allowed for them (nil list means the default sender policy).
+		ExtensibleCategories map[string][]util.Uint160
+
 		// BroadcastFactor is the factor (0-100) for fan-out optimization.
 		BroadcastFactor int
+
+		// TLS is P2P connections TLS configuration.
+		TLS config.P2PTLS
+
+		// BlockFetchWindow is the maximum number of blocks above the current
+		// height that are requested and kept in the block queue.
+		BlockFetchWindow int
+
+		// BlockFetchMaxInFlight is the maximum number of block ranges
+		// requested from a single peer at the same time.
+		BlockFetchMaxInFlight int
+
+		// BlockFetchTimeout is the time given to a peer to deliver the
+		// requested blocks before they're requested from other peers.
+		BlockFetchTimeout time.Duration
+
+		// ExternalAddress is the address advertised to other peers, if it's
+		// not set, the one elected from peer observations is used.
+		ExternalAddress string
+
+		// ExternalAddressVotes is the minimum number of distinct peers
+		// required to elect the external address.
+		ExternalAddressVotes int
 	}
 )
 
@@ -89,24 +139,93 @@ func NewServerConfig(cfg config.Config) (ServerConfig, error) {
 		return ServerConfig{}, fmt.Errorf("failed to parse addresses: %w", err)
 	}
 	c := ServerConfig{
-		UserAgent:          cfg.GenerateUserAgent(),
-		Addresses:          addrs,
-		Net:                protoConfig.Magic,
-		Relay:              appConfig.Relay,
-		Seeds:              protoConfig.SeedList,
-		DialTimeout:        appConfig.P2P.DialTimeout,
-		ProtoTickInterval:  appConfig.P2P.ProtoTickInterval,
-		PingInterval:       appConfig.P2P.PingInterval,
-		PingTimeout:        appConfig.P2P.PingTimeout,
-		MaxPeers:           appConfig.P2P.MaxPeers,
-		AttemptConnPeers:   appConfig.P2P.AttemptConnPeers,
-		MinPeers:           appConfig.P2P.MinPeers,
-		TimePerBlock:       protoConfig.TimePerBlock,
-		OracleCfg:          appConfig.Oracle,
-		P2PNotaryCfg:       appConfig.P2PNotary,
-		StateRootCfg:       appConfig.StateRoot,
-		ExtensiblePoolSize: appConfig.P2P.ExtensiblePoolSize,
-		BroadcastFactor:    appConfig.P2P.BroadcastFactor,
+		UserAgent:              cfg.GenerateUserAgent(),
+		Addresses:              addrs,
+		Net:                    protoConfig.Magic,
+		Relay:                  appConfig.Relay,
+		RelayPolicy:            appConfig.P2P.RelayPolicy,
+		Seeds:                  protoConfig.SeedList,
+		DNSSeeds:               appConfig.P2P.DNSSeeds,
+		DNSSeedRefreshInterval: appConfig.P2P.DNSSeedRefreshInterval,
+		PinnedPeers:            appConfig.P2P.PinnedPeers,
+		DialTimeout:            appConfig.P2P.DialTimeout,
+		ProtoTickInterval:      appConfig.P2P.ProtoTickInterval,
+		PingInterval:           appConfig.P2P.PingInterval,
+		PingTimeout:            appConfig.P2P.PingTimeout,
+		MaxPeers:               appConfig.P2P.MaxPeers,
+		AttemptConnPeers:       appConfig.P2P.AttemptConnPeers,
+		MinPeers:               appConfig.P2P.MinPeers,
+		TimePerBlock:           protoConfig.TimePerBlock,
+		OracleCfg:              appConfig.Oracle,
+		P2PNotaryCfg:           appConfig.P2PNotary,
+		StateRootCfg:           appConfig.StateRoot,
+		ExtensiblePoolSize:     appConfig.P2P.ExtensiblePoolSize,
+		BroadcastFactor:        appConfig.P2P.BroadcastFactor,
+		TLS:                    appConfig.P2P.TLS,
+		BlockFetchWindow:       appConfig.P2P.BlockFetchWindow,
+		BlockFetchMaxInFlight:  appConfig.P2P.BlockFetchMaxInFlight,
+		BlockFetchTimeout:      appConfig.P2P.BlockFetchTimeout,
+		ExternalAddress:        appConfig.P2P.ExternalAddress,
+		ExternalAddressVotes:   appConfig.P2P.ExternalAddressVotes,
+	}
+	for _, addr := range append(appConfig.P2P.DNSSeeds, appConfig.P2P.PinnedPeers...) {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return ServerConfig{}, fmt.Errorf("invalid peer address %q: %w", addr, err)
+		}
+	}
+	if len(c.ExternalAddress) != 0 {
+		host, port, err := net.SplitHostPort(c.ExternalAddress)
+		if err == nil {
+			_, err = strconv.ParseUint(port, 10, 16)
+		}
+		if err == nil && net.ParseIP(host) == nil {
+			err = errors.New("host is not an IP address")
+		}
+		if err != nil {
+			return ServerConfig{}, fmt.Errorf("invalid external address %q: %w", c.ExternalAddress, err)
+		}
+	}
+	if c.TLS.Enabled {
+		switch c.TLS.Mode {
+		case "", config.P2PTLSRequired:
+			if c.Net == netmode.MainNet || c.Net == netmode.TestNet {
+				return ServerConfig{}, fmt.Errorf("P2P TLS can't be required on %s", c.Net)
+			}
+		case config.P2PTLSOpportunistic:
+		default:
+			return ServerConfig{}, fmt.Errorf("invalid P2P TLS mode %q", c.TLS.Mode)
+		}
+	}
+	switch c.RelayPolicy {
+	case "":
+		c.RelayPolicy = config.RelayFull
+	case config.RelayFull:
+	case config.RelayBlocksOnly, config.RelayListenOnly:
+		if appConfig.Consensus.Enabled || appConfig.P2PNotary.Enabled {
+			return ServerConfig{}, fmt.Errorf("%s relay policy can't be used with consensus or P2P notary services", c.RelayPolicy)
+		}
+	default:
+		return ServerConfig{}, fmt.Errorf("invalid relay policy %q", c.RelayPolicy)
+	}
+	if len(appConfig.P2P.ExtensibleCategories) != 0 {
+		c.ExtensibleCategories = make(map[string][]util.Uint160, len(appConfig.P2P.ExtensibleCategories))
+	}
+	for _, cat := range appConfig.P2P.ExtensibleCategories {
+		if !payload.IsNamespacedCategory(cat.Category) {
+			return ServerConfig{}, fmt.Errorf("invalid extensible category %q: must be namespace-prefixed", cat.Category)
+		}
+		if _, ok := c.ExtensibleCategories[cat.Category]; ok {
+			return ServerConfig{}, fmt.Errorf("duplicate extensible category %q", cat.Category)
+		}
+		var senders []util.Uint160
+		for _, addr := range cat.AllowedSenders {
+			u, err := address.StringToUint160(addr)
+			if err != nil {
+				return ServerConfig{}, fmt.Errorf("invalid sender %q for extensible category %q: %w", addr, cat.Category, err)
+			}
+			senders = append(senders, u)
+		}
+		c.ExtensibleCategories[cat.Category] = senders
 	}
 	return c, nil
 }
@@ -1168,3 +1168,143 @@ func TestServer_Port(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint16(123), actual)
 }
+
+func TestNewServerConfig_RelayPolicy(t *testing.T) {
+	newCfg := func(policy string, consensus bool) config.Config {
+		var cfg config.Config
+		cfg.ApplicationConfiguration.P2P.RelayPolicy = policy
+		cfg.ApplicationConfiguration.Consensus.Enabled = consensus
+		return cfg
+	}
+	c, err := NewServerConfig(newCfg("", false))
+	require.NoError(t, err)
+	require.Equal(t, config.RelayFull, c.RelayPolicy)
+	for _, policy := range []string{config.RelayFull, config.RelayBlocksOnly, config.RelayListenOnly} {
+		c, err := NewServerConfig(newCfg(policy, false))
+		require.NoError(t, err)
+		require.Equal(t, policy, c.RelayPolicy)
+	}
+	_, err = NewServerConfig(newCfg("none", false))
+	require.Error(t, err)
+	_, err = NewServerConfig(newCfg(config.RelayBlocksOnly, true))
+	require.Error(t, err)
+	_, err = NewServerConfig(newCfg(config.RelayFull, true))
+	require.NoError(t, err)
+}
+
+func TestRelayPolicy(t *testing.T) {
+	for _, policy := range []string{config.RelayBlocksOnly, config.RelayListenOnly} {
+		t.Run(policy, func(t *testing.T) {
+			s := newTestServer(t, ServerConfig{Relay: true, RelayPolicy: policy})
+			startWithCleanup(t, s)
+			var pooled atomic.Int32
+			s.chain.(*fakechain.FakeChain).PoolTxF = func(*transaction.Transaction) error {
+				pooled.Add(1)
+				return nil
+			}
+
+			var (
+				lock sync.Mutex
+				got  []*Message
+			)
+			p := newLocalPeer(t, s)
+			p.handshaked = 1
+			p.isFullNode = true
+			p.messageHandler = func(t *testing.T, msg *Message) {
+				lock.Lock()
+				got = append(got, msg)
+				lock.Unlock()
+			}
+			s.register <- p
+			require.Eventually(t, func() bool { return 1 == s.PeerCount() }, time.Second, time.Millisecond*10)
+			// received returns messages of the given types received since
+			// the previous call.
+			received := func(cmds ...CommandType) []*Message {
+				lock.Lock()
+				defer lock.Unlock()
+				var res []*Message
+				for _, m := range got {
+					for _, c := range cmds {
+						if m.Command == c {
+							res = append(res, m)
+						}
+					}
+				}
+				got = nil
+				return res
+			}
+
+			t.Run("version", func(t *testing.T) {
+				require.NoError(t, p.SendVersion())
+				msgs := received(CMDVersion)
+				require.Equal(t, 1, len(msgs))
+				var types []capability.Type
+				for _, c := range msgs[0].Payload.(*payload.Version).Capabilities {
+					types = append(types, c.Type)
+				}
+				if policy == config.RelayBlocksOnly {
+					require.ElementsMatch(t, []capability.Type{capability.TCPServer, capability.FullNode, capability.NoTxRelay}, types)
+				} else {
+					require.ElementsMatch(t, []capability.Type{capability.TCPServer, capability.NoTxRelay}, types)
+				}
+			})
+			t.Run("tx inv is ignored", func(t *testing.T) {
+				s.testHandleMessage(t, p, CMDInv, payload.NewInventory(payload.TXType, []util.Uint256{random.Uint256()}))
+				require.Empty(t, received(CMDGetData))
+			})
+			t.Run("tx is ignored", func(t *testing.T) {
+				tx := newDummyTx()
+				s.testHandleMessage(t, p, CMDTX, tx)
+				require.Never(t, func() bool {
+					return pooled.Load() != 0 || len(received(CMDInv)) != 0
+				}, 200*time.Millisecond, 10*time.Millisecond)
+				require.False(t, s.chain.GetMemPool().ContainsKey(tx.Hash()))
+			})
+			t.Run("block inv is processed", func(t *testing.T) {
+				h := random.Uint256()
+				s.testHandleMessage(t, p, CMDInv, payload.NewInventory(payload.BlockType, []util.Uint256{h}))
+				msgs := received(CMDGetData)
+				require.Equal(t, 1, len(msgs))
+				require.Equal(t, []util.Uint256{h}, msgs[0].Payload.(*payload.Inventory).Hashes)
+			})
+			t.Run("local tx is announced", func(t *testing.T) {
+				tx := newDummyTx()
+				require.NoError(t, s.RelayTxn(tx))
+				require.Eventually(t, func() bool {
+					for _, m := range received(CMDInv) {
+						if m.Payload.(*payload.Inventory).Hashes[0] == tx.Hash() {
+							return true
+						}
+					}
+					return false
+				}, time.Second, 10*time.Millisecond)
+			})
+		})
+	}
+}
+
+func TestNoTxRelayPeer(t *testing.T) {
+	s := startTestServer(t)
+	newPeer := func(caps ...capability.Capability) *atomic.Bool {
+		var announced atomic.Bool
+		p := newLocalPeer(t, s)
+		p.handshaked = 1
+		p.isFullNode = true
+		p.version = payload.NewVersion(s.Net, 1, "/test/", caps)
+		p.messageHandler = func(t *testing.T, msg *Message) {
+			if msg.Command == CMDInv {
+				announced.Store(true)
+			}
+		}
+		s.register <- p
+		return &announced
+	}
+	full := capability.Capability{Type: capability.FullNode, Data: &capability.Node{}}
+	relaying := newPeer(full)
+	notRelaying := newPeer(full, capability.Capability{Type: capability.NoTxRelay, Data: &capability.Unknown{}})
+	require.Eventually(t, func() bool { return 2 == s.PeerCount() }, time.Second, time.Millisecond*10)
+
+	require.NoError(t, s.RelayTxn(newDummyTx()))
+	require.Eventually(t, relaying.Load, time.Second, 10*time.Millisecond)
+	require.Never(t, notRelaying.Load, 200*time.Millisecond, 10*time.Millisecond)
+}
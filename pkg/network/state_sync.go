@@ -2,6 +2,7 @@ package network
 
 import (
 	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/statesync"
 	"github.com/nspcc-dev/neo-go/pkg/network/bqueue"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 )
@@ -16,5 +17,6 @@ type StateSync interface {
 	GetUnknownMPTNodesBatch(limit int) []util.Uint256
 	NeedHeaders() bool
 	NeedMPTNodes() bool
+	Progress() (statesync.Progress, bool)
 	Traverse(root util.Uint256, process func(node mpt.Node, nodeBytes []byte) bool) error
 }
@@ -67,6 +67,9 @@ type TCPPeer struct {
 	// number of sent pings.
 	pingSent  int
 	pingTimer *time.Timer
+
+	// traffic counters of this connection.
+	traffic *peerTraffic
 }
 
 // NewTCPPeer returns a TCPPeer structure based on the given connection.
@@ -80,6 +83,7 @@ func NewTCPPeer(conn net.Conn, addr string, s *Server) *TCPPeer {
 		p2pSendQ: make(chan []byte, p2pMsgQueueSize),
 		hpSendQ:  make(chan []byte, hpRequestQueueSize),
 		incoming: make(chan *Message, incomingQueueSize),
+		traffic:  newPeerTraffic(),
 	}
 }
 
@@ -147,7 +151,9 @@ func (p *TCPPeer) writeMsg(msg *Message) error {
 	}
 
 	_, err = p.conn.Write(b)
-
+	if err == nil {
+		p.traffic.addSent(b)
+	}
 	return err
 }
 
@@ -175,6 +181,7 @@ func (p *TCPPeer) handleConn() {
 			} else if err != nil {
 				break
 			}
+			p.traffic.addReceived(msg.Command, msg.size())
 			select {
 			case p.incoming <- msg:
 			case <-p.done:
@@ -252,6 +259,7 @@ func (p *TCPPeer) handleQueues() {
 		if err != nil {
 			break
 		}
+		p.traffic.addSent(msg)
 		p2pSkipCounter++
 	}
 	p.Disconnect(err)
@@ -451,6 +459,16 @@ func (p *TCPPeer) Version() *payload.Version {
 	return p.version
 }
 
+// Stats implements the Peer interface.
+func (p *TCPPeer) Stats() PeerStats {
+	var stats = p.traffic.stats()
+	stats.Address = p.PeerAddr().String()
+	if ver := p.Version(); ver != nil {
+		stats.UserAgent = string(ver.UserAgent)
+	}
+	return stats
+}
+
 // LastBlockIndex returns the last block index.
 func (p *TCPPeer) LastBlockIndex() uint32 {
 	p.lock.RLock()
@@ -82,6 +82,14 @@ func TestPeerHandshake(t *testing.T) {
 	require.Error(t, tcpC.HandleVersionAck())
 	require.Error(t, tcpS.SendVersionAck(&Message{}))
 
+	// Handshake messages are accounted for (empty test ACKs have
+	// CMDVersion command code).
+	for _, p := range []*TCPPeer{tcpS, tcpC} {
+		stats := p.Stats()
+		require.Equal(t, uint64(2), stats.Commands[CMDVersion].SentMessages)
+		require.False(t, stats.LastSent.IsZero())
+	}
+
 	// Now regular messaging can proceed.
 	require.NoError(t, tcpS.EnqueueP2PMessage(&Message{}))
 	require.NoError(t, tcpC.EnqueueP2PMessage(&Message{}))
@@ -46,7 +46,7 @@ func NewTCPTransport(s *Server, bindAddr string, log *zap.Logger) *TCPTransport
 
 // Dial implements the Transporter interface.
 func (t *TCPTransport) Dial(addr string, timeout time.Duration) (AddressablePeer, error) {
-	conn, err := net.DialTimeout("tcp", addr, timeout)
+	conn, err := t.dialConn(addr, timeout)
 	if err != nil {
 		return nil, err
 	}
@@ -55,6 +55,42 @@ func (t *TCPTransport) Dial(addr string, timeout time.Duration) (AddressablePeer
 	return p, nil
 }
 
+// dialConn establishes an outgoing connection wrapping it into TLS if
+// configured. In the opportunistic TLS mode peers failing TLS handshake for
+// reasons other than authentication are redialed using plaintext connection.
+func (t *TCPTransport) dialConn(addr string, timeout time.Duration) (net.Conn, error) {
+	conn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil || t.server.tls == nil {
+		return conn, err
+	}
+	tlsConn, err := t.server.tls.client(conn, timeout)
+	if err == nil {
+		return tlsConn, nil
+	}
+	conn.Close()
+	if t.server.tls.required || errors.Is(err, errPeerAuth) {
+		return nil, err
+	}
+	t.log.Debug("TLS handshake failed, using plaintext connection", zap.String("address", addr), zap.Error(err))
+	return net.DialTimeout("tcp", addr, timeout)
+}
+
+// acceptConn wraps an incoming connection into TLS if configured and starts
+// handling it.
+func (t *TCPTransport) acceptConn(conn net.Conn) {
+	if t.server.tls != nil {
+		c, err := t.server.tls.server(conn, t.server.DialTimeout)
+		if err != nil {
+			t.log.Info("refusing incoming connection", zap.Stringer("address", conn.RemoteAddr()), zap.Error(err))
+			conn.Close()
+			return
+		}
+		conn = c
+	}
+	p := NewTCPPeer(conn, "", t.server)
+	p.handleConn()
+}
+
 // Accept implements the Transporter interface.
 func (t *TCPTransport) Accept() {
 	l, err := net.Listen("tcp", t.bindAddr)
@@ -86,8 +122,7 @@ func (t *TCPTransport) Accept() {
 			t.log.Warn("TCP accept error", zap.Stringer("address", l.Addr()), zap.Error(err))
 			continue
 		}
-		p := NewTCPPeer(conn, "", t.server)
-		go p.handleConn()
+		go t.acceptConn(conn)
 	}
 }
 
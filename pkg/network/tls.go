@@ -0,0 +1,166 @@
+package network
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+const (
+	// tlsRecordHandshake is the first byte of any TLS connection (handshake
+	// record type), it can't be the first byte of a P2P message which is
+	// always a message flags byte.
+	tlsRecordHandshake = 0x16
+
+	// defaultTLSHandshakeTimeout is used for TLS handshakes when no
+	// DialTimeout is configured.
+	defaultTLSHandshakeTimeout = 10 * time.Second
+)
+
+var (
+	// errPlaintextPeer is returned for plaintext incoming connections when
+	// TLS is required.
+	errPlaintextPeer = errors.New("plaintext connection refused")
+	// errPeerAuth is returned when peer certificate can't be verified or it
+	// doesn't match the pinned key.
+	errPeerAuth = errors.New("peer authentication failed")
+)
+
+// p2pTLS wraps P2P connections into mutually-authenticated TLS.
+type p2pTLS struct {
+	config   *tls.Config
+	required bool
+	roots    *x509.CertPool
+	// pinned maps certificate common names to SHA256 hashes of their public
+	// keys.
+	pinned map[string][]byte
+}
+
+// peekedConn is a connection with some data already buffered by the reader.
+type peekedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *peekedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// newP2PTLS loads certificates and creates TLS wrapper for P2P connections.
+func newP2PTLS(cfg config.P2PTLS) (*p2pTLS, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load certificate: %w", err)
+	}
+	ca, err := os.ReadFile(cfg.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA file: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(ca) {
+		return nil, errors.New("no CA certificates found")
+	}
+	t := &p2pTLS{
+		required: cfg.Mode != config.P2PTLSOpportunistic,
+		roots:    roots,
+		pinned:   make(map[string][]byte, len(cfg.PinnedKeys)),
+	}
+	for name, key := range cfg.PinnedKeys {
+		h, err := hex.DecodeString(key)
+		if err != nil || len(h) != sha256.Size {
+			return nil, fmt.Errorf("invalid pinned key for %q", name)
+		}
+		t.pinned[name] = h
+	}
+	t.config = &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS13,
+		// Nodes are identified by their certificates rather than by
+		// addresses, so the standard verification is replaced with
+		// verifyPeer on both sides.
+		ClientAuth:         tls.RequireAnyClientCert,
+		InsecureSkipVerify: true,
+		VerifyConnection:   t.verifyPeer,
+	}
+	return t, nil
+}
+
+// verifyPeer checks peer certificate against configured CAs and pinned keys.
+func (t *p2pTLS) verifyPeer(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return fmt.Errorf("%w: no certificate", errPeerAuth)
+	}
+	leaf := cs.PeerCertificates[0]
+	opts := x509.VerifyOptions{
+		Roots:         t.roots,
+		Intermediates: x509.NewCertPool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	for _, c := range cs.PeerCertificates[1:] {
+		opts.Intermediates.AddCert(c)
+	}
+	if _, err := leaf.Verify(opts); err != nil {
+		return fmt.Errorf("%w: %w", errPeerAuth, err)
+	}
+	if len(t.pinned) == 0 {
+		return nil
+	}
+	expected, ok := t.pinned[leaf.Subject.CommonName]
+	if !ok {
+		return fmt.Errorf("%w: %q is not pinned", errPeerAuth, leaf.Subject.CommonName)
+	}
+	actual := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+	if !bytes.Equal(expected, actual[:]) {
+		return fmt.Errorf("%w: %q public key mismatch", errPeerAuth, leaf.Subject.CommonName)
+	}
+	return nil
+}
+
+// client performs TLS handshake over an outgoing connection.
+func (t *p2pTLS) client(conn net.Conn, timeout time.Duration) (net.Conn, error) {
+	c := tls.Client(conn, t.config)
+	return c, handshakeTLS(c, timeout)
+}
+
+// server performs TLS handshake over an incoming connection if it's a TLS
+// one. Plaintext connections are returned as is unless TLS is required.
+func (t *p2pTLS) server(conn net.Conn, timeout time.Duration) (net.Conn, error) {
+	if timeout <= 0 {
+		timeout = defaultTLSHandshakeTimeout
+	}
+	pc := &peekedConn{Conn: conn, r: bufio.NewReader(conn)}
+	_ = conn.SetReadDeadline(time.Now().Add(timeout))
+	first, err := pc.r.Peek(1)
+	_ = conn.SetReadDeadline(time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	if first[0] != tlsRecordHandshake {
+		if t.required {
+			return nil, errPlaintextPeer
+		}
+		return pc, nil
+	}
+	c := tls.Server(pc, t.config)
+	return c, handshakeTLS(c, timeout)
+}
+
+func handshakeTLS(c *tls.Conn, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = defaultTLSHandshakeTimeout
+	}
+	_ = c.SetDeadline(time.Now().Add(timeout))
+	err := c.Handshake()
+	_ = c.SetDeadline(time.Time{})
+	return err
+}
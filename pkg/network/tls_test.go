@@ -0,0 +1,283 @@
+package network
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), name+".crt")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue creates node certificate and key files signed by the CA and returns
+// their paths along with the public key hash.
+func (ca *testCA) issue(t *testing.T, name string) (string, string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, name+".crt"), filepath.Join(dir, name+".key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+	h := sha256.Sum256(spki)
+	return certFile, keyFile, hex.EncodeToString(h[:])
+}
+
+func (ca *testCA) nodeConfig(t *testing.T, name string) config.P2PTLS {
+	certFile, keyFile, _ := ca.issue(t, name)
+	return config.P2PTLS{Enabled: true, CertFile: certFile, KeyFile: keyFile, CAFile: ca.file}
+}
+
+// tlsPair performs handshake between client and server using the given
+// configurations and returns errors from both sides.
+func tlsPair(t *testing.T, clientCfg, serverCfg config.P2PTLS) (error, error) {
+	client, err := newP2PTLS(clientCfg)
+	require.NoError(t, err)
+	server, err := newP2PTLS(serverCfg)
+	require.NoError(t, err)
+
+	c, s := net.Pipe()
+	t.Cleanup(func() {
+		c.Close()
+		s.Close()
+	})
+	serverErr := make(chan error, 1)
+	go func() {
+		_, err := server.server(s, time.Second)
+		if err != nil {
+			s.Close()
+		}
+		serverErr <- err
+	}()
+	_, clientErr := client.client(c, time.Second)
+	if clientErr != nil {
+		c.Close()
+	} else {
+		// Server can still reject the client after its handshake is done.
+		go connReadStub(c)
+	}
+	return clientErr, <-serverErr
+}
+
+func TestP2PTLSHandshake(t *testing.T) {
+	ca := newTestCA(t, "ca")
+
+	t.Run("mutual auth", func(t *testing.T) {
+		cErr, sErr := tlsPair(t, ca.nodeConfig(t, "node1"), ca.nodeConfig(t, "node2"))
+		require.NoError(t, cErr)
+		require.NoError(t, sErr)
+	})
+	t.Run("untrusted client", func(t *testing.T) {
+		other := newTestCA(t, "other")
+		clientCfg := other.nodeConfig(t, "node1")
+		clientCfg.CAFile = ca.file
+		_, sErr := tlsPair(t, clientCfg, ca.nodeConfig(t, "node2"))
+		require.ErrorIs(t, sErr, errPeerAuth)
+	})
+	t.Run("untrusted server", func(t *testing.T) {
+		other := newTestCA(t, "other")
+		serverCfg := other.nodeConfig(t, "node2")
+		serverCfg.CAFile = ca.file
+		cErr, _ := tlsPair(t, ca.nodeConfig(t, "node1"), serverCfg)
+		require.ErrorIs(t, cErr, errPeerAuth)
+	})
+
+	certFile, keyFile, keyHash := ca.issue(t, "node1")
+	client := config.P2PTLS{Enabled: true, CertFile: certFile, KeyFile: keyFile, CAFile: ca.file}
+	t.Run("pinned key", func(t *testing.T) {
+		serverCfg := ca.nodeConfig(t, "node2")
+		serverCfg.PinnedKeys = map[string]string{"node1": keyHash}
+		_, sErr := tlsPair(t, client, serverCfg)
+		require.NoError(t, sErr)
+	})
+	t.Run("pinned key mismatch", func(t *testing.T) {
+		// Another key certified by the same CA for the same name.
+		certFile, keyFile, _ := ca.issue(t, "node1")
+		serverCfg := ca.nodeConfig(t, "node2")
+		serverCfg.PinnedKeys = map[string]string{"node1": keyHash}
+		_, sErr := tlsPair(t, config.P2PTLS{Enabled: true, CertFile: certFile, KeyFile: keyFile, CAFile: ca.file}, serverCfg)
+		require.ErrorIs(t, sErr, errPeerAuth)
+		require.ErrorContains(t, sErr, "public key mismatch")
+	})
+	t.Run("not pinned", func(t *testing.T) {
+		serverCfg := ca.nodeConfig(t, "node2")
+		serverCfg.PinnedKeys = map[string]string{"node3": keyHash}
+		_, sErr := tlsPair(t, client, serverCfg)
+		require.ErrorIs(t, sErr, errPeerAuth)
+		require.ErrorContains(t, sErr, "is not pinned")
+	})
+}
+
+func TestNewP2PTLS(t *testing.T) {
+	ca := newTestCA(t, "ca")
+	good := ca.nodeConfig(t, "node")
+	_, err := newP2PTLS(good)
+	require.NoError(t, err)
+
+	for name, f := range map[string]func(*config.P2PTLS){
+		"no certificate": func(c *config.P2PTLS) { c.CertFile = "" },
+		"no CA file":     func(c *config.P2PTLS) { c.CAFile = "" },
+		"bad CA file":    func(c *config.P2PTLS) { c.CAFile = c.KeyFile },
+		"bad pinned key": func(c *config.P2PTLS) { c.PinnedKeys = map[string]string{"node": "0102"} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := good
+			f(&cfg)
+			_, err := newP2PTLS(cfg)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestP2PTLSModes(t *testing.T) {
+	ca := newTestCA(t, "ca")
+
+	plaintextIncoming := func(t *testing.T, cfg config.P2PTLS) error {
+		p, err := newP2PTLS(cfg)
+		require.NoError(t, err)
+		c, s := net.Pipe()
+		defer c.Close()
+		defer s.Close()
+		go func() { _, _ = c.Write([]byte{0, byte(CMDVersion)}) }()
+		conn, err := p.server(s, time.Second)
+		if err != nil {
+			return err
+		}
+		// The peeked data must be available for P2P message decoding.
+		b := make([]byte, 2)
+		_, err = io.ReadFull(conn, b)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0, byte(CMDVersion)}, b)
+		return nil
+	}
+
+	t.Run("required refuses plaintext", func(t *testing.T) {
+		require.ErrorIs(t, plaintextIncoming(t, ca.nodeConfig(t, "node")), errPlaintextPeer)
+	})
+	t.Run("opportunistic accepts plaintext", func(t *testing.T) {
+		cfg := ca.nodeConfig(t, "node")
+		cfg.Mode = config.P2PTLSOpportunistic
+		require.NoError(t, plaintextIncoming(t, cfg))
+	})
+}
+
+func TestTCPTransportTLSDial(t *testing.T) {
+	ca := newTestCA(t, "ca")
+
+	// Plaintext-only node closes connections with unexpected data.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				b := make([]byte, 1)
+				_, _ = conn.Read(b)
+				if b[0] == tlsRecordHandshake {
+					conn.Close()
+				}
+			}()
+		}
+	}()
+
+	newTransport := func(t *testing.T, mode string) *TCPTransport {
+		cfg := ca.nodeConfig(t, "node")
+		cfg.Mode = mode
+		s := newTestServer(t, ServerConfig{TLS: cfg})
+		return NewTCPTransport(s, "127.0.0.1:0", zaptest.NewLogger(t))
+	}
+
+	t.Run("required", func(t *testing.T) {
+		_, err := newTransport(t, config.P2PTLSRequired).dialConn(l.Addr().String(), time.Second)
+		require.Error(t, err)
+	})
+	t.Run("opportunistic", func(t *testing.T) {
+		conn, err := newTransport(t, config.P2PTLSOpportunistic).dialConn(l.Addr().String(), time.Second)
+		require.NoError(t, err)
+		_, isPlain := conn.(*net.TCPConn)
+		require.True(t, isPlain)
+		conn.Close()
+	})
+}
+
+func TestNewServerConfigTLS(t *testing.T) {
+	cfg := config.Config{
+		ApplicationConfiguration: config.ApplicationConfiguration{
+			P2P: config.P2P{TLS: config.P2PTLS{Enabled: true}},
+		},
+	}
+	cfg.ProtocolConfiguration.Magic = netmode.PrivNet
+	_, err := NewServerConfig(cfg)
+	require.NoError(t, err)
+
+	cfg.ApplicationConfiguration.P2P.TLS.Mode = "sometimes"
+	_, err = NewServerConfig(cfg)
+	require.Error(t, err)
+
+	cfg.ProtocolConfiguration.Magic = netmode.MainNet
+	cfg.ApplicationConfiguration.P2P.TLS.Mode = config.P2PTLSRequired
+	_, err = NewServerConfig(cfg)
+	require.Error(t, err)
+	cfg.ApplicationConfiguration.P2P.TLS.Mode = config.P2PTLSOpportunistic
+	_, err = NewServerConfig(cfg)
+	require.NoError(t, err)
+}
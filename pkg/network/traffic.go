@@ -0,0 +1,173 @@
+package network
+
+import (
+	"encoding/binary"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type (
+	// PeerStats contains traffic statistics of a single peer connection.
+	PeerStats struct {
+		// Address is the peer address (see Peer.PeerAddr).
+		Address string
+		// UserAgent is the user agent from the peer's version message, it's
+		// empty if the peer has not yet sent one.
+		UserAgent string
+		// ConnectedAt is the time of connection establishment.
+		ConnectedAt time.Time
+		// LastSent is the time of the last message sent to the peer, zero
+		// if there were none.
+		LastSent time.Time
+		// LastReceived is the time of the last message received from the peer,
+		// zero if there were none.
+		LastReceived time.Time
+		// Commands contains counters for every command that was sent or
+		// received via this connection.
+		Commands map[CommandType]CommandStats
+	}
+
+	// CommandStats contains byte and message counters for a single command.
+	CommandStats struct {
+		SentBytes        uint64
+		SentMessages     uint64
+		ReceivedBytes    uint64
+		ReceivedMessages uint64
+	}
+
+	// peerTraffic is a set of per-connection traffic counters, it's safe for
+	// concurrent use and cheap to update. It's never reset, a new connection
+	// (even from the same address) gets a new set of counters.
+	peerTraffic struct {
+		connectedAt  time.Time
+		lastSent     atomic.Int64
+		lastReceived atomic.Int64
+		sent         [256]trafficCounter
+		received     [256]trafficCounter
+	}
+
+	trafficCounter struct {
+		bytes    atomic.Uint64
+		messages atomic.Uint64
+	}
+)
+
+func newPeerTraffic() *peerTraffic {
+	return &peerTraffic{connectedAt: time.Now()}
+}
+
+// Total returns the total number of bytes sent to and received from the peer.
+func (s PeerStats) Total() (sent uint64, received uint64) {
+	for _, c := range s.Commands {
+		sent += c.SentBytes
+		received += c.ReceivedBytes
+	}
+	return sent, received
+}
+
+// CommandName returns a short lowercase name of the command (like "block" for
+// CMDBlock) that is used for metrics and RPC output.
+func CommandName(cmd CommandType) string {
+	return strings.ToLower(strings.TrimPrefix(cmd.String(), "CMD"))
+}
+
+// addSent accounts for the packet sent to the peer, the packet can contain
+// any number of serialized messages.
+func (t *peerTraffic) addSent(packet []byte) {
+	t.lastSent.Store(time.Now().UnixNano())
+	for len(packet) > 0 {
+		var (
+			cmd = CommandType(0)
+			sz  = messageSize(packet)
+		)
+		if len(packet) > 1 {
+			cmd = CommandType(packet[1])
+		}
+		t.sent[cmd].add(sz)
+		addTrafficMetric(cmd, true, sz)
+		packet = packet[sz:]
+	}
+}
+
+// addReceived accounts for the message received from the peer.
+func (t *peerTraffic) addReceived(cmd CommandType, size int) {
+	t.lastReceived.Store(time.Now().UnixNano())
+	t.received[cmd].add(size)
+	addTrafficMetric(cmd, false, size)
+}
+
+// stats returns a snapshot of the counters.
+func (t *peerTraffic) stats() PeerStats {
+	var res = PeerStats{
+		ConnectedAt:  t.connectedAt,
+		LastSent:     unixNanoTime(t.lastSent.Load()),
+		LastReceived: unixNanoTime(t.lastReceived.Load()),
+		Commands:     make(map[CommandType]CommandStats),
+	}
+	for i := range t.sent {
+		var c = CommandStats{
+			SentBytes:        t.sent[i].bytes.Load(),
+			SentMessages:     t.sent[i].messages.Load(),
+			ReceivedBytes:    t.received[i].bytes.Load(),
+			ReceivedMessages: t.received[i].messages.Load(),
+		}
+		if c != (CommandStats{}) {
+			res.Commands[CommandType(i)] = c
+		}
+	}
+	return res
+}
+
+func (c *trafficCounter) add(size int) {
+	c.bytes.Add(uint64(size))
+	c.messages.Add(1)
+}
+
+func unixNanoTime(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// messageSize returns the size of the first serialized message in the given
+// buffer (header included). Truncated or otherwise malformed data is treated
+// as a single message occupying the whole buffer.
+func messageSize(b []byte) int {
+	const header = 2 // Flags and command.
+	if len(b) <= header {
+		return len(b)
+	}
+	var (
+		l      uint64
+		prefix = b[header]
+		off    = header + 1
+	)
+	switch prefix {
+	case 0xfd:
+		if len(b) < off+2 {
+			return len(b)
+		}
+		l = uint64(binary.LittleEndian.Uint16(b[off:]))
+		off += 2
+	case 0xfe:
+		if len(b) < off+4 {
+			return len(b)
+		}
+		l = uint64(binary.LittleEndian.Uint32(b[off:]))
+		off += 4
+	case 0xff:
+		if len(b) < off+8 {
+			return len(b)
+		}
+		l = binary.LittleEndian.Uint64(b[off:])
+		off += 8
+	default:
+		l = uint64(prefix)
+	}
+	if l > uint64(len(b)-off) {
+		return len(b)
+	}
+	return off + int(l)
+}
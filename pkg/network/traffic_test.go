@@ -0,0 +1,69 @@
+package network
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageSize(t *testing.T) {
+	var (
+		small = NewMessage(CMDPing, payload.NewPing(1, 2))
+		big   = NewMessage(CMDAddr, &payload.AddressList{Addrs: make([]*payload.AddressAndTime, 100)})
+		empty = NewMessage(CMDGetAddr, payload.NewNullPayload())
+	)
+	for i := range big.Payload.(*payload.AddressList).Addrs {
+		big.Payload.(*payload.AddressList).Addrs[i] = &payload.AddressAndTime{}
+	}
+	var packet []byte
+	for _, m := range []*Message{small, big, empty} {
+		b, err := m.Bytes()
+		require.NoError(t, err)
+		require.Equal(t, len(b), messageSize(b))
+		require.Equal(t, len(b), m.size())
+		packet = append(packet, b...)
+	}
+
+	tr := newPeerTraffic()
+	tr.addSent(packet)
+	stats := tr.stats()
+	require.Equal(t, 3, len(stats.Commands))
+	for _, m := range []*Message{small, big, empty} {
+		require.Equal(t, CommandStats{SentBytes: uint64(m.size()), SentMessages: 1}, stats.Commands[m.Command])
+	}
+	require.False(t, stats.LastSent.IsZero())
+	require.True(t, stats.LastReceived.IsZero())
+
+	t.Run("malformed", func(t *testing.T) {
+		for _, b := range [][]byte{{0}, {0, 0x18}, {0, 0x18, 0xfd, 1}, {0, 0x18, 10, 1, 2}} {
+			require.Equal(t, len(b), messageSize(b))
+		}
+	})
+}
+
+func TestPeerTraffic(t *testing.T) {
+	tr := newPeerTraffic()
+	tr.addReceived(CMDBlock, 100)
+	tr.addReceived(CMDBlock, 50)
+	tr.addReceived(CMDTX, 10)
+	b, err := NewMessage(CMDInv, payload.NewInventory(payload.BlockType, nil)).Bytes()
+	require.NoError(t, err)
+	tr.addSent(bytes.Repeat(b, 2))
+
+	stats := tr.stats()
+	require.Equal(t, map[CommandType]CommandStats{
+		CMDBlock: {ReceivedBytes: 150, ReceivedMessages: 2},
+		CMDTX:    {ReceivedBytes: 10, ReceivedMessages: 1},
+		CMDInv:   {SentBytes: uint64(2 * len(b)), SentMessages: 2},
+	}, stats.Commands)
+	sent, received := stats.Total()
+	require.Equal(t, uint64(2*len(b)), sent)
+	require.Equal(t, uint64(160), received)
+	require.False(t, stats.LastReceived.IsZero())
+	require.False(t, stats.LastSent.Before(stats.ConnectedAt))
+
+	require.Equal(t, "block", CommandName(CMDBlock))
+	require.Equal(t, "p2pnotaryrequest", CommandName(CMDP2PNotaryRequest))
+}
@@ -0,0 +1,254 @@
+/*
+Package accountinfo allows to get aggregated information about an account via
+RPC.
+
+It collects token balances, NEO voting data, Notary deposit, contracts
+deployed by the account and pending transactions related to it using existing
+RPC methods and native contract readers, all requests are made in parallel.
+Token balances depend on the server having token tracking enabled (see
+getnep17balances and getnep11balances RPC methods).
+*/
+package accountinfo
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/notary"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// RPCClient is a set of RPC methods used to get account information,
+// rpcclient.Client implements it.
+type RPCClient interface {
+	invoker.RPCInvoke
+
+	GetContracts(filter *neorpc.ContractFilter, start *util.Uint160, count int) (*result.Contracts, error)
+	GetNativeContracts() ([]state.NativeContract, error)
+	GetNEP11Balances(address util.Uint160) (*result.NEP11Balances, error)
+	GetNEP17Balances(address util.Uint160) (*result.NEP17Balances, error)
+	GetRawMemPool() ([]util.Uint256, error)
+	GetRawTransaction(hash util.Uint256) (*transaction.Transaction, error)
+	GetUnclaimedGas(address string) (result.UnclaimedGas, error)
+}
+
+// Info is the aggregated account information.
+type Info struct {
+	Account util.Uint160 `json:"account"`
+	// NEP17 contains NEP-17 token balances with token symbols and decimals.
+	NEP17 []result.NEP17Balance `json:"nep17"`
+	// NEP11 contains the number of NEP-11 tokens owned for every contract.
+	NEP11 []NEP11Count `json:"nep11"`
+	// NEO contains NEO balance and voting data.
+	NEO NEOInfo `json:"neo"`
+	// Notary contains Notary deposit data, it's nil if Notary contract is
+	// not available on the network or if there is no deposit.
+	Notary *NotaryInfo `json:"notary,omitempty"`
+	// Contracts contains contracts deployed by the account. Contracts are
+	// attributed by their hashes, so those updated with a different NEF are
+	// not included.
+	Contracts []Contract `json:"contracts"`
+	// Pending contains mempool transactions related to the account.
+	Pending []PendingTx `json:"pending"`
+}
+
+// NEP11Count is the number of NEP-11 tokens of the contract owned by the
+// account.
+type NEP11Count struct {
+	Asset  util.Uint160 `json:"assethash"`
+	Name   string       `json:"name"`
+	Symbol string       `json:"symbol"`
+	Tokens int          `json:"tokens"`
+}
+
+// NEOInfo contains NEO-related account data.
+type NEOInfo struct {
+	Balance       *big.Int        `json:"balance"`
+	BalanceHeight uint32          `json:"balanceheight"`
+	VoteTo        *keys.PublicKey `json:"voteto"`
+	Unclaimed     *big.Int        `json:"unclaimed"`
+}
+
+// NotaryInfo contains Notary deposit data.
+type NotaryInfo struct {
+	Deposit *big.Int `json:"deposit"`
+	Till    uint32   `json:"till"`
+}
+
+// Contract is a contract deployed by the account.
+type Contract struct {
+	ID   int32        `json:"id"`
+	Hash util.Uint160 `json:"hash"`
+	Name string       `json:"name"`
+}
+
+// PendingTx is a mempool transaction related to the account. From is set if
+// the account is one of transaction signers, To is set if the transaction
+// script contains the account hash (like NEP-17 transfers to it do).
+type PendingTx struct {
+	Hash util.Uint256 `json:"hash"`
+	From bool         `json:"from"`
+	To   bool         `json:"to"`
+}
+
+// Get returns aggregated information about the account. It fails if any of
+// the requests fails except for Notary contract not being available.
+func Get(c RPCClient, account util.Uint160) (*Info, error) {
+	var (
+		res  = &Info{Account: account}
+		wg   sync.WaitGroup
+		lock sync.Mutex
+		errs []error
+	)
+	for _, f := range []func(RPCClient, *Info) error{
+		getNEP17, getNEP11, getNEO, getNotary, getContracts, getPending,
+	} {
+		wg.Add(1)
+		go func(f func(RPCClient, *Info) error) {
+			defer wg.Done()
+			// Every function only changes its own fields.
+			if err := f(c, res); err != nil {
+				lock.Lock()
+				errs = append(errs, err)
+				lock.Unlock()
+			}
+		}(f)
+	}
+	wg.Wait()
+	if len(errs) != 0 {
+		return nil, errs[0]
+	}
+	return res, nil
+}
+
+func getNEP17(c RPCClient, res *Info) error {
+	bs, err := c.GetNEP17Balances(res.Account)
+	if err != nil {
+		return fmt.Errorf("failed to get NEP-17 balances: %w", err)
+	}
+	res.NEP17 = bs.Balances
+	return nil
+}
+
+func getNEP11(c RPCClient, res *Info) error {
+	bs, err := c.GetNEP11Balances(res.Account)
+	if err != nil {
+		return fmt.Errorf("failed to get NEP-11 balances: %w", err)
+	}
+	res.NEP11 = make([]NEP11Count, 0, len(bs.Balances))
+	for _, b := range bs.Balances {
+		res.NEP11 = append(res.NEP11, NEP11Count{
+			Asset:  b.Asset,
+			Name:   b.Name,
+			Symbol: b.Symbol,
+			Tokens: len(b.Tokens),
+		})
+	}
+	return nil
+}
+
+func getNEO(c RPCClient, res *Info) error {
+	st, err := neo.NewReader(invoker.New(c, nil)).GetAccountState(res.Account)
+	if err != nil {
+		return fmt.Errorf("failed to get NEO account state: %w", err)
+	}
+	unclaimed, err := c.GetUnclaimedGas(address.Uint160ToString(res.Account))
+	if err != nil {
+		return fmt.Errorf("failed to get unclaimed GAS: %w", err)
+	}
+	res.NEO.Unclaimed = &unclaimed.Unclaimed
+	res.NEO.Balance = new(big.Int)
+	if st != nil {
+		res.NEO.Balance = &st.Balance
+		res.NEO.BalanceHeight = st.BalanceHeight
+		res.NEO.VoteTo = st.VoteTo
+	}
+	return nil
+}
+
+func getNotary(c RPCClient, res *Info) error {
+	natives, err := c.GetNativeContracts()
+	if err != nil {
+		return fmt.Errorf("failed to get native contracts: %w", err)
+	}
+	var found bool
+	for _, cs := range natives {
+		if cs.Manifest.Name == nativenames.Notary {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	r := notary.NewReader(invoker.New(c, nil))
+	deposit, err := r.BalanceOf(res.Account)
+	if err != nil {
+		return fmt.Errorf("failed to get Notary deposit: %w", err)
+	}
+	if deposit.Sign() == 0 {
+		return nil
+	}
+	till, err := r.ExpirationOf(res.Account)
+	if err != nil {
+		return fmt.Errorf("failed to get Notary deposit expiration: %w", err)
+	}
+	res.Notary = &NotaryInfo{Deposit: deposit, Till: till}
+	return nil
+}
+
+func getContracts(c RPCClient, res *Info) error {
+	var (
+		filter = &neorpc.ContractFilter{Deployer: &res.Account}
+		start  *util.Uint160
+	)
+	res.Contracts = make([]Contract, 0)
+	for {
+		cs, err := c.GetContracts(filter, start, 0)
+		if err != nil {
+			return fmt.Errorf("failed to get contracts: %w", err)
+		}
+		for _, ctr := range cs.Contracts {
+			res.Contracts = append(res.Contracts, Contract{ID: ctr.ID, Hash: ctr.Hash, Name: ctr.Manifest.Name})
+		}
+		if !cs.Truncated {
+			return nil
+		}
+		start = cs.Next
+	}
+}
+
+func getPending(c RPCClient, res *Info) error {
+	hashes, err := c.GetRawMemPool()
+	if err != nil {
+		return fmt.Errorf("failed to get mempool: %w", err)
+	}
+	res.Pending = make([]PendingTx, 0)
+	acc := res.Account.BytesBE()
+	for _, h := range hashes {
+		tx, err := c.GetRawTransaction(h)
+		if err != nil {
+			return fmt.Errorf("failed to get transaction %s: %w", h.StringLE(), err)
+		}
+		var p = PendingTx{
+			Hash: h,
+			From: tx.HasSigner(res.Account),
+			To:   bytes.Contains(tx.Script, acc),
+		}
+		if p.From || p.To {
+			res.Pending = append(res.Pending, p)
+		}
+	}
+	return nil
+}
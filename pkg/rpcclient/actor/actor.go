@@ -97,6 +97,29 @@ type Options struct {
 	// before it's signed (other methods that perform test invocations
 	// use CheckerModifier). MakeUnsigned* methods do not run it.
 	Modifier TransactionModifier
+	// ScopeSuggester, if set, receives signer scope suggestions made
+	// for transactions created via MakeCall/MakeRun (and their Tuned
+	// and Send* counterparts) based on the invocation tree of an
+	// additional diagnostic test invocation. RPCActor must implement
+	// RPCInvokeDiagnostics to use it.
+	ScopeSuggester ScopeSuggester
+	// AutoRestrictScopes makes Actor restrict signer scopes to the
+	// suggested ones (see ScopeSuggester) for transactions created
+	// via MakeCall/MakeRun (and their Tuned and Send* counterparts).
+	// Scopes are never widened automatically and restricted ones are
+	// only used if the script still HALTs with them. RPCActor must
+	// implement RPCInvokeDiagnostics to use it.
+	AutoRestrictScopes bool
+	// UseFeeEstimation makes Actor get test invocation results and fees
+	// of transactions created via MakeCall/MakeRun/MakeUnsignedCall/
+	// MakeUnsignedRun (and their Tuned and Send* counterparts) with a
+	// single estimatefees request instead of separate test invocation
+	// and network fee calculation ones. It's only effective if RPCActor
+	// implements RPCFeeEstimator and the server reports
+	// result.RPCCapabilityFeeEstimation capability, the usual requests
+	// are made otherwise. It's also not used along with ScopeSuggester
+	// and AutoRestrictScopes since they need additional invocations.
+	UseFeeEstimation bool
 }
 
 // New creates an Actor instance using the specified RPC interface and the set of
@@ -174,6 +197,14 @@ func NewTuned(ra RPCActor, signers []SignerAccount, opts Options) (*Actor, error
 	if opts.Modifier != nil {
 		a.opts.Modifier = opts.Modifier
 	}
+	a.opts.UseFeeEstimation = opts.UseFeeEstimation
+	if opts.ScopeSuggester != nil || opts.AutoRestrictScopes {
+		if _, ok := ra.(RPCInvokeDiagnostics); !ok {
+			return nil, errors.New("RPC client doesn't support diagnostic invocations required for scope suggestions")
+		}
+		a.opts.ScopeSuggester = opts.ScopeSuggester
+		a.opts.AutoRestrictScopes = opts.AutoRestrictScopes
+	}
 	return a, err
 }
 
@@ -217,6 +248,9 @@ func (a *Actor) Sign(tx *transaction.Transaction) error {
 	}
 	for i, signer := range a.signers {
 		err := signer.Account.SignTx(a.GetNetwork(), tx)
+		if errors.Is(err, wallet.ErrExternalSigner) {
+			return fmt.Errorf("failed to add witness for signer #%d (%s): %w", i, signer.Account.Address, err)
+		}
 		if err != nil { // then account is non-contract-based and locked, but let's provide more detailed error
 			if paramNum := len(signer.Account.Contract.Parameters); paramNum != 0 && signer.Account.Contract.Deployed {
 				return fmt.Errorf("failed to add contract-based witness for signer #%d (%s): "+
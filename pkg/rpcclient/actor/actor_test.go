@@ -7,14 +7,17 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/internal/fakeledger"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
 	"github.com/stretchr/testify/require"
 )
 
@@ -239,6 +242,31 @@ func TestSign(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestSignExternal(t *testing.T) {
+	client, _ := testRPCAndAccount(t)
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	app := fakeledger.New(priv)
+	d, err := ledger.New(app, "")
+	require.NoError(t, err)
+	acc, err := wallet.NewAccountFromExternalSigner(d)
+	require.NoError(t, err)
+
+	a, err := NewSimple(client, acc)
+	require.NoError(t, err)
+
+	script := []byte{1, 2, 3}
+	client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+	tx, err := a.MakeUnsignedRun(script, nil)
+	require.NoError(t, err)
+	require.NoError(t, a.Sign(tx))
+	require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+
+	app.Reject = true
+	tx.Scripts = nil
+	require.ErrorIs(t, a.Sign(tx), ledger.ErrRejected)
+}
+
 func TestSenders(t *testing.T) {
 	client, acc := testRPCAndAccount(t)
 	a, err := NewSimple(client, acc)
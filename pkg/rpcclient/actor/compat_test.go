@@ -11,3 +11,13 @@ func TestRPCActorRPCClientCompat(t *testing.T) {
 	_ = actor.RPCActor(&rpcclient.WSClient{})
 	_ = actor.RPCActor(&rpcclient.Client{})
 }
+
+func TestRPCInvokeDiagnosticsRPCClientCompat(t *testing.T) {
+	_ = actor.RPCInvokeDiagnostics(&rpcclient.WSClient{})
+	_ = actor.RPCInvokeDiagnostics(&rpcclient.Client{})
+}
+
+func TestRPCFeeEstimatorRPCClientCompat(t *testing.T) {
+	_ = actor.RPCFeeEstimator(&rpcclient.WSClient{})
+	_ = actor.RPCFeeEstimator(&rpcclient.Client{})
+}
@@ -0,0 +1,81 @@
+package actor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// RPCFeeEstimator is an optional RPCActor extension that allows to get the test
+// invocation result, system and network fees of a transaction with a single
+// request (see Options.UseFeeEstimation). Both rpcclient.Client and
+// rpcclient.WSClient implement it.
+type RPCFeeEstimator interface {
+	EstimateFees(script []byte, signers []transaction.Signer, witnesses []transaction.Witness, attrs []transaction.Attribute) (*result.FeeEstimation, error)
+}
+
+// useFeeEstimation returns true if transactions are to be created with fees
+// obtained via RPCFeeEstimator.
+func (a *Actor) useFeeEstimation() bool {
+	if !a.opts.UseFeeEstimation || a.opts.ScopeSuggester != nil || a.opts.AutoRestrictScopes {
+		return false
+	}
+	if _, ok := a.client.(RPCFeeEstimator); !ok {
+		return false
+	}
+	return a.version.RPC.HasCapability(result.RPCCapabilityFeeEstimation)
+}
+
+// estimatedCallScript returns a script calling the given method if fee
+// estimation is to be used for it, nil otherwise (parameters that can't be
+// emitted are left for the regular invocation).
+func (a *Actor) estimatedCallScript(contract util.Uint160, method string, params []any) []byte {
+	if !a.useFeeEstimation() {
+		return nil
+	}
+	script, err := smartcontract.CreateCallScript(contract, method, params...)
+	if err != nil {
+		return nil
+	}
+	return script
+}
+
+// makeEstimatedRun creates an unsigned transaction with the given script and
+// attributes (or Actor default ones if nil) getting its test invocation result
+// and fees via RPCFeeEstimator.
+func (a *Actor) makeEstimatedRun(script []byte, attrs []transaction.Attribute) (*result.Invoke, *transaction.Transaction, error) {
+	var err error
+
+	if len(script) == 0 {
+		return nil, nil, errors.New("empty script")
+	}
+	if attrs == nil {
+		attrs = a.opts.Attributes // Might as well be nil, but it's OK.
+	}
+	tx := transaction.New(script, 0)
+	tx.Signers = a.txSigners
+	tx.Attributes = attrs
+
+	tx.ValidUntilBlock, err = a.CalculateValidUntilBlock()
+	if err != nil {
+		return nil, nil, fmt.Errorf("calculating validUntilBlock: %w", err)
+	}
+	err = a.initWitnesses(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := a.client.(RPCFeeEstimator).EstimateFees(script, tx.Signers, tx.Scripts, tx.Attributes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("fee estimation failed: %w", err)
+	}
+	if res.Invocation == nil {
+		return nil, nil, errors.New("fee estimation failed: no invocation result")
+	}
+	tx.SystemFee = res.SystemFee
+	tx.NetworkFee = res.NetworkFee
+	return res.Invocation, tx, nil
+}
@@ -0,0 +1,148 @@
+package actor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type estRPCClient struct {
+	*RPCClient
+	estRes    *result.FeeEstimation
+	estErr    error
+	estCalls  int
+	witnesses []transaction.Witness
+	attrs     []transaction.Attribute
+}
+
+func (r *estRPCClient) EstimateFees(script []byte, signers []transaction.Signer, witnesses []transaction.Witness, attrs []transaction.Attribute) (*result.FeeEstimation, error) {
+	r.estCalls++
+	r.witnesses = append([]transaction.Witness(nil), witnesses...)
+	r.attrs = attrs
+	return r.estRes, r.estErr
+}
+
+func TestFeeEstimation(t *testing.T) {
+	rpc, acc := testRPCAndAccount(t)
+	client := &estRPCClient{RPCClient: rpc}
+	script := []byte{1, 2, 3}
+	rpc.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+	rpc.netFee = 2
+	client.estRes = &result.FeeEstimation{
+		Invocation: &result.Invoke{State: "HALT", GasConsumed: 30, Script: script},
+		SystemFee:  30,
+		NetworkFee: 20,
+		TotalFee:   50,
+	}
+	newActor := func(t *testing.T, opts Options) *Actor {
+		a, err := NewTuned(client, []SignerAccount{{
+			Signer:  transaction.Signer{Account: acc.ScriptHash(), Scopes: transaction.CalledByEntry},
+			Account: acc,
+		}}, opts)
+		require.NoError(t, err)
+		return a
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		client.estCalls = 0
+		rpc.version.RPC.Capabilities = []string{result.RPCCapabilityFeeEstimation}
+		tx, err := newActor(t, Options{}).MakeRun(script)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), tx.SystemFee)
+		require.Equal(t, int64(2), tx.NetworkFee)
+		require.Equal(t, 0, client.estCalls)
+	})
+	t.Run("unsupported by server", func(t *testing.T) {
+		client.estCalls = 0
+		rpc.version.RPC.Capabilities = nil
+		tx, err := newActor(t, Options{UseFeeEstimation: true}).MakeRun(script)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), tx.SystemFee)
+		require.Equal(t, 0, client.estCalls)
+	})
+	t.Run("unsupported by client", func(t *testing.T) {
+		rpc.version.RPC.Capabilities = []string{result.RPCCapabilityFeeEstimation}
+		a, err := NewTuned(rpc, []SignerAccount{{
+			Signer:  transaction.Signer{Account: acc.ScriptHash(), Scopes: transaction.CalledByEntry},
+			Account: acc,
+		}}, Options{UseFeeEstimation: true})
+		require.NoError(t, err)
+		tx, err := a.MakeRun(script)
+		require.NoError(t, err)
+		require.Equal(t, int64(3), tx.SystemFee)
+	})
+
+	rpc.version.RPC.Capabilities = []string{result.RPCCapabilityFeeEstimation}
+	attrs := []transaction.Attribute{{Type: transaction.HighPriority}}
+	a := newActor(t, Options{UseFeeEstimation: true, Attributes: attrs})
+
+	t.Run("run", func(t *testing.T) {
+		client.estCalls = 0
+		tx, err := a.MakeRun(script)
+		require.NoError(t, err)
+		require.Equal(t, 1, client.estCalls)
+		require.Equal(t, int64(30), tx.SystemFee)
+		require.Equal(t, int64(20), tx.NetworkFee)
+		require.Equal(t, attrs, client.attrs)
+		require.Equal(t, []transaction.Witness{{VerificationScript: acc.Contract.Script}}, client.witnesses)
+		require.Equal(t, 1, len(tx.Scripts))
+		require.NotEmpty(t, tx.Scripts[0].InvocationScript)
+
+		tx, err = a.MakeUnsignedRun(script, nil)
+		require.NoError(t, err)
+		require.Equal(t, int64(30), tx.SystemFee)
+		require.Equal(t, int64(20), tx.NetworkFee)
+		require.Empty(t, tx.Scripts[0].InvocationScript)
+	})
+	t.Run("call", func(t *testing.T) {
+		client.estCalls = 0
+		tx, err := a.MakeCall(util.Uint160{1, 2, 3}, "method", 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(30), tx.SystemFee)
+		tx, err = a.MakeUnsignedCall(util.Uint160{1, 2, 3}, "method", nil, 1)
+		require.NoError(t, err)
+		require.Equal(t, int64(20), tx.NetworkFee)
+		require.Equal(t, 2, client.estCalls)
+	})
+	t.Run("fault", func(t *testing.T) {
+		client.estRes.Invocation.State = "FAULT"
+		defer func() { client.estRes.Invocation.State = "HALT" }()
+		_, err := a.MakeRun(script)
+		require.Error(t, err)
+		_, err = a.MakeUnsignedRun(script, nil)
+		require.Error(t, err)
+		tx, err := a.MakeTunedRun(script, nil, func(r *result.Invoke, t *transaction.Transaction) error { return nil })
+		require.NoError(t, err)
+		require.Equal(t, int64(30), tx.SystemFee)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := a.MakeRun(nil)
+		require.Error(t, err)
+
+		client.estErr = errors.New("")
+		_, err = a.MakeRun(script)
+		require.Error(t, err)
+		client.estErr = nil
+
+		inv := client.estRes.Invocation
+		client.estRes.Invocation = nil
+		_, err = a.MakeRun(script)
+		require.Error(t, err)
+		client.estRes.Invocation = inv
+	})
+	t.Run("scope suggestions", func(t *testing.T) {
+		client.estCalls = 0
+		diag := &diagRPCClient{RPCClient: rpc}
+		diag.diagRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+		a, err := NewTuned(diag, []SignerAccount{{
+			Signer:  transaction.Signer{Account: acc.ScriptHash(), Scopes: transaction.CalledByEntry},
+			Account: acc,
+		}}, Options{UseFeeEstimation: true, ScopeSuggester: func(ScopeSuggestion) {}})
+		require.NoError(t, err)
+		require.False(t, a.useFeeEstimation())
+	})
+}
@@ -62,6 +62,9 @@ func (a *Actor) MakeCall(contract util.Uint160, method string, params ...any) (*
 // one's if nil, see TransactionCheckerModifier documentation also), so the
 // process can be aborted and transaction can be modified before signing.
 func (a *Actor) MakeTunedCall(contract util.Uint160, method string, attrs []transaction.Attribute, txHook TransactionCheckerModifier, params ...any) (*transaction.Transaction, error) {
+	if script := a.estimatedCallScript(contract, method, params); script != nil {
+		return a.MakeTunedRun(script, attrs, txHook)
+	}
 	r, err := a.Call(contract, method, params...)
 	return a.makeUncheckedWrapper(r, err, attrs, txHook)
 }
@@ -81,6 +84,24 @@ func (a *Actor) MakeRun(script []byte) (*transaction.Transaction, error) {
 // TransactionCheckerModifier documentation also), so the process can be aborted
 // and transaction can be modified before signing.
 func (a *Actor) MakeTunedRun(script []byte, attrs []transaction.Attribute, txHook TransactionCheckerModifier) (*transaction.Transaction, error) {
+	if a.useFeeEstimation() {
+		r, tx, err := a.makeEstimatedRun(script, attrs)
+		if err != nil {
+			return nil, err
+		}
+		if txHook == nil {
+			txHook = a.opts.CheckerModifier
+		}
+		err = txHook(r, tx)
+		if err != nil {
+			return nil, err
+		}
+		err = a.Sign(tx)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
 	r, err := a.Run(script)
 	return a.makeUncheckedWrapper(r, err, attrs, txHook)
 }
@@ -89,7 +110,14 @@ func (a *Actor) makeUncheckedWrapper(r *result.Invoke, err error, attrs []transa
 	if err != nil {
 		return nil, fmt.Errorf("test invocation failed: %w", err)
 	}
-	return a.MakeUncheckedRun(r.Script, r.GasConsumed, attrs, func(tx *transaction.Transaction) error {
+	var signers = a.txSigners
+	if a.opts.ScopeSuggester != nil || a.opts.AutoRestrictScopes {
+		r, signers, err = a.suggestScopes(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a.makeUncheckedRun(r.Script, r.GasConsumed, attrs, signers, func(tx *transaction.Transaction) error {
 		if txHook == nil {
 			txHook = a.opts.CheckerModifier
 		}
@@ -105,7 +133,11 @@ func (a *Actor) makeUncheckedWrapper(r *result.Invoke, err error, attrs []transa
 // signing. This method is mostly useful when test invocation is already
 // performed and the script and required system fee values are already known.
 func (a *Actor) MakeUncheckedRun(script []byte, sysfee int64, attrs []transaction.Attribute, txHook TransactionModifier) (*transaction.Transaction, error) {
-	tx, err := a.MakeUnsignedUncheckedRun(script, sysfee, attrs)
+	return a.makeUncheckedRun(script, sysfee, attrs, a.txSigners, txHook)
+}
+
+func (a *Actor) makeUncheckedRun(script []byte, sysfee int64, attrs []transaction.Attribute, signers []transaction.Signer, txHook TransactionModifier) (*transaction.Transaction, error) {
+	tx, err := a.makeUnsignedUncheckedRun(script, sysfee, attrs, signers)
 	if err != nil {
 		return nil, err
 	}
@@ -131,6 +163,9 @@ func (a *Actor) MakeUncheckedRun(script []byte, sysfee int64, attrs []transactio
 // TransactionModifier is not applied to the result of this method, but default
 // attributes are used if attrs is nil.
 func (a *Actor) MakeUnsignedCall(contract util.Uint160, method string, attrs []transaction.Attribute, params ...any) (*transaction.Transaction, error) {
+	if script := a.estimatedCallScript(contract, method, params); script != nil {
+		return a.MakeUnsignedRun(script, attrs)
+	}
 	r, err := a.Call(contract, method, params...)
 	return a.makeUnsignedWrapper(r, err, attrs)
 }
@@ -141,6 +176,17 @@ func (a *Actor) MakeUnsignedCall(contract util.Uint160, method string, attrs []t
 // NetworkFee values. TransactionModifier is not applied to the result of this
 // method, but default attributes are used if attrs is nil.
 func (a *Actor) MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error) {
+	if a.useFeeEstimation() {
+		r, tx, err := a.makeEstimatedRun(script, attrs)
+		if err != nil {
+			return nil, err
+		}
+		err = DefaultCheckerModifier(r, tx)
+		if err != nil {
+			return nil, err
+		}
+		return tx, nil
+	}
 	r, err := a.Run(script)
 	return a.makeUnsignedWrapper(r, err, attrs)
 }
@@ -166,6 +212,10 @@ func (a *Actor) makeUnsignedWrapper(r *result.Invoke, err error, attrs []transac
 // exchanged via context.ParameterContext. TransactionModifier is not applied to
 // the result of this method, but default attributes are used if attrs is nil.
 func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []transaction.Attribute) (*transaction.Transaction, error) {
+	return a.makeUnsignedUncheckedRun(script, sysFee, attrs, a.txSigners)
+}
+
+func (a *Actor) makeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []transaction.Attribute, signers []transaction.Signer) (*transaction.Transaction, error) {
 	var err error
 
 	if len(script) == 0 {
@@ -179,7 +229,7 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 		attrs = a.opts.Attributes // Might as well be nil, but it's OK.
 	}
 	tx := transaction.New(script, sysFee)
-	tx.Signers = a.txSigners
+	tx.Signers = signers
 	tx.Attributes = attrs
 
 	tx.ValidUntilBlock, err = a.CalculateValidUntilBlock()
@@ -187,6 +237,24 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 		return nil, fmt.Errorf("calculating validUntilBlock: %w", err)
 	}
 
+	err = a.initWitnesses(tx)
+	if err != nil {
+		return nil, err
+	}
+	// CalculateNetworkFee doesn't call Hash or Size, only serializes the
+	// transaction via Bytes, so it's safe wrt internal caching.
+	tx.NetworkFee, err = a.client.CalculateNetworkFee(tx)
+	if err != nil {
+		return nil, fmt.Errorf("calculating network fee: %w", err)
+	}
+
+	return tx, nil
+}
+
+// initWitnesses fills transaction witnesses with the data needed for network
+// fee calculation: verification scripts of standard accounts and invocation
+// scripts of contract-based ones (if they can be built).
+func (a *Actor) initWitnesses(tx *transaction.Transaction) error {
 	tx.Scripts = make([]transaction.Witness, len(a.signers))
 	for i := range a.signers {
 		if !a.signers[i].Account.Contract.Deployed {
@@ -196,19 +264,12 @@ func (a *Actor) MakeUnsignedUncheckedRun(script []byte, sysFee int64, attrs []tr
 		if build := a.signers[i].Account.Contract.InvocationBuilder; build != nil {
 			invoc, err := build(tx)
 			if err != nil {
-				return nil, fmt.Errorf("building witness for contract signer: %w", err)
+				return fmt.Errorf("building witness for contract signer: %w", err)
 			}
 			tx.Scripts[i].InvocationScript = invoc
 		}
 	}
-	// CalculateNetworkFee doesn't call Hash or Size, only serializes the
-	// transaction via Bytes, so it's safe wrt internal caching.
-	tx.NetworkFee, err = a.client.CalculateNetworkFee(tx)
-	if err != nil {
-		return nil, fmt.Errorf("calculating network fee: %w", err)
-	}
-
-	return tx, nil
+	return nil
 }
 
 // CalculateValidUntilBlock returns correct ValidUntilBlock value for a new
@@ -0,0 +1,149 @@
+package actor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// MaxStepRefreshes is the maximum number of times a script-based step of
+// SendOrdered is recreated with a new ValidUntilBlock value and resent if the
+// previous transaction wasn't accepted to the chain.
+const MaxStepRefreshes = 3
+
+// ErrStepFault is returned (wrapped into StepError) from SendOrdered if step
+// transaction is accepted to the chain, but its execution ends in a state
+// different from HALT.
+var ErrStepFault = errors.New("transaction execution failed")
+
+// ScriptOrTx is a single step of SendOrdered pipeline. Exactly one of the
+// fields must be set.
+type ScriptOrTx struct {
+	// Script is a script to be executed in a transaction created by
+	// Actor (see MakeRun). The transaction is created right before it's
+	// sent, so the script is test-invoked with all previous steps already
+	// accepted to the chain. If the transaction is not accepted before its
+	// ValidUntilBlock, it's created and sent again (see MaxStepRefreshes).
+	Script []byte
+	// Tx is a ready-to-send signed transaction. It's sent as is and can't
+	// be refreshed, so it fails the pipeline if it's not accepted before
+	// its ValidUntilBlock.
+	Tx *transaction.Transaction
+}
+
+// StepResult is a result of a single SendOrdered step.
+type StepResult struct {
+	// Hash is the hash of the last transaction sent for this step.
+	Hash util.Uint256
+	// ValidUntilBlock is the ValidUntilBlock value of the last
+	// transaction sent for this step.
+	ValidUntilBlock uint32
+	// AppExecResult is the execution result of the transaction, it's nil
+	// if the transaction wasn't accepted to the chain.
+	AppExecResult *state.AppExecResult
+}
+
+// StepError is returned from SendOrdered if some step fails, it contains the
+// index of this step and the reason of its failure.
+type StepError struct {
+	// Index is the index of the failed step in the list of steps.
+	Index int
+	// Hash is the hash of the step transaction, it's zero if the failure
+	// happened before the transaction was created.
+	Hash util.Uint256
+	Err  error
+}
+
+// Error implements the error interface.
+func (e *StepError) Error() string {
+	if e.Hash.Equals(util.Uint256{}) {
+		return fmt.Sprintf("step #%d failed: %s", e.Index, e.Err)
+	}
+	return fmt.Sprintf("step #%d (%s) failed: %s", e.Index, e.Hash.StringLE(), e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *StepError) Unwrap() error {
+	return e.Err
+}
+
+// SendOrdered sends the given steps to the network one by one, each step is
+// sent only after the previous one is accepted to the chain and executed
+// successfully (with HALT state). It's useful for dependent transactions
+// like deploying a contract and then configuring it. Results of all
+// processed steps are returned (including the failed one), the error
+// returned is a *StepError if some step fails (including ErrStepFault for
+// steps executed with FAULT state). The context can be used to cancel the
+// process at any moment, but steps that are already sent can still be
+// accepted to the chain. Actor must support transaction awaiting (see
+// [waiter.Waiter]).
+func (a *Actor) SendOrdered(ctx context.Context, steps []ScriptOrTx) ([]StepResult, error) {
+	if _, ok := a.Waiter.(waiter.Null); ok {
+		return nil, waiter.ErrAwaitingNotSupported
+	}
+	for i := range steps {
+		if (steps[i].Script == nil) == (steps[i].Tx == nil) {
+			return nil, fmt.Errorf("step #%d: exactly one of script or transaction must be set", i)
+		}
+	}
+	res := make([]StepResult, 0, len(steps))
+	for i := range steps {
+		r, err := a.sendStep(ctx, steps[i])
+		if r != nil {
+			res = append(res, *r)
+		}
+		if err != nil {
+			se := &StepError{Index: i, Err: err}
+			if r != nil {
+				se.Hash = r.Hash
+			}
+			return res, se
+		}
+	}
+	return res, nil
+}
+
+// sendStep sends a single SendOrdered step and waits for its execution
+// result. It returns nil result if nothing was sent.
+func (a *Actor) sendStep(ctx context.Context, step ScriptOrTx) (*StepResult, error) {
+	var (
+		r   *StepResult
+		err error
+	)
+	for attempt := 0; attempt <= MaxStepRefreshes; attempt++ {
+		if err = ctx.Err(); err != nil {
+			return r, fmt.Errorf("%w: %w", waiter.ErrContextDone, err)
+		}
+		tx := step.Tx
+		if tx == nil {
+			tx, err = a.MakeRun(step.Script)
+			if err != nil {
+				return r, err
+			}
+		}
+		r = &StepResult{Hash: tx.Hash(), ValidUntilBlock: tx.ValidUntilBlock}
+		_, _, err = a.Send(tx)
+		if err != nil && !errors.Is(err, neorpc.ErrAlreadyExists) {
+			return r, err
+		}
+		r.AppExecResult, err = a.WaitAny(ctx, r.ValidUntilBlock, r.Hash)
+		if errors.Is(err, waiter.ErrTxNotAccepted) && step.Tx == nil {
+			continue
+		}
+		if err != nil {
+			return r, err
+		}
+		if r.AppExecResult.VMState != vmstate.Halt {
+			return r, fmt.Errorf("%w: %s state, %s", ErrStepFault, r.AppExecResult.VMState, r.AppExecResult.FaultException)
+		}
+		return r, nil
+	}
+	return r, err
+}
@@ -0,0 +1,209 @@
+package actor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/waiter"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// orderedRPC is an RPCClient accepting sent transactions to the chain
+// immediately, every call to GetBlockCount produces a new block.
+type orderedRPC struct {
+	*RPCClient
+
+	lock        sync.Mutex
+	sent        []*transaction.Transaction
+	accepted    map[util.Uint256]vmstate.State
+	faultScript []byte
+	dropCount   int
+}
+
+func (r *orderedRPC) GetBlockCount() (uint32, error) {
+	return r.bCount.Add(1), nil
+}
+
+func (r *orderedRPC) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	res := *r.invRes
+	res.Script = script
+	return &res, nil
+}
+
+func (r *orderedRPC) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.sent = append(r.sent, tx)
+	if r.dropCount > 0 {
+		r.dropCount--
+		return tx.Hash(), nil
+	}
+	if _, ok := r.accepted[tx.Hash()]; ok {
+		return tx.Hash(), neorpc.ErrAlreadyExists
+	}
+	r.accepted[tx.Hash()] = vmstate.Halt
+	if bytes.Equal(tx.Script, r.faultScript) {
+		r.accepted[tx.Hash()] = vmstate.Fault
+	}
+	return tx.Hash(), nil
+}
+
+func (r *orderedRPC) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	st, ok := r.accepted[hash]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &result.ApplicationLog{
+		Container: hash,
+		Executions: []state.Execution{{
+			Trigger: trigger.Application,
+			VMState: st,
+		}},
+	}, nil
+}
+
+func TestSendOrdered(t *testing.T) {
+	newActor := func(t *testing.T) (*Actor, *orderedRPC) {
+		client, acc := testRPCAndAccount(t)
+		client.version.Protocol.MillisecondsPerBlock = 10
+		client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3}
+		rpc := &orderedRPC{
+			RPCClient:   client,
+			accepted:    make(map[util.Uint256]vmstate.State),
+			faultScript: []byte{0xff},
+		}
+		a, err := NewSimple(rpc, acc)
+		require.NoError(t, err)
+		return a, rpc
+	}
+
+	t.Run("good", func(t *testing.T) {
+		a, rpc := newActor(t)
+		tx, err := a.MakeRun([]byte{2})
+		require.NoError(t, err)
+
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}, {Tx: tx}, {Script: []byte{3}}})
+		require.NoError(t, err)
+		require.Equal(t, 3, len(res))
+		require.Equal(t, 3, len(rpc.sent))
+		for i := range res {
+			require.Equal(t, rpc.sent[i].Hash(), res[i].Hash)
+			require.Equal(t, rpc.sent[i].ValidUntilBlock, res[i].ValidUntilBlock)
+			require.Equal(t, res[i].Hash, res[i].AppExecResult.Container)
+			require.Equal(t, vmstate.Halt, res[i].AppExecResult.VMState)
+		}
+		require.Equal(t, []byte{1}, rpc.sent[0].Script)
+		require.Equal(t, tx, rpc.sent[1])
+		require.Equal(t, []byte{3}, rpc.sent[2].Script)
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		a, rpc := newActor(t)
+		tx, err := a.MakeRun([]byte{2})
+		require.NoError(t, err)
+		_, err = rpc.SendRawTransaction(tx)
+		require.NoError(t, err)
+
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Tx: tx}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+		require.Equal(t, tx.Hash(), res[0].Hash)
+	})
+
+	t.Run("fault", func(t *testing.T) {
+		a, rpc := newActor(t)
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}, {Script: rpc.faultScript}, {Script: []byte{3}}})
+		require.ErrorIs(t, err, ErrStepFault)
+		var se *StepError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, 1, se.Index)
+		require.Equal(t, rpc.sent[1].Hash(), se.Hash)
+		require.Equal(t, 2, len(res))
+		require.Equal(t, 2, len(rpc.sent))
+		require.Equal(t, vmstate.Fault, res[1].AppExecResult.VMState)
+	})
+
+	t.Run("refresh", func(t *testing.T) {
+		a, rpc := newActor(t)
+		rpc.dropCount = 1
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}})
+		require.NoError(t, err)
+		require.Equal(t, 1, len(res))
+		require.Equal(t, 2, len(rpc.sent))
+		require.Greater(t, rpc.sent[1].ValidUntilBlock, rpc.sent[0].ValidUntilBlock)
+		require.Equal(t, rpc.sent[1].Hash(), res[0].Hash)
+	})
+
+	t.Run("refresh limit", func(t *testing.T) {
+		a, rpc := newActor(t)
+		rpc.dropCount = MaxStepRefreshes + 1
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}})
+		require.ErrorIs(t, err, waiter.ErrTxNotAccepted)
+		require.Equal(t, MaxStepRefreshes+1, len(rpc.sent))
+		require.Equal(t, 1, len(res))
+		require.Nil(t, res[0].AppExecResult)
+	})
+
+	t.Run("transaction not accepted", func(t *testing.T) {
+		a, rpc := newActor(t)
+		tx, err := a.MakeRun([]byte{2})
+		require.NoError(t, err)
+		rpc.dropCount = 1
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Tx: tx}, {Script: []byte{1}}})
+		require.ErrorIs(t, err, waiter.ErrTxNotAccepted)
+		var se *StepError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, 0, se.Index)
+		require.Equal(t, 1, len(rpc.sent))
+		require.Equal(t, 1, len(res))
+	})
+
+	t.Run("make failure", func(t *testing.T) {
+		a, rpc := newActor(t)
+		rpc.invRes = &result.Invoke{State: "FAULT", FaultException: "bad"}
+		res, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}})
+		var se *StepError
+		require.ErrorAs(t, err, &se)
+		require.Equal(t, 0, se.Index)
+		require.Equal(t, util.Uint256{}, se.Hash)
+		require.Equal(t, 0, len(res))
+		require.Equal(t, 0, len(rpc.sent))
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		a, rpc := newActor(t)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := a.SendOrdered(ctx, []ScriptOrTx{{Script: []byte{1}}})
+		require.ErrorIs(t, err, waiter.ErrContextDone)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Equal(t, 0, len(rpc.sent))
+	})
+
+	t.Run("invalid steps", func(t *testing.T) {
+		a, _ := newActor(t)
+		_, err := a.SendOrdered(context.Background(), []ScriptOrTx{{}})
+		require.Error(t, err)
+		_, err = a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}, Tx: new(transaction.Transaction)}})
+		require.Error(t, err)
+	})
+
+	t.Run("awaiting not supported", func(t *testing.T) {
+		a, _ := newActor(t)
+		a.Waiter = waiter.NewNull()
+		_, err := a.SendOrdered(context.Background(), []ScriptOrTx{{Script: []byte{1}}})
+		require.ErrorIs(t, err, waiter.ErrAwaitingNotSupported)
+	})
+}
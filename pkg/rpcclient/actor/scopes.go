@@ -0,0 +1,206 @@
+package actor
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+)
+
+// RPCInvokeDiagnostics is an optional RPCActor extension that allows to
+// test-invoke scripts getting diagnostic data (including the invocation tree)
+// along with the invocation result. It's required for signer scope suggestions
+// (see Options), both rpcclient.Client and rpcclient.WSClient implement it.
+type RPCInvokeDiagnostics interface {
+	InvokeScriptWithDiagnostics(script []byte, signers []transaction.Signer) (*result.Invoke, error)
+}
+
+// ScopeSuggestion contains signer scopes that are enough for the script to be
+// executed as it was executed in test invocation. It's passed to
+// ScopeSuggester.
+type ScopeSuggestion struct {
+	// Result is the test invocation result with diagnostic data that was
+	// used to make the suggestion.
+	Result *result.Invoke
+	// Current is the list of Actor signers.
+	Current []transaction.Signer
+	// Suggested is the list of signers with minimal scopes, signers
+	// are in the same order as in Current. Suggested scopes can be
+	// wider than the current ones (like when some nested contract
+	// call fails because of insufficient scope).
+	Suggested []transaction.Signer
+	// Applied is set when the transaction is created with signers
+	// restricted to the suggested scopes (see Options.AutoRestrictScopes).
+	// Only the signers that are not widened by the suggestion are
+	// restricted in this case, others are left as is.
+	Applied bool
+}
+
+// ScopeSuggester is a callback that receives signer scope suggestions made
+// for every transaction created via MakeCall/MakeRun (and their Tuned/Send
+// variants). It's called before the transaction is signed.
+type ScopeSuggester func(s ScopeSuggestion)
+
+// SuggestScopes returns a list of signers with minimal scopes allowing the
+// given invocation trees (as returned in the diagnostic data of test
+// invocation) to be executed. For signers using Global or CalledByEntry scope
+// the suggestion is CalledByEntry with contracts called from other contracts
+// added as CustomContracts. For signers using CustomContracts scope all invoked
+// contracts are suggested. Signers with None, CustomGroups or Rules scopes are
+// returned as is. Notice that the invocation tree doesn't say which contract
+// actually checks the witness, so all contracts from it are considered to
+// require one.
+func SuggestScopes(signers []transaction.Signer, trees []*invocations.Tree) []transaction.Signer {
+	var (
+		direct = make(map[util.Uint160]bool)
+		all    []util.Uint160
+		nested []util.Uint160
+		seen   = make(map[util.Uint160]bool)
+	)
+	for _, entry := range trees {
+		for _, c := range entry.Calls {
+			direct[c.Current] = true
+		}
+	}
+	var walk func(t *invocations.Tree, depth int)
+	walk = func(t *invocations.Tree, depth int) {
+		if depth > 0 && !seen[t.Current] {
+			seen[t.Current] = true
+			all = append(all, t.Current)
+			if !direct[t.Current] {
+				nested = append(nested, t.Current)
+			}
+		}
+		for _, c := range t.Calls {
+			walk(c, depth+1)
+		}
+	}
+	for _, entry := range trees {
+		walk(entry, 0)
+	}
+
+	res := make([]transaction.Signer, len(signers))
+	for i, s := range signers {
+		res[i] = s
+		switch {
+		case s.Scopes == transaction.None,
+			s.Scopes&(transaction.CustomGroups|transaction.Rules) != 0:
+			// Can't be analyzed reliably, leave it to the user.
+		case s.Scopes&(transaction.Global|transaction.CalledByEntry) != 0:
+			res[i] = transaction.Signer{Account: s.Account, Scopes: transaction.CalledByEntry}
+			if len(nested) != 0 {
+				res[i].Scopes |= transaction.CustomContracts
+				res[i].AllowedContracts = append([]util.Uint160(nil), nested...)
+			}
+		case len(all) != 0: // CustomContracts only.
+			res[i] = transaction.Signer{
+				Account:          s.Account,
+				Scopes:           transaction.CustomContracts,
+				AllowedContracts: append([]util.Uint160(nil), all...),
+			}
+		}
+	}
+	return res
+}
+
+// isScopeWithin checks whether s scope is the same as or narrower than the cur
+// one. It's conservative, false is returned when it can't be proven.
+func isScopeWithin(s, cur transaction.Signer) bool {
+	if s.Account != cur.Account {
+		return false
+	}
+	if cur.Scopes&transaction.Global != 0 {
+		return true
+	}
+	if s.Scopes&(transaction.Global|transaction.CustomGroups|transaction.Rules) != 0 {
+		return false
+	}
+	if s.Scopes&transaction.CalledByEntry != 0 && cur.Scopes&transaction.CalledByEntry == 0 {
+		return false
+	}
+	if s.Scopes&transaction.CustomContracts != 0 {
+		if cur.Scopes&transaction.CustomContracts == 0 {
+			return false
+		}
+		for _, h := range s.AllowedContracts {
+			var found bool
+			for _, allowed := range cur.AllowedContracts {
+				if h == allowed {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// restrictScopes returns a list of signers where current ones are replaced by
+// the suggested ones if that doesn't widen their scopes. nil is returned if
+// there is nothing to restrict.
+func restrictScopes(current, suggested []transaction.Signer) []transaction.Signer {
+	var (
+		changed bool
+		res     = make([]transaction.Signer, len(current))
+	)
+	for i := range current {
+		res[i] = current[i]
+		if isScopeWithin(suggested[i], current[i]) && !isScopeWithin(current[i], suggested[i]) {
+			res[i] = suggested[i]
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return res
+}
+
+// suggestScopes performs diagnostic test invocation of the script from the
+// given result and makes scope suggestions for it. It returns the invocation
+// result and signers to be used for the transaction, these are restricted
+// only if AutoRestrictScopes is enabled and the script still HALTs with them.
+func (a *Actor) suggestScopes(r *result.Invoke) (*result.Invoke, []transaction.Signer, error) {
+	diag, ok := a.client.(RPCInvokeDiagnostics)
+	if !ok {
+		return r, a.txSigners, nil
+	}
+	dr, err := diag.InvokeScriptWithDiagnostics(r.Script, a.txSigners)
+	if err != nil {
+		return nil, nil, fmt.Errorf("diagnostic test invocation failed: %w", err)
+	}
+	if dr.Diagnostics == nil {
+		return r, a.txSigners, nil
+	}
+	var (
+		signers = a.txSigners
+		s       = ScopeSuggestion{
+			Result:    dr,
+			Current:   a.txSigners,
+			Suggested: SuggestScopes(a.txSigners, dr.Diagnostics.Invocations),
+		}
+	)
+	if a.opts.AutoRestrictScopes && r.State == vmstate.Halt.String() {
+		if restricted := restrictScopes(a.txSigners, s.Suggested); restricted != nil {
+			rr, err := a.client.InvokeScript(r.Script, restricted)
+			if err != nil {
+				return nil, nil, fmt.Errorf("test invocation with restricted scopes failed: %w", err)
+			}
+			if rr.State == vmstate.Halt.String() {
+				r = rr
+				signers = restricted
+				s.Applied = true
+			}
+		}
+	}
+	if a.opts.ScopeSuggester != nil {
+		a.opts.ScopeSuggester(s)
+	}
+	return r, signers, nil
+}
@@ -0,0 +1,222 @@
+package actor
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
+	"github.com/stretchr/testify/require"
+)
+
+type diagRPCClient struct {
+	*RPCClient
+	diagRes     *result.Invoke
+	diagSigners []transaction.Signer
+	runSigners  []transaction.Signer
+}
+
+func (r *diagRPCClient) InvokeScriptWithDiagnostics(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	r.diagSigners = signers
+	return r.diagRes, r.err
+}
+
+func (r *diagRPCClient) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	r.runSigners = signers
+	return r.invRes, r.err
+}
+
+func TestSuggestScopes(t *testing.T) {
+	var (
+		acc      = util.Uint160{0xff}
+		a, b, c  = util.Uint160{1}, util.Uint160{2}, util.Uint160{3}
+		contrs   = []util.Uint160{a}
+		trees    = []*invocations.Tree{{Calls: []*invocations.Tree{{Current: a, Calls: []*invocations.Tree{{Current: b}, {Current: a}}}, {Current: c}}}}
+		flatTree = []*invocations.Tree{{Calls: []*invocations.Tree{{Current: a}}}}
+	)
+	for name, tc := range map[string]struct {
+		signer   transaction.Signer
+		trees    []*invocations.Tree
+		expected transaction.Signer
+	}{
+		"none": {
+			signer:   transaction.Signer{Account: acc, Scopes: transaction.None},
+			trees:    trees,
+			expected: transaction.Signer{Account: acc, Scopes: transaction.None},
+		},
+		"global, direct calls only": {
+			signer:   transaction.Signer{Account: acc, Scopes: transaction.Global},
+			trees:    flatTree,
+			expected: transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry},
+		},
+		"global, nested calls": {
+			signer: transaction.Signer{Account: acc, Scopes: transaction.Global},
+			trees:  trees,
+			expected: transaction.Signer{
+				Account:          acc,
+				Scopes:           transaction.CalledByEntry | transaction.CustomContracts,
+				AllowedContracts: []util.Uint160{b},
+			},
+		},
+		"called by entry, nested calls": {
+			signer: transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry},
+			trees:  trees,
+			expected: transaction.Signer{
+				Account:          acc,
+				Scopes:           transaction.CalledByEntry | transaction.CustomContracts,
+				AllowedContracts: []util.Uint160{b},
+			},
+		},
+		"custom contracts, nested calls": {
+			signer: transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: contrs},
+			trees:  trees,
+			expected: transaction.Signer{
+				Account:          acc,
+				Scopes:           transaction.CustomContracts,
+				AllowedContracts: []util.Uint160{a, b, c},
+			},
+		},
+		"custom contracts, no calls": {
+			signer:   transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: contrs},
+			trees:    []*invocations.Tree{{}},
+			expected: transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: contrs},
+		},
+		"rules": {
+			signer:   transaction.Signer{Account: acc, Scopes: transaction.Rules},
+			trees:    trees,
+			expected: transaction.Signer{Account: acc, Scopes: transaction.Rules},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, []transaction.Signer{tc.expected}, SuggestScopes([]transaction.Signer{tc.signer}, tc.trees))
+		})
+	}
+}
+
+func TestIsScopeWithin(t *testing.T) {
+	var (
+		acc = util.Uint160{0xff}
+		a   = util.Uint160{1}
+		b   = util.Uint160{2}
+	)
+	require.True(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry},
+		transaction.Signer{Account: acc, Scopes: transaction.Global}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.Global},
+		transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry},
+		transaction.Signer{Account: util.Uint160{}, Scopes: transaction.Global}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry},
+		transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{a}}))
+	require.True(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{a}},
+		transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{b, a}}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{a, b}},
+		transaction.Signer{Account: acc, Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{a}}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry | transaction.CustomContracts, AllowedContracts: []util.Uint160{a}},
+		transaction.Signer{Account: acc, Scopes: transaction.CalledByEntry}))
+	require.False(t, isScopeWithin(transaction.Signer{Account: acc, Scopes: transaction.Rules},
+		transaction.Signer{Account: acc, Scopes: transaction.Rules}))
+}
+
+func TestScopeSuggestions(t *testing.T) {
+	rpc, acc := testRPCAndAccount(t)
+	client := &diagRPCClient{RPCClient: rpc}
+	var (
+		script = []byte{1, 2, 3}
+		a, b   = util.Uint160{1}, util.Uint160{2}
+		tree   = []*invocations.Tree{{Calls: []*invocations.Tree{{Current: a, Calls: []*invocations.Tree{{Current: b}}}}}}
+		sugg   []ScopeSuggestion
+		opts   = Options{
+			ScopeSuggester:     func(s ScopeSuggestion) { sugg = append(sugg, s) },
+			AutoRestrictScopes: true,
+		}
+		newActor = func(t *testing.T, s transaction.Signer) *Actor {
+			s.Account = acc.ScriptHash()
+			act, err := NewTuned(client, []SignerAccount{{Signer: s, Account: acc}}, opts)
+			require.NoError(t, err)
+			return act
+		}
+	)
+
+	t.Run("unsupported client", func(t *testing.T) {
+		_, err := NewTuned(rpc, []SignerAccount{{Signer: transaction.Signer{Account: acc.ScriptHash()}, Account: acc}}, opts)
+		require.Error(t, err)
+	})
+
+	t.Run("nested call requires allowed contract", func(t *testing.T) {
+		sugg = nil
+		client.invRes = &result.Invoke{State: "FAULT", GasConsumed: 3, Script: script}
+		client.diagRes = &result.Invoke{State: "FAULT", GasConsumed: 3, Script: script,
+			Diagnostics: &result.InvokeDiag{Invocations: tree}}
+		act := newActor(t, transaction.Signer{Scopes: transaction.CustomContracts, AllowedContracts: []util.Uint160{a}})
+
+		_, err := act.MakeRun(script)
+		require.Error(t, err) // Still FAULTs, scopes are never widened.
+		require.Equal(t, 1, len(sugg))
+		require.False(t, sugg[0].Applied)
+		require.Equal(t, act.txSigners, sugg[0].Current)
+		require.Equal(t, []transaction.Signer{{
+			Account:          acc.ScriptHash(),
+			Scopes:           transaction.CustomContracts,
+			AllowedContracts: []util.Uint160{a, b},
+		}}, sugg[0].Suggested)
+		require.Equal(t, act.txSigners, client.diagSigners)
+
+		// Ignoring the error doesn't lead to widening either.
+		tx, err := act.MakeTunedRun(script, nil, func(r *result.Invoke, t *transaction.Transaction) error { return nil })
+		require.NoError(t, err)
+		require.Equal(t, act.txSigners, tx.Signers)
+	})
+
+	t.Run("global is restricted", func(t *testing.T) {
+		sugg = nil
+		client.runSigners = nil
+		client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+		client.diagRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script,
+			Diagnostics: &result.InvokeDiag{Invocations: tree}}
+		act := newActor(t, transaction.Signer{Scopes: transaction.Global})
+
+		tx, err := act.MakeCall(a, "method")
+		require.NoError(t, err)
+		expected := []transaction.Signer{{
+			Account:          acc.ScriptHash(),
+			Scopes:           transaction.CalledByEntry | transaction.CustomContracts,
+			AllowedContracts: []util.Uint160{b},
+		}}
+		require.Equal(t, expected, tx.Signers)
+		require.Equal(t, expected, client.runSigners)
+		require.Equal(t, 1, len(sugg))
+		require.True(t, sugg[0].Applied)
+		require.Equal(t, expected, sugg[0].Suggested)
+		require.Equal(t, transaction.Global, act.txSigners[0].Scopes)
+
+		// Restricted scopes make the script fail, so they're not applied.
+		sugg = nil
+		client.invRes = &result.Invoke{State: "FAULT", GasConsumed: 3, Script: script}
+		client.diagRes.State = "HALT"
+		tx, err = act.MakeTunedRun(script, nil, func(r *result.Invoke, t *transaction.Transaction) error { return nil })
+		require.NoError(t, err)
+		require.Equal(t, act.txSigners, tx.Signers)
+		require.Equal(t, 1, len(sugg))
+		require.False(t, sugg[0].Applied)
+	})
+
+	t.Run("suggestion only", func(t *testing.T) {
+		sugg = nil
+		client.invRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script}
+		client.diagRes = &result.Invoke{State: "HALT", GasConsumed: 3, Script: script,
+			Diagnostics: &result.InvokeDiag{Invocations: tree}}
+		act, err := NewTuned(client, []SignerAccount{{
+			Signer:  transaction.Signer{Account: acc.ScriptHash(), Scopes: transaction.Global},
+			Account: acc,
+		}}, Options{ScopeSuggester: opts.ScopeSuggester})
+		require.NoError(t, err)
+
+		tx, err := act.MakeRun(script)
+		require.NoError(t, err)
+		require.Equal(t, act.txSigners, tx.Signers)
+		require.Equal(t, 1, len(sugg))
+		require.False(t, sugg[0].Applied)
+		require.Equal(t, transaction.CalledByEntry|transaction.CustomContracts, sugg[0].Suggested[0].Scopes)
+	})
+}
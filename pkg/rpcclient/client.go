@@ -2,18 +2,27 @@ package rpcclient
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -68,6 +77,12 @@ type Options struct {
 	RequestTimeout time.Duration
 	// Limit total number of connections per host. No limit by default.
 	MaxConnsPerHost int
+	// AdminKey is used to sign admin method calls (like
+	// ResetNativeCallStats) for servers requiring admin requests to be
+	// signed, usually it's a key of an unlocked wallet account (see
+	// wallet.Account.PrivateKey). Other requests are never signed. Signed
+	// requests are only supported by HTTP client (not WSClient).
+	AdminKey *keys.PrivateKey
 }
 
 // cache stores cache values for the RPC client methods.
@@ -147,9 +162,21 @@ func (c *Client) Init() error {
 	if err != nil {
 		return fmt.Errorf("failed to get network magic: %w", err)
 	}
-	natives, err := c.GetNativeContracts()
-	if err != nil {
-		return fmt.Errorf("failed to get native contracts: %w", err)
+	// Servers not reporting native contracts in getversion need an
+	// additional request.
+	var nativeHashes = make(map[string]util.Uint160)
+	if len(version.Protocol.NativeContracts) != 0 {
+		for _, ctr := range version.Protocol.NativeContracts {
+			nativeHashes[ctr.Name] = ctr.Hash
+		}
+	} else {
+		natives, err := c.GetNativeContracts()
+		if err != nil {
+			return fmt.Errorf("failed to get native contracts: %w", err)
+		}
+		for _, ctr := range natives {
+			nativeHashes[ctr.Manifest.Name] = ctr.Hash
+		}
 	}
 
 	c.cacheLock.Lock()
@@ -157,8 +184,8 @@ func (c *Client) Init() error {
 
 	c.cache.network = version.Protocol.Network
 	c.cache.stateRootInHeader = version.Protocol.StateRootInHeader
-	for _, ctr := range natives {
-		c.cache.nativeHashes[ctr.Manifest.Name] = ctr.Hash
+	for name, h := range nativeHashes {
+		c.cache.nativeHashes[name] = h
 	}
 
 	c.cache.initDone = true
@@ -204,19 +231,34 @@ func (c *Client) makeHTTPRequest(r *neorpc.Request) (*neorpc.Response, error) {
 		return nil, err
 	}
 
+	var reqBody = buf.Bytes()
 	req, err := http.NewRequest("POST", c.endpoint.String(), buf)
 	if err != nil {
 		return nil, err
 	}
+	if c.opts.AdminKey != nil && neorpc.IsAdminMethod(r.Method) {
+		if err := signAdminRequest(req.Header, c.opts.AdminKey, reqBody); err != nil {
+			return nil, err
+		}
+	}
+	// Setting it explicitly disables transparent decompression made by
+	// http.Transport, so it's handled below.
+	req.Header.Set("Accept-Encoding", "gzip, deflate")
 	resp, err := c.cli.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	body, err := decompressBody(resp)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
 	// The node might send us a proper JSON anyway, so look there first and if
 	// it parses, it has more relevant data than HTTP error code.
-	err = json.NewDecoder(resp.Body).Decode(raw)
+	err = json.NewDecoder(body).Decode(raw)
 	if err != nil {
 		if resp.StatusCode != http.StatusOK {
 			err = fmt.Errorf("HTTP %d/%s", resp.StatusCode, http.StatusText(resp.StatusCode))
@@ -230,6 +272,29 @@ func (c *Client) makeHTTPRequest(r *neorpc.Request) (*neorpc.Response, error) {
 	return raw, nil
 }
 
+// decompressBody returns a reader for the decompressed response body
+// according to its Content-Encoding.
+func decompressBody(resp *http.Response) (io.ReadCloser, error) {
+	switch enc := strings.ToLower(resp.Header.Get("Content-Encoding")); enc {
+	case "":
+		return io.NopCloser(resp.Body), nil
+	case "gzip":
+		r, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("gzip response: %w", err)
+		}
+		return r, nil
+	case "deflate":
+		r, err := zlib.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("deflate response: %w", err)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf("unsupported response encoding %q", enc)
+	}
+}
+
 // Ping attempts to create a connection to the endpoint
 // and returns an error if there is any.
 func (c *Client) Ping() error {
@@ -250,3 +315,22 @@ func (c *Client) Context() context.Context {
 func (c *Client) Endpoint() string {
 	return c.endpoint.String()
 }
+
+// signAdminRequest adds admin request signature headers for the given request
+// body.
+func signAdminRequest(h http.Header, key *keys.PrivateKey, body []byte) error {
+	var nonce = make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	var (
+		ts       = time.Now().UnixMilli()
+		nonceStr = hex.EncodeToString(nonce)
+		sig      = key.SignHash(neorpc.AdminRequestHash(ts, nonceStr, body))
+	)
+	h.Set(neorpc.AdminKeyHeader, hex.EncodeToString(key.PublicKey().Bytes()))
+	h.Set(neorpc.AdminTimestampHeader, strconv.FormatInt(ts, 10))
+	h.Set(neorpc.AdminNonceHeader, nonceStr)
+	h.Set(neorpc.AdminSignatureHeader, base64.StdEncoding.EncodeToString(sig))
+	return nil
+}
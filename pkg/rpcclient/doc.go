@@ -47,6 +47,13 @@ These layers are:
     example of how contract-specific wrappers can be built for other dApps
     (reusing invoker/actor layers it's pretty easy).
 
+  - Aggregating helpers like accountinfo package that collects data about an
+    account from various RPC methods and contracts.
+
+  - Mempool monitor (mempoolmon package) that wraps WSClient transaction
+    subscription into a reconnecting stream of typed and filtered events
+    (NEP-17 transfers, contract calls) derived from transaction scripts.
+
 # Client
 
 After creating a client instance with or without a ClientConfig
@@ -98,6 +105,7 @@ Extensions:
 	getblocksysfee
 	getrawnotarypool
 	getrawnotarytransaction
+	getverifiablestate
 	submitnotaryrequest
 
 Unsupported methods
@@ -0,0 +1,84 @@
+package invoker_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+// benchCalls is the number of calls made per benchmark iteration, accounts
+// repeat every benchAccounts calls.
+const (
+	benchCalls    = 1000
+	benchAccounts = 100
+)
+
+func newBenchServer(b *testing.B) (*rpcclient.Client, *atomic.Int64) {
+	var requests = new(atomic.Int64)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		requests.Add(1)
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":{"state":"HALT","gasconsumed":"1234",`+
+			`"script":"","stack":[{"type":"Integer","value":"42"}],"notifications":[]}}`, req.ID)
+	}))
+	b.Cleanup(srv.Close)
+	c, err := rpcclient.New(context.Background(), srv.URL, rpcclient.Options{})
+	require.NoError(b, err)
+	b.Cleanup(c.Close)
+	return c, requests
+}
+
+// benchmarkCalls makes benchCalls calls with a new Invoker every iteration.
+func benchmarkCalls(b *testing.B, newInv func() *invoker.Invoker, requests *atomic.Int64) {
+	var accounts = make([]util.Uint160, benchAccounts)
+	for i := range accounts {
+		accounts[i] = util.Uint160{byte(i), byte(i >> 8)}
+	}
+	requests.Store(0)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		inv := newInv()
+		for i := 0; i < benchCalls; i++ {
+			_, err := inv.Call(util.Uint160{1, 2, 3}, "balanceOf", accounts[i%benchAccounts])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(requests.Load())/float64(b.N), "rpc/op")
+}
+
+func BenchmarkInvokerCalls(b *testing.B) {
+	c, requests := newBenchServer(b)
+	b.Run("regular", func(b *testing.B) {
+		benchmarkCalls(b, func() *invoker.Invoker { return invoker.New(c, nil) }, requests)
+	})
+	b.Run("cached scripts", func(b *testing.B) {
+		benchmarkCalls(b, func() *invoker.Invoker {
+			return invoker.NewTuned(c, nil, invoker.Options{CacheScripts: true})
+		}, requests)
+	})
+	b.Run("historic", func(b *testing.B) {
+		benchmarkCalls(b, func() *invoker.Invoker { return invoker.NewHistoricWithState(util.Uint256{1}, c, nil) }, requests)
+	})
+	b.Run("pinned", func(b *testing.B) {
+		benchmarkCalls(b, func() *invoker.Invoker { return invoker.NewAtRoot(util.Uint256{1}, c, nil) }, requests)
+	})
+}
@@ -0,0 +1,184 @@
+package invoker
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// maxPinnedResults is the maximum number of invocation results kept by the
+// Invoker created with NewAtRoot.
+const maxPinnedResults = 4096
+
+// scriptKey identifies a script template. maxItems is negative for regular
+// calls.
+type scriptKey struct {
+	contract util.Uint160
+	method   string
+	params   int
+	maxItems int
+}
+
+// scriptTemplate is a script with parameters missing, they're to be pushed
+// between the head and the tail.
+type scriptTemplate struct {
+	head []byte
+	tail []byte
+}
+
+// scriptCache keeps script templates for contract calls.
+type scriptCache struct {
+	lock      sync.RWMutex
+	templates map[scriptKey]scriptTemplate
+}
+
+// pinnedConverter is a historicConverter reusing results of repeated script
+// invocations, which is only valid for a fixed state root.
+type pinnedConverter struct {
+	historicConverter
+
+	lock    sync.RWMutex
+	results map[string]*result.Invoke
+}
+
+func newScriptCache() *scriptCache {
+	return &scriptCache{templates: make(map[scriptKey]scriptTemplate)}
+}
+
+// callScript returns the same script as smartcontract.CreateCallScript does.
+func (c *scriptCache) callScript(contract util.Uint160, method string, params []any) ([]byte, error) {
+	k := scriptKey{contract: contract, method: method, params: len(params), maxItems: -1}
+	t, err := c.template(k, func() ([]byte, []byte, error) {
+		w := io.NewBufBinWriter()
+		emit.AppCallNoArgs(w.BinWriter, contract, method, callflag.All)
+		if w.Err != nil {
+			return nil, nil, w.Err
+		}
+		return nil, w.Bytes(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.script(params)
+}
+
+// expandIteratorScript returns the same script as
+// smartcontract.CreateCallAndUnwrapIteratorScript does.
+func (c *scriptCache) expandIteratorScript(contract util.Uint160, method string, maxItems int, params []any) ([]byte, error) {
+	k := scriptKey{contract: contract, method: method, params: len(params), maxItems: maxItems}
+	t, err := c.template(k, func() ([]byte, []byte, error) {
+		w := io.NewBufBinWriter()
+		emit.Int(w.BinWriter, int64(maxItems))
+		head := w.Bytes()
+		script, err := smartcontract.CreateCallAndUnwrapIteratorScript(contract, method, maxItems)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Parameter-less script has an empty array pushed after the head,
+		// everything after it doesn't depend on parameters (jumps are
+		// relative).
+		if len(script) <= len(head) || opcode.Opcode(script[len(head)]) != opcode.NEWARRAY0 {
+			return nil, nil, errors.New("unexpected script layout")
+		}
+		return head, script[len(head)+1:], nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t.script(params)
+}
+
+// template returns the cached template for the given key creating it with
+// the given function if needed.
+func (c *scriptCache) template(k scriptKey, create func() ([]byte, []byte, error)) (scriptTemplate, error) {
+	c.lock.RLock()
+	t, ok := c.templates[k]
+	c.lock.RUnlock()
+	if ok {
+		return t, nil
+	}
+	head, tail, err := create()
+	if err != nil {
+		return t, err
+	}
+	w := io.NewBufBinWriter()
+	if k.params == 0 {
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0)
+	} else {
+		emit.Int(w.BinWriter, int64(k.params))
+		emit.Opcodes(w.BinWriter, opcode.PACK)
+	}
+	w.WriteBytes(tail)
+	if w.Err != nil {
+		return t, w.Err
+	}
+	t = scriptTemplate{head: head, tail: w.Bytes()}
+	c.lock.Lock()
+	c.templates[k] = t
+	c.lock.Unlock()
+	return t, nil
+}
+
+// script creates a script with the given parameters.
+func (t scriptTemplate) script(params []any) ([]byte, error) {
+	w := io.NewBufBinWriter()
+	w.WriteBytes(t.head)
+	for i := len(params) - 1; i >= 0; i-- {
+		emit.Any(w.BinWriter, params[i])
+	}
+	w.WriteBytes(t.tail)
+	if w.Err != nil {
+		return nil, w.Err
+	}
+	return w.Bytes(), nil
+}
+
+// InvokeScript implements RPCInvoke reusing previous results for the same
+// script. Signers are not a part of the key, since they're fixed for the
+// Invoker.
+func (p *pinnedConverter) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	p.lock.RLock()
+	res, ok := p.results[string(script)]
+	p.lock.RUnlock()
+	if ok {
+		cp := *res
+		cp.Stack = append([]stackitem.Item(nil), res.Stack...)
+		return &cp, nil
+	}
+	res, err := p.historicConverter.InvokeScript(script, signers)
+	if err != nil || !reusableResult(res) {
+		return res, err
+	}
+	p.lock.Lock()
+	if len(p.results) < maxPinnedResults {
+		cp := *res
+		cp.Stack = append([]stackitem.Item(nil), res.Stack...)
+		p.results[string(script)] = &cp
+	}
+	p.lock.Unlock()
+	return res, nil
+}
+
+// reusableResult checks whether the result can be returned for repeated
+// invocations, iterators (and sessions) can't be shared.
+func reusableResult(res *result.Invoke) bool {
+	if res.Session != uuid.Nil {
+		return false
+	}
+	for _, it := range res.Stack {
+		if it.Type() == stackitem.InteropT {
+			return false
+		}
+	}
+	return true
+}
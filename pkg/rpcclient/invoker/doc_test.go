@@ -45,6 +45,14 @@ func ExampleInvoker() {
 	balance, _ = unwrap.BigInt(inv.Call(neo.Hash, "balanceOf", acc))
 	_ = balance
 
+	// An invoker pinned to some state root for a batch of calls, repeated
+	// calls are answered without RPC requests.
+	inv = invoker.NewAtRoot(util.Uint256{1, 2, 3}, c, nil)
+	for i := 0; i < 1000; i++ {
+		balance, _ = unwrap.BigInt(inv.Call(neo.Hash, "balanceOf", acc))
+		_ = balance
+	}
+
 	// This invoker has a signer for NVTiAjNgagDkTr5HTzDmQP9kPwPHN5BgVq account with
 	// CalledByEntry scope, which is sufficient for most operation. It uses current
 	// state which is exactly what you need if you want to then create a transaction
@@ -66,6 +66,17 @@ type RPCInvokeHistoric interface {
 type Invoker struct {
 	client  RPCInvoke
 	signers []transaction.Signer
+	scripts *scriptCache
+}
+
+// Options are used to create Invoker with non-default behavior.
+type Options struct {
+	// CacheScripts makes Invoker build scripts for Call and
+	// CallAndExpandIterator from the templates cached per contract, method
+	// and number of parameters (Call then uses InvokeScript instead of
+	// InvokeFunction). It saves allocations for repeated calls of the same
+	// methods.
+	CacheScripts bool
 }
 
 type historicConverter struct {
@@ -79,7 +90,17 @@ type historicConverter struct {
 // (but contract-specific in general case) it's OK to pass nil for signers (that
 // is, use no signers).
 func New(client RPCInvoke, signers []transaction.Signer) *Invoker {
-	return &Invoker{client, signers}
+	return &Invoker{client: client, signers: signers}
+}
+
+// NewTuned creates an Invoker to test-execute things at the current blockchain
+// height (like New) with the given Options.
+func NewTuned(client RPCInvoke, signers []transaction.Signer, opts Options) *Invoker {
+	inv := New(client, signers)
+	if opts.CacheScripts {
+		inv.scripts = newScriptCache()
+	}
+	return inv
 }
 
 // NewHistoricAtHeight creates an Invoker to test-execute things at some given height.
@@ -99,6 +120,23 @@ func NewHistoricWithState(rootOrBlock util.Uint256, client RPCInvokeHistoric, si
 	}, signers)
 }
 
+// NewAtRoot creates an Invoker pinned to the given state root (or block) for
+// a batch of calls. It caches scripts (see Options) and since the state can't
+// change, it also reuses results of repeated invocations of the same scripts
+// (except the ones returning iterators) without making RPC requests. Iterator
+// sessions are created by the server for the same state, so iterator
+// traversal is bound to this root as well. The Invoker is safe for concurrent
+// use.
+func NewAtRoot(rootOrBlock util.Uint256, client RPCInvokeHistoric, signers []transaction.Signer) *Invoker {
+	return NewTuned(&pinnedConverter{
+		historicConverter: historicConverter{
+			client: client,
+			root:   &rootOrBlock,
+		},
+		results: make(map[string]*result.Invoke),
+	}, signers, Options{CacheScripts: true})
+}
+
 func (h *historicConverter) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
 	if h.height != nil {
 		return h.client.InvokeScriptAtHeight(*h.height, script, signers)
@@ -140,6 +178,14 @@ func (h *historicConverter) TraverseIterator(sessionID, iteratorID uuid.UUID, ma
 // Call invokes a method of the contract with the given parameters (and
 // Invoker-specific list of signers) and returns the result as is.
 func (v *Invoker) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	if v.scripts != nil {
+		// Parameters not supported by the script emitter (like
+		// smartcontract.Parameter) are passed via InvokeFunction.
+		script, err := v.scripts.callScript(contract, operation, params)
+		if err == nil {
+			return v.Run(script)
+		}
+	}
 	ps, err := smartcontract.NewParametersFromValues(params...)
 	if err != nil {
 		return nil, err
@@ -156,6 +202,13 @@ func (v *Invoker) Call(contract util.Uint160, operation string, params ...any) (
 // from the iterator returned from the contract's method call. This script is executed
 // using regular JSON-API (according to the way Iterator is set up).
 func (v *Invoker) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error) {
+	if v.scripts != nil {
+		script, err := v.scripts.expandIteratorScript(contract, method, maxItems, params)
+		if err != nil {
+			return nil, fmt.Errorf("iterator unwrapper script: %w", err)
+		}
+		return v.Run(script)
+	}
 	bytes, err := smartcontract.CreateCallAndUnwrapIteratorScript(contract, method, maxItems, params...)
 	if err != nil {
 		return nil, fmt.Errorf("iterator unwrapper script: %w", err)
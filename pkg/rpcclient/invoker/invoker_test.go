@@ -105,6 +105,12 @@ func TestInvoker(t *testing.T) {
 	t.Run("historic, state", func(t *testing.T) {
 		testInv(t, NewHistoricWithState(util.Uint256{}, ri, nil))
 	})
+	t.Run("tuned", func(t *testing.T) {
+		testInv(t, NewTuned(ri, nil, Options{CacheScripts: true}))
+	})
+	t.Run("pinned", func(t *testing.T) {
+		testInv(t, NewAtRoot(util.Uint256{}, ri, nil))
+	})
 	t.Run("broken historic", func(t *testing.T) {
 		inv := New(&historicConverter{client: ri}, nil) // It's not possible to do this from outside.
 		require.Panics(t, func() { _, _ = inv.Call(util.Uint160{}, "method") })
@@ -158,3 +164,90 @@ func TestInvoker(t *testing.T) {
 		}
 	})
 }
+
+func TestScriptCache(t *testing.T) {
+	var (
+		c  = newScriptCache()
+		h1 = util.Uint160{1, 2, 3}
+		h2 = util.Uint160{3, 2, 1}
+	)
+	for _, params := range [][]any{nil, {42}, {"str", []byte{1}}, {h2, true, []any{1, nil}}, {util.Uint256{1}, 7}} {
+		for _, h := range []util.Uint160{h1, h2} {
+			for i := 0; i < 2; i++ {
+				exp, err := smartcontract.CreateCallScript(h, "method", params...)
+				require.NoError(t, err)
+				script, err := c.callScript(h, "method", params)
+				require.NoError(t, err)
+				require.Equal(t, exp, script)
+
+				exp, err = smartcontract.CreateCallAndUnwrapIteratorScript(h, "method", 100, params...)
+				require.NoError(t, err)
+				script, err = c.expandIteratorScript(h, "method", 100, params)
+				require.NoError(t, err)
+				require.Equal(t, exp, script)
+			}
+		}
+	}
+	_, err := c.callScript(h1, "method", []any{make(map[int]int)})
+	require.Error(t, err)
+}
+
+type rpcCounter struct {
+	rpcInv
+	calls int
+}
+
+func (r *rpcCounter) InvokeScriptWithState(stateroot util.Uint256, script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	r.calls++
+	if r.err != nil {
+		return nil, r.err
+	}
+	res := *r.resInv
+	res.Stack = append([]stackitem.Item(nil), r.resInv.Stack...)
+	return &res, nil
+}
+
+func TestNewAtRoot(t *testing.T) {
+	ri := &rpcCounter{rpcInv: rpcInv{resInv: &result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.Make(42)}}}}
+	inv := NewAtRoot(util.Uint256{1}, ri, nil)
+
+	for i := 0; i < 3; i++ {
+		res, err := inv.Call(util.Uint160{}, "method", 1)
+		require.NoError(t, err)
+		require.Equal(t, []stackitem.Item{stackitem.Make(42)}, res.Stack)
+		res.Stack[0] = stackitem.Make(1) // Doesn't affect cached result.
+	}
+	require.Equal(t, 1, ri.calls)
+
+	_, err := inv.Call(util.Uint160{}, "method", 2)
+	require.NoError(t, err)
+	_, err = inv.CallAndExpandIterator(util.Uint160{}, "method", 10, 1)
+	require.NoError(t, err)
+	require.Equal(t, 3, ri.calls)
+
+	t.Run("errors", func(t *testing.T) {
+		ri.err = errors.New("")
+		_, err := inv.Call(util.Uint160{}, "other")
+		require.Error(t, err)
+		ri.err = nil
+		_, err = inv.Call(util.Uint160{}, "other")
+		require.NoError(t, err)
+		require.Equal(t, 5, ri.calls)
+	})
+	t.Run("sessions", func(t *testing.T) {
+		ri.resInv = &result.Invoke{State: "HALT", Session: uuid.New()}
+		for i := 0; i < 2; i++ {
+			_, err := inv.Call(util.Uint160{}, "iterator")
+			require.NoError(t, err)
+		}
+		require.Equal(t, 7, ri.calls)
+	})
+	t.Run("expanded iterators", func(t *testing.T) {
+		ri.resInv = &result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.NewInterop(result.Iterator{})}}
+		for i := 0; i < 2; i++ {
+			_, err := inv.Call(util.Uint160{}, "values")
+			require.NoError(t, err)
+		}
+		require.Equal(t, 9, ri.calls)
+	})
+}
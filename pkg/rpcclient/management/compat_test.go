@@ -0,0 +1,14 @@
+package management_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/management"
+)
+
+func TestRPCEventBasedWSClientCompat(t *testing.T) {
+	_ = management.RPCEventBased(&rpcclient.WSClient{})
+	_ = management.WaitingActor(&actor.Actor{})
+}
@@ -7,15 +7,20 @@ various methods to perform state-changing calls.
 package management
 
 import (
+	"context"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -23,6 +28,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 )
 
 // Invoker is used by ContractReader to call various methods.
@@ -45,10 +51,28 @@ type Actor interface {
 	SendRun(script []byte) (util.Uint256, uint32, error)
 }
 
+// WaitingActor is an Actor that is able to wait for transaction acceptance,
+// it's required for DeployAndWait. actor.Actor implements it.
+type WaitingActor interface {
+	Actor
+
+	Sender() util.Uint160
+	Wait(h util.Uint256, vub uint32, err error) (*state.AppExecResult, error)
+}
+
+// RPCEventBased is a websocket-based RPC client interface that allows
+// WaitForDeployment to use ContractManagement notifications instead of
+// contract state polling. rpcclient.WSClient implements it.
+type RPCEventBased interface {
+	ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error)
+	Unsubscribe(id string) error
+}
+
 // ContractReader provides an interface to call read-only ContractManagement
 // contract's methods.
 type ContractReader struct {
 	invoker Invoker
+	events  RPCEventBased
 }
 
 // Contract represents a ContractManagement contract client that can be used to
@@ -85,10 +109,29 @@ type Event struct {
 
 const setMinFeeMethod = "setMinimumDeploymentFee"
 
+// ErrDeploymentFailed is returned from DeployAndWait when deployment
+// transaction ends up in the FAULT state.
+var ErrDeploymentFailed = errors.New("deployment transaction failed")
+
+// deploymentPollInterval is the interval between contract state checks done by
+// WaitForDeployment when websocket notifications are not available.
+var deploymentPollInterval = time.Second
+
+// listBatchSize is the number of contract states retrieved by List with a
+// single iterator traversal request.
+const listBatchSize = 64
+
 // NewReader creates an instance of ContractReader that can be used to read
 // data from the contract.
 func NewReader(invoker Invoker) *ContractReader {
-	return &ContractReader{invoker}
+	return &ContractReader{invoker: invoker}
+}
+
+// NewReaderWithEvents creates an instance of ContractReader that uses the
+// given websocket-based RPC client to receive ContractManagement notifications
+// in WaitForDeployment.
+func NewReaderWithEvents(invoker Invoker, events RPCEventBased) *ContractReader {
+	return &ContractReader{invoker: invoker, events: events}
 }
 
 // New creates an instance of Contract to perform actions using
@@ -97,6 +140,13 @@ func New(actor Actor) *Contract {
 	return &Contract{*NewReader(actor), actor}
 }
 
+// NewWithEvents creates an instance of Contract to perform actions using
+// the given Actor. The given websocket-based RPC client is used to receive
+// ContractManagement notifications in WaitForDeployment and DeployAndWait.
+func NewWithEvents(actor Actor, events RPCEventBased) *Contract {
+	return &Contract{*NewReaderWithEvents(actor, events), actor}
+}
+
 // GetContract allows to get contract data from its hash. This method is mostly
 // useful for historic invocations since for current contracts there is a direct
 // getcontractstate RPC API that has more options and works faster than going
@@ -212,6 +262,46 @@ func itemsToIDHashes(arr []stackitem.Item) ([]IDHash, error) {
 	return res, nil
 }
 
+// List returns states of all contracts (including native ones) matching the
+// filter ordered by their hashes. It uses getContractsIterator method and
+// depends on the server to provide session-based iterator, contracts are
+// retrieved in batches and filtered on the client side. The session is
+// terminated when List returns, the context allows to interrupt traversal.
+func (c *ContractReader) List(ctx context.Context, filter neorpc.ContractFilter) ([]state.Contract, error) {
+	sess, iter, err := unwrap.SessionIterator(c.invoker.Call(Hash, "getContractsIterator"))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = c.invoker.TerminateSession(sess) }()
+
+	var (
+		res = make([]state.Contract, 0)
+		n   int
+	)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		items, err := c.invoker.TraverseIterator(sess, &iter, listBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		for i, itm := range items {
+			var cs state.Contract
+			if err := cs.FromStackItem(itm); err != nil {
+				return nil, fmt.Errorf("item #%d is not a contract state: %w", n+i, err)
+			}
+			if filter.Matches(&cs) {
+				res = append(res, cs)
+			}
+		}
+		if len(items) < listBatchSize {
+			return res, nil
+		}
+		n += len(items)
+	}
+}
+
 // GetMinimumDeploymentFee returns the minimal amount of GAS needed to deploy a
 // contract on the network.
 func (c *ContractReader) GetMinimumDeploymentFee() (*big.Int, error) {
@@ -306,3 +396,156 @@ func (c *Contract) SetMinimumDeploymentFeeTransaction(value *big.Int) (*transact
 func (c *Contract) SetMinimumDeploymentFeeUnsigned(value *big.Int) (*transaction.Transaction, error) {
 	return c.actor.MakeUnsignedCall(Hash, setMinFeeMethod, nil, value)
 }
+
+// WaitForDeployment waits until the contract with the given hash is deployed
+// and returns its state. If the contract is already deployed its state is
+// returned immediately. Deploy and Update ContractManagement notifications are
+// used to detect deployment if the reader is created with a websocket-based
+// RPC client (see NewReaderWithEvents), contract state is polled for
+// otherwise (and in case of any subscription problems). Waiting is
+// interrupted when the given context is done. Note that the Invoker used must
+// perform invocations at the current chain state for this method to work.
+func (c *ContractReader) WaitForDeployment(ctx context.Context, hash util.Uint160) (*state.Contract, error) {
+	if c.events != nil {
+		cs, ok, err := c.waitForDeploymentEvent(ctx, hash)
+		if ok {
+			return cs, err
+		}
+	}
+	ticker := time.NewTicker(deploymentPollInterval)
+	defer ticker.Stop()
+	for {
+		cs, err := c.GetContract(hash)
+		if err != nil {
+			return nil, err
+		}
+		if cs != nil {
+			return cs, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForDeploymentEvent waits for contract deployment using websocket
+// notifications. It returns false if subscription-based waiting has failed
+// and polling should be used.
+func (c *ContractReader) waitForDeploymentEvent(ctx context.Context, hash util.Uint160) (*state.Contract, bool, error) {
+	var (
+		mgmt = Hash
+		rcvr = make(chan *state.ContainedNotificationEvent)
+	)
+	id, err := c.events.ReceiveExecutionNotifications(&neorpc.NotificationFilter{Contract: &mgmt}, rcvr)
+	if err != nil {
+		return nil, false, nil
+	}
+	var unsubErr = make(chan error)
+	defer func() {
+		go func() {
+			unsubErr <- c.events.Unsubscribe(id)
+		}()
+		// Drain the receiver to avoid other notification receivers blocking.
+		for {
+			select {
+			case _, ok := <-rcvr:
+				if !ok {
+					rcvr = nil
+				}
+			case <-unsubErr:
+				return
+			}
+		}
+	}()
+
+	// There is a potential race between subscription and deployment, so
+	// do a check once _after_ the subscription.
+	cs, err := c.GetContract(hash)
+	if err != nil || cs != nil {
+		return cs, true, err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, true, ctx.Err()
+		case ev, ok := <-rcvr:
+			if !ok {
+				// Missed event or disconnection, fall back to polling.
+				rcvr = nil
+				return nil, false, nil
+			}
+			if ev.Name != "Deploy" && ev.Name != "Update" {
+				continue
+			}
+			var e Event
+			if e.fromStackItem(ev.Item) != nil || !e.Hash.Equals(hash) {
+				continue
+			}
+			cs, err = c.GetContract(hash)
+			if err != nil || cs != nil {
+				return cs, true, err
+			}
+		}
+	}
+}
+
+// fromStackItem decodes Event from the notification stack item.
+func (e *Event) fromStackItem(itm *stackitem.Array) error {
+	if itm == nil || len(itm.Value().([]stackitem.Item)) != 1 {
+		return errors.New("wrong event structure")
+	}
+	b, err := itm.Value().([]stackitem.Item)[0].TryBytes()
+	if err != nil {
+		return err
+	}
+	e.Hash, err = util.Uint160DecodeBytesBE(b)
+	return err
+}
+
+// DeployAndWait deploys the given contract (see Deploy), waits for the
+// deployment transaction to be accepted and returns the resulting contract
+// state. It's idempotent in that if the same contract (the one with the same
+// hash) is already deployed by the same sender, its state is returned without
+// sending any transactions. If the deployment transaction fails,
+// ErrDeploymentFailed is returned along with the fault exception. The Actor
+// used must implement WaitingActor.
+func (c *Contract) DeployAndWait(exe *nef.File, manif *manifest.Manifest, data any) (*state.Contract, error) {
+	wa, ok := c.actor.(WaitingActor)
+	if !ok {
+		return nil, errors.New("actor doesn't support transaction awaiting")
+	}
+	script, err := mkDeployScript(exe, manif, data)
+	if err != nil {
+		return nil, err
+	}
+	hash := state.CreateContractHash(wa.Sender(), exe.Checksum, manif.Name)
+	cs, err := c.GetContract(hash)
+	if err != nil || cs != nil {
+		return cs, err
+	}
+	aer, err := wa.Wait(wa.SendRun(script))
+	if err != nil {
+		return nil, err
+	}
+	if aer.VMState != vmstate.Halt {
+		if strings.Contains(aer.FaultException, "contract already exists") {
+			// Someone was quicker, but it's still the same contract.
+			cs, err = c.GetContract(hash)
+			if err != nil || cs != nil {
+				return cs, err
+			}
+		}
+		return nil, fmt.Errorf("%w: %s", ErrDeploymentFailed, aer.FaultException)
+	}
+	if len(aer.Stack) != 1 {
+		return nil, fmt.Errorf("unexpected deployment result stack length: %d", len(aer.Stack))
+	}
+	cs = new(state.Contract)
+	err = cs.FromStackItem(aer.Stack[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode contract state: %w", err)
+	}
+	return cs, nil
+}
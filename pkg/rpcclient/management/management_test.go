@@ -1,17 +1,25 @@
 package management
 
 import (
+	"context"
 	"errors"
 	"math/big"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/require"
 )
 
@@ -248,6 +256,85 @@ func TestGetContractHashes(t *testing.T) {
 	}, vals[0])
 }
 
+type listAct struct {
+	testAct
+	batches [][]stackitem.Item
+}
+
+func (t *listAct) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if t.err != nil || len(t.batches) == 0 {
+		return nil, t.err
+	}
+	b := t.batches[0]
+	t.batches = t.batches[1:]
+	return b, nil
+}
+
+func TestList(t *testing.T) {
+	ta := &listAct{}
+	man := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, err := man.List(context.Background(), neorpc.ContractFilter{})
+	require.Error(t, err)
+
+	ta.err = nil
+	iid := uuid.New()
+	ta.res = &result.Invoke{
+		Session: uuid.New(),
+		State:   "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(result.Iterator{
+				ID: &iid,
+			}),
+		},
+	}
+	var (
+		all   []state.Contract
+		items []stackitem.Item
+	)
+	for i := 0; i < listBatchSize+3; i++ {
+		name := "contract"
+		if i%2 == 0 {
+			name = "token"
+		}
+		ne, err := nef.NewFile([]byte{byte(opcode.RET)})
+		require.NoError(t, err)
+		cs := state.Contract{ContractBase: state.ContractBase{
+			ID:       int32(i + 1),
+			Hash:     util.Uint160{byte(i)},
+			NEF:      *ne,
+			Manifest: *manifest.NewManifest(name),
+		}}
+		itm, err := cs.ToStackItem()
+		require.NoError(t, err)
+		all = append(all, cs)
+		items = append(items, itm)
+	}
+	ta.batches = [][]stackitem.Item{items[:listBatchSize], items[listBatchSize:]}
+	res, err := man.List(context.Background(), neorpc.ContractFilter{})
+	require.NoError(t, err)
+	require.Equal(t, all, res)
+
+	ta.batches = [][]stackitem.Item{items[:listBatchSize], items[listBatchSize:]}
+	res, err = man.List(context.Background(), neorpc.ContractFilter{Name: "tok"})
+	require.NoError(t, err)
+	require.Equal(t, (listBatchSize+4)/2, len(res))
+	for _, cs := range res {
+		require.Equal(t, "token", cs.Manifest.Name)
+	}
+
+	ta.batches = [][]stackitem.Item{{stackitem.Make(42)}}
+	_, err = man.List(context.Background(), neorpc.ContractFilter{})
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ta.batches = [][]stackitem.Item{items}
+	_, err = man.List(ctx, neorpc.ContractFilter{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
 func TestSetMinimumDeploymentFee(t *testing.T) {
 	ta := new(testAct)
 	man := New(ta)
@@ -364,3 +451,210 @@ func TestItemsToIDHashesErrors(t *testing.T) {
 		})
 	}
 }
+
+type testWaitAct struct {
+	testAct
+
+	// results are returned from Call one by one, the last one is repeated.
+	results []*result.Invoke
+	calls   atomic.Int32
+	sent    bool
+	sendErr error
+	aer     *state.AppExecResult
+}
+
+func (t *testWaitAct) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	i := int(t.calls.Add(1)) - 1
+	if i >= len(t.results) {
+		i = len(t.results) - 1
+	}
+	return t.results[i], t.err
+}
+func (t *testWaitAct) SendRun(script []byte) (util.Uint256, uint32, error) {
+	t.sent = true
+	return t.txh, t.vub, t.sendErr
+}
+func (t *testWaitAct) Sender() util.Uint160 {
+	return util.Uint160{1, 2, 3}
+}
+func (t *testWaitAct) Wait(h util.Uint256, vub uint32, err error) (*state.AppExecResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	return t.aer, nil
+}
+
+type testEvents struct {
+	rcvr   chan<- *state.ContainedNotificationEvent
+	err    error
+	unsubs int
+}
+
+func (t *testEvents) ReceiveExecutionNotifications(flt *neorpc.NotificationFilter, rcvr chan<- *state.ContainedNotificationEvent) (string, error) {
+	t.rcvr = rcvr
+	return "1", t.err
+}
+func (t *testEvents) Unsubscribe(id string) error {
+	t.unsubs++
+	return nil
+}
+
+func newTestContract(t *testing.T, sender util.Uint160) (*state.Contract, *result.Invoke) {
+	nefFile, err := nef.NewFile([]byte{byte(opcode.RET)})
+	require.NoError(t, err)
+	manif := manifest.DefaultManifest("contract")
+	manif.ABI.Methods = []manifest.Method{{Name: "main", ReturnType: smartcontract.VoidType, Parameters: []manifest.Parameter{}}}
+	cs := &state.Contract{
+		ContractBase: state.ContractBase{
+			ID:       1,
+			Hash:     state.CreateContractHash(sender, nefFile.Checksum, manif.Name),
+			NEF:      *nefFile,
+			Manifest: *manif,
+		},
+	}
+	itm, err := cs.ToStackItem()
+	require.NoError(t, err)
+	return cs, &result.Invoke{State: "HALT", Stack: []stackitem.Item{itm}}
+}
+
+type deploymentResult struct {
+	cs  *state.Contract
+	err error
+}
+
+// waitForDeployment runs WaitForDeployment in a separate goroutine and
+// returns a channel to receive its result from.
+func waitForDeployment(r *ContractReader, h util.Uint160) <-chan deploymentResult {
+	res := make(chan deploymentResult, 1)
+	go func() {
+		cs, err := r.WaitForDeployment(context.Background(), h)
+		res <- deploymentResult{cs, err}
+	}()
+	return res
+}
+
+func TestWaitForDeployment(t *testing.T) {
+	deploymentPollInterval = 10 * time.Millisecond
+	cs, deployed := newTestContract(t, util.Uint160{1, 2, 3})
+	missing := &result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.Null{}}}
+
+	t.Run("already deployed", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{deployed}}
+		actual, err := NewReader(ta).WaitForDeployment(context.Background(), cs.Hash)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+	})
+	t.Run("polling", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing, missing, deployed}}
+		actual, err := NewReader(ta).WaitForDeployment(context.Background(), cs.Hash)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+		require.Equal(t, int32(3), ta.calls.Load())
+	})
+	t.Run("polling error", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}}
+		ta.err = errors.New("")
+		_, err := NewReader(ta).WaitForDeployment(context.Background(), cs.Hash)
+		require.Error(t, err)
+	})
+	t.Run("context done", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := NewReader(ta).WaitForDeployment(ctx, cs.Hash)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+	t.Run("events", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing, missing, deployed}}
+		ev := new(testEvents)
+		deploymentPollInterval = time.Hour // Ensure polling is not used.
+		defer func() { deploymentPollInterval = 10 * time.Millisecond }()
+
+		res := waitForDeployment(NewReaderWithEvents(ta, ev), cs.Hash)
+		require.Eventually(t, func() bool { return ta.calls.Load() == 1 }, time.Second, time.Millisecond)
+		mkEvent := func(name string, h util.Uint160) *state.ContainedNotificationEvent {
+			return &state.ContainedNotificationEvent{NotificationEvent: state.NotificationEvent{
+				ScriptHash: Hash,
+				Name:       name,
+				Item:       stackitem.NewArray([]stackitem.Item{stackitem.Make(h.BytesBE())}),
+			}}
+		}
+		ev.rcvr <- mkEvent("Destroy", cs.Hash)
+		ev.rcvr <- mkEvent("Deploy", util.Uint160{3, 2, 1})
+		ev.rcvr <- mkEvent("Update", cs.Hash) // Calls == 2, still missing.
+		ev.rcvr <- mkEvent("Deploy", cs.Hash)
+		r := <-res
+		require.NoError(t, r.err)
+		require.Equal(t, cs.Hash, r.cs.Hash)
+		require.Equal(t, int32(3), ta.calls.Load())
+		require.Equal(t, 1, ev.unsubs)
+	})
+	t.Run("subscription failure", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing, deployed}}
+		ev := &testEvents{err: errors.New("")}
+		actual, err := NewReaderWithEvents(ta, ev).WaitForDeployment(context.Background(), cs.Hash)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+	})
+	t.Run("missed event", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing, missing, deployed}}
+		ev := new(testEvents)
+		res := waitForDeployment(NewReaderWithEvents(ta, ev), cs.Hash)
+		require.Eventually(t, func() bool { return ta.calls.Load() == 1 }, time.Second, time.Millisecond)
+		close(ev.rcvr)
+		r := <-res
+		require.NoError(t, r.err)
+		require.Equal(t, cs.Hash, r.cs.Hash)
+	})
+}
+
+func TestDeployAndWait(t *testing.T) {
+	sender := util.Uint160{1, 2, 3}
+	cs, deployed := newTestContract(t, sender)
+	missing := &result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.Null{}}}
+
+	t.Run("not a waiting actor", func(t *testing.T) {
+		_, err := New(new(testAct)).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.Error(t, err)
+	})
+	t.Run("already deployed", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{deployed}}
+		actual, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+		require.False(t, ta.sent)
+	})
+	t.Run("good", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}}
+		ta.aer = &state.AppExecResult{Execution: state.Execution{VMState: vmstate.Halt, Stack: deployed.Stack}}
+		actual, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+		require.True(t, ta.sent)
+	})
+	t.Run("send error", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}, sendErr: errors.New("")}
+		_, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.Error(t, err)
+	})
+	t.Run("fault", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}}
+		ta.aer = &state.AppExecResult{Execution: state.Execution{VMState: vmstate.Fault, FaultException: "oops"}}
+		_, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.ErrorIs(t, err, ErrDeploymentFailed)
+		require.ErrorContains(t, err, "oops")
+	})
+	t.Run("deployed concurrently", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing, deployed}}
+		ta.aer = &state.AppExecResult{Execution: state.Execution{VMState: vmstate.Fault, FaultException: "contract already exists"}}
+		actual, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.NoError(t, err)
+		require.Equal(t, cs.Hash, actual.Hash)
+	})
+	t.Run("bad result", func(t *testing.T) {
+		ta := &testWaitAct{results: []*result.Invoke{missing}}
+		ta.aer = &state.AppExecResult{Execution: state.Execution{VMState: vmstate.Halt}}
+		_, err := New(ta).DeployAndWait(&cs.NEF, &cs.Manifest, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,226 @@
+/*
+Package mempoolmon provides a transaction monitor built on top of WSClient
+transaction subscription.
+
+Instead of raw transactions Monitor delivers typed events produced from
+transaction scripts: NEP-17 transfers (ParsedTransfer), other contract calls
+(ContractCall) and transactions with scripts that can't be parsed (Unknown).
+Events can be filtered by transaction sender, contract called and NEP-17
+transfer sender or receiver. Lost connections are reestablished
+automatically.
+
+Notice that all of this is a best-effort analysis of transactions announced by
+the node: events describe what a transaction script is going to do, not what
+it did, the transaction can fail or never be accepted at all (if it's taken
+from the mempool), transactions announced while there was no connection are
+missed. Application logs (see waiter package) should be used to get the final
+state.
+*/
+package mempoolmon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// Default reconnection parameters.
+const (
+	DefaultReconnectDelay    = time.Second
+	DefaultMaxReconnectDelay = time.Minute
+)
+
+type (
+	// RPC is a set of methods used by Monitor, WSClient implements it.
+	RPC interface {
+		ReceiveTransactions(flt *neorpc.TxFilter, rcvr chan<- *transaction.Transaction) (string, error)
+		Close()
+	}
+
+	// DialFunc creates a new RPC connection, it's used by Monitor to connect
+	// and reconnect. Monitor owns the connection and closes it when it's lost
+	// or no longer needed.
+	DialFunc func(ctx context.Context) (RPC, error)
+
+	// Filter contains conditions events are to match, only non-nil fields
+	// are checked.
+	Filter struct {
+		// Sender is the transaction sender.
+		Sender *util.Uint160
+		// Contract is the contract called by the transaction, Unknown
+		// events never match it.
+		Contract *util.Uint160
+		// Transfer is the sender or receiver of NEP-17 transfer, only
+		// ParsedTransfer events can match it.
+		Transfer *util.Uint160
+	}
+
+	// Options contains Monitor parameters.
+	Options struct {
+		// ReconnectDelay is the delay before the first reconnection
+		// attempt, it's doubled after every failed attempt up to
+		// MaxReconnectDelay. Default values are used if not set.
+		ReconnectDelay    time.Duration
+		MaxReconnectDelay time.Duration
+	}
+
+	// Monitor delivers events for transactions announced by the node.
+	Monitor struct {
+		dial DialFunc
+		flt  Filter
+		opts Options
+	}
+)
+
+// WSDialer returns DialFunc creating WSClient for the given endpoint.
+func WSDialer(endpoint string, opts rpcclient.WSOptions) DialFunc {
+	return func(ctx context.Context) (RPC, error) {
+		return rpcclient.NewWS(ctx, endpoint, opts)
+	}
+}
+
+// New creates a Monitor using the given dial function and filter, default
+// options are used if opts is nil.
+func New(dial DialFunc, flt Filter, opts *Options) *Monitor {
+	m := &Monitor{
+		dial: dial,
+		flt:  flt,
+	}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.ReconnectDelay <= 0 {
+		m.opts.ReconnectDelay = DefaultReconnectDelay
+	}
+	if m.opts.MaxReconnectDelay <= 0 {
+		m.opts.MaxReconnectDelay = DefaultMaxReconnectDelay
+	}
+	if m.opts.MaxReconnectDelay < m.opts.ReconnectDelay {
+		m.opts.MaxReconnectDelay = m.opts.ReconnectDelay
+	}
+	return m
+}
+
+// Matches checks whether the event matches the filter.
+func (f Filter) Matches(e Event) bool {
+	if f.Sender != nil && !e.Transaction().Sender().Equals(*f.Sender) {
+		return false
+	}
+	var (
+		contract *util.Uint160
+		transfer *ParsedTransfer
+	)
+	switch e := e.(type) {
+	case *ContractCall:
+		contract = &e.Contract
+	case *ParsedTransfer:
+		contract, transfer = &e.Contract, e
+	}
+	if f.Contract != nil && (contract == nil || !contract.Equals(*f.Contract)) {
+		return false
+	}
+	if f.Transfer != nil && (transfer == nil ||
+		!transfer.From.Equals(*f.Transfer) && !transfer.To.Equals(*f.Transfer)) {
+		return false
+	}
+	return true
+}
+
+// Run connects to the node and delivers events matching the filter to the
+// given channel until the context is done. Lost connection is reestablished
+// (until that succeeds no events are delivered). An error is only returned
+// if the initial connection fails, the receiver channel is closed when Run
+// returns.
+func (m *Monitor) Run(ctx context.Context, rcvr chan<- Event) error {
+	defer close(rcvr)
+	c, txs, err := m.connect(ctx)
+	if err != nil {
+		return err
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			closeRPC(c, txs)
+			return nil
+		case tx, ok := <-txs:
+			if !ok {
+				c.Close()
+				c, txs = m.reconnect(ctx)
+				if c == nil {
+					return nil
+				}
+				continue
+			}
+			for _, e := range Parse(tx) {
+				if !m.flt.Matches(e) {
+					continue
+				}
+				select {
+				case rcvr <- e:
+				case <-ctx.Done():
+					closeRPC(c, txs)
+					return nil
+				}
+			}
+		}
+	}
+}
+
+// connect creates a new connection and subscribes for transactions.
+func (m *Monitor) connect(ctx context.Context) (RPC, chan *transaction.Transaction, error) {
+	c, err := m.dial(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	var (
+		flt *neorpc.TxFilter
+		txs = make(chan *transaction.Transaction)
+	)
+	if m.flt.Sender != nil {
+		flt = &neorpc.TxFilter{Sender: m.flt.Sender}
+	}
+	_, err = c.ReceiveTransactions(flt, txs)
+	if err != nil {
+		c.Close()
+		return nil, nil, fmt.Errorf("failed to subscribe: %w", err)
+	}
+	return c, txs, nil
+}
+
+// reconnect tries to connect until it succeeds or the context is done (nil
+// is returned then).
+func (m *Monitor) reconnect(ctx context.Context) (RPC, chan *transaction.Transaction) {
+	delay := m.opts.ReconnectDelay
+	for {
+		t := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, nil
+		case <-t.C:
+		}
+		c, txs, err := m.connect(ctx)
+		if err == nil {
+			return c, txs
+		}
+		delay *= 2
+		if delay > m.opts.MaxReconnectDelay {
+			delay = m.opts.MaxReconnectDelay
+		}
+	}
+}
+
+// closeRPC closes the connection, receiver channel is drained until it's
+// closed by the client, so that pending events don't block it.
+func closeRPC(c RPC, txs chan *transaction.Transaction) {
+	go func() {
+		for range txs {
+		}
+	}()
+	c.Close()
+}
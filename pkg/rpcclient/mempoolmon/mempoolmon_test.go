@@ -0,0 +1,166 @@
+package mempoolmon
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRPC struct {
+	once   sync.Once
+	flt    *neorpc.TxFilter
+	rcvr   chan<- *transaction.Transaction
+	ready  chan struct{}
+	closed chan struct{}
+}
+
+func newFakeRPC() *fakeRPC {
+	return &fakeRPC{
+		ready:  make(chan struct{}),
+		closed: make(chan struct{}),
+	}
+}
+
+func (r *fakeRPC) ReceiveTransactions(flt *neorpc.TxFilter, rcvr chan<- *transaction.Transaction) (string, error) {
+	r.flt = flt
+	r.rcvr = rcvr
+	close(r.ready)
+	return "1", nil
+}
+
+func (r *fakeRPC) Close() {
+	r.disconnect()
+	close(r.closed)
+}
+
+func (r *fakeRPC) disconnect() {
+	r.once.Do(func() { close(r.rcvr) })
+}
+
+func transferTx(t *testing.T, sender, token, to util.Uint160) *transaction.Transaction {
+	script, err := smartcontract.CreateCallWithAssertScript(token, "transfer", sender, to, 1, nil)
+	require.NoError(t, err)
+	tx := transaction.New(script, 0)
+	tx.Signers = []transaction.Signer{{Account: sender}}
+	return tx
+}
+
+func TestFilterMatches(t *testing.T) {
+	var (
+		sender = util.Uint160{1}
+		token  = util.Uint160{2}
+		to     = util.Uint160{3}
+		other  = util.Uint160{4}
+
+		tx       = transferTx(t, sender, token, to)
+		transfer = Parse(tx)[0]
+		call     = &ContractCall{Tx: tx, Contract: token, Method: "transfer"}
+		unknown  = &Unknown{Tx: tx}
+	)
+	for _, tc := range []struct {
+		flt     Filter
+		matches []bool // transfer, call, unknown
+	}{
+		{Filter{}, []bool{true, true, true}},
+		{Filter{Sender: &sender}, []bool{true, true, true}},
+		{Filter{Sender: &other}, []bool{false, false, false}},
+		{Filter{Contract: &token}, []bool{true, true, false}},
+		{Filter{Contract: &other}, []bool{false, false, false}},
+		{Filter{Transfer: &sender}, []bool{true, false, false}},
+		{Filter{Transfer: &to}, []bool{true, false, false}},
+		{Filter{Transfer: &other}, []bool{false, false, false}},
+		{Filter{Sender: &sender, Contract: &token, Transfer: &to}, []bool{true, false, false}},
+		{Filter{Sender: &other, Contract: &token, Transfer: &to}, []bool{false, false, false}},
+	} {
+		for i, e := range []Event{transfer, call, unknown} {
+			require.Equal(t, tc.matches[i], tc.flt.Matches(e), "%+v, event %d", tc.flt, i)
+		}
+	}
+}
+
+func TestMonitor(t *testing.T) {
+	var (
+		sender = util.Uint160{1}
+		token  = util.Uint160{2}
+		to     = util.Uint160{3}
+		other  = util.Uint160{4}
+	)
+	dials := make(chan any, 4)
+	dial := func(ctx context.Context) (RPC, error) {
+		switch d := (<-dials).(type) {
+		case *fakeRPC:
+			return d, nil
+		case error:
+			return nil, d
+		}
+		panic("unexpected dial")
+	}
+
+	t.Run("initial error", func(t *testing.T) {
+		dials <- errors.New("no connection")
+		m := New(dial, Filter{}, nil)
+		rcvr := make(chan Event)
+		require.Error(t, m.Run(context.Background(), rcvr))
+		_, ok := <-rcvr
+		require.False(t, ok)
+	})
+	t.Run("events and reconnect", func(t *testing.T) {
+		first, second := newFakeRPC(), newFakeRPC()
+		dials <- first
+		m := New(dial, Filter{Sender: &sender, Contract: &token}, &Options{ReconnectDelay: time.Millisecond})
+		ctx, cancel := context.WithCancel(context.Background())
+		rcvr := make(chan Event)
+		done := make(chan error)
+		go func() { done <- m.Run(ctx, rcvr) }()
+
+		<-first.ready
+		require.Equal(t, &neorpc.TxFilter{Sender: &sender}, first.flt)
+		tx := transferTx(t, sender, token, to)
+		first.rcvr <- transferTx(t, sender, other, to)
+		first.rcvr <- tx
+		e := <-rcvr
+		require.Equal(t, tx, e.Transaction())
+		require.Equal(t, to, e.(*ParsedTransfer).To)
+
+		dials <- errors.New("still no connection")
+		dials <- second
+		first.disconnect()
+		<-first.closed
+		<-second.ready
+		second.rcvr <- tx
+		e = <-rcvr
+		require.Equal(t, tx, e.Transaction())
+
+		cancel()
+		require.NoError(t, <-done)
+		<-second.closed
+		_, ok := <-rcvr
+		require.False(t, ok)
+	})
+	t.Run("cancel while reconnecting", func(t *testing.T) {
+		c := newFakeRPC()
+		dials <- c
+		m := New(dial, Filter{}, &Options{ReconnectDelay: time.Hour})
+		ctx, cancel := context.WithCancel(context.Background())
+		rcvr := make(chan Event)
+		done := make(chan error)
+		go func() { done <- m.Run(ctx, rcvr) }()
+
+		<-c.ready
+		require.Nil(t, c.flt)
+		c.disconnect()
+		<-c.closed
+		cancel()
+		require.NoError(t, <-done)
+		_, ok := <-rcvr
+		require.False(t, ok)
+	})
+}
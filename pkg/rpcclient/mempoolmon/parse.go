@@ -0,0 +1,264 @@
+package mempoolmon
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Event is a transaction event, it's either *ParsedTransfer, *ContractCall or
+// *Unknown. Events are derived from the transaction script only, they describe
+// what the transaction is going to do, not what it did.
+type Event interface {
+	// Transaction returns the transaction the event is produced for.
+	Transaction() *transaction.Transaction
+}
+
+// ContractCall is a contract method call made by the transaction script.
+type ContractCall struct {
+	Tx        *transaction.Transaction
+	Contract  util.Uint160
+	Method    string
+	CallFlags callflag.CallFlag
+	Args      []stackitem.Item
+}
+
+// ParsedTransfer is a call of NEP-17 `transfer` method, it's recognized by
+// the method name and parameters only, so the contract called is not
+// necessarily a NEP-17 token and the transfer can fail.
+type ParsedTransfer struct {
+	ContractCall
+
+	From   util.Uint160
+	To     util.Uint160
+	Amount *big.Int
+	Data   stackitem.Item
+}
+
+// Unknown is a transaction with a script that can't be parsed.
+type Unknown struct {
+	Tx *transaction.Transaction
+}
+
+var contractCallID = interopnames.ToID([]byte(interopnames.SystemContractCall))
+
+// Transaction implements the Event interface.
+func (c *ContractCall) Transaction() *transaction.Transaction {
+	return c.Tx
+}
+
+// Transaction implements the Event interface.
+func (u *Unknown) Transaction() *transaction.Transaction {
+	return u.Tx
+}
+
+// Parse returns events for the given transaction. Scripts consisting of
+// System.Contract.Call invocations with constant arguments (optionally followed
+// by ASSERT or DROP) like the ones created by smartcontract.Builder and actor
+// are parsed into a ContractCall (or ParsedTransfer) per invocation, a single
+// Unknown event is returned for any other script.
+func Parse(tx *transaction.Transaction) []Event {
+	calls, ok := parseCalls(tx.Script)
+	if !ok || len(calls) == 0 {
+		return []Event{&Unknown{Tx: tx}}
+	}
+	events := make([]Event, 0, len(calls))
+	for i := range calls {
+		calls[i].Tx = tx
+		if t, ok := parseTransfer(calls[i]); ok {
+			events = append(events, t)
+		} else {
+			events = append(events, &calls[i])
+		}
+	}
+	return events
+}
+
+// parseTransfer checks whether the call is NEP-17 transfer.
+func parseTransfer(c ContractCall) (*ParsedTransfer, bool) {
+	if c.Method != "transfer" || len(c.Args) != 4 {
+		return nil, false
+	}
+	from, err := c.Args[0].TryBytes()
+	if err != nil || len(from) != util.Uint160Size || c.Args[0].Type() != stackitem.ByteArrayT {
+		return nil, false
+	}
+	to, err := c.Args[1].TryBytes()
+	if err != nil || len(to) != util.Uint160Size || c.Args[1].Type() != stackitem.ByteArrayT {
+		return nil, false
+	}
+	if c.Args[2].Type() != stackitem.IntegerT {
+		return nil, false
+	}
+	amount, err := c.Args[2].TryInteger()
+	if err != nil {
+		return nil, false
+	}
+	t := &ParsedTransfer{
+		ContractCall: c,
+		Amount:       amount,
+		Data:         c.Args[3],
+	}
+	copy(t.From[:], from)
+	copy(t.To[:], to)
+	return t, true
+}
+
+// parseCalls emulates the script execution with constant values only, it
+// returns false if the script does something else.
+func parseCalls(script []byte) ([]ContractCall, bool) {
+	var (
+		ctx   = vm.NewContext(script)
+		stack []stackitem.Item
+		calls []ContractCall
+	)
+	// Call results are represented by nil items, they can only be dropped
+	// or asserted.
+	pop := func() (stackitem.Item, bool) {
+		if len(stack) == 0 || stack[len(stack)-1] == nil {
+			return nil, false
+		}
+		item := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return item, true
+	}
+	popInt := func(maxVal int) (int, bool) {
+		item, ok := pop()
+		if !ok || item.Type() != stackitem.IntegerT {
+			return 0, false
+		}
+		n, err := item.TryInteger()
+		if err != nil || !n.IsInt64() || n.Int64() < 0 || n.Int64() > int64(maxVal) {
+			return 0, false
+		}
+		return int(n.Int64()), true
+	}
+	popItems := func(n int) ([]stackitem.Item, bool) {
+		items := make([]stackitem.Item, n)
+		for i := range items {
+			item, ok := pop()
+			if !ok {
+				return nil, false
+			}
+			items[i] = item
+		}
+		return items, true
+	}
+	for ctx.NextIP() < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			return nil, false
+		}
+		switch {
+		case op <= opcode.PUSHINT256:
+			stack = append(stack, stackitem.NewBigInteger(bigint.FromBytes(param)))
+		case op >= opcode.PUSHM1 && op <= opcode.PUSH16:
+			stack = append(stack, stackitem.Make(int(op)-int(opcode.PUSH0)))
+		case op == opcode.PUSHT || op == opcode.PUSHF:
+			stack = append(stack, stackitem.NewBool(op == opcode.PUSHT))
+		case op == opcode.PUSHNULL:
+			stack = append(stack, stackitem.Null{})
+		case op == opcode.PUSHDATA1 || op == opcode.PUSHDATA2 || op == opcode.PUSHDATA4:
+			stack = append(stack, stackitem.NewByteArray(param))
+		case op == opcode.NEWARRAY0:
+			stack = append(stack, stackitem.NewArray(nil))
+		case op == opcode.NEWSTRUCT0:
+			stack = append(stack, stackitem.NewStruct(nil))
+		case op == opcode.NEWMAP:
+			stack = append(stack, stackitem.NewMap())
+		case op == opcode.PACK || op == opcode.PACKSTRUCT:
+			n, ok := popInt(len(stack))
+			if !ok {
+				return nil, false
+			}
+			items, ok := popItems(n)
+			if !ok {
+				return nil, false
+			}
+			if op == opcode.PACK {
+				stack = append(stack, stackitem.NewArray(items))
+			} else {
+				stack = append(stack, stackitem.NewStruct(items))
+			}
+		case op == opcode.PACKMAP:
+			n, ok := popInt(len(stack) / 2)
+			if !ok {
+				return nil, false
+			}
+			m := stackitem.NewMap()
+			for i := 0; i < n; i++ {
+				kv, ok := popItems(2)
+				if !ok || stackitem.IsValidMapKey(kv[0]) != nil {
+					return nil, false
+				}
+				m.Add(kv[0], kv[1])
+			}
+			stack = append(stack, m)
+		case op == opcode.SYSCALL:
+			if binary.LittleEndian.Uint32(param) != contractCallID {
+				return nil, false
+			}
+			c, ok := popCall(pop, popInt)
+			if !ok {
+				return nil, false
+			}
+			calls = append(calls, c)
+			stack = append(stack, nil)
+		case op == opcode.ASSERT || op == opcode.DROP:
+			if len(stack) == 0 {
+				return nil, false
+			}
+			stack = stack[:len(stack)-1]
+		case op == opcode.RET:
+			return calls, true
+		default:
+			return nil, false
+		}
+	}
+	return calls, true
+}
+
+// popCall takes System.Contract.Call parameters from the stack.
+func popCall(pop func() (stackitem.Item, bool), popInt func(int) (int, bool)) (ContractCall, bool) {
+	var c ContractCall
+	hash, ok := pop()
+	if !ok {
+		return c, false
+	}
+	h, err := hash.TryBytes()
+	if err != nil || hash.Type() != stackitem.ByteArrayT {
+		return c, false
+	}
+	c.Contract, err = util.Uint160DecodeBytesBE(h)
+	if err != nil {
+		return c, false
+	}
+	method, ok := pop()
+	if !ok || method.Type() != stackitem.ByteArrayT {
+		return c, false
+	}
+	m, err := stackitem.ToString(method)
+	if err != nil {
+		return c, false
+	}
+	c.Method = m
+	flags, ok := popInt(int(callflag.All))
+	if !ok {
+		return c, false
+	}
+	c.CallFlags = callflag.CallFlag(flags)
+	args, ok := pop()
+	if !ok || args.Type() != stackitem.ArrayT {
+		return c, false
+	}
+	c.Args = args.Value().([]stackitem.Item)
+	return c, true
+}
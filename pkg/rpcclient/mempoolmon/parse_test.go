@@ -0,0 +1,282 @@
+package mempoolmon
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neo"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep17"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// testActorRPC is an RPCActor successfully invoking everything.
+type testActorRPC struct{}
+
+func (r *testActorRPC) invoke(script []byte) *result.Invoke {
+	return &result.Invoke{
+		State:       "HALT",
+		GasConsumed: 100500,
+		Script:      script,
+		Stack:       []stackitem.Item{stackitem.Make(true)},
+	}
+}
+
+func (r *testActorRPC) InvokeContractVerify(contract util.Uint160, params []smartcontract.Parameter, signers []transaction.Signer, witnesses ...transaction.Witness) (*result.Invoke, error) {
+	return r.invoke(nil), nil
+}
+func (r *testActorRPC) InvokeFunction(contract util.Uint160, operation string, params []smartcontract.Parameter, signers []transaction.Signer) (*result.Invoke, error) {
+	args := make([]any, len(params))
+	for i := range params {
+		var err error
+		args[i], err = smartcontract.ExpandParameterToEmitable(params[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	script, err := smartcontract.CreateCallScript(contract, operation, args...)
+	if err != nil {
+		return nil, err
+	}
+	return r.invoke(script), nil
+}
+func (r *testActorRPC) InvokeScript(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	return r.invoke(script), nil
+}
+func (r *testActorRPC) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	return 1000, nil
+}
+func (r *testActorRPC) GetBlockCount() (uint32, error) {
+	return 42, nil
+}
+func (r *testActorRPC) GetVersion() (*result.Version, error) {
+	return &result.Version{Protocol: result.Protocol{
+		Network:              netmode.UnitTestNet,
+		MillisecondsPerBlock: 1000,
+		ValidatorsCount:      7,
+	}}, nil
+}
+func (r *testActorRPC) SendRawTransaction(tx *transaction.Transaction) (util.Uint256, error) {
+	return tx.Hash(), nil
+}
+func (r *testActorRPC) TerminateSession(sessionID uuid.UUID) (bool, error) {
+	return false, nil
+}
+func (r *testActorRPC) TraverseIterator(sessionID, iteratorID uuid.UUID, maxItemsCount int) ([]stackitem.Item, error) {
+	return nil, nil
+}
+
+func newTestActor(t *testing.T) (*actor.Actor, util.Uint160) {
+	acc, err := wallet.NewAccount()
+	require.NoError(t, err)
+	act, err := actor.NewSimple(&testActorRPC{}, acc)
+	require.NoError(t, err)
+	return act, acc.ScriptHash()
+}
+
+func TestParseTransfers(t *testing.T) {
+	act, sender := newTestActor(t)
+	var (
+		token = util.Uint160{1, 2, 3}
+		to    = util.Uint160{4, 5, 6}
+		other = util.Uint160{7, 8, 9}
+	)
+
+	t.Run("NEP-17", func(t *testing.T) {
+		tx, err := nep17.New(act, token).TransferUnsigned(sender, to, big.NewInt(100500), nil)
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 1)
+		tr, ok := events[0].(*ParsedTransfer)
+		require.True(t, ok)
+		require.Equal(t, tx, tr.Transaction())
+		require.Equal(t, token, tr.Contract)
+		require.Equal(t, "transfer", tr.Method)
+		require.Equal(t, callflag.All, tr.CallFlags)
+		require.Equal(t, sender, tr.From)
+		require.Equal(t, to, tr.To)
+		require.Equal(t, big.NewInt(100500), tr.Amount)
+		require.Equal(t, stackitem.Null{}, tr.Data)
+	})
+	t.Run("GAS with data", func(t *testing.T) {
+		tx, err := gas.New(act).TransferUnsigned(sender, to, new(big.Int).Lsh(big.NewInt(1), 100), []any{"memo", 1})
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 1)
+		tr, ok := events[0].(*ParsedTransfer)
+		require.True(t, ok)
+		require.Equal(t, gas.Hash, tr.Contract)
+		require.Equal(t, new(big.Int).Lsh(big.NewInt(1), 100), tr.Amount)
+		require.Equal(t, stackitem.NewArray([]stackitem.Item{stackitem.Make("memo"), stackitem.Make(1)}), tr.Data)
+	})
+	t.Run("multitransfer", func(t *testing.T) {
+		tx, err := nep17.New(act, token).MultiTransferUnsigned([]nep17.TransferParameters{
+			{From: sender, To: to, Amount: big.NewInt(1)},
+			{From: sender, To: other, Amount: big.NewInt(0), Data: stackitem.NewMapWithValue([]stackitem.MapElement{
+				{Key: stackitem.Make("k"), Value: stackitem.Make(1)},
+			})},
+			{From: sender, To: other, Amount: big.NewInt(-5)},
+		})
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 3)
+		for i, exp := range []struct {
+			to     util.Uint160
+			amount int64
+		}{{to, 1}, {other, 0}, {other, -5}} {
+			tr, ok := events[i].(*ParsedTransfer)
+			require.True(t, ok, i)
+			require.Equal(t, exp.to, tr.To)
+			require.Equal(t, big.NewInt(exp.amount), tr.Amount)
+		}
+		require.Equal(t, stackitem.NewMapWithValue([]stackitem.MapElement{
+			{Key: stackitem.Make("k"), Value: stackitem.Make(1)},
+		}), events[1].(*ParsedTransfer).Data)
+	})
+	t.Run("no assert", func(t *testing.T) {
+		tx, err := act.MakeUnsignedCall(token, "transfer", nil, sender, to, 5, nil)
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 1)
+		tr, ok := events[0].(*ParsedTransfer)
+		require.True(t, ok)
+		require.Equal(t, big.NewInt(5), tr.Amount)
+	})
+}
+
+func TestParseCalls(t *testing.T) {
+	act, sender := newTestActor(t)
+	token := util.Uint160{1, 2, 3}
+
+	t.Run("vote", func(t *testing.T) {
+		k, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		tx, err := neo.New(act).VoteUnsigned(sender, k.PublicKey())
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 1)
+		c, ok := events[0].(*ContractCall)
+		require.True(t, ok)
+		require.Equal(t, neo.Hash, c.Contract)
+		require.Equal(t, "vote", c.Method)
+		require.Equal(t, []stackitem.Item{stackitem.Make(sender), stackitem.Make(k.PublicKey().Bytes())}, c.Args)
+	})
+	t.Run("NEP-11 transfer", func(t *testing.T) {
+		tx, err := act.MakeUnsignedCall(token, "transfer", nil, sender, []byte{1}, nil)
+		require.NoError(t, err)
+		events := Parse(tx)
+		require.Len(t, events, 1)
+		_, ok := events[0].(*ContractCall)
+		require.True(t, ok)
+	})
+	t.Run("bad transfer arguments", func(t *testing.T) {
+		for _, args := range [][]any{
+			{sender.BytesBE()[1:], token, 1, nil},
+			{sender, "not a hash", 1, nil},
+			{sender, token, true, nil},
+			{sender, token, "1", nil},
+			{sender, []any{token}, 1, nil},
+			{sender, token, 1, nil, nil},
+		} {
+			script, err := smartcontract.CreateCallWithAssertScript(token, "transfer", args...)
+			require.NoError(t, err)
+			events := Parse(&transaction.Transaction{Script: script})
+			require.Len(t, events, 1)
+			c, ok := events[0].(*ContractCall)
+			require.True(t, ok)
+			require.Equal(t, "transfer", c.Method)
+		}
+	})
+	t.Run("mixed", func(t *testing.T) {
+		b := smartcontract.NewBuilder()
+		b.InvokeMethod(neo.Hash, "unclaimedGas", sender, 1)
+		b.InvokeWithAssert(gas.Hash, "transfer", sender, token, 1, nil)
+		b.InvokeMethod(token, "noArgs")
+		script, err := b.Script()
+		require.NoError(t, err)
+		events := Parse(&transaction.Transaction{Script: script})
+		require.Len(t, events, 3)
+		require.Equal(t, "unclaimedGas", events[0].(*ContractCall).Method)
+		require.Equal(t, gas.Hash, events[1].(*ParsedTransfer).Contract)
+		require.Equal(t, "noArgs", events[2].(*ContractCall).Method)
+		require.Empty(t, events[2].(*ContractCall).Args)
+	})
+}
+
+func TestParseUnknown(t *testing.T) {
+	call := func(w *io.BinWriter) {
+		emit.AppCall(w, util.Uint160{1}, "method", callflag.All, 1)
+	}
+	for name, f := range map[string]func(w *io.BinWriter){
+		"empty": func(w *io.BinWriter) {},
+		"syscall": func(w *io.BinWriter) {
+			emit.String(w, "log")
+			emit.Syscall(w, interopnames.SystemRuntimeLog)
+		},
+		"arithmetic": func(w *io.BinWriter) {
+			emit.Opcodes(w, opcode.PUSH1, opcode.PUSH2, opcode.ADD)
+		},
+		"call after code": func(w *io.BinWriter) {
+			emit.Opcodes(w, opcode.PUSH1, opcode.PUSH2, opcode.ADD, opcode.DROP)
+			call(w)
+		},
+		"result used": func(w *io.BinWriter) {
+			call(w)
+			emit.Opcodes(w, opcode.PUSH1, opcode.PACK)
+		},
+		"stack underflow": func(w *io.BinWriter) {
+			emit.Opcodes(w, opcode.PUSH1, opcode.PACK)
+		},
+		"bad flags": func(w *io.BinWriter) {
+			emit.Array(w, 1)
+			emit.Int(w, 100)
+			emit.String(w, "method")
+			emit.Bytes(w, util.Uint160{}.BytesBE())
+			emit.Syscall(w, interopnames.SystemContractCall)
+		},
+		"bad hash": func(w *io.BinWriter) {
+			emit.Array(w, 1)
+			emit.Int(w, int64(callflag.All))
+			emit.String(w, "method")
+			emit.Bytes(w, []byte{1, 2, 3})
+			emit.Syscall(w, interopnames.SystemContractCall)
+		},
+		"bad arguments": func(w *io.BinWriter) {
+			emit.Int(w, 1)
+			emit.Int(w, int64(callflag.All))
+			emit.String(w, "method")
+			emit.Bytes(w, util.Uint160{}.BytesBE())
+			emit.Syscall(w, interopnames.SystemContractCall)
+		},
+		"truncated": func(w *io.BinWriter) {
+			emit.Instruction(w, opcode.PUSHDATA1, []byte{10})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := io.NewBufBinWriter()
+			f(w.BinWriter)
+			require.NoError(t, w.Err)
+			script := w.Bytes()
+			if name == "truncated" {
+				script = script[:len(script)-1]
+			}
+			tx := &transaction.Transaction{Script: script}
+			require.Equal(t, []Event{&Unknown{Tx: tx}}, Parse(tx))
+		})
+	}
+}
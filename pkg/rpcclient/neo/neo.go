@@ -25,8 +25,9 @@ import (
 )
 
 const (
-	setGasMethod = "setGasPerBlock"
-	setRegMethod = "setRegisterPrice"
+	claimGasMethod = "claimGas"
+	setGasMethod   = "setGasPerBlock"
+	setRegMethod   = "setRegisterPrice"
 )
 
 // Invoker is used by ContractReader to perform read-only calls.
@@ -131,6 +132,47 @@ func (c *ContractReader) GetAccountState(account util.Uint160) (*state.NEOBalanc
 	return res, nil
 }
 
+// UnclaimedGasDetailed returns the breakdown of GAS that will be generated
+// if any NEO state change ("claim") is to happen for the given account in the
+// next block: GAS generated for NEO holding, GAS generated for voting and the
+// height of the last claim. It can return nil with no error if the account
+// given has no NEO. This method is available since NeoGoExtensions hard-fork.
+func (c *ContractReader) UnclaimedGasDetailed(account util.Uint160) (*state.NEOUnclaimedGAS, error) {
+	itm, err := unwrap.Item(c.invoker.Call(Hash, "unclaimedGasDetailed", account))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, nil
+	}
+	res := new(state.NEOUnclaimedGAS)
+	err = res.FromStackItem(itm)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// GetVoterInfo returns voting data for the account: the candidate voted for,
+// the height of the last NEO balance change and GAS per vote values used for
+// voter reward calculation. It can return nil with no error if the account
+// given has no NEO. This method is available since NeoGoExtensions hard-fork.
+func (c *ContractReader) GetVoterInfo(account util.Uint160) (*state.NEOVoterInfo, error) {
+	itm, err := unwrap.Item(c.invoker.Call(Hash, "getVoterInfo", account))
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := itm.(stackitem.Null); ok {
+		return nil, nil
+	}
+	res := new(state.NEOVoterInfo)
+	err = res.FromStackItem(itm)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 // GetAllCandidates returns an iterator that allows to retrieve all registered
 // validators from it. It depends on the server to provide proper session-based
 // iterator, but can also work with expanded one.
@@ -419,6 +461,40 @@ func voteScript(account util.Uint160, voteTo *keys.PublicKey) []byte {
 	return script
 }
 
+// ClaimGas creates and sends a transaction that mints GAS generated by the
+// given account's NEO to this account (the same way any NEO transfer does, but
+// without NEO Transfer notification). The action is successful when
+// transaction ends in HALT state, the amount claimed is returned by the
+// "claimGas" method. Notice that the account must witness the transaction, so
+// use an appropriate Actor. This method is available since NeoGoExtensions
+// hard-fork. The returned values are transaction hash, its ValidUntilBlock
+// value and an error if any.
+func (c *Contract) ClaimGas(account util.Uint160) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, claimGasMethod, account)
+}
+
+// ClaimGasTransaction creates a transaction that mints GAS generated by the
+// given account's NEO to this account (the same way any NEO transfer does, but
+// without NEO Transfer notification). The action is successful when
+// transaction ends in HALT state. Notice that the account must witness the
+// transaction, so use an appropriate Actor. This method is available since
+// NeoGoExtensions hard-fork. The transaction is signed, but not sent to the
+// network, instead it's returned to the caller.
+func (c *Contract) ClaimGasTransaction(account util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, claimGasMethod, account)
+}
+
+// ClaimGasUnsigned creates a transaction that mints GAS generated by the
+// given account's NEO to this account (the same way any NEO transfer does, but
+// without NEO Transfer notification). The action is successful when
+// transaction ends in HALT state. Notice that the account must witness the
+// transaction, so use an appropriate Actor. This method is available since
+// NeoGoExtensions hard-fork. The transaction is not signed and just returned
+// to the caller.
+func (c *Contract) ClaimGasUnsigned(account util.Uint160) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, claimGasMethod, nil, account)
+}
+
 // SetGasPerBlock creates and sends a transaction that sets the new amount of
 // GAS to be generated in each block. The action is successful when transaction
 // ends in HALT state. Notice that this setting can be changed only by the
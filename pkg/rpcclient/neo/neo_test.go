@@ -118,6 +118,104 @@ func TestGetAccountState(t *testing.T) {
 	}, st)
 }
 
+func TestUnclaimedGasDetailed(t *testing.T) {
+	ta := &testAct{}
+	neo := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, err := neo.UnclaimedGasDetailed(util.Uint160{})
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(42),
+		},
+	}
+	_, err = neo.UnclaimedGasDetailed(util.Uint160{})
+	require.Error(t, err)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Null{},
+		},
+	}
+	u, err := neo.UnclaimedGasDetailed(util.Uint160{})
+	require.NoError(t, err)
+	require.Nil(t, u)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make([]stackitem.Item{
+				stackitem.Make(100500),
+				stackitem.Make(500),
+				stackitem.Make(42),
+			}),
+		},
+	}
+	u, err = neo.UnclaimedGasDetailed(util.Uint160{})
+	require.NoError(t, err)
+	require.Equal(t, &state.NEOUnclaimedGAS{
+		HolderReward:    *big.NewInt(100500),
+		VoterReward:     *big.NewInt(500),
+		LastClaimHeight: 42,
+	}, u)
+}
+
+func TestGetVoterInfo(t *testing.T) {
+	ta := &testAct{}
+	neo := NewReader(ta)
+
+	ta.err = errors.New("")
+	_, err := neo.GetVoterInfo(util.Uint160{})
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(42),
+		},
+	}
+	_, err = neo.GetVoterInfo(util.Uint160{})
+	require.Error(t, err)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Null{},
+		},
+	}
+	v, err := neo.GetVoterInfo(util.Uint160{})
+	require.NoError(t, err)
+	require.Nil(t, v)
+
+	k, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make([]stackitem.Item{
+				stackitem.Make(k.PublicKey().Bytes()),
+				stackitem.Make(42),
+				stackitem.Make(100),
+				stackitem.Make(500),
+			}),
+		},
+	}
+	v, err = neo.GetVoterInfo(util.Uint160{})
+	require.NoError(t, err)
+	require.Equal(t, &state.NEOVoterInfo{
+		VoteTo:           k.PublicKey(),
+		BalanceHeight:    42,
+		LastGasPerVote:   *big.NewInt(100),
+		LatestGasPerVote: *big.NewInt(500),
+	}, v)
+}
+
 func TestGetAllCandidates(t *testing.T) {
 	ta := &testAct{}
 	neo := NewReader(ta)
@@ -482,6 +580,35 @@ func TestVote(t *testing.T) {
 	require.Equal(t, ta.tx, tx)
 }
 
+func TestClaimGas(t *testing.T) {
+	ta := new(testAct)
+	neo := New(ta)
+
+	ta.err = errors.New("")
+	_, _, err := neo.ClaimGas(util.Uint160{})
+	require.Error(t, err)
+	_, err = neo.ClaimGasTransaction(util.Uint160{})
+	require.Error(t, err)
+	_, err = neo.ClaimGasUnsigned(util.Uint160{})
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	h, vub, err := neo.ClaimGas(util.Uint160{})
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+
+	ta.tx = &transaction.Transaction{Nonce: 100500, ValidUntilBlock: 42}
+	tx, err := neo.ClaimGasTransaction(util.Uint160{})
+	require.NoError(t, err)
+	require.Equal(t, ta.tx, tx)
+	tx, err = neo.ClaimGasUnsigned(util.Uint160{})
+	require.NoError(t, err)
+	require.Equal(t, ta.tx, tx)
+}
+
 func TestRegisterCandidate(t *testing.T) {
 	ta := new(testAct)
 	neo := New(ta)
@@ -6,6 +6,10 @@ Base types) and divisible (DivisibleReader and Divisible) and non-divisible
 (NonDivisibleReader and NonDivisible). If you don't know the type of NEP-11
 contract you're going to use you can use Base and BaseReader types for many
 purposes, otherwise more specific types are recommended.
+
+Token properties can be retrieved as is (Properties) or with well-known ones
+decoded into Properties structure (TypedProperties). NEP-24 royalties extension
+is supported via RoyaltyInfo method and RoyaltiesTransferredEvent.
 */
 package nep11
 
@@ -21,6 +25,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -75,6 +80,17 @@ type TransferEvent struct {
 	ID     []byte
 }
 
+// Properties is a set of token properties with well-known NEP-11 ones
+// (name/description/image/tokenURI) decoded into the corresponding fields.
+type Properties struct {
+	Name        string
+	Description string
+	Image       string
+	TokenURI    string
+	// Other contains all other properties returned by the contract as is.
+	Other map[string]stackitem.Item
+}
+
 // TokenIterator is used for iterating over TokensOf results.
 type TokenIterator struct {
 	client   Invoker
@@ -104,6 +120,14 @@ func (t *BaseReader) Properties(token []byte) (*stackitem.Map, error) {
 	return unwrap.Map(t.invoker.Call(t.hash, "properties", token))
 }
 
+// TypedProperties is similar to Properties, but it returns well-known NEP-11
+// properties as typed fields keeping all other ones in Properties.Other (see
+// UnwrapProperties). It's an optional method per NEP-11 specification, so it
+// can fail.
+func (t *BaseReader) TypedProperties(token []byte) (*Properties, error) {
+	return UnwrapProperties(t.Properties(token))
+}
+
 // Tokens returns an iterator that allows to retrieve all tokens minted by the
 // contract. It depends on the server to provide proper session-based
 // iterator, but can also work with expanded one. The method itself is optional
@@ -236,18 +260,66 @@ func UnwrapKnownProperties(m *stackitem.Map, err error) (map[string]string, erro
 		if !result.KnownNEP11Properties[ks] { // Some additional elements are OK.
 			continue
 		}
-		v, err := e.Value.TryBytes()
-		if err != nil { // But known ones MUST be proper strings.
-			return nil, fmt.Errorf("invalid %s property: %w", ks, err)
+		v, err := knownProperty(ks, e.Value)
+		if err != nil {
+			return nil, err
+		}
+		res[ks] = v
+	}
+	return res, nil
+}
+
+// UnwrapProperties can be used as a proxy function to convert properties map
+// into Properties. Well-known NEP-11 properties are checked the same way
+// UnwrapKnownProperties does, all other elements are kept in Properties.Other
+// as is.
+func UnwrapProperties(m *stackitem.Map, err error) (*Properties, error) {
+	if err != nil {
+		return nil, err
+	}
+	elems := m.Value().([]stackitem.MapElement)
+	res := &Properties{Other: make(map[string]stackitem.Item)}
+	for _, e := range elems {
+		k, err := e.Key.TryBytes()
+		if err != nil { // Shouldn't ever happen in the valid Map, but.
+			continue
+		}
+		ks := string(k)
+		if !result.KnownNEP11Properties[ks] {
+			res.Other[ks] = e.Value
+			continue
+		}
+		v, err := knownProperty(ks, e.Value)
+		if err != nil {
+			return nil, err
 		}
-		if !utf8.Valid(v) {
-			return nil, fmt.Errorf("invalid %s property: not a UTF-8 string", ks)
+		switch ks {
+		case "name":
+			res.Name = v
+		case "description":
+			res.Description = v
+		case "image":
+			res.Image = v
+		case "tokenURI":
+			res.TokenURI = v
 		}
-		res[ks] = string(v)
 	}
 	return res, nil
 }
 
+// knownProperty converts the value of well-known property to string checking
+// it to be a valid UTF-8 one.
+func knownProperty(name string, item stackitem.Item) (string, error) {
+	v, err := item.TryBytes()
+	if err != nil { // Known properties MUST be proper strings.
+		return "", fmt.Errorf("invalid %s property: %w", name, err)
+	}
+	if !utf8.Valid(v) {
+		return "", fmt.Errorf("invalid %s property: not a UTF-8 string", name)
+	}
+	return string(v), nil
+}
+
 // TransferEventsFromApplicationLog retrieves all emitted TransferEvents from the
 // provided [result.ApplicationLog].
 func TransferEventsFromApplicationLog(log *result.ApplicationLog) ([]*TransferEvent, error) {
@@ -285,32 +357,25 @@ func (e *TransferEvent) FromStackItem(item *stackitem.Array) error {
 		return errors.New("wrong number of event parameters")
 	}
 
-	b, err := arr[0].TryBytes()
+	var err error
+	e.From, err = eventdecode.Hash160(arr[0])
 	if err != nil {
 		return fmt.Errorf("invalid From: %w", err)
 	}
-	e.From, err = util.Uint160DecodeBytesBE(b)
-	if err != nil {
-		return fmt.Errorf("failed to decode From: %w", err)
-	}
 
-	b, err = arr[1].TryBytes()
+	e.To, err = eventdecode.Hash160(arr[1])
 	if err != nil {
 		return fmt.Errorf("invalid To: %w", err)
 	}
-	e.To, err = util.Uint160DecodeBytesBE(b)
-	if err != nil {
-		return fmt.Errorf("failed to decode To: %w", err)
-	}
 
-	e.Amount, err = arr[2].TryInteger()
+	e.Amount, err = eventdecode.Integer(arr[2])
 	if err != nil {
-		return fmt.Errorf("field to decode Avount: %w", err)
+		return fmt.Errorf("invalid Amount: %w", err)
 	}
 
-	e.ID, err = arr[3].TryBytes()
+	e.ID, err = eventdecode.Bytes(arr[3])
 	if err != nil {
-		return fmt.Errorf("failed to decode ID: %w", err)
+		return fmt.Errorf("invalid ID: %w", err)
 	}
 
 	return nil
@@ -339,3 +339,72 @@ func TestUnwrapKnownProperties(t *testing.T) {
 	require.Equal(t, "thing", m["name"])
 	require.Equal(t, "good NFT", m["description"])
 }
+
+func TestUnwrapProperties(t *testing.T) {
+	_, err := UnwrapProperties(stackitem.NewMap(), errors.New(""))
+	require.Error(t, err)
+
+	p, err := UnwrapProperties(stackitem.NewMap(), nil)
+	require.NoError(t, err)
+	require.Equal(t, &Properties{Other: map[string]stackitem.Item{}}, p)
+
+	_, err = UnwrapProperties(stackitem.NewMapWithValue([]stackitem.MapElement{
+		{Key: stackitem.Make("image"), Value: stackitem.Make([]stackitem.Item{})},
+	}), nil)
+	require.Error(t, err)
+
+	_, err = UnwrapProperties(stackitem.NewMapWithValue([]stackitem.MapElement{
+		{Key: stackitem.Make("tokenURI"), Value: stackitem.Make([]byte{0xff})},
+	}), nil)
+	require.Error(t, err)
+
+	p, err = UnwrapProperties(stackitem.NewMapWithValue([]stackitem.MapElement{
+		{Key: stackitem.Make("name"), Value: stackitem.Make("thing")},
+		{Key: stackitem.Make("description"), Value: stackitem.Make("good NFT")},
+		{Key: stackitem.Make("image"), Value: stackitem.Make("https://example.com/nft.png")},
+		{Key: stackitem.Make("tokenURI"), Value: stackitem.Make("https://example.com/nft.json")},
+		{Key: stackitem.Make("rarity"), Value: stackitem.Make(42)},
+		{Key: stackitem.Make([]stackitem.Item{}), Value: stackitem.Make("skipped")},
+	}), nil)
+	require.NoError(t, err)
+	require.Equal(t, &Properties{
+		Name:        "thing",
+		Description: "good NFT",
+		Image:       "https://example.com/nft.png",
+		TokenURI:    "https://example.com/nft.json",
+		Other:       map[string]stackitem.Item{"rarity": stackitem.Make(42)},
+	}, p)
+}
+
+func TestReaderTypedProperties(t *testing.T) {
+	ta := new(testAct)
+	tr := NewBaseReader(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, err := tr.TypedProperties([]byte{3, 2, 1})
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewMapWithValue([]stackitem.MapElement{
+				{Key: stackitem.Make("name"), Value: stackitem.Make("thing")},
+				{Key: stackitem.Make("some"), Value: stackitem.Make("other")},
+			}),
+		},
+	}
+	p, err := tr.TypedProperties([]byte{3, 2, 1})
+	require.NoError(t, err)
+	require.Equal(t, "thing", p.Name)
+	require.Equal(t, map[string]stackitem.Item{"some": stackitem.Make("other")}, p.Other)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(42),
+		},
+	}
+	_, err = tr.TypedProperties([]byte{3, 2, 1})
+	require.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package nep11
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
 
@@ -68,6 +69,33 @@ func (t *DivisibleReader) OwnerOfExpanded(token []byte, num int) ([]util.Uint160
 	return unwrap.ArrayOfUint160(t.invoker.CallAndExpandIterator(t.hash, "ownerOf", num, token))
 }
 
+// OwnersOf returns all owners of the given token traversing the whole OwnerOf
+// iterator. Session-based iterators are terminated after use (even in case of
+// error), expanded ones are accepted only if they're not truncated by the
+// server.
+func (t *DivisibleReader) OwnersOf(token []byte) ([]util.Uint160, error) {
+	iter, err := t.OwnerOf(token)
+	if err != nil {
+		return nil, err
+	}
+	if iter.iterator.ID == nil && iter.iterator.Truncated {
+		return nil, errors.New("owners list is truncated by the server")
+	}
+	var res []util.Uint160
+	for {
+		owners, err := iter.Next(0)
+		if err != nil {
+			_ = iter.Terminate()
+			return nil, err
+		}
+		if len(owners) == 0 {
+			break
+		}
+		res = append(res, owners...)
+	}
+	return res, iter.Terminate()
+}
+
 // BalanceOfD is a BalanceOf for divisible NFTs, it returns the amount of token
 // owned by a particular account.
 func (t *DivisibleReader) BalanceOfD(owner util.Uint160, token []byte) (*big.Int, error) {
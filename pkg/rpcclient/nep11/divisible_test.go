@@ -174,6 +174,110 @@ func TestDivisibleOwnerOf(t *testing.T) {
 	}
 }
 
+// iterAct is a testAct that handles iterators the way invoker.Invoker does.
+type iterAct struct {
+	testAct
+
+	batches    [][]stackitem.Item
+	terminated bool
+}
+
+func (t *iterAct) TerminateSession(sessionID uuid.UUID) error {
+	t.terminated = true
+	return t.err
+}
+
+func (t *iterAct) TraverseIterator(sessionID uuid.UUID, iterator *result.Iterator, num int) ([]stackitem.Item, error) {
+	if t.err != nil {
+		return nil, t.err
+	}
+	if iterator.ID == nil {
+		res := iterator.Values
+		iterator.Values = nil
+		return res, nil
+	}
+	if len(t.batches) == 0 {
+		return nil, nil
+	}
+	res := t.batches[0]
+	t.batches = t.batches[1:]
+	return res, nil
+}
+
+func TestDivisibleOwnersOf(t *testing.T) {
+	ta := new(iterAct)
+	tr := NewDivisibleReader(ta, util.Uint160{1, 2, 3})
+	h1 := util.Uint160{1, 2, 3}
+	h2 := util.Uint160{3, 2, 1}
+	h3 := util.Uint160{4, 5, 6}
+
+	ta.err = errors.New("")
+	_, err := tr.OwnersOf([]byte{1})
+	require.Error(t, err)
+
+	// Value-based iterator.
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(result.Iterator{
+				Values: []stackitem.Item{
+					stackitem.Make(h1.BytesBE()),
+					stackitem.Make(h2.BytesBE()),
+				},
+			}),
+		},
+	}
+	owners, err := tr.OwnersOf([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{h1, h2}, owners)
+	require.False(t, ta.terminated)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(result.Iterator{
+				Values: []stackitem.Item{
+					stackitem.Make(h1.BytesBE()),
+				},
+				Truncated: true,
+			}),
+		},
+	}
+	_, err = tr.OwnersOf([]byte{1})
+	require.Error(t, err)
+
+	// Session-based iterator.
+	iid := uuid.New()
+	ta.res = &result.Invoke{
+		Session: uuid.New(),
+		State:   "HALT",
+		Stack: []stackitem.Item{
+			stackitem.NewInterop(result.Iterator{
+				ID: &iid,
+			}),
+		},
+	}
+	ta.batches = [][]stackitem.Item{
+		{stackitem.Make(h1.BytesBE()), stackitem.Make(h2.BytesBE())},
+		{stackitem.Make(h3.BytesBE())},
+	}
+	owners, err = tr.OwnersOf([]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{h1, h2, h3}, owners)
+	require.True(t, ta.terminated)
+
+	// Session is terminated on failure too.
+	ta.terminated = false
+	ta.batches = [][]stackitem.Item{
+		{stackitem.Make(h1.BytesBE())},
+		{stackitem.Make("not uint160")},
+	}
+	_, err = tr.OwnersOf([]byte{1})
+	require.Error(t, err)
+	require.True(t, ta.terminated)
+}
+
 func TestDivisibleTransfer(t *testing.T) {
 	ta := new(testAct)
 	tok := NewDivisible(ta, util.Uint160{1, 2, 3})
@@ -0,0 +1,228 @@
+package nep11_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/nspcc-dev/neo-go/internal/contracts"
+	"github.com/nspcc-dev/neo-go/internal/random"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/iterator"
+	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/nep11"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+const examplesPath = "../../../examples"
+
+// chainActor implements nep11.Actor over neotest executor. Iterators returned
+// from invocations are kept in a single session, like RPC server does.
+type chainActor struct {
+	t      testing.TB
+	e      *neotest.Executor
+	signer neotest.Signer
+
+	session    uuid.UUID
+	iterators  map[uuid.UUID]stackitem.Item
+	terminated int
+}
+
+func newChainActor(t testing.TB, e *neotest.Executor, signer neotest.Signer) *chainActor {
+	return &chainActor{
+		t:         t,
+		e:         e,
+		signer:    signer,
+		session:   uuid.New(),
+		iterators: make(map[uuid.UUID]stackitem.Item),
+	}
+}
+
+func (a *chainActor) run(script []byte) (*result.Invoke, error) {
+	stack, err := a.e.NewInvoker(util.Uint160{}, a.signer).TestInvokeScript(a.t, script, []neotest.Signer{a.signer})
+	if err != nil {
+		return &result.Invoke{State: "FAULT", FaultException: err.Error()}, nil
+	}
+	items := stack.ToArray()
+	for i := range items {
+		if iterator.IsIterator(items[i]) {
+			id := uuid.New()
+			a.iterators[id] = items[i]
+			items[i] = stackitem.NewInterop(result.Iterator{ID: &id})
+		}
+	}
+	return &result.Invoke{State: "HALT", Stack: items, Session: a.session}, nil
+}
+
+func (a *chainActor) Call(contract util.Uint160, operation string, params ...any) (*result.Invoke, error) {
+	script, err := smartcontract.CreateCallScript(contract, operation, params...)
+	if err != nil {
+		return nil, err
+	}
+	return a.run(script)
+}
+
+func (a *chainActor) CallAndExpandIterator(contract util.Uint160, method string, maxItems int, params ...any) (*result.Invoke, error) {
+	script, err := smartcontract.CreateCallAndUnwrapIteratorScript(contract, method, maxItems, params...)
+	if err != nil {
+		return nil, err
+	}
+	return a.run(script)
+}
+
+func (a *chainActor) TerminateSession(sessionID uuid.UUID) error {
+	if sessionID != a.session {
+		return errors.New("unknown session")
+	}
+	a.terminated++
+	a.iterators = make(map[uuid.UUID]stackitem.Item)
+	return nil
+}
+
+func (a *chainActor) TraverseIterator(sessionID uuid.UUID, iter *result.Iterator, num int) ([]stackitem.Item, error) {
+	if sessionID != a.session {
+		return nil, errors.New("unknown session")
+	}
+	item, ok := a.iterators[*iter.ID]
+	if !ok {
+		return nil, errors.New("unknown iterator")
+	}
+	if num <= 0 {
+		num = invoker.DefaultIteratorResultItems
+	}
+	return iterator.Values(item, num), nil
+}
+
+func (a *chainActor) MakeRun(script []byte) (*transaction.Transaction, error) {
+	return a.e.PrepareInvocation(a.t, script, []neotest.Signer{a.signer}), nil
+}
+
+func (a *chainActor) MakeUnsignedRun(script []byte, attrs []transaction.Attribute) (*transaction.Transaction, error) {
+	tx := a.e.PrepareInvocationNoSign(a.t, script)
+	tx.Attributes = append(tx.Attributes, attrs...)
+	return tx, nil
+}
+
+func (a *chainActor) SendRun(script []byte) (util.Uint256, uint32, error) {
+	tx := a.e.PrepareInvocation(a.t, script, []neotest.Signer{a.signer})
+	a.e.AddNewBlock(a.t, tx)
+	return tx.Hash(), tx.ValidUntilBlock, nil
+}
+
+// mint mints a token of the example NFT contract with GAS payment and returns
+// its ID.
+func mint(t *testing.T, e *neotest.Executor, h util.Uint160, to neotest.Signer, data any) []byte {
+	gas := e.NewInvoker(e.NativeHash(t, nativenames.Gas), to)
+	txH := gas.Invoke(t, true, "transfer", to.ScriptHash(), h, 10_0000_0000, data)
+	ex := e.GetTxExecResult(t, txH).Execution
+	var nftEvents []state.NotificationEvent
+	for _, ev := range ex.Events {
+		if ev.ScriptHash.Equals(h) {
+			nftEvents = append(nftEvents, ev)
+		}
+	}
+	ex.Events = nftEvents
+	events, err := nep11.TransferEventsFromApplicationLog(&result.ApplicationLog{
+		Executions: []state.Execution{ex},
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(events))
+	return events[0].ID
+}
+
+func TestNonDivisibleExample(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	h := contracts.DeployExample(t, e, examplesPath, "nft-nd", "nft.yml")
+	owner := e.NewAccount(t)
+	id := mint(t, e, h, owner, nil)
+
+	nft := nep11.NewNonDivisibleReader(newChainActor(t, e, owner), h)
+
+	props, err := nft.TypedProperties(id)
+	require.NoError(t, err)
+	require.Equal(t, &nep11.Properties{
+		Name:  "HASHY " + base64.StdEncoding.EncodeToString(id),
+		Other: map[string]stackitem.Item{},
+	}, props)
+	_, err = nft.TypedProperties([]byte("unknown"))
+	require.Error(t, err)
+
+	royalties, err := nft.RoyaltyInfo(id, e.NativeHash(t, nativenames.Gas), big.NewInt(1000))
+	require.NoError(t, err)
+	contractOwner, err := address.StringToUint160("NbrUYaZgyhSkNoRo9ugRyEMdUZxrhkNaWB")
+	require.NoError(t, err)
+	require.Equal(t, []nep11.RoyaltyRecipient{{Address: contractOwner, Amount: big.NewInt(100)}}, royalties)
+	_, err = nft.RoyaltyInfo([]byte("unknown"), e.NativeHash(t, nativenames.Gas), big.NewInt(1000))
+	require.Error(t, err)
+	_, err = nft.RoyaltyInfo(id, e.NativeHash(t, nativenames.Gas), big.NewInt(-1))
+	require.Error(t, err)
+}
+
+func TestDivisibleExample(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+	h := contracts.DeployExample(t, e, examplesPath, "nft-d", "nft.yml")
+	owner := e.NewAccount(t)
+	containerID, objectID := random.Bytes(util.Uint256Size), random.Bytes(util.Uint256Size)
+	id := mint(t, e, h, owner, []any{containerID, objectID})
+
+	act := newChainActor(t, e, owner)
+	nft := nep11.NewDivisible(act, h)
+
+	props, err := nft.TypedProperties(id)
+	require.NoError(t, err)
+	require.Equal(t, "NeoFS Object "+base64.StdEncoding.EncodeToString(id), props.Name)
+	require.Equal(t, 2, len(props.Other))
+	for k, v := range map[string][]byte{"containerID": containerID, "objectID": objectID} {
+		s, err := props.Other[k].TryBytes()
+		require.NoError(t, err)
+		require.Equal(t, base64.StdEncoding.EncodeToString(v), string(s))
+	}
+
+	owners, err := nft.OwnersOf(id)
+	require.NoError(t, err)
+	require.Equal(t, []util.Uint160{owner.ScriptHash()}, owners)
+	require.Equal(t, 1, act.terminated)
+
+	// Split the token between several owners.
+	others := make([]util.Uint160, 3)
+	for i := range others {
+		others[i] = e.NewAccount(t).ScriptHash()
+		txH, _, err := nft.TransferD(owner.ScriptHash(), others[i], big.NewInt(10), id, nil)
+		require.NoError(t, err)
+		e.CheckHalt(t, txH)
+	}
+	for _, acc := range others {
+		bal, err := nft.BalanceOfD(acc, id)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(10), bal)
+	}
+	bal, err := nft.BalanceOfD(owner.ScriptHash(), id)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(70), bal)
+
+	owners, err = nft.OwnersOf(id)
+	require.NoError(t, err)
+	require.ElementsMatch(t, append(others, owner.ScriptHash()), owners)
+	require.Equal(t, 2, act.terminated)
+
+	// Transferring more than owned fails the transaction.
+	txH, _, err := nft.TransferD(owner.ScriptHash(), others[0], big.NewInt(80), id, nil)
+	require.NoError(t, err)
+	e.CheckFault(t, txH, "ASSERT")
+
+	_, err = nft.OwnersOf([]byte("unknown"))
+	require.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package nep11
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/unwrap"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// RoyaltyRecipient is a single royalty payment description returned from
+// NEP-24 royaltyInfo method.
+type RoyaltyRecipient struct {
+	Address util.Uint160
+	Amount  *big.Int
+}
+
+// RoyaltiesTransferredEvent represents a RoyaltiesTransferred event as defined
+// in the NEP-24 standard.
+type RoyaltiesTransferredEvent struct {
+	RoyaltyToken     util.Uint160
+	RoyaltyRecipient util.Uint160
+	Buyer            util.Uint160
+	ID               []byte
+	Amount           *big.Int
+}
+
+// RoyaltyInfo returns a list of royalty payments to be made for the given token
+// sold for salePrice of royaltyToken. It's a NEP-24 method, so it only works
+// for contracts supporting this standard.
+func (t *BaseReader) RoyaltyInfo(token []byte, royaltyToken util.Uint160, salePrice *big.Int) ([]RoyaltyRecipient, error) {
+	items, err := unwrap.Array(t.invoker.Call(t.hash, "royaltyInfo", token, royaltyToken, salePrice))
+	if err != nil {
+		return nil, err
+	}
+	res := make([]RoyaltyRecipient, len(items))
+	for i := range items {
+		fields, ok := items[i].Value().([]stackitem.Item)
+		if !ok {
+			return nil, fmt.Errorf("element %d is not an array/struct", i)
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("element %d has wrong number of fields", i)
+		}
+		addr, err := fields[0].TryBytes()
+		if err == nil {
+			res[i].Address, err = util.Uint160DecodeBytesBE(addr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("element %d has invalid recipient: %w", i, err)
+		}
+		res[i].Amount, err = fields[1].TryInteger()
+		if err != nil {
+			return nil, fmt.Errorf("element %d has invalid amount: %w", i, err)
+		}
+	}
+	return res, nil
+}
+
+// RoyaltiesTransferredEventsFromApplicationLog retrieves all emitted
+// RoyaltiesTransferredEvents from the provided [result.ApplicationLog].
+func RoyaltiesTransferredEventsFromApplicationLog(log *result.ApplicationLog) ([]*RoyaltiesTransferredEvent, error) {
+	if log == nil {
+		return nil, errors.New("nil application log")
+	}
+	var res []*RoyaltiesTransferredEvent
+	for i, ex := range log.Executions {
+		for j, e := range ex.Events {
+			if e.Name != "RoyaltiesTransferred" {
+				continue
+			}
+			event := new(RoyaltiesTransferredEvent)
+			err := event.FromStackItem(e.Item)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode event from stackitem (event #%d, execution #%d): %w", j, i, err)
+			}
+			res = append(res, event)
+		}
+	}
+	return res, nil
+}
+
+// FromStackItem converts provided [stackitem.Array] to RoyaltiesTransferredEvent
+// or returns an error if it's not possible to do to so.
+func (e *RoyaltiesTransferredEvent) FromStackItem(item *stackitem.Array) error {
+	if item == nil {
+		return errors.New("nil item")
+	}
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return errors.New("not an array")
+	}
+	if len(arr) != 5 {
+		return errors.New("wrong number of event parameters")
+	}
+
+	var err error
+	e.RoyaltyToken, err = eventdecode.Hash160(arr[0])
+	if err != nil {
+		return fmt.Errorf("invalid RoyaltyToken: %w", err)
+	}
+
+	e.RoyaltyRecipient, err = eventdecode.Hash160(arr[1])
+	if err != nil {
+		return fmt.Errorf("invalid RoyaltyRecipient: %w", err)
+	}
+
+	e.Buyer, err = eventdecode.Hash160(arr[2])
+	if err != nil {
+		return fmt.Errorf("invalid Buyer: %w", err)
+	}
+
+	e.ID, err = eventdecode.Bytes(arr[3])
+	if err != nil {
+		return fmt.Errorf("invalid ID: %w", err)
+	}
+
+	e.Amount, err = eventdecode.Integer(arr[4])
+	if err != nil {
+		return fmt.Errorf("invalid Amount: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package nep11
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderRoyaltyInfo(t *testing.T) {
+	ta := new(testAct)
+	tr := NewBaseReader(ta, util.Uint160{1, 2, 3})
+	h := util.Uint160{3, 2, 1}
+
+	ta.err = errors.New("")
+	_, err := tr.RoyaltyInfo([]byte{1}, util.Uint160{}, big.NewInt(100))
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make([]stackitem.Item{
+				stackitem.NewStruct([]stackitem.Item{stackitem.Make(h.BytesBE()), stackitem.Make(10)}),
+			}),
+		},
+	}
+	res, err := tr.RoyaltyInfo([]byte{1}, util.Uint160{}, big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, []RoyaltyRecipient{{Address: h, Amount: big.NewInt(10)}}, res)
+
+	for name, item := range map[string]stackitem.Item{
+		"not an array":    stackitem.Make(42),
+		"not a struct":    stackitem.Make([]stackitem.Item{stackitem.Make(42)}),
+		"wrong field num": stackitem.Make([]stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make(h.BytesBE())})}),
+		"bad recipient":   stackitem.Make([]stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make([]byte{1}), stackitem.Make(10)})}),
+		"null recipient":  stackitem.Make([]stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Null{}, stackitem.Make(10)})}),
+		"bad amount":      stackitem.Make([]stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make(h.BytesBE()), stackitem.Make([]stackitem.Item{})})}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ta.res = &result.Invoke{
+				State: "HALT",
+				Stack: []stackitem.Item{item},
+			}
+			_, err := tr.RoyaltyInfo([]byte{1}, util.Uint160{}, big.NewInt(100))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestRoyaltiesTransferredEvent(t *testing.T) {
+	_, err := RoyaltiesTransferredEventsFromApplicationLog(nil)
+	require.Error(t, err)
+
+	token, recipient, buyer := util.Uint160{1}, util.Uint160{2}, util.Uint160{3}
+	good := stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(token.BytesBE()),
+		stackitem.Make(recipient.BytesBE()),
+		stackitem.Make(buyer.BytesBE()),
+		stackitem.Make([]byte{4, 2}),
+		stackitem.Make(100),
+	})
+	res, err := RoyaltiesTransferredEventsFromApplicationLog(&result.ApplicationLog{
+		Executions: []state.Execution{{
+			Events: []state.NotificationEvent{
+				{Name: "Transfer", Item: stackitem.NewArray(nil)},
+				{Name: "RoyaltiesTransferred", Item: good},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*RoyaltiesTransferredEvent{{
+		RoyaltyToken:     token,
+		RoyaltyRecipient: recipient,
+		Buyer:            buyer,
+		ID:               []byte{4, 2},
+		Amount:           big.NewInt(100),
+	}}, res)
+
+	_, err = RoyaltiesTransferredEventsFromApplicationLog(&result.ApplicationLog{
+		Executions: []state.Execution{{
+			Events: []state.NotificationEvent{
+				{Name: "RoyaltiesTransferred", Item: stackitem.NewArray(good.Value().([]stackitem.Item)[:4])},
+			},
+		}},
+	})
+	require.Error(t, err)
+
+	var e RoyaltiesTransferredEvent
+	require.Error(t, e.FromStackItem(nil))
+	for i := 0; i < 5; i++ {
+		items := append([]stackitem.Item{}, good.Value().([]stackitem.Item)...)
+		items[i] = stackitem.Make([]stackitem.Item{})
+		require.Error(t, e.FromStackItem(stackitem.NewArray(items)), i)
+	}
+}
@@ -12,9 +12,11 @@ import (
 	"math/big"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/neptoken"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 )
@@ -81,6 +83,56 @@ func New(actor Actor, hash util.Uint160) *Token {
 	return &Token{*NewReader(actor, hash), TokenWriter{hash, actor}}
 }
 
+// BalanceOfDecimal is the same as BalanceOf, but returns the balance as a
+// Decimal with token decimals.
+func (t *TokenReader) BalanceOfDecimal(account util.Uint160) (fixedn.Decimal, error) {
+	dec, err := t.Decimals()
+	if err != nil {
+		return fixedn.Decimal{}, err
+	}
+	bal, err := t.BalanceOf(account)
+	if err != nil {
+		return fixedn.Decimal{}, err
+	}
+	return fixedn.NewDecimal(bal, dec), nil
+}
+
+// TotalSupplyDecimal is the same as TotalSupply, but returns the amount as a
+// Decimal with token decimals.
+func (t *TokenReader) TotalSupplyDecimal() (fixedn.Decimal, error) {
+	dec, err := t.Decimals()
+	if err != nil {
+		return fixedn.Decimal{}, err
+	}
+	ts, err := t.TotalSupply()
+	if err != nil {
+		return fixedn.Decimal{}, err
+	}
+	return fixedn.NewDecimal(ts, dec), nil
+}
+
+// Amount converts a Decimal (like the one parsed from a human-readable string
+// with fixedn.ParseDecimal) into an integer token amount using token decimals.
+// The conversion is exact, fixedn.ErrPrecisionLoss is returned if amount has
+// more significant fractional digits than the token supports.
+func (t *TokenReader) Amount(amount fixedn.Decimal) (*big.Int, error) {
+	dec, err := t.Decimals()
+	if err != nil {
+		return nil, err
+	}
+	return amount.Integer(dec)
+}
+
+// TransferDecimal is the same as Transfer, but accepts the amount as a Decimal
+// converted into an integer token amount with Amount.
+func (t *Token) TransferDecimal(from util.Uint160, to util.Uint160, amount fixedn.Decimal, data any) (util.Uint256, uint32, error) {
+	a, err := t.Amount(amount)
+	if err != nil {
+		return util.Uint256{}, 0, err
+	}
+	return t.Transfer(from, to, a, data)
+}
+
 // Transfer creates and sends a transaction that performs a `transfer` method
 // call using the given parameters and checks for this call result, failing the
 // transaction if it's not true. The returned values are transaction hash, its
@@ -188,27 +240,20 @@ func (e *TransferEvent) FromStackItem(item *stackitem.Array) error {
 		return errors.New("wrong number of event parameters")
 	}
 
-	b, err := arr[0].TryBytes()
+	var err error
+	e.From, err = eventdecode.Hash160(arr[0])
 	if err != nil {
 		return fmt.Errorf("invalid From: %w", err)
 	}
-	e.From, err = util.Uint160DecodeBytesBE(b)
-	if err != nil {
-		return fmt.Errorf("failed to decode From: %w", err)
-	}
 
-	b, err = arr[1].TryBytes()
+	e.To, err = eventdecode.Hash160(arr[1])
 	if err != nil {
 		return fmt.Errorf("invalid To: %w", err)
 	}
-	e.To, err = util.Uint160DecodeBytesBE(b)
-	if err != nil {
-		return fmt.Errorf("failed to decode To: %w", err)
-	}
 
-	e.Amount, err = arr[2].TryInteger()
+	e.Amount, err = eventdecode.Integer(arr[2])
 	if err != nil {
-		return fmt.Errorf("field to decode Avount: %w", err)
+		return fmt.Errorf("invalid Amount: %w", err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/fixedn"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -169,3 +170,80 @@ func TestTokenTransferTransaction(t *testing.T) {
 	_, err = tok.MultiTransferUnsigned([]TransferParameters{})
 	require.Error(t, err)
 }
+
+func TestReaderDecimal(t *testing.T) {
+	ta := new(testAct)
+	tr := NewReader(ta, util.Uint160{1, 2, 3})
+
+	ta.err = errors.New("")
+	_, err := tr.BalanceOfDecimal(util.Uint160{3, 2, 1})
+	require.Error(t, err)
+	_, err = tr.TotalSupplyDecimal()
+	require.Error(t, err)
+	_, err = tr.Amount(fixedn.NewDecimal(big.NewInt(1), 0))
+	require.Error(t, err)
+
+	// Both decimals and balance are 3 here.
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(3),
+		},
+	}
+	bal, err := tr.BalanceOfDecimal(util.Uint160{3, 2, 1})
+	require.NoError(t, err)
+	require.Equal(t, "0.003", bal.String())
+	ts, err := tr.TotalSupplyDecimal()
+	require.NoError(t, err)
+	require.Equal(t, "0.003", ts.String())
+
+	d, err := fixedn.ParseDecimal("1.5")
+	require.NoError(t, err)
+	a, err := tr.Amount(d)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1500), a)
+
+	d, err = fixedn.ParseDecimal("1.0005")
+	require.NoError(t, err)
+	_, err = tr.Amount(d)
+	require.ErrorIs(t, err, fixedn.ErrPrecisionLoss)
+
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(-1),
+		},
+	}
+	_, err = tr.BalanceOfDecimal(util.Uint160{3, 2, 1})
+	require.Error(t, err)
+}
+
+func TestTokenTransferDecimal(t *testing.T) {
+	ta := new(testAct)
+	tok := New(ta, util.Uint160{1, 2, 3})
+	d, err := fixedn.ParseDecimal("0.5")
+	require.NoError(t, err)
+
+	ta.err = errors.New("")
+	_, _, err = tok.TransferDecimal(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, d, nil)
+	require.Error(t, err)
+
+	ta.err = nil
+	ta.res = &result.Invoke{
+		State: "HALT",
+		Stack: []stackitem.Item{
+			stackitem.Make(0),
+		},
+	}
+	_, _, err = tok.TransferDecimal(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, d, nil)
+	require.ErrorIs(t, err, fixedn.ErrPrecisionLoss)
+
+	ta.res.Stack[0] = stackitem.Make(8)
+	ta.txh = util.Uint256{1, 2, 3}
+	ta.vub = 42
+	h, vub, err := tok.TransferDecimal(util.Uint160{3, 2, 1}, util.Uint160{3, 2, 1}, d, nil)
+	require.NoError(t, err)
+	require.Equal(t, ta.txh, h)
+	require.Equal(t, ta.vub, vub)
+}
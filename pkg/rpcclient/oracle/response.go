@@ -0,0 +1,171 @@
+package oracle
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// prefixRequest is the storage prefix of requests in the native Oracle
+// contract.
+const prefixRequest = 7
+
+// RequestReader is an RPC interface allowing to read pending oracle requests
+// from the native Oracle contract storage. Both rpcclient.Client and
+// rpcclient.WSClient implement it.
+type RequestReader interface {
+	GetStorageByHash(hash util.Uint160, key []byte) ([]byte, error)
+}
+
+// NetworkFeeCalculator is an RPC interface allowing to calculate network fee
+// of oracle response transaction. Both rpcclient.Client and
+// rpcclient.WSClient implement it.
+type NetworkFeeCalculator interface {
+	CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
+}
+
+// ResponseScript is the script of any oracle response transaction, it calls
+// "finish" method of the native Oracle contract.
+var ResponseScript = func() []byte {
+	script, err := smartcontract.CreateCallScript(Hash, "finish")
+	if err != nil {
+		panic(err)
+	}
+	return script
+}()
+
+// GetRequest returns the pending request with the given ID. The request is
+// removed from the contract storage once the response for it is accepted, so
+// an error is returned for completed (or never made) requests.
+func GetRequest(r RequestReader, id uint64) (*state.OracleRequest, error) {
+	var key = make([]byte, 9)
+	key[0] = prefixRequest
+	binary.BigEndian.PutUint64(key[1:], id)
+	data, err := r.GetStorageByHash(Hash, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get request %d: %w", id, err)
+	}
+	req := new(state.OracleRequest)
+	if err := stackitem.DeserializeConvertible(data, req); err != nil {
+		return nil, fmt.Errorf("failed to decode request %d: %w", id, err)
+	}
+	return req, nil
+}
+
+// NewResponseTx creates an unsigned oracle response transaction for the given
+// response that is to be signed by the given oracle nodes. It's valid until
+// the vub block, its fees are not set (see CreateResponseTx). The first
+// witness (the one of the native Oracle contract) is empty and is not to be
+// changed, the second one is to be completed with oracle nodes signatures.
+func NewResponseTx(nodes keys.PublicKeys, resp *transaction.OracleResponse, vub uint32) (*transaction.Transaction, error) {
+	if len(resp.Result) > transaction.MaxOracleResultSize {
+		return nil, fmt.Errorf("result is too big: %d bytes", len(resp.Result))
+	}
+	if resp.Code != transaction.Success && len(resp.Result) != 0 {
+		return nil, fmt.Errorf("result is set for %s response", resp.Code)
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(nodes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid oracle nodes: %w", err)
+	}
+	tx := transaction.New(ResponseScript, 0)
+	tx.Nonce = uint32(resp.ID)
+	tx.ValidUntilBlock = vub
+	tx.Attributes = []transaction.Attribute{{
+		Type:  transaction.OracleResponseT,
+		Value: resp,
+	}}
+	tx.Signers = []transaction.Signer{
+		{Account: Hash, Scopes: transaction.None},
+		{Account: hash.Hash160(script), Scopes: transaction.None},
+	}
+	tx.Scripts = []transaction.Witness{
+		{},
+		{VerificationScript: script},
+	}
+	return tx, nil
+}
+
+// CreateResponseTx creates an unsigned oracle response transaction (see
+// NewResponseTx) for the given request and sets its fees. The network fee is
+// calculated via RPC and the system fee gets the rest of GasForResponse, an
+// error is returned if the network fee is bigger than that.
+func CreateResponseTx(c NetworkFeeCalculator, req *state.OracleRequest, nodes keys.PublicKeys,
+	resp *transaction.OracleResponse, vub uint32) (*transaction.Transaction, error) {
+	tx, err := NewResponseTx(nodes, resp, vub)
+	if err != nil {
+		return nil, err
+	}
+	netFee, err := c.CalculateNetworkFee(tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate network fee: %w", err)
+	}
+	if netFee > int64(req.GasForResponse) {
+		return nil, fmt.Errorf("network fee %d exceeds GAS for response %d", netFee, req.GasForResponse)
+	}
+	tx.NetworkFee = netFee
+	tx.SystemFee = int64(req.GasForResponse) - netFee
+	return tx, nil
+}
+
+// CheckResponseTx checks whether the given transaction is a proper oracle
+// response for the pending request (see GetRequest) made by the given oracle
+// nodes. It doesn't check witnesses.
+func CheckResponseTx(tx *transaction.Transaction, req *state.OracleRequest, nodes keys.PublicKeys) error {
+	attrs := tx.GetAttributes(transaction.OracleResponseT)
+	if len(attrs) != 1 {
+		return errors.New("transaction must have exactly one oracle response attribute")
+	}
+	resp := attrs[0].Value.(*transaction.OracleResponse)
+	if !bytes.Equal(tx.Script, ResponseScript) {
+		return errors.New("invalid script")
+	}
+	if len(resp.Result) > transaction.MaxOracleResultSize {
+		return fmt.Errorf("result is too big: %d bytes", len(resp.Result))
+	}
+	if resp.Code != transaction.Success && len(resp.Result) != 0 {
+		return fmt.Errorf("result is set for %s response", resp.Code)
+	}
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(nodes)
+	if err != nil {
+		return fmt.Errorf("invalid oracle nodes: %w", err)
+	}
+	var nodesHash = hash.Hash160(script)
+	if len(tx.Signers) != 2 || tx.Signers[0].Account != Hash || tx.Signers[1].Account != nodesHash {
+		return fmt.Errorf("signers must be the native Oracle contract and oracle nodes account %s", nodesHash.StringLE())
+	}
+	for i := range tx.Signers {
+		if tx.Signers[i].Scopes != transaction.None {
+			return fmt.Errorf("signer #%d has %s scope instead of None", i, tx.Signers[i].Scopes)
+		}
+	}
+	if uint64(tx.NetworkFee+tx.SystemFee) < req.GasForResponse {
+		return fmt.Errorf("fees (%d) are lower than GAS for response (%d)", tx.NetworkFee+tx.SystemFee, req.GasForResponse)
+	}
+	return nil
+}
+
+// VerifyResponseTx gets the pending request the given oracle response
+// transaction is made for via RPC and checks the transaction against it (see
+// CheckResponseTx). It's supposed to be used before sending the transaction
+// to ensure it can be accepted by the network.
+func VerifyResponseTx(r RequestReader, tx *transaction.Transaction, nodes keys.PublicKeys) error {
+	attrs := tx.GetAttributes(transaction.OracleResponseT)
+	if len(attrs) != 1 {
+		return errors.New("transaction must have exactly one oracle response attribute")
+	}
+	req, err := GetRequest(r, attrs[0].Value.(*transaction.OracleResponse).ID)
+	if err != nil {
+		return err
+	}
+	return CheckResponseTx(tx, req, nodes)
+}
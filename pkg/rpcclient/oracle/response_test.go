@@ -0,0 +1,111 @@
+package oracle
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+type testRPC struct {
+	err    error
+	data   []byte
+	key    []byte
+	netFee int64
+}
+
+func (t *testRPC) GetStorageByHash(hash util.Uint160, key []byte) ([]byte, error) {
+	t.key = key
+	return t.data, t.err
+}
+
+func (t *testRPC) CalculateNetworkFee(tx *transaction.Transaction) (int64, error) {
+	return t.netFee, t.err
+}
+
+func TestGetRequest(t *testing.T) {
+	rpc := new(testRPC)
+
+	rpc.err = errors.New("")
+	_, err := GetRequest(rpc, 1)
+	require.Error(t, err)
+
+	rpc.err = nil
+	rpc.data = []byte{1, 2, 3}
+	_, err = GetRequest(rpc, 1)
+	require.Error(t, err)
+
+	expected := &state.OracleRequest{
+		OriginalTxID:     util.Uint256{1, 2, 3},
+		GasForResponse:   100500,
+		URL:              "https://example.com",
+		CallbackContract: util.Uint160{4, 5, 6},
+		CallbackMethod:   "handle",
+		UserData:         []byte{},
+	}
+	rpc.data, err = stackitem.SerializeConvertible(expected)
+	require.NoError(t, err)
+	req, err := GetRequest(rpc, 0x0102)
+	require.NoError(t, err)
+	require.Equal(t, expected, req)
+	require.Equal(t, []byte{7, 0, 0, 0, 0, 0, 0, 1, 2}, rpc.key)
+}
+
+func TestResponseTx(t *testing.T) {
+	rpc := new(testRPC)
+	k, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	nodes := keys.PublicKeys{k.PublicKey()}
+	req := &state.OracleRequest{GasForResponse: 1000}
+	resp := &transaction.OracleResponse{ID: 5, Code: transaction.Success, Result: []byte{1, 2, 3}}
+
+	_, err = NewResponseTx(nodes, &transaction.OracleResponse{Code: transaction.NotFound, Result: []byte{1}}, 100)
+	require.Error(t, err)
+	_, err = NewResponseTx(nodes, &transaction.OracleResponse{Result: make([]byte, transaction.MaxOracleResultSize+1)}, 100)
+	require.Error(t, err)
+	_, err = NewResponseTx(nil, resp, 100)
+	require.Error(t, err)
+
+	rpc.err = errors.New("")
+	_, err = CreateResponseTx(rpc, req, nodes, resp, 100)
+	require.Error(t, err)
+
+	rpc.err = nil
+	rpc.netFee = 1001
+	_, err = CreateResponseTx(rpc, req, nodes, resp, 100)
+	require.Error(t, err)
+
+	rpc.netFee = 300
+	tx, err := CreateResponseTx(rpc, req, nodes, resp, 100)
+	require.NoError(t, err)
+	require.Equal(t, int64(300), tx.NetworkFee)
+	require.Equal(t, int64(700), tx.SystemFee)
+	require.Equal(t, uint32(100), tx.ValidUntilBlock)
+	require.Equal(t, uint32(5), tx.Nonce)
+	require.Equal(t, ResponseScript, tx.Script)
+	require.Equal(t, Hash, tx.Sender())
+	require.Equal(t, 2, len(tx.Scripts))
+	require.NoError(t, CheckResponseTx(tx, req, nodes))
+
+	k2, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	require.Error(t, CheckResponseTx(tx, req, keys.PublicKeys{k2.PublicKey()}))
+	require.Error(t, CheckResponseTx(tx, &state.OracleRequest{GasForResponse: 1001}, nodes))
+
+	tx.Signers[1].Scopes = transaction.CalledByEntry
+	require.Error(t, CheckResponseTx(tx, req, nodes))
+	tx.Signers[1].Scopes = transaction.None
+
+	tx.Script = []byte{1}
+	require.Error(t, CheckResponseTx(tx, req, nodes))
+	tx.Script = ResponseScript
+
+	tx.Attributes = nil
+	require.Error(t, CheckResponseTx(tx, req, nodes))
+	require.Error(t, VerifyResponseTx(rpc, tx, nodes))
+}
@@ -41,6 +41,8 @@ const (
 	feePerByteSetter   = "setFeePerByte"
 	storagePriceSetter = "setStoragePrice"
 	attributeFeeSetter = "setAttributeFee"
+	msPerBlockSetter   = "setMillisecondsPerBlock"
+	mtbSetter          = "setMaxTraceableBlocks"
 )
 
 // ContractReader provides an interface to call read-only PolicyContract
@@ -95,6 +97,19 @@ func (c *ContractReader) GetAttributeFee(t transaction.AttrType) (int64, error)
 	return unwrap.Int64(c.invoker.Call(Hash, "getAttributeFee", byte(t)))
 }
 
+// GetMillisecondsPerBlock returns current block time in milliseconds. It's
+// only available since NeoGoExtensions hard-fork.
+func (c *ContractReader) GetMillisecondsPerBlock() (int64, error) {
+	return unwrap.Int64(c.invoker.Call(Hash, "getMillisecondsPerBlock"))
+}
+
+// GetMaxTraceableBlocks returns current number of blocks available to
+// contracts (and used for transaction duplication checks). It's only available
+// since NeoGoExtensions hard-fork.
+func (c *ContractReader) GetMaxTraceableBlocks() (int64, error) {
+	return unwrap.Int64(c.invoker.Call(Hash, "getMaxTraceableBlocks"))
+}
+
 // IsBlocked checks if the given account is blocked in the PolicyContract.
 func (c *ContractReader) IsBlocked(account util.Uint160) (bool, error) {
 	return unwrap.Bool(c.invoker.Call(Hash, "isBlocked", account))
@@ -165,6 +180,50 @@ func (c *Contract) SetStoragePriceUnsigned(value int64) (*transaction.Transactio
 	return c.actor.MakeUnsignedCall(Hash, storagePriceSetter, nil, value)
 }
 
+// SetMillisecondsPerBlock creates and sends a transaction that sets the new
+// block time, it's effective since the block following the one with this
+// transaction. The action is successful when transaction ends in HALT state.
+// The returned values are transaction hash, its ValidUntilBlock value and an
+// error if any.
+func (c *Contract) SetMillisecondsPerBlock(value int64) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, msPerBlockSetter, value)
+}
+
+// SetMillisecondsPerBlockTransaction creates a transaction that sets the new
+// block time. This transaction is signed, but not sent to the network, instead
+// it's returned to the caller.
+func (c *Contract) SetMillisecondsPerBlockTransaction(value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, msPerBlockSetter, value)
+}
+
+// SetMillisecondsPerBlockUnsigned creates a transaction that sets the new
+// block time. This transaction is not signed and just returned to the caller.
+func (c *Contract) SetMillisecondsPerBlockUnsigned(value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, msPerBlockSetter, nil, value)
+}
+
+// SetMaxTraceableBlocks creates and sends a transaction that sets the new
+// MaxTraceableBlocks value (it can only be decreased). The action is
+// successful when transaction ends in HALT state. The returned values are
+// transaction hash, its ValidUntilBlock value and an error if any.
+func (c *Contract) SetMaxTraceableBlocks(value int64) (util.Uint256, uint32, error) {
+	return c.actor.SendCall(Hash, mtbSetter, value)
+}
+
+// SetMaxTraceableBlocksTransaction creates a transaction that sets the new
+// MaxTraceableBlocks value. This transaction is signed, but not sent to the
+// network, instead it's returned to the caller.
+func (c *Contract) SetMaxTraceableBlocksTransaction(value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeCall(Hash, mtbSetter, value)
+}
+
+// SetMaxTraceableBlocksUnsigned creates a transaction that sets the new
+// MaxTraceableBlocks value. This transaction is not signed and just returned
+// to the caller.
+func (c *Contract) SetMaxTraceableBlocksUnsigned(value int64) (*transaction.Transaction, error) {
+	return c.actor.MakeUnsignedCall(Hash, mtbSetter, nil, value)
+}
+
 // SetAttributeFee creates and sends a transaction that sets the new attribute
 // fee value for the specified attribute. The action is successful when
 // transaction ends in HALT state. The returned values are transaction hash, its
@@ -49,6 +49,8 @@ func TestReader(t *testing.T) {
 		pc.GetExecFeeFactor,
 		pc.GetFeePerByte,
 		pc.GetStoragePrice,
+		pc.GetMillisecondsPerBlock,
+		pc.GetMaxTraceableBlocks,
 	}
 
 	ta.err = errors.New("")
@@ -95,6 +97,8 @@ func TestIntSetters(t *testing.T) {
 		pc.SetExecFeeFactor,
 		pc.SetFeePerByte,
 		pc.SetStoragePrice,
+		pc.SetMillisecondsPerBlock,
+		pc.SetMaxTraceableBlocks,
 	}
 
 	ta.err = errors.New("")
@@ -157,6 +161,10 @@ func TestIntTransactions(t *testing.T) {
 		pc.SetFeePerByteUnsigned,
 		pc.SetStoragePriceTransaction,
 		pc.SetStoragePriceUnsigned,
+		pc.SetMillisecondsPerBlockTransaction,
+		pc.SetMillisecondsPerBlockUnsigned,
+		pc.SetMaxTraceableBlocksTransaction,
+		pc.SetMaxTraceableBlocksUnsigned,
 	} {
 		ta.err = errors.New("")
 		_, err := fun(1)
@@ -40,6 +40,38 @@ func (c *Client) CalculateNetworkFee(tx *transaction.Transaction) (int64, error)
 	return resp.Value, nil
 }
 
+// EstimateFees returns system and network fees of a transaction with the given
+// script, signers and attributes along with the test invocation result and the
+// network fee breakdown in a single request (see estimatefees RPC call
+// documentation, it's a NeoGo extension, check for
+// result.RPCCapabilityFeeEstimation server capability before using it).
+// Witnesses must be provided for every signer, they follow the same rules as
+// for CalculateNetworkFee (verification scripts for standard accounts and
+// empty witnesses for contract-based ones). Attributes may be nil.
+func (c *Client) EstimateFees(script []byte, signers []transaction.Signer, witnesses []transaction.Witness, attrs []transaction.Attribute) (*result.FeeEstimation, error) {
+	if len(witnesses) != len(signers) {
+		return nil, fmt.Errorf("number of witnesses should match number of signers, got %d vs %d", len(witnesses), len(signers))
+	}
+	var (
+		sw   = make([]neorpc.SignerWithWitness, len(signers))
+		resp = new(result.FeeEstimation)
+	)
+	for i := range signers {
+		sw[i] = neorpc.SignerWithWitness{
+			Signer:  signers[i],
+			Witness: witnesses[i],
+		}
+	}
+	var params = []any{script, sw}
+	if attrs != nil {
+		params = append(params, attrs)
+	}
+	if err := c.performRequest("estimatefees", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetApplicationLog returns a contract log based on the specified txid.
 func (c *Client) GetApplicationLog(hash util.Uint256, trig *trigger.Type) (*result.ApplicationLog, error) {
 	var (
@@ -200,6 +232,20 @@ func (c *Client) GetBlockHeaderVerbose(hash util.Uint256) (*result.Header, error
 	return resp, nil
 }
 
+// GetBlockProfile returns node-local execution profile of the block with the
+// specified index. It's a NeoGo-specific extension that requires the node to
+// have block profiling enabled.
+func (c *Client) GetBlockProfile(index uint32) (*result.BlockProfile, error) {
+	var (
+		params = []any{index}
+		resp   = new(result.BlockProfile)
+	)
+	if err := c.performRequest("getblockprofile", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetBlockSysFee returns the system fees of the block based on the specified index.
 // This method is only supported by NeoGo servers.
 func (c *Client) GetBlockSysFee(index uint32) (fixedn.Fixed8, error) {
@@ -264,6 +310,81 @@ func (c *Client) getContractState(param any) (*state.Contract, error) {
 	return resp, nil
 }
 
+// GetContractStorageUsageByHash queries storage usage statistics of the
+// contract with the given script hash. It's a NeoGo-specific extension that
+// requires the node to have storage usage tracking enabled.
+func (c *Client) GetContractStorageUsageByHash(hash util.Uint160) (*result.ContractStorageUsage, error) {
+	return c.getContractStorageUsage(hash.StringLE())
+}
+
+// GetContractStorageUsageByID queries storage usage statistics of the
+// contract with the given ID. It's a NeoGo-specific extension that requires
+// the node to have storage usage tracking enabled.
+func (c *Client) GetContractStorageUsageByID(id int32) (*result.ContractStorageUsage, error) {
+	return c.getContractStorageUsage(id)
+}
+
+// getContractStorageUsage is an internal representation of
+// GetContractStorageUsageBy* methods.
+func (c *Client) getContractStorageUsage(param any) (*result.ContractStorageUsage, error) {
+	var (
+		params = []any{param}
+		resp   = new(result.ContractStorageUsage)
+	)
+	if err := c.performRequest("getcontractstorageusage", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetNativeCallStats returns node-local invocation statistics of native
+// contract methods. It's a NeoGo-specific extension that requires the node to
+// have native call statistics gathering enabled.
+func (c *Client) GetNativeCallStats() ([]result.NativeCallStats, error) {
+	var resp []result.NativeCallStats
+	if err := c.performRequest("getnativestats", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ResetNativeCallStats resets node-local invocation statistics of native
+// contract methods. It's a NeoGo-specific extension that requires the node to
+// have native call statistics gathering and RPC admin methods enabled.
+func (c *Client) ResetNativeCallStats() error {
+	var resp bool
+	return c.performRequest("resetnativestats", nil, &resp)
+}
+
+// ListContractStorageUsage returns storage usage statistics of (at most)
+// limit contracts using the most storage space sorted by size in descending
+// order. It's a NeoGo-specific extension that requires the node to have
+// storage usage tracking enabled.
+func (c *Client) ListContractStorageUsage(limit int) ([]result.ContractStorageUsage, error) {
+	var resp []result.ContractStorageUsage
+	if err := c.performRequest("listcontractstorageusage", []any{limit}, &resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// GetContracts returns up to count (or the server limit if it's not positive)
+// contract states (including native ones) matching the filter (nil for
+// all contracts) ordered by their hashes. If start is not nil, contracts
+// following it are returned, it's usually the Next field of the previous
+// result. It's a NeoGo-specific extension.
+func (c *Client) GetContracts(filter *neorpc.ContractFilter, start *util.Uint160, count int) (*result.Contracts, error) {
+	var params = []any{filter, start}
+	if count > 0 {
+		params = append(params, count)
+	}
+	resp := new(result.Contracts)
+	if err := c.performRequest("getcontracts", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // GetNativeContracts queries information about native contracts.
 func (c *Client) GetNativeContracts() ([]state.NativeContract, error) {
 	var resp []state.NativeContract
@@ -397,6 +518,18 @@ func (c *Client) GetPeers() (*result.GetPeers, error) {
 	return resp, nil
 }
 
+// GetPeersVerbose is the same as GetPeers, but also returns traffic
+// statistics, connection age, last message times and user agent for every
+// connected peer. It's a NeoGo-specific extension.
+func (c *Client) GetPeersVerbose() (*result.GetPeers, error) {
+	var resp = &result.GetPeers{}
+
+	if err := c.performRequest("getpeers", []any{true}, resp); err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
 // GetRawMemPool returns a list of unconfirmed transactions in the memory.
 func (c *Client) GetRawMemPool() ([]util.Uint256, error) {
 	var resp = new([]util.Uint256)
@@ -477,6 +610,24 @@ func (c *Client) GetState(stateroot util.Uint256, historicalContractHash util.Ui
 	return resp, nil
 }
 
+// GetVerifiableState returns contract storage item value with its MPT proof and
+// the state root signed by state validators in a single call. The latest
+// validated state root is used if height is nil. The result can be checked with
+// stateroot.VerifyBundle. It's a NeoGo-specific extension.
+func (c *Client) GetVerifiableState(contract util.Uint160, key []byte, height *uint32) (*result.VerifiableState, error) {
+	var (
+		params = []any{contract.StringLE(), key}
+		resp   = new(result.VerifiableState)
+	)
+	if height != nil {
+		params = append(params, *height)
+	}
+	if err := c.performRequest("getverifiablestate", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // FindStates returns historical contract storage item states by the given stateroot,
 // historical contract hash and historical prefix. If `start` path is specified, items
 // starting from `start` path are being returned (excluding item located at the start path).
@@ -599,6 +750,22 @@ func (c *Client) FindStorageByID(contractID int32, prefix []byte, start *int) (r
 	return c.findStorage(params)
 }
 
+// FindStorageByHashContinue returns contract storage items by the given contract
+// hash and prefix following the last item of the previous page the given
+// continuation token (see [result.FindStorage.Continuation]) was returned for.
+// It's a NeoGo-specific extension (C# node doesn't support continuation tokens).
+func (c *Client) FindStorageByHashContinue(contractHash util.Uint160, prefix []byte, token string) (result.FindStorage, error) {
+	return c.findStorage([]any{contractHash.StringLE(), prefix, token})
+}
+
+// FindStorageByIDContinue returns contract storage items by the given contract
+// ID and prefix following the last item of the previous page the given
+// continuation token (see [result.FindStorage.Continuation]) was returned for.
+// It's a NeoGo-specific extension (C# node doesn't support continuation tokens).
+func (c *Client) FindStorageByIDContinue(contractID int32, prefix []byte, token string) (result.FindStorage, error) {
+	return c.findStorage([]any{contractID, prefix, token})
+}
+
 func (c *Client) findStorage(params []any) (result.FindStorage, error) {
 	var resp result.FindStorage
 	if err := c.performRequest("findstorage", params, &resp); err != nil {
@@ -637,6 +804,49 @@ func (c *Client) FindStorageByIDHistoric(stateroot util.Uint256, historicalContr
 	return c.findStorageHistoric(params)
 }
 
+// FindStorageByHashHistoricContinue returns historical contract storage items by
+// the given stateroot, historical contract hash and historical prefix following
+// the last item of the previous page the given continuation token (see
+// [result.FindStorage.Continuation]) was returned for. It's a NeoGo-specific
+// extension (C# node doesn't support continuation tokens).
+func (c *Client) FindStorageByHashHistoricContinue(stateroot util.Uint256, historicalContractHash util.Uint160, historicalPrefix []byte,
+	token string) (result.FindStorage, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	return c.findStorageHistoric([]any{stateroot.StringLE(), historicalContractHash.StringLE(), historicalPrefix, token})
+}
+
+// FindStorageByIDHistoricContinue returns historical contract storage items by
+// the given stateroot, historical contract ID and historical prefix following
+// the last item of the previous page the given continuation token (see
+// [result.FindStorage.Continuation]) was returned for. It's a NeoGo-specific
+// extension (C# node doesn't support continuation tokens).
+func (c *Client) FindStorageByIDHistoricContinue(stateroot util.Uint256, historicalContractID int32, historicalPrefix []byte,
+	token string) (result.FindStorage, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	return c.findStorageHistoric([]any{stateroot.StringLE(), historicalContractID, historicalPrefix, token})
+}
+
+// FindStorageByHashAtHeight returns historical contract storage items by the
+// given block index, historical contract hash and historical prefix. It's the
+// same as FindStorageByHashHistoric, but uses the stateroot of the specified
+// block index. It's a NeoGo-specific extension (C# node doesn't support
+// block index in findstoragehistoric).
+func (c *Client) FindStorageByHashAtHeight(index uint32, historicalContractHash util.Uint160, historicalPrefix []byte,
+	start *int) (result.FindStorage, error) {
+	if historicalPrefix == nil {
+		historicalPrefix = []byte{}
+	}
+	var params = []any{index, historicalContractHash.StringLE(), historicalPrefix}
+	if start != nil {
+		params = append(params, start)
+	}
+	return c.findStorageHistoric(params)
+}
+
 func (c *Client) findStorageHistoric(params []any) (result.FindStorage, error) {
 	var resp result.FindStorage
 	if err := c.performRequest("findstoragehistoric", params, &resp); err != nil {
@@ -657,6 +867,37 @@ func (c *Client) GetTransactionHeight(hash util.Uint256) (uint32, error) {
 	return resp, nil
 }
 
+// GetTransactionProof returns Merkle proof of the transaction inclusion into
+// the block. It's a NeoGo-specific extension.
+func (c *Client) GetTransactionProof(hash util.Uint256) (*result.TransactionProof, error) {
+	var (
+		params = []any{hash.StringLE()}
+		resp   = new(result.TransactionProof)
+	)
+	if err := c.performRequest("gettxproof", params, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// VerifyTransactionProof checks Merkle proof of the transaction inclusion into
+// the block with the given hash against the block header known to the node.
+// It's a NeoGo-specific extension.
+func (c *Client) VerifyTransactionProof(blockHash util.Uint256, txHash util.Uint256, index uint32, path []util.Uint256) (bool, error) {
+	var (
+		pathStr = make([]string, len(path))
+		resp    bool
+	)
+	for i := range path {
+		pathStr[i] = path[i].StringLE()
+	}
+	params := []any{blockHash.StringLE(), txHash.StringLE(), index, pathStr}
+	if err := c.performRequest("verifytxproof", params, &resp); err != nil {
+		return false, err
+	}
+	return resp, nil
+}
+
 // GetUnclaimedGas returns the unclaimed GAS amount for the specified address.
 func (c *Client) GetUnclaimedGas(address string) (result.UnclaimedGas, error) {
 	var (
@@ -725,6 +966,22 @@ func (c *Client) InvokeScriptWithState(stateOrBlock util.Uint256, script []byte,
 	return c.invokeSomething("invokescripthistoric", p, signers)
 }
 
+// InvokeScriptWithDiagnostics is similar to InvokeScript, but it also requests
+// diagnostic data (invocation tree, storage changes and VM limits usage) for
+// the script execution. This data is returned in the Diagnostics field of the
+// result.
+// NOTE: This is a test invoke and will not affect the blockchain.
+func (c *Client) InvokeScriptWithDiagnostics(script []byte, signers []transaction.Signer) (*result.Invoke, error) {
+	var resp = new(result.Invoke)
+	if signers == nil {
+		signers = []transaction.Signer{}
+	}
+	if err := c.performRequest("invokescript", []any{script, signers, true}, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
 // InvokeFunction returns the results after calling the smart contract scripthash
 // with the given operation and parameters.
 // NOTE: this is test invoke and will not affect the blockchain.
@@ -970,3 +1227,15 @@ func (c *Client) GetRawNotaryPool() (*result.RawNotaryPool, error) {
 	}
 	return resp, nil
 }
+
+// GetNotaryPoolInfo returns main transactions pending completion by the RPC
+// node's P2PNotary service along with the number of signatures collected for
+// them. It's a NeoGo-specific extension that requires the node to have
+// P2PNotary service and RPC admin methods enabled.
+func (c *Client) GetNotaryPoolInfo() (*result.NotaryPoolInfo, error) {
+	resp := new(result.NotaryPoolInfo)
+	if err := c.performRequest("getnotarypoolinfo", nil, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage/dboper"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -33,6 +34,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
@@ -287,6 +289,47 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			},
 		},
 	},
+	"getblockprofile": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.GetBlockProfile(1)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"index":1,"hash":"0xd151651e86680a7ecbc87babf3346a42e7bc9974414ce192c9c22ac4f2e9d043","time":1500000,"gas":997775,"transactions":[{"hash":"0x8b3ff5e2b18339a12e9318f1fa3a0a2c4f0a7e4d1c72b1e7b7a48ed3c24c5a62","time":1000000,"gas":997775,"vmstate":"HALT","syscalls":{"System.Contract.Call":1,"System.Contract.CallNative":1}}],"contracts":[{"hash":"0xd2a4cff31913016155e38e474a2c06d08be276cf","calls":1,"gas":997500}]}}`,
+			result: func(c *Client) any {
+				blockHash, err := util.Uint256DecodeStringLE("d151651e86680a7ecbc87babf3346a42e7bc9974414ce192c9c22ac4f2e9d043")
+				if err != nil {
+					panic(err)
+				}
+				txHash, err := util.Uint256DecodeStringLE("8b3ff5e2b18339a12e9318f1fa3a0a2c4f0a7e4d1c72b1e7b7a48ed3c24c5a62")
+				if err != nil {
+					panic(err)
+				}
+				gasHash, err := util.Uint160DecodeStringLE("d2a4cff31913016155e38e474a2c06d08be276cf")
+				if err != nil {
+					panic(err)
+				}
+				return &result.BlockProfile{
+					Index: 1,
+					Hash:  blockHash,
+					Time:  1500000,
+					GAS:   997775,
+					Transactions: []result.TxProfile{{
+						Hash:     txHash,
+						Time:     1000000,
+						GAS:      997775,
+						VMState:  vmstate.Halt,
+						Syscalls: map[string]int{"System.Contract.Call": 1, "System.Contract.CallNative": 1},
+					}},
+					Contracts: []result.ContractProfile{{
+						Hash:  gasHash,
+						Calls: 1,
+						GAS:   997500,
+					}},
+				}
+			},
+		},
+	},
 	"getblocksysfee": {
 		{
 			name: "positive",
@@ -593,6 +636,34 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "verbose",
+			invoke: func(c *Client) (any, error) {
+				return c.GetPeersVerbose()
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"unconnected":[],"connected":[{"address":"127.0.0.1","port":20335,"stats":{"useragent":"/NEO-GO:0.106.0/","age":1000,"lastsent":1700000000000,"lastreceived":1700000000500,"commands":{"block":{"sentbytes":0,"sentmessages":0,"receivedbytes":1024,"receivedmessages":2}}}}],"bad":[]}}`,
+			result: func(c *Client) any {
+				return &result.GetPeers{
+					Unconnected: result.Peers{},
+					Connected: result.Peers{
+						{
+							Address: "127.0.0.1",
+							Port:    20335,
+							Stats: &result.PeerStats{
+								UserAgent:    "/NEO-GO:0.106.0/",
+								Age:          1000,
+								LastSent:     1700000000000,
+								LastReceived: 1700000000500,
+								Commands: map[string]result.PeerCommandStats{
+									"block": {ReceivedBytes: 1024, ReceivedMessages: 2},
+								},
+							},
+						},
+					},
+					Bad: result.Peers{},
+				}
+			},
+		},
 	},
 	"getrawmempool": {
 		{
@@ -816,6 +887,37 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive by hash with token",
+			invoke: func(c *Client) (any, error) {
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStorageByHashContinue(cHash, []byte("aa"), "AWFhMQ==")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true, "next": 1, "continuation": "AWFhMTA="}}`,
+			result: func(c *Client) any {
+				return result.FindStorage{
+					Results:      []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated:    true,
+					Next:         1,
+					Continuation: "AWFhMTA=",
+				}
+			},
+		},
+		{
+			name: "positive by ID with token",
+			invoke: func(c *Client) (any, error) {
+				return c.FindStorageByIDContinue(1, []byte("aa"), "AWFhMQ==")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true, "next": 1, "continuation": "AWFhMTA="}}`,
+			result: func(c *Client) any {
+				return result.FindStorage{
+					Results:      []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated:    true,
+					Next:         1,
+					Continuation: "AWFhMTA=",
+				}
+			},
+		},
 	},
 	"findstoragehistoric": {
 		{
@@ -851,6 +953,56 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive by hash with token",
+			invoke: func(c *Client) (any, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				return c.FindStorageByHashHistoricContinue(root, cHash, []byte("aa"), "AWFhMQ==")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true, "next": 1, "continuation": "AWFhMTA="}}`,
+			result: func(c *Client) any {
+				return result.FindStorage{
+					Results:      []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated:    true,
+					Next:         1,
+					Continuation: "AWFhMTA=",
+				}
+			},
+		},
+		{
+			name: "positive by ID with token",
+			invoke: func(c *Client) (any, error) {
+				root, _ := util.Uint256DecodeStringLE("252e9d73d49c95c7618d40650da504e05183a1b2eed0685e42c360413c329170")
+				return c.FindStorageByIDHistoricContinue(root, 1, []byte("aa"), "AWFhMQ==")
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true, "next": 1, "continuation": "AWFhMTA="}}`,
+			result: func(c *Client) any {
+				return result.FindStorage{
+					Results:      []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated:    true,
+					Next:         1,
+					Continuation: "AWFhMTA=",
+				}
+			},
+		},
+		{
+			name: "positive by block index",
+			invoke: func(c *Client) (any, error) {
+				cHash, _ := util.Uint160DecodeStringLE("5c9e40a12055c6b9e3f72271c9779958c842135d")
+				start := 1
+				return c.FindStorageByHashAtHeight(20, cHash, []byte("aa"), &start)
+			},
+			serverResponse: `{"id":1,"jsonrpc":"2.0","result":{"results":[{"key":"YWExMA==","value":"djI="}],"truncated":true, "next": 1, "continuation": "AWFhMTA="}}`,
+			result: func(c *Client) any {
+				return result.FindStorage{
+					Results:      []result.KeyValue{{Key: []byte("aa10"), Value: []byte("v2")}},
+					Truncated:    true,
+					Next:         1,
+					Continuation: "AWFhMTA=",
+				}
+			},
+		},
 	},
 	"getstateheight": {
 		{
@@ -866,6 +1018,25 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, state sync",
+			invoke: func(c *Client) (any, error) {
+				return c.GetStateHeight()
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"localrootindex":0,"validatedrootindex":0,"statesync":{"point":4000,"headers":100,"blockheight":3990,"blocks":99,"mptnodes":1234,"mpt":42.5}}}`,
+			result: func(c *Client) any {
+				return &result.StateHeight{
+					StateSync: &result.StateSync{
+						Point:       4000,
+						Headers:     100,
+						BlockHeight: 3990,
+						Blocks:      99,
+						MPTNodes:    1234,
+						MPT:         42.5,
+					},
+				}
+			},
+		},
 	},
 	"getstorage": {
 		{
@@ -1159,6 +1330,32 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 				}
 			},
 		},
+		{
+			name: "positive, with diagnostics",
+			invoke: func(c *Client) (any, error) {
+				return c.InvokeScriptWithDiagnostics([]byte{1, 2, 3}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"script":"AQID","state":"HALT","gasconsumed":"100","stack":[],"diagnostics":{"invokedcontracts":[{"hash":"0x0000000000000000000000000000000000000000","call":[{"hash":"0x0000000000000000000000000000000000030201","call":[{"hash":"0x0000000000000000000000000000000000060504"}]}]}],"storagechanges":[]}}}`,
+			result: func(c *Client) any {
+				return &result.Invoke{
+					State:       "HALT",
+					GasConsumed: 100,
+					Script:      []byte{1, 2, 3},
+					Stack:       []stackitem.Item{},
+					Diagnostics: &result.InvokeDiag{
+						Changes: []dboper.Operation{},
+						Invocations: []*invocations.Tree{{
+							Calls: []*invocations.Tree{{
+								Current: util.Uint160{1, 2, 3},
+								Calls: []*invocations.Tree{{
+									Current: util.Uint160{4, 5, 6},
+								}},
+							}},
+						}},
+					},
+				}
+			},
+		},
 	},
 	"invokecontractverify": {
 		{
@@ -1200,6 +1397,45 @@ var rpcClientTestCases = map[string][]rpcClientTestCase{
 			fails: true,
 		},
 	},
+	"estimatefees": {
+		{
+			name: "positive",
+			invoke: func(c *Client) (any, error) {
+				return c.EstimateFees([]byte{1, 2, 3}, []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}, []transaction.Witness{{VerificationScript: []byte{4, 5, 6}}}, nil)
+			},
+			serverResponse: `{"jsonrpc":"2.0","id":1,"result":{"invocation":{"script":"AQID","state":"HALT","gasconsumed":"100","stack":[]},"systemfee":"100","networkfee":"200","totalfee":"300","size":150,"basesize":40,"sizefee":"150","attributesfee":"0","witnesses":[{"account":"0x0000000000000000000000000000000000030201","type":"script","invocationsize":1,"verificationsize":4,"verificationfee":"50"}]}}`,
+			result: func(c *Client) any {
+				return &result.FeeEstimation{
+					Invocation: &result.Invoke{
+						State:       "HALT",
+						GasConsumed: 100,
+						Script:      []byte{1, 2, 3},
+						Stack:       []stackitem.Item{},
+					},
+					SystemFee:  100,
+					NetworkFee: 200,
+					TotalFee:   300,
+					Size:       150,
+					BaseSize:   40,
+					SizeFee:    150,
+					Witnesses: []result.WitnessFee{{
+						Account:          util.Uint160{1, 2, 3},
+						Type:             result.WitnessScript,
+						InvocationSize:   1,
+						VerificationSize: 4,
+						VerificationFee:  50,
+					}},
+				}
+			},
+		},
+		{
+			name: "bad witness number",
+			invoke: func(c *Client) (any, error) {
+				return c.EstimateFees([]byte{1}, []transaction.Signer{{}}, nil, nil)
+			},
+			fails: true,
+		},
+	},
 	"sendrawtransaction": {
 		{
 			name: "positive",
@@ -2040,6 +2276,26 @@ func TestUninitedClient(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestInitNativesFromVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := params.NewRequest()
+		err := r.DecodeData(req.Body)
+		require.NoErrorf(t, err, "Cannot decode request body: %s", req.Body)
+		require.Equal(t, "getversion", r.In.Method)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		_, err = w.Write([]byte(`{"id":1,"jsonrpc":"2.0","result":{"protocol":{"network":42,"nativecontracts":[{"id":-6,"name":"GasToken","hash":"0xd2a4cff31913016155e38e474a2c06d08be276cf","activationheight":0}]},"tcpport":20332,"nonce":2153672787,"useragent":"/NEO-GO:0.106.0/","rpc":{"maxiteratorresultitems":100,"sessionenabled":true,"capabilities":["sessions","subscriptions"]}}}`))
+		require.NoError(t, err)
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := New(context.TODO(), srv.URL, Options{})
+	require.NoError(t, err)
+	c.getNextRequestID = getTestRequestID
+	require.NoError(t, c.Init())
+
+	require.Equal(t, "d2a4cff31913016155e38e474a2c06d08be276cf", c.cache.nativeHashes["GasToken"].StringLE())
+}
+
 func newTestNEF(script []byte) nef.File {
 	var ne nef.File
 	ne.Header.Magic = nef.Magic
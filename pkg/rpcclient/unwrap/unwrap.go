@@ -20,6 +20,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
@@ -394,6 +395,21 @@ func Map(r *result.Invoke, err error) (*stackitem.Map, error) {
 	return itm.(*stackitem.Map), nil
 }
 
+// ExtendedType expects correct execution (HALT state) with a single stack item
+// returned. This item is checked to match the given extended type (named
+// structures are resolved using the named map, see binding.ManifestTypes)
+// and returned.
+func ExtendedType(r *result.Invoke, err error, et *binding.ExtendedType, named map[string]binding.ExtendedType) (stackitem.Item, error) {
+	itm, err := Item(r, err)
+	if err != nil {
+		return nil, err
+	}
+	if err := et.CheckItem(itm, named); err != nil {
+		return nil, err
+	}
+	return itm, nil
+}
+
 func checkResOK(r *result.Invoke, err error) error {
 	if err != nil {
 		return err
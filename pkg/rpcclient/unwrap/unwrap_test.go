@@ -11,6 +11,8 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/binding"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/stretchr/testify/require"
@@ -83,6 +85,9 @@ func TestStdErrors(t *testing.T) {
 		func(r *result.Invoke, err error) (any, error) {
 			return Map(r, err)
 		},
+		func(r *result.Invoke, err error) (any, error) {
+			return ExtendedType(r, err, &binding.ExtendedType{Base: smartcontract.IntegerType}, nil)
+		},
 	}
 	t.Run("error on input", func(t *testing.T) {
 		for _, f := range funcs {
@@ -449,3 +454,31 @@ func TestMap(t *testing.T) {
 	require.Equal(t, 1, m.Len())
 	require.Equal(t, 0, m.Index(stackitem.Make(42)))
 }
+
+func TestExtendedType(t *testing.T) {
+	named := map[string]binding.ExtendedType{
+		"main.Point": {
+			Base: smartcontract.ArrayType,
+			Name: "main.Point",
+			Fields: []binding.FieldExtendedType{
+				{Field: "X", ExtendedType: binding.ExtendedType{Base: smartcontract.IntegerType}},
+				{Field: "Name", ExtendedType: binding.ExtendedType{Base: smartcontract.StringType}},
+			},
+		},
+	}
+	et := &binding.ExtendedType{Base: smartcontract.ArrayType, Name: "main.Point"}
+
+	_, err := ExtendedType(&result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.Make(42)}}, nil, et, named)
+	require.Error(t, err)
+
+	_, err = ExtendedType(&result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make(1)})}}, nil, et, named)
+	require.Error(t, err)
+
+	_, err = ExtendedType(&result.Invoke{State: "HALT", Stack: []stackitem.Item{stackitem.NewStruct([]stackitem.Item{stackitem.Make("1"), stackitem.Make("name")})}}, nil, et, named)
+	require.Error(t, err)
+
+	itm := stackitem.NewStruct([]stackitem.Item{stackitem.Make(1), stackitem.Make("name")})
+	res, err := ExtendedType(&result.Invoke{State: "HALT", Stack: []stackitem.Item{itm}}, nil, et, named)
+	require.NoError(t, err)
+	require.Equal(t, itm, res)
+}
@@ -105,9 +105,11 @@ func errIsAlreadyExists(err error) bool {
 // polling-base, otherwise Waiter stub is returned. As a first argument
 // it accepts RPCEventBased implementation, RPCPollingBased implementation
 // or not an implementation of these two interfaces. It returns websocket-based
-// waiter, polling-based waiter or a stub correspondingly.
+// waiter, polling-based waiter or a stub correspondingly. Polling-based waiter
+// is also returned for RPCEventBased implementation if the server reports
+// its capabilities and event subscriptions are not among them.
 func New(base any, v *result.Version) Waiter {
-	if eventW, ok := base.(RPCEventBased); ok {
+	if eventW, ok := base.(RPCEventBased); ok && subscriptionsSupported(v) {
 		return &EventBased{
 			ws: eventW,
 			polling: &PollingBased{
@@ -125,6 +127,12 @@ func New(base any, v *result.Version) Waiter {
 	return NewNull()
 }
 
+// subscriptionsSupported returns false if the server is known to not support
+// event subscriptions.
+func subscriptionsSupported(v *result.Version) bool {
+	return v == nil || v.RPC.Capabilities == nil || v.RPC.HasCapability(result.RPCCapabilitySubscriptions)
+}
+
 // NewNull creates an instance of Waiter stub.
 func NewNull() Null {
 	return Null{}
@@ -122,6 +122,19 @@ func TestNewWaiter(t *testing.T) {
 	w = waiter.New(&AwaitableRPCClient{RPCClient: RPCClient{}}, &result.Version{})
 	_, ok = w.(*waiter.EventBased)
 	require.True(t, ok)
+
+	w = waiter.New(&AwaitableRPCClient{RPCClient: RPCClient{}}, &result.Version{RPC: result.RPC{
+		Capabilities: []string{result.RPCCapabilitySubscriptions},
+	}})
+	_, ok = w.(*waiter.EventBased)
+	require.True(t, ok)
+
+	// Server without subscriptions support.
+	w = waiter.New(&AwaitableRPCClient{RPCClient: RPCClient{}}, &result.Version{RPC: result.RPC{
+		Capabilities: []string{result.RPCCapabilitySessions},
+	}})
+	_, ok = w.(*waiter.PollingBased)
+	require.True(t, ok)
 }
 
 func TestPollingWaiter_Wait(t *testing.T) {
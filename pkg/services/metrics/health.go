@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"go.uber.org/zap"
+)
+
+type (
+	// HealthLedger is an interface to the blockchain used by the health
+	// service, all methods should be safe for concurrent use.
+	HealthLedger interface {
+		BlockHeight() uint32
+		GetMemPool() *mempool.Pool
+		HeaderHeight() uint32
+		IsPersistFailing() bool
+		IsRunning() bool
+	}
+
+	// HealthNetwork is an interface to the network server used by the health
+	// service, all methods should be safe for concurrent use.
+	HealthNetwork interface {
+		IsInSync() bool
+		PeerCount() int
+	}
+
+	// HealthRPC is an interface to the RPC server used by the health service.
+	HealthRPC interface {
+		IsStarted() bool
+	}
+
+	// HealthStatus is the node status returned by health service endpoints.
+	HealthStatus struct {
+		Ready        bool   `json:"ready"`
+		BlockHeight  uint32 `json:"blockheight"`
+		HeaderHeight uint32 `json:"headerheight"`
+		MaxBlockLag  uint32 `json:"maxblocklag"`
+		PeerCount    int    `json:"peercount"`
+		MempoolSize  int    `json:"mempoolsize"`
+		Synchronized bool   `json:"synchronized"`
+		RPCStarted   bool   `json:"rpcstarted"`
+		DBWritable   bool   `json:"dbwritable"`
+	}
+
+	healthChecker struct {
+		chain       HealthLedger
+		net         HealthNetwork
+		rpc         HealthRPC
+		maxBlockLag uint32
+	}
+)
+
+// NewHealthService creates a new service providing "/health" and "/ready"
+// HTTP endpoints. "/health" always responds with 200 status code if the node
+// is up, while "/ready" responds with 503 if the node is not ready to serve
+// requests: it's not synchronized with the network, lags behind the known
+// header height for more than MaxBlockLag blocks, its RPC server is not
+// started or its DB is not writable. Both return the node status in JSON. rpc
+// can be nil if RPC server is not used.
+func NewHealthService(cfg config.HealthCheck, chain HealthLedger, net HealthNetwork, rpc HealthRPC, log *zap.Logger) *Service {
+	if log == nil {
+		return nil
+	}
+
+	if cfg.MaxBlockLag == 0 {
+		cfg.MaxBlockLag = config.DefaultHealthCheckMaxBlockLag
+		if cfg.Enabled {
+			log.Info("MaxBlockLag is not set or wrong, setting default value", zap.Uint32("MaxBlockLag", cfg.MaxBlockLag))
+		}
+	}
+	h := &healthChecker{
+		chain:       chain,
+		net:         net,
+		rpc:         rpc,
+		maxBlockLag: cfg.MaxBlockLag,
+	}
+	handler := http.NewServeMux()
+	handler.HandleFunc("/health", h.handleHealth)
+	handler.HandleFunc("/ready", h.handleReady)
+
+	addrs := cfg.Addresses
+	srvs := make([]*http.Server, len(addrs))
+	for i, addr := range addrs {
+		srvs[i] = &http.Server{
+			Addr:    addr,
+			Handler: handler,
+		}
+	}
+	return NewService("HealthCheck", srvs, cfg.BasicService, log)
+}
+
+// status returns the current node status.
+func (h *healthChecker) status() HealthStatus {
+	var s = HealthStatus{
+		BlockHeight:  h.chain.BlockHeight(),
+		HeaderHeight: h.chain.HeaderHeight(),
+		MaxBlockLag:  h.maxBlockLag,
+		PeerCount:    h.net.PeerCount(),
+		MempoolSize:  h.chain.GetMemPool().Count(),
+		Synchronized: h.net.IsInSync(),
+		RPCStarted:   h.rpc == nil || h.rpc.IsStarted(),
+		DBWritable:   h.chain.IsRunning() && !h.chain.IsPersistFailing(),
+	}
+	// Header height can be lower than the block height for a moment.
+	inLag := s.HeaderHeight <= s.BlockHeight || s.HeaderHeight-s.BlockHeight <= s.MaxBlockLag
+	s.Ready = inLag && s.Synchronized && s.RPCStarted && s.DBWritable
+	return s
+}
+
+func (h *healthChecker) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeHealthStatus(w, http.StatusOK, h.status())
+}
+
+func (h *healthChecker) handleReady(w http.ResponseWriter, _ *http.Request) {
+	var (
+		s    = h.status()
+		code = http.StatusOK
+	)
+	if !s.Ready {
+		code = http.StatusServiceUnavailable
+	}
+	writeHealthStatus(w, code, s)
+}
+
+func writeHealthStatus(w http.ResponseWriter, code int, s HealthStatus) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(s)
+}
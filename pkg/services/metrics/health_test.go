@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/mempool"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type fakeHealthChain struct {
+	blockHeight   atomic.Uint32
+	headerHeight  atomic.Uint32
+	persistFailed atomic.Bool
+	stopped       atomic.Bool
+	pool          *mempool.Pool
+}
+
+func (c *fakeHealthChain) BlockHeight() uint32       { return c.blockHeight.Load() }
+func (c *fakeHealthChain) GetMemPool() *mempool.Pool { return c.pool }
+func (c *fakeHealthChain) HeaderHeight() uint32      { return c.headerHeight.Load() }
+func (c *fakeHealthChain) IsPersistFailing() bool    { return c.persistFailed.Load() }
+func (c *fakeHealthChain) IsRunning() bool           { return !c.stopped.Load() }
+
+type fakeHealthNetwork struct {
+	notInSync atomic.Bool
+}
+
+func (n *fakeHealthNetwork) IsInSync() bool { return !n.notInSync.Load() }
+func (n *fakeHealthNetwork) PeerCount() int { return 3 }
+
+type fakeHealthRPC struct {
+	stopped atomic.Bool
+}
+
+func (r *fakeHealthRPC) IsStarted() bool { return !r.stopped.Load() }
+
+func TestHealthService(t *testing.T) {
+	chain := &fakeHealthChain{pool: mempool.New(10, 0, false, nil)}
+	chain.blockHeight.Store(100)
+	chain.headerHeight.Store(100)
+	net := &fakeHealthNetwork{}
+	rpc := &fakeHealthRPC{}
+	cfg := config.HealthCheck{
+		BasicService: config.BasicService{
+			Enabled:   true,
+			Addresses: []string{"localhost:0"},
+		},
+		MaxBlockLag: 5,
+	}
+	s := NewHealthService(cfg, chain, net, rpc, zaptest.NewLogger(t))
+	require.NoError(t, s.Start())
+	t.Cleanup(s.ShutDown)
+	url := "http://" + s.http[0].Addr
+
+	get := func(t *testing.T, path string, expectedCode int) HealthStatus {
+		resp, err := http.Get(url + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, expectedCode, resp.StatusCode)
+		require.Equal(t, "application/json; charset=utf-8", resp.Header.Get("Content-Type"))
+		var st HealthStatus
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&st))
+		return st
+	}
+	checkReady := func(t *testing.T, ready bool) HealthStatus {
+		code := http.StatusOK
+		if !ready {
+			code = http.StatusServiceUnavailable
+		}
+		st := get(t, "/ready", code)
+		require.Equal(t, ready, st.Ready)
+		require.Equal(t, st, get(t, "/health", http.StatusOK))
+		return st
+	}
+
+	require.Equal(t, HealthStatus{
+		Ready:        true,
+		BlockHeight:  100,
+		HeaderHeight: 100,
+		MaxBlockLag:  5,
+		PeerCount:    3,
+		MempoolSize:  0,
+		Synchronized: true,
+		RPCStarted:   true,
+		DBWritable:   true,
+	}, checkReady(t, true))
+
+	t.Run("block lag", func(t *testing.T) {
+		chain.headerHeight.Store(105)
+		st := checkReady(t, true)
+		require.Equal(t, uint32(105), st.HeaderHeight)
+
+		chain.headerHeight.Store(106)
+		st = checkReady(t, false)
+		require.Equal(t, uint32(100), st.BlockHeight)
+		require.Equal(t, uint32(106), st.HeaderHeight)
+
+		chain.blockHeight.Store(101)
+		checkReady(t, true)
+		chain.blockHeight.Store(106)
+		checkReady(t, true)
+	})
+	t.Run("not synchronized", func(t *testing.T) {
+		net.notInSync.Store(true)
+		require.False(t, checkReady(t, false).Synchronized)
+		net.notInSync.Store(false)
+		checkReady(t, true)
+	})
+	t.Run("RPC stopped", func(t *testing.T) {
+		rpc.stopped.Store(true)
+		require.False(t, checkReady(t, false).RPCStarted)
+		rpc.stopped.Store(false)
+		checkReady(t, true)
+	})
+	t.Run("DB not writable", func(t *testing.T) {
+		chain.persistFailed.Store(true)
+		require.False(t, checkReady(t, false).DBWritable)
+		chain.persistFailed.Store(false)
+		chain.stopped.Store(true)
+		require.False(t, checkReady(t, false).DBWritable)
+		chain.stopped.Store(false)
+		checkReady(t, true)
+	})
+}
+
+func TestHealthService_NoRPC(t *testing.T) {
+	chain := &fakeHealthChain{pool: mempool.New(10, 0, false, nil)}
+	h := &healthChecker{chain: chain, net: &fakeHealthNetwork{}, maxBlockLag: config.DefaultHealthCheckMaxBlockLag}
+	st := h.status()
+	require.True(t, st.Ready)
+	require.True(t, st.RPCStarted)
+}
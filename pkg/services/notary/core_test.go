@@ -485,6 +485,57 @@ func TestNotary(t *testing.T) {
 	checkFallbackTxs(t, r, false)
 	r, _ = checkCompleteMixedRequest(t, 3, true)
 	checkFallbackTxs(t, r, false)
+
+	// PendingRequests: partially signed request
+	getPending := func(t *testing.T, h util.Uint256) notary.RequestInfo {
+		for _, info := range ntr1.PendingRequests() {
+			if info.MainHash == h {
+				return info
+			}
+		}
+		t.Fatalf("main transaction %s is not pending", h.StringLE())
+		return notary.RequestInfo{}
+	}
+	multisigAccs := make([]*wallet.Account, 5)
+	for i := range multisigAccs {
+		multisigAccs[i], _ = wallet.NewAccount()
+	}
+	sigAcc, _ := wallet.NewAccount()
+	partialRequesters := []requester{
+		{accounts: multisigAccs, m: 3, typ: notary.MultiSignature},
+		{accounts: []*wallet.Account{sigAcc}, typ: notary.Signature},
+	}
+	partial := createMixedRequest(partialRequesters)
+	mainHash := partial[0].MainTransaction.Hash()
+	ntr1.OnNewRequest(partial[0])
+	info := getPending(t, mainHash)
+	require.Equal(t, 1, info.SigsCollected)
+	require.Equal(t, 4, info.SigsRequired)
+	require.Equal(t, []util.Uint256{partial[0].FallbackTransaction.Hash()}, info.Fallbacks)
+	require.Equal(t, partial[0].FallbackTransaction.GetAttributes(transaction.NotValidBeforeT)[0].Value.(*transaction.NotValidBefore).Height, info.FallbackNotValidBefore)
+	require.Equal(t, partial[0].MainTransaction.ValidUntilBlock, info.ValidUntilBlock)
+	require.False(t, info.IsCompleted)
+	require.False(t, info.IsSent)
+	require.False(t, info.Received.IsZero())
+
+	ntr1.OnNewRequest(partial[1])
+	ntr1.OnNewRequest(dupNotaryRequest(t, partial[1])) // Doesn't change anything.
+	ntr1.OnNewRequest(partial[5])
+	info = getPending(t, mainHash)
+	require.Equal(t, 3, info.SigsCollected)
+	require.Equal(t, 4, info.SigsRequired)
+	require.Equal(t, 3, len(info.Fallbacks))
+	require.False(t, info.IsCompleted)
+	checkMainTx(t, partialRequesters, partial, 3, true)
+
+	ntr1.OnNewRequest(partial[2])
+	checkMainTx(t, partialRequesters, partial, 4, true)
+	require.Eventually(t, func() bool { return getPending(t, mainHash).IsSent }, time.Second*3, time.Millisecond*50)
+	info = getPending(t, mainHash)
+	require.Equal(t, 4, info.SigsCollected)
+	require.Equal(t, 4, info.SigsRequired)
+	require.True(t, info.IsCompleted)
+
 	// PostPersist: missing account
 	setFinalizeWithError(true)
 	r, requesters := checkCompleteStandardRequest(t, 1, false)
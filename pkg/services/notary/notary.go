@@ -6,8 +6,10 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
@@ -95,6 +97,9 @@ type (
 		// We stop trying to send the mainTx to the network if the chain reaches the minNotValidBefore height.
 		minNotValidBefore uint32
 		fallbacks         []*transaction.Transaction
+		// received is the time the first payload for this main transaction
+		// was received at.
+		received time.Time
 
 		witnessInfo []witnessInfo
 	}
@@ -128,6 +133,57 @@ func (r request) isMainCompleted() bool {
 	return true
 }
 
+// sigsCount returns the number of signatures collected for the main
+// transaction and the number of signatures required to complete it.
+func (r request) sigsCount() (int, int) {
+	var collected, required int
+	for _, wi := range r.witnessInfo {
+		var c int
+		switch wi.typ {
+		case Signature:
+			c = 1 - int(wi.nSigsLeft)
+		case MultiSignature:
+			c = len(wi.sigs)
+		default:
+			continue
+		}
+		collected += c
+		required += c + int(wi.nSigsLeft)
+	}
+	return collected, required
+}
+
+// RequestInfo is a summary of the main transaction pending completion by the
+// Notary service along with the associated fallbacks.
+type RequestInfo struct {
+	// MainHash is the hash of the main transaction.
+	MainHash util.Uint256
+	// ValidUntilBlock is the ValidUntilBlock of the main transaction.
+	ValidUntilBlock uint32
+	// Fallbacks contains hashes of the pending fallback transactions.
+	Fallbacks []util.Uint256
+	// FallbackNotValidBefore is the minimum NotValidBefore height among the
+	// fallbacks, the main transaction can't be sent since this height and
+	// fallbacks are sent instead.
+	FallbackNotValidBefore uint32
+	// SigsCollected is the number of signatures collected for the main
+	// transaction.
+	SigsCollected int
+	// SigsRequired is the number of signatures needed to complete the main
+	// transaction. It's 0 if the main transaction is invalid and only
+	// fallbacks can be completed.
+	SigsRequired int
+	// IsCompleted is true if all signatures are collected for the main
+	// transaction.
+	IsCompleted bool
+	// IsSent is true if the main transaction was successfully sent to the
+	// network.
+	IsSent bool
+	// Received is the time the first request for this main transaction was
+	// received at.
+	Received time.Time
+}
+
 // NewNotary returns a new Notary module.
 func NewNotary(cfg Config, net netmode.Magic, mp *mempool.Pool, onTransaction func(tx *transaction.Transaction) error) (*Notary, error) {
 	w := cfg.MainCfg.UnlockWallet
@@ -274,8 +330,10 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 		r = &request{
 			main:              safeCopy(payload.MainTransaction),
 			minNotValidBefore: nvbFallback,
+			received:          time.Now(),
 		}
 		n.requests[payload.MainTransaction.Hash()] = r
+		updatePendingRequestsMetric(len(n.requests))
 	}
 	if r.witnessInfo == nil && validationErr == nil {
 		r.witnessInfo = newInfo
@@ -336,7 +394,12 @@ func (n *Notary) OnNewRequest(payload *payload.P2PNotaryRequest) {
 			// been added - we're OK with that, let the fallback TX to be added
 		}
 	}
-	if r.isMainCompleted() && r.minNotValidBefore > n.Config.Chain.BlockHeight() {
+	if !r.isMainCompleted() {
+		return
+	}
+	// It's the first time the request is completed, see the check above.
+	updateCompletionTimeMetric(time.Since(r.received))
+	if r.minNotValidBefore > n.Config.Chain.BlockHeight() {
 		if err := n.finalize(acc, r.main, payload.MainTransaction.Hash()); err != nil {
 			n.Config.Log.Error("failed to finalize main transaction",
 				zap.String("hash", r.main.Hash().StringLE()),
@@ -381,7 +444,40 @@ func (n *Notary) OnRequestRemoval(pld *payload.P2PNotaryRequest) {
 	}
 	if len(r.fallbacks) == 0 {
 		delete(n.requests, r.main.Hash())
+		updatePendingRequestsMetric(len(n.requests))
+	}
+}
+
+// PendingRequests returns the list of main transactions that are currently
+// processed by the service sorted by the time they were received at. It's safe
+// to call it concurrently with other Notary methods.
+func (n *Notary) PendingRequests() []RequestInfo {
+	n.reqMtx.RLock()
+	res := make([]RequestInfo, 0, len(n.requests))
+	for h, r := range n.requests {
+		info := RequestInfo{
+			MainHash:               h,
+			ValidUntilBlock:        r.main.ValidUntilBlock,
+			Fallbacks:              make([]util.Uint256, len(r.fallbacks)),
+			FallbackNotValidBefore: r.minNotValidBefore,
+			IsCompleted:            r.isMainCompleted(),
+			IsSent:                 r.isSent,
+			Received:               r.received,
+		}
+		info.SigsCollected, info.SigsRequired = r.sigsCount()
+		for i, fb := range r.fallbacks {
+			info.Fallbacks[i] = fb.Hash()
+		}
+		res = append(res, info)
 	}
+	n.reqMtx.RUnlock()
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].Received.Equal(res[j].Received) {
+			return res[i].Received.Before(res[j].Received)
+		}
+		return res[i].MainHash.CompareTo(res[j].MainHash) < 0
+	})
+	return res
 }
 
 // PostPersist is a callback which is called after a new block event is received.
@@ -498,6 +594,7 @@ func (n *Notary) newTxCallbackLoop() {
 				}
 				if len(r.fallbacks) == 0 {
 					delete(n.requests, tx.mainHash)
+					updatePendingRequestsMetric(len(n.requests))
 				}
 			}
 			n.reqMtx.Unlock()
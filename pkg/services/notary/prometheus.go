@@ -0,0 +1,42 @@
+package notary
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics used in monitoring service.
+var (
+	pendingRequests = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Help:      "Number of main transactions pending completion by the notary service",
+			Name:      "notary_pending_requests",
+			Namespace: "neogo",
+		},
+	)
+
+	completionTime = prometheus.NewHistogram(
+		prometheus.HistogramOpts{
+			Help:      "Time in seconds between receiving the first notary request for the main transaction and collecting all of its signatures",
+			Name:      "notary_request_completion_seconds",
+			Namespace: "neogo",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 15, 30, 60, 120, 300, 600},
+		},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(
+		pendingRequests,
+		completionTime,
+	)
+}
+
+func updatePendingRequestsMetric(n int) {
+	pendingRequests.Set(float64(n))
+}
+
+func updateCompletionTimeMetric(d time.Duration) {
+	completionTime.Observe(d.Seconds())
+}
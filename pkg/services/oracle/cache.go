@@ -0,0 +1,123 @@
+package oracle
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+)
+
+const (
+	// defaultCacheSize is the default number of cached oracle responses.
+	defaultCacheSize = 1000
+	// defaultCacheTTL is the default lifetime of cached oracle response.
+	defaultCacheTTL = time.Minute
+)
+
+type (
+	// responseCache is a TTL cache of oracle request results (after
+	// filtering) that also coalesces concurrent identical requests, so that
+	// they share a single fetch.
+	responseCache struct {
+		cacheErrors bool
+		lru         *expirable.LRU[string, cachedResponse]
+
+		// mtx protects inflight map.
+		mtx      sync.Mutex
+		inflight map[string]*inflightResponse
+	}
+
+	// cachedResponse is a result of oracle request processing.
+	cachedResponse struct {
+		code   transaction.OracleResponseCode
+		result []byte
+	}
+
+	// inflightResponse is a response that is being fetched.
+	inflightResponse struct {
+		done chan struct{}
+		resp cachedResponse
+	}
+)
+
+// newResponseCache creates a response cache from the given configuration, nil
+// is returned if it's disabled.
+func newResponseCache(cfg config.OracleCache) *responseCache {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Size <= 0 {
+		cfg.Size = defaultCacheSize
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultCacheTTL
+	}
+	return &responseCache{
+		cacheErrors: cfg.CacheErrors,
+		lru:         expirable.NewLRU[string, cachedResponse](cfg.Size, nil, cfg.TTL),
+		inflight:    make(map[string]*inflightResponse),
+	}
+}
+
+// get returns a cached response for the given key or uses fetch to get it.
+// If there is an active fetch for the same key, it waits for its result
+// instead of fetching again. Nil cache always uses fetch.
+func (c *responseCache) get(key string, fetch func() cachedResponse) cachedResponse {
+	if c == nil {
+		return fetch()
+	}
+	c.mtx.Lock()
+	if resp, ok := c.lru.Get(key); ok {
+		c.mtx.Unlock()
+		updateCacheRequestsMetric("hit")
+		return resp
+	}
+	if inf, ok := c.inflight[key]; ok {
+		c.mtx.Unlock()
+		updateCacheRequestsMetric("coalesced")
+		<-inf.done
+		return inf.resp
+	}
+	inf := &inflightResponse{done: make(chan struct{})}
+	c.inflight[key] = inf
+	c.mtx.Unlock()
+	updateCacheRequestsMetric("miss")
+
+	inf.resp = fetch()
+
+	c.mtx.Lock()
+	if inf.resp.code == transaction.Success || c.cacheErrors {
+		c.lru.Add(key, inf.resp)
+	}
+	delete(c.inflight, key)
+	c.mtx.Unlock()
+	close(inf.done)
+	return inf.resp
+}
+
+// cacheKey returns a cache key for the request consisting of its normalized
+// URL and filter.
+func cacheKey(req *state.OracleRequest) string {
+	var sb strings.Builder
+
+	if u, err := url.Parse(req.URL); err == nil {
+		u.Scheme = strings.ToLower(u.Scheme)
+		u.Host = strings.ToLower(u.Host)
+		u.Fragment = "" // Never sent to server.
+		u.RawFragment = ""
+		sb.WriteString(u.String())
+	} else {
+		sb.WriteString(req.URL)
+	}
+	// Nil and empty filters are different.
+	if req.Filter != nil {
+		sb.WriteByte(0)
+		sb.WriteString(*req.Filter)
+	}
+	return sb.String()
+}
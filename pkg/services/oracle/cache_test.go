@@ -0,0 +1,158 @@
+package oracle
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseCacheDisabled(t *testing.T) {
+	c := newResponseCache(config.OracleCache{})
+	require.Nil(t, c)
+
+	var calls int
+	for i := 0; i < 2; i++ {
+		resp := c.get("key", func() cachedResponse {
+			calls++
+			return cachedResponse{code: transaction.Success, result: []byte{1}}
+		})
+		require.Equal(t, []byte{1}, resp.result)
+	}
+	require.Equal(t, 2, calls)
+}
+
+func TestResponseCache(t *testing.T) {
+	c := newResponseCache(config.OracleCache{Enabled: true, Size: 2, TTL: time.Hour})
+
+	var calls int
+	fetch := func(code transaction.OracleResponseCode) func() cachedResponse {
+		return func() cachedResponse {
+			calls++
+			return cachedResponse{code: code, result: []byte{byte(calls)}}
+		}
+	}
+
+	resp := c.get("a", fetch(transaction.Success))
+	require.Equal(t, []byte{1}, resp.result)
+	resp = c.get("a", fetch(transaction.Success))
+	require.Equal(t, []byte{1}, resp.result)
+	require.Equal(t, 1, calls)
+
+	t.Run("errors are not cached", func(t *testing.T) {
+		calls = 0
+		c.get("err", fetch(transaction.NotFound))
+		resp := c.get("err", fetch(transaction.NotFound))
+		require.Equal(t, transaction.NotFound, resp.code)
+		require.Equal(t, 2, calls)
+	})
+	t.Run("size", func(t *testing.T) {
+		calls = 0
+		c.get("b", fetch(transaction.Success))
+		c.get("c", fetch(transaction.Success))
+		c.get("a", fetch(transaction.Success)) // Evicted.
+		require.Equal(t, 3, calls)
+	})
+}
+
+func TestResponseCacheErrors(t *testing.T) {
+	c := newResponseCache(config.OracleCache{Enabled: true, CacheErrors: true})
+
+	var calls int
+	fetch := func() cachedResponse {
+		calls++
+		return cachedResponse{code: transaction.Forbidden}
+	}
+	c.get("err", fetch)
+	resp := c.get("err", fetch)
+	require.Equal(t, transaction.Forbidden, resp.code)
+	require.Equal(t, 1, calls)
+}
+
+func TestResponseCacheTTL(t *testing.T) {
+	c := newResponseCache(config.OracleCache{Enabled: true, TTL: 50 * time.Millisecond})
+
+	var calls int
+	fetch := func() cachedResponse {
+		calls++
+		return cachedResponse{code: transaction.Success}
+	}
+	c.get("a", fetch)
+	c.get("a", fetch)
+	require.Equal(t, 1, calls)
+	require.Eventually(t, func() bool {
+		c.get("a", fetch)
+		return calls == 2
+	}, time.Second, 20*time.Millisecond)
+}
+
+func TestResponseCacheCoalescing(t *testing.T) {
+	const n = 10
+
+	c := newResponseCache(config.OracleCache{Enabled: true})
+	coalesced := cacheRequests.WithLabelValues("coalesced")
+	base := testutil.ToFloat64(coalesced)
+
+	var (
+		calls   atomic.Int32
+		started = make(chan struct{})
+		release = make(chan struct{})
+		wg      sync.WaitGroup
+		results = make([]cachedResponse, n)
+	)
+	fetch := func() cachedResponse {
+		calls.Add(1)
+		close(started)
+		<-release
+		return cachedResponse{code: transaction.Success, result: []byte{42}}
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		results[0] = c.get("a", fetch)
+	}()
+	<-started
+	for i := 1; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = c.get("a", fetch)
+		}(i)
+	}
+	// Let other goroutines to join the in-flight request.
+	require.Eventually(t, func() bool {
+		return testutil.ToFloat64(coalesced)-base == n-1
+	}, time.Second, 10*time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	require.EqualValues(t, 1, calls.Load())
+	for i := range results {
+		require.Equal(t, []byte{42}, results[i].result)
+	}
+}
+
+func TestCacheKey(t *testing.T) {
+	flt, empty := "$.a", ""
+	var testCases = []struct {
+		a, b  state.OracleRequest
+		equal bool
+	}{
+		{state.OracleRequest{URL: "https://example.com/x"}, state.OracleRequest{URL: "HTTPS://Example.COM/x"}, true},
+		{state.OracleRequest{URL: "https://example.com/x#frag"}, state.OracleRequest{URL: "https://example.com/x"}, true},
+		{state.OracleRequest{URL: "https://example.com/x"}, state.OracleRequest{URL: "https://example.com/X"}, false},
+		{state.OracleRequest{URL: "https://example.com/x?a=1"}, state.OracleRequest{URL: "https://example.com/x"}, false},
+		{state.OracleRequest{URL: "https://example.com", Filter: &flt}, state.OracleRequest{URL: "https://example.com", Filter: &flt}, true},
+		{state.OracleRequest{URL: "https://example.com", Filter: &flt}, state.OracleRequest{URL: "https://example.com"}, false},
+		{state.OracleRequest{URL: "https://example.com", Filter: &empty}, state.OracleRequest{URL: "https://example.com"}, false},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.equal, cacheKey(&tc.a) == cacheKey(&tc.b), "%s/%v vs %s/%v", tc.a.URL, tc.a.Filter, tc.b.URL, tc.b.Filter)
+	}
+}
@@ -1,47 +1,42 @@
 package oracle
 
 import (
+	"encoding/json"
+	"os"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 )
 
-func TestFilter(t *testing.T) {
-	js := `{
-  	"Stores": [ "Lambton Quay",	"Willis Street" ],
-  	"Manufacturers": [
-		{
-			"Name": "Acme Co",
-			"Products": [
-		        { "Name": "Anvil", "Price": 50 }
-      		]
-    	},
-    	{
-      		"Name": "Contoso",
-      		"Products": [
-        		{ "Name": "Elbow Grease", "Price": 99.95 },
-        		{ "Name": "Headlight Fluid", "Price": 4 }
-      		]
-    	}
-  	]
-}`
+// filterVectors are shared with StdLib jsonPath tests to ensure identical
+// semantics.
+type filterVectors struct {
+	Value json.RawMessage `json:"value"`
+	Valid []struct {
+		Path   string `json:"path"`
+		Result string `json:"result"`
+	} `json:"valid"`
+	Invalid []string `json:"invalid"`
+}
 
-	testCases := []struct {
-		result, path string
-	}{
-		{"[]", "$.Name"},
-		{`["Acme Co"]`, "$.Manufacturers[0].Name"},
-		{`[50]`, "$.Manufacturers[0].Products[0].Price"},
-		{`["Elbow Grease"]`, "$.Manufacturers[1].Products[0].Name"},
-		{`[{"Name":"Elbow Grease","Price":99.95}]`, "$.Manufacturers[1].Products[0]"},
-	}
+func TestFilter(t *testing.T) {
+	raw, err := os.ReadFile("./testdata/filter.json")
+	require.NoError(t, err)
+	var vs filterVectors
+	require.NoError(t, json.Unmarshal(raw, &vs))
 
-	for _, tc := range testCases {
-		t.Run(tc.path, func(t *testing.T) {
-			actual, err := filter([]byte(js), tc.path)
+	for _, tc := range vs.Valid {
+		t.Run(tc.Path, func(t *testing.T) {
+			actual, err := filter(vs.Value, tc.Path)
 			require.NoError(t, err)
-			require.Equal(t, tc.result, string(actual))
+			require.Equal(t, tc.Result, string(actual))
+		})
+	}
+	for _, path := range vs.Invalid {
+		t.Run(path, func(t *testing.T) {
+			_, err := filter(vs.Value, path)
+			require.Error(t, err)
 		})
 	}
 
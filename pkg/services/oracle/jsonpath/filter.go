@@ -0,0 +1,33 @@
+package jsonpath
+
+import (
+	"bytes"
+	"errors"
+	"unicode/utf8"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+)
+
+// Filter applies the path to the UTF-8 JSON value and returns JSON array of
+// selected substructures. It's the filter used for oracle responses, it has
+// the same nesting depth and object count limits as Get.
+func Filter(value []byte, path string) ([]byte, error) {
+	if !utf8.Valid(value) {
+		return nil, errors.New("not an UTF-8")
+	}
+
+	buf := bytes.NewBuffer(value)
+	d := json.NewDecoder(buf)
+	d.UseOrderedObject()
+
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	result, ok := Get(path, v)
+	if !ok {
+		return nil, errors.New("invalid filter")
+	}
+	return json.Marshal(result)
+}
@@ -0,0 +1,170 @@
+package jsonpath
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	json "github.com/nspcc-dev/go-ordered-json"
+)
+
+// pathStep is a single step of a simple path, either an object key or
+// a non-negative array index.
+type pathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// FilterStream is the same as Filter, but for simple paths (consisting of
+// object keys and non-negative array indices only, like `$.data['items'][2]`)
+// it doesn't materialize the whole document. Everything except the selected
+// value is only validated and skipped, which makes filtering of big responses
+// cheap. Results (including errors for malformed documents) are the same as
+// for Filter, other paths are handled by Filter directly.
+func FilterStream(value []byte, path string) ([]byte, error) {
+	steps, ok := parseSimplePath(path)
+	if !ok {
+		return Filter(value, path)
+	}
+	if !utf8.Valid(value) {
+		return nil, errors.New("not an UTF-8")
+	}
+
+	d := json.NewDecoder(bytes.NewReader(value))
+	d.UseOrderedObject()
+
+	result, err := extract(d, steps)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(result)
+}
+
+// parseSimplePath parses path into a list of steps. It returns false if the
+// path can't be handled by streaming filter (it's either invalid or uses
+// some other operators).
+func parseSimplePath(path string) ([]pathStep, bool) {
+	p := pathParser{s: path}
+	if typ, _ := p.nextToken(); typ != pathRoot {
+		return nil, false
+	}
+
+	var steps []pathStep
+	for p.i < len(p.s) {
+		switch typ, _ := p.nextToken(); typ {
+		case pathDot:
+			typ, val := p.nextToken()
+			if typ != pathIdentifier {
+				return nil, false
+			}
+			steps = append(steps, pathStep{key: val})
+		case pathLeftBracket:
+			typ, val := p.nextToken()
+			if next, _ := p.nextToken(); next != pathRightBracket {
+				return nil, false
+			}
+			switch typ {
+			case pathNumber:
+				index, err := strconv.ParseInt(val, 10, 32)
+				if err != nil || index < 0 {
+					return nil, false
+				}
+				steps = append(steps, pathStep{index: int(index), isIndex: true})
+			case pathString:
+				s := strings.Trim(val, "'")
+				if err := json.Unmarshal([]byte(`"`+s+`"`), &s); err != nil {
+					return nil, false
+				}
+				steps = append(steps, pathStep{key: s})
+			default:
+				return nil, false
+			}
+		default:
+			return nil, false
+		}
+	}
+	return steps, 0 < len(steps) && len(steps) <= maxNestingDepth
+}
+
+// extract reads the next value from the decoder and returns its part selected
+// by steps (an empty slice if there is none). The rest of the value is
+// validated and skipped.
+func extract(d *json.Decoder, steps []pathStep) ([]any, error) {
+	if len(steps) == 0 {
+		var v any
+		if err := d.Decode(&v); err != nil {
+			return nil, err
+		}
+		return []any{v}, nil
+	}
+
+	tok, err := d.Token()
+	if err != nil {
+		return nil, err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// Scalar, nothing to descend into.
+		return []any{}, nil
+	}
+
+	var (
+		step   = steps[0]
+		found  bool
+		result = []any{}
+	)
+	for i := 0; d.More(); i++ {
+		var match bool
+		if delim == '{' {
+			tok, err := d.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected object key %v", tok)
+			}
+			match = !step.isIndex && !found && key == step.key
+		} else {
+			match = step.isIndex && i == step.index
+		}
+		if match {
+			found = true
+			result, err = extract(d, steps[1:])
+		} else {
+			err = skip(d)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Closing delimiter.
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// skip reads the next value from the decoder and drops it.
+func skip(d *json.Decoder) error {
+	var depth int
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package jsonpath
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterStream(t *testing.T) {
+	docs := []string{
+		`{"a":{"b":[1,{"c":"d"},[2,3]]},"a":"dup","e":null,"f g":true}`,
+		`[{"a":1},{"a":[10,20,30]},"str",5.5]`,
+		`"scalar"`,
+		`{}`,
+		`[]`,
+		`{"a":{"b":{"c":{"d":{"e":{"f":{"g":1}}}}}}}`,
+		// Malformed documents must fail in both modes.
+		``,
+		`{"a":1,}`,
+		`[1,]`,
+		`{"a":[1,2}`,
+		`{"a":1e400}`,
+		`{"a":{"b":1},"c":1e400}`,
+		`[[1,2],[3 4]]`,
+		// Trailing data is ignored in both modes.
+		`{"a":1} garbage`,
+	}
+	paths := []string{
+		`$`, `$.a`, `$['a']`, `$.a.b`, `$.a.b[1]`, `$.a.b[1].c`, `$.a.b[2][0]`,
+		`$.a.b[5]`, `$[1].a[2]`, `$[0]`, `$[3]`, `$.e`, `$['f g']`, `$.x`,
+		`$.a.b.c.d.e.f`, `$.a.b.c.d.e.f.g`, `$.a.b[-1]`, `$..a`, `$.*`, `$[0,1]`,
+		`$[0:2]`, `a`, `$a`, ``,
+	}
+	for _, doc := range docs {
+		for _, path := range paths {
+			expected, expErr := Filter([]byte(doc), path)
+			actual, err := FilterStream([]byte(doc), path)
+			if expErr != nil {
+				require.Error(t, err, "doc %s, path %s", doc, path)
+				continue
+			}
+			require.NoError(t, err, "doc %s, path %s", doc, path)
+			require.Equal(t, string(expected), string(actual), "doc %s, path %s", doc, path)
+		}
+	}
+
+	t.Run("not an UTF-8", func(t *testing.T) {
+		_, err := FilterStream([]byte{0xFF}, "$.a")
+		require.Error(t, err)
+	})
+}
+
+func TestFilterStreamBig(t *testing.T) {
+	t.Run("deep", func(t *testing.T) {
+		const depth = 100000
+		doc := `{"skip":` + strings.Repeat(`[`, depth) + strings.Repeat(`]`, depth) + `,"a":[1,2]}`
+		actual, err := FilterStream([]byte(doc), "$.a[1]")
+		require.NoError(t, err)
+		require.Equal(t, `[2]`, string(actual))
+
+		_, err = FilterStream([]byte(doc[:len(doc)-20]), "$.a[1]")
+		require.Error(t, err)
+	})
+	t.Run("wide", func(t *testing.T) {
+		const width = 100000
+		doc := `{"list":[` + strings.Repeat(`{"x":1},`, width) + `{"x":2}],"next":3}`
+		actual, err := FilterStream([]byte(doc), "$.list[100000].x")
+		require.NoError(t, err)
+		require.Equal(t, `[2]`, string(actual))
+
+		actual, err = FilterStream([]byte(doc), "$.next")
+		require.NoError(t, err)
+		require.Equal(t, `[3]`, string(actual))
+	})
+}
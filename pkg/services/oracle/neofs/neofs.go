@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -50,18 +51,11 @@ func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) (i
 		return nil, err
 	}
 
-	c, err := client.New(client.PrmInit{})
-	if err != nil {
-		return nil, fmt.Errorf("failed to create client: %w", err)
+	c, err := dial(ctx, addr)
+	if c == nil {
+		return nil, err
 	}
-
-	var (
-		res  = clientCloseWrapper{c: c}
-		prmd client.PrmDial
-	)
-	prmd.SetServerURI(addr)
-	prmd.SetContext(ctx)
-	err = c.Dial(prmd) //nolint:contextcheck // contextcheck: Function `Dial->Balance->SendUnary->Init->setNeoFSAPIServer` should pass the context parameter
+	var res = clientCloseWrapper{c: c}
 	if err != nil {
 		return res, err
 	}
@@ -82,6 +76,65 @@ func Get(ctx context.Context, priv *keys.PrivateKey, u *url.URL, addr string) (i
 	return res, err
 }
 
+// Put uploads an object with the given payload and attributes into the
+// container cnr using the given key and returns the ID of the object stored.
+func Put(ctx context.Context, priv *keys.PrivateKey, cnr cid.ID, payload []byte, attrs map[string]string, addr string) (oid.ID, error) {
+	c, err := dial(ctx, addr)
+	if c == nil {
+		return oid.ID{}, err
+	}
+	defer c.Close()
+	if err != nil {
+		return oid.ID{}, err
+	}
+
+	var (
+		s     = user.NewAutoIDSignerRFC6979(priv.PrivateKey)
+		owner = s.UserID()
+		hdr   object.Object
+		as    = make([]object.Attribute, 0, len(attrs))
+	)
+	for k, v := range attrs {
+		a := object.NewAttribute()
+		a.SetKey(k)
+		a.SetValue(v)
+		as = append(as, *a)
+	}
+	sort.Slice(as, func(i, j int) bool { return as[i].Key() < as[j].Key() })
+	hdr.SetContainerID(cnr)
+	hdr.SetOwnerID(&owner)
+	hdr.SetAttributes(as...)
+
+	w, err := c.ObjectPutInit(ctx, hdr, s, client.PrmObjectPutInit{})
+	if err != nil {
+		return oid.ID{}, err
+	}
+	_, err = w.Write(payload)
+	cErr := w.Close()
+	if err == nil {
+		err = cErr
+	}
+	if err != nil {
+		return oid.ID{}, err
+	}
+	return w.GetResult().StoredObjectID(), nil
+}
+
+// dial creates a client connected to the given NeoFS node. It returns the
+// client (that must be closed) even if connection fails.
+func dial(ctx context.Context, addr string) (*client.Client, error) {
+	c, err := client.New(client.PrmInit{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	var prmd client.PrmDial
+	prmd.SetServerURI(addr)
+	prmd.SetContext(ctx)
+	err = c.Dial(prmd) //nolint:contextcheck // contextcheck: Function `Dial->Balance->SendUnary->Init->setNeoFSAPIServer` should pass the context parameter
+	return c, err
+}
+
 type clientCloseWrapper struct {
 	io.ReadCloser
 	c *client.Client
@@ -8,15 +8,33 @@ import (
 	"go.uber.org/zap"
 )
 
-// UpdateOracleNodes updates oracle nodes list.
-func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
+// designation is a list of oracle nodes designated at some height along with
+// the local accounts being a part of it. It's immutable once created.
+type designation struct {
+	height       uint32
+	nodes        keys.PublicKeys
+	signContract []byte
+	accs         []*wallet.Account
+}
+
+// UpdateOracleNodes updates oracle nodes list designated at the given height.
+// Local accounts matching new nodes are unlocked (the wallet is reread from
+// the disk if it has none of them, so new keys can be added without service
+// restart). Responses that are already in progress keep using the nodes and
+// keys of designation they were started with, so previous designations are
+// kept while transactions created for them can still be valid.
+func (o *Oracle) UpdateOracleNodes(height uint32, oracleNodes keys.PublicKeys) {
 	o.accMtx.Lock()
 	defer o.accMtx.Unlock()
 
-	old := o.oracleNodes
-	if isEqual := len(old) == len(oracleNodes); isEqual {
-		for i := range old {
-			if !old[i].Equal(oracleNodes[i]) {
+	var prev *designation
+	if len(o.designations) != 0 {
+		prev = o.designations[len(o.designations)-1]
+	}
+	if prev != nil && len(prev.nodes) == len(oracleNodes) {
+		isEqual := true
+		for i := range prev.nodes {
+			if !prev.nodes[i].Equal(oracleNodes[i]) {
 				isEqual = false
 				break
 			}
@@ -26,44 +44,123 @@ func (o *Oracle) UpdateOracleNodes(oracleNodes keys.PublicKeys) {
 		}
 	}
 
-	var acc *wallet.Account
+	accs := o.unlockAccounts(oracleNodes)
+	if len(accs) == 0 && o.reloadWallet() {
+		accs = o.unlockAccounts(oracleNodes)
+	}
+	signContract, _ := smartcontract.CreateDefaultMultiSigRedeemScript(oracleNodes)
+	d := &designation{
+		height:       height,
+		nodes:        oracleNodes,
+		signContract: signContract,
+		accs:         accs,
+	}
+	o.designations = append(o.designations, d)
+	o.pruneDesignations(height)
+
+	if prev != nil && !sameAccounts(prev.accs, accs) {
+		o.Log.Info("oracle key rotated",
+			zap.Uint32("height", height),
+			zap.Strings("old", accountAddresses(prev.accs)),
+			zap.Strings("new", accountAddresses(accs)))
+		updateKeyRotationsMetric()
+	}
+	var active = make(map[string]bool)
+	for _, d := range o.designations {
+		for _, acc := range d.accs {
+			active[acc.Address] = true
+		}
+	}
+	updateActiveKeysMetric(len(active))
+}
+
+// unlockAccounts returns decrypted wallet accounts corresponding to the given
+// nodes. It must be called with accMtx held.
+func (o *Oracle) unlockAccounts(oracleNodes keys.PublicKeys) []*wallet.Account {
+	var accs []*wallet.Account
 	for i := range oracleNodes {
-		acc = o.wallet.GetAccount(oracleNodes[i].GetScriptHash())
-		if acc != nil {
-			if acc.CanSign() {
-				break
-			}
+		acc := o.wallet.GetAccount(oracleNodes[i].GetScriptHash())
+		if acc == nil {
+			continue
+		}
+		if !acc.CanSign() {
 			err := acc.Decrypt(o.MainCfg.UnlockWallet.Password, o.wallet.Scrypt)
 			if err != nil {
 				o.Log.Error("can't unlock account",
 					zap.String("address", address.Uint160ToString(acc.Contract.ScriptHash())),
 					zap.Error(err))
-				o.currAccount = nil
-				return
+				continue
 			}
-			break
 		}
+		accs = append(accs, acc)
+	}
+	return accs
+}
+
+// reloadWallet rereads the wallet file and adds accounts that are missing from
+// the current wallet to it. It returns true if there were any. It must be
+// called with accMtx held.
+func (o *Oracle) reloadWallet() bool {
+	w, err := wallet.NewWalletFromFile(o.MainCfg.UnlockWallet.Path)
+	if err != nil {
+		o.Log.Warn("can't reload oracle wallet", zap.Error(err))
+		return false
+	}
+	var added bool
+	for _, acc := range w.Accounts {
+		if o.wallet.GetAccount(acc.ScriptHash()) == nil {
+			o.wallet.AddAccount(acc)
+			added = true
+		}
+	}
+	if added {
+		o.Log.Info("oracle wallet reloaded", zap.Int("accounts", len(o.wallet.Accounts)))
 	}
+	return added
+}
 
-	o.currAccount = acc
-	o.oracleSignContract, _ = smartcontract.CreateDefaultMultiSigRedeemScript(oracleNodes)
-	o.oracleNodes = oracleNodes
+// pruneDesignations drops designations that were replaced long enough ago for
+// any transaction created for them to be expired. It must be called with
+// accMtx held.
+func (o *Oracle) pruneDesignations(height uint32) {
+	// Backup transactions can be valid for up to two increments.
+	retain := 2 * o.Chain.GetConfig().MaxValidUntilBlockIncrement
+	var i int
+	for i < len(o.designations)-1 && o.designations[i+1].height+retain <= height {
+		i++
+	}
+	if i != 0 {
+		o.designations = append(o.designations[:0:0], o.designations[i:]...)
+	}
 }
 
-func (o *Oracle) getAccount() *wallet.Account {
-	o.accMtx.RLock()
-	defer o.accMtx.RUnlock()
-	return o.currAccount
+func sameAccounts(a, b []*wallet.Account) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
 
-func (o *Oracle) getOracleNodes() keys.PublicKeys {
-	o.accMtx.RLock()
-	defer o.accMtx.RUnlock()
-	return o.oracleNodes
+func accountAddresses(accs []*wallet.Account) []string {
+	var res = make([]string, len(accs))
+	for i := range accs {
+		res[i] = accs[i].Address
+	}
+	return res
 }
 
-func (o *Oracle) getOracleSignContract() []byte {
+// getDesignation returns the latest oracle nodes designation, nil if there is
+// none.
+func (o *Oracle) getDesignation() *designation {
 	o.accMtx.RLock()
 	defer o.accMtx.RUnlock()
-	return o.oracleSignContract
+	if len(o.designations) == 0 {
+		return nil
+	}
+	return o.designations[len(o.designations)-1]
 }
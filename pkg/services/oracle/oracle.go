@@ -41,14 +41,12 @@ type (
 		// This fields are readonly thus not protected by mutex.
 		oracleHash     util.Uint160
 		oracleResponse []byte
-		oracleScript   []byte
-		verifyOffset   int
 
-		// accMtx protects account and oracle nodes.
-		accMtx             sync.RWMutex
-		currAccount        *wallet.Account
-		oracleNodes        keys.PublicKeys
-		oracleSignContract []byte
+		// accMtx protects designations and wallet.
+		accMtx sync.RWMutex
+		// designations contains oracle nodes designations sorted by height,
+		// the last one is the current.
+		designations []*designation
 
 		close      chan struct{}
 		done       chan struct{}
@@ -65,6 +63,13 @@ type (
 		responses map[uint64]*incompleteTx
 		// removed contains ids of requests which won't be processed further due to expiration.
 		removed map[uint64]bool
+		// cancelled contains ids of cancelled requests along with the
+		// cancellation time, they're kept for MaxTaskTimeout to ignore
+		// in-flight processing results and responses of other nodes.
+		cancelled map[uint64]time.Time
+
+		// cache is a response cache, nil if disabled.
+		cache *responseCache
 
 		wallet *wallet.Wallet
 	}
@@ -125,6 +130,8 @@ func NewOracle(cfg Config) (*Oracle, error) {
 		pending:    make(map[uint64]*state.OracleRequest),
 		responses:  make(map[uint64]*incompleteTx),
 		removed:    make(map[uint64]bool),
+		cancelled:  make(map[uint64]time.Time),
+		cache:      newResponseCache(cfg.MainCfg.Cache),
 	}
 	if o.MainCfg.RequestTimeout == 0 {
 		o.MainCfg.RequestTimeout = defaultRequestTimeout
@@ -211,7 +218,8 @@ func (o *Oracle) Start() {
 // signatures. It returns true iff designated Oracle node's account provided to
 // the Oracle service in decrypted state.
 func (o *Oracle) IsAuthorized() bool {
-	return o.getAccount() != nil
+	d := o.getDesignation()
+	return d != nil && len(d.accs) != 0
 }
 
 func (o *Oracle) start() {
@@ -248,6 +256,11 @@ main:
 			for id := range o.removed {
 				delete(o.responses, id)
 			}
+			for id, t := range o.cancelled {
+				if time.Since(t) > o.MainCfg.MaxTaskTimeout {
+					delete(o.cancelled, id)
+				}
+			}
 			o.respMtx.Unlock()
 
 			for _, id := range reprocess {
@@ -276,12 +289,9 @@ drain:
 }
 
 // UpdateNativeContract updates native oracle contract info for tx verification.
-func (o *Oracle) UpdateNativeContract(script, resp []byte, h util.Uint160, verifyOffset int) {
-	o.oracleScript = bytes.Clone(script)
+func (o *Oracle) UpdateNativeContract(resp []byte, h util.Uint160) {
 	o.oracleResponse = bytes.Clone(resp)
-
 	o.oracleHash = h
-	o.verifyOffset = verifyOffset
 }
 
 func (o *Oracle) sendTx(tx *transaction.Transaction) {
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	gio "io"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -28,7 +30,6 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neotest"
 	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle"
-	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
@@ -122,7 +123,7 @@ func TestCreateResponseTx(t *testing.T) {
 	}
 	cInvoker.Invoke(t, stackitem.Null{}, "requestURL", req.URL, *req.Filter, req.CallbackMethod, req.UserData, int64(req.GasForResponse))
 	bc.SetOracle(orc)
-	orc.UpdateOracleNodes(keys.PublicKeys{acc.PublicKey()})
+	orc.UpdateOracleNodes(0, keys.PublicKeys{acc.PublicKey()})
 	tx, err = orc.CreateResponseTx(int64(req.GasForResponse), 1, resp)
 	require.NoError(t, err)
 	assert.Equal(t, 166, tx.Size())
@@ -154,16 +155,12 @@ func TestOracle(t *testing.T) {
 	// Must be set in native contract for tx verification.
 	designationSuperInvoker.Invoke(t, stackitem.Null{}, "designateAsRole",
 		int64(roles.Oracle), []any{oracleNodes[0].Bytes(), oracleNodes[1].Bytes()})
-	orc1.UpdateOracleNodes(oracleNodes.Copy())
-	orc2.UpdateOracleNodes(oracleNodes.Copy())
+	orc1.UpdateOracleNodes(0, oracleNodes.Copy())
+	orc2.UpdateOracleNodes(0, oracleNodes.Copy())
 
-	nativeOracleState := bc.GetContractState(nativeOracleH)
-	require.NotNil(t, nativeOracleState)
-	md := nativeOracleState.Manifest.ABI.GetMethod(manifest.MethodVerify, -1)
-	require.NotNil(t, md)
 	oracleRespScript := native.CreateOracleResponseScript(nativeOracleH)
-	orc1.UpdateNativeContract(nativeOracleState.NEF.Script, bytes.Clone(oracleRespScript), nativeOracleH, md.Offset)
-	orc2.UpdateNativeContract(nativeOracleState.NEF.Script, bytes.Clone(oracleRespScript), nativeOracleH, md.Offset)
+	orc1.UpdateNativeContract(bytes.Clone(oracleRespScript), nativeOracleH)
+	orc2.UpdateNativeContract(bytes.Clone(oracleRespScript), nativeOracleH)
 
 	cs := contracts.GetOracleContractState(t, pathToInternalContracts, validator.ScriptHash(), 0)
 	rawManifest, err := json.Marshal(cs.Manifest)
@@ -190,6 +187,7 @@ func TestOracle(t *testing.T) {
 	putOracleRequest(t, cInvoker, "https://get.filterinv", &flt, "handle", []byte{}, 10_000_000)
 
 	putOracleRequest(t, cInvoker, "https://get.invalidcontent", nil, "handle", []byte{}, 10_000_000)
+	putOracleRequest(t, cInvoker, "https://get.1234", nil, "handle", []byte{}, 10_000_000)
 
 	checkResp := func(t *testing.T, id uint64, resp *transaction.OracleResponse) *state.OracleRequest {
 		// Use a hack to get request from Oracle contract, because we can't use GetRequestInternal directly.
@@ -337,6 +335,106 @@ func TestOracle(t *testing.T) {
 			Code: transaction.ContentTypeNotSupported,
 		})
 	})
+	t.Run("Cancelled", func(t *testing.T) {
+		const reqID = 12
+
+		// The other node has already broadcasted its response.
+		req := checkResp(t, reqID, &transaction.OracleResponse{
+			ID:     reqID,
+			Code:   transaction.Success,
+			Result: []byte{1, 2, 3, 4},
+		})
+		orc2.CancelRequests([]uint64{reqID})
+		orc2.AddResponse(acc1.PublicKey(), reqID, m1[reqID].txSig)
+		require.Empty(t, ch2)
+
+		// Request picked up after cancellation is not processed.
+		orc2.ProcessRequestsInternal(map[uint64]*state.OracleRequest{reqID: req})
+		require.Nil(t, m2[reqID])
+		require.Empty(t, ch2)
+	})
+}
+
+func TestOracle_ResponseCache(t *testing.T) {
+	var hits = make(map[string]int)
+	var hitsMtx sync.Mutex
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hitsMtx.Lock()
+		hits[r.URL.Path]++
+		hitsMtx.Unlock()
+		if r.URL.Path != "/data" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"Values":[1,2]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	bc, _, _ := chain.NewMulti(t)
+	m := make(map[uint64]*responseWithSig)
+	cfg := getOracleConfig(t, bc, "./testdata/oracle1.json", "one", nil)
+	cfg.MainCfg.Cache = config.OracleCache{Enabled: true}
+	cfg.Client = srv.Client()
+	cfg.ResponseHandler = &saveToMapBroadcaster{m: m}
+	orc, err := oracle.NewOracle(cfg)
+	require.NoError(t, err)
+	w, err := wallet.NewWalletFromFile("./testdata/oracle1.json")
+	require.NoError(t, err)
+	require.NoError(t, w.Accounts[0].Decrypt("one", w.Scrypt))
+	bc.SetOracle(orc)
+	orc.UpdateOracleNodes(0, keys.PublicKeys{w.Accounts[0].PublicKey()})
+
+	newReq := func(path string, filter *string) *state.OracleRequest {
+		return &state.OracleRequest{
+			GasForResponse: 100_000_000,
+			URL:            srv.URL + path,
+			Filter:         filter,
+			CallbackMethod: "handle",
+		}
+	}
+	flt := "$.Values[1]"
+	var wg sync.WaitGroup
+	for id := uint64(0); id < 4; id++ {
+		wg.Add(1)
+		go func(id uint64) {
+			defer wg.Done()
+			orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{id: newReq("/data", nil)})
+		}(id)
+	}
+	wg.Wait()
+	orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{
+		4: newReq("/data", &flt),
+		5: newReq("/missing", nil),
+	})
+	orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{
+		6: newReq("/data", &flt),
+		7: newReq("/missing", nil),
+	})
+
+	// Every request gets its own response.
+	for id := uint64(0); id < 4; id++ {
+		require.Equal(t, &transaction.OracleResponse{
+			ID:     id,
+			Code:   transaction.Success,
+			Result: []byte(`{"Values":[1,2]}`),
+		}, m[id].resp)
+	}
+	for _, id := range []uint64{4, 6} {
+		require.Equal(t, &transaction.OracleResponse{
+			ID:     id,
+			Code:   transaction.Success,
+			Result: []byte(`[2]`),
+		}, m[id].resp)
+	}
+	for _, id := range []uint64{5, 7} {
+		require.Equal(t, &transaction.OracleResponse{
+			ID:   id,
+			Code: transaction.NotFound,
+		}, m[id].resp)
+	}
+	// Same URL with different filter is a separate entry, errors are not cached.
+	require.Equal(t, map[string]int{"/data": 2, "/missing": 2}, hits)
 }
 
 func TestOracle_GenesisRole(t *testing.T) {
@@ -363,6 +461,94 @@ func TestOracle_GenesisRole(t *testing.T) {
 	require.True(t, orc.IsAuthorized())
 }
 
+func TestOracle_KeyRotation(t *testing.T) {
+	bc, validator, committee := chain.NewMulti(t)
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	designationSuperInvoker := e.NewInvoker(e.NativeHash(t, nativenames.Designation), validator, committee)
+
+	// Use a copy of the wallet, new keys are added to it later.
+	walletPath := filepath.Join(t.TempDir(), "oracle.json")
+	raw, err := os.ReadFile("./testdata/oracle1.json")
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(walletPath, raw, 0o644))
+	w, err := wallet.NewWalletFromFile(walletPath)
+	require.NoError(t, err)
+	require.NoError(t, w.Accounts[0].Decrypt("one", w.Scrypt))
+	acc1 := w.Accounts[0]
+
+	// Key of some other oracle node.
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	designate := func(nodes ...*keys.PublicKey) {
+		var pubs = make([]any, len(nodes))
+		for i := range nodes {
+			pubs[i] = nodes[i].Bytes()
+		}
+		designationSuperInvoker.Invoke(t, stackitem.Null{}, "designateAsRole", int64(roles.Oracle), pubs)
+	}
+	designate(acc1.PublicKey(), other.PublicKey())
+
+	b := &keyRecordingBroadcaster{resps: make(map[uint64]*transaction.OracleResponse), pubs: make(map[uint64][]*keys.PublicKey)}
+	ch := make(chan *transaction.Transaction, 5)
+	cfg := getOracleConfig(t, bc, walletPath, "one", nil)
+	cfg.ResponseHandler = b
+	cfg.OnTransaction = saveTxToChan(ch)
+	orc, err := oracle.NewOracle(cfg)
+	require.NoError(t, err)
+	bc.SetOracle(orc)
+	require.True(t, orc.IsAuthorized())
+
+	newReq := func() *state.OracleRequest {
+		return &state.OracleRequest{
+			GasForResponse: 100_000_000,
+			URL:            "https://get.1234",
+			CallbackMethod: "handle",
+		}
+	}
+	orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{1: newReq(), 2: newReq()})
+	require.Equal(t, []*keys.PublicKey{acc1.PublicKey()}, b.sent(1))
+	require.Equal(t, []*keys.PublicKey{acc1.PublicKey()}, b.sent(2))
+
+	// Response tx for the first request, it's built the same way oracle does.
+	vub := bc.BlockHeight() + bc.GetConfig().MaxValidUntilBlockIncrement
+	resp1 := *b.resps[1]
+	tx1, err := orc.CreateResponseTx(int64(newReq().GasForResponse), vub, &resp1)
+	require.NoError(t, err)
+	oldSigner := tx1.Signers[1].Account
+
+	// Rotate the key: the new one is added to the wallet file and designated
+	// instead of the old one while requests are still in progress.
+	acc2, err := wallet.NewAccount()
+	require.NoError(t, err)
+	require.NoError(t, acc2.Encrypt("one", w.Scrypt))
+	w.AddAccount(acc2)
+	require.NoError(t, w.Save())
+	designate(acc2.PublicKey(), other.PublicKey())
+	require.True(t, orc.IsAuthorized())
+
+	// In-flight response is finalized for the old nodes.
+	orc.AddResponse(other.PublicKey(), 1, other.SignHashable(uint32(netmode.UnitTestNet), tx1))
+	select {
+	case tx := <-ch:
+		require.Equal(t, tx1.Hash(), tx.Hash())
+		require.Equal(t, oldSigner, tx.Signers[1].Account)
+	case <-time.After(time.Second):
+		t.Fatal("in-flight response wasn't finalized")
+	}
+
+	// Backup response of the in-flight request is signed with the old key.
+	orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{2: nil})
+	require.Equal(t, []*keys.PublicKey{acc1.PublicKey(), acc1.PublicKey()}, b.sent(2))
+
+	// New requests are signed with the new key for the new nodes.
+	orc.ProcessRequestsInternal(map[uint64]*state.OracleRequest{3: newReq()})
+	require.Equal(t, []*keys.PublicKey{acc2.PublicKey()}, b.sent(3))
+	tx3, err := orc.CreateResponseTx(int64(newReq().GasForResponse), vub, &transaction.OracleResponse{ID: 3})
+	require.NoError(t, err)
+	require.NotEqual(t, oldSigner, tx3.Signers[1].Account)
+}
+
 func TestOracleFull(t *testing.T) {
 	bc, validator, committee := chain.NewMultiWithCustomConfigAndStore(t, nil, nil, false)
 	e := neotest.NewExecutor(t, bc, validator, committee)
@@ -473,6 +659,29 @@ func (b *saveToMapBroadcaster) SendResponse(_ *keys.PrivateKey, resp *transactio
 func (*saveToMapBroadcaster) Run()      {}
 func (*saveToMapBroadcaster) Shutdown() {}
 
+// keyRecordingBroadcaster saves responses along with the keys they were sent
+// with.
+type keyRecordingBroadcaster struct {
+	mtx   sync.Mutex
+	resps map[uint64]*transaction.OracleResponse
+	pubs  map[uint64][]*keys.PublicKey
+}
+
+func (b *keyRecordingBroadcaster) SendResponse(priv *keys.PrivateKey, resp *transaction.OracleResponse, _ []byte) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	b.resps[resp.ID] = resp
+	b.pubs[resp.ID] = append(b.pubs[resp.ID], priv.PublicKey())
+}
+func (*keyRecordingBroadcaster) Run()      {}
+func (*keyRecordingBroadcaster) Shutdown() {}
+
+func (b *keyRecordingBroadcaster) sent(id uint64) []*keys.PublicKey {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	return b.pubs[id]
+}
+
 type responseWithSig struct {
 	resp  *transaction.OracleResponse
 	txSig []byte
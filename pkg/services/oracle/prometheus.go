@@ -0,0 +1,51 @@
+package oracle
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// cacheRequests prometheus metric.
+var cacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Help:      "Number of oracle response cache lookups per result (hit, miss or coalesced)",
+		Name:      "oracle_cache_requests_total",
+		Namespace: "neogo",
+	},
+	[]string{"result"},
+)
+
+// keyRotations prometheus metric.
+var keyRotations = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Help:      "Number of oracle signing key rotations",
+		Name:      "oracle_key_rotations_total",
+		Namespace: "neogo",
+	},
+)
+
+// activeKeys prometheus metric.
+var activeKeys = prometheus.NewGauge(
+	prometheus.GaugeOpts{
+		Help:      "Number of oracle signing keys that are valid for in-flight requests",
+		Name:      "oracle_active_keys",
+		Namespace: "neogo",
+	},
+)
+
+func init() {
+	prometheus.MustRegister(
+		cacheRequests,
+		keyRotations,
+		activeKeys,
+	)
+}
+
+func updateCacheRequestsMetric(result string) {
+	cacheRequests.WithLabelValues(result).Inc()
+}
+
+func updateKeyRotationsMetric() {
+	keyRotations.Inc()
+}
+
+func updateActiveKeysMetric(n int) {
+	activeKeys.Set(float64(n))
+}
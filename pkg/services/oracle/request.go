@@ -29,11 +29,7 @@ func (o *Oracle) runRequestWorker() {
 		case <-o.close:
 			return
 		case req := <-o.requestCh:
-			acc := o.getAccount()
-			if acc == nil {
-				continue
-			}
-			err := o.processRequest(acc.PrivateKey(), req)
+			err := o.processRequest(req)
 			if err != nil {
 				o.Log.Debug("can't process request", zap.Uint64("id", req.ID), zap.Error(err))
 			}
@@ -57,6 +53,27 @@ func (o *Oracle) RemoveRequests(ids []uint64) {
 	}
 }
 
+// CancelRequests removes cancelled requests, results of their processing
+// that is already in progress are dropped.
+func (o *Oracle) CancelRequests(ids []uint64) {
+	o.respMtx.Lock()
+	defer o.respMtx.Unlock()
+	var now = time.Now()
+	for _, id := range ids {
+		delete(o.pending, id)
+		delete(o.responses, id)
+		o.cancelled[id] = now
+	}
+}
+
+// isCancelled returns true if the request with the specified id is cancelled.
+func (o *Oracle) isCancelled(id uint64) bool {
+	o.respMtx.RLock()
+	defer o.respMtx.RUnlock()
+	_, ok := o.cancelled[id]
+	return ok
+}
+
 // AddRequests saves all requests in-fly for further processing.
 func (o *Oracle) AddRequests(reqs map[uint64]*state.OracleRequest) {
 	if len(reqs) == 0 {
@@ -89,41 +106,126 @@ func (o *Oracle) AddRequests(reqs map[uint64]*state.OracleRequest) {
 
 // ProcessRequestsInternal processes the provided requests synchronously.
 func (o *Oracle) ProcessRequestsInternal(reqs map[uint64]*state.OracleRequest) {
-	acc := o.getAccount()
-	if acc == nil {
-		return
-	}
-
 	// Process actual requests.
 	for id, req := range reqs {
-		if err := o.processRequest(acc.PrivateKey(), request{ID: id, Req: req}); err != nil {
+		if err := o.processRequest(request{ID: id, Req: req}); err != nil {
 			o.Log.Debug("can't process request", zap.Error(err))
 		}
 	}
 }
 
-func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
+// processRequest handles the request using the keys of oracle nodes designation
+// valid when its processing has started. The designation is pinned to the
+// response, so responses that are in progress when the designation changes
+// are completed with the same nodes and keys.
+func (o *Oracle) processRequest(req request) error {
 	if req.Req == nil {
-		o.processFailedRequest(priv, req)
+		o.processFailedRequest(req)
 		return nil
 	}
 
-	incTx := o.getResponse(req.ID, true)
+	d := o.getDesignation()
+	if d != nil && len(d.accs) == 0 {
+		d = nil
+	}
+	incTx := o.getResponse(req.ID, d != nil)
 	if incTx == nil {
 		return nil
 	}
-	resp := &transaction.OracleResponse{ID: req.ID, Code: transaction.Success}
+	incTx.Lock()
+	if incTx.desig == nil {
+		incTx.desig = d
+	}
+	d = incTx.desig
+	incTx.Unlock()
+	if d == nil {
+		return nil
+	}
+	priv := d.accs[0].PrivateKey()
+	cached := o.cache.get(cacheKey(req.Req), func() cachedResponse {
+		return o.fetchResponse(priv, req, incTx.attempts)
+	})
+	resp := &transaction.OracleResponse{ID: req.ID, Code: cached.code, Result: cached.result}
+	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
+
+	currentHeight := o.Chain.BlockHeight()
+	vubInc := o.Chain.GetConfig().MaxValidUntilBlockIncrement
+	_, h, err := o.Chain.GetTransaction(req.Req.OriginalTxID)
+	if err != nil {
+		if !errors.Is(err, storage.ErrKeyNotFound) {
+			return err
+		}
+		// The only reason tx can be not found is that it hasn't been persisted from DAO yet.
+		h = currentHeight
+	}
+	h += vubInc // Main tx is only valid for RequestHeight + ValidUntilBlock.
+	tx, err := o.createResponseTx(d, int64(req.Req.GasForResponse), h, resp)
+	if err != nil {
+		return err
+	}
+	for h <= currentHeight { // Backup tx must be valid in any event.
+		h += vubInc
+	}
+	backupTx, err := o.createResponseTx(d, int64(req.Req.GasForResponse), h, &transaction.OracleResponse{
+		ID:   req.ID,
+		Code: transaction.ConsensusUnreachable,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.isCancelled(req.ID) {
+		o.Log.Debug("oracle request cancelled", zap.Uint64("request", req.ID))
+		return nil
+	}
+	incTx.Lock()
+	incTx.request = req.Req
+	incTx.tx = tx
+	incTx.backupTx = backupTx
+	incTx.reverifyTx(o.Network)
+
+	txSigs := make([][]byte, len(d.accs))
+	for i, acc := range d.accs {
+		priv := acc.PrivateKey()
+		txSigs[i] = priv.SignHashable(uint32(o.Network), tx)
+		incTx.addResponse(priv.PublicKey(), txSigs[i], false)
+
+		backupSig := priv.SignHashable(uint32(o.Network), backupTx)
+		incTx.addResponse(priv.PublicKey(), backupSig, true)
+	}
+
+	readyTx, ready := incTx.finalize(false)
+	if ready {
+		ready = !incTx.isSent
+		incTx.isSent = true
+	}
+	incTx.time = time.Now()
+	incTx.attempts++
+	incTx.Unlock()
+
+	for i, acc := range d.accs {
+		o.ResponseHandler.SendResponse(acc.PrivateKey(), resp, txSigs[i])
+	}
+	if ready {
+		o.sendTx(readyTx)
+	}
+	return nil
+}
+
+// fetchResponse gets data for the request and applies its filter.
+func (o *Oracle) fetchResponse(priv *keys.PrivateKey, req request, attempts int) cachedResponse {
+	resp := cachedResponse{code: transaction.Success}
 	u, err := url.ParseRequestURI(req.Req.URL)
 	if err != nil {
 		o.Log.Warn("malformed oracle request", zap.String("url", req.Req.URL), zap.Error(err))
-		resp.Code = transaction.ProtocolNotSupported
+		resp.code = transaction.ProtocolNotSupported
 	} else {
 		switch u.Scheme {
 		case "https":
 			httpReq, err := http.NewRequest("GET", req.Req.URL, nil)
 			if err != nil {
 				o.Log.Warn("failed to create http request", zap.String("url", req.Req.URL), zap.Error(err))
-				resp.Code = transaction.Error
+				resp.code = transaction.Error
 				break
 			}
 			httpReq.Header.Set("User-Agent", "NeoOracleService/3.0")
@@ -131,115 +233,62 @@ func (o *Oracle) processRequest(priv *keys.PrivateKey, req request) error {
 			r, err := o.Client.Do(httpReq)
 			if err != nil {
 				if errors.Is(err, ErrRestrictedRedirect) {
-					resp.Code = transaction.Forbidden
+					resp.code = transaction.Forbidden
 				} else {
-					resp.Code = transaction.Error
+					resp.code = transaction.Error
 				}
-				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.Code))
+				o.Log.Warn("oracle request failed", zap.String("url", req.Req.URL), zap.Error(err), zap.Stringer("code", resp.code))
 				break
 			}
 			defer r.Body.Close()
 			switch r.StatusCode {
 			case http.StatusOK:
 				if !checkMediaType(r.Header.Get("Content-Type"), o.MainCfg.AllowedContentTypes) {
-					resp.Code = transaction.ContentTypeNotSupported
+					resp.code = transaction.ContentTypeNotSupported
 					break
 				}
 
-				resp.Result, resp.Code = o.readResponse(r.Body, req.Req.URL)
+				resp.result, resp.code = o.readResponse(r.Body, req.Req.URL)
 			case http.StatusForbidden:
-				resp.Code = transaction.Forbidden
+				resp.code = transaction.Forbidden
 			case http.StatusNotFound:
-				resp.Code = transaction.NotFound
+				resp.code = transaction.NotFound
 			case http.StatusRequestTimeout:
-				resp.Code = transaction.Timeout
+				resp.code = transaction.Timeout
 			default:
-				resp.Code = transaction.Error
+				resp.code = transaction.Error
 			}
 		case neofs.URIScheme:
 			ctx, cancel := context.WithTimeout(context.Background(), o.MainCfg.NeoFS.Timeout)
 			defer cancel()
-			index := (int(req.ID) + incTx.attempts) % len(o.MainCfg.NeoFS.Nodes)
+			index := (int(req.ID) + attempts) % len(o.MainCfg.NeoFS.Nodes)
 			rc, err := neofs.Get(ctx, priv, u, o.MainCfg.NeoFS.Nodes[index])
 			if err != nil {
-				resp.Code = transaction.Error
+				resp.code = transaction.Error
 				o.Log.Warn("failed to perform oracle request", zap.String("url", req.Req.URL), zap.Error(err))
 				if rc != nil {
 					rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
 				}
 				break
 			}
-			resp.Result, resp.Code = o.readResponse(rc, req.Req.URL)
+			resp.result, resp.code = o.readResponse(rc, req.Req.URL)
 			rc.Close() // intentionally skip the closing error, make it unified with Oracle `https` protocol.
 		default:
-			resp.Code = transaction.ProtocolNotSupported
+			resp.code = transaction.ProtocolNotSupported
 			o.Log.Warn("unknown oracle request scheme", zap.String("url", req.Req.URL))
 		}
 	}
-	if resp.Code == transaction.Success {
-		resp.Result, err = filterRequest(resp.Result, req.Req)
+	if resp.code == transaction.Success {
+		resp.result, err = filterRequest(resp.result, req.Req)
 		if err != nil {
 			o.Log.Warn("oracle filter failed", zap.Uint64("request", req.ID), zap.Error(err))
-			resp.Code = transaction.Error
-		}
-	}
-	o.Log.Debug("oracle request processed", zap.String("url", req.Req.URL), zap.Int("code", int(resp.Code)), zap.String("result", string(resp.Result)))
-
-	currentHeight := o.Chain.BlockHeight()
-	vubInc := o.Chain.GetConfig().MaxValidUntilBlockIncrement
-	_, h, err := o.Chain.GetTransaction(req.Req.OriginalTxID)
-	if err != nil {
-		if !errors.Is(err, storage.ErrKeyNotFound) {
-			return err
+			resp.code = transaction.Error
 		}
-		// The only reason tx can be not found is that it hasn't been persisted from DAO yet.
-		h = currentHeight
-	}
-	h += vubInc // Main tx is only valid for RequestHeight + ValidUntilBlock.
-	tx, err := o.CreateResponseTx(int64(req.Req.GasForResponse), h, resp)
-	if err != nil {
-		return err
-	}
-	for h <= currentHeight { // Backup tx must be valid in any event.
-		h += vubInc
-	}
-	backupTx, err := o.CreateResponseTx(int64(req.Req.GasForResponse), h, &transaction.OracleResponse{
-		ID:   req.ID,
-		Code: transaction.ConsensusUnreachable,
-	})
-	if err != nil {
-		return err
-	}
-
-	incTx.Lock()
-	incTx.request = req.Req
-	incTx.tx = tx
-	incTx.backupTx = backupTx
-	incTx.reverifyTx(o.Network)
-
-	txSig := priv.SignHashable(uint32(o.Network), tx)
-	incTx.addResponse(priv.PublicKey(), txSig, false)
-
-	backupSig := priv.SignHashable(uint32(o.Network), backupTx)
-	incTx.addResponse(priv.PublicKey(), backupSig, true)
-
-	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
-	if ready {
-		ready = !incTx.isSent
-		incTx.isSent = true
 	}
-	incTx.time = time.Now()
-	incTx.attempts++
-	incTx.Unlock()
-
-	o.ResponseHandler.SendResponse(priv, resp, txSig)
-	if ready {
-		o.sendTx(readyTx)
-	}
-	return nil
+	return resp
 }
 
-func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
+func (o *Oracle) processFailedRequest(req request) {
 	// Request is being processed again.
 	incTx := o.getResponse(req.ID, false)
 	if incTx == nil {
@@ -253,17 +302,35 @@ func (o *Oracle) processFailedRequest(priv *keys.PrivateKey, req request) {
 
 	// Don't process request again, fallback to backup tx.
 	incTx.Lock()
-	readyTx, ready := incTx.finalize(o.getOracleNodes(), true)
+	d := incTx.desig
+	if d == nil {
+		// Request wasn't processed by this node.
+		incTx.Unlock()
+		return
+	}
+	readyTx, ready := incTx.finalize(true)
 	if ready {
 		ready = !incTx.isSent
 		incTx.isSent = true
 	}
 	incTx.time = time.Now()
 	incTx.attempts++
-	txSig := incTx.backupSigs[string(priv.PublicKey().Bytes())].sig
+	var (
+		privs  []*keys.PrivateKey
+		txSigs [][]byte
+	)
+	for _, acc := range d.accs {
+		priv := acc.PrivateKey()
+		if sig, ok := incTx.backupSigs[string(priv.PublicKey().Bytes())]; ok {
+			privs = append(privs, priv)
+			txSigs = append(txSigs, sig.sig)
+		}
+	}
 	incTx.Unlock()
 
-	o.ResponseHandler.SendResponse(priv, getFailedResponse(req.ID), txSig)
+	for i := range privs {
+		o.ResponseHandler.SendResponse(privs[i], getFailedResponse(req.ID), txSigs[i])
+	}
 	if ready {
 		o.sendTx(readyTx)
 	}
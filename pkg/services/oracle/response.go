@@ -14,6 +14,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"go.uber.org/zap"
 )
@@ -22,7 +23,8 @@ func (o *Oracle) getResponse(reqID uint64, create bool) *incompleteTx {
 	o.respMtx.Lock()
 	defer o.respMtx.Unlock()
 	incTx, ok := o.responses[reqID]
-	if !ok && create && !o.removed[reqID] {
+	_, cancelled := o.cancelled[reqID]
+	if !ok && create && !o.removed[reqID] && !cancelled {
 		incTx = newIncompleteTx()
 		o.responses[reqID] = incTx
 	}
@@ -53,7 +55,7 @@ func (o *Oracle) AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte) {
 		}
 	}
 	incTx.addResponse(pub, txSig, isBackup)
-	readyTx, ready := incTx.finalize(o.getOracleNodes(), false)
+	readyTx, ready := incTx.finalize(false)
 	if ready {
 		ready = !incTx.isSent
 		incTx.isSent = true
@@ -101,8 +103,19 @@ func checkUTF8(v []byte) ([]byte, error) {
 	return v, nil
 }
 
-// CreateResponseTx creates an unsigned oracle response transaction.
+// CreateResponseTx creates an unsigned oracle response transaction for the
+// current oracle nodes.
 func (o *Oracle) CreateResponseTx(gasForResponse int64, vub uint32, resp *transaction.OracleResponse) (*transaction.Transaction, error) {
+	d := o.getDesignation()
+	if d == nil {
+		return nil, errors.New("no oracle nodes designated")
+	}
+	return o.createResponseTx(d, gasForResponse, vub, resp)
+}
+
+// createResponseTx creates an unsigned oracle response transaction for the
+// nodes of the given designation.
+func (o *Oracle) createResponseTx(d *designation, gasForResponse int64, vub uint32, resp *transaction.OracleResponse) (*transaction.Transaction, error) {
 	tx := transaction.New(o.oracleResponse, 0)
 	tx.Nonce = uint32(resp.ID)
 	tx.ValidUntilBlock = vub
@@ -111,7 +124,7 @@ func (o *Oracle) CreateResponseTx(gasForResponse int64, vub uint32, resp *transa
 		Value: resp,
 	}}
 
-	oracleSignContract := o.getOracleSignContract()
+	oracleSignContract := d.signContract
 	tx.Signers = []transaction.Signer{
 		{
 			Account: o.oracleHash,
@@ -166,9 +179,27 @@ func (o *Oracle) testVerify(tx *transaction.Transaction) (int64, bool, error) {
 	if err != nil {
 		return 0, false, fmt.Errorf("failed to create test VM: %w", err)
 	}
+	// Native contract script depends on the set of enabled hardforks, so it's
+	// taken for the block the transaction is to be included into.
+	var md *interop.HFSpecificContractMD
+	for _, c := range ic.Natives {
+		if c.Metadata().Hash.Equals(o.oracleHash) {
+			md = c.Metadata().HFSpecificContractMD(ic.IsHardforkEnabled)
+			break
+		}
+	}
+	if md == nil {
+		ic.Finalize()
+		return 0, false, errors.New("native Oracle contract is not found")
+	}
+	verify := md.Manifest.ABI.GetMethod(manifest.MethodVerify, -1)
+	if verify == nil {
+		ic.Finalize()
+		return 0, false, fmt.Errorf("%s method of native Oracle contract is not found", manifest.MethodVerify)
+	}
 	ic.VM.GasLimit = o.Chain.GetMaxVerificationGAS()
-	ic.VM.LoadScriptWithHash(o.oracleScript, o.oracleHash, callflag.ReadOnly)
-	ic.VM.Context().Jump(o.verifyOffset)
+	ic.VM.LoadScriptWithHash(md.NEF.Script, o.oracleHash, callflag.ReadOnly)
+	ic.VM.Context().Jump(verify.Offset)
 
 	ok := isVerifyOk(ic)
 	return ic.VM.GasConsumed(), ok, nil
@@ -25,6 +25,9 @@ type (
 		time time.Time
 		// request is an oracle request.
 		request *state.OracleRequest
+		// desig is the oracle nodes designation the response is created
+		// for, it's set on the first request processing.
+		desig *designation
 		// tx is an oracle response transaction.
 		tx *transaction.Transaction
 		// sigs contains a signature from every oracle node.
@@ -82,8 +85,13 @@ func (t *incompleteTx) addResponse(pub *keys.PublicKey, sig []byte, isBackup boo
 }
 
 // finalize checks if either main or backup tx has sufficient number of signatures and returns
-// tx and bool value indicating if it is ready to be broadcasted.
-func (t *incompleteTx) finalize(oracleNodes keys.PublicKeys, backupOnly bool) (*transaction.Transaction, bool) {
+// tx and bool value indicating if it is ready to be broadcasted. Signatures are
+// checked against the nodes of the designation tx was created for.
+func (t *incompleteTx) finalize(backupOnly bool) (*transaction.Transaction, bool) {
+	if t.desig == nil {
+		return nil, false
+	}
+	oracleNodes := t.desig.nodes
 	if !backupOnly && finalizeTx(oracleNodes, t.tx, t.sigs) {
 		return t.tx, true
 	}
@@ -0,0 +1,110 @@
+package rpcsrv
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+)
+
+// maxAdminNonceLen is the maximum length of admin request nonce.
+const maxAdminNonceLen = 64
+
+// adminAuth verifies signed admin requests. It keeps nonces of the requests
+// accepted until their timestamps become outdated to prevent replays.
+type adminAuth struct {
+	keys keys.PublicKeys
+	ttl  time.Duration
+	now  func() time.Time
+
+	lock   sync.Mutex
+	nonces map[string]time.Time
+}
+
+// newAdminAuth returns adminAuth accepting requests signed by the given keys
+// with timestamps within ttl from the current time.
+func newAdminAuth(pubs keys.PublicKeys, ttl time.Duration) *adminAuth {
+	return &adminAuth{
+		keys:   pubs,
+		ttl:    ttl,
+		now:    time.Now,
+		nonces: make(map[string]time.Time),
+	}
+}
+
+// verify checks admin request signature headers against the request body. It
+// returns false if there are no signature headers and an error if they're
+// present, but the signature is not valid.
+func (a *adminAuth) verify(h http.Header, body []byte) (bool, *neorpc.Error) {
+	var (
+		keyStr = h.Get(neorpc.AdminKeyHeader)
+		tsStr  = h.Get(neorpc.AdminTimestampHeader)
+		nonce  = h.Get(neorpc.AdminNonceHeader)
+		sigStr = h.Get(neorpc.AdminSignatureHeader)
+	)
+	if keyStr == "" && tsStr == "" && nonce == "" && sigStr == "" {
+		return false, nil
+	}
+	pub, err := keys.NewPublicKeyFromString(keyStr)
+	if err != nil {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, fmt.Sprintf("invalid key: %s", err))
+	}
+	if !a.keys.Contains(pub) {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, "unknown key")
+	}
+	ts, err := strconv.ParseInt(tsStr, 10, 64)
+	if err != nil {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, fmt.Sprintf("invalid timestamp: %s", err))
+	}
+	if len(nonce) == 0 || len(nonce) > maxAdminNonceLen {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, "invalid nonce")
+	}
+	sig, err := base64.StdEncoding.DecodeString(sigStr)
+	if err != nil {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, fmt.Sprintf("invalid signature: %s", err))
+	}
+	var (
+		now = a.now()
+		t   = time.UnixMilli(ts)
+	)
+	if t.Before(now.Add(-a.ttl)) || t.After(now.Add(a.ttl)) {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized,
+			fmt.Sprintf("request time %s differs from the node time %s by more than %s",
+				t.UTC().Format(time.RFC3339), now.UTC().Format(time.RFC3339), a.ttl))
+	}
+	hash := neorpc.AdminRequestHash(ts, nonce, body)
+	if !pub.Verify(sig, hash.BytesBE()) {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, "invalid signature")
+	}
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	for n, exp := range a.nonces {
+		if now.After(exp) {
+			delete(a.nonces, n)
+		}
+	}
+	var id = string(pub.Bytes()) + "/" + nonce
+	if _, ok := a.nonces[id]; ok {
+		return false, neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, "replayed request")
+	}
+	a.nonces[id] = t.Add(a.ttl)
+	return true, nil
+}
+
+// checkAdmin checks whether an admin method can be called. admin is true for
+// properly signed requests (and for local ones).
+func (s *Server) checkAdmin(admin bool) *neorpc.Error {
+	if !s.config.EnableAdminMethods {
+		return neorpc.NewMethodNotFoundError("admin methods are disabled")
+	}
+	if len(s.config.AdminPublicKeys) != 0 && !admin {
+		return neorpc.WrapErrorWithData(neorpc.ErrUnauthorized, "admin request must be signed")
+	}
+	return nil
+}
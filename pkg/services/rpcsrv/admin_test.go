@@ -0,0 +1,114 @@
+package rpcsrv
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/stretchr/testify/require"
+)
+
+func signedAdminHeader(key *keys.PrivateKey, ts time.Time, nonce string, body []byte) http.Header {
+	var (
+		h    = make(http.Header)
+		msec = ts.UnixMilli()
+	)
+	h.Set(neorpc.AdminKeyHeader, hex.EncodeToString(key.PublicKey().Bytes()))
+	h.Set(neorpc.AdminTimestampHeader, strconv.FormatInt(msec, 10))
+	h.Set(neorpc.AdminNonceHeader, nonce)
+	h.Set(neorpc.AdminSignatureHeader, base64.StdEncoding.EncodeToString(
+		key.SignHash(neorpc.AdminRequestHash(msec, nonce, body))))
+	return h
+}
+
+func TestAdminAuth(t *testing.T) {
+	key, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	var (
+		now  = time.Unix(1700000000, 0)
+		body = []byte(`{"jsonrpc":"2.0","id":1,"method":"resetnativestats","params":[]}`)
+		a    = newAdminAuth(keys.PublicKeys{key.PublicKey()}, time.Minute)
+	)
+	a.now = func() time.Time { return now }
+
+	checkErr := func(t *testing.T, h http.Header, b []byte, msg string) {
+		ok, err := a.verify(h, b)
+		require.False(t, ok)
+		require.NotNil(t, err)
+		require.Equal(t, int64(neorpc.ErrUnauthorizedCode), err.Code)
+		require.Contains(t, err.Data, msg)
+	}
+
+	ok, rpcErr := a.verify(make(http.Header), body)
+	require.False(t, ok)
+	require.Nil(t, rpcErr)
+
+	ok, rpcErr = a.verify(signedAdminHeader(key, now, "1", body), body)
+	require.True(t, ok)
+	require.Nil(t, rpcErr)
+
+	t.Run("replay", func(t *testing.T) {
+		checkErr(t, signedAdminHeader(key, now, "1", body), body, "replayed")
+		// Nonce is per-key.
+		a.keys = append(a.keys, other.PublicKey())
+		defer func() { a.keys = a.keys[:1] }()
+		ok, rpcErr := a.verify(signedAdminHeader(other, now, "1", body), body)
+		require.True(t, ok)
+		require.Nil(t, rpcErr)
+	})
+	t.Run("clock skew", func(t *testing.T) {
+		checkErr(t, signedAdminHeader(key, now.Add(-time.Minute-time.Millisecond), "2", body), body, "differs from the node time")
+		checkErr(t, signedAdminHeader(key, now.Add(time.Minute+time.Millisecond), "2", body), body, "differs from the node time")
+		ok, rpcErr := a.verify(signedAdminHeader(key, now.Add(-59*time.Second), "2", body), body)
+		require.True(t, ok)
+		require.Nil(t, rpcErr)
+		ok, rpcErr = a.verify(signedAdminHeader(key, now.Add(59*time.Second), "3", body), body)
+		require.True(t, ok)
+		require.Nil(t, rpcErr)
+	})
+	t.Run("nonce expiration", func(t *testing.T) {
+		start := now
+		defer func() { now = start }()
+		now = start.Add(30 * time.Second)
+		// Nonce is kept while the request timestamp is valid.
+		checkErr(t, signedAdminHeader(key, start.Add(59*time.Second), "3", body), body, "replayed")
+		now = start.Add(2 * time.Minute)
+		ok, rpcErr := a.verify(signedAdminHeader(key, now, "3", body), body)
+		require.True(t, ok)
+		require.Nil(t, rpcErr)
+		require.Equal(t, 1, len(a.nonces))
+	})
+	t.Run("invalid", func(t *testing.T) {
+		checkErr(t, signedAdminHeader(other, now, "4", body), body, "unknown key")
+		checkErr(t, signedAdminHeader(key, now, "5", body), []byte(`{}`), "invalid signature")
+		checkErr(t, signedAdminHeader(key, now, "", body), body, "invalid nonce")
+
+		h := signedAdminHeader(key, now, "6", body)
+		h.Set(neorpc.AdminKeyHeader, "0102")
+		checkErr(t, h, body, "invalid key")
+
+		h = signedAdminHeader(key, now, "6", body)
+		h.Set(neorpc.AdminTimestampHeader, "now")
+		checkErr(t, h, body, "invalid timestamp")
+
+		h = signedAdminHeader(key, now, "6", body)
+		h.Set(neorpc.AdminSignatureHeader, "!")
+		checkErr(t, h, body, "invalid signature")
+
+		h = signedAdminHeader(key, now, "6", body)
+		h.Set(neorpc.AdminNonceHeader, "7")
+		checkErr(t, h, body, "invalid signature")
+
+		h = signedAdminHeader(key, now, "6", body)
+		h.Del(neorpc.AdminSignatureHeader)
+		checkErr(t, h, body, "invalid signature")
+	})
+}
@@ -10,7 +10,10 @@ import (
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -24,10 +27,12 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core"
 	"github.com/nspcc-dev/neo-go/pkg/core/block"
 	"github.com/nspcc-dev/neo-go/pkg/core/fee"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/native"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/nativenames"
 	"github.com/nspcc-dev/neo-go/pkg/core/native/noderoles"
 	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	corestate "github.com/nspcc-dev/neo-go/pkg/core/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -37,6 +42,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient"
+	"github.com/nspcc-dev/neo-go/pkg/rpcclient/accountinfo"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/actor"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/gas"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/invoker"
@@ -50,11 +56,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/oracle"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/policy"
 	"github.com/nspcc-dev/neo-go/pkg/rpcclient/rolemgmt"
+	notarysrv "github.com/nspcc-dev/neo-go/pkg/services/notary"
+	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
@@ -333,6 +342,116 @@ func TestClientManagementContract(t *testing.T) {
 	require.Equal(t, 1, len(appLog.Executions[0].Events))
 }
 
+func TestClient_GetContracts(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): 0,
+		}
+	})
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	act, err := actor.New(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.MultisigScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.MultisigAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.MultisigVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+	man := management.New(act)
+	manReader := management.NewReader(invoker.New(c, nil))
+
+	// A dozen of contracts, half of them are NEP-17.
+	gasState := chain.GetContractState(gas.Hash)
+	var txs []*transaction.Transaction
+	for i := 0; i < 12; i++ {
+		m := gasState.Manifest
+		m.Name = fmt.Sprintf("Listed%d", i)
+		if i%2 != 0 {
+			m.SupportedStandards = nil
+		}
+		tx, err := man.DeployUnsigned(&gasState.NEF, &m, nil)
+		require.NoError(t, err)
+		tx.Scripts[0].InvocationScript = testchain.Sign(tx)
+		txs = append(txs, tx)
+	}
+	bl := testchain.NewBlock(t, chain, 1, 0, txs...)
+	_, err = c.SubmitBlock(*bl)
+	require.NoError(t, err)
+
+	all, err := c.GetContracts(nil, nil, 0)
+	require.NoError(t, err)
+	require.False(t, all.Truncated)
+	require.Nil(t, all.Next)
+	require.Equal(t, len(chain.GetNatives())+12, len(all.Contracts))
+	for i := 1; i < len(all.Contracts); i++ {
+		require.Equal(t, -1, bytes.Compare(all.Contracts[i-1].Hash.BytesBE(), all.Contracts[i].Hash.BytesBE()))
+	}
+	list, err := manReader.List(context.Background(), neorpc.ContractFilter{})
+	require.NoError(t, err)
+	require.Equal(t, all.Contracts, list)
+
+	t.Run("paging", func(t *testing.T) {
+		var (
+			res   []state.Contract
+			start *util.Uint160
+		)
+		for {
+			page, err := c.GetContracts(nil, start, 5)
+			require.NoError(t, err)
+			res = append(res, page.Contracts...)
+			if !page.Truncated {
+				require.Nil(t, page.Next)
+				break
+			}
+			require.Equal(t, 5, len(page.Contracts))
+			start = page.Next
+		}
+		require.Equal(t, all.Contracts, res)
+	})
+	t.Run("filters", func(t *testing.T) {
+		deployer := testchain.MultisigScriptHash()
+		check := func(t *testing.T, filter neorpc.ContractFilter, expected int) {
+			res, err := c.GetContracts(&filter, nil, 0)
+			require.NoError(t, err)
+			require.Equal(t, expected, len(res.Contracts))
+			for _, cs := range res.Contracts {
+				require.True(t, filter.Matches(&cs))
+			}
+			list, err := manReader.List(context.Background(), filter)
+			require.NoError(t, err)
+			require.Equal(t, res.Contracts, list)
+		}
+		check(t, neorpc.ContractFilter{Name: "Listed"}, 12)
+		check(t, neorpc.ContractFilter{Name: "Listed1"}, 3) // Listed1, Listed10, Listed11.
+		check(t, neorpc.ContractFilter{Name: "Listed", Standard: manifest.NEP17StandardName}, 6)
+		check(t, neorpc.ContractFilter{Deployer: &deployer}, 12)
+		check(t, neorpc.ContractFilter{Deployer: &util.Uint160{1, 2, 3}}, 0)
+		check(t, neorpc.ContractFilter{Name: nativenames.Gas}, 1)
+
+		page, err := c.GetContracts(&neorpc.ContractFilter{Name: "Listed"}, nil, 8)
+		require.NoError(t, err)
+		require.True(t, page.Truncated)
+		require.Equal(t, 8, len(page.Contracts))
+		page, err = c.GetContracts(&neorpc.ContractFilter{Name: "Listed"}, page.Next, 8)
+		require.NoError(t, err)
+		require.False(t, page.Truncated)
+		require.Equal(t, 4, len(page.Contracts))
+	})
+}
+
 func TestClientNEOContract(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -726,6 +845,76 @@ func TestCalculateNetworkFee_Base(t *testing.T) {
 	})
 }
 
+func TestCalculateNetworkFee_Sponsor(t *testing.T) {
+	const extensionsHeight = 3
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.Hardforks = map[string]uint32{
+			config.HFAspidochelone.String():   0,
+			config.HFBasilisk.String():        0,
+			config.HFCockatrice.String():      0,
+			config.HFNeoGoExtensions.String(): extensionsHeight,
+		}
+	})
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	// Sender has no GAS, multisignature sponsor pays.
+	acc0, err := wallet.NewAccount()
+	require.NoError(t, err)
+	sponsor := testchain.MultisigScriptHash()
+	newTx := func() *transaction.Transaction {
+		tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.Signers = []transaction.Signer{
+			{
+				Account: acc0.ScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			},
+			{
+				Account: sponsor,
+				Scopes:  transaction.None,
+			},
+		}
+		tx.Attributes = []transaction.Attribute{{
+			Type:  transaction.SponsorT,
+			Value: &transaction.Sponsor{Account: sponsor},
+		}}
+		tx.Scripts = []transaction.Witness{
+			{VerificationScript: acc0.GetVerificationScript()},
+			{VerificationScript: testchain.MultisigVerificationScript()},
+		}
+		return tx
+	}
+
+	_, err = c.CalculateNetworkFee(newTx())
+	require.ErrorIs(t, err, neorpc.ErrInvalidAttribute)
+
+	for chain.BlockHeight()+1 < extensionsHeight {
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	}
+	tx := newTx()
+	actual, err := c.CalculateNetworkFee(tx)
+	require.NoError(t, err)
+	tx.NetworkFee = actual
+
+	tx.Scripts = nil
+	require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+	tx.Scripts = append(tx.Scripts, transaction.Witness{
+		InvocationScript:   testchain.Sign(tx),
+		VerificationScript: testchain.MultisigVerificationScript(),
+	})
+	cFee, _ := fee.Calculate(chain.GetBaseExecFee(), acc0.Contract.Script)
+	cFeeM, _ := fee.Calculate(chain.GetBaseExecFee(), testchain.MultisigVerificationScript())
+	require.Equal(t, int64(io.GetVarSize(tx))*chain.FeePerByte()+cFee+cFeeM, actual)
+	require.Equal(t, 0, chain.GetUtilityTokenBalance(acc0.ScriptHash()).Sign())
+	require.NoError(t, chain.VerifyTx(tx))
+
+	tx.NetworkFee--
+	require.Error(t, chain.VerifyTx(tx))
+}
+
 func TestCalculateNetworkFee(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 	const extraFee = 10
@@ -853,6 +1042,147 @@ func TestCalculateNetworkFee(t *testing.T) {
 	})
 }
 
+func TestEstimateFees(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	priv0 := testchain.PrivateKeyByID(0)
+	acc0 := wallet.NewAccountFromPrivateKey(priv0)
+	script, err := smartcontract.CreateCallWithAssertScript(chain.UtilityTokenHash(), "transfer",
+		testchain.MultisigScriptHash(), priv0.GetScriptHash(), 1, nil)
+	require.NoError(t, err)
+
+	// check compares estimation results with invokescript and
+	// calculatenetworkfee ones for the same transaction.
+	check := func(t *testing.T, signers []transaction.Signer, witnesses []transaction.Witness, attrs []transaction.Attribute) (*result.FeeEstimation, *transaction.Transaction) {
+		est, err := c.EstimateFees(script, signers, witnesses, attrs)
+		require.NoError(t, err)
+		require.Equal(t, "HALT", est.Invocation.State)
+
+		inv, err := c.InvokeScript(script, signers)
+		require.NoError(t, err)
+		require.Equal(t, inv.GasConsumed, est.SystemFee)
+
+		tx := transaction.New(script, est.SystemFee)
+		tx.ValidUntilBlock = chain.BlockHeight() + 10
+		tx.Signers = signers
+		tx.Attributes = attrs
+		tx.Scripts = witnesses
+		netFee, err := c.CalculateNetworkFee(tx)
+		require.NoError(t, err)
+		require.Equal(t, netFee, est.NetworkFee)
+		require.Equal(t, est.SystemFee+est.NetworkFee, est.TotalFee)
+		require.Equal(t, int64(est.Size)*chain.FeePerByte(), est.SizeFee)
+
+		var verFee int64
+		size := est.BaseSize
+		require.Equal(t, len(signers), len(est.Witnesses))
+		for i, w := range est.Witnesses {
+			require.Equal(t, signers[i].Account, w.Account)
+			verFee += w.VerificationFee
+			size += w.InvocationSize + w.VerificationSize
+		}
+		require.Equal(t, est.Size, size)
+		require.Equal(t, est.NetworkFee, verFee+est.SizeFee+est.AttributesFee)
+		tx.NetworkFee = est.NetworkFee
+		return est, tx
+	}
+
+	t.Run("multisig", func(t *testing.T) {
+		signers := []transaction.Signer{
+			{Account: testchain.MultisigScriptHash(), Scopes: transaction.CalledByEntry},
+			{Account: acc0.ScriptHash(), Scopes: transaction.None},
+		}
+		est, tx := check(t, signers, []transaction.Witness{
+			{VerificationScript: testchain.MultisigVerificationScript()},
+			{VerificationScript: acc0.GetVerificationScript()},
+		}, nil)
+		m, _, ok := vm.ParseMultiSigContract(testchain.MultisigVerificationScript())
+		require.True(t, ok)
+		require.Equal(t, result.WitnessMultisig, est.Witnesses[0].Type)
+		require.Equal(t, m, est.Witnesses[0].Signatures)
+		require.Equal(t, result.WitnessSignature, est.Witnesses[1].Type)
+		require.Equal(t, 1, est.Witnesses[1].Signatures)
+		require.Equal(t, int64(0), est.AttributesFee)
+
+		tx.Scripts = []transaction.Witness{{
+			InvocationScript:   testchain.Sign(tx),
+			VerificationScript: testchain.MultisigVerificationScript(),
+		}}
+		require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+		require.Equal(t, est.Size, io.GetVarSize(tx))
+		require.NoError(t, chain.VerifyTx(tx))
+		tx.NetworkFee--
+		require.Error(t, chain.VerifyTx(tx))
+	})
+	t.Run("contract verify", func(t *testing.T) {
+		h, err := util.Uint160DecodeStringLE(verifyContractHash)
+		require.NoError(t, err)
+		signers := []transaction.Signer{
+			{Account: acc0.ScriptHash(), Scopes: transaction.None}, // Sender is checked by the contract.
+			{Account: testchain.MultisigScriptHash(), Scopes: transaction.CalledByEntry},
+			{Account: h, Scopes: transaction.None},
+		}
+		est, tx := check(t, signers, []transaction.Witness{
+			{VerificationScript: acc0.GetVerificationScript()},
+			{VerificationScript: testchain.MultisigVerificationScript()},
+			{},
+		}, nil)
+		require.Equal(t, result.WitnessContract, est.Witnesses[2].Type)
+		require.Equal(t, 0, est.Witnesses[2].Signatures)
+		require.True(t, est.Witnesses[2].VerificationFee > 0)
+
+		tx.Scripts = nil
+		require.NoError(t, acc0.SignTx(testchain.Network(), tx))
+		tx.Scripts = append(tx.Scripts, transaction.Witness{
+			InvocationScript:   testchain.Sign(tx),
+			VerificationScript: testchain.MultisigVerificationScript(),
+		}, transaction.Witness{})
+		require.NoError(t, chain.VerifyTx(tx))
+	})
+	t.Run("notary-assisted", func(t *testing.T) {
+		signers := []transaction.Signer{
+			{Account: notary.Hash, Scopes: transaction.None},
+			{Account: testchain.MultisigScriptHash(), Scopes: transaction.CalledByEntry},
+		}
+		attrs := []transaction.Attribute{{Type: transaction.NotaryAssistedT, Value: &transaction.NotaryAssisted{NKeys: 1}}}
+		est, _ := check(t, signers, []transaction.Witness{
+			{},
+			{VerificationScript: testchain.MultisigVerificationScript()},
+		}, attrs)
+		require.Equal(t, result.WitnessContract, est.Witnesses[0].Type)
+		require.Equal(t, 2*chain.GetNotaryServiceFeePerKey(), est.AttributesFee)
+	})
+	t.Run("errors", func(t *testing.T) {
+		_, err := c.EstimateFees(script, []transaction.Signer{{Account: util.Uint160{1, 2, 3}}}, []transaction.Witness{{}}, nil)
+		require.ErrorIs(t, err, neorpc.ErrInvalidVerificationFunction)
+		_, err = c.EstimateFees(script, nil, nil, nil)
+		require.ErrorIs(t, err, neorpc.ErrInvalidParams)
+	})
+	t.Run("actor", func(t *testing.T) {
+		acc := wallet.NewAccountFromPrivateKey(priv0)
+		a, err := actor.NewTuned(c, []actor.SignerAccount{{
+			Signer:  transaction.Signer{Account: acc.ScriptHash(), Scopes: transaction.CalledByEntry},
+			Account: acc,
+		}}, actor.Options{UseFeeEstimation: true})
+		require.NoError(t, err)
+		tx, err := a.MakeCall(chain.UtilityTokenHash(), "transfer", acc.ScriptHash(), acc.ScriptHash(), 1, nil)
+		require.NoError(t, err)
+		require.NoError(t, chain.VerifyTx(tx))
+
+		plain, err := actor.NewSimple(c, acc)
+		require.NoError(t, err)
+		expected, err := plain.MakeCall(chain.UtilityTokenHash(), "transfer", acc.ScriptHash(), acc.ScriptHash(), 1, nil)
+		require.NoError(t, err)
+		require.Equal(t, expected.SystemFee, tx.SystemFee)
+		require.Equal(t, expected.NetworkFee, tx.NetworkFee)
+	})
+}
+
 func TestNotaryActor(t *testing.T) {
 	_, _, httpSrv := initServerWithInMemoryChainAndServices(t, false, true, false)
 
@@ -1207,6 +1537,32 @@ func TestClient_GetNativeContracts(t *testing.T) {
 	require.Equal(t, chain.GetNatives(), cs)
 }
 
+func TestClient_Compression(t *testing.T) {
+	chain, rpcSrv, _ := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.EnableCompression = true
+	})
+	var encodings = make(chan string, 10)
+	httpSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rpcSrv.handleHTTPRequest(w, r)
+		encodings <- w.Header().Get("Content-Encoding")
+	}))
+	t.Cleanup(httpSrv.Close)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	count, err := c.GetBlockCount()
+	require.NoError(t, err)
+	require.Equal(t, chain.BlockHeight()+1, count)
+	require.Empty(t, <-encodings) // Small response.
+
+	cs, err := c.GetNativeContracts()
+	require.NoError(t, err)
+	require.Equal(t, chain.GetNatives(), cs)
+	require.Equal(t, "gzip", <-encodings)
+}
+
 func TestClient_NEP11_ND(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1608,6 +1964,116 @@ func TestClient_States(t *testing.T) {
 	})
 }
 
+func TestClient_VerifiableState(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	policy, err := chain.GetNativeContractScriptHash(nativenames.Policy)
+	require.NoError(t, err)
+	key := []byte{19} // storagePrice key in policy contract.
+
+	_, err = c.GetVerifiableState(policy, key, nil)
+	require.ErrorIs(t, err, neorpc.ErrUnknownStateRoot) // Nothing is validated yet.
+
+	act, err := actor.New(c, []actor.SignerAccount{{
+		Signer: transaction.Signer{
+			Account: testchain.CommitteeScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		},
+		Account: &wallet.Account{
+			Address: testchain.CommitteeAddress(),
+			Contract: &wallet.Contract{
+				Script: testchain.CommitteeVerificationScript(),
+			},
+		},
+	}})
+	require.NoError(t, err)
+	var (
+		pubs = make(keys.PublicKeys, 4)
+		pks  = make(map[string]*keys.PrivateKey)
+	)
+	for i := range pubs {
+		pubs[i] = testchain.PrivateKeyByID(i).PublicKey()
+		pks[string(pubs[i].Bytes())] = testchain.PrivateKeyByID(i)
+	}
+	tx, err := rolemgmt.New(act).DesignateAsRoleUnsigned(noderoles.StateValidator, pubs)
+	require.NoError(t, err)
+	tx.Scripts[0].InvocationScript = testchain.SignCommittee(tx)
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+
+	h := chain.BlockHeight()
+	r, err := chain.GetStateModule().GetStateRoot(h)
+	require.NoError(t, err)
+	verif, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs.Copy())
+	require.NoError(t, err)
+	sorted := pubs.Copy()
+	sort.Sort(sorted)
+	w := io.NewBufBinWriter()
+	for _, pub := range sorted[:smartcontract.GetDefaultHonestNodeCount(len(sorted))] {
+		emit.Bytes(w.BinWriter, pks[string(pub.Bytes())].SignHashable(uint32(testchain.Network()), r))
+	}
+	r.Witness = []transaction.Witness{{
+		InvocationScript:   w.Bytes(),
+		VerificationScript: verif,
+	}}
+	require.NoError(t, chain.GetStateModule().(*corestate.Module).AddStateRoot(r))
+
+	t.Run("latest validated", func(t *testing.T) {
+		b, err := c.GetVerifiableState(policy, key, nil)
+		require.NoError(t, err)
+		require.Equal(t, h, b.Root.Index)
+		require.Equal(t, r.Root, b.Root.Root)
+		require.Equal(t, big.NewInt(native.DefaultStoragePrice), bigint.FromBytes(b.Value))
+		require.Equal(t, append([]byte{0xf9, 0xff, 0xff, 0xff}, key...), b.Proof.Key) // Policy contract ID is -7.
+		require.NoError(t, stateroot.VerifyBundle(b, testchain.Network(), pubs))
+
+		value, err := c.VerifyProof(b.Root.Root, b.Proof)
+		require.NoError(t, err)
+		require.Equal(t, b.Value, value)
+	})
+	t.Run("by height", func(t *testing.T) {
+		b, err := c.GetVerifiableState(policy, key, &h)
+		require.NoError(t, err)
+		require.NoError(t, stateroot.VerifyBundle(b, testchain.Network(), pubs))
+
+		prev := h - 1
+		_, err = c.GetVerifiableState(policy, key, &prev)
+		require.ErrorIs(t, err, neorpc.ErrUnknownStateRoot) // Not validated.
+		future := h + 100
+		_, err = c.GetVerifiableState(policy, key, &future)
+		require.ErrorIs(t, err, neorpc.ErrUnknownStateRoot)
+	})
+	t.Run("missing item", func(t *testing.T) {
+		_, err := c.GetVerifiableState(policy, []byte{0xff, 0xff}, nil)
+		require.ErrorIs(t, err, neorpc.ErrUnknownStorageItem)
+		_, err = c.GetVerifiableState(util.Uint160{1, 2, 3}, key, nil)
+		require.ErrorIs(t, err, neorpc.ErrUnknownContract)
+	})
+	t.Run("bad bundle", func(t *testing.T) {
+		b, err := c.GetVerifiableState(policy, key, nil)
+		require.NoError(t, err)
+		b.Proof.Proof[0][len(b.Proof.Proof[0])-1] ^= 0xff
+		require.Error(t, stateroot.VerifyBundle(b, testchain.Network(), pubs))
+
+		b, err = c.GetVerifiableState(policy, key, nil)
+		require.NoError(t, err)
+		old, err := chain.GetStateModule().GetStateRoot(h - 1)
+		require.NoError(t, err)
+		b.Root.Root = old.Root // Wrong root.
+		require.Error(t, stateroot.VerifyBundle(b, testchain.Network(), pubs))
+
+		b, err = c.GetVerifiableState(policy, key, nil)
+		require.NoError(t, err)
+		b.Root.Witness[0].InvocationScript = b.Root.Witness[0].InvocationScript[2+keys.SignatureLen:] // Insufficient signatures.
+		require.Error(t, stateroot.VerifyBundle(b, testchain.Network(), pubs))
+	})
+}
+
 func TestClientOracle(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -1653,6 +2119,46 @@ func TestClientOracle(t *testing.T) {
 	require.Equal(t, newPrice, actual)
 }
 
+func TestClient_IteratorSessionMaxLifetime(t *testing.T) {
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.RPC.SessionExpirationTime = 1
+		cfg.ApplicationConfiguration.RPC.SessionMaxLifetime = 2
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	storageHash, err := util.Uint160DecodeStringLE(storageContractHash)
+	require.NoError(t, err)
+	res, err := c.InvokeFunction(storageHash, "iterateOverValues", []smartcontract.Parameter{}, nil)
+	require.NoError(t, err)
+	start := time.Now()
+	require.NotEmpty(t, res.Session)
+	iterator, ok := res.Stack[0].Value().(result.Iterator)
+	require.True(t, ok)
+
+	// Active session is still terminated after SessionMaxLifetime.
+	for {
+		_, err = c.TraverseIterator(res.Session, *iterator.ID, 1)
+		if err != nil {
+			break
+		}
+		require.Less(t, time.Since(start), 3*time.Second)
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.ErrorIs(t, err, neorpc.ErrUnknownSession)
+	require.GreaterOrEqual(t, time.Since(start), 2*time.Second)
+	require.Eventually(t, func() bool {
+		rpcSrv.sessionsLock.Lock()
+		defer rpcSrv.sessionsLock.Unlock()
+		return len(rpcSrv.sessions) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
 func TestClient_Iterator_SessionConfigVariations(t *testing.T) {
 	var expected [][]byte
 	storageHash, err := util.Uint160DecodeStringLE(storageContractHash)
@@ -2290,8 +2796,9 @@ func TestClient_FindStorage(t *testing.T) {
 				Value: []byte("v2"),
 			},
 		},
-		Next:      2,
-		Truncated: true,
+		Next:         2,
+		Truncated:    true,
+		Continuation: "AWFhMTA=",
 	}
 
 	// By hash.
@@ -2323,6 +2830,23 @@ func TestClient_FindStorage(t *testing.T) {
 		Truncated: false,
 	}, actual)
 
+	// Continuation token.
+	actual, err = c.FindStorageByHashContinue(h, prefix, expected.Continuation)
+	require.NoError(t, err)
+	require.Equal(t, result.FindStorage{
+		Results: []result.KeyValue{
+			{
+				Key:   []byte("aa50"),
+				Value: []byte("v3"),
+			},
+		},
+		Next:      3,
+		Truncated: false,
+	}, actual)
+	actual, err = c.FindStorageByIDContinue(1, prefix, expected.Continuation)
+	require.NoError(t, err)
+	require.Equal(t, 3, actual.Next)
+
 	// Missing item.
 	actual, err = c.FindStorageByHash(h, []byte("unknown prefix"), nil)
 	require.NoError(t, err)
@@ -2357,8 +2881,9 @@ func TestClient_FindStorageHistoric(t *testing.T) {
 				Value: []byte("v3"),
 			},
 		},
-		Next:      2,
-		Truncated: true,
+		Next:         2,
+		Truncated:    true,
+		Continuation: "AWFhNTA=",
 	}
 
 	// By hash.
@@ -2390,6 +2915,28 @@ func TestClient_FindStorageHistoric(t *testing.T) {
 		Truncated: false,
 	}, actual)
 
+	// Continuation token.
+	actual, err = c.FindStorageByHashHistoricContinue(root, h, prefix, expected.Continuation)
+	require.NoError(t, err)
+	require.Equal(t, result.FindStorage{
+		Results: []result.KeyValue{
+			{
+				Key:   []byte("aa"),
+				Value: []byte("v1"),
+			},
+		},
+		Next:      3,
+		Truncated: false,
+	}, actual)
+	actual, err = c.FindStorageByIDHistoricContinue(root, 1, prefix, expected.Continuation)
+	require.NoError(t, err)
+	require.Equal(t, 3, actual.Next)
+
+	// By block index.
+	actual, err = c.FindStorageByHashAtHeight(20, h, prefix, nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
 	// Missing item.
 	earlyRoot, err := chain.GetStateRoot(15) // there's no `aa10` value in Rubles contract by the moment of block #15
 	require.NoError(t, err)
@@ -2402,6 +2949,53 @@ func TestClient_FindStorageHistoric(t *testing.T) {
 	}, actual)
 }
 
+func TestClient_FindStorageHistoricPaging(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	h, err := util.Uint160DecodeStringLE(testContractHash)
+	require.NoError(t, err)
+	for _, height := range []uint32{15, 20, chain.BlockHeight()} {
+		sr, err := chain.GetStateRoot(height)
+		require.NoError(t, err)
+
+		var (
+			all  []result.KeyValue
+			page result.FindStorage
+		)
+		page, err = c.FindStorageByHashHistoric(sr.Root, h, nil, nil)
+		require.NoError(t, err)
+		byIndex, err := c.FindStorageByHashAtHeight(height, h, nil, nil)
+		require.NoError(t, err)
+		require.Equal(t, page, byIndex)
+		all = append(all, page.Results...)
+		for page.Truncated {
+			require.NotEmpty(t, page.Continuation)
+			page, err = c.FindStorageByHashHistoricContinue(sr.Root, h, nil, page.Continuation)
+			require.NoError(t, err)
+			all = append(all, page.Results...)
+		}
+		require.Empty(t, page.Continuation)
+
+		var expected []result.KeyValue
+		start := 0
+		for {
+			page, err = c.FindStorageByHashHistoric(sr.Root, h, nil, &start)
+			require.NoError(t, err)
+			expected = append(expected, page.Results...)
+			if !page.Truncated {
+				break
+			}
+			start = page.Next
+		}
+		require.Equal(t, expected, all, "height %d", height)
+	}
+}
+
 func TestClient_GetStorageHistoric(t *testing.T) {
 	chain, _, httpSrv := initServerWithInMemoryChain(t)
 
@@ -2449,3 +3043,515 @@ func TestClient_GetVersion_Hardforks(t *testing.T) {
 	}
 	require.InDeltaMapValues(t, expected, v.Protocol.Hardforks, 0)
 }
+
+func TestClient_ContractStorageUsage(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithInMemoryChain(t)
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		_, err = c.GetContractStorageUsageByID(-6) // GAS.
+		require.ErrorIs(t, err, neorpc.ErrStorageUsageUnavailable)
+		_, err = c.ListContractStorageUsage(10)
+		require.ErrorIs(t, err, neorpc.ErrStorageUsageUnavailable)
+	})
+
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.TrackStorageUsage = true
+	})
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	gasHash, err := chain.GetNativeContractScriptHash(nativenames.Gas)
+	require.NoError(t, err)
+	gasID := chain.GetContractState(gasHash).ID
+	u, err := chain.GetContractStorageUsage(gasID)
+	require.NoError(t, err)
+	expected := &result.ContractStorageUsage{
+		ID:    gasID,
+		Hash:  gasHash,
+		Items: u.Items,
+		Size:  u.Size,
+	}
+	require.NotZero(t, expected.Items)
+
+	actual, err := c.GetContractStorageUsageByID(gasID)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+	actual, err = c.GetContractStorageUsageByHash(gasHash)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	_, err = c.GetContractStorageUsageByHash(util.Uint160{1, 2, 3})
+	require.ErrorIs(t, err, neorpc.ErrUnknownContract)
+
+	top, err := c.ListContractStorageUsage(2)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(top))
+	require.GreaterOrEqual(t, top[0].Size, top[1].Size)
+
+	_, err = c.ListContractStorageUsage(0)
+	require.ErrorIs(t, err, neorpc.ErrInvalidParams)
+}
+
+func TestClient_NEP17BalancesIndex(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.P2PSigExtensions = true
+		cfg.ApplicationConfiguration.TrackNEP17Balances = true
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	acc := testchain.PrivateKeyByID(0).GetScriptHash()
+	balances, _, err := chain.GetNEP17Balances(acc)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(balances))
+	res, err := c.GetNEP17Balances(acc)
+	require.NoError(t, err)
+	checkNep17Balances(t, &executor{chain: chain}, res)
+}
+
+func TestClient_TransactionProof(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	var b *block.Block
+	for i := uint32(1); i <= chain.BlockHeight(); i++ {
+		b, err = chain.GetBlock(chain.GetHeaderHash(i))
+		require.NoError(t, err)
+		if len(b.Transactions) > 2 {
+			break
+		}
+	}
+	require.Greater(t, len(b.Transactions), 2)
+
+	for i, tx := range b.Transactions {
+		proof, err := c.GetTransactionProof(tx.Hash())
+		require.NoError(t, err)
+		require.Equal(t, b.Hash(), proof.BlockHash)
+		require.Equal(t, b.Index, proof.BlockIndex)
+		require.Equal(t, b.MerkleRoot, proof.MerkleRoot)
+		require.Equal(t, uint32(i), proof.Index)
+		require.True(t, hash.VerifyMerkleProof(b.MerkleRoot, tx.Hash(), proof.Path, proof.Index))
+
+		ok, err := c.VerifyTransactionProof(b.Hash(), tx.Hash(), proof.Index, proof.Path)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		ok, err = c.VerifyTransactionProof(b.Hash(), util.Uint256{1, 2, 3}, proof.Index, proof.Path)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = c.VerifyTransactionProof(b.PrevHash, tx.Hash(), proof.Index, proof.Path)
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	_, err = c.GetTransactionProof(util.Uint256{1, 2, 3})
+	require.ErrorIs(t, err, neorpc.ErrUnknownTransaction)
+	_, err = c.VerifyTransactionProof(util.Uint256{1, 2, 3}, b.Transactions[0].Hash(), 0, nil)
+	require.ErrorIs(t, err, neorpc.ErrUnknownBlock)
+}
+
+func TestClient_NativeCallStats(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithInMemoryChain(t)
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		_, err = c.GetNativeCallStats()
+		require.ErrorIs(t, err, neorpc.ErrNativeCallStatsDisabled)
+		err = c.ResetNativeCallStats()
+		require.ErrorIs(t, err, neorpc.NewMethodNotFoundError(""))
+	})
+
+	t.Run("enabled", func(t *testing.T) {
+		chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+			cfg.ApplicationConfiguration.TrackNativeCallStats = true
+			cfg.ApplicationConfiguration.RPC.EnableAdminMethods = true
+		})
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		stats, err := c.GetNativeCallStats()
+		require.NoError(t, err)
+		require.NotEmpty(t, stats)
+		gasHash, err := chain.GetNativeContractScriptHash(nativenames.Gas)
+		require.NoError(t, err)
+		var found bool
+		for _, s := range stats {
+			if s.Hash == gasHash && s.Method == "transfer" {
+				require.Equal(t, nativenames.Gas, s.Contract)
+				require.Equal(t, 4, s.ParamCount)
+				require.Positive(t, s.Calls)
+				require.Positive(t, s.GAS)
+				found = true
+			}
+		}
+		require.True(t, found)
+
+		require.NoError(t, c.ResetNativeCallStats())
+		stats, err = c.GetNativeCallStats()
+		require.NoError(t, err)
+		for _, s := range stats {
+			require.Zero(t, s.Calls)
+			require.Zero(t, s.GAS)
+		}
+	})
+}
+
+func TestClient_SignedAdminRequests(t *testing.T) {
+	key, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	other, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	_, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.TrackNativeCallStats = true
+		cfg.ApplicationConfiguration.RPC.EnableAdminMethods = true
+		cfg.ApplicationConfiguration.RPC.AdminPublicKeys = []string{hex.EncodeToString(key.PublicKey().Bytes())}
+	})
+	newClient := func(t *testing.T, key *keys.PrivateKey) *rpcclient.Client {
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{AdminKey: key})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+		return c
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		c := newClient(t, nil)
+		require.ErrorIs(t, c.ResetNativeCallStats(), neorpc.ErrUnauthorized)
+		// Other methods don't need a signature.
+		_, err := c.GetNativeCallStats()
+		require.NoError(t, err)
+	})
+	t.Run("unknown key", func(t *testing.T) {
+		c := newClient(t, other)
+		require.ErrorIs(t, c.ResetNativeCallStats(), neorpc.ErrUnauthorized)
+	})
+	t.Run("signed", func(t *testing.T) {
+		c := newClient(t, key)
+		require.NoError(t, c.ResetNativeCallStats())
+		require.NoError(t, c.ResetNativeCallStats())
+		_, err := c.GetNativeCallStats()
+		require.NoError(t, err)
+	})
+	t.Run("replay", func(t *testing.T) {
+		var (
+			body = []byte(`{"jsonrpc":"2.0","id":1,"method":"resetnativestats","params":[]}`)
+			ts   = time.Now().UnixMilli()
+			send = func() *neorpc.Response {
+				req, err := http.NewRequest("POST", httpSrv.URL, bytes.NewReader(body))
+				require.NoError(t, err)
+				req.Header.Set(neorpc.AdminKeyHeader, hex.EncodeToString(key.PublicKey().Bytes()))
+				req.Header.Set(neorpc.AdminTimestampHeader, strconv.FormatInt(ts, 10))
+				req.Header.Set(neorpc.AdminNonceHeader, "nonce")
+				req.Header.Set(neorpc.AdminSignatureHeader, base64.StdEncoding.EncodeToString(
+					key.SignHash(neorpc.AdminRequestHash(ts, "nonce", body))))
+				resp, err := http.DefaultClient.Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				res := new(neorpc.Response)
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(res))
+				return res
+			}
+		)
+		res := send()
+		require.Nil(t, res.Error)
+		res = send()
+		require.NotNil(t, res.Error)
+		require.Equal(t, int64(neorpc.ErrUnauthorizedCode), res.Error.Code)
+		require.Contains(t, res.Error.Data, "replayed")
+	})
+	t.Run("websocket", func(t *testing.T) {
+		url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+		c, err := rpcclient.NewWS(context.Background(), url, rpcclient.WSOptions{Options: rpcclient.Options{AdminKey: key}})
+		require.NoError(t, err)
+		t.Cleanup(c.Close)
+		require.NoError(t, c.Init())
+		require.ErrorIs(t, c.ResetNativeCallStats(), neorpc.ErrUnauthorized)
+	})
+}
+
+type testNotaryHandler []notarysrv.RequestInfo
+
+func (h testNotaryHandler) PendingRequests() []notarysrv.RequestInfo {
+	return h
+}
+
+func TestClient_NotaryPoolInfo(t *testing.T) {
+	t.Run("admin methods disabled", func(t *testing.T) {
+		_, rpcSrv, httpSrv := initClearServerWithInMemoryChain(t)
+		rpcSrv.SetNotaryHandler(testNotaryHandler{})
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		_, err = c.GetNotaryPoolInfo()
+		require.ErrorIs(t, err, neorpc.NewMethodNotFoundError(""))
+	})
+
+	chain, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.RPC.EnableAdminMethods = true
+	})
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	_, err = c.GetNotaryPoolInfo()
+	require.ErrorIs(t, err, neorpc.ErrNotaryDisabled)
+
+	received := time.Now().Add(-time.Minute)
+	rpcSrv.SetNotaryHandler(testNotaryHandler{{
+		MainHash:               util.Uint256{1, 2, 3},
+		ValidUntilBlock:        100,
+		Fallbacks:              []util.Uint256{{4, 5, 6}, {7, 8, 9}},
+		FallbackNotValidBefore: 50,
+		SigsCollected:          2,
+		SigsRequired:           3,
+		Received:               received,
+	}})
+	info, err := c.GetNotaryPoolInfo()
+	require.NoError(t, err)
+	require.Equal(t, chain.BlockHeight(), info.Height)
+	require.Equal(t, 1, len(info.Requests))
+	req := info.Requests[0]
+	require.GreaterOrEqual(t, req.Age, time.Minute.Milliseconds())
+	req.Age = 0
+	require.Equal(t, result.NotaryRequestInfo{
+		Hash:                   util.Uint256{1, 2, 3},
+		ValidUntilBlock:        100,
+		Fallbacks:              []util.Uint256{{4, 5, 6}, {7, 8, 9}},
+		FallbackNotValidBefore: 50,
+		SigsCollected:          2,
+		SigsRequired:           3,
+		Received:               received.UnixMilli(),
+	}, req)
+
+	rpcSrv.SetNotaryHandler(nil)
+	_, err = c.GetNotaryPoolInfo()
+	require.ErrorIs(t, err, neorpc.ErrNotaryDisabled)
+}
+
+func TestClient_BlockProfile(t *testing.T) {
+	t.Run("disabled", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithInMemoryChain(t)
+		c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+		require.NoError(t, err)
+		require.NoError(t, c.Init())
+
+		_, err = c.GetBlockProfile(0)
+		require.ErrorIs(t, err, neorpc.ErrBlockProfilingDisabled)
+	})
+
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ApplicationConfiguration.TrackBlockProfiles = true
+		cfg.ApplicationConfiguration.BlockProfilesCount = 5
+	})
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	// Find the latest block with transactions.
+	var b *block.Block
+	for i := chain.BlockHeight(); ; i-- {
+		b, err = chain.GetBlock(chain.GetHeaderHash(i))
+		require.NoError(t, err)
+		if len(b.Transactions) != 0 {
+			break
+		}
+	}
+	require.Greater(t, b.Index, chain.BlockHeight()-5)
+
+	bp, err := c.GetBlockProfile(b.Index)
+	require.NoError(t, err)
+	require.Equal(t, b.Index, bp.Index)
+	require.Equal(t, b.Hash(), bp.Hash)
+	require.Equal(t, len(b.Transactions), len(bp.Transactions))
+	var gas int64
+	for i, tp := range bp.Transactions {
+		require.Equal(t, b.Transactions[i].Hash(), tp.Hash)
+		aers, err := chain.GetAppExecResults(tp.Hash, trigger.Application)
+		require.NoError(t, err)
+		require.Equal(t, aers[0].GasConsumed, tp.GAS)
+		require.Equal(t, aers[0].VMState, tp.VMState)
+		gas += tp.GAS
+	}
+	require.Equal(t, gas, bp.GAS)
+
+	_, err = c.GetBlockProfile(1)
+	require.ErrorIs(t, err, neorpc.ErrUnknownBlockProfile)
+	_, err = c.GetBlockProfile(chain.BlockHeight() + 1)
+	require.ErrorIs(t, err, neorpc.ErrUnknownHeight)
+}
+
+func TestClient_GetApplicationLogArchived(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.MaxTraceableBlocks = 2
+		cfg.ApplicationConfiguration.RemoveUntraceableBlocks = true
+		cfg.ApplicationConfiguration.AERArchive = config.AERArchive{
+			Enabled: true,
+			Path:    t.TempDir(),
+		}
+	})
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	tx.Signers = []transaction.Signer{{Account: testchain.MultisigScriptHash()}}
+	require.NoError(t, testchain.SignTx(chain, tx))
+	b := testchain.NewBlock(t, chain, 1, 0, tx)
+	require.NoError(t, chain.AddBlock(b))
+
+	txLog, err := c.GetApplicationLog(tx.Hash(), nil)
+	require.NoError(t, err)
+	blockLog, err := c.GetApplicationLog(b.Hash(), nil)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(blockLog.Executions))
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0)))
+	}
+	_, err = chain.GetAppExecResults(tx.Hash(), trigger.All)
+	require.Error(t, err)
+
+	actual, err := c.GetApplicationLog(tx.Hash(), nil)
+	require.NoError(t, err)
+	require.Equal(t, txLog, actual)
+	actual, err = c.GetApplicationLog(b.Hash(), nil)
+	require.NoError(t, err)
+	require.Equal(t, blockLog, actual)
+
+	_, err = c.GetApplicationLog(util.Uint256{1, 2, 3}, nil)
+	require.ErrorIs(t, err, neorpc.ErrUnknownScriptContainer)
+}
+
+func TestClient_InvokeCallLimits(t *testing.T) {
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+		cfg.ProtocolConfiguration.Hardforks = map[string]uint32{config.HFNeoGoExtensions.String(): 0}
+		cfg.ProtocolConfiguration.MaxInvocationStackSize = 8
+		cfg.ProtocolConfiguration.MaxContractCalls = 20
+	})
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	require.NoError(t, c.Init())
+
+	src := `package recursive
+	import (
+		"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+		"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+	)
+	func Recurse(n int) int {
+		if n == 0 {
+			return 0
+		}
+		return contract.Call(runtime.GetExecutingScriptHash(), "recurse", contract.All, n-1).(int) + 1
+	}
+	func Loop(n int) {
+		for i := 0; i < n; i++ {
+			contract.Call(runtime.GetExecutingScriptHash(), "recurse", contract.All, 0)
+		}
+	}`
+	confFile := filepath.Join(t.TempDir(), "recursive.yml")
+	require.NoError(t, os.WriteFile(confFile, []byte("name: recursive\npermissions:\n  - methods: '*'\n"), 0644))
+	tx, h, _, err := testchain.NewDeployTx(chain, "recursive.go", testchain.MultisigScriptHash(), strings.NewReader(src), &confFile)
+	require.NoError(t, err)
+	tx.ValidUntilBlock = chain.BlockHeight() + 1
+	require.NoError(t, testchain.SignTx(chain, tx))
+	require.NoError(t, chain.AddBlock(testchain.NewBlock(t, chain, 1, 0, tx)))
+
+	inv := invoker.New(c, nil)
+	res, err := inv.Call(h, "recurse", 5)
+	require.NoError(t, err)
+	require.Equal(t, "HALT", res.State, res.FaultException)
+
+	res, err = inv.Call(h, "recurse", -1)
+	require.NoError(t, err)
+	require.Equal(t, "FAULT", res.State)
+	require.Contains(t, res.FaultException, interop.ErrInvocationStackLimit.Error())
+	require.Contains(t, res.FaultException, h.StringLE())
+
+	res, err = inv.Call(h, "loop", 30)
+	require.NoError(t, err)
+	require.Equal(t, "FAULT", res.State)
+	require.Contains(t, res.FaultException, interop.ErrTooManyContractCalls.Error())
+	require.Contains(t, res.FaultException, h.StringLE())
+}
+
+func TestClient_AccountInfo(t *testing.T) {
+	chain, _, httpSrv := initServerWithInMemoryChain(t)
+
+	c, err := rpcclient.New(context.Background(), httpSrv.URL, rpcclient.Options{})
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+	require.NoError(t, c.Init())
+
+	priv0 := testchain.PrivateKeyByID(0)
+	priv0Hash := priv0.PublicKey().GetScriptHash()
+
+	act, err := actor.NewSimple(c, wallet.NewAccountFromPrivateKey(priv0))
+	require.NoError(t, err)
+	txHash, _, err := gas.New(act).Transfer(priv0Hash, testchain.PrivateKeyByID(1).GetScriptHash(), big.NewInt(1), nil)
+	require.NoError(t, err)
+
+	info, err := accountinfo.Get(c, priv0Hash)
+	require.NoError(t, err)
+	require.Equal(t, priv0Hash, info.Account)
+
+	var rub *result.NEP17Balance
+	for i := range info.NEP17 {
+		if info.NEP17[i].Symbol == "RUB" {
+			rub = &info.NEP17[i]
+		}
+	}
+	require.NotNil(t, rub)
+	require.Equal(t, "877", rub.Amount)
+	require.Equal(t, 2, rub.Decimals)
+	require.Len(t, info.NEP17, 3) // NEO, GAS and RUB.
+
+	require.ElementsMatch(t, []accountinfo.NEP11Count{
+		{Asset: nnsHash, Name: "NameService", Symbol: "NNS", Tokens: 1},
+		{Asset: nfsoHash, Name: "NeoFS Object NFT", Symbol: "NFSO", Tokens: 1},
+	}, info.NEP11)
+
+	neoState, err := neo.NewReader(invoker.New(c, nil)).GetAccountState(priv0Hash)
+	require.NoError(t, err)
+	require.Equal(t, &neoState.Balance, info.NEO.Balance)
+	require.Equal(t, neoState.BalanceHeight, info.NEO.BalanceHeight)
+	require.Nil(t, info.NEO.VoteTo)
+	require.Positive(t, info.NEO.Unclaimed.Sign())
+
+	require.Equal(t, &accountinfo.NotaryInfo{Deposit: big.NewInt(10_0000_0000), Till: 1007}, info.Notary)
+
+	require.Len(t, info.Contracts, 6)
+	for _, cs := range info.Contracts {
+		require.NotNil(t, chain.GetContractState(cs.Hash))
+	}
+
+	require.Equal(t, []accountinfo.PendingTx{{Hash: txHash, From: true, To: true}}, info.Pending)
+
+	t.Run("receiver", func(t *testing.T) {
+		info, err := accountinfo.Get(c, testchain.PrivateKeyByID(1).GetScriptHash())
+		require.NoError(t, err)
+		require.Nil(t, info.Notary)
+		require.Empty(t, info.Contracts)
+		require.Equal(t, []accountinfo.PendingTx{{Hash: txHash, To: true}}, info.Pending)
+	})
+}
@@ -0,0 +1,57 @@
+package rpcsrv
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Supported HTTP content encodings.
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// negotiateEncoding returns the content encoding to be used for the response
+// based on the Accept-Encoding request header value. gzip is preferred over
+// deflate if both are equally acceptable, an empty string is returned if
+// none of them is acceptable.
+func negotiateEncoding(accept string) string {
+	var (
+		best  string
+		bestQ float64
+	)
+	for _, part := range strings.Split(accept, ",") {
+		name, qParams, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != encodingGzip && name != encodingDeflate {
+			continue
+		}
+		var q = 1.0
+		for _, param := range strings.Split(qParams, ";") {
+			k, v, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(k) != "q" {
+				continue
+			}
+			var err error
+			q, err = strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				q = 0
+			}
+		}
+		if q > bestQ || (q == bestQ && q > 0 && name == encodingGzip) {
+			best, bestQ = name, q
+		}
+	}
+	return best
+}
+
+// newCompressor returns a writer compressing data with the given encoding.
+func newCompressor(encoding string, w io.Writer) io.WriteCloser {
+	if encoding == encodingDeflate {
+		return zlib.NewWriter(w)
+	}
+	return gzip.NewWriter(w)
+}
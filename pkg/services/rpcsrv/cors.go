@@ -0,0 +1,87 @@
+package rpcsrv
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+)
+
+// corsWildcard is an origin pattern matching any origin.
+const corsWildcard = "*"
+
+// corsAllowedOrigins returns the list of origin patterns allowed by the
+// configuration, nil is returned if CORS is disabled. EnableCORSWorkaround
+// without an explicit list of origins allows any origin.
+func corsAllowedOrigins(conf config.RPC) []string {
+	if len(conf.CORSAllowedOrigins) != 0 {
+		return conf.CORSAllowedOrigins
+	}
+	if conf.EnableCORSWorkaround {
+		return []string{corsWildcard}
+	}
+	return nil
+}
+
+// matchOrigin returns the first pattern matching the given origin. Patterns
+// can be either exact origins ("https://example.com"), wildcard subdomain
+// origins ("https://*.example.com", it doesn't match "https://example.com"
+// itself) or "*" matching any origin.
+func matchOrigin(patterns []string, origin string) (string, bool) {
+	origin = strings.ToLower(origin)
+	for _, p := range patterns {
+		if p == corsWildcard {
+			return p, true
+		}
+		if origin == "" {
+			continue
+		}
+		lp := strings.ToLower(p)
+		i := strings.Index(lp, "://*.")
+		if i < 0 {
+			if lp == origin {
+				return p, true
+			}
+			continue
+		}
+		prefix, suffix := lp[:i+len("://")], lp[i+len("://*"):]
+		if len(origin) <= len(prefix)+len(suffix) ||
+			!strings.HasPrefix(origin, prefix) || !strings.HasSuffix(origin, suffix) {
+			continue
+		}
+		if !strings.ContainsAny(origin[len(prefix):len(origin)-len(suffix)], "/:@") {
+			return p, true
+		}
+	}
+	return "", false
+}
+
+// setCORSOriginHeaders sets CORS headers for the origin of the given request
+// and returns false if the origin is not allowed.
+func (s *Server) setCORSOriginHeaders(h http.Header, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	p, ok := matchOrigin(s.corsOrigins, origin)
+	if !ok {
+		return false
+	}
+	if p == corsWildcard {
+		h.Set("Access-Control-Allow-Origin", corsWildcard)
+	} else {
+		h.Set("Access-Control-Allow-Origin", origin)
+		h.Add("Vary", "Origin")
+	}
+	h.Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+	return true
+}
+
+// checkWsOrigin checks the origin of websocket connection request against the
+// given patterns. Requests without Origin header are made by non-browser
+// clients and they're always allowed.
+func checkWsOrigin(patterns []string, r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	_, ok := matchOrigin(patterns, origin)
+	return ok
+}
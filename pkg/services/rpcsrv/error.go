@@ -2,6 +2,7 @@ package rpcsrv
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
 )
@@ -18,6 +19,8 @@ type abstract struct {
 	neorpc.Header
 	Error  *neorpc.Error `json:"error,omitempty"`
 	Result any           `json:"result,omitempty"`
+	// retryAfter is the delay hint for rate-limited requests.
+	retryAfter time.Duration
 }
 
 // RunForErrors implements abstractResult interface.
@@ -48,6 +51,8 @@ func getHTTPCodeForError(respErr *neorpc.Error) int {
 		httpCode = http.StatusMethodNotAllowed
 	case neorpc.InternalServerErrorCode:
 		httpCode = http.StatusInternalServerError
+	case neorpc.ErrRateLimitExceededCode:
+		httpCode = http.StatusTooManyRequests
 	default:
 		httpCode = http.StatusUnprocessableEntity
 	}
@@ -10,6 +10,15 @@ import (
 // Metrics used in monitoring service.
 var (
 	rpcTimes = map[string]prometheus.Histogram{}
+
+	rpcThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Help:      "Number of RPC requests rejected because of client rate limit",
+			Name:      "rpc_throttled_requests_total",
+			Namespace: "neogo",
+		},
+		[]string{"method"},
+	)
 )
 
 func addReqTimeMetric(name string, t time.Duration) {
@@ -19,6 +28,15 @@ func addReqTimeMetric(name string, t time.Duration) {
 	}
 }
 
+// addThrottledMetric counts rate-limited requests, unknown methods are
+// counted together to keep the label set bounded.
+func addThrottledMetric(method string) {
+	if _, ok := rpcTimes[method]; !ok {
+		method = "unknown"
+	}
+	rpcThrottled.WithLabelValues(method).Inc()
+}
+
 func regCounter(call string) {
 	rpcTimes[call] = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
@@ -37,4 +55,5 @@ func init() {
 	for call := range rpcWsHandlers {
 		regCounter(call)
 	}
+	prometheus.MustRegister(rpcThrottled)
 }
@@ -0,0 +1,218 @@
+package rpcsrv
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+)
+
+const (
+	// defaultMethodWeight is the weight of methods not listed in
+	// defaultMethodWeights.
+	defaultMethodWeight = 1
+	// rateLimitCleanupInterval is the minimum interval between removals of
+	// idle client buckets.
+	rateLimitCleanupInterval = time.Minute
+	// forwardedForHeader is the header trusted proxies pass client address in.
+	forwardedForHeader = "X-Forwarded-For"
+)
+
+// defaultMethodWeights contains weights of methods more expensive to handle
+// than the others.
+var defaultMethodWeights = map[string]int{
+	"calculatenetworkfee":          10,
+	"estimatefees":                 20,
+	"findstates":                   5,
+	"findstorage":                  5,
+	"findstoragehistoric":          10,
+	"getcontracts":                 5,
+	"getnep11balances":             5,
+	"getnep11transfers":            5,
+	"getnep17balances":             5,
+	"getnep17transfers":            5,
+	"getproof":                     5,
+	"invokecontractverify":         10,
+	"invokecontractverifyhistoric": 20,
+	"invokefunction":               10,
+	"invokefunctionhistoric":       20,
+	"invokescript":                 10,
+	"invokescripthistoric":         20,
+	"sendrawtransaction":           5,
+	"submitblock":                  5,
+	"submitnotaryrequest":          5,
+	"traverseiterator":             2,
+	"verifyproof":                  5,
+}
+
+// rateLimiter limits request rate of every client IP address with a token
+// bucket, requests spend the number of tokens equal to their method weight.
+type rateLimiter struct {
+	rate    float64
+	burst   float64
+	weights map[string]int
+	proxies []*net.IPNet
+	now     func() time.Time
+
+	lock        sync.Mutex
+	buckets     map[string]*tokenBucket
+	lastCleanup time.Time
+}
+
+// tokenBucket is a client token bucket state as of the last request.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter returns a rateLimiter refilling rate tokens per second up to
+// burst with default method weights updated by the given ones. Requests
+// coming from trustedProxies addresses are attributed to the client specified
+// in the X-Forwarded-For header.
+func newRateLimiter(rate float64, burst int, weights map[string]int, trustedProxies []*net.IPNet) *rateLimiter {
+	ws := make(map[string]int, len(defaultMethodWeights)+len(weights))
+	for m, w := range defaultMethodWeights {
+		ws[m] = w
+	}
+	for m, w := range weights {
+		ws[strings.ToLower(m)] = w
+	}
+	return &rateLimiter{
+		rate:    rate,
+		burst:   float64(burst),
+		weights: ws,
+		proxies: trustedProxies,
+		now:     time.Now,
+		buckets: make(map[string]*tokenBucket),
+	}
+}
+
+// parseTrustedProxy parses an IP address or CIDR network.
+func parseTrustedProxy(p string) (*net.IPNet, error) {
+	if strings.Contains(p, "/") {
+		_, n, err := net.ParseCIDR(p)
+		return n, err
+	}
+	ip := net.ParseIP(p)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", p)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	bits := 8 * len(ip)
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
+
+// weight returns the method weight.
+func (l *rateLimiter) weight(method string) int {
+	if w, ok := l.weights[method]; ok {
+		return w
+	}
+	return defaultMethodWeight
+}
+
+// allow spends tokens for the method call from the client bucket. It returns
+// false and the time to wait before the call can succeed if there are not
+// enough tokens. Methods weighing more than the bucket capacity can be called
+// when the bucket is full.
+func (l *rateLimiter) allow(client string, method string) (bool, time.Duration) {
+	cost := float64(l.weight(method))
+	if cost <= 0 {
+		return true, 0
+	}
+	if cost > l.burst {
+		cost = l.burst
+	}
+	now := l.now()
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.cleanup(now)
+	b, ok := l.buckets[client]
+	if !ok {
+		b = &tokenBucket{tokens: l.burst, last: now}
+		l.buckets[client] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.last = now
+	if b.tokens >= cost {
+		b.tokens -= cost
+		return true, 0
+	}
+	return false, time.Duration(math.Ceil((cost - b.tokens) / l.rate * float64(time.Second)))
+}
+
+// refill returns the number of tokens in the bucket at the given time.
+func (l *rateLimiter) refill(b *tokenBucket, now time.Time) float64 {
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed <= 0 {
+		return b.tokens
+	}
+	return math.Min(l.burst, b.tokens+elapsed*l.rate)
+}
+
+// cleanup removes full buckets, they're no different from the new ones. It
+// must be called with the lock held.
+func (l *rateLimiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < rateLimitCleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for c, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, c)
+		}
+	}
+}
+
+// clientIP returns the address of the client making the request. For requests
+// made via trusted proxies it's the rightmost untrusted X-Forwarded-For
+// address (the leftmost one if all of them are trusted).
+func (l *rateLimiter) clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !l.isTrustedProxy(ip) {
+		return host
+	}
+	var hops []string
+	for _, h := range r.Header.Values(forwardedForHeader) {
+		hops = append(hops, strings.Split(h, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := net.ParseIP(strings.TrimSpace(hops[i]))
+		if hop == nil {
+			// Garbage can't be trusted, so the last good hop is the client.
+			break
+		}
+		host = hop.String()
+		if !l.isTrustedProxy(hop) {
+			break
+		}
+	}
+	return host
+}
+
+func (l *rateLimiter) isTrustedProxy(ip net.IP) bool {
+	for _, n := range l.proxies {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRateLimitError returns an error for requests exceeding the limit with
+// retry delay hint.
+func newRateLimitError(wait time.Duration) *neorpc.Error {
+	ms := (wait + time.Millisecond - 1) / time.Millisecond
+	return neorpc.WrapErrorWithData(neorpc.ErrRateLimitExceeded, fmt.Sprintf("retry after %d ms", ms))
+}
@@ -0,0 +1,107 @@
+package rpcsrv
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var (
+		now = time.Unix(1700000000, 0)
+		l   = newRateLimiter(2, 10, map[string]int{"getversion": 0, "GetBlock": 4, "getblockcount": 20}, nil)
+	)
+	l.now = func() time.Time { return now }
+	check := func(t *testing.T, client string, method string, ok bool, wait time.Duration) {
+		actualOK, actualWait := l.allow(client, method)
+		require.Equal(t, ok, actualOK)
+		require.Equal(t, wait, actualWait)
+	}
+
+	require.Equal(t, 1, l.weight("getpeers"))
+	require.Equal(t, 10, l.weight("invokescript"))
+	require.Equal(t, 4, l.weight("getblock"))
+
+	// Burst.
+	check(t, "a", "invokefunction", true, 0)
+	check(t, "a", "invokefunction", false, 5*time.Second)
+	check(t, "a", "getblock", false, 2*time.Second)
+	check(t, "a", "getversion", true, 0)
+	// Other clients have their own buckets.
+	check(t, "b", "getblock", true, 0)
+
+	// Refill.
+	now = now.Add(time.Second)
+	check(t, "a", "getblock", false, time.Second)
+	check(t, "a", "getpeers", true, 0)
+	check(t, "a", "getpeers", true, 0)
+	check(t, "a", "getpeers", false, 500*time.Millisecond)
+	now = now.Add(time.Hour)
+	// Methods weighing more than the capacity need the full bucket.
+	check(t, "a", "getblockcount", true, 0)
+	check(t, "a", "getpeers", false, 500*time.Millisecond)
+
+	// Full buckets are removed on cleanup ("b" is already gone).
+	require.Equal(t, 1, len(l.buckets))
+	now = now.Add(rateLimitCleanupInterval / 2)
+	check(t, "c", "getpeers", true, 0)
+	require.Equal(t, 2, len(l.buckets))
+	now = now.Add(rateLimitCleanupInterval / 2)
+	check(t, "c", "getpeers", true, 0)
+	require.Equal(t, 1, len(l.buckets))
+	require.NotNil(t, l.buckets["c"])
+
+	rpcErr := newRateLimitError(1500*time.Microsecond + time.Second)
+	require.Equal(t, int64(neorpc.ErrRateLimitExceededCode), rpcErr.Code)
+	require.Equal(t, "retry after 1002 ms", rpcErr.Data)
+}
+
+func TestParseTrustedProxy(t *testing.T) {
+	for in, out := range map[string]string{
+		"10.0.0.1":       "10.0.0.1/32",
+		"::1":            "::1/128",
+		"192.168.0.0/16": "192.168.0.0/16",
+		"fd00::/8":       "fd00::/8",
+	} {
+		n, err := parseTrustedProxy(in)
+		require.NoError(t, err, in)
+		require.Equal(t, out, n.String(), in)
+	}
+	for _, in := range []string{"", "proxy.local", "10.0.0.1/33", "10.0.0.1:80"} {
+		_, err := parseTrustedProxy(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestRateLimiterClientIP(t *testing.T) {
+	var proxies []*net.IPNet
+	for _, p := range []string{"10.0.0.1", "192.168.0.0/16"} {
+		n, err := parseTrustedProxy(p)
+		require.NoError(t, err)
+		proxies = append(proxies, n)
+	}
+	l := newRateLimiter(1, 1, nil, proxies)
+	check := func(t *testing.T, remote string, forwarded []string, expected string) {
+		r := &http.Request{RemoteAddr: remote, Header: make(http.Header)}
+		for _, f := range forwarded {
+			r.Header.Add(forwardedForHeader, f)
+		}
+		require.Equal(t, expected, l.clientIP(r))
+	}
+
+	check(t, "1.2.3.4:5678", nil, "1.2.3.4")
+	// Untrusted clients can't spoof their address.
+	check(t, "1.2.3.4:5678", []string{"5.6.7.8"}, "1.2.3.4")
+	check(t, "10.0.0.1:5678", nil, "10.0.0.1")
+	check(t, "10.0.0.1:5678", []string{"5.6.7.8"}, "5.6.7.8")
+	// Client-provided part of the header is ignored.
+	check(t, "10.0.0.1:5678", []string{"9.9.9.9, 5.6.7.8, 192.168.1.1"}, "5.6.7.8")
+	check(t, "10.0.0.1:5678", []string{"9.9.9.9", "5.6.7.8 , 192.168.1.1"}, "5.6.7.8")
+	check(t, "10.0.0.1:5678", []string{"192.168.1.2, 192.168.1.1"}, "192.168.1.2")
+	check(t, "10.0.0.1:5678", []string{"garbage, 192.168.1.1"}, "192.168.1.1")
+	check(t, "10.0.0.1:5678", []string{"garbage"}, "10.0.0.1")
+}
@@ -0,0 +1,280 @@
+package rpcsrv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"go.uber.org/zap"
+)
+
+var (
+	// errReplayStopped is returned from replay if the feed is unsubscribed
+	// or the server is shutting down.
+	errReplayStopped = errors.New("replay stopped")
+	// errReplayOverflow is returned from replay if too many live events are
+	// received during it.
+	errReplayOverflow = errors.New("too many pending events")
+)
+
+// replayStartFromParam checks subscription's starting block parameter.
+func (s *Server) replayStartFromParam(event neorpc.EventID, param *params.Param) (uint32, *neorpc.Error) {
+	if event != neorpc.NotificationEventID && event != neorpc.ExecutionEventID {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "starting block can only be specified for notification and execution events")
+	}
+	if s.config.MaxWebSocketReplayBlocks < 0 {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "events replay is disabled")
+	}
+	from, err := param.GetInt()
+	if err != nil || from < 0 {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid starting block")
+	}
+	height := int(s.chain.BlockHeight())
+	if from > height+1 {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("starting block %d is higher than the next block %d", from, height+1))
+	}
+	if height-from+1 > s.config.MaxWebSocketReplayBlocks {
+		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("too many blocks to replay: %d, max %d", height-from+1, s.config.MaxWebSocketReplayBlocks))
+	}
+	if from <= height {
+		if _, err := s.chain.GetBlock(s.chain.GetHeaderHash(uint32(from))); err != nil {
+			return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("block %d is not available: %s", from, err))
+		}
+	}
+	return uint32(from), nil
+}
+
+// startReplays starts historical events delivery for the subscriber's feeds
+// subscribed with a starting block. It's called after the subscription
+// response is sent to the subscriber.
+func (s *Server) startReplays(sub *subscriber) {
+	s.subsLock.RLock()
+	n := len(sub.replays)
+	s.subsLock.RUnlock()
+	if n == 0 {
+		return
+	}
+	s.subsLock.Lock()
+	replays := sub.replays
+	sub.replays = nil
+	s.subsLock.Unlock()
+	for _, rpl := range replays {
+		go s.runReplay(sub, rpl)
+	}
+}
+
+// runReplay delivers historical events to the subscriber, then it delivers
+// live events received during replay and switches the feed to live
+// delivery notifying the subscriber with CaughtUpEvent. If some events can't
+// be delivered, the subscriber gets MissedEvent.
+func (s *Server) runReplay(sub *subscriber, rpl *replay) {
+	err := s.replayEvents(sub, rpl)
+
+	s.subsLock.Lock()
+	defer s.subsLock.Unlock()
+	if sub.feeds[rpl.id].replay != rpl {
+		return // Unsubscribed.
+	}
+	sub.feeds[rpl.id].replay = nil
+	if err != nil {
+		if errors.Is(err, errReplayStopped) {
+			return
+		}
+		if !errors.Is(err, errReplayOverflow) {
+			s.log.Info("failed to replay events", zap.Uint32("from", rpl.from), zap.Error(err))
+		}
+		s.missEvents(sub)
+		return
+	}
+	if rpl.overflown.Load() {
+		s.missEvents(sub)
+		return
+	}
+	for _, ev := range rpl.pending {
+		// Events for replayed blocks can be received via subscription
+		// after the replay is started.
+		index, err := s.eventBlockIndex(ev.ntf)
+		if err == nil && index <= rpl.to {
+			continue
+		}
+		if !s.trySendEvent(sub, ev) {
+			return
+		}
+	}
+	rpl.pending = nil
+	var ntf = neorpc.Notification{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Event:   neorpc.CaughtUpEventID,
+		Payload: []any{result.CaughtUpEvent{
+			ID:    strconv.Itoa(rpl.id),
+			Index: rpl.to,
+		}},
+	}
+	msg, err := prepareEvent(&ntf)
+	if err != nil {
+		s.log.Error("failed to prepare caught up message", zap.Error(err))
+		return
+	}
+	s.trySendEvent(sub, intEvent{msg, &ntf})
+}
+
+// replayEvents delivers feed events of the blocks from the replay range to
+// the subscriber in the same order they're emitted by the chain.
+func (s *Server) replayEvents(sub *subscriber, rpl *replay) error {
+	for i := rpl.from; i <= rpl.to; i++ {
+		select {
+		case <-rpl.stop:
+			return errReplayStopped
+		case <-s.shutdown:
+			return errReplayStopped
+		default:
+		}
+		h := s.chain.GetHeaderHash(i)
+		b, err := s.chain.GetBlock(h)
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", i, err)
+		}
+		if err := s.replayContainer(sub, rpl, h, trigger.OnPersist); err != nil {
+			return err
+		}
+		for _, tx := range b.Transactions {
+			if err := s.replayContainer(sub, rpl, tx.Hash(), trigger.Application); err != nil {
+				return err
+			}
+		}
+		if err := s.replayContainer(sub, rpl, h, trigger.PostPersist); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// replayContainer delivers feed events of the given script container
+// execution to the subscriber.
+func (s *Server) replayContainer(sub *subscriber, rpl *replay, h util.Uint256, trig trigger.Type) error {
+	aers, err := s.getAppExecResults(h, trig)
+	if err != nil {
+		return fmt.Errorf("failed to get execution results of %s: %w", h.StringLE(), err)
+	}
+	for i := range aers {
+		if rpl.feed.event == neorpc.ExecutionEventID {
+			if err := s.replayEvent(sub, rpl, &aers[i]); err != nil {
+				return err
+			}
+			continue
+		}
+		if aers[i].VMState != vmstate.Halt {
+			continue
+		}
+		for j := range aers[i].Events {
+			err := s.replayEvent(sub, rpl, &state.ContainedNotificationEvent{
+				Container:         aers[i].Container,
+				NotificationEvent: aers[i].Events[j],
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// replayEvent delivers the event to the subscriber if it matches the feed.
+func (s *Server) replayEvent(sub *subscriber, rpl *replay, payload any) error {
+	var ntf = neorpc.Notification{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Event:   rpl.feed.event,
+		Payload: []any{payload},
+	}
+	if !rpcevent.Matches(rpl.feed, &ntf) {
+		return nil
+	}
+	msg, err := prepareEvent(&ntf)
+	if err != nil {
+		return err
+	}
+	if rpl.overflown.Load() {
+		return errReplayOverflow
+	}
+	select {
+	case sub.writer <- intEvent{msg, &ntf}:
+		return nil
+	case <-rpl.stop:
+		return errReplayStopped
+	case <-s.shutdown:
+		return errReplayStopped
+	}
+}
+
+// eventBlockIndex returns the index of the block notification or execution
+// event belongs to.
+func (s *Server) eventBlockIndex(ntf *neorpc.Notification) (uint32, error) {
+	var h util.Uint256
+	switch p := ntf.Payload[0].(type) {
+	case *state.ContainedNotificationEvent:
+		h = p.Container
+	case *state.AppExecResult:
+		h = p.Container
+	default:
+		return 0, fmt.Errorf("unexpected %s event payload: %T", ntf.Event, p)
+	}
+	if header, err := s.chain.GetHeader(h); err == nil {
+		return header.Index, nil
+	}
+	_, height, err := s.chain.GetTransaction(h)
+	return height, err
+}
+
+// trySendEvent sends the event to the subscriber if it's ready to receive it.
+// Otherwise MissedEvent is delivered eventually and false is returned.
+func (s *Server) trySendEvent(sub *subscriber, ev intEvent) bool {
+	select {
+	case sub.writer <- ev:
+		return true
+	default:
+		s.missEvents(sub)
+		return false
+	}
+}
+
+// missEvents marks the subscriber as overflown and delivers MissedEvent to
+// it, subsequent events are not sent until it's delivered.
+func (s *Server) missEvents(sub *subscriber) {
+	if !sub.overflown.CompareAndSwap(false, true) {
+		return
+	}
+	var ntf = neorpc.Notification{
+		JSONRPC: neorpc.JSONRPCVersion,
+		Event:   neorpc.MissedEventID,
+		Payload: make([]any, 0),
+	}
+	msg, err := prepareEvent(&ntf)
+	if err != nil {
+		s.log.Error("failed to prepare overflow message", zap.Error(err))
+		return
+	}
+	// MissedEvent is to be delivered eventually.
+	go func() {
+		sub.writer <- intEvent{msg, &ntf}
+		sub.overflown.Store(false)
+	}()
+}
+
+// prepareEvent creates websocket message for the event.
+func prepareEvent(ntf *neorpc.Notification) (*websocket.PreparedMessage, error) {
+	b, err := json.Marshal(ntf)
+	if err != nil {
+		return nil, err
+	}
+	return websocket.NewPreparedMessage(websocket.TextMessage, b)
+}
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"context"
 	"crypto/elliptic"
+	"encoding/base64"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	gio "io"
 	"math"
 	"math/big"
 	"net"
@@ -44,6 +46,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/neorpc/rpcevent"
 	"github.com/nspcc-dev/neo-go/pkg/network"
 	"github.com/nspcc-dev/neo-go/pkg/network/payload"
+	"github.com/nspcc-dev/neo-go/pkg/services/notary"
 	"github.com/nspcc-dev/neo-go/pkg/services/oracle/broadcaster"
 	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
@@ -71,21 +74,29 @@ type (
 		ForEachNEP11Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP11Transfer) (bool, error)) error
 		ForEachNEP17Transfer(acc util.Uint160, newestTimestamp uint64, f func(*state.NEP17Transfer) (bool, error)) error
 		GetAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
+		GetArchivedAppExecResults(util.Uint256, trigger.Type) ([]state.AppExecResult, error)
 		GetBaseExecFee() int64
 		GetBlock(hash util.Uint256) (*block.Block, error)
+		GetBlockProfile(index uint32) (*core.BlockProfile, error)
 		GetCommittee() (keys.PublicKeys, error)
 		GetConfig() config.Blockchain
 		GetContractScriptHash(id int32) (util.Uint160, error)
 		GetContractState(hash util.Uint160) *state.Contract
+		GetContractStorageUsage(id int32) (state.StorageUsage, error)
 		GetEnrollments() ([]state.Validator, error)
 		GetGoverningTokenBalance(acc util.Uint160) (*big.Int, uint32)
 		GetHeader(hash util.Uint256) (*block.Header, error)
 		GetHeaderHash(uint32) util.Uint256
+		GetMaxTraceableBlocks() uint32
 		GetMaxVerificationGAS() int64
 		GetMemPool() *mempool.Pool
+		GetMillisecondsPerBlock() uint32
 		GetNEP11Contracts() []util.Uint160
+		GetNEP17Balances(acc util.Uint160) (map[int32]*big.Int, map[int32]bool, error)
 		GetNEP17Contracts() []util.Uint160
 		GetNativeContractScriptHash(string) (util.Uint160, error)
+		GetNativeCallStats() ([]native.MethodCallStats, error)
+		GetNativeActivationHeight(h util.Uint160) (uint32, bool)
 		GetNatives() []state.NativeContract
 		GetNextBlockValidators() ([]*keys.PublicKey, error)
 		GetNotaryContractScriptHash() util.Uint160
@@ -94,10 +105,12 @@ type (
 		GetTestHistoricVM(t trigger.Type, tx *transaction.Transaction, nextBlockHeight uint32) (*interop.Context, error)
 		GetTestVM(t trigger.Type, tx *transaction.Transaction, b *block.Block) (*interop.Context, error)
 		GetTokenLastUpdated(acc util.Uint160) (map[int32]uint32, error)
+		GetTopContractStorageUsage(n int) ([]state.ContractStorageUsage, error)
 		GetTransaction(util.Uint256) (*transaction.Transaction, uint32, error)
 		HeaderHeight() uint32
 		InitVerificationContext(ic *interop.Context, hash util.Uint160, witness *transaction.Witness) error
 		P2PSigExtensionsEnabled() bool
+		ResetNativeCallStats() error
 		SubscribeForBlocks(ch chan *block.Block)
 		SubscribeForHeadersOfAddedBlocks(ch chan *block.Header)
 		SubscribeForExecutions(ch chan *state.AppExecResult)
@@ -125,6 +138,11 @@ type (
 		AddResponse(pub *keys.PublicKey, reqID uint64, txSig []byte)
 	}
 
+	// NotaryHandler is the interface P2PNotary service needs to provide for the Server.
+	NotaryHandler interface {
+		PendingRequests() []notary.RequestInfo
+	}
+
 	// Server represents the JSON-RPC 2.0 server.
 	Server struct {
 		http  []*http.Server
@@ -132,6 +150,15 @@ type (
 
 		chain  Ledger
 		config config.RPC
+		// adminAuth verifies admin request signatures, nil if they're
+		// not required.
+		adminAuth *adminAuth
+		// corsOrigins is a list of allowed CORS origin patterns, nil if
+		// CORS is disabled.
+		corsOrigins []string
+		// rateLimiter limits client request rate, nil if rate limiting is
+		// disabled.
+		rateLimiter *rateLimiter
 		// wsReadLimit represents web-socket message limit for a receiving side.
 		wsReadLimit      int64
 		upgrader         websocket.Upgrader
@@ -139,6 +166,7 @@ type (
 		stateRootEnabled bool
 		coreServer       *network.Server
 		oracle           *atomic.Value
+		notary           atomic.Pointer[NotaryHandler]
 		log              *zap.Logger
 		shutdown         chan struct{}
 		started          atomic.Bool
@@ -177,7 +205,11 @@ type (
 		// to be filled during the first `traverseiterator` call using corresponding params.
 		iteratorIdentifiers []*iteratorIdentifier
 		timer               *time.Timer
-		finalize            func()
+		// deadline is the time session expires at regardless of its
+		// activity, it limits the time session resources (like storage
+		// snapshot used by iterators) are held for.
+		deadline time.Time
+		finalize func()
 	}
 	// iteratorIdentifier represents Iterator on the server side, holding iterator ID and Iterator stackitem.
 	iteratorIdentifier struct {
@@ -200,15 +232,30 @@ const (
 	// Default maximum number of websocket clients per Server.
 	defaultMaxWebSocketClients = 64
 
+	// Default maximum number of blocks to replay events for on subscription.
+	defaultMaxWebSocketReplayBlocks = 1000
+
 	// Maximum number of elements for get*transfers requests.
 	maxTransfersLimit = 1000
 
+	// Default and maximum number of elements for listcontractstorageusage requests.
+	defaultStorageUsageLimit = 10
+	maxStorageUsageLimit     = 1000
+
 	// defaultSessionPoolSize is the number of concurrently running iterator sessions.
 	defaultSessionPoolSize = 20
+
+	// defaultSessionMaxLifetime is the maximum iterator session lifetime in
+	// seconds.
+	defaultSessionMaxLifetime = 600
+
+	// Version byte of findstorage* continuation token.
+	findStorageTokenVersion = 0x01
 )
 
 var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"calculatenetworkfee":          (*Server).calculateNetworkFee,
+	"estimatefees":                 (*Server).estimateFees,
 	"findstates":                   (*Server).findStates,
 	"findstorage":                  (*Server).findStorage,
 	"findstoragehistoric":          (*Server).findStorageHistoric,
@@ -219,17 +266,22 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getblockhash":                 (*Server).getBlockHash,
 	"getblockheader":               (*Server).getBlockHeader,
 	"getblockheadercount":          (*Server).getBlockHeaderCount,
+	"getblockprofile":              (*Server).getBlockProfile,
 	"getblocksysfee":               (*Server).getBlockSysFee,
 	"getcandidates":                (*Server).getCandidates,
 	"getcommittee":                 (*Server).getCommittee,
 	"getconnectioncount":           (*Server).getConnectionCount,
+	"getcontracts":                 (*Server).getContracts,
 	"getcontractstate":             (*Server).getContractState,
+	"getcontractstorageusage":      (*Server).getContractStorageUsage,
 	"getnativecontracts":           (*Server).getNativeContracts,
+	"getnativestats":               (*Server).getNativeStats,
 	"getnep11balances":             (*Server).getNEP11Balances,
 	"getnep11properties":           (*Server).getNEP11Properties,
 	"getnep11transfers":            (*Server).getNEP11Transfers,
 	"getnep17balances":             (*Server).getNEP17Balances,
 	"getnep17transfers":            (*Server).getNEP17Transfers,
+	"getnotarypoolinfo":            (*Server).getNotaryPoolInfo,
 	"getpeers":                     (*Server).getPeers,
 	"getproof":                     (*Server).getProof,
 	"getrawmempool":                (*Server).getRawMempool,
@@ -242,8 +294,10 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"getstorage":                   (*Server).getStorage,
 	"getstoragehistoric":           (*Server).getStorageHistoric,
 	"gettransactionheight":         (*Server).getTransactionHeight,
+	"gettxproof":                   (*Server).getTxProof,
 	"getunclaimedgas":              (*Server).getUnclaimedGas,
 	"getnextblockvalidators":       (*Server).getNextBlockValidators,
+	"getverifiablestate":           (*Server).getVerifiableState,
 	"getversion":                   (*Server).getVersion,
 	"invokefunction":               (*Server).invokeFunction,
 	"invokefunctionhistoric":       (*Server).invokeFunctionHistoric,
@@ -251,6 +305,8 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"invokescripthistoric":         (*Server).invokescripthistoric,
 	"invokecontractverify":         (*Server).invokeContractVerify,
 	"invokecontractverifyhistoric": (*Server).invokeContractVerifyHistoric,
+	"listcontractstorageusage":     (*Server).listContractStorageUsage,
+	"resetnativestats":             (*Server).resetNativeStats,
 	"sendrawtransaction":           (*Server).sendrawtransaction,
 	"submitblock":                  (*Server).submitBlock,
 	"submitnotaryrequest":          (*Server).submitNotaryRequest,
@@ -259,6 +315,7 @@ var rpcHandlers = map[string]func(*Server, params.Params) (any, *neorpc.Error){
 	"traverseiterator":             (*Server).traverseIterator,
 	"validateaddress":              (*Server).validateAddress,
 	"verifyproof":                  (*Server).verifyProof,
+	"verifytxproof":                (*Server).verifyTxProof,
 }
 
 var rpcWsHandlers = map[string]func(*Server, params.Params, *subscriber) (any, *neorpc.Error){
@@ -280,6 +337,13 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 			conf.SessionPoolSize = defaultSessionPoolSize
 			log.Info("SessionPoolSize is not set or wrong, setting default value", zap.Int("SessionPoolSize", defaultSessionPoolSize))
 		}
+		if conf.SessionMaxLifetime <= 0 {
+			conf.SessionMaxLifetime = defaultSessionMaxLifetime
+			if conf.SessionMaxLifetime < conf.SessionExpirationTime {
+				conf.SessionMaxLifetime = conf.SessionExpirationTime
+			}
+			log.Info("SessionMaxLifetime is not set or wrong, setting default value", zap.Int("SessionMaxLifetime", conf.SessionMaxLifetime))
+		}
 	}
 	if conf.MaxIteratorResultItems <= 0 {
 		conf.MaxIteratorResultItems = config.DefaultMaxIteratorResultItems
@@ -309,13 +373,64 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 		conf.MaxWebSocketClients = defaultMaxWebSocketClients
 		log.Info("MaxWebSocketClients is not set or wrong, setting default value", zap.Int("MaxWebSocketClients", defaultMaxWebSocketClients))
 	}
+	if conf.MaxWebSocketReplayBlocks == 0 {
+		conf.MaxWebSocketReplayBlocks = defaultMaxWebSocketReplayBlocks
+		log.Info("MaxWebSocketReplayBlocks is not set or wrong, setting default value", zap.Int("MaxWebSocketReplayBlocks", defaultMaxWebSocketReplayBlocks))
+	}
 	var oracleWrapped = new(atomic.Value)
 	if orc != nil {
 		oracleWrapped.Store(orc)
 	}
+	corsOrigins := corsAllowedOrigins(conf)
+	if corsOrigins != nil && conf.CORSMaxAge <= 0 {
+		conf.CORSMaxAge = config.DefaultCORSMaxAge
+		log.Info("CORSMaxAge is not set or wrong, setting default value", zap.Int("CORSMaxAge", config.DefaultCORSMaxAge))
+	}
+	if conf.EnableCompression && conf.CompressionThreshold <= 0 {
+		conf.CompressionThreshold = config.DefaultCompressionThreshold
+		log.Info("CompressionThreshold is not set or wrong, setting default value", zap.Int("CompressionThreshold", config.DefaultCompressionThreshold))
+	}
+	var auth *adminAuth
+	if len(conf.AdminPublicKeys) != 0 {
+		if conf.AdminRequestTTL <= 0 {
+			conf.AdminRequestTTL = config.DefaultAdminRequestTTL
+			log.Info("AdminRequestTTL is not set or wrong, setting default value", zap.Duration("AdminRequestTTL", config.DefaultAdminRequestTTL))
+		}
+		pubs := make(keys.PublicKeys, 0, len(conf.AdminPublicKeys))
+		for _, k := range conf.AdminPublicKeys {
+			pub, err := keys.NewPublicKeyFromString(k)
+			if err != nil {
+				log.Error("invalid admin public key, ignoring it", zap.String("key", k), zap.Error(err))
+				continue
+			}
+			pubs = append(pubs, pub)
+		}
+		auth = newAdminAuth(pubs, conf.AdminRequestTTL)
+	}
+	var limiter *rateLimiter
+	if rl := &conf.RateLimit; rl.Enabled {
+		if rl.Rate <= 0 {
+			rl.Rate = config.DefaultRateLimitRate
+			log.Info("RateLimit.Rate is not set or wrong, setting default value", zap.Float64("Rate", config.DefaultRateLimitRate))
+		}
+		if rl.Burst <= 0 {
+			rl.Burst = config.DefaultRateLimitBurst
+			log.Info("RateLimit.Burst is not set or wrong, setting default value", zap.Int("Burst", config.DefaultRateLimitBurst))
+		}
+		proxies := make([]*net.IPNet, 0, len(rl.TrustedProxies))
+		for _, p := range rl.TrustedProxies {
+			n, err := parseTrustedProxy(p)
+			if err != nil {
+				log.Error("invalid trusted proxy, ignoring it", zap.String("proxy", p), zap.Error(err))
+				continue
+			}
+			proxies = append(proxies, n)
+		}
+		limiter = newRateLimiter(rl.Rate, rl.Burst, rl.MethodWeights, proxies)
+	}
 	var wsOriginChecker func(*http.Request) bool
-	if conf.EnableCORSWorkaround {
-		wsOriginChecker = func(_ *http.Request) bool { return true }
+	if corsOrigins != nil {
+		wsOriginChecker = func(r *http.Request) bool { return checkWsOrigin(corsOrigins, r) }
 	}
 
 	addrs := conf.Addresses
@@ -345,6 +460,9 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 
 		chain:            chain,
 		config:           conf,
+		adminAuth:        auth,
+		corsOrigins:      corsOrigins,
+		rateLimiter:      limiter,
 		wsReadLimit:      int64(protoCfg.MaxBlockSize*4)/3 + 1024, // Enough for Base64-encoded content of `submitblock` and `submitp2pnotaryrequest`.
 		upgrader:         websocket.Upgrader{CheckOrigin: wsOriginChecker},
 		network:          protoCfg.Magic,
@@ -369,6 +487,12 @@ func New(chain Ledger, conf config.RPC, coreServer *network.Server,
 	}
 }
 
+// IsStarted returns true if the server is started and not yet shut down. It
+// always returns false for disabled server.
+func (s *Server) IsStarted() bool {
+	return s.started.Load()
+}
+
 // Name returns service name.
 func (s *Server) Name() string {
 	return "rpc"
@@ -387,6 +511,7 @@ func (s *Server) Start() {
 		return
 	}
 
+	s.upgrader.Error = s.writeWsUpgradeError
 	go s.handleSubEvents()
 
 	for _, srv := range s.http {
@@ -489,10 +614,20 @@ func (s *Server) SetOracleHandler(orc OracleHandler) {
 	s.oracle.Store(orc)
 }
 
+// SetNotaryHandler allows to update P2PNotary service handler used by the
+// Server, nil disables notary-related admin methods.
+func (s *Server) SetNotaryHandler(ntr NotaryHandler) {
+	s.notary.Store(&ntr)
+}
+
 func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Request) {
 	// Restrict request body before further processing.
 	httpRequest.Body = http.MaxBytesReader(w, httpRequest.Body, int64(s.config.MaxRequestBodyBytes))
 	req := params.NewRequest()
+	var client string
+	if s.rateLimiter != nil {
+		client = s.rateLimiter.clientIP(httpRequest)
+	}
 
 	if httpRequest.URL.Path == "/ws" && httpRequest.Method == "GET" {
 		// Technically there is a race between this check and
@@ -506,6 +641,7 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 			s.writeHTTPErrorResponse(
 				params.NewIn(),
 				w,
+				httpRequest,
 				neorpc.NewInternalServerError("websocket users limit reached"),
 			)
 			return
@@ -522,14 +658,17 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.subscribers[subscr] = true
 		s.subsLock.Unlock()
 		go s.handleWsWrites(ws, resChan, subChan)
-		s.handleWsReads(ws, resChan, subscr)
+		s.handleWsReads(ws, resChan, subscr, client)
 		return
 	}
 
-	if httpRequest.Method == "OPTIONS" && s.config.EnableCORSWorkaround { // Preflight CORS.
-		setCORSOriginHeaders(w.Header())
+	if httpRequest.Method == "OPTIONS" && s.corsOrigins != nil { // Preflight CORS.
+		if !s.setCORSOriginHeaders(w.Header(), httpRequest) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST") // GET for websockets.
-		w.Header().Set("Access-Control-Max-Age", "21600")           // 6 hours.
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(s.config.CORSMaxAge))
 		return
 	}
 
@@ -537,19 +676,34 @@ func (s *Server) handleHTTPRequest(w http.ResponseWriter, httpRequest *http.Requ
 		s.writeHTTPErrorResponse(
 			params.NewIn(),
 			w,
+			httpRequest,
 			neorpc.NewInvalidParamsError(fmt.Sprintf("invalid method '%s', please retry with 'POST'", httpRequest.Method)),
 		)
 		return
 	}
 
-	err := req.DecodeData(httpRequest.Body)
+	body, err := gio.ReadAll(httpRequest.Body)
 	if err != nil {
-		s.writeHTTPErrorResponse(params.NewIn(), w, neorpc.NewParseError(err.Error()))
+		s.writeHTTPErrorResponse(params.NewIn(), w, httpRequest, neorpc.NewParseError(err.Error()))
+		return
+	}
+	var admin bool
+	if s.adminAuth != nil {
+		var authErr *neorpc.Error
+		admin, authErr = s.adminAuth.verify(httpRequest.Header, body)
+		if authErr != nil {
+			s.writeHTTPErrorResponse(params.NewIn(), w, httpRequest, authErr)
+			return
+		}
+	}
+	err = req.DecodeData(gio.NopCloser(bytes.NewReader(body)))
+	if err != nil {
+		s.writeHTTPErrorResponse(params.NewIn(), w, httpRequest, neorpc.NewParseError(err.Error()))
 		return
 	}
 
-	resp := s.handleRequest(req, nil)
-	s.writeHTTPServerResponse(req, w, resp)
+	resp := s.handleRequest(req, nil, admin, client)
+	s.writeHTTPServerResponse(req, w, httpRequest, resp)
 }
 
 // RegisterLocal performs local client registration.
@@ -561,23 +715,52 @@ func (s *Server) RegisterLocal(ctx context.Context, events chan<- neorpc.Notific
 	s.subsLock.Unlock()
 	go s.handleLocalNotifications(ctx, events, subChan, subscr)
 	return func(req *neorpc.Request) (*neorpc.Response, error) {
-		return s.handleInternal(req, subscr)
+		resp, err := s.handleInternal(req, subscr)
+		s.startReplays(subscr)
+		return resp, err
 	}
 }
 
-func (s *Server) handleRequest(req *params.Request, sub *subscriber) abstractResult {
+// handleRequest handles a single request or a batch, admin is true for
+// requests that are allowed to call admin methods, client is the client IP
+// address used for rate limiting.
+func (s *Server) handleRequest(req *params.Request, sub *subscriber, admin bool, client string) abstractResult {
 	if req.In != nil {
 		req.In.Method = escapeForLog(req.In.Method) // No valid method name will be changed by it.
-		return s.handleIn(req.In, sub)
+		if resp, ok := s.checkRateLimit(req.In, admin, client); !ok {
+			return resp
+		}
+		return s.handleIn(req.In, sub, admin)
 	}
 	resp := make(abstractBatch, len(req.Batch))
 	for i, in := range req.Batch {
 		in.Method = escapeForLog(in.Method) // No valid method name will be changed by it.
-		resp[i] = s.handleIn(&in, sub)
+		if r, ok := s.checkRateLimit(&in, admin, client); !ok {
+			resp[i] = r
+			continue
+		}
+		resp[i] = s.handleIn(&in, sub, admin)
 	}
 	return resp
 }
 
+// checkRateLimit charges the client for the request, it returns an error
+// response if the client has exceeded its limit. Signed admin requests are
+// not limited.
+func (s *Server) checkRateLimit(req *params.In, admin bool, client string) (abstract, bool) {
+	if s.rateLimiter == nil || admin {
+		return abstract{}, true
+	}
+	ok, wait := s.rateLimiter.allow(client, req.Method)
+	if ok {
+		return abstract{}, true
+	}
+	addThrottledMetric(req.Method)
+	resp := s.packResponse(req, nil, newRateLimitError(wait))
+	resp.retryAfter = wait
+	return resp, false
+}
+
 // handleInternal is an experimental interface to handle client requests directly.
 func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.Response, error) {
 	var (
@@ -605,6 +788,12 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 
 	rpcRes.Error = neorpc.NewMethodNotFoundError(fmt.Sprintf("method %q not supported", req.Method))
 	handler, ok := rpcHandlers[req.Method]
+	if ok && neorpc.IsAdminMethod(req.Method) {
+		// Local clients are trusted.
+		if rpcRes.Error = s.checkAdmin(true); rpcRes.Error != nil {
+			return rpcRes, nil
+		}
+	}
 	if ok {
 		res, rpcRes.Error = handler(s, reqParams)
 	} else if sub != nil {
@@ -623,7 +812,7 @@ func (s *Server) handleInternal(req *neorpc.Request, sub *subscriber) (*neorpc.R
 	return rpcRes, nil
 }
 
-func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
+func (s *Server) handleIn(req *params.In, sub *subscriber, admin bool) abstract {
 	var res any
 	var resErr *neorpc.Error
 	if req.JSONRPC != neorpc.JSONRPCVersion {
@@ -641,6 +830,11 @@ func (s *Server) handleIn(req *params.In, sub *subscriber) abstract {
 
 	resErr = neorpc.NewMethodNotFoundError(fmt.Sprintf("method %q not supported", req.Method))
 	handler, ok := rpcHandlers[req.Method]
+	if ok && neorpc.IsAdminMethod(req.Method) {
+		if resErr = s.checkAdmin(admin); resErr != nil {
+			return s.packResponse(req, nil, resErr)
+		}
+	}
 	if ok {
 		res, resErr = handler(s, reqParams)
 	} else if sub != nil {
@@ -729,7 +923,7 @@ drainloop:
 	}
 }
 
-func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber) {
+func (s *Server) handleWsReads(ws *websocket.Conn, resChan chan<- abstractResult, subscr *subscriber, client string) {
 	ws.SetReadLimit(s.wsReadLimit)
 	err := ws.SetReadDeadline(time.Now().Add(wsPongLimit))
 	ws.SetPongHandler(func(string) error { return ws.SetReadDeadline(time.Now().Add(wsPongLimit)) })
@@ -740,7 +934,8 @@ requestloop:
 		if err != nil {
 			break
 		}
-		res := s.handleRequest(req, subscr)
+		// Websocket requests can't be signed.
+		res := s.handleRequest(req, subscr, false, client)
 		res.RunForErrors(func(jsonErr *neorpc.Error) {
 			s.logRequestError(req, jsonErr)
 		})
@@ -749,6 +944,8 @@ requestloop:
 			break requestloop
 		case resChan <- res:
 		}
+		// Replayed events are to be sent after the subscription response.
+		s.startReplays(subscr)
 	}
 	s.dropSubscriber(subscr)
 	close(resChan)
@@ -758,6 +955,11 @@ requestloop:
 func (s *Server) dropSubscriber(subscr *subscriber) {
 	s.subsLock.Lock()
 	delete(s.subscribers, subscr)
+	for _, e := range subscr.feeds {
+		if e.replay != nil {
+			close(e.replay.stop)
+		}
+	}
 	s.subsLock.Unlock()
 	s.subsCounterLock.Lock()
 	for _, e := range subscr.feeds {
@@ -864,6 +1066,19 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 		}
 		hfs[cfgHf] = height
 	}
+	var natives []result.NativeContract
+	for _, c := range s.chain.GetNatives() {
+		height, ok := s.chain.GetNativeActivationHeight(c.Hash)
+		if !ok {
+			continue
+		}
+		natives = append(natives, result.NativeContract{
+			ID:               c.ID,
+			Name:             c.Manifest.Name,
+			Hash:             c.Hash,
+			ActivationHeight: height,
+		})
+	}
 	return &result.Version{
 		TCPPort:   port,
 		Nonce:     s.coreServer.ID(),
@@ -871,12 +1086,13 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 		RPC: result.RPC{
 			MaxIteratorResultItems: s.config.MaxIteratorResultItems,
 			SessionEnabled:         s.config.SessionEnabled,
+			Capabilities:           s.capabilities(),
 		},
 		Protocol: result.Protocol{
 			AddressVersion:              address.NEO3Prefix,
 			Network:                     cfg.Magic,
-			MillisecondsPerBlock:        int(cfg.TimePerBlock / time.Millisecond),
-			MaxTraceableBlocks:          cfg.MaxTraceableBlocks,
+			MillisecondsPerBlock:        int(s.chain.GetMillisecondsPerBlock()),
+			MaxTraceableBlocks:          s.chain.GetMaxTraceableBlocks(),
 			MaxValidUntilBlockIncrement: cfg.MaxValidUntilBlockIncrement,
 			MaxTransactionsPerBlock:     cfg.MaxTransactionsPerBlock,
 			MemoryPoolMaxTransactions:   cfg.MemPoolSize,
@@ -888,18 +1104,69 @@ func (s *Server) getVersion(_ params.Params) (any, *neorpc.Error) {
 			P2PSigExtensions:  cfg.P2PSigExtensions,
 			StateRootInHeader: cfg.StateRootInHeader,
 			ValidatorsHistory: cfg.ValidatorsHistory,
+			NativeContracts:   natives,
 		},
 	}, nil
 }
 
-func (s *Server) getPeers(_ params.Params) (any, *neorpc.Error) {
+// capabilities returns the list of RPC server capabilities reported by
+// getversion.
+func (s *Server) capabilities() []string {
+	var res = []string{result.RPCCapabilitySubscriptions, result.RPCCapabilityFeeEstimation}
+	if s.config.SessionEnabled {
+		res = append(res, result.RPCCapabilitySessions)
+	}
+	if !s.chain.GetConfig().Ledger.KeepOnlyLatestState {
+		res = append(res, result.RPCCapabilityHistoric)
+	}
+	if s.chain.P2PSigExtensionsEnabled() {
+		res = append(res, result.RPCCapabilityNotary)
+	}
+	if s.config.EnableAdminMethods {
+		res = append(res, result.RPCCapabilityAdmin)
+	}
+	return res
+}
+
+func (s *Server) getPeers(reqParams params.Params) (any, *neorpc.Error) {
+	verbose, _ := reqParams.Value(0).GetBoolean()
 	peers := result.NewGetPeers()
 	peers.AddUnconnected(s.coreServer.UnconnectedPeers())
-	peers.AddConnected(s.coreServer.ConnectedPeers())
+	if verbose {
+		for _, st := range s.coreServer.PeersStats() {
+			peers.AddConnectedWithStats(st.Address, peerStatsToResult(st))
+		}
+	} else {
+		peers.AddConnected(s.coreServer.ConnectedPeers())
+	}
 	peers.AddBad(s.coreServer.BadPeers())
 	return peers, nil
 }
 
+// peerStatsToResult converts network peer statistics into the RPC result form.
+func peerStatsToResult(st network.PeerStats) *result.PeerStats {
+	var res = &result.PeerStats{
+		UserAgent: st.UserAgent,
+		Age:       uint64(time.Since(st.ConnectedAt).Milliseconds()),
+		Commands:  make(map[string]result.PeerCommandStats, len(st.Commands)),
+	}
+	if !st.LastSent.IsZero() {
+		res.LastSent = uint64(st.LastSent.UnixMilli())
+	}
+	if !st.LastReceived.IsZero() {
+		res.LastReceived = uint64(st.LastReceived.UnixMilli())
+	}
+	for cmd, c := range st.Commands {
+		res.Commands[network.CommandName(cmd)] = result.PeerCommandStats{
+			SentBytes:        c.SentBytes,
+			SentMessages:     c.SentMessages,
+			ReceivedBytes:    c.ReceivedBytes,
+			ReceivedMessages: c.ReceivedMessages,
+		}
+	}
+	return res
+}
+
 func (s *Server) getRawMempool(reqParams params.Params) (any, *neorpc.Error) {
 	verbose, _ := reqParams.Value(0).GetBoolean()
 	mp := s.chain.GetMemPool()
@@ -942,11 +1209,82 @@ func (s *Server) calculateNetworkFee(reqParams params.Params) (any, *neorpc.Erro
 	if err != nil {
 		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
 	}
+	var res result.FeeEstimation
+	if respErr := s.estimateNetworkFee(tx, &res); respErr != nil {
+		return 0, respErr
+	}
+	return result.NetworkFee{Value: res.NetworkFee}, nil
+}
+
+// estimateFees implements the `estimatefees` RPC call (NeoGo extension). It
+// takes a script, signers (with witness verification scripts for standard
+// accounts) and optional transaction attributes and returns both system and
+// network fees of the transaction with the given script.
+func (s *Server) estimateFees(reqParams params.Params) (any, *neorpc.Error) {
+	if len(reqParams) < 2 {
+		return nil, neorpc.ErrInvalidParams
+	}
+	tx, _, respErr := s.getInvokeScriptParams(reqParams[:2])
+	if respErr != nil {
+		return nil, respErr
+	}
+	if len(tx.Scripts) == 0 {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "no signers")
+	}
+	if len(reqParams) > 2 && !reqParams[2].IsNull() {
+		if err := json.Unmarshal(reqParams[2].RawMessage, &tx.Attributes); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid attributes: %s", err))
+		}
+		if len(tx.Attributes)+len(tx.Signers) > transaction.MaxAttributes {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "too many attributes")
+		}
+	}
+	inv, respErr := s.runScriptInVM(trigger.Application, tx.Script, util.Uint160{}, tx, nil, false)
+	if respErr != nil {
+		return nil, respErr
+	}
+	// Fresh transaction with the system fee set is used for the network fee
+	// calculation since the one used for invocation might have its hash
+	// cached.
+	ntx := transaction.New(tx.Script, inv.GasConsumed)
+	ntx.Signers = tx.Signers
+	ntx.Attributes = tx.Attributes
+	ntx.Scripts = tx.Scripts
+	var res = result.FeeEstimation{
+		Invocation: inv,
+		SystemFee:  inv.GasConsumed,
+	}
+	if respErr := s.estimateNetworkFee(ntx, &res); respErr != nil {
+		return nil, respErr
+	}
+	res.TotalFee = res.SystemFee + res.NetworkFee
+	return res, nil
+}
+
+// estimateNetworkFee calculates the network fee of the given transaction
+// filling network fee and size fields of the given FeeEstimation. Witnesses
+// may have no invocation scripts, dummy ones are used for verification then.
+func (s *Server) estimateNetworkFee(tx *transaction.Transaction, res *result.FeeEstimation) *neorpc.Error {
+	if tx.HasAttribute(transaction.SponsorT) {
+		// Sponsor is one of the signers, so its witness is accounted for
+		// below, but the attribute itself is only valid after the hardfork.
+		ic, err := s.chain.GetTestVM(trigger.Application, tx, nil)
+		if err != nil {
+			return neorpc.NewInternalServerError(fmt.Sprintf("failed to create test VM: %s", err))
+		}
+		enabled := ic.IsHardforkEnabled(config.HFNeoGoExtensions)
+		ic.Finalize()
+		if !enabled {
+			return neorpc.WrapErrorWithData(neorpc.ErrInvalidAttribute, fmt.Sprintf("Sponsor attribute is not allowed before %s hardfork", config.HFNeoGoExtensions))
+		}
+	}
 	hashablePart, err := tx.EncodeHashableFields()
 	if err != nil {
-		return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("failed to compute tx size: %s", err))
+		return neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("failed to compute tx size: %s", err))
 	}
 	size := len(hashablePart) + io.GetVarSize(len(tx.Signers))
+	res.BaseSize = size
+	res.Witnesses = make([]result.WitnessFee, len(tx.Signers))
 	var (
 		netFee int64
 		// Verification GAS cost can't exceed this policy.
@@ -958,47 +1296,62 @@ func (s *Server) calculateNetworkFee(reqParams params.Params) (any, *neorpc.Erro
 	}
 	for i, signer := range tx.Signers {
 		w := tx.Scripts[i]
-		if len(w.InvocationScript) == 0 { // No invocation provided, try to infer one.
-			var paramz []manifest.Parameter
-			if len(w.VerificationScript) == 0 { // Contract-based verification
+		wf := &res.Witnesses[i]
+		wf.Account = signer.Account
+		var paramz []manifest.Parameter
+		if len(w.VerificationScript) == 0 { // Contract-based verification
+			wf.Type = result.WitnessContract
+			if len(w.InvocationScript) == 0 {
 				cs := s.chain.GetContractState(signer.Account)
 				if cs == nil {
-					return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidVerificationFunction, fmt.Sprintf("signer %d has no verification script and no deployed contract", i))
+					return neorpc.WrapErrorWithData(neorpc.ErrInvalidVerificationFunction, fmt.Sprintf("signer %d has no verification script and no deployed contract", i))
 				}
 				md := cs.Manifest.ABI.GetMethod(manifest.MethodVerify, -1)
 				if md == nil || md.ReturnType != smartcontract.BoolType {
-					return 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidVerificationFunction, fmt.Sprintf("signer %d has no verify method in deployed contract", i))
+					return neorpc.WrapErrorWithData(neorpc.ErrInvalidVerificationFunction, fmt.Sprintf("signer %d has no verify method in deployed contract", i))
 				}
 				paramz = md.Parameters // Might as well have none params and it's OK.
-			} else { // Regular signature verification.
-				if vm.IsSignatureContract(w.VerificationScript) {
-					paramz = []manifest.Parameter{{Type: smartcontract.SignatureType}}
-				} else if nSigs, _, ok := vm.ParseMultiSigContract(w.VerificationScript); ok {
-					paramz = make([]manifest.Parameter, nSigs)
-					for j := 0; j < nSigs; j++ {
-						paramz[j] = manifest.Parameter{Type: smartcontract.SignatureType}
-					}
-				}
 			}
+		} else if vm.IsSignatureContract(w.VerificationScript) { // Regular signature verification.
+			wf.Type = result.WitnessSignature
+			wf.Signatures = 1
+			paramz = []manifest.Parameter{{Type: smartcontract.SignatureType}}
+		} else if nSigs, _, ok := vm.ParseMultiSigContract(w.VerificationScript); ok {
+			wf.Type = result.WitnessMultisig
+			wf.Signatures = nSigs
+			paramz = make([]manifest.Parameter, nSigs)
+			for j := 0; j < nSigs; j++ {
+				paramz[j] = manifest.Parameter{Type: smartcontract.SignatureType}
+			}
+		} else {
+			wf.Type = result.WitnessScript
+		}
+		if len(w.InvocationScript) == 0 { // No invocation provided, try to infer one.
 			inv := io.NewBufBinWriter()
 			for _, p := range paramz {
 				p.Type.EncodeDefaultValue(inv.BinWriter)
 			}
 			if inv.Err != nil {
-				return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to create dummy invocation script (signer %d): %s", i, inv.Err.Error()))
+				return neorpc.NewInternalServerError(fmt.Sprintf("failed to create dummy invocation script (signer %d): %s", i, inv.Err.Error()))
 			}
 			w.InvocationScript = inv.Bytes()
 		}
 		gasConsumed, err := s.chain.VerifyWitness(signer.Account, tx, &w, gasLimit)
 		if err != nil && !errors.Is(err, core.ErrInvalidSignature) {
-			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidSignature, err.Error())
+			return neorpc.WrapErrorWithData(neorpc.ErrInvalidSignature, err.Error())
 		}
 		gasLimit -= gasConsumed
 		netFee += gasConsumed
-		size += io.GetVarSize(w.VerificationScript) + io.GetVarSize(w.InvocationScript)
-	}
-	netFee += int64(size)*s.chain.FeePerByte() + s.chain.CalculateAttributesFee(tx)
-	return result.NetworkFee{Value: netFee}, nil
+		wf.InvocationSize = io.GetVarSize(w.InvocationScript)
+		wf.VerificationSize = io.GetVarSize(w.VerificationScript)
+		wf.VerificationFee = gasConsumed
+		size += wf.InvocationSize + wf.VerificationSize
+	}
+	res.Size = size
+	res.SizeFee = int64(size) * s.chain.FeePerByte()
+	res.AttributesFee = s.chain.CalculateAttributesFee(tx)
+	res.NetworkFee = netFee + res.SizeFee + res.AttributesFee
+	return nil
 }
 
 // getApplicationLog returns the contract log based on the specified txid or blockid.
@@ -1020,13 +1373,31 @@ func (s *Server) getApplicationLog(reqParams params.Params) (any, *neorpc.Error)
 		}
 	}
 
-	appExecResults, err := s.chain.GetAppExecResults(hash, trigger.All)
+	appExecResults, err := s.getAppExecResults(hash, trigger.All)
 	if err != nil {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownScriptContainer, fmt.Sprintf("failed to locate application log: %s", err))
 	}
 	return result.NewApplicationLog(hash, appExecResults, trig), nil
 }
 
+// getAppExecResults returns execution results of the given script container
+// (either from the DB or from the archive).
+func (s *Server) getAppExecResults(hash util.Uint256, trig trigger.Type) ([]state.AppExecResult, error) {
+	appExecResults, err := s.chain.GetAppExecResults(hash, trig)
+	// Removed transactions are not found and removed blocks have no execution
+	// results at all (only headers are kept for them), but they can still be
+	// available from the archive.
+	if errors.Is(err, storage.ErrKeyNotFound) || (err == nil && len(appExecResults) == 0) {
+		archived, aErr := s.chain.GetArchivedAppExecResults(hash, trig)
+		if aErr == nil {
+			appExecResults, err = archived, nil
+		} else if !errors.Is(aErr, storage.ErrKeyNotFound) {
+			err = aErr
+		}
+	}
+	return appExecResults, err
+}
+
 func (s *Server) getNEP11Tokens(h util.Uint160, acc util.Uint160, bw *io.BufBinWriter) ([]stackitem.Item, string, int, error) {
 	items, finalize, err := s.invokeReadOnlyMulti(bw, h, []string{"tokensOf", "symbol", "decimals"}, [][]any{{acc}, nil, nil})
 	if err != nil {
@@ -1191,17 +1562,33 @@ func (s *Server) getNEP17Balances(ps params.Params) (any, *neorpc.Error) {
 		return nil, neorpc.NewInternalServerError(fmt.Sprintf("Failed to get NEP-17 last updated block: %s", err.Error()))
 	}
 	stateSyncPoint := lastUpdated[math.MinInt32]
+	// Indexed balances are used if available, balanceOf is only invoked for
+	// unreliable tokens then.
+	indexed, unreliable, indexErr := s.chain.GetNEP17Balances(u)
 	bw := io.NewBufBinWriter()
 	for _, h := range s.chain.GetNEP17Contracts() {
-		balance, sym, dec, err := s.getNEP17TokenBalance(h, u, bw)
-		if err != nil {
+		var (
+			balance *big.Int
+			sym     string
+			dec     int
+		)
+		cs := s.chain.GetContractState(h)
+		if cs == nil {
 			continue
 		}
-		if balance.Sign() == 0 {
+		if indexErr == nil && !unreliable[cs.ID] {
+			balance = indexed[cs.ID]
+			if balance == nil {
+				continue
+			}
+			sym, dec, err = s.getNEP17TokenInfo(h, bw)
+		} else {
+			balance, sym, dec, err = s.getNEP17TokenBalance(h, u, bw)
+		}
+		if err != nil {
 			continue
 		}
-		cs := s.chain.GetContractState(h)
-		if cs == nil {
+		if balance.Sign() == 0 {
 			continue
 		}
 		lub, ok := lastUpdated[cs.ID]
@@ -1278,18 +1665,36 @@ func (s *Server) getNEP17TokenBalance(h util.Uint160, acc util.Uint160, bw *io.B
 	if err != nil {
 		return nil, "", 0, fmt.Errorf("unexpected `balanceOf` result type: %w", err)
 	}
-	sym, err := stackitem.ToString(items[1])
+	sym, dec, err := parseNEP17TokenInfo(items[1], items[2])
 	if err != nil {
-		return nil, "", 0, fmt.Errorf("`symbol` return value error: %w", err)
+		return nil, "", 0, err
 	}
-	dec, err := items[2].TryInteger()
+	return res, sym, dec, nil
+}
+
+func (s *Server) getNEP17TokenInfo(h util.Uint160, bw *io.BufBinWriter) (string, int, error) {
+	items, finalize, err := s.invokeReadOnlyMulti(bw, h, []string{"symbol", "decimals"}, [][]any{nil, nil})
 	if err != nil {
-		return nil, "", 0, fmt.Errorf("`decimals` return value error: %w", err)
+		return "", 0, err
+	}
+	finalize()
+	return parseNEP17TokenInfo(items[0], items[1])
+}
+
+// parseNEP17TokenInfo checks `symbol` and `decimals` invocation results.
+func parseNEP17TokenInfo(symItem, decItem stackitem.Item) (string, int, error) {
+	sym, err := stackitem.ToString(symItem)
+	if err != nil {
+		return "", 0, fmt.Errorf("`symbol` return value error: %w", err)
+	}
+	dec, err := decItem.TryInteger()
+	if err != nil {
+		return "", 0, fmt.Errorf("`decimals` return value error: %w", err)
 	}
 	if !dec.IsInt64() || dec.Sign() == -1 || dec.Int64() > math.MaxInt32 {
-		return nil, "", 0, errors.New("`decimals` returned a bad integer")
+		return "", 0, errors.New("`decimals` returned a bad integer")
 	}
-	return res, sym, int(dec.Int64()), nil
+	return sym, int(dec.Int64()), nil
 }
 
 func (s *Server) getNEP11DTokenBalance(h util.Uint160, acc util.Uint160, id []byte, bw *io.BufBinWriter) (*big.Int, error) {
@@ -1609,6 +2014,67 @@ func (s *Server) verifyProof(ps params.Params) (any, *neorpc.Error) {
 	return vp, nil
 }
 
+// getVerifiableState returns contract storage item value along with its MPT
+// proof and the validated state root this proof is made for.
+func (s *Server) getVerifiableState(ps params.Params) (any, *neorpc.Error) {
+	if s.chain.GetConfig().StateRootInHeader {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, "state roots are not signed separately with StateRootInHeader")
+	}
+	csHash, respErr := s.contractScriptHashFromParam(ps.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	key, err := ps.Value(1).GetBytesBase64()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid key")
+	}
+	var height = s.chain.GetStateModule().CurrentValidatedHeight()
+	if len(ps) > 2 {
+		h, err := ps.Value(2).GetInt()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid height")
+		}
+		if err := checkUint32(h); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
+		}
+		height = uint32(h)
+	}
+	root, err := s.chain.GetStateModule().GetStateRoot(height)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownStateRoot, fmt.Sprintf("failed to get stateroot for height %d: %s", height, err))
+	}
+	if len(root.Witness) == 0 {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownStateRoot, fmt.Sprintf("stateroot for height %d is not validated", height))
+	}
+	if respErr = s.checkStateRootSupported(root.Root); respErr != nil {
+		return nil, respErr
+	}
+	cs, respErr := s.getHistoricalContractState(root.Root, csHash)
+	if respErr != nil {
+		return nil, respErr
+	}
+	skey := makeStorageKey(cs.ID, key)
+	proof, err := s.chain.GetStateModule().GetStateProof(root.Root, skey)
+	if err != nil {
+		if errors.Is(err, mpt.ErrNotFound) {
+			return nil, neorpc.ErrUnknownStorageItem
+		}
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get proof: %s", err))
+	}
+	val, ok := mpt.VerifyProof(root.Root, skey, proof)
+	if !ok {
+		return nil, neorpc.NewInternalServerError("failed to verify proof")
+	}
+	return &result.VerifiableState{
+		Value: val,
+		Proof: &result.ProofWithKey{
+			Key:   skey,
+			Proof: proof,
+		},
+		Root: root,
+	}, nil
+}
+
 func (s *Server) getState(ps params.Params) (any, *neorpc.Error) {
 	root, respErr := s.getStateRootFromParam(ps.Value(0))
 	if respErr != nil {
@@ -1730,39 +2196,79 @@ func (s *Server) getStateRootFromParam(p *params.Param) (util.Uint256, *neorpc.E
 	if err != nil {
 		return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid stateroot")
 	}
+	return root, s.checkStateRootSupported(root)
+}
+
+// getKeptStateRootFromParam retrieves state root hash from the provided
+// parameter that can be either a stateroot hash or a block index and checks
+// whether MPT state for this stateroot is kept by the node.
+func (s *Server) getKeptStateRootFromParam(p *params.Param) (util.Uint256, *neorpc.Error) {
+	var root util.Uint256
+
+	height, respErr := s.blockHeightFromParam(p)
+	switch {
+	case respErr == nil:
+		sr, err := s.chain.GetStateModule().GetStateRoot(height)
+		if err != nil {
+			return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrUnknownStateRoot, fmt.Sprintf("failed to get stateroot for height %d: %s", height, err))
+		}
+		root = sr.Root
+		if respErr = s.checkStateRootSupported(root); respErr != nil {
+			return util.Uint256{}, respErr
+		}
+	case respErr.Code == neorpc.ErrUnknownHeightCode:
+		return util.Uint256{}, respErr
+	default:
+		root, respErr = s.getStateRootFromParam(p)
+		if respErr != nil {
+			return util.Uint256{}, respErr
+		}
+	}
+	if !s.chain.GetStateModule().HasState(root) {
+		return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("state %s is not kept by the node (removed or unknown)", root.StringLE()))
+	}
+	return root, nil
+}
+
+// checkStateRootSupported checks whether MPT states are supported for the
+// given stateroot.
+func (s *Server) checkStateRootSupported(root util.Uint256) *neorpc.Error {
 	if s.chain.GetConfig().Ledger.KeepOnlyLatestState {
 		curr, err := s.chain.GetStateModule().GetStateRoot(s.chain.BlockHeight())
 		if err != nil {
-			return util.Uint256{}, neorpc.NewInternalServerError(fmt.Sprintf("failed to get current stateroot: %s", err))
+			return neorpc.NewInternalServerError(fmt.Sprintf("failed to get current stateroot: %s", err))
 		}
 		if !curr.Root.Equals(root) {
-			return util.Uint256{}, neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("state-based methods are not supported for old states: %s", errKeepOnlyLatestState))
+			return neorpc.WrapErrorWithData(neorpc.ErrUnsupportedState, fmt.Sprintf("state-based methods are not supported for old states: %s", errKeepOnlyLatestState))
 		}
 	}
-	return root, nil
+	return nil
 }
 
 func (s *Server) findStorage(reqParams params.Params) (any, *neorpc.Error) {
-	id, prefix, start, take, respErr := s.getFindStorageParams(reqParams)
+	id, prefix, start, after, take, respErr := s.getFindStorageParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.findStorageInternal(id, prefix, start, take, s.chain)
+	return s.findStorageInternal(id, prefix, start, after, take, s.chain, bytes.Compare)
 }
 
-func (s *Server) findStorageInternal(id int32, prefix []byte, start, take int, seeker ContractStorageSeeker) (any, *neorpc.Error) {
+// findStorageInternal returns up to take storage items with the given prefix
+// skipping the first start items or all items with keys (without prefix) that
+// go before or equal to after (if it's not nil) in seeker's order defined by
+// cmp.
+func (s *Server) findStorageInternal(id int32, prefix []byte, start int, after []byte, take int, seeker ContractStorageSeeker, cmp func(a, b []byte) int) (any, *neorpc.Error) {
 	var (
-		i   int
-		end = start + take
+		i int
 		// Result is an empty list if a contract state is not found as it is in C# implementation.
 		res = &result.FindStorage{Results: make([]result.KeyValue, 0)}
 	)
 	seeker.SeekStorage(id, prefix, func(k, v []byte) bool {
-		if i < start {
+		if i < start || (after != nil && cmp(k, after) <= 0) {
 			i++
 			return true
 		}
-		if i < end {
+		if len(res.Results) < take {
 			res.Results = append(res.Results, result.KeyValue{
 				Key:   bytes.Clone(append(prefix, k...)), // Don't strip prefix, as it is done in C#.
 				Value: v,
@@ -1774,26 +2280,29 @@ func (s *Server) findStorageInternal(id int32, prefix []byte, start, take int, s
 		return false
 	})
 	res.Next = i
+	if res.Truncated && len(res.Results) != 0 {
+		res.Continuation = makeFindStorageToken(res.Results[len(res.Results)-1].Key)
+	}
 	return res, nil
 }
 
 func (s *Server) findStorageHistoric(reqParams params.Params) (any, *neorpc.Error) {
-	root, respErr := s.getStateRootFromParam(reqParams.Value(0))
+	root, respErr := s.getKeptStateRootFromParam(reqParams.Value(0))
 	if respErr != nil {
 		return nil, respErr
 	}
 	if len(reqParams) < 2 {
 		return nil, neorpc.ErrInvalidParams
 	}
-	id, prefix, start, take, respErr := s.getFindStorageParams(reqParams[1:], root)
+	id, prefix, start, after, take, respErr := s.getFindStorageParams(reqParams[1:], root)
 	if respErr != nil {
 		return nil, respErr
 	}
 
-	return s.findStorageInternal(id, prefix, start, take, mptStorageSeeker{
+	return s.findStorageInternal(id, prefix, start, after, take, mptStorageSeeker{
 		root:   root,
 		module: s.chain.GetStateModule(),
-	})
+	}, compareMPTKeys)
 }
 
 // mptStorageSeeker is an auxiliary structure that implements ContractStorageSeeker interface.
@@ -1807,28 +2316,75 @@ func (s mptStorageSeeker) SeekStorage(id int32, prefix []byte, cont func(k, v []
 	s.module.SeekStates(s.root, key, cont)
 }
 
-func (s *Server) getFindStorageParams(reqParams params.Params, root ...util.Uint256) (int32, []byte, int, int, *neorpc.Error) {
+// compareMPTKeys compares keys in the order of MPT traversal which is the
+// same as lexicographical order except that the key goes after all keys it's
+// a prefix of.
+func compareMPTKeys(a, b []byte) int {
+	n := len(a)
+	if len(b) < n {
+		n = len(b)
+	}
+	if c := bytes.Compare(a[:n], b[:n]); c != 0 {
+		return c
+	}
+	return len(b) - len(a)
+}
+
+// getFindStorageParams returns contract ID, prefix, start index, continuation
+// key (without prefix) and the maximum number of items for findstorage*
+// handlers. Start parameter can be either an index or a continuation token.
+func (s *Server) getFindStorageParams(reqParams params.Params, root ...util.Uint256) (int32, []byte, int, []byte, int, *neorpc.Error) {
 	if len(reqParams) < 2 {
-		return 0, nil, 0, 0, neorpc.ErrInvalidParams
+		return 0, nil, 0, nil, 0, neorpc.ErrInvalidParams
 	}
 	id, respErr := s.contractIDFromParam(reqParams.Value(0), root...)
 	if respErr != nil {
-		return 0, nil, 0, 0, respErr
+		return 0, nil, 0, nil, 0, respErr
 	}
 
 	prefix, err := reqParams.Value(1).GetBytesBase64()
 	if err != nil {
-		return 0, nil, 0, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
+		return 0, nil, 0, nil, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid prefix: %s", err))
 	}
 
-	var skip int
+	var (
+		skip  int
+		after []byte
+	)
 	if len(reqParams) > 2 {
 		skip, err = reqParams.Value(2).GetInt()
 		if err != nil {
-			return 0, nil, 0, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid start: %s", err))
+			after, err = parseFindStorageToken(reqParams.Value(2), prefix)
+			if err != nil {
+				return 0, nil, 0, nil, 0, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid start: %s", err))
+			}
 		}
 	}
-	return id, prefix, skip, s.config.MaxFindStorageResultItems, nil
+	return id, prefix, skip, after, s.config.MaxFindStorageResultItems, nil
+}
+
+// makeFindStorageToken returns findstorage* continuation token for the given
+// key (including prefix). Token version byte makes it distinguishable from
+// numeric start index (base64-encoded token always starts with 'A').
+func makeFindStorageToken(key []byte) string {
+	return base64.StdEncoding.EncodeToString(append([]byte{findStorageTokenVersion}, key...))
+}
+
+// parseFindStorageToken returns the key (without prefix) stored in the
+// findstorage* continuation token.
+func parseFindStorageToken(p *params.Param, prefix []byte) ([]byte, error) {
+	token, err := p.GetStringStrict()
+	if err != nil {
+		return nil, errors.New("neither an index nor a continuation token")
+	}
+	b, err := base64.StdEncoding.DecodeString(token)
+	if err != nil || len(b) == 0 || b[0] != findStorageTokenVersion {
+		return nil, errors.New("invalid continuation token")
+	}
+	if !bytes.HasPrefix(b[1:], prefix) {
+		return nil, errors.New("continuation token doesn't match prefix")
+	}
+	return b[1+len(prefix):], nil
 }
 
 func (s *Server) getHistoricalContractState(root util.Uint256, csHash util.Uint160) (*state.Contract, *neorpc.Error) {
@@ -1851,10 +2407,21 @@ func (s *Server) getStateHeight(_ params.Params) (any, *neorpc.Error) {
 	if s.chain.GetConfig().StateRootInHeader {
 		stateHeight = height - 1
 	}
-	return &result.StateHeight{
+	res := &result.StateHeight{
 		Local:     height,
 		Validated: stateHeight,
-	}, nil
+	}
+	if p, ok := s.coreServer.StateSyncProgress(); ok {
+		res.StateSync = &result.StateSync{
+			Point:       p.Point,
+			Headers:     p.Headers,
+			BlockHeight: p.BlockHeight,
+			Blocks:      p.Blocks,
+			MPTNodes:    p.MPTNodes,
+			MPT:         p.MPT,
+		}
+	}
+	return res, nil
 }
 
 func (s *Server) getStateRoot(ps params.Params) (any, *neorpc.Error) {
@@ -1985,6 +2552,67 @@ func (s *Server) getTransactionHeight(ps params.Params) (any, *neorpc.Error) {
 	return height, nil
 }
 
+// getTxProof returns Merkle proof of the transaction inclusion into the block.
+func (s *Server) getTxProof(ps params.Params) (any, *neorpc.Error) {
+	h, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.ErrInvalidParams
+	}
+
+	_, height, err := s.chain.GetTransaction(h)
+	if err != nil || height == math.MaxUint32 {
+		return nil, neorpc.ErrUnknownTransaction
+	}
+	block, err := s.chain.GetBlock(s.chain.GetHeaderHash(height))
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	path, index, err := block.MerkleProof(h)
+	if err != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to get proof: %s", err))
+	}
+	return &result.TransactionProof{
+		BlockHash:  block.Hash(),
+		BlockIndex: block.Index,
+		MerkleRoot: block.MerkleRoot,
+		Index:      index,
+		Path:       path,
+	}, nil
+}
+
+// verifyTxProof checks Merkle proof of the transaction inclusion into the block
+// with the given hash.
+func (s *Server) verifyTxProof(ps params.Params) (any, *neorpc.Error) {
+	blockHash, err := ps.Value(0).GetUint256()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid block hash")
+	}
+	txHash, err := ps.Value(1).GetUint256()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid transaction hash")
+	}
+	index, err := ps.Value(2).GetInt()
+	if err != nil || index < 0 || index >= block.MaxTransactionsPerBlock {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid index")
+	}
+	pathParam, err := ps.Value(3).GetArray()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid path")
+	}
+	path := make([]util.Uint256, len(pathParam))
+	for i := range pathParam {
+		path[i], err = pathParam[i].GetUint256()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid path element %d", i))
+		}
+	}
+	header, err := s.chain.GetHeader(blockHash)
+	if err != nil {
+		return nil, neorpc.ErrUnknownBlock
+	}
+	return hash.VerifyMerkleProof(header.MerkleRoot, txHash, path, uint32(index)), nil
+}
+
 // getContractState returns contract state (contract information, according to the contract script hash,
 // contract id or native contract name).
 func (s *Server) getContractState(reqParams params.Params) (any, *neorpc.Error) {
@@ -1999,10 +2627,165 @@ func (s *Server) getContractState(reqParams params.Params) (any, *neorpc.Error)
 	return cs, nil
 }
 
+// getContracts returns a page of contract states (including native ones)
+// matching the optional filter ordered by contract hashes. Optional start
+// parameter is the hash of the contract to start after, optional count
+// limits the number of returned contracts.
+func (s *Server) getContracts(reqParams params.Params) (any, *neorpc.Error) {
+	var (
+		filter neorpc.ContractFilter
+		after  *util.Uint160
+		count  = s.config.MaxFindResultItems
+	)
+	if p := reqParams.Value(0); p != nil && !p.IsNull() {
+		jd := json.NewDecoder(bytes.NewReader(p.RawMessage))
+		jd.DisallowUnknownFields()
+		if err := jd.Decode(&filter); err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid filter: %s", err))
+		}
+	}
+	if p := reqParams.Value(1); p != nil && !p.IsNull() {
+		h, err := p.GetUint160FromHex()
+		if err != nil {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("invalid start: %s", err))
+		}
+		after = &h
+	}
+	if p := reqParams.Value(2); p != nil {
+		n, err := p.GetInt()
+		if err != nil || n <= 0 {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "invalid count")
+		}
+		if n < count {
+			count = n
+		}
+	}
+
+	var (
+		res     = &result.Contracts{Contracts: make([]state.Contract, 0)}
+		prefix  = []byte{native.PrefixContract}
+		itemErr error
+	)
+	s.chain.SeekStorage(native.ManagementContractID, prefix, func(k, v []byte) bool {
+		if after != nil && bytes.Compare(k, after.BytesBE()) <= 0 {
+			return true
+		}
+		cs := new(state.Contract)
+		if err := stackitem.DeserializeConvertible(v, cs); err != nil {
+			itemErr = err
+			return false
+		}
+		if !filter.Matches(cs) {
+			return true
+		}
+		if len(res.Contracts) == count {
+			res.Truncated = true
+			return false
+		}
+		res.Contracts = append(res.Contracts, *cs)
+		return true
+	})
+	if itemErr != nil {
+		return nil, neorpc.NewInternalServerError(fmt.Sprintf("failed to decode contract state: %s", itemErr))
+	}
+	if res.Truncated {
+		res.Next = &res.Contracts[len(res.Contracts)-1].Hash
+	}
+	return res, nil
+}
+
+// getContractStorageUsage returns storage usage statistics of the contract
+// specified by its hash, ID or native contract name.
+func (s *Server) getContractStorageUsage(reqParams params.Params) (any, *neorpc.Error) {
+	scriptHash, respErr := s.contractScriptHashFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	cs := s.chain.GetContractState(scriptHash)
+	if cs == nil {
+		return nil, neorpc.ErrUnknownContract
+	}
+	u, err := s.chain.GetContractStorageUsage(cs.ID)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrStorageUsageUnavailable, err.Error())
+	}
+	return result.ContractStorageUsage{
+		ID:    cs.ID,
+		Hash:  cs.Hash,
+		Items: u.Items,
+		Size:  u.Size,
+	}, nil
+}
+
+// listContractStorageUsage returns storage usage statistics of contracts
+// using the most storage space.
+func (s *Server) listContractStorageUsage(reqParams params.Params) (any, *neorpc.Error) {
+	var limit = defaultStorageUsageLimit
+	if p := reqParams.Value(0); p != nil {
+		l, err := p.GetInt()
+		if err != nil {
+			return nil, neorpc.ErrInvalidParams
+		}
+		if l <= 0 || l > maxStorageUsageLimit {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, fmt.Sprintf("limit should be in [1, %d] range", maxStorageUsageLimit))
+		}
+		limit = l
+	}
+	top, err := s.chain.GetTopContractStorageUsage(limit)
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrStorageUsageUnavailable, err.Error())
+	}
+	res := make([]result.ContractStorageUsage, 0, len(top))
+	for _, u := range top {
+		h, err := s.chain.GetContractScriptHash(u.ID)
+		if err != nil {
+			continue // Contract can be destroyed at this point.
+		}
+		res = append(res, result.ContractStorageUsage{
+			ID:    u.ID,
+			Hash:  h,
+			Items: u.Items,
+			Size:  u.Size,
+		})
+	}
+	return res, nil
+}
+
 func (s *Server) getNativeContracts(_ params.Params) (any, *neorpc.Error) {
 	return s.chain.GetNatives(), nil
 }
 
+// getNativeStats returns node-local native contract method invocation
+// statistics.
+func (s *Server) getNativeStats(_ params.Params) (any, *neorpc.Error) {
+	stats, err := s.chain.GetNativeCallStats()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrNativeCallStatsDisabled, err.Error())
+	}
+	res := make([]result.NativeCallStats, 0, len(stats))
+	for _, st := range stats {
+		res = append(res, result.NativeCallStats{
+			Contract:   st.Contract,
+			Hash:       st.Hash,
+			Method:     st.Method,
+			ParamCount: st.ParamCount,
+			Calls:      st.Calls,
+			GAS:        st.GAS,
+		})
+	}
+	return res, nil
+}
+
+// resetNativeStats resets node-local native contract method invocation
+// statistics, it's an admin method.
+func (s *Server) resetNativeStats(_ params.Params) (any, *neorpc.Error) {
+	err := s.chain.ResetNativeCallStats()
+	if err != nil {
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrNativeCallStatsDisabled, err.Error())
+	}
+	return true, nil
+}
+
 // getBlockSysFee returns the system fees of the block, based on the specified index.
 func (s *Server) getBlockSysFee(reqParams params.Params) (any, *neorpc.Error) {
 	num, err := s.blockHeightFromParam(reqParams.Value(0))
@@ -2024,6 +2807,47 @@ func (s *Server) getBlockSysFee(reqParams params.Params) (any, *neorpc.Error) {
 	return blockSysFee, nil
 }
 
+// getBlockProfile returns node-local execution profile of the block with the
+// specified index.
+func (s *Server) getBlockProfile(reqParams params.Params) (any, *neorpc.Error) {
+	num, respErr := s.blockHeightFromParam(reqParams.Value(0))
+	if respErr != nil {
+		return nil, respErr
+	}
+	bp, err := s.chain.GetBlockProfile(num)
+	if err != nil {
+		if errors.Is(err, core.ErrBlockProfilingDisabled) {
+			return nil, neorpc.WrapErrorWithData(neorpc.ErrBlockProfilingDisabled, err.Error())
+		}
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrUnknownBlockProfile, err.Error())
+	}
+	res := &result.BlockProfile{
+		Index:        bp.Index,
+		Hash:         bp.Hash,
+		Time:         int64(bp.Time),
+		GAS:          bp.GAS,
+		Transactions: make([]result.TxProfile, 0, len(bp.Transactions)),
+		Contracts:    make([]result.ContractProfile, 0, len(bp.Contracts)),
+	}
+	for _, tp := range bp.Transactions {
+		res.Transactions = append(res.Transactions, result.TxProfile{
+			Hash:     tp.Hash,
+			Time:     int64(tp.Time),
+			GAS:      tp.GAS,
+			VMState:  tp.VMState,
+			Syscalls: tp.Syscalls,
+		})
+	}
+	for _, cp := range bp.Contracts {
+		res.Contracts = append(res.Contracts, result.ContractProfile{
+			Hash:  cp.Hash,
+			Calls: cp.Calls,
+			GAS:   cp.GAS,
+		})
+	}
+	return res, nil
+}
+
 // getBlockHeader returns the corresponding block header information according to the specified script hash.
 func (s *Server) getBlockHeader(reqParams params.Params) (any, *neorpc.Error) {
 	param := reqParams.Value(0)
@@ -2255,13 +3079,24 @@ func (s *Server) getInvokeScriptParams(reqParams params.Params) (*transaction.Tr
 	return tx, verbose, nil
 }
 
-// invokeContractVerify implements the `invokecontractverify` RPC call.
+// invokeContractVerify implements the `invokecontractverify` RPC call. The
+// optional fourth parameter (block index, block hash or stateroot hash) is a
+// NeoGo extension making it an equivalent of `invokecontractverifyhistoric`.
 func (s *Server) invokeContractVerify(reqParams params.Params) (any, *neorpc.Error) {
+	var nextH *uint32
+	if len(reqParams) > 3 {
+		h, respErr := s.getHistoricParams(reqParams[3:])
+		if respErr != nil {
+			return nil, respErr
+		}
+		nextH = &h
+		reqParams = reqParams[:3]
+	}
 	scriptHash, tx, invocationScript, respErr := s.getInvokeContractVerifyParams(reqParams)
 	if respErr != nil {
 		return nil, respErr
 	}
-	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nil, false)
+	return s.runScriptInVM(trigger.Verification, invocationScript, scriptHash, tx, nextH, false)
 }
 
 // invokeContractVerifyHistoric implements the `invokecontractverifyhistoric` RPC call.
@@ -2302,7 +3137,7 @@ func (s *Server) getInvokeContractVerifyParams(reqParams params.Params) (util.Ui
 	invocationScript := bw.Bytes()
 
 	tx := &transaction.Transaction{Script: []byte{byte(opcode.RET)}} // need something in script
-	if len(reqParams) > 2 {
+	if len(reqParams) > 2 && !reqParams[2].IsNull() {
 		signers, witnesses, err := reqParams[2].GetSignersWithWitnesses()
 		if err != nil {
 			return util.Uint160{}, nil, nil, neorpc.ErrInvalidParams
@@ -2408,6 +3243,18 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		faultException = err.Error()
 	}
 	items := ic.VM.Estack().ToArray()
+	// Diagnostics are collected before finalization that releases the
+	// storage snapshot used to tell added items from changed ones.
+	var diag *result.InvokeDiag
+	tree := ic.VM.GetInvocationTree()
+	if tree != nil {
+		usage := ic.VM.LimitUsage()
+		diag = &result.InvokeDiag{
+			Invocations: tree.Calls,
+			Changes:     storage.BatchToOperations(ic.DAO.GetBatch()),
+			Limits:      &usage,
+		}
+	}
 	sess := s.postProcessExecStack(items)
 	var id uuid.UUID
 
@@ -2422,7 +3269,8 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 		id = uuid.New()
 		sessionID := id.String()
 		sess.finalize = ic.Finalize
-		sess.timer = time.AfterFunc(time.Second*time.Duration(s.config.SessionExpirationTime), func() {
+		sess.deadline = time.Now().Add(time.Second * time.Duration(s.config.SessionMaxLifetime))
+		sess.timer = time.AfterFunc(s.sessionExpiration(&sess.deadline), func() {
 			s.sessionsLock.Lock()
 			defer s.sessionsLock.Unlock()
 			if len(s.sessions) == 0 {
@@ -2448,14 +3296,6 @@ func (s *Server) runScriptInVM(t trigger.Type, script []byte, contractScriptHash
 	} else {
 		ic.Finalize()
 	}
-	var diag *result.InvokeDiag
-	tree := ic.VM.GetInvocationTree()
-	if tree != nil {
-		diag = &result.InvokeDiag{
-			Invocations: tree.Calls,
-			Changes:     storage.BatchToOperations(ic.DAO.GetBatch()),
-		}
-	}
 	notifications := ic.Notifications
 	if notifications == nil {
 		notifications = make([]state.NotificationEvent, 0)
@@ -2545,10 +3385,15 @@ func (s *Server) traverseIterator(reqParams params.Params) (any, *neorpc.Error)
 		s.sessionsLock.Unlock()
 		return nil, neorpc.ErrUnknownSession
 	}
+	if !time.Now().Before(session.deadline) {
+		// It's to be removed by the timer.
+		s.sessionsLock.Unlock()
+		return nil, neorpc.ErrUnknownSession
+	}
 	session.iteratorsLock.Lock()
 	// Perform `till` update only after session.iteratorsLock is taken in order to have more
 	// precise session lifetime.
-	session.timer.Reset(time.Second * time.Duration(s.config.SessionExpirationTime))
+	session.timer.Reset(s.sessionExpiration(&session.deadline))
 	s.sessionsLock.Unlock()
 
 	var (
@@ -2578,6 +3423,16 @@ func (s *Server) traverseIterator(reqParams params.Params) (any, *neorpc.Error)
 	return result, nil
 }
 
+// sessionExpiration returns the time left until session expiration, it's
+// SessionExpirationTime unless the session deadline is closer.
+func (s *Server) sessionExpiration(deadline *time.Time) time.Duration {
+	var exp = time.Second * time.Duration(s.config.SessionExpirationTime)
+	if left := time.Until(*deadline); left < exp {
+		exp = left
+	}
+	return exp
+}
+
 func (s *Server) terminateSession(reqParams params.Params) (any, *neorpc.Error) {
 	if !s.config.SessionEnabled {
 		return nil, neorpc.ErrSessionsDisabled
@@ -2644,6 +3499,11 @@ func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 		return result.RelayResult{
 			Hash: hash,
 		}, nil
+	case errors.Is(err, core.ErrTxParked):
+		return result.RelayResult{
+			Hash:   hash,
+			Parked: true,
+		}, nil
 	case errors.Is(err, core.ErrTxExpired):
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrExpiredTransaction, err.Error())
 	case errors.Is(err, core.ErrAlreadyExists) || errors.Is(err, core.ErrInvalidBlockIndex):
@@ -2666,6 +3526,8 @@ func getRelayResult(err error, hash util.Uint256) (any, *neorpc.Error) {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrInsufficientFunds, err.Error())
 	case errors.Is(err, core.ErrInvalidSignature):
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidSignature, err.Error())
+	case errors.Is(err, core.ErrTxAdmission):
+		return nil, neorpc.WrapErrorWithData(neorpc.ErrTxAdmissionRejected, err.Error())
 	default:
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrVerificationFailed, err.Error())
 	}
@@ -2730,12 +3592,15 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 	if err != nil || event == neorpc.MissedEventID {
 		return nil, neorpc.ErrInvalidParams
 	}
+	if event == neorpc.CaughtUpEventID {
+		return nil, neorpc.ErrInvalidParams
+	}
 	if event == neorpc.NotaryRequestEventID && !s.chain.P2PSigExtensionsEnabled() {
 		return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, "P2PSigExtensions are disabled")
 	}
 	// Optional filter.
 	var filter neorpc.SubscriptionFilter
-	if p := reqParams.Value(1); p != nil {
+	if p := reqParams.Value(1); p != nil && !p.IsNull() {
 		param := *p
 		jd := json.NewDecoder(bytes.NewReader(param.RawMessage))
 		jd.DisallowUnknownFields()
@@ -2771,6 +3636,18 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 			return nil, neorpc.WrapErrorWithData(neorpc.ErrInvalidParams, err.Error())
 		}
 	}
+	// Optional starting block for historical events.
+	var rpl *replay
+	if p := reqParams.Value(2); p != nil {
+		from, respErr := s.replayStartFromParam(event, p)
+		if respErr != nil {
+			return nil, respErr
+		}
+		rpl = &replay{
+			from: from,
+			stop: make(chan struct{}),
+		}
+	}
 
 	s.subsLock.Lock()
 	var id int
@@ -2785,6 +3662,15 @@ func (s *Server) subscribe(reqParams params.Params, sub *subscriber) (any, *neor
 	}
 	sub.feeds[id].event = event
 	sub.feeds[id].filter = filter
+	if rpl != nil {
+		rpl.id = id
+		rpl.feed = feed{event: event, filter: filter}
+		// Events of subsequent blocks are received via subscription,
+		// they're kept pending until the replay ends.
+		rpl.to = s.chain.BlockHeight()
+		sub.feeds[id].replay = rpl
+		sub.replays = append(sub.replays, rpl)
+	}
 	s.subsLock.Unlock()
 
 	s.subsCounterLock.Lock()
@@ -2849,8 +3735,10 @@ func (s *Server) unsubscribe(reqParams params.Params, sub *subscriber) (any, *ne
 		return nil, neorpc.ErrInvalidParams
 	}
 	event := sub.feeds[id].event
-	sub.feeds[id].event = neorpc.InvalidEventID
-	sub.feeds[id].filter = nil
+	if sub.feeds[id].replay != nil {
+		close(sub.feeds[id].replay.stop)
+	}
+	sub.feeds[id] = feed{}
 	s.subsLock.Unlock()
 
 	s.subsCounterLock.Lock()
@@ -2900,21 +3788,7 @@ func (s *Server) unsubscribeFromChannel(event neorpc.EventID) {
 // handleSubEvents processes Server subscriptions until Shutdown. Upon
 // completion signals to subEventCh channel.
 func (s *Server) handleSubEvents() {
-	var overflowEvent = neorpc.Notification{
-		JSONRPC: neorpc.JSONRPCVersion,
-		Event:   neorpc.MissedEventID,
-		Payload: make([]any, 0),
-	}
-	b, err := json.Marshal(overflowEvent)
-	if err != nil {
-		s.log.Error("fatal: failed to marshal overflow event", zap.Error(err))
-		return
-	}
-	overflowMsg, err := websocket.NewPreparedMessage(websocket.TextMessage, b)
-	if err != nil {
-		s.log.Error("fatal: failed to prepare overflow message", zap.Error(err))
-		return
-	}
+	var err error
 chloop:
 	for {
 		var resp = neorpc.Notification{
@@ -2953,37 +3827,48 @@ chloop:
 			if sub.overflown.Load() {
 				continue
 			}
+			var replaying *replay
 			for i := range sub.feeds {
-				if rpcevent.Matches(sub.feeds[i], &resp) {
-					if msg == nil {
-						b, err = json.Marshal(resp)
-						if err != nil {
-							s.log.Error("failed to marshal notification",
-								zap.Error(err),
-								zap.Stringer("type", resp.Event))
-							break subloop
-						}
-						msg, err = websocket.NewPreparedMessage(websocket.TextMessage, b)
-						if err != nil {
-							s.log.Error("failed to prepare notification message",
-								zap.Error(err),
-								zap.Stringer("type", resp.Event))
-							break subloop
-						}
+				if !rpcevent.Matches(sub.feeds[i], &resp) {
+					continue
+				}
+				if sub.feeds[i].replay != nil {
+					if replaying == nil {
+						replaying = sub.feeds[i].replay
 					}
-					select {
-					case sub.writer <- intEvent{msg, &resp}:
-					default:
-						sub.overflown.Store(true)
-						// MissedEvent is to be delivered eventually.
-						go func(sub *subscriber) {
-							sub.writer <- intEvent{overflowMsg, &overflowEvent}
-							sub.overflown.Store(false)
-						}(sub)
+					continue
+				}
+				if msg == nil {
+					msg, err = prepareEvent(&resp)
+					if err != nil {
+						s.log.Error("failed to prepare notification message",
+							zap.Error(err),
+							zap.Stringer("type", resp.Event))
+						break subloop
 					}
-					// The message is sent only once per subscriber.
-					break
 				}
+				s.trySendEvent(sub, intEvent{msg, &resp})
+				// The message is sent only once per subscriber.
+				replaying = nil
+				break
+			}
+			// Replaying feeds get the event after historical ones
+			// unless it's already sent via some live feed.
+			if replaying != nil && !replaying.overflown.Load() {
+				if len(replaying.pending) >= notificationBufSize {
+					replaying.overflown.Store(true)
+					continue
+				}
+				if msg == nil {
+					msg, err = prepareEvent(&resp)
+					if err != nil {
+						s.log.Error("failed to prepare notification message",
+							zap.Error(err),
+							zap.Stringer("type", resp.Event))
+						break subloop
+					}
+				}
+				replaying.pending = append(replaying.pending, intEvent{msg, &resp})
 			}
 		}
 		s.subsLock.RUnlock()
@@ -3080,35 +3965,39 @@ func (s *Server) logRequestError(r *params.Request, jsonErr *neorpc.Error) {
 }
 
 // writeHTTPErrorResponse writes an error response to the ResponseWriter.
-func (s *Server) writeHTTPErrorResponse(r *params.In, w http.ResponseWriter, jsonErr *neorpc.Error) {
+func (s *Server) writeHTTPErrorResponse(r *params.In, w http.ResponseWriter, httpRequest *http.Request, jsonErr *neorpc.Error) {
 	resp := s.packResponse(r, nil, jsonErr)
-	s.writeHTTPServerResponse(&params.Request{In: r}, w, resp)
+	s.writeHTTPServerResponse(&params.Request{In: r}, w, httpRequest, resp)
 }
 
-func setCORSOriginHeaders(h http.Header) {
-	h.Set("Access-Control-Allow-Origin", "*")
-	h.Set("Access-Control-Allow-Headers", "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With")
+// writeWsUpgradeError writes an error response for the failed websocket
+// connection upgrade with the given HTTP status code, the failure itself is
+// logged by the caller of Upgrade.
+func (s *Server) writeWsUpgradeError(w http.ResponseWriter, httpRequest *http.Request, status int, reason error) {
+	resp := s.packResponse(params.NewIn(), nil, neorpc.NewInvalidRequestError(reason.Error()))
+	err := s.writeHTTPResponse(w, httpRequest, status, resp)
+	if err != nil {
+		s.log.Error("Error encountered while encoding websocket upgrade error response", zap.Error(err))
+	}
 }
 
-func (s *Server) writeHTTPServerResponse(r *params.Request, w http.ResponseWriter, resp abstractResult) {
+func (s *Server) writeHTTPServerResponse(r *params.Request, w http.ResponseWriter, httpRequest *http.Request, resp abstractResult) {
 	// Errors can happen in many places and we can only catch ALL of them here.
 	resp.RunForErrors(func(jsonErr *neorpc.Error) {
 		s.logRequestError(r, jsonErr)
 	})
-	w.Header().Set("Content-Type", "application/json; charset=utf-8")
-	if s.config.EnableCORSWorkaround {
-		setCORSOriginHeaders(w.Header())
-	}
+	status := http.StatusOK
 	if r.In != nil {
 		resp := resp.(abstract)
 		if resp.Error != nil {
-			w.WriteHeader(getHTTPCodeForError(resp.Error))
+			status = getHTTPCodeForError(resp.Error)
+		}
+		if resp.retryAfter > 0 {
+			w.Header().Set("Retry-After", strconv.FormatInt(int64((resp.retryAfter+time.Second-1)/time.Second), 10))
 		}
 	}
 
-	encoder := json.NewEncoder(w)
-	err := encoder.Encode(resp)
-
+	err := s.writeHTTPResponse(w, httpRequest, status, resp)
 	if err != nil {
 		switch {
 		case r.In != nil:
@@ -3122,6 +4011,45 @@ func (s *Server) writeHTTPServerResponse(r *params.Request, w http.ResponseWrite
 	}
 }
 
+// writeHTTPResponse writes JSON-encoded response with the given status code
+// setting CORS headers if needed. The response is compressed if compression
+// is enabled, the client accepts it and the response is big enough.
+func (s *Server) writeHTTPResponse(w http.ResponseWriter, httpRequest *http.Request, status int, resp any) error {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if s.corsOrigins != nil {
+		s.setCORSOriginHeaders(w.Header(), httpRequest)
+	}
+	if !s.config.EnableCompression {
+		w.WriteHeader(status)
+		return json.NewEncoder(w).Encode(resp)
+	}
+
+	w.Header().Add("Vary", "Accept-Encoding")
+	buf := new(bytes.Buffer)
+	err := json.NewEncoder(buf).Encode(resp)
+	if err != nil {
+		return err
+	}
+	var encoding string
+	if buf.Len() >= s.config.CompressionThreshold {
+		encoding = negotiateEncoding(httpRequest.Header.Get("Accept-Encoding"))
+	}
+	if encoding == "" {
+		w.WriteHeader(status)
+		_, err = w.Write(buf.Bytes())
+		return err
+	}
+	w.Header().Set("Content-Encoding", encoding)
+	w.WriteHeader(status)
+	cw := newCompressor(encoding, w)
+	_, err = cw.Write(buf.Bytes())
+	if err != nil {
+		_ = cw.Close()
+		return err
+	}
+	return cw.Close()
+}
+
 // validateAddress verifies that the address is a correct Neo address
 // see https://docs.neo.org/en-us/node/cli/2.9.4/api/validateaddress.html
 func validateAddress(addr any) bool {
@@ -3169,6 +4097,38 @@ func (s *Server) getRawNotaryPool(_ params.Params) (any, *neorpc.Error) {
 	return res, nil
 }
 
+// getNotaryPoolInfo returns main transactions pending completion by the node's
+// P2PNotary service, it's an admin method.
+func (s *Server) getNotaryPoolInfo(_ params.Params) (any, *neorpc.Error) {
+	ntrPtr := s.notary.Load()
+	if ntrPtr == nil || *ntrPtr == nil {
+		return nil, neorpc.ErrNotaryDisabled
+	}
+	var (
+		reqs = (*ntrPtr).PendingRequests()
+		now  = time.Now()
+		res  = &result.NotaryPoolInfo{
+			Height:   s.chain.BlockHeight(),
+			Requests: make([]result.NotaryRequestInfo, len(reqs)),
+		}
+	)
+	for i, r := range reqs {
+		res.Requests[i] = result.NotaryRequestInfo{
+			Hash:                   r.MainHash,
+			ValidUntilBlock:        r.ValidUntilBlock,
+			Fallbacks:              r.Fallbacks,
+			FallbackNotValidBefore: r.FallbackNotValidBefore,
+			SigsCollected:          r.SigsCollected,
+			SigsRequired:           r.SigsRequired,
+			Completed:              r.IsCompleted,
+			Sent:                   r.IsSent,
+			Received:               r.Received.UnixMilli(),
+			Age:                    now.Sub(r.Received).Milliseconds(),
+		}
+	}
+	return res, nil
+}
+
 func (s *Server) getRawNotaryTransaction(reqParams params.Params) (any, *neorpc.Error) {
 	if !s.chain.P2PSigExtensionsEnabled() {
 		return nil, neorpc.NewInternalServerError("P2PSignatureExtensions are disabled")
@@ -2,6 +2,8 @@ package rpcsrv
 
 import (
 	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/base64"
 	"encoding/binary"
@@ -48,12 +50,14 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
 	"github.com/nspcc-dev/neo-go/pkg/vm/invocations"
 	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zapcore"
@@ -232,6 +236,50 @@ var rpcTestCases = map[string][]rpcTestCase{
 			errCode: neorpc.ErrUnknownScriptContainerCode,
 		},
 	},
+	"getcontracts": {
+		{
+			name:   "positive, by name",
+			params: `[{"name": "Rubl"}]`,
+			result: func(e *executor) any { return &result.Contracts{} },
+			check: func(t *testing.T, e *executor, res any) {
+				cs, ok := res.(*result.Contracts)
+				require.True(t, ok)
+				require.Equal(t, 1, len(cs.Contracts))
+				require.Equal(t, testContractHash, cs.Contracts[0].Hash.StringLE())
+				require.False(t, cs.Truncated)
+			},
+		},
+		{
+			name:   "positive, truncated",
+			params: `[null, null, 2]`,
+			result: func(e *executor) any { return &result.Contracts{} },
+			check: func(t *testing.T, e *executor, res any) {
+				cs, ok := res.(*result.Contracts)
+				require.True(t, ok)
+				require.Equal(t, 2, len(cs.Contracts))
+				require.True(t, cs.Truncated)
+				require.Equal(t, cs.Contracts[1].Hash, *cs.Next)
+			},
+		},
+		{
+			name:    "invalid filter",
+			params:  `[{"unknown": "field"}]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid start",
+			params:  `[null, "notahash"]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "invalid count",
+			params:  `[null, null, 0]`,
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+	},
 	"getcontractstate": {
 		{
 			name:   "positive, by hash",
@@ -606,6 +654,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 
 				require.Equal(t, e.chain.BlockHeight(), sh.Local)
 				require.Equal(t, uint32(0), sh.Validated)
+				require.Nil(t, sh.StateSync)
 			},
 		},
 	},
@@ -756,8 +805,9 @@ var rpcTestCases = map[string][]rpcTestCase{
 							Value: []byte("v2"),
 						},
 					},
-					Next:      2,
-					Truncated: true,
+					Next:         2,
+					Truncated:    true,
+					Continuation: "AWFhMTA=",
 				}
 				require.Equal(t, expected, actual)
 			},
@@ -783,6 +833,48 @@ var rpcTestCases = map[string][]rpcTestCase{
 				require.Equal(t, expected, actual)
 			},
 		},
+		{
+			name:   "truncated second page by token",
+			params: fmt.Sprintf(`["%s", "%s", "%s"]`, testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")), "AWFhMTA="),
+			result: func(_ *executor) any { return new(result.FindStorage) },
+			check: func(t *testing.T, e *executor, res any) {
+				actual, ok := res.(*result.FindStorage)
+				require.True(t, ok)
+
+				expected := &result.FindStorage{
+					Results: []result.KeyValue{
+						{
+							Key:   []byte("aa50"),
+							Value: []byte("v3"),
+						},
+					},
+					Next:      3,
+					Truncated: false,
+				}
+				require.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "token for missing key",
+			params: fmt.Sprintf(`["%s", "%s", "%s"]`, testContractHash, base64.StdEncoding.EncodeToString([]byte("aa")), base64.StdEncoding.EncodeToString([]byte("\x01aa2"))),
+			result: func(_ *executor) any { return new(result.FindStorage) },
+			check: func(t *testing.T, e *executor, res any) {
+				actual, ok := res.(*result.FindStorage)
+				require.True(t, ok)
+
+				expected := &result.FindStorage{
+					Results: []result.KeyValue{
+						{
+							Key:   []byte("aa50"),
+							Value: []byte("v3"),
+						},
+					},
+					Next:      3,
+					Truncated: false,
+				}
+				require.Equal(t, expected, actual)
+			},
+		},
 		{
 			name:   "empty prefix",
 			params: fmt.Sprintf(`["%s", ""]`, storageContractHash),
@@ -802,8 +894,9 @@ var rpcTestCases = map[string][]rpcTestCase{
 							Value: []byte{0x01},
 						},
 					},
-					Next:      2,
-					Truncated: true,
+					Next:         2,
+					Truncated:    true,
+					Continuation: "AQEB",
 				}
 				require.Equal(t, expected, actual)
 			},
@@ -854,6 +947,18 @@ var rpcTestCases = map[string][]rpcTestCase{
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
+		{
+			name:    "invalid token version",
+			params:  fmt.Sprintf(`["%s", "", "%s"]`, testContractHash, base64.StdEncoding.EncodeToString([]byte("\x02aa"))),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
+		{
+			name:    "token prefix mismatch",
+			params:  fmt.Sprintf(`["%s", "%s", "AWFhMTA="]`, testContractHash, base64.StdEncoding.EncodeToString([]byte("ab"))),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 	},
 	"findstoragehistoric": {
 		{
@@ -896,8 +1001,9 @@ var rpcTestCases = map[string][]rpcTestCase{
 							Value: []byte("v3"),
 						},
 					},
-					Next:      2,
-					Truncated: true,
+					Next:         2,
+					Truncated:    true,
+					Continuation: "AWFhNTA=",
 				}
 				require.Equal(t, expected, actual)
 			},
@@ -923,6 +1029,53 @@ var rpcTestCases = map[string][]rpcTestCase{
 				require.Equal(t, expected, actual)
 			},
 		},
+		{
+			name:   "truncated second page by token",
+			params: fmt.Sprintf(`["%s","%s", "%s", "AWFhNTA="]`, block20StateRootLE, testContractHash, base64.StdEncoding.EncodeToString([]byte("aa"))),
+			result: func(_ *executor) any { return new(result.FindStorage) },
+			check: func(t *testing.T, e *executor, res any) {
+				actual, ok := res.(*result.FindStorage)
+				require.True(t, ok)
+
+				expected := &result.FindStorage{
+					Results: []result.KeyValue{
+						{
+							Key:   []byte("aa"),
+							Value: []byte("v1"),
+						},
+					},
+					Next:      3,
+					Truncated: false,
+				}
+				require.Equal(t, expected, actual)
+			},
+		},
+		{
+			name:   "by block index",
+			params: fmt.Sprintf(`[20, "%s", "%s"]`, testContractHash, base64.StdEncoding.EncodeToString([]byte("aa"))),
+			result: func(_ *executor) any { return new(result.FindStorage) },
+			check: func(t *testing.T, e *executor, res any) {
+				actual, ok := res.(*result.FindStorage)
+				require.True(t, ok)
+
+				expected := &result.FindStorage{
+					Results: []result.KeyValue{
+						{
+							Key:   []byte("aa10"),
+							Value: []byte("v2"),
+						},
+						{
+							Key:   []byte("aa50"),
+							Value: []byte("v3"),
+						},
+					},
+					Next:         2,
+					Truncated:    true,
+					Continuation: "AWFhNTA=",
+				}
+				require.Equal(t, expected, actual)
+			},
+		},
 		{
 			name:   "empty prefix",
 			params: fmt.Sprintf(`["%s", "%s", ""]`, block20StateRootLE, nnsContractHash),
@@ -942,8 +1095,9 @@ var rpcTestCases = map[string][]rpcTestCase{
 							Value: []byte{0x01},
 						},
 					},
-					Next:      2,
-					Truncated: true,
+					Next:         2,
+					Truncated:    true,
+					Continuation: "AQHunqIsJ+NL0BSPxBCOCPdOj1BIsg==",
 				}
 				require.Equal(t, expected, actual)
 			},
@@ -972,10 +1126,22 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 		{
 			name:    "invalid stateroot",
-			params:  `[12345]`,
+			params:  `["notahash"]`,
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
+		{
+			name:    "unknown height",
+			params:  `[12345]`,
+			fail:    true,
+			errCode: neorpc.ErrUnknownHeightCode,
+		},
+		{
+			name:    "state not kept",
+			params:  fmt.Sprintf(`["%s", "%s", ""]`, util.Uint256{0xab, 0xcd, 0xef}.StringLE(), testContractHash),
+			fail:    true,
+			errCode: neorpc.ErrUnsupportedStateCode,
+		},
 		{
 			name:    "no second parameter",
 			params:  fmt.Sprintf(`["%s"]`, block20StateRootLE),
@@ -1215,6 +1381,17 @@ var rpcTestCases = map[string][]rpcTestCase{
 				}
 			},
 		},
+		{
+			name:   "verbose",
+			params: "[true]",
+			result: func(*executor) any {
+				return &result.GetPeers{
+					Unconnected: []result.Peer{},
+					Connected:   []result.Peer{},
+					Bad:         []result.Peer{},
+				}
+			},
+		},
 	},
 	"getrawtransaction": {
 		{
@@ -1357,6 +1534,21 @@ var rpcTestCases = map[string][]rpcTestCase{
 				require.True(t, resp.Protocol.P2PSigExtensions) // Yeah, notary is enabled.
 				require.False(t, resp.Protocol.StateRootInHeader)
 				require.Equal(t, 0, len(resp.Protocol.ValidatorsHistory))
+
+				natives := e.chain.GetNatives()
+				require.Equal(t, len(natives), len(resp.Protocol.NativeContracts))
+				for i, n := range resp.Protocol.NativeContracts {
+					require.Equal(t, natives[i].Hash, n.Hash)
+					require.Equal(t, natives[i].Manifest.Name, n.Name)
+					require.Equal(t, natives[i].ID, n.ID)
+				}
+				require.ElementsMatch(t, []string{
+					result.RPCCapabilitySubscriptions,
+					result.RPCCapabilityFeeEstimation,
+					result.RPCCapabilitySessions,
+					result.RPCCapabilityHistoric,
+					result.RPCCapabilityNotary,
+				}, resp.RPC.Capabilities)
 			},
 		},
 	},
@@ -1444,6 +1636,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 					Stack:         []stackitem.Item{stackitem.Make("1.2.3.4")},
 					Notifications: []state.NotificationEvent{},
 					Diagnostics: &result.InvokeDiag{
+						Limits:  &vm.LimitUsage{References: 1, PeakReferences: 34, StackItems: 1, PeakStackItems: 6, MaxReferences: vm.MaxStackSize},
 						Changes: []dboper.Operation{},
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
@@ -1563,6 +1756,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 					Stack:         []stackitem.Item{stackitem.Make("1.2.3.4")},
 					Notifications: []state.NotificationEvent{},
 					Diagnostics: &result.InvokeDiag{
+						Limits:  &vm.LimitUsage{References: 1, PeakReferences: 34, StackItems: 1, PeakStackItems: 6, MaxReferences: vm.MaxStackSize},
 						Changes: []dboper.Operation{},
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
@@ -1631,7 +1825,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 		{
 			name:    "bad stateroot",
-			params:  `["` + util.Uint256{1, 2, 3}.StringLE() + `","50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [{"type": "Integer", "value": 1}]]`,
+			params:  `["` + util.Uint256{0xab, 0xcd, 0xef}.StringLE() + `","50befd26fdf6e4d957c11e078b24ebce6291456f", "test", [{"type": "Integer", "value": 1}]]`,
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
@@ -1662,6 +1856,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 					FaultException: "at instruction 0 (ROT): too big index",
 					Notifications:  []state.NotificationEvent{},
 					Diagnostics: &result.InvokeDiag{
+						Limits:  &vm.LimitUsage{References: 0, PeakReferences: 0, StackItems: 0, PeakStackItems: 0, MaxReferences: vm.MaxStackSize},
 						Changes: []dboper.Operation{},
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
@@ -1776,6 +1971,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 					FaultException: "at instruction 0 (ROT): too big index",
 					Notifications:  []state.NotificationEvent{},
 					Diagnostics: &result.InvokeDiag{
+						Limits:  &vm.LimitUsage{References: 0, PeakReferences: 0, StackItems: 0, PeakStackItems: 0, MaxReferences: vm.MaxStackSize},
 						Changes: []dboper.Operation{},
 						Invocations: []*invocations.Tree{{
 							Current: hash.Hash160(script),
@@ -1865,7 +2061,7 @@ var rpcTestCases = map[string][]rpcTestCase{
 		},
 		{
 			name:    "bad stateroot",
-			params:  `["` + util.Uint256{1, 2, 3}.StringLE() + `","UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY="]`,
+			params:  `["` + util.Uint256{0xab, 0xcd, 0xef}.StringLE() + `","UcVrDUhlbGxvLCB3b3JsZCFoD05lby5SdW50aW1lLkxvZ2FsdWY="]`,
 			fail:    true,
 			errCode: neorpc.InvalidParamsCode,
 		},
@@ -1959,6 +2155,42 @@ var rpcTestCases = map[string][]rpcTestCase{
 				assert.Equal(t, false, res.Stack[0].Value().(bool))
 			},
 		},
+		{
+			name:   "positive, with arguments and height",
+			params: fmt.Sprintf(`["%s", [{"type": "String", "value": "good_string"}, {"type": "Integer", "value": "4"}, {"type":"Boolean", "value": false}], null, 10]`, verifyWithArgsContractHash),
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State, res.FaultException)
+				assert.NotEqual(t, 0, res.GasConsumed)
+				assert.Equal(t, true, res.Stack[0].Value().(bool))
+			},
+		},
+		{
+			name:   "positive, with arguments, signers and stateroot",
+			params: fmt.Sprintf(`["%s", [{"type": "String", "value": "invalid_string"}, {"type": "Integer", "value": "5"}, {"type":"Boolean", "value": false}], [{"account":"%s"}], "`+block20StateRootLE+`"]`, verifyWithArgsContractHash, verifyWithArgsContractHash),
+			result: func(e *executor) any { return &result.Invoke{} },
+			check: func(t *testing.T, e *executor, inv any) {
+				res, ok := inv.(*result.Invoke)
+				require.True(t, ok)
+				assert.Equal(t, "HALT", res.State, res.FaultException)
+				assert.NotEqual(t, 0, res.GasConsumed)
+				assert.Equal(t, true, res.Stack[0].Value().(bool))
+			},
+		},
+		{
+			name:    "with arguments, height before deployment",
+			params:  fmt.Sprintf(`["%s", [{"type": "String", "value": "good_string"}, {"type": "Integer", "value": "4"}, {"type":"Boolean", "value": false}], null, 9]`, verifyWithArgsContractHash),
+			fail:    true,
+			errCode: neorpc.ErrUnknownContractCode,
+		},
+		{
+			name:    "invalid height",
+			params:  fmt.Sprintf(`["%s", [], null, "notaheight"]`, verifyWithArgsContractHash),
+			fail:    true,
+			errCode: neorpc.InvalidParamsCode,
+		},
 		{
 			name:    "invalid call args",
 			params:  fmt.Sprintf(`["%s", [{"type":"Map","value":{"key":"value"}}]]`, verifyWithArgsContractHash),
@@ -3330,6 +3562,36 @@ func testRPCProtocol(t *testing.T, doRPCCall func(string, string, *testing.T) []
 			body := doRPCCall(fmt.Sprintf(rpc, rawTx), httpSrv.URL, t)
 			checkErrGetResult(t, body, true, neorpc.ErrInvalidSizeCode)
 		})
+		t.Run("admission filter", func(t *testing.T) {
+			chain, _, httpSrv := initClearServerWithCustomConfig(t, nil)
+
+			tx := newTxWithParams(t, chain, opcode.PUSH1, 10, 1, 1, false)
+			chain.SetTxAdmissionFilter(core.NewSignerDenyList(tx.Signers[0].Account))
+			rawTx := encodeBinaryToString(t, tx)
+			body := doRPCCall(fmt.Sprintf(rpc, rawTx), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.ErrTxAdmissionRejectedCode)
+
+			chain.SetTxAdmissionFilter(nil)
+			body = doRPCCall(fmt.Sprintf(rpc, rawTx), httpSrv.URL, t)
+			checkErrGetResult(t, body, false, 0)
+		})
+		t.Run("parked", func(t *testing.T) {
+			chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+				c.ApplicationConfiguration.MemPoolParking.Enabled = true
+			})
+
+			tx := newTxWithParams(t, chain, opcode.PUSH1, 10, 1, 2, true)
+			rawTx := encodeBinaryToString(t, tx)
+			body := doRPCCall(fmt.Sprintf(rpc, rawTx), httpSrv.URL, t)
+			res := checkErrGetResult(t, body, false, 0)
+			var actual result.RelayResult
+			require.NoError(t, json.Unmarshal(res, &actual))
+			require.Equal(t, result.RelayResult{Hash: tx.Hash(), Parked: true}, actual)
+			require.True(t, chain.GetMemPool().IsParked(tx.Hash()))
+
+			body = doRPCCall(fmt.Sprintf(rpc, rawTx), httpSrv.URL, t)
+			checkErrGetResult(t, body, true, neorpc.ErrAlreadyInPoolCode)
+		})
 		t.Run("mempool OOM", func(t *testing.T) {
 			chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
 				c.ProtocolConfiguration.MemPoolSize = 1
@@ -4139,7 +4401,7 @@ func BenchmarkHandleIn(b *testing.B) {
 				b.FailNow()
 			}
 
-			res := rpcServer.handleIn(in, nil)
+			res := rpcServer.handleIn(in, nil, false)
 			if res.Error != nil {
 				b.FailNow()
 			}
@@ -4197,3 +4459,321 @@ func TestErrorResponseContentType(t *testing.T) {
 	contentType := resp.Header.Get("Content-Type")
 	require.Equal(t, expectedContentType, contentType)
 }
+
+func TestMatchOrigin(t *testing.T) {
+	patterns := []string{"https://example.com", "https://*.neo.org"}
+	for origin, expected := range map[string]string{
+		"https://example.com":        "https://example.com",
+		"HTTPS://Example.com":        "https://example.com",
+		"http://example.com":         "",
+		"https://example.com:8080":   "",
+		"https://sub.example.com":    "",
+		"https://neo.org":            "",
+		"https://docs.neo.org":       "https://*.neo.org",
+		"https://a.b.neo.org":        "https://*.neo.org",
+		"http://docs.neo.org":        "",
+		"https://evil.com/.neo.org":  "",
+		"https://user@evil.neo.org":  "",
+		"https://docs.neo.org.evil":  "",
+		"":                           "",
+		"null":                       "",
+		"https://docs.neo.org:10332": "",
+	} {
+		p, ok := matchOrigin(patterns, origin)
+		require.Equal(t, expected != "", ok, origin)
+		require.Equal(t, expected, p, origin)
+	}
+	p, ok := matchOrigin(append(patterns, "*"), "https://any.com")
+	require.True(t, ok)
+	require.Equal(t, "*", p)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	for accept, expected := range map[string]string{
+		"":                              "",
+		"identity":                      "",
+		"br, *":                         "",
+		"gzip":                          "gzip",
+		"GZIP":                          "gzip",
+		"deflate":                       "deflate",
+		"gzip, deflate":                 "gzip",
+		"deflate, gzip":                 "gzip",
+		"gzip;q=0.5, deflate":           "deflate",
+		"gzip; q=0.9, deflate;q=0.5":    "gzip",
+		"gzip;q=0":                      "",
+		"gzip;q=0, deflate;q=0":         "",
+		"gzip;q=invalid, deflate;q=0.1": "deflate",
+	} {
+		require.Equal(t, expected, negotiateEncoding(accept), accept)
+	}
+}
+
+func TestCORS(t *testing.T) {
+	const allowedHeaders = "Content-Type, Access-Control-Allow-Headers, Authorization, X-Requested-With"
+	request := func(t *testing.T, url string, method string, origin string) *http.Response {
+		var body gio.Reader
+		if method == http.MethodPost {
+			body = strings.NewReader(`{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`)
+		}
+		req, err := http.NewRequest(method, url, body)
+		require.NoError(t, err)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		return resp
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithInMemoryChain(t)
+
+		resp := request(t, httpSrv.URL, http.MethodOptions, "https://example.com")
+		require.NotEqual(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+
+		resp = request(t, httpSrv.URL, http.MethodPost, "https://example.com")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("workaround", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.EnableCORSWorkaround = true
+		})
+
+		resp := request(t, httpSrv.URL, http.MethodOptions, "https://example.com")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+		require.Equal(t, allowedHeaders, resp.Header.Get("Access-Control-Allow-Headers"))
+		require.Equal(t, "GET, POST", resp.Header.Get("Access-Control-Allow-Methods"))
+		require.Equal(t, strconv.Itoa(config.DefaultCORSMaxAge), resp.Header.Get("Access-Control-Max-Age"))
+
+		resp = request(t, httpSrv.URL, http.MethodPost, "https://example.com")
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+	})
+
+	t.Run("allowed origins", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.CORSAllowedOrigins = []string{"https://example.com", "https://*.neo.org"}
+			c.ApplicationConfiguration.RPC.CORSMaxAge = 600
+		})
+
+		for _, origin := range []string{"https://example.com", "https://docs.neo.org"} {
+			resp := request(t, httpSrv.URL, http.MethodOptions, origin)
+			require.Equal(t, http.StatusOK, resp.StatusCode, origin)
+			require.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+			require.Equal(t, "Origin", resp.Header.Get("Vary"))
+			require.Equal(t, allowedHeaders, resp.Header.Get("Access-Control-Allow-Headers"))
+			require.Equal(t, "GET, POST", resp.Header.Get("Access-Control-Allow-Methods"))
+			require.Equal(t, "600", resp.Header.Get("Access-Control-Max-Age"))
+
+			resp = request(t, httpSrv.URL, http.MethodPost, origin)
+			require.Equal(t, http.StatusOK, resp.StatusCode, origin)
+			require.Equal(t, origin, resp.Header.Get("Access-Control-Allow-Origin"))
+		}
+
+		for _, origin := range []string{"https://neo.org", "https://evil.com", ""} {
+			resp := request(t, httpSrv.URL, http.MethodOptions, origin)
+			require.Equal(t, http.StatusForbidden, resp.StatusCode, origin)
+			require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+			require.Empty(t, resp.Header.Get("Access-Control-Allow-Methods"))
+
+			// Request itself is processed, but the browser won't allow
+			// to read the response.
+			resp = request(t, httpSrv.URL, http.MethodPost, origin)
+			require.Equal(t, http.StatusOK, resp.StatusCode, origin)
+			require.Empty(t, resp.Header.Get("Access-Control-Allow-Origin"))
+		}
+	})
+
+	t.Run("websocket", func(t *testing.T) {
+		_, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+			c.ApplicationConfiguration.RPC.CORSAllowedOrigins = []string{"https://*.neo.org"}
+			c.ApplicationConfiguration.RPC.EnableCompression = true
+			c.ApplicationConfiguration.RPC.CompressionThreshold = 1
+		})
+		url := "ws" + strings.TrimPrefix(httpSrv.URL, "http") + "/ws"
+		dialer := websocket.Dialer{HandshakeTimeout: 5 * time.Second}
+
+		for _, origin := range []string{"https://docs.neo.org", ""} {
+			h := http.Header{}
+			if origin != "" {
+				h.Set("Origin", origin)
+			}
+			ws, r, err := dialer.Dial(url, h)
+			require.NoError(t, err, origin)
+			require.NoError(t, r.Body.Close())
+			require.NoError(t, ws.Close())
+		}
+
+		h := http.Header{}
+		h.Set("Origin", "https://evil.com")
+		h.Set("Accept-Encoding", "gzip")
+		_, r, err := dialer.Dial(url, h)
+		require.ErrorIs(t, err, websocket.ErrBadHandshake)
+		defer r.Body.Close()
+		require.Equal(t, http.StatusForbidden, r.StatusCode)
+		require.Equal(t, "application/json; charset=utf-8", r.Header.Get("Content-Type"))
+		require.Equal(t, "gzip", r.Header.Get("Content-Encoding"))
+		require.Empty(t, r.Header.Get("Access-Control-Allow-Origin"))
+		gr, err := gzip.NewReader(r.Body)
+		require.NoError(t, err)
+		var resp neorpc.Response
+		require.NoError(t, json.NewDecoder(gr).Decode(&resp))
+		require.NotNil(t, resp.Error)
+		require.Equal(t, int64(neorpc.InvalidRequestCode), resp.Error.Code)
+	})
+}
+
+func TestCompression(t *testing.T) {
+	const (
+		small = `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+		large = `{"jsonrpc": "2.0", "id": 1, "method": "getnativecontracts", "params": []}`
+	)
+	chain, _, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.EnableCompression = true
+	})
+	request := func(t *testing.T, body string, accept string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, httpSrv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if accept != "" {
+			req.Header.Set("Accept-Encoding", accept)
+		}
+		// Transport doesn't decompress the response if Accept-Encoding is set
+		// explicitly, so it's checked as is.
+		resp, err := (&http.Transport{DisableCompression: true}).RoundTrip(req)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = resp.Body.Close() })
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "Accept-Encoding", resp.Header.Get("Vary"))
+		return resp
+	}
+	checkNatives := func(t *testing.T, r gio.Reader) {
+		var resp struct {
+			Result []state.NativeContract `json:"result"`
+		}
+		require.NoError(t, json.NewDecoder(r).Decode(&resp))
+		require.Equal(t, chain.GetNatives(), resp.Result)
+	}
+
+	t.Run("gzip", func(t *testing.T) {
+		resp := request(t, large, "gzip, deflate")
+		require.Equal(t, "gzip", resp.Header.Get("Content-Encoding"))
+		r, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		checkNatives(t, r)
+	})
+	t.Run("deflate", func(t *testing.T) {
+		resp := request(t, large, "gzip;q=0.5, deflate")
+		require.Equal(t, "deflate", resp.Header.Get("Content-Encoding"))
+		r, err := zlib.NewReader(resp.Body)
+		require.NoError(t, err)
+		checkNatives(t, r)
+	})
+	t.Run("not accepted", func(t *testing.T) {
+		resp := request(t, large, "")
+		require.Empty(t, resp.Header.Get("Content-Encoding"))
+		checkNatives(t, resp.Body)
+	})
+	t.Run("below threshold", func(t *testing.T) {
+		resp := request(t, small, "gzip")
+		require.Empty(t, resp.Header.Get("Content-Encoding"))
+		var res neorpc.Response
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&res))
+		require.Nil(t, res.Error)
+	})
+}
+
+func TestRateLimit(t *testing.T) {
+	const (
+		cheap  = `{"jsonrpc": "2.0", "id": 1, "method": "getblockcount", "params": []}`
+		costly = `{"jsonrpc": "2.0", "id": 1, "method": "getversion", "params": []}`
+		batch  = `[` + cheap + `,` + costly + `,` + cheap + `]`
+	)
+	_, rpcSrv, httpSrv := initClearServerWithCustomConfig(t, func(c *config.Config) {
+		c.ApplicationConfiguration.RPC.RateLimit = config.RateLimit{
+			Enabled:        true,
+			Rate:           0.001, // No refill during the test.
+			Burst:          5,
+			MethodWeights:  map[string]int{"getversion": 3},
+			TrustedProxies: []string{"127.0.0.1"},
+		}
+	})
+	request := func(t *testing.T, body string, forwardedFor string) (*http.Response, []byte) {
+		req, err := http.NewRequest(http.MethodPost, httpSrv.URL, strings.NewReader(body))
+		require.NoError(t, err)
+		if forwardedFor != "" {
+			req.Header.Set(forwardedForHeader, forwardedFor)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := gio.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp, b
+	}
+	checkThrottled := func(t *testing.T, rpcErr *neorpc.Error) {
+		require.NotNil(t, rpcErr)
+		require.Equal(t, int64(neorpc.ErrRateLimitExceededCode), rpcErr.Code)
+		require.Contains(t, rpcErr.Data, "retry after")
+	}
+	checkOK := func(t *testing.T, body string, forwardedFor string) {
+		resp, b := request(t, body, forwardedFor)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		var res neorpc.Response
+		require.NoError(t, json.Unmarshal(b, &res))
+		require.Nil(t, res.Error)
+	}
+	checkHTTPThrottled := func(t *testing.T, body string, forwardedFor string) {
+		resp, b := request(t, body, forwardedFor)
+		require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		require.NotEmpty(t, resp.Header.Get("Retry-After"))
+		var res neorpc.Response
+		require.NoError(t, json.Unmarshal(b, &res))
+		checkThrottled(t, res.Error)
+	}
+	throttledBefore := testutil.ToFloat64(rpcThrottled.WithLabelValues("getblockcount"))
+
+	// 1.1.1.1 spends its burst via HTTP.
+	checkOK(t, costly, "1.1.1.1")
+	checkOK(t, cheap, "1.1.1.1")
+	checkHTTPThrottled(t, costly, "1.1.1.1")
+	checkOK(t, cheap, "1.1.1.1")
+	checkHTTPThrottled(t, cheap, "1.1.1.1")
+
+	// Other clients are not affected, batch elements are limited
+	// individually.
+	resp, b := request(t, batch, "2.2.2.2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var batchRes []neorpc.Response
+	require.NoError(t, json.Unmarshal(b, &batchRes))
+	require.Equal(t, 3, len(batchRes))
+	require.Nil(t, batchRes[0].Error)
+	require.Nil(t, batchRes[1].Error)
+	require.Nil(t, batchRes[2].Error)
+	resp, b = request(t, batch, "2.2.2.2")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, json.Unmarshal(b, &batchRes))
+	for _, r := range batchRes {
+		checkThrottled(t, r.Error)
+	}
+
+	// Websocket requests share the bucket with HTTP ones (the connection is
+	// made directly, so the client is 127.0.0.1).
+	for i := 0; i < 5; i++ {
+		checkOK(t, cheap, "")
+	}
+	var res neorpc.Response
+	require.NoError(t, json.Unmarshal(doRPCCallOverWS(cheap, httpSrv.URL, t), &res))
+	checkThrottled(t, res.Error)
+	checkHTTPThrottled(t, cheap, "")
+
+	require.Equal(t, throttledBefore+5, testutil.ToFloat64(rpcThrottled.WithLabelValues("getblockcount")))
+	require.Equal(t, 3, len(rpcSrv.rateLimiter.buckets))
+}
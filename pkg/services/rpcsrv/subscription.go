@@ -24,11 +24,33 @@ type (
 		// pointing to an EventID is an obvious overkill at the moment, but
 		// that's not for long.
 		feeds [maxFeeds]feed
+		// replays contains historical events replays waiting for the
+		// subscription response to be sent.
+		replays []*replay
 	}
 	// feed stores subscriber's desired event ID with filter.
 	feed struct {
 		event  neorpc.EventID
 		filter neorpc.SubscriptionFilter
+		// replay is set while historical events are being delivered for
+		// this feed.
+		replay *replay
+	}
+	// replay is the state of historical events delivery for a feed.
+	replay struct {
+		id   int
+		feed feed
+		from uint32
+		to   uint32
+		// stop is closed when the feed is unsubscribed.
+		stop chan struct{}
+		// pending contains live events matching the feed that are received
+		// during replay. It's only appended to by the event handling routine
+		// with subsLock read-locked and read by the replay routine with
+		// subsLock locked.
+		pending []intEvent
+		// overflown is set when pending events buffer is full.
+		overflown atomic.Bool
 	}
 )
 
@@ -12,9 +12,14 @@ import (
 	"github.com/nspcc-dev/neo-go/internal/testchain"
 	"github.com/nspcc-dev/neo-go/pkg/config"
 	"github.com/nspcc-dev/neo-go/pkg/core"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/neorpc"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/rpcsrv/params"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/trigger"
 	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
 	"github.com/stretchr/testify/require"
 )
 
@@ -548,6 +553,10 @@ func TestBadSubUnsub(t *testing.T) {
 		"bad (non-string) event": `{"jsonrpc": "2.0", "method": "subscribe", "params": [1], "id": 1}`,
 		"bad (wrong) event":      `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_removed"], "id": 1}`,
 		"missed event":           `{"jsonrpc": "2.0", "method": "subscribe", "params": ["event_missed"], "id": 1}`,
+		"caught up event":        `{"jsonrpc": "2.0", "method": "subscribe", "params": ["subscription_caught_up"], "id": 1}`,
+		"block starting block":   `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", null, 0], "id": 1}`,
+		"bad starting block":     `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", null, "zero"], "id": 1}`,
+		"future starting block":  `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_executed", null, 1000], "id": 1}`,
 		"block invalid filter":   `{"jsonrpc": "2.0", "method": "subscribe", "params": ["block_added", 1], "id": 1}`,
 		"tx filter 1":            `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", 1], "id": 1}`,
 		"tx filter 2":            `{"jsonrpc": "2.0", "method": "subscribe", "params": ["transaction_added", {"state": "HALT"}], "id": 1}`,
@@ -699,3 +708,271 @@ func TestFilteredSubscriptions_InvalidFilter(t *testing.T) {
 		})
 	}
 }
+
+// getBlocksExecutions returns execution results of the given blocks in the
+// order they're emitted by the chain.
+func getBlocksExecutions(t *testing.T, chain *core.Blockchain, from, to uint32) []state.AppExecResult {
+	var res []state.AppExecResult
+	for i := from; i <= to; i++ {
+		h := chain.GetHeaderHash(i)
+		b, err := chain.GetBlock(h)
+		require.NoError(t, err)
+		aers, err := chain.GetAppExecResults(h, trigger.OnPersist)
+		require.NoError(t, err)
+		res = append(res, aers...)
+		for _, tx := range b.Transactions {
+			aers, err = chain.GetAppExecResults(tx.Hash(), trigger.Application)
+			require.NoError(t, err)
+			res = append(res, aers...)
+		}
+		aers, err = chain.GetAppExecResults(h, trigger.PostPersist)
+		require.NoError(t, err)
+		res = append(res, aers...)
+	}
+	return res
+}
+
+func checkCaughtUp(t *testing.T, resp *neorpc.Notification, id string, index uint32) {
+	require.Equal(t, neorpc.CaughtUpEventID, resp.Event)
+	rmap := resp.Payload[0].(map[string]any)
+	require.Equal(t, id, rmap["id"])
+	require.Equal(t, float64(index), rmap["index"])
+}
+
+func TestSubscriptionReplay(t *testing.T) {
+	chain, _, c, respMsgs := initCleanServerAndWSClient(t)
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+	from := height - 10
+
+	t.Run("executions", func(t *testing.T) {
+		expected := getBlocksExecutions(t, chain, from, height)
+		id := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["transaction_executed", null, %d]`, from))
+		for _, aer := range expected {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, neorpc.ExecutionEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]any)
+			require.Equal(t, "0x"+aer.Container.StringLE(), rmap["container"])
+			require.Equal(t, aer.Trigger.String(), rmap["trigger"])
+		}
+		checkCaughtUp(t, getNotification(t, respMsgs), id, height)
+
+		// Live events follow.
+		b := testchain.NewBlock(t, chain, 1, 0)
+		require.NoError(t, chain.AddBlock(b))
+		for _, trig := range []trigger.Type{trigger.OnPersist, trigger.PostPersist} {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, neorpc.ExecutionEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]any)
+			require.Equal(t, "0x"+b.Hash().StringLE(), rmap["container"])
+			require.Equal(t, trig.String(), rmap["trigger"])
+		}
+		callUnsubscribe(t, c, respMsgs, id)
+	})
+	t.Run("filtered notifications", func(t *testing.T) {
+		height := chain.BlockHeight()
+		var expected []state.NotificationEvent
+		for _, aer := range getBlocksExecutions(t, chain, 0, height) {
+			if aer.VMState != vmstate.Halt {
+				continue
+			}
+			for _, ev := range aer.Events {
+				if ev.Name == "Transfer" {
+					expected = append(expected, ev)
+				}
+			}
+		}
+		require.NotEqual(t, 0, len(expected))
+		id := callSubscribe(t, c, respMsgs, `["notification_from_execution", {"name":"Transfer"}, 0]`)
+		for _, ev := range expected {
+			resp := getNotification(t, respMsgs)
+			require.Equal(t, neorpc.NotificationEventID, resp.Event)
+			rmap := resp.Payload[0].(map[string]any)
+			require.Equal(t, "Transfer", rmap["eventname"])
+			require.Equal(t, "0x"+ev.ScriptHash.StringLE(), rmap["contract"])
+		}
+		checkCaughtUp(t, getNotification(t, respMsgs), id, height)
+		callUnsubscribe(t, c, respMsgs, id)
+	})
+	t.Run("nothing to replay", func(t *testing.T) {
+		height := chain.BlockHeight()
+		id := callSubscribe(t, c, respMsgs, fmt.Sprintf(`["transaction_executed", null, %d]`, height+1))
+		checkCaughtUp(t, getNotification(t, respMsgs), id, height)
+		callUnsubscribe(t, c, respMsgs, id)
+	})
+}
+
+func TestSubscriptionReplay_Limits(t *testing.T) {
+	check := func(t *testing.T, maxBlocks int, cases map[int]string) {
+		chain, rpcSrv, _ := initClearServerWithCustomConfig(t, func(cfg *config.Config) {
+			cfg.ApplicationConfiguration.RPC.MaxWebSocketReplayBlocks = maxBlocks
+		})
+		for _, b := range getTestBlocks(t) {
+			require.NoError(t, chain.AddBlock(b))
+		}
+		height := int(chain.BlockHeight())
+		sub := &subscriber{writer: make(chan intEvent, notificationBufSize)}
+		for offset, errText := range cases {
+			p, err := params.FromAny([]any{"transaction_executed", nil, height - offset})
+			require.NoError(t, err)
+			_, respErr := rpcSrv.subscribe(p, sub)
+			if errText == "" {
+				require.Nil(t, respErr)
+			} else {
+				require.NotNil(t, respErr)
+				require.Contains(t, respErr.Error(), errText)
+			}
+		}
+	}
+	t.Run("default", func(t *testing.T) {
+		check(t, 0, map[int]string{0: "", 5: ""})
+	})
+	t.Run("limited", func(t *testing.T) {
+		check(t, 5, map[int]string{5: "too many blocks to replay: 6, max 5", 4: "", -1: "", -2: "starting block"})
+	})
+	t.Run("disabled", func(t *testing.T) {
+		check(t, -1, map[int]string{0: "events replay is disabled"})
+	})
+}
+
+func TestSubscriptionReplay_Pending(t *testing.T) {
+	chain, rpcSrv, _ := initClearServerWithInMemoryChain(t)
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+
+	ch := make(chan intEvent, notificationBufSize)
+	sub := &subscriber{writer: ch}
+	rpcSrv.subsLock.Lock()
+	rpcSrv.subscribers[sub] = true
+	rpcSrv.subsLock.Unlock()
+	t.Cleanup(func() { rpcSrv.dropSubscriber(sub) })
+
+	p, err := params.FromAny([]any{"transaction_executed", nil, height - 1})
+	require.NoError(t, err)
+	res, respErr := rpcSrv.subscribe(p, sub)
+	require.Nil(t, respErr)
+	id := res.(string)
+
+	pendingLen := func() int {
+		// Pending events are appended with subsLock read-locked.
+		rpcSrv.subsLock.Lock()
+		defer rpcSrv.subsLock.Unlock()
+		return len(sub.replays[0].pending)
+	}
+	// Live event of the replayed block is received after subscription.
+	aers, err := chain.GetAppExecResults(chain.GetHeaderHash(height), trigger.PostPersist)
+	require.NoError(t, err)
+	rpcSrv.executionCh <- &aers[0]
+	require.Eventually(t, func() bool { return pendingLen() == 1 }, time.Second, 10*time.Millisecond)
+	// New block is added before the replay is started.
+	b := testchain.NewBlock(t, chain, 1, 0)
+	require.NoError(t, chain.AddBlock(b))
+	require.Eventually(t, func() bool { return pendingLen() == 3 }, time.Second, 10*time.Millisecond)
+
+	rpcSrv.startReplays(sub)
+	expected := getBlocksExecutions(t, chain, height-1, height+1)
+	for _, aer := range expected {
+		ev := <-ch
+		require.Equal(t, neorpc.ExecutionEventID, ev.ntf.Event)
+		actual := ev.ntf.Payload[0].(*state.AppExecResult)
+		require.Equal(t, aer.Container, actual.Container)
+		require.Equal(t, aer.Trigger, actual.Trigger)
+	}
+	ev := <-ch
+	require.Equal(t, neorpc.CaughtUpEventID, ev.ntf.Event)
+	require.Equal(t, result.CaughtUpEvent{ID: id, Index: height}, ev.ntf.Payload[0])
+	require.Equal(t, 0, len(ch))
+
+	rpcSrv.subsLock.RLock()
+	require.Nil(t, sub.feeds[0].replay)
+	rpcSrv.subsLock.RUnlock()
+}
+
+func TestSubscriptionReplay_Overflow(t *testing.T) {
+	chain, rpcSrv, _ := initClearServerWithInMemoryChain(t)
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+
+	// Slow client that doesn't read anything for now.
+	ch := make(chan intEvent)
+	sub := &subscriber{writer: ch}
+	rpcSrv.subsLock.Lock()
+	rpcSrv.subscribers[sub] = true
+	rpcSrv.subsLock.Unlock()
+	t.Cleanup(func() { rpcSrv.dropSubscriber(sub) })
+
+	p, err := params.FromAny([]any{"transaction_executed", nil, height - 1})
+	require.NoError(t, err)
+	_, respErr := rpcSrv.subscribe(p, sub)
+	require.Nil(t, respErr)
+	rpl := sub.replays[0]
+	rpcSrv.startReplays(sub)
+
+	for i := 0; i <= notificationBufSize; i++ {
+		rpcSrv.executionCh <- &state.AppExecResult{
+			Container: util.Uint256{1, 2, 3},
+			Execution: state.Execution{Trigger: trigger.Application, VMState: vmstate.Halt},
+		}
+	}
+	require.Eventually(t, rpl.overflown.Load, time.Second, 10*time.Millisecond)
+
+	// Replay is aborted after the event that is being sent (if any, the
+	// replay can be overflown before the first event is ready).
+	ev := <-ch
+	if ev.ntf.Event == neorpc.ExecutionEventID {
+		ev = <-ch
+	}
+	require.Equal(t, neorpc.MissedEventID, ev.ntf.Event)
+	require.Eventually(t, func() bool { return !sub.overflown.Load() }, time.Second, 10*time.Millisecond)
+
+	rpcSrv.subsLock.RLock()
+	require.Nil(t, sub.feeds[0].replay)
+	rpcSrv.subsLock.RUnlock()
+
+	// Feed is live now.
+	b := testchain.NewBlock(t, chain, 1, 0)
+	require.NoError(t, chain.AddBlock(b))
+	ev = <-ch
+	require.Equal(t, neorpc.ExecutionEventID, ev.ntf.Event)
+	require.Equal(t, b.Hash(), ev.ntf.Payload[0].(*state.AppExecResult).Container)
+}
+
+func TestSubscriptionReplay_Unsubscribe(t *testing.T) {
+	chain, rpcSrv, _ := initClearServerWithInMemoryChain(t)
+	for _, b := range getTestBlocks(t) {
+		require.NoError(t, chain.AddBlock(b))
+	}
+	height := chain.BlockHeight()
+
+	ch := make(chan intEvent)
+	sub := &subscriber{writer: ch}
+	rpcSrv.subsLock.Lock()
+	rpcSrv.subscribers[sub] = true
+	rpcSrv.subsLock.Unlock()
+	t.Cleanup(func() { rpcSrv.dropSubscriber(sub) })
+
+	p, err := params.FromAny([]any{"transaction_executed", nil, height - 1})
+	require.NoError(t, err)
+	res, respErr := rpcSrv.subscribe(p, sub)
+	require.Nil(t, respErr)
+	rpcSrv.startReplays(sub)
+	<-ch
+
+	p, err = params.FromAny([]any{res})
+	require.NoError(t, err)
+	_, respErr = rpcSrv.unsubscribe(p, sub)
+	require.Nil(t, respErr)
+
+	// No more events are sent.
+	select {
+	case ev := <-ch:
+		t.Fatalf("unexpected event: %s", ev.ntf.Event)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
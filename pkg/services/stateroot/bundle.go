@@ -0,0 +1,81 @@
+package stateroot
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// VerifyBundle checks the result of getverifiablestate RPC call: the state
+// root must be signed by the given state validators (designated for the state
+// root height, see rolemgmt package) for the given network and the storage
+// item value must be proven to be a part of this state. It doesn't check the
+// storage key, so the caller should compare it with the expected one (contract
+// ID followed by the item key) if needed.
+func VerifyBundle(b *result.VerifiableState, net netmode.Magic, validators keys.PublicKeys) error {
+	if b.Root == nil || b.Proof == nil {
+		return errors.New("incomplete bundle")
+	}
+	if len(b.Root.Witness) != 1 {
+		return errors.New("state root is not signed")
+	}
+	// Keys are sorted by CreateDefaultMultiSigRedeemScript and signatures
+	// follow their order the same way CheckMultisig expects them to.
+	pubs := validators.Copy()
+	verif, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs)
+	if err != nil {
+		return fmt.Errorf("invalid validators: %w", err)
+	}
+	if !bytes.Equal(verif, b.Root.Witness[0].VerificationScript) {
+		return errors.New("state root witness doesn't match validators")
+	}
+	sigs, err := parseSignatures(b.Root.Witness[0].InvocationScript)
+	if err != nil {
+		return err
+	}
+	m := smartcontract.GetDefaultHonestNodeCount(len(pubs))
+	if len(sigs) != m {
+		return fmt.Errorf("invalid number of signatures: %d instead of %d", len(sigs), m)
+	}
+	var j int
+	for i := range sigs {
+		for j < len(pubs) && !pubs[j].VerifyHashable(sigs[i], uint32(net), b.Root) {
+			j++
+		}
+		if j == len(pubs) {
+			return fmt.Errorf("invalid signature %d", i)
+		}
+		j++
+	}
+	val, ok := mpt.VerifyProof(b.Root.Root, b.Proof.Key, b.Proof.Proof)
+	if !ok {
+		return errors.New("invalid proof")
+	}
+	if !bytes.Equal(val, b.Value) {
+		return errors.New("value doesn't match the proof")
+	}
+	return nil
+}
+
+// parseSignatures returns signatures pushed by the invocation script.
+func parseSignatures(script []byte) ([][]byte, error) {
+	const sigPushLen = 2 + keys.SignatureLen
+	if len(script)%sigPushLen != 0 {
+		return nil, errors.New("invalid invocation script")
+	}
+	sigs := make([][]byte, 0, len(script)/sigPushLen)
+	for ; len(script) != 0; script = script[sigPushLen:] {
+		if script[0] != byte(opcode.PUSHDATA1) || script[1] != keys.SignatureLen {
+			return nil, errors.New("invalid invocation script")
+		}
+		sigs = append(sigs, script[2:sigPushLen])
+	}
+	return sigs, nil
+}
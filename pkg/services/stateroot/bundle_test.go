@@ -0,0 +1,133 @@
+package stateroot_test
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/mpt"
+	"github.com/nspcc-dev/neo-go/pkg/core/state"
+	"github.com/nspcc-dev/neo-go/pkg/core/storage"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/neorpc/result"
+	"github.com/nspcc-dev/neo-go/pkg/services/stateroot"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestBundle returns a bundle for the key signed by n first accounts.
+func newTestBundle(t *testing.T, key []byte, pubs keys.PublicKeys, accs []*wallet.Account, n int) *result.VerifiableState {
+	tr := mpt.NewTrie(nil, mpt.ModeLatest, storage.NewMemCachedStore(storage.NewMemoryStore()))
+	for _, k := range []string{"key", "key1", "other", "value"} {
+		require.NoError(t, tr.Put([]byte(k), []byte(k+" value")))
+	}
+	proof, err := tr.GetProof(key)
+	require.NoError(t, err)
+
+	r := &state.MPTRoot{Index: 42, Root: tr.StateRoot()}
+	signStateRoot(t, r, pubs, accs[:n])
+	return &result.VerifiableState{
+		Value: []byte(string(key) + " value"),
+		Proof: &result.ProofWithKey{Key: key, Proof: proof},
+		Root:  r,
+	}
+}
+
+func signStateRoot(t *testing.T, r *state.MPTRoot, pubs keys.PublicKeys, accs []*wallet.Account) {
+	w := io.NewBufBinWriter()
+	for i := range accs {
+		emit.Bytes(w.BinWriter, accs[i].PrivateKey().SignHashable(uint32(netmode.UnitTestNet), r))
+	}
+	require.NoError(t, w.Err)
+	script, err := smartcontract.CreateDefaultMultiSigRedeemScript(pubs.Copy())
+	require.NoError(t, err)
+	r.Witness = []transaction.Witness{{
+		InvocationScript:   w.Bytes(),
+		VerificationScript: script,
+	}}
+}
+
+func TestVerifyBundle(t *testing.T) {
+	_, pubs, accs := newMajorityMultisigWithGAS(t, 4)
+	m := smartcontract.GetDefaultHonestNodeCount(len(pubs))
+	key := []byte("key1")
+
+	t.Run("good", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		require.NoError(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("good, other signers", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs[1:], m)
+		require.NoError(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("unsorted validators", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		unsorted := keys.PublicKeys{pubs[3], pubs[1], pubs[0], pubs[2]}
+		require.NoError(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, unsorted))
+		require.Equal(t, pubs[3], unsorted[0])
+	})
+	t.Run("tampered proof", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		last := b.Proof.Proof[len(b.Proof.Proof)-1]
+		last[len(last)-1] ^= 0xff
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("tampered value", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Value = []byte("other value")
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("proof for other key", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Proof.Key = []byte("key")
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("wrong root", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Root.Root = util.Uint256{1, 2, 3}
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("wrong root index", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Root.Index++
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("wrong network", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		require.Error(t, stateroot.VerifyBundle(b, netmode.MainNet, pubs))
+	})
+	t.Run("insufficient signatures", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m-1)
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("unordered signatures", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, []*wallet.Account{accs[1], accs[0], accs[2]}, m)
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("duplicate signatures", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, []*wallet.Account{accs[0], accs[0], accs[1]}, m)
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("other validators", func(t *testing.T) {
+		_, otherPubs, otherAccs := newMajorityMultisigWithGAS(t, 4)
+		b := newTestBundle(t, key, otherPubs, otherAccs, m)
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("bad invocation script", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Root.Witness[0].InvocationScript[0] = 0
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+		b.Root.Witness[0].InvocationScript = b.Root.Witness[0].InvocationScript[1:]
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+	})
+	t.Run("unsigned", func(t *testing.T) {
+		b := newTestBundle(t, key, pubs, accs, m)
+		b.Root.Witness = nil
+		require.Error(t, stateroot.VerifyBundle(b, netmode.UnitTestNet, pubs))
+		require.Error(t, stateroot.VerifyBundle(&result.VerifiableState{}, netmode.UnitTestNet, pubs))
+	})
+}
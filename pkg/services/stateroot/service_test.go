@@ -167,6 +167,38 @@ func TestStateRoot_GenesisRole(t *testing.T) {
 	require.True(t, srv.IsAuthorized())
 }
 
+func TestStateRoot_Divergence(t *testing.T) {
+	_, pubs, accs := newMajorityMultisigWithGAS(t, 2)
+
+	bc, validator, committee := chain.NewMultiWithCustomConfig(t, func(c *config.Blockchain) {
+		c.Genesis.Roles = map[noderoles.Role]keys.PublicKeys{
+			noderoles.StateValidator: {accs[0].PublicKey(), accs[1].PublicKey()},
+		}
+		c.StateRootCheck.Enabled = true
+		c.StateRootCheck.HaltOnMismatch = true
+	})
+	e := neotest.NewExecutor(t, bc, validator, committee)
+	e.AddNewBlock(t)
+
+	tmpDir := t.TempDir()
+	w := createAndWriteWallet(t, accs[0], filepath.Join(tmpDir, "w"), "pass")
+	cfg := createStateRootConfig(w.Path(), "pass")
+	srMod := bc.GetStateModule().(*corestate.Module) // Take full responsibility here.
+	srv, err := stateroot.New(cfg, srMod, zaptest.NewLogger(t), bc, nil)
+	require.NoError(t, err)
+
+	r, err := bc.GetStateModule().GetStateRoot(1)
+	require.NoError(t, err)
+	r.Root[0] ^= 0xFF
+	data := testSignStateRoot(t, r, pubs, accs...)
+	require.NoError(t, srv.OnPayload(&payload.Extensible{Data: data}))
+	require.EqualValues(t, 0, bc.GetStateModule().CurrentValidatedHeight())
+
+	b := e.NewUnsignedBlock(t)
+	e.SignBlock(b)
+	require.ErrorIs(t, bc.AddBlock(b), core.ErrStateDivergence)
+}
+
 type memoryStore struct {
 	*storage.MemoryStore
 }
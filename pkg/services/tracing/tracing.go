@@ -0,0 +1,232 @@
+/*
+Package tracing implements block processing tracer producing spans for script
+runs and contract calls made by them.
+
+Every traced script run (transaction or OnPersist/PostPersist script) is a
+separate trace with the root span covering the whole run and child spans for
+contract calls nested according to the call stack. Span structure follows
+OpenTelemetry data model (trace and span identifiers, parent span reference,
+start/end time, attributes and error status), so spans can be converted to
+OpenTelemetry ones by an Exporter one-to-one.
+*/
+package tracing
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"go.uber.org/zap"
+)
+
+// Span attribute keys.
+const (
+	AttrTrigger     = "neo.trigger"
+	AttrContainer   = "neo.container"
+	AttrBlock       = "neo.block"
+	AttrVMState     = "neo.vmstate"
+	AttrGasConsumed = "neo.gas_consumed"
+	AttrContract    = "neo.contract"
+	AttrMethod      = "neo.method"
+)
+
+type (
+	// TraceID is a trace identifier.
+	TraceID [16]byte
+	// SpanID is a span identifier, zero SpanID is not a valid one.
+	SpanID [8]byte
+
+	// Span is a completed span.
+	Span struct {
+		TraceID TraceID
+		ID      SpanID
+		// ParentID is zero for the root span of the trace.
+		ParentID   SpanID
+		Name       string
+		Start      time.Time
+		End        time.Time
+		Attributes map[string]any
+		// Err is the error the span has ended with, nil if it has
+		// ended successfully.
+		Err error
+	}
+
+	// Exporter receives spans of completed traces.
+	Exporter interface {
+		// ExportSpans exports spans of a single trace, the root span
+		// comes first. Spans must not be retained after return.
+		ExportSpans(spans []Span) error
+	}
+
+	// Tracer implements interop.Tracer building spans for script runs and
+	// contract calls. It traces a single script run at a time (which is the
+	// case for block processing), so it can't be used by concurrent script
+	// runs.
+	Tracer struct {
+		log      *zap.Logger
+		exporter Exporter
+		ratio    float64
+		rand     *rand.Rand
+
+		sampled bool
+		trace   TraceID
+		spans   []Span
+		// stack contains indexes of spans that are not yet ended.
+		stack []int
+	}
+)
+
+// errFault is set for the spans of calls that haven't ended because of VM
+// fault.
+var errFault = errors.New("VM fault")
+
+// New returns a new Tracer for the given configuration. If exp is nil, the
+// exporter is created according to the configuration.
+func New(cfg config.Tracing, log *zap.Logger, exp Exporter) (*Tracer, error) {
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, fmt.Errorf("invalid sample ratio %f", cfg.SampleRatio)
+	}
+	if exp == nil {
+		switch cfg.Exporter {
+		case "", "log":
+			exp = &LogExporter{Log: log}
+		default:
+			return nil, fmt.Errorf("unknown exporter %s", cfg.Exporter)
+		}
+	}
+	ratio := cfg.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+	return &Tracer{
+		log:      log,
+		exporter: exp,
+		ratio:    ratio,
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// OnExecStart implements interop.Tracer interface.
+func (t *Tracer) OnExecStart(ic *interop.Context) {
+	t.sampled = t.ratio >= 1 || t.rand.Float64() < t.ratio
+	if !t.sampled {
+		return
+	}
+	t.rand.Read(t.trace[:])
+	attrs := map[string]any{
+		AttrTrigger: ic.Trigger.String(),
+	}
+	if ic.Container != nil {
+		attrs[AttrContainer] = ic.Container.Hash().StringLE()
+	}
+	if ic.Block != nil {
+		attrs[AttrBlock] = ic.Block.Index
+	}
+	t.spans = t.spans[:0]
+	t.stack = t.stack[:0]
+	t.push(ic.Trigger.String(), attrs)
+}
+
+// OnExecEnd implements interop.Tracer interface.
+func (t *Tracer) OnExecEnd(ic *interop.Context, err error) {
+	if !t.sampled {
+		return
+	}
+	t.sampled = false
+	now := time.Now()
+	for len(t.stack) > 1 {
+		t.pop(now, errFault)
+	}
+	root := &t.spans[t.stack[0]]
+	root.Attributes[AttrVMState] = ic.VM.State().String()
+	root.Attributes[AttrGasConsumed] = ic.VM.GasConsumed()
+	t.pop(now, err)
+	if err := t.exporter.ExportSpans(t.spans); err != nil {
+		t.log.Warn("failed to export trace", zap.String("trace", hex.EncodeToString(t.trace[:])), zap.Error(err))
+	}
+	for i := range t.spans {
+		t.spans[i] = Span{} // Don't keep references to attributes.
+	}
+	t.spans = t.spans[:0]
+}
+
+// OnCallStart implements interop.Tracer interface.
+func (t *Tracer) OnCallStart(contract util.Uint160, method string, gasBefore int64) {
+	if !t.sampled {
+		return
+	}
+	t.push(method, map[string]any{
+		AttrContract: contract.StringLE(),
+		AttrMethod:   method,
+	})
+}
+
+// OnCallEnd implements interop.Tracer interface.
+func (t *Tracer) OnCallEnd(contract util.Uint160, method string, gasBefore, gasAfter int64, err error) {
+	if !t.sampled || len(t.stack) < 2 {
+		return
+	}
+	t.spans[t.stack[len(t.stack)-1]].Attributes[AttrGasConsumed] = gasAfter - gasBefore
+	t.pop(time.Now(), err)
+}
+
+// push starts a new span which is a child of the current one.
+func (t *Tracer) push(name string, attrs map[string]any) {
+	s := Span{
+		TraceID:    t.trace,
+		Name:       name,
+		Start:      time.Now(),
+		Attributes: attrs,
+	}
+	for s.ID == (SpanID{}) {
+		t.rand.Read(s.ID[:])
+	}
+	if len(t.stack) != 0 {
+		s.ParentID = t.spans[t.stack[len(t.stack)-1]].ID
+	}
+	t.stack = append(t.stack, len(t.spans))
+	t.spans = append(t.spans, s)
+}
+
+// pop ends the current span.
+func (t *Tracer) pop(end time.Time, err error) {
+	s := &t.spans[t.stack[len(t.stack)-1]]
+	s.End = end
+	s.Err = err
+	t.stack = t.stack[:len(t.stack)-1]
+}
+
+// LogExporter is an Exporter writing spans to the log at debug level.
+type LogExporter struct {
+	Log *zap.Logger
+}
+
+// ExportSpans implements Exporter interface.
+func (e *LogExporter) ExportSpans(spans []Span) error {
+	if !e.Log.Core().Enabled(zap.DebugLevel) {
+		return nil
+	}
+	for i := range spans {
+		s := &spans[i]
+		fields := []zap.Field{
+			zap.String("trace", hex.EncodeToString(s.TraceID[:])),
+			zap.String("span", hex.EncodeToString(s.ID[:])),
+			zap.String("name", s.Name),
+			zap.Duration("duration", s.End.Sub(s.Start)),
+			zap.Any("attributes", s.Attributes),
+		}
+		if s.ParentID != (SpanID{}) {
+			fields = append(fields, zap.String("parent", hex.EncodeToString(s.ParentID[:])))
+		}
+		if s.Err != nil {
+			fields = append(fields, zap.Error(s.Err))
+		}
+		e.Log.Debug("execution span", fields...)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package tracing_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/compiler"
+	"github.com/nspcc-dev/neo-go/pkg/config"
+	"github.com/nspcc-dev/neo-go/pkg/core/interop"
+	"github.com/nspcc-dev/neo-go/pkg/neotest"
+	"github.com/nspcc-dev/neo-go/pkg/neotest/chain"
+	"github.com/nspcc-dev/neo-go/pkg/services/tracing"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+)
+
+type saveExporter struct {
+	traces [][]tracing.Span
+}
+
+func (e *saveExporter) ExportSpans(spans []tracing.Span) error {
+	e.traces = append(e.traces, append([]tracing.Span(nil), spans...))
+	return nil
+}
+
+// txTrace returns the trace of the transaction with the given hash.
+func (e *saveExporter) txTrace(t *testing.T, h util.Uint256) []tracing.Span {
+	for _, tr := range e.traces {
+		if tr[0].Attributes[tracing.AttrContainer] == h.StringLE() {
+			return tr
+		}
+	}
+	t.Fatalf("no trace for %s", h.StringLE())
+	return nil
+}
+
+func TestTracer(t *testing.T) {
+	bc, acc := chain.NewSingle(t)
+	e := neotest.NewExecutor(t, bc, acc, acc)
+
+	src := `package nested
+		import (
+			"github.com/nspcc-dev/neo-go/pkg/interop/contract"
+			"github.com/nspcc-dev/neo-go/pkg/interop/runtime"
+		)
+		func Outer(fail bool) int {
+			return contract.Call(runtime.GetExecutingScriptHash(), "inner", contract.All, fail).(int) + 1
+		}
+		func Safe() {
+			defer func() {
+				recover()
+			}()
+			contract.Call(runtime.GetExecutingScriptHash(), "inner", contract.All, true)
+		}
+		func Inner(fail bool) int {
+			if fail {
+				panic("inner failed")
+			}
+			return 41
+		}`
+	ctr := neotest.CompileSource(t, acc.ScriptHash(), strings.NewReader(src), &compiler.Options{
+		Name:        "nested",
+		Permissions: []manifest.Permission{{Methods: manifest.WildStrings{Value: nil}}},
+	})
+	e.DeployContract(t, ctr, nil)
+	inv := e.NewInvoker(ctr.Hash, acc)
+
+	exp := &saveExporter{}
+	tr, err := tracing.New(config.Tracing{Enabled: true}, zaptest.NewLogger(t), exp)
+	require.NoError(t, err)
+	bc.SetTracer(tr)
+
+	t.Run("nested call", func(t *testing.T) {
+		h := inv.Invoke(t, 42, "outer", false)
+		spans := exp.txTrace(t, h)
+		require.Equal(t, 3, len(spans))
+
+		root, outer, inner := spans[0], spans[1], spans[2]
+		require.Equal(t, "Application", root.Name)
+		require.Equal(t, tracing.SpanID{}, root.ParentID)
+		require.Equal(t, "HALT", root.Attributes[tracing.AttrVMState])
+		require.NoError(t, root.Err)
+
+		require.Equal(t, "outer", outer.Name)
+		require.Equal(t, root.ID, outer.ParentID)
+		require.Equal(t, ctr.Hash.StringLE(), outer.Attributes[tracing.AttrContract])
+		require.NoError(t, outer.Err)
+
+		require.Equal(t, "inner", inner.Name)
+		require.Equal(t, outer.ID, inner.ParentID)
+		require.NoError(t, inner.Err)
+
+		for _, s := range spans {
+			require.Equal(t, root.TraceID, s.TraceID)
+			require.False(t, s.End.Before(s.Start))
+		}
+		rootGas := root.Attributes[tracing.AttrGasConsumed].(int64)
+		outerGas := outer.Attributes[tracing.AttrGasConsumed].(int64)
+		innerGas := inner.Attributes[tracing.AttrGasConsumed].(int64)
+		require.True(t, innerGas > 0)
+		require.True(t, outerGas > innerGas)
+		require.True(t, rootGas > outerGas)
+	})
+
+	t.Run("caught exception", func(t *testing.T) {
+		h := inv.Invoke(t, stackitem.Null{}, "safe")
+		spans := exp.txTrace(t, h)
+		require.Equal(t, 3, len(spans))
+		require.NoError(t, spans[0].Err)
+		require.NoError(t, spans[1].Err)
+		require.Equal(t, spans[1].ID, spans[2].ParentID)
+		require.ErrorIs(t, spans[2].Err, interop.ErrUnhandledException)
+	})
+
+	t.Run("fault", func(t *testing.T) {
+		h := inv.InvokeFail(t, "inner failed", "outer", true)
+		spans := exp.txTrace(t, h)
+		require.Equal(t, 3, len(spans))
+		require.Equal(t, "FAULT", spans[0].Attributes[tracing.AttrVMState])
+		for _, s := range spans {
+			require.Error(t, s.Err)
+		}
+		require.Equal(t, spans[0].ID, spans[1].ParentID)
+		require.Equal(t, spans[1].ID, spans[2].ParentID)
+	})
+
+	t.Run("sampling", func(t *testing.T) {
+		exp.traces = nil
+		tr, err := tracing.New(config.Tracing{Enabled: true, SampleRatio: 1e-12}, zaptest.NewLogger(t), exp)
+		require.NoError(t, err)
+		bc.SetTracer(tr)
+		inv.Invoke(t, 42, "outer", false)
+		require.Equal(t, 0, len(exp.traces))
+
+		bc.SetTracer(nil)
+		inv.Invoke(t, 42, "outer", false)
+		require.Equal(t, 0, len(exp.traces))
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := tracing.New(config.Tracing{Exporter: "unknown"}, zaptest.NewLogger(t), nil)
+	require.Error(t, err)
+	_, err = tracing.New(config.Tracing{SampleRatio: 1.5}, zaptest.NewLogger(t), nil)
+	require.Error(t, err)
+	_, err = tracing.New(config.Tracing{Exporter: "log"}, zaptest.NewLogger(t), nil)
+	require.NoError(t, err)
+}
@@ -0,0 +1,324 @@
+/*
+Package artifact implements contract artifacts (NEF and manifest pairs)
+publishing to and fetching from NeoFS.
+
+Both files are stored in a single NeoFS object (bundle) as a JSON document
+with a SHA256 checksum of their contents, such objects are addressed with
+neofs://<container-ID>/<object-ID> (or neofs:<container-ID>/<object-ID>) URIs.
+*/
+package artifact
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/services/oracle/neofs"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	apistatus "github.com/nspcc-dev/neofs-sdk-go/client/status"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+)
+
+// DefaultTimeout is the default timeout of a single NeoFS operation.
+const DefaultTimeout = 30 * time.Second
+
+// maxBundleSize is the maximum size of the bundle object, it's enough for the
+// base64-encoded NEF of the maximum size and the manifest.
+const maxBundleSize = 2*stackitem.MaxSize + 2*manifest.MaxManifestSize
+
+// Various artifact errors.
+var (
+	ErrInvalidURI       = errors.New("invalid NeoFS URI")
+	ErrTimeout          = errors.New("NeoFS request timed out")
+	ErrAccessDenied     = errors.New("access denied by NeoFS container ACL")
+	ErrNotFound         = errors.New("artifact not found in NeoFS")
+	ErrChecksumMismatch = errors.New("artifact checksum mismatch")
+	ErrInconsistent     = errors.New("manifest doesn't match NEF")
+)
+
+// Bundle is an artifact stored in NeoFS.
+type Bundle struct {
+	NEF      []byte          `json:"nef"`
+	Manifest json.RawMessage `json:"manifest"`
+	// Checksum is hex-encoded SHA256 of NEF and manifest bytes.
+	Checksum string `json:"sha256"`
+}
+
+// Storage is an object storage artifacts are kept in.
+type Storage interface {
+	// Get returns the payload of the object.
+	Get(ctx context.Context, addr oid.Address) ([]byte, error)
+	// Put stores an object with the given payload and attributes in the
+	// container and returns its ID.
+	Put(ctx context.Context, cnr cid.ID, payload []byte, attrs map[string]string) (oid.ID, error)
+}
+
+// Client fetches and publishes artifacts.
+type Client struct {
+	// Storage is the storage used, usually it's NeoFS (see NewNeoFSStorage).
+	Storage Storage
+	// Timeout is the timeout for a single storage operation, DefaultTimeout
+	// is used if it's zero.
+	Timeout time.Duration
+	// CacheDir is the directory to keep fetched artifacts in, they're not
+	// cached if it's empty. NeoFS objects are immutable, so cached artifacts
+	// are used instead of fetching them again.
+	CacheDir string
+}
+
+type neofsStorage struct {
+	endpoint string
+	key      *keys.PrivateKey
+}
+
+// NewNeoFSStorage returns Storage that uses NeoFS node at the given endpoint,
+// all requests are signed by the given key.
+func NewNeoFSStorage(endpoint string, key *keys.PrivateKey) Storage {
+	return &neofsStorage{endpoint: endpoint, key: key}
+}
+
+// Get implements the Storage interface.
+func (s *neofsStorage) Get(ctx context.Context, addr oid.Address) ([]byte, error) {
+	u := &url.URL{Scheme: neofs.URIScheme, Opaque: addr.Container().EncodeToString() + "/" + addr.Object().EncodeToString()}
+	rc, err := neofs.Get(ctx, s.key, u, s.endpoint)
+	if rc != nil {
+		defer rc.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, maxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBundleSize {
+		return nil, fmt.Errorf("object is too big (more than %d bytes)", maxBundleSize)
+	}
+	return data, nil
+}
+
+// Put implements the Storage interface.
+func (s *neofsStorage) Put(ctx context.Context, cnr cid.ID, payload []byte, attrs map[string]string) (oid.ID, error) {
+	return neofs.Put(ctx, s.key, cnr, payload, attrs, s.endpoint)
+}
+
+// IsURI checks whether the given string is a NeoFS URI.
+func IsURI(s string) bool {
+	return strings.HasPrefix(s, neofs.URIScheme+":")
+}
+
+// ParseURI parses neofs://<container-ID>[/<object-ID>] (or
+// neofs:<container-ID>[/<object-ID>]) URI. Object ID is nil if it's missing.
+func ParseURI(s string) (cid.ID, *oid.ID, error) {
+	var cnr cid.ID
+	if !IsURI(s) {
+		return cnr, nil, fmt.Errorf("%w: %s scheme expected", ErrInvalidURI, neofs.URIScheme)
+	}
+	ps := strings.Split(strings.TrimPrefix(strings.TrimPrefix(s, neofs.URIScheme+":"), "//"), "/")
+	if len(ps) > 2 || (len(ps) == 2 && ps[1] == "") {
+		return cnr, nil, fmt.Errorf("%w: %s", ErrInvalidURI, s)
+	}
+	if err := cnr.DecodeString(ps[0]); err != nil {
+		return cnr, nil, fmt.Errorf("%w: bad container ID: %w", ErrInvalidURI, err)
+	}
+	if len(ps) == 1 {
+		return cnr, nil, nil
+	}
+	var obj oid.ID
+	if err := obj.DecodeString(ps[1]); err != nil {
+		return cnr, nil, fmt.Errorf("%w: bad object ID: %w", ErrInvalidURI, err)
+	}
+	return cnr, &obj, nil
+}
+
+// NewBundle creates a bundle from the given NEF and manifest.
+func NewBundle(n *nef.File, m *manifest.Manifest) (*Bundle, error) {
+	nefBytes, err := n.Bytes()
+	if err != nil {
+		return nil, err
+	}
+	mBytes, err := json.Marshal(m)
+	if err != nil {
+		return nil, err
+	}
+	return &Bundle{
+		NEF:      nefBytes,
+		Manifest: mBytes,
+		Checksum: checksum(nefBytes, mBytes),
+	}, nil
+}
+
+// Verify checks bundle checksum, NEF and manifest validity and their
+// consistency (every method must be within the NEF script), it returns
+// decoded NEF and manifest.
+func (b *Bundle) Verify() (*nef.File, *manifest.Manifest, error) {
+	if checksum(b.NEF, b.Manifest) != strings.ToLower(b.Checksum) {
+		return nil, nil, ErrChecksumMismatch
+	}
+	n, err := nef.FileFromBytes(b.NEF)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid NEF: %w", err)
+	}
+	m := new(manifest.Manifest)
+	if err := json.Unmarshal(b.Manifest, m); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	if err := m.IsValid(util.Uint160{}, true); err != nil {
+		return nil, nil, fmt.Errorf("invalid manifest: %w", err)
+	}
+	for _, md := range m.ABI.Methods {
+		if md.Offset < 0 || md.Offset >= len(n.Script) {
+			return nil, nil, fmt.Errorf("%w: method %s offset %d is out of script bounds (%d)",
+				ErrInconsistent, md.Name, md.Offset, len(n.Script))
+		}
+	}
+	return &n, m, nil
+}
+
+func checksum(nefBytes, mBytes []byte) string {
+	h := sha256.New()
+	h.Write(nefBytes)
+	h.Write(mBytes)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Fetch downloads and verifies the artifact with the given URI (that must
+// contain an object ID).
+func (c *Client) Fetch(ctx context.Context, uri string) (*nef.File, *manifest.Manifest, error) {
+	cnr, obj, err := ParseURI(uri)
+	if err != nil {
+		return nil, nil, err
+	}
+	if obj == nil {
+		return nil, nil, fmt.Errorf("%w: object ID is missing", ErrInvalidURI)
+	}
+	var addr oid.Address
+	addr.SetContainer(cnr)
+	addr.SetObject(*obj)
+
+	if n, m, err := c.readCache(addr); err == nil {
+		return n, m, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+	data, err := c.Storage.Get(ctx, addr)
+	if err != nil {
+		return nil, nil, wrapError(ctx, fmt.Sprintf("failed to get %s", uri), err)
+	}
+	b := new(Bundle)
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, nil, fmt.Errorf("invalid artifact %s: %w", uri, err)
+	}
+	n, m, err := b.Verify()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid artifact %s: %w", uri, err)
+	}
+	c.writeCache(addr, data)
+	return n, m, nil
+}
+
+// Publish uploads the artifact into the container specified by the URI and
+// returns the URI of the artifact stored.
+func (c *Client) Publish(ctx context.Context, uri string, n *nef.File, m *manifest.Manifest) (string, error) {
+	cnr, obj, err := ParseURI(uri)
+	if err != nil {
+		return "", err
+	}
+	if obj != nil {
+		return "", fmt.Errorf("%w: only container ID is expected", ErrInvalidURI)
+	}
+	b, err := NewBundle(n, m)
+	if err != nil {
+		return "", err
+	}
+	if _, _, err := b.Verify(); err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout())
+	defer cancel()
+	id, err := c.Storage.Put(ctx, cnr, data, map[string]string{
+		"FileName":    m.Name + ".artifact.json",
+		"ContentType": "application/json",
+	})
+	if err != nil {
+		return "", wrapError(ctx, fmt.Sprintf("failed to put artifact into %s", cnr.EncodeToString()), err)
+	}
+	var addr oid.Address
+	addr.SetContainer(cnr)
+	addr.SetObject(id)
+	c.writeCache(addr, data)
+	return neofs.URIScheme + "://" + cnr.EncodeToString() + "/" + id.EncodeToString(), nil
+}
+
+func (c *Client) timeout() time.Duration {
+	if c.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return c.Timeout
+}
+
+func (c *Client) cachePath(addr oid.Address) string {
+	return filepath.Join(c.CacheDir, addr.Container().EncodeToString(), addr.Object().EncodeToString()+".json")
+}
+
+func (c *Client) readCache(addr oid.Address) (*nef.File, *manifest.Manifest, error) {
+	if c.CacheDir == "" {
+		return nil, nil, os.ErrNotExist
+	}
+	data, err := os.ReadFile(c.cachePath(addr))
+	if err != nil {
+		return nil, nil, err
+	}
+	b := new(Bundle)
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, nil, err
+	}
+	return b.Verify()
+}
+
+// writeCache saves the artifact to the cache, errors are ignored since the
+// cache is optional.
+func (c *Client) writeCache(addr oid.Address, data []byte) {
+	if c.CacheDir == "" {
+		return
+	}
+	p := c.cachePath(addr)
+	if err := os.MkdirAll(filepath.Dir(p), os.ModePerm); err != nil {
+		return
+	}
+	_ = os.WriteFile(p, data, 0o644)
+}
+
+// wrapError converts storage errors into more specific ones.
+func wrapError(ctx context.Context, msg string, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%s: %w: %w", msg, ErrTimeout, err)
+	case errors.Is(err, apistatus.ErrObjectAccessDenied):
+		return fmt.Errorf("%s: %w: %w", msg, ErrAccessDenied, err)
+	case errors.Is(err, apistatus.ErrObjectNotFound), errors.Is(err, apistatus.ErrContainerNotFound):
+		return fmt.Errorf("%s: %w: %w", msg, ErrNotFound, err)
+	default:
+		return fmt.Errorf("%s: %w", msg, err)
+	}
+}
@@ -0,0 +1,259 @@
+package artifact
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nspcc-dev/neo-go/internal/testcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	apistatus "github.com/nspcc-dev/neofs-sdk-go/client/status"
+	cid "github.com/nspcc-dev/neofs-sdk-go/container/id"
+	cidtest "github.com/nspcc-dev/neofs-sdk-go/container/id/test"
+	oid "github.com/nspcc-dev/neofs-sdk-go/object/id"
+	oidtest "github.com/nspcc-dev/neofs-sdk-go/object/id/test"
+	"github.com/stretchr/testify/require"
+)
+
+// mockStorage is an in-memory NeoFS.
+type mockStorage struct {
+	objects map[oid.Address][]byte
+	attrs   map[oid.Address]map[string]string
+	// err is returned for all requests if set.
+	err error
+	// hang makes requests wait for the context to be done.
+	hang  bool
+	calls int
+}
+
+func newMockStorage() *mockStorage {
+	return &mockStorage{
+		objects: make(map[oid.Address][]byte),
+		attrs:   make(map[oid.Address]map[string]string),
+	}
+}
+
+func (s *mockStorage) Get(ctx context.Context, addr oid.Address) ([]byte, error) {
+	s.calls++
+	if s.hang {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	data, ok := s.objects[addr]
+	if !ok {
+		return nil, apistatus.ErrObjectNotFound
+	}
+	return data, nil
+}
+
+func (s *mockStorage) Put(ctx context.Context, cnr cid.ID, payload []byte, attrs map[string]string) (oid.ID, error) {
+	s.calls++
+	if s.hang {
+		<-ctx.Done()
+		return oid.ID{}, ctx.Err()
+	}
+	if s.err != nil {
+		return oid.ID{}, s.err
+	}
+	var addr oid.Address
+	addr.SetContainer(cnr)
+	addr.SetObject(oidtest.ID())
+	s.objects[addr] = payload
+	s.attrs[addr] = attrs
+	return addr.Object(), nil
+}
+
+func TestParseURI(t *testing.T) {
+	cnr, obj := cidtest.ID(), oidtest.ID()
+	for _, uri := range []string{
+		"neofs://" + cnr.EncodeToString() + "/" + obj.EncodeToString(),
+		"neofs:" + cnr.EncodeToString() + "/" + obj.EncodeToString(),
+	} {
+		c, o, err := ParseURI(uri)
+		require.NoError(t, err)
+		require.Equal(t, cnr, c)
+		require.Equal(t, obj, *o)
+	}
+	c, o, err := ParseURI("neofs://" + cnr.EncodeToString())
+	require.NoError(t, err)
+	require.Equal(t, cnr, c)
+	require.Nil(t, o)
+
+	for _, uri := range []string{
+		"https://" + cnr.EncodeToString(),
+		"neofs://",
+		"neofs://bad",
+		"neofs://" + cnr.EncodeToString() + "/",
+		"neofs://" + cnr.EncodeToString() + "/bad",
+		"neofs://" + cnr.EncodeToString() + "/" + obj.EncodeToString() + "/range",
+	} {
+		_, _, err := ParseURI(uri)
+		require.ErrorIs(t, err, ErrInvalidURI, uri)
+	}
+	require.True(t, IsURI("neofs:abc"))
+	require.False(t, IsURI("contract.nef"))
+}
+
+func TestPublishFetch(t *testing.T) {
+	var (
+		s    = newMockStorage()
+		c    = &Client{Storage: s}
+		ctx  = context.Background()
+		cnr  = cidtest.ID()
+		n, m = testcontract.New(t)
+	)
+	uri, err := c.Publish(ctx, "neofs://"+cnr.EncodeToString(), n, m)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(s.objects))
+	for _, attrs := range s.attrs {
+		require.Equal(t, "Test.artifact.json", attrs["FileName"])
+	}
+
+	actualN, actualM, err := c.Fetch(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, n, actualN)
+	require.Equal(t, m, actualM)
+
+	t.Run("bad URI", func(t *testing.T) {
+		_, err := c.Publish(ctx, uri, n, m)
+		require.ErrorIs(t, err, ErrInvalidURI)
+		_, _, err = c.Fetch(ctx, "neofs://"+cnr.EncodeToString())
+		require.ErrorIs(t, err, ErrInvalidURI)
+	})
+	t.Run("not found", func(t *testing.T) {
+		_, _, err := c.Fetch(ctx, "neofs://"+cnr.EncodeToString()+"/"+oidtest.ID().EncodeToString())
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("inconsistent", func(t *testing.T) {
+		m := *m
+		m.ABI.Methods = []manifest.Method{{Name: "main", Offset: 10, ReturnType: smartcontract.IntegerType}}
+		_, err := c.Publish(ctx, "neofs://"+cnr.EncodeToString(), n, &m)
+		require.ErrorIs(t, err, ErrInconsistent)
+	})
+}
+
+func TestFetchChecksumMismatch(t *testing.T) {
+	var (
+		s    = newMockStorage()
+		c    = &Client{Storage: s}
+		ctx  = context.Background()
+		n, m = testcontract.New(t)
+	)
+	uri, err := c.Publish(ctx, "neofs://"+cidtest.ID().EncodeToString(), n, m)
+	require.NoError(t, err)
+	for addr, data := range s.objects {
+		b := new(Bundle)
+		require.NoError(t, json.Unmarshal(data, b))
+		b.NEF[len(b.NEF)-5] ^= 0xff
+		s.objects[addr], err = json.Marshal(b)
+		require.NoError(t, err)
+	}
+	_, _, err = c.Fetch(ctx, uri)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	require.ErrorContains(t, err, uri)
+
+	t.Run("bad NEF with valid checksum", func(t *testing.T) {
+		for addr, data := range s.objects {
+			b := new(Bundle)
+			require.NoError(t, json.Unmarshal(data, b))
+			b.Checksum = checksum(b.NEF, b.Manifest)
+			s.objects[addr], err = json.Marshal(b)
+			require.NoError(t, err)
+		}
+		_, _, err = c.Fetch(ctx, uri)
+		require.ErrorContains(t, err, "invalid NEF")
+	})
+	t.Run("not a bundle", func(t *testing.T) {
+		for addr := range s.objects {
+			s.objects[addr] = []byte("not a JSON")
+		}
+		_, _, err = c.Fetch(ctx, uri)
+		require.ErrorContains(t, err, "invalid artifact")
+	})
+}
+
+func TestStorageErrors(t *testing.T) {
+	var (
+		s    = newMockStorage()
+		c    = &Client{Storage: s, Timeout: 50 * time.Millisecond}
+		ctx  = context.Background()
+		cnr  = "neofs://" + cidtest.ID().EncodeToString()
+		n, m = testcontract.New(t)
+	)
+	uri, err := c.Publish(ctx, cnr, n, m)
+	require.NoError(t, err)
+
+	t.Run("timeout", func(t *testing.T) {
+		s.hang = true
+		defer func() { s.hang = false }()
+		_, _, err := c.Fetch(ctx, uri)
+		require.ErrorIs(t, err, ErrTimeout)
+		_, err = c.Publish(ctx, cnr, n, m)
+		require.ErrorIs(t, err, ErrTimeout)
+	})
+	t.Run("access denied", func(t *testing.T) {
+		s.err = apistatus.ErrObjectAccessDenied
+		defer func() { s.err = nil }()
+		_, _, err := c.Fetch(ctx, uri)
+		require.ErrorIs(t, err, ErrAccessDenied)
+		_, err = c.Publish(ctx, cnr, n, m)
+		require.ErrorIs(t, err, ErrAccessDenied)
+	})
+	t.Run("container not found", func(t *testing.T) {
+		s.err = apistatus.ErrContainerNotFound
+		defer func() { s.err = nil }()
+		_, err = c.Publish(ctx, cnr, n, m)
+		require.ErrorIs(t, err, ErrNotFound)
+	})
+	t.Run("other", func(t *testing.T) {
+		s.err = errors.New("connection refused")
+		defer func() { s.err = nil }()
+		_, _, err := c.Fetch(ctx, uri)
+		require.ErrorContains(t, err, "connection refused")
+	})
+}
+
+func TestCache(t *testing.T) {
+	var (
+		s    = newMockStorage()
+		dir  = t.TempDir()
+		c    = &Client{Storage: s, CacheDir: dir}
+		ctx  = context.Background()
+		n, m = testcontract.New(t)
+	)
+	uri, err := c.Publish(ctx, "neofs://"+cidtest.ID().EncodeToString(), n, m)
+	require.NoError(t, err)
+	s.calls = 0
+
+	// Published artifacts are cached.
+	_, _, err = c.Fetch(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, 0, s.calls)
+
+	// Fetched artifacts are cached too.
+	c2 := &Client{Storage: s, CacheDir: t.TempDir()}
+	_, _, err = c2.Fetch(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.calls)
+	_, _, err = c2.Fetch(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, 1, s.calls)
+
+	// Damaged cache is ignored.
+	cnr, obj, err := ParseURI(uri)
+	require.NoError(t, err)
+	p := filepath.Join(dir, cnr.EncodeToString(), obj.EncodeToString()+".json")
+	require.NoError(t, os.WriteFile(p, []byte("{}"), 0o644))
+	actualN, _, err := c.Fetch(ctx, uri)
+	require.NoError(t, err)
+	require.Equal(t, n, actualN)
+	require.Equal(t, 2, s.calls)
+}
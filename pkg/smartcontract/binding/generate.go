@@ -73,16 +73,16 @@ type (
 	}
 
 	ExtendedType struct {
-		Base      smartcontract.ParamType `yaml:"base"`
-		Name      string                  `yaml:"name,omitempty"`      // Structure name, omitted for arrays, interfaces and maps.
-		Interface string                  `yaml:"interface,omitempty"` // Interface type name, "iterator" only for now.
-		Key       smartcontract.ParamType `yaml:"key,omitempty"`       // Key type (only simple types can be used for keys) for maps.
-		Value     *ExtendedType           `yaml:"value,omitempty"`     // Value type for iterators, arrays and maps.
-		Fields    []FieldExtendedType     `yaml:"fields,omitempty"`    // Ordered type data for structure fields.
+		Base      smartcontract.ParamType `yaml:"base" json:"base"`
+		Name      string                  `yaml:"name,omitempty" json:"name,omitempty"`           // Structure name, omitted for arrays, interfaces and maps.
+		Interface string                  `yaml:"interface,omitempty" json:"interface,omitempty"` // Interface type name, "iterator" only for now.
+		Key       smartcontract.ParamType `yaml:"key,omitempty" json:"key,omitempty"`             // Key type (only simple types can be used for keys) for maps.
+		Value     *ExtendedType           `yaml:"value,omitempty" json:"value,omitempty"`         // Value type for iterators, arrays and maps.
+		Fields    []FieldExtendedType     `yaml:"fields,omitempty" json:"fields,omitempty"`       // Ordered type data for structure fields.
 	}
 
 	FieldExtendedType struct {
-		Field        string `yaml:"field"`
+		Field        string `yaml:"field" json:"field"`
 		ExtendedType `yaml:",inline"`
 	}
 
@@ -0,0 +1,217 @@
+package binding
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// ManifestTypesKey is the name of the manifest extra data field containing
+// extended types description (see ManifestTypes).
+const ManifestTypesKey = "extendedTypes"
+
+// ManifestTypes contains extended types description stored in the manifest
+// extra data. It makes the structure of method parameters and return values
+// (like structure field names) available to the contract users that only have
+// its manifest.
+type ManifestTypes struct {
+	// NamedTypes is the same as Config.NamedTypes.
+	NamedTypes map[string]ExtendedType `json:"namedTypes,omitempty"`
+	// Types is the same as Config.Types.
+	Types map[string]ExtendedType `json:"types,omitempty"`
+}
+
+// PutManifestTypes stores extended types description in the manifest extra
+// data. Manifest extra data must be either empty or a JSON object, other
+// fields of this object are preserved.
+func PutManifestTypes(m *manifest.Manifest, mt ManifestTypes) error {
+	extra, err := getManifestExtra(m)
+	if err != nil {
+		return err
+	}
+	if extra == nil {
+		extra = make(map[string]json.RawMessage)
+	}
+	data, err := json.Marshal(mt)
+	if err != nil {
+		return err
+	}
+	extra[ManifestTypesKey] = data
+	m.Extra, err = json.Marshal(extra)
+	return err
+}
+
+// GetManifestTypes returns extended types description stored in the manifest
+// extra data. Nil is returned if there is no such description.
+func GetManifestTypes(m *manifest.Manifest) (*ManifestTypes, error) {
+	extra, err := getManifestExtra(m)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := extra[ManifestTypesKey]
+	if !ok {
+		return nil, nil
+	}
+	var mt ManifestTypes
+	if err := json.Unmarshal(data, &mt); err != nil {
+		return nil, fmt.Errorf("invalid extended types: %w", err)
+	}
+	return &mt, nil
+}
+
+func getManifestExtra(m *manifest.Manifest) (map[string]json.RawMessage, error) {
+	if len(m.Extra) == 0 || string(m.Extra) == "null" {
+		return nil, nil
+	}
+	var extra map[string]json.RawMessage
+	if err := json.Unmarshal(m.Extra, &extra); err != nil {
+		return nil, fmt.Errorf("manifest extra data is not an object: %w", err)
+	}
+	return extra, nil
+}
+
+// ApplyManifestTypes adds extended types stored in the manifest (if any) to
+// the configuration. Types that are already present in the configuration are
+// not changed. Configuration maps are copied before modification, so it's
+// safe to use it on a copy of some other Config.
+func (c *Config) ApplyManifestTypes() error {
+	if c.Manifest == nil {
+		return nil
+	}
+	mt, err := GetManifestTypes(c.Manifest)
+	if err != nil || mt == nil {
+		return err
+	}
+	c.NamedTypes = mergeTypes(c.NamedTypes, mt.NamedTypes)
+	c.Types = mergeTypes(c.Types, mt.Types)
+	return nil
+}
+
+func mergeTypes(dst, src map[string]ExtendedType) map[string]ExtendedType {
+	var res = make(map[string]ExtendedType, len(dst)+len(src))
+	for k, et := range src {
+		res[k] = et
+	}
+	for k, et := range dst {
+		res[k] = et
+	}
+	return res
+}
+
+// CheckItem checks that the stack item has the structure described by the
+// extended type. Named structure types are resolved using the named map.
+// Null is accepted for any type except booleans and integers.
+func (e *ExtendedType) CheckItem(item stackitem.Item, named map[string]ExtendedType) error {
+	if e.Base == smartcontract.AnyType {
+		return nil
+	}
+	if _, ok := item.(stackitem.Null); ok {
+		if e.Base == smartcontract.BoolType || e.Base == smartcontract.IntegerType {
+			return fmt.Errorf("null is not a %s", e.Base)
+		}
+		return nil
+	}
+	switch e.Base {
+	case smartcontract.BoolType:
+		if item.Type() != stackitem.BooleanT {
+			return fmt.Errorf("%s is not a boolean", item.Type())
+		}
+	case smartcontract.IntegerType:
+		if item.Type() != stackitem.IntegerT {
+			return fmt.Errorf("%s is not an integer", item.Type())
+		}
+	case smartcontract.ByteArrayType, smartcontract.StringType, smartcontract.Hash160Type,
+		smartcontract.Hash256Type, smartcontract.PublicKeyType, smartcontract.SignatureType:
+		return checkBytesItem(e.Base, item)
+	case smartcontract.InteropInterfaceType:
+		if item.Type() != stackitem.InteropT {
+			return fmt.Errorf("%s is not an interop interface", item.Type())
+		}
+	case smartcontract.ArrayType:
+		return e.checkArrayItem(item, named)
+	case smartcontract.MapType:
+		m, ok := item.(*stackitem.Map)
+		if !ok {
+			return fmt.Errorf("%s is not a map", item.Type())
+		}
+		for _, el := range m.Value().([]stackitem.MapElement) {
+			if e.Key != smartcontract.AnyType {
+				if err := (&ExtendedType{Base: e.Key}).CheckItem(el.Key, named); err != nil {
+					return fmt.Errorf("key: %w", err)
+				}
+			}
+			if e.Value != nil {
+				if err := e.Value.CheckItem(el.Value, named); err != nil {
+					return fmt.Errorf("value: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (e *ExtendedType) checkArrayItem(item stackitem.Item, named map[string]ExtendedType) error {
+	if item.Type() != stackitem.ArrayT && item.Type() != stackitem.StructT {
+		return fmt.Errorf("%s is not an array", item.Type())
+	}
+	arr := item.Value().([]stackitem.Item)
+	if e.Name == "" {
+		if e.Value == nil {
+			return nil
+		}
+		for i := range arr {
+			if err := e.Value.CheckItem(arr[i], named); err != nil {
+				return fmt.Errorf("element %d: %w", i, err)
+			}
+		}
+		return nil
+	}
+	fields := e.Fields
+	if len(fields) == 0 {
+		nt, ok := named[e.Name]
+		if !ok {
+			return fmt.Errorf("unknown named type %s", e.Name)
+		}
+		fields = nt.Fields
+	}
+	if len(arr) != len(fields) {
+		return fmt.Errorf("%s has %d fields, got %d", e.Name, len(fields), len(arr))
+	}
+	for i := range fields {
+		if err := fields[i].ExtendedType.CheckItem(arr[i], named); err != nil {
+			return fmt.Errorf("%s.%s: %w", e.Name, fields[i].Field, err)
+		}
+	}
+	return nil
+}
+
+func checkBytesItem(typ smartcontract.ParamType, item stackitem.Item) error {
+	if item.Type() != stackitem.ByteArrayT && item.Type() != stackitem.BufferT {
+		return fmt.Errorf("%s is not a byte string", item.Type())
+	}
+	b, _ := item.TryBytes()
+	var size int
+	switch typ {
+	case smartcontract.StringType:
+		if !utf8.Valid(b) {
+			return errors.New("not a UTF-8 string")
+		}
+	case smartcontract.Hash160Type:
+		size = 20
+	case smartcontract.Hash256Type:
+		size = 32
+	case smartcontract.PublicKeyType:
+		size = 33
+	case smartcontract.SignatureType:
+		size = 64
+	}
+	if size != 0 && len(b) != size {
+		return fmt.Errorf("%s should be %d bytes long, got %d", typ, size, len(b))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package binding
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+var testPoint = ExtendedType{
+	Base: smartcontract.ArrayType,
+	Name: "main.Point",
+	Fields: []FieldExtendedType{
+		{Field: "X", ExtendedType: ExtendedType{Base: smartcontract.IntegerType}},
+		{Field: "Owner", ExtendedType: ExtendedType{Base: smartcontract.Hash160Type}},
+		{Field: "Tags", ExtendedType: ExtendedType{
+			Base:  smartcontract.ArrayType,
+			Value: &ExtendedType{Base: smartcontract.StringType},
+		}},
+	},
+}
+
+func TestManifestTypes(t *testing.T) {
+	m := manifest.NewManifest("test")
+	mt, err := GetManifestTypes(m)
+	require.NoError(t, err)
+	require.Nil(t, mt)
+
+	m.Extra = []byte(`"string"`)
+	_, err = GetManifestTypes(m)
+	require.Error(t, err)
+	require.Error(t, PutManifestTypes(m, ManifestTypes{}))
+
+	m.Extra = []byte(`{"Author":"me"}`)
+	expected := ManifestTypes{
+		NamedTypes: map[string]ExtendedType{"main.Point": testPoint},
+		Types: map[string]ExtendedType{
+			"get": {Base: smartcontract.ArrayType, Name: "main.Point"},
+		},
+	}
+	require.NoError(t, PutManifestTypes(m, expected))
+	require.JSONEq(t, `{"Author":"me","extendedTypes":{"namedTypes":{"main.Point":{"base":"Array","name":"main.Point","fields":[
+		{"field":"X","base":"Integer"},
+		{"field":"Owner","base":"Hash160"},
+		{"field":"Tags","base":"Array","value":{"base":"String"}}]}},
+		"types":{"get":{"base":"Array","name":"main.Point"}}}}`, string(m.Extra))
+
+	mt, err = GetManifestTypes(m)
+	require.NoError(t, err)
+	require.Equal(t, expected, *mt)
+
+	t.Run("apply", func(t *testing.T) {
+		cfg := NewConfig()
+		cfg.Manifest = m
+		cfg.Types["get"] = ExtendedType{Base: smartcontract.IntegerType}
+		types := cfg.Types
+		require.NoError(t, cfg.ApplyManifestTypes())
+		require.Equal(t, expected.NamedTypes, cfg.NamedTypes)
+		require.Equal(t, ExtendedType{Base: smartcontract.IntegerType}, cfg.Types["get"])
+		require.Equal(t, 1, len(cfg.Types))
+		require.Equal(t, 1, len(types))
+	})
+}
+
+func TestExtendedType_CheckItem(t *testing.T) {
+	named := map[string]ExtendedType{"main.Point": testPoint}
+	point := func(x, owner, tags stackitem.Item) stackitem.Item {
+		return stackitem.NewStruct([]stackitem.Item{x, owner, tags})
+	}
+	tags := stackitem.NewArray([]stackitem.Item{stackitem.Make("a"), stackitem.Make("b")})
+	owner := stackitem.Make(make([]byte, 20))
+
+	testCases := []struct {
+		name string
+		et   ExtendedType
+		item stackitem.Item
+		ok   bool
+	}{
+		{"any", ExtendedType{Base: smartcontract.AnyType}, stackitem.Make(1), true},
+		{"null string", ExtendedType{Base: smartcontract.StringType}, stackitem.Null{}, true},
+		{"null int", ExtendedType{Base: smartcontract.IntegerType}, stackitem.Null{}, false},
+		{"bool", ExtendedType{Base: smartcontract.BoolType}, stackitem.Make(true), true},
+		{"bad bool", ExtendedType{Base: smartcontract.BoolType}, stackitem.Make(1), false},
+		{"int", ExtendedType{Base: smartcontract.IntegerType}, stackitem.Make(1), true},
+		{"bad int", ExtendedType{Base: smartcontract.IntegerType}, stackitem.Make("1"), false},
+		{"string", ExtendedType{Base: smartcontract.StringType}, stackitem.Make("str"), true},
+		{"bad string", ExtendedType{Base: smartcontract.StringType}, stackitem.Make([]byte{0xff}), false},
+		{"hash160", ExtendedType{Base: smartcontract.Hash160Type}, owner, true},
+		{"bad hash160", ExtendedType{Base: smartcontract.Hash160Type}, stackitem.Make([]byte{1}), false},
+		{"interop", ExtendedType{Base: smartcontract.InteropInterfaceType}, stackitem.NewInterop(1), true},
+		{"bad interop", ExtendedType{Base: smartcontract.InteropInterfaceType}, stackitem.Make(1), false},
+		{"array", ExtendedType{Base: smartcontract.ArrayType, Value: &ExtendedType{Base: smartcontract.StringType}}, tags, true},
+		{"bad array", ExtendedType{Base: smartcontract.ArrayType}, stackitem.Make(1), false},
+		{"bad array element", ExtendedType{Base: smartcontract.ArrayType, Value: &ExtendedType{Base: smartcontract.IntegerType}}, tags, false},
+		{"struct", ExtendedType{Base: smartcontract.ArrayType, Name: "main.Point"}, point(stackitem.Make(1), owner, tags), true},
+		{"inline struct", testPoint, point(stackitem.Make(1), owner, tags), true},
+		{"unknown struct", ExtendedType{Base: smartcontract.ArrayType, Name: "main.Unknown"}, point(stackitem.Make(1), owner, tags), false},
+		{"bad struct size", ExtendedType{Base: smartcontract.ArrayType, Name: "main.Point"}, stackitem.NewStruct([]stackitem.Item{stackitem.Make(1)}), false},
+		{"bad struct field", ExtendedType{Base: smartcontract.ArrayType, Name: "main.Point"}, point(stackitem.Make(1), stackitem.Make(1), tags), false},
+		{"map", ExtendedType{Base: smartcontract.MapType, Key: smartcontract.IntegerType, Value: &ExtendedType{Base: smartcontract.ArrayType, Name: "main.Point"}},
+			stackitem.NewMapWithValue([]stackitem.MapElement{{Key: stackitem.Make(1), Value: point(stackitem.Make(1), owner, tags)}}), true},
+		{"bad map key", ExtendedType{Base: smartcontract.MapType, Key: smartcontract.IntegerType},
+			stackitem.NewMapWithValue([]stackitem.MapElement{{Key: stackitem.Make("1"), Value: stackitem.Make(1)}}), false},
+		{"bad map value", ExtendedType{Base: smartcontract.MapType, Key: smartcontract.IntegerType, Value: &ExtendedType{Base: smartcontract.BoolType}},
+			stackitem.NewMapWithValue([]stackitem.MapElement{{Key: stackitem.Make(1), Value: stackitem.Make(1)}}), false},
+		{"bad map", ExtendedType{Base: smartcontract.MapType}, stackitem.Make(1), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.et.CheckItem(tc.item, named)
+			if tc.ok {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,326 @@
+/*
+Package bundle implements contract bundles, single-file archives containing
+contract NEF, manifest and (optionally) debug info.
+
+Bundle is a ZIP archive with contract.nef, contract.manifest.json and
+contract.debug.json files along with bundle.json index file containing
+bundle format version, contract name, compiler, script hash and SHA256
+checksums of all other files. Reading a bundle verifies checksums and
+consistency of its parts, so mismatched artifacts can't be used.
+*/
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// FileExt is the conventional bundle file extension.
+const FileExt = "nefbundle"
+
+// Version is the bundle format version.
+const Version = 1
+
+// Bundle file names.
+const (
+	IndexFile     = "bundle.json"
+	NEFFile       = "contract.nef"
+	ManifestFile  = "contract.manifest.json"
+	DebugInfoFile = "contract.debug.json"
+)
+
+// Maximum sizes of bundle files.
+const (
+	maxIndexSize     = 64 * 1024
+	maxNEFSize       = stackitem.MaxSize
+	maxDebugInfoSize = 64 * 1024 * 1024
+	maxBundleSize    = maxIndexSize + maxNEFSize + manifest.MaxManifestSize + maxDebugInfoSize
+)
+
+// Various bundle errors.
+var (
+	// ErrInvalidBundle is returned for malformed bundles (not a ZIP archive,
+	// missing or unknown files, invalid index).
+	ErrInvalidBundle = errors.New("invalid bundle")
+	// ErrChecksumMismatch is returned when the file doesn't match its
+	// checksum from the index.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrHashMismatch is returned when the script hash from the index or the
+	// debug info doesn't match the NEF script.
+	ErrHashMismatch = errors.New("script hash mismatch")
+	// ErrNameMismatch is returned when the manifest contract name doesn't
+	// match the one from the index.
+	ErrNameMismatch = errors.New("contract name mismatch")
+	// ErrVersionMismatch is returned for unsupported bundle format versions
+	// and when the NEF compiler doesn't match the one from the index.
+	ErrVersionMismatch = errors.New("version mismatch")
+)
+
+// Index is the bundle index stored in bundle.json.
+type Index struct {
+	// Version is the bundle format version.
+	Version int `json:"version"`
+	// Name is the contract name from the manifest.
+	Name string `json:"name"`
+	// Compiler is the compiler from the NEF header.
+	Compiler string `json:"compiler"`
+	// Hash is the NEF script hash (it's not the contract hash, the latter
+	// depends on the sender).
+	Hash util.Uint160 `json:"hash"`
+	// Files maps file names to hex-encoded SHA256 checksums of their
+	// contents.
+	Files map[string]string `json:"files"`
+}
+
+// Bundle is a verified contract bundle.
+type Bundle struct {
+	Index    Index
+	NEF      *nef.File
+	Manifest *manifest.Manifest
+	// DebugInfo is the raw JSON debug info, it's nil if there is no debug
+	// info in the bundle.
+	DebugInfo json.RawMessage
+}
+
+// debugInfoHeader is the part of the debug info checked for consistency.
+type debugInfoHeader struct {
+	Hash util.Uint160 `json:"hash"`
+}
+
+// IsBundle checks whether the file name has bundle extension.
+func IsBundle(filename string) bool {
+	return strings.HasSuffix(filename, "."+FileExt)
+}
+
+// Write writes the bundle with the given NEF, manifest and debug info
+// (that is optional and can be nil) to w. Parts are verified for consistency
+// before writing.
+func Write(w io.Writer, n *nef.File, m *manifest.Manifest, debugInfo []byte) error {
+	nefBytes, err := n.Bytes()
+	if err != nil {
+		return fmt.Errorf("invalid NEF: %w", err)
+	}
+	mBytes, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("invalid manifest: %w", err)
+	}
+	files := map[string][]byte{
+		NEFFile:      nefBytes,
+		ManifestFile: mBytes,
+	}
+	if debugInfo != nil {
+		files[DebugInfoFile] = debugInfo
+	}
+	idx := Index{
+		Version:  Version,
+		Name:     m.Name,
+		Compiler: n.Header.Compiler,
+		Hash:     hash.Hash160(n.Script),
+		Files:    make(map[string]string, len(files)),
+	}
+	for name, data := range files {
+		idx.Files[name] = checksum(data)
+	}
+	if _, err := verify(idx, files); err != nil {
+		return err
+	}
+	idxBytes, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	zw := zip.NewWriter(w)
+	names := []string{IndexFile}
+	files[IndexFile] = idxBytes
+	for name := range idx.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names[1:])
+	for _, name := range names {
+		fw, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err := fw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// WriteFile writes the bundle to the file with the given name, see Write.
+func WriteFile(filename string, n *nef.File, m *manifest.Manifest, debugInfo []byte) error {
+	var buf bytes.Buffer
+	if err := Write(&buf, n, m, debugInfo); err != nil {
+		return err
+	}
+	return os.WriteFile(filename, buf.Bytes(), 0o644)
+}
+
+// Read reads and verifies the bundle.
+func Read(r io.Reader) (*Bundle, error) {
+	data, err := io.ReadAll(io.LimitReader(r, maxBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxBundleSize {
+		return nil, fmt.Errorf("%w: too big (more than %d bytes)", ErrInvalidBundle, maxBundleSize)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		var limit int64
+		switch f.Name {
+		case IndexFile:
+			limit = maxIndexSize
+		case NEFFile:
+			limit = maxNEFSize
+		case ManifestFile:
+			limit = manifest.MaxManifestSize
+		case DebugInfoFile:
+			limit = maxDebugInfoSize
+		default:
+			return nil, fmt.Errorf("%w: unexpected file %s", ErrInvalidBundle, f.Name)
+		}
+		if _, ok := files[f.Name]; ok {
+			return nil, fmt.Errorf("%w: duplicate file %s", ErrInvalidBundle, f.Name)
+		}
+		b, err := readZipFile(f, limit)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %w", ErrInvalidBundle, f.Name, err)
+		}
+		files[f.Name] = b
+	}
+	idxBytes, ok := files[IndexFile]
+	if !ok {
+		return nil, fmt.Errorf("%w: no %s", ErrInvalidBundle, IndexFile)
+	}
+	delete(files, IndexFile)
+	var idx Index
+	if err := json.Unmarshal(idxBytes, &idx); err != nil {
+		return nil, fmt.Errorf("%w: bad %s: %w", ErrInvalidBundle, IndexFile, err)
+	}
+	return verify(idx, files)
+}
+
+// ReadFile reads and verifies the bundle from the file with the given name.
+func ReadFile(filename string) (*Bundle, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+func readZipFile(f *zip.File, limit int64) ([]byte, error) {
+	if f.UncompressedSize64 > uint64(limit) {
+		return nil, fmt.Errorf("too big (more than %d bytes)", limit)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("too big (more than %d bytes)", limit)
+	}
+	return data, nil
+}
+
+// verify checks the files against the index and each other.
+func verify(idx Index, files map[string][]byte) (*Bundle, error) {
+	if idx.Version != Version {
+		return nil, fmt.Errorf("%w: unsupported bundle version %d", ErrVersionMismatch, idx.Version)
+	}
+	for _, name := range []string{NEFFile, ManifestFile} {
+		if _, ok := files[name]; !ok {
+			return nil, fmt.Errorf("%w: no %s", ErrInvalidBundle, name)
+		}
+	}
+	if len(idx.Files) != len(files) {
+		return nil, fmt.Errorf("%w: %d files listed in the index, %d present", ErrInvalidBundle, len(idx.Files), len(files))
+	}
+	for name, data := range files {
+		sum, ok := idx.Files[name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s is not listed in the index", ErrInvalidBundle, name)
+		}
+		if checksum(data) != strings.ToLower(sum) {
+			return nil, fmt.Errorf("%w: %s", ErrChecksumMismatch, name)
+		}
+	}
+
+	n, err := nef.FileFromBytes(files[NEFFile])
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad NEF: %w", ErrInvalidBundle, err)
+	}
+	if n.Header.Compiler != idx.Compiler {
+		return nil, fmt.Errorf("%w: NEF compiler is %q, index has %q", ErrVersionMismatch, n.Header.Compiler, idx.Compiler)
+	}
+	h := hash.Hash160(n.Script)
+	if !h.Equals(idx.Hash) {
+		return nil, fmt.Errorf("%w: NEF script hash is %s, index has %s", ErrHashMismatch, h.StringLE(), idx.Hash.StringLE())
+	}
+
+	m := new(manifest.Manifest)
+	if err := json.Unmarshal(files[ManifestFile], m); err != nil {
+		return nil, fmt.Errorf("%w: bad manifest: %w", ErrInvalidBundle, err)
+	}
+	if err := m.IsValid(util.Uint160{}, true); err != nil {
+		return nil, fmt.Errorf("%w: bad manifest: %w", ErrInvalidBundle, err)
+	}
+	if m.Name != idx.Name {
+		return nil, fmt.Errorf("%w: manifest has %q, index has %q", ErrNameMismatch, m.Name, idx.Name)
+	}
+	for _, md := range m.ABI.Methods {
+		if md.Offset < 0 || md.Offset >= len(n.Script) {
+			return nil, fmt.Errorf("%w: manifest method %s offset %d is out of script bounds (%d)",
+				ErrInvalidBundle, md.Name, md.Offset, len(n.Script))
+		}
+	}
+
+	b := &Bundle{
+		Index:    idx,
+		NEF:      &n,
+		Manifest: m,
+	}
+	if di, ok := files[DebugInfoFile]; ok {
+		var dh debugInfoHeader
+		if err := json.Unmarshal(di, &dh); err != nil {
+			return nil, fmt.Errorf("%w: bad debug info: %w", ErrInvalidBundle, err)
+		}
+		if !dh.Hash.Equals(h) {
+			return nil, fmt.Errorf("%w: debug info has %s, NEF script hash is %s", ErrHashMismatch, dh.Hash.StringLE(), h.StringLE())
+		}
+		b.DebugInfo = di
+	}
+	return b, nil
+}
+
+func checksum(data []byte) string {
+	h := sha256.Sum256(data)
+	return hex.EncodeToString(h[:])
+}
@@ -0,0 +1,198 @@
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/testcontract"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestContract(t *testing.T) (*nef.File, *manifest.Manifest, []byte) {
+	n, m := testcontract.New(t)
+	di, err := json.Marshal(map[string]any{
+		"hash":    hash.Hash160(n.Script),
+		"methods": []any{},
+	})
+	require.NoError(t, err)
+	return n, m, di
+}
+
+// writeRaw creates a bundle from the given files without any checks, index
+// is created for them unless it's given explicitly.
+func writeRaw(t *testing.T, n *nef.File, files map[string][]byte, modify func(*Index)) []byte {
+	if _, ok := files[IndexFile]; !ok {
+		idx := Index{
+			Version:  Version,
+			Name:     "Test",
+			Compiler: n.Header.Compiler,
+			Hash:     hash.Hash160(n.Script),
+			Files:    make(map[string]string),
+		}
+		for name, data := range files {
+			idx.Files[name] = checksum(data)
+		}
+		if modify != nil {
+			modify(&idx)
+		}
+		data, err := json.Marshal(idx)
+		require.NoError(t, err)
+		files[IndexFile] = data
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, data := range files {
+		fw, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = fw.Write(data)
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestWriteRead(t *testing.T) {
+	n, m, di := newTestContract(t)
+
+	t.Run("with debug info", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, n, m, di))
+		b, err := Read(&buf)
+		require.NoError(t, err)
+		require.Equal(t, n, b.NEF)
+		require.Equal(t, m, b.Manifest)
+		require.Equal(t, json.RawMessage(di), b.DebugInfo)
+		require.Equal(t, "Test", b.Index.Name)
+		require.Equal(t, Version, b.Index.Version)
+		require.Equal(t, hash.Hash160(n.Script), b.Index.Hash)
+		require.Equal(t, 3, len(b.Index.Files))
+	})
+	t.Run("without debug info", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Write(&buf, n, m, nil))
+		b, err := Read(&buf)
+		require.NoError(t, err)
+		require.Nil(t, b.DebugInfo)
+		require.Equal(t, 2, len(b.Index.Files))
+	})
+	t.Run("file", func(t *testing.T) {
+		p := filepath.Join(t.TempDir(), "contract."+FileExt)
+		require.True(t, IsBundle(p))
+		require.NoError(t, WriteFile(p, n, m, di))
+		b, err := ReadFile(p)
+		require.NoError(t, err)
+		require.Equal(t, n, b.NEF)
+	})
+	t.Run("mismatched debug info", func(t *testing.T) {
+		bad, err := json.Marshal(map[string]any{"hash": util.Uint160{1, 2, 3}})
+		require.NoError(t, err)
+		require.ErrorIs(t, Write(new(bytes.Buffer), n, m, bad), ErrHashMismatch)
+	})
+}
+
+func TestReadErrors(t *testing.T) {
+	n, m, di := newTestContract(t)
+	nefBytes, err := n.Bytes()
+	require.NoError(t, err)
+	mBytes, err := json.Marshal(m)
+	require.NoError(t, err)
+	files := func() map[string][]byte {
+		return map[string][]byte{
+			NEFFile:       nefBytes,
+			ManifestFile:  mBytes,
+			DebugInfoFile: di,
+		}
+	}
+	check := func(t *testing.T, data []byte, target error, substr string) {
+		_, err := Read(bytes.NewReader(data))
+		require.ErrorIs(t, err, target)
+		require.ErrorContains(t, err, substr)
+	}
+
+	t.Run("good", func(t *testing.T) {
+		_, err := Read(bytes.NewReader(writeRaw(t, n, files(), nil)))
+		require.NoError(t, err)
+	})
+	t.Run("not a zip", func(t *testing.T) {
+		check(t, []byte("not a bundle"), ErrInvalidBundle, "zip")
+	})
+	t.Run("no index", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, data := range files() {
+			fw, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = fw.Write(data)
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		check(t, buf.Bytes(), ErrInvalidBundle, IndexFile)
+	})
+	t.Run("bad index", func(t *testing.T) {
+		fs := files()
+		fs[IndexFile] = []byte("{")
+		check(t, writeRaw(t, n, fs, nil), ErrInvalidBundle, IndexFile)
+	})
+	t.Run("unexpected file", func(t *testing.T) {
+		fs := files()
+		fs["readme.txt"] = []byte("hello")
+		check(t, writeRaw(t, n, fs, nil), ErrInvalidBundle, "readme.txt")
+	})
+	t.Run("missing NEF", func(t *testing.T) {
+		fs := files()
+		delete(fs, NEFFile)
+		check(t, writeRaw(t, n, fs, nil), ErrInvalidBundle, NEFFile)
+	})
+	t.Run("file not in index", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			delete(idx.Files, DebugInfoFile)
+		}), ErrInvalidBundle, "files listed")
+	})
+	t.Run("corrupted manifest", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			idx.Files[ManifestFile] = checksum([]byte("other"))
+		}), ErrChecksumMismatch, ManifestFile)
+	})
+	t.Run("unsupported version", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			idx.Version = Version + 1
+		}), ErrVersionMismatch, "bundle version")
+	})
+	t.Run("compiler mismatch", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			idx.Compiler = "other-compiler"
+		}), ErrVersionMismatch, "compiler")
+	})
+	t.Run("script hash mismatch", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			idx.Hash = util.Uint160{1, 2, 3}
+		}), ErrHashMismatch, "NEF script hash")
+	})
+	t.Run("name mismatch", func(t *testing.T) {
+		check(t, writeRaw(t, n, files(), func(idx *Index) {
+			idx.Name = "Other"
+		}), ErrNameMismatch, `"Other"`)
+	})
+	t.Run("debug info hash mismatch", func(t *testing.T) {
+		fs := files()
+		fs[DebugInfoFile] = []byte(`{"hash":"0x0000000000000000000000000000000000000001"}`)
+		check(t, writeRaw(t, n, fs, nil), ErrHashMismatch, "debug info")
+	})
+	t.Run("method out of script", func(t *testing.T) {
+		bad := *m
+		bad.ABI.Methods = []manifest.Method{{Name: "main", Offset: 10, ReturnType: smartcontract.IntegerType}}
+		badBytes, err := json.Marshal(&bad)
+		require.NoError(t, err)
+		fs := files()
+		fs[ManifestFile] = badBytes
+		check(t, writeRaw(t, n, fs, nil), ErrInvalidBundle, "out of script bounds")
+	})
+}
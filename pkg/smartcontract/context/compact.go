@@ -0,0 +1,386 @@
+package context
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+)
+
+// compactMagic is the prefix of compact transaction context container.
+var compactMagic = []byte{'N', 'G', 'P', 'C'}
+
+// compactVersion is the current compact container format version.
+const compactVersion = 0
+
+// compactChecksumLen is the length of the container checksum.
+const compactChecksumLen = 4
+
+// maxCompactItems is the maximum number of items in a compact container, it's
+// limited by the maximum number of transaction signers.
+const maxCompactItems = transaction.MaxAttributes
+
+// ErrInvalidChecksum is returned when compact container checksum doesn't
+// match its contents (the container is damaged or tampered with).
+var ErrInvalidChecksum = errors.New("invalid container checksum")
+
+// MarshalCompact encodes the transaction context into a compact binary
+// container that is suitable for offline signing. The container contains
+// network magic, transaction and all context items (verification scripts,
+// parameters and signatures) protected by a checksum. Only transaction
+// contexts are supported.
+func (c *ParameterContext) MarshalCompact() ([]byte, error) {
+	if _, ok := c.Verifiable.(*transaction.Transaction); !ok {
+		return nil, errors.New("verifiable item is not a transaction")
+	}
+	verif, err := c.Verifiable.EncodeHashableFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode hashable fields: %w", err)
+	}
+	hashes := make([]util.Uint160, 0, len(c.Items))
+	for h := range c.Items {
+		hashes = append(hashes, h)
+	}
+	sort.Slice(hashes, func(i, j int) bool {
+		return hashes[i].Less(hashes[j])
+	})
+
+	bw := io.NewBufBinWriter()
+	bw.WriteBytes(compactMagic)
+	bw.WriteB(compactVersion)
+	bw.WriteU32LE(uint32(c.Network))
+	bw.WriteVarBytes(verif)
+	bw.WriteVarUint(uint64(len(hashes)))
+	for _, h := range hashes {
+		item := c.Items[h]
+		bw.WriteBytes(h[:])
+		bw.WriteVarBytes(item.Script)
+		bw.WriteVarUint(uint64(len(item.Parameters)))
+		for i := range item.Parameters {
+			if err := encodeCompactParameter(bw.BinWriter, &item.Parameters[i]); err != nil {
+				return nil, fmt.Errorf("item %s: parameter #%d: %w", h.StringLE(), i, err)
+			}
+		}
+		pubs := make([]string, 0, len(item.Signatures))
+		for pub := range item.Signatures {
+			pubs = append(pubs, pub)
+		}
+		sort.Strings(pubs)
+		bw.WriteVarUint(uint64(len(pubs)))
+		for _, pubHex := range pubs {
+			pub, err := keys.NewPublicKeyFromString(pubHex)
+			if err != nil {
+				return nil, fmt.Errorf("item %s: invalid public key %s: %w", h.StringLE(), pubHex, err)
+			}
+			bw.WriteBytes(pub.Bytes())
+			bw.WriteVarBytes(item.Signatures[pubHex])
+		}
+	}
+	if bw.Err != nil {
+		return nil, bw.Err
+	}
+	data := bw.Bytes()
+	return append(data, hash.Checksum(data)...), nil
+}
+
+// UnmarshalCompact decodes the transaction context from the compact binary
+// container created by MarshalCompact.
+func (c *ParameterContext) UnmarshalCompact(data []byte) error {
+	if len(data) < len(compactMagic)+compactChecksumLen {
+		return errors.New("container is too short")
+	}
+	if !bytes.Equal(data[:len(compactMagic)], compactMagic) {
+		return errors.New("not a compact context container")
+	}
+	payload, sum := data[:len(data)-compactChecksumLen], data[len(data)-compactChecksumLen:]
+	if !bytes.Equal(hash.Checksum(payload), sum) {
+		return ErrInvalidChecksum
+	}
+
+	br := io.NewBinReaderFromBuf(payload[len(compactMagic):])
+	if v := br.ReadB(); br.Err == nil && v != compactVersion {
+		return fmt.Errorf("unsupported container version %d", v)
+	}
+	network := netmode.Magic(br.ReadU32LE())
+	verif := br.ReadVarBytes(transaction.MaxTransactionSize)
+	if br.Err != nil {
+		return br.Err
+	}
+	tx := new(transaction.Transaction)
+	if err := tx.DecodeHashableFields(verif); err != nil {
+		return fmt.Errorf("invalid transaction: %w", err)
+	}
+	n := br.ReadVarUint()
+	if n > maxCompactItems {
+		return fmt.Errorf("too many items: %d", n)
+	}
+	items := make(map[util.Uint160]*Item, n)
+	for i := 0; i < int(n) && br.Err == nil; i++ {
+		var h util.Uint160
+		br.ReadBytes(h[:])
+		item := &Item{Signatures: make(map[string][]byte)}
+		item.Script = br.ReadVarBytes()
+		if len(item.Script) == 0 {
+			item.Script = nil
+		}
+		np := br.ReadVarUint()
+		if np > vm.MaxMultisigKeys {
+			return fmt.Errorf("item %s: too many parameters: %d", h.StringLE(), np)
+		}
+		item.Parameters = make([]smartcontract.Parameter, np)
+		for j := range item.Parameters {
+			if err := decodeCompactParameter(br, &item.Parameters[j]); err != nil {
+				return fmt.Errorf("item %s: parameter #%d: %w", h.StringLE(), j, err)
+			}
+		}
+		ns := br.ReadVarUint()
+		if ns > vm.MaxMultisigKeys {
+			return fmt.Errorf("item %s: too many signatures: %d", h.StringLE(), ns)
+		}
+		for j := 0; j < int(ns) && br.Err == nil; j++ {
+			pub := new(keys.PublicKey)
+			pub.DecodeBinary(br)
+			sig := br.ReadVarBytes(keys.SignatureLen)
+			if br.Err == nil {
+				item.AddSignature(pub, sig)
+			}
+		}
+		if _, ok := items[h]; ok {
+			return fmt.Errorf("duplicate item %s", h.StringLE())
+		}
+		items[h] = item
+	}
+	if br.Err != nil {
+		return br.Err
+	}
+	if br.Len() != 0 {
+		return errors.New("unexpected trailing data")
+	}
+	c.Type = TransactionType
+	c.Network = network
+	c.Verifiable = tx
+	c.Items = items
+	return nil
+}
+
+func encodeCompactParameter(w *io.BinWriter, p *smartcontract.Parameter) error {
+	w.WriteB(byte(p.Type))
+	if p.Value == nil {
+		w.WriteBool(false)
+		return nil
+	}
+	w.WriteBool(true)
+	switch p.Type {
+	case smartcontract.SignatureType, smartcontract.ByteArrayType, smartcontract.PublicKeyType:
+		b, ok := p.Value.([]byte)
+		if !ok {
+			return fmt.Errorf("invalid %s value", p.Type)
+		}
+		w.WriteVarBytes(b)
+	default:
+		data, err := json.Marshal(p)
+		if err != nil {
+			return err
+		}
+		w.WriteVarBytes(data)
+	}
+	return nil
+}
+
+func decodeCompactParameter(r *io.BinReader, p *smartcontract.Parameter) error {
+	p.Type = smartcontract.ParamType(r.ReadB())
+	if !r.ReadBool() {
+		return r.Err
+	}
+	data := r.ReadVarBytes()
+	if r.Err != nil {
+		return r.Err
+	}
+	switch p.Type {
+	case smartcontract.SignatureType, smartcontract.ByteArrayType, smartcontract.PublicKeyType:
+		p.Value = data
+	default:
+		typ := p.Type
+		if err := json.Unmarshal(data, p); err != nil {
+			return err
+		}
+		if p.Type != typ {
+			return fmt.Errorf("type mismatch: %s vs %s", typ, p.Type)
+		}
+	}
+	return nil
+}
+
+// VerifySignatures checks that all signatures present in the context are
+// valid signatures of its verifiable item for its network made by the keys
+// from the corresponding verification scripts. It also checks that every
+// item with a verification script is stored under the script hash. It's
+// intended to be used before merging a context received from an untrusted
+// source.
+func (c *ParameterContext) VerifySignatures() error {
+	for h, item := range c.Items {
+		if item.Script == nil {
+			continue // Deployed contract, nothing to check against.
+		}
+		if sh := hash.Hash160(item.Script); !sh.Equals(h) {
+			return fmt.Errorf("item %s: script hash mismatch (%s)", h.StringLE(), sh.StringLE())
+		}
+		var pubBytes [][]byte
+		if _, ps, ok := vm.ParseMultiSigContract(item.Script); ok {
+			pubBytes = ps
+		} else if p, ok := vm.ParseSignatureContract(item.Script); ok {
+			pubBytes = [][]byte{p}
+		} else {
+			continue // Non-standard contract, parameters are arbitrary.
+		}
+		pubs := make(keys.PublicKeys, 0, len(pubBytes))
+		for i := range pubBytes {
+			pub, err := keys.NewPublicKeyFromBytes(pubBytes[i], elliptic.P256())
+			if err != nil {
+				return fmt.Errorf("item %s: invalid public key in script: %w", h.StringLE(), err)
+			}
+			pubs = append(pubs, pub)
+		}
+		for pubHex, sig := range item.Signatures {
+			pub, err := keys.NewPublicKeyFromString(pubHex)
+			if err != nil {
+				return fmt.Errorf("item %s: invalid public key %s: %w", h.StringLE(), pubHex, err)
+			}
+			if !pubs.Contains(pub) {
+				return fmt.Errorf("item %s: public key %s is not present in script", h.StringLE(), pubHex)
+			}
+			if !pub.VerifyHashable(sig, uint32(c.Network), c.Verifiable) {
+				return fmt.Errorf("item %s: invalid signature of %s", h.StringLE(), pubHex)
+			}
+		}
+		for i := range item.Parameters {
+			sig, ok := item.Parameters[i].Value.([]byte)
+			if item.Parameters[i].Type != smartcontract.SignatureType || !ok {
+				continue
+			}
+			var valid bool
+			for _, pub := range pubs {
+				if pub.VerifyHashable(sig, uint32(c.Network), c.Verifiable) {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("item %s: invalid signature in parameter #%d", h.StringLE(), i)
+			}
+		}
+	}
+	return nil
+}
+
+// EncodeCompactChunks encodes the compact container into a set of base64
+// text chunks not exceeding the given size each, which is convenient for
+// transferring it via QR codes. Every chunk has "<index>/<total>:" prefix
+// (1-based index), so they can be decoded in any order. A single unprefixed
+// chunk is returned if size is not positive.
+func EncodeCompactChunks(data []byte, size int) ([]string, error) {
+	s := base64.StdEncoding.EncodeToString(data)
+	if size <= 0 {
+		return []string{s}, nil
+	}
+	// Prefix length depends on the number of chunks, so start with a single
+	// chunk and increase the estimation until everything fits.
+	var n, payload = 1, 0
+	for {
+		payload = size - len(strconv.Itoa(n))*2 - 2 // "n/n:"
+		if payload <= 0 {
+			return nil, fmt.Errorf("chunk size %d is too small", size)
+		}
+		need := (len(s) + payload - 1) / payload
+		if need <= n {
+			n = need
+			break
+		}
+		n = need
+	}
+	res := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		end := (i + 1) * payload
+		if end > len(s) {
+			end = len(s)
+		}
+		res = append(res, fmt.Sprintf("%d/%d:%s", i+1, n, s[i*payload:end]))
+	}
+	return res, nil
+}
+
+// DecodeCompactChunks decodes the set of chunks created by
+// EncodeCompactChunks (in any order) back into the compact container. Empty
+// strings are ignored.
+func DecodeCompactChunks(chunks []string) ([]byte, error) {
+	var (
+		parts []string
+		plain []string
+		total int
+	)
+	for _, c := range chunks {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		prefix, payload, ok := strings.Cut(c, ":")
+		if !ok {
+			plain = append(plain, c)
+			continue
+		}
+		idxStr, totalStr, ok := strings.Cut(prefix, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid chunk prefix %q", prefix)
+		}
+		idx, err := strconv.Atoi(idxStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chunk index %q", idxStr)
+		}
+		t, err := strconv.Atoi(totalStr)
+		if err != nil || t <= 0 || t > 0xffff {
+			return nil, fmt.Errorf("invalid chunk total %q", totalStr)
+		}
+		if parts == nil {
+			total = t
+			parts = make([]string, total)
+		} else if t != total {
+			return nil, fmt.Errorf("chunk total mismatch: %d vs %d", t, total)
+		}
+		if idx < 1 || idx > total {
+			return nil, fmt.Errorf("chunk index %d is out of range", idx)
+		}
+		if parts[idx-1] != "" && parts[idx-1] != payload {
+			return nil, fmt.Errorf("conflicting chunk %d", idx)
+		}
+		parts[idx-1] = payload
+	}
+	if parts != nil && plain != nil {
+		return nil, errors.New("mixed chunked and plain data")
+	}
+	if parts == nil {
+		if len(plain) == 0 {
+			return nil, errors.New("no data")
+		}
+		return base64.StdEncoding.DecodeString(strings.Join(plain, ""))
+	}
+	for i := range parts {
+		if parts[i] == "" {
+			return nil, fmt.Errorf("missing chunk %d of %d", i+1, total)
+		}
+	}
+	return base64.StdEncoding.DecodeString(strings.Join(parts, ""))
+}
@@ -0,0 +1,213 @@
+package context
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+// newOfflineTestTx returns a transaction with 2-of-3 multisig and simple
+// signature signers along with accounts that can sign it.
+func newOfflineTestTx(t *testing.T) (*transaction.Transaction, []*wallet.Account) {
+	privs, pubs := getPrivateKeys(t, 4)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs[:3]).Copy())
+	require.NoError(t, err)
+	accs := make([]*wallet.Account, len(privs))
+	for i := range privs {
+		accs[i] = wallet.NewAccountFromPrivateKey(privs[i])
+		if i < 3 {
+			require.NoError(t, accs[i].ConvertMultisig(2, keys.PublicKeys(pubs[:3]).Copy()))
+		}
+	}
+	tx := transaction.New([]byte{byte(opcode.PUSH1)}, 0)
+	tx.Signers = []transaction.Signer{{Account: hash.Hash160(script)}, {Account: accs[3].ScriptHash()}}
+	return tx, accs
+}
+
+func compactRoundTrip(t *testing.T, c *ParameterContext) *ParameterContext {
+	bin, err := c.MarshalCompact()
+	require.NoError(t, err)
+	actual := new(ParameterContext)
+	require.NoError(t, actual.UnmarshalCompact(bin))
+	return actual
+}
+
+func TestParameterContext_MarshalCompact(t *testing.T) {
+	tx, accs := newOfflineTestTx(t)
+	c := NewTransactionContext(netmode.UnitTestNet, tx)
+	require.NoError(t, c.Sign(accs[0]))
+	require.NoError(t, c.Sign(accs[3]))
+
+	actual := compactRoundTrip(t, c)
+	require.Equal(t, c.Type, actual.Type)
+	require.Equal(t, c.Network, actual.Network)
+	require.Equal(t, c.Verifiable.Hash(), actual.Verifiable.Hash())
+	require.Equal(t, c.Items, actual.Items)
+	require.NoError(t, actual.VerifySignatures())
+
+	t.Run("non-signature parameters", func(t *testing.T) {
+		c := NewTransactionContext(netmode.UnitTestNet, tx)
+		c.Items[tx.Signers[0].Account] = &Item{
+			Parameters: []smartcontract.Parameter{
+				{Type: smartcontract.IntegerType, Value: nil},
+				{Type: smartcontract.StringType, Value: "str"},
+				{Type: smartcontract.ByteArrayType, Value: []byte{1, 2, 3}},
+			},
+			Signatures: make(map[string][]byte),
+		}
+		require.Equal(t, c.Items, compactRoundTrip(t, c).Items)
+	})
+	t.Run("not a transaction", func(t *testing.T) {
+		c := NewParameterContext(TransactionType, netmode.UnitTestNet, verifStub{})
+		_, err := c.MarshalCompact()
+		require.Error(t, err)
+	})
+	t.Run("bad data", func(t *testing.T) {
+		bin, err := c.MarshalCompact()
+		require.NoError(t, err)
+		require.Error(t, new(ParameterContext).UnmarshalCompact(bin[:3]))
+		require.Error(t, new(ParameterContext).UnmarshalCompact(append([]byte{'X'}, bin[1:]...)))
+	})
+}
+
+func TestParameterContext_CompactTampered(t *testing.T) {
+	tx, accs := newOfflineTestTx(t)
+	c := NewTransactionContext(netmode.UnitTestNet, tx)
+	require.NoError(t, c.Sign(accs[3]))
+	bin, err := c.MarshalCompact()
+	require.NoError(t, err)
+
+	t.Run("checksum", func(t *testing.T) {
+		for _, i := range []int{len(compactMagic) + 1, len(bin) / 2, len(bin) - 1} {
+			damaged := append([]byte{}, bin...)
+			damaged[i] ^= 0xff
+			require.ErrorIs(t, new(ParameterContext).UnmarshalCompact(damaged), ErrInvalidChecksum)
+		}
+	})
+	t.Run("signature", func(t *testing.T) {
+		other := compactRoundTrip(t, c)
+		sig := other.Items[accs[3].ScriptHash()].Parameters[0].Value.([]byte)
+		sig[0] ^= 0xff
+		// Checksum is valid for the re-encoded container, but signature is not.
+		other = compactRoundTrip(t, other)
+		require.Error(t, other.VerifySignatures())
+	})
+	t.Run("network", func(t *testing.T) {
+		other := compactRoundTrip(t, c)
+		other.Network = netmode.TestNet
+		require.Error(t, compactRoundTrip(t, other).VerifySignatures())
+	})
+	t.Run("transaction", func(t *testing.T) {
+		other := compactRoundTrip(t, c)
+		other.Verifiable.(*transaction.Transaction).SystemFee++
+		other = compactRoundTrip(t, other)
+		require.NotEqual(t, c.Verifiable.Hash(), other.Verifiable.Hash())
+		require.Error(t, other.VerifySignatures())
+		require.Error(t, c.Merge(other))
+	})
+	t.Run("foreign key", func(t *testing.T) {
+		other := NewTransactionContext(netmode.UnitTestNet, tx)
+		require.NoError(t, other.Sign(accs[0]))
+		item := other.Items[tx.Signers[0].Account]
+		item.AddSignature(accs[3].PublicKey(), accs[3].SignHashable(other.Network, tx))
+		require.Error(t, compactRoundTrip(t, other).VerifySignatures())
+	})
+	t.Run("script hash", func(t *testing.T) {
+		other := compactRoundTrip(t, c)
+		other.Items[tx.Signers[0].Account] = other.Items[accs[3].ScriptHash()]
+		delete(other.Items, accs[3].ScriptHash())
+		require.Error(t, compactRoundTrip(t, other).VerifySignatures())
+	})
+}
+
+func TestParameterContext_CompactMergeOrder(t *testing.T) {
+	tx, accs := newOfflineTestTx(t)
+	signed := make([]*ParameterContext, 0, 3)
+	for _, i := range []int{0, 2, 3} {
+		c := NewTransactionContext(netmode.UnitTestNet, tx)
+		require.NoError(t, c.Sign(accs[i]))
+		signed = append(signed, compactRoundTrip(t, c))
+	}
+
+	var expected []transaction.Witness
+	for _, order := range [][]int{{0, 1, 2}, {2, 1, 0}, {1, 2, 0}, {2, 0, 1}} {
+		c := NewTransactionContext(netmode.UnitTestNet, tx)
+		for _, i := range order {
+			require.NoError(t, signed[i].VerifySignatures())
+			require.NoError(t, c.Merge(signed[i]))
+		}
+		require.True(t, c.IsComplete())
+		ws, err := c.GetWitnesses()
+		require.NoError(t, err)
+		if expected == nil {
+			expected = ws
+			for i := range ws {
+				v := newTestVM(&ws[i], tx)
+				require.NoError(t, v.Run())
+				require.Equal(t, true, v.Estack().Pop().Value())
+			}
+			continue
+		}
+		require.Equal(t, expected, ws)
+	}
+}
+
+func TestCompactChunks(t *testing.T) {
+	data := make([]byte, 300)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	t.Run("single", func(t *testing.T) {
+		chunks, err := EncodeCompactChunks(data, 0)
+		require.NoError(t, err)
+		require.Equal(t, []string{base64.StdEncoding.EncodeToString(data)}, chunks)
+		actual, err := DecodeCompactChunks(chunks)
+		require.NoError(t, err)
+		require.Equal(t, data, actual)
+	})
+	t.Run("chunked", func(t *testing.T) {
+		chunks, err := EncodeCompactChunks(data, 50)
+		require.NoError(t, err)
+		require.Equal(t, 9, len(chunks))
+		for _, c := range chunks {
+			require.LessOrEqual(t, len(c), 50)
+		}
+		require.True(t, strings.HasPrefix(chunks[0], "1/9:"))
+
+		reversed := make([]string, 0, len(chunks)+1)
+		for i := len(chunks) - 1; i >= 0; i-- {
+			reversed = append(reversed, chunks[i])
+		}
+		reversed = append(reversed, chunks[3], "") // Duplicates and empty lines are OK.
+		actual, err := DecodeCompactChunks(reversed)
+		require.NoError(t, err)
+		require.Equal(t, data, actual)
+
+		_, err = DecodeCompactChunks(chunks[1:])
+		require.ErrorContains(t, err, "missing chunk 1 of 9")
+		_, err = DecodeCompactChunks(append([]string{"2/9:AAAA"}, chunks...))
+		require.Error(t, err)
+		_, err = DecodeCompactChunks(append([]string{"10/10:AAAA"}, chunks...))
+		require.Error(t, err)
+		_, err = DecodeCompactChunks(append([]string{"AAAA"}, chunks...))
+		require.Error(t, err)
+	})
+	t.Run("small size", func(t *testing.T) {
+		_, err := EncodeCompactChunks(data, 4)
+		require.Error(t, err)
+	})
+	t.Run("no data", func(t *testing.T) {
+		_, err := DecodeCompactChunks([]string{"", " "})
+		require.Error(t, err)
+	})
+}
@@ -62,24 +62,55 @@ func NewParameterContext(typ string, network netmode.Magic, verif crypto.Verifia
 	}
 }
 
+// SignerStatus represents signing status of a single context item.
+type SignerStatus struct {
+	// Account is the script hash of the item.
+	Account util.Uint160
+	// Required is the number of signatures required by the item, it's zero
+	// for items that are not yet present in the context.
+	Required int
+	// Signed is the number of signatures already collected for the item.
+	Signed int
+	// Complete is true if all the parameters for the item are present, so
+	// its witness can be built.
+	Complete bool
+}
+
+// NewTransactionContext returns ParameterContext for the specified transaction
+// to be signed by its signers.
+func NewTransactionContext(network netmode.Magic, tx *transaction.Transaction) *ParameterContext {
+	return NewParameterContext(TransactionType, network, tx)
+}
+
 // GetCompleteTransaction clears transaction witnesses (if any) and refills them with
 // signatures from the parameter context.
 func (c *ParameterContext) GetCompleteTransaction() (*transaction.Transaction, error) {
+	ws, err := c.GetWitnesses()
+	if err != nil {
+		return nil, err
+	}
+	tx := c.Verifiable.(*transaction.Transaction)
+	tx.Scripts = ws
+	return tx, nil
+}
+
+// GetWitnesses returns the list of witnesses for all transaction signers (in
+// the same order). It fails if verifiable item is not a transaction or if some
+// of the witnesses can't be built yet.
+func (c *ParameterContext) GetWitnesses() ([]transaction.Witness, error) {
 	tx, ok := c.Verifiable.(*transaction.Transaction)
 	if !ok {
 		return nil, errors.New("verifiable item is not a transaction")
 	}
-	if len(tx.Scripts) > 0 {
-		tx.Scripts = tx.Scripts[:0]
-	}
+	ws := make([]transaction.Witness, 0, len(tx.Signers))
 	for i := range tx.Signers {
 		w, err := c.GetWitness(tx.Signers[i].Account)
 		if err != nil {
 			return nil, fmt.Errorf("can't create witness for signer #%d: %w", i, err)
 		}
-		tx.Scripts = append(tx.Scripts, *w)
+		ws = append(ws, *w)
 	}
-	return tx, nil
+	return ws, nil
 }
 
 // GetWitness returns invocation and verification scripts for the specified contract.
@@ -166,6 +197,154 @@ func (c *ParameterContext) AddSignature(h util.Uint160, ctr *wallet.Contract, pu
 	return nil
 }
 
+// Sign signs the verifiable item with the given account and adds the signature
+// to the context. For transactions the account must be one of the signers.
+func (c *ParameterContext) Sign(acc *wallet.Account) error {
+	if !acc.CanSign() {
+		return errors.New("account can't sign")
+	}
+	if acc.Contract == nil {
+		return errors.New("account has no contract")
+	}
+	h := acc.ScriptHash()
+	if tx, ok := c.Verifiable.(*transaction.Transaction); ok && !tx.HasSigner(h) {
+		return fmt.Errorf("%s is not a transaction signer", h.StringLE())
+	}
+	return c.AddSignature(h, acc.Contract, acc.PublicKey(), acc.SignHashable(c.Network, c.Verifiable))
+}
+
+// Merge adds all signatures and parameters from the other context to c. Both
+// contexts must have the same type and network and refer to the same
+// verifiable item. c is not changed if an error is returned.
+func (c *ParameterContext) Merge(other *ParameterContext) error {
+	if c.Type != other.Type {
+		return fmt.Errorf("type mismatch: %s vs %s", c.Type, other.Type)
+	}
+	if c.Network != other.Network {
+		return fmt.Errorf("network mismatch: %d vs %d", c.Network, other.Network)
+	}
+	if h, oh := c.Verifiable.Hash(), other.Verifiable.Hash(); !h.Equals(oh) {
+		return fmt.Errorf("verifiable item mismatch: %s vs %s", h.StringLE(), oh.StringLE())
+	}
+	items := make(map[util.Uint160]*Item, len(c.Items))
+	for h, item := range c.Items {
+		items[h] = item.copy()
+	}
+	res := &ParameterContext{
+		Type:       c.Type,
+		Network:    c.Network,
+		Verifiable: c.Verifiable,
+		Items:      items,
+	}
+	for h, oItem := range other.Items {
+		item, ok := res.Items[h]
+		if !ok {
+			res.Items[h] = oItem.copy()
+			continue
+		}
+		if !bytes.Equal(item.Script, oItem.Script) || len(item.Parameters) != len(oItem.Parameters) {
+			return fmt.Errorf("item %s mismatch", h.StringLE())
+		}
+		if m, _, ok := vm.ParseMultiSigContract(item.Script); ok {
+			ctr := &wallet.Contract{
+				Script:     item.Script,
+				Parameters: make([]wallet.ContractParam, m),
+			}
+			for i := range ctr.Parameters {
+				ctr.Parameters[i].Type = smartcontract.SignatureType
+			}
+			for pubHex, sig := range oItem.Signatures {
+				if _, ok := item.Signatures[pubHex]; ok {
+					continue
+				}
+				pub, err := keys.NewPublicKeyFromString(pubHex)
+				if err != nil {
+					return fmt.Errorf("item %s: invalid public key %s: %w", h.StringLE(), pubHex, err)
+				}
+				err = res.AddSignature(h, ctr, pub, sig)
+				if err != nil {
+					return fmt.Errorf("item %s: %w", h.StringLE(), err)
+				}
+			}
+			continue
+		}
+		for i := range oItem.Parameters {
+			if item.Parameters[i].Type != oItem.Parameters[i].Type {
+				return fmt.Errorf("item %s: parameter #%d type mismatch", h.StringLE(), i)
+			}
+			if item.Parameters[i].Value == nil {
+				item.Parameters[i].Value = oItem.Parameters[i].Value
+			}
+		}
+		for pubHex, sig := range oItem.Signatures {
+			if _, ok := item.Signatures[pubHex]; !ok {
+				item.Signatures[pubHex] = sig
+			}
+		}
+	}
+	c.Items = res.Items
+	return nil
+}
+
+// GetSignersStatus returns signing status for every transaction signer (in the
+// same order) or for every item in the context (ordered by script hash) if
+// verifiable item is not a transaction.
+func (c *ParameterContext) GetSignersStatus() []SignerStatus {
+	var hashes []util.Uint160
+	if tx, ok := c.Verifiable.(*transaction.Transaction); ok {
+		hashes = make([]util.Uint160, len(tx.Signers))
+		for i := range tx.Signers {
+			hashes[i] = tx.Signers[i].Account
+		}
+	} else {
+		hashes = make([]util.Uint160, 0, len(c.Items))
+		for h := range c.Items {
+			hashes = append(hashes, h)
+		}
+		sort.Slice(hashes, func(i, j int) bool {
+			return hashes[i].Less(hashes[j])
+		})
+	}
+	res := make([]SignerStatus, len(hashes))
+	for i, h := range hashes {
+		res[i].Account = h
+		item, ok := c.Items[h]
+		if !ok {
+			continue
+		}
+		res[i].Complete = true
+		for j := range item.Parameters {
+			if item.Parameters[j].Value == nil {
+				res[i].Complete = false
+			} else if item.Parameters[j].Type == smartcontract.SignatureType {
+				res[i].Signed++
+			}
+			if item.Parameters[j].Type == smartcontract.SignatureType {
+				res[i].Required++
+			}
+		}
+		if _, _, ok := vm.ParseMultiSigContract(item.Script); ok && !res[i].Complete {
+			// Parameters are only filled when enough signatures are collected.
+			res[i].Signed = len(item.Signatures)
+			if res[i].Signed > res[i].Required {
+				res[i].Signed = res[i].Required
+			}
+		}
+	}
+	return res
+}
+
+// IsComplete returns true if all the witnesses (see GetSignersStatus) can be
+// built from the context.
+func (c *ParameterContext) IsComplete() bool {
+	for _, s := range c.GetSignersStatus() {
+		if !s.Complete {
+			return false
+		}
+	}
+	return true
+}
+
 func (c *ParameterContext) getItemForContract(h util.Uint160, ctr *wallet.Contract) *Item {
 	item, ok := c.Items[ctr.ScriptHash()]
 	if ok {
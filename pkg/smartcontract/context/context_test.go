@@ -10,6 +10,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/core/interop"
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/crypto"
 	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
 	"github.com/nspcc-dev/neo-go/pkg/util"
@@ -158,6 +159,118 @@ func TestParameterContext_AddSignatureMultisig(t *testing.T) {
 	})
 }
 
+func TestParameterContext_Sign(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	msAcc := wallet.NewAccountFromPrivateKey(privs[0])
+	require.NoError(t, msAcc.ConvertMultisig(2, keys.PublicKeys(pubs[:2]).Copy()))
+	acc := wallet.NewAccountFromPrivateKey(privs[2])
+	tx := getContractTx(msAcc.ScriptHash())
+	tx.Signers = append(tx.Signers, transaction.Signer{Account: acc.ScriptHash()})
+
+	c := NewTransactionContext(netmode.UnitTestNet, tx)
+	require.Equal(t, TransactionType, c.Type)
+	require.Equal(t, []SignerStatus{
+		{Account: msAcc.ScriptHash()},
+		{Account: acc.ScriptHash()},
+	}, c.GetSignersStatus())
+
+	t.Run("not a signer", func(t *testing.T) {
+		priv, err := keys.NewPrivateKey()
+		require.NoError(t, err)
+		require.Error(t, c.Sign(wallet.NewAccountFromPrivateKey(priv)))
+		require.Equal(t, 0, len(c.Items))
+	})
+	t.Run("locked", func(t *testing.T) {
+		locked := wallet.NewAccountFromPrivateKey(privs[2])
+		locked.Locked = true
+		require.Error(t, c.Sign(locked))
+		require.Equal(t, 0, len(c.Items))
+	})
+
+	require.NoError(t, c.Sign(acc))
+	require.NoError(t, c.Sign(msAcc))
+	require.Error(t, c.Sign(msAcc))
+	require.False(t, c.IsComplete())
+	require.Equal(t, []SignerStatus{
+		{Account: msAcc.ScriptHash(), Required: 2, Signed: 1},
+		{Account: acc.ScriptHash(), Required: 1, Signed: 1, Complete: true},
+	}, c.GetSignersStatus())
+
+	msAcc2 := wallet.NewAccountFromPrivateKey(privs[1])
+	require.NoError(t, msAcc2.ConvertMultisig(2, keys.PublicKeys(pubs[:2]).Copy()))
+	require.NoError(t, c.Sign(msAcc2))
+	require.True(t, c.IsComplete())
+	tx, err := c.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(tx.Scripts))
+}
+
+func TestParameterContext_Merge(t *testing.T) {
+	privs, pubs := getPrivateKeys(t, 3)
+	script, err := smartcontract.CreateMultiSigRedeemScript(2, keys.PublicKeys(pubs).Copy())
+	require.NoError(t, err)
+	accs := make([]*wallet.Account, len(privs))
+	for i := range privs {
+		accs[i] = wallet.NewAccountFromPrivateKey(privs[i])
+		require.NoError(t, accs[i].ConvertMultisig(2, keys.PublicKeys(pubs).Copy()))
+	}
+	tx := getContractTx(hash.Hash160(script))
+
+	newSigned := func(t *testing.T, accs ...*wallet.Account) *ParameterContext {
+		c := NewTransactionContext(netmode.UnitTestNet, tx)
+		for _, acc := range accs {
+			require.NoError(t, c.Sign(acc))
+		}
+		return c
+	}
+	t.Run("mismatch", func(t *testing.T) {
+		c := newSigned(t, accs[0])
+		other := newSigned(t, accs[1])
+		other.Network = netmode.TestNet
+		require.Error(t, c.Merge(other))
+
+		other = newSigned(t, accs[1])
+		other.Type = compatTransactionType
+		require.Error(t, c.Merge(other))
+
+		other = NewTransactionContext(netmode.UnitTestNet, getContractTx(util.Uint160{1, 2, 3}))
+		require.Error(t, c.Merge(other))
+
+		other = newSigned(t, accs[1])
+		other.Items[tx.Signers[0].Account].Script = []byte{byte(opcode.PUSHT)}
+		require.Error(t, c.Merge(other))
+		require.False(t, c.IsComplete())
+	})
+	t.Run("the same key", func(t *testing.T) {
+		c := newSigned(t, accs[0])
+		require.NoError(t, c.Merge(newSigned(t, accs[0])))
+		require.Equal(t, 1, c.GetSignersStatus()[0].Signed)
+		require.False(t, c.IsComplete())
+	})
+	t.Run("missing item", func(t *testing.T) {
+		c := NewTransactionContext(netmode.UnitTestNet, tx)
+		other := newSigned(t, accs[0], accs[2])
+		require.NoError(t, c.Merge(other))
+		require.True(t, c.IsComplete())
+
+		// Modification of the merged context doesn't affect the source one.
+		c.Items[tx.Signers[0].Account].Parameters[0].Value = nil
+		require.False(t, c.IsComplete())
+		require.True(t, other.IsComplete())
+	})
+	t.Run("good", func(t *testing.T) {
+		c := newSigned(t, accs[2])
+		require.NoError(t, c.Merge(newSigned(t, accs[0])))
+		require.True(t, c.IsComplete())
+		require.Equal(t, []SignerStatus{{Account: tx.Signers[0].Account, Required: 2, Signed: 2, Complete: true}}, c.GetSignersStatus())
+		w, err := c.GetWitness(tx.Signers[0].Account)
+		require.NoError(t, err)
+		v := newTestVM(w, tx)
+		require.NoError(t, v.Run())
+		require.Equal(t, true, v.Estack().Pop().Value())
+	})
+}
+
 func newTestVM(w *transaction.Witness, tx *transaction.Transaction) *vm.VM {
 	ic := &interop.Context{Network: uint32(netmode.UnitTestNet), Container: tx, Functions: crypto.Interops}
 	v := ic.SpawnVM()
@@ -238,6 +351,66 @@ func TestSharpJSON(t *testing.T) {
 	input := []byte(`{"type":"Neo.Network.P2P.Payloads.Transaction","hash":"0x71b519998f41bbc1d37e383e01e2e6efe84d65abf3c7279820cc7c63daa29448","data":"AKTv6hJY8h4AAAAAAKwiUwEAAAAA0lEAAAFBO\u002BhSRSuucNKVX2lk7k5Wdr\u002BkOQEAMR8RwB8MEHNldEV4ZWNGZWVGYWN0b3IMFHvGgcCh9x1UNFe2i7qNX5/dTl7MQWJ9W1I=","items":{"0x39a4bf76564eee64695f95d270ae2b4552e83b41":{"script":"GwwhAwCbdUDhDyVi5f2PrJ6uwlFmpYsm5BI0j/WoaSe/rCKiDCEDAgXpzvrqWh38WAryDI1aokaLsBSPGl5GBfxiLIDmBLoMIQIUuvDO6jpm8X5\u002BHoOeol/YvtbNgua7bmglAYkGX0T/AQwhAzjSoai75eQ8YzNBYTMIaaXgqqUeYTSWGEp8xylL\u002BVafDCEDPY41\u002BM2aM4UigLbZMJPHKS7VzpDZDxSfotpQumFo384MIQI\u002BmzLqiblNBm5kmxJP1Q45bukTaejipq4bEcFw0CIlbQwhA0CNzUFjlvZHg6xYfqHhWTxX2f6ogMimoZIOkqJZR3gGDCEDScfvC0qvGB8KPhNQxSexNsxbQkmMuDq4iAwF7ZUWfhwMIQJWZM7wq8uneHrV\u002BxLzrzHFzcekeQaKoq2O54gEdov/6QwhA1tPm\u002BK4U\u002BButaCcFn4Di5a0gEI1lhUQQjJS8u49u6WDDCEDZQpoRGGmS/Rr7lYdmYGkxXrcbMvTqVErg3AUgLMCGKsMIQJqEKorTXY5xd6vpP8IFGfbELXQBDJ0mipe4dK/7SPhwAwhAn5FmyZLb34yWrSwuw\u002BmQQgftoUX/WE\u002BvXqUy3nTCB5PDCECiMrUQqh3lgx2tPaI9L4w92glbZo9okkrAYC5EkORi08MIQKkDFUnmPeWNglYF\u002ByIkk/Gy3CU5aPLBZqbO8keo78NPQwhAqeDS\u002BmzLimB0VfLW706y0LP0R6lw7ECJNekTpjFkQ8bDCECuixw9ZlvNXpDGYcFhZ\u002BuLP6hPhFyligAdys9WIqdSr0MIQLVeGqSFKij8XV9dZb9EPUkEgXiwNaDYvR2ZXm6xhiSSQwhA9jVjSJXymyxRSK3ZRPUeD99SBgBaViTeUwhhlFcbedvDCEC23nmnFGK6SVOMUtvX0tj6RTN1LJXTcL5I2wBwfwdiXMMIQLsFD8AuIUkyvNqASHC3gnu8FGd2\u002BHHEKAPDiZjIB7kwAAVQZ7Q3Do=","parameters":[{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"},{"type":"Signature"}],"signatures":{"03650a684461a64bf46bee561d9981a4c57adc6ccbd3a9512b83701480b30218ab":"QtjYFNpGOOnij\u002BLwNZLOO3fHNoVQas\u002B4\u002BAo6SdvEeP3C12ATXzgPjAZrd5mCDc3KYkce0wwveEuuoYA8mhraUA==","0288cad442a877960c76b4f688f4be30f768256d9a3da2492b0180b91243918b4f":"RmuTXfPokXWEL9RIM9DqUUsOH8iRMfrKTp6LdhdJ0KBW6rNSEuxxNOpSUMBEW1EE2CNh1c\u002BmElj2Ny3o89SzGQ==","035b4f9be2b853e06eb5a09c167e038b96b4804235961510423252f2ee3dbba583":"1VYiT\u002BPe/7syYDSOWaJ1jPyZ6JDPrdU9toDu0Cg9pRQAJW1KLSexiosLA73k7lQeVbq4YuNlWnY7U8CYIQ/ilA==","02a40c552798f79636095817ec88924fc6cb7094e5a3cb059a9b3bc91ea3bf0d3d":"/mXUPXp/tI6Y7LhudKzBE8K2soHcPgrr48YLrwgbTI4qypYpOzh\u002BNj03pkAvk8\u002B68kuefevNQb/pjmPRvs80DA=="}}},"network":877933390}`)
 	pc := ParameterContext{}
 	require.NoError(t, json.Unmarshal(input, &pc))
+
+	status := pc.GetSignersStatus()
+	require.Equal(t, 1, len(status))
+	require.Equal(t, "39a4bf76564eee64695f95d270ae2b4552e83b41", status[0].Account.StringLE())
+	require.Equal(t, 11, status[0].Required)
+	require.Equal(t, 4, status[0].Signed)
+	require.False(t, status[0].Complete)
+	require.False(t, pc.IsComplete())
+}
+
+func TestSharpJSONMerge(t *testing.T) {
+	// Contexts for a transaction with 2-out-of-3 multisignature and simple
+	// signature signers in the format produced by C# node. The first one is
+	// signed by the simple signature account and by one of the multisignature
+	// keys, the second one only contains the other multisignature key.
+	inputA := []byte(`{"type":"Neo.Network.P2P.Payloads.Transaction","hash":"0x4849853a7a348aba86710b087bb764efb3e7df2733ae89da6211fafcf870d638","data":"AE9FTgBAQg8AAAAAAKAlJgAAAAAA0gQAAAJo4Cndaz29QPwFoV44gD0POiUjJwE\u002BD2HDLjn/ZvE0VSoFZZ3TBLWgzgEAARE=","items":{"0x2723253a0f3d80385ea105fc40bd3d6bdd29e068":{"script":"EgwhAzP2bmeC9uour1xl2bdTSwGHyp4TVrjLRSO3ApQ4ctB4DCECNE4/aHWbFbPkM\u002BTYHDVKWVwaZhp1RpAgbe0oiIvnclIMIQPzKS2Rxg27iakep61kSuLVthICxVHCdhtbvx90BfRA8BNBntDcOg==","parameters":[{"type":"Signature"},{"type":"Signature"}],"signatures":{"0333f66e6782f6ea2eaf5c65d9b7534b0187ca9e1356b8cb4523b702943872d078":"JYE49b\u002BGJjAXrlKSQFI57o5z/TtwVw8WTvGyfsiRBfIvUn/kNPGsxRGY56TRLkG2NEpC/yQ49ybrQrtwFu3G0w=="}},"0xcea0b504d39d65052a5534f166ff392ec3610f3e":{"script":"DCECzHVrxH24g7xZBZ\u002BVBrDWUEEJOWOw2DqDVfn6HJSo4cZBVuezJw==","parameters":[{"type":"Signature","value":"S5QHO2/k7884NIyfb2iAcFyXLbVkVK3oYEeFyNqQlsVNKageVOJRBHPWfceWSZRkP1aVWEZfNB0UR8akkB0zJQ=="}],"signatures":{"02cc756bc47db883bc59059f9506b0d65041093963b0d83a8355f9fa1c94a8e1c6":"S5QHO2/k7884NIyfb2iAcFyXLbVkVK3oYEeFyNqQlsVNKageVOJRBHPWfceWSZRkP1aVWEZfNB0UR8akkB0zJQ=="}}},"network":42}`)
+	inputB := []byte(`{"type":"Neo.Network.P2P.Payloads.Transaction","hash":"0x4849853a7a348aba86710b087bb764efb3e7df2733ae89da6211fafcf870d638","data":"AE9FTgBAQg8AAAAAAKAlJgAAAAAA0gQAAAJo4Cndaz29QPwFoV44gD0POiUjJwE\u002BD2HDLjn/ZvE0VSoFZZ3TBLWgzgEAARE=","items":{"0x2723253a0f3d80385ea105fc40bd3d6bdd29e068":{"script":"EgwhAzP2bmeC9uour1xl2bdTSwGHyp4TVrjLRSO3ApQ4ctB4DCECNE4/aHWbFbPkM\u002BTYHDVKWVwaZhp1RpAgbe0oiIvnclIMIQPzKS2Rxg27iakep61kSuLVthICxVHCdhtbvx90BfRA8BNBntDcOg==","parameters":[{"type":"Signature"},{"type":"Signature"}],"signatures":{"03f3292d91c60dbb89a91ea7ad644ae2d5b61202c551c2761b5bbf1f7405f440f0":"dMbKYCuz2m/Fsn6cCIKdawx0airVX/h7aLJz8qGPImKx2yFihgIbVFVV2PEFLxOa2WzjQ2Mrod41WutTRAZnHQ=="}}},"network":42}`)
+	var pcA, pcB ParameterContext
+	require.NoError(t, json.Unmarshal(inputA, &pcA))
+	require.NoError(t, json.Unmarshal(inputB, &pcB))
+	tx := pcA.Verifiable.(*transaction.Transaction)
+	require.Equal(t, 2, len(tx.Signers))
+
+	status := pcA.GetSignersStatus()
+	require.Equal(t, []SignerStatus{
+		{Account: tx.Signers[0].Account, Required: 2, Signed: 1},
+		{Account: tx.Signers[1].Account, Required: 1, Signed: 1, Complete: true},
+	}, status)
+	status = pcB.GetSignersStatus()
+	require.Equal(t, []SignerStatus{
+		{Account: tx.Signers[0].Account, Required: 2, Signed: 1},
+		{Account: tx.Signers[1].Account},
+	}, status)
+	_, err := pcA.GetWitnesses()
+	require.Error(t, err)
+
+	require.NoError(t, pcB.Merge(&pcA))
+	require.True(t, pcB.IsComplete())
+	require.False(t, pcA.IsComplete())
+
+	ws, err := pcB.GetWitnesses()
+	require.NoError(t, err)
+	require.Equal(t, 2, len(ws))
+	for i := range ws {
+		require.Equal(t, tx.Signers[i].Account, ws[i].ScriptHash())
+		v := newTestVM(&ws[i], tx)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		require.Equal(t, true, v.Estack().Pop().Value())
+	}
+
+	// Merged context can be exported and imported back.
+	data, err := json.Marshal(pcB)
+	require.NoError(t, err)
+	var pc ParameterContext
+	require.NoError(t, json.Unmarshal(data, &pc))
+	require.True(t, pc.IsComplete())
+	cTx, err := pc.GetCompleteTransaction()
+	require.NoError(t, err)
+	require.Equal(t, ws, cTx.Scripts)
 }
 
 func getPrivateKeys(t *testing.T, n int) ([]*keys.PrivateKey, []*keys.PublicKey) {
@@ -1,6 +1,7 @@
 package context
 
 import (
+	"bytes"
 	"encoding/hex"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -24,3 +25,18 @@ func (it *Item) AddSignature(pub *keys.PublicKey, sig []byte) {
 	pubHex := hex.EncodeToString(pub.Bytes())
 	it.Signatures[pubHex] = sig
 }
+
+// copy returns a copy of the item that can be modified without affecting the
+// original one.
+func (it *Item) copy() *Item {
+	res := &Item{
+		Script:     bytes.Clone(it.Script),
+		Parameters: make([]smartcontract.Parameter, len(it.Parameters)),
+		Signatures: make(map[string][]byte, len(it.Signatures)),
+	}
+	copy(res.Parameters, it.Parameters)
+	for k, v := range it.Signatures {
+		res.Signatures[k] = v
+	}
+	return res
+}
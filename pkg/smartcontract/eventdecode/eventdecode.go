@@ -0,0 +1,239 @@
+/*
+Package eventdecode converts contract notifications into Go values using
+event definitions from contract manifests.
+
+Notification parameters are stack items that don't carry enough type
+information on their own: a 20-byte ByteString can be a Hash160 or just an
+arbitrary ByteArray. The manifest event definition resolves this ambiguity, so
+every parameter is decoded according to its type in the manifest. Typed
+decoding functions ([Hash160], [Integer], [String], etc) are also exported to
+be used by code that knows event types in advance (like generated contract
+bindings).
+*/
+package eventdecode
+
+import (
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"math/big"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Decoder converts a stack item into some Go value.
+type Decoder func(stackitem.Item) (any, error)
+
+// MismatchError is returned when notification doesn't match the event
+// definition.
+type MismatchError struct {
+	// Event is the name of the event.
+	Event string
+	// Index is the index of the mismatched parameter, it's -1 if the
+	// notification itself is not compatible with the event (like when the
+	// number of parameters is wrong).
+	Index int
+	// Parameter is the name of the mismatched parameter.
+	Parameter string
+	// Type is the parameter type from the event definition.
+	Type smartcontract.ParamType
+	// Err is the decoding error.
+	Err error
+}
+
+// ErrWrongParamCount is returned (wrapped into MismatchError) when the number
+// of notification parameters doesn't match the event definition.
+var ErrWrongParamCount = errors.New("wrong number of event parameters")
+
+// Registry is a set of decoders used for notification parameters. Decoders
+// can be registered for parameter types (overriding default ones) or for
+// specific event parameters (which is useful for extended types that are
+// not known from the manifest). Registry is not safe for concurrent
+// modification, so it should be configured before use.
+type Registry struct {
+	types  map[smartcontract.ParamType]Decoder
+	params map[string]Decoder
+}
+
+// defaultRegistry is used by Decode.
+var defaultRegistry = NewRegistry()
+
+// Error implements the error interface.
+func (e *MismatchError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("event %s: %s", e.Event, e.Err)
+	}
+	return fmt.Sprintf("event %s: parameter #%d (%s) of type %s: %s", e.Event, e.Index, e.Parameter, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *MismatchError) Unwrap() error {
+	return e.Err
+}
+
+// NewRegistry returns a Registry with decoders for all standard parameter
+// types.
+func NewRegistry() *Registry {
+	return &Registry{
+		types: map[smartcontract.ParamType]Decoder{
+			smartcontract.AnyType:              decoderFor(Any),
+			smartcontract.BoolType:             decoderFor(Bool),
+			smartcontract.IntegerType:          decoderFor(Integer),
+			smartcontract.ByteArrayType:        decoderFor(Bytes),
+			smartcontract.SignatureType:        decoderFor(Bytes),
+			smartcontract.StringType:           decoderFor(String),
+			smartcontract.Hash160Type:          decoderFor(Hash160),
+			smartcontract.Hash256Type:          decoderFor(Hash256),
+			smartcontract.PublicKeyType:        decoderFor(PublicKey),
+			smartcontract.ArrayType:            decoderFor(Array),
+			smartcontract.MapType:              decoderFor(Map),
+			smartcontract.InteropInterfaceType: decoderFor(Any),
+		},
+		params: make(map[string]Decoder),
+	}
+}
+
+func decoderFor[T any](f func(stackitem.Item) (T, error)) Decoder {
+	return func(item stackitem.Item) (any, error) {
+		return f(item)
+	}
+}
+
+// RegisterType registers decoder for the given parameter type replacing the
+// previous one.
+func (r *Registry) RegisterType(typ smartcontract.ParamType, d Decoder) {
+	r.types[typ] = d
+}
+
+// RegisterParameter registers decoder for the parameter with the given name
+// of the given event, it takes precedence over type decoders.
+func (r *Registry) RegisterParameter(event string, param string, d Decoder) {
+	r.params[event+"."+param] = d
+}
+
+// Decode converts notification parameters into Go values according to the
+// event definition. It returns MismatchError if it's not possible.
+func (r *Registry) Decode(ev *manifest.Event, item *stackitem.Array) ([]any, error) {
+	if item == nil {
+		return nil, &MismatchError{Event: ev.Name, Index: -1, Err: errors.New("nil item")}
+	}
+	arr := item.Value().([]stackitem.Item)
+	if len(arr) != len(ev.Parameters) {
+		return nil, &MismatchError{Event: ev.Name, Index: -1,
+			Err: fmt.Errorf("%w: %d instead of %d", ErrWrongParamCount, len(arr), len(ev.Parameters))}
+	}
+	var res = make([]any, len(arr))
+	for i, p := range ev.Parameters {
+		d, ok := r.params[ev.Name+"."+p.Name]
+		if !ok {
+			d, ok = r.types[p.Type]
+		}
+		if !ok {
+			return nil, &MismatchError{Event: ev.Name, Index: i, Parameter: p.Name, Type: p.Type,
+				Err: errors.New("no decoder")}
+		}
+		v, err := d(arr[i])
+		if err != nil {
+			return nil, &MismatchError{Event: ev.Name, Index: i, Parameter: p.Name, Type: p.Type, Err: err}
+		}
+		res[i] = v
+	}
+	return res, nil
+}
+
+// Decode converts notification parameters into Go values according to the
+// event definition using the default set of decoders (see NewRegistry).
+func Decode(ev *manifest.Event, item *stackitem.Array) ([]any, error) {
+	return defaultRegistry.Decode(ev, item)
+}
+
+// Any returns the value of the given item (see [stackitem.Item.Value]), it
+// never fails.
+func Any(item stackitem.Item) (any, error) {
+	return item.Value(), nil
+}
+
+// Bool converts the item to bool.
+func Bool(item stackitem.Item) (bool, error) {
+	return item.TryBool()
+}
+
+// Integer converts the item to integer.
+func Integer(item stackitem.Item) (*big.Int, error) {
+	return item.TryInteger()
+}
+
+// Bytes converts the item to byte slice.
+func Bytes(item stackitem.Item) ([]byte, error) {
+	return item.TryBytes()
+}
+
+// String converts the item to UTF-8 string.
+func String(item stackitem.Item) (string, error) {
+	b, err := item.TryBytes()
+	if err != nil {
+		return "", err
+	}
+	if !utf8.Valid(b) {
+		return "", errors.New("not a UTF-8 string")
+	}
+	return string(b), nil
+}
+
+// Hash160 converts the item to Uint160. Null is decoded as zero hash, it's
+// used for minting and burning in NEP-17 and NEP-11 Transfer events.
+func Hash160(item stackitem.Item) (util.Uint160, error) {
+	if _, ok := item.(stackitem.Null); ok {
+		return util.Uint160{}, nil
+	}
+	b, err := item.TryBytes()
+	if err != nil {
+		return util.Uint160{}, err
+	}
+	return util.Uint160DecodeBytesBE(b)
+}
+
+// Hash256 converts the item to Uint256.
+func Hash256(item stackitem.Item) (util.Uint256, error) {
+	b, err := item.TryBytes()
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	return util.Uint256DecodeBytesBE(b)
+}
+
+// PublicKey converts the item to public key.
+func PublicKey(item stackitem.Item) (*keys.PublicKey, error) {
+	b, err := item.TryBytes()
+	if err != nil {
+		return nil, err
+	}
+	return keys.NewPublicKeyFromBytes(b, elliptic.P256())
+}
+
+// Array converts the item to a slice of values of its elements.
+func Array(item stackitem.Item) ([]any, error) {
+	arr, ok := item.Value().([]stackitem.Item)
+	if !ok {
+		return nil, errors.New("not an array")
+	}
+	res := make([]any, len(arr))
+	for i := range arr {
+		res[i] = arr[i].Value()
+	}
+	return res, nil
+}
+
+// Map converts the item to a slice of map elements.
+func Map(item stackitem.Item) ([]stackitem.MapElement, error) {
+	m, ok := item.Value().([]stackitem.MapElement)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a map", item.Type())
+	}
+	return m, nil
+}
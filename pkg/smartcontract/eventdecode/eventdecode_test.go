@@ -0,0 +1,229 @@
+package eventdecode
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest/standard"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeStandardEvents(t *testing.T) {
+	var (
+		from = util.Uint160{1, 2, 3}
+		to   = util.Uint160{3, 2, 1}
+		id   = []byte{0xde, 0xad}
+	)
+	nep17 := &standard.Nep17.ABI.Events[0]
+	nep11 := &standard.Nep11Base.ABI.Events[0]
+	testCases := []struct {
+		name     string
+		event    *manifest.Event
+		item     *stackitem.Array
+		expected []any
+		index    int // Mismatched parameter index, -2 if no error expected.
+	}{
+		{
+			name:     "NEP-17 transfer",
+			event:    nep17,
+			item:     stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to), stackitem.Make(42)}),
+			expected: []any{from, to, big.NewInt(42)},
+			index:    -2,
+		},
+		{
+			name:     "NEP-17 mint",
+			event:    nep17,
+			item:     stackitem.NewArray([]stackitem.Item{stackitem.Null{}, stackitem.Make(to), stackitem.Make(42)}),
+			expected: []any{util.Uint160{}, to, big.NewInt(42)},
+			index:    -2,
+		},
+		{
+			name:     "NEP-17 burn",
+			event:    nep17,
+			item:     stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Null{}, stackitem.Make(42)}),
+			expected: []any{from, util.Uint160{}, big.NewInt(42)},
+			index:    -2,
+		},
+		{
+			name:  "NEP-17 missing parameter",
+			event: nep17,
+			item:  stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to)}),
+			index: -1,
+		},
+		{
+			name:  "NEP-17 bad sender",
+			event: nep17,
+			item:  stackitem.NewArray([]stackitem.Item{stackitem.Make([]byte{1, 2, 3}), stackitem.Make(to), stackitem.Make(42)}),
+			index: 0,
+		},
+		{
+			name:  "NEP-17 bad receiver",
+			event: nep17,
+			item:  stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.NewMap(), stackitem.Make(42)}),
+			index: 1,
+		},
+		{
+			name:  "NEP-17 bad amount",
+			event: nep17,
+			item:  stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to), stackitem.NewArray(nil)}),
+			index: 2,
+		},
+		{
+			name:     "NEP-11 transfer",
+			event:    nep11,
+			item:     stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to), stackitem.Make(1), stackitem.Make(id)}),
+			expected: []any{from, to, big.NewInt(1), id},
+			index:    -2,
+		},
+		{
+			name:  "NEP-11 20-byte token ID",
+			event: nep11,
+			item: stackitem.NewArray([]stackitem.Item{stackitem.Null{}, stackitem.Make(to), stackitem.Make(1),
+				stackitem.Make(from.BytesBE())}),
+			expected: []any{util.Uint160{}, to, big.NewInt(1), from.BytesBE()},
+			index:    -2,
+		},
+		{
+			name:  "NEP-11 extra parameter",
+			event: nep11,
+			item: stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to), stackitem.Make(1),
+				stackitem.Make(id), stackitem.Make(id)}),
+			index: -1,
+		},
+		{
+			name:  "NEP-11 bad token ID",
+			event: nep11,
+			item: stackitem.NewArray([]stackitem.Item{stackitem.Make(from), stackitem.Make(to), stackitem.Make(1),
+				stackitem.NewStruct(nil)}),
+			index: 3,
+		},
+		{
+			name:  "nil item",
+			event: nep11,
+			index: -1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Decode(tc.event, tc.item)
+			if tc.index == -2 {
+				require.NoError(t, err)
+				require.Equal(t, tc.expected, res)
+				return
+			}
+			var mErr *MismatchError
+			require.True(t, errors.As(err, &mErr))
+			require.Equal(t, tc.event.Name, mErr.Event)
+			require.Equal(t, tc.index, mErr.Index)
+			if tc.index >= 0 {
+				require.Equal(t, tc.event.Parameters[tc.index].Name, mErr.Parameter)
+				require.Equal(t, tc.event.Parameters[tc.index].Type, mErr.Type)
+			}
+		})
+	}
+}
+
+func TestDecodeTypes(t *testing.T) {
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	pub := pk.PublicKey()
+	ev := &manifest.Event{
+		Name: "Everything",
+		Parameters: []manifest.Parameter{
+			{Name: "any", Type: smartcontract.AnyType},
+			{Name: "bool", Type: smartcontract.BoolType},
+			{Name: "str", Type: smartcontract.StringType},
+			{Name: "h256", Type: smartcontract.Hash256Type},
+			{Name: "pub", Type: smartcontract.PublicKeyType},
+			{Name: "arr", Type: smartcontract.ArrayType},
+			{Name: "map", Type: smartcontract.MapType},
+		},
+	}
+	m := stackitem.NewMap()
+	m.Add(stackitem.Make(1), stackitem.Make(2))
+	item := stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(7),
+		stackitem.Make(true),
+		stackitem.Make("str"),
+		stackitem.Make(util.Uint256{1}),
+		stackitem.Make(pub.Bytes()),
+		stackitem.Make([]stackitem.Item{stackitem.Make(1), stackitem.Make("a")}),
+		m,
+	})
+	res, err := Decode(ev, item)
+	require.NoError(t, err)
+	require.Equal(t, []any{big.NewInt(7), true, "str", util.Uint256{1}, pub,
+		[]any{big.NewInt(1), []byte("a")}, m.Value()}, res)
+
+	for i, bad := range []stackitem.Item{
+		nil, // Any always succeeds.
+		stackitem.Make(make([]byte, 33)),
+		stackitem.Make([]byte{0xff}),
+		stackitem.Make([]byte{1, 2, 3}),
+		stackitem.Make([]byte{1, 2, 3}),
+		stackitem.Make(1),
+		stackitem.Make(1),
+	} {
+		if bad == nil {
+			continue
+		}
+		arr := item.Value().([]stackitem.Item)
+		broken := make([]stackitem.Item, len(arr))
+		copy(broken, arr)
+		broken[i] = bad
+		_, err := Decode(ev, stackitem.NewArray(broken))
+		var mErr *MismatchError
+		require.True(t, errors.As(err, &mErr), i)
+		require.Equal(t, i, mErr.Index)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	type myHash util.Uint160
+	var (
+		r  = NewRegistry()
+		ev = &manifest.Event{
+			Name: "Ev",
+			Parameters: []manifest.Parameter{
+				{Name: "hash", Type: smartcontract.ByteArrayType},
+				{Name: "data", Type: smartcontract.ByteArrayType},
+			},
+		}
+		h    = util.Uint160{1, 2, 3}
+		item = stackitem.NewArray([]stackitem.Item{stackitem.Make(h), stackitem.Make(h)})
+	)
+
+	// ByteArray in the manifest, so no conversion by default.
+	res, err := r.Decode(ev, item)
+	require.NoError(t, err)
+	require.Equal(t, []any{h.BytesBE(), h.BytesBE()}, res)
+
+	r.RegisterParameter("Ev", "hash", func(item stackitem.Item) (any, error) {
+		u, err := Hash160(item)
+		return myHash(u), err
+	})
+	res, err = r.Decode(ev, item)
+	require.NoError(t, err)
+	require.Equal(t, []any{myHash(h), h.BytesBE()}, res)
+
+	r.RegisterType(smartcontract.ByteArrayType, func(item stackitem.Item) (any, error) {
+		return nil, errors.New("custom")
+	})
+	_, err = r.Decode(ev, item)
+	require.ErrorContains(t, err, "parameter #1 (data) of type ByteArray: custom")
+
+	// Default registry is not affected.
+	res, err = Decode(ev, item)
+	require.NoError(t, err)
+	require.Equal(t, []any{h.BytesBE(), h.BytesBE()}, res)
+
+	_, err = r.Decode(&manifest.Event{Name: "Ev", Parameters: []manifest.Parameter{{Name: "v", Type: smartcontract.VoidType}}},
+		stackitem.NewArray([]stackitem.Item{stackitem.Null{}}))
+	require.ErrorContains(t, err, "no decoder")
+}
@@ -20,6 +20,8 @@ const (
 	NEP11StandardName = "NEP-11"
 	// NEP17StandardName represents the name of NEP-17 smartcontract standard.
 	NEP17StandardName = "NEP-17"
+	// NEP24StandardName represents the name of NEP-24 (NFT royalty) smartcontract standard.
+	NEP24StandardName = "NEP-24"
 	// NEP11Payable represents the name of contract interface which can receive NEP-11 tokens.
 	NEP11Payable = "NEP-11-Payable"
 	// NEP17Payable represents the name of contract interface which can receive NEP-17 tokens.
@@ -139,6 +139,10 @@ func TestCheck(t *testing.T) {
 	m.ABI.Events = append(m.ABI.Events, Nep17.ABI.Events...)
 	require.NoError(t, Check(m, manifest.NEP17StandardName))
 	require.NoError(t, CheckABI(m, manifest.NEP17StandardName))
+
+	require.Error(t, Check(m, manifest.NEP24StandardName))
+	m.ABI.Methods = append(m.ABI.Methods, Nep24.ABI.Methods...)
+	require.NoError(t, Check(m, manifest.NEP24StandardName))
 }
 
 func TestOptional(t *testing.T) {
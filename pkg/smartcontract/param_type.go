@@ -387,6 +387,18 @@ func inferParamType(val string) ParamType {
 	return StringType
 }
 
+// isSimpleType checks whether the given type can be represented by a single
+// string value.
+func isSimpleType(typ ParamType) bool {
+	switch typ {
+	case SignatureType, BoolType, IntegerType, Hash160Type, Hash256Type,
+		ByteArrayType, PublicKeyType, StringType:
+		return true
+	default:
+		return false
+	}
+}
+
 // ConvertToParamType converts the provided value to the parameter type if it's a valid type.
 func ConvertToParamType(val int) (ParamType, error) {
 	if validParamTypes[ParamType(val)] {
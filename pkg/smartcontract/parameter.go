@@ -201,6 +201,17 @@ func (p *Parameter) UnmarshalJSON(data []byte) (err error) {
 // interfaces and has some heuristics in it to simplify parameter passing. The exact
 // syntax is documented in the cli documentation.
 func NewParameterFromString(in string) (*Parameter, error) {
+	return NewParameterFromStringWithType(in, AnyType)
+}
+
+// NewParameterFromStringWithType is similar to NewParameterFromString, but
+// uses the given type for values without explicit type specification instead
+// of inferring it from the value. It's useful when the expected parameter type
+// is known (from the contract manifest, for example). The type is only used
+// if it's one of the simple types (not Any, Array, Map, InteropInterface or
+// Void), "nil" value is always treated as a null parameter unless the type is
+// specified explicitly.
+func NewParameterFromStringWithType(in string, typ ParamType) (*Parameter, error) {
 	var (
 		char    rune
 		val     string
@@ -245,6 +256,9 @@ func NewParameterFromString(in string) (*Parameter, error) {
 	val = buf.String()
 	if !hadType {
 		res.Type = inferParamType(val)
+		if res.Type != AnyType && isSimpleType(typ) {
+			res.Type = typ
+		}
 	}
 	if res.Type == ByteArrayType && typStr == fileBytesParamType {
 		res.Value, err = os.ReadFile(val)
@@ -395,8 +409,10 @@ func NewParametersFromValues(values ...any) ([]Parameter, error) {
 }
 
 // ExpandParameterToEmitable converts a parameter to a type which can be handled as
-// an array item by emit.Array. It correlates with the way an RPC server handles
-// FuncParams for invoke* calls inside the request.ExpandArrayIntoScript function.
+// an array item by emit.Array (and emit.StackitemValue). Map parameters are
+// converted to *stackitem.Map keeping the order of pairs. It correlates with the
+// way an RPC server handles FuncParams for invoke* calls inside the
+// request.ExpandArrayIntoScript function.
 func ExpandParameterToEmitable(param Parameter) (any, error) {
 	var err error
 	switch t := param.Type; t {
@@ -410,7 +426,28 @@ func ExpandParameterToEmitable(param Parameter) (any, error) {
 			}
 		}
 		return res, nil
-	case MapType, InteropInterfaceType, UnknownType, VoidType:
+	case MapType:
+		pairs, ok := param.Value.([]ParameterPair)
+		if !ok {
+			return nil, fmt.Errorf("invalid map value: %T", param.Value)
+		}
+		res := stackitem.NewMap()
+		for i := range pairs {
+			k, err := pairs[i].Key.ToStackItem()
+			if err != nil {
+				return nil, fmt.Errorf("map key %d: %w", i, err)
+			}
+			if err = stackitem.IsValidMapKey(k); err != nil {
+				return nil, fmt.Errorf("map key %d: %w", i, err)
+			}
+			v, err := pairs[i].Value.ToStackItem()
+			if err != nil {
+				return nil, fmt.Errorf("map value %d: %w", i, err)
+			}
+			res.Add(k, v)
+		}
+		return res, nil
+	case InteropInterfaceType, UnknownType, VoidType:
 		return nil, fmt.Errorf("unsupported parameter type: %s", t.String())
 	default:
 		return param.Value, nil
@@ -13,6 +13,7 @@ import (
 	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/io"
 	"github.com/nspcc-dev/neo-go/pkg/util"
 	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
@@ -444,6 +445,67 @@ func TestNewParameterFromString(t *testing.T) {
 	}
 }
 
+func TestNewParameterFromStringWithType(t *testing.T) {
+	addr := "NSiVJYZej4XsxG5CUpdwn7VRQk8iiiDMPM"
+	u, err := address.StringToUint160(addr)
+	require.NoError(t, err)
+	var inouts = []struct {
+		in  string
+		typ ParamType
+		out Parameter
+		err bool
+	}{{
+		in:  "42",
+		typ: StringType,
+		out: Parameter{StringType, "42"},
+	}, {
+		in:  "42",
+		typ: ByteArrayType,
+		out: Parameter{ByteArrayType, []byte{0x42}},
+	}, {
+		in:  "42",
+		typ: AnyType,
+		out: Parameter{IntegerType, big.NewInt(42)},
+	}, {
+		in:  "42",
+		typ: ArrayType,
+		out: Parameter{IntegerType, big.NewInt(42)},
+	}, {
+		in:  addr,
+		typ: StringType,
+		out: Parameter{StringType, addr},
+	}, {
+		in:  addr,
+		typ: Hash160Type,
+		out: Parameter{Hash160Type, u},
+	}, {
+		in:  "int:42",
+		typ: StringType,
+		out: Parameter{IntegerType, big.NewInt(42)},
+	}, {
+		in:  "nil",
+		typ: StringType,
+		out: Parameter{AnyType, nil},
+	}, {
+		in:  "string:nil",
+		typ: StringType,
+		out: Parameter{StringType, "nil"},
+	}, {
+		in:  "true",
+		typ: IntegerType,
+		err: true,
+	}}
+	for _, inout := range inouts {
+		out, err := NewParameterFromStringWithType(inout.in, inout.typ)
+		if inout.err {
+			require.Error(t, err, "should error on '%s' input", inout.in)
+		} else {
+			require.NoError(t, err, "shouldn't error on '%s' input", inout.in)
+			require.Equal(t, inout.out, *out, "bad output for '%s' input", inout.in)
+		}
+	}
+}
+
 func hexToBase64(s string) string {
 	b, _ := hex.DecodeString(s)
 	return base64.StdEncoding.EncodeToString(b)
@@ -530,6 +592,26 @@ func TestExpandParameterToEmitableToStackitem(t *testing.T) {
 				}),
 			}),
 		},
+		{
+			In: Parameter{Type: MapType, Value: []ParameterPair{
+				{
+					Key:   Parameter{Type: StringType, Value: "key"},
+					Value: Parameter{Type: ArrayType, Value: []Parameter{{Type: IntegerType, Value: big.NewInt(1)}}},
+				},
+				{
+					Key:   Parameter{Type: IntegerType, Value: big.NewInt(2)},
+					Value: Parameter{Type: BoolType, Value: false},
+				},
+			}},
+			Expected: stackitem.NewMapWithValue([]stackitem.MapElement{
+				{Key: stackitem.Make("key"), Value: stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})},
+				{Key: stackitem.Make(2), Value: stackitem.NewBool(false)},
+			}),
+			ExpectedStackitem: stackitem.NewMapWithValue([]stackitem.MapElement{
+				{Key: stackitem.Make("key"), Value: stackitem.NewArray([]stackitem.Item{stackitem.Make(1)})},
+				{Key: stackitem.Make(2), Value: stackitem.NewBool(false)},
+			}),
+		},
 	}
 	bw := io.NewBufBinWriter()
 	for _, testCase := range testCases {
@@ -547,6 +629,10 @@ func TestExpandParameterToEmitableToStackitem(t *testing.T) {
 	errCases := []Parameter{
 		{Type: UnknownType},
 		{Type: MapType},
+		{Type: MapType, Value: []ParameterPair{{
+			Key:   Parameter{Type: ArrayType, Value: []Parameter{}},
+			Value: Parameter{Type: BoolType, Value: true},
+		}}},
 		{Type: InteropInterfaceType},
 	}
 	for _, errCase := range errCases {
@@ -319,7 +319,7 @@ func (e *{{$e.Name}}) FromStackItem(item *stackitem.Array) error {
 	)
 	{{- range $p := $e.Parameters}}
 	index++
-	e.{{ upperFirst .Name}}, err = {{etTypeConverter .ExtType "arr[index]"}}
+	e.{{ upperFirst .Name}}, err = {{eventTypeConverter .ExtType "arr[index]"}}
 	if err != nil {
 		return fmt.Errorf("field {{ upperFirst .Name}}: %w", err)
 	}
@@ -386,8 +386,13 @@ func NewConfig() binding.Config {
 
 // Generate writes Go file containing smartcontract bindings to the `cfg.Output`.
 // It doesn't check manifest from Config for validity, incorrect manifest can
-// lead to unexpected results.
+// lead to unexpected results. Extended types stored in the manifest extra data
+// (see binding.ManifestTypes) are used if they're not overridden by the
+// configuration.
 func Generate(cfg binding.Config) error {
+	if err := cfg.ApplyManifestTypes(); err != nil {
+		return err
+	}
 	// Avoid changing *cfg.Manifest.
 	mfst := *cfg.Manifest
 	mfst.ABI.Methods = make([]manifest.Method, len(mfst.ABI.Methods))
@@ -461,8 +466,9 @@ func Generate(cfg binding.Config) error {
 	}
 
 	var srcTemplate = template.Must(template.New("generate").Funcs(template.FuncMap{
-		"addIndent":       addIndent,
-		"etTypeConverter": etTypeConverter,
+		"addIndent":          addIndent,
+		"etTypeConverter":    etTypeConverter,
+		"eventTypeConverter": eventTypeConverter,
 		"etTypeToStr": func(et binding.ExtendedType) string {
 			r, _ := extendedTypeToGo(et, cfg.NamedTypes)
 			return r
@@ -689,6 +695,47 @@ func etTypeConverter(et binding.ExtendedType, v string) string {
 	panic("unreachable")
 }
 
+// eventTypeConverter is similar to etTypeConverter, but it uses eventdecode
+// package for simple types to decode them the same way other event consumers
+// do.
+func eventTypeConverter(et binding.ExtendedType, v string) string {
+	if !isEventDecodeType(et) {
+		return etTypeConverter(et, v)
+	}
+	switch et.Base {
+	case smartcontract.BoolType:
+		return "eventdecode.Bool(" + v + ")"
+	case smartcontract.IntegerType:
+		return "eventdecode.Integer(" + v + ")"
+	case smartcontract.ByteArrayType, smartcontract.SignatureType:
+		return "eventdecode.Bytes(" + v + ")"
+	case smartcontract.StringType:
+		return "eventdecode.String(" + v + ")"
+	case smartcontract.Hash160Type:
+		return "eventdecode.Hash160(" + v + ")"
+	case smartcontract.Hash256Type:
+		return "eventdecode.Hash256(" + v + ")"
+	case smartcontract.PublicKeyType:
+		return "eventdecode.PublicKey(" + v + ")"
+	}
+	panic("unreachable")
+}
+
+// isEventDecodeType returns true if the type can be decoded with one of
+// eventdecode functions.
+func isEventDecodeType(et binding.ExtendedType) bool {
+	if len(et.Name) != 0 || len(et.Interface) != 0 {
+		return false
+	}
+	switch et.Base {
+	case smartcontract.BoolType, smartcontract.IntegerType, smartcontract.ByteArrayType,
+		smartcontract.SignatureType, smartcontract.StringType, smartcontract.Hash160Type,
+		smartcontract.Hash256Type, smartcontract.PublicKeyType:
+		return true
+	}
+	return false
+}
+
 func scTypeToGo(name string, typ smartcontract.ParamType, cfg *binding.Config) (string, string) {
 	et, ok := cfg.Types[name]
 	if !ok {
@@ -752,6 +799,10 @@ func scTemplateToRPC(cfg binding.Config, ctr ContractTmpl, imports map[string]st
 				extType = binding.ExtendedType{
 					Base: abiEvent.Parameters[i].Type,
 				}
+			}
+			if isEventDecodeType(extType) {
+				imports["github.com/nspcc-dev/neo-go/pkg/smartcontract/eventdecode"] = struct{}{}
+			} else {
 				addETImports(extType, cfg.NamedTypes, imports)
 			}
 			eTmp.Parameters = append(eTmp.Parameters, EventParamTmpl{
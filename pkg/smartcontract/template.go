@@ -0,0 +1,379 @@
+package smartcontract
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// Template is a script with named placeholders (see [emit.Placeholder]) that
+// can be filled with actual values later to produce the final script. It allows
+// to build a script in one place (like some server) and then fill in the
+// missing parameters (like recipient and amount) in another (like a client
+// signing the transaction). Template script itself is a valid serialized form
+// of Template, so it can be transferred and restored with NewTemplate.
+//
+// The same placeholder name can be used several times in the script, every
+// occurrence is replaced with the same value then. Relative offsets of jump,
+// call and try instructions are adjusted when filling the template, so
+// templates can contain arbitrary code.
+type Template struct {
+	script       []byte
+	placeholders []TemplatePlaceholder
+	jumps        []templateJump
+}
+
+// TemplatePlaceholder describes a single placeholder of the Template.
+type TemplatePlaceholder struct {
+	// Name is the placeholder name used to fill it.
+	Name string
+	// Type is the type of value expected for this placeholder.
+	Type ParamType
+	// Offset is the placeholder instruction offset in the template script.
+	Offset int
+
+	size int
+}
+
+// templateJump is a relative offset parameter of some instruction of the
+// template script.
+type templateJump struct {
+	// ip is the offset of the instruction.
+	ip int
+	// param is the offset of the parameter.
+	param int
+	// long denotes 4-byte parameter (1-byte otherwise).
+	long bool
+	// optional denotes TRY offsets where zero means no catch/finally block.
+	optional bool
+}
+
+// NewTemplate parses the given template script (that can be created with
+// [emit.Placeholder] or [emit.PlaceholderValue]) and returns a new Template.
+func NewTemplate(script []byte) (*Template, error) {
+	var (
+		t     = &Template{script: script}
+		types = make(map[string]ParamType)
+	)
+	for ip := 0; ip < len(script); {
+		op, param, next, err := templateInstruction(script, ip)
+		if err != nil {
+			return nil, fmt.Errorf("invalid instruction at %d: %w", ip, err)
+		}
+		switch op {
+		case opcode.PUSHDATA1:
+			name, typStr, ok := emit.ParsePlaceholder(script[param:next])
+			if !ok {
+				break
+			}
+			typ, err := ParseParamType(typStr)
+			if err != nil {
+				return nil, fmt.Errorf("placeholder %q: %w", name, err)
+			}
+			switch typ {
+			case UnknownType, VoidType, InteropInterfaceType:
+				return nil, fmt.Errorf("placeholder %q: unsupported type %s", name, typ)
+			}
+			if prev, ok := types[name]; ok && prev != typ {
+				return nil, fmt.Errorf("placeholder %q: type mismatch (%s vs %s)", name, prev, typ)
+			}
+			types[name] = typ
+			t.placeholders = append(t.placeholders, TemplatePlaceholder{
+				Name:   name,
+				Type:   typ,
+				Offset: ip,
+				size:   next - ip,
+			})
+		case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+			opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+			opcode.CALL, opcode.ENDTRY:
+			t.jumps = append(t.jumps, templateJump{ip: ip, param: param})
+		case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+			opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+			opcode.CALLL, opcode.ENDTRYL, opcode.PUSHA:
+			t.jumps = append(t.jumps, templateJump{ip: ip, param: param, long: true})
+		case opcode.TRY:
+			t.jumps = append(t.jumps, templateJump{ip: ip, param: param, optional: true},
+				templateJump{ip: ip, param: param + 1, optional: true})
+		case opcode.TRYL:
+			t.jumps = append(t.jumps, templateJump{ip: ip, param: param, long: true, optional: true},
+				templateJump{ip: ip, param: param + 4, long: true, optional: true})
+		}
+		ip = next
+	}
+	for _, j := range t.jumps {
+		target := j.ip + j.offset(script)
+		if target < 0 || target > len(script) {
+			return nil, fmt.Errorf("jump offset at %d is out of script bounds", j.ip)
+		}
+	}
+	return t, nil
+}
+
+// Bytes returns the template script that can be used to restore the Template
+// with NewTemplate.
+func (t *Template) Bytes() []byte {
+	return t.script
+}
+
+// Placeholders returns the list of template placeholders in the order of their
+// appearance in the script.
+func (t *Template) Placeholders() []TemplatePlaceholder {
+	return t.placeholders
+}
+
+// MarshalJSON implements the json.Marshaler interface, template is encoded as
+// a base64 string of its script.
+func (t *Template) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.script)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Template) UnmarshalJSON(data []byte) error {
+	var script []byte
+	if err := json.Unmarshal(data, &script); err != nil {
+		return err
+	}
+	res, err := NewTemplate(script)
+	if err != nil {
+		return err
+	}
+	*t = *res
+	return nil
+}
+
+// Fill returns the final script with all placeholders replaced by the given
+// values. Every placeholder must have a value and no other values are allowed.
+// Values are checked against the placeholder type, the following Go types are
+// accepted:
+//   - AnyType: anything accepted by [emit.StackitemValue] (including nil)
+//   - BoolType: bool
+//   - IntegerType: any integer type or *big.Int
+//   - ByteArrayType: []byte
+//   - StringType: string
+//   - Hash160Type: util.Uint160 or non-nil *util.Uint160
+//   - Hash256Type: util.Uint256 or non-nil *util.Uint256
+//   - PublicKeyType: *keys.PublicKey or []byte of PublicKeyLen
+//   - SignatureType: []byte of SignatureLen
+//   - ArrayType: []any with elements accepted by [emit.StackitemValue]
+//   - MapType: *stackitem.Map
+//
+// Parameter of the appropriate type can also be used for any placeholder.
+func (t *Template) Fill(values map[string]any) ([]byte, error) {
+	var encoded = make(map[string][]byte, len(values))
+	for _, p := range t.placeholders {
+		if _, ok := encoded[p.Name]; ok {
+			continue
+		}
+		v, ok := values[p.Name]
+		if !ok {
+			return nil, fmt.Errorf("missing value for placeholder %q", p.Name)
+		}
+		bw := io.NewBufBinWriter()
+		if err := emitTemplateValue(bw.BinWriter, p.Type, v); err != nil {
+			return nil, fmt.Errorf("placeholder %q: %w", p.Name, err)
+		}
+		encoded[p.Name] = bw.Bytes()
+	}
+	if len(values) != len(encoded) {
+		names := make([]string, 0, len(values)-len(encoded))
+		for name := range values {
+			if _, ok := encoded[name]; !ok {
+				names = append(names, name)
+			}
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown placeholders: %v", names)
+	}
+
+	var (
+		res  = make([]byte, 0, len(t.script))
+		last int
+	)
+	for _, p := range t.placeholders {
+		res = append(res, t.script[last:p.Offset]...)
+		res = append(res, encoded[p.Name]...)
+		last = p.Offset + p.size
+	}
+	res = append(res, t.script[last:]...)
+
+	// newOffset maps template script offset to the resulting script offset.
+	newOffset := func(old int) int {
+		var n = old
+		for _, p := range t.placeholders {
+			if p.Offset >= old {
+				break
+			}
+			n += len(encoded[p.Name]) - p.size
+		}
+		return n
+	}
+	for _, j := range t.jumps {
+		off := j.offset(t.script)
+		if off == 0 && j.optional {
+			continue
+		}
+		ip := newOffset(j.ip)
+		newOff := newOffset(j.ip+off) - ip
+		param := ip + j.param - j.ip
+		if j.long {
+			if newOff < math.MinInt32 || newOff > math.MaxInt32 {
+				return nil, fmt.Errorf("jump offset at %d overflows", ip)
+			}
+			binary.LittleEndian.PutUint32(res[param:], uint32(int32(newOff)))
+		} else {
+			if newOff < math.MinInt8 || newOff > math.MaxInt8 {
+				return nil, fmt.Errorf("short jump offset at %d overflows", ip)
+			}
+			res[param] = byte(int8(newOff))
+		}
+	}
+	return res, nil
+}
+
+// offset returns the current jump offset value.
+func (j templateJump) offset(script []byte) int {
+	if j.long {
+		return int(int32(binary.LittleEndian.Uint32(script[j.param:])))
+	}
+	return int(int8(script[j.param]))
+}
+
+// templateInstruction parses the instruction at the given offset and returns
+// its opcode, parameter offset and the next instruction offset.
+func templateInstruction(script []byte, ip int) (opcode.Opcode, int, int, error) {
+	var (
+		op    = opcode.Opcode(script[ip])
+		param = ip + 1
+		size  int
+	)
+	if !opcode.IsValid(op) {
+		return op, 0, 0, fmt.Errorf("incorrect opcode %s", op)
+	}
+	switch op {
+	case opcode.PUSHDATA1, opcode.PUSHDATA2, opcode.PUSHDATA4:
+		var lenSize = 1 << (op - opcode.PUSHDATA1)
+		if param+lenSize > len(script) {
+			return op, 0, 0, errors.New("missing instruction parameter")
+		}
+		switch lenSize {
+		case 1:
+			size = int(script[param])
+		case 2:
+			size = int(binary.LittleEndian.Uint16(script[param:]))
+		default:
+			n := binary.LittleEndian.Uint32(script[param:])
+			if n > stackitem.MaxSize {
+				return op, 0, 0, errors.New("parameter is too big")
+			}
+			size = int(n)
+		}
+		param += lenSize
+	case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.JMPEQ, opcode.JMPNE,
+		opcode.JMPGT, opcode.JMPGE, opcode.JMPLT, opcode.JMPLE,
+		opcode.CALL, opcode.ISTYPE, opcode.CONVERT, opcode.NEWARRAYT,
+		opcode.ENDTRY,
+		opcode.INITSSLOT, opcode.LDSFLD, opcode.STSFLD, opcode.LDARG, opcode.STARG, opcode.LDLOC, opcode.STLOC:
+		size = 1
+	case opcode.INITSLOT, opcode.TRY, opcode.CALLT:
+		size = 2
+	case opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.JMPEQL, opcode.JMPNEL,
+		opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLTL, opcode.JMPLEL,
+		opcode.ENDTRYL,
+		opcode.CALLL, opcode.SYSCALL, opcode.PUSHA:
+		size = 4
+	case opcode.TRYL:
+		size = 8
+	default:
+		if op <= opcode.PUSHINT256 {
+			size = 1 << op
+		}
+	}
+	if param+size > len(script) {
+		return op, 0, 0, errors.New("missing instruction parameter")
+	}
+	return op, param, param + size, nil
+}
+
+// emitTemplateValue checks the given value against the placeholder type and
+// emits it.
+func emitTemplateValue(w *io.BinWriter, typ ParamType, v any) error {
+	if p, ok := v.(Parameter); ok {
+		if typ != AnyType && p.Type != typ {
+			return fmt.Errorf("type mismatch: %s parameter for %s placeholder", p.Type, typ)
+		}
+		e, err := ExpandParameterToEmitable(p)
+		if err != nil {
+			return err
+		}
+		v = e
+		typ = p.Type
+	}
+	var ok bool
+	switch typ {
+	case AnyType:
+		ok = true
+	case BoolType:
+		_, ok = v.(bool)
+	case IntegerType:
+		switch v.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			ok = true
+		case *big.Int:
+			ok = v.(*big.Int) != nil
+		}
+	case ByteArrayType:
+		_, ok = v.([]byte)
+	case StringType:
+		_, ok = v.(string)
+	case Hash160Type:
+		switch h := v.(type) {
+		case util.Uint160:
+			ok = true
+		case *util.Uint160:
+			ok = h != nil
+		}
+	case Hash256Type:
+		switch h := v.(type) {
+		case util.Uint256:
+			ok = true
+		case *util.Uint256:
+			ok = h != nil
+		}
+	case PublicKeyType:
+		switch k := v.(type) {
+		case *keys.PublicKey:
+			if ok = k != nil; ok {
+				v = k.Bytes()
+			}
+		case []byte:
+			ok = len(k) == PublicKeyLen
+		}
+	case SignatureType:
+		var b []byte
+		b, ok = v.([]byte)
+		ok = ok && len(b) == SignatureLen
+	case ArrayType:
+		_, ok = v.([]any)
+	case MapType:
+		var m *stackitem.Map
+		m, ok = v.(*stackitem.Map)
+		ok = ok && m != nil
+	}
+	if !ok {
+		return fmt.Errorf("type mismatch: %T value for %s placeholder", v, typ)
+	}
+	emit.StackitemValue(w, v)
+	return w.Err
+}
@@ -0,0 +1,231 @@
+package smartcontract
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCallArguments(t *testing.T) {
+	pk, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+
+	var (
+		contract = util.Uint160{1, 2, 3}
+		m        = stackitem.NewMapWithValue([]stackitem.MapElement{{
+			Key:   stackitem.Make("key"),
+			Value: stackitem.Make(42),
+		}})
+		params = []struct {
+			typ ParamType
+			val any
+		}{
+			{AnyType, nil},
+			{BoolType, true},
+			{IntegerType, big.NewInt(100500)},
+			{ByteArrayType, []byte{1, 2, 3}},
+			{StringType, "some string"},
+			{Hash160Type, util.Uint160{3, 2, 1}},
+			{Hash256Type, util.Uint256{4, 5, 6}},
+			{PublicKeyType, pk.PublicKey()},
+			{SignatureType, make([]byte, SignatureLen)},
+			{ArrayType, []any{int64(1), "two", []byte{3}}},
+			{MapType, m},
+		}
+		tplArgs = make([]any, len(params))
+		args    = make([]any, len(params))
+		values  = make(map[string]any, len(params))
+	)
+	for i, p := range params {
+		name := "p" + p.typ.String()
+		tplArgs[i] = emit.PlaceholderValue{Name: name, Type: p.typ}
+		values[name] = p.val
+		args[i] = p.val
+		if k, ok := p.val.(*keys.PublicKey); ok {
+			args[i] = k.Bytes()
+		}
+	}
+
+	w := io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, "method", callflag.All, tplArgs...)
+	require.NoError(t, w.Err)
+	tpl, err := NewTemplate(w.Bytes())
+	require.NoError(t, err)
+	require.Equal(t, len(params), len(tpl.Placeholders()))
+	for i, p := range tpl.Placeholders() {
+		require.Equal(t, params[len(params)-1-i].typ, p.Type) // Arguments are pushed in reverse order.
+	}
+
+	w = io.NewBufBinWriter()
+	emit.AppCall(w.BinWriter, contract, "method", callflag.All, args...)
+	require.NoError(t, w.Err)
+	expected := w.Bytes()
+
+	actual, err := tpl.Fill(values)
+	require.NoError(t, err)
+	require.Equal(t, expected, actual)
+
+	t.Run("parameters", func(t *testing.T) {
+		vals := make(map[string]any, len(values))
+		for k, v := range values {
+			vals[k] = v
+		}
+		vals["pInteger"] = Parameter{Type: IntegerType, Value: big.NewInt(100500)}
+		actual, err := tpl.Fill(vals)
+		require.NoError(t, err)
+		require.Equal(t, expected, actual)
+
+		vals["pInteger"] = Parameter{Type: StringType, Value: "100500"}
+		_, err = tpl.Fill(vals)
+		require.Error(t, err)
+	})
+	t.Run("missing", func(t *testing.T) {
+		vals := make(map[string]any, len(values))
+		for k, v := range values {
+			vals[k] = v
+		}
+		delete(vals, "pHash160")
+		_, err := tpl.Fill(vals)
+		require.ErrorContains(t, err, "missing value")
+	})
+	t.Run("unknown", func(t *testing.T) {
+		vals := make(map[string]any, len(values))
+		for k, v := range values {
+			vals[k] = v
+		}
+		vals["unknown"] = 1
+		_, err := tpl.Fill(vals)
+		require.ErrorContains(t, err, "unknown placeholders")
+	})
+	t.Run("type mismatch", func(t *testing.T) {
+		bad := map[ParamType]any{
+			BoolType:      1,
+			IntegerType:   "1",
+			ByteArrayType: "bytes",
+			StringType:    []byte("string"),
+			Hash160Type:   util.Uint256{},
+			Hash256Type:   (*util.Uint256)(nil),
+			PublicKeyType: []byte{1, 2, 3},
+			SignatureType: make([]byte, SignatureLen-1),
+			ArrayType:     []byte{1},
+			MapType:       []any{},
+		}
+		for typ, v := range bad {
+			vals := make(map[string]any, len(values))
+			for k, v := range values {
+				vals[k] = v
+			}
+			vals["p"+typ.String()] = v
+			_, err := tpl.Fill(vals)
+			require.ErrorContains(t, err, "type mismatch", typ.String())
+		}
+	})
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(tpl)
+		require.NoError(t, err)
+		actual := new(Template)
+		require.NoError(t, json.Unmarshal(data, actual))
+		require.Equal(t, tpl, actual)
+	})
+}
+
+func TestTemplateJumps(t *testing.T) {
+	// The script pushes the result of placeholder comparison with 42 using
+	// jumps over placeholders in both directions.
+	w := io.NewBufBinWriter()
+	emit.Instruction(w.BinWriter, opcode.JMP, []byte{0})             // 0, to the JMPL below.
+	emit.Placeholder(w.BinWriter, "a", IntegerType)                  // 2
+	emit.Int(w.BinWriter, 42)                                        // PUSHINT8
+	emit.Instruction(w.BinWriter, opcode.JMPEQL, []byte{0, 0, 0, 0}) // to the PUSHT below.
+	emit.Opcodes(w.BinWriter, opcode.PUSHF, opcode.RET)
+	emit.Opcodes(w.BinWriter, opcode.PUSHT, opcode.RET)
+	emit.Instruction(w.BinWriter, opcode.JMPL, []byte{0, 0, 0, 0}) // back to the placeholder.
+	script := w.Bytes()
+
+	var (
+		phOff    = 2
+		phSize   = len(script) - phOff - 2 - 5 - 2 - 2 - 5
+		pushOff  = phOff + phSize
+		jmpeqOff = pushOff + 2
+		truthOff = jmpeqOff + 5 + 2
+		jmplOff  = truthOff + 2
+	)
+	script[1] = byte(jmplOff)
+	script[jmpeqOff+1] = byte(truthOff - jmpeqOff)
+	back := int32(phOff - jmplOff)
+	script[jmplOff+1] = byte(back)
+	script[jmplOff+2] = byte(back >> 8)
+	script[jmplOff+3] = byte(back >> 16)
+	script[jmplOff+4] = byte(back >> 24)
+
+	tpl, err := NewTemplate(script)
+	require.NoError(t, err)
+
+	check := func(t *testing.T, a any, expected bool) {
+		s, err := tpl.Fill(map[string]any{"a": a})
+		require.NoError(t, err)
+		v := vm.New()
+		v.LoadScript(s)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.Estack().Len())
+		require.Equal(t, expected, v.Estack().Pop().Bool())
+	}
+	check(t, 42, true)
+	check(t, 1, false)
+	check(t, new(big.Int).Lsh(big.NewInt(42), 128), false)
+}
+
+func TestTemplateBad(t *testing.T) {
+	t.Run("unsupported type", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Placeholder(w.BinWriter, "a", InteropInterfaceType)
+		_, err := NewTemplate(w.Bytes())
+		require.Error(t, err)
+	})
+	t.Run("conflicting types", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Placeholder(w.BinWriter, "a", IntegerType)
+		emit.Placeholder(w.BinWriter, "a", StringType)
+		_, err := NewTemplate(w.Bytes())
+		require.Error(t, err)
+	})
+	t.Run("truncated script", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Placeholder(w.BinWriter, "a", IntegerType)
+		s := w.Bytes()
+		_, err := NewTemplate(s[:len(s)-1])
+		require.Error(t, err)
+	})
+	t.Run("bad jump", func(t *testing.T) {
+		_, err := NewTemplate([]byte{byte(opcode.JMP), 0x10})
+		require.Error(t, err)
+	})
+	t.Run("short jump overflow", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.JMP, []byte{0})
+		emit.Placeholder(w.BinWriter, "a", ByteArrayType)
+		s := w.Bytes()
+		s[1] = byte(len(s))
+		tpl, err := NewTemplate(s)
+		require.NoError(t, err)
+		_, err = tpl.Fill(map[string]any{"a": make([]byte, 200)})
+		require.Error(t, err)
+		_, err = tpl.Fill(map[string]any{"a": make([]byte, 10)})
+		require.NoError(t, err)
+	})
+	t.Run("bad name", func(t *testing.T) {
+		w := io.NewBufBinWriter()
+		emit.Placeholder(w.BinWriter, "", IntegerType)
+		require.Error(t, w.Err)
+	})
+}
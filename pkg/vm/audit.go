@@ -0,0 +1,160 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// AuditMode specifies how VM handles mutations of compound stack items (Array,
+// Struct, Map and Buffer) owned by other execution contexts. Every contract
+// invocation (script loaded into VM) is a separate execution context, while
+// internal CALL/CALLL/CALLA invocations share the context of their caller.
+// Compound items are owned by the context they're created in, ownership of
+// the values returned from the context is transferred to its caller. Item
+// conversion (CONVERT) or struct cloning create a new item owned by the
+// current context.
+type AuditMode byte
+
+const (
+	// AuditOff disables compound item ownership tracking (the default).
+	AuditOff AuditMode = iota
+	// AuditReport records mutations of compound items owned by other
+	// execution contexts, they can be retrieved with GetAuditViolations.
+	AuditReport
+	// AuditFault faults the VM on mutation of compound item owned by another
+	// execution context.
+	AuditFault
+)
+
+// AuditViolation describes a mutation of compound item owned by another
+// execution context.
+type AuditViolation struct {
+	// Op is the mutating instruction.
+	Op opcode.Opcode
+	// IP is the mutating instruction offset.
+	IP int
+	// Type is the mutated item type.
+	Type stackitem.Type
+	// Owner is the script hash of the context owning the item.
+	Owner util.Uint160
+	// Mutator is the script hash of the context mutating the item. It can be
+	// the same as Owner for recursive contract calls.
+	Mutator util.Uint160
+}
+
+// audit tracks compound stack items ownership.
+type audit struct {
+	mode       AuditMode
+	owners     map[stackitem.Item]*scriptContext
+	violations []AuditViolation
+}
+
+// String implements fmt.Stringer interface.
+func (a AuditViolation) String() string {
+	return fmt.Sprintf("%s at %d: %s owned by 0x%s is mutated by 0x%s",
+		a.Op, a.IP, a.Type, a.Owner.StringLE(), a.Mutator.StringLE())
+}
+
+// EnableAudit sets compound item ownership audit mode. Items ownership is
+// preserved if audit is already enabled, so it can be safely called before
+// each Run. Audit mode is intended to be used for debugging and testing
+// purposes only, it significantly slows down the execution.
+func (v *VM) EnableAudit(mode AuditMode) {
+	if mode == AuditOff {
+		v.audit = nil
+		return
+	}
+	if v.audit == nil {
+		v.audit = &audit{owners: make(map[stackitem.Item]*scriptContext)}
+	}
+	v.audit.mode = mode
+}
+
+// GetAuditViolations returns mutations of compound items owned by other
+// execution contexts found since the last Load/LoadWithFlags call. Only
+// AuditReport mode allows to have more than one violation.
+func (v *VM) GetAuditViolations() []AuditViolation {
+	if v.audit == nil {
+		return nil
+	}
+	return v.audit.violations
+}
+
+// reset clears ownership data.
+func (a *audit) reset() {
+	a.owners = make(map[stackitem.Item]*scriptContext)
+	a.violations = nil
+}
+
+// isCompound checks whether the item can be mutated.
+func isCompound(item stackitem.Item) bool {
+	switch item.(type) {
+	case *stackitem.Array, *stackitem.Struct, *stackitem.Map, *stackitem.Buffer:
+		return true
+	default:
+		return false
+	}
+}
+
+// claimResult makes the context that has executed the instruction the owner
+// of a new item it has put onto the stack (and all of its unowned
+// sub-items).
+func (a *audit) claimResult(v *VM, ctx *Context) {
+	cur := v.Context()
+	if cur == nil || cur.sc != ctx.sc && cur.sc.callingContext != ctx.sc {
+		return // Context is unloaded, returned items are handled on RET.
+	}
+	if v.estack.Len() == 0 {
+		return
+	}
+	a.claim(v.estack.Top().value, ctx.sc, nil)
+}
+
+// claim makes sc the owner of the item and all of its sub-items that are
+// either unowned or owned by the from context.
+func (a *audit) claim(item stackitem.Item, sc *scriptContext, from *scriptContext) {
+	if !isCompound(item) {
+		return
+	}
+	if owner, ok := a.owners[item]; ok && owner != from || owner == sc {
+		return
+	}
+	a.owners[item] = sc
+	switch t := item.(type) {
+	case *stackitem.Array, *stackitem.Struct:
+		for _, it := range t.Value().([]stackitem.Item) {
+			a.claim(it, sc, from)
+		}
+	case *stackitem.Map:
+		for _, e := range t.Value().([]stackitem.MapElement) {
+			a.claim(e.Value, sc, from)
+		}
+	}
+}
+
+// checkMutation checks that the item mutated by the instruction is owned by
+// the current context, unowned items are claimed by it.
+func (a *audit) checkMutation(ctx *Context, op opcode.Opcode, item stackitem.Item) {
+	owner, ok := a.owners[item]
+	if !ok {
+		a.owners[item] = ctx.sc
+		return
+	}
+	if owner == ctx.sc {
+		return
+	}
+	viol := AuditViolation{
+		Op:      op,
+		IP:      ctx.ip,
+		Type:    item.Type(),
+		Owner:   (&Context{sc: owner}).ScriptHash(),
+		Mutator: ctx.ScriptHash(),
+	}
+	a.violations = append(a.violations, viol)
+	if a.mode == AuditFault {
+		panic(fmt.Sprintf("audit: %s", viol))
+	}
+}
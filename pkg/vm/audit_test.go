@@ -0,0 +1,203 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/stretchr/testify/require"
+)
+
+// auditCallerScript creates the item with the given script, stores it into
+// the static slot, passes it to another contract via SYSCALL and returns the
+// stored item.
+func auditCallerScript(create []byte) []byte {
+	w := io.NewBufBinWriter()
+	emit.Instruction(w.BinWriter, opcode.INITSSLOT, []byte{1})
+	w.WriteBytes(create)
+	emit.Opcodes(w.BinWriter, opcode.STSFLD0, opcode.LDSFLD0)
+	emit.Syscall(w.BinWriter, "System.Contract.Call")
+	emit.Opcodes(w.BinWriter, opcode.DROP, opcode.LDSFLD0, opcode.RET)
+	return w.Bytes()
+}
+
+func newAuditTestVM(t *testing.T, mode AuditMode, create []byte, callee []byte) *VM {
+	v := newTestVM()
+	v.SyscallHandler = func(v *VM, _ uint32) error {
+		arg := v.Estack().Pop()
+		v.LoadScriptWithHash(callee, util.Uint160{1, 2, 3}, 0)
+		v.Estack().Push(arg)
+		return nil
+	}
+	v.EnableAudit(mode)
+	v.LoadScript(auditCallerScript(create))
+	return v
+}
+
+func TestAudit(t *testing.T) {
+	newArray := []byte{byte(opcode.PUSH2), byte(opcode.PUSH1), byte(opcode.PUSH2), byte(opcode.PACK)}
+	newMap := []byte{byte(opcode.NEWMAP)}
+	newBuffer := []byte{byte(opcode.PUSH3), byte(opcode.NEWBUFFER)}
+	newNested := append(append([]byte{}, newArray...), byte(opcode.PUSH1), byte(opcode.PACK))
+
+	testCases := []struct {
+		name   string
+		create []byte
+		callee []byte
+		op     opcode.Opcode
+		typ    stackitem.Type
+	}{
+		{"append", newArray, []byte{byte(opcode.PUSH3), byte(opcode.APPEND), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.APPEND, stackitem.ArrayT},
+		{"setitem array", newArray, []byte{byte(opcode.PUSH0), byte(opcode.PUSH5), byte(opcode.SETITEM), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.SETITEM, stackitem.ArrayT},
+		{"setitem map", newMap, []byte{byte(opcode.PUSH0), byte(opcode.PUSH5), byte(opcode.SETITEM), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.SETITEM, stackitem.MapT},
+		{"setitem buffer", newBuffer, []byte{byte(opcode.PUSH0), byte(opcode.PUSH5), byte(opcode.SETITEM), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.SETITEM, stackitem.BufferT},
+		{"reverseitems", newArray, []byte{byte(opcode.REVERSEITEMS), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.REVERSEITEMS, stackitem.ArrayT},
+		{"remove", newArray, []byte{byte(opcode.PUSH0), byte(opcode.REMOVE), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.REMOVE, stackitem.ArrayT},
+		{"clearitems", newArray, []byte{byte(opcode.CLEARITEMS), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.CLEARITEMS, stackitem.ArrayT},
+		{"popitem", newArray, []byte{byte(opcode.POPITEM), byte(opcode.RET)},
+			opcode.POPITEM, stackitem.ArrayT},
+		{"memcpy", newBuffer, []byte{byte(opcode.PUSH0), byte(opcode.PUSHDATA1), 1, 0xff, byte(opcode.PUSH0), byte(opcode.PUSH1), byte(opcode.MEMCPY), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.MEMCPY, stackitem.BufferT},
+		{"nested array", newNested, []byte{byte(opcode.PUSH0), byte(opcode.PICKITEM), byte(opcode.PUSH3), byte(opcode.APPEND), byte(opcode.PUSHT), byte(opcode.RET)},
+			opcode.APPEND, stackitem.ArrayT},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("report", func(t *testing.T) {
+				v := newAuditTestVM(t, AuditReport, tc.create, tc.callee)
+				caller := v.Context().ScriptHash()
+				require.NoError(t, v.Run())
+				vs := v.GetAuditViolations()
+				require.Equal(t, 1, len(vs))
+				require.Equal(t, tc.op, vs[0].Op)
+				require.Equal(t, tc.typ, vs[0].Type)
+				require.Equal(t, caller, vs[0].Owner)
+				require.Equal(t, util.Uint160{1, 2, 3}, vs[0].Mutator)
+			})
+			t.Run("fault", func(t *testing.T) {
+				v := newAuditTestVM(t, AuditFault, tc.create, tc.callee)
+				err := v.Run()
+				require.Error(t, err)
+				require.ErrorContains(t, err, "audit: "+tc.op.String())
+				require.True(t, v.HasFailed())
+			})
+			t.Run("off", func(t *testing.T) {
+				v := newAuditTestVM(t, AuditOff, tc.create, tc.callee)
+				require.NoError(t, v.Run())
+				require.Nil(t, v.GetAuditViolations())
+			})
+		})
+	}
+
+	// The mutation is visible to the caller, that's exactly what audit mode
+	// is supposed to catch.
+	t.Run("aliasing", func(t *testing.T) {
+		v := newAuditTestVM(t, AuditReport, newArray, testCases[0].callee)
+		require.NoError(t, v.Run())
+		require.Equal(t, 3, len(v.Estack().Pop().Array()))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			create []byte
+			callee []byte
+		}{
+			{"read only", newArray, []byte{byte(opcode.PUSH0), byte(opcode.PICKITEM), byte(opcode.RET)}},
+			{"copy", newArray, []byte{byte(opcode.UNPACK), byte(opcode.PACK), byte(opcode.PUSH3), byte(opcode.APPEND), byte(opcode.PUSHT), byte(opcode.RET)}},
+			{"convert buffer", newBuffer, []byte{byte(opcode.CONVERT), byte(stackitem.ByteArrayT), byte(opcode.CONVERT), byte(stackitem.BufferT),
+				byte(opcode.PUSH0), byte(opcode.PUSH5), byte(opcode.SETITEM), byte(opcode.PUSHT), byte(opcode.RET)}},
+			{"own array", newArray, []byte{byte(opcode.DROP), byte(opcode.NEWARRAY0), byte(opcode.DUP), byte(opcode.PUSH1), byte(opcode.APPEND), byte(opcode.RET)}},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				v := newAuditTestVM(t, AuditFault, tc.create, tc.callee)
+				require.NoError(t, v.Run())
+				require.Nil(t, v.GetAuditViolations())
+			})
+		}
+	})
+
+	t.Run("returned item", func(t *testing.T) {
+		// Callee creates an array and returns it, caller mutates it.
+		callee := []byte{byte(opcode.DROP), byte(opcode.PUSH1), byte(opcode.PUSH1), byte(opcode.PACK), byte(opcode.RET)}
+		w := io.NewBufBinWriter()
+		emit.Opcodes(w.BinWriter, opcode.PUSHNULL)
+		emit.Syscall(w.BinWriter, "System.Contract.Call")
+		emit.Opcodes(w.BinWriter, opcode.DUP, opcode.PUSH2, opcode.APPEND, opcode.RET)
+		v := newTestVM()
+		v.SyscallHandler = func(v *VM, _ uint32) error {
+			v.Estack().Pop()
+			v.LoadScriptWithHash(callee, util.Uint160{1, 2, 3}, 0)
+			v.Estack().PushVal(nil)
+			return nil
+		}
+		v.EnableAudit(AuditFault)
+		v.LoadScript(w.Bytes())
+		require.NoError(t, v.Run())
+		require.Equal(t, 2, len(v.Estack().Pop().Array()))
+	})
+
+	t.Run("internal call", func(t *testing.T) {
+		// Internal calls share the context, so mutations are allowed.
+		w := io.NewBufBinWriter()
+		emit.Instruction(w.BinWriter, opcode.INITSSLOT, []byte{1})
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY0, opcode.STSFLD0, opcode.LDSFLD0)
+		emit.Instruction(w.BinWriter, opcode.CALL, []byte{4})
+		emit.Opcodes(w.BinWriter, opcode.LDSFLD0, opcode.RET)
+		emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.APPEND, opcode.RET)
+		v := newTestVM()
+		v.EnableAudit(AuditFault)
+		v.LoadScript(w.Bytes())
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, len(v.Estack().Pop().Array()))
+	})
+
+	t.Run("recursive call", func(t *testing.T) {
+		// The same contract called again has its own context.
+		v := newAuditTestVM(t, AuditReport, newArray, testCases[0].callee)
+		v.SyscallHandler = func(v *VM, _ uint32) error {
+			arg := v.Estack().Pop()
+			v.LoadScriptWithHash(testCases[0].callee, v.GetCurrentScriptHash(), 0)
+			v.Estack().Push(arg)
+			return nil
+		}
+		caller := v.Context().ScriptHash()
+		require.NoError(t, v.Run())
+		vs := v.GetAuditViolations()
+		require.Equal(t, 1, len(vs))
+		require.Equal(t, caller, vs[0].Owner)
+		require.Equal(t, caller, vs[0].Mutator)
+	})
+
+	t.Run("reload", func(t *testing.T) {
+		v := newAuditTestVM(t, AuditReport, newArray, testCases[0].callee)
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, len(v.GetAuditViolations()))
+		v.Load([]byte{byte(opcode.RET)})
+		require.Nil(t, v.GetAuditViolations())
+		v.Reset(v.trigger)
+		require.Nil(t, v.audit)
+	})
+}
+
+func TestAuditViolation_String(t *testing.T) {
+	a := AuditViolation{
+		Op:      opcode.APPEND,
+		IP:      7,
+		Type:    stackitem.ArrayT,
+		Owner:   util.Uint160{1},
+		Mutator: util.Uint160{2},
+	}
+	require.Equal(t, "APPEND at 7: Array owned by 0x"+util.Uint160{1}.StringLE()+" is mutated by 0x"+util.Uint160{2}.StringLE(), a.String())
+}
@@ -0,0 +1,327 @@
+package vm
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"text/tabwriter"
+	"unicode/utf8"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
+	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+)
+
+// DisassembleOptions contains disassembler settings.
+type DisassembleOptions struct {
+	// NoDataHeuristics disables decoding of data pushed by PUSHDATA*
+	// instructions as strings and hashes.
+	NoDataHeuristics bool
+}
+
+// Instruction is a disassembled VM instruction with annotations.
+type Instruction struct {
+	// Offset is the instruction offset in the script.
+	Offset int
+	// Size is the instruction size including the opcode and parameter.
+	Size      int
+	Opcode    opcode.Opcode
+	Parameter []byte
+	// BlockStart is true for the first instruction of a basic block (the
+	// first instruction of the script, jump, call and exception handler
+	// targets and instructions following branches and terminators).
+	BlockStart bool
+	// Label is the instruction label ("L<n>"), it's set for all basic block
+	// starts except the first instruction of the script (if it's not a jump
+	// target).
+	Label string
+	// Targets contains absolute offsets of jump, call, PUSHA and
+	// ENDTRY targets. For TRY it contains catch and finally offsets, -1 is
+	// used for the missing ones.
+	Targets []int
+	// TargetLabels contains labels of the Targets, it's empty for targets
+	// not pointing to an instruction (script end, for example).
+	TargetLabels []string
+	// Syscall is the interop name for SYSCALL instruction, it's empty if
+	// the interop is not known.
+	Syscall string
+	// Value is the decoded pushed data: *big.Int for PUSHINT*, PUSHM1 and
+	// PUSH0-PUSH16, string for PUSHDATA* with valid UTF-8 data and
+	// util.Uint160 (from big-endian bytes) for other 20-byte PUSHDATA*.
+	// It's nil for all other instructions.
+	Value any
+	// TargetErr is set if any of the Targets is outside of the script.
+	TargetErr error
+}
+
+// DisassembleError is returned for scripts that can't be disassembled
+// completely, instructions preceding the bad one are still returned.
+type DisassembleError struct {
+	// Offset is the offset of the instruction that can't be decoded.
+	Offset int
+	// Opcode is the opcode at this offset (it can be invalid).
+	Opcode opcode.Opcode
+	Err    error
+}
+
+// Error implements the error interface.
+func (e *DisassembleError) Error() string {
+	return fmt.Sprintf("at offset %d (%s): %s", e.Offset, e.Opcode, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *DisassembleError) Unwrap() error {
+	return e.Err
+}
+
+// Disassemble decodes the script into a list of annotated instructions. Jump
+// targets are resolved and labeled, basic block boundaries are marked,
+// syscall names are resolved and pushed data is decoded. If the script is
+// truncated or has an invalid instruction, all instructions preceding it are
+// returned along with *DisassembleError.
+func Disassemble(script []byte, opts DisassembleOptions) ([]Instruction, error) {
+	var (
+		res    []Instruction
+		resErr error
+		ctx    = NewContext(script)
+		index  = make(map[int]int)
+	)
+	for ctx.nextip < len(script) {
+		op, param, err := ctx.Next()
+		if err != nil {
+			resErr = &DisassembleError{Offset: ctx.ip, Opcode: op, Err: err}
+			break
+		}
+		ins := Instruction{
+			Offset:    ctx.ip,
+			Size:      ctx.nextip - ctx.ip,
+			Opcode:    op,
+			Parameter: param,
+		}
+		annotate(&ins, len(script), opts)
+		index[ins.Offset] = len(res)
+		res = append(res, ins)
+	}
+
+	// Mark basic block leaders.
+	for i := range res {
+		ins := &res[i]
+		if i == 0 {
+			ins.BlockStart = true
+		}
+		for _, t := range ins.Targets {
+			if j, ok := index[t]; ok {
+				res[j].BlockStart = true
+				if res[j].Label == "" {
+					res[j].Label = "-" // Temporary mark, see below.
+				}
+			}
+		}
+		if endsBlock(ins.Opcode) && i+1 < len(res) {
+			res[i+1].BlockStart = true
+		}
+	}
+	var n int
+	for i := range res {
+		if res[i].Label != "" || (res[i].BlockStart && i != 0) {
+			n++
+			res[i].Label = "L" + strconv.Itoa(n)
+		}
+	}
+	for i := range res {
+		ins := &res[i]
+		if len(ins.Targets) == 0 {
+			continue
+		}
+		ins.TargetLabels = make([]string, len(ins.Targets))
+		for k, t := range ins.Targets {
+			if j, ok := index[t]; ok {
+				ins.TargetLabels[k] = res[j].Label
+			}
+		}
+	}
+	return res, resErr
+}
+
+// annotate fills in instruction annotations that don't depend on other
+// instructions.
+func annotate(ins *Instruction, scriptLen int, opts DisassembleOptions) {
+	var (
+		op    = ins.Opcode
+		param = ins.Parameter
+	)
+	addTarget := func(p []byte) {
+		var rOffset int
+		if len(p) == 1 {
+			rOffset = int(int8(p[0]))
+		} else {
+			rOffset = int(int32(uint32(p[0]) | uint32(p[1])<<8 | uint32(p[2])<<16 | uint32(p[3])<<24))
+		}
+		t := ins.Offset + rOffset
+		if (t < 0 || t > scriptLen) && ins.TargetErr == nil {
+			ins.TargetErr = fmt.Errorf("invalid offset %d ip at %d", t, ins.Offset)
+		}
+		ins.Targets = append(ins.Targets, t)
+	}
+	switch {
+	case isJump(op) || op == opcode.CALL || op == opcode.CALLL ||
+		op == opcode.PUSHA || op == opcode.ENDTRY || op == opcode.ENDTRYL:
+		addTarget(param)
+	case op == opcode.TRY || op == opcode.TRYL:
+		catchP, finallyP := getTryParams(op, param)
+		for _, p := range [][]byte{catchP, finallyP} {
+			if isZero(p) {
+				ins.Targets = append(ins.Targets, -1)
+			} else {
+				addTarget(p)
+			}
+		}
+	case op == opcode.SYSCALL:
+		name, err := interopnames.FromID(GetInteropID(param))
+		if err == nil {
+			ins.Syscall = name
+		}
+	case op >= opcode.PUSHINT8 && op <= opcode.PUSHINT256:
+		ins.Value = bigint.FromBytes(param)
+	case op >= opcode.PUSHM1 && op <= opcode.PUSH16:
+		ins.Value = big.NewInt(int64(op) - int64(opcode.PUSH0))
+	case op == opcode.PUSHDATA1 || op == opcode.PUSHDATA2 || op == opcode.PUSHDATA4:
+		if opts.NoDataHeuristics {
+			break
+		}
+		if utf8.Valid(param) {
+			ins.Value = string(param)
+		} else if u, err := util.Uint160DecodeBytesBE(param); err == nil {
+			ins.Value = u
+		}
+	}
+}
+
+func isZero(p []byte) bool {
+	for _, b := range p {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// isJump checks whether op is a conditional or unconditional jump.
+func isJump(op opcode.Opcode) bool {
+	return op >= opcode.JMP && op <= opcode.JMPLEL
+}
+
+// endsBlock checks whether the instruction following op starts a new basic
+// block.
+func endsBlock(op opcode.Opcode) bool {
+	switch op {
+	case opcode.RET, opcode.THROW, opcode.ABORT, opcode.ABORTMSG,
+		opcode.TRY, opcode.TRYL, opcode.ENDTRY, opcode.ENDTRYL, opcode.ENDFINALLY:
+		return true
+	default:
+		return isJump(op)
+	}
+}
+
+// WriteDisassembly writes instructions returned from Disassemble (and the
+// error if any) to w as a table.
+func WriteDisassembly(w io.Writer, instrs []Instruction, err error) {
+	writeDisassembly(w, instrs, err, -1)
+}
+
+// writeDisassembly writes the disassembly marking the instruction at the
+// given cursor offset.
+func writeDisassembly(out io.Writer, instrs []Instruction, err error, cursor int) {
+	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
+	fmt.Fprintln(w, "INDEX\tLABEL\tOPCODE\tPARAMETER")
+	mark := func(offset int) string {
+		if offset == cursor {
+			return "\t<<"
+		}
+		return ""
+	}
+	for i := range instrs {
+		ins := &instrs[i]
+		var label string
+		if ins.Label != "" {
+			label = ins.Label + ":"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s%s\n", ins.Offset, label, ins.Opcode, ins.describe(), mark(ins.Offset))
+	}
+	var derr *DisassembleError
+	if err != nil {
+		offset := -1
+		if errors.As(err, &derr) {
+			offset = derr.Offset
+			fmt.Fprintf(w, "%d\t\t%s\tERROR: %s%s\n", derr.Offset, derr.Opcode, derr.Err, mark(offset))
+		} else {
+			fmt.Fprintf(w, "\t\t\tERROR: %s\n", err)
+		}
+	}
+	w.Flush()
+}
+
+// describe returns the instruction parameter description.
+func (ins *Instruction) describe() string {
+	param := ins.Parameter
+	if param == nil {
+		return ""
+	}
+	target := func(k int, p []byte) string {
+		if ins.TargetErr != nil {
+			return "ERROR: " + ins.TargetErr.Error()
+		}
+		t := ins.Targets[k]
+		if ins.TargetLabels[k] != "" {
+			return fmt.Sprintf("%s %d (%d/%x)", ins.TargetLabels[k], t, t-ins.Offset, p)
+		}
+		return fmt.Sprintf("%d (%d/%x)", t, t-ins.Offset, p)
+	}
+	switch op := ins.Opcode; {
+	case len(ins.Targets) != 0 && (op == opcode.TRY || op == opcode.TRYL):
+		catchP, finallyP := getTryParams(op, param)
+		var parts [2]string
+		for k, p := range [][]byte{catchP, finallyP} {
+			if ins.Targets[k] < 0 {
+				parts[k] = "none"
+			} else {
+				parts[k] = target(k, p)
+			}
+		}
+		return fmt.Sprintf("catch %s, finally %s", parts[0], parts[1])
+	case len(ins.Targets) != 0:
+		return target(0, param)
+	case op == opcode.INITSSLOT:
+		return fmt.Sprint(param[0])
+	case op == opcode.CONVERT || op == opcode.ISTYPE || op == opcode.NEWARRAYT:
+		return fmt.Sprintf("%s (%x)", stackitem.Type(param[0]), param[0])
+	case op == opcode.INITSLOT:
+		return fmt.Sprintf("%d local, %d arg", param[0], param[1])
+	case op == opcode.SYSCALL:
+		name := ins.Syscall
+		if name == "" {
+			name = "not found"
+		}
+		return fmt.Sprintf("%s (%x)", name, param)
+	case op >= opcode.PUSHINT8 && op <= opcode.PUSHINT256:
+		return fmt.Sprintf("%d (%x)", ins.Value, param)
+	case op == opcode.LDLOC || op == opcode.STLOC || op == opcode.LDARG ||
+		op == opcode.STARG || op == opcode.LDSFLD || op == opcode.STSFLD:
+		return fmt.Sprintf("%d (%x)", param[0], param)
+	case op == opcode.CALLT:
+		return fmt.Sprintf("%d (%x)", uint16(param[0])|uint16(param[1])<<8, param)
+	}
+	switch v := ins.Value.(type) {
+	case string:
+		return fmt.Sprintf("%x (%q)", param, v)
+	case util.Uint160:
+		return fmt.Sprintf("%x (%q, %q)", param, address.Uint160ToString(v), "0x"+v.StringLE())
+	default:
+		return fmt.Sprintf("%x", param)
+	}
+}
@@ -0,0 +1,138 @@
+package vm
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisassemble(t *testing.T) {
+	ops := func(ops ...opcode.Opcode) []byte {
+		return makeProgram(ops...)[:len(ops)] // Without the trailing RET.
+	}
+	w := bytes.NewBuffer(nil)
+	w.Write(ops(opcode.PUSH1, opcode.JMPIFNOT, 6, opcode.PUSHINT8, 42, opcode.JMP, 3, opcode.PUSH0))
+	w.Write([]byte{byte(opcode.SYSCALL)})
+	id := interopnames.ToID([]byte(interopnames.SystemRuntimeLog))
+	w.Write(binary.LittleEndian.AppendUint32(nil, id))
+	w.Write(ops(opcode.TRY, 5, 0, opcode.ENDTRY, 3, opcode.RET, opcode.RET))
+
+	instrs, err := Disassemble(w.Bytes(), DisassembleOptions{})
+	require.NoError(t, err)
+
+	type expected struct {
+		offset  int
+		op      opcode.Opcode
+		label   string
+		start   bool
+		targets []int
+		tlabels []string
+	}
+	exp := []expected{
+		{0, opcode.PUSH1, "", true, nil, nil},
+		{1, opcode.JMPIFNOT, "", false, []int{7}, []string{"L2"}},
+		{3, opcode.PUSHINT8, "L1", true, nil, nil},
+		{5, opcode.JMP, "", false, []int{8}, []string{"L3"}},
+		{7, opcode.PUSH0, "L2", true, nil, nil},
+		{8, opcode.SYSCALL, "L3", true, nil, nil},
+		{13, opcode.TRY, "", false, []int{18, -1}, []string{"L5", ""}},
+		{16, opcode.ENDTRY, "L4", true, []int{19}, []string{"L6"}},
+		{18, opcode.RET, "L5", true, nil, nil},
+		{19, opcode.RET, "L6", true, nil, nil},
+	}
+	require.Equal(t, len(exp), len(instrs))
+	for i, e := range exp {
+		ins := instrs[i]
+		require.Equal(t, e.offset, ins.Offset, i)
+		require.Equal(t, e.op, ins.Opcode, i)
+		require.Equal(t, e.label, ins.Label, i)
+		require.Equal(t, e.start, ins.BlockStart, i)
+		require.Equal(t, e.targets, ins.Targets, i)
+		require.Equal(t, e.tlabels, ins.TargetLabels, i)
+		require.NoError(t, ins.TargetErr, i)
+	}
+	require.Equal(t, big.NewInt(1), instrs[0].Value)
+	require.Equal(t, big.NewInt(42), instrs[2].Value)
+	require.Equal(t, big.NewInt(0), instrs[4].Value)
+	require.Equal(t, interopnames.SystemRuntimeLog, instrs[5].Syscall)
+
+	buf := bytes.NewBuffer(nil)
+	WriteDisassembly(buf, instrs, err)
+	lines := strings.Split(buf.String(), "\n")
+	require.Equal(t, len(exp)+2, len(lines)) // Header and trailing newline.
+	require.Regexp(t, `^INDEX\s+LABEL\s+OPCODE\s+PARAMETER$`, lines[0])
+	require.Regexp(t, `^1\s+JMPIFNOT\s+L2 7 \(6/06\)$`, lines[2])
+	require.Regexp(t, `^7\s+L2:\s+PUSH0\s*$`, lines[5])
+	require.Regexp(t, `^8\s+L3:\s+SYSCALL\s+System\.Runtime\.Log`, lines[6])
+	require.Regexp(t, `^13\s+TRY\s+catch L5 18 \(5/05\), finally none$`, lines[7])
+}
+
+func TestDisassemble_Data(t *testing.T) {
+	h := util.Uint160{0xff, 1, 2, 3}
+	w := bytes.NewBuffer(nil)
+	w.Write([]byte{byte(opcode.PUSHDATA1), 3, 'a', 'b', 'c'})
+	w.Write([]byte{byte(opcode.PUSHDATA1), 20})
+	w.Write(h.BytesBE())
+	w.Write([]byte{byte(opcode.PUSHDATA1), 2, 0xff, 0xfe})
+	w.Write([]byte{byte(opcode.PUSHINT16), 0x00, 0x80})
+
+	instrs, err := Disassemble(w.Bytes(), DisassembleOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 4, len(instrs))
+	require.Equal(t, "abc", instrs[0].Value)
+	require.Equal(t, h, instrs[1].Value)
+	require.Nil(t, instrs[2].Value)
+	require.Equal(t, big.NewInt(-32768), instrs[3].Value)
+
+	instrs, err = Disassemble(w.Bytes(), DisassembleOptions{NoDataHeuristics: true})
+	require.NoError(t, err)
+	require.Nil(t, instrs[0].Value)
+	require.Nil(t, instrs[1].Value)
+	require.Equal(t, big.NewInt(-32768), instrs[3].Value)
+}
+
+func TestDisassemble_Errors(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		instrs, err := Disassemble(nil, DisassembleOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(instrs))
+	})
+	t.Run("truncated", func(t *testing.T) {
+		instrs, err := Disassemble([]byte{byte(opcode.PUSH1), byte(opcode.PUSHDATA1), 10, 1, 2}, DisassembleOptions{})
+		var derr *DisassembleError
+		require.ErrorAs(t, err, &derr)
+		require.Equal(t, 1, derr.Offset)
+		require.Equal(t, opcode.PUSHDATA1, derr.Opcode)
+		require.Equal(t, 1, len(instrs))
+	})
+	t.Run("invalid opcode", func(t *testing.T) {
+		instrs, err := Disassemble([]byte{byte(opcode.PUSH1), byte(opcode.PUSH2), 0xff}, DisassembleOptions{})
+		var derr *DisassembleError
+		require.ErrorAs(t, err, &derr)
+		require.Equal(t, 2, derr.Offset)
+		require.Equal(t, 2, len(instrs))
+
+		buf := bytes.NewBuffer(nil)
+		WriteDisassembly(buf, instrs, err)
+		require.Regexp(t, `\n2\s+.*ERROR: incorrect opcode`, buf.String())
+	})
+	t.Run("bad jump", func(t *testing.T) {
+		instrs, err := Disassemble(makeProgram(opcode.JMP, 0x7f), DisassembleOptions{})
+		require.NoError(t, err)
+		require.Equal(t, 2, len(instrs))
+		require.Error(t, instrs[0].TargetErr)
+		require.Equal(t, []int{0x7f}, instrs[0].Targets)
+		require.Equal(t, []string{""}, instrs[0].TargetLabels)
+
+		buf := bytes.NewBuffer(nil)
+		WriteDisassembly(buf, instrs, err)
+		require.Contains(t, buf.String(), "ERROR: invalid offset 127 ip at 0")
+	})
+}
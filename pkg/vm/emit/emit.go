@@ -1,11 +1,14 @@
 package emit
 
 import (
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
 	"math/bits"
+	"reflect"
+	"sort"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
@@ -101,14 +104,28 @@ func bigInt(w *io.BinWriter, n *big.Int, trySmall bool) {
 // Array emits an array of elements to the given buffer. It accepts everything that
 // Any accepts.
 func Array(w *io.BinWriter, es ...any) {
-	if len(es) == 0 {
+	packArray(w, len(es), func(i int) { Any(w, es[i]) })
+}
+
+// ArrayOfAny emits an array of elements to the given buffer. Unlike Array, it
+// accepts heterogeneous elements of any type supported by StackitemValue, so
+// nested slices and maps can be passed as is.
+func ArrayOfAny(w *io.BinWriter, es []any) {
+	packArray(w, len(es), func(i int) { StackitemValue(w, es[i]) })
+}
+
+// packArray emits n elements with the given function in the reverse order and
+// packs them into an array, NEWARRAY0 is used for empty arrays. It's the
+// cheapest way to create an array both in terms of script size and GAS.
+func packArray(w *io.BinWriter, n int, elem func(i int)) {
+	if n == 0 {
 		Opcodes(w, opcode.NEWARRAY0)
 		return
 	}
-	for i := len(es) - 1; i >= 0; i-- {
-		Any(w, es[i])
+	for i := n - 1; i >= 0; i-- {
+		elem(i)
 	}
-	Int(w, int64(len(es)))
+	Int(w, int64(n))
 	Opcodes(w, opcode.PACK)
 }
 
@@ -120,12 +137,186 @@ func Array(w *io.BinWriter, es ...any) {
 //   - util.Uint160, *util.Uint160, util.Uint256, *util.Uint256
 //   - bool
 //   - stackitem.Convertible, stackitem.Item
+//   - PlaceholderValue
 //   - nil
 //   - []any
 func Any(w *io.BinWriter, something any) {
+	if !value(w, something, Any) {
+		w.Err = fmt.Errorf("unsupported type: %T", something)
+	}
+}
+
+// StackitemValue emits the code creating a stack item equivalent to the given
+// Go value. It accepts everything Any accepts (elements of []any are emitted
+// with StackitemValue) and also:
+//   - slices and arrays of any supported type, those with byte elements are
+//     emitted as byte strings
+//   - maps with keys of boolean, integer, string or byte array types (like
+//     util.Uint160) and values of any supported type, map entries are ordered
+//     by keys (booleans, then integers, then byte strings) to make the result
+//     deterministic
+//   - pointers to any supported type, nil pointers are emitted as PUSHNULL
+//   - named types based on boolean, integer and string types
+//
+// Collections are built with PACK/PACKMAP (or NEWARRAY0/NEWMAP when empty), so
+// the caller doesn't need to care about the order of elements on the stack.
+func StackitemValue(w *io.BinWriter, v any) {
+	if value(w, v, StackitemValue) {
+		return
+	}
+	var r = reflect.ValueOf(v)
+	switch r.Kind() {
+	case reflect.Pointer:
+		if r.IsNil() {
+			Opcodes(w, opcode.PUSHNULL)
+			return
+		}
+		StackitemValue(w, r.Elem().Interface())
+	case reflect.Slice, reflect.Array:
+		if r.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, r.Len())
+			reflect.Copy(reflect.ValueOf(b), r)
+			Bytes(w, b)
+			return
+		}
+		packArray(w, r.Len(), func(i int) { StackitemValue(w, r.Index(i).Interface()) })
+	case reflect.Map:
+		stackitemMap(w, r)
+	case reflect.Bool:
+		Bool(w, r.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		Int(w, r.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		BigInt(w, new(big.Int).SetUint64(r.Uint()))
+	case reflect.String:
+		String(w, r.String())
+	default:
+		w.Err = fmt.Errorf("unsupported type: %T", v)
+	}
+}
+
+// stackitemMap emits the given map with keys sorted and packed with PACKMAP.
+func stackitemMap(w *io.BinWriter, r reflect.Value) {
+	if r.Len() == 0 {
+		Opcodes(w, opcode.NEWMAP)
+		return
+	}
+	var (
+		keys = make([]stackitem.Item, 0, r.Len())
+		vals = make([]reflect.Value, 0, r.Len())
+		iter = r.MapRange()
+	)
+	for iter.Next() {
+		k, err := mapKey(iter.Key())
+		if err != nil {
+			w.Err = err
+			return
+		}
+		keys = append(keys, k)
+		vals = append(vals, iter.Value())
+	}
+	var idx = make([]int, len(keys))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		return compareKeys(keys[idx[i]], keys[idx[j]]) < 0
+	})
+	for i := 1; i < len(idx); i++ {
+		if compareKeys(keys[idx[i-1]], keys[idx[i]]) == 0 {
+			w.Err = fmt.Errorf("duplicate map key: %v", keys[idx[i]].Value())
+			return
+		}
+	}
+	for i := len(idx) - 1; i >= 0; i-- {
+		StackitemValue(w, vals[idx[i]].Interface())
+		StackItem(w, keys[idx[i]])
+	}
+	Int(w, int64(len(idx)))
+	Opcodes(w, opcode.PACKMAP)
+}
+
+// mapKey converts the given map key to a stack item that can be used as a
+// stackitem.Map key.
+func mapKey(k reflect.Value) (stackitem.Item, error) {
+	if k.Kind() == reflect.Interface {
+		if k.IsNil() {
+			return nil, errors.New("nil map key")
+		}
+		k = k.Elem()
+	}
+	var res stackitem.Item
+	switch k.Kind() {
+	case reflect.Bool:
+		res = stackitem.Bool(k.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		res = stackitem.NewBigInteger(big.NewInt(k.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		res = stackitem.NewBigInteger(new(big.Int).SetUint64(k.Uint()))
+	case reflect.String:
+		res = stackitem.NewByteArray([]byte(k.String()))
+	case reflect.Array:
+		if k.Type().Elem().Kind() != reflect.Uint8 {
+			return nil, fmt.Errorf("unsupported map key type: %s", k.Type())
+		}
+		if b, ok := k.Interface().(interface{ BytesBE() []byte }); ok {
+			res = stackitem.NewByteArray(b.BytesBE()) // util.Uint160 and util.Uint256.
+		} else {
+			b := make([]byte, k.Len())
+			reflect.Copy(reflect.ValueOf(b), k)
+			res = stackitem.NewByteArray(b)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported map key type: %s", k.Type())
+	}
+	return res, stackitem.IsValidMapKey(res)
+}
+
+// compareKeys orders map keys: booleans go first, then integers and then byte
+// strings, values of the same type are compared naturally.
+func compareKeys(a, b stackitem.Item) int {
+	if a.Type() != b.Type() {
+		if keyRank(a) < keyRank(b) {
+			return -1
+		}
+		return 1
+	}
+	switch a.Type() {
+	case stackitem.BooleanT:
+		ab, bb := a.Value().(bool), b.Value().(bool)
+		switch {
+		case ab == bb:
+			return 0
+		case bb:
+			return -1
+		default:
+			return 1
+		}
+	case stackitem.IntegerT:
+		return a.Value().(*big.Int).Cmp(b.Value().(*big.Int))
+	default:
+		return bytes.Compare(a.Value().([]byte), b.Value().([]byte))
+	}
+}
+
+func keyRank(k stackitem.Item) int {
+	switch k.Type() {
+	case stackitem.BooleanT:
+		return 0
+	case stackitem.IntegerT:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// value emits the given value if it's of one of the types supported by Any
+// using the given function for elements of []any. It returns false for
+// unsupported types.
+func value(w *io.BinWriter, something any, elem func(*io.BinWriter, any)) bool {
 	switch e := something.(type) {
 	case []any:
-		Array(w, e...)
+		packArray(w, len(e), func(i int) { elem(w, e[i]) })
 	case int64:
 		Int(w, e)
 	case uint64:
@@ -170,17 +361,18 @@ func Any(w *io.BinWriter, something any) {
 		Bytes(w, e)
 	case bool:
 		Bool(w, e)
+	case PlaceholderValue:
+		Placeholder(w, e.Name, e.Type)
 	case stackitem.Convertible:
 		Convertible(w, e)
 	case stackitem.Item:
 		StackItem(w, e)
-	default:
-		if something != nil {
-			w.Err = fmt.Errorf("unsupported type: %T", e)
-			return
-		}
+	case nil:
 		Opcodes(w, opcode.PUSHNULL)
+	default:
+		return false
 	}
+	return true
 }
 
 // Convertible converts provided stackitem.Convertible to the stackitem.Item and
@@ -649,3 +649,42 @@ func TestEmitConvertible(t *testing.T) {
 		require.ErrorIs(t, actualErr, expectedErr)
 	})
 }
+
+type testParamType string
+
+func (t testParamType) String() string { return string(t) }
+
+func TestPlaceholder(t *testing.T) {
+	buf := io.NewBufBinWriter()
+	Placeholder(buf.BinWriter, "to", testParamType("Hash160"))
+	Any(buf.BinWriter, PlaceholderValue{Name: "amount", Type: testParamType("Integer")})
+	require.NoError(t, buf.Err)
+	res := buf.Bytes()
+
+	require.EqualValues(t, opcode.PUSHDATA1, res[0])
+	l := int(res[1])
+	name, typ, ok := ParsePlaceholder(res[2 : 2+l])
+	require.True(t, ok)
+	require.Equal(t, "to", name)
+	require.Equal(t, "Hash160", typ)
+
+	res = res[2+l:]
+	require.EqualValues(t, opcode.PUSHDATA1, res[0])
+	name, typ, ok = ParsePlaceholder(res[2:])
+	require.True(t, ok)
+	require.Equal(t, "amount", name)
+	require.Equal(t, "Integer", typ)
+
+	_, _, ok = ParsePlaceholder([]byte("regular data"))
+	require.False(t, ok)
+
+	t.Run("bad", func(t *testing.T) {
+		buf := io.NewBufBinWriter()
+		Placeholder(buf.BinWriter, strings.Repeat("a", MaxPlaceholderNameLen+1), testParamType("Integer"))
+		require.Error(t, buf.Err)
+
+		buf = io.NewBufBinWriter()
+		Placeholder(buf.BinWriter, "a", testParamType(""))
+		require.Error(t, buf.Err)
+	})
+}
@@ -0,0 +1,74 @@
+package emit
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+)
+
+// placeholderMagic is a prefix of PUSHDATA1 instruction parameter that denotes
+// a placeholder. It's followed by a name length byte, name and type string.
+var placeholderMagic = []byte{0x00, 0xf5, 'n', 'g', 't', 'p', 'l'}
+
+// MaxPlaceholderNameLen is the maximum length of placeholder name.
+const MaxPlaceholderNameLen = 64
+
+// PlaceholderValue is a named placeholder of the given type that can be passed
+// to Any (and thus to Array, AppCall and other functions accepting arbitrary
+// values) to be emitted with Placeholder.
+type PlaceholderValue struct {
+	Name string
+	// Type is a parameter type (usually smartcontract.ParamType) of the
+	// value expected in place of this placeholder.
+	Type fmt.Stringer
+}
+
+// Placeholder emits a named placeholder of the given type (usually
+// smartcontract.ParamType) to the given buffer. The resulting script can't be
+// executed as is, it's a template that should be filled with actual values
+// (see smartcontract.Template). Placeholder is represented as a PUSHDATA1
+// instruction with a specially crafted parameter, so it doesn't break script
+// parsing.
+func Placeholder(w *io.BinWriter, name string, typ fmt.Stringer) {
+	if w.Err != nil {
+		return
+	}
+	if len(name) == 0 || len(name) > MaxPlaceholderNameLen {
+		w.Err = fmt.Errorf("invalid placeholder name length: %d", len(name))
+		return
+	}
+	if typ == nil || len(typ.String()) == 0 {
+		w.Err = fmt.Errorf("invalid type of placeholder %q", name)
+		return
+	}
+	var tStr = typ.String()
+	buf := make([]byte, 0, len(placeholderMagic)+1+len(name)+len(tStr))
+	buf = append(buf, placeholderMagic...)
+	buf = append(buf, byte(len(name)))
+	buf = append(buf, name...)
+	buf = append(buf, tStr...)
+	if len(buf) > 0xff {
+		w.Err = fmt.Errorf("invalid type of placeholder %q", name)
+		return
+	}
+	Instruction(w, opcode.PUSHDATA1, []byte{byte(len(buf))})
+	w.WriteBytes(buf)
+}
+
+// ParsePlaceholder checks whether the given PUSHDATA1 instruction parameter
+// is a placeholder emitted by Placeholder and returns its name and type string
+// if so.
+func ParsePlaceholder(param []byte) (string, string, bool) {
+	if !bytes.HasPrefix(param, placeholderMagic) || len(param) < len(placeholderMagic)+1 {
+		return "", "", false
+	}
+	param = param[len(placeholderMagic):]
+	nameLen := int(param[0])
+	param = param[1:]
+	if nameLen == 0 || nameLen > MaxPlaceholderNameLen || len(param) <= nameLen {
+		return "", "", false
+	}
+	return string(param[:nameLen]), string(param[nameLen:]), true
+}
@@ -0,0 +1,184 @@
+package emit_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+	"github.com/nspcc-dev/neo-go/pkg/vm"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/nspcc-dev/neo-go/pkg/vm/stackitem"
+	"github.com/nspcc-dev/neo-go/pkg/vm/vmstate"
+	"github.com/stretchr/testify/require"
+)
+
+// runValue emits the value with the given function, runs the script and
+// returns the resulting item.
+func runValue(t *testing.T, f func(*io.BinWriter)) stackitem.Item {
+	w := io.NewBufBinWriter()
+	f(w.BinWriter)
+	require.NoError(t, w.Err)
+	v := vm.New()
+	v.LoadScript(w.Bytes())
+	require.NoError(t, v.Run())
+	require.Equal(t, vmstate.Halt, v.State())
+	require.Equal(t, 1, v.Estack().Len())
+	return v.Estack().Pop().Item()
+}
+
+func requireSameItem(t *testing.T, expected, actual stackitem.Item) {
+	exp, err := stackitem.Serialize(expected)
+	require.NoError(t, err)
+	act, err := stackitem.Serialize(actual)
+	require.NoError(t, err)
+	require.Equal(t, exp, act)
+}
+
+func TestStackitemValueMake(t *testing.T) {
+	var (
+		u160 = util.Uint160{1, 2, 3}
+		u256 = util.Uint256{4, 5, 6}
+		p160 *util.Uint160
+	)
+	values := []any{
+		0, -1, 16, 100500, int64(math.MinInt64), int8(-8), int16(300), int32(-70000),
+		uint8(200), uint16(65535), uint32(math.MaxUint32), uint64(math.MaxUint64),
+		big.NewInt(-12345), new(big.Int).Lsh(big.NewInt(1), 200),
+		"", "str", []byte{}, []byte{0xCA, 0xFE}, true, false, nil,
+		u160, u256, &u160, &u256, p160,
+		[]any{}, []any{1, "two", []any{true, nil, []byte{3}}},
+		[]int{}, []int{1, 2, 3},
+		stackitem.NewStruct([]stackitem.Item{stackitem.Make(1)}),
+	}
+	for _, val := range values {
+		t.Run(stackitem.Make(val).String(), func(t *testing.T) {
+			requireSameItem(t, stackitem.Make(val), runValue(t, func(w *io.BinWriter) {
+				emit.StackitemValue(w, val)
+			}))
+		})
+	}
+}
+
+func TestStackitemValueTyped(t *testing.T) {
+	type (
+		myInt    int
+		myString string
+		myBytes  []byte
+	)
+	var (
+		i    = 42
+		u160 = util.Uint160{1, 2, 3}
+	)
+	arr := func(items ...stackitem.Item) stackitem.Item {
+		return stackitem.NewArray(items)
+	}
+	testCases := []struct {
+		name     string
+		value    any
+		expected stackitem.Item
+	}{
+		{"strings", []string{"a", "b"}, arr(stackitem.Make("a"), stackitem.Make("b"))},
+		{"nil strings", []string(nil), arr()},
+		{"byte slices", [][]byte{{1}, {}}, arr(stackitem.Make([]byte{1}), stackitem.Make([]byte{}))},
+		{"hashes", []util.Uint160{u160}, arr(stackitem.Make(u160))},
+		{"int array", [3]int{1, 2, 3}, stackitem.Make([]int{1, 2, 3})},
+		{"byte array", [3]byte{1, 2, 3}, stackitem.Make([]byte{1, 2, 3})},
+		{"named bytes", myBytes{4, 5}, stackitem.Make([]byte{4, 5})},
+		{"named int", myInt(-7), stackitem.Make(-7)},
+		{"named string", myString("s"), stackitem.Make("s")},
+		{"uint", uint(math.MaxUint32), stackitem.Make(uint64(math.MaxUint32))},
+		{"pointer", &i, stackitem.Make(42)},
+		{"nil pointer", (*int)(nil), stackitem.Null{}},
+		{"nested", [][]int{{1}, {}, {2, 3}}, arr(stackitem.Make([]int{1}), arr(), stackitem.Make([]int{2, 3}))},
+		{"empty map", map[string]int{}, stackitem.NewMap()},
+		{"string map", map[string]int{"b": 2, "a": 1, "c": 3}, stackitem.NewMapWithValue([]stackitem.MapElement{
+			{Key: stackitem.Make("a"), Value: stackitem.Make(1)},
+			{Key: stackitem.Make("b"), Value: stackitem.Make(2)},
+			{Key: stackitem.Make("c"), Value: stackitem.Make(3)},
+		})},
+		{"int map", map[int][]string{10: {"x"}, -1: nil}, stackitem.NewMapWithValue([]stackitem.MapElement{
+			{Key: stackitem.Make(-1), Value: arr()},
+			{Key: stackitem.Make(10), Value: arr(stackitem.Make("x"))},
+		})},
+		{"hash map", map[util.Uint160]bool{u160: true}, stackitem.NewMapWithValue([]stackitem.MapElement{
+			{Key: stackitem.Make(u160), Value: stackitem.Make(true)},
+		})},
+		{"mixed keys", map[any]any{"k": nil, 5: map[bool]string{true: "t", false: "f"}, true: u160}, stackitem.NewMapWithValue([]stackitem.MapElement{
+			{Key: stackitem.Make(true), Value: stackitem.Make(u160)},
+			{Key: stackitem.Make(5), Value: stackitem.NewMapWithValue([]stackitem.MapElement{
+				{Key: stackitem.Make(false), Value: stackitem.Make("f")},
+				{Key: stackitem.Make(true), Value: stackitem.Make("t")},
+			})},
+			{Key: stackitem.Make("k"), Value: stackitem.Null{}},
+		})},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requireSameItem(t, tc.expected, runValue(t, func(w *io.BinWriter) {
+				emit.StackitemValue(w, tc.value)
+			}))
+		})
+	}
+}
+
+func TestStackitemValueOptimal(t *testing.T) {
+	check := func(t *testing.T, v any, expected ...opcode.Opcode) {
+		w := io.NewBufBinWriter()
+		emit.StackitemValue(w.BinWriter, v)
+		require.NoError(t, w.Err)
+		exp := make([]byte, len(expected))
+		for i := range expected {
+			exp[i] = byte(expected[i])
+		}
+		require.Equal(t, exp, w.Bytes())
+	}
+	check(t, []int{}, opcode.NEWARRAY0)
+	check(t, map[string]int{}, opcode.NEWMAP)
+	check(t, []int{1, 2}, opcode.PUSH2, opcode.PUSH1, opcode.PUSH2, opcode.PACK)
+	check(t, map[int]int{1: 2}, opcode.PUSH2, opcode.PUSH1, opcode.PUSH1, opcode.PACKMAP)
+}
+
+func TestStackitemValueBad(t *testing.T) {
+	for name, v := range map[string]any{
+		"struct":        struct{}{},
+		"struct slice":  []struct{}{{}},
+		"float":         1.5,
+		"channel":       make(chan int),
+		"array key":     map[[2]int]int{{1, 2}: 1},
+		"nil key":       map[any]int{nil: 1},
+		"duplicate key": map[any]int{1: 1, int64(1): 2},
+		"bad value":     map[string]any{"a": struct{}{}},
+		"long key":      map[string]int{string(make([]byte, stackitem.MaxKeySize+1)): 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := io.NewBufBinWriter()
+			emit.StackitemValue(w.BinWriter, v)
+			require.Error(t, w.Err)
+		})
+	}
+}
+
+func TestArrayOfAny(t *testing.T) {
+	es := []any{1, "str", []string{"a"}, map[string]int{"x": 1}, nil, []any{[]int{2}}}
+	expected := stackitem.NewArray([]stackitem.Item{
+		stackitem.Make(1),
+		stackitem.Make("str"),
+		stackitem.NewArray([]stackitem.Item{stackitem.Make("a")}),
+		stackitem.NewMapWithValue([]stackitem.MapElement{{Key: stackitem.Make("x"), Value: stackitem.Make(1)}}),
+		stackitem.Null{},
+		stackitem.NewArray([]stackitem.Item{stackitem.Make([]int{2})}),
+	})
+	requireSameItem(t, expected, runValue(t, func(w *io.BinWriter) {
+		emit.ArrayOfAny(w, es)
+	}))
+	requireSameItem(t, stackitem.NewArray(nil), runValue(t, func(w *io.BinWriter) {
+		emit.ArrayOfAny(w, nil)
+	}))
+
+	w := io.NewBufBinWriter()
+	emit.ArrayOfAny(w.BinWriter, []any{1, struct{}{}})
+	require.Error(t, w.Err)
+}
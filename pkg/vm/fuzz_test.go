@@ -1,6 +1,7 @@
 package vm
 
 import (
+	"io"
 	"testing"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
@@ -86,3 +87,31 @@ func FuzzVMDontPanic(f *testing.F) {
 		})
 	})
 }
+
+func FuzzDisassemble(f *testing.F) {
+	for _, s := range fuzzSeedValidScripts {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, script []byte) {
+		var (
+			instrs []Instruction
+			err    error
+		)
+		require.NotPanics(t, func() {
+			instrs, err = Disassemble(script, DisassembleOptions{})
+			WriteDisassembly(io.Discard, instrs, err)
+		})
+		var offset int
+		for _, ins := range instrs {
+			require.Equal(t, offset, ins.Offset)
+			offset += ins.Size
+		}
+		if err != nil {
+			var derr *DisassembleError
+			require.ErrorAs(t, err, &derr)
+			require.Equal(t, offset, derr.Offset)
+		} else {
+			require.Equal(t, len(script), offset)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package vm
+
+// LimitUsage describes the usage of VM stack limits. References are counted
+// the same way they're checked against MaxStackSize: every item on the
+// evaluation stack, in slots and inside of compound items (once per
+// compound item) is a reference.
+type LimitUsage struct {
+	// References is the current number of references.
+	References int `json:"references"`
+	// PeakReferences is the maximum number of references observed during
+	// execution.
+	PeakReferences int `json:"peakreferences"`
+	// StackItems is the current number of items on the evaluation stack.
+	StackItems int `json:"stackitems"`
+	// PeakStackItems is the maximum number of items on the evaluation stack
+	// observed during execution.
+	PeakStackItems int `json:"peakstackitems"`
+	// MaxReferences is the reference limit (MaxStackSize).
+	MaxReferences int `json:"maxreferences"`
+}
+
+// LimitWarningHandler is a callback invoked when the number of references
+// crosses the threshold set with SetLimitWarning. ctx is the context of the
+// instruction that has caused it and ip is its offset.
+type LimitWarningHandler func(ctx *Context, ip int, u LimitUsage)
+
+// limits tracks VM limits usage.
+type limits struct {
+	peakRefs  int
+	peakStack int
+
+	// warnAt is the number of references to invoke onWarning at, zero if
+	// disabled.
+	warnAt    int
+	warned    bool
+	onWarning LimitWarningHandler
+}
+
+// LimitUsage returns the current and peak usage of VM stack limits. Peak
+// values are reset on script (re)load with Load*, but not with LoadScript*.
+func (v *VM) LimitUsage() LimitUsage {
+	return LimitUsage{
+		References:     int(v.refs),
+		PeakReferences: v.limits.peakRefs,
+		StackItems:     v.estack.Len(),
+		PeakStackItems: v.limits.peakStack,
+		MaxReferences:  MaxStackSize,
+	}
+}
+
+// SetLimitWarning makes VM invoke f every time the number of references
+// reaches the given percentage of MaxStackSize (after being below it). It
+// allows to detect scripts approaching the limit before they fault. Zero
+// percent or nil f disable warnings.
+func (v *VM) SetLimitWarning(percent int, f LimitWarningHandler) {
+	if percent <= 0 || f == nil {
+		v.limits.warnAt, v.limits.onWarning = 0, nil
+		return
+	}
+	if percent > 100 {
+		percent = 100
+	}
+	v.limits.warnAt = MaxStackSize * percent / 100
+	if v.limits.warnAt == 0 {
+		v.limits.warnAt = 1
+	}
+	v.limits.onWarning = f
+	v.limits.warned = false
+}
+
+// updateLimitUsage updates peak values after the current instruction of ctx
+// is executed and invokes limit warning handler if needed.
+func (v *VM) updateLimitUsage(ctx *Context) {
+	refs := int(v.refs)
+	if refs > v.limits.peakRefs {
+		v.limits.peakRefs = refs
+	}
+	if n := v.estack.Len(); n > v.limits.peakStack {
+		v.limits.peakStack = n
+	}
+	if v.limits.warnAt == 0 {
+		return
+	}
+	if refs < v.limits.warnAt {
+		v.limits.warned = false
+	} else if !v.limits.warned {
+		v.limits.warned = true
+		v.limits.onWarning(ctx, ctx.ip, v.LimitUsage())
+	}
+}
+
+// reset resets peak values keeping warning settings.
+func (l *limits) reset() {
+	l.peakRefs = 0
+	l.peakStack = 0
+	l.warned = false
+}
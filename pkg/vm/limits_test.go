@@ -0,0 +1,69 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/io"
+	"github.com/nspcc-dev/neo-go/pkg/vm/emit"
+	"github.com/nspcc-dev/neo-go/pkg/vm/opcode"
+	"github.com/stretchr/testify/require"
+)
+
+// bigArrayScript creates and drops an array of n elements twice.
+func bigArrayScript(n int64) []byte {
+	w := io.NewBufBinWriter()
+	for i := 0; i < 2; i++ {
+		emit.Int(w.BinWriter, n)
+		emit.Opcodes(w.BinWriter, opcode.NEWARRAY, opcode.DROP)
+	}
+	emit.Opcodes(w.BinWriter, opcode.PUSH1, opcode.PUSH2, opcode.RET)
+	return w.Bytes()
+}
+
+func TestLimitUsage(t *testing.T) {
+	const n = 1900
+
+	type warning struct {
+		ip   int
+		refs int
+	}
+	var warnings []warning
+	v := newTestVM()
+	v.SetLimitWarning(90, func(ctx *Context, ip int, u LimitUsage) {
+		require.Equal(t, v.Context(), ctx)
+		warnings = append(warnings, warning{ip: ip, refs: u.References})
+	})
+	v.LoadScript(bigArrayScript(n))
+	require.NoError(t, v.Run())
+	require.Equal(t, LimitUsage{
+		References:     2,
+		PeakReferences: n + 1,
+		StackItems:     2,
+		PeakStackItems: 2,
+		MaxReferences:  MaxStackSize,
+	}, v.LimitUsage())
+	// PUSHINT16 takes 3 bytes, NEWARRAY and DROP take one.
+	require.Equal(t, []warning{{ip: 3, refs: n + 1}, {ip: 8, refs: n + 1}}, warnings)
+
+	t.Run("reload", func(t *testing.T) {
+		warnings = warnings[:0]
+		v.Load([]byte{byte(opcode.PUSH1)})
+		require.NoError(t, v.Run())
+		require.Equal(t, 1, v.LimitUsage().PeakReferences)
+		require.Equal(t, 0, len(warnings))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		warnings = warnings[:0]
+		v.SetLimitWarning(0, nil)
+		v.Load(bigArrayScript(n))
+		require.NoError(t, v.Run())
+		require.Equal(t, n+1, v.LimitUsage().PeakReferences)
+		require.Equal(t, 0, len(warnings))
+	})
+	t.Run("fault", func(t *testing.T) {
+		v := newTestVM()
+		v.LoadScript(bigArrayScript(MaxStackSize))
+		require.Error(t, v.Run())
+		require.Equal(t, MaxStackSize+1, v.LimitUsage().PeakReferences)
+	})
+}
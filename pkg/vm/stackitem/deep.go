@@ -0,0 +1,130 @@
+package stackitem
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/nspcc-dev/neo-go/pkg/crypto/hash"
+	"github.com/nspcc-dev/neo-go/pkg/util"
+)
+
+// itemPair is a pair of compound items being compared by DeepEquals.
+type itemPair struct {
+	a, b Item
+}
+
+// deepEqualsContext is an internal DeepEquals state.
+type deepEqualsContext struct {
+	limit   int
+	visited map[itemPair]struct{}
+}
+
+// DeepEquals checks whether two items are deeply equal. Primitive types are
+// compared in the same way VM does it (items of different types are never
+// equal), Interop and Pointer items are compared with their Equals methods.
+// Unlike VM (that uses reference equality for them), Buffer items are
+// compared by their contents and Array, Struct and Map items are compared
+// element by element recursively (Map elements order matters, as it's
+// preserved by VM and serialization). Recursive items are handled: a pair of
+// items that is already being compared is considered to be equal. The limit
+// restricts the number of compared elements (MaxComparableNumOfItems is used
+// if it's not positive), ErrTooBig is returned if it's exceeded.
+func DeepEquals(a, b Item, limit int) (bool, error) {
+	dc := deepEqualsContext{
+		limit:   MaxComparableNumOfItems,
+		visited: make(map[itemPair]struct{}),
+	}
+	if limit > 0 {
+		dc.limit = limit
+	}
+	return dc.equals(a, b)
+}
+
+func (dc *deepEqualsContext) equals(a, b Item) (bool, error) {
+	dc.limit--
+	if dc.limit < 0 {
+		return false, errTooBigElements
+	}
+	if a == nil || b == nil {
+		return a == nil && b == nil, nil
+	}
+	if a.Type() != b.Type() {
+		return false, nil
+	}
+	switch t := a.(type) {
+	case *ByteArray:
+		return bytes.Equal(*t, *b.(*ByteArray)), nil
+	case *Buffer:
+		return bytes.Equal(*t, *b.(*Buffer)), nil
+	case *BigInteger:
+		return t.Big().Cmp(b.(*BigInteger).Big()) == 0, nil
+	case *Array:
+		return dc.equalsCompound(a, b, t.value, b.(*Array).value)
+	case *Struct:
+		return dc.equalsCompound(a, b, t.value, b.(*Struct).value)
+	case *Map:
+		m := b.(*Map)
+		if len(t.value) != len(m.value) {
+			return false, nil
+		}
+		if !dc.visit(a, b) {
+			return true, nil
+		}
+		for i := range t.value {
+			ok, err := dc.equals(t.value[i].Key, m.value[i].Key)
+			if !ok || err != nil {
+				return false, err
+			}
+			ok, err = dc.equals(t.value[i].Value, m.value[i].Value)
+			if !ok || err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	default:
+		return a.Equals(b), nil
+	}
+}
+
+// equalsCompound compares elements of two arrays (or structs).
+func (dc *deepEqualsContext) equalsCompound(a, b Item, as, bs []Item) (bool, error) {
+	if len(as) != len(bs) {
+		return false, nil
+	}
+	if !dc.visit(a, b) {
+		return true, nil
+	}
+	for i := range as {
+		ok, err := dc.equals(as[i], bs[i])
+		if !ok || err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// visit marks the pair as visited and returns false if it was already
+// visited before.
+func (dc *deepEqualsContext) visit(a, b Item) bool {
+	p := itemPair{a, b}
+	if _, ok := dc.visited[p]; ok {
+		return false
+	}
+	dc.visited[p] = struct{}{}
+	return true
+}
+
+// Hash returns a deterministic digest of the item computed over its standard
+// binary serialization (that is canonical for any serializable item) prefixed
+// with the seed. Items that are equal according to DeepEquals have equal
+// hashes, so it can be used to build map keys for compound items. Items that
+// can't be serialized (like recursive ones or Interop) produce an error.
+func Hash(item Item, seed uint64) (util.Uint256, error) {
+	data, err := Serialize(item)
+	if err != nil {
+		return util.Uint256{}, err
+	}
+	buf := make([]byte, 8, 8+len(data))
+	binary.LittleEndian.PutUint64(buf, seed)
+	return hash.Sha256(append(buf, data...)), nil
+}
@@ -0,0 +1,214 @@
+package stackitem
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepEquals(t *testing.T) {
+	var testCases = map[string]struct {
+		a, b   Item
+		result bool
+	}{
+		"nils":               {nil, nil, true},
+		"nil and null":       {nil, Null{}, false},
+		"nulls":              {Null{}, Null{}, true},
+		"bools":              {NewBool(true), NewBool(true), true},
+		"different bools":    {NewBool(true), NewBool(false), false},
+		"integers":           {Make(42), Make(42), true},
+		"different integers": {Make(42), Make(43), false},
+		"integer and bool":   {Make(1), NewBool(true), false},
+		"byte arrays":        {Make([]byte{1, 2}), Make([]byte{1, 2}), true},
+		"buffers":            {NewBuffer([]byte{1, 2}), NewBuffer([]byte{1, 2}), true},
+		"different buffers":  {NewBuffer([]byte{1, 2}), NewBuffer([]byte{1, 3}), false},
+		"buffer and bytes":   {NewBuffer([]byte{1, 2}), Make([]byte{1, 2}), false},
+		"big byte arrays": {
+			NewByteArray(make([]byte, MaxByteArrayComparableSize+1)),
+			NewByteArray(make([]byte, MaxByteArrayComparableSize+1)),
+			true,
+		},
+		"arrays": {
+			Make([]Item{Make(1), Make([]Item{Make("a")})}),
+			Make([]Item{Make(1), Make([]Item{Make("a")})}),
+			true,
+		},
+		"arrays of different length": {
+			Make([]Item{Make(1)}),
+			Make([]Item{Make(1), Make(2)}),
+			false,
+		},
+		"arrays with different nested items": {
+			Make([]Item{Make(1), Make([]Item{Make("a")})}),
+			Make([]Item{Make(1), Make([]Item{Make("b")})}),
+			false,
+		},
+		"array and struct": {
+			NewArray([]Item{Make(1)}),
+			NewStruct([]Item{Make(1)}),
+			false,
+		},
+		"structs": {
+			NewStruct([]Item{Make(1), NewBuffer([]byte{1})}),
+			NewStruct([]Item{Make(1), NewBuffer([]byte{1})}),
+			true,
+		},
+		"maps": {
+			NewMapWithValue([]MapElement{{Key: Make(1), Value: Make("a")}, {Key: Make(2), Value: NewArray(nil)}}),
+			NewMapWithValue([]MapElement{{Key: Make(1), Value: Make("a")}, {Key: Make(2), Value: NewArray(nil)}}),
+			true,
+		},
+		"maps with different values": {
+			NewMapWithValue([]MapElement{{Key: Make(1), Value: Make("a")}}),
+			NewMapWithValue([]MapElement{{Key: Make(1), Value: Make("b")}}),
+			false,
+		},
+		"maps with different order": {
+			NewMapWithValue([]MapElement{{Key: Make(1), Value: Make("a")}, {Key: Make(2), Value: Make("b")}}),
+			NewMapWithValue([]MapElement{{Key: Make(2), Value: Make("b")}, {Key: Make(1), Value: Make("a")}}),
+			false,
+		},
+		"pointers": {NewPointer(1, []byte{1}), NewPointer(1, []byte{1}), true},
+		"interops": {NewInterop(42), NewInterop(42), true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			res, err := DeepEquals(tc.a, tc.b, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res)
+			res, err = DeepEquals(tc.b, tc.a, 0)
+			require.NoError(t, err)
+			require.Equal(t, tc.result, res)
+		})
+	}
+}
+
+func TestDeepEqualsRecursive(t *testing.T) {
+	newRecursive := func() *Array {
+		a := NewArray([]Item{Make(1)})
+		a.Append(a)
+		return a
+	}
+	a, b := newRecursive(), newRecursive()
+	res, err := DeepEquals(a, b, 0)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	b.Append(Make(2))
+	res, err = DeepEquals(a, b, 0)
+	require.NoError(t, err)
+	require.False(t, res)
+
+	m1, m2 := NewMap(), NewMap()
+	m1.Add(Make(1), m1)
+	m2.Add(Make(1), m2)
+	res, err = DeepEquals(m1, m2, 0)
+	require.NoError(t, err)
+	require.True(t, res)
+}
+
+func TestDeepEqualsLimit(t *testing.T) {
+	items := make([]Item, 10)
+	for i := range items {
+		items[i] = Make(i)
+	}
+	a, b := NewArray(items), NewArray(items)
+	res, err := DeepEquals(a, b, len(items)+1)
+	require.NoError(t, err)
+	require.True(t, res)
+
+	_, err = DeepEquals(a, b, len(items))
+	require.ErrorIs(t, err, ErrTooBig)
+}
+
+func TestHash(t *testing.T) {
+	a := Make([]Item{Make(1), NewStruct([]Item{Make("a")})})
+	b := Make([]Item{Make(1), NewStruct([]Item{Make("a")})})
+	ha, err := Hash(a, 0)
+	require.NoError(t, err)
+	hb, err := Hash(b, 0)
+	require.NoError(t, err)
+	require.Equal(t, ha, hb)
+
+	hs, err := Hash(a, 1)
+	require.NoError(t, err)
+	require.NotEqual(t, ha, hs)
+
+	hc, err := Hash(Make([]Item{Make(1), NewArray([]Item{Make("a")})}), 0)
+	require.NoError(t, err)
+	require.NotEqual(t, ha, hc)
+
+	r := NewArray(nil)
+	r.Append(r)
+	_, err = Hash(r, 0)
+	require.ErrorIs(t, err, ErrRecursive)
+
+	_, err = Hash(NewInterop(42), 0)
+	require.ErrorIs(t, err, ErrUnserializable)
+}
+
+// randomItem generates a random serializable item with limited nesting.
+func randomItem(r *rand.Rand, depth int) Item {
+	n := r.Intn(8)
+	if depth == 0 && n > 4 {
+		n %= 5
+	}
+	switch n {
+	case 0:
+		return Null{}
+	case 1:
+		return NewBool(r.Intn(2) == 1)
+	case 2:
+		return NewBigInteger(big.NewInt(int64(r.Intn(4) - 2)))
+	case 3:
+		return NewByteArray([]byte{byte(r.Intn(3))})
+	case 4:
+		return NewBuffer([]byte{byte(r.Intn(3))})
+	case 5, 6:
+		items := make([]Item, r.Intn(3))
+		for i := range items {
+			items[i] = randomItem(r, depth-1)
+		}
+		if n == 5 {
+			return NewArray(items)
+		}
+		return NewStruct(items)
+	default:
+		m := NewMap()
+		for i := r.Intn(3); i > 0; i-- {
+			m.Add(NewBigInteger(big.NewInt(int64(r.Intn(3)))), randomItem(r, depth-1))
+		}
+		return m
+	}
+}
+
+func TestDeepEqualsHashConsistency(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for i := 0; i < 2000; i++ {
+		a, b := randomItem(r, 2), randomItem(r, 2)
+		da, err := Serialize(a)
+		require.NoError(t, err)
+		db, err := Serialize(b)
+		require.NoError(t, err)
+
+		eq, err := DeepEquals(a, b, 0)
+		require.NoError(t, err)
+		require.Equal(t, bytes.Equal(da, db), eq)
+
+		ha, err := Hash(a, 7)
+		require.NoError(t, err)
+		hb, err := Hash(b, 7)
+		require.NoError(t, err)
+		require.Equal(t, eq, ha == hb)
+
+		// Deserialized copy is always deeply equal to the original.
+		c, err := Deserialize(da)
+		require.NoError(t, err)
+		eq, err = DeepEquals(a, c, 0)
+		require.NoError(t, err)
+		require.True(t, eq)
+	}
+}
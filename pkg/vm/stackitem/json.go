@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	gio "io"
+	"math"
 	"math/big"
 	"strconv"
 	"strings"
@@ -16,8 +17,13 @@ import (
 type decoder struct {
 	json.Decoder
 
-	count int
-	depth int
+	count    int
+	depth    int
+	maxDepth int
+	maxItems int
+	// path contains the location of the item being decoded, it's used for
+	// error reporting only.
+	path []string
 	// bestIntPrecision denotes whether maximum allowed integer precision should
 	// be used to parse big.Int items. If false, then default NeoC# value will be
 	// used which doesn't allow to precisely parse big values. This behaviour is
@@ -50,6 +56,53 @@ var ErrInvalidValue = errors.New("invalid value")
 // its processing.
 var ErrTooDeep = errors.New("too deep")
 
+// JSONLimits contains restrictions applied to JSON data being decoded into
+// stack items. Zero (or negative) values are treated as "no limit".
+type JSONLimits struct {
+	// MaxDepth is the maximum nesting level of arrays and objects.
+	MaxDepth int
+	// MaxItems is the maximum number of items (including map keys) that
+	// can be produced.
+	MaxItems int
+	// MaxSize is the maximum length of the input data in bytes.
+	MaxSize int
+}
+
+// DefaultJSONLimits are the protocol-fixed limits used by the StdLib
+// jsonDeserialize method.
+var DefaultJSONLimits = JSONLimits{
+	MaxDepth: MaxJSONDepth,
+	MaxItems: MaxDeserialized,
+	MaxSize:  MaxSize,
+}
+
+// JSONLimitError is returned by FromJSONWithLimits when one of the limits
+// is exceeded. It wraps ErrTooDeep or ErrTooBig, so errors.Is can be used
+// to check for the specific kind of limit.
+type JSONLimitError struct {
+	// Limit is the name of the limit exceeded ("depth", "items" or "size").
+	Limit string
+	// Value is the configured value of the limit.
+	Value int
+	// Offset is the input offset (in bytes) where the limit was hit.
+	Offset int64
+	// Path is the JSONPath-like location of the item that exceeded the
+	// limit, like `$.a[3].b`.
+	Path string
+
+	err error
+}
+
+// Error implements the error interface.
+func (e *JSONLimitError) Error() string {
+	return fmt.Sprintf("%s: %s limit of %d exceeded at %s (offset %d)", e.err, e.Limit, e.Value, e.Path, e.Offset)
+}
+
+// Unwrap returns the underlying error.
+func (e *JSONLimitError) Unwrap() error {
+	return e.err
+}
+
 // ToJSON encodes Item to JSON.
 // It behaves as following:
 //
@@ -181,11 +234,34 @@ func itemToJSONString(it Item) ([]byte, error) {
 //	array -> Array
 //	map -> Map, keys are UTF-8
 func FromJSON(data []byte, maxCount int, bestIntPrecision bool) (Item, error) {
+	return FromJSONWithLimits(data, JSONLimits{MaxDepth: MaxJSONDepth, MaxItems: maxCount}, bestIntPrecision)
+}
+
+// FromJSONWithLimits is similar to FromJSON, but allows to specify all
+// decoding limits. If some limit is exceeded, *JSONLimitError is returned.
+func FromJSONWithLimits(data []byte, limits JSONLimits, bestIntPrecision bool) (Item, error) {
 	d := decoder{
 		Decoder:          *json.NewDecoder(bytes.NewReader(data)),
-		count:            maxCount,
+		count:            limits.MaxItems,
+		maxDepth:         limits.MaxDepth,
+		maxItems:         limits.MaxItems,
 		bestIntPrecision: bestIntPrecision,
 	}
+	if limits.MaxItems <= 0 {
+		d.count = math.MaxInt
+	}
+	if limits.MaxDepth <= 0 {
+		d.maxDepth = math.MaxInt
+	}
+	if limits.MaxSize > 0 && len(data) > limits.MaxSize {
+		return nil, &JSONLimitError{
+			Limit:  "size",
+			Value:  limits.MaxSize,
+			Offset: int64(limits.MaxSize),
+			Path:   "$",
+			err:    errTooBigSize,
+		}
+	}
 	d.UseNumber()
 	item, err := d.decode()
 	if err != nil {
@@ -198,6 +274,17 @@ func FromJSON(data []byte, maxCount int, bestIntPrecision bool) (Item, error) {
 	return item, nil
 }
 
+// limitError creates JSONLimitError for the current decoder position.
+func (d *decoder) limitError(limit string, value int, err error) error {
+	return &JSONLimitError{
+		Limit:  limit,
+		Value:  value,
+		Offset: d.InputOffset(),
+		Path:   "$" + strings.Join(d.path, ""),
+		err:    err,
+	}
+}
+
 func (d *decoder) decode() (Item, error) {
 	tok, err := d.Token()
 	if err != nil {
@@ -206,15 +293,15 @@ func (d *decoder) decode() (Item, error) {
 
 	d.count--
 	if d.count < 0 && tok != json.Delim('}') && tok != json.Delim(']') {
-		return nil, errTooBigElements
+		return nil, d.limitError("items", d.maxItems, errTooBigElements)
 	}
 
 	switch t := tok.(type) {
 	case json.Delim:
 		switch t {
 		case json.Delim('{'), json.Delim('['):
-			if d.depth == MaxJSONDepth {
-				return nil, ErrTooDeep
+			if d.depth == d.maxDepth {
+				return nil, d.limitError("depth", d.maxDepth, ErrTooDeep)
 			}
 			d.depth++
 			var item Item
@@ -283,7 +370,9 @@ func (d *decoder) decode() (Item, error) {
 func (d *decoder) decodeArray() (*Array, error) {
 	items := []Item{}
 	for {
+		d.path = append(d.path, "["+strconv.Itoa(len(items))+"]")
 		item, err := d.decode()
+		d.path = d.path[:len(d.path)-1]
 		if err != nil {
 			return nil, err
 		}
@@ -306,11 +395,13 @@ func (d *decoder) decodeMap() (*Map, error) {
 			return m, nil
 		}
 
+		d.path = append(d.path, pathKey(k))
 		d.count--
 		if d.count < 0 {
-			return nil, errTooBigElements
+			return nil, d.limitError("items", d.maxItems, errTooBigElements)
 		}
 		val, err := d.decode()
+		d.path = d.path[:len(d.path)-1]
 		if err != nil {
 			return nil, err
 		}
@@ -318,6 +409,20 @@ func (d *decoder) decodeMap() (*Map, error) {
 	}
 }
 
+// pathKey returns a path element for the given map key.
+func pathKey(k string) string {
+	for i := 0; i < len(k); i++ {
+		c := k[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_' || i > 0 && c >= '0' && c <= '9') {
+			return "[" + strconv.Quote(k) + "]"
+		}
+	}
+	if len(k) == 0 {
+		return `[""]`
+	}
+	return "." + k
+}
+
 // ToJSONWithTypes serializes any stackitem to JSON in a lossless way.
 func ToJSONWithTypes(item Item) ([]byte, error) {
 	return toJSONWithTypes(nil, item, make(map[Item]sliceNoPointer, typicalNumOfItems))
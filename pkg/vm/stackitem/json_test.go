@@ -2,6 +2,7 @@ package stackitem
 
 import (
 	"math/big"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -169,6 +170,63 @@ func TestFromJSON_CompatBigInt(t *testing.T) {
 	}
 }
 
+func TestFromJSONWithLimits(t *testing.T) {
+	nested := func(depth int) string {
+		return strings.Repeat(`{"a":[`, depth) + strings.Repeat(`]}`, depth)
+	}
+	t.Run("deep", func(t *testing.T) {
+		// Every level adds a map and an array.
+		_, err := FromJSONWithLimits([]byte(nested(2)), JSONLimits{MaxDepth: 4}, true)
+		require.NoError(t, err)
+
+		_, err = FromJSONWithLimits([]byte(nested(3)), JSONLimits{MaxDepth: 4}, true)
+		require.ErrorIs(t, err, ErrTooDeep)
+		var lErr *JSONLimitError
+		require.ErrorAs(t, err, &lErr)
+		require.Equal(t, "depth", lErr.Limit)
+		require.Equal(t, 4, lErr.Value)
+		require.Equal(t, "$.a[0].a[0]", lErr.Path)
+		require.EqualValues(t, 13, lErr.Offset)
+	})
+	t.Run("very deep", func(t *testing.T) {
+		_, err := FromJSONWithLimits([]byte(nested(10000)), DefaultJSONLimits, true)
+		require.ErrorIs(t, err, ErrTooDeep)
+	})
+	t.Run("wide", func(t *testing.T) {
+		js := `{"list":[` + strings.Repeat(`1,`, 99) + `1],"other":{"x y":1}}`
+		_, err := FromJSONWithLimits([]byte(js), JSONLimits{MaxItems: 107}, true)
+		require.NoError(t, err)
+
+		_, err = FromJSONWithLimits([]byte(js), JSONLimits{MaxItems: 100}, true)
+		require.ErrorIs(t, err, errTooBigElements)
+		var lErr *JSONLimitError
+		require.ErrorAs(t, err, &lErr)
+		require.Equal(t, "items", lErr.Limit)
+		require.Equal(t, 100, lErr.Value)
+		require.Equal(t, "$.list[97]", lErr.Path)
+
+		_, err = FromJSONWithLimits([]byte(js), JSONLimits{MaxItems: 106}, true)
+		require.ErrorAs(t, err, &lErr)
+		require.Equal(t, `$.other["x y"]`, lErr.Path)
+	})
+	t.Run("very wide", func(t *testing.T) {
+		js := `[` + strings.Repeat(`0,`, MaxDeserialized) + `0]`
+		_, err := FromJSONWithLimits([]byte(js), DefaultJSONLimits, true)
+		require.ErrorIs(t, err, ErrTooBig)
+	})
+	t.Run("size", func(t *testing.T) {
+		js := `[` + strings.Repeat(`0,`, 10) + `0]`
+		_, err := FromJSONWithLimits([]byte(js), JSONLimits{MaxSize: len(js)}, true)
+		require.NoError(t, err)
+
+		_, err = FromJSONWithLimits([]byte(js), JSONLimits{MaxSize: len(js) - 1}, true)
+		require.ErrorIs(t, err, ErrTooBig)
+		var lErr *JSONLimitError
+		require.ErrorAs(t, err, &lErr)
+		require.Equal(t, "size", lErr.Limit)
+	})
+}
+
 func testToJSON(t *testing.T, expectedErr error, item Item) {
 	data, err := ToJSON(item)
 	if expectedErr != nil {
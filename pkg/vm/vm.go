@@ -10,12 +10,9 @@ import (
 	"math"
 	"math/big"
 	"os"
-	"text/tabwriter"
-	"unicode/utf8"
 
 	"github.com/nspcc-dev/neo-go/pkg/core/interop/interopnames"
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
-	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/bigint"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/callflag"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/nef"
@@ -90,6 +87,12 @@ type VM struct {
 
 	// invTree is a top-level invocation tree (if enabled).
 	invTree *invocations.Tree
+
+	// audit tracks compound items ownership (if enabled).
+	audit *audit
+
+	// limits tracks stack limits usage.
+	limits limits
 }
 
 var (
@@ -138,6 +141,8 @@ func (v *VM) Reset(t trigger.Type) {
 	v.LoadToken = nil
 	v.trigger = t
 	v.invTree = nil
+	v.audit = nil
+	v.limits = limits{}
 }
 
 // GasConsumed returns the amount of GAS consumed during execution.
@@ -161,89 +166,15 @@ func (v *VM) Istack() []*Context {
 	return v.istack
 }
 
-// PrintOps prints the opcodes of the current loaded program to stdout.
+// PrintOps prints the opcodes of the current loaded program to stdout
+// marking the current instruction, see Disassemble for details.
 func (v *VM) PrintOps(out io.Writer) {
 	if out == nil {
 		out = os.Stdout
 	}
-	w := tabwriter.NewWriter(out, 0, 0, 4, ' ', 0)
-	fmt.Fprintln(w, "INDEX\tOPCODE\tPARAMETER")
 	realctx := v.Context()
-	ctx := &Context{sc: realctx.sc}
-	for {
-		cursor := ""
-		instr, parameter, err := ctx.Next()
-		if ctx.ip == realctx.ip {
-			cursor = "\t<<"
-		}
-		if err != nil {
-			fmt.Fprintf(w, "%d\t%s\tERROR: %s%s\n", ctx.ip, instr, err, cursor)
-			break
-		}
-		var desc = ""
-		if parameter != nil {
-			switch instr {
-			case opcode.JMP, opcode.JMPIF, opcode.JMPIFNOT, opcode.CALL,
-				opcode.JMPEQ, opcode.JMPNE,
-				opcode.JMPGT, opcode.JMPGE, opcode.JMPLE, opcode.JMPLT,
-				opcode.JMPL, opcode.JMPIFL, opcode.JMPIFNOTL, opcode.CALLL,
-				opcode.JMPEQL, opcode.JMPNEL,
-				opcode.JMPGTL, opcode.JMPGEL, opcode.JMPLEL, opcode.JMPLTL,
-				opcode.PUSHA, opcode.ENDTRY, opcode.ENDTRYL:
-				desc = getOffsetDesc(ctx, parameter)
-			case opcode.TRY, opcode.TRYL:
-				catchP, finallyP := getTryParams(instr, parameter)
-				desc = fmt.Sprintf("catch %s, finally %s",
-					getOffsetDesc(ctx, catchP), getOffsetDesc(ctx, finallyP))
-			case opcode.INITSSLOT:
-				desc = fmt.Sprint(parameter[0])
-			case opcode.CONVERT, opcode.ISTYPE:
-				typ := stackitem.Type(parameter[0])
-				desc = fmt.Sprintf("%s (%x)", typ, parameter[0])
-			case opcode.INITSLOT:
-				desc = fmt.Sprintf("%d local, %d arg", parameter[0], parameter[1])
-			case opcode.SYSCALL:
-				name, err := interopnames.FromID(GetInteropID(parameter))
-				if err != nil {
-					name = "not found"
-				}
-				desc = fmt.Sprintf("%s (%x)", name, parameter)
-			case opcode.PUSHINT8, opcode.PUSHINT16, opcode.PUSHINT32,
-				opcode.PUSHINT64, opcode.PUSHINT128, opcode.PUSHINT256:
-				val := bigint.FromBytes(parameter)
-				desc = fmt.Sprintf("%d (%x)", val, parameter)
-			case opcode.LDLOC, opcode.STLOC, opcode.LDARG, opcode.STARG, opcode.LDSFLD, opcode.STSFLD:
-				desc = fmt.Sprintf("%d (%x)", parameter[0], parameter)
-			default:
-				if utf8.Valid(parameter) {
-					desc = fmt.Sprintf("%x (%q)", parameter, parameter)
-				} else {
-					// Try converting the parameter to an address and swap the endianness
-					// if the parameter is a 20-byte value.
-					u, err := util.Uint160DecodeBytesBE(parameter)
-					if err == nil {
-						desc = fmt.Sprintf("%x (%q, %q)", parameter, address.Uint160ToString(u), "0x"+u.StringLE())
-					} else {
-						desc = fmt.Sprintf("%x", parameter)
-					}
-				}
-			}
-		}
-
-		fmt.Fprintf(w, "%d\t%s\t%s%s\n", ctx.ip, instr, desc, cursor)
-		if ctx.nextip >= len(ctx.sc.prog) {
-			break
-		}
-	}
-	w.Flush()
-}
-
-func getOffsetDesc(ctx *Context, parameter []byte) string {
-	offset, rOffset, err := calcJumpOffset(ctx, parameter)
-	if err != nil {
-		return fmt.Sprintf("ERROR: %v", err)
-	}
-	return fmt.Sprintf("%d (%d/%x)", offset, rOffset, parameter)
+	instrs, err := Disassemble(realctx.sc.prog, DisassembleOptions{})
+	writeDisassembly(out, instrs, err, realctx.ip)
 }
 
 // AddBreakPoint adds a breakpoint to the current context.
@@ -296,6 +227,10 @@ func (v *VM) LoadWithFlags(prog []byte, f callflag.CallFlag) {
 	v.state = vmstate.None
 	v.gasConsumed = 0
 	v.invTree = nil
+	if v.audit != nil {
+		v.audit.reset()
+	}
+	v.limits.reset()
 	v.LoadScriptWithFlags(prog, f)
 }
 
@@ -587,12 +522,15 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 	// Instead of polluting the whole VM logic with error handling, we will recover
 	// each panic at a central point, putting the VM in a fault state and setting error.
 	defer func() {
+		v.updateLimitUsage(ctx)
 		if errRecover := recover(); errRecover != nil {
 			v.state = vmstate.Fault
 			err = newError(ctx.ip, op, errRecover)
 		} else if v.refs > MaxStackSize {
 			v.state = vmstate.Fault
 			err = newError(ctx.ip, op, "stack is too big")
+		} else if v.audit != nil {
+			v.audit.claimResult(v, ctx)
 		}
 	}()
 
@@ -744,7 +682,11 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		if di < 0 {
 			panic("invalid destination index")
 		}
-		dst := v.estack.Pop().value.(*stackitem.Buffer).Value().([]byte)
+		dstItem := v.estack.Pop().value.(*stackitem.Buffer)
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, dstItem)
+		}
+		dst := dstItem.Value().([]byte)
 		if sum := di + n; sum < 0 || sum > len(dst) {
 			panic("size is too big")
 		}
@@ -1151,6 +1093,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		arrElem := v.estack.Pop()
 
 		val := cloneIfStruct(itemElem.value)
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, arrElem.value)
+		}
 
 		switch t := arrElem.value.(type) {
 		case *stackitem.Array:
@@ -1269,6 +1214,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		validateMapKey(key)
 
 		obj := v.estack.Pop()
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, obj.value)
+		}
 
 		switch t := obj.value.(type) {
 		// Struct and Array items have their underlying value as []Item.
@@ -1318,6 +1266,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 
 	case opcode.REVERSEITEMS:
 		item := v.estack.Pop()
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, item.value)
+		}
 		switch t := item.value.(type) {
 		case *stackitem.Array, *stackitem.Struct:
 			if t.(stackitem.Immutable).IsReadOnly() {
@@ -1338,6 +1289,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 		validateMapKey(key)
 
 		elem := v.estack.Pop()
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, elem.value)
+		}
 		switch t := elem.value.(type) {
 		case *stackitem.Array:
 			a := t.Value().([]stackitem.Item)
@@ -1374,6 +1328,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 
 	case opcode.CLEARITEMS:
 		elem := v.estack.Pop()
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, elem.value)
+		}
 		switch t := elem.value.(type) {
 		case *stackitem.Array:
 			if t.IsReadOnly() {
@@ -1407,6 +1364,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 
 	case opcode.POPITEM:
 		arr := v.estack.Pop().Item()
+		if v.audit != nil {
+			v.audit.checkMutation(ctx, op, arr)
+		}
 		elems := arr.Value().([]stackitem.Item)
 		index := len(elems) - 1
 		elem := elems[index]
@@ -1507,6 +1467,9 @@ func (v *VM) execute(ctx *Context, op opcode.Opcode, parameter []byte) (err erro
 			rvcount := oldEstack.Len()
 			for i := rvcount; i > 0; i-- {
 				elem := oldEstack.RemoveAt(i - 1)
+				if v.audit != nil && oldCtx.sc != v.Context().sc {
+					v.audit.claim(elem.value, v.Context().sc, oldCtx.sc)
+				}
 				newEstack.Push(elem)
 			}
 			v.estack = newEstack
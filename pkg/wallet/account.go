@@ -22,6 +22,9 @@ type Account struct {
 	// NEO private key.
 	privateKey *keys.PrivateKey
 
+	// External signer used instead of the private key.
+	external ExternalSigner
+
 	// Script hash corresponding to the Address.
 	scriptHash util.Uint160
 
@@ -44,6 +47,10 @@ type Account struct {
 
 	// Indicates whether the account is the default change account.
 	Default bool `json:"isDefault"`
+
+	// Extra contains account metadata (tags, creation time, notes) along
+	// with any other NEP-6 extra data. This field can be nil.
+	Extra *AccountExtra `json:"extra,omitempty"`
 }
 
 // Contract represents a subset of the smartcontract to embed in the
@@ -147,10 +154,19 @@ func (a *Account) SignTx(net netmode.Magic, t *transaction.Transaction) error {
 	if len(a.Contract.Parameters) == 0 {
 		return nil
 	}
-	if a.privateKey == nil {
+	var sign []byte
+	switch {
+	case a.privateKey != nil:
+		sign = a.privateKey.SignHashable(uint32(net), t)
+	case a.external != nil:
+		var err error
+		sign, err = a.external.SignTx(net, t)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrExternalSigner, err)
+		}
+	default:
 		return errors.New("account key is not available (need to decrypt?)")
 	}
-	sign := a.privateKey.SignHashable(uint32(net), t)
 
 	invoc := append([]byte{byte(opcode.PUSHDATA1), keys.SignatureLen}, sign...)
 	if len(a.Contract.Parameters) == 1 {
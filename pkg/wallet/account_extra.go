@@ -0,0 +1,164 @@
+package wallet
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
+
+// AccountExtra is the NEP-6 account extra data. NeoGo uses it to store
+// user-defined account metadata, all other fields (like the ones created by
+// other wallet implementations) are preserved as is. Extra data that is not a
+// JSON object is preserved as well unless some metadata is set for the
+// account (it's replaced then).
+type AccountExtra struct {
+	// Tags is a list of user-defined account tags like "cold".
+	Tags []string
+	// Created is the time the account was created at or added to the
+	// wallet, it's zero if unknown.
+	Created time.Time
+	// Notes is an arbitrary user-defined text.
+	Notes string
+
+	// other contains extra fields not known to NeoGo.
+	other map[string]json.RawMessage
+	// raw is the original extra data if it's not an object.
+	raw json.RawMessage
+}
+
+// accountExtraAux is used for (un)marshaling of the known AccountExtra
+// fields.
+type accountExtraAux struct {
+	Tags    []string   `json:"tags,omitempty"`
+	Created *time.Time `json:"created,omitempty"`
+	Notes   string     `json:"notes,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e AccountExtra) MarshalJSON() ([]byte, error) {
+	if e.raw != nil && len(e.Tags) == 0 && e.Created.IsZero() && e.Notes == "" {
+		return e.raw, nil
+	}
+	aux := accountExtraAux{
+		Tags:  e.Tags,
+		Notes: e.Notes,
+	}
+	if !e.Created.IsZero() {
+		created := e.Created.UTC()
+		aux.Created = &created
+	}
+	known, err := json.Marshal(aux)
+	if err != nil {
+		return nil, err
+	}
+	if len(e.other) == 0 {
+		return known, nil
+	}
+	var fields = make(map[string]json.RawMessage, len(e.other)+3)
+	for k, v := range e.other {
+		fields[k] = v
+	}
+	// Known fields take precedence over the preserved ones with the same
+	// name (but unsupported type).
+	if err := json.Unmarshal(known, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface. Fields with known
+// names, but unexpected types are preserved as unknown ones.
+func (e *AccountExtra) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	*e = AccountExtra{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		if !json.Valid(data) {
+			return err
+		}
+		e.raw = bytes.Clone(data)
+		return nil
+	}
+	for k, v := range fields {
+		var (
+			known = true
+			err   error
+		)
+		switch k {
+		case "tags":
+			err = json.Unmarshal(v, &e.Tags)
+		case "created":
+			err = json.Unmarshal(v, &e.Created)
+		case "notes":
+			err = json.Unmarshal(v, &e.Notes)
+		default:
+			known = false
+		}
+		if !known || err != nil || bytes.Equal(v, []byte("null")) {
+			if e.other == nil {
+				e.other = make(map[string]json.RawMessage)
+			}
+			e.other[k] = v
+		}
+	}
+	return nil
+}
+
+// HasTag checks whether the account has the given tag.
+func (a *Account) HasTag(tag string) bool {
+	if a.Extra == nil {
+		return false
+	}
+	for _, t := range a.Extra.Tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTag adds the given tag to the account if it doesn't have it yet.
+func (a *Account) AddTag(tag string) {
+	if a.HasTag(tag) {
+		return
+	}
+	a.ensureExtra()
+	a.Extra.Tags = append(a.Extra.Tags, tag)
+}
+
+// RemoveTag removes the given tag from the account. It returns false if the
+// account doesn't have this tag.
+func (a *Account) RemoveTag(tag string) bool {
+	if !a.HasTag(tag) {
+		return false
+	}
+	tags := a.Extra.Tags[:0]
+	for _, t := range a.Extra.Tags {
+		if t != tag {
+			tags = append(tags, t)
+		}
+	}
+	if len(tags) == 0 {
+		tags = nil
+	}
+	a.Extra.Tags = tags
+	return true
+}
+
+// SetNotes sets the account notes.
+func (a *Account) SetNotes(notes string) {
+	a.ensureExtra()
+	a.Extra.Notes = notes
+}
+
+// SetCreated sets the account creation time (with the precision of
+// seconds, as it's stored in the wallet).
+func (a *Account) SetCreated(t time.Time) {
+	a.ensureExtra()
+	a.Extra.Created = t.UTC().Truncate(time.Second)
+}
+
+func (a *Account) ensureExtra() {
+	if a.Extra == nil {
+		a.Extra = new(AccountExtra)
+	}
+}
@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// csharpWallet is a wallet as it's created by C# node with some extra
+// account data added by other applications.
+const csharpWallet = `{
+  "name": "wallet",
+  "version": "1.0",
+  "scrypt": {"n": 16384, "r": 8, "p": 8},
+  "accounts": [
+    {
+      "address": "Nhfg3TbpwogLvDGVvAvqyThbsHgoSUKwtn",
+      "label": null,
+      "isDefault": false,
+      "lock": false,
+      "key": "6PYM8VdX3hY4B51UJxmm8D41RQMbpJT8aYHibyQ67gjkUPmvQgu51Y5UQR",
+      "contract": {
+        "script": "DCECs2Ir9AF73+MXxYrtX0x1PyBrfbiWBG+n13S7xL9/jcJBVuezJw==",
+        "parameters": [{"name": "signature", "type": "Signature"}],
+        "deployed": false
+      },
+      "extra": null
+    },
+    {
+      "address": "NVTiAjNgagDkTr5HTzDmQP9kPwPHN5BgVq",
+      "label": "multi",
+      "isDefault": false,
+      "lock": false,
+      "key": null,
+      "contract": null,
+      "extra": {"app": {"id": 42, "flags": [true, null]}, "tags": "not a list", "notes": null}
+    },
+    {
+      "address": "NUVPACMnKFhpuHjsRjhUvXz1XhqfGZYVtY",
+      "label": null,
+      "isDefault": true,
+      "lock": false,
+      "key": null,
+      "contract": null,
+      "extra": "some string"
+    }
+  ],
+  "extra": null
+}`
+
+func TestAccountExtra_CSharpRoundTrip(t *testing.T) {
+	w, err := NewWalletFromBytes([]byte(csharpWallet))
+	require.NoError(t, err)
+	require.Equal(t, 3, len(w.Accounts))
+	require.Nil(t, w.Accounts[0].Extra)
+	require.NotNil(t, w.Accounts[1].Extra)
+	require.Nil(t, w.Accounts[1].Extra.Tags)
+	require.Equal(t, "", w.Accounts[1].Extra.Notes)
+	require.NotNil(t, w.Accounts[2].Extra)
+
+	checkExtras := func(t *testing.T, w *Wallet) {
+		var expected, actual struct {
+			Accounts []struct {
+				Extra json.RawMessage `json:"extra"`
+			} `json:"accounts"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(csharpWallet), &expected))
+		data, err := w.JSON()
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(data, &actual))
+		require.Equal(t, len(expected.Accounts), len(actual.Accounts))
+		require.Nil(t, actual.Accounts[0].Extra) // Null extra is omitted.
+		for i := 1; i < len(expected.Accounts); i++ {
+			require.JSONEq(t, string(expected.Accounts[i].Extra), string(actual.Accounts[i].Extra), i)
+		}
+	}
+	checkExtras(t, w)
+
+	// Metadata changes don't affect unknown data.
+	w.Accounts[1].AddTag("cold")
+	w.Accounts[1].SetNotes("deposit")
+	data, err := w.JSON()
+	require.NoError(t, err)
+	w2, err := NewWalletFromBytes(data)
+	require.NoError(t, err)
+	require.Equal(t, []string{"cold"}, w2.Accounts[1].Extra.Tags)
+	require.Equal(t, "deposit", w2.Accounts[1].Extra.Notes)
+	extra, err := json.Marshal(w2.Accounts[1].Extra)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app": {"id": 42, "flags": [true, null]}, "tags": ["cold"], "notes": "deposit"}`, string(extra))
+
+	// Known fields replace unsupported values with the same name.
+	require.True(t, w2.Accounts[1].RemoveTag("cold"))
+	w2.Accounts[1].SetNotes("")
+	extra, err = json.Marshal(w2.Accounts[1].Extra)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"app": {"id": 42, "flags": [true, null]}}`, string(extra))
+}
+
+func TestAccountExtra_JSON(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	acc := &Account{Address: "NVTiAjNgagDkTr5HTzDmQP9kPwPHN5BgVq"}
+	acc.AddTag("cold")
+	acc.AddTag("deposit-42")
+	acc.SetCreated(created.Add(500 * time.Millisecond).In(time.FixedZone("UTC+3", 3*3600)))
+	acc.SetNotes("hardware")
+
+	data, err := json.Marshal(acc.Extra)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"tags": ["cold", "deposit-42"], "created": "2024-01-02T03:04:05Z", "notes": "hardware"}`, string(data))
+
+	actual := new(AccountExtra)
+	require.NoError(t, json.Unmarshal(data, actual))
+	require.Equal(t, acc.Extra, actual)
+
+	for _, js := range []string{`42`, `[1, "a"]`, `{}`, `{"created": "yesterday"}`} {
+		e := new(AccountExtra)
+		require.NoError(t, json.Unmarshal([]byte(js), e), js)
+		require.Equal(t, 0, len(e.Tags), js)
+		require.True(t, e.Created.IsZero(), js)
+		data, err := json.Marshal(e)
+		require.NoError(t, err)
+		require.JSONEq(t, js, string(data))
+	}
+	require.Error(t, json.Unmarshal([]byte(`{`), new(AccountExtra)))
+}
+
+func TestAccountTags(t *testing.T) {
+	acc := new(Account)
+	require.False(t, acc.HasTag("cold"))
+	require.False(t, acc.RemoveTag("cold"))
+	require.Nil(t, acc.Extra)
+
+	acc.AddTag("cold")
+	acc.AddTag("hot")
+	acc.AddTag("cold")
+	require.Equal(t, []string{"cold", "hot"}, acc.Extra.Tags)
+	require.True(t, acc.HasTag("hot"))
+
+	require.True(t, acc.RemoveTag("cold"))
+	require.False(t, acc.HasTag("cold"))
+	require.True(t, acc.RemoveTag("hot"))
+	require.Nil(t, acc.Extra.Tags)
+}
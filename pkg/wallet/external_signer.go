@@ -0,0 +1,47 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+)
+
+// ErrExternalSigner is returned from Account.SignTx (wrapping the original
+// error) if the account's external signer fails to sign the transaction.
+var ErrExternalSigner = errors.New("external signer failed")
+
+// ExternalSigner is a key holder that can't export its private key, but can
+// sign transactions with it (like a hardware wallet). Signing may require
+// user interaction, so it can take a while and it can fail if user rejects
+// the request.
+type ExternalSigner interface {
+	// GetPublicKey returns the public key corresponding to the private key
+	// used for signing.
+	GetPublicKey() (*keys.PublicKey, error)
+	// SignTx returns a signature of the given transaction for the given
+	// network.
+	SignTx(net netmode.Magic, tx *transaction.Transaction) ([]byte, error)
+}
+
+// NewAccountFromExternalSigner creates a standard signature account for the key
+// held by the given external signer. SignTx of this account uses the signer to
+// create signatures, but it can't sign anything else, so CanSign returns false
+// for it and it's not supposed to be saved into a wallet.
+func NewAccountFromExternalSigner(s ExternalSigner) (*Account, error) {
+	pub, err := s.GetPublicKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+	return &Account{
+		external:   s,
+		scriptHash: pub.GetScriptHash(),
+		Address:    pub.Address(),
+		Contract: &Contract{
+			Script:     pub.GetVerificationScript(),
+			Parameters: getContractParams(1),
+		},
+	}, nil
+}
@@ -0,0 +1,66 @@
+package wallet
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/stretchr/testify/require"
+)
+
+type testSigner struct {
+	priv *keys.PrivateKey
+	err  error
+}
+
+func (s *testSigner) GetPublicKey() (*keys.PublicKey, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.priv.PublicKey(), nil
+}
+
+func (s *testSigner) SignTx(net netmode.Magic, tx *transaction.Transaction) ([]byte, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.priv.SignHashable(uint32(net), tx), nil
+}
+
+func TestNewAccountFromExternalSigner(t *testing.T) {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	s := &testSigner{priv: priv}
+
+	s.err = errors.New("no device")
+	_, err = NewAccountFromExternalSigner(s)
+	require.ErrorIs(t, err, s.err)
+
+	s.err = nil
+	acc, err := NewAccountFromExternalSigner(s)
+	require.NoError(t, err)
+	require.Equal(t, priv.Address(), acc.Address)
+	require.Equal(t, priv.GetScriptHash(), acc.ScriptHash())
+	require.Equal(t, priv.PublicKey().GetVerificationScript(), acc.Contract.Script)
+	require.False(t, acc.CanSign())
+	require.Nil(t, acc.PrivateKey())
+
+	tx := &transaction.Transaction{
+		Script: []byte{1, 2, 3},
+		Signers: []transaction.Signer{{
+			Account: acc.ScriptHash(),
+			Scopes:  transaction.CalledByEntry,
+		}},
+	}
+	require.NoError(t, acc.SignTx(netmode.UnitTestNet, tx))
+	require.Equal(t, 1, len(tx.Scripts))
+	require.Equal(t, 66, len(tx.Scripts[0].InvocationScript))
+	require.True(t, priv.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.UnitTestNet), tx))
+
+	s.err = errors.New("rejected")
+	err = acc.SignTx(netmode.UnitTestNet, tx)
+	require.ErrorIs(t, err, s.err)
+	require.ErrorIs(t, err, ErrExternalSigner)
+}
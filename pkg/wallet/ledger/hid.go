@@ -0,0 +1,43 @@
+//go:build ledger
+
+package ledger
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/karalabe/hid"
+)
+
+// ledgerUsagePage is the HID usage page of Ledger APDU interface.
+const ledgerUsagePage = 0xffa0
+
+// ErrNotSupported is returned from OpenHID if HID is not supported on this
+// platform (or NeoGo is built without cgo).
+var ErrNotSupported = errors.New("HID is not supported on this platform, Ledger can't be used")
+
+// ErrNoDevice is returned from OpenHID if no Ledger device is found.
+var ErrNoDevice = errors.New("no Ledger device found, make sure it's connected and unlocked (on Linux udev rules allowing access to it may be needed)")
+
+// OpenHID opens the first Ledger device found.
+func OpenHID() (Transport, error) {
+	if !hid.Supported() {
+		return nil, ErrNotSupported
+	}
+	infos, err := hid.Enumerate(VendorID, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enumerate HID devices: %w", err)
+	}
+	for _, info := range infos {
+		// Different platforms report either usage page or interface number.
+		if info.UsagePage != ledgerUsagePage && info.Interface != 0 {
+			continue
+		}
+		dev, err := info.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s (check device permissions): %w", info.Product, err)
+		}
+		return newHIDTransport(dev), nil
+	}
+	return nil, ErrNoDevice
+}
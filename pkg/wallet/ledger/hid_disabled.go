@@ -0,0 +1,15 @@
+//go:build !ledger
+
+package ledger
+
+import "errors"
+
+// ErrNotSupported is returned from OpenHID if NeoGo is built without Ledger
+// support.
+var ErrNotSupported = errors.New("no Ledger support in this build, rebuild NeoGo with `ledger` build tag")
+
+// OpenHID opens the first Ledger device found. This build has no HID support,
+// so it always returns ErrNotSupported.
+func OpenHID() (Transport, error) {
+	return nil, ErrNotSupported
+}
@@ -0,0 +1,102 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Ledger HID framing constants.
+const (
+	hidChannel    = 0x0101
+	hidTag        = 0x05
+	hidPacketSize = 64
+	// hidHeaderSize is the size of channel, tag and sequence number.
+	hidHeaderSize = 5
+)
+
+// VendorID is the USB vendor ID of Ledger devices.
+const VendorID = 0x2c97
+
+// hidTransport implements Transport over HID device using Ledger framing.
+type hidTransport struct {
+	dev io.ReadWriteCloser
+}
+
+// newHIDTransport creates Transport for the given opened HID device.
+func newHIDTransport(dev io.ReadWriteCloser) *hidTransport {
+	return &hidTransport{dev: dev}
+}
+
+// Exchange implements the Transport interface.
+func (h *hidTransport) Exchange(apdu []byte) ([]byte, error) {
+	for _, p := range wrapAPDU(apdu) {
+		if _, err := h.dev.Write(p); err != nil {
+			return nil, fmt.Errorf("failed to write to device: %w", err)
+		}
+	}
+	return readAPDU(h.dev)
+}
+
+// Close implements the Transport interface.
+func (h *hidTransport) Close() error {
+	return h.dev.Close()
+}
+
+// wrapAPDU splits command APDU into HID packets.
+func wrapAPDU(apdu []byte) [][]byte {
+	var (
+		res  [][]byte
+		data = binary.BigEndian.AppendUint16(nil, uint16(len(apdu)))
+	)
+	data = append(data, apdu...)
+	for seq := 0; len(data) > 0; seq++ {
+		p := make([]byte, hidPacketSize)
+		binary.BigEndian.PutUint16(p, hidChannel)
+		p[2] = hidTag
+		binary.BigEndian.PutUint16(p[3:], uint16(seq))
+		n := copy(p[hidHeaderSize:], data)
+		data = data[n:]
+		res = append(res, p)
+	}
+	return res
+}
+
+// readAPDU reads response APDU from HID packets.
+func readAPDU(r io.Reader) ([]byte, error) {
+	var (
+		res  []byte
+		size = -1
+		p    = make([]byte, hidPacketSize)
+	)
+	for seq := 0; size < 0 || len(res) < size; seq++ {
+		n, err := r.Read(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read from device: %w", err)
+		}
+		if n < hidHeaderSize {
+			return nil, errors.New("invalid response packet")
+		}
+		if binary.BigEndian.Uint16(p) != hidChannel || p[2] != hidTag {
+			return nil, errors.New("invalid response packet header")
+		}
+		if int(binary.BigEndian.Uint16(p[3:])) != seq {
+			return nil, fmt.Errorf("unexpected response packet #%d", binary.BigEndian.Uint16(p[3:]))
+		}
+		data := p[hidHeaderSize:n]
+		if size < 0 {
+			if len(data) < 2 {
+				return nil, errors.New("invalid response packet")
+			}
+			size = int(binary.BigEndian.Uint16(data))
+			data = data[2:]
+			res = make([]byte, 0, size)
+		}
+		if rem := size - len(res); len(data) > rem {
+			data = data[:rem]
+		}
+		res = append(res, data...)
+	}
+	return res, nil
+}
@@ -0,0 +1,75 @@
+package ledger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeHID is a HID device returning prepared packets.
+type fakeHID struct {
+	written [][]byte
+	toRead  [][]byte
+}
+
+func (f *fakeHID) Write(p []byte) (int, error) {
+	f.written = append(f.written, bytes.Clone(p))
+	return len(p), nil
+}
+
+func (f *fakeHID) Read(p []byte) (int, error) {
+	if len(f.toRead) == 0 {
+		return 0, errors.New("nothing to read")
+	}
+	n := copy(p, f.toRead[0])
+	f.toRead = f.toRead[1:]
+	return n, nil
+}
+
+func (f *fakeHID) Close() error { return nil }
+
+func TestHIDFraming(t *testing.T) {
+	for _, n := range []int{0, 10, 57, 58, 59, 120, 300} {
+		data := make([]byte, n)
+		for i := range data {
+			data[i] = byte(i)
+		}
+		packets := wrapAPDU(data)
+		require.Equal(t, 1+(n+2-1)/(hidPacketSize-hidHeaderSize), len(packets), n)
+		for _, p := range packets {
+			require.Equal(t, hidPacketSize, len(p))
+		}
+		res, err := readAPDU(&fakeHID{toRead: packets})
+		require.NoError(t, err)
+		require.Equal(t, data, res)
+	}
+
+	t.Run("exchange", func(t *testing.T) {
+		dev := &fakeHID{toRead: wrapAPDU([]byte{1, 2, 0x90, 0x00})}
+		tr := newHIDTransport(dev)
+		res, err := tr.Exchange([]byte{CLA, InsGetVersion, 0, 0, 0})
+		require.NoError(t, err)
+		require.Equal(t, []byte{1, 2, 0x90, 0x00}, res)
+		require.Equal(t, 1, len(dev.written))
+		require.Equal(t, []byte{0x01, 0x01, 0x05, 0x00, 0x00, 0x00, 0x05, CLA, InsGetVersion}, dev.written[0][:9])
+		require.NoError(t, tr.Close())
+	})
+
+	t.Run("bad packets", func(t *testing.T) {
+		_, err := readAPDU(&fakeHID{})
+		require.Error(t, err)
+
+		packets := wrapAPDU(make([]byte, 100))
+		_, err = readAPDU(&fakeHID{toRead: packets[1:]})
+		require.ErrorContains(t, err, "unexpected response packet #1")
+
+		packets[0][2] = 0x06
+		_, err = readAPDU(&fakeHID{toRead: packets})
+		require.ErrorContains(t, err, "invalid response packet header")
+
+		_, err = readAPDU(&fakeHID{toRead: [][]byte{{1, 1}}})
+		require.ErrorContains(t, err, "invalid response packet")
+	})
+}
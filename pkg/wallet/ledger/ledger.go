@@ -0,0 +1,333 @@
+/*
+Package ledger implements signing with the NEO N3 application of Ledger
+hardware wallets.
+
+The device is accessed via [Transport], HID transport is only available if
+NeoGo is built with `ledger` build tag (it requires cgo), use [Open] to get a
+[Device] working with the first Ledger found. [Device] implements
+[wallet.ExternalSigner], so it can be used for [wallet.Account] creation with
+[wallet.NewAccountFromExternalSigner].
+*/
+package ledger
+
+import (
+	"crypto/elliptic"
+	"encoding/asn1"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+)
+
+// AppName is the name of the NEO N3 Ledger application.
+const AppName = "NEO N3"
+
+// MinAppVersion is the minimal supported NEO N3 application version.
+var MinAppVersion = AppVersion{Major: 1, Minor: 0, Patch: 0}
+
+// NEO N3 application APDU constants.
+const (
+	CLA = 0x80
+
+	InsGetAppName   = 0x00
+	InsGetVersion   = 0x01
+	InsSignTx       = 0x02
+	InsGetPublicKey = 0x04
+
+	// P1SignPath, P1SignMagic and subsequent P1 values are used for
+	// SIGN_TX chunks containing BIP32 path, network magic and transaction
+	// data respectively.
+	P1SignPath  = 0x00
+	P1SignMagic = 0x01
+	P1SignData  = 0x02
+	// P2More is set for all SIGN_TX chunks except the last one.
+	P2More = 0x80
+	P2Last = 0x00
+
+	// MaxChunkSize is the maximum APDU data size.
+	MaxChunkSize = 255
+)
+
+// Status words returned by the device.
+const (
+	SWOk               = 0x9000
+	SWDenied           = 0x6985
+	SWWrongP1P2        = 0x6A86
+	SWWrongDataLength  = 0x6A87
+	SWInsNotSupported  = 0x6D00
+	SWClaNotSupported  = 0x6E00
+	SWAppNotOpen       = 0x6E01
+	SWLocked           = 0x5515
+	SWSecurityNotValid = 0x6982
+	SWTxParsingFailed  = 0xB002
+	SWTxHashFailed     = 0xB003
+	SWBadState         = 0xB007
+	SWSignatureFailed  = 0xB008
+)
+
+var (
+	// ErrRejected is returned when the user rejects the request on the
+	// device.
+	ErrRejected = errors.New("request was rejected on the device")
+	// ErrAppNotOpen is returned when NEO N3 application is not open on the
+	// device.
+	ErrAppNotOpen = errors.New("NEO N3 app is not open on the device, please open it")
+	// ErrLocked is returned when the device is locked.
+	ErrLocked = errors.New("device is locked, please unlock it")
+)
+
+// Transport exchanges APDUs with the device.
+type Transport interface {
+	// Exchange sends the given command APDU to the device and returns the
+	// response APDU (data followed by two-byte status word).
+	Exchange(apdu []byte) ([]byte, error)
+	// Close releases the device.
+	Close() error
+}
+
+// AppVersion is a NEO N3 application version.
+type AppVersion struct {
+	Major byte
+	Minor byte
+	Patch byte
+}
+
+// StatusError is an unexpected device status word.
+type StatusError uint16
+
+// Device is a NEO N3 application of a Ledger device using a key with the
+// specified BIP32 derivation path.
+type Device struct {
+	t       Transport
+	path    []uint32
+	version AppVersion
+	pub     *keys.PublicKey
+}
+
+var _ wallet.ExternalSigner = (*Device)(nil)
+
+// String implements the fmt.Stringer interface.
+func (v AppVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// Less returns true if v is older than o.
+func (v AppVersion) Less(o AppVersion) bool {
+	if v.Major != o.Major {
+		return v.Major < o.Major
+	}
+	if v.Minor != o.Minor {
+		return v.Minor < o.Minor
+	}
+	return v.Patch < o.Patch
+}
+
+// Error implements the error interface.
+func (e StatusError) Error() string {
+	switch e {
+	case SWDenied:
+		return ErrRejected.Error()
+	case SWInsNotSupported, SWClaNotSupported, SWAppNotOpen:
+		return ErrAppNotOpen.Error()
+	case SWLocked, SWSecurityNotValid:
+		return ErrLocked.Error()
+	case SWTxParsingFailed:
+		return "device can't parse the transaction (is NEO N3 app up to date?)"
+	default:
+		return fmt.Sprintf("device returned unexpected status 0x%04X", uint16(e))
+	}
+}
+
+// Is implements errors.Is interface, so that status errors can be compared
+// with ErrRejected, ErrAppNotOpen and ErrLocked.
+func (e StatusError) Is(target error) bool {
+	switch target {
+	case ErrRejected:
+		return e == SWDenied
+	case ErrAppNotOpen:
+		return e == SWInsNotSupported || e == SWClaNotSupported || e == SWAppNotOpen
+	case ErrLocked:
+		return e == SWLocked || e == SWSecurityNotValid
+	}
+	return false
+}
+
+// Open opens the first Ledger device found via HID and checks its NEO N3
+// application (see New). It returns an error if NeoGo is built without Ledger
+// support.
+func Open(path string) (*Device, error) {
+	t, err := OpenHID()
+	if err != nil {
+		return nil, err
+	}
+	d, err := New(t, path)
+	if err != nil {
+		_ = t.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// New creates a Device using the given transport and BIP32 key derivation
+// path (DefaultPath is used if it's empty). It checks that NEO N3 application
+// is open and its version is supported.
+func New(t Transport, path string) (*Device, error) {
+	if path == "" {
+		path = DefaultPath
+	}
+	p, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	d := &Device{t: t, path: p}
+	name, err := d.exchange(InsGetAppName, 0, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app name: %w", err)
+	}
+	if string(name) != AppName {
+		return nil, fmt.Errorf("%q app is open on the device, please open %s app", name, AppName)
+	}
+	ver, err := d.exchange(InsGetVersion, 0, 0, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get app version: %w", err)
+	}
+	if len(ver) != 3 {
+		return nil, fmt.Errorf("invalid app version response length %d", len(ver))
+	}
+	d.version = AppVersion{Major: ver[0], Minor: ver[1], Patch: ver[2]}
+	if d.version.Less(MinAppVersion) {
+		return nil, fmt.Errorf("%s app version %s is not supported, please update it to %s or later via Ledger Live",
+			AppName, d.version, MinAppVersion)
+	}
+	return d, nil
+}
+
+// Version returns the version of NEO N3 application.
+func (d *Device) Version() AppVersion {
+	return d.version
+}
+
+// Close releases the device.
+func (d *Device) Close() error {
+	return d.t.Close()
+}
+
+// GetPublicKey implements the wallet.ExternalSigner interface. The key is
+// requested from the device once and then cached.
+func (d *Device) GetPublicKey() (*keys.PublicKey, error) {
+	if d.pub != nil {
+		return d.pub, nil
+	}
+	res, err := d.exchange(InsGetPublicKey, 0, 0, encodePath(d.path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get public key: %w", err)
+	}
+	pub, err := keys.NewPublicKeyFromBytes(res, elliptic.P256())
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key returned by the device: %w", err)
+	}
+	d.pub = pub
+	return pub, nil
+}
+
+// SignTx implements the wallet.ExternalSigner interface. It sends the given
+// transaction to the device and waits for the user to confirm signing.
+func (d *Device) SignTx(net netmode.Magic, tx *transaction.Transaction) ([]byte, error) {
+	data, err := tx.EncodeHashableFields()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode transaction: %w", err)
+	}
+	if len(data) > (0xff-P1SignData+1)*MaxChunkSize {
+		return nil, fmt.Errorf("transaction is too big (%d bytes) to be signed by the device", len(data))
+	}
+	_, err = d.exchange(InsSignTx, P1SignPath, P2More, encodePath(d.path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to send key path: %w", err)
+	}
+	magic := make([]byte, 4)
+	binary.LittleEndian.PutUint32(magic, uint32(net))
+	_, err = d.exchange(InsSignTx, P1SignMagic, P2More, magic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send network magic: %w", err)
+	}
+	var res []byte
+	for i, p1 := 0, byte(P1SignData); i < len(data); i, p1 = i+MaxChunkSize, p1+1 {
+		var (
+			end = i + MaxChunkSize
+			p2  = byte(P2More)
+		)
+		if end >= len(data) {
+			end = len(data)
+			p2 = P2Last
+		}
+		res, err = d.exchange(InsSignTx, p1, p2, data[i:end])
+		if err != nil {
+			return nil, err
+		}
+	}
+	sig, err := decodeSignature(res)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature returned by the device: %w", err)
+	}
+	return sig, nil
+}
+
+// exchange sends a command and returns response data if the device returned
+// successful status.
+func (d *Device) exchange(ins, p1, p2 byte, data []byte) ([]byte, error) {
+	apdu := append([]byte{CLA, ins, p1, p2, byte(len(data))}, data...)
+	res, err := d.t.Exchange(apdu)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 2 {
+		return nil, fmt.Errorf("invalid response length %d", len(res))
+	}
+	sw := binary.BigEndian.Uint16(res[len(res)-2:])
+	if sw != SWOk {
+		return nil, StatusError(sw)
+	}
+	return res[:len(res)-2], nil
+}
+
+// ecdsaSignature is an ASN.1 DER ECDSA signature.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// decodeSignature converts DER signature returned by the device into the
+// 64-byte r||s form used by Neo.
+func decodeSignature(der []byte) ([]byte, error) {
+	var sig ecdsaSignature
+	rest, err := asn1.Unmarshal(der, &sig)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after signature")
+	}
+	if sig.R.Sign() <= 0 || sig.S.Sign() <= 0 || sig.R.BitLen() > 256 || sig.S.BitLen() > 256 {
+		return nil, errors.New("signature values are out of range")
+	}
+	res := make([]byte, keys.SignatureLen)
+	sig.R.FillBytes(res[:keys.SignatureLen/2])
+	sig.S.FillBytes(res[keys.SignatureLen/2:])
+	return res, nil
+}
+
+// EncodeSignature converts 64-byte r||s signature into DER form returned by
+// the device, it's mostly useful for device emulation.
+func EncodeSignature(sig []byte) ([]byte, error) {
+	if len(sig) != keys.SignatureLen {
+		return nil, fmt.Errorf("invalid signature length %d", len(sig))
+	}
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(sig[:keys.SignatureLen/2]),
+		S: new(big.Int).SetBytes(sig[keys.SignatureLen/2:]),
+	})
+}
@@ -0,0 +1,136 @@
+package ledger_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/nspcc-dev/neo-go/internal/fakeledger"
+	"github.com/nspcc-dev/neo-go/pkg/config/netmode"
+	"github.com/nspcc-dev/neo-go/pkg/core/transaction"
+	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
+	"github.com/nspcc-dev/neo-go/pkg/wallet"
+	"github.com/nspcc-dev/neo-go/pkg/wallet/ledger"
+	"github.com/stretchr/testify/require"
+)
+
+func newApp(t *testing.T) *fakeledger.App {
+	priv, err := keys.NewPrivateKey()
+	require.NoError(t, err)
+	return fakeledger.New(priv)
+}
+
+func TestNew(t *testing.T) {
+	t.Run("good", func(t *testing.T) {
+		app := newApp(t)
+		app.Version = ledger.AppVersion{Major: 1, Minor: 2, Patch: 3}
+		d, err := ledger.New(app, "")
+		require.NoError(t, err)
+		require.Equal(t, "1.2.3", d.Version().String())
+		require.NoError(t, d.Close())
+		require.True(t, app.Closed)
+	})
+	t.Run("locked", func(t *testing.T) {
+		app := newApp(t)
+		app.Locked = true
+		_, err := ledger.New(app, "")
+		require.ErrorIs(t, err, ledger.ErrLocked)
+		require.ErrorContains(t, err, "unlock")
+	})
+	t.Run("wrong app", func(t *testing.T) {
+		app := newApp(t)
+		app.Name = "Bitcoin"
+		_, err := ledger.New(app, "")
+		require.ErrorContains(t, err, `"Bitcoin" app is open on the device, please open NEO N3 app`)
+	})
+	t.Run("old version", func(t *testing.T) {
+		app := newApp(t)
+		app.Version = ledger.AppVersion{Major: 0, Minor: 9, Patch: 9}
+		_, err := ledger.New(app, "")
+		require.ErrorContains(t, err, "version 0.9.9 is not supported, please update it to 1.0.0 or later")
+	})
+	t.Run("bad path", func(t *testing.T) {
+		_, err := ledger.New(newApp(t), "44'/888'")
+		require.ErrorContains(t, err, "invalid BIP32 path")
+	})
+}
+
+func TestParsePath(t *testing.T) {
+	p, err := ledger.ParsePath(ledger.DefaultPath)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0x8000002c, 0x80000378, 0x80000000, 0, 0}, p)
+
+	p, err = ledger.ParsePath("m/44h/888h/1h/0/5")
+	require.NoError(t, err)
+	require.Equal(t, []uint32{0x8000002c, 0x80000378, 0x80000001, 0, 5}, p)
+
+	for _, s := range []string{"", "m", "m/", "x/44'", "m/a", "m/2147483648", "m/1'/2'/3'/4'/5'/6'/7'/8'/9'/10'/11'"} {
+		_, err = ledger.ParsePath(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestDevice_GetPublicKey(t *testing.T) {
+	app := newApp(t)
+	d, err := ledger.New(app, "m/44'/888'/1'/0/0")
+	require.NoError(t, err)
+	pub, err := d.GetPublicKey()
+	require.NoError(t, err)
+	require.Equal(t, app.Key.PublicKey(), pub)
+	require.Equal(t, "8000002c80000378800000010000000000000000", hex.EncodeToString(app.Path()))
+
+	app.Locked = true
+	pub, err = d.GetPublicKey() // Cached.
+	require.NoError(t, err)
+	require.Equal(t, app.Key.PublicKey(), pub)
+}
+
+func TestDevice_SignTx(t *testing.T) {
+	app := newApp(t)
+	d, err := ledger.New(app, "")
+	require.NoError(t, err)
+	acc, err := wallet.NewAccountFromExternalSigner(d)
+	require.NoError(t, err)
+	require.Equal(t, app.Key.Address(), acc.Address)
+
+	newTx := func(scriptLen int) *transaction.Transaction {
+		return &transaction.Transaction{
+			Script:          make([]byte, scriptLen),
+			ValidUntilBlock: 100,
+			Signers: []transaction.Signer{{
+				Account: acc.ScriptHash(),
+				Scopes:  transaction.CalledByEntry,
+			}},
+		}
+	}
+	// Single chunk and multiple chunks.
+	for _, n := range []int{1, 1000} {
+		tx := newTx(n)
+		require.NoError(t, acc.SignTx(netmode.TestNet, tx))
+		require.Equal(t, 66, len(tx.Scripts[0].InvocationScript))
+		require.True(t, app.Key.PublicKey().VerifyHashable(tx.Scripts[0].InvocationScript[2:], uint32(netmode.TestNet), tx))
+	}
+	require.Equal(t, 2, app.Signed)
+
+	t.Run("rejected", func(t *testing.T) {
+		app.Reject = true
+		defer func() { app.Reject = false }()
+		err := acc.SignTx(netmode.TestNet, newTx(1))
+		require.ErrorIs(t, err, ledger.ErrRejected)
+		require.ErrorContains(t, err, "rejected on the device")
+	})
+	t.Run("app closed", func(t *testing.T) {
+		app.Name = "Ethereum"
+		defer func() { app.Name = ledger.AppName }()
+		_, err := d.SignTx(netmode.TestNet, newTx(1))
+		require.ErrorIs(t, err, ledger.ErrAppNotOpen)
+	})
+	t.Run("too big", func(t *testing.T) {
+		_, err := d.SignTx(netmode.TestNet, newTx(70000))
+		require.ErrorContains(t, err, "too big")
+	})
+}
+
+func TestSignatureEncoding(t *testing.T) {
+	_, err := ledger.EncodeSignature([]byte{1, 2, 3})
+	require.Error(t, err)
+}
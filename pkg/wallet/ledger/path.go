@@ -0,0 +1,55 @@
+package ledger
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultPath is the default BIP32 key derivation path used by NEO N3
+// application (coin type 888).
+const DefaultPath = "m/44'/888'/0'/0/0"
+
+// hardened is the BIP32 hardened derivation flag.
+const hardened = 0x80000000
+
+// maxPathDepth is the maximum BIP32 path length supported by the device.
+const maxPathDepth = 10
+
+// ParsePath parses BIP32 derivation path like "m/44'/888'/0'/0/0" (both ' and h
+// can be used to mark hardened elements).
+func ParsePath(s string) ([]uint32, error) {
+	elems := strings.Split(s, "/")
+	if len(elems) < 2 || elems[0] != "m" {
+		return nil, fmt.Errorf("invalid BIP32 path %q: should start with m/", s)
+	}
+	elems = elems[1:]
+	if len(elems) > maxPathDepth {
+		return nil, fmt.Errorf("invalid BIP32 path %q: too many elements", s)
+	}
+	res := make([]uint32, len(elems))
+	for i, e := range elems {
+		var flag uint32
+		if strings.HasSuffix(e, "'") || strings.HasSuffix(e, "h") {
+			flag = hardened
+			e = e[:len(e)-1]
+		}
+		n, err := strconv.ParseUint(e, 10, 32)
+		if err != nil || n >= hardened {
+			return nil, fmt.Errorf("invalid BIP32 path %q: bad element #%d", s, i+1)
+		}
+		res[i] = uint32(n) | flag
+	}
+	return res, nil
+}
+
+// encodePath serializes BIP32 path in the form expected by the device
+// (big-endian uint32 elements).
+func encodePath(p []uint32) []byte {
+	res := make([]byte, 4*len(p))
+	for i := range p {
+		binary.BigEndian.PutUint32(res[4*i:], p[i])
+	}
+	return res
+}
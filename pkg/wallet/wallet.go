@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/crypto/keys"
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
@@ -119,6 +120,7 @@ func (w *Wallet) CreateAccount(name, passphrase string) error {
 		return err
 	}
 	acc.Label = name
+	acc.SetCreated(time.Now())
 	if err := acc.Encrypt(passphrase, w.Scrypt); err != nil {
 		return err
 	}
@@ -144,6 +146,21 @@ func (w *Wallet) RemoveAccount(addr string) error {
 	return errors.New("account wasn't found")
 }
 
+// FindAccounts returns all wallet accounts satisfying the given predicate
+// in the order they're stored in the wallet. For example, accounts with the
+// given tag can be found with
+//
+//	w.FindAccounts(func(a *Account) bool { return a.HasTag("cold") })
+func (w *Wallet) FindAccounts(f func(*Account) bool) []*Account {
+	var res []*Account
+	for _, acc := range w.Accounts {
+		if f(acc) {
+			res = append(res, acc)
+		}
+	}
+	return res
+}
+
 // AddToken adds a new token to a wallet.
 func (w *Wallet) AddToken(tok *Token) {
 	w.Extra.Tokens = append(w.Extra.Tokens, tok)
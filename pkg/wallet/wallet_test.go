@@ -5,6 +5,7 @@ import (
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/nspcc-dev/neo-go/pkg/encoding/address"
 	"github.com/nspcc-dev/neo-go/pkg/smartcontract/manifest"
@@ -185,6 +186,33 @@ func TestWallet_GetAccount(t *testing.T) {
 	}
 }
 
+func TestWallet_FindAccounts(t *testing.T) {
+	w, err := NewWalletFromFile("testdata/wallet2.json")
+	require.NoError(t, err)
+	require.Nil(t, w.FindAccounts(func(a *Account) bool { return a.HasTag("cold") }))
+	require.Equal(t, w.Accounts, w.FindAccounts(func(*Account) bool { return true }))
+
+	w.Accounts[0].AddTag("cold")
+	w.Accounts[2].AddTag("cold")
+	w.Accounts[2].AddTag("deposit")
+	require.Equal(t, []*Account{w.Accounts[0], w.Accounts[2]}, w.FindAccounts(func(a *Account) bool { return a.HasTag("cold") }))
+	require.Equal(t, []*Account{w.Accounts[2]}, w.FindAccounts(func(a *Account) bool { return a.HasTag("deposit") }))
+}
+
+func TestCreateAccount_Created(t *testing.T) {
+	w := NewInMemoryWallet()
+	w.SetPath(filepath.Join(t.TempDir(), "wallet.json"))
+	before := time.Now().Truncate(time.Second)
+	require.NoError(t, w.CreateAccount("acc", "pass"))
+	require.NotNil(t, w.Accounts[0].Extra)
+	require.False(t, w.Accounts[0].Extra.Created.Before(before))
+	require.False(t, w.Accounts[0].Extra.Created.After(time.Now()))
+
+	w2, err := NewWalletFromFile(w.Path())
+	require.NoError(t, err)
+	require.Equal(t, w.Accounts[0].Extra, w2.Accounts[0].Extra)
+}
+
 func TestWalletGetChangeAddress(t *testing.T) {
 	w1, err := NewWalletFromFile("testdata/wallet1.json")
 	require.NoError(t, err)